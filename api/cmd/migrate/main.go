@@ -1,22 +1,28 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
 func main() {
 	var migrationsPath string
 	var databaseURL string
+	var dryRun bool
 
 	flag.StringVar(&migrationsPath, "path", "migrations", "path to migrations folder")
 	flag.StringVar(&databaseURL, "database", "", "database connection string")
+	flag.BoolVar(&dryRun, "dry-run", false, "print the pending migrations and their SQL without running them (up only)")
 	flag.Parse()
 
 	if databaseURL == "" {
@@ -31,6 +37,9 @@ func main() {
 	if command == "" {
 		command = "up"
 	}
+	if dryRun && command != "up" {
+		log.Fatal("--dry-run is only supported for the up command")
+	}
 
 	m, err := migrate.New(
 		fmt.Sprintf("file://%s", migrationsPath),
@@ -43,6 +52,10 @@ func main() {
 
 	switch command {
 	case "up":
+		if dryRun {
+			previewUp(m, migrationsPath)
+			return
+		}
 		if err := m.Up(); err != nil && err != migrate.ErrNoChange {
 			log.Fatalf("Migration up failed: %v", err)
 		}
@@ -81,6 +94,26 @@ func main() {
 		}
 		fmt.Printf("Forced to version %d\n", v)
 
+	case "steps":
+		n := parseIntArg("Step count required for steps command")
+		if n == 0 {
+			log.Fatal("Step count must not be zero")
+		}
+		if err := m.Steps(n); err != nil && err != migrate.ErrNoChange {
+			log.Fatalf("Steps failed: %v", err)
+		}
+		printVersion(m)
+
+	case "goto":
+		v := parseIntArg("Version number required for goto command")
+		if v < 1 {
+			log.Fatal("Version number must be positive")
+		}
+		if err := m.Migrate(uint(v)); err != nil && err != migrate.ErrNoChange {
+			log.Fatalf("Goto failed: %v", err)
+		}
+		printVersion(m)
+
 	default:
 		fmt.Println("Usage: migrate [flags] <command>")
 		fmt.Println("")
@@ -90,8 +123,98 @@ func main() {
 		fmt.Println("  drop     Drop everything in database")
 		fmt.Println("  version  Show current migration version")
 		fmt.Println("  force N  Force set version to N")
+		fmt.Println("  steps N  Apply the next N migrations, or roll back N if negative")
+		fmt.Println("  goto V   Migrate up or down to version V")
 		fmt.Println("")
 		fmt.Println("Flags:")
 		flag.PrintDefaults()
 	}
 }
+
+// parseIntArg reads the command's numeric argument
+func parseIntArg(missing string) int {
+	arg := flag.Arg(1)
+	if arg == "" {
+		log.Fatal(missing)
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		log.Fatalf("Invalid number %q", arg)
+	}
+	return n
+}
+
+func printVersion(m *migrate.Migrate) {
+	version, dirty, err := m.Version()
+	if err == migrate.ErrNilVersion {
+		fmt.Println("No migrations applied")
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to get version: %v", err)
+	}
+	fmt.Printf("Now at version %d, Dirty: %v\n", version, dirty)
+}
+
+// previewUp prints the migrations up would apply, with their SQL, without
+// running anything. The files are read through the source driver and the
+// database is only asked for its current version.
+func previewUp(m *migrate.Migrate, migrationsPath string) {
+	current, dirty, err := m.Version()
+	hasVersion := true
+	if errors.Is(err, migrate.ErrNilVersion) {
+		hasVersion = false
+	} else if err != nil {
+		log.Fatalf("Failed to get version: %v", err)
+	}
+	if dirty {
+		log.Fatalf("Database is dirty at version %d; fix it and force the version before migrating", current)
+	}
+
+	src, err := source.Open(fmt.Sprintf("file://%s", migrationsPath))
+	if err != nil {
+		log.Fatalf("Failed to open migrations: %v", err)
+	}
+	defer src.Close()
+
+	var pending []uint
+	v, err := src.First()
+	for err == nil {
+		if !hasVersion || v > current {
+			pending = append(pending, v)
+		}
+		v, err = src.Next(v)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		log.Fatalf("Failed to read migrations: %v", err)
+	}
+
+	if hasVersion {
+		fmt.Printf("Current version: %d\n", current)
+	} else {
+		fmt.Println("No migrations applied")
+	}
+	if len(pending) == 0 {
+		fmt.Println("No pending migrations")
+		return
+	}
+
+	fmt.Printf("%d pending migration(s):\n", len(pending))
+	for _, v := range pending {
+		body, identifier, err := src.ReadUp(v)
+		if errors.Is(err, os.ErrNotExist) {
+			// A version with only a down file is skipped by up
+			continue
+		}
+		if err != nil {
+			log.Fatalf("Failed to read migration %d: %v", v, err)
+		}
+
+		fmt.Printf("\n-- ===== %d %s =====\n", v, identifier)
+		_, err = io.Copy(os.Stdout, body)
+		body.Close()
+		if err != nil {
+			log.Fatalf("Failed to print migration %d: %v", v, err)
+		}
+	}
+}
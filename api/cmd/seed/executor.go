@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// executor is the subset of *pgx.Conn the seeders use, so a dry run can
+// swap in an implementation that prints writes instead of running them.
+type executor interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// dryRunExecutor prints every write statement with its arguments and never
+// sends it to the database. Reads still hit the connection so lookups of
+// existing rows reflect the real state; rows the dry run would have inserted
+// are not found, so a dry run of a later section stops where the real run
+// would need an earlier one first.
+type dryRunExecutor struct {
+	conn *pgx.Conn
+}
+
+func newDryRunExecutor(conn *pgx.Conn) *dryRunExecutor {
+	return &dryRunExecutor{conn: conn}
+}
+
+func (e *dryRunExecutor) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	printStatement(sql, args)
+	return pgconn.NewCommandTag("DRY RUN"), nil
+}
+
+func (e *dryRunExecutor) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if isWrite(sql) {
+		printStatement(sql, args)
+		return placeholderRow{}
+	}
+	return e.conn.QueryRow(ctx, sql, args...)
+}
+
+func (e *dryRunExecutor) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if isWrite(sql) {
+		return nil, fmt.Errorf("dry run: multi-row writes are not supported")
+	}
+	return e.conn.Query(ctx, sql, args...)
+}
+
+// placeholderRow stands in for RETURNING results of statements that weren't run
+type placeholderRow struct{}
+
+func (placeholderRow) Scan(dest ...any) error {
+	for _, d := range dest {
+		switch v := d.(type) {
+		case *string:
+			*v = "<dry-run>"
+		case *bool:
+			*v = false
+		case *int:
+			*v = 0
+		case *int64:
+			*v = 0
+		default:
+			return fmt.Errorf("dry run: unsupported scan destination %T", d)
+		}
+	}
+	return nil
+}
+
+func isWrite(sql string) bool {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "INSERT", "UPDATE", "DELETE", "UPSERT":
+		return true
+	}
+	return false
+}
+
+func printStatement(sql string, args []any) {
+	fmt.Printf("  [dry-run] %s\n", strings.Join(strings.Fields(sql), " "))
+	if len(args) == 0 {
+		return
+	}
+
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		s := fmt.Sprintf("%v", arg)
+		if len(s) > 80 {
+			s = s[:77] + "..."
+		}
+		formatted[i] = fmt.Sprintf("$%d=%q", i+1, s)
+	}
+	fmt.Printf("            args: %s\n", strings.Join(formatted, ", "))
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -15,16 +16,28 @@ import (
 
 func main() {
 	var (
-		databaseURL string
-		email       string
-		password    string
-		name        string
+		databaseURL    string
+		email          string
+		password       string
+		name           string
+		dryRun         bool
+		rolesOnly      bool
+		categoriesOnly bool
+		tagsOnly       bool
+		articlesOnly   bool
+		force          bool
 	)
 
 	flag.StringVar(&databaseURL, "database", "", "Database URL")
 	flag.StringVar(&email, "email", "", "Admin email")
 	flag.StringVar(&password, "password", "", "Admin password")
 	flag.StringVar(&name, "name", "Admin", "Admin name")
+	flag.BoolVar(&dryRun, "dry-run", false, "Print the SQL that would run without writing anything")
+	flag.BoolVar(&rolesOnly, "roles-only", false, "Seed roles only")
+	flag.BoolVar(&categoriesOnly, "categories-only", false, "Seed categories only")
+	flag.BoolVar(&tagsOnly, "tags-only", false, "Seed tags only")
+	flag.BoolVar(&articlesOnly, "articles-only", false, "Seed articles only (requires an existing admin author)")
+	flag.BoolVar(&force, "force", false, "Update seeded articles that already exist instead of skipping them")
 	flag.Parse()
 
 	// Fall back to env vars
@@ -43,42 +56,104 @@ func main() {
 		}
 	}
 
+	// The -only flags can be combined; with none set everything is seeded
+	seedAll := !rolesOnly && !categoriesOnly && !tagsOnly && !articlesOnly
+
 	if databaseURL == "" {
 		log.Fatal("DATABASE_URL is required")
 	}
-	if email == "" {
+	if email == "" && (seedAll || articlesOnly) {
 		log.Fatal("ADMIN_EMAIL is required")
 	}
-	if password == "" {
+	if password == "" && seedAll {
 		log.Fatal("ADMIN_PASSWORD is required")
 	}
 
-	// Hash password
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), 10)
-	if err != nil {
-		log.Fatalf("Failed to hash password: %v", err)
-	}
-
-	// Connect to database
+	// Connect to database (also validates the connection in dry-run mode)
 	ctx := context.Background()
-	conn, err := pgx.Connect(ctx, databaseURL)
+	pgConn, err := pgx.Connect(ctx, databaseURL)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
-	defer conn.Close(ctx)
+	defer pgConn.Close(ctx)
+
+	var conn executor = pgConn
+	if dryRun {
+		fmt.Println("Dry run: no changes will be written")
+		conn = newDryRunExecutor(pgConn)
+	}
 
 	// Seed roles
-	fmt.Println("Seeding roles...")
-	if err := seedRoles(ctx, conn); err != nil {
-		log.Fatalf("Failed to seed roles: %v", err)
+	if seedAll || rolesOnly {
+		fmt.Println("Seeding roles...")
+		if err := seedRoles(ctx, conn); err != nil {
+			log.Fatalf("Failed to seed roles: %v", err)
+		}
+		fmt.Println("Roles seeded successfully")
+	}
+
+	if seedAll {
+		if err := seedAdmin(ctx, conn, email, password, name); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Seed categories
+	if seedAll || categoriesOnly {
+		fmt.Println("Seeding categories...")
+		if err := seedCategories(ctx, conn); err != nil {
+			log.Fatalf("Failed to seed categories: %v", err)
+		}
+		fmt.Println("Categories seeded successfully")
+	}
+
+	// Seed tags
+	if seedAll || tagsOnly {
+		fmt.Println("Seeding tags...")
+		if err := seedTags(ctx, conn); err != nil {
+			log.Fatalf("Failed to seed tags: %v", err)
+		}
+		fmt.Println("Tags seeded successfully")
+	}
+
+	// Seed politicians
+	if seedAll {
+		fmt.Println("Seeding politicians...")
+		if err := seedPoliticians(ctx, conn); err != nil {
+			log.Fatalf("Failed to seed politicians: %v", err)
+		}
+		fmt.Println("Politicians seeded successfully")
+	}
+
+	// Seed sample articles
+	if seedAll || articlesOnly {
+		fmt.Println("Seeding articles...")
+		if err := seedArticles(ctx, conn, email, force); err != nil {
+			log.Fatalf("Failed to seed articles: %v", err)
+		}
+		fmt.Println("Articles seeded successfully")
+	}
+
+	if dryRun {
+		fmt.Println("\n✓ Dry run completed, nothing was written")
+		return
+	}
+	fmt.Println("\n✓ Database seeding completed!")
+}
+
+// seedAdmin upserts the super admin user and its protected author profile
+func seedAdmin(ctx context.Context, conn executor, email, password, name string) error {
+	// Hash password
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), 10)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
 	}
-	fmt.Println("Roles seeded successfully")
 
 	// Get admin role ID
 	var adminRoleID string
 	err = conn.QueryRow(ctx, `SELECT id FROM roles WHERE slug = 'admin'`).Scan(&adminRoleID)
 	if err != nil {
-		log.Fatalf("Failed to get admin role: %v", err)
+		return fmt.Errorf("failed to get admin role: %w", err)
 	}
 
 	// Upsert admin user with role_id
@@ -92,7 +167,7 @@ func main() {
 	`, email, string(hash), name, adminRoleID)
 
 	if err != nil {
-		log.Fatalf("Failed to create admin user: %v", err)
+		return fmt.Errorf("failed to create admin user: %w", err)
 	}
 
 	fmt.Printf("Super admin user created/updated: %s\n", email)
@@ -109,43 +184,15 @@ func main() {
 	`, name, slug, email, adminRoleID)
 
 	if err != nil {
-		log.Fatalf("Failed to create super admin author profile: %v", err)
+		return fmt.Errorf("failed to create super admin author profile: %w", err)
 	}
 
 	fmt.Printf("Super admin author profile created/updated: %s (protected from deletion)\n", email)
 
-	// Seed categories
-	fmt.Println("Seeding categories...")
-	if err := seedCategories(ctx, conn); err != nil {
-		log.Fatalf("Failed to seed categories: %v", err)
-	}
-	fmt.Println("Categories seeded successfully")
-
-	// Seed tags
-	fmt.Println("Seeding tags...")
-	if err := seedTags(ctx, conn); err != nil {
-		log.Fatalf("Failed to seed tags: %v", err)
-	}
-	fmt.Println("Tags seeded successfully")
-
-	// Seed politicians
-	fmt.Println("Seeding politicians...")
-	if err := seedPoliticians(ctx, conn); err != nil {
-		log.Fatalf("Failed to seed politicians: %v", err)
-	}
-	fmt.Println("Politicians seeded successfully")
-
-	// Seed sample articles
-	fmt.Println("Seeding articles...")
-	if err := seedArticles(ctx, conn, email); err != nil {
-		log.Fatalf("Failed to seed articles: %v", err)
-	}
-	fmt.Println("Articles seeded successfully")
-
-	fmt.Println("\n✓ Database seeding completed!")
+	return nil
 }
 
-func seedRoles(ctx context.Context, conn *pgx.Conn) error {
+func seedRoles(ctx context.Context, conn executor) error {
 	// Seed the three main roles
 	roles := []struct {
 		name        string
@@ -173,7 +220,7 @@ func seedRoles(ctx context.Context, conn *pgx.Conn) error {
 	return nil
 }
 
-func seedCategories(ctx context.Context, conn *pgx.Conn) error {
+func seedCategories(ctx context.Context, conn executor) error {
 	categories := []struct {
 		name        string
 		slug        string
@@ -204,7 +251,7 @@ func seedCategories(ctx context.Context, conn *pgx.Conn) error {
 	return nil
 }
 
-func seedTags(ctx context.Context, conn *pgx.Conn) error {
+func seedTags(ctx context.Context, conn executor) error {
 	tags := []struct {
 		name string
 		slug string
@@ -354,7 +401,7 @@ func generateSlug(name string) string {
 	return slug
 }
 
-func seedPoliticians(ctx context.Context, conn *pgx.Conn) error {
+func seedPoliticians(ctx context.Context, conn executor) error {
 	politicians := []struct {
 		name      string
 		slug      string
@@ -496,7 +543,11 @@ func seedPoliticians(ctx context.Context, conn *pgx.Conn) error {
 	return nil
 }
 
-func seedArticles(ctx context.Context, conn *pgx.Conn, authorEmail string) error {
+// seedArticles inserts the sample articles as drafts. Existing articles are
+// skipped unless force is set, in which case their content, category and tags
+// are brought in line with the seed. Articles that now belong to another
+// author are never touched, and the author of an article is never changed.
+func seedArticles(ctx context.Context, conn executor, authorEmail string, force bool) error {
 	// Get author ID by email
 	var authorID string
 	err := conn.QueryRow(ctx, `SELECT id FROM authors WHERE email = $1`, authorEmail).Scan(&authorID)
@@ -838,7 +889,49 @@ func seedArticles(ctx context.Context, conn *pgx.Conn, authorEmail string) error
 		},
 	}
 
+	var created, updated, skipped int
 	for _, article := range articles {
+		label := article.title[:50] + "..."
+		categoryID := categoryIDs[article.categorySlug]
+
+		var articleID string
+		if force {
+			// Upsert, leaving articles another author has taken over alone
+			var inserted bool
+			err := conn.QueryRow(ctx, `
+				INSERT INTO articles (slug, title, summary, content, author_id, category_id, status)
+				VALUES ($1, $2, $3, $4, $5, $6, 'draft')
+				ON CONFLICT (slug) DO UPDATE SET
+					title = EXCLUDED.title,
+					summary = EXCLUDED.summary,
+					content = EXCLUDED.content,
+					category_id = EXCLUDED.category_id
+				WHERE articles.author_id = EXCLUDED.author_id
+				RETURNING id, (xmax = 0) AS inserted
+			`, article.slug, article.title, article.summary, article.content, authorID, categoryID).Scan(&articleID, &inserted)
+			if errors.Is(err, pgx.ErrNoRows) {
+				fmt.Printf("  - Article '%s' belongs to another author, skipped\n", label)
+				skipped++
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to upsert article %s: %w", article.slug, err)
+			}
+
+			if err := syncArticleTags(ctx, conn, articleID, article.tagSlugs, tagIDs); err != nil {
+				return err
+			}
+
+			if inserted {
+				fmt.Printf("  - Article '%s' created\n", label)
+				created++
+			} else {
+				fmt.Printf("  - Article '%s' updated\n", label)
+				updated++
+			}
+			continue
+		}
+
 		// Check if article already exists
 		var exists bool
 		err := conn.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM articles WHERE slug = $1)`, article.slug).Scan(&exists)
@@ -846,14 +939,12 @@ func seedArticles(ctx context.Context, conn *pgx.Conn, authorEmail string) error
 			return fmt.Errorf("failed to check article existence: %w", err)
 		}
 		if exists {
-			fmt.Printf("  - Article '%s' already exists, skipping\n", article.title[:50]+"...")
+			fmt.Printf("  - Article '%s' already exists, skipped\n", label)
+			skipped++
 			continue
 		}
 
-		categoryID := categoryIDs[article.categorySlug]
-
 		// Insert article
-		var articleID string
 		err = conn.QueryRow(ctx, `
 			INSERT INTO articles (slug, title, summary, content, author_id, category_id, status)
 			VALUES ($1, $2, $3, $4, $5, $6, 'draft')
@@ -863,23 +954,46 @@ func seedArticles(ctx context.Context, conn *pgx.Conn, authorEmail string) error
 			return fmt.Errorf("failed to insert article %s: %w", article.slug, err)
 		}
 
-		// Insert article tags
-		for _, tagSlug := range article.tagSlugs {
-			tagID, ok := tagIDs[tagSlug]
-			if !ok {
-				continue
-			}
-			_, err = conn.Exec(ctx, `
-				INSERT INTO article_tags (article_id, tag_id)
-				VALUES ($1, $2)
-				ON CONFLICT DO NOTHING
-			`, articleID, tagID)
-			if err != nil {
-				return fmt.Errorf("failed to insert article tag: %w", err)
-			}
+		if err := syncArticleTags(ctx, conn, articleID, article.tagSlugs, tagIDs); err != nil {
+			return err
+		}
+
+		fmt.Printf("  - Article '%s' created\n", label)
+		created++
+	}
+
+	fmt.Printf("  Articles: %d created, %d updated, %d skipped\n", created, updated, skipped)
+
+	return nil
+}
+
+// syncArticleTags makes an article's tags match tagSlugs, removing tags no
+// longer listed. Slugs of tags that don't exist are ignored.
+func syncArticleTags(ctx context.Context, conn executor, articleID string, tagSlugs []string, tagIDs map[string]string) error {
+	ids := make([]string, 0, len(tagSlugs))
+	for _, tagSlug := range tagSlugs {
+		if tagID, ok := tagIDs[tagSlug]; ok {
+			ids = append(ids, tagID)
 		}
+	}
 
-		fmt.Printf("  - Article '%s' seeded\n", article.title[:50]+"...")
+	_, err := conn.Exec(ctx, `
+		DELETE FROM article_tags
+		WHERE article_id = $1 AND NOT (tag_id = ANY($2::uuid[]))
+	`, articleID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to remove article tags: %w", err)
+	}
+
+	for _, tagID := range ids {
+		_, err = conn.Exec(ctx, `
+			INSERT INTO article_tags (article_id, tag_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, articleID, tagID)
+		if err != nil {
+			return fmt.Errorf("failed to insert article tag: %w", err)
+		}
 	}
 
 	return nil
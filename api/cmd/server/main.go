@@ -12,16 +12,24 @@ import (
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog"
 
 	"github.com/humfurie/pulpulitiko/api/internal/config"
 	"github.com/humfurie/pulpulitiko/api/internal/handlers"
+	"github.com/humfurie/pulpulitiko/api/internal/jobs"
 	"github.com/humfurie/pulpulitiko/api/internal/middleware"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/repository"
 	"github.com/humfurie/pulpulitiko/api/internal/services"
 	"github.com/humfurie/pulpulitiko/api/pkg/cache"
 	"github.com/humfurie/pulpulitiko/api/pkg/email"
+	"github.com/humfurie/pulpulitiko/api/pkg/fcm"
+	"github.com/humfurie/pulpulitiko/api/pkg/geoip"
+	"github.com/humfurie/pulpulitiko/api/pkg/metrics"
 	"github.com/humfurie/pulpulitiko/api/pkg/storage"
+	"github.com/humfurie/pulpulitiko/api/pkg/toxicity"
+	"github.com/humfurie/pulpulitiko/api/pkg/webhook"
 )
 
 func main() {
@@ -34,12 +42,15 @@ func main() {
 
 	// Load configuration
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		logger.Fatal().Err(err).Msg("Invalid configuration")
+	}
 
 	ctx := context.Background()
 
 	// Initialize database
 	logger.Info().Msg("Connecting to database...")
-	db, err := repository.NewDBPool(ctx, cfg.DatabaseURL)
+	db, err := repository.NewDBPool(ctx, cfg.DatabaseURL, logger)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to connect to database")
 	}
@@ -87,6 +98,7 @@ func main() {
 	articleRepo := repository.NewArticleRepository(db)
 	categoryRepo := repository.NewCategoryRepository(db)
 	tagRepo := repository.NewTagRepository(db)
+	explainerRepo := repository.NewExplainerRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	authorRepo := repository.NewAuthorRepository(db)
 	metricsRepo := repository.NewMetricsRepository(db)
@@ -103,47 +115,164 @@ func main() {
 	billRepo := repository.NewBillRepository(db)
 	electionRepo := repository.NewElectionRepository(db)
 	pollRepo := repository.NewPollRepository(db)
+	userBlockRepo := repository.NewUserBlockRepository(db)
+	savedSearchRepo := repository.NewSavedSearchRepository(db)
+	followRepo := repository.NewFollowRepository(db)
+	auditLogRepo := repository.NewAuditLogRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	embedRepo := repository.NewEmbedRepository(db)
 
 	// Initialize services
 	politicianService := services.NewPoliticianService(politicianRepo, redisCache)
+	mergeService := services.NewMergeService(politicianRepo, redisCache)
 	articleService := services.NewArticleService(articleRepo, politicianRepo, redisCache)
+	articleService.SetSiteURL(cfg.SiteURL)
+	articleService.SetImageStorage(minioStorage)
+	siteLocation, err := time.LoadLocation(cfg.SiteTimezone)
+	if err != nil {
+		logger.Warn().Err(err).Str("timezone", cfg.SiteTimezone).Msg("Unknown site timezone, using UTC")
+		siteLocation = time.UTC
+	}
+	emailService.SetLocation(siteLocation)
+	articleService.SetScheduleConfig(services.ArticleScheduleConfig{
+		Location:       siteLocation,
+		ConflictWindow: cfg.ScheduleConflictWindow,
+		ConflictLimit:  cfg.ScheduleConflictLimit,
+		PublishCap:     cfg.SchedulePublishCap,
+		EnforceCap:     cfg.ScheduleEnforceCap,
+		Stagger:        cfg.SchedulePublishStagger,
+	})
+	articleService.SetAccessConfig(services.ArticleAccessConfig{
+		PreviewParagraphs: cfg.ArticlePreviewParagraphs,
+		FreeMonthly:       cfg.ArticleFreeMonthly,
+	}, userRepo)
 	categoryService := services.NewCategoryService(categoryRepo, redisCache)
-	tagService := services.NewTagService(tagRepo)
+	tagService := services.NewTagService(tagRepo, redisCache)
+	explainerService := services.NewExplainerService(explainerRepo, redisCache)
 	authService := services.NewAuthService(userRepo, roleRepo, authorRepo, emailService, cfg.JWTSecret)
+	auditService := services.NewAuditService(auditLogRepo)
+	authService.SetAuditService(auditService)
+	articleService.SetAuditService(auditService)
 	uploadService := services.NewUploadService(minioStorage)
+	uploadService.SetFeaturedMediaLookup(articleRepo)
+	politicianService.SetUploadService(uploadService)
 	authorService := services.NewAuthorService(authorRepo)
 	roleService := services.NewRoleService(roleRepo, permissionRepo)
-	messageService := services.NewMessageService(messageRepo)
+	messageService := services.NewMessageService(messageRepo, userRepo, userBlockRepo)
 	searchAnalyticsService := services.NewSearchAnalyticsService(searchAnalyticsRepo)
-	notificationService := services.NewNotificationService(notificationRepo, userRepo)
-	commentService := services.NewCommentService(commentRepo, articleRepo, notificationService)
-	politicianCommentService := services.NewPoliticianCommentService(politicianCommentRepo, politicianRepo, notificationService)
+	notificationService := services.NewNotificationService(notificationRepo, userRepo, commentRepo, messageRepo, userBlockRepo, redisCache)
+	messageService.SetUnreadInvalidator(notificationService)
+	messageService.SetAssignmentNotifier(notificationService)
+	if cfg.FCMCredentialsJSON != "" {
+		pushClient, err := fcm.NewClient(cfg.FCMProjectID, []byte(cfg.FCMCredentialsJSON), 10*time.Second)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Invalid FCM credentials, push notifications disabled")
+		} else {
+			notificationService.SetPushSender(pushClient, notificationRepo, logger)
+		}
+	}
+	messageService.SetRequireClaim(cfg.SupportRequireClaim)
+	commentService := services.NewCommentService(commentRepo, articleRepo, notificationService, userBlockRepo)
+	politicianCommentService := services.NewPoliticianCommentService(politicianCommentRepo, politicianRepo, notificationService, userBlockRepo)
 	locationService := services.NewLocationService(locationRepo, redisCache)
+	if cfg.GeoIPDatabasePath != "" {
+		if reader, err := geoip.Open(cfg.GeoIPDatabasePath); err != nil {
+			logger.Warn().Err(err).Msg("Location detection disabled")
+		} else {
+			locationService.SetGeoIP(reader)
+		}
+	}
 	politicalPartyService := services.NewPoliticalPartyService(politicalPartyRepo, redisCache)
+	politicalPartyService.SetUploadService(uploadService)
+	politicalPartyService.SetLocationLookup(locationRepo)
 	billService := services.NewBillService(billRepo, redisCache)
+	billService.SetUploadService(uploadService)
+	billService.SetStaleAfter(cfg.BillStaleAfter)
 	electionService := services.NewElectionService(electionRepo, redisCache)
-	pollService := services.NewPollService(pollRepo, redisCache)
+	electionService.SetStaleAfter(cfg.ElectionStaleAfter)
+	electionService.SetLocationLookup(locationRepo)
+	electionService.SetVotingRecords(billRepo)
+	electionService.SetSiteLocation(siteLocation)
+	pollService := services.NewPollService(pollRepo, redisCache, notificationService)
+	pollService.SetVoterSecret(cfg.PollVoterSecret)
+	if n, err := pollService.HashLegacyVoters(ctx); err != nil {
+		logger.Error().Err(err).Msg("Failed to hash voters of earlier anonymous poll votes")
+	} else if n > 0 {
+		logger.Info().Int("votes", n).Msg("Hashed voters of earlier anonymous poll votes")
+	}
+	embedService := services.NewEmbedService(embedRepo, pollService, electionService, redisCache)
+	embedService.SetSiteURL(cfg.SiteURL)
+	userBlockService := services.NewUserBlockService(userBlockRepo, userRepo)
+	savedSearchService := services.NewSavedSearchService(savedSearchRepo, articleService, categoryRepo, emailService)
+	followService := services.NewFollowService(followRepo, articleRepo, redisCache)
+	webhookService := services.NewWebhookService(webhookRepo, webhook.NewClient(10*time.Second))
+	articleService.SetWebhookService(webhookService)
+	billService.SetWebhookService(webhookService)
+	electionService.SetWebhookService(webhookService)
 
 	// Initialize WebSocket hub
 	wsHub := handlers.NewHub()
 	go wsHub.Run()
+	commentService.SetEventPublisher(wsHub)
+	if err := commentService.LoadSentimentWords(ctx); err != nil {
+		logger.Warn().Err(err).Msg("Comment sentiment scoring disabled")
+	}
+	switch cfg.ToxicityProvider {
+	case toxicity.ProviderWordlist:
+		commentService.SetToxicityScorer(toxicity.NewWordlistScorer(), cfg.ToxicityFlagThreshold)
+	case toxicity.ProviderWebhook:
+		if cfg.ToxicityWebhookURL == "" {
+			logger.Warn().Msg("TOXICITY_WEBHOOK_URL is not set, comment toxicity scoring disabled")
+			break
+		}
+		scorer := toxicity.NewWebhookScorer(cfg.ToxicityWebhookURL, cfg.ToxicityWebhookSecret, cfg.ToxicityTimeout)
+		commentService.SetToxicityScorer(scorer, cfg.ToxicityFlagThreshold)
+	case "off":
+	default:
+		logger.Warn().Str("provider", cfg.ToxicityProvider).Msg("Unknown TOXICITY_PROVIDER, comment toxicity scoring disabled")
+	}
+
+	// Start background jobs
+	jobRunner := jobs.NewJobRunner(logger)
+	jobRunner.Register(jobs.NewPollSchedulerJob(pollService, time.Minute, logger))
+	jobRunner.Register(jobs.NewPollCloserJob(pollService, 5*time.Minute, logger))
+	jobRunner.Register(jobs.NewArticleSchedulerJob(articleService, time.Minute, logger))
+	jobRunner.Register(jobs.NewSavedSearchAlertJob(savedSearchService, 24*time.Hour, logger))
+	jobRunner.Register(jobs.NewWebhookDispatcherJob(webhookService, 30*time.Second, logger))
+	jobRunner.Register(jobs.NewActivityRollupJob(metricsRepo, 10*time.Minute))
+	jobRunner.Register(jobs.NewCommentToxicityJob(commentService, time.Minute, logger))
+	jobRunner.Register(jobs.NewElectionResultRollupJob(electionService, time.Minute, logger))
+	viewCountFlushJob := jobs.NewViewCountFlushJob(articleService, electionService, embedService, 30*time.Second)
+	jobRunner.Register(viewCountFlushJob)
+	jobRunner.Start(context.Background())
 
 	// Initialize handlers
 	articleHandler := handlers.NewArticleHandler(articleService)
+	articleHandler.SetCommentService(commentService)
 	categoryHandler := handlers.NewCategoryHandler(categoryService, articleService)
 	tagHandler := handlers.NewTagHandler(tagService, articleService)
+	explainerHandler := handlers.NewExplainerHandler(explainerService)
 	authHandler := handlers.NewAuthHandler(authService)
 	uploadHandler := handlers.NewUploadHandler(uploadService)
-	healthHandler := handlers.NewHealthHandler()
+	healthHandler := handlers.NewHealthHandler(wsHub)
 	authorHandler := handlers.NewAuthorHandler(authorService, articleService)
-	metricsHandler := handlers.NewMetricsHandler(metricsRepo)
+	metricsHandler := handlers.NewMetricsHandler(metricsRepo, cfg.CoAuthorMetricWeight, models.StalenessThresholds{
+		Bill:     cfg.BillStaleAfter,
+		Election: cfg.ElectionStaleAfter,
+	})
+	metricsHandler.SetSiteLocation(siteLocation)
 	roleHandler := handlers.NewRoleHandler(roleService)
 	commentHandler := handlers.NewCommentHandler(commentService)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchService)
+	followHandler := handlers.NewFollowHandler(followService)
 	rssHandler := handlers.NewRSSHandler(articleService, cfg.SiteURL)
-	userHandler := handlers.NewUserHandler(userRepo)
+	rssHandler.SetSiteLocation(siteLocation)
+	userHandler := handlers.NewUserHandler(userRepo, userBlockService)
 	messageHandler := handlers.NewMessageHandler(messageService, wsHub)
 	wsHandler := handlers.NewWebSocketHandler(wsHub, authService, messageService)
-	politicianHandler := handlers.NewPoliticianHandler(politicianService, articleService)
+	wsHandler.SetAllowedOrigins(cfg.CORS.WebSocketOrigins)
+	politicianHandler := handlers.NewPoliticianHandler(politicianService, articleService, mergeService)
+	politicianHandler.SetPartyService(politicalPartyService)
 	searchAnalyticsHandler := handlers.NewSearchAnalyticsHandler(searchAnalyticsService)
 	politicianCommentHandler := handlers.NewPoliticianCommentHandler(politicianCommentService)
 	notificationHandler := handlers.NewNotificationHandler(notificationService)
@@ -152,50 +281,99 @@ func main() {
 	billHandler := handlers.NewBillHandler(billService)
 	electionHandler := handlers.NewElectionHandler(electionService)
 	pollHandler := handlers.NewPollHandler(pollService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	embedHandler := handlers.NewEmbedHandler(embedService, cfg.SiteURL, cfg.EmbedBaseURL, cfg.EmbedAllowedOrigins)
+	embedHandler.SetSiteLocation(siteLocation)
 
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(authService)
+	authMiddleware.SetAuditRecorder(auditService, logger)
 	rateLimiter := middleware.NewRateLimiter(redisCache, 100, 60) // 100 requests per minute
 
+	// Prometheus metrics
+	metrics.Registry.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "ws_connections_total",
+			Help: "WebSocket connections accepted since start.",
+		}, func() float64 { return float64(wsHub.ConnectionsTotal()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "ws_messages_sent_total",
+			Help: "WebSocket messages written to clients since start.",
+		}, func() float64 { return float64(wsHub.MessagesSent()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "ws_connections",
+			Help: "WebSocket connections currently open.",
+		}, func() float64 { return float64(wsHub.ConnectionCount()) }),
+	)
+
 	// Initialize router
 	r := chi.NewRouter()
 
 	// Global middleware
 	r.Use(chimiddleware.RequestID)
 	r.Use(chimiddleware.RealIP)
-	r.Use(middleware.Logger(logger))
+	r.Use(middleware.SampledLogger(logger, middleware.LogSampling{
+		Rate:          cfg.LogSampleRate,
+		SlowThreshold: cfg.LogSlowRequest,
+	}))
+	r.Use(middleware.RequestMetrics)
 	r.Use(chimiddleware.Recoverer)
 	r.Use(rateLimiter.Limit)
 
-	// CORS configuration
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"}, // In production, specify exact origins
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
+	// CORS is applied per group of routes: admin routes only answer the admin
+	// origins, public API routes a broader list
+	corsHandler := func(origins []string) func(http.Handler) http.Handler {
+		return cors.Handler(cors.Options{
+			AllowedOrigins:   origins,
+			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Legacy-Pagination", "X-Visitor-ID"},
+			ExposedHeaders:   []string{"Link", "X-Canonical-Slug", middleware.RequestIDHeader},
+			AllowCredentials: true,
+			MaxAge:           300,
+		})
+	}
 
 	// Health check
 	r.Get("/health", healthHandler.Health)
 
+	// Prometheus scrape endpoint, only served with a token to guard it
+	if cfg.MetricsToken != "" {
+		r.With(middleware.MetricsToken(cfg.MetricsToken)).Method(http.MethodGet, "/metrics", metrics.Handler())
+	} else {
+		logger.Warn().Msg("METRICS_TOKEN not set, /metrics is disabled")
+	}
+
 	// RSS Feed
 	r.Get("/rss", rssHandler.Feed)
 	r.Get("/feed", rssHandler.Feed)
 
+	// Embeddable poll and election result boxes for partner sites
+	r.Route("/embed", func(r chi.Router) {
+		r.Get("/polls/{slug}", embedHandler.Poll)
+		r.Get("/elections/{slug}/results", embedHandler.ElectionResults)
+		r.Post("/views", embedHandler.RecordView)
+	})
+
 	// WebSocket endpoint
 	r.Get("/ws", wsHandler.HandleWebSocket)
 
 	// Public API routes
 	r.Route("/api", func(r chi.Router) {
+		r.Use(corsHandler(cfg.CORS.PublicOrigins))
+
 		// Articles - use nested routing to avoid route conflicts
 		r.Get("/articles", articleHandler.List)
 		r.Get("/articles/trending", articleHandler.GetTrending)
 		r.Route("/articles/{slug}", func(r chi.Router) {
-			r.Get("/", articleHandler.GetBySlug)
+			r.With(authMiddleware.OptionalAuth).Get("/", articleHandler.GetBySlug)
+			r.With(authMiddleware.OptionalAuth).Get("/text", articleHandler.GetText)
+			r.With(authMiddleware.OptionalAuth).Get("/markdown", articleHandler.GetMarkdown)
+			r.With(authMiddleware.OptionalAuth).Get("/meta", articleHandler.GetMeta)
 			r.Post("/view", articleHandler.IncrementViewCount)
 			r.Get("/related", articleHandler.GetRelatedArticles)
+			// Reactions - OptionalAuth so anonymous readers get a login prompt error
+			r.With(authMiddleware.OptionalAuth).Post("/reactions", articleHandler.AddReaction)
+			r.With(authMiddleware.OptionalAuth).Delete("/reactions", articleHandler.RemoveReaction)
 			// Comments for this article - use OptionalAuth to identify user for reaction status
 			r.With(authMiddleware.OptionalAuth).Get("/comments", commentHandler.ListComments)
 			r.Get("/comments/count", commentHandler.GetCommentCount)
@@ -204,11 +382,20 @@ func main() {
 
 		// Categories
 		r.Get("/categories", categoryHandler.List)
-		r.Get("/categories/{slug}", categoryHandler.GetArticlesBySlug)
+		r.With(authMiddleware.OptionalAuth).Get("/categories/{slug}", categoryHandler.GetArticlesBySlug)
+		r.With(authMiddleware.Authenticate).Post("/categories/{slug}/follow", categoryHandler.Follow)
+		r.With(authMiddleware.Authenticate).Delete("/categories/{slug}/follow", categoryHandler.Unfollow)
 
 		// Tags
 		r.Get("/tags", tagHandler.List)
-		r.Get("/tags/{slug}", tagHandler.GetArticlesBySlug)
+		r.With(authMiddleware.OptionalAuth).Get("/tags/{slug}", tagHandler.GetArticlesBySlug)
+		r.With(authMiddleware.Authenticate).Post("/tags/{slug}/follow", tagHandler.Follow)
+		r.With(authMiddleware.Authenticate).Delete("/tags/{slug}/follow", tagHandler.Unfollow)
+
+		// Explainers
+		r.Get("/explainers/{slug}", explainerHandler.GetBySlug)
+		r.With(authMiddleware.Authenticate).Post("/follows", followHandler.Follow)
+		r.With(authMiddleware.Authenticate).Delete("/follows", followHandler.Unfollow)
 
 		// Authors
 		r.Get("/authors", authorHandler.List)
@@ -218,6 +405,7 @@ func main() {
 		r.Get("/politicians", politicianHandler.List)
 		r.Get("/politicians/search", politicianHandler.Search)
 		r.Route("/politicians/{slug}", func(r chi.Router) {
+			r.Use(politicianHandler.RedirectMerged)
 			r.Get("/", politicianHandler.GetBySlug)
 			// Politician comments
 			r.With(authMiddleware.OptionalAuth).Get("/comments", politicianCommentHandler.ListComments)
@@ -234,11 +422,14 @@ func main() {
 			r.Get("/provinces/by-region/{region_id}", locationHandler.GetProvincesByRegion)
 			r.Get("/cities/{slug}", locationHandler.GetCityBySlug)
 			r.Get("/cities/by-province/{province_id}", locationHandler.GetCitiesByProvince)
+			r.Get("/barangays/search", locationHandler.SearchBarangays)
 			r.Get("/barangays/{slug}", locationHandler.GetBarangayBySlug)
 			r.Get("/barangays/by-city/{city_id}", locationHandler.GetBarangaysByCity)
 			r.Get("/districts/{slug}", locationHandler.GetDistrictBySlug)
 			r.Get("/districts/by-province/{province_id}", locationHandler.GetDistrictsByProvince)
+			r.Get("/tree", locationHandler.GetTree)
 			r.Get("/search", locationHandler.SearchLocations)
+			r.Get("/detect", locationHandler.DetectLocation)
 			r.Get("/hierarchy/{barangay_id}", locationHandler.GetHierarchy)
 		})
 
@@ -268,15 +459,21 @@ func main() {
 			// Committees
 			r.Get("/committees", billHandler.ListCommittees)
 			r.Get("/committees/{slug}", billHandler.GetCommitteeBySlug)
+			r.Get("/committees/{slug}/bills", billHandler.GetCommitteeBills)
 
 			// Topics
 			r.Get("/topics", billHandler.ListAllTopics)
+			r.Get("/topics/tree", billHandler.GetTopicTree)
 
 			// Bills
 			r.Get("/bills", billHandler.ListBills)
+			r.Get("/bills/autocomplete", billHandler.AutocompleteBills)
 			r.Get("/bills/{slug}", billHandler.GetBillBySlug)
 			r.Get("/bills/id/{id}", billHandler.GetBillByID)
 			r.Get("/bills/{id}/votes", billHandler.GetBillVotes)
+			r.Get("/bills/{slug}/deliberations", billHandler.ListBillDeliberations)
+			r.Get("/bills/{slug}/deliberations/search", billHandler.SearchBillDeliberations)
+			r.Get("/bills/{slug}/deliberations/{deliberationId}", billHandler.GetBillDeliberation)
 			r.Get("/votes/{voteId}/politicians", billHandler.GetPoliticianVotesForBillVote)
 
 			// Politician voting records
@@ -284,6 +481,9 @@ func main() {
 			r.Get("/politicians/{id}/voting-record", billHandler.GetPoliticianVotingRecord)
 		})
 
+		// Election positions
+		r.Get("/election-positions/{id}/compare", electionHandler.CompareCandidates)
+
 		// Elections
 		r.Route("/elections", func(r chi.Router) {
 			r.Get("/", electionHandler.ListElections)
@@ -293,6 +493,17 @@ func main() {
 			r.Get("/slug/{slug}", electionHandler.GetElectionBySlug)
 			r.Get("/{id}", electionHandler.GetElectionByID)
 			r.Get("/{id}/positions", electionHandler.GetElectionPositions)
+			// Surveys
+			r.Get("/{slug}/surveys", electionHandler.GetElectionSurveys)
+			r.Get("/{slug}/positions/{positionId}/survey-trend", electionHandler.GetSurveyTrend)
+			// Issues
+			r.Get("/{slug}/positions/{positionId}/candidates", electionHandler.GetCandidatesForPosition)
+			r.Get("/{slug}/issues-matrix", electionHandler.GetIssuesMatrix)
+			// Results
+			r.Get("/{slug}/results", electionHandler.GetResultsSummary)
+			r.Get("/{slug}/results/by-coalition", electionHandler.GetCoalitionResults)
+			r.Get("/{slug}/results/map", electionHandler.GetResultsMap)
+			r.Get("/{slug}/results/locations/{citySlug}", electionHandler.GetCityResults)
 		})
 
 		// Candidates
@@ -315,6 +526,8 @@ func main() {
 			r.Get("/slug/{slug}", pollHandler.GetPollBySlug)
 			r.Get("/{id}", pollHandler.GetPollByID)
 			r.Get("/{id}/results", pollHandler.GetPollResults)
+			r.With(authMiddleware.Authenticate, authMiddleware.RequireAdmin).Get("/{slug}/results/timeline", pollHandler.GetVotingTimeline)
+			r.Get("/{slug}/results/by-region", pollHandler.GetResultsByRegion)
 			r.With(authMiddleware.OptionalAuth).Post("/{id}/vote", pollHandler.CastVote)
 			// Poll comments
 			r.With(authMiddleware.OptionalAuth).Get("/{id}/comments", pollHandler.GetPollComments)
@@ -331,6 +544,9 @@ func main() {
 			r.Delete("/{id}", pollHandler.DeletePoll)
 		})
 
+		// oEmbed discovery for poll and election result pages
+		r.Get("/oembed", embedHandler.OEmbed)
+
 		// Search
 		r.Get("/search", articleHandler.Search)
 
@@ -360,6 +576,8 @@ func main() {
 		r.Post("/auth/forgot-password", authHandler.ForgotPassword)
 		r.Post("/auth/reset-password", authHandler.ResetPassword)
 		r.With(authMiddleware.Authenticate).Get("/auth/me", authHandler.GetCurrentUser)
+		r.With(authMiddleware.Authenticate).Get("/auth/feed", followHandler.Feed)
+		r.With(authMiddleware.Authenticate).Post("/auth/device-token", notificationHandler.RegisterDeviceToken)
 		r.With(authMiddleware.Authenticate).Get("/auth/account", authorHandler.GetAccount)
 		r.With(authMiddleware.Authenticate).Put("/auth/account", authorHandler.UpdateAccount)
 
@@ -368,6 +586,27 @@ func main() {
 		r.Get("/users/{slug}/profile", userHandler.GetUserProfile)
 		r.Get("/users/{slug}/comments", userHandler.GetUserComments)
 		r.Get("/users/{slug}/replies", userHandler.GetUserReplies)
+		r.With(authMiddleware.Authenticate).Post("/users/{slug}/block", userHandler.BlockUser)
+		r.With(authMiddleware.Authenticate).Delete("/users/{slug}/block", userHandler.UnblockUser)
+		r.With(authMiddleware.Authenticate).Get("/me/blocks", userHandler.GetMyBlocks)
+
+		// Mentions inbox (authenticated users)
+		r.Route("/me/mentions", func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Get("/", commentHandler.ListMyMentions)
+			r.Get("/unread-count", commentHandler.GetMyUnreadMentionCount)
+			r.Post("/{id}/read", commentHandler.MarkMentionRead)
+			r.Post("/read-all", commentHandler.MarkAllMentionsRead)
+		})
+
+		// Unified notifications inbox: mentions, replies and messages (authenticated users)
+		r.Route("/me/notifications", func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Get("/", notificationHandler.ListInbox)
+			r.Get("/unread-count", notificationHandler.GetInboxUnreadCount)
+			r.Post("/{type}/{id}/read", notificationHandler.MarkInboxItemRead)
+			r.Post("/read-all", notificationHandler.MarkInboxRead)
+		})
 
 		// Messaging (authenticated users)
 		r.Route("/messages", func(r chi.Router) {
@@ -394,6 +633,8 @@ func main() {
 
 	// Admin API routes (authenticated)
 	r.Route("/api/admin", func(r chi.Router) {
+		// Before authentication, so preflight requests are answered
+		r.Use(corsHandler(cfg.CORS.AdminOrigins))
 		r.Use(authMiddleware.Authenticate)
 
 		// Metrics
@@ -401,17 +642,50 @@ func main() {
 		r.Get("/metrics/top-articles", metricsHandler.GetTopArticles)
 		r.Get("/metrics/categories", metricsHandler.GetCategoryMetrics)
 		r.Get("/metrics/tags", metricsHandler.GetTagMetrics)
+		r.Get("/metrics/authors", metricsHandler.GetAuthorMetrics)
+		r.Get("/metrics/articles/{id}/referrers", metricsHandler.GetArticleReferrers)
+		r.Get("/metrics/referrers/summary", metricsHandler.GetReferrerSummary)
+		r.Get("/metrics/activity-heatmap", metricsHandler.GetActivityHeatmap)
+		r.Get("/metrics/comments/sentiment", commentHandler.GetSentimentSummary)
+		r.Get("/metrics/embeds", embedHandler.GetReach)
+
+		// How current hand-encoded data is
+		r.Get("/freshness", metricsHandler.GetDataFreshness)
 
 		// Search Analytics (admin only)
 		r.Get("/analytics/search", searchAnalyticsHandler.GetAnalytics)
 
+		// Saved article searches
+		r.Get("/searches", savedSearchHandler.List)
+		r.Post("/searches", savedSearchHandler.Create)
+		r.Get("/searches/{id}", savedSearchHandler.Get)
+		r.Get("/searches/{id}/results", savedSearchHandler.Execute)
+		r.Delete("/searches/{id}", savedSearchHandler.Delete)
+
 		// Articles
 		r.Get("/articles", articleHandler.AdminList)
+		r.Get("/articles/export.csv", articleHandler.AdminExportCSV)
+		r.Get("/articles/schedule", articleHandler.AdminGetSchedule)
+		r.Post("/articles/comment-settings", articleHandler.AdminBulkCommentSettings)
+		r.Post("/articles/bulk-delete", articleHandler.AdminBulkDelete)
+		r.Post("/articles/bulk-restore", articleHandler.AdminBulkRestore)
 		r.Get("/articles/{id}", articleHandler.AdminGetByID)
+		r.Get("/articles/{id}/seo-score", articleHandler.AdminGetSEOScore)
+		r.Get("/articles/{id}/keyword-density", articleHandler.AdminGetKeywordDensity)
 		r.Post("/articles", articleHandler.Create)
 		r.Put("/articles/{id}", articleHandler.Update)
 		r.Delete("/articles/{id}", articleHandler.Delete)
 		r.Post("/articles/{id}/restore", articleHandler.Restore)
+		r.Get("/articles/{id}/sources", articleHandler.ListSources)
+		r.Post("/articles/{id}/sources", articleHandler.CreateSource)
+		r.Put("/articles/{id}/sources/{sourceId}", articleHandler.UpdateSource)
+		r.Delete("/articles/{id}/sources/{sourceId}", articleHandler.DeleteSource)
+		r.Get("/articles/{id}/media", articleHandler.ListMedia)
+		r.Post("/articles/{id}/media", articleHandler.CreateMedia)
+		r.Put("/articles/{id}/media/order", articleHandler.ReorderMedia)
+		r.Put("/articles/{id}/media/{mediaId}", articleHandler.UpdateMedia)
+		r.Delete("/articles/{id}/media/{mediaId}", articleHandler.DeleteMedia)
+		r.With(authMiddleware.RequireAdmin).Post("/articles/backfill-featured-media", articleHandler.BackfillFeaturedImages)
 
 		// Categories
 		r.Get("/categories", categoryHandler.AdminList)
@@ -420,6 +694,7 @@ func main() {
 		r.Put("/categories/{id}", categoryHandler.Update)
 		r.Delete("/categories/{id}", categoryHandler.Delete)
 		r.Post("/categories/{id}/restore", categoryHandler.Restore)
+		r.Post("/categories/{id}/migrate-articles", categoryHandler.MigrateArticles)
 
 		// Tags
 		r.Get("/tags", tagHandler.AdminList)
@@ -428,6 +703,16 @@ func main() {
 		r.Put("/tags/{id}", tagHandler.Update)
 		r.Delete("/tags/{id}", tagHandler.Delete)
 		r.Post("/tags/{id}/restore", tagHandler.Restore)
+		r.Post("/tags/{id}/assign", tagHandler.AssignArticles)
+		r.Post("/tags/{id}/unassign", tagHandler.UnassignArticles)
+		r.Post("/tags/{id}/merge", tagHandler.Merge)
+
+		// Explainers
+		r.Get("/explainers", explainerHandler.AdminList)
+		r.Get("/explainers/{id}", explainerHandler.AdminGetByID)
+		r.Post("/explainers", explainerHandler.Create)
+		r.Put("/explainers/{id}", explainerHandler.Update)
+		r.Delete("/explainers/{id}", explainerHandler.Delete)
 
 		// Politicians
 		r.Get("/politicians", politicianHandler.AdminList)
@@ -436,6 +721,18 @@ func main() {
 		r.Put("/politicians/{id}", politicianHandler.Update)
 		r.Delete("/politicians/{id}", politicianHandler.Delete)
 		r.Post("/politicians/{id}/restore", politicianHandler.Restore)
+		r.Post("/politicians/{id}/merge", politicianHandler.Merge)
+		r.Get("/politicians/{id}/history", politicianHandler.GetHistory)
+		r.Get("/politicians/{id}/tenures", politicianHandler.ListTenures)
+		r.Post("/politicians/{id}/tenures", politicianHandler.CreateTenure)
+		r.Put("/politicians/{id}/tenures/{tenureId}", politicianHandler.UpdateTenure)
+		r.Delete("/politicians/{id}/tenures/{tenureId}", politicianHandler.DeleteTenure)
+		r.Get("/politicians/{id}/social-links", politicianHandler.ListSocialLinks)
+		r.Post("/politicians/{id}/social-links", politicianHandler.CreateSocialLink)
+		r.Put("/politicians/{id}/social-links/{linkId}", politicianHandler.UpdateSocialLink)
+		r.Delete("/politicians/{id}/social-links/{linkId}", politicianHandler.DeleteSocialLink)
+		r.Post("/politicians/{id}/photo", politicianHandler.UploadPhoto)
+		r.Delete("/politicians/{id}/photo", politicianHandler.RemovePhoto)
 
 		// Locations management (admin only)
 		r.Route("/locations", func(r chi.Router) {
@@ -463,6 +760,7 @@ func main() {
 			// Districts
 			r.Get("/districts/{id}", locationHandler.AdminGetDistrictByID)
 			r.Post("/districts", locationHandler.CreateDistrict)
+			r.Put("/districts/{id}", locationHandler.UpdateDistrict)
 		})
 
 		// Political Parties management (admin only)
@@ -471,6 +769,12 @@ func main() {
 			r.Post("/", politicalPartyHandler.CreateParty)
 			r.Put("/{id}", politicalPartyHandler.UpdateParty)
 			r.Delete("/{id}", politicalPartyHandler.DeleteParty)
+			r.Post("/{id}/logo", politicalPartyHandler.UploadLogo)
+			r.Delete("/{id}/logo", politicalPartyHandler.RemoveLogo)
+			r.Get("/{id}/relations", politicalPartyHandler.ListPartyRelations)
+			r.Post("/{id}/relations", politicalPartyHandler.CreatePartyRelation)
+			r.Put("/{id}/relations/{relationId}", politicalPartyHandler.UpdatePartyRelation)
+			r.Delete("/{id}/relations/{relationId}", politicalPartyHandler.DeletePartyRelation)
 		})
 
 		// Government Positions management (admin only)
@@ -501,6 +805,24 @@ func main() {
 			r.Post("/bills/{id}/status", billHandler.AddBillStatus)
 			// Bill votes
 			r.Post("/bills/{id}/votes", billHandler.AddBillVote)
+			// Bill documents
+			r.Get("/bills/{id}/documents", billHandler.ListDocuments)
+			r.Post("/bills/{id}/documents", billHandler.UploadDocument)
+			r.Delete("/bills/{id}/documents/{documentId}", billHandler.DeleteDocument)
+			// Floor deliberations
+			r.Get("/bills/{id}/deliberations", billHandler.ListDeliberations)
+			r.Post("/bills/{id}/deliberations", billHandler.AddDeliberation)
+			r.Put("/bills/{id}/deliberations/{deliberationId}", billHandler.UpdateDeliberation)
+			r.Delete("/bills/{id}/deliberations/{deliberationId}", billHandler.RemoveDeliberation)
+			// Topic hierarchy
+			r.Post("/topics", billHandler.CreateTopic)
+			r.Put("/topics/{id}", billHandler.UpdateTopic)
+			r.Delete("/topics/{id}", billHandler.DeleteTopic)
+			// Committee memberships
+			r.Get("/committees/{id}/members", billHandler.ListCommitteeMembers)
+			r.Post("/committees/{id}/members", billHandler.AddCommitteeMember)
+			r.Put("/committees/{id}/members/{memberId}", billHandler.UpdateCommitteeMember)
+			r.Delete("/committees/{id}/members/{memberId}", billHandler.RemoveCommitteeMember)
 		})
 
 		// Elections management (admin only)
@@ -512,13 +834,25 @@ func main() {
 			r.Delete("/{id}", electionHandler.DeleteElection)
 			// Election positions
 			r.Post("/positions", electionHandler.CreateElectionPosition)
+			r.Put("/{id}/positions/order", electionHandler.ReorderElectionPositions)
+			r.Patch("/{id}/positions/reorder", electionHandler.SetBallotOrders)
+			// Results
+			r.Post("/{id}/results/locations/import", electionHandler.ImportLocationResults)
 			// Candidates
 			r.Post("/candidates", electionHandler.CreateCandidate)
 			r.Put("/candidates/{id}", electionHandler.UpdateCandidate)
 			// Voter education
 			r.Post("/voter-education", electionHandler.CreateVoterEducation)
+			// Surveys
+			r.Get("/{id}/surveys", electionHandler.AdminListSurveys)
+			r.Post("/{id}/surveys", electionHandler.CreateSurvey)
+			r.Put("/{id}/surveys/{surveyId}", electionHandler.UpdateSurvey)
+			r.Delete("/{id}/surveys/{surveyId}", electionHandler.DeleteSurvey)
 		})
 
+		// Candidate substitution (admin only)
+		r.With(authMiddleware.RequireAdmin).Post("/candidates/{id}/substitute", electionHandler.SubstituteCandidate)
+
 		// Polls management (admin only)
 		r.Route("/polls", func(r chi.Router) {
 			r.Use(authMiddleware.RequireAdmin)
@@ -532,6 +866,8 @@ func main() {
 
 		// Upload
 		r.Post("/upload", uploadHandler.Upload)
+		r.Post("/upload/article-image", uploadHandler.UploadArticleImage)
+		r.Delete("/upload", uploadHandler.Delete)
 
 		// Users management (admin only)
 		r.Route("/users", func(r chi.Router) {
@@ -542,6 +878,8 @@ func main() {
 			r.Put("/{id}", authorHandler.AdminUpdate)
 			r.Delete("/{id}", authorHandler.AdminDelete)
 			r.Post("/{id}/restore", authorHandler.AdminRestore)
+			r.Post("/{id}/impersonate", authHandler.Impersonate)
+			r.Put("/{id}/membership", userHandler.SetMembership)
 		})
 
 		// Roles management (admin only)
@@ -554,6 +892,7 @@ func main() {
 			r.Put("/{id}", roleHandler.Update)
 			r.Delete("/{id}", roleHandler.Delete)
 			r.Post("/{id}/restore", roleHandler.Restore)
+			r.Put("/{id}/permissions", roleHandler.SetPermissions)
 		})
 
 		// Comments moderation (admin only)
@@ -578,6 +917,23 @@ func main() {
 			r.Post("/conversations/{id}/messages", messageHandler.SendMessage)
 			r.Post("/conversations/{id}/read", messageHandler.MarkAsRead)
 			r.Patch("/conversations/{id}/status", messageHandler.AdminUpdateConversationStatus)
+			r.Patch("/conversations/{id}/assign", messageHandler.AdminAssignConversation)
+			r.Post("/conversations/{id}/claim", messageHandler.AdminClaimConversation)
+			r.Post("/conversations/{id}/archive", messageHandler.AdminArchiveConversation)
+			r.Delete("/conversations/{id}/archive", messageHandler.AdminUnarchiveConversation)
+			r.Get("/queues", messageHandler.AdminGetQueueCounts)
+		})
+
+		// Webhooks for integrators (admin only)
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(authMiddleware.RequireAdmin)
+			r.Get("/", webhookHandler.List)
+			r.Post("/", webhookHandler.Create)
+			r.Get("/{id}", webhookHandler.GetByID)
+			r.Put("/{id}", webhookHandler.Update)
+			r.Delete("/{id}", webhookHandler.Delete)
+			r.Get("/{id}/deliveries", webhookHandler.ListDeliveries)
+			r.Post("/{id}/deliveries/{deliveryId}/retry", webhookHandler.RetryDelivery)
 		})
 	})
 
@@ -612,5 +968,16 @@ func main() {
 		logger.Fatal().Err(err).Msg("Server forced to shutdown")
 	}
 
+	jobRunner.Stop()
+
+	if err := notificationService.StopPush(ctx); err != nil {
+		logger.Error().Err(err).Msg("Failed to send queued push notifications")
+	}
+
+	// Write out views counted since the last flush
+	if err := viewCountFlushJob.Run(ctx); err != nil {
+		logger.Error().Err(err).Msg("Failed to flush view counts")
+	}
+
 	logger.Info().Msg("Server exited")
 }
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCORSDefaults(t *testing.T) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("CORS_PUBLIC_ORIGINS", "")
+	t.Setenv("CORS_ADMIN_ORIGINS", "https://admin.pulpulitiko.com, https://pulpulitiko.com")
+	t.Setenv("CORS_WEBSOCKET_ORIGINS", "")
+
+	cfg := Load()
+	assert.Equal(t, []string{"*"}, cfg.CORS.PublicOrigins)
+	assert.Equal(t, []string{"https://admin.pulpulitiko.com", "https://pulpulitiko.com"}, cfg.CORS.AdminOrigins)
+	assert.NoError(t, cfg.Validate())
+
+	t.Setenv("APP_ENV", "production")
+	assert.Empty(t, Load().CORS.PublicOrigins)
+}
+
+func TestValidateProductionCORS(t *testing.T) {
+	cfg := &Config{
+		AppEnv: "production",
+		CORS: CORSConfig{
+			PublicOrigins:    []string{"*"},
+			AdminOrigins:     []string{"https://pulpulitiko.com"},
+			WebSocketOrigins: []string{"https://pulpulitiko.com"},
+		},
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.CORS.AdminOrigins = []string{"*"}
+	assert.EqualError(t, cfg.Validate(), "CORS_ADMIN_ORIGINS cannot allow every origin in production")
+
+	cfg.CORS.AdminOrigins = []string{"https://pulpulitiko.com"}
+	cfg.CORS.WebSocketOrigins = nil
+	assert.EqualError(t, cfg.Validate(), "CORS_WEBSOCKET_ORIGINS must be set in production")
+}
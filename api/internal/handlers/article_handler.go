@@ -1,22 +1,34 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/middleware"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/services"
 )
 
 type ArticleHandler struct {
-	service *services.ArticleService
+	service  *services.ArticleService
+	comments *services.CommentService
 }
 
 func NewArticleHandler(service *services.ArticleService) *ArticleHandler {
 	return &ArticleHandler{service: service}
 }
 
+// SetCommentService enables the comment sentiment on the admin article page
+func (h *ArticleHandler) SetCommentService(comments *services.CommentService) {
+	h.comments = comments
+}
+
 // GET /api/articles
 func (h *ArticleHandler) List(w http.ResponseWriter, r *http.Request) {
 	page, perPage := GetPaginationParams(r)
@@ -31,41 +43,249 @@ func (h *ArticleHandler) List(w http.ResponseWriter, r *http.Request) {
 	// For simplicity, we skip this filter in the handler - use /categories/:slug endpoint instead
 	_ = r.URL.Query().Get("category")
 
+	after, before, err := parsePublishedRange(r.URL.Query())
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+	filter.PublishedAfter = models.ParseArticleDate(after)
+	filter.PublishedBefore = models.ParseArticleDate(before)
+
+	sort, err := models.ParseArticleSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+	filter.Sort = sort
+
 	articles, err := h.service.List(r.Context(), filter, page, perPage)
 	if err != nil {
 		WriteInternalError(w, "failed to fetch articles")
 		return
 	}
 
-	WriteSuccess(w, articles)
+	WritePaginated(w, r, articles)
 }
 
 // GET /api/articles/:slug
 func (h *ArticleHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.getPublishedBySlug(w, r)
+	if !ok {
+		return
+	}
+
+	// Reactions and access are per caller, so they're added after the cached
+	// article
+	reader := articleReader(r)
+	h.service.ApplyAccess(r.Context(), article, reader)
+
+	reactions, err := h.service.GetReactions(r.Context(), article.ID, reader.UserID)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch article reactions")
+		return
+	}
+	article.Reactions = reactions
+
+	WriteSuccess(w, article)
+}
+
+// POST /api/articles/:slug/reactions - React to an article, replacing any earlier reaction
+func (h *ArticleHandler) AddReaction(w http.ResponseWriter, r *http.Request) {
+	userID, ok := reactingUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.AddReactionRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	article, ok := h.getPublishedBySlug(w, r)
+	if !ok {
+		return
+	}
+
+	reactions, err := h.service.React(r.Context(), article.ID, userID, req.Reaction)
+	if err != nil {
+		if err.Error() == "invalid reaction type" {
+			WriteBadRequest(w, err.Error())
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, reactions)
+}
+
+// DELETE /api/articles/:slug/reactions - Remove the caller's reaction
+func (h *ArticleHandler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	userID, ok := reactingUserID(w, r)
+	if !ok {
+		return
+	}
+
+	article, ok := h.getPublishedBySlug(w, r)
+	if !ok {
+		return
+	}
+
+	reactions, err := h.service.Unreact(r.Context(), article.ID, userID)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, reactions)
+}
+
+// reactingUserID returns the signed-in reader, telling anonymous readers to
+// log in so the page can prompt them
+func reactingUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteError(w, http.StatusUnauthorized, "LOGIN_REQUIRED", "log in to react to articles")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// GET /api/articles/:slug/text - Plain-text rendering for assistive technology
+func (h *ArticleHandler) GetText(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.getPublishedBySlug(w, r)
+	if !ok {
+		return
+	}
+
+	h.service.ApplyAccess(r.Context(), article, articleReader(r))
+	writeText(w, "text/plain; charset=utf-8", h.service.GetPlainText(r.Context(), article))
+}
+
+// GET /api/articles/:slug/markdown
+func (h *ArticleHandler) GetMarkdown(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.getPublishedBySlug(w, r)
+	if !ok {
+		return
+	}
+
+	h.service.ApplyAccess(r.Context(), article, articleReader(r))
+	writeText(w, "text/markdown; charset=utf-8", h.service.GetMarkdown(r.Context(), article))
+}
+
+// GET /api/articles/:slug/meta - Open Graph and other sharing metadata for
+// server-rendered pages. Staff may also read it for unpublished articles, to
+// preview how they'll be shared.
+func (h *ArticleHandler) GetMeta(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	staff := claims != nil && (claims.Role == "admin" || claims.Role == "author")
+
+	article, ok := h.getBySlug(w, r, staff)
+	if !ok {
+		return
+	}
+
+	WriteSuccess(w, h.service.GetMeta(r.Context(), article))
+}
+
+// getPublishedBySlug loads the published article named by the slug URL
+// parameter, redirecting renamed slugs and writing a 404 for anything else
+func (h *ArticleHandler) getPublishedBySlug(w http.ResponseWriter, r *http.Request) (*models.Article, bool) {
+	return h.getBySlug(w, r, false)
+}
+
+// getBySlug is getPublishedBySlug, also accepting unpublished articles when
+// allowed
+func (h *ArticleHandler) getBySlug(w http.ResponseWriter, r *http.Request, allowUnpublished bool) (*models.Article, bool) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
 		WriteBadRequest(w, "slug is required")
-		return
+		return nil, false
 	}
 
 	article, err := h.service.GetBySlug(r.Context(), slug)
 	if err != nil {
 		WriteInternalError(w, "failed to fetch article")
-		return
+		return nil, false
 	}
 
 	if article == nil {
+		// The slug may have been renamed
+		newSlug, err := h.service.ResolveSlugRedirect(r.Context(), slug)
+		if err != nil {
+			WriteInternalError(w, "failed to fetch article")
+			return nil, false
+		}
+		if newSlug != "" {
+			WriteSlugRedirect(w, r, slug, newSlug)
+			return nil, false
+		}
 		WriteNotFound(w, "article not found")
-		return
+		return nil, false
 	}
 
 	// Only return published articles for public API
-	if article.Status != models.ArticleStatusPublished {
+	if article.Status != models.ArticleStatusPublished && !allowUnpublished {
 		WriteNotFound(w, "article not found")
-		return
+		return nil, false
 	}
 
-	WriteSuccess(w, article)
+	return article, true
+}
+
+// maxVisitorIDLength bounds the visitor IDs accepted for metering
+const maxVisitorIDLength = 64
+
+// articleReader identifies the caller for members-only articles: the signed-in
+// user, or else the anonymous visitor ID sent in the X-Visitor-ID header or
+// the visitor_id cookie
+func articleReader(r *http.Request) services.ArticleReader {
+	var reader services.ArticleReader
+	if claims := middleware.GetUserClaims(r.Context()); claims != nil {
+		if userID, err := uuid.Parse(claims.UserID); err == nil {
+			reader.UserID = &userID
+		}
+		reader.Role = claims.Role
+	}
+
+	visitorID := strings.TrimSpace(r.Header.Get("X-Visitor-ID"))
+	if visitorID == "" {
+		if cookie, err := r.Cookie("visitor_id"); err == nil {
+			visitorID = strings.TrimSpace(cookie.Value)
+		}
+	}
+	if validVisitorID(visitorID) {
+		reader.VisitorID = visitorID
+	}
+	return reader
+}
+
+// validVisitorID accepts IDs such as UUIDs: letters, digits, dashes and
+// underscores, up to maxVisitorIDLength long
+func validVisitorID(id string) bool {
+	if id == "" || len(id) > maxVisitorIDLength {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func writeText(w http.ResponseWriter, contentType, body string) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(body))
 }
 
 // GET /api/articles/trending
@@ -95,7 +315,7 @@ func (h *ArticleHandler) Search(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, articles)
+	WritePaginated(w, r, articles)
 }
 
 // POST /api/admin/articles
@@ -108,6 +328,10 @@ func (h *ArticleHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	article, err := h.service.Create(r.Context(), &req)
 	if err != nil {
+		if isArticleRequestError(err) {
+			WriteValidationError(w, err)
+			return
+		}
 		WriteInternalError(w, err.Error())
 		return
 	}
@@ -132,6 +356,14 @@ func (h *ArticleHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	article, err := h.service.Update(r.Context(), id, &req)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "moving a published article into an internal category") {
+			WriteError(w, http.StatusConflict, "CONFIRMATION_REQUIRED", err.Error())
+			return
+		}
+		if isArticleRequestError(err) {
+			WriteValidationError(w, err)
+			return
+		}
 		WriteInternalError(w, err.Error())
 		return
 	}
@@ -144,6 +376,22 @@ func (h *ArticleHandler) Update(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, article)
 }
 
+// isArticleRequestError reports whether a create or update failed because of
+// the request rather than the server
+func isArticleRequestError(err error) bool {
+	switch err.Error() {
+	case "primary author cannot also be a co-author",
+		"published_at is required to schedule an article",
+		"scheduled publish time must be in the future",
+		"published_at must be RFC 3339 or a local YYYY-MM-DDTHH:MM time",
+		"comments_locked_at must be RFC 3339 or a local YYYY-MM-DDTHH:MM time",
+		"featured_image must be an http(s) URL or an uploaded file key",
+		"cover_image must be an http(s) URL or an uploaded file key":
+		return true
+	}
+	return false
+}
+
 // DELETE /api/admin/articles/:id
 func (h *ArticleHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -165,20 +413,24 @@ func (h *ArticleHandler) Delete(w http.ResponseWriter, r *http.Request) {
 func (h *ArticleHandler) AdminList(w http.ResponseWriter, r *http.Request) {
 	page, perPage := GetPaginationParams(r)
 
-	filter := &models.ArticleFilter{}
-
-	if status := r.URL.Query().Get("status"); status != "" {
-		s := models.ArticleStatus(status)
-		filter.Status = &s
+	params, err := parseArticleSearchParams(r)
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
 	}
 
+	// Staff see articles in internal categories too
+	filter := params.Filter()
+	filter.IncludeInternal = true
+	filter.IncludeDeleted = GetIncludeDeleted(r)
+
 	articles, err := h.service.List(r.Context(), filter, page, perPage)
 	if err != nil {
 		WriteInternalError(w, "failed to fetch articles")
 		return
 	}
 
-	WriteSuccess(w, articles)
+	WritePaginated(w, r, articles)
 }
 
 // GET /api/admin/articles/:id
@@ -201,9 +453,145 @@ func (h *ArticleHandler) AdminGetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.comments != nil {
+		sentiment, err := h.comments.GetSentimentSummary(r.Context(), article.ID)
+		if err != nil {
+			WriteInternalError(w, "failed to fetch comment sentiment")
+			return
+		}
+		article.AvgCommentSentiment = sentiment.AverageScore
+	}
+
 	WriteSuccess(w, article)
 }
 
+// GET /api/admin/articles/:id/seo-score
+func (h *ArticleHandler) AdminGetSEOScore(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid article ID")
+		return
+	}
+
+	report, err := h.service.GetSEOReport(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch article")
+		return
+	}
+	if report == nil {
+		WriteNotFound(w, "article not found")
+		return
+	}
+
+	WriteSuccess(w, report)
+}
+
+// GET /api/admin/articles/:id/keyword-density
+func (h *ArticleHandler) AdminGetKeywordDensity(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid article ID")
+		return
+	}
+
+	report, err := h.service.GetKeywordDensity(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch article")
+		return
+	}
+	if report == nil {
+		WriteNotFound(w, "article not found")
+		return
+	}
+
+	WriteSuccess(w, report)
+}
+
+// GET /api/admin/articles/schedule?from=&to=
+func (h *ArticleHandler) AdminGetSchedule(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseScheduleRange(r.URL.Query(), h.service.ScheduleLocation(), time.Now())
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	schedule, err := h.service.GetSchedule(r.Context(), from, to)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch article schedule")
+		return
+	}
+
+	WriteSuccess(w, schedule)
+}
+
+// POST /api/admin/articles/comment-settings
+func (h *ArticleHandler) AdminBulkCommentSettings(w http.ResponseWriter, r *http.Request) {
+	var req models.BulkCommentSettingsRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	result, err := h.service.BulkUpdateCommentSettings(r.Context(), &req)
+	if err != nil {
+		if isArticleRequestError(err) || err.Error() == "no comment settings to apply" {
+			WriteBadRequest(w, err.Error())
+			return
+		}
+		WriteInternalError(w, "failed to update comment settings")
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
+// maxScheduleRange is the longest stretch of the timeline returned at once
+const maxScheduleRange = 92 * 24 * time.Hour
+
+// parseScheduleRange reads the timeline's from and to, each a date or a time
+// in the site timezone. A date for to covers that whole day. The range defaults
+// to the week starting today.
+func parseScheduleRange(q url.Values, loc *time.Location, now time.Time) (from, to time.Time, err error) {
+	parse := func(name string, endOfDay bool) (time.Time, error) {
+		v := q.Get(name)
+		if d, err := time.ParseInLocation(models.ArticleDateLayout, v, loc); err == nil {
+			if endOfDay {
+				d = d.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+			return d, nil
+		}
+		t, err := models.ParseScheduleTime(v, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%s must be a date (YYYY-MM-DD) or time (YYYY-MM-DDTHH:MM)", name)
+		}
+		return t, nil
+	}
+
+	local := now.In(loc)
+	from = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	if q.Get("from") != "" {
+		if from, err = parse("from", false); err != nil {
+			return from, to, err
+		}
+	}
+
+	to = from.AddDate(0, 0, 7)
+	if q.Get("to") != "" {
+		if to, err = parse("to", true); err != nil {
+			return from, to, err
+		}
+	}
+
+	if to.Before(from) {
+		return from, to, fmt.Errorf("to must not be before from")
+	}
+	if to.Sub(from) > maxScheduleRange {
+		return from, to, fmt.Errorf("schedule range must not exceed 92 days")
+	}
+
+	return from.UTC(), to.UTC(), nil
+}
+
 // POST /api/articles/:slug/view
 func (h *ArticleHandler) IncrementViewCount(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
@@ -212,7 +600,7 @@ func (h *ArticleHandler) IncrementViewCount(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if err := h.service.IncrementViewCount(r.Context(), slug); err != nil {
+	if err := h.service.IncrementViewCount(r.Context(), slug, r.Referer()); err != nil {
 		WriteInternalError(w, "failed to increment view count")
 		return
 	}
@@ -237,6 +625,40 @@ func (h *ArticleHandler) Restore(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, map[string]string{"message": "article restored"})
 }
 
+// POST /api/admin/articles/bulk-delete
+func (h *ArticleHandler) AdminBulkDelete(w http.ResponseWriter, r *http.Request) {
+	var req models.BulkArticleRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	result, err := h.service.BulkDelete(r.Context(), req.ArticleIDs)
+	if err != nil {
+		WriteInternalError(w, "failed to delete articles")
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
+// POST /api/admin/articles/bulk-restore
+func (h *ArticleHandler) AdminBulkRestore(w http.ResponseWriter, r *http.Request) {
+	var req models.BulkArticleRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	result, err := h.service.BulkRestore(r.Context(), req.ArticleIDs)
+	if err != nil {
+		WriteInternalError(w, "failed to restore articles")
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
 // GET /api/articles/:slug/related
 func (h *ArticleHandler) GetRelatedArticles(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
@@ -271,3 +693,90 @@ func (h *ArticleHandler) GetRelatedArticles(w http.ResponseWriter, r *http.Reque
 
 	WriteSuccess(w, related)
 }
+
+// parseArticleSearchParams reads the admin article list filters from the
+// query string; saved searches store the same parameters
+func parseArticleSearchParams(r *http.Request) (models.ArticleSearchParams, error) {
+	var params models.ArticleSearchParams
+	q := r.URL.Query()
+
+	if status := q.Get("status"); status != "" {
+		s := models.ArticleStatus(status)
+		params.Status = &s
+	}
+
+	ids := []struct {
+		name string
+		dest **uuid.UUID
+	}{
+		{"category_id", &params.CategoryID},
+		{"tag_id", &params.TagID},
+		{"author_id", &params.AuthorID},
+		{"politician_id", &params.PoliticianID},
+	}
+	for _, id := range ids {
+		if v := q.Get(id.name); v != "" {
+			parsed, err := uuid.Parse(v)
+			if err != nil {
+				return params, fmt.Errorf("invalid %s", id.name)
+			}
+			*id.dest = &parsed
+		}
+	}
+
+	if search := q.Get("search"); search != "" {
+		params.Search = &search
+	}
+
+	if v := q.Get("older_than_days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 {
+			return params, fmt.Errorf("older_than_days must be a positive number")
+		}
+		params.OlderThanDays = &days
+	}
+
+	after, before, err := parsePublishedRange(q)
+	if err != nil {
+		return params, err
+	}
+	params.PublishedAfter = after
+	params.PublishedBefore = before
+
+	if v := q.Get("sort"); v != "" {
+		sort, err := models.ParseArticleSort(v)
+		if err != nil {
+			return params, err
+		}
+		params.Sort = &sort
+	}
+
+	return params, nil
+}
+
+// parsePublishedRange reads and validates the optional published_after and
+// published_before dates (YYYY-MM-DD, both inclusive)
+func parsePublishedRange(q url.Values) (after, before *string, err error) {
+	dates := []struct {
+		name string
+		dest **string
+	}{
+		{"published_after", &after},
+		{"published_before", &before},
+	}
+	for _, d := range dates {
+		if v := q.Get(d.name); v != "" {
+			if _, err := time.Parse(models.ArticleDateLayout, v); err != nil {
+				return nil, nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", d.name)
+			}
+			*d.dest = &v
+		}
+	}
+
+	params := models.ArticleSearchParams{PublishedAfter: after, PublishedBefore: before}
+	if err := params.ValidatePublishedRange(); err != nil {
+		return nil, nil, err
+	}
+
+	return after, before, nil
+}
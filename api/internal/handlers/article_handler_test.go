@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArticleSearchParams(t *testing.T) {
+	categoryID := uuid.New()
+	r := httptest.NewRequest(http.MethodGet,
+		"/api/admin/articles?status=draft&category_id="+categoryID.String()+"&search=budget&older_than_days=7", nil)
+
+	params, err := parseArticleSearchParams(r)
+	require.NoError(t, err)
+
+	filter := params.Filter()
+	require.NotNil(t, filter.Status)
+	assert.Equal(t, models.ArticleStatusDraft, *filter.Status)
+	assert.Equal(t, &categoryID, filter.CategoryID)
+	assert.Equal(t, "budget", *filter.Search)
+	assert.Equal(t, 7, *filter.OlderThanDays)
+	assert.Nil(t, filter.TagID)
+	assert.False(t, filter.IncludeDeleted)
+}
+
+func TestParseArticleSearchParamsInvalid(t *testing.T) {
+	for query, message := range map[string]string{
+		"tag_id=nope":                 "invalid tag_id",
+		"older_than_days=0":           "older_than_days must be a positive number",
+		"older_than_days=ab":          "older_than_days must be a positive number",
+		"published_after=2024-13-01":  "published_after must be a date in YYYY-MM-DD format",
+		"published_before=01/02/2024": "published_before must be a date in YYYY-MM-DD format",
+		"published_after=2024-06-01&published_before=2024-05-31": "published_after cannot be after published_before",
+		"sort=popular": "sort must be one of newest, oldest, most_viewed, most_commented",
+	} {
+		_, err := parseArticleSearchParams(httptest.NewRequest(http.MethodGet, "/api/admin/articles?"+query, nil))
+		assert.EqualError(t, err, message, query)
+	}
+}
+
+func TestParseArticleSearchParamsPublishedRange(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet,
+		"/api/admin/articles?status=published&published_after=2024-01-01&published_before=2024-01-31", nil)
+
+	params, err := parseArticleSearchParams(r)
+	require.NoError(t, err)
+
+	filter := params.Filter()
+	require.NotNil(t, filter.PublishedAfter)
+	require.NotNil(t, filter.PublishedBefore)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *filter.PublishedAfter)
+	assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), *filter.PublishedBefore)
+
+	// A single-day range is allowed
+	_, err = parseArticleSearchParams(httptest.NewRequest(http.MethodGet,
+		"/api/admin/articles?published_after=2024-01-01&published_before=2024-01-01", nil))
+	assert.NoError(t, err)
+}
+
+func TestParseArticleSearchParamsSort(t *testing.T) {
+	params, err := parseArticleSearchParams(httptest.NewRequest(http.MethodGet, "/api/admin/articles?sort=most_commented", nil))
+	require.NoError(t, err)
+	assert.Equal(t, models.ArticleSortMostCommented, params.Filter().Sort)
+
+	// No sort keeps the repository default
+	params, err = parseArticleSearchParams(httptest.NewRequest(http.MethodGet, "/api/admin/articles", nil))
+	require.NoError(t, err)
+	assert.Nil(t, params.Sort)
+}
+
+func TestListRejectsUnknownSort(t *testing.T) {
+	h := &ArticleHandler{}
+	w := httptest.NewRecorder()
+	h.List(w, httptest.NewRequest(http.MethodGet, "/api/articles?sort=random", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestReactionsRequireLogin(t *testing.T) {
+	h := &ArticleHandler{}
+	for method, handler := range map[string]http.HandlerFunc{
+		http.MethodPost:   h.AddReaction,
+		http.MethodDelete: h.RemoveReaction,
+	} {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(method, "/api/articles/budget-hearing/reactions", strings.NewReader(`{"reaction":"heart"}`)))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, method)
+		var body models.APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.NotNil(t, body.Error, method)
+		assert.Equal(t, "LOGIN_REQUIRED", body.Error.Code, method)
+	}
+}
+
+// A saved search stores the parsed params as JSON; running it must build the
+// same filter as the live query it was saved from
+func TestSavedSearchParamsMatchLiveFilter(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet,
+		"/api/admin/articles?status=published&author_id="+uuid.NewString()+"&politician_id="+uuid.NewString()+"&older_than_days=30&published_after=2024-01-01&sort=oldest", nil)
+
+	live, err := parseArticleSearchParams(r)
+	require.NoError(t, err)
+
+	stored, err := json.Marshal(live)
+	require.NoError(t, err)
+
+	var saved models.ArticleSearchParams
+	require.NoError(t, json.Unmarshal(stored, &saved))
+
+	assert.Equal(t, live.Filter(), saved.Filter())
+}
+
+func TestParseScheduleRange(t *testing.T) {
+	manila := time.FixedZone("Asia/Manila", 8*60*60)
+	// 2025-05-12 01:30 in Manila
+	now := time.Date(2025, 5, 11, 17, 30, 0, 0, time.UTC)
+
+	parse := func(query string) (time.Time, time.Time, error) {
+		return parseScheduleRange(httptest.NewRequest(http.MethodGet, "/api/admin/articles/schedule?"+query, nil).URL.Query(), manila, now)
+	}
+
+	// Defaults to the week starting at local midnight
+	from, to, err := parse("")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 5, 11, 16, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 5, 18, 16, 0, 0, 0, time.UTC), to)
+
+	// A date for to covers the whole local day
+	from, to, err = parse("from=2025-06-01&to=2025-06-01")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 5, 31, 16, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 6, 1, 15, 59, 59, 999999999, time.UTC), to)
+
+	from, to, err = parse("from=2025-06-01T09:00&to=2025-06-01T12:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 1, 1, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), to)
+
+	for query, message := range map[string]string{
+		"from=soon":                       "from must be a date (YYYY-MM-DD) or time (YYYY-MM-DDTHH:MM)",
+		"from=2025-06-02&to=2025-06-01":   "to must not be before from",
+		"from=2025-01-01&to=2025-12-31":   "schedule range must not exceed 92 days",
+		"from=2025-06-01&to=2025-06-01T7": "to must be a date (YYYY-MM-DD) or time (YYYY-MM-DDTHH:MM)",
+	} {
+		_, _, err := parse(query)
+		assert.EqualError(t, err, message, query)
+	}
+}
+
+func TestArticleReaderVisitorID(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/articles/budget", nil)
+	r.AddCookie(&http.Cookie{Name: "visitor_id", Value: "cookie-id"})
+	assert.Equal(t, "cookie-id", articleReader(r).VisitorID)
+
+	// The header wins over the cookie
+	r.Header.Set("X-Visitor-ID", "header_id")
+	assert.Equal(t, "header_id", articleReader(r).VisitorID)
+
+	for _, id := range []string{"has space", "meter:*", strings.Repeat("a", maxVisitorIDLength+1)} {
+		r := httptest.NewRequest(http.MethodGet, "/api/articles/budget", nil)
+		r.Header.Set("X-Visitor-ID", id)
+		assert.Empty(t, articleReader(r).VisitorID, id)
+	}
+}
+
+func TestArticleExportRecord(t *testing.T) {
+	id := uuid.New()
+	author := "Maria Santos"
+	publishedAt := time.Date(2024, time.May, 2, 8, 30, 0, 0, time.FixedZone("PHT", 8*3600))
+
+	assert.Equal(t,
+		[]string{id.String(), "Budget, explained", "budget", "published", "Maria Santos", "", "2024-05-02T00:30:00Z", "120", "4"},
+		articleExportRecord(&models.ArticleExportRow{
+			ID: id, Title: "Budget, explained", Slug: "budget", Status: models.ArticleStatusPublished,
+			Author: &author, PublishedAt: &publishedAt, ViewCount: 120, CommentCount: 4,
+		}),
+	)
+	assert.Len(t, articleExportHeader, 9)
+
+	// Cells a spreadsheet would run as formulas are quoted
+	category := "@SUM(A1)"
+	record := articleExportRecord(&models.ArticleExportRow{
+		ID: id, Title: "=HYPERLINK(\"http://example.com\")", Slug: "-budget", Status: models.ArticleStatusDraft,
+		Author: &author, Category: &category,
+	})
+	assert.Equal(t, "'=HYPERLINK(\"http://example.com\")", record[1])
+	assert.Equal(t, "'-budget", record[2])
+	assert.Equal(t, "Maria Santos", record[4])
+	assert.Equal(t, "'@SUM(A1)", record[5])
+	assert.Equal(t, "'+63 912", csvText("+63 912"))
+}
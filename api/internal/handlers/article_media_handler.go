@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// GET /api/admin/articles/:id/media
+func (h *ArticleHandler) ListMedia(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid article ID")
+		return
+	}
+
+	gallery, err := h.service.ListMedia(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "failed to list article media")
+		return
+	}
+	if gallery == nil {
+		WriteNotFound(w, "article not found")
+		return
+	}
+
+	WriteSuccess(w, gallery)
+}
+
+// POST /api/admin/articles/:id/media - Add an uploaded image to the end of
+// the gallery
+func (h *ArticleHandler) CreateMedia(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid article ID")
+		return
+	}
+
+	var req models.CreateArticleMediaRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	m, err := h.service.CreateMedia(r.Context(), id, &req)
+	if err != nil {
+		writeArticleMediaError(w, err, "failed to create article media")
+		return
+	}
+
+	WriteCreated(w, m)
+}
+
+// PUT /api/admin/articles/:id/media/order
+func (h *ArticleHandler) ReorderMedia(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid article ID")
+		return
+	}
+
+	var req models.ReorderArticleMediaRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	gallery, err := h.service.ReorderMedia(r.Context(), id, req.MediaIDs)
+	if err != nil {
+		writeArticleMediaError(w, err, "failed to reorder article media")
+		return
+	}
+
+	WriteSuccess(w, gallery)
+}
+
+// PUT /api/admin/articles/:id/media/:mediaId
+func (h *ArticleHandler) UpdateMedia(w http.ResponseWriter, r *http.Request) {
+	id, mediaID, ok := parseArticleMediaIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateArticleMediaRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	m, err := h.service.UpdateMedia(r.Context(), id, mediaID, &req)
+	if err != nil {
+		writeArticleMediaError(w, err, "failed to update article media")
+		return
+	}
+
+	WriteSuccess(w, m)
+}
+
+// DELETE /api/admin/articles/:id/media/:mediaId
+func (h *ArticleHandler) DeleteMedia(w http.ResponseWriter, r *http.Request) {
+	id, mediaID, ok := parseArticleMediaIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteMedia(r.Context(), id, mediaID); err != nil {
+		writeArticleMediaError(w, err, "failed to delete article media")
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "article media deleted"})
+}
+
+// POST /api/admin/articles/backfill-featured-media - Give articles without
+// featured media their featured image, or the first image in their content
+// as a provisional one
+func (h *ArticleHandler) BackfillFeaturedImages(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.BackfillFeaturedImages(r.Context())
+	if err != nil {
+		WriteInternalError(w, "failed to backfill featured images")
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
+func parseArticleMediaIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid article ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	mediaID, err := uuid.Parse(chi.URLParam(r, "mediaId"))
+	if err != nil {
+		WriteBadRequest(w, "invalid media ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, mediaID, true
+}
+
+func writeArticleMediaError(w http.ResponseWriter, err error, fallback string) {
+	switch msg := err.Error(); msg {
+	case "article not found", "article media not found":
+		WriteNotFound(w, msg)
+	case "media IDs must list every item of the gallery once":
+		WriteBadRequest(w, msg)
+	default:
+		WriteInternalError(w, fallback)
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// GET /api/admin/articles/:id/sources
+func (h *ArticleHandler) ListSources(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid article ID")
+		return
+	}
+
+	sources, err := h.service.ListSources(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "failed to list article sources")
+		return
+	}
+	if sources == nil {
+		WriteNotFound(w, "article not found")
+		return
+	}
+
+	WriteSuccess(w, sources)
+}
+
+// POST /api/admin/articles/:id/sources
+func (h *ArticleHandler) CreateSource(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid article ID")
+		return
+	}
+
+	var req models.CreateArticleSourceRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	source, err := h.service.CreateSource(r.Context(), id, &req)
+	if err != nil {
+		writeArticleSourceError(w, err, "failed to create article source")
+		return
+	}
+
+	WriteCreated(w, source)
+}
+
+// PUT /api/admin/articles/:id/sources/:sourceId
+func (h *ArticleHandler) UpdateSource(w http.ResponseWriter, r *http.Request) {
+	id, sourceID, ok := parseArticleSourceIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateArticleSourceRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	source, err := h.service.UpdateSource(r.Context(), id, sourceID, &req)
+	if err != nil {
+		writeArticleSourceError(w, err, "failed to update article source")
+		return
+	}
+
+	WriteSuccess(w, source)
+}
+
+// DELETE /api/admin/articles/:id/sources/:sourceId
+func (h *ArticleHandler) DeleteSource(w http.ResponseWriter, r *http.Request) {
+	id, sourceID, ok := parseArticleSourceIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSource(r.Context(), id, sourceID); err != nil {
+		writeArticleSourceError(w, err, "failed to delete article source")
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "article source deleted"})
+}
+
+func parseArticleSourceIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid article ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	sourceID, err := uuid.Parse(chi.URLParam(r, "sourceId"))
+	if err != nil {
+		WriteBadRequest(w, "invalid source ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, sourceID, true
+}
+
+func writeArticleSourceError(w http.ResponseWriter, err error, fallback string) {
+	switch msg := err.Error(); msg {
+	case "article not found", "article source not found":
+		WriteNotFound(w, msg)
+	default:
+		WriteInternalError(w, fallback)
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/middleware"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
@@ -58,6 +59,42 @@ func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, user)
 }
 
+// POST /api/admin/users/:id/impersonate
+func (h *AuthHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "not authenticated")
+		return
+	}
+
+	adminID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "invalid user ID")
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid user ID")
+		return
+	}
+
+	response, err := h.authService.Impersonate(r.Context(), adminID, userID, getClientIP(r))
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			WriteNotFound(w, err.Error())
+		case "cannot impersonate an admin", "cannot impersonate yourself":
+			WriteForbidden(w, err.Error())
+		default:
+			WriteInternalError(w, "failed to start impersonation")
+		}
+		return
+	}
+
+	WriteSuccess(w, response)
+}
+
 // POST /api/admin/users
 func (h *AuthHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateUserRequest
@@ -68,7 +68,7 @@ func (h *AuthorHandler) GetArticlesBySlug(w http.ResponseWriter, r *http.Request
 
 	WriteSuccess(w, map[string]interface{}{
 		"author":   author,
-		"articles": articles,
+		"articles": PaginatedData(w, r, articles),
 	})
 }
 
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/middleware"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+)
+
+// Bill Documents - Admin Endpoints
+
+func (h *BillHandler) ListDocuments(w http.ResponseWriter, r *http.Request) {
+	bill, ok := h.getBillByID(w, r)
+	if !ok {
+		return
+	}
+
+	docs, err := h.service.ListBillDocuments(r.Context(), bill.ID)
+	if err != nil {
+		WriteInternalError(w, "Failed to list bill documents")
+		return
+	}
+	WriteSuccess(w, docs)
+}
+
+// UploadDocument attaches a PDF to a bill (multipart "file" and "document_type")
+func (h *BillHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	bill, ok := h.getBillByID(w, r)
+	if !ok {
+		return
+	}
+
+	// Limit request body size
+	r.Body = http.MaxBytesReader(w, r.Body, services.MaxBillDocumentSize+1024)
+
+	if err := r.ParseMultipartForm(services.MaxBillDocumentSize); err != nil {
+		WriteBadRequest(w, "file too large or invalid form data")
+		return
+	}
+
+	documentType := r.FormValue("document_type")
+	if !models.IsValidBillDocumentType(documentType) {
+		WriteBadRequest(w, "document_type must be one of filed_copy, committee_report, enrolled_copy")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		WriteBadRequest(w, "file is required")
+		return
+	}
+	defer file.Close()
+
+	var uploadedBy *uuid.UUID
+	if claims := middleware.GetUserClaims(r.Context()); claims != nil {
+		if id, err := uuid.Parse(claims.UserID); err == nil {
+			uploadedBy = &id
+		}
+	}
+
+	doc, err := h.service.AddBillDocument(r.Context(), bill.ID, documentType, file, header.Filename, uploadedBy)
+	if err != nil {
+		h.writeDocumentError(w, err, "Failed to upload bill document")
+		return
+	}
+	WriteCreated(w, doc)
+}
+
+func (h *BillHandler) DeleteDocument(w http.ResponseWriter, r *http.Request) {
+	bill, ok := h.getBillByID(w, r)
+	if !ok {
+		return
+	}
+
+	documentID, err := uuid.Parse(chi.URLParam(r, "documentId"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid document ID")
+		return
+	}
+
+	doc, err := h.service.GetBillDocument(r.Context(), bill.ID, documentID)
+	if err != nil {
+		WriteInternalError(w, "Failed to get bill document")
+		return
+	}
+	if doc == nil {
+		WriteNotFound(w, "Bill document not found")
+		return
+	}
+
+	if err := h.service.DeleteBillDocument(r.Context(), doc); err != nil {
+		h.writeDocumentError(w, err, "Failed to delete bill document")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *BillHandler) writeDocumentError(w http.ResponseWriter, err error, fallback string) {
+	switch msg := err.Error(); {
+	case msg == "file must be a PDF", strings.HasPrefix(msg, "file size exceeds"):
+		WriteBadRequest(w, msg)
+	case msg == "bill not found":
+		WriteNotFound(w, "Bill not found")
+	case msg == "bill document not found":
+		WriteNotFound(w, "Bill document not found")
+	default:
+		WriteInternalError(w, fallback)
+	}
+}
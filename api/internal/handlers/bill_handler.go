@@ -78,8 +78,189 @@ func (h *BillHandler) GetCommitteeBySlug(w http.ResponseWriter, r *http.Request)
 	WriteSuccess(w, committee)
 }
 
+// GetCommitteeBills lists the bills referred to a committee
+func (h *BillHandler) GetCommitteeBills(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	committee, err := h.service.GetCommitteeBySlug(r.Context(), slug)
+	if err != nil {
+		WriteInternalError(w, "Failed to get committee")
+		return
+	}
+	if committee == nil {
+		WriteNotFound(w, "Committee not found")
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage < 1 || perPage > 50 {
+		perPage = 20
+	}
+
+	bills, err := h.service.ListCommitteeBills(r.Context(), committee.ID, page, perPage)
+	if err != nil {
+		WriteInternalError(w, "Failed to list committee bills")
+		return
+	}
+	WritePaginated(w, r, bills)
+}
+
+// Committee Memberships - Admin Endpoints
+
+func (h *BillHandler) ListCommitteeMembers(w http.ResponseWriter, r *http.Request) {
+	committee, ok := h.getCommittee(w, r)
+	if !ok {
+		return
+	}
+
+	memberships, err := h.service.ListCommitteeMemberships(r.Context(), committee.ID)
+	if err != nil {
+		WriteInternalError(w, "Failed to list committee members")
+		return
+	}
+	WriteSuccess(w, memberships)
+}
+
+func (h *BillHandler) AddCommitteeMember(w http.ResponseWriter, r *http.Request) {
+	committee, ok := h.getCommittee(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateCommitteeMembershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	membership, err := h.service.CreateCommitteeMembership(r.Context(), committee, &req)
+	if err != nil {
+		h.writeMembershipError(w, err, "Failed to add committee member")
+		return
+	}
+	WriteCreated(w, membership)
+}
+
+func (h *BillHandler) UpdateCommitteeMember(w http.ResponseWriter, r *http.Request) {
+	committee, membership, ok := h.getCommitteeMembership(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCommitteeMembershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	updated, err := h.service.UpdateCommitteeMembership(r.Context(), committee, membership, &req)
+	if err != nil {
+		h.writeMembershipError(w, err, "Failed to update committee member")
+		return
+	}
+	WriteSuccess(w, updated)
+}
+
+func (h *BillHandler) RemoveCommitteeMember(w http.ResponseWriter, r *http.Request) {
+	committee, membership, ok := h.getCommitteeMembership(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteCommitteeMembership(r.Context(), committee, membership); err != nil {
+		h.writeMembershipError(w, err, "Failed to remove committee member")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getCommittee loads the committee from the URL
+func (h *BillHandler) getCommittee(w http.ResponseWriter, r *http.Request) (*models.Committee, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid committee ID")
+		return nil, false
+	}
+
+	committee, err := h.service.GetCommitteeByID(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "Failed to get committee")
+		return nil, false
+	}
+	if committee == nil {
+		WriteNotFound(w, "Committee not found")
+		return nil, false
+	}
+
+	return committee, true
+}
+
+// getCommitteeMembership loads the membership from the URL and checks it belongs to the committee
+func (h *BillHandler) getCommitteeMembership(w http.ResponseWriter, r *http.Request) (*models.Committee, *models.CommitteeMembership, bool) {
+	committee, ok := h.getCommittee(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+
+	membershipID, err := uuid.Parse(chi.URLParam(r, "memberId"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid membership ID")
+		return nil, nil, false
+	}
+
+	membership, err := h.service.GetCommitteeMembership(r.Context(), committee.ID, membershipID)
+	if err != nil {
+		WriteInternalError(w, "Failed to get committee membership")
+		return nil, nil, false
+	}
+	if membership == nil {
+		WriteNotFound(w, "Committee membership not found")
+		return nil, nil, false
+	}
+
+	return committee, membership, true
+}
+
+func (h *BillHandler) writeMembershipError(w http.ResponseWriter, err error, fallback string) {
+	switch err.Error() {
+	case "politician not found", "end_date cannot be before start_date":
+		WriteBadRequest(w, err.Error())
+	case "committee membership not found":
+		WriteNotFound(w, "Committee membership not found")
+	case "committee already has a chair for this period", "committee already has a vice chair for this period":
+		WriteError(w, http.StatusConflict, "COMMITTEE_ROLE_TAKEN", err.Error())
+	default:
+		WriteInternalError(w, fallback)
+	}
+}
+
 // Bills - Public Endpoints
 
+// GET /api/legislation/bills/autocomplete?q= - Up to 8 bills whose number
+// starts with q or whose title contains it
+func (h *BillHandler) AutocompleteBills(w http.ResponseWriter, r *http.Request) {
+	suggestions, err := h.service.AutocompleteBills(r.Context(), r.URL.Query().Get("q"))
+	if err != nil {
+		WriteInternalError(w, "failed to autocomplete bills")
+		return
+	}
+
+	WriteSuccess(w, suggestions)
+}
+
 func (h *BillHandler) ListBills(w http.ResponseWriter, r *http.Request) {
 	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
 	if page < 1 {
@@ -113,6 +294,11 @@ func (h *BillHandler) ListBills(w http.ResponseWriter, r *http.Request) {
 			filter.AuthorID = &id
 		}
 	}
+	if committeeID := r.URL.Query().Get("committee_id"); committeeID != "" {
+		if id, err := uuid.Parse(committeeID); err == nil {
+			filter.CommitteeID = &id
+		}
+	}
 	if search := r.URL.Query().Get("search"); search != "" {
 		filter.Search = &search
 	}
@@ -122,7 +308,7 @@ func (h *BillHandler) ListBills(w http.ResponseWriter, r *http.Request) {
 		WriteInternalError(w, "Failed to list bills")
 		return
 	}
-	WriteSuccess(w, bills)
+	WritePaginated(w, r, bills)
 }
 
 func (h *BillHandler) GetBillBySlug(w http.ResponseWriter, r *http.Request) {
@@ -170,6 +356,97 @@ func (h *BillHandler) ListAllTopics(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, topics)
 }
 
+func (h *BillHandler) GetTopicTree(w http.ResponseWriter, r *http.Request) {
+	tree, err := h.service.GetTopicTree(r.Context())
+	if err != nil {
+		WriteInternalError(w, "Failed to get topic tree")
+		return
+	}
+	WriteSuccess(w, tree)
+}
+
+func (h *BillHandler) CreateTopic(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateBillTopicRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	topic, err := h.service.CreateTopic(r.Context(), &req)
+	if err != nil {
+		if err.Error() == "parent topic not found" {
+			WriteBadRequest(w, err.Error())
+			return
+		}
+		WriteInternalError(w, "Failed to create topic")
+		return
+	}
+	WriteCreated(w, topic)
+}
+
+func (h *BillHandler) UpdateTopic(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		WriteBadRequest(w, "Invalid topic ID")
+		return
+	}
+
+	var req models.UpdateBillTopicRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	topic, err := h.service.UpdateTopic(r.Context(), id, &req)
+	if err != nil {
+		switch err.Error() {
+		case "parent topic not found", "a topic cannot be its own parent", "parent topic cannot be a descendant of this topic":
+			WriteBadRequest(w, err.Error())
+		default:
+			WriteInternalError(w, "Failed to update topic")
+		}
+		return
+	}
+	if topic == nil {
+		WriteNotFound(w, "Topic not found")
+		return
+	}
+	WriteSuccess(w, topic)
+}
+
+func (h *BillHandler) DeleteTopic(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		WriteBadRequest(w, "Invalid topic ID")
+		return
+	}
+
+	if err := h.service.DeleteTopic(r.Context(), id); err != nil {
+		switch err.Error() {
+		case "topic not found":
+			WriteNotFound(w, "Topic not found")
+		case "topic has sub-topics":
+			WriteError(w, http.StatusConflict, "TOPIC_HAS_CHILDREN", "Move or delete the sub-topics first")
+		default:
+			WriteInternalError(w, "Failed to delete topic")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Politician Voting Records
 
 func (h *BillHandler) GetPoliticianVotingHistory(w http.ResponseWriter, r *http.Request) {
@@ -194,7 +471,7 @@ func (h *BillHandler) GetPoliticianVotingHistory(w http.ResponseWriter, r *http.
 		WriteInternalError(w, "Failed to get voting history")
 		return
 	}
-	WriteSuccess(w, history)
+	WritePaginated(w, r, history)
 }
 
 func (h *BillHandler) GetPoliticianVotingRecord(w http.ResponseWriter, r *http.Request) {
@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/middleware"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/services"
 )
@@ -47,6 +49,20 @@ func (h *CategoryHandler) GetArticlesBySlug(w http.ResponseWriter, r *http.Reque
 	}
 
 	if category == nil {
+		// The slug may have been renamed
+		newSlug, err := h.categoryService.ResolveSlugRedirect(r.Context(), slug)
+		if err != nil {
+			WriteInternalError(w, "failed to fetch category")
+			return
+		}
+		if newSlug != "" {
+			WriteSlugRedirect(w, r, slug, newSlug)
+			return
+		}
+		WriteNotFound(w, "category not found")
+		return
+	}
+	if category.IsInternal {
 		WriteNotFound(w, "category not found")
 		return
 	}
@@ -65,12 +81,82 @@ func (h *CategoryHandler) GetArticlesBySlug(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Anonymous viewers still get the follower count, with is_following=false
+	var currentUserID *uuid.UUID
+	if claims := middleware.GetUserClaims(r.Context()); claims != nil {
+		if userID, err := uuid.Parse(claims.UserID); err == nil {
+			currentUserID = &userID
+		}
+	}
+
+	followStatus, err := h.categoryService.GetFollowStatus(r.Context(), category.ID, currentUserID)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch follow status")
+		return
+	}
+
 	WriteSuccess(w, map[string]interface{}{
-		"category": category,
-		"articles": articles,
+		"category":       category,
+		"articles":       PaginatedData(w, r, articles),
+		"follower_count": followStatus.FollowerCount,
+		"is_following":   followStatus.IsFollowing,
 	})
 }
 
+// POST /api/categories/:slug/follow
+func (h *CategoryHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	h.setFollowing(w, r, true)
+}
+
+// DELETE /api/categories/:slug/follow
+func (h *CategoryHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	h.setFollowing(w, r, false)
+}
+
+func (h *CategoryHandler) setFollowing(w http.ResponseWriter, r *http.Request, follow bool) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		WriteBadRequest(w, "slug is required")
+		return
+	}
+
+	category, err := h.categoryService.GetBySlug(r.Context(), slug)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch category")
+		return
+	}
+
+	if category == nil || category.IsInternal {
+		WriteNotFound(w, "category not found")
+		return
+	}
+
+	var status *models.FollowStatus
+	if follow {
+		status, err = h.categoryService.Follow(r.Context(), category.ID, userID)
+	} else {
+		status, err = h.categoryService.Unfollow(r.Context(), category.ID, userID)
+	}
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, status)
+}
+
 // GET /api/admin/categories - List all categories with pagination, search, and sorting (admin)
 func (h *CategoryHandler) AdminList(w http.ResponseWriter, r *http.Request) {
 	page, perPage := GetPaginationParams(r)
@@ -79,7 +165,7 @@ func (h *CategoryHandler) AdminList(w http.ResponseWriter, r *http.Request) {
 	sortBy := r.URL.Query().Get("sort_by")
 	sortOrder := r.URL.Query().Get("sort_order")
 
-	filter := &models.CategoryFilter{}
+	filter := &models.CategoryFilter{IncludeDeleted: GetIncludeDeleted(r)}
 	if search != "" {
 		filter.Search = &search
 	}
@@ -96,7 +182,7 @@ func (h *CategoryHandler) AdminList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, paginatedCategories)
+	WritePaginated(w, r, paginatedCategories)
 }
 
 // GET /api/admin/categories/:id
@@ -201,3 +287,44 @@ func (h *CategoryHandler) Restore(w http.ResponseWriter, r *http.Request) {
 
 	WriteSuccess(w, map[string]string{"message": "category restored"})
 }
+
+// POST /api/admin/categories/:id/migrate-articles - Move the category's
+// articles, or only those with any of filter.tag_ids, into another category
+func (h *CategoryHandler) MigrateArticles(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		WriteBadRequest(w, "invalid category ID")
+		return
+	}
+
+	var req models.MigrateArticlesRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	var tagIDs []uuid.UUID
+	if req.Filter != nil {
+		tagIDs = req.Filter.TagIDs
+	}
+
+	result, err := h.categoryService.MigrateArticles(r.Context(), id, req.TargetCategoryID, tagIDs, req.DeleteSource, req.Confirm)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "moving published articles into an internal category") {
+			WriteError(w, http.StatusConflict, "CONFIRMATION_REQUIRED", err.Error())
+			return
+		}
+		switch err.Error() {
+		case "category not found":
+			WriteNotFound(w, err.Error())
+		case "target category not found", "target category must differ from the source":
+			WriteBadRequest(w, err.Error())
+		default:
+			WriteInternalError(w, "failed to migrate articles")
+		}
+		return
+	}
+
+	WriteSuccess(w, result)
+}
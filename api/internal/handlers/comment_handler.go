@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
@@ -22,7 +23,7 @@ func NewCommentHandler(commentService *services.CommentService) *CommentHandler
 	}
 }
 
-// ListComments GET /api/articles/{slug}/comments - List comments for an article
+// ListComments GET /api/articles/{slug}/comments?sort=newest|oldest|top&page=&per_page= - List paginated comments for an article
 func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
@@ -30,6 +31,12 @@ func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	sort, err := models.ParseCommentSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
 	// Get current user ID if authenticated (for reaction status)
 	var currentUserID *uuid.UUID
 	includeHidden := false
@@ -44,13 +51,15 @@ func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	comments, err := h.commentService.ListArticleComments(r.Context(), slug, currentUserID, includeHidden)
+	page, perPage := GetPaginationParams(r)
+
+	result, err := h.commentService.ListArticleComments(r.Context(), slug, currentUserID, includeHidden, sort, page, perPage)
 	if err != nil {
 		WriteNotFound(w, err.Error())
 		return
 	}
 
-	WriteSuccess(w, comments)
+	WritePaginated(w, r, result)
 }
 
 // CreateComment POST /api/articles/{slug}/comments - Create a new comment
@@ -85,7 +94,9 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	comment, err := h.commentService.CreateComment(r.Context(), slug, userID, &req)
+	isAdmin := claims.Role == "admin"
+
+	comment, err := h.commentService.CreateComment(r.Context(), slug, userID, isAdmin, &req)
 	if err != nil {
 		errMsg := err.Error()
 		// Check for foreign key violation (user doesn't exist)
@@ -93,6 +104,17 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 			WriteUnauthorized(w, "user session invalid - please log out and log in again")
 			return
 		}
+		switch errMsg {
+		case "you cannot reply to this comment":
+			WriteError(w, http.StatusForbidden, "REPLY_BLOCKED", errMsg)
+			return
+		case "comments are disabled on this article":
+			WriteError(w, http.StatusForbidden, "COMMENTS_DISABLED", errMsg)
+			return
+		case "comments are locked on this article":
+			WriteError(w, http.StatusForbidden, "COMMENTS_LOCKED", errMsg)
+			return
+		}
 		WriteInternalError(w, errMsg)
 		return
 	}
@@ -418,13 +440,36 @@ func (h *CommentHandler) ListAllComments(w http.ResponseWriter, r *http.Request)
 		currentUserID = &userID
 	}
 
-	// Optional status filter
-	var filter *models.CommentFilter
+	// Optional status, sentiment and toxicity filters
+	filter := &models.CommentFilter{}
 	if statusParam := r.URL.Query().Get("status"); statusParam != "" {
 		status := models.CommentStatus(statusParam)
-		filter = &models.CommentFilter{
-			Status: &status,
+		filter.Status = &status
+	}
+	if v := r.URL.Query().Get("sentiment_lt"); v != "" {
+		threshold, err := strconv.ParseFloat(v, 64)
+		if err != nil || threshold < -1 || threshold > 1 {
+			WriteBadRequest(w, "sentiment_lt must be a number from -1 to 1")
+			return
 		}
+		filter.SentimentLt = &threshold
+	}
+	if v := r.URL.Query().Get("min_toxicity"); v != "" {
+		threshold, err := strconv.ParseFloat(v, 64)
+		if err != nil || threshold < 0 || threshold > 1 {
+			WriteBadRequest(w, "min_toxicity must be a number from 0 to 1")
+			return
+		}
+		filter.MinToxicity = &threshold
+	}
+	filter.Unscored = r.URL.Query().Get("unscored") == "true"
+	switch sort := models.CommentSort(r.URL.Query().Get("sort")); sort {
+	case "", models.CommentSortNewest:
+	case models.CommentSortToxicity:
+		filter.Sort = sort
+	default:
+		WriteBadRequest(w, "sort must be newest or toxicity")
+		return
 	}
 
 	comments, err := h.commentService.ListAllComments(r.Context(), filter, currentUserID)
@@ -435,3 +480,123 @@ func (h *CommentHandler) ListAllComments(w http.ResponseWriter, r *http.Request)
 
 	WriteSuccess(w, comments)
 }
+
+// GetSentimentSummary GET /api/admin/metrics/comments/sentiment?article_id= - Average comment sentiment of an article (admin only)
+func (h *CommentHandler) GetSentimentSummary(w http.ResponseWriter, r *http.Request) {
+	articleID, err := uuid.Parse(r.URL.Query().Get("article_id"))
+	if err != nil {
+		WriteBadRequest(w, "article_id is required")
+		return
+	}
+
+	summary, err := h.commentService.GetSentimentSummary(r.Context(), articleID)
+	if err != nil {
+		WriteInternalError(w, "failed to get comment sentiment")
+		return
+	}
+
+	WriteSuccess(w, summary)
+}
+
+// ListMyMentions GET /api/me/mentions - Comments where the authenticated user was mentioned
+func (h *CommentHandler) ListMyMentions(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	page, perPage := GetPaginationParams(r)
+	unreadOnly := r.URL.Query().Get("unread_only") == "true"
+
+	result, err := h.commentService.ListUserMentions(r.Context(), userID, page, perPage, unreadOnly)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WritePaginated(w, r, result)
+}
+
+// GetMyUnreadMentionCount GET /api/me/mentions/unread-count - Count unread mentions
+func (h *CommentHandler) GetMyUnreadMentionCount(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	count, err := h.commentService.GetUnreadMentionCount(r.Context(), userID)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]int{"count": count})
+}
+
+// MarkMentionRead POST /api/me/mentions/{id}/read - Mark a mention as read
+func (h *CommentHandler) MarkMentionRead(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid mention ID")
+		return
+	}
+
+	if err := h.commentService.MarkMentionRead(r.Context(), id, userID); err != nil {
+		if err.Error() == "mention not found" {
+			WriteNotFound(w, err.Error())
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "mention marked as read"})
+}
+
+// MarkAllMentionsRead POST /api/me/mentions/read-all - Mark all mentions as read
+func (h *CommentHandler) MarkAllMentionsRead(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	if err := h.commentService.MarkAllMentionsRead(r.Context(), userID); err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "all mentions marked as read"})
+}
@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -24,8 +25,8 @@ func NewElectionHandler(service *services.ElectionService) *ElectionHandler {
 
 func (h *ElectionHandler) CreateElection(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateElectionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		WriteBadRequest(w, "Invalid request body")
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
 		return
 	}
 
@@ -114,7 +115,7 @@ func (h *ElectionHandler) ListElections(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	WriteSuccess(w, result)
+	WritePaginated(w, r, result)
 }
 
 func (h *ElectionHandler) GetUpcomingElections(w http.ResponseWriter, r *http.Request) {
@@ -147,7 +148,8 @@ func (h *ElectionHandler) GetFeaturedElections(w http.ResponseWriter, r *http.Re
 
 func (h *ElectionHandler) GetElectionCalendar(w http.ResponseWriter, r *http.Request) {
 	yearStr := r.URL.Query().Get("year")
-	year := time.Now().Year()
+	// Default to this year in the site timezone, not the server's
+	year := h.service.Today().Year()
 	if yearStr != "" {
 		if y, err := strconv.Atoi(yearStr); err == nil {
 			year = y
@@ -172,8 +174,8 @@ func (h *ElectionHandler) UpdateElection(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req models.UpdateElectionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		WriteBadRequest(w, "Invalid request body")
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
 		return
 	}
 
@@ -217,6 +219,15 @@ func (h *ElectionHandler) CreateElectionPosition(w http.ResponseWriter, r *http.
 
 	position, err := h.service.CreateElectionPosition(r.Context(), &req)
 	if err != nil {
+		if err.Error() == "government position not found" {
+			WriteNotFound(w, "Position not found")
+			return
+		}
+		var hierarchyErr *models.LocationHierarchyError
+		if errors.As(err, &hierarchyErr) {
+			WriteBadRequest(w, hierarchyErr.Message)
+			return
+		}
 		WriteInternalError(w, err.Error())
 		return
 	}
@@ -241,6 +252,78 @@ func (h *ElectionHandler) GetElectionPositions(w http.ResponseWriter, r *http.Re
 	WriteSuccess(w, positions)
 }
 
+// ReorderElectionPositions sets the ballot order of every position in an election
+func (h *ElectionHandler) ReorderElectionPositions(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid election ID")
+		return
+	}
+
+	var req models.ReorderElectionPositionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+	if len(req.PositionIDs) == 0 {
+		WriteBadRequest(w, "position_ids is required")
+		return
+	}
+
+	positions, err := h.service.ReorderElectionPositions(r.Context(), id, &req)
+	if err != nil {
+		switch err.Error() {
+		case "election not found":
+			WriteNotFound(w, "Election not found")
+		case "position_ids contains a duplicate position",
+			"position_ids contains a position from another election",
+			"position_ids must include every position in the election":
+			WriteBadRequest(w, err.Error())
+		default:
+			WriteInternalError(w, err.Error())
+		}
+		return
+	}
+
+	WriteSuccess(w, positions)
+}
+
+// SetBallotOrders moves some positions within their ballot groups
+func (h *ElectionHandler) SetBallotOrders(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid election ID")
+		return
+	}
+
+	var req models.SetBallotOrdersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+	if len(req.Orders) == 0 {
+		WriteBadRequest(w, "orders is required")
+		return
+	}
+
+	positions, err := h.service.SetBallotOrders(r.Context(), id, &req)
+	if err != nil {
+		switch err.Error() {
+		case "election not found":
+			WriteNotFound(w, "Election not found")
+		case "orders contains a duplicate position",
+			"orders contains a position from another election",
+			"order must be at least 1":
+			WriteBadRequest(w, err.Error())
+		default:
+			WriteInternalError(w, err.Error())
+		}
+		return
+	}
+
+	WriteSuccess(w, positions)
+}
+
 // Candidates
 
 func (h *ElectionHandler) CreateCandidate(w http.ResponseWriter, r *http.Request) {
@@ -252,7 +335,7 @@ func (h *ElectionHandler) CreateCandidate(w http.ResponseWriter, r *http.Request
 
 	candidate, err := h.service.CreateCandidate(r.Context(), &req)
 	if err != nil {
-		WriteInternalError(w, err.Error())
+		writeCandidateError(w, err)
 		return
 	}
 
@@ -288,7 +371,32 @@ func (h *ElectionHandler) GetCandidatesForPosition(w http.ResponseWriter, r *htt
 		return
 	}
 
-	candidates, err := h.service.GetCandidatesForPosition(r.Context(), id)
+	var issue *string
+	if v := r.URL.Query().Get("issue"); v != "" {
+		if !h.service.IsKnownIssue(v) {
+			WriteBadRequest(w, "Unknown issue")
+			return
+		}
+		issue = &v
+	}
+
+	// Under /elections/{slug}, the position must be one of that election's
+	if chi.URLParam(r, "slug") != "" {
+		election := h.getElectionBySlugParam(w, r)
+		if election == nil {
+			return
+		}
+		if err := h.service.CheckPositionInElection(r.Context(), election.ID, id); err != nil {
+			if err.Error() == "position is not contested in this election" {
+				WriteNotFound(w, "Position not found")
+				return
+			}
+			WriteInternalError(w, err.Error())
+			return
+		}
+	}
+
+	candidates, err := h.service.GetCandidatesForPosition(r.Context(), id, issue)
 	if err != nil {
 		WriteInternalError(w, err.Error())
 		return
@@ -297,6 +405,37 @@ func (h *ElectionHandler) GetCandidatesForPosition(w http.ResponseWriter, r *htt
 	WriteSuccess(w, candidates)
 }
 
+// GET /api/election-positions/:id/compare?candidates=id1,id2 - Candidates for
+// one position side by side
+func (h *ElectionHandler) CompareCandidates(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid position ID")
+		return
+	}
+
+	ids, err := models.ParseComparedCandidates(r.URL.Query().Get("candidates"))
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	comparison, err := h.service.CompareCandidates(r.Context(), id, ids)
+	if err != nil {
+		switch {
+		case err.Error() == "position not found":
+			WriteNotFound(w, "Position not found")
+		case strings.HasSuffix(err.Error(), "is not running for this position"):
+			WriteBadRequest(w, err.Error())
+		default:
+			WriteInternalError(w, err.Error())
+		}
+		return
+	}
+
+	WriteSuccess(w, comparison)
+}
+
 func (h *ElectionHandler) ListCandidates(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 
@@ -345,7 +484,7 @@ func (h *ElectionHandler) ListCandidates(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	WriteSuccess(w, result)
+	WritePaginated(w, r, result)
 }
 
 func (h *ElectionHandler) UpdateCandidate(w http.ResponseWriter, r *http.Request) {
@@ -364,7 +503,7 @@ func (h *ElectionHandler) UpdateCandidate(w http.ResponseWriter, r *http.Request
 
 	candidate, err := h.service.UpdateCandidate(r.Context(), id, &req)
 	if err != nil {
-		WriteInternalError(w, err.Error())
+		writeCandidateError(w, err)
 		return
 	}
 	if candidate == nil {
@@ -375,6 +514,49 @@ func (h *ElectionHandler) UpdateCandidate(w http.ResponseWriter, r *http.Request
 	WriteSuccess(w, candidate)
 }
 
+// POST /api/admin/candidates/:id/substitute - Replace a candidate with a new
+// one on the same ballot number
+func (h *ElectionHandler) SubstituteCandidate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid candidate ID")
+		return
+	}
+
+	var req models.SubstituteCandidateRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	substitute, err := h.service.SubstituteCandidate(r.Context(), id, &req)
+	if err != nil {
+		writeCandidateError(w, err)
+		return
+	}
+
+	WriteCreated(w, substitute)
+}
+
+func writeCandidateError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "ballot number is already taken in this position":
+		WriteError(w, http.StatusConflict, "BALLOT_NUMBER_TAKEN", err.Error())
+	case "candidate not found":
+		WriteNotFound(w, "Candidate not found")
+	case "candidate has already been substituted":
+		WriteError(w, http.StatusConflict, "CANDIDATE_SUBSTITUTED", err.Error())
+	case "politician is already a candidate for this position":
+		WriteError(w, http.StatusConflict, "ALREADY_A_CANDIDATE", err.Error())
+	case "a candidate can't substitute themselves",
+		"independent candidates can't be substituted without an override reason",
+		"substitute must be from the same party without an override reason":
+		WriteBadRequest(w, err.Error())
+	default:
+		WriteInternalError(w, err.Error())
+	}
+}
+
 // Voter Education
 
 func (h *ElectionHandler) CreateVoterEducation(w http.ResponseWriter, r *http.Request) {
@@ -406,10 +588,8 @@ func (h *ElectionHandler) GetVoterEducationBySlug(w http.ResponseWriter, r *http
 		return
 	}
 
-	// Increment view count asynchronously
-	go func() {
-		_ = h.service.IncrementVoterEducationViewCount(r.Context(), ve.ID)
-	}()
+	// Views are batched in Redis, so counting one is cheap enough to do inline
+	_ = h.service.IncrementVoterEducationViewCount(r.Context(), ve.ID)
 
 	WriteSuccess(w, ve)
 }
@@ -444,5 +624,233 @@ func (h *ElectionHandler) ListVoterEducation(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	WriteSuccess(w, result)
+	WritePaginated(w, r, result)
+}
+
+// Surveys
+
+// writeSurveyError maps survey service errors to responses
+func writeSurveyError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "election not found":
+		WriteNotFound(w, "Election not found")
+	case "survey not found":
+		WriteNotFound(w, "Survey not found")
+	case "position is not contested in this election",
+		"conducted_end cannot be before conducted_start",
+		"results list a candidate more than once",
+		"results include a candidate not running for this position",
+		"conducted_start must be a date in YYYY-MM-DD format",
+		"conducted_end must be a date in YYYY-MM-DD format",
+		"published_at must be a date in YYYY-MM-DD format":
+		WriteBadRequest(w, err.Error())
+	default:
+		WriteInternalError(w, err.Error())
+	}
+}
+
+// getElectionBySlugParam loads the election named by the slug URL parameter,
+// writing a response and returning nil if there is none
+func (h *ElectionHandler) getElectionBySlugParam(w http.ResponseWriter, r *http.Request) *models.Election {
+	election, err := h.service.GetElectionBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return nil
+	}
+	if election == nil {
+		WriteNotFound(w, "Election not found")
+		return nil
+	}
+	return election
+}
+
+// GET /api/elections/{slug}/surveys
+func (h *ElectionHandler) GetElectionSurveys(w http.ResponseWriter, r *http.Request) {
+	election := h.getElectionBySlugParam(w, r)
+	if election == nil {
+		return
+	}
+
+	surveys, err := h.service.ListSurveys(r.Context(), election.ID)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, surveys)
+}
+
+// GET /api/elections/{slug}/positions/{positionId}/survey-trend
+func (h *ElectionHandler) GetSurveyTrend(w http.ResponseWriter, r *http.Request) {
+	positionID, err := uuid.Parse(chi.URLParam(r, "positionId"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid position ID")
+		return
+	}
+
+	election := h.getElectionBySlugParam(w, r)
+	if election == nil {
+		return
+	}
+
+	trend, err := h.service.GetSurveyTrend(r.Context(), election.ID, positionID)
+	if err != nil {
+		if err.Error() == "position is not contested in this election" {
+			WriteNotFound(w, "Position not found")
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, trend)
+}
+
+// GET /api/elections/{slug}/issues-matrix?position_id=
+func (h *ElectionHandler) GetIssuesMatrix(w http.ResponseWriter, r *http.Request) {
+	positionID, err := uuid.Parse(r.URL.Query().Get("position_id"))
+	if err != nil {
+		WriteBadRequest(w, "position_id is required")
+		return
+	}
+
+	election := h.getElectionBySlugParam(w, r)
+	if election == nil {
+		return
+	}
+
+	matrix, err := h.service.GetIssuesMatrix(r.Context(), election.ID, positionID)
+	if err != nil {
+		if err.Error() == "position is not contested in this election" {
+			WriteNotFound(w, "Position not found")
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, matrix)
+}
+
+// GET /api/elections/{slug}/results
+func (h *ElectionHandler) GetResultsSummary(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.service.GetResultsSummary(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		if err.Error() == "election not found" {
+			WriteNotFound(w, "Election not found")
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, summary)
+}
+
+// GET /api/elections/{slug}/results/by-coalition
+func (h *ElectionHandler) GetCoalitionResults(w http.ResponseWriter, r *http.Request) {
+	results, err := h.service.GetCoalitionResults(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		if err.Error() == "election not found" {
+			WriteNotFound(w, "Election not found")
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, results)
+}
+
+// GET /api/admin/elections/{id}/surveys
+func (h *ElectionHandler) AdminListSurveys(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid election ID")
+		return
+	}
+
+	surveys, err := h.service.ListSurveys(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, surveys)
+}
+
+// POST /api/admin/elections/{id}/surveys
+func (h *ElectionHandler) CreateSurvey(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid election ID")
+		return
+	}
+
+	var req models.CreateElectionSurveyRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	survey, err := h.service.CreateSurvey(r.Context(), id, &req)
+	if err != nil {
+		writeSurveyError(w, err)
+		return
+	}
+
+	WriteCreated(w, survey)
+}
+
+// PUT /api/admin/elections/{id}/surveys/{surveyId}
+func (h *ElectionHandler) UpdateSurvey(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid election ID")
+		return
+	}
+	surveyID, err := uuid.Parse(chi.URLParam(r, "surveyId"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid survey ID")
+		return
+	}
+
+	var req models.UpdateElectionSurveyRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	survey, err := h.service.UpdateSurvey(r.Context(), id, surveyID, &req)
+	if err != nil {
+		writeSurveyError(w, err)
+		return
+	}
+	if survey == nil {
+		WriteNotFound(w, "Survey not found")
+		return
+	}
+
+	WriteSuccess(w, survey)
+}
+
+// DELETE /api/admin/elections/{id}/surveys/{surveyId}
+func (h *ElectionHandler) DeleteSurvey(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid election ID")
+		return
+	}
+	surveyID, err := uuid.Parse(chi.URLParam(r, "surveyId"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid survey ID")
+		return
+	}
+
+	if err := h.service.DeleteSurvey(r.Context(), id, surveyID); err != nil {
+		writeSurveyError(w, err)
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "Survey deleted"})
 }
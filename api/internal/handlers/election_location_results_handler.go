@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// POST /api/admin/elections/{id}/results/locations/import - Import votes by city,
+// municipality or barangay from a CSV (multipart "file"). With
+// credit_substitutes=true, votes for substituted candidates go to their
+// substitutes.
+func (h *ElectionHandler) ImportLocationResults(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid election ID")
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB limit
+		WriteBadRequest(w, "failed to parse form data")
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		WriteBadRequest(w, "file is required")
+		return
+	}
+	defer file.Close()
+
+	creditSubstitutes := r.FormValue("credit_substitutes") == "true"
+
+	result, err := h.service.ImportLocationResults(r.Context(), id, file, creditSubstitutes)
+	if err != nil {
+		if err.Error() == "election not found" {
+			WriteNotFound(w, "Election not found")
+			return
+		}
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
+// GET /api/elections/{slug}/results/map - Leading candidate and margin in each
+// province and region, for one position (optional ?position_id=)
+func (h *ElectionHandler) GetResultsMap(w http.ResponseWriter, r *http.Request) {
+	var positionID *uuid.UUID
+	if v := r.URL.Query().Get("position_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			WriteBadRequest(w, "Invalid position ID")
+			return
+		}
+		positionID = &id
+	}
+
+	result, err := h.service.GetResultsMap(r.Context(), chi.URLParam(r, "slug"), positionID)
+	if err != nil {
+		switch err.Error() {
+		case "election not found":
+			WriteNotFound(w, "Election not found")
+		case "position has no location results":
+			WriteNotFound(w, "Position has no location results")
+		default:
+			WriteInternalError(w, err.Error())
+		}
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
+// GET /api/elections/{slug}/results/locations/{citySlug} - Every position's
+// results in one city or municipality
+func (h *ElectionHandler) GetCityResults(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.GetCityResults(r.Context(), chi.URLParam(r, "slug"), chi.URLParam(r, "citySlug"))
+	if err != nil {
+		switch err.Error() {
+		case "election not found":
+			WriteNotFound(w, "Election not found")
+		case "city not found":
+			WriteNotFound(w, "City not found")
+		default:
+			WriteInternalError(w, err.Error())
+		}
+		return
+	}
+
+	WriteSuccess(w, result)
+}
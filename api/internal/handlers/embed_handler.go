@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+)
+
+const (
+	// Embeds are read-only snapshots, so browsers and CDNs may keep them a
+	// while; the service caches the data for as long
+	embedCacheControl  = "public, max-age=300, stale-while-revalidate=600"
+	oembedCacheControl = "public, max-age=3600"
+	oembedCacheAge     = 3600
+
+	embedDefaultWidth = 480
+	embedMinWidth     = 240
+)
+
+// EmbedHandler serves oEmbed discovery and the read-only poll and election
+// result boxes partner sites embed in an iframe
+type EmbedHandler struct {
+	service        *services.EmbedService
+	siteURL        string
+	embedBaseURL   string
+	frameAncestors string
+	// X-Frame-Options can't list partner origins, so it is only sent when
+	// there are none; browsers that know frame-ancestors ignore it anyway
+	sameOriginOnly bool
+	siteLocation   *time.Location
+}
+
+func NewEmbedHandler(service *services.EmbedService, siteURL, embedBaseURL string, allowedOrigins []string) *EmbedHandler {
+	return &EmbedHandler{
+		service:        service,
+		siteURL:        strings.TrimRight(siteURL, "/"),
+		embedBaseURL:   strings.TrimRight(embedBaseURL, "/"),
+		frameAncestors: models.EmbedFrameAncestors(siteURL, allowedOrigins),
+		sameOriginOnly: len(allowedOrigins) == 0,
+		siteLocation:   time.UTC,
+	}
+}
+
+// SetSiteLocation sets the timezone embed timestamps are shown in
+func (h *EmbedHandler) SetSiteLocation(loc *time.Location) {
+	h.siteLocation = loc
+}
+
+// GET /api/oembed?url=...&maxwidth=...&maxheight=...
+func (h *EmbedHandler) OEmbed(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if format := query.Get("format"); format != "" && format != "json" {
+		WriteError(w, http.StatusNotImplemented, "NOT_IMPLEMENTED", "only the json format is supported")
+		return
+	}
+
+	rawURL := query.Get("url")
+	if rawURL == "" {
+		WriteBadRequest(w, "url is required")
+		return
+	}
+	target, ok := models.ParseEmbedURL(rawURL, h.siteURL)
+	if !ok {
+		WriteNotFound(w, "url cannot be embedded")
+		return
+	}
+
+	var title, src string
+	var height int
+	switch target.Type {
+	case models.EmbedTypePoll:
+		poll, err := h.service.GetPollEmbed(r.Context(), target.Slug)
+		if err != nil {
+			WriteInternalError(w, "failed to get poll")
+			return
+		}
+		if poll == nil {
+			WriteNotFound(w, "poll not found")
+			return
+		}
+		title = poll.Title
+		src = h.embedBaseURL + "/embed/polls/" + poll.Slug
+		height = 130 + 44*len(poll.Options)
+	case models.EmbedTypeElection:
+		election, err := h.service.GetElectionResultsEmbed(r.Context(), target.Slug, target.PositionID)
+		if err != nil {
+			WriteInternalError(w, "failed to get election results")
+			return
+		}
+		if election == nil {
+			WriteNotFound(w, "election not found")
+			return
+		}
+		title = election.Name
+		src = h.embedBaseURL + "/embed/elections/" + election.Slug + "/results"
+		if target.PositionID != nil {
+			src += "?position=" + target.PositionID.String()
+		}
+		height = 110
+		for _, position := range election.Positions {
+			height += 48 + 30*len(position.Candidates)
+		}
+	}
+
+	width := embedDefaultWidth
+	if limit, err := strconv.Atoi(query.Get("maxwidth")); err == nil && limit > 0 {
+		width = min(width, max(limit, embedMinWidth))
+	}
+	if limit, err := strconv.Atoi(query.Get("maxheight")); err == nil && limit > 0 {
+		// The box scrolls when it is cut short
+		height = min(height, limit)
+	}
+
+	w.Header().Set("Cache-Control", oembedCacheControl)
+	WriteJSON(w, http.StatusOK, models.OEmbedResponse{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        title,
+		ProviderName: "Pulpulitiko",
+		ProviderURL:  h.siteURL,
+		CacheAge:     oembedCacheAge,
+		HTML: fmt.Sprintf(
+			`<iframe src="%s" width="%d" height="%d" title="%s" loading="lazy" style="border:0;max-width:100%%"></iframe>`,
+			html.EscapeString(src), width, height, html.EscapeString(title),
+		),
+		Width:  width,
+		Height: height,
+	})
+}
+
+// GET /embed/polls/{slug}
+func (h *EmbedHandler) Poll(w http.ResponseWriter, r *http.Request) {
+	poll, err := h.service.GetPollEmbed(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		http.Error(w, "Failed to load poll", http.StatusInternalServerError)
+		return
+	}
+	if poll == nil {
+		http.Error(w, "Poll not found", http.StatusNotFound)
+		return
+	}
+
+	h.render(w, "poll", embedPage{
+		Title:   poll.Title,
+		SiteURL: h.siteURL,
+		PageURL: h.siteURL + "/polls/" + poll.Slug,
+		ViewURL: viewURL(models.EmbedTypePoll, poll.ID),
+		Poll:    poll,
+	})
+}
+
+// GET /embed/elections/{slug}/results?position={id}
+func (h *EmbedHandler) ElectionResults(w http.ResponseWriter, r *http.Request) {
+	var positionID *uuid.UUID
+	if raw := r.URL.Query().Get("position"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			http.Error(w, "Invalid position", http.StatusBadRequest)
+			return
+		}
+		positionID = &id
+	}
+
+	election, err := h.service.GetElectionResultsEmbed(r.Context(), chi.URLParam(r, "slug"), positionID)
+	if err != nil {
+		http.Error(w, "Failed to load election results", http.StatusInternalServerError)
+		return
+	}
+	if election == nil {
+		http.Error(w, "Election not found", http.StatusNotFound)
+		return
+	}
+
+	h.render(w, "election", embedPage{
+		Title:    election.Name,
+		SiteURL:  h.siteURL,
+		PageURL:  h.siteURL + "/elections/" + election.Slug,
+		ViewURL:  viewURL(models.EmbedTypeElection, election.ID),
+		AsOf:     h.formatAsOf(election.DataAsOf),
+		Election: election,
+	})
+}
+
+// POST /embed/views?type=...&id=...&ref=...
+//
+// Sent by the embed once it loads, with ref set to the embedding page. The
+// embed itself is cached, so its own request can't be counted.
+func (h *EmbedHandler) RecordView(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	contentType := query.Get("type")
+	if contentType != models.EmbedTypePoll && contentType != models.EmbedTypeElection {
+		WriteBadRequest(w, "invalid type")
+		return
+	}
+	id, err := uuid.Parse(query.Get("id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid id")
+		return
+	}
+
+	if err := h.service.RecordView(r.Context(), contentType, id, query.Get("ref")); err != nil {
+		WriteInternalError(w, "failed to record view")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /api/admin/metrics/embeds?days=30
+//
+// Lists the most viewed embedded polls and election results over the last
+// ?days= days, with the sites embedding them most
+func (h *EmbedHandler) GetReach(w http.ResponseWriter, r *http.Request) {
+	days, ok := parseReferrerDays(w, r)
+	if !ok {
+		return
+	}
+
+	reach, err := h.service.GetReach(r.Context(), days, 20)
+	if err != nil {
+		WriteInternalError(w, "Failed to get embed reach")
+		return
+	}
+
+	WriteSuccess(w, reach)
+}
+
+// formatAsOf writes when embedded data was last updated, in the site
+// timezone
+func (h *EmbedHandler) formatAsOf(t time.Time) string {
+	return t.In(h.siteLocation).Format("Jan 2, 2006 3:04 PM")
+}
+
+func viewURL(contentType string, id uuid.UUID) string {
+	return "/embed/views?type=" + contentType + "&id=" + id.String()
+}
+
+// embedPage is the data the embed templates render
+type embedPage struct {
+	Title    string
+	SiteURL  string
+	PageURL  string
+	ViewURL  string
+	AsOf     string // When the data was last updated, in the site timezone
+	Poll     *models.PollEmbed
+	Election *models.ElectionResultsEmbed
+}
+
+func (h *EmbedHandler) render(w http.ResponseWriter, name string, page embedPage) {
+	var buf bytes.Buffer
+	if err := embedTemplates.ExecuteTemplate(&buf, name, page); err != nil {
+		http.Error(w, "Failed to render embed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Security-Policy", h.frameAncestors)
+	if h.sameOriginOnly {
+		w.Header().Set("X-Frame-Options", "SAMEORIGIN")
+	}
+	w.Header().Set("Cache-Control", embedCacheControl)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	writeText(w, "text/html; charset=utf-8", buf.String())
+}
+
+var embedTemplates = template.Must(template.New("embed").Funcs(template.FuncMap{
+	"percent": func(p float64) string { return strconv.FormatFloat(p, 'f', 1, 64) + "%" },
+	"votes":   formatVotes,
+}).Parse(embedTemplateSource))
+
+// formatVotes writes a vote count with thousands separators
+func formatVotes(n int) string {
+	if n < 0 {
+		return "-" + formatVotes(-n)
+	}
+	digits := strconv.Itoa(n)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+const embedTemplateSource = `
+{{define "head"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}} - Pulpulitiko</title>
+<style>
+body{margin:0;font:14px/1.4 system-ui,-apple-system,"Segoe UI",Roboto,sans-serif;color:#1f2937;background:#fff}
+.box{border:1px solid #e5e7eb;border-radius:8px;padding:16px}
+h1{font-size:16px;margin:0 0 12px}
+h2{font-size:14px;margin:12px 0 6px}
+.row{margin:6px 0}
+.label{display:flex;justify-content:space-between;gap:8px}
+.bar{height:6px;background:#f3f4f6;border-radius:3px;overflow:hidden;margin-top:3px}
+.bar span{display:block;height:100%;background:#2563eb}
+.winner{font-weight:600}
+.muted{color:#6b7280;font-size:12px}
+.footer{display:flex;justify-content:space-between;align-items:center;margin-top:12px}
+a{color:#2563eb;text-decoration:none}
+.button{background:#2563eb;color:#fff;padding:6px 12px;border-radius:6px}
+</style>
+</head>
+<body>
+<div class="box">{{end}}
+
+{{define "foot"}}</div>
+<script>
+navigator.sendBeacon && navigator.sendBeacon({{.ViewURL}} + "&ref=" + encodeURIComponent(document.referrer));
+</script>
+</body>
+</html>{{end}}
+
+{{define "poll"}}{{template "head" .}}
+<h1>{{.Poll.Title}}</h1>
+{{range .Poll.Options}}<div class="row">
+<div class="label"><span>{{.Text}}</span>{{if $.Poll.ShowResults}}<span>{{percent .Percentage}}</span>{{end}}</div>
+{{if $.Poll.ShowResults}}<div class="bar"><span style="width: {{percent .Percentage}}"></span></div>{{end}}
+</div>{{end}}
+<div class="footer">
+<span class="muted">{{if .Poll.ShowResults}}{{votes .Poll.TotalVotes}} votes{{else}}Results are shown after voting{{end}}{{if not .Poll.VotingOpen}} &middot; Closed{{end}}</span>
+{{if .Poll.VotingOpen}}<a class="button" href="{{.PageURL}}" target="_blank" rel="noopener">Vote on Pulpulitiko</a>{{else}}<a href="{{.PageURL}}" target="_blank" rel="noopener">View on Pulpulitiko</a>{{end}}
+</div>
+{{template "foot" .}}{{end}}
+
+{{define "election"}}{{template "head" .}}
+<h1>{{.Election.Name}}</h1>
+<div class="muted">{{.Election.ElectionDate.Format "January 2, 2006"}} &middot; As of {{.AsOf}}</div>
+{{range .Election.Positions}}<h2>{{.Name}}{{with .Location}} &middot; {{.}}{{end}}</h2>
+{{range .Candidates}}<div class="row{{if .IsWinner}} winner{{end}}">
+<div class="label"><span>{{.Name}}{{with .Party}} <span class="muted">({{.}})</span>{{end}}</span><span>{{with .VotesReceived}}{{votes .}}{{end}}{{with .VotePercentage}} &middot; {{percent .}}{{end}}</span></div>
+{{with .VotePercentage}}<div class="bar"><span style="width: {{percent .}}"></span></div>{{end}}
+</div>{{else}}<div class="muted">No candidates yet</div>{{end}}
+{{end}}
+<div class="footer">
+<span class="muted">Pulpulitiko</span>
+<a href="{{.PageURL}}" target="_blank" rel="noopener">Full results on Pulpulitiko</a>
+</div>
+{{template "foot" .}}{{end}}
+`
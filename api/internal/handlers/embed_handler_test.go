@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatVotes(t *testing.T) {
+	assert.Equal(t, "0", formatVotes(0))
+	assert.Equal(t, "999", formatVotes(999))
+	assert.Equal(t, "1,000", formatVotes(1000))
+	assert.Equal(t, "31,629,783", formatVotes(31629783))
+	assert.Equal(t, "-12,000", formatVotes(-12000))
+}
+
+func TestEmbedHandler_RenderPoll(t *testing.T) {
+	h := NewEmbedHandler(nil, "https://pulpulitiko.com", "https://api.pulpulitiko.com", []string{"https://news.example"})
+	id := uuid.New()
+
+	w := httptest.NewRecorder()
+	h.render(w, "poll", embedPage{
+		Title:   `Should the <budget> pass?`,
+		PageURL: "https://pulpulitiko.com/polls/budget",
+		ViewURL: viewURL(models.EmbedTypePoll, id),
+		Poll: &models.PollEmbed{
+			ID:          id,
+			Title:       `Should the <budget> pass?`,
+			VotingOpen:  true,
+			ShowResults: true,
+			TotalVotes:  1500,
+			Options:     []models.PollEmbedOption{{Text: "Yes", VoteCount: 1000, Percentage: 66.666}},
+		},
+	})
+
+	assert.Equal(t, "frame-ancestors 'self' https://pulpulitiko.com https://news.example", w.Header().Get("Content-Security-Policy"))
+	assert.Empty(t, w.Header().Get("X-Frame-Options"))
+	assert.Contains(t, w.Header().Get("Cache-Control"), "public")
+
+	body := w.Body.String()
+	assert.Contains(t, body, "Should the &lt;budget&gt; pass?")
+	assert.Contains(t, body, `style="width: 66.7%"`)
+	assert.Contains(t, body, "1,500 votes")
+	// Voting happens on the site, not in the embed
+	assert.Contains(t, body, `href="https://pulpulitiko.com/polls/budget"`)
+	assert.NotContains(t, body, "<form")
+	// The view is counted by a beacon carrying the embedding page
+	assert.Contains(t, body, `"/embed/views?type=poll\u0026id=`+id.String()+`"`)
+	assert.Contains(t, body, "document.referrer")
+}
+
+func TestEmbedHandler_RenderElectionWithoutPartners(t *testing.T) {
+	h := NewEmbedHandler(nil, "https://pulpulitiko.com", "https://api.pulpulitiko.com", nil)
+	votes, percentage := 5400, 54.0
+
+	w := httptest.NewRecorder()
+	h.render(w, "election", embedPage{
+		Title:   "2025 Midterms",
+		PageURL: "https://pulpulitiko.com/elections/2025-midterms",
+		ViewURL: viewURL(models.EmbedTypeElection, uuid.New()),
+		Election: &models.ElectionResultsEmbed{
+			Name:         "2025 Midterms",
+			ElectionDate: models.NewDate(2025, time.May, 12),
+			Positions: []models.ElectionResultsEmbedPosition{
+				{Name: "Senator", Candidates: []models.ElectionResultsEmbedCandidate{
+					{Name: "Juan Leader", VotesReceived: &votes, VotePercentage: &percentage, IsWinner: true},
+				}},
+				{Name: "Mayor"},
+			},
+		},
+	})
+
+	assert.Equal(t, "frame-ancestors 'self' https://pulpulitiko.com", w.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "SAMEORIGIN", w.Header().Get("X-Frame-Options"))
+
+	body := w.Body.String()
+	assert.Contains(t, body, "May 12, 2025")
+	assert.Contains(t, body, "5,400")
+	assert.Contains(t, body, "54.0%")
+	assert.Contains(t, body, "No candidates yet")
+}
+
+func TestEmbedHandler_FormatAsOfInSiteTimezone(t *testing.T) {
+	h := NewEmbedHandler(nil, "https://pulpulitiko.com", "https://api.pulpulitiko.com", nil)
+	// Half past midnight on the 13th in Manila is still the 12th in UTC
+	dataAsOf := time.Date(2025, time.May, 12, 16, 30, 0, 0, time.UTC)
+	assert.Equal(t, "May 12, 2025 4:30 PM", h.formatAsOf(dataAsOf))
+
+	manila, err := time.LoadLocation("Asia/Manila")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.SetSiteLocation(manila)
+	assert.Equal(t, "May 13, 2025 12:30 AM", h.formatAsOf(dataAsOf))
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/storage"
+)
+
+// POST /api/admin/politicians/:id/photo - Upload a politician photo (multipart "file",
+// optional "focal_x" and "focal_y" percentages)
+func (h *PoliticianHandler) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid politician ID")
+		return
+	}
+
+	file, header, focal, ok := parseEntityImageUpload(w, r)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	img, err := h.politicianService.SetPhoto(r.Context(), id, file, header, focal, editorID(r))
+	if err != nil {
+		writeEntityImageError(w, err)
+		return
+	}
+
+	WriteSuccess(w, img)
+}
+
+// DELETE /api/admin/politicians/:id/photo - Replace the photo with an initials placeholder
+func (h *PoliticianHandler) RemovePhoto(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid politician ID")
+		return
+	}
+
+	img, err := h.politicianService.RemovePhoto(r.Context(), id, editorID(r))
+	if err != nil {
+		writeEntityImageError(w, err)
+		return
+	}
+
+	WriteSuccess(w, img)
+}
+
+// UploadLogo uploads a party logo (multipart "file", optional "focal_x" and
+// "focal_y" percentages)
+func (h *PoliticalPartyHandler) UploadLogo(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid party ID")
+		return
+	}
+
+	file, header, focal, ok := parseEntityImageUpload(w, r)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	img, err := h.partyService.SetLogo(r.Context(), id, file, header, focal)
+	if err != nil {
+		writeEntityImageError(w, err)
+		return
+	}
+
+	WriteSuccess(w, img)
+}
+
+// RemoveLogo replaces a party logo with an initials placeholder
+func (h *PoliticalPartyHandler) RemoveLogo(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid party ID")
+		return
+	}
+
+	img, err := h.partyService.RemoveLogo(r.Context(), id)
+	if err != nil {
+		writeEntityImageError(w, err)
+		return
+	}
+
+	WriteSuccess(w, img)
+}
+
+// parseEntityImageUpload reads the uploaded file and optional focal point,
+// writing a 400 and returning ok=false if either is invalid
+func parseEntityImageUpload(w http.ResponseWriter, r *http.Request) (multipart.File, *multipart.FileHeader, *models.FocalPoint, bool) {
+	// Limit request body size
+	r.Body = http.MaxBytesReader(w, r.Body, storage.GetMaxFileSize()+1024)
+
+	if err := r.ParseMultipartForm(storage.GetMaxFileSize()); err != nil {
+		WriteBadRequest(w, "file too large or invalid form data")
+		return nil, nil, nil, false
+	}
+
+	focal, err := parseFocalPoint(r.FormValue("focal_x"), r.FormValue("focal_y"))
+	if err != "" {
+		WriteBadRequest(w, err)
+		return nil, nil, nil, false
+	}
+
+	file, header, ferr := r.FormFile("file")
+	if ferr != nil {
+		WriteBadRequest(w, "file is required")
+		return nil, nil, nil, false
+	}
+
+	return file, header, focal, true
+}
+
+// parseFocalPoint parses an optional focal point; both coordinates must be
+// given together. It returns a message describing the problem on failure.
+func parseFocalPoint(xStr, yStr string) (*models.FocalPoint, string) {
+	if xStr == "" && yStr == "" {
+		return nil, ""
+	}
+	if xStr == "" || yStr == "" {
+		return nil, "focal_x and focal_y must be given together"
+	}
+
+	x, errX := strconv.ParseFloat(xStr, 64)
+	y, errY := strconv.ParseFloat(yStr, 64)
+	if errX != nil || errY != nil || x < 0 || x > 100 || y < 0 || y > 100 {
+		return nil, "focal_x and focal_y must be percentages between 0 and 100"
+	}
+
+	return &models.FocalPoint{X: x, Y: y}, ""
+}
+
+func writeEntityImageError(w http.ResponseWriter, err error) {
+	switch msg := err.Error(); {
+	case msg == "politician not found":
+		WriteNotFound(w, "politician not found")
+	case msg == "party not found":
+		WriteNotFound(w, "Party not found")
+	case msg == "image uploads are not configured", strings.HasPrefix(msg, "failed to"):
+		WriteInternalError(w, msg)
+	default:
+		// Anything else is a validation failure of the uploaded image
+		WriteBadRequest(w, msg)
+	}
+}
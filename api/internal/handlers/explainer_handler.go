@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+)
+
+type ExplainerHandler struct {
+	service *services.ExplainerService
+}
+
+func NewExplainerHandler(service *services.ExplainerService) *ExplainerHandler {
+	return &ExplainerHandler{service: service}
+}
+
+// GET /api/explainers/:slug
+func (h *ExplainerHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	explainer, err := h.service.GetBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		WriteInternalError(w, "failed to fetch explainer")
+		return
+	}
+	if explainer == nil {
+		WriteNotFound(w, "explainer not found")
+		return
+	}
+
+	WriteSuccess(w, explainer)
+}
+
+// GET /api/admin/explainers?search= - Every explainer with how many articles
+// embed it
+func (h *ExplainerHandler) AdminList(w http.ResponseWriter, r *http.Request) {
+	explainers, err := h.service.List(r.Context(), r.URL.Query().Get("search"))
+	if err != nil {
+		WriteInternalError(w, "failed to fetch explainers")
+		return
+	}
+
+	WriteSuccess(w, explainers)
+}
+
+// GET /api/admin/explainers/:id
+func (h *ExplainerHandler) AdminGetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid explainer ID")
+		return
+	}
+
+	explainer, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch explainer")
+		return
+	}
+	if explainer == nil {
+		WriteNotFound(w, "explainer not found")
+		return
+	}
+
+	WriteSuccess(w, explainer)
+}
+
+// POST /api/admin/explainers
+func (h *ExplainerHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateExplainerRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	explainer, err := h.service.Create(r.Context(), &req)
+	if err != nil {
+		writeExplainerError(w, err)
+		return
+	}
+
+	WriteCreated(w, explainer)
+}
+
+// PUT /api/admin/explainers/:id - Cached articles embedding the explainer are
+// dropped so readers get the new content
+func (h *ExplainerHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid explainer ID")
+		return
+	}
+
+	var req models.UpdateExplainerRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	explainer, err := h.service.Update(r.Context(), id, &req)
+	if err != nil {
+		writeExplainerError(w, err)
+		return
+	}
+
+	WriteSuccess(w, explainer)
+}
+
+// DELETE /api/admin/explainers/:id
+func (h *ExplainerHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid explainer ID")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		writeExplainerError(w, err)
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "explainer deleted"})
+}
+
+func writeExplainerError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "explainer not found":
+		WriteNotFound(w, err.Error())
+	case "slug must be lowercase letters and numbers separated by hyphens":
+		WriteBadRequest(w, err.Error())
+	case "explainer slug already exists":
+		WriteError(w, http.StatusConflict, "EXPLAINER_SLUG_TAKEN", err.Error())
+	default:
+		WriteInternalError(w, err.Error())
+	}
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+const maxDeliberationQueryLength = 200
+
+// Floor Deliberations - Public Endpoints
+
+// ListBillDeliberations lists a bill's floor deliberations without transcript text
+func (h *BillHandler) ListBillDeliberations(w http.ResponseWriter, r *http.Request) {
+	bill, ok := h.getBillBySlug(w, r)
+	if !ok {
+		return
+	}
+
+	deliberations, err := h.service.ListFloorDeliberations(r.Context(), bill.ID)
+	if err != nil {
+		WriteInternalError(w, "Failed to list floor deliberations")
+		return
+	}
+	WriteSuccess(w, deliberations)
+}
+
+// GetBillDeliberation returns a single deliberation including its transcript
+func (h *BillHandler) GetBillDeliberation(w http.ResponseWriter, r *http.Request) {
+	bill, ok := h.getBillBySlug(w, r)
+	if !ok {
+		return
+	}
+
+	deliberation, ok := h.getDeliberation(w, r, bill.ID)
+	if !ok {
+		return
+	}
+	WriteSuccess(w, deliberation)
+}
+
+// SearchBillDeliberations full-text searches a bill's transcripts
+func (h *BillHandler) SearchBillDeliberations(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		WriteBadRequest(w, "q is required")
+		return
+	}
+	if utf8.RuneCountInString(q) > maxDeliberationQueryLength {
+		WriteBadRequest(w, "q must be at most 200 characters")
+		return
+	}
+
+	bill, ok := h.getBillBySlug(w, r)
+	if !ok {
+		return
+	}
+
+	results, err := h.service.SearchFloorDeliberations(r.Context(), bill.ID, q)
+	if err != nil {
+		WriteInternalError(w, "Failed to search floor deliberations")
+		return
+	}
+	WriteSuccess(w, results)
+}
+
+// Floor Deliberations - Admin Endpoints
+
+func (h *BillHandler) ListDeliberations(w http.ResponseWriter, r *http.Request) {
+	bill, ok := h.getBillByID(w, r)
+	if !ok {
+		return
+	}
+
+	deliberations, err := h.service.ListFloorDeliberations(r.Context(), bill.ID)
+	if err != nil {
+		WriteInternalError(w, "Failed to list floor deliberations")
+		return
+	}
+	WriteSuccess(w, deliberations)
+}
+
+func (h *BillHandler) AddDeliberation(w http.ResponseWriter, r *http.Request) {
+	bill, ok := h.getBillByID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateFloorDeliberationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	deliberation, err := h.service.CreateFloorDeliberation(r.Context(), bill.ID, &req)
+	if err != nil {
+		h.writeDeliberationError(w, err, "Failed to add floor deliberation")
+		return
+	}
+	WriteCreated(w, deliberation)
+}
+
+func (h *BillHandler) UpdateDeliberation(w http.ResponseWriter, r *http.Request) {
+	bill, ok := h.getBillByID(w, r)
+	if !ok {
+		return
+	}
+	deliberation, ok := h.getDeliberation(w, r, bill.ID)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateFloorDeliberationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	updated, err := h.service.UpdateFloorDeliberation(r.Context(), deliberation, &req)
+	if err != nil {
+		h.writeDeliberationError(w, err, "Failed to update floor deliberation")
+		return
+	}
+	WriteSuccess(w, updated)
+}
+
+func (h *BillHandler) RemoveDeliberation(w http.ResponseWriter, r *http.Request) {
+	bill, ok := h.getBillByID(w, r)
+	if !ok {
+		return
+	}
+	deliberation, ok := h.getDeliberation(w, r, bill.ID)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteFloorDeliberation(r.Context(), deliberation); err != nil {
+		h.writeDeliberationError(w, err, "Failed to remove floor deliberation")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getBillBySlug loads the bill from the URL slug
+func (h *BillHandler) getBillBySlug(w http.ResponseWriter, r *http.Request) (*models.Bill, bool) {
+	bill, err := h.service.GetBillBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		WriteInternalError(w, "Failed to get bill")
+		return nil, false
+	}
+	if bill == nil {
+		WriteNotFound(w, "Bill not found")
+		return nil, false
+	}
+	return bill, true
+}
+
+// getBillByID loads the bill from the URL ID
+func (h *BillHandler) getBillByID(w http.ResponseWriter, r *http.Request) (*models.Bill, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid bill ID")
+		return nil, false
+	}
+
+	bill, err := h.service.GetBillByID(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "Failed to get bill")
+		return nil, false
+	}
+	if bill == nil {
+		WriteNotFound(w, "Bill not found")
+		return nil, false
+	}
+	return bill, true
+}
+
+// getDeliberation loads the deliberation from the URL and checks it belongs to the bill
+func (h *BillHandler) getDeliberation(w http.ResponseWriter, r *http.Request, billID uuid.UUID) (*models.FloorDeliberation, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "deliberationId"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid deliberation ID")
+		return nil, false
+	}
+
+	deliberation, err := h.service.GetFloorDeliberation(r.Context(), billID, id)
+	if err != nil {
+		WriteInternalError(w, "Failed to get floor deliberation")
+		return nil, false
+	}
+	if deliberation == nil {
+		WriteNotFound(w, "Floor deliberation not found")
+		return nil, false
+	}
+	return deliberation, true
+}
+
+func (h *BillHandler) writeDeliberationError(w http.ResponseWriter, err error, fallback string) {
+	switch err.Error() {
+	case "transcript_text or transcript_url is required":
+		WriteBadRequest(w, err.Error())
+	case "bill not found":
+		WriteNotFound(w, "Bill not found")
+	case "floor deliberation not found":
+		WriteNotFound(w, "Floor deliberation not found")
+	default:
+		WriteInternalError(w, fallback)
+	}
+}
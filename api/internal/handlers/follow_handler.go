@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/middleware"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+)
+
+type FollowHandler struct {
+	service *services.FollowService
+}
+
+func NewFollowHandler(service *services.FollowService) *FollowHandler {
+	return &FollowHandler{service: service}
+}
+
+// currentUserID returns the authenticated user's ID, writing a 401 if missing
+func (h *FollowHandler) currentUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// POST /api/follows - Follow a category, tag, politician or author
+func (h *FollowHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	h.setFollowing(w, r, true)
+}
+
+// DELETE /api/follows - Unfollow a category, tag, politician or author
+func (h *FollowHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	h.setFollowing(w, r, false)
+}
+
+func (h *FollowHandler) setFollowing(w http.ResponseWriter, r *http.Request, follow bool) {
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.FollowRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	var status *models.FollowStatus
+	var err error
+	if follow {
+		status, err = h.service.Follow(r.Context(), userID, &req)
+	} else {
+		status, err = h.service.Unfollow(r.Context(), userID, &req)
+	}
+	if err != nil {
+		if err.Error() == req.EntityType+" not found" {
+			WriteNotFound(w, err.Error())
+			return
+		}
+		WriteInternalError(w, "failed to update follow")
+		return
+	}
+
+	WriteSuccess(w, status)
+}
+
+// GET /api/auth/feed - Recently published articles from what the user follows
+func (h *FollowHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	page, perPage := GetPaginationParams(r)
+	feed, err := h.service.GetFeed(r.Context(), userID, page, perPage)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch feed")
+		return
+	}
+
+	WritePaginated(w, r, feed)
+}
@@ -4,15 +4,18 @@ import (
 	"net/http"
 )
 
-type HealthHandler struct{}
+type HealthHandler struct {
+	hub *Hub
+}
 
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+func NewHealthHandler(hub *Hub) *HealthHandler {
+	return &HealthHandler{hub: hub}
 }
 
 // GET /health
 func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
-	WriteSuccess(w, map[string]string{
-		"status": "healthy",
+	WriteSuccess(w, map[string]interface{}{
+		"status":                "healthy",
+		"websocket_connections": h.hub.ConnectionCount(),
 	})
 }
@@ -3,9 +3,12 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/humfurie/pulpulitiko/api/internal/middleware"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 )
 
@@ -25,8 +28,12 @@ func WriteCreated(w http.ResponseWriter, data interface{}) {
 	WriteJSON(w, http.StatusCreated, models.SuccessResponse(data))
 }
 
+// WriteError writes an error response, carrying the request ID the request
+// logger set on the response so the client's report can be matched to the log
 func WriteError(w http.ResponseWriter, status int, code, message string) {
-	WriteJSON(w, status, models.ErrorResponse(code, message))
+	resp := models.ErrorResponse(code, message)
+	resp.Error.RequestID = w.Header().Get(middleware.RequestIDHeader)
+	WriteJSON(w, status, resp)
 }
 
 func WriteBadRequest(w http.ResponseWriter, message string) {
@@ -82,3 +89,51 @@ func GetPaginationParams(r *http.Request) (page, perPage int) {
 
 	return page, perPage
 }
+
+// GetIncludeDeleted reports whether an admin list should include soft-deleted
+// rows, so they can be found and restored. Deleted rows are left out unless
+// include_deleted=true.
+func GetIncludeDeleted(r *http.Request) bool {
+	return r.URL.Query().Get("include_deleted") == "true"
+}
+
+// LegacyPaginationHeader lets older clients opt back into the per-endpoint
+// list shapes (e.g. {"articles": [...], "total": ...}) instead of the envelope
+const LegacyPaginationHeader = "X-Legacy-Pagination"
+
+// Paginated is implemented by the models.Paginated* list results
+type Paginated interface {
+	Envelope() interface{}
+}
+
+// PaginatedData returns result in the standard {items, meta} envelope, or
+// unchanged when the request sets X-Legacy-Pagination: true
+func PaginatedData(w http.ResponseWriter, r *http.Request, result Paginated) interface{} {
+	w.Header().Add("Vary", LegacyPaginationHeader)
+	if r.Header.Get(LegacyPaginationHeader) == "true" {
+		return result
+	}
+	return result.Envelope()
+}
+
+func WritePaginated(w http.ResponseWriter, r *http.Request, result Paginated) {
+	WriteSuccess(w, PaginatedData(w, r, result))
+}
+
+// WriteSlugRedirect sends a 301 to the same URL with the oldSlug path segment
+// replaced by newSlug, keeping the rest of the path and the query string
+func WriteSlugRedirect(w http.ResponseWriter, r *http.Request, oldSlug, newSlug string) {
+	segments := strings.Split(r.URL.Path, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] == oldSlug {
+			segments[i] = url.PathEscape(newSlug)
+			break
+		}
+	}
+
+	location := strings.Join(segments, "/")
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+	http.Redirect(w, r, location, http.StatusMovedPermanently)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/humfurie/pulpulitiko/api/internal/middleware"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePaginated(t *testing.T) {
+	result := &models.PaginatedTags{
+		Tags:       []models.Tag{{Name: "Budget", Slug: "budget"}},
+		Total:      21,
+		Page:       2,
+		PerPage:    10,
+		TotalPages: 3,
+	}
+
+	t.Run("envelope", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		WritePaginated(w, httptest.NewRequest(http.MethodGet, "/api/admin/tags", nil), result)
+
+		var body struct {
+			Data struct {
+				Items []models.Tag           `json:"items"`
+				Meta  map[string]interface{} `json:"meta"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+		require.Len(t, body.Data.Items, 1)
+		assert.Equal(t, "budget", body.Data.Items[0].Slug)
+		assert.Equal(t, map[string]interface{}{
+			"page": float64(2), "per_page": float64(10), "total": float64(21),
+			"total_pages": float64(3), "has_next": true, "has_prev": true,
+		}, body.Data.Meta)
+		assert.Equal(t, LegacyPaginationHeader, w.Header().Get("Vary"))
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/tags", nil)
+		req.Header.Set(LegacyPaginationHeader, "true")
+		w := httptest.NewRecorder()
+		WritePaginated(w, req, result)
+
+		var body struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+		assert.Contains(t, body.Data, "data")
+		assert.Equal(t, float64(21), body.Data["total"])
+		assert.Equal(t, float64(3), body.Data["total_pages"])
+		assert.NotContains(t, body.Data, "items")
+	})
+}
+
+func TestWritePaginatedKeepsNotificationUnreadCount(t *testing.T) {
+	w := httptest.NewRecorder()
+	WritePaginated(w, httptest.NewRequest(http.MethodGet, "/api/notifications", nil), &models.PaginatedNotifications{
+		Total:       0,
+		UnreadCount: 4,
+		Page:        1,
+		PerPage:     20,
+	})
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+	assert.Equal(t, float64(4), body.Data["unread_count"])
+	assert.Equal(t, []interface{}{}, body.Data["items"])
+	assert.Contains(t, body.Data, "meta")
+}
+
+func TestWriteSlugRedirect(t *testing.T) {
+	for path, want := range map[string]string{
+		"/api/articles/old-title":             "/api/articles/new-title",
+		"/api/articles/old-title/":            "/api/articles/new-title/",
+		"/api/tags/old-title?page=2":          "/api/tags/new-title?page=2",
+		"/api/categories/old-title/old-title": "/api/categories/old-title/new-title",
+	} {
+		w := httptest.NewRecorder()
+		WriteSlugRedirect(w, httptest.NewRequest(http.MethodGet, path, nil), "old-title", "new-title")
+
+		assert.Equal(t, http.StatusMovedPermanently, w.Code, path)
+		assert.Equal(t, want, w.Header().Get("Location"), path)
+	}
+}
+
+func TestWriteErrorIncludesRequestID(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set(middleware.RequestIDHeader, "host/abc-000042")
+	WriteInternalError(w, "failed to get election: connection refused")
+
+	var body models.APIResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.NotNil(t, body.Error)
+	assert.Equal(t, "host/abc-000042", body.Error.RequestID)
+
+	// Without a request ID the field is left out
+	w = httptest.NewRecorder()
+	WriteBadRequest(w, "Invalid ID")
+	assert.NotContains(t, w.Body.String(), "request_id")
+}
+
+func TestGetIncludeDeleted(t *testing.T) {
+	for query, want := range map[string]bool{
+		"":                       false,
+		"?include_deleted=true":  true,
+		"?include_deleted=false": false,
+		"?include_deleted=1":     false,
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/api/admin/articles"+query, nil)
+		assert.Equal(t, want, GetIncludeDeleted(r), query)
+	}
+}
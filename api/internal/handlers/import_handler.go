@@ -134,7 +134,7 @@ func (h *ImportHandler) ListImportLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, logs)
+	WritePaginated(w, r, logs)
 }
 
 // GET /api/admin/import/politicians/logs/:id - Get single import log details
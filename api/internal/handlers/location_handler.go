@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"net/netip"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -139,7 +142,7 @@ func (h *LocationHandler) GetCityBySlug(w http.ResponseWriter, r *http.Request)
 
 	WriteSuccess(w, map[string]interface{}{
 		"city":      city,
-		"barangays": barangays,
+		"barangays": PaginatedData(w, r, barangays),
 	})
 }
 
@@ -212,6 +215,34 @@ func (h *LocationHandler) SearchLocations(w http.ResponseWriter, r *http.Request
 	WriteSuccess(w, results)
 }
 
+// GET /api/locations/tree?depth=3 - Regions nested down to depth: 1 regions,
+// 2 provinces, 3 cities (the default), 4 barangays. Depth 4 returns every
+// barangay in the country and also needs include_barangays=true.
+func (h *LocationHandler) GetTree(w http.ResponseWriter, r *http.Request) {
+	depth := models.LocationTreeCities
+	if v := r.URL.Query().Get("depth"); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil || d < models.LocationTreeRegions || d > models.LocationTreeBarangays {
+			WriteBadRequest(w, "depth must be between 1 and 4")
+			return
+		}
+		depth = d
+	}
+
+	if depth == models.LocationTreeBarangays && r.URL.Query().Get("include_barangays") != "true" {
+		WriteBadRequest(w, "depth 4 lists every barangay; pass include_barangays=true to confirm")
+		return
+	}
+
+	tree, err := h.locationService.GetLocationTree(r.Context(), depth)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch location tree")
+		return
+	}
+
+	WriteSuccess(w, tree)
+}
+
 // GET /api/locations/hierarchy/{barangay_id} - Get full location hierarchy
 func (h *LocationHandler) GetHierarchy(w http.ResponseWriter, r *http.Request) {
 	barangayIDStr := chi.URLParam(r, "barangay_id")
@@ -235,6 +266,46 @@ func (h *LocationHandler) GetHierarchy(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, hierarchy)
 }
 
+// GET /api/locations/detect - Guess the visitor's city from their IP address,
+// to preselect the location pickers. The data is null when there is no GeoIP
+// database or the guess isn't confident.
+func (h *LocationHandler) DetectLocation(w http.ResponseWriter, r *http.Request) {
+	// The answer depends on who asks
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	ip, ok := requestIP(r)
+	if !ok {
+		WriteSuccess(w, nil)
+		return
+	}
+
+	location, err := h.locationService.DetectLocation(r.Context(), ip)
+	if err != nil {
+		WriteInternalError(w, "failed to detect location")
+		return
+	}
+
+	WriteSuccess(w, location)
+}
+
+// requestIP returns the address of the client that made the request: the
+// first X-Forwarded-For entry behind a proxy, otherwise the peer address
+func requestIP(r *http.Request) (netip.Addr, bool) {
+	candidate := r.RemoteAddr
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		candidate, _, _ = strings.Cut(xff, ",")
+	} else if xri := r.Header.Get("X-Real-IP"); xri != "" {
+		candidate = xri
+	}
+	candidate = strings.TrimSpace(candidate)
+
+	if addrPort, err := netip.ParseAddrPort(candidate); err == nil {
+		return addrPort.Addr(), true
+	}
+	addr, err := netip.ParseAddr(candidate)
+	return addr, err == nil
+}
+
 // =====================================================
 // CASCADING ENDPOINTS (for LocationPicker component)
 // =====================================================
@@ -291,7 +362,51 @@ func (h *LocationHandler) GetBarangaysByCity(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	WriteSuccess(w, barangays)
+	WritePaginated(w, r, barangays)
+}
+
+// GET /api/locations/barangays/search?q=&city_id=&min_population=&max_population= - Search barangays (paginated)
+func (h *LocationHandler) SearchBarangays(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := &models.BarangaySearchFilter{Query: strings.TrimSpace(query.Get("q"))}
+
+	if v := query.Get("city_id"); v != "" {
+		cityID, err := uuid.Parse(v)
+		if err != nil {
+			WriteBadRequest(w, "invalid city ID")
+			return
+		}
+		filter.CityID = &cityID
+	}
+	if v := query.Get("min_population"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			WriteBadRequest(w, "min_population must be a non-negative integer")
+			return
+		}
+		filter.MinPopulation = &n
+	}
+	if v := query.Get("max_population"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			WriteBadRequest(w, "max_population must be a non-negative integer")
+			return
+		}
+		filter.MaxPopulation = &n
+	}
+	if filter.MinPopulation != nil && filter.MaxPopulation != nil && *filter.MinPopulation > *filter.MaxPopulation {
+		WriteBadRequest(w, "min_population cannot be greater than max_population")
+		return
+	}
+
+	page, perPage := GetPaginationParams(r)
+	barangays, err := h.locationService.SearchBarangays(r.Context(), filter, page, perPage)
+	if err != nil {
+		WriteInternalError(w, "failed to search barangays")
+		return
+	}
+
+	WritePaginated(w, r, barangays)
 }
 
 // GET /api/locations/districts/by-province/{province_id} - Get districts by province ID
@@ -373,7 +488,7 @@ func (h *LocationHandler) UpdateRegion(w http.ResponseWriter, r *http.Request) {
 
 	region, err := h.locationService.UpdateRegion(r.Context(), id, &req)
 	if err != nil {
-		WriteInternalError(w, err.Error())
+		writeLocationUpdateError(w, err)
 		return
 	}
 
@@ -454,7 +569,7 @@ func (h *LocationHandler) UpdateProvince(w http.ResponseWriter, r *http.Request)
 
 	province, err := h.locationService.UpdateProvince(r.Context(), id, &req)
 	if err != nil {
-		WriteInternalError(w, err.Error())
+		writeLocationUpdateError(w, err)
 		return
 	}
 
@@ -535,7 +650,7 @@ func (h *LocationHandler) UpdateCity(w http.ResponseWriter, r *http.Request) {
 
 	city, err := h.locationService.UpdateCityMunicipality(r.Context(), id, &req)
 	if err != nil {
-		WriteInternalError(w, err.Error())
+		writeLocationUpdateError(w, err)
 		return
 	}
 
@@ -657,6 +772,30 @@ func (h *LocationHandler) CreateDistrict(w http.ResponseWriter, r *http.Request)
 	WriteCreated(w, district)
 }
 
+// PUT /api/admin/locations/districts/{id} - Update congressional district
+func (h *LocationHandler) UpdateDistrict(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		WriteBadRequest(w, "invalid district ID")
+		return
+	}
+
+	var req models.UpdateDistrictRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	district, err := h.locationService.UpdateDistrict(r.Context(), id, &req)
+	if err != nil {
+		writeLocationUpdateError(w, err)
+		return
+	}
+
+	WriteSuccess(w, district)
+}
+
 // GET /api/admin/locations/districts/{id} - Get district by ID
 func (h *LocationHandler) AdminGetDistrictByID(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -679,3 +818,21 @@ func (h *LocationHandler) AdminGetDistrictByID(w http.ResponseWriter, r *http.Re
 
 	WriteSuccess(w, district)
 }
+
+// writeLocationUpdateError maps a failed location update to a response. A lost
+// optimistic lock returns the record's current updated_at so the client can
+// reload and retry.
+func writeLocationUpdateError(w http.ResponseWriter, err error) {
+	var conflict *models.ConcurrentUpdateError
+	switch {
+	case errors.As(err, &conflict):
+		WriteJSON(w, http.StatusConflict, map[string]interface{}{
+			"error":              "concurrent_update",
+			"current_updated_at": conflict.CurrentUpdatedAt,
+		})
+	case strings.HasSuffix(err.Error(), "not found"):
+		WriteNotFound(w, err.Error())
+	default:
+		WriteInternalError(w, err.Error())
+	}
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{"peer address", "49.145.1.1:52311", nil, "49.145.1.1"},
+		{"first forwarded address", "10.0.0.2:80", map[string]string{"X-Forwarded-For": "49.145.1.1, 10.0.0.1"}, "49.145.1.1"},
+		{"real ip header", "10.0.0.2:80", map[string]string{"X-Real-IP": "2001:db8::1"}, "2001:db8::1"},
+		{"ipv6 peer", "[2001:db8::1]:443", nil, "2001:db8::1"},
+		{"unparseable", "10.0.0.2:80", map[string]string{"X-Forwarded-For": "unknown"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/locations/detect", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+
+			ip, ok := requestIP(r)
+			if tt.want == "" {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, ip.String())
+		})
+	}
+}
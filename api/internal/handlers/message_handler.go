@@ -43,14 +43,27 @@ func (h *MessageHandler) CreateConversation(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	conversation, message, err := h.service.CreateConversation(r.Context(), userID, &req)
+	isAdmin := claims.Role == "admin"
+
+	conversation, message, err := h.service.CreateConversation(r.Context(), userID, isAdmin, &req)
 	if err != nil {
+		if isMessagingBlockedError(err) {
+			WriteError(w, http.StatusForbidden, "MESSAGING_BLOCKED", err.Error())
+			return
+		}
+		switch err.Error() {
+		case "you cannot message yourself":
+			WriteBadRequest(w, err.Error())
+			return
+		case "recipient not found":
+			WriteNotFound(w, err.Error())
+			return
+		}
 		WriteInternalError(w, err.Error())
 		return
 	}
 
-	// Broadcast to admins that a new message arrived
-	h.hub.BroadcastNewMessage(message, userID, false)
+	h.broadcastNewMessage(conversation, message, userID, isAdmin)
 
 	WriteCreated(w, map[string]interface{}{
 		"conversation": conversation,
@@ -160,7 +173,7 @@ func (h *MessageHandler) GetMessages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, messages)
+	WritePaginated(w, r, messages)
 }
 
 // SendMessage sends a message in a conversation
@@ -198,8 +211,20 @@ func (h *MessageHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	message, err := h.service.SendMessage(r.Context(), conversationID, userID, &req)
+	message, err := h.service.SendMessage(r.Context(), conversationID, userID, isAdmin, &req)
 	if err != nil {
+		if isMessagingBlockedError(err) {
+			WriteError(w, http.StatusForbidden, "MESSAGING_BLOCKED", err.Error())
+			return
+		}
+		switch err.Error() {
+		case "claim this conversation before replying":
+			WriteError(w, http.StatusConflict, "CONVERSATION_UNCLAIMED", err.Error())
+			return
+		case "conversation is assigned to another admin":
+			WriteError(w, http.StatusConflict, "CONVERSATION_ASSIGNED", err.Error())
+			return
+		}
 		WriteInternalError(w, err.Error())
 		return
 	}
@@ -207,7 +232,7 @@ func (h *MessageHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 	// Get conversation to know who to notify
 	conversation, _ := h.service.GetConversation(r.Context(), conversationID)
 	if conversation != nil {
-		h.hub.BroadcastNewMessage(message, conversation.UserID, isAdmin)
+		h.broadcastNewMessage(conversation, message, userID, isAdmin)
 	}
 
 	WriteCreated(w, message)
@@ -274,17 +299,50 @@ func (h *MessageHandler) GetUnreadCounts(w http.ResponseWriter, r *http.Request)
 
 // ===== Admin Endpoints =====
 
-// AdminListConversations lists all conversations (admin only)
-// GET /api/admin/messages/conversations
+// AdminListConversations lists support conversations (admin only). Archived
+// conversations are only listed when asked for with status=archived.
+// GET /api/admin/messages/conversations?status=&assignee=me|unassigned|{id}&unread=true
 func (h *MessageHandler) AdminListConversations(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "Unauthorized")
+		return
+	}
+
 	page, perPage := GetPaginationParams(r)
+	query := r.URL.Query()
 
 	// Parse filter from query params
-	var filter *models.ConversationFilter
-	statusParam := r.URL.Query().Get("status")
-	if statusParam != "" {
+	filter := &models.ConversationFilter{UnreadOnly: query.Get("unread") == "true"}
+	if statusParam := query.Get("status"); statusParam != "" {
 		status := models.ConversationStatus(statusParam)
-		filter = &models.ConversationFilter{Status: &status}
+		switch status {
+		case models.ConversationStatusOpen, models.ConversationStatusClosed, models.ConversationStatusArchived:
+			filter.Status = &status
+		default:
+			WriteBadRequest(w, "status must be one of open, closed, archived")
+			return
+		}
+	}
+
+	switch assignee := query.Get("assignee"); assignee {
+	case "":
+	case "unassigned":
+		filter.Unassigned = true
+	case "me":
+		adminID, err := uuid.Parse(claims.UserID)
+		if err != nil {
+			WriteBadRequest(w, "Invalid user ID")
+			return
+		}
+		filter.AssignedTo = &adminID
+	default:
+		adminID, err := uuid.Parse(assignee)
+		if err != nil {
+			WriteBadRequest(w, "assignee must be me, unassigned or an admin ID")
+			return
+		}
+		filter.AssignedTo = &adminID
 	}
 
 	conversations, err := h.service.ListConversations(r.Context(), filter, page, perPage)
@@ -293,7 +351,136 @@ func (h *MessageHandler) AdminListConversations(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	WriteSuccess(w, conversations)
+	WritePaginated(w, r, conversations)
+}
+
+// AdminGetQueueCounts sizes the support inbox queues for the sidebar
+// GET /api/admin/messages/queues
+func (h *MessageHandler) AdminGetQueueCounts(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	adminID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteBadRequest(w, "Invalid user ID")
+		return
+	}
+
+	counts, err := h.service.GetQueueCounts(r.Context(), adminID)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, counts)
+}
+
+// AdminAssignConversation assigns a support conversation to an admin
+// PATCH /api/admin/messages/conversations/{id}/assign
+func (h *MessageHandler) AdminAssignConversation(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	conversationID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	actorID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteBadRequest(w, "Invalid user ID")
+		return
+	}
+
+	var req models.AssignConversationRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	conversation, err := h.service.AssignConversation(r.Context(), conversationID, actorID, &req)
+	if err != nil {
+		writeConversationAssignmentError(w, err)
+		return
+	}
+
+	WriteSuccess(w, conversation)
+}
+
+// AdminClaimConversation assigns an unassigned support conversation to the current admin
+// POST /api/admin/messages/conversations/{id}/claim
+func (h *MessageHandler) AdminClaimConversation(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	conversationID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	adminID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteBadRequest(w, "Invalid user ID")
+		return
+	}
+
+	conversation, err := h.service.ClaimConversation(r.Context(), conversationID, adminID)
+	if err != nil {
+		writeConversationAssignmentError(w, err)
+		return
+	}
+
+	WriteSuccess(w, conversation)
+}
+
+// AdminArchiveConversation moves a conversation out of the inbox
+// POST /api/admin/messages/conversations/{id}/archive
+func (h *MessageHandler) AdminArchiveConversation(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, true)
+}
+
+// AdminUnarchiveConversation returns an archived conversation to the inbox
+// DELETE /api/admin/messages/conversations/{id}/archive
+func (h *MessageHandler) AdminUnarchiveConversation(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, false)
+}
+
+func (h *MessageHandler) setArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	conversationID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	if archived {
+		err = h.service.ArchiveConversation(r.Context(), conversationID)
+	} else {
+		err = h.service.UnarchiveConversation(r.Context(), conversationID)
+	}
+	if err != nil {
+		switch err.Error() {
+		case "conversation not found":
+			WriteNotFound(w, "Conversation not found")
+		case "conversation is already archived", "conversation is not archived":
+			WriteBadRequest(w, err.Error())
+		default:
+			WriteInternalError(w, err.Error())
+		}
+		return
+	}
+
+	WriteSuccess(w, map[string]bool{"success": true})
 }
 
 // AdminUpdateConversationStatus updates a conversation's status
@@ -319,3 +506,40 @@ func (h *MessageHandler) AdminUpdateConversationStatus(w http.ResponseWriter, r
 
 	WriteSuccess(w, map[string]bool{"success": true})
 }
+
+// broadcastNewMessage notifies the admins or the other participant of a new message
+func (h *MessageHandler) broadcastNewMessage(conversation *models.Conversation, message *models.Message, senderID uuid.UUID, senderIsAdmin bool) {
+	if conversation.RecipientID == nil {
+		h.hub.BroadcastNewMessage(message, conversation.UserID, senderIsAdmin)
+		return
+	}
+
+	// Direct conversation: notify every participant except the sender
+	for _, participantID := range []uuid.UUID{conversation.UserID, *conversation.RecipientID} {
+		if participantID != senderID {
+			h.hub.BroadcastDirectMessage(message, participantID)
+		}
+	}
+}
+
+func writeConversationAssignmentError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "conversation not found":
+		WriteNotFound(w, "Conversation not found")
+	case "only support conversations can be assigned", "assignee must be an admin":
+		WriteBadRequest(w, err.Error())
+	case "conversation is assigned to another admin":
+		WriteError(w, http.StatusConflict, "CONVERSATION_ASSIGNED", err.Error())
+	default:
+		WriteInternalError(w, err.Error())
+	}
+}
+
+// isMessagingBlockedError reports whether err comes from a block between the participants
+func isMessagingBlockedError(err error) bool {
+	switch err.Error() {
+	case "you cannot message this user", "this conversation is read-only", "unblock this user to send them messages":
+		return true
+	}
+	return false
+}
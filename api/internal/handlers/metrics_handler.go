@@ -2,16 +2,34 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/repository"
 )
 
 type MetricsHandler struct {
-	metricsRepo *repository.MetricsRepository
+	metricsRepo    *repository.MetricsRepository
+	coAuthorWeight float64
+	staleness      models.StalenessThresholds
+	siteLocation   *time.Location
 }
 
-func NewMetricsHandler(metricsRepo *repository.MetricsRepository) *MetricsHandler {
-	return &MetricsHandler{metricsRepo: metricsRepo}
+func NewMetricsHandler(metricsRepo *repository.MetricsRepository, coAuthorWeight float64, staleness models.StalenessThresholds) *MetricsHandler {
+	return &MetricsHandler{
+		metricsRepo:    metricsRepo,
+		coAuthorWeight: coAuthorWeight,
+		staleness:      staleness,
+		siteLocation:   time.UTC,
+	}
+}
+
+// SetSiteLocation sets the timezone the activity heatmap is bucketed in
+func (h *MetricsHandler) SetSiteLocation(loc *time.Location) {
+	h.siteLocation = loc
 }
 
 func (h *MetricsHandler) GetDashboardMetrics(w http.ResponseWriter, r *http.Request) {
@@ -61,3 +79,110 @@ func (h *MetricsHandler) GetTagMetrics(w http.ResponseWriter, r *http.Request) {
 
 	WriteSuccess(w, metrics)
 }
+
+func (h *MetricsHandler) GetAuthorMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	metrics, err := h.metricsRepo.GetAuthorMetrics(ctx, h.coAuthorWeight)
+	if err != nil {
+		WriteInternalError(w, "Failed to get author metrics")
+		return
+	}
+
+	WriteSuccess(w, metrics)
+}
+
+// GetArticleReferrers returns an article's views by traffic source over the
+// last ?days= days (default 30)
+func (h *MetricsHandler) GetArticleReferrers(w http.ResponseWriter, r *http.Request) {
+	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid article ID")
+		return
+	}
+
+	days, ok := parseReferrerDays(w, r)
+	if !ok {
+		return
+	}
+
+	breakdown, err := h.metricsRepo.GetReferrerBreakdown(r.Context(), articleID, days)
+	if err != nil {
+		WriteInternalError(w, "Failed to get referrer breakdown")
+		return
+	}
+
+	WriteSuccess(w, breakdown)
+}
+
+// GetReferrerSummary returns views of all articles by traffic source over the
+// last ?days= days (default 30)
+func (h *MetricsHandler) GetReferrerSummary(w http.ResponseWriter, r *http.Request) {
+	days, ok := parseReferrerDays(w, r)
+	if !ok {
+		return
+	}
+
+	breakdown, err := h.metricsRepo.GetReferrerSummary(r.Context(), days)
+	if err != nil {
+		WriteInternalError(w, "Failed to get referrer summary")
+		return
+	}
+
+	WriteSuccess(w, breakdown)
+}
+
+// GetActivityHeatmap returns comments, poll votes and article views by day of
+// week and hour over the last ?days (default 30)
+func (h *MetricsHandler) GetActivityHeatmap(w http.ResponseWriter, r *http.Request) {
+	days := defaultActivityHeatmapDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > models.MaxActivityHeatmapDays {
+			WriteBadRequest(w, "days must be between 1 and 90")
+			return
+		}
+		days = n
+	}
+
+	heatmap, err := h.metricsRepo.GetActivityHeatmap(r.Context(), days, h.siteLocation)
+	if err != nil {
+		WriteInternalError(w, "Failed to get activity heatmap")
+		return
+	}
+
+	WriteSuccess(w, heatmap)
+}
+
+// GetDataFreshness returns, per hand-encoded module, the least recently
+// updated active record and how many active records have gone stale
+func (h *MetricsHandler) GetDataFreshness(w http.ResponseWriter, r *http.Request) {
+	freshness, err := h.metricsRepo.GetDataFreshness(r.Context(), h.staleness)
+	if err != nil {
+		WriteInternalError(w, "Failed to get data freshness")
+		return
+	}
+
+	WriteSuccess(w, freshness)
+}
+
+const (
+	defaultReferrerDays = 30
+	maxReferrerDays     = 365
+
+	defaultActivityHeatmapDays = 30
+)
+
+func parseReferrerDays(w http.ResponseWriter, r *http.Request) (int, bool) {
+	v := r.URL.Query().Get("days")
+	if v == "" {
+		return defaultReferrerDays, true
+	}
+
+	days, err := strconv.Atoi(v)
+	if err != nil || days < 1 || days > maxReferrerDays {
+		WriteBadRequest(w, "days must be between 1 and 365")
+		return 0, false
+	}
+	return days, true
+}
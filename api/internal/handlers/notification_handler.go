@@ -7,6 +7,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/middleware"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/services"
 )
 
@@ -58,7 +59,7 @@ func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	WriteSuccess(w, result)
+	WritePaginated(w, r, result)
 }
 
 // GetUnreadCount GET /api/notifications/unread-count - Get unread notification count
@@ -171,3 +172,143 @@ func (h *NotificationHandler) DeleteNotification(w http.ResponseWriter, r *http.
 
 	WriteSuccess(w, map[string]string{"message": "notification deleted"})
 }
+
+// ListInbox GET /api/me/notifications - List mentions, replies and messages for authenticated user
+func (h *NotificationHandler) ListInbox(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	page, perPage := GetPaginationParams(r)
+	unreadOnly := r.URL.Query().Get("unread_only") == "true"
+
+	result, err := h.notificationService.ListInbox(r.Context(), userID, page, perPage, unreadOnly)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WritePaginated(w, r, result)
+}
+
+// GetInboxUnreadCount GET /api/me/notifications/unread-count - Get unread mentions, replies and messages
+func (h *NotificationHandler) GetInboxUnreadCount(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	counts, err := h.notificationService.GetInboxUnreadCounts(r.Context(), userID)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, counts)
+}
+
+// MarkInboxItemRead POST /api/me/notifications/{type}/{id}/read - Mark an inbox item as read
+func (h *NotificationHandler) MarkInboxItemRead(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	itemType := models.InboxItemType(chi.URLParam(r, "type"))
+	if !itemType.Valid() {
+		WriteBadRequest(w, "invalid notification type")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid notification ID")
+		return
+	}
+
+	if err := h.notificationService.MarkInboxItemRead(r.Context(), userID, itemType, id); err != nil {
+		switch err.Error() {
+		case "mention not found", "notification not found", "message not found":
+			WriteNotFound(w, err.Error())
+		default:
+			WriteInternalError(w, err.Error())
+		}
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "notification marked as read"})
+}
+
+// MarkInboxRead POST /api/me/notifications/read-all - Mark all mentions, replies and messages as read
+func (h *NotificationHandler) MarkInboxRead(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	if err := h.notificationService.MarkInboxRead(r.Context(), userID); err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "all notifications marked as read"})
+}
+
+// RegisterDeviceToken POST /api/auth/device-token - Register or refresh the
+// push token of the app the user is signed in to
+func (h *NotificationHandler) RegisterDeviceToken(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	var req models.RegisterDeviceTokenRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	token, err := h.notificationService.RegisterDeviceToken(r.Context(), userID, &req)
+	if err != nil {
+		WriteInternalError(w, "failed to register device token")
+		return
+	}
+
+	WriteSuccess(w, token)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// Party relation endpoints (admin)
+
+// ListPartyRelations returns the party's coalitions, mergers and renamings
+func (h *PoliticalPartyHandler) ListPartyRelations(w http.ResponseWriter, r *http.Request) {
+	party, ok := h.getParty(w, r)
+	if !ok {
+		return
+	}
+
+	relations, err := h.partyService.ListRelations(r.Context(), party.ID)
+	if err != nil {
+		WriteInternalError(w, "Failed to list party relations")
+		return
+	}
+	WriteSuccess(w, relations)
+}
+
+func (h *PoliticalPartyHandler) CreatePartyRelation(w http.ResponseWriter, r *http.Request) {
+	party, ok := h.getParty(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreatePartyRelationRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	relation, err := h.partyService.CreateRelation(r.Context(), party.ID, &req)
+	if err != nil {
+		writePartyRelationError(w, err, "Failed to create party relation")
+		return
+	}
+	WriteCreated(w, relation)
+}
+
+func (h *PoliticalPartyHandler) UpdatePartyRelation(w http.ResponseWriter, r *http.Request) {
+	relation, ok := h.getPartyRelation(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePartyRelationRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	updated, err := h.partyService.UpdateRelation(r.Context(), relation, &req)
+	if err != nil {
+		writePartyRelationError(w, err, "Failed to update party relation")
+		return
+	}
+	WriteSuccess(w, updated)
+}
+
+func (h *PoliticalPartyHandler) DeletePartyRelation(w http.ResponseWriter, r *http.Request) {
+	relation, ok := h.getPartyRelation(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.partyService.DeleteRelation(r.Context(), relation); err != nil {
+		writePartyRelationError(w, err, "Failed to delete party relation")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getParty loads the party from the URL
+func (h *PoliticalPartyHandler) getParty(w http.ResponseWriter, r *http.Request) (*models.PoliticalParty, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid party ID")
+		return nil, false
+	}
+
+	party, err := h.partyService.GetByID(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "Failed to get party")
+		return nil, false
+	}
+	if party == nil {
+		WriteNotFound(w, "Party not found")
+		return nil, false
+	}
+
+	return party, true
+}
+
+// getPartyRelation loads the relation from the URL and checks it belongs to the party
+func (h *PoliticalPartyHandler) getPartyRelation(w http.ResponseWriter, r *http.Request) (*models.PartyRelation, bool) {
+	party, ok := h.getParty(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	relationID, err := uuid.Parse(chi.URLParam(r, "relationId"))
+	if err != nil {
+		WriteBadRequest(w, "Invalid relation ID")
+		return nil, false
+	}
+
+	relation, err := h.partyService.GetRelation(r.Context(), party.ID, relationID)
+	if err != nil {
+		WriteInternalError(w, "Failed to get party relation")
+		return nil, false
+	}
+	if relation == nil {
+		WriteNotFound(w, "Party relation not found")
+		return nil, false
+	}
+
+	return relation, true
+}
+
+func writePartyRelationError(w http.ResponseWriter, err error, fallback string) {
+	switch err.Error() {
+	case "related party not found", "end_date cannot be before start_date":
+		WriteBadRequest(w, err.Error())
+	case "party relation not found":
+		WriteNotFound(w, "Party relation not found")
+	case "party cannot be its own predecessor", "a coalition cannot be a member of itself":
+		WriteError(w, http.StatusConflict, "PARTY_RELATION_CYCLE", err.Error())
+	case "party already merged into another party for this period":
+		WriteError(w, http.StatusConflict, "PARTY_ALREADY_MERGED", err.Error())
+	default:
+		WriteInternalError(w, fallback)
+	}
+}
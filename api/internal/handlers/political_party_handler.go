@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -41,7 +42,7 @@ func (h *PoliticalPartyHandler) GetParties(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	WriteSuccess(w, parties)
+	WritePaginated(w, r, parties)
 }
 
 // GetAllParties returns all political parties (for dropdowns)
@@ -129,21 +130,34 @@ func (h *PoliticalPartyHandler) GetPositionBySlug(w http.ResponseWriter, r *http
 	WriteSuccess(w, position)
 }
 
-// FindMyRepresentatives returns representatives for a given barangay
+// FindMyRepresentatives returns representatives for a given barangay, or for
+// a city/municipality (such as one from /api/locations/detect) when the
+// barangay isn't known
 func (h *PoliticalPartyHandler) FindMyRepresentatives(w http.ResponseWriter, r *http.Request) {
-	barangayIDStr := r.URL.Query().Get("barangay_id")
-	if barangayIDStr == "" {
-		WriteBadRequest(w, "barangay_id is required")
-		return
-	}
-
-	barangayID, err := uuid.Parse(barangayIDStr)
-	if err != nil {
-		WriteBadRequest(w, "Invalid barangay_id")
+	query := r.URL.Query()
+	barangayIDStr, cityIDStr := query.Get("barangay_id"), query.Get("city_municipality_id")
+
+	var representatives []models.PoliticianListItem
+	var err error
+	switch {
+	case barangayIDStr != "":
+		barangayID, parseErr := uuid.Parse(barangayIDStr)
+		if parseErr != nil {
+			WriteBadRequest(w, "Invalid barangay_id")
+			return
+		}
+		representatives, err = h.partyService.FindRepresentativesByBarangay(r.Context(), barangayID)
+	case cityIDStr != "":
+		cityID, parseErr := uuid.Parse(cityIDStr)
+		if parseErr != nil {
+			WriteBadRequest(w, "Invalid city_municipality_id")
+			return
+		}
+		representatives, err = h.partyService.FindRepresentativesByCity(r.Context(), cityID)
+	default:
+		WriteBadRequest(w, "barangay_id or city_municipality_id is required")
 		return
 	}
-
-	representatives, err := h.partyService.FindRepresentativesByBarangay(r.Context(), barangayID)
 	if err != nil {
 		WriteInternalError(w, "Failed to find representatives")
 		return
@@ -229,6 +243,11 @@ func (h *PoliticalPartyHandler) CreateJurisdiction(w http.ResponseWriter, r *htt
 
 	jurisdiction, err := h.partyService.CreateJurisdiction(r.Context(), &req)
 	if err != nil {
+		var hierarchyErr *models.LocationHierarchyError
+		if errors.As(err, &hierarchyErr) {
+			WriteBadRequest(w, hierarchyErr.Message)
+			return
+		}
 		WriteInternalError(w, "Failed to create jurisdiction")
 		return
 	}
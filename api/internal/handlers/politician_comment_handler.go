@@ -67,7 +67,7 @@ func (h *PoliticianCommentHandler) ListComments(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	WriteSuccess(w, result)
+	WritePaginated(w, r, result)
 }
 
 // CreateComment POST /api/politicians/{slug}/comments - Create a new comment
@@ -109,6 +109,10 @@ func (h *PoliticianCommentHandler) CreateComment(w http.ResponseWriter, r *http.
 			WriteUnauthorized(w, "user session invalid - please log out and log in again")
 			return
 		}
+		if errMsg == "you cannot reply to this comment" {
+			WriteError(w, http.StatusForbidden, "REPLY_BLOCKED", errMsg)
+			return
+		}
 		WriteInternalError(w, errMsg)
 		return
 	}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/middleware"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/services"
 )
@@ -13,17 +16,55 @@ import (
 type PoliticianHandler struct {
 	politicianService *services.PoliticianService
 	articleService    *services.ArticleService
+	mergeService      *services.MergeService
+	partyService      *services.PoliticalPartyService
 }
 
-func NewPoliticianHandler(politicianService *services.PoliticianService, articleService *services.ArticleService) *PoliticianHandler {
+func NewPoliticianHandler(politicianService *services.PoliticianService, articleService *services.ArticleService, mergeService *services.MergeService) *PoliticianHandler {
 	return &PoliticianHandler{
 		politicianService: politicianService,
 		articleService:    articleService,
+		mergeService:      mergeService,
 	}
 }
 
+// SetPartyService enables showing the party's coalition during an election
+// period
+func (h *PoliticianHandler) SetPartyService(partyService *services.PoliticalPartyService) {
+	h.partyService = partyService
+}
+
+// RedirectMerged sends requests for a merged politician's old slug to the
+// politician it was merged into, keeping the rest of the path and query
+func (h *PoliticianHandler) RedirectMerged(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := chi.URLParam(r, "slug")
+		target, err := h.mergeService.ResolveMergedSlug(r.Context(), slug)
+		if err != nil || target == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		location := *r.URL
+		location.Path = strings.Replace(r.URL.Path, "/politicians/"+slug, "/politicians/"+target, 1)
+		location.RawPath = ""
+
+		// 308 keeps the method and body for writes like posting a comment
+		status := http.StatusMovedPermanently
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			status = http.StatusPermanentRedirect
+		}
+		http.Redirect(w, r, location.String(), status)
+	})
+}
+
 // GET /api/politicians - List all politicians (public)
 func (h *PoliticianHandler) List(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("active") == "true" {
+		h.listActive(w, r)
+		return
+	}
+
 	politicians, err := h.politicianService.ListAll(r.Context())
 	if err != nil {
 		WriteInternalError(w, "failed to fetch politicians")
@@ -33,10 +74,64 @@ func (h *PoliticianHandler) List(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, politicians)
 }
 
-// GET /api/politicians/search?q= - Search politicians for autocomplete
+// GET /api/politicians?active=true&position_id=&level=&party_id=&party= -
+// Page through politicians serving today, shaped like the admin list
+func (h *PoliticianHandler) listActive(w http.ResponseWriter, r *http.Request) {
+	page, perPage := GetPaginationParams(r)
+	query := r.URL.Query()
+
+	filter := &models.PoliticianFilter{Active: true}
+	if v := query.Get("position_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			WriteBadRequest(w, "invalid position ID")
+			return
+		}
+		filter.PositionID = &id
+	}
+	if v := query.Get("party_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			WriteBadRequest(w, "invalid party ID")
+			return
+		}
+		filter.PartyID = &id
+	}
+	if v := query.Get("party"); v != "" {
+		filter.Party = &v
+	}
+	if v := query.Get("level"); v != "" {
+		if !slices.Contains(models.GovernmentLevels, v) {
+			WriteBadRequest(w, "invalid government level")
+			return
+		}
+		filter.Level = &v
+	}
+
+	politicians, err := h.politicianService.List(r.Context(), filter, page, perPage)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch politicians")
+		return
+	}
+
+	WritePaginated(w, r, politicians)
+}
+
+// GET /api/politicians/search?q=&has_social= - Search politicians for autocomplete
 func (h *PoliticianHandler) Search(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
-	if query == "" {
+
+	var hasSocial *models.SocialPlatform
+	if v := r.URL.Query().Get("has_social"); v != "" {
+		platform := models.SocialPlatform(v)
+		if !models.IsValidSocialPlatform(platform) {
+			WriteBadRequest(w, "invalid has_social platform")
+			return
+		}
+		hasSocial = &platform
+	}
+
+	if query == "" && hasSocial == nil {
 		WriteSuccess(w, []models.Politician{})
 		return
 	}
@@ -49,7 +144,7 @@ func (h *PoliticianHandler) Search(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	politicians, err := h.politicianService.Search(r.Context(), query, limit)
+	politicians, err := h.politicianService.Search(r.Context(), query, hasSocial, limit)
 	if err != nil {
 		WriteInternalError(w, "failed to search politicians")
 		return
@@ -77,6 +172,15 @@ func (h *PoliticianHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.partyService != nil && politician.PartyInfo != nil {
+		coalition, err := h.partyService.GetElectionPeriodCoalition(r.Context(), politician.PartyInfo.ID)
+		if err != nil {
+			WriteInternalError(w, "failed to fetch party coalition")
+			return
+		}
+		politician.Coalition = coalition
+	}
+
 	page, perPage := GetPaginationParams(r)
 
 	status := models.ArticleStatusPublished
@@ -93,7 +197,7 @@ func (h *PoliticianHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
 
 	WriteSuccess(w, map[string]interface{}{
 		"politician": politician,
-		"articles":   articles,
+		"articles":   PaginatedData(w, r, articles),
 	})
 }
 
@@ -124,7 +228,7 @@ func (h *PoliticianHandler) AdminList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, politicians)
+	WritePaginated(w, r, politicians)
 }
 
 // GET /api/admin/politicians/:id - Get politician by ID (admin)
@@ -182,7 +286,7 @@ func (h *PoliticianHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	politician, err := h.politicianService.Update(r.Context(), id, &req)
+	politician, err := h.politicianService.Update(r.Context(), id, &req, editorID(r))
 	if err != nil {
 		WriteInternalError(w, err.Error())
 		return
@@ -196,6 +300,37 @@ func (h *PoliticianHandler) Update(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, politician)
 }
 
+// GET /api/admin/politicians/:id/history - Recent changes to the politician's bio
+func (h *PoliticianHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid politician ID")
+		return
+	}
+
+	history, err := h.politicianService.GetHistory(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch politician history")
+		return
+	}
+
+	WriteSuccess(w, history)
+}
+
+// editorID returns the signed-in user making an admin edit, or nil if the
+// token has no valid user ID
+func editorID(r *http.Request) *uuid.UUID {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		return nil
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
+
 // DELETE /api/admin/politicians/:id - Delete politician (soft)
 func (h *PoliticianHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -229,3 +364,261 @@ func (h *PoliticianHandler) Restore(w http.ResponseWriter, r *http.Request) {
 
 	WriteSuccess(w, map[string]string{"message": "politician restored"})
 }
+
+// POST /api/admin/politicians/:id/merge - Merge a duplicate politician into another record
+func (h *PoliticianHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid politician ID")
+		return
+	}
+
+	var req models.MergePoliticianRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	result, err := h.mergeService.MergePolitician(r.Context(), id, req.MergeIntoID)
+	if err != nil {
+		switch err.Error() {
+		case "politician not found":
+			WriteNotFound(w, err.Error())
+		case "merge target not found", "cannot merge a politician into itself":
+			WriteBadRequest(w, err.Error())
+		default:
+			WriteInternalError(w, err.Error())
+		}
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
+// GET /api/admin/politicians/:id/tenures - List tenures for a politician
+func (h *PoliticianHandler) ListTenures(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid politician ID")
+		return
+	}
+
+	tenures, err := h.politicianService.ListTenures(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, tenures)
+}
+
+// POST /api/admin/politicians/:id/tenures - Add a tenure to a politician
+func (h *PoliticianHandler) CreateTenure(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid politician ID")
+		return
+	}
+
+	var req models.CreatePoliticianTenureRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	politician, err := h.politicianService.GetByID(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+	if politician == nil {
+		WriteNotFound(w, "politician not found")
+		return
+	}
+
+	tenure, err := h.politicianService.CreateTenure(r.Context(), id, &req)
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	WriteCreated(w, tenure)
+}
+
+// PUT /api/admin/politicians/:id/tenures/:tenureId - Update a tenure
+func (h *PoliticianHandler) UpdateTenure(w http.ResponseWriter, r *http.Request) {
+	tenure, ok := h.getTenure(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePoliticianTenureRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	updated, err := h.politicianService.UpdateTenure(r.Context(), tenure, &req)
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, updated)
+}
+
+// DELETE /api/admin/politicians/:id/tenures/:tenureId - Delete a tenure
+func (h *PoliticianHandler) DeleteTenure(w http.ResponseWriter, r *http.Request) {
+	tenure, ok := h.getTenure(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.politicianService.DeleteTenure(r.Context(), tenure); err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "tenure deleted"})
+}
+
+// getTenure loads the tenure from the URL and checks it belongs to the politician
+func (h *PoliticianHandler) getTenure(w http.ResponseWriter, r *http.Request) (*models.PoliticianTenure, bool) {
+	politicianID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid politician ID")
+		return nil, false
+	}
+
+	tenureID, err := uuid.Parse(chi.URLParam(r, "tenureId"))
+	if err != nil {
+		WriteBadRequest(w, "invalid tenure ID")
+		return nil, false
+	}
+
+	tenure, err := h.politicianService.GetTenure(r.Context(), politicianID, tenureID)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return nil, false
+	}
+	if tenure == nil {
+		WriteNotFound(w, "tenure not found")
+		return nil, false
+	}
+
+	return tenure, true
+}
+
+// GET /api/admin/politicians/:id/social-links - List social links for a politician
+func (h *PoliticianHandler) ListSocialLinks(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid politician ID")
+		return
+	}
+
+	links, err := h.politicianService.ListSocialLinks(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, links)
+}
+
+// POST /api/admin/politicians/:id/social-links - Add a social link to a politician
+func (h *PoliticianHandler) CreateSocialLink(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid politician ID")
+		return
+	}
+
+	var req models.CreatePoliticianSocialLinkRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	politician, err := h.politicianService.GetByID(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+	if politician == nil {
+		WriteNotFound(w, "politician not found")
+		return
+	}
+
+	link, err := h.politicianService.CreateSocialLink(r.Context(), id, &req)
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	WriteCreated(w, link)
+}
+
+// PUT /api/admin/politicians/:id/social-links/:linkId - Update a social link
+func (h *PoliticianHandler) UpdateSocialLink(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.getSocialLink(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePoliticianSocialLinkRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	updated, err := h.politicianService.UpdateSocialLink(r.Context(), link, &req)
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, updated)
+}
+
+// DELETE /api/admin/politicians/:id/social-links/:linkId - Delete a social link
+func (h *PoliticianHandler) DeleteSocialLink(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.getSocialLink(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.politicianService.DeleteSocialLink(r.Context(), link); err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "social link deleted"})
+}
+
+// getSocialLink loads the social link from the URL and checks it belongs to the politician
+func (h *PoliticianHandler) getSocialLink(w http.ResponseWriter, r *http.Request) (*models.PoliticianSocialLink, bool) {
+	politicianID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid politician ID")
+		return nil, false
+	}
+
+	linkID, err := uuid.Parse(chi.URLParam(r, "linkId"))
+	if err != nil {
+		WriteBadRequest(w, "invalid social link ID")
+		return nil, false
+	}
+
+	link, err := h.politicianService.GetSocialLink(r.Context(), politicianID, linkID)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return nil, false
+	}
+	if link == nil {
+		WriteNotFound(w, "social link not found")
+		return nil, false
+	}
+
+	return link, true
+}
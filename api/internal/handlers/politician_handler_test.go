@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+type mergedSlugs map[string]string
+
+func (m mergedSlugs) GetByID(context.Context, uuid.UUID) (*models.Politician, error) {
+	return nil, nil
+}
+
+func (m mergedSlugs) MergePolitician(context.Context, uuid.UUID, uuid.UUID) (*models.PoliticianMergeResult, error) {
+	return nil, nil
+}
+
+func (m mergedSlugs) GetMergedTargetSlug(_ context.Context, slug string) (string, error) {
+	return m[slug], nil
+}
+
+func TestPoliticianHandler_RedirectMerged(t *testing.T) {
+	h := &PoliticianHandler{
+		mergeService: services.NewMergeService(mergedSlugs{"juan-cruz": "juan-dela-cruz"}, nil),
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r := chi.NewRouter()
+	r.Route("/api/politicians/{slug}", func(r chi.Router) {
+		r.Use(h.RedirectMerged)
+		r.Get("/", ok)
+		r.Get("/comments", ok)
+		r.Post("/comments", ok)
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		status   int
+		location string
+	}{
+		{"profile", http.MethodGet, "/api/politicians/juan-cruz", http.StatusMovedPermanently, "/api/politicians/juan-dela-cruz"},
+		{"sub-resource keeps path and query", http.MethodGet, "/api/politicians/juan-cruz/comments?page=2", http.StatusMovedPermanently, "/api/politicians/juan-dela-cruz/comments?page=2"},
+		{"writes keep their method", http.MethodPost, "/api/politicians/juan-cruz/comments", http.StatusPermanentRedirect, "/api/politicians/juan-dela-cruz/comments"},
+		{"active slug passes through", http.MethodGet, "/api/politicians/juan-dela-cruz", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.location, w.Header().Get("Location"))
+		})
+	}
+}
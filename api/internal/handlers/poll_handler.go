@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
@@ -54,6 +55,9 @@ func (h *PollHandler) ListPolls(w http.ResponseWriter, r *http.Request) {
 			filter.ElectionID = &id
 		}
 	}
+	if !parsePollAttachmentFilter(w, query, filter) {
+		return
+	}
 
 	// Location filters
 	if regionID := query.Get("region_id"); regionID != "" {
@@ -87,7 +91,28 @@ func (h *PollHandler) ListPolls(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, result)
+	WritePaginated(w, r, result)
+}
+
+// parsePollAttachmentFilter reads the bill_id and attachment filters, writing
+// a 400 for an unknown attachment type
+func parsePollAttachmentFilter(w http.ResponseWriter, query url.Values, filter *models.PollFilter) bool {
+	if billID := query.Get("bill_id"); billID != "" {
+		if id, err := uuid.Parse(billID); err == nil {
+			filter.BillID = &id
+		}
+	}
+
+	switch attachment := query.Get("attachment"); attachment {
+	case "":
+	case models.PollAttachmentPolitician, models.PollAttachmentElection, models.PollAttachmentBill,
+		models.PollAttachmentLocation, models.PollAttachmentNone:
+		filter.AttachmentType = &attachment
+	default:
+		WriteBadRequest(w, "attachment must be one of politician, election, bill, location or none")
+		return false
+	}
+	return true
 }
 
 func (h *PollHandler) GetPollBySlug(w http.ResponseWriter, r *http.Request) {
@@ -215,7 +240,7 @@ func (h *PollHandler) CastVote(w http.ResponseWriter, r *http.Request) {
 
 	ip := getClientIP(r)
 
-	result, err := h.service.CastVote(r.Context(), pollID, req.OptionID, userID, ip)
+	result, err := h.service.CastVote(r.Context(), pollID, req.OptionID, userID, ip, req.RegionID)
 	if err != nil {
 		WriteInternalError(w, err.Error())
 		return
@@ -229,6 +254,45 @@ func (h *PollHandler) CastVote(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, result)
 }
 
+// GET /api/polls/{slug}/results/timeline?bucket=hour|day (admin only)
+func (h *PollHandler) GetVotingTimeline(w http.ResponseWriter, r *http.Request) {
+	bucket := r.URL.Query().Get("bucket")
+	if bucket == "" {
+		bucket = models.PollTimelineBucketHour
+	}
+	if bucket != models.PollTimelineBucketHour && bucket != models.PollTimelineBucketDay {
+		WriteBadRequest(w, "bucket must be hour or day")
+		return
+	}
+
+	timeline, err := h.service.GetVotingTimeline(r.Context(), chi.URLParam(r, "slug"), bucket)
+	if err != nil {
+		if err.Error() == "poll not found" {
+			WriteNotFound(w, "Poll not found")
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, timeline)
+}
+
+// GET /api/polls/{slug}/results/by-region
+func (h *PollHandler) GetResultsByRegion(w http.ResponseWriter, r *http.Request) {
+	results, err := h.service.GetResultsByRegion(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		if err.Error() == "poll not found" {
+			WriteNotFound(w, "Poll not found")
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, results)
+}
+
 // Comments
 
 func (h *PollHandler) GetPollComments(w http.ResponseWriter, r *http.Request) {
@@ -255,7 +319,7 @@ func (h *PollHandler) GetPollComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, comments)
+	WritePaginated(w, r, comments)
 }
 
 func (h *PollHandler) CreatePollComment(w http.ResponseWriter, r *http.Request) {
@@ -393,7 +457,7 @@ func (h *PollHandler) GetMyPolls(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, result)
+	WritePaginated(w, r, result)
 }
 
 func (h *PollHandler) DeletePoll(w http.ResponseWriter, r *http.Request) {
@@ -426,7 +490,7 @@ func (h *PollHandler) AdminListPolls(w http.ResponseWriter, r *http.Request) {
 		perPage = 20
 	}
 
-	filter := &models.PollFilter{}
+	filter := &models.PollFilter{IncludeDeleted: GetIncludeDeleted(r)}
 
 	if category := query.Get("category"); category != "" {
 		filter.Category = &category
@@ -441,6 +505,9 @@ func (h *PollHandler) AdminListPolls(w http.ResponseWriter, r *http.Request) {
 		featured := true
 		filter.IsFeatured = &featured
 	}
+	if !parsePollAttachmentFilter(w, query, filter) {
+		return
+	}
 
 	// Location filters
 	if regionID := query.Get("region_id"); regionID != "" {
@@ -470,7 +537,7 @@ func (h *PollHandler) AdminListPolls(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, result)
+	WritePaginated(w, r, result)
 }
 
 func (h *PollHandler) AdminUpdatePoll(w http.ResponseWriter, r *http.Request) {
@@ -21,9 +21,7 @@ func NewRoleHandler(roleService *services.RoleService) *RoleHandler {
 // List returns all roles
 func (h *RoleHandler) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	includeDeleted := r.URL.Query().Get("include_deleted") == "true"
-
-	roles, err := h.roleService.ListRoles(ctx, includeDeleted)
+	roles, err := h.roleService.ListRoles(ctx, GetIncludeDeleted(r))
 	if err != nil {
 		WriteInternalError(w, "Failed to list roles")
 		return
@@ -140,6 +138,39 @@ func (h *RoleHandler) Restore(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, map[string]string{"message": "Role restored successfully"})
 }
 
+// SetPermissions replaces a role's permissions with the given set
+func (h *RoleHandler) SetPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		WriteBadRequest(w, "Invalid role ID")
+		return
+	}
+
+	var req models.SetRolePermissionsRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteBadRequest(w, "permission_ids must be a list of permission IDs")
+		return
+	}
+
+	permissions, err := h.roleService.SetRolePermissions(ctx, id, req.PermissionIDs)
+	if err != nil {
+		switch err.Error() {
+		case "role not found":
+			WriteNotFound(w, "Role not found")
+		case "unknown permission", "cannot remove permissions from system role":
+			WriteBadRequest(w, err.Error())
+		default:
+			WriteInternalError(w, "Failed to set role permissions")
+		}
+		return
+	}
+
+	WriteSuccess(w, permissions)
+}
+
 // ListPermissions returns all permissions grouped by category
 func (h *RoleHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"encoding/xml"
+	"mime"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/humfurie/pulpulitiko/api/internal/models"
@@ -12,20 +16,47 @@ import (
 type RSSHandler struct {
 	articleService *services.ArticleService
 	siteURL        string
+	siteLocation   *time.Location
 }
 
 func NewRSSHandler(articleService *services.ArticleService, siteURL string) *RSSHandler {
 	return &RSSHandler{
 		articleService: articleService,
 		siteURL:        siteURL,
+		siteLocation:   time.UTC,
 	}
 }
 
-// RSS 2.0 structures
+// SetSiteLocation sets the timezone feed dates are written in
+func (h *RSSHandler) SetSiteLocation(loc *time.Location) {
+	h.siteLocation = loc
+}
+
+// formatDate writes a time in the site timezone as RSS 2.0 expects (RFC 1123
+// with a numeric offset)
+func (h *RSSHandler) formatDate(t time.Time) string {
+	return t.In(h.siteLocation).Format(time.RFC1123Z)
+}
+
+// Namespaces declared on the feed
+const (
+	rssNamespaceAtom    = "http://www.w3.org/2005/Atom"
+	rssNamespaceDC      = "http://purl.org/dc/elements/1.1/"
+	rssNamespaceContent = "http://purl.org/rss/1.0/modules/content/"
+	rssNamespaceMedia   = "http://search.yahoo.com/mrss/"
+)
+
+// rssFeedSize is how many articles the feed lists
+const rssFeedSize = 20
+
+// RSS 2.0 structures, with the Dublin Core, content and Media RSS modules
 type RSS struct {
 	XMLName xml.Name   `xml:"rss"`
 	Version string     `xml:"version,attr"`
 	Atom    string     `xml:"xmlns:atom,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Content string     `xml:"xmlns:content,attr"`
+	Media   string     `xml:"xmlns:media,attr"`
 	Channel RSSChannel `xml:"channel"`
 }
 
@@ -46,72 +77,123 @@ type AtomLink struct {
 }
 
 type RSSItem struct {
-	Title       string `xml:"title"`
-	Link        string `xml:"link"`
-	Description string `xml:"description"`
-	Author      string `xml:"author,omitempty"`
-	Category    string `xml:"category,omitempty"`
-	GUID        string `xml:"guid"`
-	PubDate     string `xml:"pubDate"`
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	Category    string        `xml:"category,omitempty"`
+	GUID        RSSGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Creators    []string      `xml:"dc:creator"`
+	Subjects    []string      `xml:"dc:subject"`
+	Content     *RSSCDATA     `xml:"content:encoded"`
+	Enclosure   *RSSEnclosure `xml:"enclosure"`
+	Media       *MediaContent `xml:"media:content"`
+}
+
+type RSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSSCDATA writes its text as a CDATA section
+type RSSCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+// RSSEnclosure is an article's cover image. The file's size isn't stored, so
+// the length is 0, which RSS readers take to mean unknown.
+type RSSEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// MediaContent is a media:content image, with its rendition's size when known
+// and its caption and photographer credit when the article gives them
+type MediaContent struct {
+	URL         string          `xml:"url,attr"`
+	Medium      string          `xml:"medium,attr"`
+	Type        string          `xml:"type,attr,omitempty"`
+	Width       int             `xml:"width,attr,omitempty"`
+	Height      int             `xml:"height,attr,omitempty"`
+	Thumbnail   *MediaThumbnail `xml:"media:thumbnail"`
+	Description string          `xml:"media:description,omitempty"`
+	Credit      string          `xml:"media:credit,omitempty"`
+}
+
+type MediaThumbnail struct {
+	URL    string `xml:"url,attr"`
+	Width  int    `xml:"width,attr,omitempty"`
+	Height int    `xml:"height,attr,omitempty"`
 }
 
 // GET /rss or /feed
 func (h *RSSHandler) Feed(w http.ResponseWriter, r *http.Request) {
-	// Get latest published articles
-	status := models.ArticleStatusPublished
-	filter := &models.ArticleFilter{
-		Status: &status,
-	}
-
-	articles, err := h.articleService.List(r.Context(), filter, 1, 20)
+	articles, err := h.articleService.ListRSS(r.Context(), rssFeedSize)
 	if err != nil {
 		http.Error(w, "Failed to fetch articles", http.StatusInternalServerError)
 		return
 	}
 
-	// Build RSS items
-	items := make([]RSSItem, 0, len(articles.Articles))
-	for _, article := range articles.Articles {
-		description := ""
+	rss := h.buildFeed(articles, time.Now())
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=900") // 15 minutes cache
+
+	_, _ = w.Write([]byte(xml.Header))
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(rss); err != nil {
+		http.Error(w, "Failed to encode RSS", http.StatusInternalServerError)
+		return
+	}
+}
+
+// buildFeed makes the feed document for articles, newest first
+func (h *RSSHandler) buildFeed(articles []models.Article, now time.Time) RSS {
+	items := make([]RSSItem, 0, len(articles))
+	for _, article := range articles {
+		link := h.siteURL + "/article/" + article.Slug
+		item := RSSItem{
+			Title:     article.Title,
+			Link:      link,
+			GUID:      RSSGUID{IsPermaLink: true, Value: link},
+			Enclosure: rssEnclosure(article),
+			Media:     rssMedia(article),
+		}
 		if article.Summary != nil {
-			description = *article.Summary
+			item.Description = *article.Summary
 		}
-
-		pubDate := ""
 		if article.PublishedAt != nil {
-			pubDate = article.PublishedAt.Format(time.RFC1123Z)
+			item.PubDate = h.formatDate(*article.PublishedAt)
 		}
-
-		author := ""
-		if article.AuthorName != nil {
-			author = *article.AuthorName
+		if article.Category != nil {
+			item.Category = article.Category.Name
 		}
-
-		category := ""
-		if article.CategoryName != nil {
-			category = *article.CategoryName
+		for _, author := range article.Authors {
+			item.Creators = append(item.Creators, author.Name)
 		}
-
-		items = append(items, RSSItem{
-			Title:       article.Title,
-			Link:        h.siteURL + "/article/" + article.Slug,
-			Description: description,
-			Author:      author,
-			Category:    category,
-			GUID:        h.siteURL + "/article/" + article.Slug,
-			PubDate:     pubDate,
-		})
+		for _, tag := range article.Tags {
+			item.Subjects = append(item.Subjects, tag.Name)
+		}
+		if article.Content != "" {
+			item.Content = &RSSCDATA{Value: article.Content}
+		}
+		items = append(items, item)
 	}
 
-	rss := RSS{
+	return RSS{
 		Version: "2.0",
-		Atom:    "http://www.w3.org/2005/Atom",
+		Atom:    rssNamespaceAtom,
+		DC:      rssNamespaceDC,
+		Content: rssNamespaceContent,
+		Media:   rssNamespaceMedia,
 		Channel: RSSChannel{
 			Title:         "Pulpulitiko - Philippine Politics News",
 			Link:          h.siteURL,
 			Description:   "Your trusted source for Philippine political news and commentary",
 			Language:      "en-ph",
-			LastBuildDate: time.Now().Format(time.RFC1123Z),
+			LastBuildDate: h.formatDate(now),
 			AtomLink: AtomLink{
 				Href: h.siteURL + "/rss",
 				Rel:  "self",
@@ -120,15 +202,67 @@ func (h *RSSHandler) Feed(w http.ResponseWriter, r *http.Request) {
 			Items: items,
 		},
 	}
+}
 
-	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
-	w.Header().Set("Cache-Control", "public, max-age=900") // 15 minutes cache
+// rssMedia describes an article's featured image. The renditions have known
+// sizes; an original upload is described by its file extension alone.
+func rssMedia(article models.Article) *MediaContent {
+	media := rssImage(article)
+	if media != nil && article.FeaturedMedia != nil {
+		if article.FeaturedMedia.Caption != nil {
+			media.Description = *article.FeaturedMedia.Caption
+		}
+		if article.FeaturedMedia.Credit != nil {
+			media.Credit = *article.FeaturedMedia.Credit
+		}
+	}
+	return media
+}
 
-	_, _ = w.Write([]byte(xml.Header))
-	encoder := xml.NewEncoder(w)
-	encoder.Indent("", "  ")
-	if err := encoder.Encode(rss); err != nil {
-		http.Error(w, "Failed to encode RSS", http.StatusInternalServerError)
-		return
+func rssImage(article models.Article) *MediaContent {
+	if article.Images != nil {
+		return &MediaContent{
+			URL:    article.Images.Full,
+			Medium: "image",
+			Type:   imageTypeOf(article.Images.Full),
+			Width:  models.ArticleFullWidth,
+			Height: models.ArticleFullHeight,
+			Thumbnail: &MediaThumbnail{
+				URL:    article.Images.Thumb,
+				Width:  models.ArticleThumbWidth,
+				Height: models.ArticleThumbHeight,
+			},
+		}
+	}
+	if article.FeaturedImage == nil || *article.FeaturedImage == "" {
+		return nil
+	}
+
+	return &MediaContent{URL: *article.FeaturedImage, Medium: "image", Type: imageTypeOf(*article.FeaturedImage)}
+}
+
+// rssEnclosure describes an article's cover image. An enclosure needs a
+// content type, so a cover whose type can't be told from its URL is left out.
+func rssEnclosure(article models.Article) *RSSEnclosure {
+	if article.CoverImage == nil || *article.CoverImage == "" {
+		return nil
+	}
+	imageType := imageTypeOf(*article.CoverImage)
+	if imageType == "" {
+		return nil
+	}
+	return &RSSEnclosure{URL: *article.CoverImage, Type: imageType}
+}
+
+// imageTypeOf guesses an image URL's content type from its file extension,
+// or returns "" when the extension isn't an image's
+func imageTypeOf(imageURL string) string {
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return ""
+	}
+	if t := mime.TypeByExtension(path.Ext(u.Path)); strings.HasPrefix(t, "image/") {
+		return t
 	}
+	return ""
 }
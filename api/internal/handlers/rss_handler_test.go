@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // Asia/Manila without relying on the system zone database
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSSFormatDate(t *testing.T) {
+	published := time.Date(2025, 5, 12, 16, 30, 0, 0, time.UTC)
+
+	h := NewRSSHandler(nil, "https://pulpulitiko.com")
+	assert.Equal(t, "Mon, 12 May 2025 16:30:00 +0000", h.formatDate(published))
+
+	manila, err := time.LoadLocation("Asia/Manila")
+	require.NoError(t, err)
+	h.SetSiteLocation(manila)
+
+	// Half past midnight the next day in Manila, at +08:00
+	assert.Equal(t, "Tue, 13 May 2025 00:30:00 +0800", h.formatDate(published))
+}
+
+func TestRSSBuildFeed(t *testing.T) {
+	h := NewRSSHandler(nil, "https://pulpulitiko.com")
+	published := time.Date(2025, 5, 12, 8, 0, 0, 0, time.UTC)
+	summary := "Senate passes the budget"
+	upload := "https://cdn.pulpulitiko.com/uploads/budget.jpg"
+	caption, credit := "Senators vote on the budget", "Juan dela Cruz/Pulpulitiko"
+	cover := "https://cdn.pulpulitiko.com/covers/budget.png"
+
+	articles := []models.Article{
+		{
+			Slug:        "budget-passes",
+			Title:       "Budget passes",
+			Summary:     &summary,
+			Content:     "<p>The vote was 20-3.</p><p>Details ]]> here.</p>",
+			PublishedAt: &published,
+			CoverImage:  &cover,
+			Images:      &models.ArticleImages{Thumb: "https://cdn.pulpulitiko.com/a/thumb.webp", Full: "https://cdn.pulpulitiko.com/a/full.webp"},
+			Category:    &models.Category{Name: "Senate"},
+			Authors:     []models.ArticleAuthor{{Name: "Maria Santos"}, {Name: "Jose Cruz", Position: 1}},
+			Tags:        []models.Tag{{Name: "Budget"}, {Name: "Senate"}},
+			FeaturedMedia: &models.ArticleMedia{
+				Caption: &caption,
+				Credit:  &credit,
+			},
+		},
+		{Slug: "no-renditions", Title: "No renditions", PublishedAt: &published, FeaturedImage: &upload},
+		{Slug: "no-image", Title: "No image", PublishedAt: &published},
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	require.NoError(t, xml.NewEncoder(&buf).Encode(h.buildFeed(articles, published)))
+	validateRSS(t, buf.Bytes())
+
+	var feed parsedRSS
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &feed))
+	assert.Equal(t, "https://pulpulitiko.com/rss", feed.Channel.AtomLink.Href)
+	assert.Equal(t, "self", feed.Channel.AtomLink.Rel)
+	require.Len(t, feed.Channel.Items, 3)
+
+	item := feed.Channel.Items[0]
+	assert.Equal(t, []string{"Maria Santos", "Jose Cruz"}, item.Creators)
+	assert.Equal(t, []string{"Budget", "Senate"}, item.Subjects)
+	assert.Equal(t, articles[0].Content, item.Content)
+	assert.Equal(t, "Senate", item.Category)
+	require.NotNil(t, item.Media)
+	assert.Equal(t, "https://cdn.pulpulitiko.com/a/full.webp", item.Media.URL)
+	assert.Equal(t, "image/webp", item.Media.Type)
+	assert.Equal(t, 1200, item.Media.Width)
+	assert.Equal(t, 675, item.Media.Height)
+	assert.Equal(t, caption, item.Media.Description)
+	assert.Equal(t, credit, item.Media.Credit)
+	require.NotNil(t, item.Enclosure)
+	assert.Equal(t, cover, item.Enclosure.URL)
+	assert.Equal(t, "image/png", item.Enclosure.Type)
+	assert.Equal(t, "0", item.Enclosure.Length)
+	assert.Nil(t, feed.Channel.Items[1].Enclosure)
+
+	require.NotNil(t, feed.Channel.Items[1].Media)
+	assert.Equal(t, upload, feed.Channel.Items[1].Media.URL)
+	assert.Equal(t, "image/jpeg", feed.Channel.Items[1].Media.Type)
+	assert.Zero(t, feed.Channel.Items[1].Media.Width)
+
+	assert.Nil(t, feed.Channel.Items[2].Media)
+	assert.Empty(t, feed.Channel.Items[2].Content)
+}
+
+// parsedRSS reads back the parts of the feed the test checks, by namespace
+type parsedRSS struct {
+	Channel struct {
+		AtomLink struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"http://www.w3.org/2005/Atom link"`
+		Items []struct {
+			Category  string   `xml:"category"`
+			Creators  []string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+			Subjects  []string `xml:"http://purl.org/dc/elements/1.1/ subject"`
+			Content   string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+			Enclosure *struct {
+				URL    string `xml:"url,attr"`
+				Length string `xml:"length,attr"`
+				Type   string `xml:"type,attr"`
+			} `xml:"enclosure"`
+			Media *struct {
+				URL         string `xml:"url,attr"`
+				Type        string `xml:"type,attr"`
+				Width       int    `xml:"width,attr"`
+				Height      int    `xml:"height,attr"`
+				Description string `xml:"http://search.yahoo.com/mrss/ description"`
+				Credit      string `xml:"http://search.yahoo.com/mrss/ credit"`
+			} `xml:"http://search.yahoo.com/mrss/ content"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// validateRSS checks a document against the RSS 2.0 structure: an rss root
+// at version 2.0 holding one channel with its required elements, items with
+// a title or description, RFC 822 dates, and only declared namespaces
+func validateRSS(t *testing.T, data []byte) {
+	t.Helper()
+
+	known := map[string]bool{
+		"": true, rssNamespaceAtom: true, rssNamespaceDC: true,
+		rssNamespaceContent: true, rssNamespaceMedia: true, "xmlns": true,
+	}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var stack []string
+	children := map[string]map[string]int{}
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			// Elements outside RSS itself are told apart by namespace
+			name := el.Name.Local
+			if el.Name.Space != "" {
+				name = el.Name.Space + " " + name
+			}
+			require.True(t, known[el.Name.Space], "undeclared namespace %q on %s", el.Name.Space, el.Name.Local)
+			for _, a := range el.Attr {
+				require.True(t, known[a.Name.Space], "undeclared namespace %q on %s", a.Name.Space, a.Name.Local)
+			}
+			if len(stack) == 0 {
+				require.Equal(t, "rss", el.Name.Local)
+				version := ""
+				for _, a := range el.Attr {
+					if a.Name.Space == "" && a.Name.Local == "version" {
+						version = a.Value
+					}
+				}
+				require.Equal(t, "2.0", version)
+			} else {
+				parent := strings.Join(stack, "/")
+				if children[parent] == nil {
+					children[parent] = map[string]int{}
+				}
+				children[parent][name]++
+			}
+			path := strings.Join(append(stack, name), "/")
+			children[path] = map[string]int{}
+			stack = append(stack, name)
+			text.Reset()
+		case xml.CharData:
+			text.Write(el)
+		case xml.EndElement:
+			path := strings.Join(stack, "/")
+			switch path {
+			case "rss/channel/item":
+				assert.True(t, children[path]["title"]+children[path]["description"] > 0, "item needs a title or description")
+				children[path] = nil
+			case "rss/channel/item/pubDate", "rss/channel/lastBuildDate":
+				_, err := time.Parse(time.RFC1123Z, strings.TrimSpace(text.String()))
+				assert.NoError(t, err, "%s is not an RFC 822 date", path)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	assert.Equal(t, 1, children["rss"]["channel"], "rss needs one channel")
+	for _, required := range []string{"title", "link", "description"} {
+		assert.Equal(t, 1, children["rss/channel"][required], "channel needs one %s", required)
+	}
+}
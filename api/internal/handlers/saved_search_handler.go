@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/middleware"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+)
+
+type SavedSearchHandler struct {
+	service *services.SavedSearchService
+}
+
+func NewSavedSearchHandler(service *services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{service: service}
+}
+
+// currentUserID returns the authenticated user's ID, writing a 401 if missing
+func (h *SavedSearchHandler) currentUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// GET /api/admin/searches - List the current editor's saved searches
+func (h *SavedSearchHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	searches, err := h.service.List(r.Context(), userID)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch saved searches")
+		return
+	}
+
+	WriteSuccess(w, searches)
+}
+
+// POST /api/admin/searches - Save an admin article search
+func (h *SavedSearchHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateSavedSearchRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	search, err := h.service.Create(r.Context(), userID, &req)
+	if err != nil {
+		switch err.Error() {
+		case "category not found", "published_after cannot be after published_before":
+			WriteBadRequest(w, err.Error())
+			return
+		}
+		WriteInternalError(w, "failed to save search")
+		return
+	}
+
+	WriteCreated(w, search)
+}
+
+// GET /api/admin/searches/:id - Get a saved search
+func (h *SavedSearchHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid saved search ID")
+		return
+	}
+
+	search, err := h.service.Get(r.Context(), id, userID)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch saved search")
+		return
+	}
+	if search == nil {
+		WriteNotFound(w, "saved search not found")
+		return
+	}
+
+	WriteSuccess(w, search)
+}
+
+// GET /api/admin/searches/:id/results - Run a saved search
+func (h *SavedSearchHandler) Execute(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid saved search ID")
+		return
+	}
+
+	page, perPage := GetPaginationParams(r)
+
+	articles, err := h.service.Execute(r.Context(), id, userID, page, perPage)
+	if err != nil {
+		switch err.Error() {
+		case "saved search not found":
+			WriteNotFound(w, err.Error())
+		case "saved search is broken":
+			WriteError(w, http.StatusConflict, "SAVED_SEARCH_BROKEN", "This saved search refers to a deleted record and needs to be recreated")
+		default:
+			WriteInternalError(w, "failed to run saved search")
+		}
+		return
+	}
+
+	WritePaginated(w, r, articles)
+}
+
+// DELETE /api/admin/searches/:id - Delete a saved search
+func (h *SavedSearchHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid saved search ID")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id, userID); err != nil {
+		if err.Error() == "saved search not found" {
+			WriteNotFound(w, err.Error())
+			return
+		}
+		WriteInternalError(w, "failed to delete saved search")
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "saved search deleted"})
+}
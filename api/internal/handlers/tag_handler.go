@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/middleware"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/services"
 )
@@ -40,7 +41,7 @@ func (h *TagHandler) AdminList(w http.ResponseWriter, r *http.Request) {
 	sortBy := r.URL.Query().Get("sort_by")
 	sortOrder := r.URL.Query().Get("sort_order")
 
-	filter := &models.TagFilter{}
+	filter := &models.TagFilter{IncludeDeleted: GetIncludeDeleted(r)}
 	if search != "" {
 		filter.Search = &search
 	}
@@ -57,7 +58,7 @@ func (h *TagHandler) AdminList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, paginatedTags)
+	WritePaginated(w, r, paginatedTags)
 }
 
 // GET /api/tags/:slug
@@ -75,9 +76,20 @@ func (h *TagHandler) GetArticlesBySlug(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if tag == nil {
+		// The slug may have been renamed
+		newSlug, err := h.tagService.ResolveSlugRedirect(r.Context(), slug)
+		if err != nil {
+			WriteInternalError(w, "failed to fetch tag")
+			return
+		}
+		if newSlug != "" {
+			WriteSlugRedirect(w, r, slug, newSlug)
+			return
+		}
 		WriteNotFound(w, "tag not found")
 		return
 	}
+	setCanonicalSlug(w, slug, tag.Slug)
 
 	page, perPage := GetPaginationParams(r)
 
@@ -93,12 +105,91 @@ func (h *TagHandler) GetArticlesBySlug(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Anonymous viewers still get the follower count, with is_following=false
+	var currentUserID *uuid.UUID
+	if claims := middleware.GetUserClaims(r.Context()); claims != nil {
+		if userID, err := uuid.Parse(claims.UserID); err == nil {
+			currentUserID = &userID
+		}
+	}
+
+	followStatus, err := h.tagService.GetFollowStatus(r.Context(), tag.ID, currentUserID)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch follow status")
+		return
+	}
+
 	WriteSuccess(w, map[string]interface{}{
-		"tag":      tag,
-		"articles": articles,
+		"tag":            tag,
+		"articles":       PaginatedData(w, r, articles),
+		"follower_count": followStatus.FollowerCount,
+		"is_following":   followStatus.IsFollowing,
 	})
 }
 
+// setCanonicalSlug tells the caller which slug to link to when a tag was
+// found through the alias of a tag merged into it
+func setCanonicalSlug(w http.ResponseWriter, requested, canonical string) {
+	if requested != canonical {
+		w.Header().Set("X-Canonical-Slug", canonical)
+	}
+}
+
+// POST /api/tags/:slug/follow
+func (h *TagHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	h.setFollowing(w, r, true)
+}
+
+// DELETE /api/tags/:slug/follow
+func (h *TagHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	h.setFollowing(w, r, false)
+}
+
+func (h *TagHandler) setFollowing(w http.ResponseWriter, r *http.Request, follow bool) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		WriteBadRequest(w, "slug is required")
+		return
+	}
+
+	tag, err := h.tagService.GetBySlug(r.Context(), slug)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch tag")
+		return
+	}
+
+	if tag == nil {
+		WriteNotFound(w, "tag not found")
+		return
+	}
+	setCanonicalSlug(w, slug, tag.Slug)
+
+	var status *models.FollowStatus
+	if follow {
+		status, err = h.tagService.Follow(r.Context(), tag.ID, userID)
+	} else {
+		status, err = h.tagService.Unfollow(r.Context(), tag.ID, userID)
+	}
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, status)
+}
+
 // GET /api/admin/tags/:id
 func (h *TagHandler) AdminGetByID(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -122,6 +213,99 @@ func (h *TagHandler) AdminGetByID(w http.ResponseWriter, r *http.Request) {
 	WriteSuccess(w, tag)
 }
 
+// POST /api/admin/tags/:id/assign?preview=true - Add the tag to the articles
+// picked by IDs or a filter
+func (h *TagHandler) AssignArticles(w http.ResponseWriter, r *http.Request) {
+	h.bulkTag(w, r, true)
+}
+
+// POST /api/admin/tags/:id/unassign?preview=true - Remove the tag from the
+// articles picked by IDs or a filter
+func (h *TagHandler) UnassignArticles(w http.ResponseWriter, r *http.Request) {
+	h.bulkTag(w, r, false)
+}
+
+func (h *TagHandler) bulkTag(w http.ResponseWriter, r *http.Request, assign bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid tag ID")
+		return
+	}
+
+	var req models.BulkTagRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	tag, err := h.tagService.GetByID(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch tag")
+		return
+	}
+	if tag == nil {
+		WriteNotFound(w, "tag not found")
+		return
+	}
+
+	if r.URL.Query().Get("preview") == "true" {
+		preview, err := h.articleService.PreviewBulkTag(r.Context(), id, assign, &req)
+		if err != nil {
+			WriteInternalError(w, "failed to preview tag change")
+			return
+		}
+		WriteSuccess(w, preview)
+		return
+	}
+
+	result, err := h.articleService.BulkTag(r.Context(), id, assign, &req)
+	if err != nil {
+		WriteInternalError(w, "failed to update article tags")
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
+// POST /api/admin/tags/:id/merge - Merge the tag into another
+func (h *TagHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid tag ID")
+		return
+	}
+
+	var req models.MergeTagRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+	intoID, err := uuid.Parse(req.IntoTagID)
+	if err != nil {
+		WriteBadRequest(w, "invalid into_tag_id")
+		return
+	}
+
+	result, err := h.tagService.Merge(r.Context(), id, intoID)
+	if err != nil {
+		switch err.Error() {
+		case "tag not found", "target tag not found":
+			WriteNotFound(w, err.Error())
+		case "cannot merge a tag into itself":
+			WriteBadRequest(w, err.Error())
+		default:
+			WriteInternalError(w, "failed to merge tag")
+		}
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
 // POST /api/admin/tags
 func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateTagRequest
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/services"
 	"github.com/humfurie/pulpulitiko/api/pkg/storage"
 )
@@ -40,3 +42,52 @@ func (h *UploadHandler) Upload(w http.ResponseWriter, r *http.Request) {
 
 	WriteSuccess(w, result)
 }
+
+// POST /api/admin/upload/article-image - Convert a featured image into WebP
+// thumbnail and full-size renditions
+func (h *UploadHandler) UploadArticleImage(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, storage.GetMaxFileSize()+1024)
+
+	if err := r.ParseMultipartForm(storage.GetMaxFileSize()); err != nil {
+		WriteBadRequest(w, "file too large or invalid form data")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		WriteBadRequest(w, "file is required")
+		return
+	}
+	defer file.Close()
+
+	images, err := h.uploadService.UploadArticleImage(r.Context(), file, header)
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, images)
+}
+
+// DELETE /api/admin/upload?url= - Delete an uploaded file. Articles' featured
+// images can't be deleted.
+func (h *UploadHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	fileURL := r.URL.Query().Get("url")
+	if fileURL == "" {
+		WriteBadRequest(w, "url is required")
+		return
+	}
+
+	err := h.uploadService.DeleteUpload(r.Context(), fileURL)
+	var inUse *models.MediaInUseError
+	switch {
+	case err == nil:
+		WriteSuccess(w, map[string]string{"message": "file deleted"})
+	case errors.As(err, &inUse):
+		WriteError(w, http.StatusConflict, "MEDIA_IN_USE", err.Error())
+	case err.Error() == "invalid file URL":
+		WriteBadRequest(w, err.Error())
+	default:
+		WriteInternalError(w, "failed to delete file")
+	}
+}
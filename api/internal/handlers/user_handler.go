@@ -5,17 +5,22 @@ import (
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/middleware"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/repository"
+	"github.com/humfurie/pulpulitiko/api/internal/services"
 )
 
 type UserHandler struct {
-	userRepo *repository.UserRepository
+	userRepo     *repository.UserRepository
+	blockService *services.UserBlockService
 }
 
-func NewUserHandler(userRepo *repository.UserRepository) *UserHandler {
+func NewUserHandler(userRepo *repository.UserRepository, blockService *services.UserBlockService) *UserHandler {
 	return &UserHandler{
-		userRepo: userRepo,
+		userRepo:     userRepo,
+		blockService: blockService,
 	}
 }
 
@@ -170,7 +175,7 @@ func (h *UserHandler) AdminList(w http.ResponseWriter, r *http.Request) {
 	sortBy := r.URL.Query().Get("sort_by")
 	sortOrder := r.URL.Query().Get("sort_order")
 
-	filter := &models.UserFilter{}
+	filter := &models.UserFilter{IncludeDeleted: GetIncludeDeleted(r)}
 	if search != "" {
 		filter.Search = &search
 	}
@@ -190,5 +195,110 @@ func (h *UserHandler) AdminList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, paginatedUsers)
+	WritePaginated(w, r, paginatedUsers)
+}
+
+// SetMembership PUT /api/admin/users/{id}/membership - Grant or revoke membership (admin)
+func (h *UserHandler) SetMembership(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid user ID")
+		return
+	}
+
+	var req models.SetMembershipRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	if err := h.userRepo.SetMembership(r.Context(), id, *req.IsMember); err != nil {
+		if err.Error() == "user not found" {
+			WriteNotFound(w, err.Error())
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]bool{"is_member": *req.IsMember})
+}
+
+// BlockUser POST /api/users/{slug}/block - Block a user
+func (h *UserHandler) BlockUser(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	if err := h.blockService.BlockUser(r.Context(), userID, chi.URLParam(r, "slug")); err != nil {
+		switch err.Error() {
+		case "user not found":
+			WriteNotFound(w, err.Error())
+		case "you cannot block yourself":
+			WriteBadRequest(w, err.Error())
+		default:
+			WriteInternalError(w, err.Error())
+		}
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "user blocked"})
+}
+
+// UnblockUser DELETE /api/users/{slug}/block - Unblock a user
+func (h *UserHandler) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	if err := h.blockService.UnblockUser(r.Context(), userID, chi.URLParam(r, "slug")); err != nil {
+		switch err.Error() {
+		case "user not found", "block not found":
+			WriteNotFound(w, err.Error())
+		default:
+			WriteInternalError(w, err.Error())
+		}
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "user unblocked"})
+}
+
+// GetMyBlocks GET /api/me/blocks - List users the current user has blocked
+func (h *UserHandler) GetMyBlocks(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserClaims(r.Context())
+	if claims == nil {
+		WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		WriteUnauthorized(w, "invalid user ID")
+		return
+	}
+
+	blocked, err := h.blockService.ListBlocked(r.Context(), userID)
+	if err != nil {
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, blocked)
 }
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+)
+
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// GET /api/admin/webhooks - List webhooks
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhookService.List(r.Context())
+	if err != nil {
+		WriteInternalError(w, "failed to fetch webhooks")
+		return
+	}
+
+	WriteSuccess(w, webhooks)
+}
+
+// GET /api/admin/webhooks/:id - Get a webhook
+func (h *WebhookHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid webhook ID")
+		return
+	}
+
+	webhook, err := h.webhookService.GetByID(r.Context(), id)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch webhook")
+		return
+	}
+	if webhook == nil {
+		WriteNotFound(w, "webhook not found")
+		return
+	}
+
+	WriteSuccess(w, webhook)
+}
+
+// POST /api/admin/webhooks - Create a webhook. The response holds the
+// signing secret, which is not shown again.
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateWebhookRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	webhook, err := h.webhookService.Create(r.Context(), &req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "unknown event type") {
+			WriteBadRequest(w, err.Error())
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteCreated(w, webhook)
+}
+
+// PUT /api/admin/webhooks/:id - Update a webhook
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid webhook ID")
+		return
+	}
+
+	var req models.UpdateWebhookRequest
+	if err := DecodeAndValidate(r, &req); err != nil {
+		WriteValidationError(w, err)
+		return
+	}
+
+	webhook, err := h.webhookService.Update(r.Context(), id, &req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "unknown event type") {
+			WriteBadRequest(w, err.Error())
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+	if webhook == nil {
+		WriteNotFound(w, "webhook not found")
+		return
+	}
+
+	WriteSuccess(w, webhook)
+}
+
+// DELETE /api/admin/webhooks/:id - Delete a webhook and its deliveries
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid webhook ID")
+		return
+	}
+
+	if err := h.webhookService.Delete(r.Context(), id); err != nil {
+		if err.Error() == "webhook not found" {
+			WriteNotFound(w, err.Error())
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "webhook deleted"})
+}
+
+// GET /api/admin/webhooks/:id/deliveries?status=dead - List recent deliveries
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid webhook ID")
+		return
+	}
+
+	var status *string
+	if v := r.URL.Query().Get("status"); v != "" {
+		if v != models.WebhookDeliveryPending && v != models.WebhookDeliveryDelivered && v != models.WebhookDeliveryDead {
+			WriteBadRequest(w, "status must be one of pending, delivered, dead")
+			return
+		}
+		status = &v
+	}
+
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil && l > 0 && l <= 200 {
+			limit = l
+		}
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), id, status, limit)
+	if err != nil {
+		WriteInternalError(w, "failed to fetch deliveries")
+		return
+	}
+
+	WriteSuccess(w, deliveries)
+}
+
+// POST /api/admin/webhooks/:id/deliveries/:deliveryId/retry - Requeue a dead-lettered delivery
+func (h *WebhookHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteBadRequest(w, "invalid webhook ID")
+		return
+	}
+
+	deliveryID, err := uuid.Parse(chi.URLParam(r, "deliveryId"))
+	if err != nil {
+		WriteBadRequest(w, "invalid delivery ID")
+		return
+	}
+
+	if err := h.webhookService.RetryDelivery(r.Context(), id, deliveryID); err != nil {
+		if err.Error() == "delivery not found" {
+			WriteNotFound(w, "dead-lettered delivery not found")
+			return
+		}
+		WriteInternalError(w, err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{"message": "delivery requeued"})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,25 +16,64 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// Allow all origins in development
-		// TODO: Restrict in production
-		return true
-	},
+// newUpgrader accepts WebSocket connections from the allowed origins. "*"
+// allows any origin. Requests without an Origin header don't come from a
+// browser page and are always accepted.
+func newUpgrader(allowedOrigins []string) *websocket.Upgrader {
+	return &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+			for _, allowed := range allowedOrigins {
+				if allowed == "*" || strings.EqualFold(allowed, origin) {
+					return true
+				}
+			}
+			return false
+		},
+	}
 }
 
+const (
+	// commentEventInterval is how often queued comment events are flushed to
+	// article subscribers; events within the same window are coalesced
+	commentEventInterval = time.Second
+
+	// maxArticleSubscriptions caps how many article channels a client can join
+	maxArticleSubscriptions = 20
+
+	// Clients are pinged every pingInterval, and dropped when nothing, not
+	// even a pong, has been read from them for pongTimeout
+	pingInterval = 30 * time.Second
+	pongTimeout  = 60 * time.Second
+)
+
 // Client represents a connected WebSocket client
 type Client struct {
 	ID             string
-	UserID         uuid.UUID
+	UserID         uuid.UUID // uuid.Nil for anonymous readers
 	IsAdmin        bool
 	Conn           *websocket.Conn
 	Send           chan []byte
 	Hub            *Hub
 	ConversationID *uuid.UUID // Currently viewing conversation
+
+	// Heartbeat: a ping is sent every PingInterval, and the connection is
+	// closed and unregistered if no pong arrives within PongTimeout
+	PingInterval time.Duration
+	PongTimeout  time.Duration
+
+	// Article slugs this client receives comment events for (guarded by Hub.mu)
+	articles map[string]bool
+}
+
+// IsAnonymous reports whether the client connected without a valid token
+func (c *Client) IsAnonymous() bool {
+	return c.UserID == uuid.Nil
 }
 
 // Hub maintains active clients and broadcasts messages
@@ -52,6 +93,25 @@ type Hub struct {
 	// Broadcast to specific user
 	broadcast chan *BroadcastMessage
 
+	// All connected clients, including anonymous readers
+	conns map[*Client]bool
+
+	// Article comment channels: slug -> subscribed clients
+	articles map[string]map[*Client]bool
+
+	// Comment events waiting for the next flush, by article slug
+	pendingEvents   map[string][]models.CommentEvent
+	pendingMu       sync.Mutex
+	commentInterval time.Duration
+
+	// Heartbeat timings given to new clients
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+
+	// Totals since start, for the metrics endpoint
+	connectionsTotal atomic.Uint64
+	messagesSent     atomic.Uint64
+
 	// Mutex for thread-safe operations
 	mu sync.RWMutex
 }
@@ -71,18 +131,51 @@ func NewHub() *Hub {
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		broadcast:  make(chan *BroadcastMessage),
+
+		conns:           make(map[*Client]bool),
+		articles:        make(map[string]map[*Client]bool),
+		pendingEvents:   make(map[string][]models.CommentEvent),
+		commentInterval: commentEventInterval,
+		pingInterval:    pingInterval,
+		pongTimeout:     pongTimeout,
 	}
 }
 
+// ConnectionCount returns how many clients are connected, including
+// anonymous readers
+func (h *Hub) ConnectionCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.conns)
+}
+
+// ConnectionsTotal returns how many clients have connected since start
+func (h *Hub) ConnectionsTotal() uint64 {
+	return h.connectionsTotal.Load()
+}
+
+// MessagesSent returns how many messages have been written to clients
+// since start
+func (h *Hub) MessagesSent() uint64 {
+	return h.messagesSent.Load()
+}
+
 // Run starts the hub's main loop
 func (h *Hub) Run() {
+	commentTicker := time.NewTicker(h.commentInterval)
+	defer commentTicker.Stop()
+
 	for {
 		select {
 		case client := <-h.register:
 			h.mu.Lock()
-			h.clients[client.UserID] = client
-			if client.IsAdmin {
-				h.admins[client.UserID] = client
+			h.conns[client] = true
+			h.connectionsTotal.Add(1)
+			if !client.IsAnonymous() {
+				h.clients[client.UserID] = client
+				if client.IsAdmin {
+					h.admins[client.UserID] = client
+				}
 			}
 			h.mu.Unlock()
 
@@ -93,9 +186,15 @@ func (h *Hub) Run() {
 
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if _, ok := h.clients[client.UserID]; ok {
-				delete(h.clients, client.UserID)
-				delete(h.admins, client.UserID)
+			if h.conns[client] {
+				delete(h.conns, client)
+				if h.clients[client.UserID] == client {
+					delete(h.clients, client.UserID)
+					delete(h.admins, client.UserID)
+				}
+				for slug := range client.articles {
+					h.removeSubscriber(slug, client)
+				}
 				close(client.Send)
 			}
 			h.mu.Unlock()
@@ -128,10 +227,126 @@ func (h *Hub) Run() {
 				}
 			}
 			h.mu.RUnlock()
+
+		case <-commentTicker.C:
+			h.flushCommentEvents()
 		}
 	}
 }
 
+// SubscribeArticle adds the client to an article's comment channel
+func (h *Hub) SubscribeArticle(client *Client, slug string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.conns[client] {
+		return false
+	}
+	if client.articles == nil {
+		client.articles = make(map[string]bool)
+	}
+	if !client.articles[slug] && len(client.articles) >= maxArticleSubscriptions {
+		return false
+	}
+
+	client.articles[slug] = true
+	if h.articles[slug] == nil {
+		h.articles[slug] = make(map[*Client]bool)
+	}
+	h.articles[slug][client] = true
+	return true
+}
+
+// UnsubscribeArticle removes the client from an article's comment channel
+func (h *Hub) UnsubscribeArticle(client *Client, slug string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(client.articles, slug)
+	h.removeSubscriber(slug, client)
+}
+
+// removeSubscriber must be called with h.mu held
+func (h *Hub) removeSubscriber(slug string, client *Client) {
+	subscribers, ok := h.articles[slug]
+	if !ok {
+		return
+	}
+	delete(subscribers, client)
+	if len(subscribers) == 0 {
+		delete(h.articles, slug)
+	}
+}
+
+// ArticleSubscriberCount returns how many clients are watching an article
+func (h *Hub) ArticleSubscriberCount(slug string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.articles[slug])
+}
+
+// PublishCommentEvent queues a comment event for an article's subscribers.
+// Events are delivered in batches on the next flush rather than immediately.
+func (h *Hub) PublishCommentEvent(articleSlug string, event models.CommentEvent) {
+	if h.ArticleSubscriberCount(articleSlug) == 0 {
+		return
+	}
+
+	h.pendingMu.Lock()
+	h.pendingEvents[articleSlug] = append(h.pendingEvents[articleSlug], event)
+	h.pendingMu.Unlock()
+}
+
+// flushCommentEvents sends each article's queued events to its subscribers as
+// a single message, keeping only the latest event per comment
+func (h *Hub) flushCommentEvents() {
+	h.pendingMu.Lock()
+	pending := h.pendingEvents
+	h.pendingEvents = make(map[string][]models.CommentEvent)
+	h.pendingMu.Unlock()
+
+	for slug, events := range pending {
+		articleSlug := slug
+		data, err := json.Marshal(&models.WSMessage{
+			Type:        models.WSMessageTypeCommentEvents,
+			ArticleSlug: &articleSlug,
+			Events:      coalesceCommentEvents(events),
+			Timestamp:   time.Now(),
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to marshal WebSocket message")
+			continue
+		}
+
+		h.mu.RLock()
+		for client := range h.articles[slug] {
+			select {
+			case client.Send <- data:
+			default:
+				// Client's buffer is full, skip
+			}
+		}
+		h.mu.RUnlock()
+	}
+}
+
+// coalesceCommentEvents collapses multiple events for the same comment into
+// the most recent one, preserving the order in which comments last changed
+func coalesceCommentEvents(events []models.CommentEvent) []models.CommentEvent {
+	latest := make(map[uuid.UUID]int, len(events))
+	for i, event := range events {
+		latest[event.CommentID] = i
+	}
+
+	coalesced := make([]models.CommentEvent, 0, len(latest))
+	for i, event := range events {
+		if latest[event.CommentID] == i {
+			coalesced = append(coalesced, event)
+		}
+	}
+	return coalesced
+}
+
 // BroadcastToUser sends a message to a specific user
 func (h *Hub) BroadcastToUser(userID uuid.UUID, msg *models.WSMessage) {
 	data, err := json.Marshal(msg)
@@ -178,6 +393,16 @@ func (h *Hub) BroadcastNewMessage(message *models.Message, conversationUserID uu
 	}
 }
 
+// BroadcastDirectMessage sends a new message in a direct conversation to one participant
+func (h *Hub) BroadcastDirectMessage(message *models.Message, recipientID uuid.UUID) {
+	h.BroadcastToUser(recipientID, &models.WSMessage{
+		Type:           models.WSMessageTypeNewMessage,
+		ConversationID: &message.ConversationID,
+		Message:        message,
+		Timestamp:      time.Now(),
+	})
+}
+
 // IsUserOnline checks if a user is currently connected
 func (h *Hub) IsUserOnline(userID uuid.UUID) bool {
 	h.mu.RLock()
@@ -191,6 +416,7 @@ type WebSocketHandler struct {
 	hub            *Hub
 	authService    *services.AuthService
 	messageService *services.MessageService
+	upgrader       *websocket.Upgrader
 }
 
 // NewWebSocketHandler creates a new WebSocketHandler
@@ -199,40 +425,45 @@ func NewWebSocketHandler(hub *Hub, authService *services.AuthService, messageSer
 		hub:            hub,
 		authService:    authService,
 		messageService: messageService,
+		upgrader:       newUpgrader([]string{"*"}),
 	}
 }
 
-// HandleWebSocket handles WebSocket upgrade and connection
-func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
-	// Get token from query parameter (WebSocket doesn't support custom headers easily)
-	token := r.URL.Query().Get("token")
-	if token == "" {
-		http.Error(w, "Missing token", http.StatusUnauthorized)
-		return
-	}
+// SetAllowedOrigins limits which browser origins may connect. Every origin is
+// allowed until it is called.
+func (h *WebSocketHandler) SetAllowedOrigins(origins []string) {
+	h.upgrader = newUpgrader(origins)
+}
 
-	// Validate token
-	claims, err := h.authService.ValidateToken(token)
-	if err != nil {
-		http.Error(w, "Invalid token", http.StatusUnauthorized)
-		return
-	}
+// HandleWebSocket handles WebSocket upgrade and connection.
+// Connections without a valid token are accepted as anonymous, read-only
+// clients that can only subscribe to article comment channels. Impersonation
+// tokens are refused: what is sent over a socket can't be audited per request.
+func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	var userID uuid.UUID
+	var isAdmin bool
 
-	userID, err := uuid.Parse(claims.UserID)
-	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusUnauthorized)
-		return
+	// Get token from query parameter (WebSocket doesn't support custom headers easily)
+	if token := r.URL.Query().Get("token"); token != "" && h.authService != nil {
+		if claims, err := h.authService.ValidateToken(token); err == nil {
+			if claims.ImpersonatedBy != "" {
+				WriteForbidden(w, "Real-time updates are not available while impersonating")
+				return
+			}
+			if id, err := uuid.Parse(claims.UserID); err == nil {
+				userID = id
+				isAdmin = claims.Role == "admin"
+			}
+		}
 	}
 
 	// Upgrade to WebSocket
-	conn, err := upgrader.Upgrade(w, r, nil)
+	conn, err := h.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to upgrade to WebSocket")
 		return
 	}
 
-	isAdmin := claims.Role == "admin"
-
 	client := &Client{
 		ID:      uuid.New().String(),
 		UserID:  userID,
@@ -240,6 +471,9 @@ func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 		Conn:    conn,
 		Send:    make(chan []byte, 256),
 		Hub:     h.hub,
+
+		PingInterval: h.hub.pingInterval,
+		PongTimeout:  h.hub.pongTimeout,
 	}
 
 	h.hub.register <- client
@@ -257,9 +491,11 @@ func (c *Client) readPump(h *WebSocketHandler) {
 	}()
 
 	c.Conn.SetReadLimit(512 * 1024) // 512KB max message size
-	_ = c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+	// A read past the deadline fails, ending the loop and unregistering the
+	// client; each pong pushes the deadline back
+	_ = c.Conn.SetReadDeadline(time.Now().Add(c.PongTimeout))
 	c.Conn.SetPongHandler(func(string) error {
-		_ = c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+		_ = c.Conn.SetReadDeadline(time.Now().Add(c.PongTimeout))
 		return nil
 	})
 
@@ -279,6 +515,25 @@ func (c *Client) readPump(h *WebSocketHandler) {
 			continue
 		}
 
+		// Article channels are open to everyone
+		switch wsMsg.Type {
+		case models.WSMessageTypeSubscribeArticle:
+			if wsMsg.ArticleSlug != nil && *wsMsg.ArticleSlug != "" {
+				c.Hub.SubscribeArticle(c, *wsMsg.ArticleSlug)
+			}
+			continue
+		case models.WSMessageTypeUnsubscribeArticle:
+			if wsMsg.ArticleSlug != nil {
+				c.Hub.UnsubscribeArticle(c, *wsMsg.ArticleSlug)
+			}
+			continue
+		}
+
+		// Anonymous clients are read-only
+		if c.IsAnonymous() {
+			continue
+		}
+
 		// Handle different message types
 		switch wsMsg.Type {
 		case models.WSMessageTypeTyping, models.WSMessageTypeStopTyping:
@@ -297,7 +552,7 @@ func (c *Client) readPump(h *WebSocketHandler) {
 
 // writePump writes messages to the WebSocket connection
 func (c *Client) writePump() {
-	ticker := time.NewTicker(54 * time.Second)
+	ticker := time.NewTicker(c.PingInterval)
 	defer func() {
 		ticker.Stop()
 		c.Conn.Close()
@@ -322,6 +577,7 @@ func (c *Client) writePump() {
 			if err := w.Close(); err != nil {
 				return
 			}
+			c.Hub.messagesSent.Add(1)
 		case <-ticker.C:
 			_ = c.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
 			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
@@ -348,7 +604,12 @@ func (h *WebSocketHandler) handleTypingIndicator(c *Client, wsMsg *models.WSMess
 		Timestamp:      time.Now(),
 	}
 
-	if c.IsAdmin {
+	if conversation.RecipientID != nil {
+		// Direct conversation, notify the other participant
+		if c.UserID == conversation.UserID || c.UserID == *conversation.RecipientID {
+			h.hub.BroadcastToUser(*conversation.OtherParticipant(c.UserID), broadcastMsg)
+		}
+	} else if c.IsAdmin {
 		// Admin typing, notify user
 		h.hub.BroadcastToUser(conversation.UserID, broadcastMsg)
 	} else {
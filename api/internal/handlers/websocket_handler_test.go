@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHub(t *testing.T) (*Hub, string) {
+	t.Helper()
+
+	hub := NewHub()
+	hub.commentInterval = 20 * time.Millisecond
+	go hub.Run()
+
+	server := httptest.NewServer(http.HandlerFunc(NewWebSocketHandler(hub, nil, nil).HandleWebSocket))
+	t.Cleanup(server.Close)
+
+	return hub, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func dialAndSubscribe(t *testing.T, url, slug string) *websocket.Conn {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.WriteJSON(models.WSMessage{
+		Type:        models.WSMessageTypeSubscribeArticle,
+		ArticleSlug: &slug,
+	}))
+	return conn
+}
+
+func readCommentEvents(t *testing.T, conn *websocket.Conn) models.WSMessage {
+	t.Helper()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var msg models.WSMessage
+	_, data, err := conn.ReadMessage()
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &msg))
+	return msg
+}
+
+func waitForSubscribers(t *testing.T, hub *Hub, slug string, want int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return hub.ArticleSubscriberCount(slug) == want
+	}, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestArticleCommentChannelDelivery(t *testing.T) {
+	hub, url := newTestHub(t)
+	slug := "budget-hearing"
+
+	first := dialAndSubscribe(t, url, slug)
+	defer first.Close()
+	second := dialAndSubscribe(t, url, slug)
+	defer second.Close()
+	waitForSubscribers(t, hub, slug, 2)
+
+	commentID := uuid.New()
+	hub.PublishCommentEvent(slug, models.CommentEvent{
+		Type:      models.CommentEventCreated,
+		CommentID: commentID,
+		Comment:   &models.Comment{ID: commentID, Content: "First!"},
+	})
+
+	for _, conn := range []*websocket.Conn{first, second} {
+		msg := readCommentEvents(t, conn)
+		assert.Equal(t, models.WSMessageTypeCommentEvents, msg.Type)
+		require.NotNil(t, msg.ArticleSlug)
+		assert.Equal(t, slug, *msg.ArticleSlug)
+		require.Len(t, msg.Events, 1)
+		assert.Equal(t, models.CommentEventCreated, msg.Events[0].Type)
+		assert.Equal(t, "First!", msg.Events[0].Comment.Content)
+	}
+	assert.Eventually(t, func() bool { return hub.MessagesSent() == 2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestArticleCommentChannelUnsubscribesOnDisconnect(t *testing.T) {
+	hub, url := newTestHub(t)
+	slug := "senate-vote"
+
+	leaving := dialAndSubscribe(t, url, slug)
+	staying := dialAndSubscribe(t, url, slug)
+	defer staying.Close()
+	waitForSubscribers(t, hub, slug, 2)
+
+	require.NoError(t, leaving.Close())
+	waitForSubscribers(t, hub, slug, 1)
+
+	commentID := uuid.New()
+	hub.PublishCommentEvent(slug, models.CommentEvent{Type: models.CommentEventRemoved, CommentID: commentID})
+
+	msg := readCommentEvents(t, staying)
+	require.Len(t, msg.Events, 1)
+	assert.Equal(t, models.CommentEventRemoved, msg.Events[0].Type)
+	assert.Equal(t, commentID, msg.Events[0].CommentID)
+
+	require.NoError(t, staying.Close())
+	waitForSubscribers(t, hub, slug, 0)
+}
+
+func TestArticleCommentChannelIgnoresOtherArticles(t *testing.T) {
+	hub, url := newTestHub(t)
+
+	conn := dialAndSubscribe(t, url, "watched")
+	defer conn.Close()
+	waitForSubscribers(t, hub, "watched", 1)
+
+	hub.PublishCommentEvent("unwatched", models.CommentEvent{Type: models.CommentEventRemoved, CommentID: uuid.New()})
+	watchedID := uuid.New()
+	hub.PublishCommentEvent("watched", models.CommentEvent{Type: models.CommentEventRemoved, CommentID: watchedID})
+
+	msg := readCommentEvents(t, conn)
+	require.Len(t, msg.Events, 1)
+	assert.Equal(t, watchedID, msg.Events[0].CommentID)
+}
+
+func TestCoalesceCommentEvents(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	events := []models.CommentEvent{
+		{Type: models.CommentEventCreated, CommentID: a},
+		{Type: models.CommentEventCreated, CommentID: b},
+		{Type: models.CommentEventUpdated, CommentID: a},
+		{Type: models.CommentEventRemoved, CommentID: a},
+	}
+
+	coalesced := coalesceCommentEvents(events)
+	require.Len(t, coalesced, 2)
+	assert.Equal(t, b, coalesced[0].CommentID)
+	assert.Equal(t, models.CommentEventCreated, coalesced[0].Type)
+	assert.Equal(t, a, coalesced[1].CommentID)
+	assert.Equal(t, models.CommentEventRemoved, coalesced[1].Type)
+}
+
+func TestHeartbeatDropsUnresponsiveClients(t *testing.T) {
+	hub := NewHub()
+	hub.pingInterval = 20 * time.Millisecond
+	hub.pongTimeout = 100 * time.Millisecond
+	go hub.Run()
+
+	server := httptest.NewServer(http.HandlerFunc(NewWebSocketHandler(hub, nil, nil).HandleWebSocket))
+	t.Cleanup(server.Close)
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	// The client only answers pings while it is reading
+	live, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer live.Close()
+	go func() {
+		for {
+			if _, _, err := live.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	silent, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer silent.Close()
+
+	require.Eventually(t, func() bool { return hub.ConnectionCount() == 2 }, 2*time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool { return hub.ConnectionCount() == 1 }, 2*time.Second, 10*time.Millisecond)
+
+	// The responsive client outlives several timeouts
+	time.Sleep(3 * hub.pongTimeout)
+	assert.Equal(t, 1, hub.ConnectionCount())
+	assert.Equal(t, uint64(2), hub.ConnectionsTotal())
+}
+
+func TestWebSocketAllowedOrigins(t *testing.T) {
+	check := newUpgrader([]string{"https://pulpulitiko.com"}).CheckOrigin
+	request := func(origin string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		return r
+	}
+
+	assert.True(t, check(request("https://pulpulitiko.com")))
+	assert.True(t, check(request("https://PULPULITIKO.com")))
+	assert.True(t, check(request("")), "non-browser clients send no origin")
+	assert.False(t, check(request("https://evil.example")))
+
+	assert.True(t, newUpgrader([]string{"*"}).CheckOrigin(request("https://evil.example")))
+	assert.False(t, newUpgrader(nil).CheckOrigin(request("https://pulpulitiko.com")))
+}
+
+func TestWebSocketRejectsImpersonationTokens(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+	auth := services.NewAuthService(nil, nil, nil, nil, "test-secret")
+	server := httptest.NewServer(http.HandlerFunc(NewWebSocketHandler(hub, auth, nil).HandleWebSocket))
+	t.Cleanup(server.Close)
+
+	sign := func(impersonatedBy string) string {
+		claims := &services.JWTClaims{
+			UserID:         uuid.NewString(),
+			Role:           "user",
+			ImpersonatedBy: impersonatedBy,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+		require.NoError(t, err)
+		return token
+	}
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?token="
+
+	_, resp, err := websocket.DefaultDialer.Dial(url+sign(uuid.NewString()), nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url+sign(""), nil)
+	require.NoError(t, err)
+	_ = conn.Close()
+}
@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/repository"
+)
+
+// ActivityRollupJob recounts hourly comment and poll vote activity for the
+// metrics heatmap. Each run recounts from the hour of the previous run, so
+// late rows in that hour are picked up; the first run after startup recounts
+// the whole heatmap window.
+type ActivityRollupJob struct {
+	metricsRepo *repository.MetricsRepository
+	interval    time.Duration
+	lastRun     time.Time
+}
+
+func NewActivityRollupJob(metricsRepo *repository.MetricsRepository, interval time.Duration) *ActivityRollupJob {
+	return &ActivityRollupJob{
+		metricsRepo: metricsRepo,
+		interval:    interval,
+	}
+}
+
+func (j *ActivityRollupJob) Name() string {
+	return "activity_rollup"
+}
+
+func (j *ActivityRollupJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *ActivityRollupJob) Run(ctx context.Context) error {
+	now := time.Now()
+	since := j.lastRun
+	if since.IsZero() {
+		since = now.AddDate(0, 0, -models.MaxActivityHeatmapDays)
+	}
+
+	if err := j.metricsRepo.RollupActivity(ctx, since); err != nil {
+		return err
+	}
+	j.lastRun = now
+	return nil
+}
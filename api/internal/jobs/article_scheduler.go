@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// ArticleSchedulerJob publishes scheduled articles once their publish time
+// arrives, staggering any over the hourly cap when it is enforced
+type ArticleSchedulerJob struct {
+	articleService *services.ArticleService
+	interval       time.Duration
+	logger         zerolog.Logger
+}
+
+func NewArticleSchedulerJob(articleService *services.ArticleService, interval time.Duration, logger zerolog.Logger) *ArticleSchedulerJob {
+	return &ArticleSchedulerJob{
+		articleService: articleService,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+func (j *ArticleSchedulerJob) Name() string {
+	return "article_scheduler"
+}
+
+func (j *ArticleSchedulerJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *ArticleSchedulerJob) Run(ctx context.Context) error {
+	published, rescheduled, err := j.articleService.PublishScheduled(ctx)
+	if published > 0 || rescheduled > 0 {
+		j.logger.Info().Int("published", published).Int("rescheduled", rescheduled).Msg("Processed article schedule")
+	}
+	return err
+}
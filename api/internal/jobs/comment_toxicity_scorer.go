@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// CommentToxicityJob retries toxicity scoring for comments whose scoring
+// failed, or was cut short by a restart, backing off after each failure
+type CommentToxicityJob struct {
+	commentService *services.CommentService
+	interval       time.Duration
+	logger         zerolog.Logger
+}
+
+func NewCommentToxicityJob(commentService *services.CommentService, interval time.Duration, logger zerolog.Logger) *CommentToxicityJob {
+	return &CommentToxicityJob{
+		commentService: commentService,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+func (j *CommentToxicityJob) Name() string {
+	return "comment_toxicity"
+}
+
+func (j *CommentToxicityJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *CommentToxicityJob) Run(ctx context.Context) error {
+	scored, failed, err := j.commentService.ScoreUnscoredToxicity(ctx)
+	if scored > 0 || failed > 0 {
+		j.logger.Info().Int("scored", scored).Int("failed", failed).Msg("Retried comment toxicity scoring")
+	}
+	return err
+}
@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// ElectionResultRollupJob precomputes the city, province and region totals of
+// elections with newly imported location results
+type ElectionResultRollupJob struct {
+	electionService *services.ElectionService
+	interval        time.Duration
+	logger          zerolog.Logger
+}
+
+func NewElectionResultRollupJob(electionService *services.ElectionService, interval time.Duration, logger zerolog.Logger) *ElectionResultRollupJob {
+	return &ElectionResultRollupJob{
+		electionService: electionService,
+		interval:        interval,
+		logger:          logger,
+	}
+}
+
+func (j *ElectionResultRollupJob) Name() string {
+	return "election_result_rollup"
+}
+
+func (j *ElectionResultRollupJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *ElectionResultRollupJob) Run(ctx context.Context) error {
+	done, err := j.electionService.RecomputeStaleRollups(ctx)
+	if done > 0 {
+		j.logger.Info().Int("elections", done).Msg("Rolled up election location results")
+	}
+	return err
+}
@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// PollCloserJob closes polls once their end time passes and notifies each
+// poll's creator. A poll that fails to close is logged and retried on the
+// next run without holding up the rest. A poll another run or instance closed
+// first is skipped.
+type PollCloserJob struct {
+	pollService *services.PollService
+	interval    time.Duration
+	logger      zerolog.Logger
+}
+
+func NewPollCloserJob(pollService *services.PollService, interval time.Duration, logger zerolog.Logger) *PollCloserJob {
+	return &PollCloserJob{
+		pollService: pollService,
+		interval:    interval,
+		logger:      logger,
+	}
+}
+
+func (j *PollCloserJob) Name() string {
+	return "poll_closer"
+}
+
+func (j *PollCloserJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *PollCloserJob) Run(ctx context.Context) error {
+	polls, err := j.pollService.ListExpiredPolls(ctx)
+	if err != nil {
+		return err
+	}
+
+	closed := 0
+	for i := range polls {
+		poll := &polls[i]
+		ok, err := j.pollService.CloseExpiredPoll(ctx, poll)
+		if ok {
+			closed++
+		}
+		if err != nil {
+			j.logger.Error().Err(err).Str("poll_id", poll.ID.String()).Msg("Failed to close expired poll")
+		}
+	}
+
+	if closed > 0 {
+		j.logger.Info().Int("closed", closed).Msg("Closed expired polls")
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// PollSchedulerJob activates scheduled polls once their start time arrives.
+// PollCloserJob closes them.
+type PollSchedulerJob struct {
+	pollService *services.PollService
+	interval    time.Duration
+	logger      zerolog.Logger
+}
+
+func NewPollSchedulerJob(pollService *services.PollService, interval time.Duration, logger zerolog.Logger) *PollSchedulerJob {
+	return &PollSchedulerJob{
+		pollService: pollService,
+		interval:    interval,
+		logger:      logger,
+	}
+}
+
+func (j *PollSchedulerJob) Name() string {
+	return "poll_scheduler"
+}
+
+func (j *PollSchedulerJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *PollSchedulerJob) Run(ctx context.Context) error {
+	activated, err := j.pollService.ActivateScheduledPolls(ctx)
+	if activated > 0 {
+		j.logger.Info().Int("activated", activated).Msg("Activated scheduled polls")
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Job is a unit of background work executed on a fixed interval
+type Job interface {
+	Name() string
+	Interval() time.Duration
+	Run(ctx context.Context) error
+}
+
+// JobRunner runs registered jobs on their own tickers until stopped
+type JobRunner struct {
+	jobs   []Job
+	logger zerolog.Logger
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewJobRunner(logger zerolog.Logger) *JobRunner {
+	return &JobRunner{logger: logger}
+}
+
+// Register adds a job to the runner. Jobs must be registered before Start.
+func (r *JobRunner) Register(job Job) {
+	r.jobs = append(r.jobs, job)
+}
+
+// Start launches a goroutine per job. Each job runs once immediately and then
+// on every tick of its interval.
+func (r *JobRunner) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	for _, job := range r.jobs {
+		r.wg.Add(1)
+		go r.loop(ctx, job)
+	}
+}
+
+// Stop cancels all running jobs and waits for in-flight runs to finish
+func (r *JobRunner) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+func (r *JobRunner) loop(ctx context.Context, job Job) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(job.Interval())
+	defer ticker.Stop()
+
+	for {
+		r.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *JobRunner) run(ctx context.Context, job Job) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			r.logger.Error().Str("job", job.Name()).Interface("panic", rec).Msg("Job panicked")
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+		r.logger.Error().Err(err).Str("job", job.Name()).Msg("Job failed")
+		return
+	}
+	r.logger.Debug().Str("job", job.Name()).Dur("duration", time.Since(start)).Msg("Job finished")
+}
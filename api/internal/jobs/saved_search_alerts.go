@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// SavedSearchAlertJob emails editors whose alerting saved searches have more
+// results than their threshold
+type SavedSearchAlertJob struct {
+	savedSearchService *services.SavedSearchService
+	interval           time.Duration
+	logger             zerolog.Logger
+}
+
+func NewSavedSearchAlertJob(savedSearchService *services.SavedSearchService, interval time.Duration, logger zerolog.Logger) *SavedSearchAlertJob {
+	return &SavedSearchAlertJob{
+		savedSearchService: savedSearchService,
+		interval:           interval,
+		logger:             logger,
+	}
+}
+
+func (j *SavedSearchAlertJob) Name() string {
+	return "saved_search_alerts"
+}
+
+func (j *SavedSearchAlertJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *SavedSearchAlertJob) Run(ctx context.Context) error {
+	sent, err := j.savedSearchService.RunAlerts(ctx)
+	if sent > 0 {
+		j.logger.Info().Int("sent", sent).Msg("Sent saved search alerts")
+	}
+	return err
+}
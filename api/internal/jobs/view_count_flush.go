@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+)
+
+// ViewCountFlushJob writes view counts accumulated in Redis to the database.
+// Run it once more after the runner stops so no views are left behind.
+type ViewCountFlushJob struct {
+	articleService  *services.ArticleService
+	electionService *services.ElectionService
+	embedService    *services.EmbedService
+	interval        time.Duration
+}
+
+func NewViewCountFlushJob(articleService *services.ArticleService, electionService *services.ElectionService, embedService *services.EmbedService, interval time.Duration) *ViewCountFlushJob {
+	return &ViewCountFlushJob{
+		articleService:  articleService,
+		electionService: electionService,
+		embedService:    embedService,
+		interval:        interval,
+	}
+}
+
+func (j *ViewCountFlushJob) Name() string {
+	return "view_count_flush"
+}
+
+func (j *ViewCountFlushJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *ViewCountFlushJob) Run(ctx context.Context) error {
+	return errors.Join(
+		j.articleService.FlushViewCounts(ctx),
+		j.electionService.FlushViewCounts(ctx),
+		j.embedService.FlushViewCounts(ctx),
+	)
+}
@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// WebhookDispatcherJob sends queued webhook deliveries that are due. Failed
+// deliveries are retried with backoff by later runs until they are
+// dead-lettered.
+type WebhookDispatcherJob struct {
+	webhookService *services.WebhookService
+	interval       time.Duration
+	logger         zerolog.Logger
+}
+
+func NewWebhookDispatcherJob(webhookService *services.WebhookService, interval time.Duration, logger zerolog.Logger) *WebhookDispatcherJob {
+	return &WebhookDispatcherJob{
+		webhookService: webhookService,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+func (j *WebhookDispatcherJob) Name() string {
+	return "webhook_dispatcher"
+}
+
+func (j *WebhookDispatcherJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *WebhookDispatcherJob) Run(ctx context.Context) error {
+	delivered, failed, err := j.webhookService.DispatchDue(ctx)
+	if delivered > 0 || failed > 0 {
+		j.logger.Info().Int("delivered", delivered).Int("failed", failed).Msg("Dispatched webhooks")
+	}
+	return err
+}
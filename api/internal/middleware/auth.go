@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"strings"
 
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/rs/zerolog"
 )
 
 type contextKey string
@@ -15,12 +18,26 @@ type contextKey string
 const UserContextKey contextKey = "user"
 const PermissionsContextKey contextKey = "permissions"
 
+// AuditRecorder records entries in the audit trail
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *models.AuditLog) error
+}
+
 type AuthMiddleware struct {
 	authService *services.AuthService
+	audit       AuditRecorder
+	logger      zerolog.Logger
 }
 
 func NewAuthMiddleware(authService *services.AuthService) *AuthMiddleware {
-	return &AuthMiddleware{authService: authService}
+	return &AuthMiddleware{authService: authService, logger: zerolog.Nop()}
+}
+
+// SetAuditRecorder sets where requests made while impersonating a user are
+// recorded. Failures to record are logged to logger.
+func (m *AuthMiddleware) SetAuditRecorder(audit AuditRecorder, logger zerolog.Logger) {
+	m.audit = audit
+	m.logger = logger
 }
 
 func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
@@ -43,18 +60,7 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), UserContextKey, claims)
-
-		// Load permissions for the user's role
-		if claims.RoleID != "" {
-			roleID, err := uuid.Parse(claims.RoleID)
-			if err == nil {
-				permissions, _ := m.authService.GetPermissionsByRoleID(r.Context(), roleID)
-				ctx = context.WithValue(ctx, PermissionsContextKey, permissions)
-			}
-		}
-
-		next.ServeHTTP(w, r.WithContext(ctx))
+		m.serveAuthenticated(w, r, next, claims)
 	})
 }
 
@@ -83,19 +89,64 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 		}
 
 		// Valid token - add user context
-		ctx := context.WithValue(r.Context(), UserContextKey, claims)
-
-		// Load permissions for the user's role
-		if claims.RoleID != "" {
-			roleID, err := uuid.Parse(claims.RoleID)
-			if err == nil {
-				permissions, _ := m.authService.GetPermissionsByRoleID(r.Context(), roleID)
-				ctx = context.WithValue(ctx, PermissionsContextKey, permissions)
-			}
+		m.serveAuthenticated(w, r, next, claims)
+	})
+}
+
+// serveAuthenticated adds the user's claims and permissions to the request
+// context and serves it. Requests made while impersonating are recorded in
+// the audit log against the real admin.
+func (m *AuthMiddleware) serveAuthenticated(w http.ResponseWriter, r *http.Request, next http.Handler, claims *services.JWTClaims) {
+	ctx := context.WithValue(r.Context(), UserContextKey, claims)
+	setLogUserID(ctx, claims.UserID)
+
+	// Load permissions for the user's role
+	if claims.RoleID != "" {
+		roleID, err := uuid.Parse(claims.RoleID)
+		if err == nil {
+			permissions, _ := m.authService.GetPermissionsByRoleID(r.Context(), roleID)
+			ctx = context.WithValue(ctx, PermissionsContextKey, permissions)
 		}
+	}
 
+	if claims.ImpersonatedBy == "" {
 		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+		return
+	}
+
+	setLogImpersonatedBy(ctx, claims.ImpersonatedBy)
+	ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+	r = r.WithContext(ctx)
+	next.ServeHTTP(ww, r)
+	m.recordImpersonatedRequest(r, claims, ww.Status())
+}
+
+// recordImpersonatedRequest attributes a request made with an impersonation
+// token to the admin behind it
+func (m *AuthMiddleware) recordImpersonatedRequest(r *http.Request, claims *services.JWTClaims, status int) {
+	adminID, err := uuid.Parse(claims.ImpersonatedBy)
+	userID, userErr := uuid.Parse(claims.UserID)
+	if err != nil || userErr != nil || m.audit == nil {
+		m.logger.Error().Str("impersonated_by", claims.ImpersonatedBy).Msg("Impersonated request not audited")
+		return
+	}
+
+	method, route, path, ip := r.Method, routePattern(r), r.URL.Path, getClientIP(r)
+	entry := &models.AuditLog{
+		ActorID:            adminID,
+		ImpersonatedUserID: &userID,
+		Action:             models.AuditActionImpersonatedRequest,
+		Method:             &method,
+		Route:              &route,
+		Path:               &path,
+		StatusCode:         &status,
+		IPAddress:          &ip,
+	}
+
+	// Record even if the client has gone away
+	if err := m.audit.Record(context.WithoutCancel(r.Context()), entry); err != nil {
+		m.logger.Error().Err(err).Str("impersonated_by", claims.ImpersonatedBy).Msg("Failed to audit impersonated request")
+	}
 }
 
 // RequireAdmin checks if user has admin role
@@ -186,6 +237,15 @@ func GetUserClaims(ctx context.Context) *services.JWTClaims {
 	return claims
 }
 
+// GetImpersonatorID returns the ID of the admin impersonating the current
+// user, or "" when the request isn't impersonated
+func GetImpersonatorID(ctx context.Context) string {
+	if claims := GetUserClaims(ctx); claims != nil {
+		return claims.ImpersonatedBy
+	}
+	return ""
+}
+
 func GetUserPermissions(ctx context.Context) []string {
 	permissions, ok := ctx.Value(PermissionsContextKey).([]string)
 	if !ok {
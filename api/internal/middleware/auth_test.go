@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/services"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJWTSecret = "test-secret"
+
+type fakeAudit struct {
+	entries []*models.AuditLog
+}
+
+func (f *fakeAudit) Record(_ context.Context, entry *models.AuditLog) error {
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+func signTestToken(t *testing.T, userID, impersonatedBy string) string {
+	t.Helper()
+	claims := &services.JWTClaims{
+		UserID:         userID,
+		Role:           "user",
+		ImpersonatedBy: impersonatedBy,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	require.NoError(t, err)
+	return token
+}
+
+func newImpersonationRouter(audit AuditRecorder, logger zerolog.Logger) http.Handler {
+	m := NewAuthMiddleware(services.NewAuthService(nil, nil, nil, nil, testJWTSecret))
+	m.SetAuditRecorder(audit, zerolog.Nop())
+
+	r := chi.NewRouter()
+	r.Use(Logger(logger))
+	r.With(m.Authenticate).Post("/api/comments/{id}/reactions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	return r
+}
+
+func TestAuthenticateAuditsImpersonatedRequests(t *testing.T) {
+	var buf bytes.Buffer
+	audit := &fakeAudit{}
+	router := newImpersonationRouter(audit, zerolog.New(&buf))
+
+	adminID, userID := uuid.New(), uuid.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/comments/abc/reactions", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, userID.String(), adminID.String()))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Len(t, audit.entries, 1)
+	entry := audit.entries[0]
+	assert.Equal(t, adminID, entry.ActorID)
+	require.NotNil(t, entry.ImpersonatedUserID)
+	assert.Equal(t, userID, *entry.ImpersonatedUserID)
+	assert.Equal(t, models.AuditActionImpersonatedRequest, entry.Action)
+	assert.Equal(t, "/api/comments/{id}/reactions", *entry.Route)
+	assert.Equal(t, "/api/comments/abc/reactions", *entry.Path)
+	assert.Equal(t, http.StatusCreated, *entry.StatusCode)
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, userID.String(), line["user_id"])
+	assert.Equal(t, adminID.String(), line["impersonated_by"])
+}
+
+func TestAuthenticateDoesNotAuditOwnRequests(t *testing.T) {
+	var buf bytes.Buffer
+	audit := &fakeAudit{}
+	router := newImpersonationRouter(audit, zerolog.New(&buf))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/comments/abc/reactions", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, uuid.NewString(), ""))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Empty(t, audit.entries)
+	assert.NotContains(t, buf.String(), "impersonated_by")
+}
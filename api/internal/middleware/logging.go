@@ -1,32 +1,195 @@
 package middleware
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand/v2"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
 	"github.com/rs/zerolog"
 )
 
+const logFieldsContextKey contextKey = "log_fields"
+
+// logFields carries values discovered further down the middleware chain
+// (e.g. by route-level auth) back up to the request logger
+type logFields struct {
+	userID         string
+	impersonatedBy string
+}
+
+// latencyBuckets are the upper bounds used to group request durations
+var latencyBuckets = []struct {
+	limit time.Duration
+	label string
+}{
+	{50 * time.Millisecond, "lt_50ms"},
+	{100 * time.Millisecond, "lt_100ms"},
+	{250 * time.Millisecond, "lt_250ms"},
+	{500 * time.Millisecond, "lt_500ms"},
+	{time.Second, "lt_1s"},
+	{5 * time.Second, "lt_5s"},
+}
+
+// RequestIDHeader carries the request ID back to the client, and into error
+// responses, so a failed request can be found in the logs
+const RequestIDHeader = "X-Request-Id"
+
+// maxLoggedError bounds how much of a 5xx response body is kept for the log
+const maxLoggedError = 1024
+
+// LogSampling thins out the request log on busy public pages. Fast,
+// successful GETs from anonymous users are logged at Rate, between 0 and 1;
+// errors, authenticated requests and anything taking SlowThreshold or longer
+// are always logged.
+type LogSampling struct {
+	Rate          float64
+	SlowThreshold time.Duration
+}
+
+// Logger logs one line per request. It records the matched route pattern rather
+// than the raw path to keep cardinality low, and never logs bodies or headers
+// other than the user agent, except for the error message of a 5xx response.
 func Logger(logger zerolog.Logger) func(next http.Handler) http.Handler {
+	return SampledLogger(logger, LogSampling{Rate: 1})
+}
+
+// SampledLogger is Logger with routine requests logged at sampling.Rate
+func SampledLogger(logger zerolog.Logger, sampling LogSampling) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			errorBody := &serverErrorBody{status: ww.Status}
+			ww.Tee(errorBody)
+
+			requestID := middleware.GetReqID(r.Context())
+			if requestID != "" {
+				ww.Header().Set(RequestIDHeader, requestID)
+			}
+
+			fields := &logFields{}
+			ctx, cacheStatus := cache.WithStatus(context.WithValue(r.Context(), logFieldsContextKey, fields))
+			r = r.WithContext(ctx)
 
 			defer func() {
-				logger.Info().
+				duration := time.Since(start)
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				routine := r.Method == http.MethodGet && status < 300 && fields.userID == "" &&
+					(sampling.SlowThreshold <= 0 || duration < sampling.SlowThreshold)
+				if routine && sampling.Rate < 1 && rand.Float64() >= sampling.Rate {
+					return
+				}
+
+				event := logger.Info().
+					Str("request_id", requestID).
 					Str("method", r.Method).
-					Str("path", r.URL.Path).
+					Str("route", routePattern(r)).
 					Str("remote_addr", r.RemoteAddr).
-					Int("status", ww.Status()).
+					Int("status", status).
 					Int("bytes", ww.BytesWritten()).
-					Dur("duration", time.Since(start)).
-					Str("user_agent", r.UserAgent()).
-					Msg("request")
+					Dur("duration", duration).
+					Str("latency_bucket", latencyBucket(duration)).
+					Str("user_agent", r.UserAgent())
+
+				if fields.userID != "" {
+					event = event.Str("user_id", fields.userID)
+				}
+				if fields.impersonatedBy != "" {
+					event = event.Str("impersonated_by", fields.impersonatedBy)
+				}
+				if s := cacheStatus.String(); s != "" {
+					event = event.Str("cache_status", s)
+				}
+				if msg := errorBody.message(); msg != "" {
+					event = event.Str("error", msg)
+				}
+				if routine && sampling.Rate < 1 {
+					// Lets log queries scale sampled counts back up
+					event = event.Float64("sample_rate", sampling.Rate)
+				}
+
+				event.Msg("request")
 			}()
 
 			next.ServeHTTP(ww, r)
 		})
 	}
 }
+
+// serverErrorBody keeps the start of a 5xx response body, so the error
+// message sent to the client can be logged alongside its request ID
+type serverErrorBody struct {
+	status func() int
+	buf    bytes.Buffer
+}
+
+func (b *serverErrorBody) Write(p []byte) (int, error) {
+	if b.status() >= 500 {
+		if room := maxLoggedError - b.buf.Len(); room > 0 {
+			b.buf.Write(p[:min(len(p), room)])
+		}
+	}
+	return len(p), nil
+}
+
+// message is the error message from a JSON error response, or the raw body
+// for anything else
+func (b *serverErrorBody) message() string {
+	if b.buf.Len() == 0 {
+		return ""
+	}
+	var resp models.APIResponse
+	if err := json.Unmarshal(b.buf.Bytes(), &resp); err == nil && resp.Error != nil {
+		return resp.Error.Message
+	}
+	return strings.TrimSpace(b.buf.String())
+}
+
+// setLogUserID records the authenticated user on the request's log line
+func setLogUserID(ctx context.Context, userID string) {
+	if fields, ok := ctx.Value(logFieldsContextKey).(*logFields); ok {
+		fields.userID = userID
+	}
+}
+
+// setLogImpersonatedBy records the admin behind an impersonated request on
+// the request's log line
+func setLogImpersonatedBy(ctx context.Context, adminID string) {
+	if fields, ok := ctx.Value(logFieldsContextKey).(*logFields); ok {
+		fields.impersonatedBy = adminID
+	}
+}
+
+// routePattern returns the chi route pattern matched for the request, or
+// "unmatched" when no route handled it
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return "unmatched"
+	}
+	if pattern := rctx.RoutePattern(); pattern != "" {
+		return pattern
+	}
+	return "unmatched"
+}
+
+func latencyBucket(d time.Duration) string {
+	for _, b := range latencyBuckets {
+		if d < b.limit {
+			return b.label
+		}
+	}
+	return "gte_5s"
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerRecordsRouteAndUser(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	r := chi.NewRouter()
+	r.Use(Logger(logger))
+	r.With(func(next http.Handler) http.Handler {
+		// Stand-in for route-level auth
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			setLogUserID(r.Context(), "user-123")
+			next.ServeHTTP(w, r)
+		})
+	}).Post("/api/articles/{slug}/comments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/articles/some-article/comments", strings.NewReader(`{"content":"secret body"}`))
+	req.Header.Set("Authorization", "Bearer secret-token")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	assert.Equal(t, "/api/articles/{slug}/comments", entry["route"])
+	assert.Equal(t, "user-123", entry["user_id"])
+	assert.Equal(t, float64(2), entry["bytes"])
+	assert.Equal(t, float64(http.StatusOK), entry["status"])
+	assert.NotContains(t, buf.String(), "secret-token")
+	assert.NotContains(t, buf.String(), "secret body")
+	assert.NotContains(t, buf.String(), "some-article")
+}
+
+func TestLoggerUnmatchedRoute(t *testing.T) {
+	var buf bytes.Buffer
+	r := chi.NewRouter()
+	r.Use(Logger(zerolog.New(&buf)))
+	r.Get("/known", func(w http.ResponseWriter, r *http.Request) {})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/random/scanner/path", nil))
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "unmatched", entry["route"])
+	assert.NotContains(t, entry, "user_id")
+}
+
+func TestLatencyBucket(t *testing.T) {
+	assert.Equal(t, "lt_50ms", latencyBucket(10*time.Millisecond))
+	assert.Equal(t, "lt_100ms", latencyBucket(50*time.Millisecond))
+	assert.Equal(t, "lt_1s", latencyBucket(999*time.Millisecond))
+	assert.Equal(t, "gte_5s", latencyBucket(5*time.Second))
+}
+
+func TestLoggerErrorResponse(t *testing.T) {
+	var buf bytes.Buffer
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(Logger(zerolog.New(&buf)))
+	r.Get("/api/elections/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"success":false,"error":{"code":"INTERNAL_ERROR","message":"failed to get election: connection refused"}}`))
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/elections/eleksyon-2025", nil))
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "failed to get election: connection refused", entry["error"])
+	assert.NotEmpty(t, entry["request_id"])
+	assert.Equal(t, entry["request_id"], rec.Header().Get(RequestIDHeader))
+}
+
+func TestLoggerCacheStatus(t *testing.T) {
+	var buf bytes.Buffer
+	redisCache, err := cache.NewRedisCache("redis://localhost:6379")
+	if err != nil {
+		t.Skip("Skipping cache status test: cannot connect to Redis")
+	}
+	defer redisCache.Close()
+
+	r := chi.NewRouter()
+	r.Use(Logger(zerolog.New(&buf)))
+	r.Get("/cached", func(w http.ResponseWriter, r *http.Request) {
+		var v string
+		_ = redisCache.Get(r.Context(), "test:logger:missing", &v)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/cached", nil))
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "miss", entry["cache_status"])
+}
+
+func TestSampledLogger(t *testing.T) {
+	var buf bytes.Buffer
+	r := chi.NewRouter()
+	r.Use(SampledLogger(zerolog.New(&buf), LogSampling{Rate: 0, SlowThreshold: 50 * time.Millisecond}))
+	r.Get("/fast", func(w http.ResponseWriter, r *http.Request) {})
+	r.Get("/slow", func(w http.ResponseWriter, r *http.Request) { time.Sleep(60 * time.Millisecond) })
+	r.Get("/broken", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusBadGateway) })
+	r.Post("/fast", func(w http.ResponseWriter, r *http.Request) {})
+	r.With(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			setLogUserID(r.Context(), "user-123")
+			next.ServeHTTP(w, r)
+		})
+	}).Get("/mine", func(w http.ResponseWriter, r *http.Request) {})
+
+	logged := func(method, path string) bool {
+		buf.Reset()
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, path, nil))
+		return buf.Len() > 0
+	}
+
+	assert.False(t, logged(http.MethodGet, "/fast"))
+	assert.True(t, logged(http.MethodGet, "/slow"))
+	assert.True(t, logged(http.MethodGet, "/broken"))
+	assert.True(t, logged(http.MethodPost, "/fast"))
+	assert.True(t, logged(http.MethodGet, "/mine"))
+}
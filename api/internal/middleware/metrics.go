@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/humfurie/pulpulitiko/api/pkg/metrics"
+)
+
+// RequestMetrics counts and times every request by method, matched route
+// pattern and status
+func RequestMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		defer func() {
+			status := ww.Status()
+			if status == 0 {
+				// Nothing was written, which net/http sends as a 200
+				status = http.StatusOK
+			}
+			metrics.RecordHTTPRequest(r.Method, routePattern(r), status, time.Since(start))
+		}()
+
+		next.ServeHTTP(ww, r)
+	})
+}
+
+// MetricsToken guards the /metrics endpoint, letting through only requests
+// bearing token. Scrapers send it as "Authorization: Bearer <token>". An
+// empty token lets nothing through.
+func MetricsToken(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				http.Error(w, `{"success":false,"error":{"code":"UNAUTHORIZED","message":"invalid metrics token"}}`, http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/humfurie/pulpulitiko/api/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrapeMetrics(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return rec.Body.String()
+}
+
+func TestRequestMetricsCountsByRoute(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(RequestMetrics)
+	r.Get("/test/metrics/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	r.Post("/test/metrics/{slug}/views", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for _, path := range []string{"/test/metrics/one", "/test/metrics/two"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/test/metrics/one/views", nil))
+
+	body := scrapeMetrics(t)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/test/metrics/{slug}",status="200"} 2`)
+	assert.Contains(t, body, `http_requests_total{method="POST",route="/test/metrics/{slug}/views",status="204"} 1`)
+	assert.Contains(t, body, `http_request_duration_seconds_count{route="/test/metrics/{slug}",status="200"} 2`)
+	assert.NotContains(t, body, "/test/metrics/one")
+}
+
+func TestMetricsToken(t *testing.T) {
+	handler := MetricsToken("s3cret")(metrics.Handler())
+
+	for header, want := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"s3cret":        http.StatusUnauthorized,
+		"Bearer s3cret": http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, header)
+	}
+
+	// Without a token configured nothing gets through
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	MetricsToken("")(metrics.Handler()).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
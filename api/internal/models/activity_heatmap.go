@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// MaxActivityHeatmapDays is the longest trailing window the heatmap covers,
+// and how far back the activity rollup recounts on startup
+const MaxActivityHeatmapDays = 90
+
+// ActivityHeatmap is site activity by day of week and hour of day, in the
+// site timezone. Rows are days from Sunday (0) to Saturday (6), columns hours
+// from 0 to 23.
+type ActivityHeatmap struct {
+	Days         int           `json:"days"`
+	Since        time.Time     `json:"since"`
+	Timezone     string        `json:"timezone"`
+	Comments     HeatmapMatrix `json:"comments"`
+	PollVotes    HeatmapMatrix `json:"poll_votes"`
+	ArticleViews HeatmapMatrix `json:"article_views"`
+}
+
+// HeatmapMatrix is one metric's counts and the same counts scaled to 0-1
+// against its busiest cell, for shading
+type HeatmapMatrix struct {
+	Raw        [7][24]int64   `json:"raw"`
+	Normalized [7][24]float64 `json:"normalized"`
+	Total      int64          `json:"total"`
+}
+
+// Normalize fills in Normalized and Total from Raw. With no activity every
+// cell stays 0.
+func (m *HeatmapMatrix) Normalize() {
+	var peak int64
+	m.Total = 0
+	for _, day := range m.Raw {
+		for _, n := range day {
+			m.Total += n
+			peak = max(peak, n)
+		}
+	}
+
+	for d, day := range m.Raw {
+		for h, n := range day {
+			m.Normalized[d][h] = 0
+			if peak > 0 {
+				m.Normalized[d][h] = float64(n) / float64(peak)
+			}
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeatmapMatrixNormalize(t *testing.T) {
+	var m HeatmapMatrix
+	m.Raw[1][9] = 40 // Monday 9am, the busiest cell
+	m.Raw[5][20] = 10
+	m.Normalize()
+
+	assert.Equal(t, int64(50), m.Total)
+	assert.Equal(t, 1.0, m.Normalized[1][9])
+	assert.Equal(t, 0.25, m.Normalized[5][20])
+	assert.Equal(t, 0.0, m.Normalized[0][0])
+
+	var empty HeatmapMatrix
+	empty.Normalize()
+	assert.Equal(t, int64(0), empty.Total)
+	assert.Equal(t, [7][24]float64{}, empty.Normalized)
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,32 +11,144 @@ type ArticleStatus string
 
 const (
 	ArticleStatusDraft     ArticleStatus = "draft"
+	ArticleStatusScheduled ArticleStatus = "scheduled" // Published automatically once published_at arrives
 	ArticleStatusPublished ArticleStatus = "published"
 	ArticleStatusArchived  ArticleStatus = "archived"
 )
 
+// Who may read an article in full
+const (
+	ArticleAccessPublic  = "public"
+	ArticleAccessMembers = "members" // Non-members see a preview after their free reads
+)
+
+// RolePremium is the role whose users read premium articles in full
+const RolePremium = "premium"
+
+// How much of an article a reader was given
+const (
+	ArticleAccessFull       = "full"
+	ArticleAccessRestricted = "restricted" // Content is a preview
+)
+
+// ArticleSort is the order of an article list
+type ArticleSort string
+
+const (
+	ArticleSortNewest        ArticleSort = "newest"
+	ArticleSortOldest        ArticleSort = "oldest"
+	ArticleSortMostViewed    ArticleSort = "most_viewed"
+	ArticleSortMostCommented ArticleSort = "most_commented"
+)
+
+// ParseArticleSort validates a sort query value. An empty value sorts newest first.
+func ParseArticleSort(value string) (ArticleSort, error) {
+	switch sort := ArticleSort(value); sort {
+	case "":
+		return ArticleSortNewest, nil
+	case ArticleSortNewest, ArticleSortOldest, ArticleSortMostViewed, ArticleSortMostCommented:
+		return sort, nil
+	default:
+		return "", fmt.Errorf("sort must be one of newest, oldest, most_viewed, most_commented")
+	}
+}
+
 type Article struct {
-	ID                  uuid.UUID     `json:"id"`
-	Slug                string        `json:"slug"`
-	Title               string        `json:"title"`
-	Summary             *string       `json:"summary,omitempty"`
-	Content             string        `json:"content"`
-	FeaturedImage       *string       `json:"featured_image,omitempty"`
-	AuthorID            *uuid.UUID    `json:"author_id,omitempty"`
-	CategoryID          *uuid.UUID    `json:"category_id,omitempty"`
-	PrimaryPoliticianID *uuid.UUID    `json:"primary_politician_id,omitempty"`
-	Status              ArticleStatus `json:"status"`
-	ViewCount           int           `json:"view_count"`
-	PublishedAt         *time.Time    `json:"published_at,omitempty"`
-	CreatedAt           time.Time     `json:"created_at"`
-	UpdatedAt           time.Time     `json:"updated_at"`
+	ID                  uuid.UUID      `json:"id"`
+	Slug                string         `json:"slug"`
+	Title               string         `json:"title"`
+	Summary             *string        `json:"summary,omitempty"`
+	Content             string         `json:"content"`
+	FeaturedImage       *string        `json:"featured_image,omitempty"`
+	CoverImage          *string        `json:"cover_image,omitempty"` // Shown on cards and in link previews; falls back to the featured image
+	Images              *ArticleImages `json:"images,omitempty"`      // Cropped renditions of the featured image
+	AuthorID            *uuid.UUID     `json:"author_id,omitempty"`
+	CategoryID          *uuid.UUID     `json:"category_id,omitempty"`
+	PrimaryPoliticianID *uuid.UUID     `json:"primary_politician_id,omitempty"`
+	Status              ArticleStatus  `json:"status"`
+	AccessLevel         string         `json:"access_level"`
+	IsPremium           bool           `json:"is_premium"`
+	PremiumSummary      *string        `json:"premium_summary,omitempty"` // Shown in place of the content to readers without the premium role
+	FocusKeyword        *string        `json:"focus_keyword,omitempty"`   // What the article is meant to rank for in search
+	ViewCount           int            `json:"view_count"`
+	PublishedAt         *time.Time     `json:"published_at,omitempty"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+
+	// Comment settings
+	CommentsEnabled      bool       `json:"comments_enabled"`
+	CommentsLockedAt     *time.Time `json:"comments_locked_at,omitempty"` // No new threads from readers from this time on
+	CommentsPremoderated bool       `json:"comments_premoderated"`        // Reader comments wait for approval
+
+	// Comment state in effect when the article was fetched, so readers know
+	// whether to show the comment composer
+	CommentState CommentState `json:"comment_state,omitempty"`
+
+	// Reader reactions, filled per request on the public article page
+	Reactions *ArticleReactions `json:"reactions,omitempty"`
+
+	// Average sentiment of the visible comments, filled on the admin article
+	// page when any comment has been scored
+	AvgCommentSentiment *float64 `json:"avg_comment_sentiment,omitempty"`
 
 	// Relations (populated when needed)
-	Author               *Author      `json:"author,omitempty"`
-	Category             *Category    `json:"category,omitempty"`
-	Tags                 []Tag        `json:"tags,omitempty"`
-	PrimaryPolitician    *Politician  `json:"primary_politician,omitempty"`
-	MentionedPoliticians []Politician `json:"mentioned_politicians,omitempty"`
+	Author               *Author         `json:"author,omitempty"`
+	Authors              []ArticleAuthor `json:"authors,omitempty"` // Primary author first, then co-authors in order
+	Category             *Category       `json:"category,omitempty"`
+	Tags                 []Tag           `json:"tags,omitempty"`
+	PrimaryPolitician    *Politician     `json:"primary_politician,omitempty"`
+	MentionedPoliticians []Politician    `json:"mentioned_politicians,omitempty"`
+	Sources              []ArticleSource `json:"sources,omitempty"` // Primary sources first
+
+	// The article's image gallery in display order, and the featured item
+	// out of it, with the caption and credit shown alongside
+	Gallery       []ArticleMedia `json:"gallery,omitempty"`
+	FeaturedMedia *ArticleMedia  `json:"featured_media,omitempty"`
+
+	// Current content of the explainers the article embeds, in the order
+	// their tokens first appear. Unknown slugs are left out.
+	EmbeddedExplainers []EmbeddedExplainer `json:"embedded_explainers,omitempty"`
+
+	// Other articles publishing close to this one, set when a create or update
+	// crowds the publish window
+	ScheduleConflicts []ScheduledArticle `json:"schedule_conflicts,omitempty"`
+
+	// Set when a create leaves an article in a category that expects sources
+	// without any. It is advisory; the article is saved regardless.
+	SourceWarning string `json:"source_warning,omitempty"`
+
+	// Set on members-only articles served to readers. A restricted article's
+	// content is cut to a preview. Anonymous and signed-in non-members get a
+	// few free articles a month; FreeArticlesRemaining counts what is left.
+	Access                string `json:"access,omitempty"`
+	FreeArticlesRemaining *int   `json:"free_articles_remaining,omitempty"`
+
+	// Set on premium articles served to readers without the premium role,
+	// whose content is replaced by the premium summary
+	ContentLocked bool `json:"content_locked,omitempty"`
+}
+
+// ArticleImages are the renditions made from an uploaded featured image, both
+// cropped around the centre. Each is WebP, or JPEG or PNG when that was
+// smaller.
+type ArticleImages struct {
+	Thumb string `json:"thumb" validate:"required,url"` // 400x250
+	Full  string `json:"full" validate:"required,url"`  // 1200x675
+}
+
+// Sizes of the ArticleImages renditions
+const (
+	ArticleThumbWidth, ArticleThumbHeight = 400, 250
+	ArticleFullWidth, ArticleFullHeight   = 1200, 675
+)
+
+// NewArticleImages builds the renditions from their stored columns, or nil
+// when the article has none
+func NewArticleImages(thumb, full *string) *ArticleImages {
+	if thumb == nil || full == nil {
+		return nil
+	}
+	return &ArticleImages{Thumb: *thumb, Full: *full}
 }
 
 type ArticleListItem struct {
@@ -44,10 +157,14 @@ type ArticleListItem struct {
 	Title         string        `json:"title"`
 	Summary       *string       `json:"summary,omitempty"`
 	FeaturedImage *string       `json:"featured_image,omitempty"`
+	CoverImage    *string       `json:"cover_image,omitempty"`
 	Status        ArticleStatus `json:"status"`
+	AccessLevel   string        `json:"access_level"`
+	IsPremium     bool          `json:"is_premium"`
 	ViewCount     int           `json:"view_count"`
 	PublishedAt   *time.Time    `json:"published_at,omitempty"`
 	CreatedAt     time.Time     `json:"created_at"`
+	DeletedAt     *time.Time    `json:"deleted_at,omitempty"` // Admin list with include_deleted only
 
 	AuthorName            *string `json:"author_name,omitempty"`
 	AuthorSlug            *string `json:"author_slug,omitempty"`
@@ -56,44 +173,91 @@ type ArticleListItem struct {
 	CategorySlug          *string `json:"category_slug,omitempty"`
 	PrimaryPoliticianName *string `json:"primary_politician_name,omitempty"`
 	PrimaryPoliticianSlug *string `json:"primary_politician_slug,omitempty"`
+	CitationCount         int     `json:"citation_count"`
+
+	Authors []ArticleAuthor `json:"authors,omitempty"`
 }
 
+// ArticleAuthor is one entry in an article's byline. Position 0 is the primary author.
+type ArticleAuthor struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Slug     string    `json:"slug"`
+	Avatar   *string   `json:"avatar,omitempty"`
+	Position int       `json:"position"`
+}
+
+// MaxArticleCoAuthors is the most co-authors an article can list besides its primary author
+const MaxArticleCoAuthors = 5
+
 type CreateArticleRequest struct {
-	Slug                string   `json:"slug" validate:"required,min=3,max=255"`
-	Title               string   `json:"title" validate:"required,min=3,max=500"`
-	Summary             *string  `json:"summary,omitempty"`
-	Content             string   `json:"content" validate:"required"`
-	FeaturedImage       *string  `json:"featured_image,omitempty"`
-	AuthorID            *string  `json:"author_id,omitempty" validate:"omitempty,uuid"`
-	CategoryID          *string  `json:"category_id,omitempty" validate:"omitempty,uuid"`
-	PrimaryPoliticianID *string  `json:"primary_politician_id,omitempty" validate:"omitempty,uuid"`
-	Status              string   `json:"status,omitempty" validate:"omitempty,oneof=draft published archived"`
-	TagIDs              []string `json:"tag_ids,omitempty" validate:"omitempty,dive,uuid"`
-	PoliticianIDs       []string `json:"politician_ids,omitempty" validate:"omitempty,dive,uuid"`
+	Slug                 string         `json:"slug" validate:"required,min=3,max=255"`
+	Title                string         `json:"title" validate:"required,min=3,max=500"`
+	Summary              *string        `json:"summary,omitempty"`
+	Content              string         `json:"content" validate:"required"`
+	FeaturedImage        *string        `json:"featured_image,omitempty"`
+	CoverImage           *string        `json:"cover_image,omitempty"` // A URL or the object key of an upload
+	Images               *ArticleImages `json:"images,omitempty"`
+	AuthorID             *string        `json:"author_id,omitempty" validate:"omitempty,uuid"`
+	CategoryID           *string        `json:"category_id,omitempty" validate:"omitempty,uuid"`
+	PrimaryPoliticianID  *string        `json:"primary_politician_id,omitempty" validate:"omitempty,uuid"`
+	Status               string         `json:"status,omitempty" validate:"omitempty,oneof=draft scheduled published archived"`
+	PublishedAt          *string        `json:"published_at,omitempty"` // RFC 3339, or a local time in the site timezone
+	TagIDs               []string       `json:"tag_ids,omitempty" validate:"omitempty,dive,uuid"`
+	PoliticianIDs        []string       `json:"politician_ids,omitempty" validate:"omitempty,dive,uuid"`
+	CoAuthorIDs          []string       `json:"co_author_ids,omitempty" validate:"omitempty,max=5,unique,dive,uuid"`
+	CommentsEnabled      *bool          `json:"comments_enabled,omitempty"`
+	CommentsLockedAt     *string        `json:"comments_locked_at,omitempty"` // RFC 3339, or a local time in the site timezone
+	CommentsPremoderated *bool          `json:"comments_premoderated,omitempty"`
+	AccessLevel          string         `json:"access_level,omitempty" validate:"omitempty,oneof=public members"`
+	IsPremium            bool           `json:"is_premium,omitempty"`
+	PremiumSummary       *string        `json:"premium_summary,omitempty"`
+	FocusKeyword         *string        `json:"focus_keyword,omitempty" validate:"omitempty,max=100"`
+
+	Sources []CreateArticleSourceRequest `json:"sources,omitempty" validate:"omitempty,dive"`
 }
 
 type UpdateArticleRequest struct {
-	Slug                *string  `json:"slug,omitempty" validate:"omitempty,min=3,max=255"`
-	Title               *string  `json:"title,omitempty" validate:"omitempty,min=3,max=500"`
-	Summary             *string  `json:"summary,omitempty"`
-	Content             *string  `json:"content,omitempty"`
-	FeaturedImage       *string  `json:"featured_image,omitempty"`
-	AuthorID            *string  `json:"author_id,omitempty" validate:"omitempty,uuid"`
-	CategoryID          *string  `json:"category_id,omitempty" validate:"omitempty,uuid"`
-	PrimaryPoliticianID *string  `json:"primary_politician_id,omitempty" validate:"omitempty,uuid"`
-	Status              *string  `json:"status,omitempty" validate:"omitempty,oneof=draft published archived"`
-	TagIDs              []string `json:"tag_ids,omitempty" validate:"omitempty,dive,uuid"`
-	PoliticianIDs       []string `json:"politician_ids,omitempty" validate:"omitempty,dive,uuid"`
+	Slug                 *string        `json:"slug,omitempty" validate:"omitempty,min=3,max=255"`
+	Title                *string        `json:"title,omitempty" validate:"omitempty,min=3,max=500"`
+	Summary              *string        `json:"summary,omitempty"`
+	Content              *string        `json:"content,omitempty"`
+	FeaturedImage        *string        `json:"featured_image,omitempty"`
+	CoverImage           *string        `json:"cover_image,omitempty"` // As on create; empty clears it
+	Images               *ArticleImages `json:"images,omitempty"`
+	AuthorID             *string        `json:"author_id,omitempty" validate:"omitempty,uuid"`
+	CategoryID           *string        `json:"category_id,omitempty" validate:"omitempty,uuid"`
+	PrimaryPoliticianID  *string        `json:"primary_politician_id,omitempty" validate:"omitempty,uuid"`
+	Status               *string        `json:"status,omitempty" validate:"omitempty,oneof=draft scheduled published archived"`
+	PublishedAt          *string        `json:"published_at,omitempty"` // RFC 3339, or a local time in the site timezone
+	TagIDs               []string       `json:"tag_ids,omitempty" validate:"omitempty,dive,uuid"`
+	PoliticianIDs        []string       `json:"politician_ids,omitempty" validate:"omitempty,dive,uuid"`
+	CoAuthorIDs          []string       `json:"co_author_ids,omitempty" validate:"omitempty,max=5,unique,dive,uuid"`
+	CommentsEnabled      *bool          `json:"comments_enabled,omitempty"`
+	CommentsLockedAt     *string        `json:"comments_locked_at,omitempty"` // As on create; empty unlocks
+	CommentsPremoderated *bool          `json:"comments_premoderated,omitempty"`
+	AccessLevel          *string        `json:"access_level,omitempty" validate:"omitempty,oneof=public members"`
+	IsPremium            *bool          `json:"is_premium,omitempty"`
+	PremiumSummary       *string        `json:"premium_summary,omitempty"`                            // Empty clears it
+	FocusKeyword         *string        `json:"focus_keyword,omitempty" validate:"omitempty,max=100"` // Empty clears it
+	// Confirm moving a published article into an internal category, which
+	// takes it away from readers
+	Confirm bool `json:"confirm,omitempty"`
 }
 
 type ArticleFilter struct {
-	Status         *ArticleStatus
-	CategoryID     *uuid.UUID
-	TagID          *uuid.UUID
-	AuthorID       *uuid.UUID
-	PoliticianID   *uuid.UUID // Filter by primary or mentioned politician
-	Search         *string
-	IncludeDeleted bool
+	Status          *ArticleStatus
+	CategoryID      *uuid.UUID
+	TagID           *uuid.UUID
+	AuthorID        *uuid.UUID
+	PoliticianID    *uuid.UUID // Filter by primary or mentioned politician
+	Search          *string
+	OlderThanDays   *int       // Created more than this many days ago
+	PublishedAfter  *time.Time // Published on or after this date
+	PublishedBefore *time.Time // Published on or before this date, whole day included
+	IncludeDeleted  bool
+	IncludeInternal bool        // Include articles in internal categories; only admin lists set this
+	Sort            ArticleSort // Empty sorts newest first
 }
 
 type PaginatedArticles struct {
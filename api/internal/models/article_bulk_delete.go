@@ -0,0 +1,23 @@
+package models
+
+import "github.com/google/uuid"
+
+// BulkArticleRequest lists the articles a bulk delete or restore applies to.
+// The cap keeps a stray selection from removing the whole archive at once.
+type BulkArticleRequest struct {
+	ArticleIDs []uuid.UUID `json:"article_ids" validate:"required,min=1,max=100,unique"`
+}
+
+// BulkArticleResult reports the outcome for each requested article, in the
+// order they were requested
+type BulkArticleResult struct {
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+	Results   []BulkArticleItemResult `json:"results"`
+}
+
+type BulkArticleItemResult struct {
+	ID      uuid.UUID `json:"id"`
+	Success bool      `json:"success"`
+	Error   string    `json:"error,omitempty"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommentState is whether readers can comment on an article right now
+type CommentState string
+
+const (
+	CommentStateOpen         CommentState = "open"
+	CommentStatePremoderated CommentState = "premoderated" // Comments wait for approval before they show
+	CommentStateLocked       CommentState = "locked"       // Replies only; no new threads
+	CommentStateDisabled     CommentState = "disabled"
+)
+
+// EffectiveCommentState returns the comment state in effect at now. Disabled
+// wins over locked, and locked over premoderated.
+func (a *Article) EffectiveCommentState(now time.Time) CommentState {
+	switch {
+	case !a.CommentsEnabled:
+		return CommentStateDisabled
+	case a.CommentsLockedAt != nil && !now.Before(*a.CommentsLockedAt):
+		return CommentStateLocked
+	case a.CommentsPremoderated:
+		return CommentStatePremoderated
+	default:
+		return CommentStateOpen
+	}
+}
+
+// BulkCommentSettingsRequest applies comment settings to every article in a
+// category, optionally only those published more than OlderThanDays ago.
+// Settings left out are not changed; an empty comments_locked_at unlocks.
+type BulkCommentSettingsRequest struct {
+	CategoryID           uuid.UUID `json:"category_id" validate:"required"`
+	OlderThanDays        *int      `json:"older_than_days,omitempty" validate:"omitempty,min=0"`
+	CommentsEnabled      *bool     `json:"comments_enabled,omitempty"`
+	CommentsLockedAt     *string   `json:"comments_locked_at,omitempty"` // RFC 3339, or a local time in the site timezone
+	CommentsPremoderated *bool     `json:"comments_premoderated,omitempty"`
+}
+
+// BulkCommentSettingsResult reports how many articles a bulk update changed
+type BulkCommentSettingsResult struct {
+	Updated int `json:"updated"`
+}
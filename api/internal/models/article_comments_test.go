@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleEffectiveCommentState(t *testing.T) {
+	now := time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC)
+	at := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name    string
+		article Article
+		want    CommentState
+	}{
+		{"open", Article{CommentsEnabled: true}, CommentStateOpen},
+		{"disabled", Article{}, CommentStateDisabled},
+		{"disabled wins over locked", Article{CommentsLockedAt: at(now.Add(-time.Hour))}, CommentStateDisabled},
+		{"premoderated", Article{CommentsEnabled: true, CommentsPremoderated: true}, CommentStatePremoderated},
+		{"locks later", Article{CommentsEnabled: true, CommentsLockedAt: at(now.Add(time.Second))}, CommentStateOpen},
+		{"locks exactly now", Article{CommentsEnabled: true, CommentsLockedAt: at(now)}, CommentStateLocked},
+		{"locked wins over premoderated", Article{CommentsEnabled: true, CommentsPremoderated: true, CommentsLockedAt: at(now.Add(-time.Hour))}, CommentStateLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.article.EffectiveCommentState(now))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxArticleExportRows caps an admin article CSV export
+const MaxArticleExportRows = 50000
+
+// ArticleExportRow is one line of an admin article CSV export
+type ArticleExportRow struct {
+	ID           uuid.UUID
+	Title        string
+	Slug         string
+	Status       ArticleStatus
+	Author       *string
+	Category     *string
+	PublishedAt  *time.Time
+	ViewCount    int
+	CommentCount int // Visible comments
+}
+
+// ArticleExportTooLargeError is returned when more articles match an export
+// than MaxArticleExportRows
+type ArticleExportTooLargeError struct {
+	Count int
+	Limit int
+}
+
+func (e *ArticleExportTooLargeError) Error() string {
+	return fmt.Sprintf("%d articles match, more than the export limit of %d; narrow the filters", e.Count, e.Limit)
+}
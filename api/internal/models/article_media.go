@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleMedia is one image in an article's gallery. URL is the original
+// upload; Images are its cropped renditions, when it went through the article
+// image pipeline. The featured item is also the article's featured image.
+// A provisional item was picked by the backfill and hasn't been reviewed.
+type ArticleMedia struct {
+	ID            uuid.UUID      `json:"id"`
+	ArticleID     uuid.UUID      `json:"article_id"`
+	URL           string         `json:"url"`
+	Images        *ArticleImages `json:"images,omitempty"`
+	Caption       *string        `json:"caption,omitempty"`
+	Credit        *string        `json:"credit,omitempty"`
+	Position      int            `json:"position"`
+	IsFeatured    bool           `json:"is_featured"`
+	IsProvisional bool           `json:"is_provisional"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// FeaturedMedia returns the featured item of a gallery, or nil
+func FeaturedMedia(gallery []ArticleMedia) *ArticleMedia {
+	for i := range gallery {
+		if gallery[i].IsFeatured {
+			featured := gallery[i]
+			return &featured
+		}
+	}
+	return nil
+}
+
+// CreateArticleMediaRequest adds an uploaded image to the end of the gallery
+type CreateArticleMediaRequest struct {
+	URL        string         `json:"url" validate:"required,url,max=500"`
+	Images     *ArticleImages `json:"images,omitempty"`
+	Caption    *string        `json:"caption,omitempty" validate:"omitempty,max=2000"`
+	Credit     *string        `json:"credit,omitempty" validate:"omitempty,max=300"`
+	IsFeatured bool           `json:"is_featured"`
+}
+
+// UpdateArticleMediaRequest edits an item. Any edit marks a provisional item
+// as reviewed. Featuring an item unfeatures the article's other items.
+type UpdateArticleMediaRequest struct {
+	Caption    *string `json:"caption,omitempty" validate:"omitempty,max=2000"`
+	Credit     *string `json:"credit,omitempty" validate:"omitempty,max=300"`
+	IsFeatured *bool   `json:"is_featured,omitempty"`
+}
+
+// ReorderArticleMediaRequest lists every item of the gallery in its new order
+type ReorderArticleMediaRequest struct {
+	MediaIDs []uuid.UUID `json:"media_ids" validate:"required,min=1"`
+}
+
+// FeaturedImageBackfill reports a featured image backfill. Articles with a
+// featured image get it recorded as their featured media; the others get
+// the first image of their content, marked provisional.
+type FeaturedImageBackfill struct {
+	ArticlesScanned   int `json:"articles_scanned"`
+	FromFeaturedImage int `json:"from_featured_image"`
+	FromContent       int `json:"from_content"`
+	WithoutImage      int `json:"without_image"`
+}
+
+// MediaInUseError rejects deleting an upload that is the featured image of
+// one or more articles
+type MediaInUseError struct {
+	Articles []ArticleTitle
+}
+
+func (e *MediaInUseError) Error() string {
+	titles := make([]string, len(e.Articles))
+	for i, a := range e.Articles {
+		titles[i] = strconv.Quote(a.Title)
+	}
+	noun := "article"
+	if len(titles) > 1 {
+		noun = "articles"
+	}
+	return fmt.Sprintf("image is the featured image of %s %s", noun, strings.Join(titles, ", "))
+}
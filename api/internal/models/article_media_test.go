@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturedMedia(t *testing.T) {
+	featuredID := uuid.New()
+	gallery := []ArticleMedia{{ID: uuid.New()}, {ID: featuredID, IsFeatured: true}}
+
+	featured := FeaturedMedia(gallery)
+	require.NotNil(t, featured)
+	assert.Equal(t, featuredID, featured.ID)
+
+	featured.Position = 9
+	assert.Zero(t, gallery[1].Position, "returns a copy")
+
+	assert.Nil(t, FeaturedMedia(gallery[:1]))
+	assert.Nil(t, FeaturedMedia(nil))
+}
+
+func TestMediaInUseError(t *testing.T) {
+	one := &MediaInUseError{Articles: []ArticleTitle{{Title: "Budget passes"}}}
+	assert.Equal(t, `image is the featured image of article "Budget passes"`, one.Error())
+
+	two := &MediaInUseError{Articles: []ArticleTitle{{Title: "Budget passes"}, {Title: `The "pork" debate`}}}
+	assert.Equal(t, `image is the featured image of articles "Budget passes", "The \"pork\" debate"`, two.Error())
+}
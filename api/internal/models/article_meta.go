@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// ArticleMeta is what a page needs for an article's Open Graph and other
+// social sharing tags
+type ArticleMeta struct {
+	Title         string            `json:"title"`
+	Description   string            `json:"description"`
+	CanonicalURL  string            `json:"canonical_url"`
+	PublishedTime *time.Time        `json:"published_time,omitempty"`
+	ModifiedTime  time.Time         `json:"modified_time"`
+	Author        string            `json:"author,omitempty"` // Primary author
+	Authors       []string          `json:"authors"`          // Full byline in order
+	Section       string            `json:"section,omitempty"`
+	Tags          []string          `json:"tags"`
+	Image         *ArticleMetaImage `json:"image,omitempty"`
+}
+
+// ArticleMetaImage is an article's sharing image. The size is only known
+// for renditions made by the article image pipeline.
+type ArticleMetaImage struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Alt    string `json:"alt,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// ArticleReactions summarizes reader reactions on an article
+type ArticleReactions struct {
+	Total        int               `json:"total"`
+	Counts       []ReactionSummary `json:"counts"`                  // Most used first
+	UserReaction *string           `json:"user_reaction,omitempty"` // The caller's reaction, if any
+}
+
+// NewArticleReactions builds the summary from per-reaction counts
+func NewArticleReactions(counts []ReactionSummary) *ArticleReactions {
+	reactions := &ArticleReactions{Counts: counts}
+	if reactions.Counts == nil {
+		reactions.Counts = []ReactionSummary{}
+	}
+	for _, c := range reactions.Counts {
+		reactions.Total += c.Count
+		if c.HasReacted {
+			reaction := c.Reaction
+			reactions.UserReaction = &reaction
+		}
+	}
+	return reactions
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewArticleReactions(t *testing.T) {
+	t.Run("no reactions", func(t *testing.T) {
+		reactions := NewArticleReactions(nil)
+		assert.Equal(t, 0, reactions.Total)
+		assert.Equal(t, []ReactionSummary{}, reactions.Counts)
+		assert.Nil(t, reactions.UserReaction)
+	})
+
+	t.Run("totals counts and picks the caller's reaction", func(t *testing.T) {
+		reactions := NewArticleReactions([]ReactionSummary{
+			{Reaction: "heart", Count: 4},
+			{Reaction: "fire", Count: 2, HasReacted: true},
+			{Reaction: "eyes", Count: 1},
+		})
+		assert.Equal(t, 7, reactions.Total)
+		if assert.NotNil(t, reactions.UserReaction) {
+			assert.Equal(t, "fire", *reactions.UserReaction)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons the scheduled publisher moves an article's publish time
+const (
+	ScheduleAdjustmentPublishCap = "publish_cap"
+)
+
+// ScheduledArticle is a queued or published article on the editorial timeline
+type ScheduledArticle struct {
+	ID          uuid.UUID     `json:"id"`
+	Slug        string        `json:"slug"`
+	Title       string        `json:"title"`
+	Status      ArticleStatus `json:"status"`
+	PublishedAt time.Time     `json:"published_at"`
+
+	// Most recent time the publisher moved this article, if it has
+	Adjustment *ArticleScheduleAdjustment `json:"adjustment,omitempty"`
+}
+
+// ArticleScheduleAdjustment records the publisher moving an article's publish time
+type ArticleScheduleAdjustment struct {
+	ID                uuid.UUID `json:"id"`
+	ArticleID         uuid.UUID `json:"article_id"`
+	OriginalPublishAt time.Time `json:"original_publish_at"`
+	AdjustedPublishAt time.Time `json:"adjusted_publish_at"`
+	Reason            string    `json:"reason"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// ArticleSchedule is the timeline of articles publishing between From and To,
+// with times in the site timezone
+type ArticleSchedule struct {
+	From     time.Time          `json:"from"`
+	To       time.Time          `json:"to"`
+	Timezone string             `json:"timezone"`
+	Articles []ScheduledArticle `json:"articles"`
+}
+
+// scheduleTimeLayouts are the local time formats accepted besides RFC 3339
+var scheduleTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// ParseScheduleTime parses a publish time. Times with an offset are taken as
+// is; times without one are read as wall clock time in loc. The result is UTC.
+func ParseScheduleTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	for _, layout := range scheduleTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected RFC 3339 or YYYY-MM-DDTHH:MM", value)
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleTime(t *testing.T) {
+	manila := time.FixedZone("Asia/Manila", 8*60*60)
+	nineUTC := time.Date(2025, 5, 12, 1, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{
+		"2025-05-12T09:00",
+		"2025-05-12T09:00:00",
+		"2025-05-12 09:00",
+		"2025-05-12T09:00:00+08:00",
+		"2025-05-12T01:00:00Z",
+	} {
+		got, err := ParseScheduleTime(value, manila)
+		require.NoError(t, err, value)
+		assert.Equal(t, nineUTC, got, value)
+		assert.Equal(t, time.UTC, got.Location(), value)
+	}
+
+	for _, value := range []string{"", "2025-05-12", "tomorrow 9am", "2025-05-12T25:00"} {
+		_, err := ParseScheduleTime(value, manila)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Article source types
+const (
+	ArticleSourcePressRelease      = "press_release"
+	ArticleSourceOfficialStatement = "official_statement"
+	ArticleSourceInterview         = "interview"
+	ArticleSourceDocument          = "document"
+	ArticleSourceOther             = "other"
+)
+
+// SourcedCategorySlugs are the categories whose articles are expected to cite
+// at least one source. Creating one without is allowed but warned about.
+var SourcedCategorySlugs = []string{"investigations", "fact-check"}
+
+// ArticleSource is a press release, statement, interview or document an
+// article is attributed to. AccessedAt is a date, YYYY-MM-DD.
+type ArticleSource struct {
+	ID         uuid.UUID `json:"id"`
+	ArticleID  uuid.UUID `json:"article_id"`
+	Type       string    `json:"type"`
+	Title      string    `json:"title"`
+	URL        *string   `json:"url,omitempty"`
+	AccessedAt *string   `json:"accessed_at,omitempty"`
+	IsPrimary  bool      `json:"is_primary"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CreateArticleSourceRequest struct {
+	Type       string  `json:"type" validate:"required,oneof=press_release official_statement interview document other"`
+	Title      string  `json:"title" validate:"required,max=500"`
+	URL        *string `json:"url,omitempty" validate:"omitempty,url,max=1000"`
+	AccessedAt *string `json:"accessed_at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	IsPrimary  bool    `json:"is_primary"`
+}
+
+type UpdateArticleSourceRequest struct {
+	Type       *string `json:"type,omitempty" validate:"omitempty,oneof=press_release official_statement interview document other"`
+	Title      *string `json:"title,omitempty" validate:"omitempty,min=1,max=500"`
+	URL        *string `json:"url,omitempty" validate:"omitempty,url,max=1000"`
+	AccessedAt *string `json:"accessed_at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	IsPrimary  *bool   `json:"is_primary,omitempty"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit actions
+const (
+	AuditActionImpersonationStart  = "impersonation.start"
+	AuditActionImpersonatedRequest = "impersonation.request" // A request made with an impersonation token
+	AuditActionArticleExport       = "articles.export"       // A CSV export of the admin article list
+)
+
+// AuditLog is one entry in the audit trail. ActorID is always the real
+// person acting; ImpersonatedUserID is set when they acted as someone else.
+type AuditLog struct {
+	ID                 uuid.UUID  `json:"id"`
+	ActorID            uuid.UUID  `json:"actor_id"`
+	ImpersonatedUserID *uuid.UUID `json:"impersonated_user_id,omitempty"`
+	Action             string     `json:"action"`
+	Method             *string    `json:"method,omitempty"`
+	Route              *string    `json:"route,omitempty"`
+	Path               *string    `json:"path,omitempty"`
+	StatusCode         *int       `json:"status_code,omitempty"`
+	IPAddress          *string    `json:"ip_address,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// ImpersonationResponse is a short-lived session as another user
+type ImpersonationResponse struct {
+	Token          string    `json:"token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	User           User      `json:"user"`
+	Permissions    []string  `json:"permissions"`
+	ImpersonatedBy uuid.UUID `json:"impersonated_by"`
+}
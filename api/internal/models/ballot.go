@@ -0,0 +1,108 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Sample ballot sections, in the order they appear on the ballot
+const (
+	BallotGroupNational   = "national"
+	BallotGroupRegional   = "regional"
+	BallotGroupProvincial = "provincial"
+	BallotGroupCity       = "city"
+	BallotGroupBarangay   = "barangay"
+)
+
+var ballotGroupLabels = map[string]string{
+	BallotGroupNational:   "National",
+	BallotGroupRegional:   "Regional",
+	BallotGroupProvincial: "Provincial",
+	BallotGroupCity:       "City/Municipal",
+	BallotGroupBarangay:   "Barangay",
+}
+
+// BallotSection is one section of a sample ballot and its positions in order
+type BallotSection struct {
+	Group     string                     `json:"group"`
+	Label     string                     `json:"label"`
+	Positions []ElectionPositionListItem `json:"positions"`
+}
+
+// GroupBallotSections splits positions already sorted by ballot group and
+// order into ballot sections
+func GroupBallotSections(positions []ElectionPositionListItem) []BallotSection {
+	var sections []BallotSection
+	for _, p := range positions {
+		if len(sections) == 0 || sections[len(sections)-1].Group != p.BallotGroup {
+			sections = append(sections, BallotSection{Group: p.BallotGroup, Label: ballotGroupLabels[p.BallotGroup]})
+		}
+		last := &sections[len(sections)-1]
+		last.Positions = append(last.Positions, p)
+	}
+	return sections
+}
+
+// ValidateBallotOrder checks that ordered lists each of an election's
+// positions exactly once
+func ValidateBallotOrder(existing, ordered []uuid.UUID) error {
+	known := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		known[id] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ordered))
+	for _, id := range ordered {
+		if seen[id] {
+			return fmt.Errorf("position_ids contains a duplicate position")
+		}
+		if !known[id] {
+			return fmt.Errorf("position_ids contains a position from another election")
+		}
+		seen[id] = true
+	}
+
+	if len(seen) != len(known) {
+		return fmt.Errorf("position_ids must include every position in the election")
+	}
+	return nil
+}
+
+// BallotPositionOrder puts one position at a place within its ballot group
+type BallotPositionOrder struct {
+	PositionID uuid.UUID `json:"position_id"`
+	Order      int       `json:"order"`
+}
+
+// SetBallotOrdersRequest sets the ballot order of some of an election's
+// positions, e.g. to move the party-list after the senators. The others keep
+// theirs; positions with the same order fall back to the office's display
+// order.
+type SetBallotOrdersRequest struct {
+	Orders []BallotPositionOrder `json:"orders"`
+}
+
+// ValidateBallotOrders checks that orders names each position at most once,
+// only from this election, with an order of at least 1
+func ValidateBallotOrders(existing []uuid.UUID, orders []BallotPositionOrder) error {
+	known := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		known[id] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(orders))
+	for _, o := range orders {
+		if seen[o.PositionID] {
+			return fmt.Errorf("orders contains a duplicate position")
+		}
+		if !known[o.PositionID] {
+			return fmt.Errorf("orders contains a position from another election")
+		}
+		if o.Order < 1 {
+			return fmt.Errorf("order must be at least 1")
+		}
+		seen[o.PositionID] = true
+	}
+	return nil
+}
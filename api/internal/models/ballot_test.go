@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupBallotSections(t *testing.T) {
+	positions := []ElectionPositionListItem{
+		{ID: uuid.New(), BallotGroup: BallotGroupNational, BallotOrder: 1, SeatsAvailable: 1},
+		{ID: uuid.New(), BallotGroup: BallotGroupNational, BallotOrder: 2, SeatsAvailable: 12},
+		{ID: uuid.New(), BallotGroup: BallotGroupProvincial, BallotOrder: 1, SeatsAvailable: 1},
+		{ID: uuid.New(), BallotGroup: BallotGroupCity, BallotOrder: 1, SeatsAvailable: 8, CandidateCount: 15},
+	}
+
+	sections := GroupBallotSections(positions)
+
+	assert.Len(t, sections, 3)
+	assert.Equal(t, BallotGroupNational, sections[0].Group)
+	assert.Equal(t, "National", sections[0].Label)
+	assert.Equal(t, []ElectionPositionListItem{positions[0], positions[1]}, sections[0].Positions)
+	assert.Equal(t, BallotGroupProvincial, sections[1].Group)
+	assert.Equal(t, "City/Municipal", sections[2].Label)
+	assert.Equal(t, 8, sections[2].Positions[0].SeatsAvailable)
+	assert.Equal(t, 15, sections[2].Positions[0].CandidateCount)
+
+	assert.Nil(t, GroupBallotSections(nil))
+}
+
+func TestValidateBallotOrder(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	existing := []uuid.UUID{a, b, c}
+
+	assert.NoError(t, ValidateBallotOrder(existing, []uuid.UUID{c, a, b}))
+	assert.EqualError(t, ValidateBallotOrder(existing, []uuid.UUID{a, b}),
+		"position_ids must include every position in the election")
+	assert.EqualError(t, ValidateBallotOrder(existing, []uuid.UUID{a, b, b}),
+		"position_ids contains a duplicate position")
+	assert.EqualError(t, ValidateBallotOrder(existing, []uuid.UUID{a, b, c, uuid.New()}),
+		"position_ids contains a position from another election")
+}
+
+func TestValidateBallotOrders(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	existing := []uuid.UUID{a, b, uuid.New()}
+
+	assert.NoError(t, ValidateBallotOrders(existing, []BallotPositionOrder{{a, 2}, {b, 1}}))
+	assert.EqualError(t, ValidateBallotOrders(existing, []BallotPositionOrder{{a, 1}, {a, 2}}),
+		"orders contains a duplicate position")
+	assert.EqualError(t, ValidateBallotOrders(existing, []BallotPositionOrder{{uuid.New(), 1}}),
+		"orders contains a position from another election")
+	assert.EqualError(t, ValidateBallotOrders(existing, []BallotPositionOrder{{a, 0}}),
+		"order must be at least 1")
+}
@@ -77,6 +77,70 @@ type Committee struct {
 	DeletedAt         *time.Time          `json:"deleted_at,omitempty"`
 	Chairperson       *PoliticianListItem `json:"chairperson,omitempty"`
 	ViceChairperson   *PoliticianListItem `json:"vice_chairperson,omitempty"`
+
+	// Current members, chair first; the chairperson fields above are derived from them
+	Members []CommitteeMember `json:"members"`
+}
+
+// Committee membership roles
+const (
+	CommitteeRoleChair     = "chair"
+	CommitteeRoleViceChair = "vice_chair"
+	CommitteeRoleMember    = "member"
+)
+
+// CommitteeMembership is a politician's seat on a committee
+type CommitteeMembership struct {
+	ID           uuid.UUID  `json:"id"`
+	CommitteeID  uuid.UUID  `json:"committee_id"`
+	PoliticianID uuid.UUID  `json:"politician_id"`
+	Role         string     `json:"role"`
+	StartDate    *time.Time `json:"start_date,omitempty"`
+	EndDate      *time.Time `json:"end_date,omitempty"`
+	SessionID    *uuid.UUID `json:"session_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// Joined fields
+	PoliticianName  string  `json:"politician_name"`
+	PoliticianSlug  string  `json:"politician_slug"`
+	PoliticianPhoto *string `json:"politician_photo,omitempty"`
+}
+
+// CommitteeMember is the public view of a current member on a committee page
+type CommitteeMember struct {
+	PoliticianID   uuid.UUID `json:"politician_id"`
+	PoliticianName string    `json:"politician_name"`
+	Slug           string    `json:"slug"`
+	Photo          *string   `json:"photo,omitempty"`
+	Role           string    `json:"role"`
+}
+
+// PoliticianCommitteeMembership is the public view of a committee seat on a politician profile
+type PoliticianCommitteeMembership struct {
+	CommitteeName string     `json:"committee_name"`
+	CommitteeSlug string     `json:"committee_slug"`
+	Chamber       string     `json:"chamber"`
+	Role          string     `json:"role"`
+	StartDate     *time.Time `json:"start_date,omitempty"`
+	EndDate       *time.Time `json:"end_date,omitempty"`
+	IsCurrent     bool       `json:"is_current"`
+}
+
+type CreateCommitteeMembershipRequest struct {
+	PoliticianID uuid.UUID  `json:"politician_id" validate:"required"`
+	Role         string     `json:"role" validate:"required,oneof=chair vice_chair member"`
+	StartDate    *string    `json:"start_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	EndDate      *string    `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	SessionID    *uuid.UUID `json:"session_id,omitempty"`
+}
+
+type UpdateCommitteeMembershipRequest struct {
+	Role          *string    `json:"role,omitempty" validate:"omitempty,oneof=chair vice_chair member"`
+	StartDate     *string    `json:"start_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	EndDate       *string    `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	SessionID     *uuid.UUID `json:"session_id,omitempty"`
+	RemoveEndDate bool       `json:"remove_end_date,omitempty"` // reopens an ended seat
 }
 
 type CommitteeListItem struct {
@@ -101,10 +165,12 @@ type Bill struct {
 	FullText          *string    `json:"full_text,omitempty"`
 	Significance      *string    `json:"significance,omitempty"`
 	Status            string     `json:"status"`
-	FiledDate         time.Time  `json:"filed_date"`
-	LastActionDate    *time.Time `json:"last_action_date,omitempty"`
-	DateSigned        *time.Time `json:"date_signed,omitempty"`
+	FiledDate         Date       `json:"filed_date"`
+	LastActionDate    *Date      `json:"last_action_date,omitempty"`
+	DateSigned        *Date      `json:"date_signed,omitempty"`
 	RepublicActNumber *string    `json:"republic_act_number,omitempty"`
+	DataAsOf          time.Time  `json:"data_as_of"` // When the bill's status and votes were last brought up to date
+	IsStale           bool       `json:"is_stale"`   // Computed against the configured threshold
 	CreatedAt         time.Time  `json:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at"`
 	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
@@ -117,22 +183,40 @@ type Bill struct {
 	StatusHistory    []BillStatusHistoryItem     `json:"status_history,omitempty"`
 	Topics           []BillTopic                 `json:"topics,omitempty"`
 	Votes            []BillVote                  `json:"votes,omitempty"`
+	Documents        []BillDocument              `json:"documents,omitempty"`
 }
 
 type BillListItem struct {
-	ID             uuid.UUID  `json:"id"`
-	Chamber        string     `json:"chamber"`
-	BillNumber     string     `json:"bill_number"`
-	Title          string     `json:"title"`
-	Slug           string     `json:"slug"`
-	ShortTitle     *string    `json:"short_title,omitempty"`
-	Status         string     `json:"status"`
-	FiledDate      time.Time  `json:"filed_date"`
-	LastActionDate *time.Time `json:"last_action_date,omitempty"`
-	AuthorCount    int        `json:"author_count"`
-	TopicNames     []string   `json:"topic_names,omitempty"`
+	ID             uuid.UUID `json:"id"`
+	Chamber        string    `json:"chamber"`
+	BillNumber     string    `json:"bill_number"`
+	Title          string    `json:"title"`
+	Slug           string    `json:"slug"`
+	ShortTitle     *string   `json:"short_title,omitempty"`
+	Status         string    `json:"status"`
+	FiledDate      Date      `json:"filed_date"`
+	LastActionDate *Date     `json:"last_action_date,omitempty"`
+	AuthorCount    int       `json:"author_count"`
+	TopicNames     []string  `json:"topic_names,omitempty"`
+	HasTranscripts bool      `json:"has_transcripts"`
+	DataAsOf       time.Time `json:"data_as_of"`
+	IsStale        bool      `json:"is_stale"`
 }
 
+// BillSuggestion is a bill autocomplete result
+type BillSuggestion struct {
+	ID         uuid.UUID `json:"id"`
+	BillNumber string    `json:"bill_number"`
+	Title      string    `json:"title"`
+	Slug       string    `json:"slug"`
+	ShortTitle *string   `json:"short_title,omitempty"`
+	Status     string    `json:"status"`
+	Chamber    string    `json:"chamber"`
+}
+
+// BillAutocompleteLimit is how many suggestions bill autocomplete returns
+const BillAutocompleteLimit = 8
+
 // BillAuthor represents an author of a bill
 type BillAuthor struct {
 	ID                uuid.UUID           `json:"id"`
@@ -149,7 +233,7 @@ type BillStatusHistoryItem struct {
 	BillID            uuid.UUID `json:"bill_id"`
 	Status            string    `json:"status"`
 	ActionDescription *string   `json:"action_description,omitempty"`
-	ActionDate        time.Time `json:"action_date"`
+	ActionDate        Date      `json:"action_date"`
 	CreatedAt         time.Time `json:"created_at"`
 }
 
@@ -193,12 +277,20 @@ type PoliticianVote struct {
 
 // BillTopic represents a topic/category for bills
 type BillTopic struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	Description *string   `json:"description,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	BillCount   int       `json:"bill_count,omitempty"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description *string    `json:"description,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	Level       int        `json:"level"`
+	BillCount   int        `json:"bill_count,omitempty"`
+}
+
+// BillTopicNode is a topic with its nested sub-topics
+type BillTopicNode struct {
+	BillTopic
+	Children []BillTopicNode `json:"children"`
 }
 
 // Request types
@@ -214,7 +306,7 @@ type CreateBillRequest struct {
 	FullText         *string     `json:"full_text,omitempty"`
 	Significance     *string     `json:"significance,omitempty" validate:"omitempty,max=100"`
 	Status           string      `json:"status" validate:"required"`
-	FiledDate        string      `json:"filed_date" validate:"required"` // YYYY-MM-DD
+	FiledDate        string      `json:"filed_date" validate:"required,datetime=2006-01-02"`
 	PrincipalAuthors []uuid.UUID `json:"principal_authors,omitempty"`
 	CoAuthors        []uuid.UUID `json:"co_authors,omitempty"`
 	TopicIDs         []uuid.UUID `json:"topic_ids,omitempty"`
@@ -228,22 +320,38 @@ type UpdateBillRequest struct {
 	FullText          *string     `json:"full_text,omitempty"`
 	Significance      *string     `json:"significance,omitempty" validate:"omitempty,max=100"`
 	Status            *string     `json:"status,omitempty"`
-	LastActionDate    *string     `json:"last_action_date,omitempty"` // YYYY-MM-DD
-	DateSigned        *string     `json:"date_signed,omitempty"`      // YYYY-MM-DD
+	LastActionDate    *string     `json:"last_action_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	DateSigned        *string     `json:"date_signed,omitempty" validate:"omitempty,datetime=2006-01-02"`
 	RepublicActNumber *string     `json:"republic_act_number,omitempty" validate:"omitempty,max=50"`
 	TopicIDs          []uuid.UUID `json:"topic_ids,omitempty"`
+	DataAsOf          *time.Time  `json:"data_as_of,omitempty"` // Set by hand after a bulk import session
+}
+
+type CreateBillTopicRequest struct {
+	Name        string     `json:"name" validate:"required,max=100"`
+	Slug        string     `json:"slug" validate:"required,max=100"`
+	Description *string    `json:"description,omitempty"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+type UpdateBillTopicRequest struct {
+	Name         *string    `json:"name,omitempty" validate:"omitempty,max=100"`
+	Slug         *string    `json:"slug,omitempty" validate:"omitempty,max=100"`
+	Description  *string    `json:"description,omitempty"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	RemoveParent bool       `json:"remove_parent,omitempty"` // move the topic back to the root
 }
 
 type AddBillStatusRequest struct {
 	Status            string `json:"status" validate:"required"`
 	ActionDescription string `json:"action_description,omitempty"`
-	ActionDate        string `json:"action_date" validate:"required"` // YYYY-MM-DD
+	ActionDate        string `json:"action_date" validate:"required,datetime=2006-01-02"`
 }
 
 type AddBillVoteRequest struct {
 	Chamber     string `json:"chamber" validate:"required,oneof=senate house"`
 	Reading     string `json:"reading" validate:"required,oneof=second third"`
-	VoteDate    string `json:"vote_date" validate:"required"` // YYYY-MM-DD
+	VoteDate    string `json:"vote_date" validate:"required,datetime=2006-01-02"`
 	Yeas        int    `json:"yeas" validate:"min=0"`
 	Nays        int    `json:"nays" validate:"min=0"`
 	Abstentions int    `json:"abstentions" validate:"min=0"`
@@ -263,6 +371,7 @@ type BillFilter struct {
 	SessionID      *uuid.UUID
 	TopicID        *uuid.UUID
 	AuthorID       *uuid.UUID
+	CommitteeID    *uuid.UUID
 	Search         *string
 	FiledAfter     *time.Time
 	FiledBefore    *time.Time
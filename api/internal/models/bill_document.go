@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bill document types
+const (
+	BillDocumentFiledCopy       = "filed_copy"
+	BillDocumentCommitteeReport = "committee_report"
+	BillDocumentEnrolledCopy    = "enrolled_copy"
+)
+
+// IsValidBillDocumentType reports whether t is one of the bill document types
+func IsValidBillDocumentType(t string) bool {
+	switch t {
+	case BillDocumentFiledCopy, BillDocumentCommitteeReport, BillDocumentEnrolledCopy:
+		return true
+	}
+	return false
+}
+
+// BillDocument is a PDF copy of a bill stored alongside it. ExtractionFailed
+// is set when no text could be read from the file, usually because it is a
+// scanned copy.
+type BillDocument struct {
+	ID               uuid.UUID  `json:"id"`
+	BillID           uuid.UUID  `json:"bill_id"`
+	DocumentType     string     `json:"document_type"`
+	FileURL          string     `json:"file_url"`
+	FileName         string     `json:"file_name"`
+	FileSize         int64      `json:"file_size"`
+	PageCount        int        `json:"page_count"`
+	ExtractionFailed bool       `json:"extraction_failed"`
+	UploadedBy       *uuid.UUID `json:"uploaded_by,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
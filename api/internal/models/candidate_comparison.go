@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxComparedCandidates is how many candidates one comparison can include
+const MaxComparedCandidates = 5
+
+// CandidateComparison sets candidates for one position side by side, in the
+// order they were asked for
+type CandidateComparison struct {
+	Position   ElectionPositionBrief `json:"position"`
+	Candidates []ComparedCandidate   `json:"candidates"`
+}
+
+// ComparedCandidate is one candidate in a comparison. Every field is present
+// for every candidate: issues is empty and voting_record null when there is
+// nothing on record.
+type ComparedCandidate struct {
+	ID             uuid.UUID               `json:"id"`
+	PoliticianID   uuid.UUID               `json:"politician_id"`
+	BallotNumber   *int                    `json:"ballot_number"`
+	BallotName     *string                 `json:"ballot_name"`
+	Status         string                  `json:"status"`
+	IsIncumbent    bool                    `json:"is_incumbent"`
+	CampaignSlogan *string                 `json:"campaign_slogan"`
+	Platform       *string                 `json:"platform"`
+	Issues         []string                `json:"issues"` // Issues the platform addresses
+	Politician     PoliticianListItem      `json:"politician"`
+	Party          *PartyBrief             `json:"party"`
+	VotingRecord   *PoliticianVotingRecord `json:"voting_record"` // Set when the politician has recorded bill votes
+}
+
+// ParseComparedCandidates reads a comma-separated list of candidate IDs,
+// dropping repeats
+func ParseComparedCandidates(list string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := uuid.Parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid candidate ID: %s", part)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if len(ids) < 2 {
+		return nil, fmt.Errorf("choose at least 2 candidates to compare")
+	}
+	if len(ids) > MaxComparedCandidates {
+		return nil, fmt.Errorf("at most %d candidates can be compared", MaxComparedCandidates)
+	}
+	return ids, nil
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseComparedCandidates(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	ids, err := ParseComparedCandidates(a.String() + ", " + b.String() + "," + a.String() + ",")
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{a, b}, ids)
+
+	_, err = ParseComparedCandidates(a.String() + "," + a.String())
+	assert.EqualError(t, err, "choose at least 2 candidates to compare")
+
+	_, err = ParseComparedCandidates(a.String() + ",nope")
+	assert.EqualError(t, err, "invalid candidate ID: nope")
+
+	many := ""
+	for i := 0; i <= MaxComparedCandidates; i++ {
+		many += uuid.NewString() + ","
+	}
+	_, err = ParseComparedCandidates(many)
+	assert.EqualError(t, err, "at most 5 candidates can be compared")
+}
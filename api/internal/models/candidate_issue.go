@@ -0,0 +1,41 @@
+package models
+
+import "github.com/google/uuid"
+
+// IssueMatrixEntry says whether one candidate's platform addresses an issue
+type IssueMatrixEntry struct {
+	CandidateID   uuid.UUID `json:"candidate_id"`
+	CandidateName string    `json:"candidate_name"`
+	HasIssue      bool      `json:"has_issue"`
+}
+
+// IssuesMatrix compares the candidates for a position issue by issue. Every
+// known issue is a key, each listing all the candidates in the same order.
+type IssuesMatrix map[string][]IssueMatrixEntry
+
+// CandidateIssues is a candidate with the issues their platform addresses
+type CandidateIssues struct {
+	CandidateID   uuid.UUID
+	CandidateName string
+	Issues        []string
+}
+
+// NewIssuesMatrix builds the matrix for the given issues from each
+// candidate's issues
+func NewIssuesMatrix(issues []string, candidates []CandidateIssues) IssuesMatrix {
+	matrix := make(IssuesMatrix, len(issues))
+	for _, issue := range issues {
+		entries := make([]IssueMatrixEntry, len(candidates))
+		for i, c := range candidates {
+			entries[i] = IssueMatrixEntry{CandidateID: c.CandidateID, CandidateName: c.CandidateName}
+			for _, ci := range c.Issues {
+				if ci == issue {
+					entries[i].HasIssue = true
+					break
+				}
+			}
+		}
+		matrix[issue] = entries
+	}
+	return matrix
+}
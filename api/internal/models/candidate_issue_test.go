@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIssuesMatrix(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	candidates := []CandidateIssues{
+		{CandidateID: a, CandidateName: "Ana", Issues: []string{"healthcare", "education"}},
+		{CandidateID: b, CandidateName: "Ben", Issues: nil},
+	}
+
+	matrix := NewIssuesMatrix([]string{"healthcare", "corruption"}, candidates)
+
+	assert.Len(t, matrix, 2)
+	assert.Equal(t, []IssueMatrixEntry{
+		{CandidateID: a, CandidateName: "Ana", HasIssue: true},
+		{CandidateID: b, CandidateName: "Ben", HasIssue: false},
+	}, matrix["healthcare"])
+	assert.Equal(t, []IssueMatrixEntry{
+		{CandidateID: a, CandidateName: "Ana", HasIssue: false},
+		{CandidateID: b, CandidateName: "Ben", HasIssue: false},
+	}, matrix["corruption"])
+
+	assert.Equal(t, []IssueMatrixEntry{}, NewIssuesMatrix([]string{"labor"}, nil)["labor"])
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SubstituteCandidateRequest replaces a candidate with another politician,
+// who takes over the position and ballot number. The substitute runs for the
+// replaced candidate's party unless PartyID says otherwise; a different party
+// needs an OverrideReason.
+type SubstituteCandidateRequest struct {
+	PoliticianID   uuid.UUID  `json:"politician_id" validate:"required"`
+	PartyID        *uuid.UUID `json:"party_id,omitempty"`
+	BallotName     *string    `json:"ballot_name,omitempty" validate:"omitempty,max=200"`
+	CampaignSlogan *string    `json:"campaign_slogan,omitempty" validate:"omitempty,max=500"`
+	Platform       *string    `json:"platform,omitempty"`
+	FilingDate     *string    `json:"filing_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	IsIncumbent    bool       `json:"is_incumbent"`
+	OverrideReason *string    `json:"override_reason,omitempty" validate:"omitempty,max=1000"`
+}
+
+// IsWithdrawnCandidate reports whether a candidate has dropped out. They
+// stay named on a printed ballot but can't win.
+func IsWithdrawnCandidate(status string) bool {
+	return status == CandidateStatusWithdrawn || status == CandidateStatusSubstituted
+}
+
+// SubstituteParty returns the party the substitute runs for and checks it
+// against COMELEC rules: only a candidate of a party can be substituted, and
+// only by a member of the same party. An admin can allow other cases by
+// giving a reason.
+func (req *SubstituteCandidateRequest) SubstituteParty(original *Candidate) (*uuid.UUID, error) {
+	if original.Status == CandidateStatusSubstituted {
+		return nil, fmt.Errorf("candidate has already been substituted")
+	}
+	if original.PoliticianID == req.PoliticianID {
+		return nil, fmt.Errorf("a candidate can't substitute themselves")
+	}
+
+	partyID := original.PartyID
+	if req.PartyID != nil {
+		partyID = req.PartyID
+	}
+
+	overridden := req.OverrideReason != nil && strings.TrimSpace(*req.OverrideReason) != ""
+	if original.PartyID == nil && !overridden {
+		return nil, fmt.Errorf("independent candidates can't be substituted without an override reason")
+	}
+	if original.PartyID != nil && (partyID == nil || *partyID != *original.PartyID) && !overridden {
+		return nil, fmt.Errorf("substitute must be from the same party without an override reason")
+	}
+
+	return partyID, nil
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstituteParty(t *testing.T) {
+	party, other := uuid.New(), uuid.New()
+	original := &Candidate{PoliticianID: uuid.New(), PartyID: &party, Status: CandidateStatusWithdrawn}
+	reason := "Party coalition agreement"
+
+	req := &SubstituteCandidateRequest{PoliticianID: uuid.New()}
+	got, err := req.SubstituteParty(original)
+	require.NoError(t, err)
+	assert.Equal(t, party, *got, "substitute inherits the party")
+
+	req.PartyID = &other
+	_, err = req.SubstituteParty(original)
+	assert.EqualError(t, err, "substitute must be from the same party without an override reason")
+
+	req.OverrideReason = &reason
+	got, err = req.SubstituteParty(original)
+	require.NoError(t, err)
+	assert.Equal(t, other, *got)
+
+	independent := &Candidate{PoliticianID: uuid.New(), Status: CandidateStatusFiled}
+	_, err = (&SubstituteCandidateRequest{PoliticianID: uuid.New()}).SubstituteParty(independent)
+	assert.EqualError(t, err, "independent candidates can't be substituted without an override reason")
+
+	_, err = (&SubstituteCandidateRequest{PoliticianID: original.PoliticianID}).SubstituteParty(original)
+	assert.EqualError(t, err, "a candidate can't substitute themselves")
+
+	original.Status = CandidateStatusSubstituted
+	_, err = (&SubstituteCandidateRequest{PoliticianID: uuid.New()}).SubstituteParty(original)
+	assert.EqualError(t, err, "candidate has already been substituted")
+}
+
+func TestIsWithdrawnCandidate(t *testing.T) {
+	assert.True(t, IsWithdrawnCandidate(CandidateStatusWithdrawn))
+	assert.True(t, IsWithdrawnCandidate(CandidateStatusSubstituted))
+	assert.False(t, IsWithdrawnCandidate(CandidateStatusQualified))
+	assert.False(t, IsWithdrawnCandidate(CandidateStatusDisqualified))
+}
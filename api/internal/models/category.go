@@ -7,30 +7,56 @@ import (
 )
 
 type Category struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	Description *string   `json:"description,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description *string    `json:"description,omitempty"`
+	IsInternal  bool       `json:"is_internal"` // Articles in internal categories never appear publicly
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"` // Admin list with include_deleted only
 }
 
 type CreateCategoryRequest struct {
 	Name        string  `json:"name" validate:"required,min=2,max=100"`
 	Slug        string  `json:"slug" validate:"required,min=2,max=100"`
 	Description *string `json:"description,omitempty"`
+	IsInternal  bool    `json:"is_internal"`
 }
 
 type UpdateCategoryRequest struct {
 	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
 	Slug        *string `json:"slug,omitempty" validate:"omitempty,min=2,max=100"`
 	Description *string `json:"description,omitempty"`
+	IsInternal  *bool   `json:"is_internal,omitempty"`
+}
+
+// MigrateArticlesRequest moves a category's articles into another category,
+// optionally only those tagged with any of Filter.TagIDs
+type MigrateArticlesRequest struct {
+	TargetCategoryID uuid.UUID              `json:"target_category_id" validate:"required"`
+	Filter           *MigrateArticlesFilter `json:"filter,omitempty"`
+	DeleteSource     bool                   `json:"delete_source"` // Soft-delete the source once it has no articles left
+	// Confirm moving published articles into an internal category, which
+	// takes them away from readers
+	Confirm bool `json:"confirm,omitempty"`
+}
+
+type MigrateArticlesFilter struct {
+	TagIDs []uuid.UUID `json:"tag_ids,omitempty"`
+}
+
+type MigrateArticlesResult struct {
+	Migrated      int  `json:"migrated"`
+	Remaining     int  `json:"remaining"` // Articles still in the source category
+	SourceDeleted bool `json:"source_deleted"`
 }
 
 type CategoryFilter struct {
-	Search    *string
-	SortBy    *string // name, created_at
-	SortOrder *string // asc, desc
+	Search         *string
+	SortBy         *string // name, created_at
+	SortOrder      *string // asc, desc
+	IncludeDeleted bool
 }
 
 type PaginatedCategories struct {
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +17,30 @@ const (
 	CommentStatusHidden      CommentStatus = "hidden"
 )
 
+// CommentSort is the order of an article's comments
+type CommentSort string
+
+const (
+	CommentSortNewest CommentSort = "newest"
+	CommentSortOldest CommentSort = "oldest"
+	CommentSortTop    CommentSort = "top" // Most reactions first
+
+	// Admin moderation list only: likeliest abuse first, unscored last
+	CommentSortToxicity CommentSort = "toxicity"
+)
+
+// ParseCommentSort validates a sort query value. An empty value sorts newest first.
+func ParseCommentSort(value string) (CommentSort, error) {
+	switch sort := CommentSort(value); sort {
+	case "":
+		return CommentSortNewest, nil
+	case CommentSortNewest, CommentSortOldest, CommentSortTop:
+		return sort, nil
+	default:
+		return "", fmt.Errorf("sort must be one of newest, oldest, top")
+	}
+}
+
 // Comment represents a comment on an article
 type Comment struct {
 	ID        uuid.UUID     `json:"id"`
@@ -33,6 +58,16 @@ type Comment struct {
 	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
 	ModerationReason *string    `json:"moderation_reason,omitempty"`
 
+	// Keyword sentiment from -1 (very negative) to +1, set on the admin
+	// moderation list
+	SentimentScore *float64 `json:"sentiment_score,omitempty"`
+
+	// Likelihood the comment is abusive, from 0 to 1, and the scorer that
+	// rated it; set on the admin moderation list. A nil score means the
+	// comment hasn't been scored yet.
+	ToxicityScore    *float64 `json:"toxicity_score,omitempty"`
+	ToxicityProvider *string  `json:"toxicity_provider,omitempty"`
+
 	// Relations (populated when needed)
 	Author    *CommentAuthor    `json:"author,omitempty"` // User info displayed as "author" in JSON for frontend compatibility
 	Replies   []Comment         `json:"replies,omitempty"`
@@ -70,15 +105,34 @@ type ReactionSummary struct {
 
 // CommentMention represents a @mention in a comment
 type CommentMention struct {
-	ID                uuid.UUID `json:"id"`
-	CommentID         uuid.UUID `json:"comment_id"`
-	MentionedAuthorID uuid.UUID `json:"mentioned_author_id"`
-	CreatedAt         time.Time `json:"created_at"`
+	ID                uuid.UUID  `json:"id"`
+	CommentID         uuid.UUID  `json:"comment_id"`
+	MentionedAuthorID *uuid.UUID `json:"mentioned_author_id,omitempty"`
+	MentionedUserID   *uuid.UUID `json:"mentioned_user_id,omitempty"`
+	ReadAt            *time.Time `json:"read_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
 
 	// Populated when needed
 	MentionedAuthor *CommentAuthor `json:"mentioned_author,omitempty"`
 }
 
+// CommentEventType identifies a live comment update pushed to article readers
+type CommentEventType string
+
+const (
+	CommentEventCreated CommentEventType = "comment.created"
+	CommentEventUpdated CommentEventType = "comment.updated"
+	CommentEventRemoved CommentEventType = "comment.removed"
+)
+
+// CommentEvent is a live comment update. Comment is set for created/updated
+// events; removed events only carry the ID.
+type CommentEvent struct {
+	Type      CommentEventType `json:"type"`
+	CommentID uuid.UUID        `json:"comment_id"`
+	Comment   *Comment         `json:"comment,omitempty"`
+}
+
 // CreateCommentRequest is the request body for creating a comment
 type CreateCommentRequest struct {
 	Content  string  `json:"content" validate:"required,min=1,max=10000"`
@@ -108,7 +162,26 @@ type CommentFilter struct {
 	ParentID       *uuid.UUID // NULL to get only root comments
 	Status         *CommentStatus
 	IncludeDeleted bool
-	IncludeHidden  bool // Admin-only: include hidden/spam comments
+	IncludeHidden  bool     // Admin-only: include hidden/spam comments
+	SentimentLt    *float64 // Admin-only: comments scored below this sentiment
+	MinToxicity    *float64 // Admin-only: comments scored at or above this toxicity
+	Unscored       bool     // Admin-only: comments without a toxicity score
+	Sort           CommentSort
+}
+
+// CommentSentiment is the average sentiment of an article's visible comments.
+// AverageScore is nil when none of them has been scored.
+type CommentSentiment struct {
+	ArticleID      uuid.UUID `json:"article_id"`
+	AverageScore   *float64  `json:"average_score"`
+	ScoredComments int       `json:"scored_comments"`
+}
+
+// SentimentWord is an entry in the comment sentiment word list
+type SentimentWord struct {
+	Word     string
+	Weight   float64
+	Language string // en, fil
 }
 
 // PaginatedComments for paginated comment responses
@@ -120,6 +193,33 @@ type PaginatedComments struct {
 	TotalPages int       `json:"total_pages"`
 }
 
+// MentionArticle is the article a mentioning comment was posted on
+type MentionArticle struct {
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+	Slug  string    `json:"slug"`
+}
+
+// UserMention is an entry in a user's "mentions of me" inbox
+type UserMention struct {
+	ID          uuid.UUID      `json:"id"`
+	IsRead      bool           `json:"is_read"`
+	ReadAt      *time.Time     `json:"read_at,omitempty"`
+	MentionedAt time.Time      `json:"mentioned_at"`
+	Comment     Comment        `json:"comment"`
+	Article     MentionArticle `json:"article"`
+}
+
+// PaginatedMentions for the mentions inbox
+type PaginatedMentions struct {
+	Mentions    []UserMention `json:"mentions"`
+	Total       int           `json:"total"`
+	UnreadCount int           `json:"unread_count"`
+	Page        int           `json:"page"`
+	PerPage     int           `json:"per_page"`
+	TotalPages  int           `json:"total_pages"`
+}
+
 // ReplyPreview shows a preview of replies for collapsed view
 type ReplyPreview struct {
 	Count   int             `json:"count"`
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DateLayout is how a Date is written in JSON and accepted in requests
+const DateLayout = "2006-01-02"
+
+// Date is a calendar day with no time of day or timezone, such as an
+// election day or the day a bill was filed. It is stored as midnight UTC so
+// that it never moves to the day before or after when it is formatted, and
+// it reads and writes Postgres DATE columns and "YYYY-MM-DD" JSON strings.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the given calendar day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the calendar day t falls on in loc. A nil loc means UTC.
+func DateOf(t time.Time, loc *time.Location) Date {
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, d := t.In(loc).Date()
+	return NewDate(y, m, d)
+}
+
+// ParseDate reads a "YYYY-MM-DD" date
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	return Date{t}, nil
+}
+
+// ParseDatePtr reads an optional "YYYY-MM-DD" date. Nil stays nil.
+func ParseDatePtr(s *string) (*Date, error) {
+	if s == nil {
+		return nil, nil
+	}
+	d, err := ParseDate(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// String returns the date as YYYY-MM-DD
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+// Before reports whether d is an earlier day than o
+func (d Date) Before(o Date) bool {
+	return d.Time.Before(o.Time)
+}
+
+// After reports whether d is a later day than o
+func (d Date) After(o Date) bool {
+	return d.Time.After(o.Time)
+}
+
+// Equal reports whether d and o are the same day
+func (d Date) Equal(o Date) bool {
+	return d.Time.Equal(o.Time)
+}
+
+// AddDays returns the day n days after d, or before it when n is negative
+func (d Date) AddDays(n int) Date {
+	return Date{d.AddDate(0, 0, n)}
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON reads a "YYYY-MM-DD" string. Null leaves the date unchanged.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("invalid date %s, expected a YYYY-MM-DD string", data)
+	}
+	parsed, err := ParseDate(string(data[1 : len(data)-1]))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(data []byte) error {
+	parsed, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// ScanDate implements pgtype.DateScanner. A NULL scans as the zero Date;
+// scan nullable columns into a *Date instead.
+func (d *Date) ScanDate(v pgtype.Date) error {
+	if !v.Valid {
+		*d = Date{}
+		return nil
+	}
+	if v.InfinityModifier != pgtype.Finite {
+		return fmt.Errorf("cannot scan infinite date into Date")
+	}
+	*d = DateOf(v.Time, time.UTC)
+	return nil
+}
+
+// DateValue implements pgtype.DateValuer
+func (d Date) DateValue() (pgtype.Date, error) {
+	return pgtype.Date{Time: d.Time, Valid: true}, nil
+}
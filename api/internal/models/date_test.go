@@ -0,0 +1,111 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateOf(t *testing.T) {
+	manila, err := time.LoadLocation("Asia/Manila")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		loc  *time.Location
+		want Date
+	}{
+		// 2025-05-11T16:30Z is 00:30 on election day in Manila (+08:00)
+		{"after midnight in Manila", time.Date(2025, 5, 11, 16, 30, 0, 0, time.UTC), manila, NewDate(2025, 5, 12)},
+		{"before midnight in Manila", time.Date(2025, 5, 11, 15, 59, 59, 0, time.UTC), manila, NewDate(2025, 5, 11)},
+		{"same instant in UTC", time.Date(2025, 5, 11, 16, 30, 0, 0, time.UTC), time.UTC, NewDate(2025, 5, 11)},
+		{"new year in Manila", time.Date(2024, 12, 31, 16, 0, 0, 0, time.UTC), manila, NewDate(2025, 1, 1)},
+		{"nil location is UTC", time.Date(2024, 12, 31, 16, 0, 0, 0, time.UTC), nil, NewDate(2024, 12, 31)},
+		{"time already in Manila", time.Date(2025, 5, 12, 0, 30, 0, 0, manila), manila, NewDate(2025, 5, 12)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DateOf(tt.t, tt.loc)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+			assert.Equal(t, time.UTC, got.Location())
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	d, err := ParseDate("2025-05-12")
+	require.NoError(t, err)
+	assert.Equal(t, "2025-05-12", d.String())
+
+	for _, s := range []string{"", "2025-5-12", "12/05/2025", "2025-05-12T00:00:00+08:00", "2025-02-30"} {
+		_, err := ParseDate(s)
+		assert.Error(t, err, s)
+	}
+
+	p, err := ParseDatePtr(nil)
+	require.NoError(t, err)
+	assert.Nil(t, p)
+}
+
+func TestDateJSON(t *testing.T) {
+	type item struct {
+		Day      Date  `json:"day"`
+		Optional *Date `json:"optional,omitempty"`
+	}
+
+	manila, err := time.LoadLocation("Asia/Manila")
+	require.NoError(t, err)
+	// Election day as read late at night UTC must not turn into the day
+	// before, and must not carry a timezone
+	in := item{Day: DateOf(time.Date(2025, 5, 11, 16, 30, 0, 0, time.UTC), manila)}
+
+	data, err := json.Marshal(in)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"day":"2025-05-12"}`, string(data))
+
+	var out item
+	require.NoError(t, json.Unmarshal([]byte(`{"day":"2025-05-12","optional":"2025-02-13"}`), &out))
+	assert.Equal(t, "2025-05-12", out.Day.String())
+	require.NotNil(t, out.Optional)
+	assert.Equal(t, "2025-02-13", out.Optional.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"day":"2025-05-12T00:00:00+08:00"}`), &out))
+	assert.Error(t, json.Unmarshal([]byte(`{"day":20250512}`), &out))
+}
+
+func TestDatePostgres(t *testing.T) {
+	m := pgtype.NewMap()
+	want := NewDate(2025, 5, 12)
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.DateOID, format, want, nil)
+		require.NoError(t, err)
+
+		var got Date
+		require.NoError(t, m.Scan(pgtype.DateOID, format, buf, &got))
+		assert.True(t, want.Equal(got), "got %s", got)
+
+		var ptr *Date
+		require.NoError(t, m.Scan(pgtype.DateOID, format, buf, &ptr))
+		require.NotNil(t, ptr)
+		assert.True(t, want.Equal(*ptr), "got %s", ptr)
+
+		ptr = &want
+		require.NoError(t, m.Scan(pgtype.DateOID, format, nil, &ptr))
+		assert.Nil(t, ptr)
+	}
+}
+
+func TestDateCompare(t *testing.T) {
+	d := NewDate(2025, 5, 12)
+	assert.True(t, d.Before(d.AddDays(1)))
+	assert.True(t, d.After(d.AddDays(-1)))
+	assert.True(t, d.Equal(NewDate(2025, 5, 12)))
+	assert.Equal(t, "2025-06-01", NewDate(2025, 5, 31).AddDays(1).String())
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Platforms a device token can be registered from
+const (
+	DevicePlatformIOS     = "ios"
+	DevicePlatformAndroid = "android"
+	DevicePlatformWeb     = "web"
+)
+
+// DeviceToken is a Firebase Cloud Messaging registration token for one app
+// install, used to send its user push notifications
+type DeviceToken struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Token      string    `json:"token"`
+	Platform   string    `json:"platform"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+type RegisterDeviceTokenRequest struct {
+	Token    string `json:"token" validate:"required,max=4096"`
+	Platform string `json:"platform" validate:"required,oneof=ios android web"`
+}
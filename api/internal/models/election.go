@@ -40,23 +40,26 @@ type Election struct {
 	Slug                   string     `json:"slug"`
 	ElectionType           string     `json:"election_type"`
 	Description            *string    `json:"description,omitempty"`
-	ElectionDate           time.Time  `json:"election_date"`
-	RegistrationStart      *time.Time `json:"registration_start,omitempty"`
-	RegistrationEnd        *time.Time `json:"registration_end,omitempty"`
-	CampaignStart          *time.Time `json:"campaign_start,omitempty"`
-	CampaignEnd            *time.Time `json:"campaign_end,omitempty"`
+	ElectionDate           Date       `json:"election_date"`
+	RegistrationStart      *Date      `json:"registration_start,omitempty"`
+	RegistrationEnd        *Date      `json:"registration_end,omitempty"`
+	CampaignStart          *Date      `json:"campaign_start,omitempty"`
+	CampaignEnd            *Date      `json:"campaign_end,omitempty"`
 	Status                 string     `json:"status"`
 	IsFeatured             bool       `json:"is_featured"`
 	VoterTurnoutPercentage *float64   `json:"voter_turnout_percentage,omitempty"`
 	TotalRegisteredVoters  *int       `json:"total_registered_voters,omitempty"`
 	TotalVotesCast         *int       `json:"total_votes_cast,omitempty"`
+	DataAsOf               time.Time  `json:"data_as_of"` // When turnout and candidate tallies were last brought up to date
+	IsStale                bool       `json:"is_stale"`   // Computed against the configured threshold
 	CreatedAt              time.Time  `json:"created_at"`
 	UpdatedAt              time.Time  `json:"updated_at"`
 	DeletedAt              *time.Time `json:"deleted_at,omitempty"`
 
 	// Joined fields
-	Positions  []ElectionPositionListItem `json:"positions,omitempty"`
-	Candidates []CandidateListItem        `json:"candidates,omitempty"`
+	Positions    []ElectionPositionListItem `json:"positions,omitempty"`
+	BallotGroups []BallotSection            `json:"ballot_groups,omitempty"`
+	Candidates   []CandidateListItem        `json:"candidates,omitempty"`
 }
 
 type ElectionListItem struct {
@@ -64,7 +67,7 @@ type ElectionListItem struct {
 	Name                   string    `json:"name"`
 	Slug                   string    `json:"slug"`
 	ElectionType           string    `json:"election_type"`
-	ElectionDate           time.Time `json:"election_date"`
+	ElectionDate           Date      `json:"election_date"`
 	Status                 string    `json:"status"`
 	IsFeatured             bool      `json:"is_featured"`
 	VoterTurnoutPercentage *float64  `json:"voter_turnout_percentage,omitempty"`
@@ -84,6 +87,8 @@ type ElectionPosition struct {
 	DistrictID         *uuid.UUID `json:"district_id,omitempty"`
 	SeatsAvailable     int        `json:"seats_available"`
 	Description        *string    `json:"description,omitempty"`
+	BallotGroup        string     `json:"ballot_group"`
+	BallotOrder        int        `json:"ballot_order"`
 	CreatedAt          time.Time  `json:"created_at"`
 
 	// Joined fields
@@ -96,6 +101,8 @@ type ElectionPositionListItem struct {
 	ID             uuid.UUID               `json:"id"`
 	PositionID     uuid.UUID               `json:"position_id"`
 	SeatsAvailable int                     `json:"seats_available"`
+	BallotGroup    string                  `json:"ballot_group"`
+	BallotOrder    int                     `json:"ballot_order"`
 	Position       *GovernmentPositionInfo `json:"position,omitempty"`
 	Location       *string                 `json:"location,omitempty"`
 	CandidateCount int                     `json:"candidate_count"`
@@ -112,11 +119,13 @@ type Candidate struct {
 	CampaignSlogan     *string    `json:"campaign_slogan,omitempty"`
 	Platform           *string    `json:"platform,omitempty"`
 	Status             string     `json:"status"`
-	FilingDate         *time.Time `json:"filing_date,omitempty"`
+	FilingDate         *Date      `json:"filing_date,omitempty"`
 	IsIncumbent        bool       `json:"is_incumbent"`
 	IsWinner           bool       `json:"is_winner"`
 	VotesReceived      *int       `json:"votes_received,omitempty"`
 	VotePercentage     *float64   `json:"vote_percentage,omitempty"`
+	SubstitutedBy      *uuid.UUID `json:"substituted_by,omitempty"`      // The candidate who replaced this one
+	SubstitutionReason *string    `json:"substitution_reason,omitempty"` // Why a substitute from another party was allowed
 	CreatedAt          time.Time  `json:"created_at"`
 	UpdatedAt          time.Time  `json:"updated_at"`
 
@@ -135,6 +144,8 @@ type CandidateListItem struct {
 	IsWinner       bool                `json:"is_winner"`
 	VotesReceived  *int                `json:"votes_received,omitempty"`
 	VotePercentage *float64            `json:"vote_percentage,omitempty"`
+	Withdrawn      bool                `json:"withdrawn"` // Withdrawn or substituted, but still named on the ballot
+	SubstitutedBy  *uuid.UUID          `json:"substituted_by,omitempty"`
 	Politician     *PoliticianListItem `json:"politician,omitempty"`
 	Party          *PartyBrief         `json:"party,omitempty"`
 }
@@ -203,29 +214,30 @@ type CreateElectionRequest struct {
 	Slug              string  `json:"slug" validate:"required,max=300"`
 	ElectionType      string  `json:"election_type" validate:"required,oneof=national local barangay special plebiscite recall"`
 	Description       *string `json:"description,omitempty"`
-	ElectionDate      string  `json:"election_date" validate:"required"` // YYYY-MM-DD
-	RegistrationStart *string `json:"registration_start,omitempty"`      // YYYY-MM-DD
-	RegistrationEnd   *string `json:"registration_end,omitempty"`        // YYYY-MM-DD
-	CampaignStart     *string `json:"campaign_start,omitempty"`          // YYYY-MM-DD
-	CampaignEnd       *string `json:"campaign_end,omitempty"`            // YYYY-MM-DD
+	ElectionDate      string  `json:"election_date" validate:"required,datetime=2006-01-02"`
+	RegistrationStart *string `json:"registration_start,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	RegistrationEnd   *string `json:"registration_end,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	CampaignStart     *string `json:"campaign_start,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	CampaignEnd       *string `json:"campaign_end,omitempty" validate:"omitempty,datetime=2006-01-02"`
 	Status            string  `json:"status" validate:"required,oneof=upcoming ongoing completed cancelled"`
 	IsFeatured        bool    `json:"is_featured"`
 }
 
 type UpdateElectionRequest struct {
-	Name                   *string  `json:"name,omitempty" validate:"omitempty,max=300"`
-	Slug                   *string  `json:"slug,omitempty" validate:"omitempty,max=300"`
-	Description            *string  `json:"description,omitempty"`
-	ElectionDate           *string  `json:"election_date,omitempty"`      // YYYY-MM-DD
-	RegistrationStart      *string  `json:"registration_start,omitempty"` // YYYY-MM-DD
-	RegistrationEnd        *string  `json:"registration_end,omitempty"`   // YYYY-MM-DD
-	CampaignStart          *string  `json:"campaign_start,omitempty"`     // YYYY-MM-DD
-	CampaignEnd            *string  `json:"campaign_end,omitempty"`       // YYYY-MM-DD
-	Status                 *string  `json:"status,omitempty" validate:"omitempty,oneof=upcoming ongoing completed cancelled"`
-	IsFeatured             *bool    `json:"is_featured,omitempty"`
-	VoterTurnoutPercentage *float64 `json:"voter_turnout_percentage,omitempty"`
-	TotalRegisteredVoters  *int     `json:"total_registered_voters,omitempty"`
-	TotalVotesCast         *int     `json:"total_votes_cast,omitempty"`
+	Name                   *string    `json:"name,omitempty" validate:"omitempty,max=300"`
+	Slug                   *string    `json:"slug,omitempty" validate:"omitempty,max=300"`
+	Description            *string    `json:"description,omitempty"`
+	ElectionDate           *string    `json:"election_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	RegistrationStart      *string    `json:"registration_start,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	RegistrationEnd        *string    `json:"registration_end,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	CampaignStart          *string    `json:"campaign_start,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	CampaignEnd            *string    `json:"campaign_end,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Status                 *string    `json:"status,omitempty" validate:"omitempty,oneof=upcoming ongoing completed cancelled"`
+	IsFeatured             *bool      `json:"is_featured,omitempty"`
+	VoterTurnoutPercentage *float64   `json:"voter_turnout_percentage,omitempty"`
+	TotalRegisteredVoters  *int       `json:"total_registered_voters,omitempty"`
+	TotalVotesCast         *int       `json:"total_votes_cast,omitempty"`
+	DataAsOf               *time.Time `json:"data_as_of,omitempty"` // Set by hand after a bulk import session
 }
 
 type CreateElectionPositionRequest struct {
@@ -238,6 +250,14 @@ type CreateElectionPositionRequest struct {
 	DistrictID         *uuid.UUID `json:"district_id,omitempty"`
 	SeatsAvailable     int        `json:"seats_available" validate:"min=1"`
 	Description        *string    `json:"description,omitempty"`
+	// Defaults to the section matching the position's government level
+	BallotGroup *string `json:"ballot_group,omitempty" validate:"omitempty,oneof=national regional provincial city barangay"`
+}
+
+// ReorderElectionPositionsRequest lists every position of an election in
+// ballot order. Only the relative order within each ballot group is used.
+type ReorderElectionPositionsRequest struct {
+	PositionIDs []uuid.UUID `json:"position_ids" validate:"required,min=1"`
 }
 
 type CreateCandidateRequest struct {
@@ -249,7 +269,7 @@ type CreateCandidateRequest struct {
 	CampaignSlogan     *string    `json:"campaign_slogan,omitempty" validate:"omitempty,max=500"`
 	Platform           *string    `json:"platform,omitempty"`
 	Status             string     `json:"status" validate:"required,oneof=filed qualified disqualified withdrawn substituted"`
-	FilingDate         *string    `json:"filing_date,omitempty"` // YYYY-MM-DD
+	FilingDate         *string    `json:"filing_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
 	IsIncumbent        bool       `json:"is_incumbent"`
 }
 
@@ -340,6 +360,6 @@ type ElectionCalendarItem struct {
 	Name         string    `json:"name"`
 	Slug         string    `json:"slug"`
 	ElectionType string    `json:"election_type"`
-	ElectionDate time.Time `json:"election_date"`
+	ElectionDate Date      `json:"election_date"`
 	Status       string    `json:"status"`
 }
@@ -11,7 +11,7 @@ type ElectionEvent struct {
 	ID           uuid.UUID  `json:"id"`
 	Name         string     `json:"name"`
 	Description  *string    `json:"description,omitempty"`
-	ElectionDate time.Time  `json:"election_date"`
+	ElectionDate Date       `json:"election_date"`
 	Level        string     `json:"level"`  // 'national', 'local', 'barangay', 'regional'
 	Status       string     `json:"status"` // 'scheduled', 'in_progress', 'completed', 'cancelled'
 	CreatedAt    time.Time  `json:"created_at"`
@@ -27,7 +27,7 @@ type ElectionEvent struct {
 type ElectionEventListItem struct {
 	ID             uuid.UUID `json:"id"`
 	Name           string    `json:"name"`
-	ElectionDate   time.Time `json:"election_date"`
+	ElectionDate   Date      `json:"election_date"`
 	Level          string    `json:"level"`
 	Status         string    `json:"status"`
 	TotalPositions int       `json:"total_positions"`
@@ -36,19 +36,19 @@ type ElectionEventListItem struct {
 
 // CreateElectionEventRequest for creating an election
 type CreateElectionEventRequest struct {
-	Name         string    `json:"name" validate:"required,min=2,max=200"`
-	Description  *string   `json:"description,omitempty"`
-	ElectionDate time.Time `json:"election_date" validate:"required"`
-	Level        string    `json:"level" validate:"required,oneof=national local barangay regional provincial city municipal"`
+	Name         string  `json:"name" validate:"required,min=2,max=200"`
+	Description  *string `json:"description,omitempty"`
+	ElectionDate Date    `json:"election_date" validate:"required"`
+	Level        string  `json:"level" validate:"required,oneof=national local barangay regional provincial city municipal"`
 }
 
 // UpdateElectionEventRequest for updating an election
 type UpdateElectionEventRequest struct {
-	Name         *string    `json:"name,omitempty" validate:"omitempty,min=2,max=200"`
-	Description  *string    `json:"description,omitempty"`
-	ElectionDate *time.Time `json:"election_date,omitempty"`
-	Level        *string    `json:"level,omitempty" validate:"omitempty,oneof=national local barangay regional provincial city municipal"`
-	Status       *string    `json:"status,omitempty" validate:"omitempty,oneof=scheduled in_progress completed cancelled"`
+	Name         *string `json:"name,omitempty" validate:"omitempty,min=2,max=200"`
+	Description  *string `json:"description,omitempty"`
+	ElectionDate *Date   `json:"election_date,omitempty"`
+	Level        *string `json:"level,omitempty" validate:"omitempty,oneof=national local barangay regional provincial city municipal"`
+	Status       *string `json:"status,omitempty" validate:"omitempty,oneof=scheduled in_progress completed cancelled"`
 }
 
 // ProcessElectionResultsRequest for importing election results
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Levels election results are rolled up to
+const (
+	ResultLevelCity     = "city"
+	ResultLevelProvince = "province"
+	ResultLevelRegion   = "region"
+)
+
+// LocationResultRow is one row of a location results CSV: a candidate's votes
+// in a city or municipality, or in one of its barangays
+type LocationResultRow struct {
+	Row         int
+	CandidateID uuid.UUID
+	Location    string // City or municipality slug or PSGC code
+	Barangay    string // Barangay slug or PSGC code, empty for the whole city
+	Votes       int
+}
+
+// CandidateLocationResult is a validated row ready to be stored
+type CandidateLocationResult struct {
+	CandidateID        uuid.UUID
+	CityMunicipalityID uuid.UUID
+	BarangayID         *uuid.UUID
+	Votes              int
+}
+
+// LocationResultsImport reports a location results import. Nothing is
+// stored when there are errors.
+type LocationResultsImport struct {
+	TotalRows    int               `json:"total_rows"`
+	RowsImported int               `json:"rows_imported"`
+	Errors       []ValidationError `json:"errors,omitempty"`
+}
+
+// RollupFreshness says how current the precomputed results are. Pending is
+// set when results were imported after the last rollup.
+type RollupFreshness struct {
+	DataAsOf *time.Time `json:"data_as_of,omitempty"`
+	Pending  bool       `json:"pending"`
+}
+
+// ElectionPositionBrief names an election position
+type ElectionPositionBrief struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// ElectionResultsMap is the leading candidate in each province and region
+// for one position, for a choropleth map
+type ElectionResultsMap struct {
+	ElectionID   uuid.UUID `json:"election_id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	ElectionDate Date      `json:"election_date"`
+	RollupFreshness
+
+	Position  *ElectionPositionBrief  `json:"position,omitempty"`
+	Positions []ElectionPositionBrief `json:"positions"` // Positions with location results
+	Provinces []LocationLeader        `json:"provinces"`
+	Regions   []LocationLeader        `json:"regions"`
+}
+
+// LocationLeader is the leading candidate in one place, with the margin over
+// the runner-up
+type LocationLeader struct {
+	LocationID       uuid.UUID        `json:"location_id"`
+	Name             string           `json:"name"`
+	Slug             string           `json:"slug"`
+	TotalVotes       int              `json:"total_votes"`
+	Leader           *ResultCandidate `json:"leader,omitempty"`
+	RunnerUp         *ResultCandidate `json:"runner_up,omitempty"`
+	MarginVotes      *int             `json:"margin_votes,omitempty"`
+	MarginPercentage *float64         `json:"margin_percentage,omitempty"`
+}
+
+// ElectionCityResults is every position's results in one city or
+// municipality
+type ElectionCityResults struct {
+	ElectionID   uuid.UUID `json:"election_id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	ElectionDate Date      `json:"election_date"`
+	RollupFreshness
+
+	City      CityMunicipalityBrief `json:"city"`
+	Positions []CityPositionResult  `json:"positions"`
+}
+
+// CityMunicipalityBrief names a city or municipality and its province
+type CityMunicipalityBrief struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	ProvinceName string    `json:"province_name"`
+}
+
+// CityPositionResult is one position's candidates in a city, most votes first
+type CityPositionResult struct {
+	ElectionPositionID uuid.UUID         `json:"election_position_id"`
+	PositionName       string            `json:"position_name"`
+	TotalVotes         int               `json:"total_votes"`
+	Candidates         []ResultCandidate `json:"candidates"`
+}
+
+// ShareOfVotes is votes as a percentage of total, to two decimal places
+func ShareOfVotes(votes, total int) *float64 {
+	if total <= 0 {
+		return nil
+	}
+	pct := math.Round(float64(votes)*10000/float64(total)) / 100
+	return &pct
+}
+
+// SetLeaders fills in the leader, runner-up and margin from the top two
+// candidates, which must already have their percentages
+func (l *LocationLeader) SetLeaders(top []ResultCandidate) {
+	l.Leader, l.RunnerUp, l.MarginVotes, l.MarginPercentage = nil, nil, nil, nil
+	if len(top) == 0 {
+		return
+	}
+	leader := top[0]
+	l.Leader = &leader
+	if len(top) < 2 {
+		return
+	}
+
+	runnerUp := top[1]
+	l.RunnerUp = &runnerUp
+	margin := leader.Votes - runnerUp.Votes
+	l.MarginVotes = &margin
+	if leader.Percentage != nil && runnerUp.Percentage != nil {
+		pct := math.Round((*leader.Percentage-*runnerUp.Percentage)*100) / 100
+		l.MarginPercentage = &pct
+	}
+}
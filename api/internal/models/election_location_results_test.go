@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareOfVotes(t *testing.T) {
+	assert.Nil(t, ShareOfVotes(10, 0))
+	require.NotNil(t, ShareOfVotes(1, 3))
+	assert.Equal(t, 33.33, *ShareOfVotes(1, 3))
+	assert.Equal(t, 100.0, *ShareOfVotes(7, 7))
+}
+
+func TestLocationLeaderSetLeaders(t *testing.T) {
+	var l LocationLeader
+	l.SetLeaders(nil)
+	assert.Nil(t, l.Leader)
+	assert.Nil(t, l.MarginVotes)
+
+	top := rankedCandidates(600, 400)
+	top[0].Percentage, top[1].Percentage = ShareOfVotes(600, 1000), ShareOfVotes(400, 1000)
+	l.SetLeaders(top)
+	require.NotNil(t, l.Leader)
+	require.NotNil(t, l.RunnerUp)
+	assert.Equal(t, "A", l.Leader.Name)
+	assert.Equal(t, "B", l.RunnerUp.Name)
+	assert.Equal(t, 200, *l.MarginVotes)
+	assert.Equal(t, 20.0, *l.MarginPercentage)
+
+	// An uncontested place has a leader but no margin
+	l.SetLeaders(rankedCandidates(50))
+	assert.Equal(t, "A", l.Leader.Name)
+	assert.Nil(t, l.RunnerUp)
+	assert.Nil(t, l.MarginVotes)
+	assert.Nil(t, l.MarginPercentage)
+}
@@ -0,0 +1,167 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outcome of a position in an election results summary
+const (
+	PositionResultPending = "pending" // No votes have been tallied yet
+	PositionResultDecided = "decided"
+	PositionResultTied    = "tied" // Candidates tied for the last seat, all listed as winners
+)
+
+// ElectionResultsSummary lists the winners of each position in an election
+type ElectionResultsSummary struct {
+	ElectionID   uuid.UUID               `json:"election_id"`
+	Name         string                  `json:"name"`
+	Slug         string                  `json:"slug"`
+	Status       string                  `json:"status"`
+	ElectionDate Date                    `json:"election_date"`
+	DataAsOf     time.Time               `json:"data_as_of"`
+	Positions    []PositionResultSummary `json:"positions"`
+}
+
+// PositionResultSummary is the outcome of one contested position. The margin
+// is between the last winner and the runner-up.
+type PositionResultSummary struct {
+	ElectionPositionID uuid.UUID         `json:"election_position_id"`
+	PositionName       string            `json:"position_name"`
+	Location           *string           `json:"location,omitempty"`
+	SeatsAvailable     int               `json:"seats_available"`
+	Status             string            `json:"status"`
+	Winners            []ResultCandidate `json:"winners"`
+	RunnerUp           *ResultCandidate  `json:"runner_up,omitempty"`
+	MarginVotes        *int              `json:"margin_votes,omitempty"`
+	MarginPercentage   *float64          `json:"margin_percentage,omitempty"`
+}
+
+// ResultCandidate is a candidate's standing in a results summary
+type ResultCandidate struct {
+	CandidateID  uuid.UUID   `json:"candidate_id"`
+	PoliticianID uuid.UUID   `json:"politician_id"`
+	Name         string      `json:"name"`
+	Slug         string      `json:"slug"`
+	Photo        *string     `json:"photo,omitempty"`
+	Party        *PartyBrief `json:"party,omitempty"`
+	Votes        int         `json:"votes"`
+	Percentage   *float64    `json:"percentage,omitempty"`
+}
+
+// DecideResults fills in the winners, runner-up and margin from the
+// position's candidates, ranked by votes. Candidates tied with the last
+// winner all win, leaving the position tied.
+func (p *PositionResultSummary) DecideResults(ranked []ResultCandidate) {
+	p.Winners = []ResultCandidate{}
+	p.RunnerUp = nil
+	p.MarginVotes = nil
+	p.MarginPercentage = nil
+
+	if len(ranked) == 0 || ranked[0].Votes == 0 {
+		p.Status = PositionResultPending
+		return
+	}
+
+	seats := max(p.SeatsAvailable, 1)
+	n := min(seats, len(ranked))
+	for n < len(ranked) && ranked[n].Votes == ranked[n-1].Votes {
+		n++
+	}
+	p.Winners = append(p.Winners, ranked[:n]...)
+
+	p.Status = PositionResultDecided
+	if n > seats {
+		p.Status = PositionResultTied
+	}
+
+	if n == len(ranked) {
+		return
+	}
+	last, runnerUp := ranked[n-1], ranked[n]
+	p.RunnerUp = &runnerUp
+
+	margin := last.Votes - runnerUp.Votes
+	p.MarginVotes = &margin
+	if last.Percentage != nil && runnerUp.Percentage != nil {
+		pct := math.Round((*last.Percentage-*runnerUp.Percentage)*100) / 100
+		p.MarginPercentage = &pct
+	}
+}
+
+// ElectionCoalitionResults sums candidates and winners by the coalition their
+// party belonged to on election day
+type ElectionCoalitionResults struct {
+	ElectionID   uuid.UUID         `json:"election_id"`
+	Name         string            `json:"name"`
+	Slug         string            `json:"slug"`
+	Status       string            `json:"status"`
+	ElectionDate Date              `json:"election_date"`
+	DataAsOf     time.Time         `json:"data_as_of"`
+	Coalitions   []CoalitionResult `json:"coalitions"`
+}
+
+// CoalitionResult is one coalition's totals. Coalition is nil for the
+// parties that were in none.
+type CoalitionResult struct {
+	Coalition  *PartyBrief  `json:"coalition"`
+	Candidates int          `json:"candidates"`
+	Winners    int          `json:"winners"`
+	Parties    []PartyTally `json:"parties"`
+}
+
+// PartyTally is how many candidates a party fielded and how many won. A
+// coalition fielding candidates under its own name is counted as its own
+// member.
+type PartyTally struct {
+	Party      PartyBrief  `json:"party"`
+	Coalition  *PartyBrief `json:"-"`
+	Candidates int         `json:"candidates"`
+	Winners    int         `json:"winners"`
+}
+
+// GroupByCoalition totals party tallies by coalition, most winners first, with
+// the parties in no coalition last. Tallies keep their order within a group.
+func GroupByCoalition(tallies []PartyTally) []CoalitionResult {
+	results := []CoalitionResult{}
+	index := make(map[uuid.UUID]int)
+	var unaligned *CoalitionResult
+
+	for _, t := range tallies {
+		var group *CoalitionResult
+		if t.Coalition == nil {
+			if unaligned == nil {
+				unaligned = &CoalitionResult{}
+			}
+			group = unaligned
+		} else {
+			i, ok := index[t.Coalition.ID]
+			if !ok {
+				i = len(results)
+				index[t.Coalition.ID] = i
+				results = append(results, CoalitionResult{Coalition: t.Coalition})
+			}
+			group = &results[i]
+		}
+		group.Candidates += t.Candidates
+		group.Winners += t.Winners
+		group.Parties = append(group.Parties, t)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Winners != results[j].Winners {
+			return results[i].Winners > results[j].Winners
+		}
+		if results[i].Candidates != results[j].Candidates {
+			return results[i].Candidates > results[j].Candidates
+		}
+		return results[i].Coalition.Name < results[j].Coalition.Name
+	})
+	if unaligned != nil {
+		results = append(results, *unaligned)
+	}
+	return results
+}
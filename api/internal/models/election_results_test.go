@@ -0,0 +1,123 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rankedCandidates(votes ...int) []ResultCandidate {
+	ranked := make([]ResultCandidate, len(votes))
+	for i, v := range votes {
+		ranked[i] = ResultCandidate{Name: string(rune('A' + i)), Votes: v}
+	}
+	return ranked
+}
+
+func winnerNames(p PositionResultSummary) []string {
+	names := make([]string, len(p.Winners))
+	for i, w := range p.Winners {
+		names[i] = w.Name
+	}
+	return names
+}
+
+func TestDecideResultsSingleSeat(t *testing.T) {
+	ranked := rankedCandidates(500, 300, 100)
+	a, b := 50.0, 30.0
+	ranked[0].Percentage, ranked[1].Percentage = &a, &b
+
+	p := PositionResultSummary{SeatsAvailable: 1}
+	p.DecideResults(ranked)
+
+	assert.Equal(t, PositionResultDecided, p.Status)
+	assert.Equal(t, []string{"A"}, winnerNames(p))
+	require.NotNil(t, p.RunnerUp)
+	assert.Equal(t, "B", p.RunnerUp.Name)
+	assert.Equal(t, 200, *p.MarginVotes)
+	assert.Equal(t, 20.0, *p.MarginPercentage)
+}
+
+func TestDecideResultsMultipleSeats(t *testing.T) {
+	p := PositionResultSummary{SeatsAvailable: 2}
+	p.DecideResults(rankedCandidates(500, 400, 350, 10))
+
+	assert.Equal(t, PositionResultDecided, p.Status)
+	assert.Equal(t, []string{"A", "B"}, winnerNames(p))
+	assert.Equal(t, "C", p.RunnerUp.Name)
+	assert.Equal(t, 50, *p.MarginVotes)
+	assert.Nil(t, p.MarginPercentage)
+}
+
+func TestDecideResultsTieForLastSeat(t *testing.T) {
+	p := PositionResultSummary{SeatsAvailable: 2}
+	p.DecideResults(rankedCandidates(500, 400, 400, 100))
+
+	assert.Equal(t, PositionResultTied, p.Status)
+	assert.Equal(t, []string{"A", "B", "C"}, winnerNames(p))
+	assert.Equal(t, "D", p.RunnerUp.Name)
+	assert.Equal(t, 300, *p.MarginVotes)
+
+	// A tie between every candidate leaves no runner-up
+	p = PositionResultSummary{SeatsAvailable: 1}
+	p.DecideResults(rankedCandidates(100, 100))
+	assert.Equal(t, PositionResultTied, p.Status)
+	assert.Len(t, p.Winners, 2)
+	assert.Nil(t, p.RunnerUp)
+	assert.Nil(t, p.MarginVotes)
+}
+
+func TestDecideResultsPending(t *testing.T) {
+	for _, ranked := range [][]ResultCandidate{nil, rankedCandidates(0, 0)} {
+		p := PositionResultSummary{SeatsAvailable: 1}
+		p.DecideResults(ranked)
+
+		assert.Equal(t, PositionResultPending, p.Status)
+		assert.Empty(t, p.Winners)
+		assert.NotNil(t, p.Winners)
+		assert.Nil(t, p.RunnerUp)
+	}
+}
+
+func TestDecideResultsUncontested(t *testing.T) {
+	p := PositionResultSummary{SeatsAvailable: 3}
+	p.DecideResults(rankedCandidates(900, 800))
+
+	assert.Equal(t, PositionResultDecided, p.Status)
+	assert.Equal(t, []string{"A", "B"}, winnerNames(p))
+	assert.Nil(t, p.RunnerUp)
+}
+
+func TestGroupByCoalition(t *testing.T) {
+	ally := &PartyBrief{ID: uuid.New(), Name: "Alliance"}
+	bloc := &PartyBrief{ID: uuid.New(), Name: "Bloc"}
+	tallies := []PartyTally{
+		{Party: PartyBrief{Name: "A"}, Coalition: ally, Candidates: 5, Winners: 1},
+		{Party: PartyBrief{Name: "Independent"}, Candidates: 4, Winners: 2},
+		{Party: PartyBrief{Name: "B"}, Coalition: bloc, Candidates: 3, Winners: 2},
+		{Party: PartyBrief{Name: "C"}, Coalition: ally, Candidates: 2, Winners: 2},
+		{Party: *bloc, Coalition: bloc, Candidates: 1, Winners: 0},
+	}
+
+	results := GroupByCoalition(tallies)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "Alliance", results[0].Coalition.Name)
+	assert.Equal(t, 7, results[0].Candidates)
+	assert.Equal(t, 3, results[0].Winners)
+	assert.Len(t, results[0].Parties, 2)
+
+	assert.Equal(t, "Bloc", results[1].Coalition.Name)
+	assert.Equal(t, 4, results[1].Candidates)
+	assert.Equal(t, 2, results[1].Winners)
+
+	// Parties in no coalition come last even with more winners
+	assert.Nil(t, results[2].Coalition)
+	assert.Equal(t, 2, results[2].Winners)
+}
+
+func TestGroupByCoalitionEmpty(t *testing.T) {
+	assert.Equal(t, []CoalitionResult{}, GroupByCoalition(nil))
+}
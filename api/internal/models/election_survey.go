@@ -0,0 +1,217 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SurveyDateLayout is the format of survey fieldwork and publication dates
+const SurveyDateLayout = "2006-01-02"
+
+// ElectionSurvey is a pre-election survey of one contested position, as
+// published by a polling firm such as SWS or Pulse Asia
+type ElectionSurvey struct {
+	ID             uuid.UUID  `json:"id"`
+	ElectionID     uuid.UUID  `json:"election_id"`
+	PositionID     uuid.UUID  `json:"position_id"` // Election position surveyed
+	SurveyFirm     string     `json:"survey_firm"`
+	ConductedStart time.Time  `json:"conducted_start"`
+	ConductedEnd   time.Time  `json:"conducted_end"`
+	SampleSize     *int       `json:"sample_size,omitempty"`
+	MarginOfError  *float64   `json:"margin_of_error,omitempty"` // Percentage points, ±
+	PublishedAt    *time.Time `json:"published_at,omitempty"`
+	SourceURL      *string    `json:"source_url,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	// Joined fields
+	PositionName *string        `json:"position_name,omitempty"`
+	Results      []SurveyResult `json:"results"`
+}
+
+// SurveyResult is one candidate's standing in a survey
+type SurveyResult struct {
+	ID          uuid.UUID `json:"id"`
+	SurveyID    uuid.UUID `json:"survey_id"`
+	CandidateID uuid.UUID `json:"candidate_id"`
+	Percentage  float64   `json:"percentage"`
+	Rank        *int      `json:"rank,omitempty"`
+
+	// Percentage minus and plus the survey's margin of error, kept within 0-100
+	Low  *float64 `json:"low,omitempty"`
+	High *float64 `json:"high,omitempty"`
+
+	// Joined fields
+	CandidateName  string  `json:"candidate_name"`
+	PoliticianSlug string  `json:"politician_slug"`
+	PartyName      *string `json:"party_name,omitempty"`
+	PartyColor     *string `json:"party_color,omitempty"`
+}
+
+// SurveyTrend is the survey history of one election position, a series per
+// candidate in order of their latest standing
+type SurveyTrend struct {
+	ElectionID uuid.UUID               `json:"election_id"`
+	PositionID uuid.UUID               `json:"position_id"`
+	Surveys    int                     `json:"surveys"`
+	Series     []CandidateSurveySeries `json:"series"`
+}
+
+// CandidateSurveySeries is one candidate's results across surveys, oldest first
+type CandidateSurveySeries struct {
+	CandidateID    uuid.UUID          `json:"candidate_id"`
+	CandidateName  string             `json:"candidate_name"`
+	PoliticianSlug string             `json:"politician_slug"`
+	PartyName      *string            `json:"party_name,omitempty"`
+	PartyColor     *string            `json:"party_color,omitempty"`
+	Points         []SurveyTrendPoint `json:"points"`
+}
+
+// SurveyTrendPoint is a candidate's result in one survey. Date is the last
+// day of fieldwork.
+type SurveyTrendPoint struct {
+	SurveyID      uuid.UUID `json:"survey_id"`
+	SurveyFirm    string    `json:"survey_firm"`
+	Date          time.Time `json:"date"`
+	Percentage    float64   `json:"percentage"`
+	Rank          *int      `json:"rank,omitempty"`
+	MarginOfError *float64  `json:"margin_of_error,omitempty"`
+	Low           *float64  `json:"low,omitempty"`
+	High          *float64  `json:"high,omitempty"`
+}
+
+type SurveyResultInput struct {
+	CandidateID uuid.UUID `json:"candidate_id" validate:"required"`
+	Percentage  float64   `json:"percentage" validate:"gte=0,lte=100"`
+	Rank        *int      `json:"rank,omitempty" validate:"omitempty,min=1"`
+}
+
+type CreateElectionSurveyRequest struct {
+	PositionID     uuid.UUID           `json:"position_id" validate:"required"`
+	SurveyFirm     string              `json:"survey_firm" validate:"required,max=200"`
+	ConductedStart string              `json:"conducted_start" validate:"required"` // YYYY-MM-DD
+	ConductedEnd   string              `json:"conducted_end" validate:"required"`   // YYYY-MM-DD
+	SampleSize     *int                `json:"sample_size,omitempty" validate:"omitempty,min=1"`
+	MarginOfError  *float64            `json:"margin_of_error,omitempty" validate:"omitempty,gt=0,lt=100"`
+	PublishedAt    *string             `json:"published_at,omitempty"` // YYYY-MM-DD
+	SourceURL      *string             `json:"source_url,omitempty" validate:"omitempty,max=500,url"`
+	Results        []SurveyResultInput `json:"results" validate:"required,min=1,dive"`
+}
+
+// UpdateElectionSurveyRequest changes the given fields. Results, when given,
+// replace all of the survey's results.
+type UpdateElectionSurveyRequest struct {
+	PositionID     *uuid.UUID          `json:"position_id,omitempty"`
+	SurveyFirm     *string             `json:"survey_firm,omitempty" validate:"omitempty,max=200"`
+	ConductedStart *string             `json:"conducted_start,omitempty"` // YYYY-MM-DD
+	ConductedEnd   *string             `json:"conducted_end,omitempty"`   // YYYY-MM-DD
+	SampleSize     *int                `json:"sample_size,omitempty" validate:"omitempty,min=1"`
+	MarginOfError  *float64            `json:"margin_of_error,omitempty" validate:"omitempty,gt=0,lt=100"`
+	PublishedAt    *string             `json:"published_at,omitempty"` // YYYY-MM-DD
+	SourceURL      *string             `json:"source_url,omitempty" validate:"omitempty,max=500,url"`
+	Results        []SurveyResultInput `json:"results,omitempty" validate:"omitempty,min=1,dive"`
+}
+
+// AssignSurveyRanks fills in missing ranks from the percentages, highest
+// first. Candidates with the same percentage share a rank.
+func AssignSurveyRanks(results []SurveyResultInput) {
+	order := make([]int, len(results))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return results[order[a]].Percentage > results[order[b]].Percentage
+	})
+
+	for pos, i := range order {
+		if results[i].Rank != nil {
+			continue
+		}
+		rank := pos + 1
+		if pos > 0 && results[order[pos-1]].Percentage == results[i].Percentage {
+			// Share the rank of the candidate just ahead
+			prev := order[pos-1]
+			if results[prev].Rank != nil {
+				rank = *results[prev].Rank
+			}
+		}
+		results[i].Rank = &rank
+	}
+}
+
+// ApplyMarginOfError sets the low and high bounds of each result
+func (s *ElectionSurvey) ApplyMarginOfError() {
+	if s.MarginOfError == nil {
+		return
+	}
+	for i := range s.Results {
+		s.Results[i].Low, s.Results[i].High = marginBounds(s.Results[i].Percentage, *s.MarginOfError)
+	}
+}
+
+func marginBounds(percentage, margin float64) (*float64, *float64) {
+	low := math.Max(0, percentage-margin)
+	high := math.Min(100, percentage+margin)
+	return &low, &high
+}
+
+// BuildSurveyTrend turns a position's surveys into a series per candidate.
+// Points within a series are ordered by fieldwork end date, and series by
+// the candidate's percentage in their latest survey, highest first.
+func BuildSurveyTrend(electionID, positionID uuid.UUID, surveys []ElectionSurvey) *SurveyTrend {
+	ordered := make([]ElectionSurvey, len(surveys))
+	copy(ordered, surveys)
+	sort.SliceStable(ordered, func(a, b int) bool {
+		return ordered[a].ConductedEnd.Before(ordered[b].ConductedEnd)
+	})
+
+	trend := &SurveyTrend{
+		ElectionID: electionID,
+		PositionID: positionID,
+		Surveys:    len(ordered),
+		Series:     []CandidateSurveySeries{},
+	}
+
+	index := make(map[uuid.UUID]int)
+	for _, survey := range ordered {
+		for _, result := range survey.Results {
+			i, ok := index[result.CandidateID]
+			if !ok {
+				i = len(trend.Series)
+				index[result.CandidateID] = i
+				trend.Series = append(trend.Series, CandidateSurveySeries{
+					CandidateID:    result.CandidateID,
+					CandidateName:  result.CandidateName,
+					PoliticianSlug: result.PoliticianSlug,
+					PartyName:      result.PartyName,
+					PartyColor:     result.PartyColor,
+				})
+			}
+
+			point := SurveyTrendPoint{
+				SurveyID:      survey.ID,
+				SurveyFirm:    survey.SurveyFirm,
+				Date:          survey.ConductedEnd,
+				Percentage:    result.Percentage,
+				Rank:          result.Rank,
+				MarginOfError: survey.MarginOfError,
+			}
+			if survey.MarginOfError != nil {
+				point.Low, point.High = marginBounds(result.Percentage, *survey.MarginOfError)
+			}
+			trend.Series[i].Points = append(trend.Series[i].Points, point)
+		}
+	}
+
+	sort.SliceStable(trend.Series, func(a, b int) bool {
+		return latestPercentage(trend.Series[a]) > latestPercentage(trend.Series[b])
+	})
+
+	return trend
+}
+
+func latestPercentage(series CandidateSurveySeries) float64 {
+	return series.Points[len(series.Points)-1].Percentage
+}
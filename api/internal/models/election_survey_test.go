@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignSurveyRanks(t *testing.T) {
+	rank := func(n int) *int { return &n }
+	results := []SurveyResultInput{
+		{Percentage: 21.5},
+		{Percentage: 34},
+		{Percentage: 21.5},
+		{Percentage: 9},
+		{Percentage: 30, Rank: rank(1)}, // Given ranks are kept
+	}
+
+	AssignSurveyRanks(results)
+
+	var got []int
+	for _, r := range results {
+		require.NotNil(t, r.Rank)
+		got = append(got, *r.Rank)
+	}
+	assert.Equal(t, []int{3, 1, 3, 5, 1}, got)
+}
+
+func TestBuildSurveyTrend(t *testing.T) {
+	electionID, positionID := uuid.New(), uuid.New()
+	ana, ben := uuid.New(), uuid.New()
+	moe := 2.5
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	// Newest first, as listed
+	surveys := []ElectionSurvey{
+		{ID: uuid.New(), SurveyFirm: "Pulse Asia", ConductedEnd: day(20), MarginOfError: &moe, Results: []SurveyResult{
+			{CandidateID: ben, CandidateName: "Ben", Percentage: 40},
+			{CandidateID: ana, CandidateName: "Ana", Percentage: 1},
+		}},
+		{ID: uuid.New(), SurveyFirm: "SWS", ConductedEnd: day(5), Results: []SurveyResult{
+			{CandidateID: ana, CandidateName: "Ana", Percentage: 45},
+			{CandidateID: ben, CandidateName: "Ben", Percentage: 38},
+		}},
+	}
+
+	trend := BuildSurveyTrend(electionID, positionID, surveys)
+
+	assert.Equal(t, 2, trend.Surveys)
+	require.Len(t, trend.Series, 2)
+
+	// Ben leads the latest survey, so his series comes first
+	assert.Equal(t, ben, trend.Series[0].CandidateID)
+	require.Len(t, trend.Series[0].Points, 2)
+	assert.Equal(t, "SWS", trend.Series[0].Points[0].SurveyFirm)
+	assert.Nil(t, trend.Series[0].Points[0].Low)
+	assert.Equal(t, day(20), trend.Series[0].Points[1].Date)
+	assert.Equal(t, 37.5, *trend.Series[0].Points[1].Low)
+	assert.Equal(t, 42.5, *trend.Series[0].Points[1].High)
+
+	// Bounds stay within 0-100
+	assert.Equal(t, 0.0, *trend.Series[1].Points[1].Low)
+	assert.Equal(t, 3.5, *trend.Series[1].Points[1].High)
+}
+
+func TestBuildSurveyTrendEmpty(t *testing.T) {
+	trend := BuildSurveyTrend(uuid.New(), uuid.New(), nil)
+	assert.Equal(t, 0, trend.Surveys)
+	assert.NotNil(t, trend.Series)
+}
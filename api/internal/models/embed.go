@@ -0,0 +1,167 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Content that partner sites can embed
+const (
+	EmbedTypePoll     = "poll"
+	EmbedTypeElection = "election"
+)
+
+// EmbedTypes lists every embeddable content type
+var EmbedTypes = []string{EmbedTypePoll, EmbedTypeElection}
+
+// EmbedTarget is a site page that has an embeddable version
+type EmbedTarget struct {
+	Type       string
+	Slug       string
+	PositionID *uuid.UUID // Election results for a single position
+}
+
+// ParseEmbedURL recognizes the site pages that can be embedded: polls at
+// /polls/{slug} and election results at /elections/{slug} or
+// /elections/{slug}/results, optionally narrowed with ?position={id}. The URL
+// must be on the site's own host.
+func ParseEmbedURL(rawURL, siteURL string) (*EmbedTarget, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, false
+	}
+	site, err := url.Parse(siteURL)
+	if err != nil || normalizeHost(u.Hostname()) != normalizeHost(site.Hostname()) {
+		return nil, false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "polls" && parts[1] != "":
+		return &EmbedTarget{Type: EmbedTypePoll, Slug: parts[1]}, true
+	case len(parts) >= 2 && len(parts) <= 3 && parts[0] == "elections" && parts[1] != "":
+		if len(parts) == 3 && parts[2] != "results" {
+			return nil, false
+		}
+		target := &EmbedTarget{Type: EmbedTypeElection, Slug: parts[1]}
+		if id, err := uuid.Parse(u.Query().Get("position")); err == nil {
+			target.PositionID = &id
+		}
+		return target, true
+	}
+	return nil, false
+}
+
+// EmbedFrameAncestors builds the CSP frame-ancestors directive for embeds:
+// the site itself plus the allowed partner origins. Entries that aren't an
+// origin are skipped, except "*" which lets any site embed.
+func EmbedFrameAncestors(siteURL string, allowedOrigins []string) string {
+	sources := []string{"'self'"}
+	if origin := originOf(siteURL); origin != "" {
+		sources = append(sources, origin)
+	}
+	for _, allowed := range allowedOrigins {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" {
+			return "frame-ancestors *"
+		}
+		if origin := originOf(allowed); origin != "" {
+			sources = append(sources, origin)
+		}
+	}
+	return "frame-ancestors " + strings.Join(sources, " ")
+}
+
+// originOf returns the scheme and host of an http(s) URL, or "" if it isn't one
+func originOf(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + strings.ToLower(u.Host)
+}
+
+// OEmbedResponse is a "rich" oEmbed response (https://oembed.com)
+type OEmbedResponse struct {
+	Type         string `json:"type"`
+	Version      string `json:"version"`
+	Title        string `json:"title"`
+	ProviderName string `json:"provider_name"`
+	ProviderURL  string `json:"provider_url"`
+	CacheAge     int    `json:"cache_age"` // Seconds
+	HTML         string `json:"html"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
+
+// PollEmbed is the read-only poll box shown on partner sites
+type PollEmbed struct {
+	ID          uuid.UUID         `json:"id"`
+	Title       string            `json:"title"`
+	Slug        string            `json:"slug"`
+	Status      string            `json:"status"`
+	VotingOpen  bool              `json:"voting_open"`
+	ShowResults bool              `json:"show_results"` // False while voting is open on polls that hide results until a vote
+	TotalVotes  int               `json:"total_votes"`
+	Options     []PollEmbedOption `json:"options"`
+}
+
+type PollEmbedOption struct {
+	Text       string  `json:"text"`
+	VoteCount  int     `json:"vote_count,omitempty"`
+	Percentage float64 `json:"percentage,omitempty"`
+}
+
+// ElectionResultsEmbed is the read-only results box shown on partner sites
+type ElectionResultsEmbed struct {
+	ID           uuid.UUID                      `json:"id"`
+	Name         string                         `json:"name"`
+	Slug         string                         `json:"slug"`
+	Status       string                         `json:"status"`
+	ElectionDate Date                           `json:"election_date"`
+	DataAsOf     time.Time                      `json:"data_as_of"`
+	Positions    []ElectionResultsEmbedPosition `json:"positions"`
+}
+
+type ElectionResultsEmbedPosition struct {
+	ID         uuid.UUID                       `json:"id"`
+	Name       string                          `json:"name"`
+	Location   *string                         `json:"location,omitempty"`
+	Candidates []ElectionResultsEmbedCandidate `json:"candidates"`
+}
+
+type ElectionResultsEmbedCandidate struct {
+	Name           string   `json:"name"`
+	Party          *string  `json:"party,omitempty"`
+	VotesReceived  *int     `json:"votes_received,omitempty"`
+	VotePercentage *float64 `json:"vote_percentage,omitempty"`
+	IsWinner       bool     `json:"is_winner"`
+}
+
+// EmbedViews is a batch of views of one embedded item from one embedding
+// domain on one day
+type EmbedViews struct {
+	Type      string
+	ContentID uuid.UUID
+	Date      string // YYYY-MM-DD
+	Domain    string // empty when the embedding page sent no referrer
+	Views     int64
+}
+
+// EmbedReach is how widely one item was viewed through embeds
+type EmbedReach struct {
+	Type       string             `json:"type"`
+	ContentID  uuid.UUID          `json:"content_id"`
+	Slug       string             `json:"slug"`
+	Title      string             `json:"title"`
+	Views      int64              `json:"views"`
+	TopDomains []EmbedDomainViews `json:"top_domains"`
+}
+
+type EmbedDomainViews struct {
+	Domain string `json:"domain"`
+	Views  int64  `json:"views"`
+}
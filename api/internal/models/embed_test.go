@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEmbedURL(t *testing.T) {
+	const site = "https://pulpulitiko.com"
+	position := uuid.New()
+
+	tests := []struct {
+		url  string
+		want *EmbedTarget
+	}{
+		{"https://pulpulitiko.com/polls/budget-2026", &EmbedTarget{Type: EmbedTypePoll, Slug: "budget-2026"}},
+		{"https://www.pulpulitiko.com/polls/budget-2026/", &EmbedTarget{Type: EmbedTypePoll, Slug: "budget-2026"}},
+		{"https://pulpulitiko.com/elections/2025-midterms", &EmbedTarget{Type: EmbedTypeElection, Slug: "2025-midterms"}},
+		{"https://pulpulitiko.com/elections/2025-midterms/results", &EmbedTarget{Type: EmbedTypeElection, Slug: "2025-midterms"}},
+		{
+			"https://pulpulitiko.com/elections/2025-midterms/results?position=" + position.String(),
+			&EmbedTarget{Type: EmbedTypeElection, Slug: "2025-midterms", PositionID: &position},
+		},
+		{"https://pulpulitiko.com/elections/2025-midterms?position=senator", &EmbedTarget{Type: EmbedTypeElection, Slug: "2025-midterms"}},
+		{"https://pulpulitiko.com/polls", nil},
+		{"https://pulpulitiko.com/polls/a/b", nil},
+		{"https://pulpulitiko.com/elections/2025-midterms/candidates", nil},
+		{"https://pulpulitiko.com/articles/some-story", nil},
+		{"https://evil.example/polls/budget-2026", nil},
+		{"javascript:alert(1)//pulpulitiko.com/polls/x", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, ok := ParseEmbedURL(tt.url, site)
+			if tt.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEmbedFrameAncestors(t *testing.T) {
+	assert.Equal(t, "frame-ancestors 'self' https://pulpulitiko.com",
+		EmbedFrameAncestors("https://pulpulitiko.com/", nil))
+
+	assert.Equal(t, "frame-ancestors 'self' https://pulpulitiko.com https://news.example http://localhost:4000",
+		EmbedFrameAncestors("https://pulpulitiko.com", []string{"https://News.example/", "not an origin", " http://localhost:4000 "}))
+
+	assert.Equal(t, "frame-ancestors *",
+		EmbedFrameAncestors("https://pulpulitiko.com", []string{"https://news.example", "*"}))
+}
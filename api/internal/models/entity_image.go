@@ -0,0 +1,26 @@
+package models
+
+// FocalPoint marks the part of an image a square crop should keep, as
+// percentages of its width and height from the top-left corner
+type FocalPoint struct {
+	X float64 `json:"x" validate:"gte=0,lte=100"`
+	Y float64 `json:"y" validate:"gte=0,lte=100"`
+}
+
+// NewFocalPoint builds a focal point from its stored columns, or nil when
+// either is unset
+func NewFocalPoint(x, y *float64) *FocalPoint {
+	if x == nil || y == nil {
+		return nil
+	}
+	return &FocalPoint{X: *x, Y: *y}
+}
+
+// EntityImage is a politician photo or party logo: the original upload and
+// the variants derived from it. Placeholders use the same URL for all three.
+type EntityImage struct {
+	Original   string      `json:"original"`
+	Thumb      string      `json:"thumb"`
+	List       string      `json:"list"`
+	FocalPoint *FocalPoint `json:"focal_point,omitempty"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Explainer is a reusable background box, such as "What is the Maharlika
+// fund?", that articles embed with an <!--explainer:slug--> token
+type Explainer struct {
+	ID        uuid.UUID `json:"id"`
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
+	Body      string    `json:"body"` // Sanitized HTML
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Articles whose content embeds the explainer, set on the admin list so
+	// editors can see how far an edit reaches
+	ArticleCount *int `json:"article_count,omitempty"`
+}
+
+type CreateExplainerRequest struct {
+	Title string `json:"title" validate:"required,min=3,max=300"`
+	Slug  string `json:"slug" validate:"required,min=2,max=200"`
+	Body  string `json:"body" validate:"required"`
+}
+
+type UpdateExplainerRequest struct {
+	Title *string `json:"title,omitempty" validate:"omitempty,min=3,max=300"`
+	Slug  *string `json:"slug,omitempty" validate:"omitempty,min=2,max=200"`
+	Body  *string `json:"body,omitempty" validate:"omitempty,min=1"`
+}
+
+// EmbeddedExplainer is the current content of an explainer an article embeds
+type EmbeddedExplainer struct {
+	Slug      string    `json:"slug"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ExplainerTokenPattern matches an explainer embed token in article content;
+// the first group is the slug
+var ExplainerTokenPattern = regexp.MustCompile(`<!--\s*explainer:([a-z0-9]+(?:-[a-z0-9]+)*)\s*-->`)
+
+var explainerSlugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// ValidExplainerSlug reports whether slug can be used in an embed token:
+// lowercase letters and digits, separated by single hyphens
+func ValidExplainerSlug(slug string) bool {
+	return explainerSlugPattern.MatchString(slug)
+}
+
+// ExplainerToken is the embed token for an explainer
+func ExplainerToken(slug string) string {
+	return "<!--explainer:" + slug + "-->"
+}
+
+// ExplainerSlugs lists the explainers content embeds, each once, in the
+// order they first appear
+func ExplainerSlugs(content string) []string {
+	var slugs []string
+	seen := map[string]bool{}
+	for _, m := range ExplainerTokenPattern.FindAllStringSubmatch(content, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			slugs = append(slugs, m[1])
+		}
+	}
+	return slugs
+}
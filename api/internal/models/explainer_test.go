@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidExplainerSlug(t *testing.T) {
+	assert.True(t, ValidExplainerSlug("pork-barrel"))
+	assert.True(t, ValidExplainerSlug("ra-11232"))
+	assert.False(t, ValidExplainerSlug("Pork-Barrel"))
+	assert.False(t, ValidExplainerSlug("pork--barrel"))
+	assert.False(t, ValidExplainerSlug("-pork"))
+	assert.False(t, ValidExplainerSlug(""))
+}
+
+func TestExplainerSlugs(t *testing.T) {
+	content := `<p>Intro</p><!--explainer:pork-barrel--><p>More</p>` +
+		`<!-- explainer:cha-cha --><!--explainer:pork-barrel--><!-- explainer:Bad -->`
+
+	assert.Equal(t, []string{"pork-barrel", "cha-cha"}, ExplainerSlugs(content))
+	assert.Empty(t, ExplainerSlugs("<p>No explainers</p>"))
+	assert.Equal(t, "<!--explainer:cha-cha-->", ExplainerToken("cha-cha"))
+}
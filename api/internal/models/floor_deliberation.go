@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FloorDeliberation is a plenary session in which a bill was debated. The
+// transcript text is only included when a single deliberation is fetched;
+// lists report HasTranscriptText instead.
+type FloorDeliberation struct {
+	ID                uuid.UUID `json:"id"`
+	BillID            uuid.UUID `json:"bill_id"`
+	SessionDate       time.Time `json:"session_date"`
+	Chamber           string    `json:"chamber"`
+	TranscriptText    *string   `json:"transcript_text,omitempty"`
+	TranscriptURL     *string   `json:"transcript_url,omitempty"`
+	HasTranscriptText bool      `json:"has_transcript_text"`
+	IsThirdReading    bool      `json:"is_third_reading"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type CreateFloorDeliberationRequest struct {
+	SessionDate    string  `json:"session_date" validate:"required,datetime=2006-01-02"`
+	Chamber        string  `json:"chamber" validate:"required,oneof=senate house"`
+	TranscriptText *string `json:"transcript_text,omitempty"`
+	TranscriptURL  *string `json:"transcript_url,omitempty" validate:"omitempty,url,max=500"`
+	IsThirdReading bool    `json:"is_third_reading"`
+}
+
+// UpdateFloorDeliberationRequest applies a partial update. An empty
+// transcript_text or transcript_url clears that field.
+type UpdateFloorDeliberationRequest struct {
+	SessionDate    *string `json:"session_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Chamber        *string `json:"chamber,omitempty" validate:"omitempty,oneof=senate house"`
+	TranscriptText *string `json:"transcript_text,omitempty"`
+	TranscriptURL  *string `json:"transcript_url,omitempty" validate:"omitempty,max=500,url|len=0"`
+	IsThirdReading *bool   `json:"is_third_reading,omitempty"`
+}
+
+// DeliberationSearchResult is a deliberation whose transcript matched a
+// search, with an HTML-safe excerpt where matches are wrapped in <mark>
+type DeliberationSearchResult struct {
+	ID             uuid.UUID `json:"id"`
+	SessionDate    time.Time `json:"session_date"`
+	Chamber        string    `json:"chamber"`
+	IsThirdReading bool      `json:"is_third_reading"`
+	Snippet        string    `json:"snippet"`
+	Rank           float64   `json:"rank"`
+}
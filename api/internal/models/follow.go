@@ -0,0 +1,17 @@
+package models
+
+import "github.com/google/uuid"
+
+// What a user can follow for their feed
+const (
+	FollowEntityCategory   = "category"
+	FollowEntityTag        = "tag"
+	FollowEntityPolitician = "politician"
+	FollowEntityAuthor     = "author"
+)
+
+// FollowRequest names something to follow or unfollow
+type FollowRequest struct {
+	EntityType string    `json:"entity_type" validate:"required,oneof=category tag politician author"`
+	EntityID   uuid.UUID `json:"entity_id" validate:"required"`
+}
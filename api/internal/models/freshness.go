@@ -0,0 +1,67 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Modules whose data is encoded by hand and tracked for freshness
+const (
+	FreshnessModuleBills     = "bills"
+	FreshnessModuleElections = "elections"
+)
+
+// StalenessThresholds is how long data may go untouched before readers are
+// warned that it may be out of date
+type StalenessThresholds struct {
+	Bill     time.Duration // For bills still moving through Congress
+	Election time.Duration // For upcoming and ongoing elections
+}
+
+// FinalBillStatuses are the statuses a bill does not move on from, so its
+// data can't go stale
+var FinalBillStatuses = []string{
+	BillStatusConsolidated,
+	BillStatusSignedIntoLaw,
+	BillStatusVetoed,
+	BillStatusLapsed,
+	BillStatusWithdrawn,
+	BillStatusArchived,
+}
+
+// ActiveElectionStatuses are the statuses of elections whose data is still
+// expected to change
+var ActiveElectionStatuses = []string{ElectionStatusUpcoming, ElectionStatusOngoing}
+
+// IsBillStale reports whether an active bill's data has gone untouched for
+// longer than threshold. A zero threshold turns the check off.
+func IsBillStale(status string, dataAsOf, now time.Time, threshold time.Duration) bool {
+	if threshold <= 0 || slices.Contains(FinalBillStatuses, status) {
+		return false
+	}
+	return now.Sub(dataAsOf) > threshold
+}
+
+// IsElectionStale reports whether an upcoming or ongoing election's data has
+// gone untouched for longer than threshold. A zero threshold turns the check
+// off.
+func IsElectionStale(status string, dataAsOf, now time.Time, threshold time.Duration) bool {
+	if threshold <= 0 || !slices.Contains(ActiveElectionStatuses, status) {
+		return false
+	}
+	return now.Sub(dataAsOf) > threshold
+}
+
+// ModuleFreshness summarizes how current a module's active records are:
+// active bills, or upcoming and ongoing elections
+type ModuleFreshness struct {
+	Module         string     `json:"module"`
+	ActiveCount    int        `json:"active_count"`
+	StaleCount     int        `json:"stale_count"`
+	OldestDataAsOf *time.Time `json:"oldest_data_as_of,omitempty"` // Nil when nothing is active
+	OldestID       *uuid.UUID `json:"oldest_id,omitempty"`
+	OldestSlug     *string    `json:"oldest_slug,omitempty"`
+	OldestTitle    *string    `json:"oldest_title,omitempty"`
+}
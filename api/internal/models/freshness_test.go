@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBillStale(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	month := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		status    string
+		dataAsOf  time.Time
+		threshold time.Duration
+		want      bool
+	}{
+		{"active and recent", BillStatusInCommittee, now.Add(-24 * time.Hour), month, false},
+		{"active and untouched", BillStatusInCommittee, now.Add(-31 * 24 * time.Hour), month, true},
+		{"exactly at threshold", BillStatusFiled, now.Add(-month), month, false},
+		{"signed into law", BillStatusSignedIntoLaw, now.Add(-365 * 24 * time.Hour), month, false},
+		{"withdrawn", BillStatusWithdrawn, now.Add(-365 * 24 * time.Hour), month, false},
+		{"check turned off", BillStatusFiled, now.Add(-365 * 24 * time.Hour), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsBillStale(tt.status, tt.dataAsOf, now, tt.threshold))
+		})
+	}
+}
+
+func TestIsElectionStale(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+	old := now.Add(-8 * 24 * time.Hour)
+
+	assert.True(t, IsElectionStale(ElectionStatusUpcoming, old, now, week))
+	assert.True(t, IsElectionStale(ElectionStatusOngoing, old, now, week))
+	assert.False(t, IsElectionStale(ElectionStatusOngoing, now.Add(-time.Hour), now, week))
+	assert.False(t, IsElectionStale(ElectionStatusCompleted, old, now, week))
+	assert.False(t, IsElectionStale(ElectionStatusCancelled, old, now, week))
+	assert.False(t, IsElectionStale(ElectionStatusUpcoming, old, now, 0))
+}
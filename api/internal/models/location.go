@@ -50,6 +50,8 @@ type CityMunicipality struct {
 	IsHUC      bool       `json:"is_huc"` // Highly Urbanized City
 	IsICC      bool       `json:"is_icc"` // Independent Component City
 	Population *int       `json:"population,omitempty"`
+	Latitude   *float64   `json:"latitude,omitempty"` // Town centre
+	Longitude  *float64   `json:"longitude,omitempty"`
 	CreatedAt  time.Time  `json:"created_at"`
 	UpdatedAt  time.Time  `json:"updated_at"`
 	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
@@ -135,7 +137,18 @@ type BarangayListItem struct {
 	Code                 string    `json:"code"`
 	Name                 string    `json:"name"`
 	Slug                 string    `json:"slug"`
+	Population           *int      `json:"population,omitempty"`
 	CityMunicipalityName string    `json:"city_municipality_name,omitempty"`
+	ProvinceName         string    `json:"province_name,omitempty"` // Set by barangay search
+}
+
+// BarangaySearchFilter narrows a barangay search. Query matches the start of
+// any word in the name, falling back to a substring match.
+type BarangaySearchFilter struct {
+	Query         string
+	CityID        *uuid.UUID
+	MinPopulation *int
+	MaxPopulation *int
 }
 
 type DistrictListItem struct {
@@ -159,6 +172,59 @@ type LocationHierarchy struct {
 	District         *DistrictListItem         `json:"district,omitempty"`
 }
 
+// DetectedLocation is the city nearest a visitor's approximate location, with
+// the province and region it is in
+type DetectedLocation struct {
+	Region           RegionListItem           `json:"region"`
+	Province         ProvinceListItem         `json:"province"`
+	CityMunicipality CityMunicipalityListItem `json:"city_municipality"`
+	DistanceKm       float64                  `json:"distance_km"` // From the city's town centre
+}
+
+// Depths of the location tree, each level including the ones above it
+const (
+	LocationTreeRegions   = 1
+	LocationTreeProvinces = 2
+	LocationTreeCities    = 3
+	LocationTreeBarangays = 4 // Every barangay in the country; only on request
+)
+
+// LocationTreeRegion is the root of the nested location tree used by
+// location pickers. Levels below the requested depth are left out.
+type LocationTreeRegion struct {
+	ID        uuid.UUID              `json:"id"`
+	Code      string                 `json:"code"`
+	Name      string                 `json:"name"`
+	Slug      string                 `json:"slug"`
+	Provinces []LocationTreeProvince `json:"provinces,omitempty"`
+}
+
+type LocationTreeProvince struct {
+	ID     uuid.UUID          `json:"id"`
+	Code   string             `json:"code"`
+	Name   string             `json:"name"`
+	Slug   string             `json:"slug"`
+	Cities []LocationTreeCity `json:"cities,omitempty"`
+}
+
+type LocationTreeCity struct {
+	ID        uuid.UUID              `json:"id"`
+	Code      string                 `json:"code"`
+	Name      string                 `json:"name"`
+	Slug      string                 `json:"slug"`
+	IsCity    bool                   `json:"is_city"`
+	IsCapital bool                   `json:"is_capital"`
+	IsHUC     bool                   `json:"is_huc"`
+	Barangays []LocationTreeBarangay `json:"barangays,omitempty"`
+}
+
+type LocationTreeBarangay struct {
+	ID   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+	Name string    `json:"name"`
+	Slug string    `json:"slug"`
+}
+
 // =====================================================
 // REQUEST/RESPONSE TYPES
 // =====================================================
@@ -178,15 +244,17 @@ type CreateProvinceRequest struct {
 }
 
 type CreateCityMunicipalityRequest struct {
-	ProvinceID string `json:"province_id" validate:"required,uuid"`
-	Code       string `json:"code" validate:"required,max=20"`
-	Name       string `json:"name" validate:"required,max=200"`
-	Slug       string `json:"slug" validate:"required,max=200"`
-	IsCity     bool   `json:"is_city"`
-	IsCapital  bool   `json:"is_capital"`
-	IsHUC      bool   `json:"is_huc"`
-	IsICC      bool   `json:"is_icc"`
-	Population *int   `json:"population,omitempty"`
+	ProvinceID string   `json:"province_id" validate:"required,uuid"`
+	Code       string   `json:"code" validate:"required,max=20"`
+	Name       string   `json:"name" validate:"required,max=200"`
+	Slug       string   `json:"slug" validate:"required,max=200"`
+	IsCity     bool     `json:"is_city"`
+	IsCapital  bool     `json:"is_capital"`
+	IsHUC      bool     `json:"is_huc"`
+	IsICC      bool     `json:"is_icc"`
+	Population *int     `json:"population,omitempty"`
+	Latitude   *float64 `json:"latitude,omitempty" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude  *float64 `json:"longitude,omitempty" validate:"required_with=Latitude,omitempty,longitude"`
 }
 
 type CreateBarangayRequest struct {
@@ -206,29 +274,39 @@ type CreateDistrictRequest struct {
 }
 
 // Update Requests
+//
+// Setting ExpectedUpdatedAt to the updated_at the editor last read turns on
+// optimistic locking: the update is refused if the record has changed since.
+
 type UpdateRegionRequest struct {
-	Code *string `json:"code,omitempty" validate:"omitempty,max=20"`
-	Name *string `json:"name,omitempty" validate:"omitempty,max=200"`
-	Slug *string `json:"slug,omitempty" validate:"omitempty,max=200"`
+	Code              *string    `json:"code,omitempty" validate:"omitempty,max=20"`
+	Name              *string    `json:"name,omitempty" validate:"omitempty,max=200"`
+	Slug              *string    `json:"slug,omitempty" validate:"omitempty,max=200"`
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at,omitempty"` // See ConcurrentUpdateError
 }
 
 type UpdateProvinceRequest struct {
-	RegionID *string `json:"region_id,omitempty" validate:"omitempty,uuid"`
-	Code     *string `json:"code,omitempty" validate:"omitempty,max=20"`
-	Name     *string `json:"name,omitempty" validate:"omitempty,max=200"`
-	Slug     *string `json:"slug,omitempty" validate:"omitempty,max=200"`
+	RegionID          *string    `json:"region_id,omitempty" validate:"omitempty,uuid"`
+	Code              *string    `json:"code,omitempty" validate:"omitempty,max=20"`
+	Name              *string    `json:"name,omitempty" validate:"omitempty,max=200"`
+	Slug              *string    `json:"slug,omitempty" validate:"omitempty,max=200"`
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at,omitempty"`
 }
 
 type UpdateCityMunicipalityRequest struct {
-	ProvinceID *string `json:"province_id,omitempty" validate:"omitempty,uuid"`
-	Code       *string `json:"code,omitempty" validate:"omitempty,max=20"`
-	Name       *string `json:"name,omitempty" validate:"omitempty,max=200"`
-	Slug       *string `json:"slug,omitempty" validate:"omitempty,max=200"`
-	IsCity     *bool   `json:"is_city,omitempty"`
-	IsCapital  *bool   `json:"is_capital,omitempty"`
-	IsHUC      *bool   `json:"is_huc,omitempty"`
-	IsICC      *bool   `json:"is_icc,omitempty"`
-	Population *int    `json:"population,omitempty"`
+	ProvinceID *string  `json:"province_id,omitempty" validate:"omitempty,uuid"`
+	Code       *string  `json:"code,omitempty" validate:"omitempty,max=20"`
+	Name       *string  `json:"name,omitempty" validate:"omitempty,max=200"`
+	Slug       *string  `json:"slug,omitempty" validate:"omitempty,max=200"`
+	IsCity     *bool    `json:"is_city,omitempty"`
+	IsCapital  *bool    `json:"is_capital,omitempty"`
+	IsHUC      *bool    `json:"is_huc,omitempty"`
+	IsICC      *bool    `json:"is_icc,omitempty"`
+	Population *int     `json:"population,omitempty"`
+	Latitude   *float64 `json:"latitude,omitempty" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude  *float64 `json:"longitude,omitempty" validate:"required_with=Latitude,omitempty,longitude"`
+
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at,omitempty"`
 }
 
 type UpdateBarangayRequest struct {
@@ -239,6 +317,36 @@ type UpdateBarangayRequest struct {
 	Population         *int    `json:"population,omitempty"`
 }
 
+type UpdateDistrictRequest struct {
+	ProvinceID         *string    `json:"province_id,omitempty" validate:"omitempty,uuid"`
+	CityMunicipalityID *string    `json:"city_municipality_id,omitempty" validate:"omitempty,uuid"`
+	DistrictNumber     *int       `json:"district_number,omitempty" validate:"omitempty,min=1"`
+	Name               *string    `json:"name,omitempty" validate:"omitempty,max=200"`
+	Slug               *string    `json:"slug,omitempty" validate:"omitempty,max=200"`
+	ExpectedUpdatedAt  *time.Time `json:"expected_updated_at,omitempty"`
+}
+
+// ConcurrentUpdateError is returned when an update's ExpectedUpdatedAt no
+// longer matches because someone else changed the record first
+type ConcurrentUpdateError struct {
+	CurrentUpdatedAt time.Time
+}
+
+func (e *ConcurrentUpdateError) Error() string {
+	return "concurrent_update"
+}
+
+// LocationHierarchyError is returned when a record names locations that do
+// not nest, e.g. a city/municipality outside the given province, or one that
+// does not exist
+type LocationHierarchyError struct {
+	Message string
+}
+
+func (e *LocationHierarchyError) Error() string {
+	return e.Message
+}
+
 // Filters
 type LocationFilter struct {
 	Search         *string
@@ -314,4 +422,5 @@ type LocationSearchResult struct {
 	Slug       string    `json:"slug"`
 	ParentName string    `json:"parent_name,omitempty"` // For display context
 	FullPath   string    `json:"full_path"`             // e.g., "Barangay 1, Quezon City, NCR"
+	Score      float64   `json:"score"`                 // How closely the name matches, 0 to 1
 }
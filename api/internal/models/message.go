@@ -15,11 +15,13 @@ const (
 	ConversationStatusArchived ConversationStatus = "archived"
 )
 
-// Conversation represents a chat conversation between a user and admin
+// Conversation represents a chat conversation between a user and admin,
+// or between two users when RecipientID is set
 type Conversation struct {
 	ID            uuid.UUID          `json:"id"`
 	UserID        uuid.UUID          `json:"user_id"`
 	User          *User              `json:"user,omitempty"`
+	RecipientID   *uuid.UUID         `json:"recipient_id,omitempty"`
 	Subject       *string            `json:"subject,omitempty"`
 	Status        ConversationStatus `json:"status"`
 	LastMessageAt *time.Time         `json:"last_message_at,omitempty"`
@@ -27,6 +29,18 @@ type Conversation struct {
 	UnreadCount   int                `json:"unread_count,omitempty"`
 	CreatedAt     time.Time          `json:"created_at"`
 	UpdatedAt     time.Time          `json:"updated_at"`
+
+	// Support conversations can be assigned to one admin
+	AssignedTo *uuid.UUID            `json:"assigned_to,omitempty"`
+	AssignedAt *time.Time            `json:"assigned_at,omitempty"`
+	Assignee   *ConversationAssignee `json:"assignee,omitempty"`
+}
+
+// ConversationAssignee is the admin handling a support conversation
+type ConversationAssignee struct {
+	ID     uuid.UUID `json:"id"`
+	Name   string    `json:"name"`
+	Avatar *string   `json:"avatar,omitempty"`
 }
 
 // Message represents a single message in a conversation
@@ -43,8 +57,21 @@ type Message struct {
 
 // CreateConversationRequest represents the request to create a new conversation
 type CreateConversationRequest struct {
-	Subject string `json:"subject"`
-	Message string `json:"message" validate:"required,min=1"`
+	Subject     string     `json:"subject"`
+	Message     string     `json:"message" validate:"required,min=1"`
+	RecipientID *uuid.UUID `json:"recipient_id,omitempty"` // Omit to message the admin team
+}
+
+// OtherParticipant returns the user on the other side of a direct conversation,
+// or nil for support conversations
+func (c *Conversation) OtherParticipant(userID uuid.UUID) *uuid.UUID {
+	if c.RecipientID == nil {
+		return nil
+	}
+	if userID == c.UserID {
+		return c.RecipientID
+	}
+	return &c.UserID
 }
 
 // CreateMessageRequest represents the request to send a new message
@@ -57,10 +84,44 @@ type UpdateConversationRequest struct {
 	Status ConversationStatus `json:"status" validate:"required,oneof=open closed archived"`
 }
 
-// ConversationFilter represents filters for listing conversations
+// AssignConversationRequest assigns a support conversation to an admin.
+// A null admin_id returns it to the unassigned queue.
+type AssignConversationRequest struct {
+	AdminID *uuid.UUID `json:"admin_id"`
+}
+
+// ConversationFilter represents filters for listing conversations.
+// Archived conversations are left out unless Status asks for them.
 type ConversationFilter struct {
-	UserID *uuid.UUID
-	Status *ConversationStatus
+	UserID     *uuid.UUID
+	Status     *ConversationStatus
+	AssignedTo *uuid.UUID
+	Unassigned bool
+	UnreadOnly bool
+}
+
+// QueueCount is the size of one support inbox queue
+type QueueCount struct {
+	Total  int `json:"total"`
+	Unread int `json:"unread"`
+}
+
+// AdminQueueCount is the open support conversations assigned to one admin
+type AdminQueueCount struct {
+	AdminID uuid.UUID `json:"admin_id"`
+	Name    string    `json:"name"`
+	Avatar  *string   `json:"avatar,omitempty"`
+	QueueCount
+}
+
+// ConversationQueueCounts sizes the support inbox queues for the admin
+// sidebar. Every queue except Archived counts open conversations only.
+type ConversationQueueCounts struct {
+	Mine       QueueCount        `json:"mine"`
+	Unassigned QueueCount        `json:"unassigned"`
+	All        QueueCount        `json:"all"`
+	Archived   int               `json:"archived"`
+	Admins     []AdminQueueCount `json:"admins"`
 }
 
 // PaginatedConversations represents a paginated list of conversations
@@ -92,15 +153,22 @@ const (
 	WSMessageTypeUserOnline   WSMessageType = "user_online"
 	WSMessageTypeUserOffline  WSMessageType = "user_offline"
 	WSMessageTypeConversation WSMessageType = "conversation_update"
+
+	// Article comment channels
+	WSMessageTypeSubscribeArticle   WSMessageType = "subscribe_article"
+	WSMessageTypeUnsubscribeArticle WSMessageType = "unsubscribe_article"
+	WSMessageTypeCommentEvents      WSMessageType = "comment_events"
 )
 
 // WSMessage represents a WebSocket message
 type WSMessage struct {
-	Type           WSMessageType `json:"type"`
-	ConversationID *uuid.UUID    `json:"conversation_id,omitempty"`
-	Message        *Message      `json:"message,omitempty"`
-	UserID         *uuid.UUID    `json:"user_id,omitempty"`
-	Timestamp      time.Time     `json:"timestamp"`
+	Type           WSMessageType  `json:"type"`
+	ConversationID *uuid.UUID     `json:"conversation_id,omitempty"`
+	Message        *Message       `json:"message,omitempty"`
+	UserID         *uuid.UUID     `json:"user_id,omitempty"`
+	ArticleSlug    *string        `json:"article_slug,omitempty"`
+	Events         []CommentEvent `json:"events,omitempty"`
+	Timestamp      time.Time      `json:"timestamp"`
 }
 
 // UnreadCounts represents unread message counts for a user
@@ -20,13 +20,27 @@ type TagMetric struct {
 	TotalViews   int       `json:"total_views"`
 }
 
+// AuthorMetric represents article count and views per author. Co-authored
+// articles count toward the weighted totals at the configured co-author weight.
+type AuthorMetric struct {
+	ID                   uuid.UUID `json:"id"`
+	Name                 string    `json:"name"`
+	Slug                 string    `json:"slug"`
+	ArticleCount         int       `json:"article_count"`
+	CoAuthoredCount      int       `json:"co_authored_count"`
+	WeightedArticleCount float64   `json:"weighted_article_count"`
+	TotalViews           int       `json:"total_views"`
+	WeightedViews        float64   `json:"weighted_views"`
+}
+
 // TopArticle represents a top viewed article
 type TopArticle struct {
-	ID           uuid.UUID `json:"id"`
-	Slug         string    `json:"slug"`
-	Title        string    `json:"title"`
-	ViewCount    int       `json:"view_count"`
-	CategoryName *string   `json:"category_name,omitempty"`
+	ID            uuid.UUID `json:"id"`
+	Slug          string    `json:"slug"`
+	Title         string    `json:"title"`
+	ViewCount     int       `json:"view_count"`
+	ReactionCount int       `json:"reaction_count"`
+	CategoryName  *string   `json:"category_name,omitempty"`
 }
 
 // DashboardMetrics represents all metrics for the admin dashboard
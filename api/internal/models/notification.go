@@ -15,22 +15,26 @@ const (
 	NotificationTypeReplyArticleComment      NotificationType = "reply_article_comment"
 	NotificationTypeReplyPoliticianComment   NotificationType = "reply_politician_comment"
 	NotificationTypeCommentReaction          NotificationType = "comment_reaction"
+	NotificationTypePollClosed               NotificationType = "poll_closed"
+	NotificationTypeConversationAssigned     NotificationType = "conversation_assigned"
 )
 
 // Notification represents a user notification
 type Notification struct {
-	ID           uuid.UUID        `json:"id"`
-	UserID       uuid.UUID        `json:"user_id"`
-	Type         NotificationType `json:"type"`
-	Title        string           `json:"title"`
-	Message      *string          `json:"message,omitempty"`
-	ActorID      *uuid.UUID       `json:"actor_id,omitempty"`
-	ArticleID    *uuid.UUID       `json:"article_id,omitempty"`
-	PoliticianID *uuid.UUID       `json:"politician_id,omitempty"`
-	CommentID    *uuid.UUID       `json:"comment_id,omitempty"`
-	IsRead       bool             `json:"is_read"`
-	ReadAt       *time.Time       `json:"read_at,omitempty"`
-	CreatedAt    time.Time        `json:"created_at"`
+	ID             uuid.UUID        `json:"id"`
+	UserID         uuid.UUID        `json:"user_id"`
+	Type           NotificationType `json:"type"`
+	Title          string           `json:"title"`
+	Message        *string          `json:"message,omitempty"`
+	ActorID        *uuid.UUID       `json:"actor_id,omitempty"`
+	ArticleID      *uuid.UUID       `json:"article_id,omitempty"`
+	PoliticianID   *uuid.UUID       `json:"politician_id,omitempty"`
+	CommentID      *uuid.UUID       `json:"comment_id,omitempty"`
+	PollID         *uuid.UUID       `json:"poll_id,omitempty"`
+	ConversationID *uuid.UUID       `json:"conversation_id,omitempty"`
+	IsRead         bool             `json:"is_read"`
+	ReadAt         *time.Time       `json:"read_at,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
 
 	// Relations (populated when needed)
 	Actor         *NotificationActor `json:"actor,omitempty"`
@@ -64,12 +68,65 @@ type PaginatedNotifications struct {
 
 // CreateNotificationRequest for creating notifications
 type CreateNotificationRequest struct {
-	UserID       uuid.UUID
-	Type         NotificationType
-	Title        string
-	Message      *string
-	ActorID      *uuid.UUID
-	ArticleID    *uuid.UUID
-	PoliticianID *uuid.UUID
-	CommentID    *uuid.UUID
+	UserID         uuid.UUID
+	Type           NotificationType
+	Title          string
+	Message        *string
+	ActorID        *uuid.UUID
+	ArticleID      *uuid.UUID
+	PoliticianID   *uuid.UUID
+	CommentID      *uuid.UUID
+	PollID         *uuid.UUID
+	ConversationID *uuid.UUID
+}
+
+// InboxItemType discriminates the sources merged into the notifications inbox
+type InboxItemType string
+
+const (
+	InboxItemMention InboxItemType = "mention"
+	InboxItemReply   InboxItemType = "reply"
+	InboxItemMessage InboxItemType = "message"
+)
+
+// Valid reports whether t is a known inbox item type
+func (t InboxItemType) Valid() bool {
+	switch t {
+	case InboxItemMention, InboxItemReply, InboxItemMessage:
+		return true
+	}
+	return false
+}
+
+// InboxItem is one entry of the unified notifications inbox. ID is the
+// mention, reply notification or message ID depending on Type.
+type InboxItem struct {
+	Type           InboxItemType      `json:"type"`
+	ID             uuid.UUID          `json:"id"`
+	IsRead         bool               `json:"is_read"`
+	Preview        string             `json:"preview"`
+	CommentID      *uuid.UUID         `json:"comment_id,omitempty"`
+	ConversationID *uuid.UUID         `json:"conversation_id,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+	Actor          *NotificationActor `json:"actor,omitempty"`
+	ArticleRef     *NotificationRef   `json:"article,omitempty"`
+	PoliticianRef  *NotificationRef   `json:"politician,omitempty"`
+}
+
+// InboxUnreadCounts breaks the unified unread count down by source
+type InboxUnreadCounts struct {
+	Mentions int `json:"mentions"`
+	Replies  int `json:"replies"`
+	Messages int `json:"messages"`
+	Total    int `json:"total"`
+}
+
+// PaginatedInbox for the unified notifications inbox
+type PaginatedInbox struct {
+	Items       []InboxItem `json:"items"`
+	Total       int         `json:"total"`
+	UnreadCount int         `json:"unread_count"`
+	Page        int         `json:"page"`
+	PerPage     int         `json:"per_page"`
+	TotalPages  int         `json:"total_pages"`
 }
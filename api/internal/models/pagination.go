@@ -0,0 +1,141 @@
+package models
+
+import "github.com/humfurie/pulpulitiko/api/pkg/pagination"
+
+// Envelope methods convert the legacy Paginated* shapes, which each name their
+// items differently, into the standard pagination.PaginatedResponse envelope.
+
+func (p *PaginatedArticles) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Articles, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedBills) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Bills, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedPoliticianVotes) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Votes, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedRegions) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Regions, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedProvinces) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Provinces, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedCitiesMunicipalities) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.CitiesMunicipalities, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedBarangays) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Barangays, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedDistricts) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Districts, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedImportLogs) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.ImportLogs, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedUsers) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Users, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedPoliticianComments) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Comments, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedCategories) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Categories, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedPoliticalParties) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Parties, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedElectionEvents) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.ElectionEvents, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedElections) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Elections, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedCandidates) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Candidates, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedVoterEducation) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Items, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedComments) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Comments, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedConversations) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Conversations, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedMessages) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Messages, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedPolls) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Polls, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedPollComments) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Comments, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedTags) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Tags, p.Page, p.PerPage, p.Total)
+}
+
+func (p *PaginatedPoliticians) Envelope() interface{} {
+	return pagination.NewPaginatedResponse(p.Politicians, p.Page, p.PerPage, p.Total)
+}
+
+// NotificationsEnvelope keeps the unread count alongside the standard envelope
+type NotificationsEnvelope struct {
+	*pagination.PaginatedResponse[Notification]
+	UnreadCount int `json:"unread_count"`
+}
+
+func (p *PaginatedNotifications) Envelope() interface{} {
+	return NotificationsEnvelope{
+		PaginatedResponse: pagination.NewPaginatedResponse(p.Notifications, p.Page, p.PerPage, p.Total),
+		UnreadCount:       p.UnreadCount,
+	}
+}
+
+// InboxEnvelope keeps the unread count alongside the standard envelope
+type InboxEnvelope struct {
+	*pagination.PaginatedResponse[InboxItem]
+	UnreadCount int `json:"unread_count"`
+}
+
+func (p *PaginatedInbox) Envelope() interface{} {
+	return InboxEnvelope{
+		PaginatedResponse: pagination.NewPaginatedResponse(p.Items, p.Page, p.PerPage, p.Total),
+		UnreadCount:       p.UnreadCount,
+	}
+}
+
+// MentionsEnvelope keeps the unread count alongside the standard envelope
+type MentionsEnvelope struct {
+	*pagination.PaginatedResponse[UserMention]
+	UnreadCount int `json:"unread_count"`
+}
+
+func (p *PaginatedMentions) Envelope() interface{} {
+	return MentionsEnvelope{
+		PaginatedResponse: pagination.NewPaginatedResponse(p.Mentions, p.Page, p.PerPage, p.Total),
+		UnreadCount:       p.UnreadCount,
+	}
+}
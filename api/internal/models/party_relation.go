@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// How one party relates to another. The party is the subject: it is a member
+// of, merged into, or was renamed from the related party.
+const (
+	PartyRelationCoalitionMemberOf = "coalition_member_of"
+	PartyRelationMergedInto        = "merged_into"
+	PartyRelationRenamedFrom       = "renamed_from"
+)
+
+// PartyRelation links a party to a coalition, or to a party before or after
+// a merger or renaming, over an effective date range. Open ends are unbounded.
+type PartyRelation struct {
+	ID           uuid.UUID  `json:"id"`
+	PartyID      uuid.UUID  `json:"party_id"`
+	RelatedParty PartyBrief `json:"related_party"`
+	RelationType string     `json:"relation_type"`
+	StartDate    *time.Time `json:"start_date,omitempty"`
+	EndDate      *time.Time `json:"end_date,omitempty"`
+	Notes        *string    `json:"notes,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// Joined field
+	Party PartyBrief `json:"party"`
+}
+
+type CreatePartyRelationRequest struct {
+	RelatedPartyID uuid.UUID `json:"related_party_id" validate:"required"`
+	RelationType   string    `json:"relation_type" validate:"required,oneof=coalition_member_of merged_into renamed_from"`
+	StartDate      *string   `json:"start_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	EndDate        *string   `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Notes          *string   `json:"notes,omitempty"`
+}
+
+type UpdatePartyRelationRequest struct {
+	StartDate     *string `json:"start_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	EndDate       *string `json:"end_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	RemoveEndDate bool    `json:"remove_end_date,omitempty"` // Reopen the relation
+	Notes         *string `json:"notes,omitempty"`
+}
+
+// PartyLineageStep is one merger or renaming in a party's history: From
+// became, or became part of, To
+type PartyLineageStep struct {
+	RelationType string     `json:"relation_type"`
+	From         PartyBrief `json:"from"`
+	To           PartyBrief `json:"to"`
+	Date         *time.Time `json:"date,omitempty"`
+}
+
+// PartyLineageEdge is a lineage step by party id, predecessor to successor.
+// Renamings point from the old party to the new one, like mergers.
+func PartyLineageEdge(relationType string, partyID, relatedPartyID uuid.UUID) (from, to uuid.UUID) {
+	if relationType == PartyRelationRenamedFrom {
+		return relatedPartyID, partyID
+	}
+	return partyID, relatedPartyID
+}
+
+// PartyRelationGraph is the parties each party points to, in one family of
+// relations: lineage (predecessor to successor) or coalitions (member to
+// coalition)
+type PartyRelationGraph map[uuid.UUID][]uuid.UUID
+
+// CreatesCycle reports whether adding an edge from one party to another would
+// make a party its own ancestor
+func (g PartyRelationGraph) CreatesCycle(from, to uuid.UUID) bool {
+	if from == to {
+		return true
+	}
+
+	seen := map[uuid.UUID]bool{to: true}
+	queue := []uuid.UUID{to}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, id := range g[next] {
+			if id == from {
+				return true
+			}
+			if !seen[id] {
+				seen[id] = true
+				queue = append(queue, id)
+			}
+		}
+	}
+	return false
+}
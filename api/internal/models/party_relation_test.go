@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartyLineageEdge(t *testing.T) {
+	party, related := uuid.New(), uuid.New()
+
+	from, to := PartyLineageEdge(PartyRelationMergedInto, party, related)
+	assert.Equal(t, party, from)
+	assert.Equal(t, related, to)
+
+	// A party renamed from another comes after it
+	from, to = PartyLineageEdge(PartyRelationRenamedFrom, party, related)
+	assert.Equal(t, related, from)
+	assert.Equal(t, party, to)
+}
+
+func TestPartyRelationGraphCreatesCycle(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	graph := PartyRelationGraph{
+		a: {b},
+		b: {c},
+	}
+
+	assert.True(t, graph.CreatesCycle(c, a), "closing the chain")
+	assert.True(t, graph.CreatesCycle(b, a))
+	assert.True(t, graph.CreatesCycle(d, d), "a party related to itself")
+	assert.False(t, graph.CreatesCycle(a, c), "a shortcut along the chain")
+	assert.False(t, graph.CreatesCycle(c, d))
+	assert.False(t, PartyRelationGraph{}.CreatesCycle(a, b))
+}
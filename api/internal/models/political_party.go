@@ -7,23 +7,33 @@ import (
 )
 
 type PoliticalParty struct {
-	ID           uuid.UUID  `json:"id"`
-	Name         string     `json:"name"`
-	Slug         string     `json:"slug"`
-	Abbreviation *string    `json:"abbreviation,omitempty"`
-	Logo         *string    `json:"logo,omitempty"`
-	Color        *string    `json:"color,omitempty"`
-	Description  *string    `json:"description,omitempty"`
-	FoundedYear  *int       `json:"founded_year,omitempty"`
-	Website      *string    `json:"website,omitempty"`
-	IsMajor      bool       `json:"is_major"`
-	IsActive     bool       `json:"is_active"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
-	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	ID           uuid.UUID   `json:"id"`
+	Name         string      `json:"name"`
+	Slug         string      `json:"slug"`
+	Abbreviation *string     `json:"abbreviation,omitempty"`
+	Logo         *string     `json:"logo,omitempty"`
+	LogoThumb    *string     `json:"logo_thumb,omitempty"`
+	LogoList     *string     `json:"logo_list,omitempty"`
+	LogoFocal    *FocalPoint `json:"logo_focal_point,omitempty"`
+	Color        *string     `json:"color,omitempty"`
+	Description  *string     `json:"description,omitempty"`
+	FoundedYear  *int        `json:"founded_year,omitempty"`
+	Website      *string     `json:"website,omitempty"`
+	IsMajor      bool        `json:"is_major"`
+	IsActive     bool        `json:"is_active"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+	DeletedAt    *time.Time  `json:"deleted_at,omitempty"`
 
 	// Computed fields
 	MemberCount int `json:"member_count,omitempty"`
+
+	// Public party page only: coalitions the party is in today, the parties
+	// in it today if it is a coalition, and the mergers and renamings before
+	// and after it, oldest first
+	Coalitions       []PartyBrief       `json:"coalitions,omitempty"`
+	CoalitionMembers []PartyBrief       `json:"coalition_members,omitempty"`
+	Lineage          []PartyLineageStep `json:"lineage,omitempty"`
 }
 
 type PoliticalPartyListItem struct {
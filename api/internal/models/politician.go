@@ -1,6 +1,8 @@
 package models
 
 import (
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,7 +12,11 @@ type Politician struct {
 	ID           uuid.UUID    `json:"id"`
 	Name         string       `json:"name"`
 	Slug         string       `json:"slug"`
+	Nickname     *string      `json:"nickname,omitempty"` // e.g. "Bongbong"; matched by name search
 	Photo        *string      `json:"photo,omitempty"`
+	PhotoThumb   *string      `json:"photo_thumb,omitempty"`
+	PhotoList    *string      `json:"photo_list,omitempty"`
+	PhotoFocal   *FocalPoint  `json:"photo_focal_point,omitempty"`
 	Position     *string      `json:"position,omitempty"`
 	Party        *string      `json:"party,omitempty"`
 	ShortBio     *string      `json:"short_bio,omitempty"`
@@ -40,6 +46,19 @@ type Politician struct {
 	ArticleCount int                     `json:"article_count,omitempty"`
 	PartyInfo    *PartyBrief             `json:"party_info,omitempty"`
 	PositionInfo *GovernmentPositionInfo `json:"position_info,omitempty"`
+
+	// The party's coalition, set on the public profile during an election
+	// period
+	Coalition *PartyBrief `json:"coalition,omitempty"`
+
+	// Offices held, most recent first (public profile only)
+	CareerTimeline []CareerTimelineEntry `json:"career_timeline,omitempty"`
+
+	// Committee seats, current first (public profile only)
+	CommitteeMemberships []PoliticianCommitteeMembership `json:"committee_memberships,omitempty"`
+
+	// Linked social media accounts and websites (public profile only)
+	SocialAccounts []PoliticianSocialLink `json:"social_accounts,omitempty"`
 }
 
 // GovernmentPositionInfo is a lightweight version for embedding in Politician
@@ -71,6 +90,7 @@ type PoliticianListItem struct {
 	Party        *string     `json:"party,omitempty"`
 	Level        *string     `json:"level,omitempty"`
 	Branch       *string     `json:"branch,omitempty"`
+	Location     *string     `json:"location,omitempty"` // Most specific place the politician represents
 	TermStart    *time.Time  `json:"term_start,omitempty"`
 	TermEnd      *time.Time  `json:"term_end,omitempty"`
 	ArticleCount int         `json:"article_count"`
@@ -80,6 +100,7 @@ type PoliticianListItem struct {
 type CreatePoliticianRequest struct {
 	Name       string     `json:"name" validate:"required,min=2,max=200"`
 	Slug       string     `json:"slug" validate:"required,min=2,max=200"`
+	Nickname   *string    `json:"nickname,omitempty" validate:"omitempty,max=100"` // Defaults to a nickname quoted in the name
 	Photo      *string    `json:"photo,omitempty" validate:"omitempty,max=500"`
 	Position   *string    `json:"position,omitempty" validate:"omitempty,max=200"`
 	Party      *string    `json:"party,omitempty" validate:"omitempty,max=200"`
@@ -96,6 +117,7 @@ type CreatePoliticianRequest struct {
 type UpdatePoliticianRequest struct {
 	Name       *string    `json:"name,omitempty" validate:"omitempty,min=2,max=200"`
 	Slug       *string    `json:"slug,omitempty" validate:"omitempty,min=2,max=200"`
+	Nickname   *string    `json:"nickname,omitempty" validate:"omitempty,max=100"` // Empty clears it
 	Photo      *string    `json:"photo,omitempty" validate:"omitempty,max=500"`
 	Position   *string    `json:"position,omitempty" validate:"omitempty,max=200"`
 	Party      *string    `json:"party,omitempty" validate:"omitempty,max=200"`
@@ -109,6 +131,9 @@ type UpdatePoliticianRequest struct {
 	DistrictID *uuid.UUID `json:"district_id,omitempty"`
 }
 
+// GovernmentLevels are the values of the government_level enum
+var GovernmentLevels = []string{"national", "regional", "provincial", "city", "municipal", "barangay"}
+
 type PoliticianFilter struct {
 	Search         *string
 	Party          *string
@@ -118,6 +143,7 @@ type PoliticianFilter struct {
 	PositionID     *uuid.UUID
 	LocationID     *uuid.UUID // Can be region, province, city, or barangay
 	LocationType   *string    // "region", "province", "city", "barangay"
+	Active         bool       // Only politicians serving today, by tenure or term dates
 	IncludeDeleted bool
 }
 
@@ -128,3 +154,20 @@ type PaginatedPoliticians struct {
 	PerPage     int                  `json:"per_page"`
 	TotalPages  int                  `json:"total_pages"`
 }
+
+// quotedNickname matches a nickname written into a name in straight or curly
+// quotes, as in Ferdinand "Bongbong" Marcos Jr.
+var quotedNickname = regexp.MustCompile(`["“]([^"”]+)["”]`)
+
+// ExtractNickname returns the nickname quoted in name, or nil if there is none
+func ExtractNickname(name string) *string {
+	m := quotedNickname.FindStringSubmatch(name)
+	if m == nil {
+		return nil
+	}
+	nickname := strings.TrimSpace(m[1])
+	if nickname == "" {
+		return nil
+	}
+	return &nickname
+}
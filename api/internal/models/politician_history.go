@@ -0,0 +1,134 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Politician fields whose edits are kept in politician_history. The names
+// are the column names.
+const (
+	PoliticianFieldName      = "name"
+	PoliticianFieldSlug      = "slug"
+	PoliticianFieldNickname  = "nickname"
+	PoliticianFieldPhoto     = "photo"
+	PoliticianFieldPosition  = "position"
+	PoliticianFieldParty     = "party"
+	PoliticianFieldShortBio  = "short_bio"
+	PoliticianFieldTermStart = "term_start"
+	PoliticianFieldTermEnd   = "term_end"
+)
+
+var politicianHistoryFields = []string{
+	PoliticianFieldName, PoliticianFieldSlug, PoliticianFieldNickname, PoliticianFieldPhoto,
+	PoliticianFieldPosition, PoliticianFieldParty, PoliticianFieldShortBio,
+	PoliticianFieldTermStart, PoliticianFieldTermEnd,
+}
+
+// PoliticianHistoryLimit is how many change events the admin history shows
+const PoliticianHistoryLimit = 50
+
+// PoliticianHistoryEntry is one field changed by one edit. Dates are stored
+// as YYYY-MM-DD; a nil value means the field was empty.
+type PoliticianHistoryEntry struct {
+	ID           uuid.UUID  `json:"id"`
+	PoliticianID uuid.UUID  `json:"politician_id"`
+	FieldName    string     `json:"field_name"`
+	OldValue     *string    `json:"old_value,omitempty"`
+	NewValue     *string    `json:"new_value,omitempty"`
+	ChangedBy    *uuid.UUID `json:"changed_by,omitempty"`
+	ChangedAt    time.Time  `json:"changed_at"`
+
+	// Joined fields
+	ChangedByName *string `json:"changed_by_name,omitempty"`
+}
+
+// HistoryValues returns the tracked fields of a politician as they are
+// stored in politician_history
+func (p *Politician) HistoryValues() map[string]*string {
+	return map[string]*string{
+		PoliticianFieldName:      &p.Name,
+		PoliticianFieldSlug:      &p.Slug,
+		PoliticianFieldNickname:  p.Nickname,
+		PoliticianFieldPhoto:     p.Photo,
+		PoliticianFieldPosition:  p.Position,
+		PoliticianFieldParty:     p.Party,
+		PoliticianFieldShortBio:  p.ShortBio,
+		PoliticianFieldTermStart: formatHistoryDate(p.TermStart),
+		PoliticianFieldTermEnd:   formatHistoryDate(p.TermEnd),
+	}
+}
+
+// DiffPoliticianHistory lists the tracked fields that differ between two
+// versions of a politician, in a fixed order
+func DiffPoliticianHistory(before, after *Politician) []PoliticianHistoryEntry {
+	old, updated := before.HistoryValues(), after.HistoryValues()
+
+	var changes []PoliticianHistoryEntry
+	for _, field := range politicianHistoryFields {
+		o, n := old[field], updated[field]
+		if o == nil && n == nil || o != nil && n != nil && *o == *n {
+			continue
+		}
+		changes = append(changes, PoliticianHistoryEntry{
+			PoliticianID: after.ID,
+			FieldName:    field,
+			OldValue:     o,
+			NewValue:     n,
+		})
+	}
+	return changes
+}
+
+// SetHistoryValue sets a tracked field back to a value from
+// politician_history. A replaced photo loses its variants and focal point,
+// which are not tracked. Unknown fields are ignored.
+func (p *Politician) SetHistoryValue(field string, value *string) {
+	switch field {
+	case PoliticianFieldName:
+		if value != nil {
+			p.Name = *value
+		}
+	case PoliticianFieldSlug:
+		if value != nil {
+			p.Slug = *value
+		}
+	case PoliticianFieldNickname:
+		p.Nickname = value
+	case PoliticianFieldPhoto:
+		p.Photo = value
+		p.PhotoThumb = nil
+		p.PhotoList = nil
+		p.PhotoFocal = nil
+	case PoliticianFieldPosition:
+		p.Position = value
+	case PoliticianFieldParty:
+		p.Party = value
+	case PoliticianFieldShortBio:
+		p.ShortBio = value
+	case PoliticianFieldTermStart:
+		p.TermStart = parseHistoryDate(value)
+	case PoliticianFieldTermEnd:
+		p.TermEnd = parseHistoryDate(value)
+	}
+}
+
+func formatHistoryDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("2006-01-02")
+	return &s
+}
+
+func parseHistoryDate(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffPoliticianHistory(t *testing.T) {
+	str := func(s string) *string { return &s }
+	start := time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	before := &Politician{Name: "Juan dela Cruz", Slug: "juan-dela-cruz", Position: str("Senator"), TermStart: &start}
+	after := *before
+	after.Position = str("Vice President")
+	after.ShortBio = str("Former senator")
+	after.TermStart = nil
+
+	changes := DiffPoliticianHistory(before, &after)
+	require.Len(t, changes, 3)
+
+	assert.Equal(t, PoliticianFieldPosition, changes[0].FieldName)
+	assert.Equal(t, "Senator", *changes[0].OldValue)
+	assert.Equal(t, "Vice President", *changes[0].NewValue)
+
+	assert.Equal(t, PoliticianFieldShortBio, changes[1].FieldName)
+	assert.Nil(t, changes[1].OldValue)
+
+	assert.Equal(t, PoliticianFieldTermStart, changes[2].FieldName)
+	assert.Equal(t, "2022-06-30", *changes[2].OldValue)
+	assert.Nil(t, changes[2].NewValue)
+
+	assert.Empty(t, DiffPoliticianHistory(before, before))
+}
+
+func TestSetHistoryValueUndoesDiff(t *testing.T) {
+	str := func(s string) *string { return &s }
+	start := time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	before := Politician{Name: "Juan dela Cruz", Slug: "juan", Photo: str("old.jpg"), TermStart: &start}
+	after := Politician{Name: "Juan D. Cruz", Slug: "juan", Photo: str("new.jpg"), PhotoThumb: str("new-thumb.jpg"), Party: str("Liberal")}
+
+	replayed := after
+	for _, change := range DiffPoliticianHistory(&before, &after) {
+		replayed.SetHistoryValue(change.FieldName, change.OldValue)
+	}
+
+	assert.Equal(t, "Juan dela Cruz", replayed.Name)
+	assert.Equal(t, "old.jpg", *replayed.Photo)
+	assert.Nil(t, replayed.PhotoThumb, "variants of the newer photo are dropped")
+	assert.Nil(t, replayed.Party)
+	require.NotNil(t, replayed.TermStart)
+	assert.True(t, start.Equal(*replayed.TermStart))
+}
@@ -0,0 +1,18 @@
+package models
+
+import "github.com/google/uuid"
+
+// MergePoliticianRequest names the record a duplicate politician is merged into
+type MergePoliticianRequest struct {
+	MergeIntoID uuid.UUID `json:"merge_into_id" validate:"required"`
+}
+
+// PoliticianMergeResult reports what a merge carried over to the target
+type PoliticianMergeResult struct {
+	SourceID   uuid.UUID        `json:"source_id"`
+	TargetID   uuid.UUID        `json:"target_id"`
+	TargetSlug string           `json:"target_slug"`
+	Copied     map[string]int64 `json:"copied"` // rows copied per table; duplicates already on the target are skipped
+
+	PrimaryArticles int64 `json:"primary_articles"` // articles whose primary politician moved to the target
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SocialPlatform string
+
+const (
+	SocialPlatformFacebook  SocialPlatform = "facebook"
+	SocialPlatformTwitter   SocialPlatform = "twitter"
+	SocialPlatformInstagram SocialPlatform = "instagram"
+	SocialPlatformYouTube   SocialPlatform = "youtube"
+	SocialPlatformTikTok    SocialPlatform = "tiktok"
+	SocialPlatformWebsite   SocialPlatform = "website"
+)
+
+// socialPlatformPrefixes lists the URL prefixes accepted for each platform.
+// Websites take any http or https URL.
+var socialPlatformPrefixes = map[SocialPlatform][]string{
+	SocialPlatformFacebook:  {"https://facebook.com/", "https://www.facebook.com/"},
+	SocialPlatformTwitter:   {"https://twitter.com/", "https://x.com/"},
+	SocialPlatformInstagram: {"https://instagram.com/", "https://www.instagram.com/"},
+	SocialPlatformYouTube:   {"https://youtube.com/", "https://www.youtube.com/"},
+	SocialPlatformTikTok:    {"https://tiktok.com/@", "https://www.tiktok.com/@"},
+	SocialPlatformWebsite:   nil,
+}
+
+// PoliticianSocialLink is a social media account or website of a politician
+type PoliticianSocialLink struct {
+	ID           uuid.UUID      `json:"id"`
+	PoliticianID uuid.UUID      `json:"politician_id"`
+	Platform     SocialPlatform `json:"platform"`
+	URL          string         `json:"url"`
+	IsVerified   bool           `json:"is_verified"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+type CreatePoliticianSocialLinkRequest struct {
+	Platform   SocialPlatform `json:"platform" validate:"required"`
+	URL        string         `json:"url" validate:"required,max=500"`
+	IsVerified bool           `json:"is_verified"`
+}
+
+type UpdatePoliticianSocialLinkRequest struct {
+	Platform   *SocialPlatform `json:"platform,omitempty"`
+	URL        *string         `json:"url,omitempty" validate:"omitempty,max=500"`
+	IsVerified *bool           `json:"is_verified,omitempty"`
+}
+
+// IsValidSocialPlatform reports whether platform is one of the supported platforms
+func IsValidSocialPlatform(platform SocialPlatform) bool {
+	_, ok := socialPlatformPrefixes[platform]
+	return ok
+}
+
+// ValidateSocialLinkURL checks that rawURL is a link to a profile on the platform
+func ValidateSocialLinkURL(platform SocialPlatform, rawURL string) error {
+	prefixes, ok := socialPlatformPrefixes[platform]
+	if !ok {
+		return fmt.Errorf("unsupported social platform")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || strings.ContainsAny(rawURL, " \t\n") {
+		return fmt.Errorf("url must be a valid URL")
+	}
+
+	if platform == SocialPlatformWebsite {
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("website url must start with http:// or https://")
+		}
+		return nil
+	}
+
+	for _, prefix := range prefixes {
+		// The profile name or path has to follow the prefix
+		if strings.HasPrefix(rawURL, prefix) && len(rawURL) > len(prefix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s url must start with %s", platform, strings.Join(prefixes, " or "))
+}
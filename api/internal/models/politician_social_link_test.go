@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSocialLinkURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform SocialPlatform
+		url      string
+		valid    bool
+	}{
+		{"facebook", SocialPlatformFacebook, "https://facebook.com/juandelacruz", true},
+		{"facebook www", SocialPlatformFacebook, "https://www.facebook.com/juandelacruz", true},
+		{"facebook over http", SocialPlatformFacebook, "http://facebook.com/juandelacruz", false},
+		{"facebook lookalike host", SocialPlatformFacebook, "https://facebook.com.example.com/juan", false},
+		{"facebook without profile", SocialPlatformFacebook, "https://facebook.com/", false},
+		{"twitter", SocialPlatformTwitter, "https://twitter.com/juan", true},
+		{"x", SocialPlatformTwitter, "https://x.com/juan", true},
+		{"twitter link on facebook", SocialPlatformFacebook, "https://twitter.com/juan", false},
+		{"instagram", SocialPlatformInstagram, "https://www.instagram.com/juan", true},
+		{"youtube", SocialPlatformYouTube, "https://www.youtube.com/@juan", true},
+		{"tiktok", SocialPlatformTikTok, "https://www.tiktok.com/@juan", true},
+		{"tiktok without handle", SocialPlatformTikTok, "https://www.tiktok.com/juan", false},
+		{"website", SocialPlatformWebsite, "https://juandelacruz.ph", true},
+		{"website over http", SocialPlatformWebsite, "http://juandelacruz.ph/about", true},
+		{"website with other scheme", SocialPlatformWebsite, "ftp://juandelacruz.ph", false},
+		{"not a url", SocialPlatformWebsite, "juandelacruz.ph", false},
+		{"contains spaces", SocialPlatformTwitter, "https://x.com/juan dela cruz", false},
+		{"unknown platform", SocialPlatform("linkedin"), "https://linkedin.com/in/juan", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSocialLinkURL(tt.platform, tt.url)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PoliticianTenure is a single office held by a politician
+type PoliticianTenure struct {
+	ID                uuid.UUID  `json:"id"`
+	PoliticianID      uuid.UUID  `json:"politician_id"`
+	PositionID        uuid.UUID  `json:"position_id"`
+	StartedAt         time.Time  `json:"started_at"`
+	EndedAt           *time.Time `json:"ended_at,omitempty"`
+	ElectionID        *uuid.UUID `json:"election_id,omitempty"`
+	AppointmentReason *string    `json:"appointment_reason,omitempty"`
+	IsCurrent         bool       `json:"is_current"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+
+	// Joined fields
+	PositionName string `json:"position_name"`
+}
+
+// CareerTimelineEntry is the public view of a tenure on a politician profile
+type CareerTimelineEntry struct {
+	PositionName string     `json:"position_name"`
+	StartedAt    time.Time  `json:"started_at"`
+	EndedAt      *time.Time `json:"ended_at,omitempty"`
+	IsCurrent    bool       `json:"is_current"`
+}
+
+type CreatePoliticianTenureRequest struct {
+	PositionID        uuid.UUID  `json:"position_id" validate:"required"`
+	StartedAt         string     `json:"started_at" validate:"required,datetime=2006-01-02"` // Format: YYYY-MM-DD
+	EndedAt           *string    `json:"ended_at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	ElectionID        *uuid.UUID `json:"election_id,omitempty"`
+	AppointmentReason *string    `json:"appointment_reason,omitempty" validate:"omitempty,max=1000"`
+	IsCurrent         bool       `json:"is_current"`
+}
+
+type UpdatePoliticianTenureRequest struct {
+	PositionID        *uuid.UUID `json:"position_id,omitempty"`
+	StartedAt         *string    `json:"started_at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	EndedAt           *string    `json:"ended_at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	ElectionID        *uuid.UUID `json:"election_id,omitempty"`
+	AppointmentReason *string    `json:"appointment_reason,omitempty" validate:"omitempty,max=1000"`
+	IsCurrent         *bool      `json:"is_current,omitempty"`
+}
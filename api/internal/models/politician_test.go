@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractNickname(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{`Ferdinand "Bongbong" Romualdez Marcos Jr.`, "Bongbong"},
+		{"Maria Leonor “Leni” Gerona Robredo", "Leni"},
+		{`Emmanuel " Manny " Pacquiao`, "Manny"},
+		{"Risa Hontiveros", ""},
+		{`Juan "" dela Cruz`, ""},
+	}
+
+	for _, tt := range tests {
+		got := ExtractNickname(tt.name)
+		if tt.want == "" {
+			assert.Nil(t, got, tt.name)
+			continue
+		}
+		if assert.NotNil(t, got, tt.name) {
+			assert.Equal(t, tt.want, *got)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 const (
 	PollStatusDraft           = "draft"
 	PollStatusPendingApproval = "pending_approval"
+	PollStatusScheduled       = "scheduled"
 	PollStatusActive          = "active"
 	PollStatusClosed          = "closed"
 	PollStatusRejected        = "rejected"
@@ -26,6 +27,16 @@ const (
 	PollCategoryNationalIssue = "national_issue"
 )
 
+// What a poll is attached to, for filtering. None means attached to nothing,
+// not even a location.
+const (
+	PollAttachmentPolitician = "politician"
+	PollAttachmentElection   = "election"
+	PollAttachmentBill       = "bill"
+	PollAttachmentLocation   = "location"
+	PollAttachmentNone       = "none"
+)
+
 // Poll represents a user or admin created poll
 type Poll struct {
 	ID           uuid.UUID  `json:"id"`
@@ -52,6 +63,7 @@ type Poll struct {
 	ApprovedBy            *uuid.UUID `json:"approved_by,omitempty"`
 	ApprovedAt            *time.Time `json:"approved_at,omitempty"`
 	RejectionReason       *string    `json:"rejection_reason,omitempty"`
+	ClosedAt              *time.Time `json:"closed_at,omitempty"`
 	TotalVotes            int        `json:"total_votes"`
 	ViewCount             int        `json:"view_count"`
 	CommentCount          int        `json:"comment_count"`
@@ -59,6 +71,9 @@ type Poll struct {
 	UpdatedAt             time.Time  `json:"updated_at"`
 	DeletedAt             *time.Time `json:"deleted_at,omitempty"`
 
+	// Computed fields
+	VotingOpen bool `json:"voting_open"`
+
 	// Joined fields
 	Author     *PollAuthor      `json:"author,omitempty"`
 	Options    []PollOption     `json:"options,omitempty"`
@@ -69,6 +84,21 @@ type Poll struct {
 	UserVote   *uuid.UUID       `json:"user_vote,omitempty"` // Option ID user voted for
 }
 
+// IsVotingOpen reports whether the poll accepts votes at the given instant.
+// The window is inclusive of starts_at and exclusive of ends_at.
+func (p *Poll) IsVotingOpen(now time.Time) bool {
+	if p.Status != PollStatusActive {
+		return false
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && !now.Before(*p.EndsAt) {
+		return false
+	}
+	return true
+}
+
 type PollListItem struct {
 	ID           uuid.UUID   `json:"id"`
 	Title        string      `json:"title"`
@@ -82,7 +112,8 @@ type PollListItem struct {
 	CreatedAt    time.Time   `json:"created_at"`
 	Author       *PollAuthor `json:"author,omitempty"`
 	OptionCount  int         `json:"option_count"`
-	Location     *string     `json:"location,omitempty"` // Human-readable location display name
+	Location     *string     `json:"location,omitempty"`   // Human-readable location display name
+	DeletedAt    *time.Time  `json:"deleted_at,omitempty"` // Admin list with include_deleted only
 }
 
 type PollAuthor struct {
@@ -142,6 +173,8 @@ type PollVote struct {
 	OptionID  uuid.UUID  `json:"option_id"`
 	UserID    *uuid.UUID `json:"user_id,omitempty"`
 	IPHash    *string    `json:"-"` // Never expose
+	RegionID  *uuid.UUID `json:"region_id,omitempty"`
+	VotedAt   time.Time  `json:"voted_at"`
 	CreatedAt time.Time  `json:"created_at"`
 }
 
@@ -203,7 +236,7 @@ type UpdatePollRequest struct {
 
 type AdminUpdatePollRequest struct {
 	UpdatePollRequest
-	Status     *string `json:"status,omitempty" validate:"omitempty,oneof=draft pending_approval active closed rejected"`
+	Status     *string `json:"status,omitempty" validate:"omitempty,oneof=draft pending_approval scheduled active closed rejected"`
 	IsFeatured *bool   `json:"is_featured,omitempty"`
 }
 
@@ -213,7 +246,8 @@ type ApprovePollRequest struct {
 }
 
 type CastVoteRequest struct {
-	OptionID uuid.UUID `json:"option_id" validate:"required"`
+	OptionID uuid.UUID  `json:"option_id" validate:"required"`
+	RegionID *uuid.UUID `json:"region_id,omitempty"` // Voter's region, for regional breakdowns
 }
 
 type CreatePollCommentRequest struct {
@@ -229,9 +263,14 @@ type PollFilter struct {
 	UserID       *uuid.UUID
 	PoliticianID *uuid.UUID
 	ElectionID   *uuid.UUID
+	BillID       *uuid.UUID
 	IsFeatured   *bool
 	Search       *string
 	ActiveOnly   bool
+	// Admin list only: include soft-deleted polls
+	IncludeDeleted bool
+	// One of the PollAttachment constants
+	AttachmentType *string
 	// Location filters
 	RegionID           *uuid.UUID
 	ProvinceID         *uuid.UUID
@@ -267,6 +306,35 @@ type PollResults struct {
 	Options    []PollOption `json:"options"`
 }
 
+// Bucket sizes for a poll's voting timeline
+const (
+	PollTimelineBucketHour = "hour"
+	PollTimelineBucketDay  = "day"
+)
+
+// PollVoteBucket is how many votes a poll received in one hour or day (UTC).
+// Buckets without votes between the first and last vote are included.
+type PollVoteBucket struct {
+	Bucket time.Time `json:"bucket"`
+	Votes  int       `json:"votes"`
+}
+
+// PollRegionResults is a poll's results among voters from one region. Votes
+// with no known region are grouped with a nil RegionID.
+type PollRegionResults struct {
+	RegionID   *uuid.UUID          `json:"region_id"`
+	RegionName *string             `json:"region_name"`
+	TotalVotes int                 `json:"total_votes"`
+	Options    []PollRegionOptions `json:"options"`
+}
+
+type PollRegionOptions struct {
+	OptionID   uuid.UUID `json:"option_id"`
+	Text       string    `json:"text"`
+	VoteCount  int       `json:"vote_count"`
+	Percentage float64   `json:"percentage"`
+}
+
 type VoteResponse struct {
 	Success bool         `json:"success"`
 	Message string       `json:"message"`
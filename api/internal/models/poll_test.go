@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollIsVotingOpen(t *testing.T) {
+	manila := time.FixedZone("Asia/Manila", 8*60*60)
+	now := time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC)
+	at := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name string
+		poll Poll
+		want bool
+	}{
+		{"active without window", Poll{Status: PollStatusActive}, true},
+		{"not active", Poll{Status: PollStatusClosed}, false},
+		{"scheduled", Poll{Status: PollStatusScheduled, StartsAt: at(now.Add(time.Hour))}, false},
+		{"starts in future", Poll{Status: PollStatusActive, StartsAt: at(now.Add(time.Second))}, false},
+		{"starts exactly now", Poll{Status: PollStatusActive, StartsAt: at(now)}, true},
+		{"ends exactly now", Poll{Status: PollStatusActive, EndsAt: at(now)}, false},
+		{"ends in future", Poll{Status: PollStatusActive, EndsAt: at(now.Add(time.Second))}, true},
+		// 08:00 in Manila is midnight UTC, so the poll has just ended
+		{"ends now in other zone", Poll{Status: PollStatusActive, EndsAt: at(time.Date(2025, 5, 12, 8, 0, 0, 0, manila))}, false},
+		// 08:00:01 in Manila is one second after midnight UTC
+		{"ends later in other zone", Poll{Status: PollStatusActive, EndsAt: at(time.Date(2025, 5, 12, 8, 0, 1, 0, manila))}, true},
+		// Same wall clock as now, but eight hours earlier in absolute time
+		{"starts earlier in other zone", Poll{Status: PollStatusActive, StartsAt: at(time.Date(2025, 5, 12, 0, 0, 0, 0, manila))}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.poll.IsVotingOpen(now))
+		})
+	}
+}
@@ -53,7 +53,7 @@ type PoliticianPositionHistory struct {
 type ElectionEventBrief struct {
 	ID           uuid.UUID `json:"id"`
 	Name         string    `json:"name"`
-	ElectionDate time.Time `json:"election_date"`
+	ElectionDate Date      `json:"election_date"`
 	Level        string    `json:"level"`
 }
 
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"math"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Referrer types an article view is attributed to
+const (
+	ReferrerDirect   = "direct"
+	ReferrerSearch   = "search"
+	ReferrerSocial   = "social"
+	ReferrerInternal = "internal"
+	ReferrerOther    = "other"
+)
+
+// ReferrerTypes lists every referrer type in display order
+var ReferrerTypes = []string{ReferrerDirect, ReferrerSearch, ReferrerSocial, ReferrerInternal, ReferrerOther}
+
+// searchDomains and socialDomains match the host itself or any subdomain
+var searchDomains = []string{
+	"bing.com", "yahoo.com", "duckduckgo.com", "baidu.com", "yandex.com", "yandex.ru",
+	"ecosia.org", "search.brave.com", "ask.com",
+}
+
+var socialDomains = []string{
+	"facebook.com", "fb.com", "fb.me", "messenger.com", "twitter.com", "x.com", "t.co",
+	"instagram.com", "threads.net", "linkedin.com", "lnkd.in", "reddit.com", "tiktok.com",
+	"youtube.com", "youtu.be", "pinterest.com", "tumblr.com", "telegram.org", "t.me",
+}
+
+// maxReferrerDomainLength matches article_referrers.referrer_domain
+const maxReferrerDomainLength = 255
+
+// ReferrerSource is where an article view came from
+type ReferrerSource struct {
+	Type   string
+	Domain string // empty for direct traffic
+}
+
+// ReferrerViews is a batch of views of one article from one referrer on one day
+type ReferrerViews struct {
+	ArticleID uuid.UUID
+	Date      string // YYYY-MM-DD
+	Source    ReferrerSource
+	Views     int64
+}
+
+// ClassifyReferrer sorts a Referer header into a referrer type. siteHost is
+// the site's own host; links from it count as internal. Missing or
+// unparseable referrers count as direct.
+func ClassifyReferrer(referer, siteHost string) ReferrerSource {
+	u, err := url.Parse(strings.TrimSpace(referer))
+	if err != nil || u.Hostname() == "" {
+		return ReferrerSource{Type: ReferrerDirect}
+	}
+
+	domain := normalizeHost(u.Hostname())
+	if len(domain) > maxReferrerDomainLength {
+		domain = strings.ToValidUTF8(domain[:maxReferrerDomainLength], "")
+	}
+
+	switch {
+	case siteHost != "" && matchesDomain(domain, normalizeHost(siteHost)):
+		return ReferrerSource{Type: ReferrerInternal, Domain: domain}
+	case u.Scheme != "http" && u.Scheme != "https":
+		// e.g. android-app:// referrers from mail and chat apps
+		return ReferrerSource{Type: ReferrerOther, Domain: domain}
+	case isGoogle(domain) || matchesAny(domain, searchDomains):
+		return ReferrerSource{Type: ReferrerSearch, Domain: domain}
+	case matchesAny(domain, socialDomains):
+		return ReferrerSource{Type: ReferrerSocial, Domain: domain}
+	default:
+		return ReferrerSource{Type: ReferrerOther, Domain: domain}
+	}
+}
+
+// normalizeHost lowercases a host and drops a leading "www."
+func normalizeHost(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
+
+func matchesDomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+func matchesAny(host string, domains []string) bool {
+	for _, d := range domains {
+		if matchesDomain(host, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGoogle matches Google search on any country domain (google.com,
+// google.com.ph, google.co.uk), but not other Google products
+func isGoogle(host string) bool {
+	return strings.HasPrefix(host, "google.")
+}
+
+// ReferrerTypeCount is one slice of a referrer breakdown
+type ReferrerTypeCount struct {
+	Type       string  `json:"type"`
+	Views      int     `json:"views"`
+	Percentage float64 `json:"percentage"`
+}
+
+// ReferrerDomainCount is the views from a single referring domain
+type ReferrerDomainCount struct {
+	Domain string `json:"domain"`
+	Type   string `json:"type"`
+	Views  int    `json:"views"`
+}
+
+// ReferrerBreakdown splits views over a period by referrer type, ready to
+// chart. Types lists every type, including those with no views.
+type ReferrerBreakdown struct {
+	Days       int                   `json:"days"`
+	TotalViews int                   `json:"total_views"`
+	Types      []ReferrerTypeCount   `json:"types"`
+	TopDomains []ReferrerDomainCount `json:"top_domains"`
+}
+
+// NewReferrerBreakdown fills in every referrer type from per-type view counts
+// and computes each type's share of the total
+func NewReferrerBreakdown(days int, views map[string]int, topDomains []ReferrerDomainCount) *ReferrerBreakdown {
+	breakdown := &ReferrerBreakdown{Days: days, TopDomains: topDomains}
+	for _, t := range ReferrerTypes {
+		breakdown.TotalViews += views[t]
+	}
+
+	breakdown.Types = make([]ReferrerTypeCount, 0, len(ReferrerTypes))
+	for _, t := range ReferrerTypes {
+		count := ReferrerTypeCount{Type: t, Views: views[t]}
+		if breakdown.TotalViews > 0 {
+			// Rounded to one decimal place
+			count.Percentage = math.Round(float64(views[t])*1000/float64(breakdown.TotalViews)) / 10
+		}
+		breakdown.Types = append(breakdown.Types, count)
+	}
+
+	if breakdown.TopDomains == nil {
+		breakdown.TopDomains = []ReferrerDomainCount{}
+	}
+	return breakdown
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyReferrer(t *testing.T) {
+	const site = "pulpulitiko.com"
+
+	for referer, want := range map[string]ReferrerSource{
+		"":                                     {Type: ReferrerDirect},
+		"not a url":                            {Type: ReferrerDirect},
+		"https://www.google.com/":              {Type: ReferrerSearch, Domain: "google.com"},
+		"https://www.google.com.ph/search?q=x": {Type: ReferrerSearch, Domain: "google.com.ph"},
+		"https://duckduckgo.com/":              {Type: ReferrerSearch, Domain: "duckduckgo.com"},
+		"https://search.yahoo.com/search":      {Type: ReferrerSearch, Domain: "search.yahoo.com"},
+		"https://l.facebook.com/l.php?u=x":     {Type: ReferrerSocial, Domain: "l.facebook.com"},
+		"https://t.co/abc":                     {Type: ReferrerSocial, Domain: "t.co"},
+		"https://X.com/someone/status/1":       {Type: ReferrerSocial, Domain: "x.com"},
+		"https://pulpulitiko.com/articles/a":   {Type: ReferrerInternal, Domain: "pulpulitiko.com"},
+		"https://www.pulpulitiko.com/":         {Type: ReferrerInternal, Domain: "pulpulitiko.com"},
+		"https://news.example.ph/story":        {Type: ReferrerOther, Domain: "news.example.ph"},
+		"https://notfacebook.com/":             {Type: ReferrerOther, Domain: "notfacebook.com"},
+		"https://docs.google.com/document/d/1": {Type: ReferrerOther, Domain: "docs.google.com"},
+		"android-app://com.google.android.gm/": {Type: ReferrerOther, Domain: "com.google.android.gm"},
+	} {
+		assert.Equal(t, want, ClassifyReferrer(referer, site), referer)
+	}
+
+	t.Run("without a site host nothing is internal", func(t *testing.T) {
+		assert.Equal(t, ReferrerOther, ClassifyReferrer("https://pulpulitiko.com/", "").Type)
+	})
+}
+
+func TestNewReferrerBreakdown(t *testing.T) {
+	breakdown := NewReferrerBreakdown(30, map[string]int{ReferrerSearch: 2, ReferrerSocial: 1}, nil)
+
+	assert.Equal(t, 3, breakdown.TotalViews)
+	assert.Len(t, breakdown.Types, len(ReferrerTypes))
+	assert.Equal(t, ReferrerTypeCount{Type: ReferrerDirect}, breakdown.Types[0])
+	assert.Equal(t, ReferrerTypeCount{Type: ReferrerSearch, Views: 2, Percentage: 66.7}, breakdown.Types[1])
+	assert.Equal(t, ReferrerTypeCount{Type: ReferrerSocial, Views: 1, Percentage: 33.3}, breakdown.Types[2])
+	assert.NotNil(t, breakdown.TopDomains)
+
+	empty := NewReferrerBreakdown(30, nil, nil)
+	assert.Zero(t, empty.TotalViews)
+	assert.Zero(t, empty.Types[0].Percentage)
+}
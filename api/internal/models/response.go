@@ -7,8 +7,9 @@ type APIResponse struct {
 }
 
 type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"` // Matches the request's log line
 }
 
 func SuccessResponse(data interface{}) APIResponse {
@@ -58,6 +58,11 @@ type UpdateRoleRequest struct {
 	PermissionIDs []string `json:"permission_ids,omitempty"`
 }
 
+// SetRolePermissionsRequest replaces a role's permissions with the given set
+type SetRolePermissionsRequest struct {
+	PermissionIDs []uuid.UUID `json:"permission_ids" validate:"required"`
+}
+
 // PermissionCategory groups permissions by category for display
 type PermissionCategory struct {
 	Category    string       `json:"category"`
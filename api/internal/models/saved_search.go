@@ -0,0 +1,105 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleSearchParams are the admin article list filters. They are parsed from
+// the AdminList query string and stored as-is on saved searches, so both go
+// through Filter and the same repository query.
+type ArticleSearchParams struct {
+	Status        *ArticleStatus `json:"status,omitempty" validate:"omitempty,oneof=draft published archived"`
+	CategoryID    *uuid.UUID     `json:"category_id,omitempty"`
+	TagID         *uuid.UUID     `json:"tag_id,omitempty"`
+	AuthorID      *uuid.UUID     `json:"author_id,omitempty"`
+	PoliticianID  *uuid.UUID     `json:"politician_id,omitempty"`
+	Search        *string        `json:"search,omitempty" validate:"omitempty,max=200"`
+	OlderThanDays *int           `json:"older_than_days,omitempty" validate:"omitempty,min=1,max=3650"`
+	// Publish date bounds as YYYY-MM-DD, both inclusive
+	PublishedAfter  *string      `json:"published_after,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	PublishedBefore *string      `json:"published_before,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Sort            *ArticleSort `json:"sort,omitempty" validate:"omitempty,oneof=newest oldest most_viewed most_commented"`
+}
+
+// ArticleDateLayout is the format of the published_after and published_before filters
+const ArticleDateLayout = "2006-01-02"
+
+// ParseArticleDate parses an optional YYYY-MM-DD filter date. Invalid dates
+// parse as nil; callers validate the format first.
+func ParseArticleDate(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t, err := time.Parse(ArticleDateLayout, *value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// ValidatePublishedRange rejects a range whose start is after its end
+func (p ArticleSearchParams) ValidatePublishedRange() error {
+	after, before := ParseArticleDate(p.PublishedAfter), ParseArticleDate(p.PublishedBefore)
+	if after != nil && before != nil && after.After(*before) {
+		return fmt.Errorf("published_after cannot be after published_before")
+	}
+	return nil
+}
+
+// IsEmpty reports whether the parameters match every article. Sort does not
+// narrow the match.
+func (p ArticleSearchParams) IsEmpty() bool {
+	return p.Status == nil && p.CategoryID == nil && p.TagID == nil && p.AuthorID == nil &&
+		p.PoliticianID == nil && (p.Search == nil || *p.Search == "") && p.OlderThanDays == nil &&
+		p.PublishedAfter == nil && p.PublishedBefore == nil
+}
+
+// Filter converts the parameters into an ArticleFilter
+func (p ArticleSearchParams) Filter() *ArticleFilter {
+	filter := &ArticleFilter{
+		Status:          p.Status,
+		CategoryID:      p.CategoryID,
+		TagID:           p.TagID,
+		AuthorID:        p.AuthorID,
+		PoliticianID:    p.PoliticianID,
+		Search:          p.Search,
+		OlderThanDays:   p.OlderThanDays,
+		PublishedAfter:  ParseArticleDate(p.PublishedAfter),
+		PublishedBefore: ParseArticleDate(p.PublishedBefore),
+	}
+	if p.Sort != nil {
+		filter.Sort = *p.Sort
+	}
+	return filter
+}
+
+// SavedSearch is a named admin article search owned by an editor
+type SavedSearch struct {
+	ID             uuid.UUID           `json:"id"`
+	UserID         uuid.UUID           `json:"user_id"`
+	Name           string              `json:"name"`
+	Params         ArticleSearchParams `json:"params"`
+	AlertEnabled   bool                `json:"alert_enabled"`
+	AlertThreshold int                 `json:"alert_threshold"` // alert when the result count exceeds this
+	IsBroken       bool                `json:"is_broken"`
+	BrokenReason   *string             `json:"broken_reason,omitempty"`
+	LastAlertedAt  *time.Time          `json:"last_alerted_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// SavedSearchAlert is an alerting search along with its owner's email
+type SavedSearchAlert struct {
+	SavedSearch
+	OwnerEmail string
+}
+
+type CreateSavedSearchRequest struct {
+	Name           string              `json:"name" validate:"required,min=1,max=200"`
+	Params         ArticleSearchParams `json:"params"`
+	AlertEnabled   bool                `json:"alert_enabled"`
+	AlertThreshold int                 `json:"alert_threshold" validate:"min=0"`
+}
@@ -7,11 +7,12 @@ import (
 )
 
 type Tag struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	Slug      string    `json:"slug"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // Admin list with include_deleted only
 }
 
 type CreateTagRequest struct {
@@ -25,9 +26,10 @@ type UpdateTagRequest struct {
 }
 
 type TagFilter struct {
-	Search    *string
-	SortBy    *string // name, created_at
-	SortOrder *string // asc, desc
+	Search         *string
+	SortBy         *string // name, created_at
+	SortOrder      *string // asc, desc
+	IncludeDeleted bool
 }
 
 type PaginatedTags struct {
@@ -37,3 +39,21 @@ type PaginatedTags struct {
 	PerPage    int   `json:"per_page"`
 	TotalPages int   `json:"total_pages"`
 }
+
+// FollowStatus summarizes followers of a tag or category for the current viewer
+type FollowStatus struct {
+	FollowerCount int  `json:"follower_count"`
+	IsFollowing   bool `json:"is_following"`
+}
+
+// MergeTagRequest merges a tag into another, which keeps its articles and
+// followers and answers to its slug from then on
+type MergeTagRequest struct {
+	IntoTagID string `json:"into_tag_id" validate:"required,uuid"`
+}
+
+// TagMergeResult is the tag another was merged into
+type TagMergeResult struct {
+	Tag           *Tag `json:"tag"`
+	ArticlesMoved int  `json:"articles_moved"` // Articles that had the merged tag
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// BulkTagPreviewSize is how many matching articles a bulk tag preview lists
+const BulkTagPreviewSize = 20
+
+// BulkTagRequest picks the articles a tag is assigned to or removed from:
+// either explicit IDs, or a filter read the way the admin article list reads
+// its query parameters
+type BulkTagRequest struct {
+	ArticleIDs []string             `json:"article_ids,omitempty" validate:"omitempty,max=1000,unique,dive,uuid"`
+	Filter     *ArticleSearchParams `json:"filter,omitempty"`
+}
+
+// Validate checks that exactly one way of picking articles is used. A filter
+// must narrow the match, so an empty one cannot tag every article at once.
+func (r *BulkTagRequest) Validate() error {
+	switch {
+	case len(r.ArticleIDs) > 0 && r.Filter != nil:
+		return fmt.Errorf("use either article_ids or filter, not both")
+	case len(r.ArticleIDs) == 0 && r.Filter == nil:
+		return fmt.Errorf("article_ids or filter is required")
+	case r.Filter != nil:
+		if r.Filter.IsEmpty() {
+			return fmt.Errorf("filter must set at least one condition")
+		}
+		return r.Filter.ValidatePublishedRange()
+	}
+	return nil
+}
+
+// BulkTagResult is the outcome of a bulk tag assignment or removal
+type BulkTagResult struct {
+	Affected int `json:"affected"` // Articles that gained or lost the tag
+}
+
+// BulkTagPreview is what a bulk tag change would touch, without making it.
+// Articles that already have the tag (or, for removal, lack it) are left out.
+type BulkTagPreview struct {
+	Count    int            `json:"count"`
+	Articles []ArticleTitle `json:"articles"` // The first BulkTagPreviewSize, newest first
+}
+
+// ArticleTitle identifies an article in a preview
+type ArticleTitle struct {
+	ID    uuid.UUID `json:"id"`
+	Slug  string    `json:"slug"`
+	Title string    `json:"title"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkTagRequestValidate(t *testing.T) {
+	search := "impeachment"
+	empty := ""
+	after, before := "2025-02-01", "2025-01-01"
+
+	for name, tc := range map[string]struct {
+		req     BulkTagRequest
+		wantErr string
+	}{
+		"article IDs":          {req: BulkTagRequest{ArticleIDs: []string{"5f0c7c1e-8d0b-4a43-9c52-3a1f0e6d2b11"}}},
+		"filter":               {req: BulkTagRequest{Filter: &ArticleSearchParams{Search: &search}}},
+		"neither":              {wantErr: "article_ids or filter is required"},
+		"both":                 {req: BulkTagRequest{ArticleIDs: []string{"x"}, Filter: &ArticleSearchParams{Search: &search}}, wantErr: "use either article_ids or filter, not both"},
+		"filter matching all":  {req: BulkTagRequest{Filter: &ArticleSearchParams{Search: &empty}}, wantErr: "filter must set at least one condition"},
+		"backwards date range": {req: BulkTagRequest{Filter: &ArticleSearchParams{PublishedAfter: &after, PublishedBefore: &before}}, wantErr: "published_after cannot be after published_before"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.req.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
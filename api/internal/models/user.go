@@ -14,6 +14,7 @@ type User struct {
 	Avatar       *string    `json:"avatar,omitempty"`
 	RoleID       *uuid.UUID `json:"role_id,omitempty"`
 	RoleSlug     string     `json:"role"` // Populated from join with roles table
+	IsMember     bool       `json:"is_member"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
 	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
@@ -65,11 +66,18 @@ type ResetPasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8"`
 }
 
+// SetMembershipRequest grants or revokes a user's membership, which unlocks
+// members-only articles
+type SetMembershipRequest struct {
+	IsMember *bool `json:"is_member" validate:"required"`
+}
+
 type UserFilter struct {
-	Search    *string
-	RoleSlug  *string
-	SortBy    *string // name, email, created_at
-	SortOrder *string // asc, desc
+	Search         *string
+	RoleSlug       *string
+	SortBy         *string // name, email, created_at
+	SortOrder      *string // asc, desc
+	IncludeDeleted bool
 }
 
 type PaginatedUsers struct {
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BlockedUser is an entry in the current user's block list
+type BlockedUser struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Avatar    *string   `json:"avatar,omitempty"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
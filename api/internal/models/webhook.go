@@ -0,0 +1,136 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook event types
+const (
+	WebhookEventArticlePublished       = "article.published"
+	WebhookEventBillStatusChanged      = "bill.status_changed"
+	WebhookEventElectionResultsUpdated = "election.results_updated"
+)
+
+// WebhookEventTypes lists the events a webhook can subscribe to
+var WebhookEventTypes = []string{
+	WebhookEventArticlePublished,
+	WebhookEventBillStatusChanged,
+	WebhookEventElectionResultsUpdated,
+}
+
+// IsValidWebhookEvent checks if an event type can be subscribed to
+func IsValidWebhookEvent(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead" // Gave up after MaxWebhookAttempts failures
+)
+
+// MaxWebhookAttempts is how many times a delivery is tried before it is
+// dead-lettered
+const MaxWebhookAttempts = 8
+
+// Webhook is an integrator's endpoint and the events it wants
+type Webhook struct {
+	ID          uuid.UUID `json:"id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"` // Only returned when the webhook is created
+	EventTypes  []string  `json:"event_types"`
+	Description *string   `json:"description,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookEvent is the JSON body POSTed to webhooks. ID is the same for every
+// webhook the event goes to and across retries.
+type WebhookEvent struct {
+	ID        uuid.UUID   `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookDelivery is one event queued for, or sent to, one webhook
+type WebhookDelivery struct {
+	ID             uuid.UUID  `json:"id"`
+	WebhookID      uuid.UUID  `json:"webhook_id"`
+	EventID        uuid.UUID  `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Payload        []byte     `json:"-"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastStatusCode *int       `json:"last_status_code,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	// Joined fields, for sending
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+// WebhookRetryDelay is how long to wait after a delivery's attempts-th
+// failure: a minute, doubling each time, up to six hours
+func WebhookRetryDelay(attempts int) time.Duration {
+	const maxDelay = 6 * time.Hour
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 10 {
+		return maxDelay
+	}
+	return min(time.Minute<<(attempts-1), maxDelay)
+}
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=500"`
+	Secret      string   `json:"secret,omitempty" validate:"omitempty,min=16,max=255"` // Generated when empty
+	EventTypes  []string `json:"event_types" validate:"required,min=1"`
+	Description *string  `json:"description,omitempty"`
+	IsActive    *bool    `json:"is_active,omitempty"` // Defaults to true
+}
+
+type UpdateWebhookRequest struct {
+	URL         *string  `json:"url,omitempty" validate:"omitempty,url,max=500"`
+	Secret      *string  `json:"secret,omitempty" validate:"omitempty,min=16,max=255"`
+	EventTypes  []string `json:"event_types,omitempty" validate:"omitempty,min=1"`
+	Description *string  `json:"description,omitempty"`
+	IsActive    *bool    `json:"is_active,omitempty"`
+}
+
+// Event data
+
+type ArticlePublishedEvent struct {
+	ArticleID   uuid.UUID  `json:"article_id"`
+	Slug        string     `json:"slug"`
+	Title       string     `json:"title"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+type BillStatusChangedEvent struct {
+	BillID         uuid.UUID `json:"bill_id"`
+	Slug           string    `json:"slug"`
+	Title          string    `json:"title"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+}
+
+// ElectionResultsUpdatedEvent is sent when an election's turnout or a
+// candidate's result changes. CandidateID is set for candidate results.
+type ElectionResultsUpdatedEvent struct {
+	ElectionID  uuid.UUID  `json:"election_id"`
+	CandidateID *uuid.UUID `json:"candidate_id,omitempty"`
+}
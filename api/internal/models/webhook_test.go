@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{5, 16 * time.Minute},
+		{9, 256 * time.Minute},
+		{10, 6 * time.Hour},
+		{40, 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, WebhookRetryDelay(tt.attempts), "attempts=%d", tt.attempts)
+	}
+}
+
+func TestIsValidWebhookEvent(t *testing.T) {
+	assert.True(t, IsValidWebhookEvent(WebhookEventBillStatusChanged))
+	assert.False(t, IsValidWebhookEvent("article.deleted"))
+}
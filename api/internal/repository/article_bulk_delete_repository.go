@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// BulkSoftDelete deletes the articles that aren't deleted yet and returns
+// them. The batch is one statement, so it is applied all at once or not at all.
+func (r *ArticleRepository) BulkSoftDelete(ctx context.Context, ids []uuid.UUID) ([]models.ArticleTitle, error) {
+	articles, err := r.setArticlesDeleted(ctx, `
+		UPDATE articles SET deleted_at = NOW()
+		WHERE id = ANY($1) AND deleted_at IS NULL
+		RETURNING id, slug, title
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete articles: %w", err)
+	}
+	return articles, nil
+}
+
+// BulkRestore restores the articles that are deleted and returns them
+func (r *ArticleRepository) BulkRestore(ctx context.Context, ids []uuid.UUID) ([]models.ArticleTitle, error) {
+	articles, err := r.setArticlesDeleted(ctx, `
+		UPDATE articles SET deleted_at = NULL
+		WHERE id = ANY($1) AND deleted_at IS NOT NULL
+		RETURNING id, slug, title
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore articles: %w", err)
+	}
+	return articles, nil
+}
+
+func (r *ArticleRepository) setArticlesDeleted(ctx context.Context, query string, ids []uuid.UUID) ([]models.ArticleTitle, error) {
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	articles := []models.ArticleTitle{}
+	for rows.Next() {
+		var a models.ArticleTitle
+		if err := rows.Scan(&a.ID, &a.Slug, &a.Title); err != nil {
+			return nil, err
+		}
+		articles = append(articles, a)
+	}
+	return articles, rows.Err()
+}
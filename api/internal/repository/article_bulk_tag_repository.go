@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// bulkTagBatchSize is how many articles each statement of a bulk tag change
+// covers
+const bulkTagBatchSize = 500
+
+// BulkTagTargets lists the articles a bulk tag change would touch: those
+// picked by articleIDs or by filter that lack the tag when assigning, or have
+// it when removing. Deleted articles are never picked.
+func (r *ArticleRepository) BulkTagTargets(ctx context.Context, tagID uuid.UUID, assign bool, articleIDs []uuid.UUID, filter *models.ArticleFilter) ([]models.ArticleTitle, error) {
+	where, args := "a.deleted_at IS NULL", []interface{}{}
+	if filter != nil {
+		where, args = articleFilterWhere(filter)
+	}
+	if articleIDs != nil {
+		args = append(args, articleIDs)
+		where += fmt.Sprintf(" AND a.id = ANY($%d)", len(args))
+	}
+
+	tagged := "EXISTS"
+	if assign {
+		tagged = "NOT EXISTS"
+	}
+	args = append(args, tagID)
+	where += fmt.Sprintf(" AND %s (SELECT 1 FROM article_tags at WHERE at.article_id = a.id AND at.tag_id = $%d)", tagged, len(args))
+
+	rows, err := r.db.Query(ctx, fmt.Sprintf(`
+		SELECT a.id, a.slug, a.title FROM articles a
+		WHERE %s
+		ORDER BY %s
+	`, where, articleOrderBy(filter)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bulk tag targets: %w", err)
+	}
+	defer rows.Close()
+
+	targets := []models.ArticleTitle{}
+	for rows.Next() {
+		var t models.ArticleTitle
+		if err := rows.Scan(&t.ID, &t.Slug, &t.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan bulk tag target: %w", err)
+		}
+		targets = append(targets, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list bulk tag targets: %w", err)
+	}
+	return targets, nil
+}
+
+// SetArticlesTag adds the tag to, or removes it from, the articles in batches
+// inside one transaction, so a failure leaves every article as it was. It
+// returns how many articles changed.
+func (r *ArticleRepository) SetArticlesTag(ctx context.Context, tagID uuid.UUID, articleIDs []uuid.UUID, assign bool) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	affected := 0
+	for start := 0; start < len(articleIDs); start += bulkTagBatchSize {
+		batch := articleIDs[start:min(start+bulkTagBatchSize, len(articleIDs))]
+
+		var result pgconn.CommandTag
+		if assign {
+			result, err = tx.Exec(ctx, `
+				INSERT INTO article_tags (article_id, tag_id)
+				SELECT id, $2 FROM unnest($1::uuid[]) AS id
+				ON CONFLICT DO NOTHING
+			`, batch, tagID)
+		} else {
+			result, err = tx.Exec(ctx, "DELETE FROM article_tags WHERE tag_id = $2 AND article_id = ANY($1)", batch, tagID)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to update article tags: %w", err)
+		}
+		affected += int(result.RowsAffected())
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit article tags: %w", err)
+	}
+	return affected, nil
+}
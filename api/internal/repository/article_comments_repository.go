@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdateCategoryCommentSettings applies comment setting updates to the
+// category's articles, only those published before publishedBefore when it
+// is set. It returns the slugs of the updated articles by ID.
+func (r *ArticleRepository) UpdateCategoryCommentSettings(ctx context.Context, categoryID uuid.UUID, publishedBefore *time.Time, updates map[string]interface{}) (map[uuid.UUID]string, error) {
+	columns := make([]string, 0, len(updates))
+	for column := range updates {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	setClauses := make([]string, len(columns))
+	args := []interface{}{categoryID, publishedBefore}
+	for i, column := range columns {
+		args = append(args, updates[column])
+		setClauses[i] = fmt.Sprintf("%s = $%d", column, len(args))
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE articles SET %s, updated_at = NOW()
+		WHERE category_id = $1 AND deleted_at IS NULL
+		  AND ($2::timestamptz IS NULL OR published_at < $2)
+		RETURNING id, slug
+	`, strings.Join(setClauses, ", "))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment settings: %w", err)
+	}
+	defer rows.Close()
+
+	updated := make(map[uuid.UUID]string)
+	for rows.Next() {
+		var id uuid.UUID
+		var slug string
+		if err := rows.Scan(&id, &slug); err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		updated[id] = slug
+	}
+
+	return updated, rows.Err()
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// SetArticleExplainers records the explainer slugs an article's content
+// embeds, replacing what was recorded before
+func (r *ArticleRepository) SetArticleExplainers(ctx context.Context, articleID uuid.UUID, slugs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, "DELETE FROM article_explainers WHERE article_id = $1", articleID); err != nil {
+		return fmt.Errorf("failed to clear article explainers: %w", err)
+	}
+	if len(slugs) > 0 {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO article_explainers (article_id, explainer_slug)
+			SELECT $1, slug FROM unnest($2::text[]) AS slug
+			ON CONFLICT DO NOTHING
+		`, articleID, slugs)
+		if err != nil {
+			return fmt.Errorf("failed to set article explainers: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmbeddedExplainers returns the explainers with the given slugs, in the
+// order of slugs. Slugs without an explainer are skipped.
+func (r *ArticleRepository) GetEmbeddedExplainers(ctx context.Context, slugs []string) ([]models.EmbeddedExplainer, error) {
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT e.slug, e.title, e.body, e.updated_at
+		FROM unnest($1::text[]) WITH ORDINALITY AS s(slug, ord)
+		JOIN explainers e ON e.slug = s.slug
+		ORDER BY s.ord
+	`, slugs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embedded explainers: %w", err)
+	}
+	defer rows.Close()
+
+	var explainers []models.EmbeddedExplainer
+	for rows.Next() {
+		var e models.EmbeddedExplainer
+		if err := rows.Scan(&e.Slug, &e.Title, &e.Body, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan explainer: %w", err)
+		}
+		explainers = append(explainers, e)
+	}
+
+	return explainers, rows.Err()
+}
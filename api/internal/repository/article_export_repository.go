@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// CountForExport counts the articles an export with the filter would list
+func (r *ArticleRepository) CountForExport(ctx context.Context, filter *models.ArticleFilter) (int, error) {
+	where, args := articleFilterWhere(filter)
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM articles a WHERE "+where, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count articles: %w", err)
+	}
+	return total, nil
+}
+
+// EachForExport calls fn with every article matching the filter, in the
+// admin list's order. Rows are read from the database as fn consumes them,
+// so memory stays bounded however many match. At most limit rows are read.
+func (r *ArticleRepository) EachForExport(ctx context.Context, filter *models.ArticleFilter, limit int, fn func(*models.ArticleExportRow) error) error {
+	where, args := articleFilterWhere(filter)
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT a.id, a.title, a.slug, a.status, au.name, c.name, a.published_at, a.view_count,
+		       (SELECT COUNT(*) FROM comments cm
+		        WHERE cm.article_id = a.id AND cm.deleted_at IS NULL AND cm.status = 'active')
+		FROM articles a
+		LEFT JOIN authors au ON a.author_id = au.id
+		LEFT JOIN categories c ON a.category_id = c.id
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d
+	`, where, articleOrderBy(filter), len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to export articles: %w", err)
+	}
+	defer rows.Close()
+
+	var row models.ArticleExportRow
+	for rows.Next() {
+		err := rows.Scan(
+			&row.ID, &row.Title, &row.Slug, &row.Status, &row.Author, &row.Category, &row.PublishedAt, &row.ViewCount,
+			&row.CommentCount,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan exported article: %w", err)
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to export articles: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// feedArticleIDs lists the articles matching anything user $1 follows: the
+// category, a tag, a mentioned politician, or an author. An article matching
+// more than one follow appears more than once.
+const feedArticleIDs = `
+	SELECT a.id FROM articles a
+	JOIN user_follows f ON f.entity_type = 'category' AND f.entity_id = a.category_id
+	WHERE f.user_id = $1
+	UNION ALL
+	SELECT at.article_id FROM article_tags at
+	JOIN user_follows f ON f.entity_type = 'tag' AND f.entity_id = at.tag_id
+	WHERE f.user_id = $1
+	UNION ALL
+	SELECT a.id FROM articles a
+	JOIN user_follows f ON f.entity_type = 'politician' AND f.entity_id = a.primary_politician_id
+	WHERE f.user_id = $1
+	UNION ALL
+	SELECT ap.article_id FROM article_politicians ap
+	JOIN user_follows f ON f.entity_type = 'politician' AND f.entity_id = ap.politician_id
+	WHERE f.user_id = $1
+	UNION ALL
+	SELECT a.id FROM articles a
+	JOIN user_follows f ON f.entity_type = 'author' AND f.entity_id = a.author_id
+	WHERE f.user_id = $1
+	UNION ALL
+	SELECT aa.article_id FROM article_authors aa
+	JOIN user_follows f ON f.entity_type = 'author' AND f.entity_id = aa.author_id
+	WHERE f.user_id = $1`
+
+// GetFeed lists published articles matching anything the user follows, each
+// once, newest first
+func (r *ArticleRepository) GetFeed(ctx context.Context, userID uuid.UUID, page, perPage int) (*models.PaginatedArticles, error) {
+	where := fmt.Sprintf(`a.id IN (SELECT DISTINCT id FROM (%s) followed(id))
+		AND a.status = 'published' AND a.deleted_at IS NULL AND a.published_at <= NOW() AND %s`,
+		feedArticleIDs, notInInternalCategory)
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM articles a WHERE "+where, userID).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count feed articles: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT a.id, a.slug, a.title, a.summary, a.featured_image, a.cover_image, a.status, a.access_level, a.is_premium, a.view_count, a.published_at, a.created_at,
+			   au.name, au.slug, au.avatar, c.name, c.slug, p.name, p.slug, %s
+		FROM articles a
+		LEFT JOIN authors au ON a.author_id = au.id
+		LEFT JOIN categories c ON a.category_id = c.id
+		LEFT JOIN politicians p ON a.primary_politician_id = p.id
+		WHERE %s
+		ORDER BY a.published_at DESC, a.id DESC
+		LIMIT $2 OFFSET $3
+	`, articleCitationCount, where)
+
+	rows, err := r.db.Query(ctx, query, userID, perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []models.ArticleListItem{}
+	for rows.Next() {
+		var article models.ArticleListItem
+		err := rows.Scan(
+			&article.ID, &article.Slug, &article.Title, &article.Summary, &article.FeaturedImage, &article.CoverImage,
+			&article.Status, &article.AccessLevel, &article.IsPremium, &article.ViewCount, &article.PublishedAt, &article.CreatedAt,
+			&article.AuthorName, &article.AuthorSlug, &article.AuthorAvatar, &article.CategoryName, &article.CategorySlug,
+			&article.PrimaryPoliticianName, &article.PrimaryPoliticianSlug, &article.CitationCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		articles = append(articles, article)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+	rows.Close()
+
+	if err := r.attachAuthors(ctx, articles); err != nil {
+		return nil, err
+	}
+
+	return &models.PaginatedArticles{
+		Articles:   articles,
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: (total + perPage - 1) / perPage,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArticleRepository_GetFeed(t *testing.T) {
+	ctx := context.Background()
+	pool := openTestDB(t)
+
+	suffix := uuid.NewString()[:8]
+	insert := func(query string, args ...interface{}) uuid.UUID {
+		var id uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, query, args...).Scan(&id))
+		return id
+	}
+	article := func(slug, status string, categoryID *uuid.UUID, age string) uuid.UUID {
+		return insert(`
+			INSERT INTO articles (slug, title, content, status, category_id, published_at)
+			VALUES ($1, $1, 'body', $2, $3, NOW() - $4::interval) RETURNING id
+		`, slug+"-"+suffix, status, categoryID, age)
+	}
+
+	userID := insert("INSERT INTO users (email, password_hash, name) VALUES ($1, 'x', 'Reader') RETURNING id", "feed-"+suffix+"@example.com")
+	categoryID := insert("INSERT INTO categories (name, slug) VALUES ($1, $1) RETURNING id", "feed-"+suffix)
+	tagID := insert("INSERT INTO tags (name, slug) VALUES ($1, $1) RETURNING id", "feed-"+suffix)
+
+	both := article("both", "published", &categoryID, "1 hour")
+	tagged := article("tagged", "published", nil, "2 hours")
+	draft := article("draft", "draft", &categoryID, "3 hours")
+	other := article("other", "published", nil, "4 hours")
+	for _, a := range []uuid.UUID{both, tagged} {
+		_, err := pool.Exec(ctx, "INSERT INTO article_tags (article_id, tag_id) VALUES ($1, $2)", a, tagID)
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM articles WHERE id = ANY($1)", []uuid.UUID{both, tagged, draft, other})
+		_, _ = pool.Exec(ctx, "DELETE FROM tags WHERE id = $1", tagID)
+		_, _ = pool.Exec(ctx, "DELETE FROM categories WHERE id = $1", categoryID)
+		_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = $1", userID)
+	})
+
+	follows := NewFollowRepository(pool)
+	require.NoError(t, follows.Follow(ctx, userID, models.FollowEntityCategory, categoryID))
+	require.NoError(t, follows.Follow(ctx, userID, models.FollowEntityTag, tagID))
+	require.NoError(t, follows.Follow(ctx, userID, models.FollowEntityTag, tagID))
+
+	feed, err := NewArticleRepository(pool).GetFeed(ctx, userID, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 2, feed.Total)
+
+	var ids []uuid.UUID
+	for _, a := range feed.Articles {
+		ids = append(ids, a.ID)
+	}
+	// Matching both the category and the tag lists the article once; drafts
+	// and unfollowed articles are left out
+	assert.Equal(t, []uuid.UUID{both, tagged}, ids)
+
+	require.NoError(t, follows.Unfollow(ctx, userID, models.FollowEntityCategory, categoryID))
+	feed, err = NewArticleRepository(pool).GetFeed(ctx, userID, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 2, feed.Total, "both is still tagged")
+
+	status, err := follows.GetFollowStatus(ctx, models.FollowEntityTag, tagID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, models.FollowStatus{FollowerCount: 1, IsFollowing: true}, *status)
+}
@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const articleMediaColumns = `
+	id, article_id, url, thumb_url, full_url, caption, credit, position, is_featured, is_provisional, created_at, updated_at
+`
+
+func scanArticleMedia(row pgx.Row) (*models.ArticleMedia, error) {
+	m := &models.ArticleMedia{}
+	var thumb, full *string
+	err := row.Scan(
+		&m.ID, &m.ArticleID, &m.URL, &thumb, &full, &m.Caption, &m.Credit, &m.Position,
+		&m.IsFeatured, &m.IsProvisional, &m.CreatedAt, &m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.Images = models.NewArticleImages(thumb, full)
+	return m, nil
+}
+
+// GetArticleMedia returns an article's gallery in display order
+func (r *ArticleRepository) GetArticleMedia(ctx context.Context, articleID uuid.UUID) ([]models.ArticleMedia, error) {
+	query := `
+		SELECT ` + articleMediaColumns + `
+		FROM article_media
+		WHERE article_id = $1
+		ORDER BY position, created_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article media: %w", err)
+	}
+	defer rows.Close()
+
+	gallery := []models.ArticleMedia{}
+	for rows.Next() {
+		m, err := scanArticleMedia(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan article media: %w", err)
+		}
+		gallery = append(gallery, *m)
+	}
+
+	return gallery, rows.Err()
+}
+
+func (r *ArticleRepository) GetArticleMediaItem(ctx context.Context, id uuid.UUID) (*models.ArticleMedia, error) {
+	query := `SELECT ` + articleMediaColumns + ` FROM article_media WHERE id = $1`
+
+	m, err := scanArticleMedia(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article media: %w", err)
+	}
+
+	return m, nil
+}
+
+// CreateArticleMedia adds an item to the end of an article's gallery.
+// Provisional items come from the featured image backfill.
+func (r *ArticleRepository) CreateArticleMedia(ctx context.Context, articleID uuid.UUID, req *models.CreateArticleMediaRequest, provisional bool) (*models.ArticleMedia, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Locking the article serializes gallery edits, so positions and the
+	// featured item stay consistent
+	var exists bool
+	err = tx.QueryRow(ctx, `
+		SELECT TRUE FROM articles WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+	`, articleID).Scan(&exists)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("article not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article: %w", err)
+	}
+
+	if req.IsFeatured {
+		if err := unfeatureArticleMedia(ctx, tx, articleID); err != nil {
+			return nil, err
+		}
+	}
+
+	var thumb, full *string
+	if req.Images != nil {
+		thumb, full = &req.Images.Thumb, &req.Images.Full
+	}
+	m, err := scanArticleMedia(tx.QueryRow(ctx, `
+		INSERT INTO article_media (article_id, url, thumb_url, full_url, caption, credit, position, is_featured, is_provisional)
+		SELECT $1, $2, $3, $4, NULLIF(TRIM($5), ''), NULLIF(TRIM($6), ''), COALESCE(MAX(position) + 1, 0), $7, $8
+		FROM article_media
+		WHERE article_id = $1
+		RETURNING `+articleMediaColumns,
+		articleID, req.URL, thumb, full, req.Caption, req.Credit, req.IsFeatured, provisional,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create article media: %w", err)
+	}
+
+	if m.IsFeatured {
+		if err := setArticleFeaturedImage(ctx, tx, m); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return m, nil
+}
+
+func (r *ArticleRepository) UpdateArticleMedia(ctx context.Context, id uuid.UUID, req *models.UpdateArticleMediaRequest) (*models.ArticleMedia, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := scanArticleMedia(tx.QueryRow(ctx, `
+		SELECT `+articleMediaColumns+` FROM article_media WHERE id = $1 FOR UPDATE
+	`, id))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("article media not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article media: %w", err)
+	}
+
+	if req.IsFeatured != nil && *req.IsFeatured && !before.IsFeatured {
+		if err := unfeatureArticleMedia(ctx, tx, before.ArticleID); err != nil {
+			return nil, err
+		}
+	}
+
+	// A blank caption or credit clears it
+	m, err := scanArticleMedia(tx.QueryRow(ctx, `
+		UPDATE article_media SET
+			caption = CASE WHEN $2::text IS NULL THEN caption ELSE NULLIF(TRIM($2), '') END,
+			credit = CASE WHEN $3::text IS NULL THEN credit ELSE NULLIF(TRIM($3), '') END,
+			is_featured = COALESCE($4, is_featured),
+			is_provisional = FALSE
+		WHERE id = $1
+		RETURNING `+articleMediaColumns,
+		id, req.Caption, req.Credit, req.IsFeatured,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update article media: %w", err)
+	}
+
+	switch {
+	case m.IsFeatured && !before.IsFeatured:
+		err = setArticleFeaturedImage(ctx, tx, m)
+	case !m.IsFeatured && before.IsFeatured:
+		err = clearArticleFeaturedImage(ctx, tx, before)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return m, nil
+}
+
+// DeleteArticleMedia removes an item from its gallery. Deleting the featured
+// item leaves the article without a featured image. The upload itself is
+// kept.
+func (r *ArticleRepository) DeleteArticleMedia(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	m, err := scanArticleMedia(tx.QueryRow(ctx, `
+		DELETE FROM article_media WHERE id = $1 RETURNING `+articleMediaColumns, id))
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("article media not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete article media: %w", err)
+	}
+
+	if m.IsFeatured {
+		if err := clearArticleFeaturedImage(ctx, tx, m); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ReorderArticleMedia puts an article's gallery in the given order. The IDs
+// must be exactly the article's items.
+func (r *ArticleRepository) ReorderArticleMedia(ctx context.Context, articleID uuid.UUID, ids []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	rows, err := tx.Query(ctx, `SELECT id FROM article_media WHERE article_id = $1 FOR UPDATE`, articleID)
+	if err != nil {
+		return fmt.Errorf("failed to get article media: %w", err)
+	}
+	current := map[uuid.UUID]bool{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan article media: %w", err)
+		}
+		current[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to get article media: %w", err)
+	}
+
+	if len(ids) != len(current) {
+		return fmt.Errorf("media IDs must list every item of the gallery once")
+	}
+	for _, id := range ids {
+		if !current[id] {
+			return fmt.Errorf("media IDs must list every item of the gallery once")
+		}
+		delete(current, id)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE article_media m SET position = o.ord - 1
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+		WHERE m.id = o.id AND m.article_id = $1
+	`, articleID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to reorder article media: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListArticlesFeaturingMedia returns the articles whose featured media is the
+// upload at url, whether as the original or one of its renditions
+func (r *ArticleRepository) ListArticlesFeaturingMedia(ctx context.Context, url string) ([]models.ArticleTitle, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT a.id, a.slug, a.title
+		FROM article_media m
+		JOIN articles a ON a.id = m.article_id AND a.deleted_at IS NULL
+		WHERE m.is_featured AND (m.url = $1 OR m.thumb_url = $1 OR m.full_url = $1)
+		ORDER BY a.title, a.id
+	`, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list articles featuring media: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []models.ArticleTitle{}
+	for rows.Next() {
+		var a models.ArticleTitle
+		if err := rows.Scan(&a.ID, &a.Slug, &a.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		articles = append(articles, a)
+	}
+
+	return articles, rows.Err()
+}
+
+// ListArticlesWithoutFeaturedMedia returns a page of articles, ordered by ID
+// after the given one, that have no featured media: their ID, content and
+// featured image columns only
+func (r *ArticleRepository) ListArticlesWithoutFeaturedMedia(ctx context.Context, after uuid.UUID, limit int) ([]models.Article, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT a.id, a.content, a.featured_image, a.featured_image_thumb, a.featured_image_full
+		FROM articles a
+		WHERE a.deleted_at IS NULL AND a.id > $1
+		  AND NOT EXISTS (SELECT 1 FROM article_media m WHERE m.article_id = a.id AND m.is_featured)
+		ORDER BY a.id
+		LIMIT $2
+	`, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list articles without featured media: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []models.Article{}
+	for rows.Next() {
+		var a models.Article
+		var thumb, full *string
+		if err := rows.Scan(&a.ID, &a.Content, &a.FeaturedImage, &thumb, &full); err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		a.Images = models.NewArticleImages(thumb, full)
+		articles = append(articles, a)
+	}
+
+	return articles, rows.Err()
+}
+
+func unfeatureArticleMedia(ctx context.Context, tx pgx.Tx, articleID uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE article_media SET is_featured = FALSE WHERE article_id = $1 AND is_featured
+	`, articleID)
+	if err != nil {
+		return fmt.Errorf("failed to unfeature article media: %w", err)
+	}
+	return nil
+}
+
+// setArticleFeaturedImage copies the featured item's URLs to the article
+func setArticleFeaturedImage(ctx context.Context, tx pgx.Tx, m *models.ArticleMedia) error {
+	var thumb, full *string
+	if m.Images != nil {
+		thumb, full = &m.Images.Thumb, &m.Images.Full
+	}
+	_, err := tx.Exec(ctx, `
+		UPDATE articles SET featured_image = $2, featured_image_thumb = $3, featured_image_full = $4
+		WHERE id = $1
+	`, m.ArticleID, m.URL, thumb, full)
+	if err != nil {
+		return fmt.Errorf("failed to set featured image: %w", err)
+	}
+	return nil
+}
+
+// clearArticleFeaturedImage removes a no longer featured item's URLs from
+// the article, unless an editor has since set another featured image
+func clearArticleFeaturedImage(ctx context.Context, tx pgx.Tx, m *models.ArticleMedia) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE articles SET featured_image = NULL, featured_image_thumb = NULL, featured_image_full = NULL
+		WHERE id = $1 AND featured_image = $2
+	`, m.ArticleID, m.URL)
+	if err != nil {
+		return fmt.Errorf("failed to clear featured image: %w", err)
+	}
+	return nil
+}
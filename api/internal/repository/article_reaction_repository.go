@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// SetReaction records the user's reaction to an article, replacing any they made before
+func (r *ArticleRepository) SetReaction(ctx context.Context, articleID, userID uuid.UUID, reaction string) error {
+	query := `
+		INSERT INTO article_reactions (article_id, user_id, reaction)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (article_id, user_id) DO UPDATE SET reaction = EXCLUDED.reaction, created_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, articleID, userID, reaction); err != nil {
+		return fmt.Errorf("failed to set article reaction: %w", err)
+	}
+	return nil
+}
+
+// RemoveReaction removes the user's reaction to an article, if they made one
+func (r *ArticleRepository) RemoveReaction(ctx context.Context, articleID, userID uuid.UUID) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM article_reactions WHERE article_id = $1 AND user_id = $2`, articleID, userID); err != nil {
+		return fmt.Errorf("failed to remove article reaction: %w", err)
+	}
+	return nil
+}
+
+// GetReactionSummary gets reaction counts for an article, marking the
+// current user's reaction when a user is given
+func (r *ArticleRepository) GetReactionSummary(ctx context.Context, articleID uuid.UUID, currentUserID *uuid.UUID) ([]models.ReactionSummary, error) {
+	summaries, err := getReactionSummaries(ctx, r.db, articleReactions, []uuid.UUID{articleID}, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+	return summaries[articleID], nil
+}
@@ -20,10 +20,16 @@ func NewArticleRepository(db *pgxpool.Pool) *ArticleRepository {
 	return &ArticleRepository{db: db}
 }
 
+// notInInternalCategory keeps articles filed under an internal category out
+// of public queries. It checks the category even when it is soft-deleted.
+const notInInternalCategory = "NOT EXISTS (SELECT 1 FROM categories ic WHERE ic.id = a.category_id AND ic.is_internal)"
+
 func (r *ArticleRepository) Create(ctx context.Context, article *models.Article) error {
 	query := `
-		INSERT INTO articles (slug, title, summary, content, featured_image, author_id, category_id, primary_politician_id, status, published_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO articles (slug, title, summary, content, featured_image, author_id, category_id, primary_politician_id, status, published_at,
+			comments_enabled, comments_locked_at, comments_premoderated, featured_image_thumb, featured_image_full, access_level,
+			is_premium, premium_summary, focus_keyword, cover_image)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -35,6 +41,11 @@ func (r *ArticleRepository) Create(ctx context.Context, article *models.Article)
 		publishedAt = article.PublishedAt
 	}
 
+	var imageThumb, imageFull *string
+	if article.Images != nil {
+		imageThumb, imageFull = &article.Images.Thumb, &article.Images.Full
+	}
+
 	err := r.db.QueryRow(ctx, query,
 		article.Slug,
 		article.Title,
@@ -46,6 +57,16 @@ func (r *ArticleRepository) Create(ctx context.Context, article *models.Article)
 		article.PrimaryPoliticianID,
 		article.Status,
 		publishedAt,
+		article.CommentsEnabled,
+		article.CommentsLockedAt,
+		article.CommentsPremoderated,
+		imageThumb,
+		imageFull,
+		article.AccessLevel,
+		article.IsPremium,
+		article.PremiumSummary,
+		article.FocusKeyword,
+		article.CoverImage,
 	).Scan(&article.ID, &article.CreatedAt, &article.UpdatedAt)
 
 	if err != nil {
@@ -58,10 +79,11 @@ func (r *ArticleRepository) Create(ctx context.Context, article *models.Article)
 
 func (r *ArticleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Article, error) {
 	query := `
-		SELECT a.id, a.slug, a.title, a.summary, a.content, a.featured_image,
+		SELECT a.id, a.slug, a.title, a.summary, a.content, a.featured_image, a.cover_image,
 			   a.author_id, a.category_id, a.primary_politician_id, a.status, a.view_count, a.published_at, a.created_at, a.updated_at,
+			   a.comments_enabled, a.comments_locked_at, a.comments_premoderated, a.featured_image_thumb, a.featured_image_full, a.access_level, a.is_premium, a.premium_summary, a.focus_keyword,
 			   au.id, au.name, au.slug, au.bio, au.avatar, au.email,
-			   c.id, c.name, c.slug, c.description,
+			   c.id, c.name, c.slug, c.description, c.is_internal,
 			   p.id, p.name, p.slug, p.photo, p.position, p.party, p.short_bio
 		FROM articles a
 		LEFT JOIN authors au ON a.author_id = au.id AND au.deleted_at IS NULL
@@ -74,13 +96,16 @@ func (r *ArticleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 	var authorID, categoryID, politicianID *uuid.UUID
 	var authorName, authorSlug, authorBio, authorAvatar, authorEmail *string
 	var categoryName, categorySlug, categoryDescription *string
+	var categoryInternal *bool
 	var politicianName, politicianSlug, politicianPhoto, politicianPosition, politicianParty, politicianBio *string
+	var imageThumb, imageFull *string
 
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&article.ID, &article.Slug, &article.Title, &article.Summary, &article.Content, &article.FeaturedImage,
+		&article.ID, &article.Slug, &article.Title, &article.Summary, &article.Content, &article.FeaturedImage, &article.CoverImage,
 		&article.AuthorID, &article.CategoryID, &article.PrimaryPoliticianID, &article.Status, &article.ViewCount, &article.PublishedAt, &article.CreatedAt, &article.UpdatedAt,
+		&article.CommentsEnabled, &article.CommentsLockedAt, &article.CommentsPremoderated, &imageThumb, &imageFull, &article.AccessLevel, &article.IsPremium, &article.PremiumSummary, &article.FocusKeyword,
 		&authorID, &authorName, &authorSlug, &authorBio, &authorAvatar, &authorEmail,
-		&categoryID, &categoryName, &categorySlug, &categoryDescription,
+		&categoryID, &categoryName, &categorySlug, &categoryDescription, &categoryInternal,
 		&politicianID, &politicianName, &politicianSlug, &politicianPhoto, &politicianPosition, &politicianParty, &politicianBio,
 	)
 
@@ -90,6 +115,7 @@ func (r *ArticleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 	if err != nil {
 		return nil, fmt.Errorf("failed to get article: %w", err)
 	}
+	article.Images = models.NewArticleImages(imageThumb, imageFull)
 
 	if authorID != nil {
 		article.Author = &models.Author{
@@ -107,6 +133,7 @@ func (r *ArticleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 			Name:        *categoryName,
 			Slug:        *categorySlug,
 			Description: categoryDescription,
+			IsInternal:  *categoryInternal,
 		}
 	}
 	if politicianID != nil {
@@ -127,34 +154,57 @@ func (r *ArticleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 	}
 	article.Tags = tags
 
+	authors, err := r.GetArticleAuthors(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+	article.Authors = authors
+
+	sources, err := r.GetArticleSources(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+	article.Sources = sources
+
+	gallery, err := r.GetArticleMedia(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+	article.Gallery = gallery
+	article.FeaturedMedia = models.FeaturedMedia(gallery)
+
 	return article, nil
 }
 
 func (r *ArticleRepository) GetBySlug(ctx context.Context, slug string) (*models.Article, error) {
 	query := `
-		SELECT a.id, a.slug, a.title, a.summary, a.content, a.featured_image,
+		SELECT a.id, a.slug, a.title, a.summary, a.content, a.featured_image, a.cover_image,
 			   a.author_id, a.category_id, a.primary_politician_id, a.status, a.view_count, a.published_at, a.created_at, a.updated_at,
+			   a.comments_enabled, a.comments_locked_at, a.comments_premoderated, a.featured_image_thumb, a.featured_image_full, a.access_level, a.is_premium, a.premium_summary, a.focus_keyword,
 			   au.id, au.name, au.slug, au.bio, au.avatar, au.email,
-			   c.id, c.name, c.slug, c.description,
+			   c.id, c.name, c.slug, c.description, c.is_internal,
 			   p.id, p.name, p.slug, p.photo, p.position, p.party, p.short_bio
 		FROM articles a
 		LEFT JOIN authors au ON a.author_id = au.id AND au.deleted_at IS NULL
 		LEFT JOIN categories c ON a.category_id = c.id AND c.deleted_at IS NULL
 		LEFT JOIN politicians p ON a.primary_politician_id = p.id AND p.deleted_at IS NULL
-		WHERE a.slug = $1 AND a.deleted_at IS NULL
+		WHERE a.slug = $1 AND a.deleted_at IS NULL AND ` + notInInternalCategory + `
 	`
 
 	article := &models.Article{}
 	var authorID, categoryID, politicianID *uuid.UUID
 	var authorName, authorSlug, authorBio, authorAvatar, authorEmail *string
 	var categoryName, categorySlug, categoryDescription *string
+	var categoryInternal *bool
 	var politicianName, politicianSlug, politicianPhoto, politicianPosition, politicianParty, politicianBio *string
+	var imageThumb, imageFull *string
 
 	err := r.db.QueryRow(ctx, query, slug).Scan(
-		&article.ID, &article.Slug, &article.Title, &article.Summary, &article.Content, &article.FeaturedImage,
+		&article.ID, &article.Slug, &article.Title, &article.Summary, &article.Content, &article.FeaturedImage, &article.CoverImage,
 		&article.AuthorID, &article.CategoryID, &article.PrimaryPoliticianID, &article.Status, &article.ViewCount, &article.PublishedAt, &article.CreatedAt, &article.UpdatedAt,
+		&article.CommentsEnabled, &article.CommentsLockedAt, &article.CommentsPremoderated, &imageThumb, &imageFull, &article.AccessLevel, &article.IsPremium, &article.PremiumSummary, &article.FocusKeyword,
 		&authorID, &authorName, &authorSlug, &authorBio, &authorAvatar, &authorEmail,
-		&categoryID, &categoryName, &categorySlug, &categoryDescription,
+		&categoryID, &categoryName, &categorySlug, &categoryDescription, &categoryInternal,
 		&politicianID, &politicianName, &politicianSlug, &politicianPhoto, &politicianPosition, &politicianParty, &politicianBio,
 	)
 
@@ -164,6 +214,7 @@ func (r *ArticleRepository) GetBySlug(ctx context.Context, slug string) (*models
 	if err != nil {
 		return nil, fmt.Errorf("failed to get article by slug: %w", err)
 	}
+	article.Images = models.NewArticleImages(imageThumb, imageFull)
 
 	if authorID != nil {
 		article.Author = &models.Author{
@@ -181,6 +232,7 @@ func (r *ArticleRepository) GetBySlug(ctx context.Context, slug string) (*models
 			Name:        *categoryName,
 			Slug:        *categorySlug,
 			Description: categoryDescription,
+			IsInternal:  *categoryInternal,
 		}
 	}
 	if politicianID != nil {
@@ -201,10 +253,54 @@ func (r *ArticleRepository) GetBySlug(ctx context.Context, slug string) (*models
 	}
 	article.Tags = tags
 
+	authors, err := r.GetArticleAuthors(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+	article.Authors = authors
+
+	sources, err := r.GetArticleSources(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+	article.Sources = sources
+
+	gallery, err := r.GetArticleMedia(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+	article.Gallery = gallery
+	article.FeaturedMedia = models.FeaturedMedia(gallery)
+
 	return article, nil
 }
 
-func (r *ArticleRepository) List(ctx context.Context, filter *models.ArticleFilter, page, perPage int) (*models.PaginatedArticles, error) {
+// articleOrderBy maps the filter's sort to a fixed ORDER BY clause; the value
+// is never interpolated. Each order ends on a.id so pages stay stable when
+// the leading keys tie.
+func articleOrderBy(filter *models.ArticleFilter) string {
+	var sort models.ArticleSort
+	if filter != nil {
+		sort = filter.Sort
+	}
+
+	switch sort {
+	case models.ArticleSortOldest:
+		return "a.published_at ASC NULLS LAST, a.created_at ASC, a.id ASC"
+	case models.ArticleSortMostViewed:
+		return "a.view_count DESC, a.published_at DESC NULLS LAST, a.id DESC"
+	case models.ArticleSortMostCommented:
+		return `(SELECT COUNT(*) FROM comments cm
+			WHERE cm.article_id = a.id AND cm.deleted_at IS NULL AND cm.status = 'active') DESC,
+			a.published_at DESC NULLS LAST, a.id DESC`
+	default:
+		return "a.published_at DESC NULLS LAST, a.created_at DESC, a.id DESC"
+	}
+}
+
+// articleFilterWhere builds the WHERE clause for an article filter on
+// articles aliased as a, with placeholders numbered from $1
+func articleFilterWhere(filter *models.ArticleFilter) (string, []interface{}) {
 	whereClause := []string{"a.deleted_at IS NULL"}
 	args := []interface{}{}
 	argNum := 1
@@ -221,7 +317,7 @@ func (r *ArticleRepository) List(ctx context.Context, filter *models.ArticleFilt
 			argNum++
 		}
 		if filter.AuthorID != nil {
-			whereClause = append(whereClause, fmt.Sprintf("a.author_id = $%d", argNum))
+			whereClause = append(whereClause, fmt.Sprintf("(a.author_id = $%d OR EXISTS (SELECT 1 FROM article_authors aa WHERE aa.article_id = a.id AND aa.author_id = $%d))", argNum, argNum))
 			args = append(args, *filter.AuthorID)
 			argNum++
 		}
@@ -240,12 +336,36 @@ func (r *ArticleRepository) List(ctx context.Context, filter *models.ArticleFilt
 			args = append(args, *filter.Search)
 			argNum++
 		}
+		if filter.OlderThanDays != nil {
+			whereClause = append(whereClause, fmt.Sprintf("a.created_at < NOW() - make_interval(days => $%d)", argNum))
+			args = append(args, *filter.OlderThanDays)
+			argNum++
+		}
+		if filter.PublishedAfter != nil {
+			whereClause = append(whereClause, fmt.Sprintf("a.published_at >= $%d", argNum))
+			args = append(args, *filter.PublishedAfter)
+			argNum++
+		}
+		if filter.PublishedBefore != nil {
+			// Exclusive upper bound so the whole end day is included
+			whereClause = append(whereClause, fmt.Sprintf("a.published_at < $%d::timestamp + INTERVAL '1 day'", argNum))
+			args = append(args, *filter.PublishedBefore)
+			argNum++
+		}
 		if filter.IncludeDeleted {
 			whereClause[0] = "1=1"
 		}
 	}
+	if filter == nil || !filter.IncludeInternal {
+		whereClause = append(whereClause, notInInternalCategory)
+	}
 
-	where := strings.Join(whereClause, " AND ")
+	return strings.Join(whereClause, " AND "), args
+}
+
+func (r *ArticleRepository) List(ctx context.Context, filter *models.ArticleFilter, page, perPage int) (*models.PaginatedArticles, error) {
+	where, args := articleFilterWhere(filter)
+	argNum := len(args) + 1
 
 	// Count total
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM articles a WHERE %s", where)
@@ -260,16 +380,16 @@ func (r *ArticleRepository) List(ctx context.Context, filter *models.ArticleFilt
 	args = append(args, perPage, offset)
 
 	query := fmt.Sprintf(`
-		SELECT a.id, a.slug, a.title, a.summary, a.featured_image, a.status, a.view_count, a.published_at, a.created_at,
-			   au.name, au.slug, au.avatar, c.name, c.slug, p.name, p.slug
+		SELECT a.id, a.slug, a.title, a.summary, a.featured_image, a.cover_image, a.status, a.access_level, a.is_premium, a.view_count, a.published_at, a.created_at, a.deleted_at,
+			   au.name, au.slug, au.avatar, c.name, c.slug, p.name, p.slug, %s
 		FROM articles a
 		LEFT JOIN authors au ON a.author_id = au.id
 		LEFT JOIN categories c ON a.category_id = c.id
 		LEFT JOIN politicians p ON a.primary_politician_id = p.id
 		WHERE %s
-		ORDER BY a.published_at DESC NULLS LAST, a.created_at DESC
+		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, where, argNum, argNum+1)
+	`, articleCitationCount, where, articleOrderBy(filter), argNum, argNum+1)
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
@@ -281,16 +401,21 @@ func (r *ArticleRepository) List(ctx context.Context, filter *models.ArticleFilt
 	for rows.Next() {
 		var article models.ArticleListItem
 		err := rows.Scan(
-			&article.ID, &article.Slug, &article.Title, &article.Summary, &article.FeaturedImage,
-			&article.Status, &article.ViewCount, &article.PublishedAt, &article.CreatedAt,
+			&article.ID, &article.Slug, &article.Title, &article.Summary, &article.FeaturedImage, &article.CoverImage,
+			&article.Status, &article.AccessLevel, &article.IsPremium, &article.ViewCount, &article.PublishedAt, &article.CreatedAt, &article.DeletedAt,
 			&article.AuthorName, &article.AuthorSlug, &article.AuthorAvatar, &article.CategoryName, &article.CategorySlug,
-			&article.PrimaryPoliticianName, &article.PrimaryPoliticianSlug,
+			&article.PrimaryPoliticianName, &article.PrimaryPoliticianSlug, &article.CitationCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan article: %w", err)
 		}
 		articles = append(articles, article)
 	}
+	rows.Close()
+
+	if err := r.attachAuthors(ctx, articles); err != nil {
+		return nil, err
+	}
 
 	totalPages := (total + perPage - 1) / perPage
 
@@ -433,15 +558,135 @@ func (r *ArticleRepository) SetArticleTags(ctx context.Context, articleID uuid.U
 	return nil
 }
 
-func (r *ArticleRepository) GetTrendingIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+// GetArticleAuthors returns the full byline of an article: the primary author first, then co-authors in order
+func (r *ArticleRepository) GetArticleAuthors(ctx context.Context, articleID uuid.UUID) ([]models.ArticleAuthor, error) {
+	authors, err := r.GetAuthorsForArticles(ctx, []uuid.UUID{articleID})
+	if err != nil {
+		return nil, err
+	}
+	return authors[articleID], nil
+}
+
+// GetAuthorsForArticles returns the bylines of many articles in a single query
+func (r *ArticleRepository) GetAuthorsForArticles(ctx context.Context, articleIDs []uuid.UUID) (map[uuid.UUID][]models.ArticleAuthor, error) {
+	bylines := make(map[uuid.UUID][]models.ArticleAuthor, len(articleIDs))
+	if len(articleIDs) == 0 {
+		return bylines, nil
+	}
+
+	query := `
+		SELECT byline.article_id, au.id, au.name, au.slug, au.avatar, byline.position
+		FROM (
+			SELECT id AS article_id, author_id, 0 AS position
+			FROM articles
+			WHERE id = ANY($1) AND author_id IS NOT NULL
+			UNION ALL
+			SELECT article_id, author_id, position
+			FROM article_authors
+			WHERE article_id = ANY($1)
+		) byline
+		JOIN authors au ON byline.author_id = au.id AND au.deleted_at IS NULL
+		ORDER BY byline.article_id, byline.position
+	`
+
+	rows, err := r.db.Query(ctx, query, articleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article authors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var articleID uuid.UUID
+		var author models.ArticleAuthor
+		if err := rows.Scan(&articleID, &author.ID, &author.Name, &author.Slug, &author.Avatar, &author.Position); err != nil {
+			return nil, fmt.Errorf("failed to scan article author: %w", err)
+		}
+		bylines[articleID] = append(bylines[articleID], author)
+	}
+
+	return bylines, rows.Err()
+}
+
+// GetArticleCoAuthorIDs returns the co-author IDs of an article in byline order
+func (r *ArticleRepository) GetArticleCoAuthorIDs(ctx context.Context, articleID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `SELECT author_id FROM article_authors WHERE article_id = $1 ORDER BY position`, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article co-authors: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan co-author id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// SetArticleCoAuthors replaces an article's co-authors, keeping the given order
+func (r *ArticleRepository) SetArticleCoAuthors(ctx context.Context, articleID uuid.UUID, authorIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, "DELETE FROM article_authors WHERE article_id = $1", articleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete existing co-authors: %w", err)
+	}
+
+	for i, authorID := range authorIDs {
+		_, err = tx.Exec(ctx, "INSERT INTO article_authors (article_id, author_id, position) VALUES ($1, $2, $3)", articleID, authorID, i+1)
+		if err != nil {
+			return fmt.Errorf("failed to insert co-author: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// attachAuthors fills in the byline of each list item with one query
+func (r *ArticleRepository) attachAuthors(ctx context.Context, articles []models.ArticleListItem) error {
+	ids := make([]uuid.UUID, len(articles))
+	for i := range articles {
+		ids[i] = articles[i].ID
+	}
+
+	bylines, err := r.GetAuthorsForArticles(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range articles {
+		articles[i].Authors = bylines[articles[i].ID]
+	}
+
+	return nil
+}
+
+// GetTrendingIDs ranks published articles by views plus reactionWeight
+// views for every reader reaction
+func (r *ArticleRepository) GetTrendingIDs(ctx context.Context, limit, reactionWeight int) ([]uuid.UUID, error) {
 	query := `
-		SELECT id FROM articles
-		WHERE status = 'published' AND deleted_at IS NULL
-		ORDER BY view_count DESC, published_at DESC
+		SELECT a.id FROM articles a
+		LEFT JOIN (
+			SELECT article_id, COUNT(*) AS reactions FROM article_reactions GROUP BY article_id
+		) ar ON ar.article_id = a.id
+		WHERE a.status = 'published' AND a.deleted_at IS NULL AND ` + notInInternalCategory + `
+		ORDER BY a.view_count + $2 * COALESCE(ar.reactions, 0) DESC, a.published_at DESC
 		LIMIT $1
 	`
 
-	rows, err := r.db.Query(ctx, query, limit)
+	rows, err := r.db.Query(ctx, query, limit, reactionWeight)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get trending articles: %w", err)
 	}
@@ -472,14 +717,14 @@ func (r *ArticleRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]mo
 	}
 
 	query := fmt.Sprintf(`
-		SELECT a.id, a.slug, a.title, a.summary, a.featured_image, a.status, a.view_count, a.published_at, a.created_at,
-			   au.name, au.slug, au.avatar, c.name, c.slug, p.name, p.slug
+		SELECT a.id, a.slug, a.title, a.summary, a.featured_image, a.cover_image, a.status, a.access_level, a.is_premium, a.view_count, a.published_at, a.created_at,
+			   au.name, au.slug, au.avatar, c.name, c.slug, p.name, p.slug, %s
 		FROM articles a
 		LEFT JOIN authors au ON a.author_id = au.id AND au.deleted_at IS NULL
 		LEFT JOIN categories c ON a.category_id = c.id AND c.deleted_at IS NULL
 		LEFT JOIN politicians p ON a.primary_politician_id = p.id AND p.deleted_at IS NULL
-		WHERE a.id IN (%s) AND a.deleted_at IS NULL
-	`, strings.Join(placeholders, ","))
+		WHERE a.id IN (%s) AND a.deleted_at IS NULL AND %s
+	`, articleCitationCount, strings.Join(placeholders, ","), notInInternalCategory)
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
@@ -491,10 +736,10 @@ func (r *ArticleRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]mo
 	for rows.Next() {
 		var article models.ArticleListItem
 		err := rows.Scan(
-			&article.ID, &article.Slug, &article.Title, &article.Summary, &article.FeaturedImage,
-			&article.Status, &article.ViewCount, &article.PublishedAt, &article.CreatedAt,
+			&article.ID, &article.Slug, &article.Title, &article.Summary, &article.FeaturedImage, &article.CoverImage,
+			&article.Status, &article.AccessLevel, &article.IsPremium, &article.ViewCount, &article.PublishedAt, &article.CreatedAt,
 			&article.AuthorName, &article.AuthorSlug, &article.AuthorAvatar, &article.CategoryName, &article.CategorySlug,
-			&article.PrimaryPoliticianName, &article.PrimaryPoliticianSlug,
+			&article.PrimaryPoliticianName, &article.PrimaryPoliticianSlug, &article.CitationCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan article: %w", err)
@@ -502,6 +747,8 @@ func (r *ArticleRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]mo
 		articlesMap[article.ID] = article
 	}
 
+	rows.Close()
+
 	// Preserve order from input IDs
 	articles := make([]models.ArticleListItem, 0, len(ids))
 	for _, id := range ids {
@@ -510,9 +757,26 @@ func (r *ArticleRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]mo
 		}
 	}
 
+	if err := r.attachAuthors(ctx, articles); err != nil {
+		return nil, err
+	}
+
 	return articles, nil
 }
 
+// IsInternalCategory reports whether the category is hidden from the public
+func (r *ArticleRepository) IsInternalCategory(ctx context.Context, categoryID uuid.UUID) (bool, error) {
+	var internal bool
+	err := r.db.QueryRow(ctx, "SELECT is_internal FROM categories WHERE id = $1", categoryID).Scan(&internal)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check category: %w", err)
+	}
+	return internal, nil
+}
+
 func (r *ArticleRepository) IncrementViewCount(ctx context.Context, id uuid.UUID) error {
 	query := "UPDATE articles SET view_count = view_count + 1 WHERE id = $1"
 	_, err := r.db.Exec(ctx, query, id)
@@ -522,15 +786,124 @@ func (r *ArticleRepository) IncrementViewCount(ctx context.Context, id uuid.UUID
 	return nil
 }
 
-func (r *ArticleRepository) IncrementViewCountBySlug(ctx context.Context, slug string) error {
-	query := "UPDATE articles SET view_count = view_count + 1 WHERE slug = $1 AND status = 'published'"
-	_, err := r.db.Exec(ctx, query, slug)
+// IncrementViewCountBySlug counts a view of a published article and adds it
+// to the day's tally for its referrer
+func (r *ArticleRepository) IncrementViewCountBySlug(ctx context.Context, slug string, source models.ReferrerSource) error {
+	query := `
+		WITH viewed AS (
+			UPDATE articles SET view_count = view_count + 1
+			WHERE slug = $1 AND status = 'published'
+			RETURNING id
+		)
+		INSERT INTO article_referrers (article_id, view_date, referrer_type, referrer_domain, view_count)
+		SELECT id, CURRENT_DATE, $2::referrer_type, $3, 1 FROM viewed
+		ON CONFLICT (article_id, view_date, referrer_type, referrer_domain)
+		DO UPDATE SET view_count = article_referrers.view_count + 1
+	`
+	_, err := r.db.Exec(ctx, query, slug, source.Type, source.Domain)
 	if err != nil {
 		return fmt.Errorf("failed to increment view count: %w", err)
 	}
 	return nil
 }
 
+// AddViewCounts writes batched view counts and their referrer tallies in one
+// transaction. It returns the slugs of the articles updated, keyed by ID;
+// articles deleted since the views were counted are skipped.
+func (r *ArticleRepository) AddViewCounts(ctx context.Context, views map[uuid.UUID]int64, referrers []models.ReferrerViews) (map[uuid.UUID]string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	ids := make([]uuid.UUID, 0, len(views))
+	deltas := make([]int64, 0, len(views))
+	for id, delta := range views {
+		ids = append(ids, id)
+		deltas = append(deltas, delta)
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE articles a SET view_count = a.view_count + d.delta
+		FROM unnest($1::uuid[], $2::bigint[]) AS d(id, delta)
+		WHERE a.id = d.id
+		RETURNING a.id, a.slug
+	`, ids, deltas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add view counts: %w", err)
+	}
+
+	updated := make(map[uuid.UUID]string, len(ids))
+	for rows.Next() {
+		var id uuid.UUID
+		var slug string
+		if err := rows.Scan(&id, &slug); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		updated[id] = slug
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to add view counts: %w", err)
+	}
+
+	if len(referrers) > 0 {
+		articleIDs := make([]uuid.UUID, len(referrers))
+		dates := make([]string, len(referrers))
+		types := make([]string, len(referrers))
+		domains := make([]string, len(referrers))
+		counts := make([]int64, len(referrers))
+		for i, ref := range referrers {
+			articleIDs[i] = ref.ArticleID
+			dates[i] = ref.Date
+			types[i] = ref.Source.Type
+			domains[i] = ref.Source.Domain
+			counts[i] = ref.Views
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO article_referrers (article_id, view_date, referrer_type, referrer_domain, view_count)
+			SELECT d.article_id, d.view_date::date, d.referrer_type::referrer_type, d.referrer_domain, d.views
+			FROM unnest($1::uuid[], $2::text[], $3::text[], $4::text[], $5::bigint[])
+			     AS d(article_id, view_date, referrer_type, referrer_domain, views)
+			JOIN articles a ON a.id = d.article_id
+			ON CONFLICT (article_id, view_date, referrer_type, referrer_domain)
+			DO UPDATE SET view_count = article_referrers.view_count + EXCLUDED.view_count
+		`, articleIDs, dates, types, domains, counts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add referrer counts: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return updated, nil
+}
+
+// AddHourlyViews adds site-wide article views to the activity rollups, keyed
+// by the UTC hour they were counted in
+func (r *ArticleRepository) AddHourlyViews(ctx context.Context, views map[time.Time]int64) error {
+	hours := make([]time.Time, 0, len(views))
+	deltas := make([]int64, 0, len(views))
+	for hour, delta := range views {
+		hours = append(hours, hour)
+		deltas = append(deltas, delta)
+	}
+
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO activity_hourly (hour, article_views)
+		SELECT d.hour, d.views FROM unnest($1::timestamptz[], $2::bigint[]) AS d(hour, views)
+		ON CONFLICT (hour) DO UPDATE SET article_views = activity_hourly.article_views + EXCLUDED.article_views
+	`, hours, deltas)
+	if err != nil {
+		return fmt.Errorf("failed to add hourly views: %w", err)
+	}
+	return nil
+}
+
 // GetRelatedArticles returns articles related to the given article by category and tags
 func (r *ArticleRepository) GetRelatedArticles(ctx context.Context, articleID uuid.UUID, categoryID *uuid.UUID, tagIDs []uuid.UUID, limit int) ([]models.ArticleListItem, error) {
 	if limit < 1 {
@@ -552,7 +925,10 @@ func (r *ArticleRepository) GetRelatedArticles(ctx context.Context, articleID uu
 				a.title,
 				a.summary,
 				a.featured_image,
+				a.cover_image,
 				a.status,
+				a.access_level,
+				a.is_premium,
 				a.view_count,
 				a.published_at,
 				a.created_at,
@@ -563,6 +939,7 @@ func (r *ArticleRepository) GetRelatedArticles(ctx context.Context, articleID uu
 				c.slug as category_slug,
 				p.name as primary_politician_name,
 				p.slug as primary_politician_slug,
+				` + articleCitationCount + ` as citation_count,
 				COALESCE((
 					SELECT COUNT(*)
 					FROM article_tags at
@@ -577,9 +954,11 @@ func (r *ArticleRepository) GetRelatedArticles(ctx context.Context, articleID uu
 			WHERE a.id != $1
 				AND a.status = 'published'
 				AND a.deleted_at IS NULL
+				AND ` + notInInternalCategory + `
 		)
-		SELECT id, slug, title, summary, featured_image, status, view_count, published_at, created_at,
-			   author_name, author_slug, author_avatar, category_name, category_slug, primary_politician_name, primary_politician_slug
+		SELECT id, slug, title, summary, featured_image, cover_image, status, access_level, is_premium, view_count, published_at, created_at,
+			   author_name, author_slug, author_avatar, category_name, category_slug, primary_politician_name, primary_politician_slug,
+			   citation_count
 		FROM scored_articles
 		WHERE shared_tags > 0 OR same_category = 1
 		ORDER BY shared_tags DESC, same_category DESC, view_count DESC, published_at DESC NULLS LAST
@@ -596,16 +975,21 @@ func (r *ArticleRepository) GetRelatedArticles(ctx context.Context, articleID uu
 	for rows.Next() {
 		var article models.ArticleListItem
 		err := rows.Scan(
-			&article.ID, &article.Slug, &article.Title, &article.Summary, &article.FeaturedImage,
-			&article.Status, &article.ViewCount, &article.PublishedAt, &article.CreatedAt,
+			&article.ID, &article.Slug, &article.Title, &article.Summary, &article.FeaturedImage, &article.CoverImage,
+			&article.Status, &article.AccessLevel, &article.IsPremium, &article.ViewCount, &article.PublishedAt, &article.CreatedAt,
 			&article.AuthorName, &article.AuthorSlug, &article.AuthorAvatar, &article.CategoryName, &article.CategorySlug,
-			&article.PrimaryPoliticianName, &article.PrimaryPoliticianSlug,
+			&article.PrimaryPoliticianName, &article.PrimaryPoliticianSlug, &article.CitationCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan related article: %w", err)
 		}
 		articles = append(articles, article)
 	}
+	rows.Close()
+
+	if err := r.attachAuthors(ctx, articles); err != nil {
+		return nil, err
+	}
 
 	return articles, nil
 }
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// internalCategoryFixture is a public and an internal category, each with one
+// published article
+type internalCategoryFixture struct {
+	publicCategoryID   uuid.UUID
+	internalCategoryID uuid.UUID
+	publicArticle      models.Article
+	internalArticle    models.Article
+}
+
+func setupInternalCategoryFixture(t *testing.T) (*pgxpool.Pool, *internalCategoryFixture) {
+	t.Helper()
+
+	ctx := context.Background()
+	pool := openTestDB(t)
+
+	suffix := uuid.NewString()[:8]
+	f := &internalCategoryFixture{}
+
+	createCategory := func(slug string, internal bool) uuid.UUID {
+		var id uuid.UUID
+		err := pool.QueryRow(ctx, `
+			INSERT INTO categories (name, slug, is_internal) VALUES ($1, $1, $2) RETURNING id
+		`, slug+"-"+suffix, internal).Scan(&id)
+		require.NoError(t, err)
+		return id
+	}
+	createArticle := func(slug string, categoryID uuid.UUID) models.Article {
+		article := models.Article{Slug: slug + "-" + suffix, CategoryID: &categoryID}
+		err := pool.QueryRow(ctx, `
+			INSERT INTO articles (slug, title, content, category_id, status, published_at)
+			VALUES ($1, $1, 'body', $2, 'published', NOW())
+			RETURNING id
+		`, article.Slug, categoryID).Scan(&article.ID)
+		require.NoError(t, err)
+		return article
+	}
+
+	f.publicCategoryID = createCategory("public-desk", false)
+	f.internalCategoryID = createCategory("internal-desk", true)
+	f.publicArticle = createArticle("public-story", f.publicCategoryID)
+	f.internalArticle = createArticle("internal-story", f.internalCategoryID)
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM articles WHERE id = ANY($1)", []uuid.UUID{f.publicArticle.ID, f.internalArticle.ID})
+		_, _ = pool.Exec(ctx, "DELETE FROM categories WHERE id = ANY($1)", []uuid.UUID{f.publicCategoryID, f.internalCategoryID})
+	})
+
+	return pool, f
+}
+
+func articleIDs(items []models.ArticleListItem) []uuid.UUID {
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+// The RSS feed lists published articles with no other filter
+func TestArticleRepository_RSSExcludesInternalCategories(t *testing.T) {
+	pool, f := setupInternalCategoryFixture(t)
+	repo := NewArticleRepository(pool)
+
+	status := models.ArticleStatusPublished
+	result, err := repo.List(context.Background(), &models.ArticleFilter{Status: &status}, 1, 20)
+	require.NoError(t, err)
+
+	ids := articleIDs(result.Articles)
+	assert.Contains(t, ids, f.publicArticle.ID)
+	assert.NotContains(t, ids, f.internalArticle.ID)
+}
+
+// The sitemap is built from the public article list and the public category
+// list
+func TestArticleRepository_SitemapExcludesInternalCategories(t *testing.T) {
+	pool, f := setupInternalCategoryFixture(t)
+	ctx := context.Background()
+
+	status := models.ArticleStatusPublished
+	articles, err := NewArticleRepository(pool).List(ctx, &models.ArticleFilter{Status: &status}, 1, 1000)
+	require.NoError(t, err)
+	assert.NotContains(t, articleIDs(articles.Articles), f.internalArticle.ID)
+
+	categories, err := NewCategoryRepository(pool).List(ctx)
+	require.NoError(t, err)
+	categoryIDs := make([]uuid.UUID, len(categories))
+	for i, c := range categories {
+		categoryIDs[i] = c.ID
+	}
+	assert.Contains(t, categoryIDs, f.publicCategoryID)
+	assert.NotContains(t, categoryIDs, f.internalCategoryID)
+}
+
+func TestArticleRepository_InternalCategoryDetailAndRelated(t *testing.T) {
+	pool, f := setupInternalCategoryFixture(t)
+	repo := NewArticleRepository(pool)
+	ctx := context.Background()
+
+	article, err := repo.GetBySlug(ctx, f.internalArticle.Slug)
+	require.NoError(t, err)
+	assert.Nil(t, article)
+
+	related, err := repo.GetRelatedArticles(ctx, f.publicArticle.ID, &f.internalCategoryID, nil, 10)
+	require.NoError(t, err)
+	assert.NotContains(t, articleIDs(related), f.internalArticle.ID)
+
+	byIDs, err := repo.GetByIDs(ctx, []uuid.UUID{f.publicArticle.ID, f.internalArticle.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{f.publicArticle.ID}, articleIDs(byIDs))
+}
+
+func TestArticleRepository_AdminSeesInternalCategories(t *testing.T) {
+	pool, f := setupInternalCategoryFixture(t)
+	repo := NewArticleRepository(pool)
+	ctx := context.Background()
+
+	result, err := repo.List(ctx, &models.ArticleFilter{CategoryID: &f.internalCategoryID, IncludeInternal: true}, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{f.internalArticle.ID}, articleIDs(result.Articles))
+
+	article, err := repo.GetByID(ctx, f.internalArticle.ID)
+	require.NoError(t, err)
+	require.NotNil(t, article)
+	require.NotNil(t, article.Category)
+	assert.True(t, article.Category.IsInternal)
+
+	internal, err := repo.IsInternalCategory(ctx, f.internalCategoryID)
+	require.NoError(t, err)
+	assert.True(t, internal)
+}
+
+func TestArticleRepository_BulkTag(t *testing.T) {
+	pool, f := setupInternalCategoryFixture(t)
+	repo := NewArticleRepository(pool)
+	ctx := context.Background()
+
+	var tagID uuid.UUID
+	slug := "bulk-" + uuid.NewString()[:8]
+	require.NoError(t, pool.QueryRow(ctx, "INSERT INTO tags (name, slug) VALUES ($1, $1) RETURNING id", slug).Scan(&tagID))
+	t.Cleanup(func() { _, _ = pool.Exec(ctx, "DELETE FROM tags WHERE id = $1", tagID) })
+
+	// Filters include internal categories the way the admin list does
+	filter := &models.ArticleFilter{CategoryID: &f.internalCategoryID, IncludeInternal: true}
+	targets, err := repo.BulkTagTargets(ctx, tagID, true, nil, filter)
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, f.internalArticle.ID, targets[0].ID)
+
+	ids := []uuid.UUID{f.publicArticle.ID, f.internalArticle.ID}
+	affected, err := repo.SetArticlesTag(ctx, tagID, ids, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, affected)
+
+	// Already tagged articles are no longer targets, and re-assigning is a no-op
+	targets, err = repo.BulkTagTargets(ctx, tagID, true, ids, nil)
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+	affected, err = repo.SetArticlesTag(ctx, tagID, ids, true)
+	require.NoError(t, err)
+	assert.Equal(t, 0, affected)
+
+	targets, err = repo.BulkTagTargets(ctx, tagID, false, []uuid.UUID{f.publicArticle.ID}, nil)
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	affected, err = repo.SetArticlesTag(ctx, tagID, []uuid.UUID{f.publicArticle.ID}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, affected)
+}
+
+func TestArticleRepository_Sources(t *testing.T) {
+	pool, f := setupInternalCategoryFixture(t)
+	repo := NewArticleRepository(pool)
+	ctx := context.Background()
+
+	url := "https://example.gov.ph/statement"
+	accessed := "2024-03-05"
+	statement, err := repo.CreateArticleSource(ctx, f.publicArticle.ID, &models.CreateArticleSourceRequest{
+		Type: models.ArticleSourceOfficialStatement, Title: "  Statement  ", URL: &url, AccessedAt: &accessed,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Statement", statement.Title)
+	require.NotNil(t, statement.AccessedAt)
+	assert.Equal(t, accessed, *statement.AccessedAt)
+
+	_, err = repo.CreateArticleSource(ctx, f.publicArticle.ID, &models.CreateArticleSourceRequest{
+		Type: models.ArticleSourceDocument, Title: "Audit report", IsPrimary: true,
+	})
+	require.NoError(t, err)
+
+	_, err = repo.CreateArticleSource(ctx, uuid.New(), &models.CreateArticleSourceRequest{Type: models.ArticleSourceOther, Title: "x"})
+	assert.EqualError(t, err, "article not found")
+
+	// The public page carries the sources, primary first
+	article, err := repo.GetBySlug(ctx, f.publicArticle.Slug)
+	require.NoError(t, err)
+	require.Len(t, article.Sources, 2)
+	assert.Equal(t, "Audit report", article.Sources[0].Title)
+
+	items, err := repo.GetByIDs(ctx, []uuid.UUID{f.publicArticle.ID})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 2, items[0].CitationCount)
+
+	require.NoError(t, repo.DeleteArticleSource(ctx, statement.ID))
+	assert.EqualError(t, repo.DeleteArticleSource(ctx, statement.ID), "article source not found")
+}
+
+func TestArticleRepository_CoverImage(t *testing.T) {
+	pool, f := setupInternalCategoryFixture(t)
+	repo := NewArticleRepository(pool)
+	ctx := context.Background()
+
+	cover := "https://cdn.pulpulitiko.com/covers/" + f.publicArticle.Slug + ".png"
+	require.NoError(t, repo.Update(ctx, f.publicArticle.ID, map[string]interface{}{"cover_image": cover}))
+
+	article, err := repo.GetBySlug(ctx, f.publicArticle.Slug)
+	require.NoError(t, err)
+	require.NotNil(t, article.CoverImage)
+	assert.Equal(t, cover, *article.CoverImage)
+
+	items, err := repo.GetByIDs(ctx, []uuid.UUID{f.publicArticle.ID})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.NotNil(t, items[0].CoverImage)
+	assert.Equal(t, cover, *items[0].CoverImage)
+
+	require.NoError(t, repo.Update(ctx, f.publicArticle.ID, map[string]interface{}{"cover_image": nil}))
+	article, err = repo.GetByID(ctx, f.publicArticle.ID)
+	require.NoError(t, err)
+	assert.Nil(t, article.CoverImage)
+}
+
+// Renaming an internal article or category must not reveal the new slug
+// through a redirect from the old one
+func TestSlugRedirects_ExcludeInternalCategories(t *testing.T) {
+	pool, f := setupInternalCategoryFixture(t)
+	ctx := context.Background()
+	articles := NewArticleRepository(pool)
+	categories := NewCategoryRepository(pool)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM slug_redirects WHERE entity_id = ANY($1)", []uuid.UUID{
+			f.publicArticle.ID, f.internalArticle.ID, f.publicCategoryID, f.internalCategoryID,
+		})
+	})
+
+	rename := func(table string, id uuid.UUID, oldSlug string) string {
+		newSlug := oldSlug + "-renamed"
+		_, err := pool.Exec(ctx, "UPDATE "+table+" SET slug = $2 WHERE id = $1", id, newSlug)
+		require.NoError(t, err)
+		return newSlug
+	}
+
+	publicSlug := rename("articles", f.publicArticle.ID, f.publicArticle.Slug)
+	rename("articles", f.internalArticle.ID, f.internalArticle.Slug)
+
+	slug, err := articles.GetSlugRedirect(ctx, f.publicArticle.Slug)
+	require.NoError(t, err)
+	assert.Equal(t, publicSlug, slug)
+
+	slug, err = articles.GetSlugRedirect(ctx, f.internalArticle.Slug)
+	require.NoError(t, err)
+	assert.Empty(t, slug)
+
+	var publicCategorySlug, internalCategorySlug string
+	require.NoError(t, pool.QueryRow(ctx, "SELECT slug FROM categories WHERE id = $1", f.publicCategoryID).Scan(&publicCategorySlug))
+	require.NoError(t, pool.QueryRow(ctx, "SELECT slug FROM categories WHERE id = $1", f.internalCategoryID).Scan(&internalCategorySlug))
+	renamedPublic := rename("categories", f.publicCategoryID, publicCategorySlug)
+	rename("categories", f.internalCategoryID, internalCategorySlug)
+
+	slug, err = categories.GetSlugRedirect(ctx, publicCategorySlug)
+	require.NoError(t, err)
+	assert.Equal(t, renamedPublic, slug)
+
+	slug, err = categories.GetSlugRedirect(ctx, internalCategorySlug)
+	require.NoError(t, err)
+	assert.Empty(t, slug)
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// ListRSS returns the latest published articles with their content, byline,
+// category and tags, newest first
+func (r *ArticleRepository) ListRSS(ctx context.Context, limit int) ([]models.Article, error) {
+	status := models.ArticleStatusPublished
+	where, args := articleFilterWhere(&models.ArticleFilter{Status: &status})
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT a.id, a.slug, a.title, a.summary, a.content, a.featured_image, a.cover_image, a.featured_image_thumb, a.featured_image_full,
+			   a.access_level, a.is_premium, a.premium_summary, a.published_at, a.created_at, a.updated_at,
+			   c.id, c.name, c.slug, fm.id, fm.caption, fm.credit
+		FROM articles a
+		LEFT JOIN categories c ON a.category_id = c.id AND c.deleted_at IS NULL
+		LEFT JOIN article_media fm ON fm.article_id = a.id AND fm.is_featured
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d
+	`, where, articleOrderBy(nil), len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list RSS articles: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []models.Article{}
+	for rows.Next() {
+		var a models.Article
+		var imageThumb, imageFull *string
+		var categoryID *uuid.UUID
+		var categoryName, categorySlug *string
+		var mediaID *uuid.UUID
+		var mediaCaption, mediaCredit *string
+		err := rows.Scan(
+			&a.ID, &a.Slug, &a.Title, &a.Summary, &a.Content, &a.FeaturedImage, &a.CoverImage, &imageThumb, &imageFull,
+			&a.AccessLevel, &a.IsPremium, &a.PremiumSummary, &a.PublishedAt, &a.CreatedAt, &a.UpdatedAt,
+			&categoryID, &categoryName, &categorySlug, &mediaID, &mediaCaption, &mediaCredit,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan RSS article: %w", err)
+		}
+		a.Status = models.ArticleStatusPublished
+		a.Images = models.NewArticleImages(imageThumb, imageFull)
+		if categoryID != nil {
+			a.CategoryID = categoryID
+			a.Category = &models.Category{ID: *categoryID, Name: *categoryName, Slug: *categorySlug}
+		}
+		if mediaID != nil {
+			// Only the caption and credit the feed shows
+			a.FeaturedMedia = &models.ArticleMedia{
+				ID: *mediaID, ArticleID: a.ID, Caption: mediaCaption, Credit: mediaCredit, IsFeatured: true,
+			}
+		}
+		articles = append(articles, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list RSS articles: %w", err)
+	}
+	rows.Close()
+
+	ids := make([]uuid.UUID, len(articles))
+	for i := range articles {
+		ids[i] = articles[i].ID
+	}
+	bylines, err := r.GetAuthorsForArticles(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := r.GetTagsForArticles(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range articles {
+		articles[i].Authors = bylines[articles[i].ID]
+		articles[i].Tags = tags[articles[i].ID]
+	}
+
+	return articles, nil
+}
+
+// GetTagsForArticles returns the tags of each article, by name
+func (r *ArticleRepository) GetTagsForArticles(ctx context.Context, articleIDs []uuid.UUID) (map[uuid.UUID][]models.Tag, error) {
+	tags := make(map[uuid.UUID][]models.Tag, len(articleIDs))
+	if len(articleIDs) == 0 {
+		return tags, nil
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT at.article_id, t.id, t.name, t.slug
+		FROM article_tags at
+		JOIN tags t ON at.tag_id = t.id
+		WHERE at.article_id = ANY($1)
+		ORDER BY at.article_id, t.name
+	`, articleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var articleID uuid.UUID
+		var tag models.Tag
+		if err := rows.Scan(&articleID, &tag.ID, &tag.Name, &tag.Slug); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags[articleID] = append(tags[articleID], tag)
+	}
+
+	return tags, rows.Err()
+}
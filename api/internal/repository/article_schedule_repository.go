@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// ListScheduled returns the scheduled and published articles whose publish
+// time falls between from and to inclusive, in publish order, each with its
+// latest schedule adjustment. excludeID leaves one article out; pass uuid.Nil
+// to keep them all.
+func (r *ArticleRepository) ListScheduled(ctx context.Context, from, to time.Time, excludeID uuid.UUID) ([]models.ScheduledArticle, error) {
+	query := `
+		SELECT a.id, a.slug, a.title, a.status, a.published_at,
+			   adj.id, adj.original_publish_at, adj.adjusted_publish_at, adj.reason, adj.created_at
+		FROM articles a
+		LEFT JOIN LATERAL (
+			SELECT id, original_publish_at, adjusted_publish_at, reason, created_at
+			FROM article_schedule_adjustments
+			WHERE article_id = a.id
+			ORDER BY created_at DESC
+			LIMIT 1
+		) adj ON true
+		WHERE a.status IN ('scheduled', 'published') AND a.deleted_at IS NULL
+		  AND a.published_at BETWEEN $1 AND $2
+		  AND a.id <> $3
+		ORDER BY a.published_at, a.id
+	`
+
+	rows, err := r.db.Query(ctx, query, from, to, excludeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled articles: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []models.ScheduledArticle{}
+	for rows.Next() {
+		var article models.ScheduledArticle
+		var adjID *uuid.UUID
+		var originalAt, adjustedAt, adjCreatedAt *time.Time
+		var reason *string
+		if err := rows.Scan(
+			&article.ID, &article.Slug, &article.Title, &article.Status, &article.PublishedAt,
+			&adjID, &originalAt, &adjustedAt, &reason, &adjCreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled article: %w", err)
+		}
+		if adjID != nil {
+			article.Adjustment = &models.ArticleScheduleAdjustment{
+				ID:                *adjID,
+				ArticleID:         article.ID,
+				OriginalPublishAt: *originalAt,
+				AdjustedPublishAt: *adjustedAt,
+				Reason:            *reason,
+				CreatedAt:         *adjCreatedAt,
+			}
+		}
+		articles = append(articles, article)
+	}
+
+	return articles, nil
+}
+
+// ListDueScheduled returns the scheduled articles whose publish time has
+// arrived by now, earliest first
+func (r *ArticleRepository) ListDueScheduled(ctx context.Context, now time.Time) ([]models.ScheduledArticle, error) {
+	query := `
+		SELECT id, slug, title, status, published_at
+		FROM articles
+		WHERE status = 'scheduled' AND deleted_at IS NULL AND published_at <= $1
+		ORDER BY published_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due articles: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []models.ScheduledArticle{}
+	for rows.Next() {
+		var article models.ScheduledArticle
+		if err := rows.Scan(&article.ID, &article.Slug, &article.Title, &article.Status, &article.PublishedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan due article: %w", err)
+		}
+		articles = append(articles, article)
+	}
+
+	return articles, nil
+}
+
+// CountPublishedBetween counts the articles published after from and up to to
+func (r *ArticleRepository) CountPublishedBetween(ctx context.Context, from, to time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM articles
+		WHERE status = 'published' AND deleted_at IS NULL
+		  AND published_at > $1 AND published_at <= $2
+	`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, from, to).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count published articles: %w", err)
+	}
+	return count, nil
+}
+
+// PublishScheduled publishes the given articles if they are still scheduled,
+// keeping their scheduled time as the publish time. It returns the IDs it published.
+func (r *ArticleRepository) PublishScheduled(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		UPDATE articles SET status = 'published', updated_at = NOW()
+		WHERE id = ANY($1) AND status = 'scheduled' AND deleted_at IS NULL
+		RETURNING id
+	`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish scheduled articles: %w", err)
+	}
+	defer rows.Close()
+
+	published := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan id: %w", err)
+		}
+		published = append(published, id)
+	}
+
+	return published, nil
+}
+
+// RescheduleArticles moves scheduled articles to their adjusted publish times
+// and records each adjustment
+func (r *ArticleRepository) RescheduleArticles(ctx context.Context, adjustments []models.ArticleScheduleAdjustment) error {
+	if len(adjustments) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for i := range adjustments {
+		adj := &adjustments[i]
+
+		result, err := tx.Exec(ctx,
+			`UPDATE articles SET published_at = $1, updated_at = NOW() WHERE id = $2 AND status = 'scheduled'`,
+			adj.AdjustedPublishAt, adj.ArticleID)
+		if err != nil {
+			return fmt.Errorf("failed to reschedule article: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			// Published or unscheduled since it was picked up
+			continue
+		}
+
+		err = tx.QueryRow(ctx, `
+			INSERT INTO article_schedule_adjustments (article_id, original_publish_at, adjusted_publish_at, reason)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at
+		`, adj.ArticleID, adj.OriginalPublishAt, adj.AdjustedPublishAt, adj.Reason).Scan(&adj.ID, &adj.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record schedule adjustment: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
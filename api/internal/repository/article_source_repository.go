@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// accessed_at is read back as YYYY-MM-DD so it round-trips as a plain date
+const articleSourceColumns = `
+	id, article_id, type, title, url, to_char(accessed_at, 'YYYY-MM-DD'), is_primary, created_at
+`
+
+// articleCitationCount counts the sources of the article aliased as a
+const articleCitationCount = `(SELECT COUNT(*) FROM article_sources src WHERE src.article_id = a.id)`
+
+func scanArticleSource(row pgx.Row) (*models.ArticleSource, error) {
+	source := &models.ArticleSource{}
+	err := row.Scan(
+		&source.ID, &source.ArticleID, &source.Type, &source.Title, &source.URL, &source.AccessedAt,
+		&source.IsPrimary, &source.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// GetArticleSources returns an article's sources, primary sources first
+func (r *ArticleRepository) GetArticleSources(ctx context.Context, articleID uuid.UUID) ([]models.ArticleSource, error) {
+	query := `
+		SELECT ` + articleSourceColumns + `
+		FROM article_sources
+		WHERE article_id = $1
+		ORDER BY is_primary DESC, created_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article sources: %w", err)
+	}
+	defer rows.Close()
+
+	sources := []models.ArticleSource{}
+	for rows.Next() {
+		source, err := scanArticleSource(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan article source: %w", err)
+		}
+		sources = append(sources, *source)
+	}
+
+	return sources, nil
+}
+
+func (r *ArticleRepository) GetArticleSource(ctx context.Context, id uuid.UUID) (*models.ArticleSource, error) {
+	query := `SELECT ` + articleSourceColumns + ` FROM article_sources WHERE id = $1`
+
+	source, err := scanArticleSource(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article source: %w", err)
+	}
+
+	return source, nil
+}
+
+func (r *ArticleRepository) CreateArticleSource(ctx context.Context, articleID uuid.UUID, req *models.CreateArticleSourceRequest) (*models.ArticleSource, error) {
+	// Selecting from articles rejects missing or deleted articles without a FK error
+	query := `
+		INSERT INTO article_sources (article_id, type, title, url, accessed_at, is_primary)
+		SELECT a.id, $2::article_source_type, $3, $4, $5::date, $6
+		FROM articles a
+		WHERE a.id = $1 AND a.deleted_at IS NULL
+		RETURNING ` + articleSourceColumns
+
+	source, err := scanArticleSource(r.db.QueryRow(ctx, query,
+		articleID, req.Type, strings.TrimSpace(req.Title), req.URL, req.AccessedAt, req.IsPrimary,
+	))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("article not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create article source: %w", err)
+	}
+
+	return source, nil
+}
+
+func (r *ArticleRepository) UpdateArticleSource(ctx context.Context, id uuid.UUID, req *models.UpdateArticleSourceRequest) (*models.ArticleSource, error) {
+	var title *string
+	if req.Title != nil {
+		trimmed := strings.TrimSpace(*req.Title)
+		title = &trimmed
+	}
+
+	query := `
+		UPDATE article_sources SET
+			type = COALESCE($2::article_source_type, type),
+			title = COALESCE($3, title),
+			url = COALESCE($4, url),
+			accessed_at = COALESCE($5::date, accessed_at),
+			is_primary = COALESCE($6, is_primary)
+		WHERE id = $1
+		RETURNING ` + articleSourceColumns
+
+	source, err := scanArticleSource(r.db.QueryRow(ctx, query,
+		id, req.Type, title, req.URL, req.AccessedAt, req.IsPrimary,
+	))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("article source not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update article source: %w", err)
+	}
+
+	return source, nil
+}
+
+func (r *ArticleRepository) DeleteArticleSource(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM article_sources WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete article source: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("article source not found")
+	}
+	return nil
+}
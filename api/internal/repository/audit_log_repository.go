@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditLogRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditLogRepository(db *pgxpool.Pool) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create records an audit entry, filling in its ID and creation time
+func (r *AuditLogRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (actor_id, impersonated_user_id, action, method, route, path, status_code, ip_address)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		entry.ActorID, entry.ImpersonatedUserID, entry.Action, entry.Method,
+		entry.Route, entry.Path, entry.StatusCode, entry.IPAddress,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return nil
+}
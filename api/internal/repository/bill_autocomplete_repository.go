@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// billNumberPrefix is the LIKE pattern matching bill numbers that start with
+// query, ignoring case and spaces as idx_bills_number_prefix does
+func billNumberPrefix(query string) string {
+	return likeEscaper.Replace(strings.ToLower(strings.ReplaceAll(query, " ", ""))) + "%"
+}
+
+// Autocomplete suggests bills whose number starts with query, ignoring spaces,
+// or whose title contains it. Number matches come first, then the most
+// recently filed.
+func (r *BillRepository) Autocomplete(ctx context.Context, query string, limit int) ([]models.BillSuggestion, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, bill_number, title, slug, short_title, status, chamber
+		FROM bills
+		WHERE deleted_at IS NULL
+		  AND (lower(replace(bill_number, ' ', '')) LIKE $1 OR title ILIKE $2)
+		ORDER BY lower(replace(bill_number, ' ', '')) LIKE $1 DESC, filed_date DESC, bill_number
+		LIMIT $3
+	`, billNumberPrefix(query), "%"+likeEscaper.Replace(query)+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to autocomplete bills: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []models.BillSuggestion{}
+	for rows.Next() {
+		var b models.BillSuggestion
+		if err := rows.Scan(&b.ID, &b.BillNumber, &b.Title, &b.Slug, &b.ShortTitle, &b.Status, &b.Chamber); err != nil {
+			return nil, fmt.Errorf("failed to scan bill suggestion: %w", err)
+		}
+		suggestions = append(suggestions, b)
+	}
+
+	return suggestions, rows.Err()
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const billDocumentColumns = `
+	id, bill_id, document_type, file_url, file_name, file_size, page_count, extraction_failed,
+	uploaded_by, created_at
+`
+
+func scanBillDocument(row pgx.Row) (*models.BillDocument, error) {
+	doc := &models.BillDocument{}
+	err := row.Scan(
+		&doc.ID, &doc.BillID, &doc.DocumentType, &doc.FileURL, &doc.FileName, &doc.FileSize,
+		&doc.PageCount, &doc.ExtractionFailed, &doc.UploadedBy, &doc.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// GetBillDocuments returns a bill's documents, oldest first
+func (r *BillRepository) GetBillDocuments(ctx context.Context, billID uuid.UUID) ([]models.BillDocument, error) {
+	query := `
+		SELECT ` + billDocumentColumns + `
+		FROM bill_documents
+		WHERE bill_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, billID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill documents: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []models.BillDocument{}
+	for rows.Next() {
+		doc, err := scanBillDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bill document: %w", err)
+		}
+		docs = append(docs, *doc)
+	}
+
+	return docs, nil
+}
+
+func (r *BillRepository) GetBillDocument(ctx context.Context, id uuid.UUID) (*models.BillDocument, error) {
+	query := `SELECT ` + billDocumentColumns + ` FROM bill_documents WHERE id = $1`
+
+	doc, err := scanBillDocument(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// CreateBillDocument records an uploaded document and, when text was
+// extracted from it, adds that text to the bill's full text in the same
+// transaction
+func (r *BillRepository) CreateBillDocument(ctx context.Context, doc *models.BillDocument, text string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Selecting from bills rejects missing or deleted bills without a FK error
+	err = tx.QueryRow(ctx, `
+		INSERT INTO bill_documents (bill_id, document_type, file_url, file_name, file_size, page_count, extraction_failed, uploaded_by)
+		SELECT b.id, $2::bill_document_type, $3, $4, $5, $6, $7, $8
+		FROM bills b
+		WHERE b.id = $1 AND b.deleted_at IS NULL
+		RETURNING id, created_at
+	`, doc.BillID, doc.DocumentType, doc.FileURL, doc.FileName, doc.FileSize, doc.PageCount,
+		doc.ExtractionFailed, doc.UploadedBy,
+	).Scan(&doc.ID, &doc.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("bill not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create bill document: %w", err)
+	}
+
+	if text != "" {
+		_, err = tx.Exec(ctx, `
+			UPDATE bills
+			SET full_text = CASE WHEN COALESCE(full_text, '') = '' THEN $2 ELSE full_text || E'\n\n' || $2 END,
+				updated_at = NOW()
+			WHERE id = $1
+		`, doc.BillID, text)
+		if err != nil {
+			return fmt.Errorf("failed to update bill full text: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBillDocument removes the document record. Text it contributed stays in
+// the bill's full text, which staff may have edited since.
+func (r *BillRepository) DeleteBillDocument(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM bill_documents WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete bill document: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("bill document not found")
+	}
+
+	return nil
+}
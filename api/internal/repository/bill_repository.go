@@ -103,12 +103,12 @@ func (r *BillRepository) ListCommittees(ctx context.Context, chamber *string) ([
 func (r *BillRepository) GetCommitteeBySlug(ctx context.Context, slug string) (*models.Committee, error) {
 	committee := &models.Committee{}
 	err := r.db.QueryRow(ctx, `
-		SELECT id, chamber, name, slug, description, chairperson_id, vice_chairperson_id, is_active, created_at, updated_at
+		SELECT id, chamber, name, slug, description, is_active, created_at, updated_at
 		FROM committees
 		WHERE slug = $1 AND deleted_at IS NULL
 	`, slug).Scan(
 		&committee.ID, &committee.Chamber, &committee.Name, &committee.Slug, &committee.Description,
-		&committee.ChairpersonID, &committee.ViceChairpersonID, &committee.IsActive, &committee.CreatedAt, &committee.UpdatedAt,
+		&committee.IsActive, &committee.CreatedAt, &committee.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, nil
@@ -116,13 +116,20 @@ func (r *BillRepository) GetCommitteeBySlug(ctx context.Context, slug string) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to get committee: %w", err)
 	}
+
+	members, err := r.GetCurrentCommitteeMembers(ctx, committee.ID)
+	if err != nil {
+		return nil, err
+	}
+	applyCommitteeMembers(committee, members)
+
 	return committee, nil
 }
 
 // Bills
 
 func (r *BillRepository) Create(ctx context.Context, req *models.CreateBillRequest) (*models.Bill, error) {
-	filedDate, err := time.Parse("2006-01-02", req.FiledDate)
+	filedDate, err := models.ParseDate(req.FiledDate)
 	if err != nil {
 		return nil, fmt.Errorf("invalid filed_date format: %w", err)
 	}
@@ -137,11 +144,11 @@ func (r *BillRepository) Create(ctx context.Context, req *models.CreateBillReque
 	err = tx.QueryRow(ctx, `
 		INSERT INTO bills (session_id, chamber, bill_number, title, slug, short_title, summary, full_text, significance, status, filed_date)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING id, session_id, chamber, bill_number, title, slug, short_title, summary, full_text, significance, status, filed_date, created_at, updated_at
+		RETURNING id, session_id, chamber, bill_number, title, slug, short_title, summary, full_text, significance, status, filed_date, data_as_of, created_at, updated_at
 	`, req.SessionID, req.Chamber, req.BillNumber, req.Title, req.Slug, req.ShortTitle, req.Summary, req.FullText, req.Significance, req.Status, filedDate).Scan(
 		&bill.ID, &bill.SessionID, &bill.Chamber, &bill.BillNumber, &bill.Title, &bill.Slug,
 		&bill.ShortTitle, &bill.Summary, &bill.FullText, &bill.Significance, &bill.Status, &bill.FiledDate,
-		&bill.CreatedAt, &bill.UpdatedAt,
+		&bill.DataAsOf, &bill.CreatedAt, &bill.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bill: %w", err)
@@ -197,13 +204,13 @@ func (r *BillRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Bil
 	bill := &models.Bill{}
 	err := r.db.QueryRow(ctx, `
 		SELECT id, session_id, chamber, bill_number, title, slug, short_title, summary, full_text, significance,
-		       status, filed_date, last_action_date, date_signed, republic_act_number, created_at, updated_at
+		       status, filed_date, last_action_date, date_signed, republic_act_number, data_as_of, created_at, updated_at
 		FROM bills
 		WHERE id = $1 AND deleted_at IS NULL
 	`, id).Scan(
 		&bill.ID, &bill.SessionID, &bill.Chamber, &bill.BillNumber, &bill.Title, &bill.Slug, &bill.ShortTitle,
 		&bill.Summary, &bill.FullText, &bill.Significance, &bill.Status, &bill.FiledDate, &bill.LastActionDate,
-		&bill.DateSigned, &bill.RepublicActNumber, &bill.CreatedAt, &bill.UpdatedAt,
+		&bill.DateSigned, &bill.RepublicActNumber, &bill.DataAsOf, &bill.CreatedAt, &bill.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, nil
@@ -218,13 +225,13 @@ func (r *BillRepository) GetBySlug(ctx context.Context, slug string) (*models.Bi
 	bill := &models.Bill{}
 	err := r.db.QueryRow(ctx, `
 		SELECT id, session_id, chamber, bill_number, title, slug, short_title, summary, full_text, significance,
-		       status, filed_date, last_action_date, date_signed, republic_act_number, created_at, updated_at
+		       status, filed_date, last_action_date, date_signed, republic_act_number, data_as_of, created_at, updated_at
 		FROM bills
 		WHERE slug = $1 AND deleted_at IS NULL
 	`, slug).Scan(
 		&bill.ID, &bill.SessionID, &bill.Chamber, &bill.BillNumber, &bill.Title, &bill.Slug, &bill.ShortTitle,
 		&bill.Summary, &bill.FullText, &bill.Significance, &bill.Status, &bill.FiledDate, &bill.LastActionDate,
-		&bill.DateSigned, &bill.RepublicActNumber, &bill.CreatedAt, &bill.UpdatedAt,
+		&bill.DateSigned, &bill.RepublicActNumber, &bill.DataAsOf, &bill.CreatedAt, &bill.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, nil
@@ -239,6 +246,7 @@ func (r *BillRepository) GetBySlug(ctx context.Context, slug string) (*models.Bi
 	bill.Topics, _ = r.GetBillTopics(ctx, bill.ID)
 	bill.Committees, _ = r.GetBillCommittees(ctx, bill.ID)
 	bill.Votes, _ = r.GetBillVotes(ctx, bill.ID)
+	bill.Documents, _ = r.GetBillDocuments(ctx, bill.ID)
 
 	// Load principal authors separately for easy access
 	for _, author := range bill.Authors {
@@ -274,7 +282,8 @@ func (r *BillRepository) List(ctx context.Context, filter *models.BillFilter, pa
 			argNum++
 		}
 		if filter.TopicID != nil {
-			whereClause += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM bill_topic_assignments bta WHERE bta.bill_id = b.id AND bta.topic_id = $%d)", argNum)
+			// Filtering by a topic also matches bills tagged with any of its sub-topics
+			whereClause += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM bill_topic_assignments bta WHERE bta.bill_id = b.id AND bta.topic_id IN (%s))", fmt.Sprintf(topicSubtreeQuery, argNum))
 			args = append(args, *filter.TopicID)
 			argNum++
 		}
@@ -283,11 +292,17 @@ func (r *BillRepository) List(ctx context.Context, filter *models.BillFilter, pa
 			args = append(args, *filter.AuthorID)
 			argNum++
 		}
-		if filter.Search != nil && *filter.Search != "" {
-			whereClause += fmt.Sprintf(" AND (b.title ILIKE $%d OR b.bill_number ILIKE $%d OR b.short_title ILIKE $%d)", argNum, argNum, argNum)
-			args = append(args, "%"+*filter.Search+"%")
+		if filter.CommitteeID != nil {
+			whereClause += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM bill_committees bc WHERE bc.bill_id = b.id AND bc.committee_id = $%d)", argNum)
+			args = append(args, *filter.CommitteeID)
 			argNum++
 		}
+		if filter.Search != nil && *filter.Search != "" {
+			// Full text matches the expression indexed by idx_bills_full_text_search
+			whereClause += fmt.Sprintf(" AND (b.title ILIKE $%d OR b.bill_number ILIKE $%d OR b.short_title ILIKE $%d OR to_tsvector('english', COALESCE(b.full_text, '')) @@ plainto_tsquery('english', $%d))", argNum, argNum, argNum, argNum+1)
+			args = append(args, "%"+*filter.Search+"%", *filter.Search)
+			argNum += 2
+		}
 		if filter.FiledAfter != nil {
 			whereClause += fmt.Sprintf(" AND b.filed_date >= $%d", argNum)
 			args = append(args, *filter.FiledAfter)
@@ -310,9 +325,10 @@ func (r *BillRepository) List(ctx context.Context, filter *models.BillFilter, pa
 
 	// Get bills
 	query := fmt.Sprintf(`
-		SELECT b.id, b.chamber, b.bill_number, b.title, b.slug, b.short_title, b.status, b.filed_date, b.last_action_date,
+		SELECT b.id, b.chamber, b.bill_number, b.title, b.slug, b.short_title, b.status, b.filed_date, b.last_action_date, b.data_as_of,
 		       COALESCE((SELECT COUNT(*) FROM bill_authors WHERE bill_id = b.id), 0) as author_count,
-		       COALESCE((SELECT array_agg(bt.name) FROM bill_topics bt JOIN bill_topic_assignments bta ON bt.id = bta.topic_id WHERE bta.bill_id = b.id), '{}') as topic_names
+		       COALESCE((SELECT array_agg(bt.name) FROM bill_topics bt JOIN bill_topic_assignments bta ON bt.id = bta.topic_id WHERE bta.bill_id = b.id), '{}') as topic_names,
+		       EXISTS (SELECT 1 FROM floor_deliberations fd WHERE fd.bill_id = b.id) as has_transcripts
 		FROM bills b
 		%s
 		ORDER BY b.filed_date DESC, b.created_at DESC
@@ -330,8 +346,8 @@ func (r *BillRepository) List(ctx context.Context, filter *models.BillFilter, pa
 	for rows.Next() {
 		var b models.BillListItem
 		err := rows.Scan(
-			&b.ID, &b.Chamber, &b.BillNumber, &b.Title, &b.Slug, &b.ShortTitle, &b.Status, &b.FiledDate, &b.LastActionDate,
-			&b.AuthorCount, &b.TopicNames,
+			&b.ID, &b.Chamber, &b.BillNumber, &b.Title, &b.Slug, &b.ShortTitle, &b.Status, &b.FiledDate, &b.LastActionDate, &b.DataAsOf,
+			&b.AuthorCount, &b.TopicNames, &b.HasTranscripts,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan bill: %w", err)
@@ -389,9 +405,17 @@ func (r *BillRepository) Update(ctx context.Context, id uuid.UUID, req *models.U
 		setClauses = append(setClauses, fmt.Sprintf("status = $%d", argNum))
 		args = append(args, *req.Status)
 		argNum++
+		if req.DataAsOf == nil {
+			setClauses = append(setClauses, "data_as_of = NOW()")
+		}
+	}
+	if req.DataAsOf != nil {
+		setClauses = append(setClauses, fmt.Sprintf("data_as_of = $%d", argNum))
+		args = append(args, *req.DataAsOf)
+		argNum++
 	}
 	if req.LastActionDate != nil {
-		date, err := time.Parse("2006-01-02", *req.LastActionDate)
+		date, err := models.ParseDate(*req.LastActionDate)
 		if err != nil {
 			return nil, fmt.Errorf("invalid last_action_date format: %w", err)
 		}
@@ -400,7 +424,7 @@ func (r *BillRepository) Update(ctx context.Context, id uuid.UUID, req *models.U
 		argNum++
 	}
 	if req.DateSigned != nil {
-		date, err := time.Parse("2006-01-02", *req.DateSigned)
+		date, err := models.ParseDate(*req.DateSigned)
 		if err != nil {
 			return nil, fmt.Errorf("invalid date_signed format: %w", err)
 		}
@@ -421,14 +445,14 @@ func (r *BillRepository) Update(ctx context.Context, id uuid.UUID, req *models.U
 		UPDATE bills SET %s
 		WHERE id = $1 AND deleted_at IS NULL
 		RETURNING id, session_id, chamber, bill_number, title, slug, short_title, summary, full_text, significance,
-		          status, filed_date, last_action_date, date_signed, republic_act_number, created_at, updated_at
+		          status, filed_date, last_action_date, date_signed, republic_act_number, data_as_of, created_at, updated_at
 	`, strings.Join(setClauses, ", "))
 
 	bill := &models.Bill{}
 	err := r.db.QueryRow(ctx, query, args...).Scan(
 		&bill.ID, &bill.SessionID, &bill.Chamber, &bill.BillNumber, &bill.Title, &bill.Slug, &bill.ShortTitle,
 		&bill.Summary, &bill.FullText, &bill.Significance, &bill.Status, &bill.FiledDate, &bill.LastActionDate,
-		&bill.DateSigned, &bill.RepublicActNumber, &bill.CreatedAt, &bill.UpdatedAt,
+		&bill.DateSigned, &bill.RepublicActNumber, &bill.DataAsOf, &bill.CreatedAt, &bill.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, nil
@@ -508,7 +532,7 @@ func (r *BillRepository) GetBillStatusHistory(ctx context.Context, billID uuid.U
 }
 
 func (r *BillRepository) AddBillStatus(ctx context.Context, billID uuid.UUID, req *models.AddBillStatusRequest) error {
-	actionDate, err := time.Parse("2006-01-02", req.ActionDate)
+	actionDate, err := models.ParseDate(req.ActionDate)
 	if err != nil {
 		return fmt.Errorf("invalid action_date format: %w", err)
 	}
@@ -530,7 +554,7 @@ func (r *BillRepository) AddBillStatus(ctx context.Context, billID uuid.UUID, re
 
 	// Update bill status and last_action_date
 	_, err = tx.Exec(ctx, `
-		UPDATE bills SET status = $1, last_action_date = $2 WHERE id = $3
+		UPDATE bills SET status = $1, last_action_date = $2, data_as_of = NOW() WHERE id = $3
 	`, req.Status, actionDate, billID)
 	if err != nil {
 		return fmt.Errorf("failed to update bill status: %w", err)
@@ -541,9 +565,31 @@ func (r *BillRepository) AddBillStatus(ctx context.Context, billID uuid.UUID, re
 
 // Bill Topics
 
+// topicTreeCTE walks bill_topics from the roots down, computing each topic's
+// depth and a name path used to order topics depth-first.
+const topicTreeCTE = `
+	WITH RECURSIVE topic_tree AS (
+		SELECT id, name, slug, description, created_at, parent_id, 0 AS level, ARRAY[name::text] AS path
+		FROM bill_topics
+		WHERE parent_id IS NULL
+		UNION ALL
+		SELECT bt.id, bt.name, bt.slug, bt.description, bt.created_at, bt.parent_id, tt.level + 1, tt.path || bt.name::text
+		FROM bill_topics bt
+		JOIN topic_tree tt ON bt.parent_id = tt.id
+	)`
+
+// topicSubtreeQuery selects a topic's ID and the IDs of all its descendants.
+const topicSubtreeQuery = `
+	WITH RECURSIVE subtree AS (
+		SELECT id FROM bill_topics WHERE id = $%d
+		UNION ALL
+		SELECT bt.id FROM bill_topics bt JOIN subtree st ON bt.parent_id = st.id
+	)
+	SELECT id FROM subtree`
+
 func (r *BillRepository) GetBillTopics(ctx context.Context, billID uuid.UUID) ([]models.BillTopic, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT bt.id, bt.name, bt.slug, bt.description, bt.created_at
+		SELECT bt.id, bt.name, bt.slug, bt.description, bt.created_at, bt.parent_id
 		FROM bill_topics bt
 		JOIN bill_topic_assignments bta ON bt.id = bta.topic_id
 		WHERE bta.bill_id = $1
@@ -557,7 +603,7 @@ func (r *BillRepository) GetBillTopics(ctx context.Context, billID uuid.UUID) ([
 	var topics []models.BillTopic
 	for rows.Next() {
 		var t models.BillTopic
-		err := rows.Scan(&t.ID, &t.Name, &t.Slug, &t.Description, &t.CreatedAt)
+		err := rows.Scan(&t.ID, &t.Name, &t.Slug, &t.Description, &t.CreatedAt, &t.ParentID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan topic: %w", err)
 		}
@@ -566,12 +612,14 @@ func (r *BillRepository) GetBillTopics(ctx context.Context, billID uuid.UUID) ([
 	return topics, nil
 }
 
+// ListAllTopics returns every topic flattened in depth-first order, with its
+// parent and depth in the hierarchy.
 func (r *BillRepository) ListAllTopics(ctx context.Context) ([]models.BillTopic, error) {
-	rows, err := r.db.Query(ctx, `
-		SELECT bt.id, bt.name, bt.slug, bt.description, bt.created_at,
-		       COALESCE((SELECT COUNT(*) FROM bill_topic_assignments WHERE topic_id = bt.id), 0) as bill_count
-		FROM bill_topics bt
-		ORDER BY bt.name
+	rows, err := r.db.Query(ctx, topicTreeCTE+`
+		SELECT tt.id, tt.name, tt.slug, tt.description, tt.created_at, tt.parent_id, tt.level,
+		       COALESCE((SELECT COUNT(*) FROM bill_topic_assignments WHERE topic_id = tt.id), 0) as bill_count
+		FROM topic_tree tt
+		ORDER BY tt.path
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list topics: %w", err)
@@ -581,7 +629,7 @@ func (r *BillRepository) ListAllTopics(ctx context.Context) ([]models.BillTopic,
 	var topics []models.BillTopic
 	for rows.Next() {
 		var t models.BillTopic
-		err := rows.Scan(&t.ID, &t.Name, &t.Slug, &t.Description, &t.CreatedAt, &t.BillCount)
+		err := rows.Scan(&t.ID, &t.Name, &t.Slug, &t.Description, &t.CreatedAt, &t.ParentID, &t.Level, &t.BillCount)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan topic: %w", err)
 		}
@@ -590,6 +638,153 @@ func (r *BillRepository) ListAllTopics(ctx context.Context) ([]models.BillTopic,
 	return topics, nil
 }
 
+// GetTopicTree returns the root topics with their sub-topics nested beneath them.
+func (r *BillRepository) GetTopicTree(ctx context.Context) ([]models.BillTopicNode, error) {
+	topics, err := r.ListAllTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildTopicTree(topics), nil
+}
+
+// buildTopicTree nests a depth-first ordered topic list under its parents.
+func buildTopicTree(topics []models.BillTopic) []models.BillTopicNode {
+	children := make(map[uuid.UUID][]models.BillTopic)
+	var roots []models.BillTopic
+	for _, t := range topics {
+		if t.ParentID == nil {
+			roots = append(roots, t)
+			continue
+		}
+		children[*t.ParentID] = append(children[*t.ParentID], t)
+	}
+
+	var build func(list []models.BillTopic) []models.BillTopicNode
+	build = func(list []models.BillTopic) []models.BillTopicNode {
+		nodes := make([]models.BillTopicNode, 0, len(list))
+		for _, t := range list {
+			nodes = append(nodes, models.BillTopicNode{
+				BillTopic: t,
+				Children:  build(children[t.ID]),
+			})
+		}
+		return nodes
+	}
+	return build(roots)
+}
+
+func (r *BillRepository) GetTopicByID(ctx context.Context, id uuid.UUID) (*models.BillTopic, error) {
+	t := &models.BillTopic{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, name, slug, description, created_at, parent_id
+		FROM bill_topics
+		WHERE id = $1
+	`, id).Scan(&t.ID, &t.Name, &t.Slug, &t.Description, &t.CreatedAt, &t.ParentID)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topic: %w", err)
+	}
+	return t, nil
+}
+
+func (r *BillRepository) CreateTopic(ctx context.Context, req *models.CreateBillTopicRequest) (*models.BillTopic, error) {
+	t := &models.BillTopic{}
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO bill_topics (name, slug, description, parent_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, name, slug, description, created_at, parent_id
+	`, req.Name, req.Slug, req.Description, req.ParentID).Scan(
+		&t.ID, &t.Name, &t.Slug, &t.Description, &t.CreatedAt, &t.ParentID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create topic: %w", err)
+	}
+	return t, nil
+}
+
+func (r *BillRepository) UpdateTopic(ctx context.Context, id uuid.UUID, req *models.UpdateBillTopicRequest) (*models.BillTopic, error) {
+	setClauses := []string{}
+	args := []interface{}{id}
+	argNum := 2
+
+	if req.Name != nil {
+		setClauses = append(setClauses, fmt.Sprintf("name = $%d", argNum))
+		args = append(args, *req.Name)
+		argNum++
+	}
+	if req.Slug != nil {
+		setClauses = append(setClauses, fmt.Sprintf("slug = $%d", argNum))
+		args = append(args, *req.Slug)
+		argNum++
+	}
+	if req.Description != nil {
+		setClauses = append(setClauses, fmt.Sprintf("description = $%d", argNum))
+		args = append(args, *req.Description)
+		argNum++
+	}
+	if req.RemoveParent {
+		setClauses = append(setClauses, "parent_id = NULL")
+	} else if req.ParentID != nil {
+		setClauses = append(setClauses, fmt.Sprintf("parent_id = $%d", argNum))
+		args = append(args, *req.ParentID)
+	}
+
+	if len(setClauses) == 0 {
+		return r.GetTopicByID(ctx, id)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE bill_topics SET %s
+		WHERE id = $1
+		RETURNING id, name, slug, description, created_at, parent_id
+	`, strings.Join(setClauses, ", "))
+
+	t := &models.BillTopic{}
+	err := r.db.QueryRow(ctx, query, args...).Scan(&t.ID, &t.Name, &t.Slug, &t.Description, &t.CreatedAt, &t.ParentID)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update topic: %w", err)
+	}
+	return t, nil
+}
+
+func (r *BillRepository) DeleteTopic(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM bill_topics WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete topic: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("topic not found")
+	}
+	return nil
+}
+
+// HasChildTopics reports whether any topic is nested directly under id.
+func (r *BillRepository) HasChildTopics(ctx context.Context, id uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM bill_topics WHERE parent_id = $1)`, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check child topics: %w", err)
+	}
+	return exists, nil
+}
+
+// IsTopicInSubtree reports whether candidateID is rootID itself or one of its
+// descendants.
+func (r *BillRepository) IsTopicInSubtree(ctx context.Context, rootID, candidateID uuid.UUID) (bool, error) {
+	var exists bool
+	query := fmt.Sprintf(`SELECT $2::uuid IN (%s)`, fmt.Sprintf(topicSubtreeQuery, 1))
+	err := r.db.QueryRow(ctx, query, rootID, candidateID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check topic hierarchy: %w", err)
+	}
+	return exists, nil
+}
+
 // Bill Committees
 
 func (r *BillRepository) GetBillCommittees(ctx context.Context, billID uuid.UUID) ([]models.BillCommittee, error) {
@@ -657,11 +852,17 @@ func (r *BillRepository) AddBillVote(ctx context.Context, billID uuid.UUID, req
 		return nil, fmt.Errorf("invalid vote_date format: %w", err)
 	}
 
+	// Recording a vote brings the bill's data up to date
 	vote := &models.BillVote{}
 	err = r.db.QueryRow(ctx, `
-		INSERT INTO bill_votes (bill_id, chamber, reading, vote_date, yeas, nays, abstentions, absent, is_passed, notes)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, bill_id, chamber, reading, vote_date, yeas, nays, abstentions, absent, is_passed, notes, created_at
+		WITH vote AS (
+			INSERT INTO bill_votes (bill_id, chamber, reading, vote_date, yeas, nays, abstentions, absent, is_passed, notes)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING id, bill_id, chamber, reading, vote_date, yeas, nays, abstentions, absent, is_passed, notes, created_at
+		), touched AS (
+			UPDATE bills SET data_as_of = NOW() WHERE id = $1
+		)
+		SELECT * FROM vote
 	`, billID, req.Chamber, req.Reading, voteDate, req.Yeas, req.Nays, req.Abstentions, req.Absent, req.IsPassed, req.Notes).Scan(
 		&vote.ID, &vote.BillID, &vote.Chamber, &vote.Reading, &vote.VoteDate, &vote.Yeas, &vote.Nays,
 		&vote.Abstentions, &vote.Absent, &vote.IsPassed, &vote.Notes, &vote.CreatedAt,
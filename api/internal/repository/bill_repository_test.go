@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTopicTree(t *testing.T) {
+	economy := models.BillTopic{ID: uuid.New(), Name: "Economy"}
+	tax := models.BillTopic{ID: uuid.New(), Name: "Taxation", ParentID: &economy.ID, Level: 1}
+	vat := models.BillTopic{ID: uuid.New(), Name: "VAT", ParentID: &tax.ID, Level: 2}
+	health := models.BillTopic{ID: uuid.New(), Name: "Health"}
+
+	tree := buildTopicTree([]models.BillTopic{economy, tax, vat, health})
+
+	require.Len(t, tree, 2)
+	assert.Equal(t, "Economy", tree[0].Name)
+	assert.Equal(t, "Health", tree[1].Name)
+	assert.NotNil(t, tree[1].Children, "leaf topics should serialize an empty children array")
+	assert.Empty(t, tree[1].Children)
+
+	require.Len(t, tree[0].Children, 1)
+	assert.Equal(t, "Taxation", tree[0].Children[0].Name)
+	require.Len(t, tree[0].Children[0].Children, 1)
+	assert.Equal(t, "VAT", tree[0].Children[0].Children[0].Name)
+	assert.Equal(t, 2, tree[0].Children[0].Children[0].Level)
+}
+
+func TestBuildTopicTreeEmpty(t *testing.T) {
+	tree := buildTopicTree(nil)
+	assert.NotNil(t, tree)
+	assert.Empty(t, tree)
+}
+
+func TestBillNumberPrefix(t *testing.T) {
+	assert.Equal(t, "hb123%", billNumberPrefix("HB 123"))
+	assert.Equal(t, "sb%", billNumberPrefix("SB"))
+	assert.Equal(t, `100\%\_\\%`, billNumberPrefix(`100% _\`), "LIKE wildcards are matched literally")
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetElectionPositionBrief names an election position. It returns nil if the
+// position doesn't exist.
+func (r *ElectionRepository) GetElectionPositionBrief(ctx context.Context, id uuid.UUID) (*models.ElectionPositionBrief, error) {
+	var p models.ElectionPositionBrief
+	err := r.db.QueryRow(ctx, `
+		SELECT ep.id, gp.name
+		FROM election_positions ep
+		JOIN government_positions gp ON ep.position_id = gp.id
+		WHERE ep.id = $1
+	`, id).Scan(&p.ID, &p.Name)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get election position: %w", err)
+	}
+	return &p, nil
+}
+
+// GetComparedCandidates returns the given candidates for a position, with
+// their party and platform issues. Candidates running for another position
+// are left out.
+func (r *ElectionRepository) GetComparedCandidates(ctx context.Context, positionID uuid.UUID, ids []uuid.UUID) ([]models.ComparedCandidate, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id, c.politician_id, c.ballot_number, c.ballot_name, c.status, c.is_incumbent,
+		       c.campaign_slogan, c.platform,
+		       COALESCE((SELECT ARRAY_AGG(ci.issue_slug ORDER BY ci.issue_slug) FROM candidate_issues ci WHERE ci.candidate_id = c.id), '{}'),
+		       p.id, p.name, p.slug, COALESCE(p.photo_list, p.photo), p.position, p.party,
+		       pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color
+		FROM candidates c
+		JOIN politicians p ON c.politician_id = p.id
+		LEFT JOIN political_parties pp ON c.party_id = pp.id
+		WHERE c.election_position_id = $1 AND c.id = ANY($2)
+	`, positionID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []models.ComparedCandidate
+	for rows.Next() {
+		var c models.ComparedCandidate
+		var partyID *uuid.UUID
+		var partyName, partySlug *string
+		var party models.PartyBrief
+
+		err := rows.Scan(
+			&c.ID, &c.PoliticianID, &c.BallotNumber, &c.BallotName, &c.Status, &c.IsIncumbent,
+			&c.CampaignSlogan, &c.Platform, &c.Issues,
+			&c.Politician.ID, &c.Politician.Name, &c.Politician.Slug, &c.Politician.Photo, &c.Politician.Position, &c.Politician.Party,
+			&partyID, &partyName, &partySlug, &party.Abbreviation, &party.Logo, &party.Color,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan candidate: %w", err)
+		}
+
+		if partyID != nil {
+			party.ID = *partyID
+			party.Name = *partyName
+			party.Slug = *partySlug
+			c.Party = &party
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// SetCandidateIssues replaces the issues recorded for a candidate
+func (r *ElectionRepository) SetCandidateIssues(ctx context.Context, candidateID uuid.UUID, issues []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM candidate_issues WHERE candidate_id = $1`, candidateID); err != nil {
+		return fmt.Errorf("failed to clear candidate issues: %w", err)
+	}
+
+	if len(issues) > 0 {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO candidate_issues (candidate_id, issue_slug)
+			SELECT $1, UNNEST($2::varchar[])
+			ON CONFLICT DO NOTHING
+		`, candidateID, issues)
+		if err != nil {
+			return fmt.Errorf("failed to save candidate issues: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetCandidateIssuesForPosition returns every candidate for a position with
+// the issues their platform addresses, in ballot order
+func (r *ElectionRepository) GetCandidateIssuesForPosition(ctx context.Context, positionID uuid.UUID) ([]models.CandidateIssues, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id, COALESCE(c.ballot_name, p.name),
+		       COALESCE(ARRAY_AGG(ci.issue_slug ORDER BY ci.issue_slug) FILTER (WHERE ci.issue_slug IS NOT NULL), '{}')
+		FROM candidates c
+		JOIN politicians p ON c.politician_id = p.id
+		LEFT JOIN candidate_issues ci ON ci.candidate_id = c.id
+		WHERE c.election_position_id = $1
+		GROUP BY c.id, c.ballot_name, p.name, c.ballot_number
+		ORDER BY c.ballot_number NULLS LAST, 2
+	`, positionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candidate issues: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []models.CandidateIssues{}
+	for rows.Next() {
+		var c models.CandidateIssues
+		if err := rows.Scan(&c.CandidateID, &c.CandidateName, &c.Issues); err != nil {
+			return nil, fmt.Errorf("failed to scan candidate issues: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SubstituteCandidate marks a candidate substituted and creates their
+// substitute for the same position and ballot number, in one transaction.
+// It returns the substitute's ID.
+func (r *ElectionRepository) SubstituteCandidate(ctx context.Context, originalID uuid.UUID, req *models.SubstituteCandidateRequest, partyID *uuid.UUID) (uuid.UUID, error) {
+	filingDate, err := models.ParseDatePtr(req.FilingDate)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid filing_date format: %w", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Marked first so the substitute can take over the ballot number
+	var positionID uuid.UUID
+	var ballotNumber *int
+	err = tx.QueryRow(ctx, `
+		UPDATE candidates SET status = 'substituted', substitution_reason = $2
+		WHERE id = $1 AND status <> 'substituted'
+		RETURNING election_position_id, ballot_number
+	`, originalID, req.OverrideReason).Scan(&positionID, &ballotNumber)
+	if err == pgx.ErrNoRows {
+		return uuid.Nil, fmt.Errorf("candidate has already been substituted")
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to mark candidate substituted: %w", err)
+	}
+
+	var substituteID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO candidates (election_position_id, politician_id, party_id, ballot_number, ballot_name, campaign_slogan, platform, status, filing_date, is_incumbent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 'filed', $8, $9)
+		RETURNING id
+	`, positionID, req.PoliticianID, partyID, ballotNumber, req.BallotName, req.CampaignSlogan, req.Platform, filingDate, req.IsIncumbent).Scan(&substituteID)
+	if err != nil {
+		if conflict := ballotNumberConflict(err); conflict != nil {
+			return uuid.Nil, conflict
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return uuid.Nil, fmt.Errorf("politician is already a candidate for this position")
+		}
+		return uuid.Nil, fmt.Errorf("failed to create substitute: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE candidates SET substituted_by = $2 WHERE id = $1`, originalID, substituteID); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to link substitute: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return substituteID, nil
+}
+
+// ListElectionSubstitutions maps each substituted candidate in an election
+// to the candidate who replaced them
+func (r *ElectionRepository) ListElectionSubstitutions(ctx context.Context, electionID uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id, c.substituted_by
+		FROM candidates c
+		JOIN election_positions ep ON c.election_position_id = ep.id
+		WHERE ep.election_id = $1 AND c.substituted_by IS NOT NULL
+	`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list substitutions: %w", err)
+	}
+	defer rows.Close()
+
+	substitutions := map[uuid.UUID]uuid.UUID{}
+	for rows.Next() {
+		var id, substituteID uuid.UUID
+		if err := rows.Scan(&id, &substituteID); err != nil {
+			return nil, fmt.Errorf("failed to scan substitution: %w", err)
+		}
+		substitutions[id] = substituteID
+	}
+	return substitutions, rows.Err()
+}
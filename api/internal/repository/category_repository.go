@@ -20,8 +20,8 @@ func NewCategoryRepository(db *pgxpool.Pool) *CategoryRepository {
 
 func (r *CategoryRepository) Create(ctx context.Context, category *models.Category) error {
 	query := `
-		INSERT INTO categories (name, slug, description)
-		VALUES ($1, $2, $3)
+		INSERT INTO categories (name, slug, description, is_internal)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -29,6 +29,7 @@ func (r *CategoryRepository) Create(ctx context.Context, category *models.Catego
 		category.Name,
 		category.Slug,
 		category.Description,
+		category.IsInternal,
 	).Scan(&category.ID, &category.CreatedAt, &category.UpdatedAt)
 
 	if err != nil {
@@ -40,14 +41,14 @@ func (r *CategoryRepository) Create(ctx context.Context, category *models.Catego
 
 func (r *CategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
 	query := `
-		SELECT id, name, slug, description, created_at, updated_at
+		SELECT id, name, slug, description, is_internal, created_at, updated_at
 		FROM categories
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	category := &models.Category{}
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&category.ID, &category.Name, &category.Slug, &category.Description,
+		&category.ID, &category.Name, &category.Slug, &category.Description, &category.IsInternal,
 		&category.CreatedAt, &category.UpdatedAt,
 	)
 
@@ -63,14 +64,14 @@ func (r *CategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models
 
 func (r *CategoryRepository) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
 	query := `
-		SELECT id, name, slug, description, created_at, updated_at
+		SELECT id, name, slug, description, is_internal, created_at, updated_at
 		FROM categories
 		WHERE slug = $1 AND deleted_at IS NULL
 	`
 
 	category := &models.Category{}
 	err := r.db.QueryRow(ctx, query, slug).Scan(
-		&category.ID, &category.Name, &category.Slug, &category.Description,
+		&category.ID, &category.Name, &category.Slug, &category.Description, &category.IsInternal,
 		&category.CreatedAt, &category.UpdatedAt,
 	)
 
@@ -84,11 +85,12 @@ func (r *CategoryRepository) GetBySlug(ctx context.Context, slug string) (*model
 	return category, nil
 }
 
+// List returns the public categories; internal ones are left out
 func (r *CategoryRepository) List(ctx context.Context) ([]models.Category, error) {
 	query := `
-		SELECT id, name, slug, description, created_at, updated_at
+		SELECT id, name, slug, description, is_internal, created_at, updated_at
 		FROM categories
-		WHERE deleted_at IS NULL
+		WHERE deleted_at IS NULL AND NOT is_internal
 		ORDER BY name ASC
 	`
 
@@ -102,7 +104,7 @@ func (r *CategoryRepository) List(ctx context.Context) ([]models.Category, error
 	for rows.Next() {
 		var category models.Category
 		err := rows.Scan(
-			&category.ID, &category.Name, &category.Slug, &category.Description,
+			&category.ID, &category.Name, &category.Slug, &category.Description, &category.IsInternal,
 			&category.CreatedAt, &category.UpdatedAt,
 		)
 		if err != nil {
@@ -116,6 +118,9 @@ func (r *CategoryRepository) List(ctx context.Context) ([]models.Category, error
 
 func (r *CategoryRepository) AdminList(ctx context.Context, filter *models.CategoryFilter, page, perPage int) (*models.PaginatedCategories, error) {
 	whereClause := "WHERE deleted_at IS NULL"
+	if filter.IncludeDeleted {
+		whereClause = "WHERE 1=1"
+	}
 	args := []interface{}{}
 	argCount := 0
 
@@ -149,7 +154,7 @@ func (r *CategoryRepository) AdminList(ctx context.Context, filter *models.Categ
 
 	argCount++
 	query := fmt.Sprintf(`
-		SELECT id, name, slug, description, created_at, updated_at
+		SELECT id, name, slug, description, is_internal, created_at, updated_at, deleted_at
 		FROM categories
 		%s
 		%s
@@ -166,7 +171,7 @@ func (r *CategoryRepository) AdminList(ctx context.Context, filter *models.Categ
 	categories := []models.Category{}
 	for rows.Next() {
 		var category models.Category
-		err := rows.Scan(&category.ID, &category.Name, &category.Slug, &category.Description, &category.CreatedAt, &category.UpdatedAt)
+		err := rows.Scan(&category.ID, &category.Name, &category.Slug, &category.Description, &category.IsInternal, &category.CreatedAt, &category.UpdatedAt, &category.DeletedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan category: %w", err)
 		}
@@ -187,11 +192,12 @@ func (r *CategoryRepository) Update(ctx context.Context, id uuid.UUID, req *mode
 		UPDATE categories
 		SET name = COALESCE($1, name),
 			slug = COALESCE($2, slug),
-			description = COALESCE($3, description)
-		WHERE id = $4
+			description = COALESCE($3, description),
+			is_internal = COALESCE($4, is_internal)
+		WHERE id = $5
 	`
 
-	result, err := r.db.Exec(ctx, query, req.Name, req.Slug, req.Description, id)
+	result, err := r.db.Exec(ctx, query, req.Name, req.Slug, req.Description, req.IsInternal, id)
 	if err != nil {
 		return fmt.Errorf("failed to update category: %w", err)
 	}
@@ -247,3 +253,125 @@ func (r *CategoryRepository) HardDelete(ctx context.Context, id uuid.UUID) error
 
 	return nil
 }
+
+// MigrateArticles moves the source category's articles, soft-deleted ones
+// included, into the target category in one transaction. With tagIDs only
+// articles carrying at least one of those tags move. With deleteSource the
+// source is soft-deleted when no articles are left in it.
+//
+// Moving published articles out of a public category into an internal one
+// takes them off the public site, so it is refused unless confirm is set.
+func (r *CategoryRepository) MigrateArticles(ctx context.Context, sourceID, targetID uuid.UUID, tagIDs []uuid.UUID, deleteSource, confirm bool) (*models.MigrateArticlesResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Lock the source so no article is added to it between the move and the
+	// remaining count
+	var sourceInternal, targetInternal bool
+	err = tx.QueryRow(ctx, "SELECT is_internal FROM categories WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", sourceID).Scan(&sourceInternal)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("category not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, "SELECT is_internal FROM categories WHERE id = $1 AND deleted_at IS NULL FOR SHARE", targetID).Scan(&targetInternal)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("target category not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target category: %w", err)
+	}
+
+	if len(tagIDs) == 0 {
+		tagIDs = nil
+	}
+
+	if targetInternal && !sourceInternal && !confirm {
+		var published int
+		err = tx.QueryRow(ctx, `
+			SELECT COUNT(*) FROM articles
+			WHERE category_id = $1 AND status = 'published' AND deleted_at IS NULL
+				AND ($2::uuid[] IS NULL OR id IN (SELECT article_id FROM article_tags WHERE tag_id = ANY($2)))
+		`, sourceID, tagIDs).Scan(&published)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count published articles: %w", err)
+		}
+		if published > 0 {
+			return nil, fmt.Errorf("moving published articles into an internal category hides them from the public; resend with confirm=true")
+		}
+	}
+	result, err := tx.Exec(ctx, `
+		UPDATE articles SET category_id = $2
+		WHERE category_id = $1
+			AND ($3::uuid[] IS NULL OR id IN (SELECT article_id FROM article_tags WHERE tag_id = ANY($3)))
+	`, sourceID, targetID, tagIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate articles: %w", err)
+	}
+
+	migrated := &models.MigrateArticlesResult{Migrated: int(result.RowsAffected())}
+
+	err = tx.QueryRow(ctx, "SELECT COUNT(*) FROM articles WHERE category_id = $1", sourceID).Scan(&migrated.Remaining)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count remaining articles: %w", err)
+	}
+
+	if deleteSource && migrated.Remaining == 0 {
+		if _, err := tx.Exec(ctx, "UPDATE categories SET deleted_at = NOW() WHERE id = $1", sourceID); err != nil {
+			return nil, fmt.Errorf("failed to delete category: %w", err)
+		}
+		migrated.SourceDeleted = true
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return migrated, nil
+}
+
+// Follow adds a follower to the category; following twice is a no-op
+func (r *CategoryRepository) Follow(ctx context.Context, categoryID, userID uuid.UUID) error {
+	query := `
+		INSERT INTO user_follows (user_id, entity_type, entity_id)
+		VALUES ($1, 'category', $2)
+		ON CONFLICT (user_id, entity_type, entity_id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, categoryID); err != nil {
+		return fmt.Errorf("failed to follow category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CategoryRepository) Unfollow(ctx context.Context, categoryID, userID uuid.UUID) error {
+	query := "DELETE FROM user_follows WHERE user_id = $1 AND entity_type = 'category' AND entity_id = $2"
+
+	if _, err := r.db.Exec(ctx, query, userID, categoryID); err != nil {
+		return fmt.Errorf("failed to unfollow category: %w", err)
+	}
+
+	return nil
+}
+
+// GetFollowStatus returns the follower count and whether userID (if any) follows the category
+func (r *CategoryRepository) GetFollowStatus(ctx context.Context, categoryID uuid.UUID, userID *uuid.UUID) (*models.FollowStatus, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(BOOL_OR(user_id = $2), false)
+		FROM user_follows
+		WHERE entity_type = 'category' AND entity_id = $1
+	`
+
+	status := &models.FollowStatus{}
+	if err := r.db.QueryRow(ctx, query, categoryID, userID).Scan(&status.FollowerCount, &status.IsFollowing); err != nil {
+		return nil, fmt.Errorf("failed to get category follow status: %w", err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// migrateArticlesFixture is a deprecated category holding a tagged and an
+// untagged article, and the category they are moving to
+type migrateArticlesFixture struct {
+	sourceID, targetID uuid.UUID
+	tagID              uuid.UUID
+	tagged, untagged   uuid.UUID
+}
+
+func setupMigrateArticlesFixture(t *testing.T) (*pgxpool.Pool, *migrateArticlesFixture) {
+	t.Helper()
+
+	ctx := context.Background()
+	pool := openTestDB(t)
+
+	suffix := uuid.NewString()[:8]
+	f := &migrateArticlesFixture{}
+
+	insert := func(query string, args ...interface{}) uuid.UUID {
+		var id uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, query, args...).Scan(&id))
+		return id
+	}
+	createCategory := func(slug string) uuid.UUID {
+		return insert("INSERT INTO categories (name, slug) VALUES ($1, $1) RETURNING id", slug+"-"+suffix)
+	}
+	createArticle := func(slug string) uuid.UUID {
+		return insert(`
+			INSERT INTO articles (slug, title, content, category_id, status)
+			VALUES ($1, $1, 'body', $2, 'draft')
+			RETURNING id
+		`, slug+"-"+suffix, f.sourceID)
+	}
+
+	f.sourceID = createCategory("old-desk")
+	f.targetID = createCategory("new-desk")
+	f.tagID = insert("INSERT INTO tags (name, slug) VALUES ($1, $1) RETURNING id", "move-me-"+suffix)
+	f.tagged = createArticle("tagged-story")
+	f.untagged = createArticle("untagged-story")
+	_, err := pool.Exec(ctx, "INSERT INTO article_tags (article_id, tag_id) VALUES ($1, $2)", f.tagged, f.tagID)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM articles WHERE id = ANY($1)", []uuid.UUID{f.tagged, f.untagged})
+		_, _ = pool.Exec(ctx, "DELETE FROM tags WHERE id = $1", f.tagID)
+		_, _ = pool.Exec(ctx, "DELETE FROM categories WHERE id = ANY($1)", []uuid.UUID{f.sourceID, f.targetID})
+	})
+
+	return pool, f
+}
+
+func articleCategory(t *testing.T, pool *pgxpool.Pool, articleID uuid.UUID) uuid.UUID {
+	t.Helper()
+
+	var categoryID uuid.UUID
+	require.NoError(t, pool.QueryRow(context.Background(), "SELECT category_id FROM articles WHERE id = $1", articleID).Scan(&categoryID))
+	return categoryID
+}
+
+func TestCategoryRepository_MigrateArticlesByTag(t *testing.T) {
+	pool, f := setupMigrateArticlesFixture(t)
+	repo := NewCategoryRepository(pool)
+
+	// The source keeps an article, so it is not deleted
+	result, err := repo.MigrateArticles(context.Background(), f.sourceID, f.targetID, []uuid.UUID{f.tagID}, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Migrated)
+	assert.Equal(t, 1, result.Remaining)
+	assert.False(t, result.SourceDeleted)
+
+	assert.Equal(t, f.targetID, articleCategory(t, pool, f.tagged))
+	assert.Equal(t, f.sourceID, articleCategory(t, pool, f.untagged))
+}
+
+func TestCategoryRepository_MigrateArticlesDeletesEmptySource(t *testing.T) {
+	pool, f := setupMigrateArticlesFixture(t)
+	repo := NewCategoryRepository(pool)
+	ctx := context.Background()
+
+	result, err := repo.MigrateArticles(ctx, f.sourceID, f.targetID, nil, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Migrated)
+	assert.Equal(t, 0, result.Remaining)
+	assert.True(t, result.SourceDeleted)
+
+	source, err := repo.GetByID(ctx, f.sourceID)
+	require.NoError(t, err)
+	assert.Nil(t, source)
+
+	// A deleted category can no longer be migrated from or into
+	_, err = repo.MigrateArticles(ctx, f.sourceID, f.targetID, nil, false, false)
+	assert.EqualError(t, err, "category not found")
+	_, err = repo.MigrateArticles(ctx, f.targetID, f.sourceID, nil, false, false)
+	assert.EqualError(t, err, "target category not found")
+}
+
+func TestCategoryRepository_MigrateArticlesIntoInternalNeedsConfirm(t *testing.T) {
+	pool, f := setupMigrateArticlesFixture(t)
+	repo := NewCategoryRepository(pool)
+	ctx := context.Background()
+
+	_, err := pool.Exec(ctx, "UPDATE categories SET is_internal = true WHERE id = $1", f.targetID)
+	require.NoError(t, err)
+
+	// Drafts can move without confirming
+	result, err := repo.MigrateArticles(ctx, f.sourceID, f.targetID, []uuid.UUID{f.tagID}, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Migrated)
+
+	_, err = pool.Exec(ctx, "UPDATE articles SET status = 'published', published_at = NOW() WHERE id = $1", f.untagged)
+	require.NoError(t, err)
+
+	_, err = repo.MigrateArticles(ctx, f.sourceID, f.targetID, nil, false, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "confirm=true")
+	assert.Equal(t, f.sourceID, articleCategory(t, pool, f.untagged))
+
+	result, err = repo.MigrateArticles(ctx, f.sourceID, f.targetID, nil, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Migrated)
+	assert.Equal(t, f.targetID, articleCategory(t, pool, f.untagged))
+}
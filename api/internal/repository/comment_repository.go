@@ -21,8 +21,9 @@ func NewCommentRepository(db *pgxpool.Pool) *CommentRepository {
 	return &CommentRepository{db: db}
 }
 
-// Create creates a new comment
-func (r *CommentRepository) Create(ctx context.Context, articleID, userID uuid.UUID, req *models.CreateCommentRequest, status models.CommentStatus) (*models.Comment, error) {
+// Create creates a new comment. sentimentScore is nil when comments are not
+// being scored.
+func (r *CommentRepository) Create(ctx context.Context, articleID, userID uuid.UUID, req *models.CreateCommentRequest, status models.CommentStatus, sentimentScore *float64) (*models.Comment, error) {
 	var parentID *uuid.UUID
 	if req.ParentID != nil && *req.ParentID != "" {
 		parsed, err := uuid.Parse(*req.ParentID)
@@ -34,12 +35,12 @@ func (r *CommentRepository) Create(ctx context.Context, articleID, userID uuid.U
 
 	comment := &models.Comment{}
 	query := `
-		INSERT INTO comments (article_id, user_id, parent_id, content, status)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO comments (article_id, user_id, parent_id, content, status, sentiment_score)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id, article_id, user_id, parent_id, content, status, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query, articleID, userID, parentID, req.Content, status).Scan(
+	err := r.db.QueryRow(ctx, query, articleID, userID, parentID, req.Content, status, sentimentScore).Scan(
 		&comment.ID, &comment.ArticleID, &comment.UserID, &comment.ParentID,
 		&comment.Content, &comment.Status, &comment.CreatedAt, &comment.UpdatedAt,
 	)
@@ -92,9 +93,36 @@ func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 	return comment, nil
 }
 
-// ListByArticle retrieves all root comments for an article with replies
+// commentOrderBy returns the ORDER BY clause for a comment sort. Every order
+// ends on the id so pages of the same list never overlap.
+func commentOrderBy(sort models.CommentSort) string {
+	switch sort {
+	case models.CommentSortOldest:
+		return "c.created_at ASC, c.id ASC"
+	case models.CommentSortTop:
+		return `(SELECT COUNT(*) FROM comment_reactions cr WHERE cr.comment_id = c.id) DESC,
+			c.created_at DESC, c.id DESC`
+	default:
+		return "c.created_at DESC, c.id DESC"
+	}
+}
+
+// ListByArticle retrieves a page of an article's root comments in the given
+// order, with their reactions and reply counts. Replies are loaded separately.
 // Only shows 'active' comments to regular users. Admin can see all via includeHidden parameter.
-func (r *CommentRepository) ListByArticle(ctx context.Context, articleID uuid.UUID, currentUserID *uuid.UUID, includeHidden bool) ([]models.Comment, error) {
+func (r *CommentRepository) ListByArticle(ctx context.Context, articleID uuid.UUID, currentUserID *uuid.UUID, includeHidden bool, sort models.CommentSort, page, perPage int) (*models.PaginatedComments, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 10
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	offset := (page - 1) * perPage
+
 	// Get root comments (parent_id IS NULL)
 	// Only show active comments unless admin requests hidden ones
 	statusFilter := "AND c.status = 'active'"
@@ -102,6 +130,15 @@ func (r *CommentRepository) ListByArticle(ctx context.Context, articleID uuid.UU
 		statusFilter = "" // Admin can see all
 	}
 
+	var total int
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*) FROM comments c
+		WHERE c.article_id = $1 AND c.parent_id IS NULL AND c.deleted_at IS NULL %s
+	`, statusFilter)
+	if err := r.db.QueryRow(ctx, countQuery, articleID).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count comments: %w", err)
+	}
+
 	query := fmt.Sprintf(`
 		SELECT c.id, c.article_id, c.user_id, c.parent_id, c.content, c.status,
 		       c.created_at, c.updated_at,
@@ -110,16 +147,17 @@ func (r *CommentRepository) ListByArticle(ctx context.Context, articleID uuid.UU
 		FROM comments c
 		JOIN users u ON c.user_id = u.id
 		WHERE c.article_id = $1 AND c.parent_id IS NULL AND c.deleted_at IS NULL %s
-		ORDER BY c.created_at DESC
-	`, statusFilter)
+		ORDER BY %s
+		LIMIT $2 OFFSET $3
+	`, statusFilter, commentOrderBy(sort))
 
-	rows, err := r.db.Query(ctx, query, articleID)
+	rows, err := r.db.Query(ctx, query, articleID, perPage, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list comments: %w", err)
 	}
 	defer rows.Close()
 
-	var comments []models.Comment
+	comments := []models.Comment{}
 	for rows.Next() {
 		var comment models.Comment
 		var author models.CommentAuthor
@@ -135,17 +173,21 @@ func (r *CommentRepository) ListByArticle(ctx context.Context, articleID uuid.UU
 		}
 
 		comment.Author = &author
-
-		// Get reactions for this comment
-		reactions, err := r.GetReactionSummary(ctx, comment.ID, currentUserID)
-		if err == nil {
-			comment.Reactions = reactions
-		}
-
 		comments = append(comments, comment)
 	}
+	rows.Close()
 
-	return comments, nil
+	if err := r.attachReactions(ctx, comments, currentUserID); err != nil {
+		return nil, err
+	}
+
+	return &models.PaginatedComments{
+		Comments:   comments,
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: (total + perPage - 1) / perPage,
+	}, nil
 }
 
 // ListReplies retrieves all replies for a parent comment
@@ -187,24 +229,29 @@ func (r *CommentRepository) ListReplies(ctx context.Context, parentID uuid.UUID,
 		}
 
 		comment.Author = &author
-
-		// Get reactions for this reply
-		reactions, err := r.GetReactionSummary(ctx, comment.ID, currentUserID)
-		if err == nil {
-			comment.Reactions = reactions
-		}
-
 		replies = append(replies, comment)
 	}
+	rows.Close()
+
+	if err := r.attachReactions(ctx, replies, currentUserID); err != nil {
+		return nil, err
+	}
 
 	return replies, nil
 }
 
-// Update updates a comment's content
-func (r *CommentRepository) Update(ctx context.Context, id uuid.UUID, content string) error {
-	query := `UPDATE comments SET content = $1 WHERE id = $2 AND deleted_at IS NULL`
+// Update updates a comment's content. Its toxicity score is cleared, to be
+// scored again for the new content.
+func (r *CommentRepository) Update(ctx context.Context, id uuid.UUID, content string, sentimentScore *float64) error {
+	query := `
+		UPDATE comments
+		SET content = $1, sentiment_score = $2,
+			toxicity_score = NULL, toxicity_provider = NULL, toxicity_scored_at = NULL,
+			toxicity_attempts = 0, toxicity_next_attempt_at = NULL
+		WHERE id = $3 AND deleted_at IS NULL
+	`
 
-	result, err := r.db.Exec(ctx, query, content, id)
+	result, err := r.db.Exec(ctx, query, content, sentimentScore, id)
 	if err != nil {
 		return fmt.Errorf("failed to update comment: %w", err)
 	}
@@ -213,13 +260,23 @@ func (r *CommentRepository) Update(ctx context.Context, id uuid.UUID, content st
 		return fmt.Errorf("comment not found")
 	}
 
-	// Update mentions
-	mentions := extractMentions(content)
-	// Clear old mentions and save new ones
-	_, _ = r.db.Exec(ctx, `DELETE FROM comment_mentions WHERE comment_id = $1`, id)
-	if len(mentions) > 0 {
-		_ = r.saveMentions(ctx, id, mentions)
+	// Update mentions, keeping the read state of ones that are still present
+	targets := r.resolveMentions(ctx, extractMentions(content))
+	authorIDs, userIDs := []uuid.UUID{}, []uuid.UUID{}
+	for _, t := range targets {
+		if t.AuthorID != nil {
+			authorIDs = append(authorIDs, *t.AuthorID)
+		}
+		if t.UserID != nil {
+			userIDs = append(userIDs, *t.UserID)
+		}
 	}
+	_, _ = r.db.Exec(ctx, `
+		DELETE FROM comment_mentions
+		WHERE comment_id = $1
+		  AND NOT (COALESCE(mentioned_author_id = ANY($2), false) OR COALESCE(mentioned_user_id = ANY($3), false))
+	`, id, authorIDs, userIDs)
+	_ = r.insertMentions(ctx, id, targets)
 
 	return nil
 }
@@ -264,7 +321,8 @@ func (r *CommentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, stat
 func (r *CommentRepository) ListAllComments(ctx context.Context, filter *models.CommentFilter, currentUserID *uuid.UUID) ([]models.Comment, error) {
 	query := `
 		SELECT c.id, c.article_id, c.user_id, c.parent_id, c.content, c.status,
-		       c.moderated_by, c.moderated_at, c.moderation_reason,
+		       c.moderated_by, c.moderated_at, c.moderation_reason, c.sentiment_score,
+		       c.toxicity_score, c.toxicity_provider,
 		       c.created_at, c.updated_at,
 		       u.id, u.name, u.avatar, COALESCE(u.is_system, false),
 		       a.slug as article_slug, a.title as article_title
@@ -276,14 +334,33 @@ func (r *CommentRepository) ListAllComments(ctx context.Context, filter *models.
 
 	args := []interface{}{}
 	argNum := 1
+	orderBy := "c.created_at DESC"
 
 	if filter != nil && filter.Status != nil {
 		query += fmt.Sprintf(" AND c.status = $%d", argNum)
 		args = append(args, *filter.Status)
-		_ = argNum // Mark as used for future filter additions
+		argNum++
+	}
+	if filter != nil && filter.SentimentLt != nil {
+		query += fmt.Sprintf(" AND c.sentiment_score < $%d", argNum)
+		args = append(args, *filter.SentimentLt)
+		// Most negative first, for moderators working down the list
+		orderBy = "c.sentiment_score ASC, c.created_at DESC"
+		argNum++
+	}
+	if filter != nil && filter.MinToxicity != nil {
+		query += fmt.Sprintf(" AND c.toxicity_score >= $%d", argNum)
+		args = append(args, *filter.MinToxicity)
+		argNum++
+	}
+	if filter != nil && filter.Unscored {
+		query += " AND c.toxicity_score IS NULL"
+	}
+	if filter != nil && filter.Sort == models.CommentSortToxicity {
+		orderBy = "c.toxicity_score DESC NULLS LAST, c.created_at DESC"
 	}
 
-	query += " ORDER BY c.created_at DESC LIMIT 100"
+	query += " ORDER BY " + orderBy + " LIMIT 100"
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
@@ -300,7 +377,8 @@ func (r *CommentRepository) ListAllComments(ctx context.Context, filter *models.
 		err := rows.Scan(
 			&comment.ID, &comment.ArticleID, &comment.UserID, &comment.ParentID,
 			&comment.Content, &comment.Status,
-			&comment.ModeratedBy, &comment.ModeratedAt, &comment.ModerationReason,
+			&comment.ModeratedBy, &comment.ModeratedAt, &comment.ModerationReason, &comment.SentimentScore,
+			&comment.ToxicityScore, &comment.ToxicityProvider,
 			&comment.CreatedAt, &comment.UpdatedAt,
 			&author.ID, &author.Name, &author.Avatar, &author.IsSystem,
 			&articleSlug, &articleTitle,
@@ -314,14 +392,13 @@ func (r *CommentRepository) ListAllComments(ctx context.Context, filter *models.
 		_ = articleSlug
 		_ = articleTitle
 
-		// Get reactions
-		reactions, err := r.GetReactionSummary(ctx, comment.ID, currentUserID)
-		if err == nil {
-			comment.Reactions = reactions
-		}
-
 		comments = append(comments, comment)
 	}
+	rows.Close()
+
+	if err := r.attachReactions(ctx, comments, currentUserID); err != nil {
+		return nil, err
+	}
 
 	return comments, nil
 }
@@ -356,40 +433,35 @@ func (r *CommentRepository) RemoveReaction(ctx context.Context, commentID, userI
 
 // GetReactionSummary gets reaction counts for a comment
 func (r *CommentRepository) GetReactionSummary(ctx context.Context, commentID uuid.UUID, currentUserID *uuid.UUID) ([]models.ReactionSummary, error) {
-	query := `
-		SELECT reaction, COUNT(*) as count
-		FROM comment_reactions
-		WHERE comment_id = $1
-		GROUP BY reaction
-		ORDER BY count DESC
-	`
-
-	rows, err := r.db.Query(ctx, query, commentID)
+	summaries, err := r.GetReactionSummariesForComments(ctx, []uuid.UUID{commentID}, currentUserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reaction summary: %w", err)
+		return nil, err
 	}
-	defer rows.Close()
+	return summaries[commentID], nil
+}
 
-	var summaries []models.ReactionSummary
-	for rows.Next() {
-		var summary models.ReactionSummary
-		if err := rows.Scan(&summary.Reaction, &summary.Count); err != nil {
-			return nil, err
-		}
+// GetReactionSummariesForComments gets reaction counts for many comments at once
+func (r *CommentRepository) GetReactionSummariesForComments(ctx context.Context, commentIDs []uuid.UUID, currentUserID *uuid.UUID) (map[uuid.UUID][]models.ReactionSummary, error) {
+	return getReactionSummaries(ctx, r.db, commentReactions, commentIDs, currentUserID)
+}
 
-		// Check if current user has this reaction
-		if currentUserID != nil {
-			var hasReacted bool
-			_ = r.db.QueryRow(ctx, `
-				SELECT EXISTS(SELECT 1 FROM comment_reactions WHERE comment_id = $1 AND user_id = $2 AND reaction = $3)
-			`, commentID, *currentUserID, summary.Reaction).Scan(&hasReacted)
-			summary.HasReacted = hasReacted
-		}
+// attachReactions loads reaction summaries for a page of comments in a fixed number of queries
+func (r *CommentRepository) attachReactions(ctx context.Context, comments []models.Comment, currentUserID *uuid.UUID) error {
+	ids := make([]uuid.UUID, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].ID
+	}
+
+	summaries, err := r.GetReactionSummariesForComments(ctx, ids, currentUserID)
+	if err != nil {
+		return err
+	}
 
-		summaries = append(summaries, summary)
+	for i := range comments {
+		comments[i].Reactions = summaries[comments[i].ID]
 	}
 
-	return summaries, nil
+	return nil
 }
 
 // GetReplyPreview gets a preview of replies for collapsed view
@@ -443,28 +515,54 @@ func (r *CommentRepository) GetCommentCount(ctx context.Context, articleID uuid.
 	return count, err
 }
 
-// saveMentions saves @mentions for a comment
-func (r *CommentRepository) saveMentions(ctx context.Context, commentID uuid.UUID, mentions []string) error {
-	for _, slug := range mentions {
-		// Get author by slug (mentions still reference authors for article writers)
-		var authorID uuid.UUID
-		err := r.db.QueryRow(ctx, `SELECT id FROM authors WHERE slug = $1 AND deleted_at IS NULL`, slug).Scan(&authorID)
-		if err != nil {
+// mentionTarget is what an @slug resolved to; a slug can match an author, a
+// user, or both
+type mentionTarget struct {
+	AuthorID *uuid.UUID
+	UserID   *uuid.UUID
+}
+
+// resolveMentions matches mention slugs against author slugs and user name
+// slugs, dropping slugs that match neither
+func (r *CommentRepository) resolveMentions(ctx context.Context, slugs []string) []mentionTarget {
+	var targets []mentionTarget
+	for _, slug := range slugs {
+		var t mentionTarget
+		err := r.db.QueryRow(ctx, `
+			SELECT (SELECT id FROM authors WHERE slug = $1 AND deleted_at IS NULL),
+			       (SELECT id FROM users
+			        WHERE regexp_replace(LOWER(name), '\s+', '-', 'g') = $1 AND deleted_at IS NULL
+			        ORDER BY created_at
+			        LIMIT 1)
+		`, slug).Scan(&t.AuthorID, &t.UserID)
+		if err != nil || (t.AuthorID == nil && t.UserID == nil) {
 			continue // Skip invalid mentions
 		}
+		targets = append(targets, t)
+	}
+	return targets
+}
 
-		_, err = r.db.Exec(ctx, `
-			INSERT INTO comment_mentions (comment_id, mentioned_author_id)
-			VALUES ($1, $2)
+// insertMentions stores resolved mentions, ignoring ones already recorded
+func (r *CommentRepository) insertMentions(ctx context.Context, commentID uuid.UUID, targets []mentionTarget) error {
+	for _, t := range targets {
+		_, err := r.db.Exec(ctx, `
+			INSERT INTO comment_mentions (comment_id, mentioned_author_id, mentioned_user_id)
+			VALUES ($1, $2, $3)
 			ON CONFLICT DO NOTHING
-		`, commentID, authorID)
+		`, commentID, t.AuthorID, t.UserID)
 		if err != nil {
-			continue
+			return fmt.Errorf("failed to save mention: %w", err)
 		}
 	}
 	return nil
 }
 
+// saveMentions saves @mentions for a comment
+func (r *CommentRepository) saveMentions(ctx context.Context, commentID uuid.UUID, mentions []string) error {
+	return r.insertMentions(ctx, commentID, r.resolveMentions(ctx, mentions))
+}
+
 // extractMentions extracts @username mentions from content
 func extractMentions(content string) []string {
 	re := regexp.MustCompile(`@([a-zA-Z0-9_-]+)`)
@@ -545,10 +643,10 @@ func (r *CommentRepository) SaveMentions(ctx context.Context, commentID uuid.UUI
 	return mentionedUserIDs, nil
 }
 
-// GetMentions gets all mentions for a comment
+// GetMentions gets all author mentions for a comment
 func (r *CommentRepository) GetMentions(ctx context.Context, commentID uuid.UUID) ([]models.CommentMention, error) {
 	query := `
-		SELECT m.id, m.comment_id, m.mentioned_author_id, m.created_at,
+		SELECT m.id, m.comment_id, m.mentioned_author_id, m.mentioned_user_id, m.read_at, m.created_at,
 		       a.id, a.name, a.avatar
 		FROM comment_mentions m
 		JOIN authors a ON m.mentioned_author_id = a.id
@@ -567,7 +665,7 @@ func (r *CommentRepository) GetMentions(ctx context.Context, commentID uuid.UUID
 		var author models.CommentAuthor
 
 		if err := rows.Scan(
-			&mention.ID, &mention.CommentID, &mention.MentionedAuthorID, &mention.CreatedAt,
+			&mention.ID, &mention.CommentID, &mention.MentionedAuthorID, &mention.MentionedUserID, &mention.ReadAt, &mention.CreatedAt,
 			&author.ID, &author.Name, &author.Avatar,
 		); err != nil {
 			return nil, err
@@ -579,3 +677,130 @@ func (r *CommentRepository) GetMentions(ctx context.Context, commentID uuid.UUID
 
 	return mentions, nil
 }
+
+// mentionInboxFilter limits a user's mentions to visible comments by other
+// users they have not blocked
+const mentionInboxFilter = `
+	m.mentioned_user_id = $1
+	AND c.deleted_at IS NULL AND c.status = 'active'
+	AND a.deleted_at IS NULL
+	AND c.user_id <> $1
+	AND NOT EXISTS (SELECT 1 FROM user_blocks ub WHERE ub.blocker_id = $1 AND ub.blocked_id = c.user_id)`
+
+// ListUserMentions returns comments mentioning the user, newest first
+func (r *CommentRepository) ListUserMentions(ctx context.Context, userID uuid.UUID, page, perPage int, unreadOnly bool) (*models.PaginatedMentions, error) {
+	offset := (page - 1) * perPage
+
+	filter := mentionInboxFilter
+	if unreadOnly {
+		filter += " AND m.read_at IS NULL"
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM comment_mentions m
+		JOIN comments c ON m.comment_id = c.id
+		JOIN articles a ON c.article_id = a.id
+		WHERE %s
+	`, filter)
+	if err := r.db.QueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count mentions: %w", err)
+	}
+
+	unreadCount, err := r.CountUnreadMentions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT m.id, m.read_at, m.created_at,
+		       c.id, c.article_id, c.user_id, c.parent_id, c.content, c.status, c.created_at, c.updated_at,
+		       u.id, u.name, u.avatar, COALESCE(u.is_system, false),
+		       a.id, a.title, a.slug
+		FROM comment_mentions m
+		JOIN comments c ON m.comment_id = c.id
+		JOIN users u ON c.user_id = u.id
+		JOIN articles a ON c.article_id = a.id
+		WHERE %s
+		ORDER BY c.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, filter)
+
+	rows, err := r.db.Query(ctx, query, userID, perPage, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mentions: %w", err)
+	}
+	defer rows.Close()
+
+	mentions := []models.UserMention{}
+	for rows.Next() {
+		var m models.UserMention
+		author := &models.CommentAuthor{}
+		if err := rows.Scan(
+			&m.ID, &m.ReadAt, &m.MentionedAt,
+			&m.Comment.ID, &m.Comment.ArticleID, &m.Comment.UserID, &m.Comment.ParentID,
+			&m.Comment.Content, &m.Comment.Status, &m.Comment.CreatedAt, &m.Comment.UpdatedAt,
+			&author.ID, &author.Name, &author.Avatar, &author.IsSystem,
+			&m.Article.ID, &m.Article.Title, &m.Article.Slug,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan mention: %w", err)
+		}
+		m.IsRead = m.ReadAt != nil
+		m.Comment.Author = author
+		m.Comment.ArticleSlug = &m.Article.Slug
+		mentions = append(mentions, m)
+	}
+
+	return &models.PaginatedMentions{
+		Mentions:    mentions,
+		Total:       total,
+		UnreadCount: unreadCount,
+		Page:        page,
+		PerPage:     perPage,
+		TotalPages:  (total + perPage - 1) / perPage,
+	}, nil
+}
+
+// CountUnreadMentions counts the user's unread inbox mentions
+func (r *CommentRepository) CountUnreadMentions(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM comment_mentions m
+		JOIN comments c ON m.comment_id = c.id
+		JOIN articles a ON c.article_id = a.id
+		WHERE %s AND m.read_at IS NULL
+	`, mentionInboxFilter), userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread mentions: %w", err)
+	}
+	return count, nil
+}
+
+// MarkMentionRead marks one of the user's mentions as read
+func (r *CommentRepository) MarkMentionRead(ctx context.Context, id, userID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE comment_mentions SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND mentioned_user_id = $2
+	`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark mention as read: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("mention not found")
+	}
+	return nil
+}
+
+// MarkAllMentionsRead marks all of the user's mentions as read
+func (r *CommentRepository) MarkAllMentionsRead(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE comment_mentions SET read_at = NOW()
+		WHERE mentioned_user_id = $1 AND read_at IS NULL
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark mentions as read: %w", err)
+	}
+	return nil
+}
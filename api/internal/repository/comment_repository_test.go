@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queryCounter is a pgx tracer that counts every query sent to the database
+type queryCounter struct {
+	count atomic.Int64
+}
+
+func (q *queryCounter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	q.count.Add(1)
+	return ctx
+}
+
+func (q *queryCounter) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+// countQueries returns how many queries fn sent through the pool
+func (q *queryCounter) countQueries(fn func()) int64 {
+	before := q.count.Load()
+	fn()
+	return q.count.Load() - before
+}
+
+func connectCountingDB(t *testing.T) (*pgxpool.Pool, *queryCounter) {
+	t.Helper()
+
+	config, err := pgxpool.ParseConfig(testDBConnString)
+	require.NoError(t, err)
+
+	counter := &queryCounter{}
+	config.ConnConfig.Tracer = counter
+
+	pool := openTestDBWithConfig(t, config)
+	return pool, counter
+}
+
+func TestCommentRepository_ReactionQueriesAreConstant(t *testing.T) {
+	pool, counter := connectCountingDB(t)
+	repo := NewCommentRepository(pool)
+	ctx := context.Background()
+	suffix := uuid.NewString()[:8]
+
+	insert := func(query string, args ...interface{}) uuid.UUID {
+		var id uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, query, args...).Scan(&id))
+		return id
+	}
+
+	// Every comment gets a heart from the reader and a thumbs-up from someone else,
+	// so both the summary and the reader's own reactions are queried
+	reader := insert("INSERT INTO users (email, password_hash, name) VALUES ($1, 'x', 'Reader') RETURNING id", "reader-"+suffix+"@example.com")
+	other := insert("INSERT INTO users (email, password_hash, name) VALUES ($1, 'x', 'Other') RETURNING id", "other-"+suffix+"@example.com")
+	articleID := insert("INSERT INTO articles (slug, title, content, status) VALUES ($1, 'Reactions', 'Body', 'published') RETURNING id", "reactions-"+suffix)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM articles WHERE id = $1", articleID)
+		_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = ANY($1)", []uuid.UUID{reader, other})
+	})
+
+	var ids []uuid.UUID
+	rows, err := pool.Query(ctx, `
+		INSERT INTO comments (article_id, user_id, content)
+		SELECT $1, $2, 'Hi' FROM generate_series(1, 200)
+		RETURNING id
+	`, articleID, other)
+	require.NoError(t, err)
+	for rows.Next() {
+		var id uuid.UUID
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	rows.Close()
+	require.NoError(t, rows.Err())
+
+	_, err = pool.Exec(ctx, `
+		INSERT INTO comment_reactions (comment_id, user_id, reaction)
+		SELECT id, $2, 'heart' FROM unnest($1::uuid[]) AS id
+		UNION ALL
+		SELECT id, $3, 'thumbsup' FROM unnest($1::uuid[]) AS id
+	`, ids, reader, other)
+	require.NoError(t, err)
+
+	var few, many map[uuid.UUID][]models.ReactionSummary
+	fewQueries := counter.countQueries(func() {
+		few, err = repo.GetReactionSummariesForComments(ctx, ids[:1], &reader)
+		require.NoError(t, err)
+	})
+	manyQueries := counter.countQueries(func() {
+		many, err = repo.GetReactionSummariesForComments(ctx, ids, &reader)
+		require.NoError(t, err)
+	})
+
+	// One query for the counts, one for the reader's own reactions
+	assert.Equal(t, int64(2), fewQueries)
+	assert.Equal(t, fewQueries, manyQueries)
+
+	assert.Len(t, few, 1)
+	require.Len(t, many, len(ids))
+	assert.ElementsMatch(t, []models.ReactionSummary{
+		{Reaction: "heart", Count: 1, HasReacted: true},
+		{Reaction: "thumbsup", Count: 1},
+	}, many[ids[len(ids)-1]])
+}
+
+func TestCommentRepository_ListByArticleQueryCount(t *testing.T) {
+	pool, counter := connectCountingDB(t)
+	repo := NewCommentRepository(pool)
+	ctx := context.Background()
+
+	// Use whichever article has the most root comments
+	var articleID uuid.UUID
+	var commentCount int
+	err := pool.QueryRow(ctx, `
+		SELECT article_id, COUNT(*) FROM comments
+		WHERE parent_id IS NULL AND deleted_at IS NULL AND status = 'active'
+		GROUP BY article_id ORDER BY COUNT(*) DESC LIMIT 1
+	`).Scan(&articleID, &commentCount)
+	if err == pgx.ErrNoRows {
+		t.Skip("Skipping: no comments in test database")
+	}
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	var comments int
+	queries := counter.countQueries(func() {
+		list, err := repo.ListByArticle(ctx, articleID, &userID, false, models.CommentSortNewest, 1, 100)
+		require.NoError(t, err)
+		assert.Equal(t, commentCount, list.Total)
+		comments = len(list.Comments)
+	})
+
+	assert.Equal(t, min(commentCount, 100), comments)
+	// One query for the total, one for the comments, one for reaction counts,
+	// one for the user's reactions
+	assert.LessOrEqual(t, queries, int64(4))
+}
+
+func TestCommentRepository_ListByArticleSort(t *testing.T) {
+	pool, _ := connectCountingDB(t)
+	repo := NewCommentRepository(pool)
+	ctx := context.Background()
+	suffix := uuid.NewString()[:8]
+
+	insert := func(query string, args ...interface{}) uuid.UUID {
+		var id uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, query, args...).Scan(&id))
+		return id
+	}
+
+	users := make([]uuid.UUID, 3)
+	for i := range users {
+		users[i] = insert("INSERT INTO users (email, password_hash, name) VALUES ($1, 'x', 'Commenter') RETURNING id",
+			"commenter-"+suffix+"-"+string(rune('a'+i))+"@example.com")
+	}
+	articleID := insert("INSERT INTO articles (slug, title, content, status) VALUES ($1, 'Sorting', 'Body', 'published') RETURNING id", "sorting-"+suffix)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM articles WHERE id = $1", articleID)
+		_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = ANY($1)", users)
+	})
+
+	start := time.Now().Add(-time.Hour)
+	comment := func(age int, reactions int) uuid.UUID {
+		id := insert("INSERT INTO comments (article_id, user_id, content, created_at) VALUES ($1, $2, 'Hi', $3) RETURNING id",
+			articleID, users[0], start.Add(time.Duration(age)*time.Minute))
+		for i := 0; i < reactions; i++ {
+			_, err := pool.Exec(ctx, "INSERT INTO comment_reactions (comment_id, user_id, reaction) VALUES ($1, $2, 'heart')", id, users[i])
+			require.NoError(t, err)
+		}
+		return id
+	}
+	first := comment(0, 0)
+	second := comment(1, 2)
+	third := comment(2, 1)
+
+	for sort, want := range map[models.CommentSort][]uuid.UUID{
+		models.CommentSortNewest: {third, second, first},
+		models.CommentSortOldest: {first, second, third},
+		models.CommentSortTop:    {second, third, first},
+	} {
+		list, err := repo.ListByArticle(ctx, articleID, nil, false, sort, 1, 20)
+		require.NoError(t, err)
+
+		got := make([]uuid.UUID, len(list.Comments))
+		for i, c := range list.Comments {
+			got[i] = c.ID
+		}
+		assert.Equal(t, want, got, sort)
+	}
+
+	// The last page holds what is left over, and the total counts every page
+	page, err := repo.ListByArticle(ctx, articleID, nil, false, models.CommentSortOldest, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	assert.Equal(t, 2, page.TotalPages)
+	require.Len(t, page.Comments, 1)
+	assert.Equal(t, third, page.Comments[0].ID)
+}
+
+func TestExtractMentions(t *testing.T) {
+	mentions := extractMentions("Thanks @Juan-Dela-Cruz and @maria_santos! cc @juan-dela-cruz")
+	assert.Equal(t, []string{"juan-dela-cruz", "maria_santos"}, mentions)
+
+	assert.Empty(t, extractMentions("no mentions here, just an email@"))
+}
+
+func TestCommentRepository_ListUserMentionsEmpty(t *testing.T) {
+	pool, _ := connectCountingDB(t)
+	repo := NewCommentRepository(pool)
+	ctx := context.Background()
+
+	result, err := repo.ListUserMentions(ctx, uuid.New(), 1, 20, false)
+	require.NoError(t, err)
+	assert.NotNil(t, result.Mentions)
+	assert.Empty(t, result.Mentions)
+	assert.Equal(t, 0, result.UnreadCount)
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// ListSentimentWords returns the word list comments are scored against
+func (r *CommentRepository) ListSentimentWords(ctx context.Context) ([]models.SentimentWord, error) {
+	rows, err := r.db.Query(ctx, `SELECT word, weight, language FROM sentiment_words ORDER BY word`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sentiment words: %w", err)
+	}
+	defer rows.Close()
+
+	words := []models.SentimentWord{}
+	for rows.Next() {
+		var word models.SentimentWord
+		if err := rows.Scan(&word.Word, &word.Weight, &word.Language); err != nil {
+			return nil, fmt.Errorf("failed to scan sentiment word: %w", err)
+		}
+		words = append(words, word)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list sentiment words: %w", err)
+	}
+
+	return words, nil
+}
+
+// GetSentimentSummary averages the sentiment of an article's visible
+// comments. Comments posted before scoring began have no score and are left
+// out.
+func (r *CommentRepository) GetSentimentSummary(ctx context.Context, articleID uuid.UUID) (*models.CommentSentiment, error) {
+	summary := &models.CommentSentiment{ArticleID: articleID}
+	err := r.db.QueryRow(ctx, `
+		SELECT AVG(sentiment_score)::float8, COUNT(sentiment_score)
+		FROM comments
+		WHERE article_id = $1 AND deleted_at IS NULL AND status = 'active'
+	`, articleID).Scan(&summary.AverageScore, &summary.ScoredComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment sentiment: %w", err)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// SetToxicityScore records a comment's toxicity score, unless the comment has
+// since been edited or deleted. With flag set, an active comment no moderator
+// has ruled on is held under review. It reports whether the comment was held.
+func (r *CommentRepository) SetToxicityScore(ctx context.Context, id uuid.UUID, content string, score float64, provider string, flag bool) (bool, error) {
+	var held bool
+	err := r.db.QueryRow(ctx, `
+		UPDATE comments c
+		SET toxicity_score = $3, toxicity_provider = $4, toxicity_scored_at = NOW(),
+			toxicity_next_attempt_at = NULL,
+			status = CASE
+				WHEN $5 AND c.status = 'active' AND c.moderated_at IS NULL THEN 'under_review'
+				ELSE c.status
+			END
+		FROM (SELECT id, status FROM comments WHERE id = $1 FOR UPDATE) old
+		WHERE c.id = old.id AND c.content = $2 AND c.deleted_at IS NULL
+		RETURNING old.status <> c.status
+	`, id, content, score, provider, flag).Scan(&held)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to set toxicity score: %w", err)
+	}
+	return held, nil
+}
+
+// RecordToxicityFailure counts a failed scoring attempt and schedules the
+// next one, doubling the wait from retryBase after each failure
+func (r *CommentRepository) RecordToxicityFailure(ctx context.Context, id uuid.UUID, retryBase time.Duration) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE comments
+		SET toxicity_attempts = toxicity_attempts + 1,
+			toxicity_next_attempt_at = NOW() + make_interval(secs => $2 * POWER(2, toxicity_attempts))
+		WHERE id = $1 AND toxicity_scored_at IS NULL
+	`, id, retryBase.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to record toxicity failure: %w", err)
+	}
+	return nil
+}
+
+// ListUnscoredToxicity returns comments posted within window that still have
+// no toxicity score and are due another attempt, oldest first. Comments
+// never attempted are due after grace, leaving time for the scoring started
+// when they were posted.
+func (r *CommentRepository) ListUnscoredToxicity(ctx context.Context, maxAttempts int, window, grace time.Duration, limit int) ([]models.Comment, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, content
+		FROM comments
+		WHERE toxicity_scored_at IS NULL AND deleted_at IS NULL
+		  AND created_at > NOW() - make_interval(secs => $2)
+		  AND toxicity_attempts < $1
+		  AND COALESCE(toxicity_next_attempt_at, created_at + make_interval(secs => $3)) <= NOW()
+		ORDER BY created_at
+		LIMIT $4
+	`, maxAttempts, window.Seconds(), grace.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unscored comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []models.Comment{}
+	for rows.Next() {
+		var c models.Comment
+		if err := rows.Scan(&c.ID, &c.Content); err != nil {
+			return nil, fmt.Errorf("failed to scan unscored comment: %w", err)
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
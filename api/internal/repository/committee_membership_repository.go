@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// currentMembershipCond matches seats held today
+const currentMembershipCond = `(m.start_date IS NULL OR m.start_date <= CURRENT_DATE) AND (m.end_date IS NULL OR m.end_date >= CURRENT_DATE)`
+
+// committeeRoleOrder sorts chairs before vice chairs before members
+const committeeRoleOrder = `CASE m.role WHEN 'chair' THEN 0 WHEN 'vice_chair' THEN 1 ELSE 2 END`
+
+const committeeMembershipColumns = `
+	m.id, m.committee_id, m.politician_id, m.role, m.start_date, m.end_date, m.session_id,
+	m.created_at, m.updated_at, p.name, p.slug, COALESCE(p.photo_list, p.photo)
+`
+
+func scanCommitteeMembership(row pgx.Row) (*models.CommitteeMembership, error) {
+	membership := &models.CommitteeMembership{}
+	err := row.Scan(
+		&membership.ID, &membership.CommitteeID, &membership.PoliticianID, &membership.Role,
+		&membership.StartDate, &membership.EndDate, &membership.SessionID,
+		&membership.CreatedAt, &membership.UpdatedAt,
+		&membership.PoliticianName, &membership.PoliticianSlug, &membership.PoliticianPhoto,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (r *BillRepository) GetCommitteeByID(ctx context.Context, id uuid.UUID) (*models.Committee, error) {
+	committee := &models.Committee{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, chamber, name, slug, description, is_active, created_at, updated_at
+		FROM committees
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id).Scan(
+		&committee.ID, &committee.Chamber, &committee.Name, &committee.Slug, &committee.Description,
+		&committee.IsActive, &committee.CreatedAt, &committee.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get committee: %w", err)
+	}
+	return committee, nil
+}
+
+// ListCommitteeMemberships returns every seat on a committee, past and
+// present, with current seats first
+func (r *BillRepository) ListCommitteeMemberships(ctx context.Context, committeeID uuid.UUID) ([]models.CommitteeMembership, error) {
+	query := `
+		SELECT ` + committeeMembershipColumns + `
+		FROM committee_memberships m
+		JOIN politicians p ON m.politician_id = p.id
+		WHERE m.committee_id = $1
+		ORDER BY (` + currentMembershipCond + `) DESC, ` + committeeRoleOrder + `, m.start_date DESC NULLS LAST, p.name
+	`
+
+	rows, err := r.db.Query(ctx, query, committeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list committee memberships: %w", err)
+	}
+	defer rows.Close()
+
+	memberships := []models.CommitteeMembership{}
+	for rows.Next() {
+		membership, err := scanCommitteeMembership(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan committee membership: %w", err)
+		}
+		memberships = append(memberships, *membership)
+	}
+
+	return memberships, nil
+}
+
+func (r *BillRepository) GetCommitteeMembershipByID(ctx context.Context, id uuid.UUID) (*models.CommitteeMembership, error) {
+	query := `
+		SELECT ` + committeeMembershipColumns + `
+		FROM committee_memberships m
+		JOIN politicians p ON m.politician_id = p.id
+		WHERE m.id = $1
+	`
+
+	membership, err := scanCommitteeMembership(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get committee membership: %w", err)
+	}
+
+	return membership, nil
+}
+
+func (r *BillRepository) CreateCommitteeMembership(ctx context.Context, committeeID uuid.UUID, req *models.CreateCommitteeMembershipRequest) (uuid.UUID, error) {
+	// Selecting from politicians rejects missing or deleted politicians without a FK error
+	query := `
+		INSERT INTO committee_memberships (committee_id, politician_id, role, start_date, end_date, session_id)
+		SELECT $1, p.id, $3::committee_role, $4::date, $5::date, $6
+		FROM politicians p
+		WHERE p.id = $2 AND p.deleted_at IS NULL
+		RETURNING id
+	`
+
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, query,
+		committeeID, req.PoliticianID, req.Role, req.StartDate, req.EndDate, req.SessionID,
+	).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return uuid.Nil, fmt.Errorf("politician not found")
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create committee membership: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateCommitteeMembership applies a partial update. RemoveEndDate reopens an ended seat.
+func (r *BillRepository) UpdateCommitteeMembership(ctx context.Context, id uuid.UUID, req *models.UpdateCommitteeMembershipRequest) error {
+	query := `
+		UPDATE committee_memberships
+		SET role = COALESCE($1::committee_role, role),
+			start_date = COALESCE($2::date, start_date),
+			end_date = CASE WHEN $5::boolean THEN NULL ELSE COALESCE($3::date, end_date) END,
+			session_id = COALESCE($4, session_id),
+			updated_at = NOW()
+		WHERE id = $6
+	`
+
+	result, err := r.db.Exec(ctx, query, req.Role, req.StartDate, req.EndDate, req.SessionID, req.RemoveEndDate, id)
+	if err != nil {
+		return fmt.Errorf("failed to update committee membership: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("committee membership not found")
+	}
+
+	return nil
+}
+
+func (r *BillRepository) DeleteCommitteeMembership(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM committee_memberships WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete committee membership: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("committee membership not found")
+	}
+
+	return nil
+}
+
+// HasOverlappingRoleHolder reports whether another seat with the given role on
+// the committee overlaps the start/end range. Open ends are unbounded.
+func (r *BillRepository) HasOverlappingRoleHolder(ctx context.Context, committeeID uuid.UUID, role string, startDate, endDate *string, excludeID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM committee_memberships m
+			WHERE m.committee_id = $1 AND m.role = $2::committee_role AND m.id <> $5
+			  AND COALESCE(m.start_date, '-infinity'::date) <= COALESCE($4::date, 'infinity'::date)
+			  AND COALESCE(m.end_date, 'infinity'::date) >= COALESCE($3::date, '-infinity'::date)
+		)
+	`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, committeeID, role, startDate, endDate, excludeID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check committee role holder: %w", err)
+	}
+	return exists, nil
+}
+
+// GetCurrentCommitteeMembers returns the committee's current members, chair first
+func (r *BillRepository) GetCurrentCommitteeMembers(ctx context.Context, committeeID uuid.UUID) ([]models.CommitteeMember, error) {
+	query := `
+		SELECT p.id, p.name, p.slug, COALESCE(p.photo_list, p.photo), m.role
+		FROM committee_memberships m
+		JOIN politicians p ON m.politician_id = p.id
+		WHERE m.committee_id = $1 AND p.deleted_at IS NULL AND ` + currentMembershipCond + `
+		ORDER BY ` + committeeRoleOrder + `, p.name
+	`
+
+	rows, err := r.db.Query(ctx, query, committeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get committee members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []models.CommitteeMember{}
+	for rows.Next() {
+		var member models.CommitteeMember
+		if err := rows.Scan(&member.PoliticianID, &member.PoliticianName, &member.Slug, &member.Photo, &member.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan committee member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// applyCommitteeMembers sets the committee's members and derives the
+// chairperson fields from them
+func applyCommitteeMembers(committee *models.Committee, members []models.CommitteeMember) {
+	committee.Members = members
+	for _, member := range members {
+		brief := &models.PoliticianListItem{
+			ID:    member.PoliticianID,
+			Name:  member.PoliticianName,
+			Slug:  member.Slug,
+			Photo: member.Photo,
+		}
+		switch {
+		case member.Role == models.CommitteeRoleChair && committee.Chairperson == nil:
+			committee.ChairpersonID = &brief.ID
+			committee.Chairperson = brief
+		case member.Role == models.CommitteeRoleViceChair && committee.ViceChairperson == nil:
+			committee.ViceChairpersonID = &brief.ID
+			committee.ViceChairperson = brief
+		}
+	}
+}
+
+// GetPoliticianCommitteeMemberships returns a politician's committee seats,
+// current ones first
+func (r *PoliticianRepository) GetPoliticianCommitteeMemberships(ctx context.Context, politicianID uuid.UUID) ([]models.PoliticianCommitteeMembership, error) {
+	query := `
+		SELECT c.name, c.slug, c.chamber, m.role, m.start_date, m.end_date, ` + currentMembershipCond + `
+		FROM committee_memberships m
+		JOIN committees c ON m.committee_id = c.id
+		WHERE m.politician_id = $1 AND c.deleted_at IS NULL
+		ORDER BY 7 DESC, m.start_date DESC NULLS LAST, c.name
+	`
+
+	rows, err := r.db.Query(ctx, query, politicianID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get committee memberships: %w", err)
+	}
+	defer rows.Close()
+
+	memberships := []models.PoliticianCommitteeMembership{}
+	for rows.Next() {
+		var m models.PoliticianCommitteeMembership
+		if err := rows.Scan(&m.CommitteeName, &m.CommitteeSlug, &m.Chamber, &m.Role, &m.StartDate, &m.EndDate, &m.IsCurrent); err != nil {
+			return nil, fmt.Errorf("failed to scan committee membership: %w", err)
+		}
+		memberships = append(memberships, m)
+	}
+
+	return memberships, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyCommitteeMembers(t *testing.T) {
+	chair := models.CommitteeMember{PoliticianID: uuid.New(), PoliticianName: "Ana Reyes", Slug: "ana-reyes", Role: models.CommitteeRoleChair}
+	vice := models.CommitteeMember{PoliticianID: uuid.New(), PoliticianName: "Ben Cruz", Slug: "ben-cruz", Role: models.CommitteeRoleViceChair}
+	member := models.CommitteeMember{PoliticianID: uuid.New(), PoliticianName: "Carla Santos", Slug: "carla-santos", Role: models.CommitteeRoleMember}
+
+	t.Run("derives chairperson fields from members", func(t *testing.T) {
+		committee := &models.Committee{}
+		applyCommitteeMembers(committee, []models.CommitteeMember{chair, vice, member})
+
+		assert.Len(t, committee.Members, 3)
+		require.NotNil(t, committee.Chairperson)
+		assert.Equal(t, "ana-reyes", committee.Chairperson.Slug)
+		assert.Equal(t, chair.PoliticianID, *committee.ChairpersonID)
+		require.NotNil(t, committee.ViceChairperson)
+		assert.Equal(t, vice.PoliticianID, *committee.ViceChairpersonID)
+	})
+
+	t.Run("vacant chair leaves fields empty", func(t *testing.T) {
+		committee := &models.Committee{}
+		applyCommitteeMembers(committee, []models.CommitteeMember{member})
+
+		assert.Nil(t, committee.Chairperson)
+		assert.Nil(t, committee.ChairpersonID)
+		assert.Nil(t, committee.ViceChairperson)
+	})
+
+	t.Run("no members encodes as an empty list", func(t *testing.T) {
+		committee := &models.Committee{}
+		applyCommitteeMembers(committee, []models.CommitteeMember{})
+		assert.NotNil(t, committee.Members)
+	})
+}
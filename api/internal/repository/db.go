@@ -2,12 +2,19 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/humfurie/pulpulitiko/api/pkg/metrics"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
 )
 
-func NewDBPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
+func NewDBPool(ctx context.Context, databaseURL string, logger zerolog.Logger) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(databaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse database URL: %w", err)
@@ -15,6 +22,7 @@ func NewDBPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
 
 	config.MaxConns = 25
 	config.MinConns = 5
+	config.ConnConfig.Tracer = queryTracer{logger: logger}
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -27,3 +35,38 @@ func NewDBPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
 
 	return pool, nil
 }
+
+type queryContextKey struct{}
+
+type queryStart struct {
+	sql string
+	at  time.Time
+}
+
+// queryTracer times every query for the metrics endpoint, and logs failed
+// ones with the ID of the request that ran them. The "failed to ..." error a
+// repository wraps it in ends up in that request's log line and error
+// response, both carrying the same ID.
+type queryTracer struct {
+	logger zerolog.Logger
+}
+
+func (t queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryContextKey{}, queryStart{sql: data.SQL, at: time.Now()})
+}
+
+func (t queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, _ := ctx.Value(queryContextKey{}).(queryStart)
+	if !start.at.IsZero() {
+		metrics.RecordDBQuery(time.Since(start.at), data.Err)
+	}
+
+	if data.Err == nil || errors.Is(data.Err, pgx.ErrNoRows) || errors.Is(data.Err, context.Canceled) {
+		return
+	}
+	t.logger.Error().
+		Err(data.Err).
+		Str("request_id", middleware.GetReqID(ctx)).
+		Str("sql", strings.Join(strings.Fields(start.sql), " ")).
+		Msg("query failed")
+}
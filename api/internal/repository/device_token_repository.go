@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// SaveDeviceToken registers a push token for a user. A token already known
+// moves to this user, since whoever signed in on the device last gets its
+// notifications, and is marked seen.
+func (r *NotificationRepository) SaveDeviceToken(ctx context.Context, userID uuid.UUID, req *models.RegisterDeviceTokenRequest) (*models.DeviceToken, error) {
+	var t models.DeviceToken
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO device_tokens (user_id, token, platform)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (token) DO UPDATE
+		SET user_id = EXCLUDED.user_id, platform = EXCLUDED.platform, last_seen_at = NOW()
+		RETURNING id, user_id, token, platform, created_at, last_seen_at
+	`, userID, req.Token, req.Platform).Scan(
+		&t.ID, &t.UserID, &t.Token, &t.Platform, &t.CreatedAt, &t.LastSeenAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save device token: %w", err)
+	}
+	return &t, nil
+}
+
+// ListDeviceTokens returns a user's push tokens seen since the given time,
+// most recently seen first
+func (r *NotificationRepository) ListDeviceTokens(ctx context.Context, userID uuid.UUID, seenSince time.Time) ([]models.DeviceToken, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, token, platform, created_at, last_seen_at
+		FROM device_tokens
+		WHERE user_id = $1 AND last_seen_at >= $2
+		ORDER BY last_seen_at DESC
+	`, userID, seenSince)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list device tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []models.DeviceToken
+	for rows.Next() {
+		var t models.DeviceToken
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Token, &t.Platform, &t.CreatedAt, &t.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan device token: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list device tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// DeleteDeviceToken forgets a push token
+func (r *NotificationRepository) DeleteDeviceToken(ctx context.Context, token string) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM device_tokens WHERE token = $1`, token); err != nil {
+		return fmt.Errorf("failed to delete device token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListElectionCandidateIDs returns the IDs of every candidate in an election
+func (r *ElectionRepository) ListElectionCandidateIDs(ctx context.Context, electionID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id
+		FROM candidates c
+		JOIN election_positions ep ON c.election_position_id = ep.id
+		WHERE ep.election_id = $1
+	`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list election candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan candidate: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ResolveCities maps each key that is the slug or PSGC code of a city or
+// municipality to its ID. Unknown keys are left out.
+func (r *ElectionRepository) ResolveCities(ctx context.Context, keys []string) (map[string]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, slug, code
+		FROM cities_municipalities
+		WHERE deleted_at IS NULL AND (slug = ANY($1) OR code = ANY($1))
+	`, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve cities: %w", err)
+	}
+	defer rows.Close()
+
+	cities := make(map[string]uuid.UUID)
+	for rows.Next() {
+		var id uuid.UUID
+		var slug, code string
+		if err := rows.Scan(&id, &slug, &code); err != nil {
+			return nil, fmt.Errorf("failed to scan city: %w", err)
+		}
+		cities[slug] = id
+		cities[code] = id
+	}
+	return cities, rows.Err()
+}
+
+// ResolveBarangays maps each key that is the slug or PSGC code of a barangay
+// to the barangay. Unknown keys are left out.
+func (r *ElectionRepository) ResolveBarangays(ctx context.Context, keys []string) (map[string]models.Barangay, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, city_municipality_id, code, name, slug
+		FROM barangays
+		WHERE deleted_at IS NULL AND (slug = ANY($1) OR code = ANY($1))
+	`, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve barangays: %w", err)
+	}
+	defer rows.Close()
+
+	barangays := make(map[string]models.Barangay)
+	for rows.Next() {
+		var b models.Barangay
+		if err := rows.Scan(&b.ID, &b.CityMunicipalityID, &b.Code, &b.Name, &b.Slug); err != nil {
+			return nil, fmt.Errorf("failed to scan barangay: %w", err)
+		}
+		barangays[b.Slug] = b
+		barangays[b.Code] = b
+	}
+	return barangays, rows.Err()
+}
+
+// lockRollupStatus locks an election's rollup status row, creating it if
+// needed. Imports and rollups both take this lock first.
+func lockRollupStatus(ctx context.Context, tx pgx.Tx, electionID uuid.UUID) (importedVersion int64, err error) {
+	_, err = tx.Exec(ctx, `
+		INSERT INTO election_result_rollup_status (election_id) VALUES ($1)
+		ON CONFLICT (election_id) DO NOTHING
+	`, electionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create rollup status: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		SELECT imported_version FROM election_result_rollup_status WHERE election_id = $1 FOR UPDATE
+	`, electionID).Scan(&importedVersion)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock rollup status: %w", err)
+	}
+	return importedVersion, nil
+}
+
+// ImportLocationResults stores location results, replacing any earlier votes
+// for the same candidate and place, and marks the election's rollups stale
+func (r *ElectionRepository) ImportLocationResults(ctx context.Context, electionID uuid.UUID, results []models.CandidateLocationResult) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := lockRollupStatus(ctx, tx, electionID); err != nil {
+		return err
+	}
+
+	candidateIDs := make([]uuid.UUID, len(results))
+	cityIDs := make([]uuid.UUID, len(results))
+	barangayIDs := make([]*uuid.UUID, len(results))
+	votes := make([]int, len(results))
+	for i, res := range results {
+		candidateIDs[i], cityIDs[i], barangayIDs[i], votes[i] = res.CandidateID, res.CityMunicipalityID, res.BarangayID, res.Votes
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO candidate_location_results (candidate_id, city_municipality_id, barangay_id, votes)
+		SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::uuid[], $4::int[])
+		ON CONFLICT (candidate_id, city_municipality_id, barangay_id)
+		DO UPDATE SET votes = EXCLUDED.votes, updated_at = NOW()
+	`, candidateIDs, cityIDs, barangayIDs, votes)
+	if err != nil {
+		return fmt.Errorf("failed to store location results: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE election_result_rollup_status
+		SET imported_version = imported_version + 1, imported_at = NOW()
+		WHERE election_id = $1
+	`, electionID)
+	if err != nil {
+		return fmt.Errorf("failed to update rollup status: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListStaleRollups returns the elections with results imported since their
+// rollups were last computed
+func (r *ElectionRepository) ListStaleRollups(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT election_id FROM election_result_rollup_status
+		WHERE computed_version < imported_version
+		ORDER BY imported_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale rollups: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan election: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// RecomputeRollups rebuilds an election's city, province and region totals
+// from its location results. Each city counts its own total if it has one,
+// otherwise the sum of its barangays.
+func (r *ElectionRepository) RecomputeRollups(ctx context.Context, electionID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	version, err := lockRollupStatus(ctx, tx, electionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM election_result_rollups rr
+		USING election_positions ep
+		WHERE rr.election_position_id = ep.id AND ep.election_id = $1
+	`, electionID)
+	if err != nil {
+		return fmt.Errorf("failed to clear rollups: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		WITH city_votes AS (
+			SELECT c.election_position_id, lr.candidate_id, lr.city_municipality_id,
+			       COALESCE(SUM(lr.votes) FILTER (WHERE lr.barangay_id IS NULL), SUM(lr.votes)) AS votes
+			FROM candidate_location_results lr
+			JOIN candidates c ON lr.candidate_id = c.id
+			JOIN election_positions ep ON c.election_position_id = ep.id
+			WHERE ep.election_id = $1
+			GROUP BY c.election_position_id, lr.candidate_id, lr.city_municipality_id
+		),
+		located AS (
+			SELECT cv.*, cm.province_id, pr.region_id
+			FROM city_votes cv
+			JOIN cities_municipalities cm ON cv.city_municipality_id = cm.id
+			JOIN provinces pr ON cm.province_id = pr.id
+		),
+		tallies AS (
+			SELECT election_position_id, 'city' AS level, city_municipality_id AS location_id, candidate_id, votes
+			FROM located
+			UNION ALL
+			SELECT election_position_id, 'province', province_id, candidate_id, SUM(votes)
+			FROM located GROUP BY election_position_id, province_id, candidate_id
+			UNION ALL
+			SELECT election_position_id, 'region', region_id, candidate_id, SUM(votes)
+			FROM located GROUP BY election_position_id, region_id, candidate_id
+		)
+		INSERT INTO election_result_rollups (election_position_id, level, location_id, candidate_id, votes, total_votes, rank)
+		SELECT t.election_position_id, t.level, t.location_id, t.candidate_id, t.votes,
+		       SUM(t.votes) OVER w,
+		       ROW_NUMBER() OVER (w ORDER BY t.votes DESC, c.ballot_number NULLS LAST, t.candidate_id)
+		FROM tallies t
+		JOIN candidates c ON t.candidate_id = c.id
+		WINDOW w AS (PARTITION BY t.election_position_id, t.level, t.location_id)
+	`, electionID)
+	if err != nil {
+		return fmt.Errorf("failed to compute rollups: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE election_result_rollup_status
+		SET computed_version = $2, computed_at = NOW()
+		WHERE election_id = $1
+	`, electionID, version)
+	if err != nil {
+		return fmt.Errorf("failed to update rollup status: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetRollupFreshness says when an election's rollups were last computed
+func (r *ElectionRepository) GetRollupFreshness(ctx context.Context, electionID uuid.UUID) (models.RollupFreshness, error) {
+	var f models.RollupFreshness
+	err := r.db.QueryRow(ctx, `
+		SELECT computed_at, computed_version < imported_version
+		FROM election_result_rollup_status
+		WHERE election_id = $1
+	`, electionID).Scan(&f.DataAsOf, &f.Pending)
+	if err == pgx.ErrNoRows {
+		return f, nil
+	}
+	if err != nil {
+		return f, fmt.Errorf("failed to get rollup status: %w", err)
+	}
+	return f, nil
+}
+
+// GetRollupPositions lists the positions of an election that have location
+// results, in ballot order
+func (r *ElectionRepository) GetRollupPositions(ctx context.Context, electionID uuid.UUID) ([]models.ElectionPositionBrief, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT ep.id, gp.name
+		FROM election_positions ep
+		JOIN government_positions gp ON ep.position_id = gp.id
+		WHERE ep.election_id = $1
+		  AND EXISTS (SELECT 1 FROM election_result_rollups rr WHERE rr.election_position_id = ep.id)
+		ORDER BY ep.ballot_group, ep.ballot_order, gp.display_order
+	`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rollup positions: %w", err)
+	}
+	defer rows.Close()
+
+	positions := []models.ElectionPositionBrief{}
+	for rows.Next() {
+		var p models.ElectionPositionBrief
+		if err := rows.Scan(&p.ID, &p.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan position: %w", err)
+		}
+		positions = append(positions, p)
+	}
+	return positions, rows.Err()
+}
+
+// rollupCandidateColumns are the candidate columns scanned by
+// resultCandidateRow, from election_result_rollups rr joined to candidates c,
+// politicians p and political_parties pp
+const rollupCandidateColumns = `
+	rr.votes, rr.total_votes,
+	c.id, c.politician_id, COALESCE(NULLIF(c.ballot_name, ''), p.name), p.slug, COALESCE(p.photo_list, p.photo),
+	pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color`
+
+const rollupCandidateJoins = `
+	JOIN candidates c ON rr.candidate_id = c.id
+	JOIN politicians p ON c.politician_id = p.id
+	LEFT JOIN political_parties pp ON c.party_id = pp.id`
+
+// resultCandidateRow holds the scan targets for rollupCandidateColumns
+type resultCandidateRow struct {
+	candidate  models.ResultCandidate
+	totalVotes int
+	party      models.PartyBrief
+	partyID    *uuid.UUID
+	partyName  *string
+	partySlug  *string
+}
+
+func (row *resultCandidateRow) dests() []any {
+	c := &row.candidate
+	return []any{
+		&c.Votes, &row.totalVotes,
+		&c.CandidateID, &c.PoliticianID, &c.Name, &c.Slug, &c.Photo,
+		&row.partyID, &row.partyName, &row.partySlug, &row.party.Abbreviation, &row.party.Logo, &row.party.Color,
+	}
+}
+
+func (row *resultCandidateRow) result() models.ResultCandidate {
+	c := row.candidate
+	if row.partyID != nil {
+		party := row.party
+		party.ID, party.Name, party.Slug = *row.partyID, *row.partyName, *row.partySlug
+		c.Party = &party
+	}
+	c.Percentage = models.ShareOfVotes(c.Votes, row.totalVotes)
+	return c
+}
+
+// GetLocationLeaders returns the top two candidates for a position in each
+// province or region, by name
+func (r *ElectionRepository) GetLocationLeaders(ctx context.Context, electionPositionID uuid.UUID, level string) ([]models.LocationLeader, error) {
+	locations := "provinces"
+	if level == models.ResultLevelRegion {
+		locations = "regions"
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT l.id, l.name, l.slug, `+rollupCandidateColumns+`
+		FROM election_result_rollups rr
+		JOIN `+locations+` l ON rr.location_id = l.id
+		`+rollupCandidateJoins+`
+		WHERE rr.election_position_id = $1 AND rr.level = $2 AND rr.rank <= 2
+		ORDER BY l.name, l.id, rr.rank
+	`, electionPositionID, level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location leaders: %w", err)
+	}
+	defer rows.Close()
+
+	leaders := []models.LocationLeader{}
+	var top [][]models.ResultCandidate
+	for rows.Next() {
+		var l models.LocationLeader
+		var row resultCandidateRow
+		if err := rows.Scan(append([]any{&l.LocationID, &l.Name, &l.Slug}, row.dests()...)...); err != nil {
+			return nil, fmt.Errorf("failed to scan location leader: %w", err)
+		}
+
+		if n := len(leaders); n == 0 || leaders[n-1].LocationID != l.LocationID {
+			l.TotalVotes = row.totalVotes
+			leaders = append(leaders, l)
+			top = append(top, nil)
+		}
+		top[len(top)-1] = append(top[len(top)-1], row.result())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get location leaders: %w", err)
+	}
+
+	for i := range leaders {
+		leaders[i].SetLeaders(top[i])
+	}
+	return leaders, nil
+}
+
+// GetResultsCity finds a city or municipality by slug for a results
+// drill-down
+func (r *ElectionRepository) GetResultsCity(ctx context.Context, slug string) (*models.CityMunicipalityBrief, error) {
+	var city models.CityMunicipalityBrief
+	err := r.db.QueryRow(ctx, `
+		SELECT cm.id, cm.name, cm.slug, pr.name
+		FROM cities_municipalities cm
+		JOIN provinces pr ON cm.province_id = pr.id
+		WHERE cm.slug = $1 AND cm.deleted_at IS NULL
+	`, slug).Scan(&city.ID, &city.Name, &city.Slug, &city.ProvinceName)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get city: %w", err)
+	}
+	return &city, nil
+}
+
+// GetCityResults returns every candidate's votes in a city, by position in
+// ballot order, most votes first
+func (r *ElectionRepository) GetCityResults(ctx context.Context, electionID, cityID uuid.UUID) ([]models.CityPositionResult, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT ep.id, gp.name, `+rollupCandidateColumns+`
+		FROM election_result_rollups rr
+		JOIN election_positions ep ON rr.election_position_id = ep.id
+		JOIN government_positions gp ON ep.position_id = gp.id
+		`+rollupCandidateJoins+`
+		WHERE ep.election_id = $1 AND rr.level = 'city' AND rr.location_id = $2
+		ORDER BY ep.ballot_group, ep.ballot_order, gp.display_order, ep.id, rr.rank
+	`, electionID, cityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get city results: %w", err)
+	}
+	defer rows.Close()
+
+	positions := []models.CityPositionResult{}
+	for rows.Next() {
+		var p models.CityPositionResult
+		var row resultCandidateRow
+		if err := rows.Scan(append([]any{&p.ElectionPositionID, &p.PositionName}, row.dests()...)...); err != nil {
+			return nil, fmt.Errorf("failed to scan city result: %w", err)
+		}
+
+		if n := len(positions); n == 0 || positions[n-1].ElectionPositionID != p.ElectionPositionID {
+			p.TotalVotes = row.totalVotes
+			positions = append(positions, p)
+		}
+		last := &positions[len(positions)-1]
+		last.Candidates = append(last.Candidates, row.result())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get city results: %w", err)
+	}
+	return positions, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -23,38 +25,36 @@ func NewElectionRepository(db *pgxpool.Pool) *ElectionRepository {
 // Elections
 
 func (r *ElectionRepository) CreateElection(ctx context.Context, req *models.CreateElectionRequest) (*models.Election, error) {
-	electionDate, err := time.Parse("2006-01-02", req.ElectionDate)
+	electionDate, err := models.ParseDate(req.ElectionDate)
 	if err != nil {
 		return nil, fmt.Errorf("invalid election_date format: %w", err)
 	}
-
-	var registrationStart, registrationEnd, campaignStart, campaignEnd *time.Time
-	if req.RegistrationStart != nil {
-		t, _ := time.Parse("2006-01-02", *req.RegistrationStart)
-		registrationStart = &t
+	registrationStart, err := models.ParseDatePtr(req.RegistrationStart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registration_start format: %w", err)
 	}
-	if req.RegistrationEnd != nil {
-		t, _ := time.Parse("2006-01-02", *req.RegistrationEnd)
-		registrationEnd = &t
+	registrationEnd, err := models.ParseDatePtr(req.RegistrationEnd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registration_end format: %w", err)
 	}
-	if req.CampaignStart != nil {
-		t, _ := time.Parse("2006-01-02", *req.CampaignStart)
-		campaignStart = &t
+	campaignStart, err := models.ParseDatePtr(req.CampaignStart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid campaign_start format: %w", err)
 	}
-	if req.CampaignEnd != nil {
-		t, _ := time.Parse("2006-01-02", *req.CampaignEnd)
-		campaignEnd = &t
+	campaignEnd, err := models.ParseDatePtr(req.CampaignEnd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid campaign_end format: %w", err)
 	}
 
 	election := &models.Election{}
 	err = r.db.QueryRow(ctx, `
 		INSERT INTO elections (name, slug, election_type, description, election_date, registration_start, registration_end, campaign_start, campaign_end, status, is_featured)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING id, name, slug, election_type, description, election_date, registration_start, registration_end, campaign_start, campaign_end, status, is_featured, created_at, updated_at
+		RETURNING id, name, slug, election_type, description, election_date, registration_start, registration_end, campaign_start, campaign_end, status, is_featured, data_as_of, created_at, updated_at
 	`, req.Name, req.Slug, req.ElectionType, req.Description, electionDate, registrationStart, registrationEnd, campaignStart, campaignEnd, req.Status, req.IsFeatured).Scan(
 		&election.ID, &election.Name, &election.Slug, &election.ElectionType, &election.Description,
 		&election.ElectionDate, &election.RegistrationStart, &election.RegistrationEnd, &election.CampaignStart, &election.CampaignEnd,
-		&election.Status, &election.IsFeatured, &election.CreatedAt, &election.UpdatedAt,
+		&election.Status, &election.IsFeatured, &election.DataAsOf, &election.CreatedAt, &election.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create election: %w", err)
@@ -68,14 +68,14 @@ func (r *ElectionRepository) GetElectionByID(ctx context.Context, id uuid.UUID)
 	err := r.db.QueryRow(ctx, `
 		SELECT id, name, slug, election_type, description, election_date, registration_start, registration_end,
 		       campaign_start, campaign_end, status, is_featured, voter_turnout_percentage, total_registered_voters,
-		       total_votes_cast, created_at, updated_at
+		       total_votes_cast, data_as_of, created_at, updated_at
 		FROM elections
 		WHERE id = $1 AND deleted_at IS NULL
 	`, id).Scan(
 		&election.ID, &election.Name, &election.Slug, &election.ElectionType, &election.Description,
 		&election.ElectionDate, &election.RegistrationStart, &election.RegistrationEnd, &election.CampaignStart, &election.CampaignEnd,
 		&election.Status, &election.IsFeatured, &election.VoterTurnoutPercentage, &election.TotalRegisteredVoters,
-		&election.TotalVotesCast, &election.CreatedAt, &election.UpdatedAt,
+		&election.TotalVotesCast, &election.DataAsOf, &election.CreatedAt, &election.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, nil
@@ -92,14 +92,14 @@ func (r *ElectionRepository) GetElectionBySlug(ctx context.Context, slug string)
 	err := r.db.QueryRow(ctx, `
 		SELECT id, name, slug, election_type, description, election_date, registration_start, registration_end,
 		       campaign_start, campaign_end, status, is_featured, voter_turnout_percentage, total_registered_voters,
-		       total_votes_cast, created_at, updated_at
+		       total_votes_cast, data_as_of, created_at, updated_at
 		FROM elections
 		WHERE slug = $1 AND deleted_at IS NULL
 	`, slug).Scan(
 		&election.ID, &election.Name, &election.Slug, &election.ElectionType, &election.Description,
 		&election.ElectionDate, &election.RegistrationStart, &election.RegistrationEnd, &election.CampaignStart, &election.CampaignEnd,
 		&election.Status, &election.IsFeatured, &election.VoterTurnoutPercentage, &election.TotalRegisteredVoters,
-		&election.TotalVotesCast, &election.CreatedAt, &election.UpdatedAt,
+		&election.TotalVotesCast, &election.DataAsOf, &election.CreatedAt, &election.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, nil
@@ -110,6 +110,7 @@ func (r *ElectionRepository) GetElectionBySlug(ctx context.Context, slug string)
 
 	// Load positions
 	election.Positions, _ = r.GetElectionPositions(ctx, election.ID)
+	election.BallotGroups = models.GroupBallotSections(election.Positions)
 
 	return election, nil
 }
@@ -199,16 +200,18 @@ func (r *ElectionRepository) ListElections(ctx context.Context, filter *models.E
 	}, nil
 }
 
-func (r *ElectionRepository) GetUpcomingElections(ctx context.Context, limit int) ([]models.ElectionListItem, error) {
+// GetUpcomingElections returns upcoming elections held on or after today,
+// the current day in the site timezone, soonest first
+func (r *ElectionRepository) GetUpcomingElections(ctx context.Context, today models.Date, limit int) ([]models.ElectionListItem, error) {
 	rows, err := r.db.Query(ctx, `
 		SELECT e.id, e.name, e.slug, e.election_type, e.election_date, e.status, e.is_featured, e.voter_turnout_percentage,
 		       COALESCE((SELECT COUNT(*) FROM election_positions WHERE election_id = e.id), 0) as position_count,
 		       COALESCE((SELECT COUNT(*) FROM candidates c JOIN election_positions ep ON c.election_position_id = ep.id WHERE ep.election_id = e.id), 0) as candidate_count
 		FROM elections e
-		WHERE e.deleted_at IS NULL AND e.status = 'upcoming' AND e.election_date >= CURRENT_DATE
+		WHERE e.deleted_at IS NULL AND e.status = 'upcoming' AND e.election_date >= $1
 		ORDER BY e.election_date ASC
-		LIMIT $1
-	`, limit)
+		LIMIT $2
+	`, today, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get upcoming elections: %w", err)
 	}
@@ -303,10 +306,32 @@ func (r *ElectionRepository) UpdateElection(ctx context.Context, id uuid.UUID, r
 		setClauses = append(setClauses, fmt.Sprintf("description = $%d", len(args)))
 	}
 	if req.ElectionDate != nil {
-		date, _ := time.Parse("2006-01-02", *req.ElectionDate)
+		date, err := models.ParseDate(*req.ElectionDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid election_date format: %w", err)
+		}
 		args = append(args, date)
 		setClauses = append(setClauses, fmt.Sprintf("election_date = $%d", len(args)))
 	}
+	for _, field := range []struct {
+		column string
+		value  *string
+	}{
+		{"registration_start", req.RegistrationStart},
+		{"registration_end", req.RegistrationEnd},
+		{"campaign_start", req.CampaignStart},
+		{"campaign_end", req.CampaignEnd},
+	} {
+		if field.value == nil {
+			continue
+		}
+		date, err := models.ParseDate(*field.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s format: %w", field.column, err)
+		}
+		args = append(args, date)
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", field.column, len(args)))
+	}
 	if req.Status != nil {
 		args = append(args, *req.Status)
 		setClauses = append(setClauses, fmt.Sprintf("status = $%d", len(args)))
@@ -327,6 +352,12 @@ func (r *ElectionRepository) UpdateElection(ctx context.Context, id uuid.UUID, r
 		args = append(args, *req.TotalVotesCast)
 		setClauses = append(setClauses, fmt.Sprintf("total_votes_cast = $%d", len(args)))
 	}
+	if req.DataAsOf != nil {
+		args = append(args, *req.DataAsOf)
+		setClauses = append(setClauses, fmt.Sprintf("data_as_of = $%d", len(args)))
+	} else if req.VoterTurnoutPercentage != nil || req.TotalRegisteredVoters != nil || req.TotalVotesCast != nil {
+		setClauses = append(setClauses, "data_as_of = NOW()")
+	}
 
 	if len(setClauses) == 0 {
 		return r.GetElectionByID(ctx, id)
@@ -335,14 +366,15 @@ func (r *ElectionRepository) UpdateElection(ctx context.Context, id uuid.UUID, r
 	query := fmt.Sprintf(`
 		UPDATE elections SET %s
 		WHERE id = $1 AND deleted_at IS NULL
-		RETURNING id, name, slug, election_type, description, election_date, status, is_featured, voter_turnout_percentage, total_registered_voters, total_votes_cast, created_at, updated_at
+		RETURNING id, name, slug, election_type, description, election_date, registration_start, registration_end,
+		          campaign_start, campaign_end, status, is_featured, voter_turnout_percentage, total_registered_voters, total_votes_cast, data_as_of, created_at, updated_at
 	`, strings.Join(setClauses, ", "))
 
 	election := &models.Election{}
 	err := r.db.QueryRow(ctx, query, args...).Scan(
 		&election.ID, &election.Name, &election.Slug, &election.ElectionType, &election.Description,
-		&election.ElectionDate, &election.Status, &election.IsFeatured, &election.VoterTurnoutPercentage,
-		&election.TotalRegisteredVoters, &election.TotalVotesCast, &election.CreatedAt, &election.UpdatedAt,
+		&election.ElectionDate, &election.RegistrationStart, &election.RegistrationEnd, &election.CampaignStart, &election.CampaignEnd, &election.Status, &election.IsFeatured, &election.VoterTurnoutPercentage,
+		&election.TotalRegisteredVoters, &election.TotalVotesCast, &election.DataAsOf, &election.CreatedAt, &election.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, nil
@@ -365,15 +397,27 @@ func (r *ElectionRepository) DeleteElection(ctx context.Context, id uuid.UUID) e
 // Election Positions
 
 func (r *ElectionRepository) CreateElectionPosition(ctx context.Context, req *models.CreateElectionPositionRequest) (*models.ElectionPosition, error) {
+	// New positions go to the end of their ballot group, which defaults to
+	// the position's government level (municipal offices share the city group)
 	position := &models.ElectionPosition{}
 	err := r.db.QueryRow(ctx, `
-		INSERT INTO election_positions (election_id, position_id, region_id, province_id, city_municipality_id, barangay_id, district_id, seats_available, description)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, election_id, position_id, region_id, province_id, city_municipality_id, barangay_id, district_id, seats_available, description, created_at
-	`, req.ElectionID, req.PositionID, req.RegionID, req.ProvinceID, req.CityMunicipalityID, req.BarangayID, req.DistrictID, req.SeatsAvailable, req.Description).Scan(
+		INSERT INTO election_positions (election_id, position_id, region_id, province_id, city_municipality_id, barangay_id, district_id, seats_available, description, ballot_group, ballot_order)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, g.grp,
+		       COALESCE((SELECT MAX(ballot_order) FROM election_positions WHERE election_id = $1 AND ballot_group = g.grp), 0) + 1
+		FROM (
+			SELECT COALESCE($10::ballot_group, (CASE gp.level WHEN 'municipal' THEN 'city' ELSE gp.level::text END)::ballot_group) AS grp
+			FROM government_positions gp
+			WHERE gp.id = $2
+		) g
+		RETURNING id, election_id, position_id, region_id, province_id, city_municipality_id, barangay_id, district_id, seats_available, description, ballot_group, ballot_order, created_at
+	`, req.ElectionID, req.PositionID, req.RegionID, req.ProvinceID, req.CityMunicipalityID, req.BarangayID, req.DistrictID, req.SeatsAvailable, req.Description, req.BallotGroup).Scan(
 		&position.ID, &position.ElectionID, &position.PositionID, &position.RegionID, &position.ProvinceID,
-		&position.CityMunicipalityID, &position.BarangayID, &position.DistrictID, &position.SeatsAvailable, &position.Description, &position.CreatedAt,
+		&position.CityMunicipalityID, &position.BarangayID, &position.DistrictID, &position.SeatsAvailable, &position.Description,
+		&position.BallotGroup, &position.BallotOrder, &position.CreatedAt,
 	)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("government position not found")
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create election position: %w", err)
 	}
@@ -382,7 +426,7 @@ func (r *ElectionRepository) CreateElectionPosition(ctx context.Context, req *mo
 
 func (r *ElectionRepository) GetElectionPositions(ctx context.Context, electionID uuid.UUID) ([]models.ElectionPositionListItem, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT ep.id, ep.position_id, ep.seats_available,
+		SELECT ep.id, ep.position_id, ep.seats_available, ep.ballot_group, ep.ballot_order,
 		       gp.id, gp.name, gp.slug, gp.level, gp.branch, gp.is_elected,
 		       COALESCE(r.name, pr.name, cm.name, b.name, cd.name, '') as location_name,
 		       COALESCE((SELECT COUNT(*) FROM candidates WHERE election_position_id = ep.id), 0) as candidate_count
@@ -394,7 +438,7 @@ func (r *ElectionRepository) GetElectionPositions(ctx context.Context, electionI
 		LEFT JOIN barangays b ON ep.barangay_id = b.id
 		LEFT JOIN congressional_districts cd ON ep.district_id = cd.id
 		WHERE ep.election_id = $1
-		ORDER BY gp.display_order, location_name
+		ORDER BY ep.ballot_group, ep.ballot_order, gp.display_order, location_name
 	`, electionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get election positions: %w", err)
@@ -407,7 +451,7 @@ func (r *ElectionRepository) GetElectionPositions(ctx context.Context, electionI
 		var posInfo models.GovernmentPositionInfo
 		var locationName string
 		err := rows.Scan(
-			&p.ID, &p.PositionID, &p.SeatsAvailable,
+			&p.ID, &p.PositionID, &p.SeatsAvailable, &p.BallotGroup, &p.BallotOrder,
 			&posInfo.ID, &posInfo.Name, &posInfo.Slug, &posInfo.Level, &posInfo.Branch, &posInfo.IsElected,
 			&locationName, &p.CandidateCount,
 		)
@@ -424,17 +468,116 @@ func (r *ElectionRepository) GetElectionPositions(ctx context.Context, electionI
 	return positions, nil
 }
 
+// lockElectionPositions returns the IDs of an election's positions, locking
+// them until the transaction ends
+func lockElectionPositions(ctx context.Context, tx pgx.Tx, electionID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := tx.Query(ctx, `SELECT id FROM election_positions WHERE election_id = $1 FOR UPDATE`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock election positions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan election position: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ReorderElectionPositions sets the ballot order of an election's positions
+// from ids, which must list each of them exactly once. Positions are numbered
+// within their ballot group following their order in ids.
+func (r *ElectionRepository) ReorderElectionPositions(ctx context.Context, electionID uuid.UUID, ids []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Lock the positions so one added concurrently can't be left unordered
+	existing, err := lockElectionPositions(ctx, tx, electionID)
+	if err != nil {
+		return err
+	}
+
+	if err := models.ValidateBallotOrder(existing, ids); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE election_positions ep
+		SET ballot_order = ranked.n
+		FROM (
+			SELECT ep2.id, ROW_NUMBER() OVER (PARTITION BY ep2.ballot_group ORDER BY o.ord) AS n
+			FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+			JOIN election_positions ep2 ON ep2.id = o.id
+		) ranked
+		WHERE ep.id = ranked.id AND ep.election_id = $1
+	`, electionID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to reorder election positions: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SetBallotOrders sets the ballot order of the listed positions of an
+// election, leaving the others as they are
+func (r *ElectionRepository) SetBallotOrders(ctx context.Context, electionID uuid.UUID, orders []models.BallotPositionOrder) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	existing, err := lockElectionPositions(ctx, tx, electionID)
+	if err != nil {
+		return err
+	}
+
+	if err := models.ValidateBallotOrders(existing, orders); err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, len(orders))
+	values := make([]int, len(orders))
+	for i, o := range orders {
+		ids[i], values[i] = o.PositionID, o.Order
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE election_positions ep
+		SET ballot_order = o.ord
+		FROM unnest($2::uuid[], $3::int[]) AS o(id, ord)
+		WHERE ep.id = o.id AND ep.election_id = $1
+	`, electionID, ids, values)
+	if err != nil {
+		return fmt.Errorf("failed to set ballot orders: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // Candidates
 
 func (r *ElectionRepository) CreateCandidate(ctx context.Context, req *models.CreateCandidateRequest) (*models.Candidate, error) {
-	var filingDate *time.Time
-	if req.FilingDate != nil {
-		t, _ := time.Parse("2006-01-02", *req.FilingDate)
-		filingDate = &t
+	filingDate, err := models.ParseDatePtr(req.FilingDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filing_date format: %w", err)
 	}
 
 	candidate := &models.Candidate{}
-	err := r.db.QueryRow(ctx, `
+	err = r.db.QueryRow(ctx, `
 		INSERT INTO candidates (election_position_id, politician_id, party_id, ballot_number, ballot_name, campaign_slogan, platform, status, filing_date, is_incumbent)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id, election_position_id, politician_id, party_id, ballot_number, ballot_name, campaign_slogan, platform, status, filing_date, is_incumbent, is_winner, votes_received, vote_percentage, created_at, updated_at
@@ -445,11 +588,24 @@ func (r *ElectionRepository) CreateCandidate(ctx context.Context, req *models.Cr
 		&candidate.VotesReceived, &candidate.VotePercentage, &candidate.CreatedAt, &candidate.UpdatedAt,
 	)
 	if err != nil {
+		if conflict := ballotNumberConflict(err); conflict != nil {
+			return nil, conflict
+		}
 		return nil, fmt.Errorf("failed to create candidate: %w", err)
 	}
 	return candidate, nil
 }
 
+// ballotNumberConflict turns a clash on idx_candidates_position_ballot_number
+// into the error shown to admins, or returns nil for any other error
+func ballotNumberConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_candidates_position_ballot_number" {
+		return fmt.Errorf("ballot number is already taken in this position")
+	}
+	return nil
+}
+
 func (r *ElectionRepository) GetCandidateByID(ctx context.Context, id uuid.UUID) (*models.Candidate, error) {
 	candidate := &models.Candidate{}
 	var pol models.PoliticianListItem
@@ -459,9 +615,9 @@ func (r *ElectionRepository) GetCandidateByID(ctx context.Context, id uuid.UUID)
 	err := r.db.QueryRow(ctx, `
 		SELECT c.id, c.election_position_id, c.politician_id, c.party_id, c.ballot_number, c.ballot_name,
 		       c.campaign_slogan, c.platform, c.status, c.filing_date, c.is_incumbent, c.is_winner,
-		       c.votes_received, c.vote_percentage, c.created_at, c.updated_at,
-		       p.id, p.name, p.slug, p.photo, p.position, p.party,
-		       pp.id, pp.name, pp.slug, pp.abbreviation, pp.logo, pp.color
+		       c.votes_received, c.vote_percentage, c.substituted_by, c.substitution_reason, c.created_at, c.updated_at,
+		       p.id, p.name, p.slug, COALESCE(p.photo_list, p.photo), p.position, p.party,
+		       pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color
 		FROM candidates c
 		JOIN politicians p ON c.politician_id = p.id
 		LEFT JOIN political_parties pp ON c.party_id = pp.id
@@ -470,7 +626,8 @@ func (r *ElectionRepository) GetCandidateByID(ctx context.Context, id uuid.UUID)
 		&candidate.ID, &candidate.ElectionPositionID, &candidate.PoliticianID, &partyID,
 		&candidate.BallotNumber, &candidate.BallotName, &candidate.CampaignSlogan, &candidate.Platform,
 		&candidate.Status, &candidate.FilingDate, &candidate.IsIncumbent, &candidate.IsWinner,
-		&candidate.VotesReceived, &candidate.VotePercentage, &candidate.CreatedAt, &candidate.UpdatedAt,
+		&candidate.VotesReceived, &candidate.VotePercentage, &candidate.SubstitutedBy, &candidate.SubstitutionReason,
+		&candidate.CreatedAt, &candidate.UpdatedAt,
 		&pol.ID, &pol.Name, &pol.Slug, &pol.Photo, &pol.Position, &pol.Party,
 		&party.ID, &party.Name, &party.Slug, &party.Abbreviation, &party.Logo, &party.Color,
 	)
@@ -490,17 +647,24 @@ func (r *ElectionRepository) GetCandidateByID(ctx context.Context, id uuid.UUID)
 	return candidate, nil
 }
 
-func (r *ElectionRepository) GetCandidatesForPosition(ctx context.Context, positionID uuid.UUID) ([]models.CandidateListItem, error) {
+// GetCandidatesForPosition lists a position's candidates, only those whose
+// platform addresses issue when it is given. Withdrawn and substituted
+// candidates are still listed, since they remain on the printed ballot.
+func (r *ElectionRepository) GetCandidatesForPosition(ctx context.Context, positionID uuid.UUID, issue *string) ([]models.CandidateListItem, error) {
 	rows, err := r.db.Query(ctx, `
 		SELECT c.id, c.politician_id, c.ballot_number, c.ballot_name, c.status, c.is_incumbent, c.is_winner, c.votes_received, c.vote_percentage,
-		       p.id, p.name, p.slug, p.photo, p.position, p.party,
-		       pp.id, pp.name, pp.slug, pp.abbreviation, pp.logo, pp.color
+		       c.substituted_by,
+		       p.id, p.name, p.slug, COALESCE(p.photo_list, p.photo), p.position, p.party,
+		       pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color
 		FROM candidates c
 		JOIN politicians p ON c.politician_id = p.id
 		LEFT JOIN political_parties pp ON c.party_id = pp.id
 		WHERE c.election_position_id = $1
+		  AND ($2::varchar IS NULL OR EXISTS (
+		      SELECT 1 FROM candidate_issues ci WHERE ci.candidate_id = c.id AND ci.issue_slug = $2
+		  ))
 		ORDER BY COALESCE(c.votes_received, 0) DESC, c.ballot_number
-	`, positionID)
+	`, positionID, issue)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get candidates: %w", err)
 	}
@@ -515,6 +679,7 @@ func (r *ElectionRepository) GetCandidatesForPosition(ctx context.Context, posit
 
 		err := rows.Scan(
 			&c.ID, &c.PoliticianID, &c.BallotNumber, &c.BallotName, &c.Status, &c.IsIncumbent, &c.IsWinner, &c.VotesReceived, &c.VotePercentage,
+			&c.SubstitutedBy,
 			&pol.ID, &pol.Name, &pol.Slug, &pol.Photo, &pol.Position, &pol.Party,
 			&partyID, &partyName, &partySlug, &partyAbbr, &partyLogo, &partyColor,
 		)
@@ -522,6 +687,7 @@ func (r *ElectionRepository) GetCandidatesForPosition(ctx context.Context, posit
 			return nil, fmt.Errorf("failed to scan candidate: %w", err)
 		}
 
+		c.Withdrawn = models.IsWithdrawnCandidate(c.Status)
 		c.Politician = &pol
 		if partyID != nil {
 			party.Name = *partyName
@@ -592,8 +758,8 @@ func (r *ElectionRepository) ListCandidates(ctx context.Context, filter *models.
 	// List
 	query := fmt.Sprintf(`
 		SELECT c.id, c.politician_id, c.ballot_number, c.ballot_name, c.status, c.is_incumbent, c.is_winner, c.votes_received, c.vote_percentage,
-		       p.id, p.name, p.slug, p.photo, p.position, p.party,
-		       pp.id, pp.name, pp.slug, pp.abbreviation, pp.logo, pp.color
+		       p.id, p.name, p.slug, COALESCE(p.photo_list, p.photo), p.position, p.party,
+		       pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color
 		FROM candidates c
 		JOIN election_positions ep ON c.election_position_id = ep.id
 		JOIN politicians p ON c.politician_id = p.id
@@ -698,8 +864,25 @@ func (r *ElectionRepository) UpdateCandidate(ctx context.Context, id uuid.UUID,
 		return r.GetCandidateByID(ctx, id)
 	}
 
-	_, err := r.db.Exec(ctx, fmt.Sprintf("UPDATE candidates SET %s WHERE id = $1", strings.Join(setClauses, ", ")), args...)
+	query := fmt.Sprintf("UPDATE candidates SET %s WHERE id = $1", strings.Join(setClauses, ", "))
+	if req.IsWinner != nil || req.VotesReceived != nil || req.VotePercentage != nil {
+		// A new tally brings the election's data up to date
+		query = fmt.Sprintf(`
+			WITH candidate AS (
+				UPDATE candidates SET %s WHERE id = $1 RETURNING election_position_id
+			)
+			UPDATE elections e SET data_as_of = NOW()
+			FROM candidate c
+			JOIN election_positions ep ON ep.id = c.election_position_id
+			WHERE e.id = ep.election_id
+		`, strings.Join(setClauses, ", "))
+	}
+
+	_, err := r.db.Exec(ctx, query, args...)
 	if err != nil {
+		if conflict := ballotNumberConflict(err); conflict != nil {
+			return nil, conflict
+		}
 		return nil, fmt.Errorf("failed to update candidate: %w", err)
 	}
 
@@ -814,3 +997,36 @@ func (r *ElectionRepository) IncrementVoterEducationViewCount(ctx context.Contex
 	_, err := r.db.Exec(ctx, `UPDATE voter_education SET view_count = view_count + 1 WHERE id = $1`, id)
 	return err
 }
+
+// AddVoterEducationViewCounts writes batched view counts, returning the slugs
+// of the items updated keyed by ID
+func (r *ElectionRepository) AddVoterEducationViewCounts(ctx context.Context, views map[uuid.UUID]int64) (map[uuid.UUID]string, error) {
+	ids := make([]uuid.UUID, 0, len(views))
+	deltas := make([]int64, 0, len(views))
+	for id, delta := range views {
+		ids = append(ids, id)
+		deltas = append(deltas, delta)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		UPDATE voter_education v SET view_count = v.view_count + d.delta
+		FROM unnest($1::uuid[], $2::bigint[]) AS d(id, delta)
+		WHERE v.id = d.id
+		RETURNING v.id, v.slug
+	`, ids, deltas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add voter education view counts: %w", err)
+	}
+	defer rows.Close()
+
+	updated := make(map[uuid.UUID]string, len(ids))
+	for rows.Next() {
+		var id uuid.UUID
+		var slug string
+		if err := rows.Scan(&id, &slug); err != nil {
+			return nil, fmt.Errorf("failed to scan voter education: %w", err)
+		}
+		updated[id] = slug
+	}
+	return updated, rows.Err()
+}
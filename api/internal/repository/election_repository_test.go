@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBallotNumberConflict(t *testing.T) {
+	taken := &pgconn.PgError{Code: "23505", ConstraintName: "idx_candidates_position_ballot_number"}
+	assert.EqualError(t, ballotNumberConflict(fmt.Errorf("insert: %w", taken)), "ballot number is already taken in this position")
+
+	// Other unique violations and other errors pass through
+	assert.Nil(t, ballotNumberConflict(&pgconn.PgError{Code: "23505", ConstraintName: "candidates_pkey"}))
+	assert.Nil(t, ballotNumberConflict(errors.New("connection reset")))
+}
+
+func TestElectionRepository_CandidateBallotNumbers(t *testing.T) {
+	ctx := context.Background()
+	pool := openTestDB(t)
+
+	suffix := uuid.NewString()[:8]
+	insert := func(query string, args ...interface{}) uuid.UUID {
+		var id uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, query, args...).Scan(&id))
+		return id
+	}
+
+	electionID := insert(`
+		INSERT INTO elections (name, slug, election_type, election_date)
+		VALUES ($1, $1, 'local', '2028-05-08') RETURNING id
+	`, "ballot-"+suffix)
+	office := func(slug string) uuid.UUID {
+		return insert(`
+			INSERT INTO government_positions (name, slug, level, branch)
+			VALUES ($1, $1, 'municipal', 'executive') RETURNING id
+		`, slug+"-"+suffix)
+	}
+	offices := []uuid.UUID{office("mayor"), office("vice-mayor")}
+	mayorID := insert("INSERT INTO election_positions (election_id, position_id) VALUES ($1, $2) RETURNING id", electionID, offices[0])
+	viceID := insert("INSERT INTO election_positions (election_id, position_id) VALUES ($1, $2) RETURNING id", electionID, offices[1])
+	politicians := make([]uuid.UUID, 4)
+	for i := range politicians {
+		slug := "ballot-" + suffix + "-" + string(rune('a'+i))
+		politicians[i] = insert("INSERT INTO politicians (name, slug) VALUES ($1, $1) RETURNING id", slug)
+	}
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM elections WHERE id = $1", electionID)
+		_, _ = pool.Exec(ctx, "DELETE FROM politicians WHERE id = ANY($1)", politicians)
+		_, _ = pool.Exec(ctx, "DELETE FROM government_positions WHERE id = ANY($1)", offices)
+	})
+
+	repo := NewElectionRepository(pool)
+	create := func(positionID, politicianID uuid.UUID, number *int) (*models.Candidate, error) {
+		return repo.CreateCandidate(ctx, &models.CreateCandidateRequest{
+			ElectionPositionID: positionID,
+			PoliticianID:       politicianID,
+			BallotNumber:       number,
+			Status:             "filed",
+		})
+	}
+	one, two := 1, 2
+
+	first, err := create(mayorID, politicians[0], &one)
+	require.NoError(t, err)
+
+	_, err = create(mayorID, politicians[1], &one)
+	assert.EqualError(t, err, "ballot number is already taken in this position")
+
+	// The same number in another position, and any number of candidates
+	// before the draw, are fine
+	_, err = create(viceID, politicians[1], &one)
+	assert.NoError(t, err)
+	second, err := create(mayorID, politicians[2], nil)
+	require.NoError(t, err)
+	_, err = create(mayorID, politicians[3], nil)
+	assert.NoError(t, err)
+
+	_, err = repo.UpdateCandidate(ctx, second.ID, &models.UpdateCandidateRequest{BallotNumber: &one})
+	assert.EqualError(t, err, "ballot number is already taken in this position")
+
+	updated, err := repo.UpdateCandidate(ctx, second.ID, &models.UpdateCandidateRequest{BallotNumber: &two})
+	require.NoError(t, err)
+	assert.Equal(t, &two, updated.BallotNumber)
+
+	// Keeping a candidate's own number is not a conflict
+	_, err = repo.UpdateCandidate(ctx, first.ID, &models.UpdateCandidateRequest{BallotNumber: &one})
+	assert.NoError(t, err)
+}
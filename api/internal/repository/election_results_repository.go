@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// GetResultsSummary lists the outcome of each of an election's positions in
+// ballot order. Disqualified, withdrawn and substituted candidates cannot win
+// and are left out.
+func (r *ElectionRepository) GetResultsSummary(ctx context.Context, electionID uuid.UUID) ([]models.PositionResultSummary, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT ep.id, gp.name, COALESCE(r.name, pr.name, cm.name, b.name, cd.name, '') AS location_name, ep.seats_available,
+		       c.id, c.politician_id, COALESCE(NULLIF(c.ballot_name, ''), p.name), p.slug, COALESCE(p.photo_list, p.photo),
+		       COALESCE(c.votes_received, 0), c.vote_percentage,
+		       pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color
+		FROM election_positions ep
+		JOIN government_positions gp ON ep.position_id = gp.id
+		LEFT JOIN regions r ON ep.region_id = r.id
+		LEFT JOIN provinces pr ON ep.province_id = pr.id
+		LEFT JOIN cities_municipalities cm ON ep.city_municipality_id = cm.id
+		LEFT JOIN barangays b ON ep.barangay_id = b.id
+		LEFT JOIN congressional_districts cd ON ep.district_id = cd.id
+		LEFT JOIN candidates c ON c.election_position_id = ep.id
+		     AND c.status NOT IN ('disqualified', 'withdrawn', 'substituted')
+		LEFT JOIN politicians p ON c.politician_id = p.id
+		LEFT JOIN political_parties pp ON c.party_id = pp.id
+		WHERE ep.election_id = $1
+		ORDER BY ep.ballot_group, ep.ballot_order, gp.display_order, location_name, ep.id,
+		         COALESCE(c.votes_received, 0) DESC, c.ballot_number
+	`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get results summary: %w", err)
+	}
+	defer rows.Close()
+
+	positions := []models.PositionResultSummary{}
+	var ranked [][]models.ResultCandidate
+	for rows.Next() {
+		var position models.PositionResultSummary
+		var locationName string
+		var candidateID, politicianID *uuid.UUID
+		var name, slug *string
+		var c models.ResultCandidate
+		var partyID *uuid.UUID
+		var partyName, partySlug *string
+		var party models.PartyBrief
+
+		err := rows.Scan(
+			&position.ElectionPositionID, &position.PositionName, &locationName, &position.SeatsAvailable,
+			&candidateID, &politicianID, &name, &slug, &c.Photo,
+			&c.Votes, &c.Percentage,
+			&partyID, &partyName, &partySlug, &party.Abbreviation, &party.Logo, &party.Color,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan results summary: %w", err)
+		}
+
+		if n := len(positions); n == 0 || positions[n-1].ElectionPositionID != position.ElectionPositionID {
+			if locationName != "" {
+				position.Location = &locationName
+			}
+			positions = append(positions, position)
+			ranked = append(ranked, nil)
+		}
+		if candidateID == nil {
+			continue
+		}
+
+		c.CandidateID = *candidateID
+		c.PoliticianID = *politicianID
+		c.Name = *name
+		c.Slug = *slug
+		if partyID != nil {
+			party.ID = *partyID
+			party.Name = *partyName
+			party.Slug = *partySlug
+			c.Party = &party
+		}
+		ranked[len(ranked)-1] = append(ranked[len(ranked)-1], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get results summary: %w", err)
+	}
+
+	for i := range positions {
+		positions[i].DecideResults(ranked[i])
+	}
+
+	return positions, nil
+}
+
+// GetPartyTallies counts each party's candidates and winners in an election,
+// with the coalition the party was in on election day. A coalition fielding
+// candidates under its own name is its own coalition. Independents, and
+// candidates who dropped out, are not counted.
+func (r *ElectionRepository) GetPartyTallies(ctx context.Context, electionID uuid.UUID) ([]models.PartyTally, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH memberships AS (
+			SELECT DISTINCT ON (pr.party_id) pr.party_id, pr.related_party_id AS coalition_id
+			FROM party_relations pr
+			JOIN elections e ON e.id = $1
+			WHERE pr.relation_type = 'coalition_member_of'
+			  AND COALESCE(pr.start_date, '-infinity'::date) <= e.election_date
+			  AND COALESCE(pr.end_date, 'infinity'::date) >= e.election_date
+			ORDER BY pr.party_id, pr.start_date DESC NULLS LAST
+		),
+		tallies AS (
+			SELECT c.party_id, COUNT(*) AS candidates, COUNT(*) FILTER (WHERE c.is_winner) AS winners
+			FROM candidates c
+			JOIN election_positions ep ON c.election_position_id = ep.id
+			WHERE ep.election_id = $1 AND c.party_id IS NOT NULL
+			  AND c.status NOT IN ('disqualified', 'withdrawn', 'substituted')
+			GROUP BY c.party_id
+		)
+		SELECT pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color,
+		       cp.id, cp.name, cp.slug, cp.abbreviation, COALESCE(cp.logo_list, cp.logo), cp.color,
+		       t.candidates, t.winners
+		FROM tallies t
+		JOIN political_parties pp ON t.party_id = pp.id
+		LEFT JOIN memberships m ON m.party_id = t.party_id
+		LEFT JOIN political_parties cp ON cp.id = COALESCE(
+			m.coalition_id,
+			(SELECT DISTINCT coalition_id FROM memberships WHERE coalition_id = t.party_id)
+		)
+		ORDER BY t.winners DESC, t.candidates DESC, pp.name
+	`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get party tallies: %w", err)
+	}
+	defer rows.Close()
+
+	tallies := []models.PartyTally{}
+	for rows.Next() {
+		var t models.PartyTally
+		var coalitionID *uuid.UUID
+		var coalitionName, coalitionSlug *string
+		var coalition models.PartyBrief
+
+		err := rows.Scan(
+			&t.Party.ID, &t.Party.Name, &t.Party.Slug, &t.Party.Abbreviation, &t.Party.Logo, &t.Party.Color,
+			&coalitionID, &coalitionName, &coalitionSlug, &coalition.Abbreviation, &coalition.Logo, &coalition.Color,
+			&t.Candidates, &t.Winners,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan party tally: %w", err)
+		}
+
+		if coalitionID != nil {
+			coalition.ID = *coalitionID
+			coalition.Name = *coalitionName
+			coalition.Slug = *coalitionSlug
+			t.Coalition = &coalition
+		}
+		tallies = append(tallies, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get party tallies: %w", err)
+	}
+
+	return tallies, nil
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// Election Surveys
+
+const surveyColumns = `
+	s.id, s.election_id, s.position_id, s.survey_firm, s.conducted_start, s.conducted_end,
+	s.sample_size, s.margin_of_error, s.published_at, s.source_url, s.created_at, gp.name`
+
+func scanSurvey(row pgx.Row, survey *models.ElectionSurvey) error {
+	return row.Scan(
+		&survey.ID, &survey.ElectionID, &survey.PositionID, &survey.SurveyFirm, &survey.ConductedStart, &survey.ConductedEnd,
+		&survey.SampleSize, &survey.MarginOfError, &survey.PublishedAt, &survey.SourceURL, &survey.CreatedAt, &survey.PositionName,
+	)
+}
+
+// GetElectionPositionElectionID returns the election a contested position
+// belongs to, or nil if there is no such position
+func (r *ElectionRepository) GetElectionPositionElectionID(ctx context.Context, positionID uuid.UUID) (*uuid.UUID, error) {
+	var electionID uuid.UUID
+	err := r.db.QueryRow(ctx, `SELECT election_id FROM election_positions WHERE id = $1`, positionID).Scan(&electionID)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get election position: %w", err)
+	}
+	return &electionID, nil
+}
+
+// CountPositionCandidates counts how many of candidateIDs run for the position
+func (r *ElectionRepository) CountPositionCandidates(ctx context.Context, positionID uuid.UUID, candidateIDs []uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM candidates WHERE election_position_id = $1 AND id = ANY($2)
+	`, positionID, candidateIDs).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count position candidates: %w", err)
+	}
+	return count, nil
+}
+
+// CreateSurvey inserts the survey and its results
+func (r *ElectionRepository) CreateSurvey(ctx context.Context, survey *models.ElectionSurvey, results []models.SurveyResultInput) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO election_surveys (election_id, position_id, survey_firm, conducted_start, conducted_end,
+			sample_size, margin_of_error, published_at, source_url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`, survey.ElectionID, survey.PositionID, survey.SurveyFirm, survey.ConductedStart, survey.ConductedEnd,
+		survey.SampleSize, survey.MarginOfError, survey.PublishedAt, survey.SourceURL,
+	).Scan(&survey.ID, &survey.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create survey: %w", err)
+	}
+
+	if err := insertSurveyResults(ctx, tx, survey.ID, results); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateSurvey saves the survey's fields and, when results is not nil,
+// replaces its results
+func (r *ElectionRepository) UpdateSurvey(ctx context.Context, survey *models.ElectionSurvey, results []models.SurveyResultInput) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result, err := tx.Exec(ctx, `
+		UPDATE election_surveys
+		SET position_id = $2, survey_firm = $3, conducted_start = $4, conducted_end = $5,
+		    sample_size = $6, margin_of_error = $7, published_at = $8, source_url = $9
+		WHERE id = $1
+	`, survey.ID, survey.PositionID, survey.SurveyFirm, survey.ConductedStart, survey.ConductedEnd,
+		survey.SampleSize, survey.MarginOfError, survey.PublishedAt, survey.SourceURL)
+	if err != nil {
+		return fmt.Errorf("failed to update survey: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("survey not found")
+	}
+
+	if results != nil {
+		if _, err := tx.Exec(ctx, `DELETE FROM survey_data WHERE survey_id = $1`, survey.ID); err != nil {
+			return fmt.Errorf("failed to clear survey results: %w", err)
+		}
+		if err := insertSurveyResults(ctx, tx, survey.ID, results); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func insertSurveyResults(ctx context.Context, tx pgx.Tx, surveyID uuid.UUID, results []models.SurveyResultInput) error {
+	candidateIDs := make([]uuid.UUID, len(results))
+	percentages := make([]float64, len(results))
+	ranks := make([]*int, len(results))
+	for i, res := range results {
+		candidateIDs[i] = res.CandidateID
+		percentages[i] = res.Percentage
+		ranks[i] = res.Rank
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO survey_data (survey_id, candidate_id, percentage, rank)
+		SELECT $1, d.candidate_id, d.percentage, d.rank
+		FROM unnest($2::uuid[], $3::numeric[], $4::int[]) AS d(candidate_id, percentage, rank)
+	`, surveyID, candidateIDs, percentages, ranks)
+	if err != nil {
+		return fmt.Errorf("failed to save survey results: %w", err)
+	}
+	return nil
+}
+
+// DeleteSurvey removes a survey of the election along with its results
+func (r *ElectionRepository) DeleteSurvey(ctx context.Context, electionID, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM election_surveys WHERE id = $1 AND election_id = $2`, id, electionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete survey: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("survey not found")
+	}
+	return nil
+}
+
+// GetSurvey returns a survey with its results, or nil if there is none
+func (r *ElectionRepository) GetSurvey(ctx context.Context, id uuid.UUID) (*models.ElectionSurvey, error) {
+	survey := &models.ElectionSurvey{}
+	err := scanSurvey(r.db.QueryRow(ctx, `
+		SELECT `+surveyColumns+`
+		FROM election_surveys s
+		JOIN election_positions ep ON s.position_id = ep.id
+		JOIN government_positions gp ON ep.position_id = gp.id
+		WHERE s.id = $1
+	`, id), survey)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get survey: %w", err)
+	}
+
+	surveys := []models.ElectionSurvey{*survey}
+	if err := r.attachSurveyResults(ctx, surveys); err != nil {
+		return nil, err
+	}
+	return &surveys[0], nil
+}
+
+// ListSurveys returns an election's surveys with their results, newest
+// fieldwork first
+func (r *ElectionRepository) ListSurveys(ctx context.Context, electionID uuid.UUID) ([]models.ElectionSurvey, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+surveyColumns+`
+		FROM election_surveys s
+		JOIN election_positions ep ON s.position_id = ep.id
+		JOIN government_positions gp ON ep.position_id = gp.id
+		WHERE s.election_id = $1
+		ORDER BY s.conducted_end DESC, s.survey_firm
+	`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list surveys: %w", err)
+	}
+	defer rows.Close()
+
+	surveys := []models.ElectionSurvey{}
+	for rows.Next() {
+		var survey models.ElectionSurvey
+		if err := scanSurvey(rows, &survey); err != nil {
+			return nil, fmt.Errorf("failed to scan survey: %w", err)
+		}
+		surveys = append(surveys, survey)
+	}
+	rows.Close()
+
+	if err := r.attachSurveyResults(ctx, surveys); err != nil {
+		return nil, err
+	}
+	return surveys, nil
+}
+
+// attachSurveyResults loads the results of all the surveys in one query,
+// each survey's ordered by rank
+func (r *ElectionRepository) attachSurveyResults(ctx context.Context, surveys []models.ElectionSurvey) error {
+	if len(surveys) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(surveys))
+	index := make(map[uuid.UUID]int, len(surveys))
+	for i := range surveys {
+		ids[i] = surveys[i].ID
+		index[surveys[i].ID] = i
+		surveys[i].Results = []models.SurveyResult{}
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT sd.id, sd.survey_id, sd.candidate_id, sd.percentage, sd.rank,
+		       COALESCE(c.ballot_name, p.name), p.slug, pp.name, pp.color
+		FROM survey_data sd
+		JOIN candidates c ON sd.candidate_id = c.id
+		JOIN politicians p ON c.politician_id = p.id
+		LEFT JOIN political_parties pp ON c.party_id = pp.id
+		WHERE sd.survey_id = ANY($1)
+		ORDER BY sd.rank NULLS LAST, sd.percentage DESC
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get survey results: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var res models.SurveyResult
+		if err := rows.Scan(
+			&res.ID, &res.SurveyID, &res.CandidateID, &res.Percentage, &res.Rank,
+			&res.CandidateName, &res.PoliticianSlug, &res.PartyName, &res.PartyColor,
+		); err != nil {
+			return fmt.Errorf("failed to scan survey result: %w", err)
+		}
+		i := index[res.SurveyID]
+		surveys[i].Results = append(surveys[i].Results, res)
+	}
+
+	for i := range surveys {
+		surveys[i].ApplyMarginOfError()
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// embedTopDomains is how many embedding domains are listed per item in reach
+// reports
+const embedTopDomains = 5
+
+type EmbedRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewEmbedRepository(db *pgxpool.Pool) *EmbedRepository {
+	return &EmbedRepository{db: db}
+}
+
+// AddViewCounts adds batched embed views to the daily tallies. Views of
+// polls or elections deleted since they were counted are skipped.
+func (r *EmbedRepository) AddViewCounts(ctx context.Context, views []models.EmbedViews) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	types := make([]string, len(views))
+	ids := make([]uuid.UUID, len(views))
+	dates := make([]string, len(views))
+	domains := make([]string, len(views))
+	counts := make([]int64, len(views))
+	for i, v := range views {
+		types[i] = v.Type
+		ids[i] = v.ContentID
+		dates[i] = v.Date
+		domains[i] = v.Domain
+		counts[i] = v.Views
+	}
+
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO embed_views (content_type, content_id, view_date, referrer_domain, view_count)
+		SELECT d.content_type, d.content_id, d.view_date::date, d.referrer_domain, d.views
+		FROM unnest($1::text[], $2::uuid[], $3::text[], $4::text[], $5::bigint[])
+		     AS d(content_type, content_id, view_date, referrer_domain, views)
+		WHERE (d.content_type = 'poll' AND EXISTS (SELECT 1 FROM polls p WHERE p.id = d.content_id))
+		   OR (d.content_type = 'election' AND EXISTS (SELECT 1 FROM elections e WHERE e.id = d.content_id))
+		ON CONFLICT (content_type, content_id, view_date, referrer_domain)
+		DO UPDATE SET view_count = embed_views.view_count + EXCLUDED.view_count
+	`, types, ids, dates, domains, counts)
+	if err != nil {
+		return fmt.Errorf("failed to add embed view counts: %w", err)
+	}
+	return nil
+}
+
+// GetReach lists the most viewed embedded items since the given date, each
+// with the domains embedding it most
+func (r *EmbedRepository) GetReach(ctx context.Context, since time.Time, limit int) ([]models.EmbedReach, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH totals AS (
+			SELECT content_type, content_id, SUM(view_count)::bigint AS views
+			FROM embed_views
+			WHERE view_date >= $1::date
+			GROUP BY content_type, content_id
+			ORDER BY views DESC
+			LIMIT $2
+		)
+		SELECT t.content_type, t.content_id, COALESCE(p.slug, e.slug, ''), COALESCE(p.title, e.name, ''), t.views
+		FROM totals t
+		LEFT JOIN polls p ON t.content_type = 'poll' AND p.id = t.content_id
+		LEFT JOIN elections e ON t.content_type = 'election' AND e.id = t.content_id
+		ORDER BY t.views DESC, t.content_id
+	`, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embed reach: %w", err)
+	}
+
+	reach := []models.EmbedReach{}
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		item := models.EmbedReach{TopDomains: []models.EmbedDomainViews{}}
+		if err := rows.Scan(&item.Type, &item.ContentID, &item.Slug, &item.Title, &item.Views); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan embed reach: %w", err)
+		}
+		index[item.ContentID] = len(reach)
+		reach = append(reach, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get embed reach: %w", err)
+	}
+	if len(reach) == 0 {
+		return reach, nil
+	}
+
+	ids := make([]uuid.UUID, len(reach))
+	for i, item := range reach {
+		ids[i] = item.ContentID
+	}
+
+	rows, err = r.db.Query(ctx, `
+		SELECT content_id, referrer_domain, views
+		FROM (
+			SELECT content_id, referrer_domain, SUM(view_count)::bigint AS views,
+			       ROW_NUMBER() OVER (PARTITION BY content_id ORDER BY SUM(view_count) DESC, referrer_domain) AS rank
+			FROM embed_views
+			WHERE view_date >= $1::date AND content_id = ANY($2)
+			GROUP BY content_id, referrer_domain
+		) ranked
+		WHERE rank <= $3
+		ORDER BY content_id, rank
+	`, since, ids, embedTopDomains)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embed domains: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var domain models.EmbedDomainViews
+		if err := rows.Scan(&id, &domain.Domain, &domain.Views); err != nil {
+			return nil, fmt.Errorf("failed to scan embed domain: %w", err)
+		}
+		if i, ok := index[id]; ok {
+			reach[i].TopDomains = append(reach[i].TopDomains, domain)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get embed domains: %w", err)
+	}
+
+	return reach, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// focalColumns splits a focal point into its two nullable columns
+func focalColumns(focal *models.FocalPoint) (x, y *float64) {
+	if focal == nil {
+		return nil, nil
+	}
+	return &focal.X, &focal.Y
+}
+
+// SetPhoto replaces the politician's photo and its variants, logging the
+// change to politician_history. changedBy is the editor, if known.
+func (r *PoliticianRepository) SetPhoto(ctx context.Context, id uuid.UUID, img *models.EntityImage, changedBy *uuid.UUID) error {
+	focalX, focalY := focalColumns(img.FocalPoint)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := lockHistoryFields(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return fmt.Errorf("politician not found")
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE politicians
+		SET photo = $2, photo_thumb = $3, photo_list = $4, photo_focal_x = $5, photo_focal_y = $6, updated_at = NOW()
+		WHERE id = $1
+	`, id, img.Original, img.Thumb, img.List, focalX, focalY)
+	if err != nil {
+		return fmt.Errorf("failed to set politician photo: %w", err)
+	}
+
+	after := *before
+	after.Photo = &img.Original
+	if err := recordHistory(ctx, tx, before, &after, changedBy); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SetLogo replaces the party's logo and its variants
+func (r *PoliticalPartyRepository) SetLogo(ctx context.Context, id uuid.UUID, img *models.EntityImage) error {
+	focalX, focalY := focalColumns(img.FocalPoint)
+
+	result, err := r.db.Exec(ctx, `
+		UPDATE political_parties
+		SET logo = $2, logo_thumb = $3, logo_list = $4, logo_focal_x = $5, logo_focal_y = $6
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, img.Original, img.Thumb, img.List, focalX, focalY)
+	if err != nil {
+		return fmt.Errorf("failed to set party logo: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("party not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExplainerRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewExplainerRepository(db *pgxpool.Pool) *ExplainerRepository {
+	return &ExplainerRepository{db: db}
+}
+
+func (r *ExplainerRepository) Create(ctx context.Context, e *models.Explainer) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO explainers (title, slug, body)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`, e.Title, e.Slug, e.Body).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create explainer: %w", err)
+	}
+	return nil
+}
+
+func (r *ExplainerRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Explainer, error) {
+	return r.get(ctx, "id = $1", id)
+}
+
+func (r *ExplainerRepository) GetBySlug(ctx context.Context, slug string) (*models.Explainer, error) {
+	return r.get(ctx, "slug = $1", slug)
+}
+
+func (r *ExplainerRepository) get(ctx context.Context, where string, arg interface{}) (*models.Explainer, error) {
+	e := &models.Explainer{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, title, slug, body, created_at, updated_at
+		FROM explainers
+		WHERE `+where, arg).Scan(&e.ID, &e.Title, &e.Slug, &e.Body, &e.CreatedAt, &e.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get explainer: %w", err)
+	}
+	return e, nil
+}
+
+// List returns every explainer by title, with how many live articles embed it
+func (r *ExplainerRepository) List(ctx context.Context, search string) ([]models.Explainer, error) {
+	where, args := "", []interface{}{}
+	if search != "" {
+		where = "WHERE e.title ILIKE $1 OR e.slug ILIKE $1"
+		args = append(args, "%"+search+"%")
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT e.id, e.title, e.slug, e.body, e.created_at, e.updated_at,
+		       (SELECT COUNT(*) FROM article_explainers ae
+		        JOIN articles a ON a.id = ae.article_id AND a.deleted_at IS NULL
+		        WHERE ae.explainer_slug = e.slug)
+		FROM explainers e
+		`+where+`
+		ORDER BY e.title, e.id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list explainers: %w", err)
+	}
+	defer rows.Close()
+
+	explainers := []models.Explainer{}
+	for rows.Next() {
+		var e models.Explainer
+		var count int
+		if err := rows.Scan(&e.ID, &e.Title, &e.Slug, &e.Body, &e.CreatedAt, &e.UpdatedAt, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan explainer: %w", err)
+		}
+		e.ArticleCount = &count
+		explainers = append(explainers, e)
+	}
+
+	return explainers, rows.Err()
+}
+
+func (r *ExplainerRepository) Update(ctx context.Context, id uuid.UUID, req *models.UpdateExplainerRequest) error {
+	setClauses := []string{}
+	args := []interface{}{id}
+
+	if req.Title != nil {
+		args = append(args, *req.Title)
+		setClauses = append(setClauses, fmt.Sprintf("title = $%d", len(args)))
+	}
+	if req.Slug != nil {
+		args = append(args, *req.Slug)
+		setClauses = append(setClauses, fmt.Sprintf("slug = $%d", len(args)))
+	}
+	if req.Body != nil {
+		args = append(args, *req.Body)
+		setClauses = append(setClauses, fmt.Sprintf("body = $%d", len(args)))
+	}
+	if len(setClauses) == 0 {
+		return nil
+	}
+
+	_, err := r.db.Exec(ctx, fmt.Sprintf("UPDATE explainers SET %s WHERE id = $1", strings.Join(setClauses, ", ")), args...)
+	if err != nil {
+		return fmt.Errorf("failed to update explainer: %w", err)
+	}
+	return nil
+}
+
+func (r *ExplainerRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.Exec(ctx, "DELETE FROM explainers WHERE id = $1", id); err != nil {
+		return fmt.Errorf("failed to delete explainer: %w", err)
+	}
+	return nil
+}
+
+// ListEmbeddingArticles returns the articles whose content embeds any of the
+// slugs, deleted ones included since their cached copies may linger
+func (r *ExplainerRepository) ListEmbeddingArticles(ctx context.Context, slugs []string) ([]models.ArticleTitle, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT a.id, a.slug, a.title
+		FROM article_explainers ae
+		JOIN articles a ON a.id = ae.article_id
+		WHERE ae.explainer_slug = ANY($1)
+	`, slugs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embedding articles: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []models.ArticleTitle{}
+	for rows.Next() {
+		var a models.ArticleTitle
+		if err := rows.Scan(&a.ID, &a.Slug, &a.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		articles = append(articles, a)
+	}
+
+	return articles, rows.Err()
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// Transcript text is left out of lists; it is only read by GetFloorDeliberation
+const floorDeliberationColumns = `
+	d.id, d.bill_id, d.session_date, d.chamber, d.transcript_url, d.transcript_text IS NOT NULL,
+	d.is_third_reading, d.created_at, d.updated_at
+`
+
+// deliberationSearchLimit caps the matches returned for a single bill
+const deliberationSearchLimit = 50
+
+// Private-use characters mark matches in ts_headline output so the excerpt can
+// be HTML-escaped before the markers are swapped for <mark> tags
+const (
+	snippetStartSel = "\ue000"
+	snippetStopSel  = "\ue001"
+)
+
+var snippetOptions = fmt.Sprintf(
+	`StartSel="%s", StopSel="%s", MaxWords=35, MinWords=15, MaxFragments=3, FragmentDelimiter=" … "`,
+	snippetStartSel, snippetStopSel,
+)
+
+func scanFloorDeliberation(row pgx.Row, extra ...any) (*models.FloorDeliberation, error) {
+	d := &models.FloorDeliberation{}
+	dest := append([]any{
+		&d.ID, &d.BillID, &d.SessionDate, &d.Chamber, &d.TranscriptURL, &d.HasTranscriptText,
+		&d.IsThirdReading, &d.CreatedAt, &d.UpdatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ListFloorDeliberations returns a bill's deliberations in session order,
+// without transcript text
+func (r *BillRepository) ListFloorDeliberations(ctx context.Context, billID uuid.UUID) ([]models.FloorDeliberation, error) {
+	query := `
+		SELECT ` + floorDeliberationColumns + `
+		FROM floor_deliberations d
+		WHERE d.bill_id = $1
+		ORDER BY d.session_date, d.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, billID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list floor deliberations: %w", err)
+	}
+	defer rows.Close()
+
+	deliberations := []models.FloorDeliberation{}
+	for rows.Next() {
+		d, err := scanFloorDeliberation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan floor deliberation: %w", err)
+		}
+		deliberations = append(deliberations, *d)
+	}
+
+	return deliberations, nil
+}
+
+// GetFloorDeliberation returns a deliberation including its transcript text
+func (r *BillRepository) GetFloorDeliberation(ctx context.Context, id uuid.UUID) (*models.FloorDeliberation, error) {
+	query := `
+		SELECT ` + floorDeliberationColumns + `, d.transcript_text
+		FROM floor_deliberations d
+		WHERE d.id = $1
+	`
+
+	var text *string
+	d, err := scanFloorDeliberation(r.db.QueryRow(ctx, query, id), &text)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor deliberation: %w", err)
+	}
+	d.TranscriptText = text
+
+	return d, nil
+}
+
+func (r *BillRepository) CreateFloorDeliberation(ctx context.Context, billID uuid.UUID, req *models.CreateFloorDeliberationRequest) (uuid.UUID, error) {
+	// Selecting from bills rejects missing or deleted bills without a FK error
+	query := `
+		INSERT INTO floor_deliberations (bill_id, session_date, chamber, transcript_text, transcript_url, is_third_reading)
+		SELECT b.id, $2::date, $3::legislative_chamber, NULLIF($4, ''), NULLIF($5, ''), $6
+		FROM bills b
+		WHERE b.id = $1 AND b.deleted_at IS NULL
+		RETURNING id
+	`
+
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, query,
+		billID, req.SessionDate, req.Chamber, req.TranscriptText, req.TranscriptURL, req.IsThirdReading,
+	).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return uuid.Nil, fmt.Errorf("bill not found")
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create floor deliberation: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateFloorDeliberation applies a partial update. An empty transcript text
+// or URL clears that field.
+func (r *BillRepository) UpdateFloorDeliberation(ctx context.Context, id uuid.UUID, req *models.UpdateFloorDeliberationRequest) error {
+	query := `
+		UPDATE floor_deliberations
+		SET session_date = COALESCE($1::date, session_date),
+			chamber = COALESCE($2::legislative_chamber, chamber),
+			transcript_text = CASE WHEN $3::text IS NULL THEN transcript_text ELSE NULLIF($3, '') END,
+			transcript_url = CASE WHEN $4::text IS NULL THEN transcript_url ELSE NULLIF($4, '') END,
+			is_third_reading = COALESCE($5, is_third_reading),
+			updated_at = NOW()
+		WHERE id = $6
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		req.SessionDate, req.Chamber, req.TranscriptText, req.TranscriptURL, req.IsThirdReading, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update floor deliberation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("floor deliberation not found")
+	}
+
+	return nil
+}
+
+func (r *BillRepository) DeleteFloorDeliberation(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM floor_deliberations WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete floor deliberation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("floor deliberation not found")
+	}
+
+	return nil
+}
+
+// SearchFloorDeliberations full-text searches a bill's transcripts, best
+// matches first
+func (r *BillRepository) SearchFloorDeliberations(ctx context.Context, billID uuid.UUID, q string) ([]models.DeliberationSearchResult, error) {
+	// The tsvector expression must match idx_floor_deliberations_transcript_search
+	query := `
+		SELECT d.id, d.session_date, d.chamber, d.is_third_reading,
+		       ts_headline('english', d.transcript_text, query, $3),
+		       ts_rank(to_tsvector('english', COALESCE(d.transcript_text, '')), query) AS rank
+		FROM floor_deliberations d, plainto_tsquery('english', $2) query
+		WHERE d.bill_id = $1
+		  AND to_tsvector('english', COALESCE(d.transcript_text, '')) @@ query
+		ORDER BY rank DESC, d.session_date
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(ctx, query, billID, q, snippetOptions, deliberationSearchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search floor deliberations: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.DeliberationSearchResult{}
+	for rows.Next() {
+		var result models.DeliberationSearchResult
+		var headline string
+		if err := rows.Scan(
+			&result.ID, &result.SessionDate, &result.Chamber, &result.IsThirdReading, &headline, &result.Rank,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan floor deliberation: %w", err)
+		}
+		result.Snippet = highlightSnippet(headline)
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// highlightSnippet escapes a ts_headline excerpt and turns its match markers
+// into <mark> tags, so transcripts can't inject markup
+func highlightSnippet(headline string) string {
+	escaped := html.EscapeString(headline)
+	return strings.NewReplacer(snippetStartSel, "<mark>", snippetStopSel, "</mark>").Replace(escaped)
+}
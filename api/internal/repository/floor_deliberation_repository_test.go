@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightSnippet(t *testing.T) {
+	t.Run("wraps matches in mark tags", func(t *testing.T) {
+		headline := "the " + snippetStartSel + "franchise" + snippetStopSel + " was extended"
+		assert.Equal(t, "the <mark>franchise</mark> was extended", highlightSnippet(headline))
+	})
+
+	t.Run("escapes transcript markup", func(t *testing.T) {
+		headline := `<script>alert(1)</script> & ` + snippetStartSel + "tax" + snippetStopSel
+		assert.Equal(t, "&lt;script&gt;alert(1)&lt;/script&gt; &amp; <mark>tax</mark>", highlightSnippet(headline))
+	})
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type FollowRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewFollowRepository(db *pgxpool.Pool) *FollowRepository {
+	return &FollowRepository{db: db}
+}
+
+// followableEntities finds a followable entity of each type by id. Internal
+// categories are hidden from the public, so can't be followed.
+var followableEntities = map[string]string{
+	models.FollowEntityCategory:   "SELECT 1 FROM categories WHERE id = $1 AND deleted_at IS NULL AND NOT is_internal",
+	models.FollowEntityTag:        "SELECT 1 FROM tags WHERE id = $1 AND deleted_at IS NULL",
+	models.FollowEntityPolitician: "SELECT 1 FROM politicians WHERE id = $1 AND deleted_at IS NULL",
+	models.FollowEntityAuthor:     "SELECT 1 FROM authors WHERE id = $1 AND deleted_at IS NULL",
+}
+
+// EntityExists reports whether the entity can be followed: it exists and has
+// not been deleted
+func (r *FollowRepository) EntityExists(ctx context.Context, entityType string, entityID uuid.UUID) (bool, error) {
+	find, ok := followableEntities[entityType]
+	if !ok {
+		return false, nil
+	}
+
+	var exists bool
+	query := "SELECT EXISTS (" + find + ")"
+	if err := r.db.QueryRow(ctx, query, entityID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", entityType, err)
+	}
+	return exists, nil
+}
+
+// Follow adds a follow; following twice is a no-op
+func (r *FollowRepository) Follow(ctx context.Context, userID uuid.UUID, entityType string, entityID uuid.UUID) error {
+	query := `
+		INSERT INTO user_follows (user_id, entity_type, entity_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, entity_type, entity_id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, entityType, entityID); err != nil {
+		return fmt.Errorf("failed to follow %s: %w", entityType, err)
+	}
+
+	return nil
+}
+
+func (r *FollowRepository) Unfollow(ctx context.Context, userID uuid.UUID, entityType string, entityID uuid.UUID) error {
+	query := "DELETE FROM user_follows WHERE user_id = $1 AND entity_type = $2 AND entity_id = $3"
+
+	if _, err := r.db.Exec(ctx, query, userID, entityType, entityID); err != nil {
+		return fmt.Errorf("failed to unfollow %s: %w", entityType, err)
+	}
+
+	return nil
+}
+
+// GetFollowStatus returns the follower count and whether userID follows the entity
+func (r *FollowRepository) GetFollowStatus(ctx context.Context, entityType string, entityID, userID uuid.UUID) (*models.FollowStatus, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(BOOL_OR(user_id = $3), false)
+		FROM user_follows
+		WHERE entity_type = $1 AND entity_id = $2
+	`
+
+	status := &models.FollowStatus{}
+	if err := r.db.QueryRow(ctx, query, entityType, entityID, userID).Scan(&status.FollowerCount, &status.IsFollowing); err != nil {
+		return nil, fmt.Errorf("failed to get %s follow status: %w", entityType, err)
+	}
+
+	return status, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
@@ -87,9 +90,14 @@ func (r *LocationRepository) GetRegionBySlug(ctx context.Context, slug string) (
 
 func (r *LocationRepository) ListRegions(ctx context.Context) ([]models.RegionListItem, error) {
 	query := `
-		SELECT r.id, r.code, r.name, r.slug,
-			(SELECT COUNT(*) FROM provinces p WHERE p.region_id = r.id AND p.deleted_at IS NULL) as province_count
+		SELECT r.id, r.code, r.name, r.slug, COALESCE(pc.province_count, 0) as province_count
 		FROM regions r
+		LEFT JOIN (
+			SELECT region_id, COUNT(*) as province_count
+			FROM provinces
+			WHERE deleted_at IS NULL
+			GROUP BY region_id
+		) pc ON pc.region_id = r.id
 		WHERE r.deleted_at IS NULL
 		ORDER BY r.name ASC
 	`
@@ -121,15 +129,16 @@ func (r *LocationRepository) UpdateRegion(ctx context.Context, id uuid.UUID, req
 			slug = COALESCE($3, slug),
 			updated_at = NOW()
 		WHERE id = $4 AND deleted_at IS NULL
+			AND ($5::timestamp IS NULL OR updated_at = $5)
 	`
 
-	result, err := r.db.Exec(ctx, query, req.Code, req.Name, req.Slug, id)
+	result, err := r.db.Exec(ctx, query, req.Code, req.Name, req.Slug, id, utcTime(req.ExpectedUpdatedAt))
 	if err != nil {
 		return fmt.Errorf("failed to update region: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("region not found")
+		return r.updateMissed(ctx, "regions", id, "region not found")
 	}
 
 	return nil
@@ -254,9 +263,15 @@ func (r *LocationRepository) ListProvincesByRegion(ctx context.Context, regionID
 func (r *LocationRepository) ListAllProvinces(ctx context.Context) ([]models.ProvinceListItem, error) {
 	query := `
 		SELECT p.id, p.region_id, p.code, p.name, p.slug, r.name as region_name,
-			(SELECT COUNT(*) FROM cities_municipalities c WHERE c.province_id = p.id AND c.deleted_at IS NULL) as city_count
+			COALESCE(cc.city_count, 0) as city_count
 		FROM provinces p
 		LEFT JOIN regions r ON p.region_id = r.id
+		LEFT JOIN (
+			SELECT province_id, COUNT(*) as city_count
+			FROM cities_municipalities
+			WHERE deleted_at IS NULL
+			GROUP BY province_id
+		) cc ON cc.province_id = p.id
 		WHERE p.deleted_at IS NULL
 		ORDER BY r.name ASC, p.name ASC
 	`
@@ -298,15 +313,16 @@ func (r *LocationRepository) UpdateProvince(ctx context.Context, id uuid.UUID, r
 			slug = COALESCE($4, slug),
 			updated_at = NOW()
 		WHERE id = $5 AND deleted_at IS NULL
+			AND ($6::timestamp IS NULL OR updated_at = $6)
 	`
 
-	result, err := r.db.Exec(ctx, query, regionID, req.Code, req.Name, req.Slug, id)
+	result, err := r.db.Exec(ctx, query, regionID, req.Code, req.Name, req.Slug, id, utcTime(req.ExpectedUpdatedAt))
 	if err != nil {
 		return fmt.Errorf("failed to update province: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("province not found")
+		return r.updateMissed(ctx, "provinces", id, "province not found")
 	}
 
 	return nil
@@ -333,14 +349,14 @@ func (r *LocationRepository) DeleteProvince(ctx context.Context, id uuid.UUID) e
 
 func (r *LocationRepository) CreateCityMunicipality(ctx context.Context, city *models.CityMunicipality) error {
 	query := `
-		INSERT INTO cities_municipalities (province_id, code, name, slug, is_city, is_capital, is_huc, is_icc, population)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO cities_municipalities (province_id, code, name, slug, is_city, is_capital, is_huc, is_icc, population, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id, created_at, updated_at
 	`
 
 	err := r.db.QueryRow(ctx, query,
 		city.ProvinceID, city.Code, city.Name, city.Slug,
-		city.IsCity, city.IsCapital, city.IsHUC, city.IsICC, city.Population,
+		city.IsCity, city.IsCapital, city.IsHUC, city.IsICC, city.Population, city.Latitude, city.Longitude,
 	).Scan(&city.ID, &city.CreatedAt, &city.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create city/municipality: %w", err)
@@ -352,7 +368,7 @@ func (r *LocationRepository) CreateCityMunicipality(ctx context.Context, city *m
 func (r *LocationRepository) GetCityMunicipalityByID(ctx context.Context, id uuid.UUID) (*models.CityMunicipality, error) {
 	query := `
 		SELECT c.id, c.province_id, c.code, c.name, c.slug, c.is_city, c.is_capital, c.is_huc, c.is_icc, c.population,
-			c.created_at, c.updated_at, c.deleted_at,
+			c.latitude, c.longitude, c.created_at, c.updated_at, c.deleted_at,
 			p.id, p.code, p.name, p.slug, p.region_id
 		FROM cities_municipalities c
 		LEFT JOIN provinces p ON c.province_id = p.id
@@ -363,7 +379,7 @@ func (r *LocationRepository) GetCityMunicipalityByID(ctx context.Context, id uui
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&city.ID, &city.ProvinceID, &city.Code, &city.Name, &city.Slug,
 		&city.IsCity, &city.IsCapital, &city.IsHUC, &city.IsICC, &city.Population,
-		&city.CreatedAt, &city.UpdatedAt, &city.DeletedAt,
+		&city.Latitude, &city.Longitude, &city.CreatedAt, &city.UpdatedAt, &city.DeletedAt,
 		&city.Province.ID, &city.Province.Code, &city.Province.Name, &city.Province.Slug, &city.Province.RegionID,
 	)
 
@@ -380,7 +396,7 @@ func (r *LocationRepository) GetCityMunicipalityByID(ctx context.Context, id uui
 func (r *LocationRepository) GetCityMunicipalityBySlug(ctx context.Context, slug string) (*models.CityMunicipality, error) {
 	query := `
 		SELECT c.id, c.province_id, c.code, c.name, c.slug, c.is_city, c.is_capital, c.is_huc, c.is_icc, c.population,
-			c.created_at, c.updated_at, c.deleted_at,
+			c.latitude, c.longitude, c.created_at, c.updated_at, c.deleted_at,
 			p.id, p.code, p.name, p.slug, p.region_id
 		FROM cities_municipalities c
 		LEFT JOIN provinces p ON c.province_id = p.id
@@ -391,7 +407,7 @@ func (r *LocationRepository) GetCityMunicipalityBySlug(ctx context.Context, slug
 	err := r.db.QueryRow(ctx, query, slug).Scan(
 		&city.ID, &city.ProvinceID, &city.Code, &city.Name, &city.Slug,
 		&city.IsCity, &city.IsCapital, &city.IsHUC, &city.IsICC, &city.Population,
-		&city.CreatedAt, &city.UpdatedAt, &city.DeletedAt,
+		&city.Latitude, &city.Longitude, &city.CreatedAt, &city.UpdatedAt, &city.DeletedAt,
 		&city.Province.ID, &city.Province.Code, &city.Province.Name, &city.Province.Slug, &city.Province.RegionID,
 	)
 
@@ -455,20 +471,24 @@ func (r *LocationRepository) UpdateCityMunicipality(ctx context.Context, id uuid
 			is_huc = COALESCE($7, is_huc),
 			is_icc = COALESCE($8, is_icc),
 			population = COALESCE($9, population),
+			latitude = COALESCE($10, latitude),
+			longitude = COALESCE($11, longitude),
 			updated_at = NOW()
-		WHERE id = $10 AND deleted_at IS NULL
+		WHERE id = $12 AND deleted_at IS NULL
+			AND ($13::timestamp IS NULL OR updated_at = $13)
 	`
 
 	result, err := r.db.Exec(ctx, query,
 		provinceID, req.Code, req.Name, req.Slug,
-		req.IsCity, req.IsCapital, req.IsHUC, req.IsICC, req.Population, id,
+		req.IsCity, req.IsCapital, req.IsHUC, req.IsICC, req.Population,
+		req.Latitude, req.Longitude, id, utcTime(req.ExpectedUpdatedAt),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update city/municipality: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("city/municipality not found")
+		return r.updateMissed(ctx, "cities_municipalities", id, "city/municipality not found")
 	}
 
 	return nil
@@ -760,43 +780,151 @@ func (r *LocationRepository) ListDistrictsByProvince(ctx context.Context, provin
 	return districts, nil
 }
 
+func (r *LocationRepository) UpdateDistrict(ctx context.Context, id uuid.UUID, req *models.UpdateDistrictRequest) error {
+	var provinceID, cityID *uuid.UUID
+	if req.ProvinceID != nil {
+		parsed, err := uuid.Parse(*req.ProvinceID)
+		if err != nil {
+			return fmt.Errorf("invalid province_id: %w", err)
+		}
+		provinceID = &parsed
+	}
+	if req.CityMunicipalityID != nil {
+		parsed, err := uuid.Parse(*req.CityMunicipalityID)
+		if err != nil {
+			return fmt.Errorf("invalid city_municipality_id: %w", err)
+		}
+		cityID = &parsed
+	}
+
+	query := `
+		UPDATE congressional_districts
+		SET province_id = COALESCE($1, province_id),
+			city_municipality_id = COALESCE($2, city_municipality_id),
+			district_number = COALESCE($3, district_number),
+			name = COALESCE($4, name),
+			slug = COALESCE($5, slug),
+			updated_at = NOW()
+		WHERE id = $6 AND deleted_at IS NULL
+			AND ($7::timestamp IS NULL OR updated_at = $7)
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		provinceID, cityID, req.DistrictNumber, req.Name, req.Slug, id, utcTime(req.ExpectedUpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update district: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return r.updateMissed(ctx, "congressional_districts", id, "district not found")
+	}
+
+	return nil
+}
+
+// updateMissed explains why a location update touched no rows: either the
+// record is gone, or its updated_at no longer matches the caller's
+// expected_updated_at because someone else changed it first
+func (r *LocationRepository) updateMissed(ctx context.Context, table string, id uuid.UUID, notFound string) error {
+	var current time.Time
+	err := r.db.QueryRow(ctx,
+		"SELECT updated_at FROM "+table+" WHERE id = $1 AND deleted_at IS NULL", id,
+	).Scan(&current)
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("%s", notFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check %s: %w", table, err)
+	}
+
+	return &models.ConcurrentUpdateError{CurrentUpdatedAt: current}
+}
+
+// utcTime converts t to UTC. updated_at is a TIMESTAMP without time zone
+// holding UTC, and pgx sends a time's wall clock as is, so a value given in
+// another zone would never match.
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
 // =====================================================
 // SEARCH & HIERARCHY
 // =====================================================
 
+// locationFuzzyMinLength is the shortest query matched by trigram
+// similarity; shorter queries share too few trigrams with any name, so they
+// are matched as a prefix instead
+const locationFuzzyMinLength = 3
+
+// locationSearchLevels are the levels SearchLocations looks through, each
+// with the table of the parent named for context
+var locationSearchLevels = []struct {
+	typ, table, parentTable, parentKey string
+}{
+	{"region", "regions", "", ""},
+	{"province", "provinces", "regions", "region_id"},
+	{"city", "cities_municipalities", "provinces", "province_id"},
+	{"barangay", "barangays", "cities_municipalities", "city_municipality_id"},
+}
+
+// SearchLocations finds locations at every level whose name resembles the
+// query, best match first. Names within pg_trgm's similarity threshold (0.3
+// by default), or containing a word that close to the query, count as
+// matches, so typos like "Cebuu" still find Cebu. Each result is scored
+// from 0 to 1; ties go to the higher level.
 func (r *LocationRepository) SearchLocations(ctx context.Context, query string, limit int) ([]models.LocationSearchResult, error) {
 	if limit <= 0 {
 		limit = 20
 	}
 
-	searchPattern := "%" + strings.ToLower(query) + "%"
-
-	sqlQuery := `
-		(SELECT 'region' as type, id, code, name, slug, '' as parent_name, name as full_path
-		 FROM regions WHERE LOWER(name) LIKE $1 AND deleted_at IS NULL LIMIT $2)
-		UNION ALL
-		(SELECT 'province' as type, p.id, p.code, p.name, p.slug, r.name as parent_name,
-		        p.name || ', ' || r.name as full_path
-		 FROM provinces p
-		 LEFT JOIN regions r ON p.region_id = r.id
-		 WHERE LOWER(p.name) LIKE $1 AND p.deleted_at IS NULL LIMIT $2)
-		UNION ALL
-		(SELECT 'city' as type, c.id, c.code, c.name, c.slug, p.name as parent_name,
-		        c.name || ', ' || p.name as full_path
-		 FROM cities_municipalities c
-		 LEFT JOIN provinces p ON c.province_id = p.id
-		 WHERE LOWER(c.name) LIKE $1 AND c.deleted_at IS NULL LIMIT $2)
-		UNION ALL
-		(SELECT 'barangay' as type, b.id, b.code, b.name, b.slug, c.name as parent_name,
-		        b.name || ', ' || c.name as full_path
-		 FROM barangays b
-		 LEFT JOIN cities_municipalities c ON b.city_municipality_id = c.id
-		 WHERE LOWER(b.name) LIKE $1 AND b.deleted_at IS NULL LIMIT $2)
-		ORDER BY type, name
+	term := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	if term == "" {
+		return []models.LocationSearchResult{}, nil
+	}
+
+	match := `l.name_lower % $1 OR $1 <% l.name_lower OR strpos(l.name_lower, $1) > 0`
+	score := `GREATEST(
+		similarity(l.name_lower, $1),
+		word_similarity($1, l.name_lower),
+		CASE
+			WHEN l.name_lower = $1 THEN 1
+			WHEN starts_with(l.name_lower, $1) THEN 0.9
+			WHEN strpos(l.name_lower, $1) > 0 THEN 0.7
+			ELSE 0
+		END
+	)`
+	if utf8.RuneCountInString(term) < locationFuzzyMinLength {
+		match = `starts_with(l.name_lower, $1)`
+		score = `CASE WHEN l.name_lower = $1 THEN 1 ELSE 0.5 END`
+	}
+
+	subqueries := make([]string, len(locationSearchLevels))
+	for i, level := range locationSearchLevels {
+		parentName, parentJoin := "''", ""
+		if level.parentTable != "" {
+			parentName = "COALESCE(p.name, '')"
+			parentJoin = fmt.Sprintf("LEFT JOIN %s p ON l.%s = p.id", level.parentTable, level.parentKey)
+		}
+		subqueries[i] = fmt.Sprintf(`
+		(SELECT '%s' AS type, %d AS level, l.id, l.code, l.name, l.slug, %s AS parent_name, %s AS score
+		 FROM (SELECT *, lower(name) AS name_lower FROM %s WHERE deleted_at IS NULL) l
+		 %s
+		 WHERE %s
+		 ORDER BY score DESC, l.name
+		 LIMIT $2)`, level.typ, i, parentName, score, level.table, parentJoin, match)
+	}
+
+	sqlQuery := strings.Join(subqueries, "\n\t\tUNION ALL") + `
+		ORDER BY score DESC, level, name
 		LIMIT $2
 	`
 
-	rows, err := r.db.Query(ctx, sqlQuery, searchPattern, limit)
+	rows, err := r.db.Query(ctx, sqlQuery, term, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search locations: %w", err)
 	}
@@ -805,17 +933,259 @@ func (r *LocationRepository) SearchLocations(ctx context.Context, query string,
 	results := []models.LocationSearchResult{}
 	for rows.Next() {
 		var result models.LocationSearchResult
-		err := rows.Scan(&result.Type, &result.ID, &result.Code, &result.Name, &result.Slug, &result.ParentName, &result.FullPath)
+		var level int
+		err := rows.Scan(&result.Type, &level, &result.ID, &result.Code, &result.Name, &result.Slug, &result.ParentName, &result.Score)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan search result: %w", err)
 		}
+		result.FullPath = result.Name
+		if result.ParentName != "" {
+			result.FullPath += ", " + result.ParentName
+		}
 		results = append(results, result)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search locations: %w", err)
+	}
 
 	return results, nil
 }
 
+// SearchBarangays finds barangays whose name has a word starting with each
+// word of the query, or failing that contains the query, best matches first
+func (r *LocationRepository) SearchBarangays(ctx context.Context, filter *models.BarangaySearchFilter, page, perPage int) (*models.PaginatedBarangays, error) {
+	conditions := []string{"b.deleted_at IS NULL"}
+	args := []interface{}{}
+	argNum := 1
+	orderBy := "b.name ASC, c.name ASC"
+
+	if filter.Query != "" {
+		tsQuery := prefixTSQuery(filter.Query)
+		if tsQuery != "" {
+			conditions = append(conditions, fmt.Sprintf("(to_tsvector('simple', b.name) @@ to_tsquery('simple', $%d) OR b.name ILIKE $%d)", argNum, argNum+1))
+			orderBy = fmt.Sprintf("ts_rank(to_tsvector('simple', b.name), to_tsquery('simple', $%d)) DESC, ", argNum) + orderBy
+			args = append(args, tsQuery, "%"+filter.Query+"%")
+			argNum += 2
+		} else {
+			conditions = append(conditions, fmt.Sprintf("b.name ILIKE $%d", argNum))
+			args = append(args, "%"+filter.Query+"%")
+			argNum++
+		}
+	}
+	if filter.CityID != nil {
+		conditions = append(conditions, fmt.Sprintf("b.city_municipality_id = $%d", argNum))
+		args = append(args, *filter.CityID)
+		argNum++
+	}
+	if filter.MinPopulation != nil {
+		conditions = append(conditions, fmt.Sprintf("b.population >= $%d", argNum))
+		args = append(args, *filter.MinPopulation)
+		argNum++
+	}
+	if filter.MaxPopulation != nil {
+		conditions = append(conditions, fmt.Sprintf("b.population <= $%d", argNum))
+		args = append(args, *filter.MaxPopulation)
+		argNum++
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM barangays b " + whereClause
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count barangays: %w", err)
+	}
+
+	offset := (page - 1) * perPage
+	query := fmt.Sprintf(`
+		SELECT b.id, b.city_municipality_id, b.code, b.name, b.slug, b.population,
+		       c.name as city_name, COALESCE(p.name, '') as province_name
+		FROM barangays b
+		JOIN cities_municipalities c ON b.city_municipality_id = c.id
+		LEFT JOIN provinces p ON c.province_id = p.id
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, whereClause, orderBy, argNum, argNum+1)
+	args = append(args, perPage, offset)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search barangays: %w", err)
+	}
+	defer rows.Close()
+
+	barangays := []models.BarangayListItem{}
+	for rows.Next() {
+		var barangay models.BarangayListItem
+		err := rows.Scan(
+			&barangay.ID, &barangay.CityMunicipalityID, &barangay.Code, &barangay.Name, &barangay.Slug, &barangay.Population,
+			&barangay.CityMunicipalityName, &barangay.ProvinceName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan barangay: %w", err)
+		}
+		barangays = append(barangays, barangay)
+	}
+
+	totalPages := (total + perPage - 1) / perPage
+
+	return &models.PaginatedBarangays{
+		Barangays:  barangays,
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// prefixTSQuery turns a search into a tsquery matching words that start with
+// each of its words, e.g. "san jo" becomes "san:* & jo:*". It returns "" if
+// the search has no letters or digits.
+func prefixTSQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
 // GetLocationHierarchy returns the full hierarchy for a given barangay
+// GetLocationTree returns regions nested down to depth (see
+// models.LocationTreeRegions and the rest), with one query per level.
+// Entries whose parent is deleted are left out.
+func (r *LocationRepository) GetLocationTree(ctx context.Context, depth int) ([]models.LocationTreeRegion, error) {
+	regions := []models.LocationTreeRegion{}
+	var provinces []treeChild[models.LocationTreeProvince]
+	var cities []treeChild[models.LocationTreeCity]
+	var barangays []treeChild[models.LocationTreeBarangay]
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, code, name, slug FROM regions
+		WHERE deleted_at IS NULL
+		ORDER BY name ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list regions: %w", err)
+	}
+	for rows.Next() {
+		var region models.LocationTreeRegion
+		if err := rows.Scan(&region.ID, &region.Code, &region.Name, &region.Slug); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan region: %w", err)
+		}
+		regions = append(regions, region)
+	}
+	rows.Close()
+
+	if depth >= models.LocationTreeProvinces {
+		rows, err := r.db.Query(ctx, `
+			SELECT region_id, id, code, name, slug FROM provinces
+			WHERE deleted_at IS NULL
+			ORDER BY name ASC
+		`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list provinces: %w", err)
+		}
+		for rows.Next() {
+			var p treeChild[models.LocationTreeProvince]
+			if err := rows.Scan(&p.parentID, &p.node.ID, &p.node.Code, &p.node.Name, &p.node.Slug); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan province: %w", err)
+			}
+			provinces = append(provinces, p)
+		}
+		rows.Close()
+	}
+
+	if depth >= models.LocationTreeCities {
+		rows, err := r.db.Query(ctx, `
+			SELECT province_id, id, code, name, slug, is_city, is_capital, is_huc FROM cities_municipalities
+			WHERE deleted_at IS NULL
+			ORDER BY is_capital DESC, is_city DESC, name ASC
+		`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list cities: %w", err)
+		}
+		for rows.Next() {
+			var c treeChild[models.LocationTreeCity]
+			err := rows.Scan(&c.parentID, &c.node.ID, &c.node.Code, &c.node.Name, &c.node.Slug,
+				&c.node.IsCity, &c.node.IsCapital, &c.node.IsHUC)
+			if err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan city: %w", err)
+			}
+			cities = append(cities, c)
+		}
+		rows.Close()
+	}
+
+	if depth >= models.LocationTreeBarangays {
+		rows, err := r.db.Query(ctx, `
+			SELECT city_municipality_id, id, code, name, slug FROM barangays
+			WHERE deleted_at IS NULL
+			ORDER BY name ASC
+		`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list barangays: %w", err)
+		}
+		for rows.Next() {
+			var b treeChild[models.LocationTreeBarangay]
+			if err := rows.Scan(&b.parentID, &b.node.ID, &b.node.Code, &b.node.Name, &b.node.Slug); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan barangay: %w", err)
+			}
+			barangays = append(barangays, b)
+		}
+		rows.Close()
+	}
+
+	return buildLocationTree(regions, provinces, cities, barangays), nil
+}
+
+// treeChild is a location tree entry with the ID of the entry it nests under
+type treeChild[T any] struct {
+	parentID uuid.UUID
+	node     T
+}
+
+// groupByParent collects children under their parent IDs, keeping their order
+func groupByParent[T any](children []treeChild[T]) map[uuid.UUID][]T {
+	groups := make(map[uuid.UUID][]T)
+	for _, c := range children {
+		groups[c.parentID] = append(groups[c.parentID], c.node)
+	}
+	return groups
+}
+
+// buildLocationTree nests each level under its parent, bottom up so every
+// child slice is complete before it is copied into its parent
+func buildLocationTree(
+	regions []models.LocationTreeRegion,
+	provinces []treeChild[models.LocationTreeProvince],
+	cities []treeChild[models.LocationTreeCity],
+	barangays []treeChild[models.LocationTreeBarangay],
+) []models.LocationTreeRegion {
+	barangaysByCity := groupByParent(barangays)
+	for i := range cities {
+		cities[i].node.Barangays = barangaysByCity[cities[i].node.ID]
+	}
+
+	citiesByProvince := groupByParent(cities)
+	for i := range provinces {
+		provinces[i].node.Cities = citiesByProvince[provinces[i].node.ID]
+	}
+
+	provincesByRegion := groupByParent(provinces)
+	for i := range regions {
+		regions[i].Provinces = provincesByRegion[regions[i].ID]
+	}
+
+	return regions
+}
+
 func (r *LocationRepository) GetLocationHierarchy(ctx context.Context, barangayID uuid.UUID) (*models.LocationHierarchy, error) {
 	query := `
 		SELECT
@@ -856,6 +1226,47 @@ func (r *LocationRepository) GetLocationHierarchy(ctx context.Context, barangayI
 	return hierarchy, nil
 }
 
+// FindNearestCity returns the city/municipality whose town centre is closest
+// to the point, along with its province and region. Cities without
+// coordinates are skipped; returns nil if none have them.
+func (r *LocationRepository) FindNearestCity(ctx context.Context, lat, lng float64) (*models.DetectedLocation, error) {
+	query := `
+		SELECT
+			r.id, r.code, r.name, r.slug,
+			p.id, p.region_id, p.code, p.name, p.slug,
+			c.id, c.province_id, c.code, c.name, c.slug, c.is_city, c.is_capital, c.is_huc,
+			6371 * 2 * ASIN(SQRT(
+				POWER(SIN(RADIANS(c.latitude - $1) / 2), 2)
+				+ COS(RADIANS($1)) * COS(RADIANS(c.latitude)) * POWER(SIN(RADIANS(c.longitude - $2) / 2), 2)
+			)) AS distance_km
+		FROM cities_municipalities c
+		JOIN provinces p ON c.province_id = p.id AND p.deleted_at IS NULL
+		JOIN regions r ON p.region_id = r.id AND r.deleted_at IS NULL
+		WHERE c.deleted_at IS NULL AND c.latitude IS NOT NULL
+		ORDER BY distance_km
+		LIMIT 1
+	`
+
+	var loc models.DetectedLocation
+	err := r.db.QueryRow(ctx, query, lat, lng).Scan(
+		&loc.Region.ID, &loc.Region.Code, &loc.Region.Name, &loc.Region.Slug,
+		&loc.Province.ID, &loc.Province.RegionID, &loc.Province.Code, &loc.Province.Name, &loc.Province.Slug,
+		&loc.CityMunicipality.ID, &loc.CityMunicipality.ProvinceID, &loc.CityMunicipality.Code,
+		&loc.CityMunicipality.Name, &loc.CityMunicipality.Slug, &loc.CityMunicipality.IsCity,
+		&loc.CityMunicipality.IsCapital, &loc.CityMunicipality.IsHUC,
+		&loc.DistanceKm,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find nearest city: %w", err)
+	}
+
+	return &loc, nil
+}
+
 // GetRegionByCode gets a region by its PSGC code
 func (r *LocationRepository) GetRegionByCode(ctx context.Context, code string) (*models.Region, error) {
 	query := `
@@ -908,7 +1319,7 @@ func (r *LocationRepository) GetProvinceByCode(ctx context.Context, code string)
 func (r *LocationRepository) GetCityMunicipalityByCode(ctx context.Context, code string) (*models.CityMunicipality, error) {
 	query := `
 		SELECT id, province_id, code, name, slug, is_city, is_capital, is_huc, is_icc, population,
-			created_at, updated_at, deleted_at
+			latitude, longitude, created_at, updated_at, deleted_at
 		FROM cities_municipalities
 		WHERE code = $1 AND deleted_at IS NULL
 	`
@@ -917,7 +1328,7 @@ func (r *LocationRepository) GetCityMunicipalityByCode(ctx context.Context, code
 	err := r.db.QueryRow(ctx, query, code).Scan(
 		&city.ID, &city.ProvinceID, &city.Code, &city.Name, &city.Slug,
 		&city.IsCity, &city.IsCapital, &city.IsHUC, &city.IsICC, &city.Population,
-		&city.CreatedAt, &city.UpdatedAt, &city.DeletedAt,
+		&city.Latitude, &city.Longitude, &city.CreatedAt, &city.UpdatedAt, &city.DeletedAt,
 	)
 
 	if err == pgx.ErrNoRows {
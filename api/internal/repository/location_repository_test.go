@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// correlatedCounts runs the original per-row subquery form of a count query
+// so the joined version can be checked against it
+func correlatedCounts(t *testing.T, pool *pgxpool.Pool, query string) map[uuid.UUID]int {
+	t.Helper()
+
+	rows, err := pool.Query(context.Background(), query)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var id uuid.UUID
+		var count int
+		require.NoError(t, rows.Scan(&id, &count))
+		counts[id] = count
+	}
+	require.NoError(t, rows.Err())
+	return counts
+}
+
+func TestLocationRepository_ListRegionsCounts(t *testing.T) {
+	pool := openTestDB(t)
+	repo := NewLocationRepository(pool)
+
+	regions, err := repo.ListRegions(context.Background())
+	require.NoError(t, err)
+
+	expected := correlatedCounts(t, pool, `
+		SELECT r.id, (SELECT COUNT(*) FROM provinces p WHERE p.region_id = r.id AND p.deleted_at IS NULL)
+		FROM regions r WHERE r.deleted_at IS NULL
+	`)
+	require.Len(t, regions, len(expected))
+	for _, region := range regions {
+		assert.Equal(t, expected[region.ID], region.ProvinceCount, region.Name)
+	}
+}
+
+func TestLocationRepository_ListAllProvincesCounts(t *testing.T) {
+	pool := openTestDB(t)
+	repo := NewLocationRepository(pool)
+
+	provinces, err := repo.ListAllProvinces(context.Background())
+	require.NoError(t, err)
+
+	expected := correlatedCounts(t, pool, `
+		SELECT p.id, (SELECT COUNT(*) FROM cities_municipalities c WHERE c.province_id = p.id AND c.deleted_at IS NULL)
+		FROM provinces p WHERE p.deleted_at IS NULL
+	`)
+	require.Len(t, provinces, len(expected))
+	for _, province := range provinces {
+		assert.Equal(t, expected[province.ID], province.CityCount, province.Name)
+	}
+}
+
+func BenchmarkLocationRepository_ListRegions(b *testing.B) {
+	repo := NewLocationRepository(openTestDB(b))
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.ListRegions(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLocationRepository_ListAllProvinces(b *testing.B) {
+	repo := NewLocationRepository(openTestDB(b))
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.ListAllProvinces(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestPrefixTSQuery(t *testing.T) {
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{"san", "san:*"},
+		{"San Jo", "san:* & jo:*"},
+		{"  Sto. Niño ", "sto:* & niño:*"},
+		{"Barangay 176-A", "barangay:* & 176:* & a:*"},
+		{"'&|!", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, prefixTSQuery(tt.search), tt.search)
+	}
+}
+
+// createLockingFixture creates a throwaway region, province and district for
+// the optimistic locking tests. Deleting the region cascades to the rest.
+func createLockingFixture(t *testing.T, repo *LocationRepository) (*models.Region, *models.CongressionalDistrict) {
+	t.Helper()
+
+	ctx := context.Background()
+	suffix := uuid.NewString()[:8]
+
+	region := &models.Region{Code: "T" + suffix, Name: "Test Region " + suffix, Slug: "test-region-" + suffix}
+	require.NoError(t, repo.CreateRegion(ctx, region))
+	t.Cleanup(func() {
+		_, _ = repo.db.Exec(context.Background(), "DELETE FROM regions WHERE id = $1", region.ID)
+	})
+
+	province := &models.Province{RegionID: region.ID, Code: "T" + suffix, Name: "Test Province " + suffix, Slug: "test-province-" + suffix}
+	require.NoError(t, repo.CreateProvince(ctx, province))
+
+	district := &models.CongressionalDistrict{
+		ProvinceID:     &province.ID,
+		DistrictNumber: 1,
+		Name:           "1st District " + suffix,
+		Slug:           "test-district-" + suffix,
+	}
+	require.NoError(t, repo.CreateDistrict(ctx, district))
+
+	return region, district
+}
+
+func TestLocationRepository_UpdateDistrictDetectsConcurrentUpdate(t *testing.T) {
+	repo := NewLocationRepository(openTestDB(t))
+	ctx := context.Background()
+	_, district := createLockingFixture(t, repo)
+
+	// Both editors loaded the same version
+	expected := district.UpdatedAt
+	first, second := "First Edit", "Second Edit"
+
+	err := repo.UpdateDistrict(ctx, district.ID, &models.UpdateDistrictRequest{Name: &first, ExpectedUpdatedAt: &expected})
+	require.NoError(t, err)
+
+	err = repo.UpdateDistrict(ctx, district.ID, &models.UpdateDistrictRequest{Name: &second, ExpectedUpdatedAt: &expected})
+	var conflict *models.ConcurrentUpdateError
+	require.True(t, errors.As(err, &conflict), "expected a concurrent update error, got %v", err)
+
+	current, err := repo.GetDistrictByID(ctx, district.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first, current.Name)
+	assert.True(t, conflict.CurrentUpdatedAt.Equal(current.UpdatedAt))
+	assert.False(t, conflict.CurrentUpdatedAt.Equal(expected))
+
+	// Retrying against the current version goes through
+	err = repo.UpdateDistrict(ctx, district.ID, &models.UpdateDistrictRequest{Name: &second, ExpectedUpdatedAt: &conflict.CurrentUpdatedAt})
+	require.NoError(t, err)
+}
+
+func TestLocationRepository_UpdateDistrictRacingWriters(t *testing.T) {
+	repo := NewLocationRepository(openTestDB(t))
+	_, district := createLockingFixture(t, repo)
+
+	const writers = 8
+	expected := district.UpdatedAt
+
+	var wg sync.WaitGroup
+	errs := make([]error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			number := i + 2
+			errs[i] = repo.UpdateDistrict(context.Background(), district.ID, &models.UpdateDistrictRequest{
+				DistrictNumber:    &number,
+				ExpectedUpdatedAt: &expected,
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		var conflict *models.ConcurrentUpdateError
+		assert.True(t, errors.As(err, &conflict), "unexpected error: %v", err)
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func TestLocationRepository_UpdateRegionDetectsConcurrentUpdate(t *testing.T) {
+	repo := NewLocationRepository(openTestDB(t))
+	ctx := context.Background()
+	region, _ := createLockingFixture(t, repo)
+
+	// Given in another time zone, the same instant still matches
+	expected := region.UpdatedAt.In(time.FixedZone("PHT", 8*60*60))
+	first, second := "First Edit", "Second Edit"
+
+	require.NoError(t, repo.UpdateRegion(ctx, region.ID, &models.UpdateRegionRequest{Name: &first, ExpectedUpdatedAt: &expected}))
+
+	err := repo.UpdateRegion(ctx, region.ID, &models.UpdateRegionRequest{Name: &second, ExpectedUpdatedAt: &expected})
+	var conflict *models.ConcurrentUpdateError
+	assert.True(t, errors.As(err, &conflict), "expected a concurrent update error, got %v", err)
+
+	// Without expected_updated_at the last write wins, as before
+	require.NoError(t, repo.UpdateRegion(ctx, region.ID, &models.UpdateRegionRequest{Name: &second}))
+}
+
+func TestLocationRepository_UpdateMissingDistrict(t *testing.T) {
+	repo := NewLocationRepository(openTestDB(t))
+
+	expected := time.Now().UTC()
+	name := "Nowhere"
+	err := repo.UpdateDistrict(context.Background(), uuid.New(), &models.UpdateDistrictRequest{Name: &name, ExpectedUpdatedAt: &expected})
+	require.Error(t, err)
+	assert.Equal(t, "district not found", err.Error())
+}
+
+func TestBuildLocationTree(t *testing.T) {
+	ncr, car := uuid.New(), uuid.New()
+	metroManila, benguet := uuid.New(), uuid.New()
+	manila, quezonCity, baguio := uuid.New(), uuid.New(), uuid.New()
+	tondo, ermita := uuid.New(), uuid.New()
+
+	regions := []models.LocationTreeRegion{{ID: car, Name: "CAR"}, {ID: ncr, Name: "NCR"}}
+	provinces := []treeChild[models.LocationTreeProvince]{
+		{parentID: car, node: models.LocationTreeProvince{ID: benguet, Name: "Benguet"}},
+		{parentID: ncr, node: models.LocationTreeProvince{ID: metroManila, Name: "Metro Manila"}},
+		{parentID: uuid.New(), node: models.LocationTreeProvince{ID: uuid.New(), Name: "Orphan"}},
+	}
+	cities := []treeChild[models.LocationTreeCity]{
+		{parentID: benguet, node: models.LocationTreeCity{ID: baguio, Name: "Baguio"}},
+		{parentID: metroManila, node: models.LocationTreeCity{ID: manila, Name: "Manila"}},
+		{parentID: metroManila, node: models.LocationTreeCity{ID: quezonCity, Name: "Quezon City"}},
+	}
+	barangays := []treeChild[models.LocationTreeBarangay]{
+		{parentID: manila, node: models.LocationTreeBarangay{ID: ermita, Name: "Ermita"}},
+		{parentID: manila, node: models.LocationTreeBarangay{ID: tondo, Name: "Tondo"}},
+	}
+
+	tree := buildLocationTree(regions, provinces, cities, barangays)
+	require.Len(t, tree, 2)
+
+	require.Len(t, tree[0].Provinces, 1)
+	assert.Equal(t, benguet, tree[0].Provinces[0].ID)
+	require.Len(t, tree[0].Provinces[0].Cities, 1)
+	assert.Empty(t, tree[0].Provinces[0].Cities[0].Barangays)
+
+	require.Len(t, tree[1].Provinces, 1)
+	metro := tree[1].Provinces[0]
+	require.Len(t, metro.Cities, 2)
+	assert.Equal(t, manila, metro.Cities[0].ID)
+	assert.Equal(t, quezonCity, metro.Cities[1].ID)
+	require.Len(t, metro.Cities[0].Barangays, 2)
+	assert.Equal(t, ermita, metro.Cities[0].Barangays[0].ID)
+	assert.Equal(t, tondo, metro.Cities[0].Barangays[1].ID)
+}
+
+func TestLocationRepository_GetLocationTreeDepth(t *testing.T) {
+	repo := NewLocationRepository(openTestDB(t))
+	ctx := context.Background()
+
+	regions, err := repo.ListRegions(ctx)
+	require.NoError(t, err)
+
+	shallow, err := repo.GetLocationTree(ctx, models.LocationTreeRegions)
+	require.NoError(t, err)
+	require.Len(t, shallow, len(regions))
+	for _, region := range shallow {
+		assert.Empty(t, region.Provinces)
+	}
+
+	// Each region holds as many provinces as ListRegions counts
+	counts := make(map[uuid.UUID]int, len(regions))
+	for _, region := range regions {
+		counts[region.ID] = region.ProvinceCount
+	}
+	cities, err := repo.GetLocationTree(ctx, models.LocationTreeCities)
+	require.NoError(t, err)
+	require.Len(t, cities, len(regions))
+	for _, region := range cities {
+		assert.Len(t, region.Provinces, counts[region.ID], region.Name)
+		for _, province := range region.Provinces {
+			for _, city := range province.Cities {
+				assert.Empty(t, city.Barangays)
+			}
+		}
+	}
+}
+
+func TestLocationRepository_SearchLocationsMisspellings(t *testing.T) {
+	pool := openTestDB(t)
+	repo := NewLocationRepository(pool)
+	ctx := context.Background()
+
+	tests := []struct {
+		query, wantName, wantType string
+	}{
+		{"Cebuu", "Cebu", "province"},
+		{"cebu  city", "Cebu City", "city"},
+		{"Pampamga", "Pampanga", "province"},
+		{"Ilo-ilo", "Iloilo", "province"},
+		{"Davao del Sur", "Davao del Sur", "province"},
+		{"Dabao City", "Davao City", "city"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := repo.SearchLocations(ctx, tt.query, 10)
+			require.NoError(t, err)
+			if len(results) == 0 {
+				t.Skip("Skipping: seeded locations not loaded")
+			}
+
+			assert.Equal(t, tt.wantName, results[0].Name)
+			assert.Equal(t, tt.wantType, results[0].Type)
+			for i := 1; i < len(results); i++ {
+				assert.LessOrEqual(t, results[i].Score, results[i-1].Score, "results are ordered by score")
+			}
+		})
+	}
+}
+
+func TestLocationRepository_SearchLocationsShortQuery(t *testing.T) {
+	pool := openTestDB(t)
+	repo := NewLocationRepository(pool)
+
+	// Too short for trigrams, so matched as a prefix
+	results, err := repo.SearchLocations(context.Background(), "Ce", 20)
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.Regexp(t, `(?i)^ce`, result.Name)
+	}
+
+	results, err = repo.SearchLocations(context.Background(), "  ", 20)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
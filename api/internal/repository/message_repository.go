@@ -19,16 +19,16 @@ func NewMessageRepository(db *pgxpool.Pool) *MessageRepository {
 }
 
 // CreateConversation creates a new conversation
-func (r *MessageRepository) CreateConversation(ctx context.Context, userID uuid.UUID, subject *string) (*models.Conversation, error) {
+func (r *MessageRepository) CreateConversation(ctx context.Context, userID uuid.UUID, recipientID *uuid.UUID, subject *string) (*models.Conversation, error) {
 	conversation := &models.Conversation{}
 	query := `
-		INSERT INTO conversations (user_id, subject, status)
-		VALUES ($1, $2, 'open')
-		RETURNING id, user_id, subject, status, last_message_at, created_at, updated_at
+		INSERT INTO conversations (user_id, recipient_id, subject, status)
+		VALUES ($1, $2, $3, 'open')
+		RETURNING id, user_id, recipient_id, subject, status, last_message_at, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query, userID, subject).Scan(
-		&conversation.ID, &conversation.UserID, &conversation.Subject,
+	err := r.db.QueryRow(ctx, query, userID, recipientID, subject).Scan(
+		&conversation.ID, &conversation.UserID, &conversation.RecipientID, &conversation.Subject,
 		&conversation.Status, &conversation.LastMessageAt,
 		&conversation.CreatedAt, &conversation.UpdatedAt,
 	)
@@ -42,21 +42,25 @@ func (r *MessageRepository) CreateConversation(ctx context.Context, userID uuid.
 // GetConversationByID retrieves a conversation by ID with user info
 func (r *MessageRepository) GetConversationByID(ctx context.Context, id uuid.UUID) (*models.Conversation, error) {
 	query := `
-		SELECT c.id, c.user_id, c.subject, c.status, c.last_message_at, c.created_at, c.updated_at,
-		       u.id, u.name, u.email, u.avatar
+		SELECT c.id, c.user_id, c.recipient_id, c.subject, c.status, c.last_message_at, c.created_at, c.updated_at,
+		       u.id, u.name, u.email, u.avatar,
+		       c.assigned_to, c.assigned_at, a.name, a.avatar
 		FROM conversations c
 		JOIN users u ON c.user_id = u.id
+		LEFT JOIN users a ON c.assigned_to = a.id
 		WHERE c.id = $1
 	`
 
 	conversation := &models.Conversation{}
 	user := &models.User{}
+	var assigneeName, assigneeAvatar *string
 
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&conversation.ID, &conversation.UserID, &conversation.Subject,
+		&conversation.ID, &conversation.UserID, &conversation.RecipientID, &conversation.Subject,
 		&conversation.Status, &conversation.LastMessageAt,
 		&conversation.CreatedAt, &conversation.UpdatedAt,
 		&user.ID, &user.Name, &user.Email, &user.Avatar,
+		&conversation.AssignedTo, &conversation.AssignedAt, &assigneeName, &assigneeAvatar,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, nil
@@ -66,16 +70,19 @@ func (r *MessageRepository) GetConversationByID(ctx context.Context, id uuid.UUI
 	}
 
 	conversation.User = user
+	setConversationAssignee(conversation, assigneeName, assigneeAvatar)
 	return conversation, nil
 }
 
 // GetConversationByUserID gets the open conversation for a user (creates one if not exists)
 func (r *MessageRepository) GetConversationByUserID(ctx context.Context, userID uuid.UUID) (*models.Conversation, error) {
 	query := `
-		SELECT c.id, c.user_id, c.subject, c.status, c.last_message_at, c.created_at, c.updated_at,
-		       u.id, u.name, u.email, u.avatar
+		SELECT c.id, c.user_id, c.recipient_id, c.subject, c.status, c.last_message_at, c.created_at, c.updated_at,
+		       u.id, u.name, u.email, u.avatar,
+		       c.assigned_to, c.assigned_at, a.name, a.avatar
 		FROM conversations c
 		JOIN users u ON c.user_id = u.id
+		LEFT JOIN users a ON c.assigned_to = a.id
 		WHERE c.user_id = $1 AND c.status = 'open'
 		ORDER BY c.created_at DESC
 		LIMIT 1
@@ -83,12 +90,14 @@ func (r *MessageRepository) GetConversationByUserID(ctx context.Context, userID
 
 	conversation := &models.Conversation{}
 	user := &models.User{}
+	var assigneeName, assigneeAvatar *string
 
 	err := r.db.QueryRow(ctx, query, userID).Scan(
-		&conversation.ID, &conversation.UserID, &conversation.Subject,
+		&conversation.ID, &conversation.UserID, &conversation.RecipientID, &conversation.Subject,
 		&conversation.Status, &conversation.LastMessageAt,
 		&conversation.CreatedAt, &conversation.UpdatedAt,
 		&user.ID, &user.Name, &user.Email, &user.Avatar,
+		&conversation.AssignedTo, &conversation.AssignedAt, &assigneeName, &assigneeAvatar,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, nil
@@ -98,15 +107,17 @@ func (r *MessageRepository) GetConversationByUserID(ctx context.Context, userID
 	}
 
 	conversation.User = user
+	setConversationAssignee(conversation, assigneeName, assigneeAvatar)
 	return conversation, nil
 }
 
-// ListConversations lists all conversations with pagination
+// ListConversations lists the support conversations in the admin inbox with
+// pagination. Direct messages between users are never listed.
 func (r *MessageRepository) ListConversations(ctx context.Context, filter *models.ConversationFilter, page, perPage int) (*models.PaginatedConversations, error) {
 	offset := (page - 1) * perPage
 
 	// Build query conditions
-	whereClause := "WHERE 1=1"
+	whereClause := "WHERE c.recipient_id IS NULL"
 	args := []interface{}{}
 
 	if filter != nil {
@@ -118,6 +129,19 @@ func (r *MessageRepository) ListConversations(ctx context.Context, filter *model
 			args = append(args, *filter.Status)
 			whereClause += fmt.Sprintf(" AND c.status = $%d", len(args))
 		}
+		if filter.AssignedTo != nil {
+			args = append(args, *filter.AssignedTo)
+			whereClause += fmt.Sprintf(" AND c.assigned_to = $%d", len(args))
+		}
+		if filter.Unassigned {
+			whereClause += " AND c.assigned_to IS NULL"
+		}
+		if filter.UnreadOnly {
+			whereClause += " AND EXISTS (" + conversationUnreadQuery + ")"
+		}
+	}
+	if filter == nil || filter.Status == nil {
+		whereClause += " AND c.status != 'archived'"
 	}
 
 	// Count total
@@ -130,11 +154,13 @@ func (r *MessageRepository) ListConversations(ctx context.Context, filter *model
 
 	// Get conversations with last message and unread count
 	query := fmt.Sprintf(`
-		SELECT c.id, c.user_id, c.subject, c.status, c.last_message_at, c.created_at, c.updated_at,
+		SELECT c.id, c.user_id, c.recipient_id, c.subject, c.status, c.last_message_at, c.created_at, c.updated_at,
 		       u.id, u.name, u.email, u.avatar,
-		       (SELECT COUNT(*) FROM messages m WHERE m.conversation_id = c.id AND m.is_read = false AND m.sender_id = c.user_id) as unread_count
+		       (SELECT COUNT(*) FROM messages m WHERE m.conversation_id = c.id AND m.is_read = false AND m.sender_id = c.user_id) as unread_count,
+		       c.assigned_to, c.assigned_at, a.name, a.avatar
 		FROM conversations c
 		JOIN users u ON c.user_id = u.id
+		LEFT JOIN users a ON c.assigned_to = a.id
 		%s
 		ORDER BY c.last_message_at DESC NULLS LAST, c.created_at DESC
 		LIMIT $%d OFFSET $%d
@@ -152,18 +178,21 @@ func (r *MessageRepository) ListConversations(ctx context.Context, filter *model
 	for rows.Next() {
 		var conv models.Conversation
 		var user models.User
+		var assigneeName, assigneeAvatar *string
 
 		err := rows.Scan(
-			&conv.ID, &conv.UserID, &conv.Subject, &conv.Status,
+			&conv.ID, &conv.UserID, &conv.RecipientID, &conv.Subject, &conv.Status,
 			&conv.LastMessageAt, &conv.CreatedAt, &conv.UpdatedAt,
 			&user.ID, &user.Name, &user.Email, &user.Avatar,
 			&conv.UnreadCount,
+			&conv.AssignedTo, &conv.AssignedAt, &assigneeName, &assigneeAvatar,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan conversation: %w", err)
 		}
 
 		conv.User = &user
+		setConversationAssignee(&conv, assigneeName, assigneeAvatar)
 
 		// Get last message preview
 		lastMsg, _ := r.GetLastMessage(ctx, conv.ID)
@@ -199,6 +228,114 @@ func (r *MessageRepository) UpdateConversationStatus(ctx context.Context, id uui
 	return nil
 }
 
+// AssignConversation assigns a support conversation to an admin, or returns
+// it to the unassigned queue when adminID is nil
+func (r *MessageRepository) AssignConversation(ctx context.Context, id uuid.UUID, adminID *uuid.UUID) error {
+	query := `
+		UPDATE conversations
+		SET assigned_to = $2, assigned_at = CASE WHEN $2::uuid IS NULL THEN NULL ELSE NOW() END
+		WHERE id = $1 AND recipient_id IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, id, adminID)
+	if err != nil {
+		return fmt.Errorf("failed to assign conversation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("conversation not found")
+	}
+
+	return nil
+}
+
+// ClaimConversation assigns a support conversation to the admin unless another
+// admin already holds it. It reports whether the admin now holds it.
+func (r *MessageRepository) ClaimConversation(ctx context.Context, id, adminID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE conversations
+		SET assigned_to = $2, assigned_at = COALESCE(assigned_at, NOW())
+		WHERE id = $1 AND recipient_id IS NULL AND (assigned_to IS NULL OR assigned_to = $2)
+	`
+
+	result, err := r.db.Exec(ctx, query, id, adminID)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim conversation: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// GetQueueCounts sizes the support inbox queues as seen by adminID
+func (r *MessageRepository) GetQueueCounts(ctx context.Context, adminID uuid.UUID) (*models.ConversationQueueCounts, error) {
+	counts := &models.ConversationQueueCounts{Admins: []models.AdminQueueCount{}}
+
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE c.status = 'open'),
+			COUNT(*) FILTER (WHERE c.status = 'open' AND q.unread),
+			COUNT(*) FILTER (WHERE c.status = 'open' AND c.assigned_to = $1),
+			COUNT(*) FILTER (WHERE c.status = 'open' AND c.assigned_to = $1 AND q.unread),
+			COUNT(*) FILTER (WHERE c.status = 'open' AND c.assigned_to IS NULL),
+			COUNT(*) FILTER (WHERE c.status = 'open' AND c.assigned_to IS NULL AND q.unread),
+			COUNT(*) FILTER (WHERE c.status = 'archived')
+		FROM conversations c
+		CROSS JOIN LATERAL (SELECT EXISTS (` + conversationUnreadQuery + `) AS unread) q
+		WHERE c.recipient_id IS NULL
+	`
+
+	err := r.db.QueryRow(ctx, query, adminID).Scan(
+		&counts.All.Total, &counts.All.Unread,
+		&counts.Mine.Total, &counts.Mine.Unread,
+		&counts.Unassigned.Total, &counts.Unassigned.Unread,
+		&counts.Archived,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count conversation queues: %w", err)
+	}
+
+	adminQuery := `
+		SELECT u.id, u.name, u.avatar, COUNT(q.id), COUNT(q.id) FILTER (WHERE q.unread)
+		FROM users u
+		JOIN roles r ON u.role_id = r.id
+		LEFT JOIN LATERAL (
+			SELECT c.id, EXISTS (` + conversationUnreadQuery + `) AS unread
+			FROM conversations c
+			WHERE c.assigned_to = u.id AND c.status = 'open' AND c.recipient_id IS NULL
+		) q ON TRUE
+		WHERE r.slug = 'admin' AND u.deleted_at IS NULL
+		GROUP BY u.id, u.name, u.avatar
+		ORDER BY u.name
+	`
+
+	rows, err := r.db.Query(ctx, adminQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count admin queues: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var admin models.AdminQueueCount
+		if err := rows.Scan(&admin.AdminID, &admin.Name, &admin.Avatar, &admin.Total, &admin.Unread); err != nil {
+			return nil, fmt.Errorf("failed to scan admin queue: %w", err)
+		}
+		counts.Admins = append(counts.Admins, admin)
+	}
+
+	return counts, nil
+}
+
+// conversationUnreadQuery matches unread messages from the member who opened
+// conversation c, i.e. ones still waiting on the support team
+const conversationUnreadQuery = `SELECT 1 FROM messages m WHERE m.conversation_id = c.id AND m.is_read = false AND m.sender_id = c.user_id`
+
+func setConversationAssignee(conversation *models.Conversation, name, avatar *string) {
+	if conversation.AssignedTo == nil || name == nil {
+		return
+	}
+	conversation.Assignee = &models.ConversationAssignee{ID: *conversation.AssignedTo, Name: *name, Avatar: avatar}
+}
+
 // CreateMessage creates a new message in a conversation
 func (r *MessageRepository) CreateMessage(ctx context.Context, conversationID, senderID uuid.UUID, content string) (*models.Message, error) {
 	message := &models.Message{}
@@ -359,12 +496,51 @@ func (r *MessageRepository) MarkMessagesAsRead(ctx context.Context, conversation
 	return nil
 }
 
+// MarkMessageRead marks one incoming message in the reader's conversations as read
+func (r *MessageRepository) MarkMessageRead(ctx context.Context, id, readerID uuid.UUID) error {
+	query := `
+		UPDATE messages m
+		SET is_read = true, read_at = COALESCE(m.read_at, NOW())
+		FROM conversations c
+		WHERE m.id = $1 AND m.conversation_id = c.id
+		  AND (c.user_id = $2 OR c.recipient_id = $2) AND m.sender_id != $2
+	`
+
+	result, err := r.db.Exec(ctx, query, id, readerID)
+	if err != nil {
+		return fmt.Errorf("failed to mark message as read: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("message not found")
+	}
+
+	return nil
+}
+
+// MarkAllMessagesRead marks every incoming message in the reader's conversations as read
+func (r *MessageRepository) MarkAllMessagesRead(ctx context.Context, readerID uuid.UUID) error {
+	query := `
+		UPDATE messages m
+		SET is_read = true, read_at = NOW()
+		FROM conversations c
+		WHERE m.conversation_id = c.id AND m.is_read = false
+		  AND (c.user_id = $1 OR c.recipient_id = $1) AND m.sender_id != $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, readerID); err != nil {
+		return fmt.Errorf("failed to mark all messages as read: %w", err)
+	}
+
+	return nil
+}
+
 // GetUnreadCounts gets unread message counts for a user
 func (r *MessageRepository) GetUnreadCounts(ctx context.Context, userID uuid.UUID, isAdmin bool) (*models.UnreadCounts, error) {
 	counts := &models.UnreadCounts{}
 
 	if isAdmin {
-		// Admin sees unread messages from all users (messages where sender is not admin)
+		// Admin sees unread messages from all users in support conversations (messages where sender is not admin).
+		// Archived conversations are out of the inbox, so they don't count.
 		query := `
 			SELECT
 				COUNT(DISTINCT m.id) as total_messages,
@@ -373,21 +549,21 @@ func (r *MessageRepository) GetUnreadCounts(ctx context.Context, userID uuid.UUI
 			JOIN conversations c ON m.conversation_id = c.id
 			JOIN users u ON m.sender_id = u.id
 			JOIN roles r ON u.role_id = r.id
-			WHERE m.is_read = false AND r.slug != 'admin'
+			WHERE m.is_read = false AND r.slug != 'admin' AND c.recipient_id IS NULL AND c.status != 'archived'
 		`
 		err := r.db.QueryRow(ctx, query).Scan(&counts.Total, &counts.Conversations)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get admin unread counts: %w", err)
 		}
 	} else {
-		// User sees unread messages in their conversations (from admins)
+		// User sees unread messages in their conversations (from admins or the other participant)
 		query := `
 			SELECT
 				COUNT(DISTINCT m.id) as total_messages,
 				COUNT(DISTINCT c.id) as total_conversations
 			FROM messages m
 			JOIN conversations c ON m.conversation_id = c.id
-			WHERE c.user_id = $1 AND m.is_read = false AND m.sender_id != $1
+			WHERE (c.user_id = $1 OR c.recipient_id = $1) AND m.is_read = false AND m.sender_id != $1
 		`
 		err := r.db.QueryRow(ctx, query, userID).Scan(&counts.Total, &counts.Conversations)
 		if err != nil {
@@ -401,10 +577,10 @@ func (r *MessageRepository) GetUnreadCounts(ctx context.Context, userID uuid.UUI
 // GetUserConversations gets all conversations for a specific user
 func (r *MessageRepository) GetUserConversations(ctx context.Context, userID uuid.UUID) ([]models.Conversation, error) {
 	query := `
-		SELECT c.id, c.user_id, c.subject, c.status, c.last_message_at, c.created_at, c.updated_at,
+		SELECT c.id, c.user_id, c.recipient_id, c.subject, c.status, c.last_message_at, c.created_at, c.updated_at,
 		       (SELECT COUNT(*) FROM messages m WHERE m.conversation_id = c.id AND m.is_read = false AND m.sender_id != $1) as unread_count
 		FROM conversations c
-		WHERE c.user_id = $1
+		WHERE c.user_id = $1 OR c.recipient_id = $1
 		ORDER BY c.last_message_at DESC NULLS LAST, c.created_at DESC
 	`
 
@@ -419,7 +595,7 @@ func (r *MessageRepository) GetUserConversations(ctx context.Context, userID uui
 		var conv models.Conversation
 
 		err := rows.Scan(
-			&conv.ID, &conv.UserID, &conv.Subject, &conv.Status,
+			&conv.ID, &conv.UserID, &conv.RecipientID, &conv.Subject, &conv.Status,
 			&conv.LastMessageAt, &conv.CreatedAt, &conv.UpdatedAt,
 			&conv.UnreadCount,
 		)
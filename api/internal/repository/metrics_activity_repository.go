@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// RollupActivity recounts comments and poll votes per hour, for every hour
+// from since's onwards, into activity_hourly. Only rows created in that span
+// are read, through the created_at and voted_at indexes.
+func (r *MetricsRepository) RollupActivity(ctx context.Context, since time.Time) error {
+	since = since.UTC().Truncate(time.Hour)
+
+	// comments.created_at is a plain TIMESTAMP written in UTC
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO activity_hourly (hour, comments, poll_votes)
+		SELECT hour, SUM(comments), SUM(poll_votes)
+		FROM (
+			SELECT date_trunc('hour', created_at) AT TIME ZONE 'UTC' AS hour, COUNT(*) AS comments, 0 AS poll_votes
+			FROM comments
+			WHERE created_at >= $1::timestamptz AT TIME ZONE 'UTC'
+			GROUP BY 1
+			UNION ALL
+			SELECT date_trunc('hour', voted_at, 'UTC'), 0, COUNT(*)
+			FROM poll_votes
+			WHERE voted_at >= $1
+			GROUP BY 1
+		) counts
+		GROUP BY hour
+		ON CONFLICT (hour) DO UPDATE SET
+			comments = EXCLUDED.comments,
+			poll_votes = EXCLUDED.poll_votes
+	`, since)
+	if err != nil {
+		return fmt.Errorf("failed to roll up activity: %w", err)
+	}
+	return nil
+}
+
+// GetActivityHeatmap sums the hourly rollups of the last days by day of week
+// and hour of day in loc
+func (r *MetricsRepository) GetActivityHeatmap(ctx context.Context, days int, loc *time.Location) (*models.ActivityHeatmap, error) {
+	heatmap := &models.ActivityHeatmap{
+		Days:     days,
+		Since:    time.Now().UTC().Truncate(time.Hour).AddDate(0, 0, -days),
+		Timezone: loc.String(),
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT date_part('dow', hour AT TIME ZONE $2)::int, date_part('hour', hour AT TIME ZONE $2)::int,
+		       SUM(comments), SUM(poll_votes), SUM(article_views)
+		FROM activity_hourly
+		WHERE hour >= $1
+		GROUP BY 1, 2
+	`, heatmap.Since, heatmap.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity heatmap: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day, hour int
+		var comments, pollVotes, views int64
+		if err := rows.Scan(&day, &hour, &comments, &pollVotes, &views); err != nil {
+			return nil, fmt.Errorf("failed to scan activity heatmap: %w", err)
+		}
+		heatmap.Comments.Raw[day][hour] = comments
+		heatmap.PollVotes.Raw[day][hour] = pollVotes
+		heatmap.ArticleViews.Raw[day][hour] = views
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get activity heatmap: %w", err)
+	}
+
+	heatmap.Comments.Normalize()
+	heatmap.PollVotes.Normalize()
+	heatmap.ArticleViews.Normalize()
+
+	return heatmap, nil
+}
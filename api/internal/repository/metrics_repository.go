@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -67,7 +70,8 @@ func (r *MetricsRepository) GetDashboardMetrics(ctx context.Context) (*models.Da
 
 func (r *MetricsRepository) GetTopArticles(ctx context.Context, limit int) ([]models.TopArticle, error) {
 	query := `
-		SELECT a.id, a.slug, a.title, a.view_count, c.name
+		SELECT a.id, a.slug, a.title, a.view_count, c.name,
+			   (SELECT COUNT(*) FROM article_reactions ar WHERE ar.article_id = a.id)
 		FROM articles a
 		LEFT JOIN categories c ON a.category_id = c.id
 		WHERE a.status = 'published'
@@ -84,7 +88,7 @@ func (r *MetricsRepository) GetTopArticles(ctx context.Context, limit int) ([]mo
 	articles := []models.TopArticle{}
 	for rows.Next() {
 		var article models.TopArticle
-		err := rows.Scan(&article.ID, &article.Slug, &article.Title, &article.ViewCount, &article.CategoryName)
+		err := rows.Scan(&article.ID, &article.Slug, &article.Title, &article.ViewCount, &article.CategoryName, &article.ReactionCount)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan top article: %w", err)
 		}
@@ -154,3 +158,177 @@ func (r *MetricsRepository) GetTagMetrics(ctx context.Context) ([]models.TagMetr
 
 	return metrics, nil
 }
+
+// GetAuthorMetrics returns per-author article counts and views. Articles an
+// author co-wrote are credited at coAuthorWeight in the weighted totals.
+func (r *MetricsRepository) GetAuthorMetrics(ctx context.Context, coAuthorWeight float64) ([]models.AuthorMetric, error) {
+	query := `
+		WITH primary_stats AS (
+			SELECT author_id, COUNT(*) as article_count, COALESCE(SUM(view_count), 0) as total_views
+			FROM articles
+			WHERE status = 'published' AND deleted_at IS NULL AND author_id IS NOT NULL
+			GROUP BY author_id
+		),
+		co_author_stats AS (
+			SELECT aa.author_id, COUNT(*) as article_count, COALESCE(SUM(a.view_count), 0) as total_views
+			FROM article_authors aa
+			JOIN articles a ON aa.article_id = a.id
+			WHERE a.status = 'published' AND a.deleted_at IS NULL
+			GROUP BY aa.author_id
+		)
+		SELECT au.id, au.name, au.slug,
+			   COALESCE(ps.article_count, 0) as article_count,
+			   COALESCE(cs.article_count, 0) as co_authored_count,
+			   COALESCE(ps.article_count, 0) + $1::float8 * COALESCE(cs.article_count, 0) as weighted_article_count,
+			   COALESCE(ps.total_views, 0) as total_views,
+			   COALESCE(ps.total_views, 0) + $1::float8 * COALESCE(cs.total_views, 0) as weighted_views
+		FROM authors au
+		LEFT JOIN primary_stats ps ON ps.author_id = au.id
+		LEFT JOIN co_author_stats cs ON cs.author_id = au.id
+		WHERE au.deleted_at IS NULL
+		ORDER BY weighted_views DESC, au.name
+	`
+
+	rows, err := r.db.Query(ctx, query, coAuthorWeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author metrics: %w", err)
+	}
+	defer rows.Close()
+
+	metrics := []models.AuthorMetric{}
+	for rows.Next() {
+		var metric models.AuthorMetric
+		err := rows.Scan(
+			&metric.ID, &metric.Name, &metric.Slug,
+			&metric.ArticleCount, &metric.CoAuthoredCount, &metric.WeightedArticleCount,
+			&metric.TotalViews, &metric.WeightedViews,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan author metric: %w", err)
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return metrics, nil
+}
+
+// GetReferrerBreakdown splits an article's views over the last days days by
+// referrer type
+func (r *MetricsRepository) GetReferrerBreakdown(ctx context.Context, articleID uuid.UUID, days int) (*models.ReferrerBreakdown, error) {
+	return r.referrerBreakdown(ctx, days, "ar.article_id = $2", articleID)
+}
+
+// GetReferrerSummary splits views of all articles over the last days days by
+// referrer type
+func (r *MetricsRepository) GetReferrerSummary(ctx context.Context, days int) (*models.ReferrerBreakdown, error) {
+	return r.referrerBreakdown(ctx, days, "TRUE")
+}
+
+// topReferrerDomains is how many referring domains a breakdown lists
+const topReferrerDomains = 10
+
+func (r *MetricsRepository) referrerBreakdown(ctx context.Context, days int, cond string, args ...interface{}) (*models.ReferrerBreakdown, error) {
+	// Today counts as one of the days
+	where := "ar.view_date > CURRENT_DATE - $1::int AND " + cond
+	args = append([]interface{}{days}, args...)
+
+	rows, err := r.db.Query(ctx, `
+		SELECT ar.referrer_type, SUM(ar.view_count)
+		FROM article_referrers ar
+		WHERE `+where+`
+		GROUP BY ar.referrer_type
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referrer breakdown: %w", err)
+	}
+	defer rows.Close()
+
+	views := map[string]int{}
+	for rows.Next() {
+		var referrerType string
+		var count int
+		if err := rows.Scan(&referrerType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan referrer breakdown: %w", err)
+		}
+		views[referrerType] = count
+	}
+	rows.Close()
+
+	domainQuery := fmt.Sprintf(`
+		SELECT ar.referrer_domain, ar.referrer_type, SUM(ar.view_count) AS views
+		FROM article_referrers ar
+		WHERE %s AND ar.referrer_domain <> ''
+		GROUP BY ar.referrer_domain, ar.referrer_type
+		ORDER BY views DESC, ar.referrer_domain
+		LIMIT $%d
+	`, where, len(args)+1)
+	rows, err = r.db.Query(ctx, domainQuery, append(args, topReferrerDomains)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top referrer domains: %w", err)
+	}
+	defer rows.Close()
+
+	domains := []models.ReferrerDomainCount{}
+	for rows.Next() {
+		var domain models.ReferrerDomainCount
+		if err := rows.Scan(&domain.Domain, &domain.Type, &domain.Views); err != nil {
+			return nil, fmt.Errorf("failed to scan referrer domain: %w", err)
+		}
+		domains = append(domains, domain)
+	}
+
+	return models.NewReferrerBreakdown(days, views, domains), nil
+}
+
+// GetDataFreshness reports, for each hand-encoded module, how many active
+// records have gone untouched past their staleness threshold and which one
+// was updated least recently
+func (r *MetricsRepository) GetDataFreshness(ctx context.Context, thresholds models.StalenessThresholds) ([]models.ModuleFreshness, error) {
+	modules := []struct {
+		name      string
+		table     string
+		titleCol  string
+		statuses  []string
+		active    bool // Whether statuses lists the active statuses or the final ones
+		threshold time.Duration
+	}{
+		{models.FreshnessModuleBills, "bills", "title", models.FinalBillStatuses, false, thresholds.Bill},
+		{models.FreshnessModuleElections, "elections", "name", models.ActiveElectionStatuses, true, thresholds.Election},
+	}
+
+	result := make([]models.ModuleFreshness, 0, len(modules))
+	for _, m := range modules {
+		statusCond := "NOT (status = ANY($1))"
+		if m.active {
+			statusCond = "status = ANY($1)"
+		}
+
+		freshness := models.ModuleFreshness{Module: m.name}
+		err := r.db.QueryRow(ctx, fmt.Sprintf(`
+			SELECT COUNT(*),
+			       COUNT(*) FILTER (WHERE $2::float8 > 0 AND data_as_of < NOW() - make_interval(secs => $2))
+			FROM %[1]s
+			WHERE deleted_at IS NULL AND %[2]s
+		`, m.table, statusCond), m.statuses, m.threshold.Seconds()).Scan(&freshness.ActiveCount, &freshness.StaleCount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s freshness: %w", m.name, err)
+		}
+
+		err = r.db.QueryRow(ctx, fmt.Sprintf(`
+			SELECT id, slug, %[3]s, data_as_of
+			FROM %[1]s
+			WHERE deleted_at IS NULL AND %[2]s
+			ORDER BY data_as_of
+			LIMIT 1
+		`, m.table, statusCond, m.titleCol), m.statuses).Scan(
+			&freshness.OldestID, &freshness.OldestSlug, &freshness.OldestTitle, &freshness.OldestDataAsOf,
+		)
+		if err != nil && err != pgx.ErrNoRows {
+			return nil, fmt.Errorf("failed to get oldest %s: %w", m.name, err)
+		}
+
+		result = append(result, freshness)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// replyNotificationTypes are the notification types surfaced as inbox replies
+const replyNotificationTypes = `('reply_article_comment', 'reply_politician_comment')`
+
+// inboxQuery merges mentions, reply notifications and incoming messages into
+// one result set. Each %s is an extra unread filter for that branch.
+const inboxQuery = `
+	SELECT 'mention' AS type, m.id, m.read_at IS NOT NULL AS is_read, LEFT(c.content, 200) AS preview,
+	       c.id AS comment_id, NULL::uuid AS conversation_id, c.created_at,
+	       u.id AS actor_id, u.name AS actor_name, u.avatar AS actor_avatar,
+	       a.id AS article_id, a.title AS article_title, a.slug AS article_slug,
+	       NULL::uuid AS politician_id, NULL::text AS politician_name, NULL::text AS politician_slug
+	FROM comment_mentions m
+	JOIN comments c ON m.comment_id = c.id
+	JOIN articles a ON c.article_id = a.id
+	LEFT JOIN users u ON c.user_id = u.id
+	WHERE ` + mentionInboxFilter + `%s
+	UNION ALL
+	SELECT 'reply', n.id, COALESCE(n.is_read, FALSE), COALESCE(n.message, n.title),
+	       n.comment_id, NULL::uuid, n.created_at,
+	       u.id, u.name, u.avatar,
+	       a.id, a.title, a.slug,
+	       p.id, p.name, p.slug
+	FROM notifications n
+	LEFT JOIN users u ON n.actor_id = u.id
+	LEFT JOIN articles a ON n.article_id = a.id
+	LEFT JOIN politicians p ON n.politician_id = p.id
+	WHERE n.user_id = $1 AND n.type IN ` + replyNotificationTypes + `%s
+	UNION ALL
+	SELECT 'message', msg.id, COALESCE(msg.is_read, FALSE), LEFT(msg.content, 200),
+	       NULL::uuid, cv.id, msg.created_at,
+	       u.id, u.name, u.avatar,
+	       NULL::uuid, NULL::text, NULL::text,
+	       NULL::uuid, NULL::text, NULL::text
+	FROM messages msg
+	JOIN conversations cv ON msg.conversation_id = cv.id
+	LEFT JOIN users u ON msg.sender_id = u.id
+	WHERE (cv.user_id = $1 OR cv.recipient_id = $1) AND msg.sender_id <> $1%s`
+
+// inboxUnion returns the inbox query, optionally limited to unread items
+func inboxUnion(unreadOnly bool) string {
+	if !unreadOnly {
+		return fmt.Sprintf(inboxQuery, "", "", "")
+	}
+	return fmt.Sprintf(inboxQuery,
+		" AND m.read_at IS NULL",
+		" AND n.is_read = FALSE",
+		" AND msg.is_read = FALSE",
+	)
+}
+
+// ListInbox returns the user's mentions, replies and incoming messages, newest first
+func (r *NotificationRepository) ListInbox(ctx context.Context, userID uuid.UUID, page, perPage int, unreadOnly bool) (*models.PaginatedInbox, error) {
+	offset := (page - 1) * perPage
+	union := inboxUnion(unreadOnly)
+
+	var total int
+	if err := r.db.QueryRow(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM (%s) inbox`, union), userID).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count inbox: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM (%s) inbox
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`, union)
+
+	rows, err := r.db.Query(ctx, query, userID, perPage, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inbox: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.InboxItem{}
+	for rows.Next() {
+		var item models.InboxItem
+		var actorID *uuid.UUID
+		var actorName, actorAvatar *string
+		var articleID *uuid.UUID
+		var articleTitle, articleSlug *string
+		var politicianID *uuid.UUID
+		var politicianName, politicianSlug *string
+
+		err := rows.Scan(
+			&item.Type, &item.ID, &item.IsRead, &item.Preview,
+			&item.CommentID, &item.ConversationID, &item.CreatedAt,
+			&actorID, &actorName, &actorAvatar,
+			&articleID, &articleTitle, &articleSlug,
+			&politicianID, &politicianName, &politicianSlug,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inbox item: %w", err)
+		}
+
+		if actorID != nil && actorName != nil {
+			item.Actor = &models.NotificationActor{ID: *actorID, Name: *actorName, Avatar: actorAvatar}
+		}
+		if articleID != nil && articleTitle != nil && articleSlug != nil {
+			item.ArticleRef = &models.NotificationRef{ID: *articleID, Name: *articleTitle, Slug: *articleSlug}
+		}
+		if politicianID != nil && politicianName != nil && politicianSlug != nil {
+			item.PoliticianRef = &models.NotificationRef{ID: *politicianID, Name: *politicianName, Slug: *politicianSlug}
+		}
+
+		items = append(items, item)
+	}
+
+	return &models.PaginatedInbox{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: (total + perPage - 1) / perPage,
+	}, nil
+}
+
+// CountUnreadReplies counts the user's unread reply notifications
+func (r *NotificationRepository) CountUnreadReplies(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM notifications
+		WHERE user_id = $1 AND is_read = FALSE AND type IN `+replyNotificationTypes, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread replies: %w", err)
+	}
+	return count, nil
+}
+
+// MarkAllRepliesRead marks all of the user's reply notifications as read
+func (r *NotificationRepository) MarkAllRepliesRead(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notifications SET is_read = TRUE, read_at = NOW()
+		WHERE user_id = $1 AND is_read = FALSE AND type IN `+replyNotificationTypes, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark replies as read: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInboxUnionUnreadFilters(t *testing.T) {
+	all := inboxUnion(false)
+	unread := inboxUnion(true)
+
+	assert.NotContains(t, all, "m.read_at IS NULL")
+	assert.Contains(t, unread, "m.read_at IS NULL")
+	assert.Contains(t, unread, "n.is_read = FALSE")
+	assert.Contains(t, unread, "msg.is_read = FALSE")
+	assert.NotContains(t, unread, "%!")
+}
+
+func TestNotificationRepository_ListInboxEmpty(t *testing.T) {
+	pool, _ := connectCountingDB(t)
+	repo := NewNotificationRepository(pool)
+	ctx := context.Background()
+
+	result, err := repo.ListInbox(ctx, uuid.New(), 1, 20, false)
+	require.NoError(t, err)
+	assert.NotNil(t, result.Items)
+	assert.Empty(t, result.Items)
+	assert.Equal(t, 0, result.Total)
+
+	replies, err := repo.CountUnreadReplies(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, 0, replies)
+}
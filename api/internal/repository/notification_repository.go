@@ -22,17 +22,17 @@ func NewNotificationRepository(db *pgxpool.Pool) *NotificationRepository {
 func (r *NotificationRepository) Create(ctx context.Context, req *models.CreateNotificationRequest) (*models.Notification, error) {
 	notification := &models.Notification{}
 	query := `
-		INSERT INTO notifications (user_id, type, title, message, actor_id, article_id, politician_id, comment_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, user_id, type, title, message, actor_id, article_id, politician_id, comment_id, is_read, read_at, created_at
+		INSERT INTO notifications (user_id, type, title, message, actor_id, article_id, politician_id, comment_id, poll_id, conversation_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, user_id, type, title, message, actor_id, article_id, politician_id, comment_id, poll_id, conversation_id, is_read, read_at, created_at
 	`
 
 	err := r.db.QueryRow(ctx, query,
 		req.UserID, req.Type, req.Title, req.Message,
-		req.ActorID, req.ArticleID, req.PoliticianID, req.CommentID,
+		req.ActorID, req.ArticleID, req.PoliticianID, req.CommentID, req.PollID, req.ConversationID,
 	).Scan(
 		&notification.ID, &notification.UserID, &notification.Type, &notification.Title, &notification.Message,
-		&notification.ActorID, &notification.ArticleID, &notification.PoliticianID, &notification.CommentID,
+		&notification.ActorID, &notification.ArticleID, &notification.PoliticianID, &notification.CommentID, &notification.PollID, &notification.ConversationID,
 		&notification.IsRead, &notification.ReadAt, &notification.CreatedAt,
 	)
 	if err != nil {
@@ -45,7 +45,7 @@ func (r *NotificationRepository) Create(ctx context.Context, req *models.CreateN
 // GetByID retrieves a notification by ID
 func (r *NotificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Notification, error) {
 	query := `
-		SELECT n.id, n.user_id, n.type, n.title, n.message, n.actor_id, n.article_id, n.politician_id, n.comment_id,
+		SELECT n.id, n.user_id, n.type, n.title, n.message, n.actor_id, n.article_id, n.politician_id, n.comment_id, n.poll_id, n.conversation_id,
 		       n.is_read, n.read_at, n.created_at,
 		       u.id, u.name, u.avatar
 		FROM notifications n
@@ -59,7 +59,7 @@ func (r *NotificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&notification.ID, &notification.UserID, &notification.Type, &notification.Title, &notification.Message,
-		&notification.ActorID, &notification.ArticleID, &notification.PoliticianID, &notification.CommentID,
+		&notification.ActorID, &notification.ArticleID, &notification.PoliticianID, &notification.CommentID, &notification.PollID, &notification.ConversationID,
 		&notification.IsRead, &notification.ReadAt, &notification.CreatedAt,
 		&actorID, &actorName, &actorAvatar,
 	)
@@ -118,7 +118,7 @@ func (r *NotificationRepository) ListByUser(ctx context.Context, userID uuid.UUI
 
 	// Get notifications with related data
 	query := fmt.Sprintf(`
-		SELECT n.id, n.user_id, n.type, n.title, n.message, n.actor_id, n.article_id, n.politician_id, n.comment_id,
+		SELECT n.id, n.user_id, n.type, n.title, n.message, n.actor_id, n.article_id, n.politician_id, n.comment_id, n.poll_id, n.conversation_id,
 		       n.is_read, n.read_at, n.created_at,
 		       u.id, u.name, u.avatar,
 		       a.id, a.title, a.slug,
@@ -149,7 +149,7 @@ func (r *NotificationRepository) ListByUser(ctx context.Context, userID uuid.UUI
 		var politicianName, politicianSlug *string
 
 		err := rows.Scan(
-			&n.ID, &n.UserID, &n.Type, &n.Title, &n.Message, &n.ActorID, &n.ArticleID, &n.PoliticianID, &n.CommentID,
+			&n.ID, &n.UserID, &n.Type, &n.Title, &n.Message, &n.ActorID, &n.ArticleID, &n.PoliticianID, &n.CommentID, &n.PollID, &n.ConversationID,
 			&n.IsRead, &n.ReadAt, &n.CreatedAt,
 			&actorID, &actorName, &actorAvatar,
 			&articleID, &articleTitle, &articleSlug,
@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// currentPartyRelationCond matches relations in effect today
+const currentPartyRelationCond = `(r.start_date IS NULL OR r.start_date <= CURRENT_DATE) AND (r.end_date IS NULL OR r.end_date >= CURRENT_DATE)`
+
+// partyBriefColumns selects a party aliased as the given table alias, for scanPartyBrief
+func partyBriefColumns(alias string) string {
+	return fmt.Sprintf("%[1]s.id, %[1]s.name, %[1]s.slug, %[1]s.abbreviation, COALESCE(%[1]s.logo_list, %[1]s.logo), %[1]s.color", alias)
+}
+
+func partyBriefDest(p *models.PartyBrief) []interface{} {
+	return []interface{}{&p.ID, &p.Name, &p.Slug, &p.Abbreviation, &p.Logo, &p.Color}
+}
+
+const partyRelationSelect = `
+	SELECT r.id, r.party_id, r.relation_type, r.start_date, r.end_date, r.notes, r.created_at, r.updated_at,
+	       %s, %s
+	FROM party_relations r
+	JOIN political_parties pp ON r.party_id = pp.id
+	JOIN political_parties rp ON r.related_party_id = rp.id`
+
+func scanPartyRelation(row pgx.Row) (*models.PartyRelation, error) {
+	relation := &models.PartyRelation{}
+	dest := []interface{}{
+		&relation.ID, &relation.PartyID, &relation.RelationType, &relation.StartDate, &relation.EndDate, &relation.Notes,
+		&relation.CreatedAt, &relation.UpdatedAt,
+	}
+	dest = append(dest, partyBriefDest(&relation.Party)...)
+	dest = append(dest, partyBriefDest(&relation.RelatedParty)...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return relation, nil
+}
+
+// ListRelations returns every relation the party is on either side of,
+// latest first
+func (r *PoliticalPartyRepository) ListRelations(ctx context.Context, partyID uuid.UUID) ([]models.PartyRelation, error) {
+	query := fmt.Sprintf(partyRelationSelect, partyBriefColumns("pp"), partyBriefColumns("rp")) + `
+		WHERE r.party_id = $1 OR r.related_party_id = $1
+		ORDER BY r.start_date DESC NULLS LAST, r.created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, partyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list party relations: %w", err)
+	}
+	defer rows.Close()
+
+	relations := []models.PartyRelation{}
+	for rows.Next() {
+		relation, err := scanPartyRelation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan party relation: %w", err)
+		}
+		relations = append(relations, *relation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list party relations: %w", err)
+	}
+
+	return relations, nil
+}
+
+func (r *PoliticalPartyRepository) GetRelationByID(ctx context.Context, id uuid.UUID) (*models.PartyRelation, error) {
+	query := fmt.Sprintf(partyRelationSelect, partyBriefColumns("pp"), partyBriefColumns("rp")) + `
+		WHERE r.id = $1
+	`
+
+	relation, err := scanPartyRelation(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get party relation: %w", err)
+	}
+	return relation, nil
+}
+
+func (r *PoliticalPartyRepository) CreateRelation(ctx context.Context, partyID uuid.UUID, req *models.CreatePartyRelationRequest) (uuid.UUID, error) {
+	// Selecting from political_parties rejects a missing or deleted related
+	// party without a FK error
+	query := `
+		INSERT INTO party_relations (party_id, related_party_id, relation_type, start_date, end_date, notes)
+		SELECT $1, pp.id, $3::party_relation_type, $4::date, $5::date, $6
+		FROM political_parties pp
+		WHERE pp.id = $2 AND pp.deleted_at IS NULL
+		RETURNING id
+	`
+
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, query,
+		partyID, req.RelatedPartyID, req.RelationType, req.StartDate, req.EndDate, req.Notes,
+	).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return uuid.Nil, fmt.Errorf("related party not found")
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create party relation: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *PoliticalPartyRepository) UpdateRelation(ctx context.Context, id uuid.UUID, req *models.UpdatePartyRelationRequest) error {
+	query := `
+		UPDATE party_relations
+		SET start_date = COALESCE($1::date, start_date),
+			end_date = CASE WHEN $3::boolean THEN NULL ELSE COALESCE($2::date, end_date) END,
+			notes = COALESCE($4, notes),
+			updated_at = NOW()
+		WHERE id = $5
+	`
+
+	result, err := r.db.Exec(ctx, query, req.StartDate, req.EndDate, req.RemoveEndDate, req.Notes, id)
+	if err != nil {
+		return fmt.Errorf("failed to update party relation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("party relation not found")
+	}
+
+	return nil
+}
+
+func (r *PoliticalPartyRepository) DeleteRelation(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM party_relations WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete party relation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("party relation not found")
+	}
+
+	return nil
+}
+
+// GetRelationGraph loads every relation of the given types as edges. Lineage
+// edges point from the earlier party to the later one.
+func (r *PoliticalPartyRepository) GetRelationGraph(ctx context.Context, relationTypes []string) (models.PartyRelationGraph, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT relation_type, party_id, related_party_id
+		FROM party_relations
+		WHERE relation_type::text = ANY($1)
+	`, relationTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load party relations: %w", err)
+	}
+	defer rows.Close()
+
+	graph := models.PartyRelationGraph{}
+	for rows.Next() {
+		var relationType string
+		var partyID, relatedID uuid.UUID
+		if err := rows.Scan(&relationType, &partyID, &relatedID); err != nil {
+			return nil, fmt.Errorf("failed to scan party relation: %w", err)
+		}
+		from, to := models.PartyLineageEdge(relationType, partyID, relatedID)
+		graph[from] = append(graph[from], to)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load party relations: %w", err)
+	}
+
+	return graph, nil
+}
+
+// HasOverlappingRelation reports whether another relation of the type from
+// the party overlaps the start/end range. Open ends are unbounded.
+func (r *PoliticalPartyRepository) HasOverlappingRelation(ctx context.Context, partyID uuid.UUID, relationType string, startDate, endDate *string, excludeID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM party_relations r
+			WHERE r.party_id = $1 AND r.relation_type = $2::party_relation_type AND r.id <> $5
+			  AND COALESCE(r.start_date, '-infinity'::date) <= COALESCE($4::date, 'infinity'::date)
+			  AND COALESCE(r.end_date, 'infinity'::date) >= COALESCE($3::date, '-infinity'::date)
+		)
+	`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, partyID, relationType, startDate, endDate, excludeID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check party relations: %w", err)
+	}
+	return exists, nil
+}
+
+// GetCurrentCoalitions returns the coalitions the party is in today
+func (r *PoliticalPartyRepository) GetCurrentCoalitions(ctx context.Context, partyID uuid.UUID) ([]models.PartyBrief, error) {
+	return r.queryPartyBriefs(ctx, `
+		SELECT `+partyBriefColumns("rp")+`
+		FROM party_relations r
+		JOIN political_parties rp ON r.related_party_id = rp.id
+		WHERE r.party_id = $1 AND r.relation_type = 'coalition_member_of'
+		  AND rp.deleted_at IS NULL AND `+currentPartyRelationCond+`
+		ORDER BY rp.name
+	`, partyID)
+}
+
+// GetCurrentCoalitionMembers returns the parties in the coalition today
+func (r *PoliticalPartyRepository) GetCurrentCoalitionMembers(ctx context.Context, coalitionID uuid.UUID) ([]models.PartyBrief, error) {
+	return r.queryPartyBriefs(ctx, `
+		SELECT `+partyBriefColumns("pp")+`
+		FROM party_relations r
+		JOIN political_parties pp ON r.party_id = pp.id
+		WHERE r.related_party_id = $1 AND r.relation_type = 'coalition_member_of'
+		  AND pp.deleted_at IS NULL AND `+currentPartyRelationCond+`
+		ORDER BY pp.name
+	`, coalitionID)
+}
+
+// GetElectionPeriodCoalition returns the coalition the party is in today if
+// an election is under way: campaigning has started, or it is ongoing. It is
+// nil otherwise, or if the party is in no coalition.
+func (r *PoliticalPartyRepository) GetElectionPeriodCoalition(ctx context.Context, partyID uuid.UUID) (*models.PartyBrief, error) {
+	query := `
+		SELECT ` + partyBriefColumns("rp") + `
+		FROM party_relations r
+		JOIN political_parties rp ON r.related_party_id = rp.id
+		WHERE r.party_id = $1 AND r.relation_type = 'coalition_member_of'
+		  AND rp.deleted_at IS NULL AND ` + currentPartyRelationCond + `
+		  AND EXISTS (
+			SELECT 1 FROM elections e
+			WHERE e.deleted_at IS NULL
+			  AND (e.status = 'ongoing'
+			       OR (e.status = 'upcoming' AND CURRENT_DATE BETWEEN COALESCE(e.campaign_start, e.election_date) AND e.election_date))
+		  )
+		ORDER BY r.start_date DESC NULLS LAST, rp.name
+		LIMIT 1
+	`
+
+	var coalition models.PartyBrief
+	err := r.db.QueryRow(ctx, query, partyID).Scan(partyBriefDest(&coalition)...)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get party coalition: %w", err)
+	}
+	return &coalition, nil
+}
+
+// GetLineage returns every merger and renaming leading to or from the party,
+// following the chain both ways, oldest first
+func (r *PoliticalPartyRepository) GetLineage(ctx context.Context, partyID uuid.UUID) ([]models.PartyLineageStep, error) {
+	// Cycles are rejected when relations are saved; the depth cap only
+	// guards against one slipping in some other way
+	query := `
+		WITH RECURSIVE edges AS (
+			SELECT relation_type, start_date,
+			       CASE WHEN relation_type = 'renamed_from' THEN related_party_id ELSE party_id END AS from_id,
+			       CASE WHEN relation_type = 'renamed_from' THEN party_id ELSE related_party_id END AS to_id
+			FROM party_relations
+			WHERE relation_type IN ('merged_into', 'renamed_from')
+		),
+		before AS (
+			SELECT e.*, 1 AS depth FROM edges e WHERE e.to_id = $1
+			UNION
+			SELECT e.*, b.depth + 1 FROM edges e JOIN before b ON e.to_id = b.from_id WHERE b.depth < 50
+		),
+		after AS (
+			SELECT e.*, 1 AS depth FROM edges e WHERE e.from_id = $1
+			UNION
+			SELECT e.*, a.depth + 1 FROM edges e JOIN after a ON e.from_id = a.to_id WHERE a.depth < 50
+		),
+		steps AS (
+			SELECT DISTINCT relation_type, start_date, from_id, to_id FROM before
+			UNION
+			SELECT DISTINCT relation_type, start_date, from_id, to_id FROM after
+		)
+		SELECT s.relation_type, s.start_date, ` + partyBriefColumns("fp") + `, ` + partyBriefColumns("tp") + `
+		FROM steps s
+		JOIN political_parties fp ON s.from_id = fp.id
+		JOIN political_parties tp ON s.to_id = tp.id
+		ORDER BY s.start_date ASC NULLS FIRST, fp.name, tp.name
+	`
+
+	rows, err := r.db.Query(ctx, query, partyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get party lineage: %w", err)
+	}
+	defer rows.Close()
+
+	lineage := []models.PartyLineageStep{}
+	for rows.Next() {
+		var step models.PartyLineageStep
+		dest := []interface{}{&step.RelationType, &step.Date}
+		dest = append(dest, partyBriefDest(&step.From)...)
+		dest = append(dest, partyBriefDest(&step.To)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan party lineage: %w", err)
+		}
+		lineage = append(lineage, step)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get party lineage: %w", err)
+	}
+
+	return lineage, nil
+}
+
+func (r *PoliticalPartyRepository) queryPartyBriefs(ctx context.Context, query string, args ...interface{}) ([]models.PartyBrief, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parties: %w", err)
+	}
+	defer rows.Close()
+
+	parties := []models.PartyBrief{}
+	for rows.Next() {
+		var party models.PartyBrief
+		if err := rows.Scan(partyBriefDest(&party)...); err != nil {
+			return nil, fmt.Errorf("failed to scan party: %w", err)
+		}
+		parties = append(parties, party)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list parties: %w", err)
+	}
+
+	return parties, nil
+}
@@ -44,16 +44,17 @@ func (r *PoliticalPartyRepository) Create(ctx context.Context, req *models.Creat
 
 func (r *PoliticalPartyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.PoliticalParty, error) {
 	party := &models.PoliticalParty{}
+	var focalX, focalY *float64
 
 	err := r.db.QueryRow(ctx, `
-		SELECT pp.id, pp.name, pp.slug, pp.abbreviation, pp.logo, pp.color, pp.description,
-		       pp.founded_year, pp.website, pp.is_major, pp.is_active, pp.created_at, pp.updated_at, pp.deleted_at,
+		SELECT pp.id, pp.name, pp.slug, pp.abbreviation, pp.logo, pp.logo_thumb, pp.logo_list, pp.logo_focal_x, pp.logo_focal_y,
+		       pp.color, pp.description, pp.founded_year, pp.website, pp.is_major, pp.is_active, pp.created_at, pp.updated_at, pp.deleted_at,
 		       COALESCE((SELECT COUNT(*) FROM politicians WHERE party_id = pp.id AND deleted_at IS NULL), 0) as member_count
 		FROM political_parties pp
 		WHERE pp.id = $1 AND pp.deleted_at IS NULL
 	`, id).Scan(
-		&party.ID, &party.Name, &party.Slug, &party.Abbreviation, &party.Logo, &party.Color,
-		&party.Description, &party.FoundedYear, &party.Website, &party.IsMajor, &party.IsActive,
+		&party.ID, &party.Name, &party.Slug, &party.Abbreviation, &party.Logo, &party.LogoThumb, &party.LogoList, &focalX, &focalY,
+		&party.Color, &party.Description, &party.FoundedYear, &party.Website, &party.IsMajor, &party.IsActive,
 		&party.CreatedAt, &party.UpdatedAt, &party.DeletedAt, &party.MemberCount,
 	)
 
@@ -63,22 +64,24 @@ func (r *PoliticalPartyRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to get political party: %w", err)
 	}
+	party.LogoFocal = models.NewFocalPoint(focalX, focalY)
 
 	return party, nil
 }
 
 func (r *PoliticalPartyRepository) GetBySlug(ctx context.Context, slug string) (*models.PoliticalParty, error) {
 	party := &models.PoliticalParty{}
+	var focalX, focalY *float64
 
 	err := r.db.QueryRow(ctx, `
-		SELECT pp.id, pp.name, pp.slug, pp.abbreviation, pp.logo, pp.color, pp.description,
-		       pp.founded_year, pp.website, pp.is_major, pp.is_active, pp.created_at, pp.updated_at, pp.deleted_at,
+		SELECT pp.id, pp.name, pp.slug, pp.abbreviation, pp.logo, pp.logo_thumb, pp.logo_list, pp.logo_focal_x, pp.logo_focal_y,
+		       pp.color, pp.description, pp.founded_year, pp.website, pp.is_major, pp.is_active, pp.created_at, pp.updated_at, pp.deleted_at,
 		       COALESCE((SELECT COUNT(*) FROM politicians WHERE party_id = pp.id AND deleted_at IS NULL), 0) as member_count
 		FROM political_parties pp
 		WHERE pp.slug = $1 AND pp.deleted_at IS NULL
 	`, slug).Scan(
-		&party.ID, &party.Name, &party.Slug, &party.Abbreviation, &party.Logo, &party.Color,
-		&party.Description, &party.FoundedYear, &party.Website, &party.IsMajor, &party.IsActive,
+		&party.ID, &party.Name, &party.Slug, &party.Abbreviation, &party.Logo, &party.LogoThumb, &party.LogoList, &focalX, &focalY,
+		&party.Color, &party.Description, &party.FoundedYear, &party.Website, &party.IsMajor, &party.IsActive,
 		&party.CreatedAt, &party.UpdatedAt, &party.DeletedAt, &party.MemberCount,
 	)
 
@@ -88,6 +91,7 @@ func (r *PoliticalPartyRepository) GetBySlug(ctx context.Context, slug string) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to get political party by slug: %w", err)
 	}
+	party.LogoFocal = models.NewFocalPoint(focalX, focalY)
 
 	return party, nil
 }
@@ -97,7 +101,7 @@ func (r *PoliticalPartyRepository) List(ctx context.Context, page, perPage int,
 
 	// Build query with filters
 	query := `
-		SELECT pp.id, pp.name, pp.slug, pp.abbreviation, pp.logo, pp.color, pp.is_major, pp.is_active,
+		SELECT pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color, pp.is_major, pp.is_active,
 		       COALESCE((SELECT COUNT(*) FROM politicians WHERE party_id = pp.id AND deleted_at IS NULL), 0) as member_count
 		FROM political_parties pp
 		WHERE pp.deleted_at IS NULL
@@ -155,7 +159,7 @@ func (r *PoliticalPartyRepository) List(ctx context.Context, page, perPage int,
 
 func (r *PoliticalPartyRepository) GetAll(ctx context.Context, activeOnly bool) ([]models.PoliticalPartyListItem, error) {
 	query := `
-		SELECT pp.id, pp.name, pp.slug, pp.abbreviation, pp.logo, pp.color, pp.is_major, pp.is_active,
+		SELECT pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color, pp.is_major, pp.is_active,
 		       COALESCE((SELECT COUNT(*) FROM politicians WHERE party_id = pp.id AND deleted_at IS NULL), 0) as member_count
 		FROM political_parties pp
 		WHERE pp.deleted_at IS NULL
@@ -196,6 +200,11 @@ func (r *PoliticalPartyRepository) Update(ctx context.Context, id uuid.UUID, req
 			slug = COALESCE($3, slug),
 			abbreviation = COALESCE($4, abbreviation),
 			logo = COALESCE($5, logo),
+			-- A logo set by URL has no variants or focal point of its own
+			logo_thumb = CASE WHEN $5 IS NULL THEN logo_thumb END,
+			logo_list = CASE WHEN $5 IS NULL THEN logo_list END,
+			logo_focal_x = CASE WHEN $5 IS NULL THEN logo_focal_x END,
+			logo_focal_y = CASE WHEN $5 IS NULL THEN logo_focal_y END,
 			color = COALESCE($6, color),
 			description = COALESCE($7, description),
 			founded_year = COALESCE($8, founded_year),
@@ -554,9 +563,9 @@ func (r *PoliticalPartyRepository) FindRepresentativesByBarangay(ctx context.Con
 	var cityID, provinceID, regionID uuid.UUID
 
 	err := r.db.QueryRow(ctx, `
-		SELECT b.city_id, c.province_id, p.region_id
+		SELECT b.city_municipality_id, c.province_id, p.region_id
 		FROM barangays b
-		JOIN cities_municipalities c ON b.city_id = c.id
+		JOIN cities_municipalities c ON b.city_municipality_id = c.id
 		JOIN provinces p ON c.province_id = p.id
 		WHERE b.id = $1
 	`, barangayID).Scan(&cityID, &provinceID, &regionID)
@@ -564,9 +573,31 @@ func (r *PoliticalPartyRepository) FindRepresentativesByBarangay(ctx context.Con
 		return nil, fmt.Errorf("failed to get barangay hierarchy: %w", err)
 	}
 
+	return r.findRepresentatives(ctx, regionID, provinceID, cityID, &barangayID)
+}
+
+// FindRepresentativesByCity returns the representatives of a city/municipality
+// down to the mayor, leaving out barangay officials
+func (r *PoliticalPartyRepository) FindRepresentativesByCity(ctx context.Context, cityID uuid.UUID) ([]models.PoliticianListItem, error) {
+	var provinceID, regionID uuid.UUID
+
+	err := r.db.QueryRow(ctx, `
+		SELECT c.province_id, p.region_id
+		FROM cities_municipalities c
+		JOIN provinces p ON c.province_id = p.id
+		WHERE c.id = $1
+	`, cityID).Scan(&provinceID, &regionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get city hierarchy: %w", err)
+	}
+
+	return r.findRepresentatives(ctx, regionID, provinceID, cityID, nil)
+}
+
+func (r *PoliticalPartyRepository) findRepresentatives(ctx context.Context, regionID, provinceID, cityID uuid.UUID, barangayID *uuid.UUID) ([]models.PoliticianListItem, error) {
 	// Find all politicians who represent any level of this location
 	rows, err := r.db.Query(ctx, `
-		SELECT DISTINCT ON (p.id) p.id, p.name, p.slug, p.photo, p.position, p.party, p.term_start, p.term_end,
+		SELECT DISTINCT ON (p.id) p.id, p.name, p.slug, COALESCE(p.photo_list, p.photo), p.position, p.party, p.term_start, p.term_end,
 		       COALESCE((SELECT COUNT(*) FROM article_politicians WHERE politician_id = p.id), 0) as article_count
 		FROM politicians p
 		JOIN politician_jurisdictions pj ON p.id = pj.politician_id
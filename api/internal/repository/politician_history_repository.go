@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// politicianHistoryColumns are the tracked columns, in the order
+// scanHistoryFields reads them
+const politicianHistoryColumns = `name, slug, nickname, photo, position, party, short_bio, term_start, term_end`
+
+func scanHistoryFields(row pgx.Row, p *models.Politician) error {
+	return row.Scan(&p.Name, &p.Slug, &p.Nickname, &p.Photo, &p.Position, &p.Party, &p.ShortBio, &p.TermStart, &p.TermEnd)
+}
+
+// lockHistoryFields reads the tracked fields before an edit, locking the row
+// until the transaction ends. It returns nil if the politician doesn't exist.
+func lockHistoryFields(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*models.Politician, error) {
+	before := &models.Politician{ID: id}
+	err := scanHistoryFields(tx.QueryRow(ctx, `
+		SELECT `+politicianHistoryColumns+`
+		FROM politicians
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`, id), before)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get politician: %w", err)
+	}
+	return before, nil
+}
+
+// recordHistory logs each tracked field that differs between before and after
+func recordHistory(ctx context.Context, tx pgx.Tx, before, after *models.Politician, changedBy *uuid.UUID) error {
+	for _, change := range models.DiffPoliticianHistory(before, after) {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO politician_history (politician_id, field_name, old_value, new_value, changed_by)
+			VALUES ($1, $2, $3, $4, $5)
+		`, after.ID, change.FieldName, change.OldValue, change.NewValue, changedBy)
+		if err != nil {
+			return fmt.Errorf("failed to record politician history: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetHistory returns a politician's most recent field changes, newest first
+func (r *PoliticianRepository) GetHistory(ctx context.Context, politicianID uuid.UUID, limit int) ([]models.PoliticianHistoryEntry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT h.id, h.politician_id, h.field_name, h.old_value, h.new_value, h.changed_by, h.changed_at, u.name
+		FROM politician_history h
+		LEFT JOIN users u ON h.changed_by = u.id
+		WHERE h.politician_id = $1
+		ORDER BY h.changed_at DESC, h.id
+		LIMIT $2
+	`, politicianID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list politician history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.PoliticianHistoryEntry{}
+	for rows.Next() {
+		var e models.PoliticianHistoryEntry
+		if err := rows.Scan(&e.ID, &e.PoliticianID, &e.FieldName, &e.OldValue, &e.NewValue, &e.ChangedBy, &e.ChangedAt, &e.ChangedByName); err != nil {
+			return nil, fmt.Errorf("failed to scan politician history: %w", err)
+		}
+		history = append(history, e)
+	}
+
+	return history, rows.Err()
+}
+
+// GetBioAtDate reconstructs the politician as they were at asOf by undoing,
+// newest first, every change made after it. It returns nil if the politician
+// doesn't exist or was added after asOf. Untracked fields keep their current
+// values.
+func (r *PoliticianRepository) GetBioAtDate(ctx context.Context, politicianID uuid.UUID, asOf time.Time) (*models.Politician, error) {
+	politician, err := r.GetByID(ctx, politicianID)
+	if err != nil || politician == nil {
+		return nil, err
+	}
+	if politician.CreatedAt.After(asOf) {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT field_name, old_value
+		FROM politician_history
+		WHERE politician_id = $1 AND changed_at > $2
+		ORDER BY changed_at DESC, id
+	`, politicianID, asOf.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list politician history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var field string
+		var oldValue *string
+		if err := rows.Scan(&field, &oldValue); err != nil {
+			return nil, fmt.Errorf("failed to scan politician history: %w", err)
+		}
+		politician.SetHistoryValue(field, oldValue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list politician history: %w", err)
+	}
+
+	return politician, nil
+}
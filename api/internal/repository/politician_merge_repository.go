@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// mergeTransfer describes how rows in one politician-linked table are copied
+// from a merged duplicate to the politician it is merged into
+type mergeTransfer struct {
+	Table string
+	// Columns are copied as-is; politician_id is always rewritten to the target
+	Columns []string
+	// DedupeOn lists columns that identify the same row when the table has no
+	// unique constraint of its own; rows the target already has are skipped.
+	// NULLs compare equal so rows with open dates still dedupe.
+	DedupeOn []string
+}
+
+// politicianMergeTransfers are the tables carried over by a merge. Tables with
+// a unique (x, politician_id) constraint rely on ON CONFLICT DO NOTHING.
+var politicianMergeTransfers = []mergeTransfer{
+	{
+		Table:   "bill_authors",
+		Columns: []string{"bill_id", "is_principal_author", "created_at"},
+	},
+	{
+		Table: "candidates",
+		Columns: []string{
+			"election_position_id", "party_id", "ballot_number", "ballot_name", "campaign_slogan", "platform",
+			"status", "filing_date", "is_incumbent", "is_winner", "votes_received", "vote_percentage", "created_at",
+		},
+	},
+	{
+		Table:   "article_politicians",
+		Columns: []string{"article_id"},
+	},
+	{
+		Table:    "politician_tenures",
+		Columns:  []string{"position_id", "started_at", "ended_at", "election_id", "appointment_reason", "is_current", "created_at"},
+		DedupeOn: []string{"position_id", "started_at"},
+	},
+	{
+		Table:    "committee_memberships",
+		Columns:  []string{"committee_id", "role", "start_date", "end_date", "session_id", "created_at"},
+		DedupeOn: []string{"committee_id", "role", "start_date"},
+	},
+}
+
+// copySQL builds the INSERT ... SELECT that copies the source's rows ($1) to
+// the target ($2)
+func (t mergeTransfer) copySQL() string {
+	cols := strings.Join(t.Columns, ", ")
+
+	selectCols := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		selectCols[i] = "src." + c
+	}
+
+	where := "src.politician_id = $1"
+	if len(t.DedupeOn) > 0 {
+		matches := make([]string, len(t.DedupeOn))
+		for i, c := range t.DedupeOn {
+			matches[i] = fmt.Sprintf("dst.%s IS NOT DISTINCT FROM src.%s", c, c)
+		}
+		where += fmt.Sprintf(
+			" AND NOT EXISTS (SELECT 1 FROM %s dst WHERE dst.politician_id = $2 AND %s)",
+			t.Table, strings.Join(matches, " AND "),
+		)
+	}
+
+	return fmt.Sprintf(
+		"INSERT INTO %s (politician_id, %s) SELECT $2, %s FROM %s src WHERE %s ON CONFLICT DO NOTHING",
+		t.Table, cols, strings.Join(selectCols, ", "), t.Table, where,
+	)
+}
+
+// MergePolitician copies the source politician's linked records to the target
+// and makes the target the primary politician of the source's articles, then
+// soft-deletes the source and points it at the target. Politicians that
+// were previously merged into the source are re-pointed at the target too, so
+// old slugs always redirect in a single hop.
+func (r *PoliticianRepository) MergePolitician(ctx context.Context, sourceID, targetID uuid.UUID) (*models.PoliticianMergeResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result := &models.PoliticianMergeResult{
+		SourceID: sourceID,
+		TargetID: targetID,
+		Copied:   make(map[string]int64, len(politicianMergeTransfers)),
+	}
+
+	err = tx.QueryRow(ctx, `
+		SELECT slug FROM politicians WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+	`, targetID).Scan(&result.TargetSlug)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("merge target not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock merge target: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE politicians SET deleted_at = NOW(), merged_into_id = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, sourceID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark politician as merged: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, fmt.Errorf("politician not found")
+	}
+
+	for _, transfer := range politicianMergeTransfers {
+		tag, err := tx.Exec(ctx, transfer.copySQL(), sourceID, targetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", transfer.Table, err)
+		}
+		result.Copied[transfer.Table] = tag.RowsAffected()
+	}
+
+	tag, err = tx.Exec(ctx, `UPDATE articles SET primary_politician_id = $2 WHERE primary_politician_id = $1`, sourceID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move primary politician of articles: %w", err)
+	}
+	result.PrimaryArticles = tag.RowsAffected()
+
+	_, err = tx.Exec(ctx, `UPDATE politicians SET merged_into_id = $2 WHERE merged_into_id = $1`, sourceID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-point merged politicians: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit merge: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetMergedTargetSlug returns the slug of the active politician that the
+// politician with the given slug was merged into, or "" if it was not merged
+func (r *PoliticianRepository) GetMergedTargetSlug(ctx context.Context, slug string) (string, error) {
+	var targetSlug string
+	err := r.db.QueryRow(ctx, `
+		SELECT t.slug
+		FROM politicians s
+		JOIN politicians t ON t.id = s.merged_into_id
+		WHERE s.slug = $1 AND s.deleted_at IS NOT NULL AND t.deleted_at IS NULL
+	`, slug).Scan(&targetSlug)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve merged politician: %w", err)
+	}
+	return targetSlug, nil
+}
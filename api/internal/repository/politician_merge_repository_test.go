@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoliticianMergeTransfers_CoverLinkedTables(t *testing.T) {
+	tables := make([]string, 0, len(politicianMergeTransfers))
+	for _, transfer := range politicianMergeTransfers {
+		tables = append(tables, transfer.Table)
+	}
+
+	assert.ElementsMatch(t, []string{"bill_authors", "candidates", "article_politicians", "politician_tenures", "committee_memberships"}, tables)
+}
+
+func TestMergeTransfer_CopySQL(t *testing.T) {
+	for _, transfer := range politicianMergeTransfers {
+		t.Run(transfer.Table, func(t *testing.T) {
+			sql := transfer.copySQL()
+
+			assert.True(t, strings.HasPrefix(sql, "INSERT INTO "+transfer.Table+" (politician_id, "))
+			assert.Contains(t, sql, "SELECT $2, ")
+			assert.Contains(t, sql, "FROM "+transfer.Table+" src WHERE src.politician_id = $1")
+			assert.True(t, strings.HasSuffix(sql, "ON CONFLICT DO NOTHING"))
+
+			// The source's own politician_id must never be copied across
+			for _, c := range transfer.Columns {
+				assert.NotEqual(t, "politician_id", c)
+				assert.NotEqual(t, "id", c)
+			}
+		})
+	}
+}
+
+func TestMergeTransfer_CopySQLColumnsLineUp(t *testing.T) {
+	transfer := mergeTransfer{Table: "bill_authors", Columns: []string{"bill_id", "is_principal_author"}}
+
+	assert.Equal(t,
+		"INSERT INTO bill_authors (politician_id, bill_id, is_principal_author) "+
+			"SELECT $2, src.bill_id, src.is_principal_author FROM bill_authors src "+
+			"WHERE src.politician_id = $1 ON CONFLICT DO NOTHING",
+		transfer.copySQL(),
+	)
+}
+
+func TestMergeTransfer_CopySQLDedupe(t *testing.T) {
+	transfer := mergeTransfer{
+		Table:    "politician_tenures",
+		Columns:  []string{"position_id", "started_at"},
+		DedupeOn: []string{"position_id", "started_at"},
+	}
+
+	assert.Contains(t, transfer.copySQL(),
+		"AND NOT EXISTS (SELECT 1 FROM politician_tenures dst WHERE dst.politician_id = $2 "+
+			"AND dst.position_id IS NOT DISTINCT FROM src.position_id AND dst.started_at IS NOT DISTINCT FROM src.started_at)",
+	)
+}
+
+func TestPoliticianRepository_MergePolitician(t *testing.T) {
+	ctx := context.Background()
+	pool := openTestDB(t)
+
+	suffix := uuid.NewString()[:8]
+	insert := func(query string, args ...interface{}) uuid.UUID {
+		var id uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, query, args...).Scan(&id))
+		return id
+	}
+	exec := func(query string, args ...interface{}) {
+		_, err := pool.Exec(ctx, query, args...)
+		require.NoError(t, err)
+	}
+
+	source := insert("INSERT INTO politicians (name, slug) VALUES ($1, $1) RETURNING id", "duplicate-"+suffix)
+	target := insert("INSERT INTO politicians (name, slug) VALUES ($1, $1) RETURNING id", "canonical-"+suffix)
+	positionID := insert(`
+		INSERT INTO government_positions (name, slug, level, branch) VALUES ('Mayor', $1, 'municipal', 'executive')
+		RETURNING id
+	`, "mayor-"+suffix)
+	featured := insert("INSERT INTO articles (slug, title, content, primary_politician_id) VALUES ($1, $1, 'body', $2) RETURNING id", "featured-"+suffix, source)
+	both := insert("INSERT INTO articles (slug, title, content) VALUES ($1, $1, 'body') RETURNING id", "both-"+suffix)
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM articles WHERE id = ANY($1)", []uuid.UUID{featured, both})
+		_, _ = pool.Exec(ctx, "DELETE FROM politician_tenures WHERE position_id = $1", positionID)
+		_, _ = pool.Exec(ctx, "DELETE FROM politicians WHERE id = ANY($1)", []uuid.UUID{source, target})
+		_, _ = pool.Exec(ctx, "DELETE FROM government_positions WHERE id = $1", positionID)
+	})
+
+	// Both tagged on one article and holding the same tenure; only the source
+	// is tagged on the featured article and holds the later tenure
+	exec("INSERT INTO article_politicians (article_id, politician_id) VALUES ($1, $3), ($2, $3), ($2, $4)", featured, both, source, target)
+	exec(`
+		INSERT INTO politician_tenures (politician_id, position_id, started_at, ended_at) VALUES
+			($1, $3, DATE '2016-06-30', DATE '2019-06-30'),
+			($2, $3, DATE '2016-06-30', DATE '2019-06-30'),
+			($1, $3, DATE '2019-06-30', NULL)
+	`, source, target, positionID)
+
+	repo := NewPoliticianRepository(pool)
+	result, err := repo.MergePolitician(ctx, source, target)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Copied["article_politicians"])
+	assert.Equal(t, int64(1), result.Copied["politician_tenures"])
+	assert.Equal(t, int64(1), result.PrimaryArticles)
+
+	var primary uuid.UUID
+	require.NoError(t, pool.QueryRow(ctx, "SELECT primary_politician_id FROM articles WHERE id = $1", featured).Scan(&primary))
+	assert.Equal(t, target, primary)
+
+	var tagged []uuid.UUID
+	rows, err := pool.Query(ctx, "SELECT article_id FROM article_politicians WHERE politician_id = $1", target)
+	require.NoError(t, err)
+	for rows.Next() {
+		var id uuid.UUID
+		require.NoError(t, rows.Scan(&id))
+		tagged = append(tagged, id)
+	}
+	rows.Close()
+	require.NoError(t, rows.Err())
+	assert.ElementsMatch(t, []uuid.UUID{featured, both}, tagged)
+
+	var tenures int
+	require.NoError(t, pool.QueryRow(ctx, "SELECT COUNT(*) FROM politician_tenures WHERE politician_id = $1", target).Scan(&tenures))
+	assert.Equal(t, 2, tenures)
+
+	slug, err := repo.GetMergedTargetSlug(ctx, "duplicate-"+suffix)
+	require.NoError(t, err)
+	assert.Equal(t, "canonical-"+suffix, slug)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
@@ -21,8 +22,8 @@ func NewPoliticianRepository(db *pgxpool.Pool) *PoliticianRepository {
 
 func (r *PoliticianRepository) Create(ctx context.Context, politician *models.Politician) error {
 	query := `
-		INSERT INTO politicians (name, slug, photo, position, party, short_bio, term_start, term_end)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO politicians (name, slug, photo, position, party, short_bio, term_start, term_end, nickname)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -35,6 +36,7 @@ func (r *PoliticianRepository) Create(ctx context.Context, politician *models.Po
 		politician.ShortBio,
 		politician.TermStart,
 		politician.TermEnd,
+		politician.Nickname,
 	).Scan(&politician.ID, &politician.CreatedAt, &politician.UpdatedAt)
 
 	if err != nil {
@@ -46,17 +48,24 @@ func (r *PoliticianRepository) Create(ctx context.Context, politician *models.Po
 
 func (r *PoliticianRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Politician, error) {
 	query := `
-		SELECT id, name, slug, photo, position, party, short_bio, term_start, term_end, created_at, updated_at, deleted_at
+		SELECT id, name, slug, nickname, photo, photo_thumb, photo_list, photo_focal_x, photo_focal_y,
+		       position, party, short_bio, term_start, term_end, created_at, updated_at, deleted_at
 		FROM politicians
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	politician := &models.Politician{}
+	var focalX, focalY *float64
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&politician.ID,
 		&politician.Name,
 		&politician.Slug,
+		&politician.Nickname,
 		&politician.Photo,
+		&politician.PhotoThumb,
+		&politician.PhotoList,
+		&focalX,
+		&focalY,
 		&politician.Position,
 		&politician.Party,
 		&politician.ShortBio,
@@ -73,23 +82,31 @@ func (r *PoliticianRepository) GetByID(ctx context.Context, id uuid.UUID) (*mode
 	if err != nil {
 		return nil, fmt.Errorf("failed to get politician: %w", err)
 	}
+	politician.PhotoFocal = models.NewFocalPoint(focalX, focalY)
 
 	return politician, nil
 }
 
 func (r *PoliticianRepository) GetBySlug(ctx context.Context, slug string) (*models.Politician, error) {
 	query := `
-		SELECT id, name, slug, photo, position, party, short_bio, term_start, term_end, created_at, updated_at, deleted_at
+		SELECT id, name, slug, nickname, photo, photo_thumb, photo_list, photo_focal_x, photo_focal_y,
+		       position, party, short_bio, term_start, term_end, created_at, updated_at, deleted_at
 		FROM politicians
 		WHERE slug = $1 AND deleted_at IS NULL
 	`
 
 	politician := &models.Politician{}
+	var focalX, focalY *float64
 	err := r.db.QueryRow(ctx, query, slug).Scan(
 		&politician.ID,
 		&politician.Name,
 		&politician.Slug,
+		&politician.Nickname,
 		&politician.Photo,
+		&politician.PhotoThumb,
+		&politician.PhotoList,
+		&focalX,
+		&focalY,
 		&politician.Position,
 		&politician.Party,
 		&politician.ShortBio,
@@ -106,19 +123,58 @@ func (r *PoliticianRepository) GetBySlug(ctx context.Context, slug string) (*mod
 	if err != nil {
 		return nil, fmt.Errorf("failed to get politician by slug: %w", err)
 	}
+	politician.PhotoFocal = models.NewFocalPoint(focalX, focalY)
 
 	return politician, nil
 }
 
+// currentTenure matches tenures of the politician aliased as p that cover
+// today
+const currentTenure = `
+	t.politician_id = p.id AND t.started_at <= CURRENT_DATE AND (t.ended_at IS NULL OR t.ended_at >= CURRENT_DATE)
+`
+
+// politicianActive matches politicians serving today. Politicians with
+// recorded tenures are judged by them; the rest by their single term fields.
+const politicianActive = `
+	CASE WHEN EXISTS (SELECT 1 FROM politician_tenures t WHERE t.politician_id = p.id)
+		THEN EXISTS (SELECT 1 FROM politician_tenures t WHERE ` + currentTenure + `)
+		ELSE p.term_start <= CURRENT_DATE AND (p.term_end IS NULL OR p.term_end >= CURRENT_DATE)
+	END
+`
+
 func (r *PoliticianRepository) List(ctx context.Context, filter *models.PoliticianFilter, page, perPage int) (*models.PaginatedPoliticians, error) {
-	// Build base query with article count and party info
+	// Build base query with article count and party info. The position is the
+	// current tenure's when there is one, and the location the most specific
+	// place of the politician's first jurisdiction.
 	baseQuery := `
-		SELECT p.id, p.name, p.slug, p.photo, p.position, p.party, p.term_start, p.term_end,
+		SELECT p.id, p.name, p.slug, COALESCE(p.photo_list, p.photo), COALESCE(ct.position_name, p.position), p.party,
+			p.level, p.branch, p.term_start, p.term_end,
 			(SELECT COUNT(*) FROM articles a WHERE a.primary_politician_id = p.id AND a.deleted_at IS NULL) +
 			(SELECT COUNT(*) FROM article_politicians ap JOIN articles a ON ap.article_id = a.id WHERE ap.politician_id = p.id AND a.deleted_at IS NULL) as article_count,
-			pp.id, pp.name, pp.slug, pp.abbreviation, pp.logo, pp.color
+			pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color,
+			jl.location
 		FROM politicians p
 		LEFT JOIN political_parties pp ON p.party_id = pp.id
+		LEFT JOIN LATERAL (
+			SELECT gp.name AS position_name
+			FROM politician_tenures t
+			JOIN government_positions gp ON t.position_id = gp.id
+			WHERE ` + currentTenure + `
+			ORDER BY t.is_current DESC, t.started_at DESC
+			LIMIT 1
+		) ct ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT COALESCE(b.name, cm.name, pr.name, rg.name) AS location
+			FROM politician_jurisdictions j
+			LEFT JOIN regions rg ON j.region_id = rg.id
+			LEFT JOIN provinces pr ON j.province_id = pr.id
+			LEFT JOIN cities_municipalities cm ON j.city_id = cm.id
+			LEFT JOIN barangays b ON j.barangay_id = b.id
+			WHERE j.politician_id = p.id AND NOT COALESCE(j.is_national, FALSE)
+			ORDER BY j.created_at, j.id
+			LIMIT 1
+		) jl ON TRUE
 		WHERE p.deleted_at IS NULL
 	`
 	countQuery := "SELECT COUNT(*) FROM politicians p WHERE p.deleted_at IS NULL"
@@ -146,6 +202,31 @@ func (r *PoliticianRepository) List(ctx context.Context, filter *models.Politici
 			argNum++
 		}
 
+		if filter.Level != nil && *filter.Level != "" {
+			conditions = append(conditions, fmt.Sprintf("p.level = $%d", argNum))
+			args = append(args, *filter.Level)
+			argNum++
+		}
+
+		if filter.Branch != nil && *filter.Branch != "" {
+			conditions = append(conditions, fmt.Sprintf("p.branch = $%d", argNum))
+			args = append(args, *filter.Branch)
+			argNum++
+		}
+
+		if filter.PositionID != nil {
+			conditions = append(conditions, fmt.Sprintf(
+				"(p.position_id = $%d OR EXISTS (SELECT 1 FROM politician_tenures t WHERE %s AND t.position_id = $%d))",
+				argNum, currentTenure, argNum,
+			))
+			args = append(args, *filter.PositionID)
+			argNum++
+		}
+
+		if filter.Active {
+			conditions = append(conditions, politicianActive)
+		}
+
 		if filter.IncludeDeleted {
 			// Remove the deleted_at IS NULL condition
 			baseQuery = strings.Replace(baseQuery, "WHERE p.deleted_at IS NULL", "WHERE 1=1", 1)
@@ -187,8 +268,9 @@ func (r *PoliticianRepository) List(ctx context.Context, filter *models.Politici
 
 		err := rows.Scan(
 			&p.ID, &p.Name, &p.Slug, &p.Photo, &p.Position, &p.Party,
-			&p.TermStart, &p.TermEnd, &p.ArticleCount,
+			&p.Level, &p.Branch, &p.TermStart, &p.TermEnd, &p.ArticleCount,
 			&partyID, &partyName, &partySlug, &partyAbbr, &partyLogo, &partyColor,
+			&p.Location,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan politician: %w", err)
@@ -248,36 +330,152 @@ func (r *PoliticianRepository) ListAll(ctx context.Context) ([]models.Politician
 	return politicians, nil
 }
 
-// Search returns politicians matching the query (for autocomplete)
-func (r *PoliticianRepository) Search(ctx context.Context, query string, limit int) ([]models.Politician, error) {
+// ShortSearchLength is the query length below which politician search
+// matches name prefixes instead of using trigrams, which need three letters
+// to be useful
+const ShortSearchLength = 3
+
+// politicianSearchSelect reads the columns scanned by scanSearchResults, with
+// the politician's current position and party
+const politicianSearchSelect = `
+		SELECT p.id, p.name, p.slug, p.nickname, p.photo, p.position, p.party, p.short_bio,
+			p.term_start, p.term_end, p.created_at, p.updated_at,
+			gp.id, gp.name, gp.slug, gp.level::text, gp.branch::text, COALESCE(gp.is_elected, false),
+			pp.id, pp.name, pp.slug, pp.abbreviation, COALESCE(pp.logo_list, pp.logo), pp.color
+		FROM politicians p
+		CROSS JOIN q
+		LEFT JOIN LATERAL (
+			SELECT t.position_id FROM politician_tenures t
+			WHERE t.politician_id = p.id AND t.is_current
+			ORDER BY t.started_at DESC
+			LIMIT 1
+		) ct ON true
+		LEFT JOIN government_positions gp ON gp.id = COALESCE(ct.position_id, p.position_id)
+		LEFT JOIN political_parties pp ON pp.id = p.party_id`
+
+// Search finds politicians by name for autocomplete. The query is normalized
+// like the stored names (see normalize_person_name) and matched against the
+// nickname, the full name, any single surname, and by trigram similarity
+// using pg_trgm's default thresholds. Exact nickname matches rank first, then
+// exact surnames, then other whole-word matches, then fuzzy ones; a query that
+// only matches the position or party text ranks last. Queries shorter than
+// ShortSearchLength only match the start of the nickname or of a word in the
+// name. Each result carries its current position and party to tell relatives
+// apart. With hasSocial set, only politicians linking that platform are
+// returned.
+func (r *PoliticianRepository) Search(ctx context.Context, query string, hasSocial *models.SocialPlatform, limit int) ([]models.Politician, error) {
 	sqlQuery := `
-		SELECT id, name, slug, photo, position, party, short_bio, term_start, term_end, created_at, updated_at
-		FROM politicians
-		WHERE deleted_at IS NULL AND (name ILIKE $1 OR position ILIKE $1 OR party ILIKE $1)
-		ORDER BY name ASC
+		WITH q AS (SELECT normalize_person_name($1) AS term)` + politicianSearchSelect + `
+		WHERE p.deleted_at IS NULL
+		  AND ($1 = '' OR (q.term <> '' AND (
+			p.search_nickname = q.term
+			OR p.search_name % q.term
+			OR q.term <% p.search_name
+			OR p.search_nickname % q.term
+			OR p.position ILIKE $2
+			OR p.party ILIKE $2
+		  )))
+		  AND ($4::social_platform IS NULL OR EXISTS (
+			SELECT 1 FROM politician_social_links sl WHERE sl.politician_id = p.id AND sl.platform = $4
+		  ))
+		ORDER BY
+			CASE
+				WHEN p.search_nickname = q.term THEN 0
+				WHEN p.search_name = q.term OR p.search_name LIKE '% ' || q.term THEN 1
+				WHEN ' ' || p.search_name || ' ' LIKE '% ' || q.term || ' %' THEN 2
+				WHEN p.search_name % q.term OR q.term <% p.search_name OR p.search_nickname % q.term THEN 3
+				ELSE 4
+			END,
+			GREATEST(
+				similarity(p.search_name, q.term),
+				word_similarity(q.term, p.search_name),
+				COALESCE(similarity(p.search_nickname, q.term), 0)
+			) DESC,
+			p.name ASC
+		LIMIT $3
+	`
+	args := []interface{}{query, "%" + query + "%", limit, hasSocial}
+	if query != "" && utf8.RuneCountInString(strings.TrimSpace(query)) < ShortSearchLength {
+		sqlQuery = `
+		WITH q AS (SELECT normalize_person_name($1) AS term)` + politicianSearchSelect + `
+		WHERE p.deleted_at IS NULL
+		  AND q.term <> ''
+		  AND (p.nickname ILIKE q.term || '%' OR p.name ILIKE q.term || '%' OR p.name ILIKE '% ' || q.term || '%')
+		  AND ($3::social_platform IS NULL OR EXISTS (
+			SELECT 1 FROM politician_social_links sl WHERE sl.politician_id = p.id AND sl.platform = $3
+		  ))
+		ORDER BY
+			CASE
+				WHEN p.nickname ILIKE q.term || '%' THEN 0
+				WHEN p.name ILIKE q.term || '%' THEN 1
+				ELSE 2
+			END,
+			p.name ASC
 		LIMIT $2
 	`
+		args = []interface{}{query, limit, hasSocial}
+	}
 
-	rows, err := r.db.Query(ctx, sqlQuery, "%"+query+"%", limit)
+	rows, err := r.db.Query(ctx, sqlQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search politicians: %w", err)
 	}
 	defer rows.Close()
 
+	return scanSearchResults(rows)
+}
+
+func scanSearchResults(rows pgx.Rows) ([]models.Politician, error) {
 	politicians := []models.Politician{}
 	for rows.Next() {
 		var p models.Politician
-		err := rows.Scan(&p.ID, &p.Name, &p.Slug, &p.Photo, &p.Position, &p.Party, &p.ShortBio, &p.TermStart, &p.TermEnd, &p.CreatedAt, &p.UpdatedAt)
+		var positionID *uuid.UUID
+		var positionName, positionSlug, positionLevel, positionBranch *string
+		var positionElected bool
+		var partyID *uuid.UUID
+		var partyName, partySlug *string
+		var partyAbbr, partyLogo, partyColor *string
+
+		err := rows.Scan(
+			&p.ID, &p.Name, &p.Slug, &p.Nickname, &p.Photo, &p.Position, &p.Party, &p.ShortBio,
+			&p.TermStart, &p.TermEnd, &p.CreatedAt, &p.UpdatedAt,
+			&positionID, &positionName, &positionSlug, &positionLevel, &positionBranch, &positionElected,
+			&partyID, &partyName, &partySlug, &partyAbbr, &partyLogo, &partyColor,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan politician: %w", err)
 		}
+
+		if positionID != nil {
+			p.PositionInfo = &models.GovernmentPositionInfo{
+				ID:        *positionID,
+				Name:      *positionName,
+				Slug:      *positionSlug,
+				Level:     *positionLevel,
+				Branch:    *positionBranch,
+				IsElected: positionElected,
+			}
+		}
+		if partyID != nil && partyName != nil && partySlug != nil {
+			p.PartyInfo = &models.PartyBrief{
+				ID:           *partyID,
+				Name:         *partyName,
+				Slug:         *partySlug,
+				Abbreviation: partyAbbr,
+				Logo:         partyLogo,
+				Color:        partyColor,
+			}
+		}
+
 		politicians = append(politicians, p)
 	}
 
-	return politicians, nil
+	return politicians, rows.Err()
 }
 
-func (r *PoliticianRepository) Update(ctx context.Context, id uuid.UUID, req *models.UpdatePoliticianRequest) error {
+// Update applies an edit and logs each changed bio field to
+// politician_history. changedBy is the editor, if known.
+func (r *PoliticianRepository) Update(ctx context.Context, id uuid.UUID, req *models.UpdatePoliticianRequest, changedBy *uuid.UUID) error {
 	// Parse term dates if provided
 	var termStart, termEnd interface{}
 	if req.TermStart != nil {
@@ -292,16 +490,37 @@ func (r *PoliticianRepository) Update(ctx context.Context, id uuid.UUID, req *mo
 		SET name = COALESCE($1, name),
 			slug = COALESCE($2, slug),
 			photo = COALESCE($3, photo),
+			-- A photo set by URL has no variants or focal point of its own
+			photo_thumb = CASE WHEN $3 IS NULL THEN photo_thumb END,
+			photo_list = CASE WHEN $3 IS NULL THEN photo_list END,
+			photo_focal_x = CASE WHEN $3 IS NULL THEN photo_focal_x END,
+			photo_focal_y = CASE WHEN $3 IS NULL THEN photo_focal_y END,
 			position = COALESCE($4, position),
 			party = COALESCE($5, party),
 			short_bio = COALESCE($6, short_bio),
 			term_start = COALESCE($7::date, term_start),
 			term_end = COALESCE($8::date, term_end),
+			nickname = CASE WHEN $10::text IS NULL THEN nickname ELSE NULLIF(btrim($10), '') END,
 			updated_at = NOW()
 		WHERE id = $9 AND deleted_at IS NULL
-	`
+		RETURNING ` + politicianHistoryColumns
 
-	result, err := r.db.Exec(ctx, query,
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := lockHistoryFields(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return fmt.Errorf("politician not found")
+	}
+
+	after := &models.Politician{ID: id}
+	err = scanHistoryFields(tx.QueryRow(ctx, query,
 		req.Name,
 		req.Slug,
 		req.Photo,
@@ -311,13 +530,18 @@ func (r *PoliticianRepository) Update(ctx context.Context, id uuid.UUID, req *mo
 		termStart,
 		termEnd,
 		id,
-	)
+		req.Nickname,
+	), after)
 	if err != nil {
 		return fmt.Errorf("failed to update politician: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("politician not found")
+	if err := recordHistory(ctx, tx, before, after, changedBy); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -339,7 +563,7 @@ func (r *PoliticianRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *PoliticianRepository) Restore(ctx context.Context, id uuid.UUID) error {
-	query := "UPDATE politicians SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL"
+	query := "UPDATE politicians SET deleted_at = NULL, merged_into_id = NULL WHERE id = $1 AND deleted_at IS NOT NULL"
 
 	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
@@ -420,3 +644,185 @@ func (r *PoliticianRepository) SetArticleMentionedPoliticians(ctx context.Contex
 
 	return nil
 }
+
+// Tenures
+
+const tenureColumns = `
+	t.id, t.politician_id, t.position_id, t.started_at, t.ended_at, t.election_id,
+	t.appointment_reason, t.is_current, t.created_at, t.updated_at, gp.name
+`
+
+func scanTenure(row pgx.Row) (*models.PoliticianTenure, error) {
+	tenure := &models.PoliticianTenure{}
+	err := row.Scan(
+		&tenure.ID, &tenure.PoliticianID, &tenure.PositionID, &tenure.StartedAt, &tenure.EndedAt, &tenure.ElectionID,
+		&tenure.AppointmentReason, &tenure.IsCurrent, &tenure.CreatedAt, &tenure.UpdatedAt, &tenure.PositionName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tenure, nil
+}
+
+// ListTenures returns every tenure for a politician, most recent first
+func (r *PoliticianRepository) ListTenures(ctx context.Context, politicianID uuid.UUID) ([]models.PoliticianTenure, error) {
+	query := `
+		SELECT ` + tenureColumns + `
+		FROM politician_tenures t
+		JOIN government_positions gp ON t.position_id = gp.id
+		WHERE t.politician_id = $1
+		ORDER BY t.started_at DESC, t.created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, politicianID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenures: %w", err)
+	}
+	defer rows.Close()
+
+	tenures := []models.PoliticianTenure{}
+	for rows.Next() {
+		tenure, err := scanTenure(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tenure: %w", err)
+		}
+		tenures = append(tenures, *tenure)
+	}
+
+	return tenures, nil
+}
+
+func (r *PoliticianRepository) GetTenureByID(ctx context.Context, id uuid.UUID) (*models.PoliticianTenure, error) {
+	query := `
+		SELECT ` + tenureColumns + `
+		FROM politician_tenures t
+		JOIN government_positions gp ON t.position_id = gp.id
+		WHERE t.id = $1
+	`
+
+	tenure, err := scanTenure(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenure: %w", err)
+	}
+
+	return tenure, nil
+}
+
+func (r *PoliticianRepository) CreateTenure(ctx context.Context, politicianID uuid.UUID, req *models.CreatePoliticianTenureRequest) (uuid.UUID, error) {
+	query := `
+		INSERT INTO politician_tenures (politician_id, position_id, started_at, ended_at, election_id, appointment_reason, is_current)
+		VALUES ($1, $2, $3::date, $4::date, $5, $6, $7)
+		RETURNING id
+	`
+
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, query,
+		politicianID, req.PositionID, req.StartedAt, req.EndedAt, req.ElectionID, req.AppointmentReason, req.IsCurrent,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create tenure: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateTenure applies a partial update. Marking a tenure current clears its end date.
+func (r *PoliticianRepository) UpdateTenure(ctx context.Context, id uuid.UUID, req *models.UpdatePoliticianTenureRequest) error {
+	query := `
+		UPDATE politician_tenures
+		SET position_id = COALESCE($1, position_id),
+			started_at = COALESCE($2::date, started_at),
+			ended_at = CASE WHEN $6::boolean IS TRUE THEN NULL ELSE COALESCE($3::date, ended_at) END,
+			election_id = COALESCE($4, election_id),
+			appointment_reason = COALESCE($5, appointment_reason),
+			is_current = COALESCE($6, is_current),
+			updated_at = NOW()
+		WHERE id = $7
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		req.PositionID, req.StartedAt, req.EndedAt, req.ElectionID, req.AppointmentReason, req.IsCurrent, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update tenure: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tenure not found")
+	}
+
+	return nil
+}
+
+func (r *PoliticianRepository) DeleteTenure(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM politician_tenures WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tenure: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tenure not found")
+	}
+
+	return nil
+}
+
+// GetCareerTimeline returns the offices a politician has held, most recent first
+func (r *PoliticianRepository) GetCareerTimeline(ctx context.Context, politicianID uuid.UUID) ([]models.CareerTimelineEntry, error) {
+	query := `
+		SELECT gp.name, t.started_at, t.ended_at, t.is_current
+		FROM politician_tenures t
+		JOIN government_positions gp ON t.position_id = gp.id
+		WHERE t.politician_id = $1
+		ORDER BY t.started_at DESC, t.created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, politicianID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get career timeline: %w", err)
+	}
+	defer rows.Close()
+
+	timeline := []models.CareerTimelineEntry{}
+	for rows.Next() {
+		var entry models.CareerTimelineEntry
+		if err := rows.Scan(&entry.PositionName, &entry.StartedAt, &entry.EndedAt, &entry.IsCurrent); err != nil {
+			return nil, fmt.Errorf("failed to scan career timeline entry: %w", err)
+		}
+		timeline = append(timeline, entry)
+	}
+
+	return timeline, nil
+}
+
+// GetCurrentOfficeholders returns all politicians with a current tenure in the given position
+func (r *PoliticianRepository) GetCurrentOfficeholders(ctx context.Context, positionID uuid.UUID) ([]models.Politician, error) {
+	query := `
+		SELECT DISTINCT p.id, p.name, p.slug, p.photo, p.position, p.party, p.short_bio, p.term_start, p.term_end, p.created_at, p.updated_at
+		FROM politicians p
+		JOIN politician_tenures t ON t.politician_id = p.id
+		WHERE t.position_id = $1 AND t.is_current = TRUE AND p.deleted_at IS NULL
+		ORDER BY p.name ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, positionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current officeholders: %w", err)
+	}
+	defer rows.Close()
+
+	politicians := []models.Politician{}
+	for rows.Next() {
+		var p models.Politician
+		err := rows.Scan(&p.ID, &p.Name, &p.Slug, &p.Photo, &p.Position, &p.Party, &p.ShortBio, &p.TermStart, &p.TermEnd, &p.CreatedAt, &p.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan politician: %w", err)
+		}
+		politicians = append(politicians, p)
+	}
+
+	return politicians, nil
+}
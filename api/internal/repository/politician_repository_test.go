@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nameSearchFixture is a family sharing a made-up surname, so the results can
+// be told apart from seeded politicians
+type nameSearchFixture struct {
+	surname  string
+	nickname string
+	bongbong uuid.UUID // Ferdinand "<nickname>" Romualdez <surname> Jr.
+	imee     uuid.UUID // Imee Romualdez <surname>
+	cousin   uuid.UUID // Ana <surname> Reyes, with <surname> as maternal surname
+	partyID  uuid.UUID
+}
+
+func setupNameSearchFixture(t *testing.T) (*PoliticianRepository, *nameSearchFixture) {
+	t.Helper()
+
+	ctx := context.Background()
+	pool := openTestDB(t)
+
+	// Letters only, so the suffix survives name normalization as one word
+	suffix := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return 'g' + (r - '0')
+		}
+		return r
+	}, uuid.NewString()[:8])
+
+	f := &nameSearchFixture{surname: "Marcos" + suffix, nickname: "Bong" + suffix}
+	require.NoError(t, pool.QueryRow(ctx,
+		"INSERT INTO political_parties (name, slug) VALUES ($1, $1) RETURNING id", "party-"+suffix,
+	).Scan(&f.partyID))
+
+	repo := NewPoliticianRepository(pool)
+	create := func(name string, nickname *string) uuid.UUID {
+		p := &models.Politician{Name: name, Slug: strings.ToLower(strings.ReplaceAll(name, " ", "-")) + "-" + suffix, Nickname: nickname}
+		require.NoError(t, repo.Create(ctx, p))
+		_, err := pool.Exec(ctx, "UPDATE politicians SET party_id = $2 WHERE id = $1", p.ID, f.partyID)
+		require.NoError(t, err)
+		return p.ID
+	}
+
+	f.bongbong = create(`Ferdinand "`+f.nickname+`" Romualdez `+f.surname+` Jr.`, &f.nickname)
+	f.imee = create("Imee Romualdez "+f.surname, nil)
+	f.cousin = create("Ana "+f.surname+" Reyes", nil)
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM politicians WHERE id = ANY($1)", []uuid.UUID{f.bongbong, f.imee, f.cousin})
+		_, _ = pool.Exec(ctx, "DELETE FROM political_parties WHERE id = $1", f.partyID)
+	})
+
+	return repo, f
+}
+
+// searchFixture runs a search and keeps only the fixture's politicians, in
+// result order
+func searchFixture(t *testing.T, repo *PoliticianRepository, f *nameSearchFixture, query string) []uuid.UUID {
+	t.Helper()
+
+	results, err := repo.Search(context.Background(), query, nil, 50)
+	require.NoError(t, err)
+
+	ids := []uuid.UUID{}
+	for _, p := range results {
+		if p.ID == f.bongbong || p.ID == f.imee || p.ID == f.cousin {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
+
+func TestPoliticianRepository_SearchNickname(t *testing.T) {
+	repo, f := setupNameSearchFixture(t)
+
+	ids := searchFixture(t, repo, f, `"`+strings.ToUpper(f.nickname)+`"`)
+	require.NotEmpty(t, ids)
+	assert.Equal(t, f.bongbong, ids[0])
+}
+
+func TestPoliticianRepository_SearchSurnameRanksAboveMaternal(t *testing.T) {
+	repo, f := setupNameSearchFixture(t)
+
+	ids := searchFixture(t, repo, f, f.surname+", Jr.")
+	require.Len(t, ids, 3)
+	assert.ElementsMatch(t, []uuid.UUID{f.bongbong, f.imee}, ids[:2])
+	assert.Equal(t, f.cousin, ids[2])
+}
+
+func TestPoliticianRepository_SearchFuzzyAndDisambiguation(t *testing.T) {
+	repo, f := setupNameSearchFixture(t)
+
+	// One letter off still finds the family
+	typo := strings.Replace(f.surname, "Marcos", "Markos", 1)
+	assert.Len(t, searchFixture(t, repo, f, typo), 3)
+
+	results, err := repo.Search(context.Background(), "Imee Romualdez "+f.surname, nil, 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, f.imee, results[0].ID)
+	require.NotNil(t, results[0].PartyInfo)
+	assert.Equal(t, f.partyID, results[0].PartyInfo.ID)
+}
+
+func TestPoliticianRepository_SearchShortQueryMatchesPrefixes(t *testing.T) {
+	repo, _ := setupNameSearchFixture(t)
+
+	results, err := repo.Search(context.Background(), "Bo", nil, 50)
+	require.NoError(t, err)
+	for _, p := range results {
+		matches := p.Nickname != nil && strings.HasPrefix(strings.ToLower(*p.Nickname), "bo")
+		for _, word := range strings.FieldsFunc(strings.ToLower(p.Name), func(r rune) bool { return !unicode.IsLetter(r) }) {
+			matches = matches || strings.HasPrefix(word, "bo")
+		}
+		assert.True(t, matches, "%q doesn't start a word with the query", p.Name)
+	}
+}
+
+func TestPoliticianRepository_ListActive(t *testing.T) {
+	repo, f := setupNameSearchFixture(t)
+	ctx := context.Background()
+	pool := repo.db
+
+	var positionID uuid.UUID
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO government_positions (name, slug, level, branch) VALUES ('Governor', $1, 'provincial', 'executive')
+		RETURNING id
+	`, "governor-"+uuid.NewString()[:8]).Scan(&positionID))
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM politician_tenures WHERE position_id = $1", positionID)
+		_, _ = pool.Exec(ctx, "DELETE FROM government_positions WHERE id = $1", positionID)
+	})
+
+	// Bongbong's term fields are current; Imee's ended, but a tenure of hers
+	// is current; the cousin's term fields are current but her only tenure ended
+	_, err := pool.Exec(ctx, `
+		UPDATE politicians SET
+			term_start = CASE WHEN id = $2 THEN DATE '2010-06-30' ELSE CURRENT_DATE - 30 END,
+			term_end = CASE WHEN id = $2 THEN DATE '2016-06-30' ELSE NULL END
+		WHERE id = ANY($1)
+	`, []uuid.UUID{f.bongbong, f.imee, f.cousin}, f.imee)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `
+		INSERT INTO politician_tenures (politician_id, position_id, started_at, ended_at, is_current) VALUES
+			($1, $3, CURRENT_DATE - 100, NULL, TRUE),
+			($2, $3, DATE '2019-06-30', DATE '2022-06-30', FALSE)
+	`, f.imee, f.cousin, positionID)
+	require.NoError(t, err)
+
+	result, err := repo.List(ctx, &models.PoliticianFilter{PartyID: &f.partyID, Active: true}, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Total)
+
+	byID := map[uuid.UUID]models.PoliticianListItem{}
+	for _, p := range result.Politicians {
+		byID[p.ID] = p
+	}
+	assert.Contains(t, byID, f.bongbong)
+	require.Contains(t, byID, f.imee)
+	require.NotNil(t, byID[f.imee].Position)
+	assert.Equal(t, "Governor", *byID[f.imee].Position)
+
+	result, err = repo.List(ctx, &models.PoliticianFilter{PartyID: &f.partyID, Active: true, PositionID: &positionID}, 1, 20)
+	require.NoError(t, err)
+	require.Len(t, result.Politicians, 1)
+	assert.Equal(t, f.imee, result.Politicians[0].ID)
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// Social Links
+
+const socialLinkColumns = `id, politician_id, platform, url, is_verified, created_at`
+
+func scanSocialLink(row pgx.Row, link *models.PoliticianSocialLink) error {
+	return row.Scan(&link.ID, &link.PoliticianID, &link.Platform, &link.URL, &link.IsVerified, &link.CreatedAt)
+}
+
+// ListSocialLinks returns a politician's social links in platform order
+func (r *PoliticianRepository) ListSocialLinks(ctx context.Context, politicianID uuid.UUID) ([]models.PoliticianSocialLink, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+socialLinkColumns+`
+		FROM politician_social_links
+		WHERE politician_id = $1
+		ORDER BY platform, created_at
+	`, politicianID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list social links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []models.PoliticianSocialLink{}
+	for rows.Next() {
+		var link models.PoliticianSocialLink
+		if err := scanSocialLink(rows, &link); err != nil {
+			return nil, fmt.Errorf("failed to scan social link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+// GetSocialLink returns a social link of the politician, or nil if it has none with that ID
+func (r *PoliticianRepository) GetSocialLink(ctx context.Context, politicianID, id uuid.UUID) (*models.PoliticianSocialLink, error) {
+	link := &models.PoliticianSocialLink{}
+	err := scanSocialLink(r.db.QueryRow(ctx, `
+		SELECT `+socialLinkColumns+`
+		FROM politician_social_links
+		WHERE id = $1 AND politician_id = $2
+	`, id, politicianID), link)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get social link: %w", err)
+	}
+	return link, nil
+}
+
+// SocialLinkExists reports whether the politician already links to url,
+// ignoring the link excludeID
+func (r *PoliticianRepository) SocialLinkExists(ctx context.Context, politicianID uuid.UUID, url string, excludeID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM politician_social_links WHERE politician_id = $1 AND url = $2 AND id <> $3)
+	`, politicianID, url, excludeID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check social link: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *PoliticianRepository) CreateSocialLink(ctx context.Context, link *models.PoliticianSocialLink) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO politician_social_links (politician_id, platform, url, is_verified)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, link.PoliticianID, link.Platform, link.URL, link.IsVerified).Scan(&link.ID, &link.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create social link: %w", err)
+	}
+	return nil
+}
+
+func (r *PoliticianRepository) UpdateSocialLink(ctx context.Context, link *models.PoliticianSocialLink) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE politician_social_links
+		SET platform = $3, url = $4, is_verified = $5
+		WHERE id = $1 AND politician_id = $2
+	`, link.ID, link.PoliticianID, link.Platform, link.URL, link.IsVerified)
+	if err != nil {
+		return fmt.Errorf("failed to update social link: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("social link not found")
+	}
+	return nil
+}
+
+func (r *PoliticianRepository) DeleteSocialLink(ctx context.Context, politicianID, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM politician_social_links WHERE id = $1 AND politician_id = $2`, id, politicianID)
+	if err != nil {
+		return fmt.Errorf("failed to delete social link: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("social link not found")
+	}
+	return nil
+}
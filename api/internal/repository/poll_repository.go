@@ -116,7 +116,7 @@ func (r *PollRepository) GetPollByID(ctx context.Context, id uuid.UUID) (*models
 			p.region_id, p.province_id, p.city_municipality_id, p.barangay_id,
 			p.is_anonymous, p.allow_multiple_votes, p.show_results_before_vote,
 			p.is_featured, p.starts_at, p.ends_at,
-			p.approved_by, p.approved_at, p.rejection_reason,
+			p.approved_by, p.approved_at, p.rejection_reason, p.closed_at,
 			p.total_votes, p.view_count, p.comment_count,
 			p.created_at, p.updated_at,
 			u.id, u.name, u.avatar
@@ -129,7 +129,7 @@ func (r *PollRepository) GetPollByID(ctx context.Context, id uuid.UUID) (*models
 		&poll.RegionID, &poll.ProvinceID, &poll.CityMunicipalityID, &poll.BarangayID,
 		&poll.IsAnonymous, &poll.AllowMultipleVotes, &poll.ShowResultsBeforeVote,
 		&poll.IsFeatured, &poll.StartsAt, &poll.EndsAt,
-		&poll.ApprovedBy, &poll.ApprovedAt, &poll.RejectionReason,
+		&poll.ApprovedBy, &poll.ApprovedAt, &poll.RejectionReason, &poll.ClosedAt,
 		&poll.TotalVotes, &poll.ViewCount, &poll.CommentCount,
 		&poll.CreatedAt, &poll.UpdatedAt,
 		&authorID, &authorName, &authorAvatar,
@@ -187,12 +187,27 @@ func (r *PollRepository) GetPollBySlug(ctx context.Context, slug string) (*model
 	return r.GetPollByID(ctx, id)
 }
 
+const pollHasLocation = "(p.region_id IS NOT NULL OR p.province_id IS NOT NULL OR p.city_municipality_id IS NOT NULL OR p.barangay_id IS NOT NULL)"
+
+// pollAttachmentConditions matches polls by what they are attached to
+var pollAttachmentConditions = map[string]string{
+	models.PollAttachmentPolitician: "p.politician_id IS NOT NULL",
+	models.PollAttachmentElection:   "p.election_id IS NOT NULL",
+	models.PollAttachmentBill:       "p.bill_id IS NOT NULL",
+	models.PollAttachmentLocation:   pollHasLocation,
+	models.PollAttachmentNone:       "(p.politician_id IS NULL AND p.election_id IS NULL AND p.bill_id IS NULL AND NOT " + pollHasLocation + ")",
+}
+
 func (r *PollRepository) ListPolls(ctx context.Context, filter *models.PollFilter, page, perPage int) (*models.PaginatedPolls, error) {
 	var conditions []string
 	var args []interface{}
 	argNum := 1
 
-	conditions = append(conditions, "p.deleted_at IS NULL")
+	if filter != nil && filter.IncludeDeleted {
+		conditions = append(conditions, "1=1")
+	} else {
+		conditions = append(conditions, "p.deleted_at IS NULL")
+	}
 
 	if filter != nil {
 		if filter.Category != nil {
@@ -220,6 +235,16 @@ func (r *PollRepository) ListPolls(ctx context.Context, filter *models.PollFilte
 			args = append(args, *filter.ElectionID)
 			argNum++
 		}
+		if filter.BillID != nil {
+			conditions = append(conditions, fmt.Sprintf("p.bill_id = $%d", argNum))
+			args = append(args, *filter.BillID)
+			argNum++
+		}
+		if filter.AttachmentType != nil {
+			if condition, ok := pollAttachmentConditions[*filter.AttachmentType]; ok {
+				conditions = append(conditions, condition)
+			}
+		}
 		if filter.IsFeatured != nil {
 			conditions = append(conditions, fmt.Sprintf("p.is_featured = $%d", argNum))
 			args = append(args, *filter.IsFeatured)
@@ -295,7 +320,8 @@ func (r *PollRepository) ListPolls(ctx context.Context, filter *models.PollFilte
 				prov.name || ', ' || r.name,
 				r.name,
 				NULL
-			) as location_display
+			) as location_display,
+			p.deleted_at
 		FROM polls p
 		JOIN users u ON p.user_id = u.id
 		LEFT JOIN regions r ON p.region_id = r.id
@@ -324,7 +350,7 @@ func (r *PollRepository) ListPolls(ctx context.Context, filter *models.PollFilte
 			&poll.ID, &poll.Title, &poll.Slug, &poll.Category, &poll.Status,
 			&poll.IsFeatured, &poll.TotalVotes, &poll.CommentCount, &poll.EndsAt,
 			&poll.CreatedAt, &authorID, &authorName, &authorAvatar, &poll.OptionCount,
-			&poll.Location,
+			&poll.Location, &poll.DeletedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -448,7 +474,8 @@ func (r *PollRepository) AdminUpdatePoll(ctx context.Context, id uuid.UUID, req
 
 	if req.Status != nil {
 		args = append(args, *req.Status)
-		sets = append(sets, fmt.Sprintf("status = $%d", len(args)))
+		sets = append(sets, fmt.Sprintf(
+			"status = $%[1]d, closed_at = CASE WHEN $%[1]d = 'closed' THEN COALESCE(closed_at, NOW()) END", len(args)))
 	}
 	if req.IsFeatured != nil {
 		args = append(args, *req.IsFeatured)
@@ -479,9 +506,14 @@ func (r *PollRepository) ApprovePoll(ctx context.Context, id uuid.UUID, approver
 		status = models.PollStatusRejected
 	}
 
+	// Approved polls that haven't reached starts_at wait in 'scheduled'
+	// until the poll scheduler activates them
 	_, err := r.db.Exec(ctx, `
 		UPDATE polls SET
-			status = $1,
+			status = CASE
+				WHEN $1::poll_status = 'active' AND starts_at > NOW() THEN 'scheduled'::poll_status
+				ELSE $1::poll_status
+			END,
 			approved_by = $2,
 			approved_at = NOW(),
 			rejection_reason = $3,
@@ -491,12 +523,80 @@ func (r *PollRepository) ApprovePoll(ctx context.Context, id uuid.UUID, approver
 	return err
 }
 
-func (r *PollRepository) ClosePoll(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.Exec(ctx, `
-		UPDATE polls SET status = 'closed', updated_at = NOW()
-		WHERE id = $1 AND deleted_at IS NULL
-	`, id)
-	return err
+// ClosePoll closes an active or scheduled poll, recording when it closed, and
+// reports whether it did. A poll that isn't open, including one closed
+// concurrently by someone else, is left alone.
+func (r *PollRepository) ClosePoll(ctx context.Context, id uuid.UUID) (bool, error) {
+	var closedID uuid.UUID
+	err := r.db.QueryRow(ctx, `
+		UPDATE polls SET status = 'closed', closed_at = COALESCE(closed_at, NOW()), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL AND status IN ('active', 'scheduled')
+		RETURNING id
+	`, id).Scan(&closedID)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to close poll: %w", err)
+	}
+	return true, nil
+}
+
+// ActivateScheduledPolls moves scheduled polls whose starts_at has passed to
+// active and returns their IDs
+func (r *PollRepository) ActivateScheduledPolls(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE polls SET status = 'active', updated_at = NOW()
+		WHERE status = 'scheduled'
+			AND deleted_at IS NULL
+			AND (starts_at IS NULL OR starts_at <= NOW())
+			AND (ends_at IS NULL OR ends_at > NOW())
+		RETURNING id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate scheduled polls: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan activated poll: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// ListExpiredPolls returns the active or scheduled polls whose ends_at has
+// passed. Only the identifying fields of the returned polls are populated.
+func (r *PollRepository) ListExpiredPolls(ctx context.Context) ([]models.Poll, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, title, slug, status, ends_at
+		FROM polls
+		WHERE status IN ('active', 'scheduled')
+			AND deleted_at IS NULL
+			AND ends_at IS NOT NULL
+			AND ends_at < NOW()
+		ORDER BY ends_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired polls: %w", err)
+	}
+	defer rows.Close()
+
+	var polls []models.Poll
+	for rows.Next() {
+		var poll models.Poll
+		if err := rows.Scan(&poll.ID, &poll.UserID, &poll.Title, &poll.Slug, &poll.Status, &poll.EndsAt); err != nil {
+			return nil, fmt.Errorf("failed to scan expired poll: %w", err)
+		}
+		polls = append(polls, poll)
+	}
+
+	return polls, rows.Err()
 }
 
 func (r *PollRepository) DeletePoll(ctx context.Context, id uuid.UUID) error {
@@ -544,7 +644,9 @@ func (r *PollRepository) GetPollOptions(ctx context.Context, pollID uuid.UUID) (
 
 // Voting
 
-func (r *PollRepository) CastVote(ctx context.Context, pollID, optionID uuid.UUID, userID *uuid.UUID, ipHash *string) error {
+// CastVote records a vote. regionID is the voter's region, if known; an
+// unknown region is stored as no region.
+func (r *PollRepository) CastVote(ctx context.Context, pollID, optionID uuid.UUID, userID *uuid.UUID, ipHash *string, regionID *uuid.UUID) error {
 	var existingVote uuid.UUID
 
 	// Check for existing vote
@@ -570,12 +672,27 @@ func (r *PollRepository) CastVote(ctx context.Context, pollID, optionID uuid.UUI
 		}
 	}
 
-	// Cast vote
-	_, err := r.db.Exec(ctx, `
-		INSERT INTO poll_votes (poll_id, option_id, user_id, ip_hash)
-		VALUES ($1, $2, $3, $4)
-	`, pollID, optionID, userID, ipHash)
-	return err
+	// Cast vote. The poll's status and window are re-checked in the same
+	// statement so a vote can't slip in after the poll has been closed.
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO poll_votes (poll_id, option_id, user_id, ip_hash, region_id)
+		SELECT $1, $2, $3, $4, (SELECT id FROM regions WHERE id = $5)
+		WHERE EXISTS (
+			SELECT 1 FROM polls
+			WHERE id = $1
+				AND status = 'active'
+				AND deleted_at IS NULL
+				AND (starts_at IS NULL OR starts_at <= NOW())
+				AND (ends_at IS NULL OR ends_at > NOW())
+		)
+	`, pollID, optionID, userID, ipHash, regionID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("this poll is not accepting votes")
+	}
+	return nil
 }
 
 func (r *PollRepository) HasUserVoted(ctx context.Context, pollID uuid.UUID, userID *uuid.UUID, ipHash *string) (bool, *uuid.UUID) {
@@ -602,6 +719,59 @@ func (r *PollRepository) HasUserVoted(ctx context.Context, pollID uuid.UUID, use
 	return false, nil
 }
 
+// HashLegacyVoters replaces the user ids stored on signed-in votes of
+// anonymous polls, from before such votes were kept by hash, with
+// hashVoter's hash. If the voter has voted again since under the hash, that
+// second vote is deleted and the first one kept. It returns how many votes
+// were hashed.
+func (r *PollRepository) HashLegacyVoters(ctx context.Context, hashVoter func(userID, pollID uuid.UUID) string) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	rows, err := tx.Query(ctx, `
+		SELECT v.id, v.user_id, v.poll_id
+		FROM poll_votes v
+		JOIN polls p ON p.id = v.poll_id
+		WHERE p.is_anonymous AND v.user_id IS NOT NULL
+		FOR UPDATE OF v
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list legacy votes: %w", err)
+	}
+	type legacyVote struct{ id, userID, pollID uuid.UUID }
+	var votes []legacyVote
+	for rows.Next() {
+		var v legacyVote
+		if err := rows.Scan(&v.id, &v.userID, &v.pollID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan legacy vote: %w", err)
+		}
+		votes = append(votes, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to list legacy votes: %w", err)
+	}
+
+	for _, v := range votes {
+		hash := hashVoter(v.userID, v.pollID)
+		if _, err := tx.Exec(ctx, `DELETE FROM poll_votes WHERE poll_id = $1 AND ip_hash = $2`, v.pollID, hash); err != nil {
+			return 0, fmt.Errorf("failed to delete repeat vote: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE poll_votes SET user_id = NULL, ip_hash = $2 WHERE id = $1`, v.id, hash); err != nil {
+			return 0, fmt.Errorf("failed to hash legacy vote: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit hashed votes: %w", err)
+	}
+	return len(votes), nil
+}
+
 func (r *PollRepository) GetPollResults(ctx context.Context, pollID uuid.UUID) (*models.PollResults, error) {
 	var totalVotes int
 	err := r.db.QueryRow(ctx, `
@@ -630,6 +800,104 @@ func (r *PollRepository) GetPollResults(ctx context.Context, pollID uuid.UUID) (
 	}, nil
 }
 
+// GetVotingTimeline counts a poll's votes per hour or day (bucketSize is
+// "hour" or "day"), in UTC, from the first vote to the last
+func (r *PollRepository) GetVotingTimeline(ctx context.Context, pollID uuid.UUID, bucketSize string) ([]models.PollVoteBucket, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH counts AS (
+			SELECT DATE_TRUNC($2, voted_at, 'UTC') AS bucket, COUNT(*) AS votes
+			FROM poll_votes
+			WHERE poll_id = $1
+			GROUP BY 1
+		), span AS (
+			SELECT MIN(bucket) AS first, MAX(bucket) AS last FROM counts
+		)
+		SELECT s.bucket, COALESCE(c.votes, 0)
+		FROM span
+		CROSS JOIN generate_series(span.first, span.last, ('1 ' || $2)::interval) AS s(bucket)
+		LEFT JOIN counts c ON c.bucket = s.bucket
+		ORDER BY s.bucket
+	`, pollID, bucketSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voting timeline: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []models.PollVoteBucket{}
+	for rows.Next() {
+		var b models.PollVoteBucket
+		if err := rows.Scan(&b.Bucket, &b.Votes); err != nil {
+			return nil, fmt.Errorf("failed to scan vote bucket: %w", err)
+		}
+		b.Bucket = b.Bucket.UTC()
+		buckets = append(buckets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get voting timeline: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// GetResultsByRegion breaks a poll's results down by the voters' regions,
+// most votes first, with votes from no known region last
+func (r *PollRepository) GetResultsByRegion(ctx context.Context, pollID uuid.UUID) ([]models.PollRegionResults, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH regional AS (
+			SELECT v.region_id, v.option_id, COUNT(*) AS votes
+			FROM poll_votes v
+			WHERE v.poll_id = $1
+			GROUP BY v.region_id, v.option_id
+		), totals AS (
+			SELECT region_id, SUM(votes)::int AS total FROM regional GROUP BY region_id
+		)
+		SELECT t.region_id, rg.name, t.total, o.id, o.text, COALESCE(rv.votes, 0)
+		FROM totals t
+		LEFT JOIN regions rg ON rg.id = t.region_id
+		CROSS JOIN poll_options o
+		LEFT JOIN regional rv ON rv.region_id IS NOT DISTINCT FROM t.region_id AND rv.option_id = o.id
+		WHERE o.poll_id = $1
+		ORDER BY t.region_id IS NULL, t.total DESC, rg.name, o.display_order
+	`, pollID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get results by region: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.PollRegionResults{}
+	for rows.Next() {
+		var regionID *uuid.UUID
+		var regionName *string
+		var total int
+		var option models.PollRegionOptions
+		if err := rows.Scan(&regionID, &regionName, &total, &option.OptionID, &option.Text, &option.VoteCount); err != nil {
+			return nil, fmt.Errorf("failed to scan region results: %w", err)
+		}
+		if total > 0 {
+			option.Percentage = float64(option.VoteCount) / float64(total) * 100
+		}
+
+		last := len(results) - 1
+		if last < 0 || !sameRegion(results[last].RegionID, regionID) {
+			results = append(results, models.PollRegionResults{RegionID: regionID, RegionName: regionName, TotalVotes: total})
+			last++
+		}
+		results[last].Options = append(results[last].Options, option)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get results by region: %w", err)
+	}
+
+	return results, nil
+}
+
+func sameRegion(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
 // Poll Comments
 
 func (r *PollRepository) CreatePollComment(ctx context.Context, pollID, userID uuid.UUID, req *models.CreatePollCommentRequest) (*models.PollComment, error) {
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pollAnalyticsFixture is an active poll with two options, and a region some
+// of its voters come from
+type pollAnalyticsFixture struct {
+	pollID   uuid.UUID
+	yes, no  uuid.UUID
+	regionID uuid.UUID
+	vote     func(optionID uuid.UUID, at time.Time, regionID *uuid.UUID)
+}
+
+func setupPollAnalyticsFixture(t *testing.T) (*PollRepository, *pollAnalyticsFixture) {
+	t.Helper()
+
+	ctx := context.Background()
+	pool := openTestDB(t)
+
+	suffix := uuid.NewString()[:8]
+	f := &pollAnalyticsFixture{}
+
+	insert := func(query string, args ...interface{}) uuid.UUID {
+		var id uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, query, args...).Scan(&id))
+		return id
+	}
+
+	userID := insert("INSERT INTO users (email, password_hash, name) VALUES ($1, 'x', 'Poll Author') RETURNING id", "poll-"+suffix+"@example.com")
+	f.regionID = insert("INSERT INTO regions (code, name, slug) VALUES ($1, $1, $1) RETURNING id", "region-"+suffix)
+	f.pollID = insert("INSERT INTO polls (user_id, title, slug, status) VALUES ($1, 'Timeline', $2, 'active') RETURNING id", userID, "timeline-"+suffix)
+	f.yes = insert("INSERT INTO poll_options (poll_id, text, display_order) VALUES ($1, 'Yes', 1) RETURNING id", f.pollID)
+	f.no = insert("INSERT INTO poll_options (poll_id, text, display_order) VALUES ($1, 'No', 2) RETURNING id", f.pollID)
+
+	f.vote = func(optionID uuid.UUID, at time.Time, regionID *uuid.UUID) {
+		_, err := pool.Exec(ctx, `
+			INSERT INTO poll_votes (poll_id, option_id, ip_hash, voted_at, region_id) VALUES ($1, $2, $3, $4, $5)
+		`, f.pollID, optionID, uuid.NewString(), at, regionID)
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM polls WHERE id = $1", f.pollID)
+		_, _ = pool.Exec(ctx, "DELETE FROM regions WHERE id = $1", f.regionID)
+		_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = $1", userID)
+	})
+
+	return NewPollRepository(pool), f
+}
+
+func TestPollRepository_GetVotingTimeline(t *testing.T) {
+	repo, f := setupPollAnalyticsFixture(t)
+	ctx := context.Background()
+
+	start := time.Date(2025, time.July, 4, 10, 0, 0, 0, time.UTC)
+	f.vote(f.yes, start.Add(5*time.Minute), nil)
+	f.vote(f.no, start.Add(50*time.Minute), nil)
+	// Nothing at 11:00, then a spike at 12:00
+	for i := 0; i < 3; i++ {
+		f.vote(f.yes, start.Add(2*time.Hour+time.Duration(i)*time.Minute), nil)
+	}
+
+	hourly, err := repo.GetVotingTimeline(ctx, f.pollID, models.PollTimelineBucketHour)
+	require.NoError(t, err)
+	assert.Equal(t, []models.PollVoteBucket{
+		{Bucket: start, Votes: 2},
+		{Bucket: start.Add(time.Hour), Votes: 0},
+		{Bucket: start.Add(2 * time.Hour), Votes: 3},
+	}, hourly)
+
+	daily, err := repo.GetVotingTimeline(ctx, f.pollID, models.PollTimelineBucketDay)
+	require.NoError(t, err)
+	assert.Equal(t, []models.PollVoteBucket{{Bucket: time.Date(2025, time.July, 4, 0, 0, 0, 0, time.UTC), Votes: 5}}, daily)
+}
+
+func TestPollRepository_GetResultsByRegion(t *testing.T) {
+	repo, f := setupPollAnalyticsFixture(t)
+
+	now := time.Now()
+	f.vote(f.yes, now, &f.regionID)
+	f.vote(f.yes, now, &f.regionID)
+	f.vote(f.no, now, &f.regionID)
+	f.vote(f.no, now, nil)
+
+	results, err := repo.GetResultsByRegion(context.Background(), f.pollID)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	region := results[0]
+	require.NotNil(t, region.RegionID)
+	assert.Equal(t, f.regionID, *region.RegionID)
+	assert.Equal(t, 3, region.TotalVotes)
+	require.Len(t, region.Options, 2)
+	assert.Equal(t, "Yes", region.Options[0].Text)
+	assert.Equal(t, 2, region.Options[0].VoteCount)
+	assert.InDelta(t, 66.67, region.Options[0].Percentage, 0.01)
+
+	// Votes with no known region come last, still listing every option
+	unknown := results[1]
+	assert.Nil(t, unknown.RegionID)
+	assert.Equal(t, 1, unknown.TotalVotes)
+	assert.Equal(t, 0, unknown.Options[0].VoteCount)
+	assert.Equal(t, 1, unknown.Options[1].VoteCount)
+}
+
+func TestPollRepository_ListPollsByAttachment(t *testing.T) {
+	repo, f := setupPollAnalyticsFixture(t)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	require.NoError(t, repo.db.QueryRow(ctx, "SELECT user_id FROM polls WHERE id = $1", f.pollID).Scan(&userID))
+
+	var regional uuid.UUID
+	require.NoError(t, repo.db.QueryRow(ctx, `
+		INSERT INTO polls (user_id, title, slug, status, region_id) VALUES ($1, 'Regional', $2, 'active', $3) RETURNING id
+	`, userID, "regional-"+uuid.NewString()[:8], f.regionID).Scan(&regional))
+	t.Cleanup(func() {
+		_, _ = repo.db.Exec(ctx, "DELETE FROM polls WHERE id = $1", regional)
+	})
+
+	list := func(attachment string) []uuid.UUID {
+		result, err := repo.ListPolls(ctx, &models.PollFilter{UserID: &userID, AttachmentType: &attachment}, 1, 10)
+		require.NoError(t, err)
+		var ids []uuid.UUID
+		for _, p := range result.Polls {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []uuid.UUID{regional}, list(models.PollAttachmentLocation))
+	assert.Equal(t, []uuid.UUID{f.pollID}, list(models.PollAttachmentNone))
+	assert.Empty(t, list(models.PollAttachmentBill))
+}
+
+func TestPollRepository_ClosePollOnlyOnce(t *testing.T) {
+	repo, f := setupPollAnalyticsFixture(t)
+	ctx := context.Background()
+
+	// Two closer runs racing on the same expired poll
+	results := make(chan bool, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			closed, err := repo.ClosePoll(ctx, f.pollID)
+			assert.NoError(t, err)
+			results <- closed
+		}()
+	}
+	first, second := <-results, <-results
+	assert.True(t, first != second, "exactly one close should take effect")
+
+	poll, err := repo.GetPollByID(ctx, f.pollID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PollStatusClosed, poll.Status)
+	require.NotNil(t, poll.ClosedAt)
+
+	closed, err := repo.ClosePoll(ctx, f.pollID)
+	require.NoError(t, err)
+	assert.False(t, closed)
+}
+
+// Signed-in votes on anonymous polls used to be stored by user id; once
+// hashed, those voters must still be recognized and can't vote again
+func TestPollRepository_HashLegacyVoters(t *testing.T) {
+	repo, f := setupPollAnalyticsFixture(t)
+	ctx := context.Background()
+	pool := repo.db
+	suffix := uuid.NewString()[:8]
+
+	users := make([]uuid.UUID, 2)
+	for i := range users {
+		require.NoError(t, pool.QueryRow(ctx, "INSERT INTO users (email, password_hash, name) VALUES ($1, 'x', 'Voter') RETURNING id",
+			"voter-"+suffix+"-"+string(rune('a'+i))+"@example.com").Scan(&users[i]))
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM poll_votes WHERE poll_id = $1", f.pollID)
+		_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = ANY($1)", users)
+	})
+	early, repeat := users[0], users[1]
+	hash := func(userID, _ uuid.UUID) string { return "voter:" + userID.String() }
+	repeatHash := hash(repeat, f.pollID)
+
+	// Both voted by user id; the second voted again after votes were hashed
+	_, err := pool.Exec(ctx, `
+		INSERT INTO poll_votes (poll_id, option_id, user_id) VALUES ($1, $2, $3), ($1, $2, $4)
+	`, f.pollID, f.yes, early, repeat)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, "INSERT INTO poll_votes (poll_id, option_id, ip_hash) VALUES ($1, $2, $3)", f.pollID, f.no, repeatHash)
+	require.NoError(t, err)
+
+	n, err := repo.HashLegacyVoters(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	for _, userID := range users {
+		voterHash := hash(userID, f.pollID)
+		voted, optionID := repo.HasUserVoted(ctx, f.pollID, nil, &voterHash)
+		assert.True(t, voted)
+		require.NotNil(t, optionID)
+		assert.Equal(t, f.yes, *optionID, "the first vote is kept")
+
+		assert.Error(t, repo.CastVote(ctx, f.pollID, f.no, nil, &voterHash, nil))
+
+		voted, _ = repo.HasUserVoted(ctx, f.pollID, &userID, nil)
+		assert.False(t, voted, "no vote is stored by user id any more")
+	}
+
+	var totalVotes int
+	require.NoError(t, pool.QueryRow(ctx, "SELECT total_votes FROM polls WHERE id = $1", f.pollID).Scan(&totalVotes))
+	assert.Equal(t, 2, totalVotes)
+
+	n, err = repo.HashLegacyVoters(ctx, hash)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
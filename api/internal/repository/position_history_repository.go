@@ -7,7 +7,6 @@ import (
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -75,7 +74,7 @@ func (r *PositionHistoryRepository) GetByID(ctx context.Context, id uuid.UUID) (
 
 	var partyID, partyName, partySlug, partyAbbr, partyLogo, partyColor *string
 	var electionID, electionName, electionLevel *string
-	var electionDate *pgtype.Date
+	var electionDate *models.Date
 
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&history.ID, &history.PoliticianID, &history.PositionID, &history.PartyID,
@@ -115,7 +114,7 @@ func (r *PositionHistoryRepository) GetByID(ctx context.Context, id uuid.UUID) (
 	if electionID != nil {
 		election.ID = uuid.MustParse(*electionID)
 		election.Name = *electionName
-		election.ElectionDate = electionDate.Time
+		election.ElectionDate = *electionDate
 		election.Level = *electionLevel
 		history.Election = &election
 	}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// reactionTable names a table of one-per-user reactions and the column
+// holding the ID of what was reacted to
+type reactionTable struct {
+	name      string
+	targetCol string
+}
+
+var (
+	commentReactions = reactionTable{name: "comment_reactions", targetCol: "comment_id"}
+	articleReactions = reactionTable{name: "article_reactions", targetCol: "article_id"}
+)
+
+// getReactionSummaries gets reaction counts for many targets at once, most
+// used reaction first. It runs one query for the counts and, when a user is
+// given, one for that user's reactions.
+func getReactionSummaries(ctx context.Context, db *pgxpool.Pool, table reactionTable, ids []uuid.UUID, currentUserID *uuid.UUID) (map[uuid.UUID][]models.ReactionSummary, error) {
+	summaries := make(map[uuid.UUID][]models.ReactionSummary, len(ids))
+	if len(ids) == 0 {
+		return summaries, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %[2]s, reaction, COUNT(*) as count
+		FROM %[1]s
+		WHERE %[2]s = ANY($1)
+		GROUP BY %[2]s, reaction
+		ORDER BY %[2]s, count DESC, reaction
+	`, table.name, table.targetCol)
+
+	rows, err := db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reaction summaries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var targetID uuid.UUID
+		var summary models.ReactionSummary
+		if err := rows.Scan(&targetID, &summary.Reaction, &summary.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction summary: %w", err)
+		}
+		summaries[targetID] = append(summaries[targetID], summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get reaction summaries: %w", err)
+	}
+	rows.Close()
+
+	if currentUserID == nil || len(summaries) == 0 {
+		return summaries, nil
+	}
+
+	// Mark the reactions the current user has made
+	userRows, err := db.Query(ctx, fmt.Sprintf(`
+		SELECT %[2]s, reaction FROM %[1]s
+		WHERE %[2]s = ANY($1) AND user_id = $2
+	`, table.name, table.targetCol), ids, *currentUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user reactions: %w", err)
+	}
+	defer userRows.Close()
+
+	for userRows.Next() {
+		var targetID uuid.UUID
+		var reaction string
+		if err := userRows.Scan(&targetID, &reaction); err != nil {
+			return nil, fmt.Errorf("failed to scan user reaction: %w", err)
+		}
+		for i := range summaries[targetID] {
+			if summaries[targetID][i].Reaction == reaction {
+				summaries[targetID][i].HasReacted = true
+			}
+		}
+	}
+
+	return summaries, userRows.Err()
+}
@@ -357,3 +357,78 @@ func (r *RoleRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+// SetPermissions makes permissionIDs the role's full permission set, removing
+// and adding only what differs. System roles can gain permissions but not lose
+// them. It returns the resulting permissions.
+func (r *RoleRepository) SetPermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) ([]models.Permission, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Lock the role so concurrent updates apply one after the other
+	var isSystem bool
+	err = tx.QueryRow(ctx,
+		"SELECT is_system FROM roles WHERE id = $1 AND deleted_at IS NULL FOR UPDATE",
+		roleID,
+	).Scan(&isSystem)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("role not found")
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	var known int
+	err = tx.QueryRow(ctx, "SELECT COUNT(*) FROM permissions WHERE id = ANY($1)", permissionIDs).Scan(&known)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permissions: %w", err)
+	}
+	if known != len(permissionIDs) {
+		return nil, fmt.Errorf("unknown permission")
+	}
+
+	if isSystem {
+		var removed int
+		err = tx.QueryRow(ctx,
+			"SELECT COUNT(*) FROM role_permissions WHERE role_id = $1 AND NOT (permission_id = ANY($2))",
+			roleID, permissionIDs,
+		).Scan(&removed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check permissions: %w", err)
+		}
+		if removed > 0 {
+			return nil, fmt.Errorf("cannot remove permissions from system role")
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		"DELETE FROM role_permissions WHERE role_id = $1 AND NOT (permission_id = ANY($2))",
+		roleID, permissionIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove permissions: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO role_permissions (role_id, permission_id)
+		SELECT $1, p.id FROM unnest($2::uuid[]) AS p(id)
+		ON CONFLICT DO NOTHING
+	`, roleID, permissionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add permissions: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE roles SET updated_at = NOW() WHERE id = $1", roleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return r.GetPermissionsByRoleID(ctx, roleID)
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SavedSearchRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSavedSearchRepository(db *pgxpool.Pool) *SavedSearchRepository {
+	return &SavedSearchRepository{db: db}
+}
+
+const savedSearchColumns = `
+	id, user_id, name, params, alert_enabled, alert_threshold,
+	is_broken, broken_reason, last_alerted_at, created_at, updated_at`
+
+// scanSavedSearch scans savedSearchColumns, followed by any extra destinations
+func scanSavedSearch(row pgx.Row, s *models.SavedSearch, extra ...interface{}) error {
+	var paramsJSON []byte
+	dest := append([]interface{}{
+		&s.ID, &s.UserID, &s.Name, &paramsJSON, &s.AlertEnabled, &s.AlertThreshold,
+		&s.IsBroken, &s.BrokenReason, &s.LastAlertedAt, &s.CreatedAt, &s.UpdatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return err
+	}
+	if len(paramsJSON) > 0 {
+		if err := json.Unmarshal(paramsJSON, &s.Params); err != nil {
+			return fmt.Errorf("failed to decode saved search params: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *SavedSearchRepository) Create(ctx context.Context, userID uuid.UUID, req *models.CreateSavedSearchRequest) (*models.SavedSearch, error) {
+	paramsJSON, err := json.Marshal(req.Params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal saved search params: %w", err)
+	}
+
+	search := &models.SavedSearch{}
+	err = scanSavedSearch(r.db.QueryRow(ctx, `
+		INSERT INTO saved_searches (user_id, name, params, alert_enabled, alert_threshold)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING`+savedSearchColumns,
+		userID, req.Name, paramsJSON, req.AlertEnabled, req.AlertThreshold,
+	), search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saved search: %w", err)
+	}
+	return search, nil
+}
+
+// GetByID returns the saved search if it belongs to userID
+func (r *SavedSearchRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.SavedSearch, error) {
+	search := &models.SavedSearch{}
+	err := scanSavedSearch(r.db.QueryRow(ctx, `
+		SELECT`+savedSearchColumns+`
+		FROM saved_searches
+		WHERE id = $1 AND user_id = $2
+	`, id, userID), search)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+	return search, nil
+}
+
+func (r *SavedSearchRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.SavedSearch, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT`+savedSearchColumns+`
+		FROM saved_searches
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	searches := []models.SavedSearch{}
+	for rows.Next() {
+		var s models.SavedSearch
+		if err := scanSavedSearch(rows, &s); err != nil {
+			return nil, fmt.Errorf("failed to scan saved search: %w", err)
+		}
+		searches = append(searches, s)
+	}
+	return searches, nil
+}
+
+func (r *SavedSearchRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("saved search not found")
+	}
+	return nil
+}
+
+// MarkBroken flags a search that can no longer run as saved
+func (r *SavedSearchRepository) MarkBroken(ctx context.Context, id uuid.UUID, reason string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE saved_searches SET is_broken = TRUE, broken_reason = $2 WHERE id = $1
+	`, id, reason)
+	if err != nil {
+		return fmt.Errorf("failed to mark saved search as broken: %w", err)
+	}
+	return nil
+}
+
+// ListDueAlerts returns working alerting searches that have not alerted since
+// the given number of hours, with their owners' emails
+func (r *SavedSearchRepository) ListDueAlerts(ctx context.Context, sinceHours int) ([]models.SavedSearchAlert, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT s.id, s.user_id, s.name, s.params, s.alert_enabled, s.alert_threshold,
+		       s.is_broken, s.broken_reason, s.last_alerted_at, s.created_at, s.updated_at, u.email
+		FROM saved_searches s
+		JOIN users u ON s.user_id = u.id
+		WHERE s.alert_enabled = TRUE AND s.is_broken = FALSE AND u.deleted_at IS NULL
+		  AND (s.last_alerted_at IS NULL OR s.last_alerted_at < NOW() - make_interval(hours => $1))
+		ORDER BY s.created_at
+	`, sinceHours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved search alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []models.SavedSearchAlert{}
+	for rows.Next() {
+		var a models.SavedSearchAlert
+		if err := scanSavedSearch(rows, &a.SavedSearch, &a.OwnerEmail); err != nil {
+			return nil, fmt.Errorf("failed to scan saved search alert: %w", err)
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, nil
+}
+
+// MarkAlerted records that an alert email was sent for the search
+func (r *SavedSearchRepository) MarkAlerted(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `UPDATE saved_searches SET last_alerted_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark saved search alerted: %w", err)
+	}
+	return nil
+}
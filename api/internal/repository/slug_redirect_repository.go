@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Previous slugs are recorded by the record_slug_redirect trigger. A redirect
+// always resolves to the entity's current slug rather than another redirect,
+// so chains collapse and loops can't form.
+
+// GetSlugRedirect returns the current slug of the published article that used
+// to have oldSlug, or "" if there is none. Articles in internal categories are
+// never redirected to, so their new slugs stay private.
+func (r *ArticleRepository) GetSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	return resolveSlugRedirect(ctx, r.db, `
+		SELECT a.slug
+		FROM slug_redirects sr
+		JOIN articles a ON a.id = sr.entity_id
+		WHERE sr.entity_type = 'article' AND sr.old_slug = $1
+		  AND a.deleted_at IS NULL AND a.status = 'published' AND a.slug <> $1
+		  AND `+notInInternalCategory+`
+	`, oldSlug)
+}
+
+// GetSlugRedirect returns the current slug of the public category that used to
+// have oldSlug, or "" if there is none
+func (r *CategoryRepository) GetSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	return resolveSlugRedirect(ctx, r.db, `
+		SELECT c.slug
+		FROM slug_redirects sr
+		JOIN categories c ON c.id = sr.entity_id
+		WHERE sr.entity_type = 'category' AND sr.old_slug = $1
+		  AND c.deleted_at IS NULL AND NOT c.is_internal AND c.slug <> $1
+	`, oldSlug)
+}
+
+// GetSlugRedirect returns the current slug of the tag that used to have
+// oldSlug, or "" if there is none
+func (r *TagRepository) GetSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	return resolveSlugRedirect(ctx, r.db, `
+		SELECT t.slug
+		FROM slug_redirects sr
+		JOIN tags t ON t.id = sr.entity_id
+		WHERE sr.entity_type = 'tag' AND sr.old_slug = $1
+		  AND t.deleted_at IS NULL AND t.slug <> $1
+	`, oldSlug)
+}
+
+func resolveSlugRedirect(ctx context.Context, db *pgxpool.Pool, query, oldSlug string) (string, error) {
+	var slug string
+	err := db.QueryRow(ctx, query, oldSlug).Scan(&slug)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get slug redirect: %w", err)
+	}
+	return slug, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetBySlugOrAlias returns the tag with the slug or, failing that, the tag a
+// tag with that slug was merged into. Callers can tell an alias was used by
+// the returned tag's slug differing from the one asked for.
+func (r *TagRepository) GetBySlugOrAlias(ctx context.Context, slug string) (*models.Tag, error) {
+	tag, err := r.GetBySlug(ctx, slug)
+	if err != nil || tag != nil {
+		return tag, err
+	}
+
+	query := `
+		SELECT t.id, t.name, t.slug, t.created_at, t.updated_at
+		FROM tag_aliases ta
+		JOIN tags t ON t.id = ta.canonical_tag_id
+		WHERE ta.old_slug = $1 AND t.deleted_at IS NULL
+	`
+
+	tag = &models.Tag{}
+	err = r.db.QueryRow(ctx, query, slug).Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.CreatedAt, &tag.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag by alias: %w", err)
+	}
+
+	return tag, nil
+}
+
+// Merge moves the source tag's articles and followers to the target, makes
+// the source's current and previous slugs aliases of the target, and
+// soft-deletes the source. Aliases that pointed at the source move to the
+// target too, so they never chain. It returns the articles that had the
+// source tag.
+func (r *TagRepository) Merge(ctx context.Context, sourceID, targetID uuid.UUID) ([]models.ArticleTitle, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var sourceSlug string
+	err = tx.QueryRow(ctx, "SELECT slug FROM tags WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", sourceID).Scan(&sourceSlug)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("tag not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	var exists bool
+	err = tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM tags WHERE id = $1 AND deleted_at IS NULL)", targetID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target tag: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("target tag not found")
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT a.id, a.slug, a.title
+		FROM article_tags at
+		JOIN articles a ON a.id = at.article_id
+		WHERE at.tag_id = $1
+	`, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tagged articles: %w", err)
+	}
+	articles := []models.ArticleTitle{}
+	for rows.Next() {
+		var a models.ArticleTitle
+		if err := rows.Scan(&a.ID, &a.Slug, &a.Title); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan tagged article: %w", err)
+		}
+		articles = append(articles, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tagged articles: %w", err)
+	}
+
+	statements := []string{
+		`INSERT INTO article_tags (article_id, tag_id)
+		 SELECT article_id, $2 FROM article_tags WHERE tag_id = $1
+		 ON CONFLICT DO NOTHING`,
+		`DELETE FROM article_tags WHERE tag_id = $1`,
+		`INSERT INTO user_follows (user_id, entity_type, entity_id, created_at)
+		 SELECT user_id, 'tag', $2, created_at FROM user_follows WHERE entity_type = 'tag' AND entity_id = $1
+		 ON CONFLICT DO NOTHING`,
+		`DELETE FROM user_follows WHERE entity_type = 'tag' AND entity_id = $1`,
+		`UPDATE tag_aliases SET canonical_tag_id = $2 WHERE canonical_tag_id = $1`,
+		`INSERT INTO tag_aliases (old_slug, canonical_tag_id)
+		 SELECT slug, $2 FROM tags WHERE id = $1
+		 UNION
+		 SELECT old_slug, $2 FROM slug_redirects WHERE entity_type = 'tag' AND entity_id = $1
+		 ON CONFLICT (old_slug) DO UPDATE SET canonical_tag_id = EXCLUDED.canonical_tag_id`,
+		`UPDATE tags SET deleted_at = NOW() WHERE id = $1`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt, sourceID, targetID); err != nil {
+			return nil, fmt.Errorf("failed to merge tag: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit tag merge: %w", err)
+	}
+	return articles, nil
+}
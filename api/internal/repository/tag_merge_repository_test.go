@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagRepository_Merge(t *testing.T) {
+	ctx := context.Background()
+	pool := openTestDB(t)
+
+	suffix := uuid.NewString()[:8]
+	insert := func(query string, args ...interface{}) uuid.UUID {
+		var id uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, query, args...).Scan(&id))
+		return id
+	}
+	typo := insert("INSERT INTO tags (name, slug) VALUES ($1, $1) RETURNING id", "eleciton-"+suffix)
+	canonical := insert("INSERT INTO tags (name, slug) VALUES ($1, $1) RETURNING id", "election-"+suffix)
+	both := insert("INSERT INTO articles (slug, title, content) VALUES ($1, $1, 'body') RETURNING id", "both-"+suffix)
+	typoOnly := insert("INSERT INTO articles (slug, title, content) VALUES ($1, $1, 'body') RETURNING id", "typo-only-"+suffix)
+	for _, at := range [][2]uuid.UUID{{both, typo}, {both, canonical}, {typoOnly, typo}} {
+		_, err := pool.Exec(ctx, "INSERT INTO article_tags (article_id, tag_id) VALUES ($1, $2)", at[0], at[1])
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM articles WHERE id = ANY($1)", []uuid.UUID{both, typoOnly})
+		_, _ = pool.Exec(ctx, "DELETE FROM tags WHERE id = ANY($1)", []uuid.UUID{typo, canonical})
+	})
+
+	repo := NewTagRepository(pool)
+
+	articles, err := repo.Merge(ctx, typo, canonical)
+	require.NoError(t, err)
+	assert.Len(t, articles, 2)
+
+	var tagged []uuid.UUID
+	rows, err := pool.Query(ctx, "SELECT article_id FROM article_tags WHERE tag_id = $1", canonical)
+	require.NoError(t, err)
+	for rows.Next() {
+		var id uuid.UUID
+		require.NoError(t, rows.Scan(&id))
+		tagged = append(tagged, id)
+	}
+	rows.Close()
+	assert.ElementsMatch(t, []uuid.UUID{both, typoOnly}, tagged)
+
+	// The old slug resolves to the canonical tag; the new one to itself
+	tag, err := repo.GetBySlugOrAlias(ctx, "eleciton-"+suffix)
+	require.NoError(t, err)
+	require.NotNil(t, tag)
+	assert.Equal(t, canonical, tag.ID)
+
+	tag, err = repo.GetBySlugOrAlias(ctx, "election-"+suffix)
+	require.NoError(t, err)
+	require.NotNil(t, tag)
+	assert.Equal(t, canonical, tag.ID)
+
+	_, err = repo.Merge(ctx, typo, canonical)
+	assert.EqualError(t, err, "tag not found")
+}
@@ -103,6 +103,9 @@ func (r *TagRepository) List(ctx context.Context) ([]models.Tag, error) {
 func (r *TagRepository) AdminList(ctx context.Context, filter *models.TagFilter, page, perPage int) (*models.PaginatedTags, error) {
 	// Build WHERE clause
 	whereClause := "WHERE deleted_at IS NULL"
+	if filter.IncludeDeleted {
+		whereClause = "WHERE 1=1"
+	}
 	args := []interface{}{}
 	argCount := 0
 
@@ -141,7 +144,7 @@ func (r *TagRepository) AdminList(ctx context.Context, filter *models.TagFilter,
 	// Build main query with pagination
 	argCount++
 	query := fmt.Sprintf(`
-		SELECT id, name, slug, created_at, updated_at
+		SELECT id, name, slug, created_at, updated_at, deleted_at
 		FROM tags
 		%s
 		%s
@@ -158,7 +161,7 @@ func (r *TagRepository) AdminList(ctx context.Context, filter *models.TagFilter,
 	tags := []models.Tag{}
 	for rows.Next() {
 		var tag models.Tag
-		err := rows.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.CreatedAt, &tag.UpdatedAt)
+		err := rows.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.CreatedAt, &tag.UpdatedAt, &tag.DeletedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan tag: %w", err)
 		}
@@ -238,3 +241,44 @@ func (r *TagRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+// Follow adds a follower to the tag; following twice is a no-op
+func (r *TagRepository) Follow(ctx context.Context, tagID, userID uuid.UUID) error {
+	query := `
+		INSERT INTO user_follows (user_id, entity_type, entity_id)
+		VALUES ($1, 'tag', $2)
+		ON CONFLICT (user_id, entity_type, entity_id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, tagID); err != nil {
+		return fmt.Errorf("failed to follow tag: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Unfollow(ctx context.Context, tagID, userID uuid.UUID) error {
+	query := "DELETE FROM user_follows WHERE user_id = $1 AND entity_type = 'tag' AND entity_id = $2"
+
+	if _, err := r.db.Exec(ctx, query, userID, tagID); err != nil {
+		return fmt.Errorf("failed to unfollow tag: %w", err)
+	}
+
+	return nil
+}
+
+// GetFollowStatus returns the follower count and whether userID (if any) follows the tag
+func (r *TagRepository) GetFollowStatus(ctx context.Context, tagID uuid.UUID, userID *uuid.UUID) (*models.FollowStatus, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(BOOL_OR(user_id = $2), false)
+		FROM user_follows
+		WHERE entity_type = 'tag' AND entity_id = $1
+	`
+
+	status := &models.FollowStatus{}
+	if err := r.db.QueryRow(ctx, query, tagID, userID).Scan(&status.FollowerCount, &status.IsFollowing); err != nil {
+		return nil, fmt.Errorf("failed to get tag follow status: %w", err)
+	}
+
+	return status, nil
+}
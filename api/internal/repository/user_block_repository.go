@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserBlockRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUserBlockRepository(db *pgxpool.Pool) *UserBlockRepository {
+	return &UserBlockRepository{db: db}
+}
+
+// Block records that blockerID has blocked blockedID. Blocking twice is a no-op.
+func (r *UserBlockRepository) Block(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	query := `
+		INSERT INTO user_blocks (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserBlockRepository) Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2`, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("block not found")
+	}
+
+	return nil
+}
+
+// IsBlocked reports whether blockerID has blocked blockedID
+func (r *UserBlockRepository) IsBlocked(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	var blocked bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2)`,
+		blockerID, blockedID,
+	).Scan(&blocked)
+	if err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// ListBlocked returns the users blocked by blockerID, most recent first
+func (r *UserBlockRepository) ListBlocked(ctx context.Context, blockerID uuid.UUID) ([]models.BlockedUser, error) {
+	query := `
+		SELECT u.id, u.name, u.avatar, b.created_at
+		FROM user_blocks b
+		JOIN users u ON b.blocked_id = u.id
+		WHERE b.blocker_id = $1 AND u.deleted_at IS NULL
+		ORDER BY b.created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, blockerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocked users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []models.BlockedUser{}
+	for rows.Next() {
+		var user models.BlockedUser
+		if err := rows.Scan(&user.ID, &user.Name, &user.Avatar, &user.BlockedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan blocked user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
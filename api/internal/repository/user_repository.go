@@ -43,7 +43,7 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	query := `
 		SELECT u.id, u.email, u.password_hash, u.name, COALESCE(a.avatar, u.avatar) as avatar,
-		       u.role_id, COALESCE(r.slug, '') as role_slug, u.created_at, u.updated_at, u.deleted_at
+		       u.role_id, COALESCE(r.slug, '') as role_slug, u.is_member, u.created_at, u.updated_at, u.deleted_at
 		FROM users u
 		LEFT JOIN roles r ON u.role_id = r.id
 		LEFT JOIN authors a ON a.email = u.email AND a.deleted_at IS NULL
@@ -53,7 +53,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 	user := &models.User{}
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.Avatar,
-		&user.RoleID, &user.RoleSlug, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+		&user.RoleID, &user.RoleSlug, &user.IsMember, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -69,7 +69,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
 		SELECT u.id, u.email, u.password_hash, u.name, COALESCE(a.avatar, u.avatar) as avatar,
-		       u.role_id, COALESCE(r.slug, '') as role_slug, u.created_at, u.updated_at, u.deleted_at
+		       u.role_id, COALESCE(r.slug, '') as role_slug, u.is_member, u.created_at, u.updated_at, u.deleted_at
 		FROM users u
 		LEFT JOIN roles r ON u.role_id = r.id
 		LEFT JOIN authors a ON LOWER(a.email) = LOWER(u.email) AND a.deleted_at IS NULL
@@ -79,7 +79,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 	user := &models.User{}
 	err := r.db.QueryRow(ctx, query, email).Scan(
 		&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.Avatar,
-		&user.RoleID, &user.RoleSlug, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+		&user.RoleID, &user.RoleSlug, &user.IsMember, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -95,7 +95,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 func (r *UserRepository) List(ctx context.Context) ([]models.User, error) {
 	query := `
 		SELECT u.id, u.email, u.password_hash, u.name, COALESCE(a.avatar, u.avatar) as avatar,
-		       u.role_id, COALESCE(r.slug, '') as role_slug, u.created_at, u.updated_at, u.deleted_at
+		       u.role_id, COALESCE(r.slug, '') as role_slug, u.is_member, u.created_at, u.updated_at, u.deleted_at
 		FROM users u
 		LEFT JOIN roles r ON u.role_id = r.id
 		LEFT JOIN authors a ON a.email = u.email AND a.deleted_at IS NULL
@@ -114,7 +114,7 @@ func (r *UserRepository) List(ctx context.Context) ([]models.User, error) {
 		var user models.User
 		err := rows.Scan(
 			&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.Avatar,
-			&user.RoleID, &user.RoleSlug, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+			&user.RoleID, &user.RoleSlug, &user.IsMember, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
@@ -131,6 +131,9 @@ func (r *UserRepository) AdminList(ctx context.Context, filter *models.UserFilte
 		LEFT JOIN roles r ON u.role_id = r.id
 		LEFT JOIN authors a ON a.email = u.email AND a.deleted_at IS NULL
 		WHERE u.deleted_at IS NULL`
+	if filter.IncludeDeleted {
+		baseQuery = strings.Replace(baseQuery, "WHERE u.deleted_at IS NULL", "WHERE 1=1", 1)
+	}
 
 	args := []interface{}{}
 	argCount := 0
@@ -176,7 +179,7 @@ func (r *UserRepository) AdminList(ctx context.Context, filter *models.UserFilte
 	argCount++
 	query := fmt.Sprintf(`
 		SELECT u.id, u.email, u.password_hash, u.name, COALESCE(a.avatar, u.avatar) as avatar,
-		       u.role_id, COALESCE(r.slug, '') as role_slug, u.created_at, u.updated_at, u.deleted_at
+		       u.role_id, COALESCE(r.slug, '') as role_slug, u.is_member, u.created_at, u.updated_at, u.deleted_at
 		%s
 		%s
 		LIMIT $%d OFFSET $%d
@@ -192,7 +195,7 @@ func (r *UserRepository) AdminList(ctx context.Context, filter *models.UserFilte
 	users := []models.User{}
 	for rows.Next() {
 		var user models.User
-		err := rows.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.Avatar, &user.RoleID, &user.RoleSlug, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
+		err := rows.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.Name, &user.Avatar, &user.RoleID, &user.RoleSlug, &user.IsMember, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
@@ -488,6 +491,36 @@ func (r *UserRepository) MarkPasswordResetTokenUsed(ctx context.Context, tokenID
 	return nil
 }
 
+// SetMembership grants or revokes a user's membership
+func (r *UserRepository) SetMembership(ctx context.Context, userID uuid.UUID, isMember bool) error {
+	query := `UPDATE users SET is_member = $1, updated_at = NOW() WHERE id = $2 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, isMember, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set membership: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// IsMember reports whether the user is a member. Unknown and deleted users
+// are not.
+func (r *UserRepository) IsMember(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var isMember bool
+	err := r.db.QueryRow(ctx, `SELECT is_member FROM users WHERE id = $1 AND deleted_at IS NULL`, userID).Scan(&isMember)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check membership: %w", err)
+	}
+	return isMember, nil
+}
+
 // UpdatePassword updates a user's password hash
 func (r *UserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
 	query := `UPDATE users SET password_hash = $1, updated_at = NOW() WHERE id = $2 AND deleted_at IS NULL`
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+const webhookColumns = `id, url, secret, event_types, description, is_active, created_at, updated_at`
+
+func scanWebhook(row pgx.Row, webhook *models.Webhook) error {
+	return row.Scan(
+		&webhook.ID, &webhook.URL, &webhook.Secret, &webhook.EventTypes, &webhook.Description,
+		&webhook.IsActive, &webhook.CreatedAt, &webhook.UpdatedAt,
+	)
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO webhooks (url, secret, event_types, description, is_active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`, webhook.URL, webhook.Secret, webhook.EventTypes, webhook.Description, webhook.IsActive,
+	).Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error) {
+	webhook := &models.Webhook{}
+	err := scanWebhook(r.db.QueryRow(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id), webhook)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+func (r *WebhookRepository) List(ctx context.Context) ([]models.Webhook, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		var webhook models.Webhook
+		if err := scanWebhook(rows, &webhook); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE webhooks
+		SET url = $2, secret = $3, event_types = $4, description = $5, is_active = $6
+		WHERE id = $1
+		RETURNING updated_at
+	`, webhook.ID, webhook.URL, webhook.Secret, webhook.EventTypes, webhook.Description, webhook.IsActive,
+	).Scan(&webhook.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("webhook not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("webhook not found")
+	}
+	return nil
+}
+
+// Deliveries
+
+const webhookDeliveryColumns = `
+	d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+	d.last_status_code, d.last_error, d.delivered_at, d.created_at`
+
+func scanWebhookDelivery(row pgx.Row, delivery *models.WebhookDelivery, extra ...any) error {
+	dest := []any{
+		&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &delivery.Payload, &delivery.Status,
+		&delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastStatusCode, &delivery.LastError,
+		&delivery.DeliveredAt, &delivery.CreatedAt,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// EnqueueEvent queues the event for every active webhook subscribed to its
+// type and returns how many deliveries were queued
+func (r *WebhookRepository) EnqueueEvent(ctx context.Context, event *models.WebhookEvent, payload []byte) (int, error) {
+	result, err := r.db.Exec(ctx, `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+		SELECT id, $1, $2, $3
+		FROM webhooks
+		WHERE is_active AND $2 = ANY(event_types)
+	`, event.ID, event.Type, payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook event: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries that are due,
+// with their webhook's URL and secret. Claimed deliveries are pushed back by
+// lease so other dispatchers leave them alone while they are being sent.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT d.id
+			FROM webhook_deliveries d
+			JOIN webhooks w ON d.webhook_id = w.id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= NOW() AND w.is_active
+			ORDER BY d.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM due, webhooks w
+		WHERE d.id = due.id AND w.id = d.webhook_id
+		RETURNING `+webhookDeliveryColumns+`, w.url, w.secret
+	`, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := scanWebhookDelivery(rows, &delivery, &delivery.URL, &delivery.Secret); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// MarkDelivered records a successful attempt
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id uuid.UUID, statusCode int) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', attempts = attempts + 1, last_status_code = $2, last_error = NULL, delivered_at = NOW()
+		WHERE id = $1
+	`, id, statusCode)
+	if err != nil {
+		return fmt.Errorf("failed to mark webhook delivered: %w", err)
+	}
+	return nil
+}
+
+// RecordFailure records a failed attempt, leaving the delivery in status
+// (pending to retry at nextAttemptAt, or dead)
+func (r *WebhookRepository) RecordFailure(ctx context.Context, id uuid.UUID, status string, statusCode *int, errMsg string, nextAttemptAt time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1, last_status_code = $3, last_error = $4, next_attempt_at = $5
+		WHERE id = $1
+	`, id, status, statusCode, errMsg, nextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook failure: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns a webhook's most recent deliveries, optionally only
+// those with the given status
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID uuid.UUID, status *string, limit int) ([]models.WebhookDelivery, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = $1 AND ($2::varchar IS NULL OR d.status = $2)
+		ORDER BY d.created_at DESC
+		LIMIT $3
+	`, webhookID, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := scanWebhookDelivery(rows, &delivery); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// RequeueDelivery puts a dead-lettered delivery back in the queue with its
+// attempts reset
+func (r *WebhookRepository) RequeueDelivery(ctx context.Context, webhookID, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW()
+		WHERE id = $1 AND webhook_id = $2 AND status = 'dead'
+	`, id, webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to requeue webhook delivery: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("delivery not found")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+	"github.com/humfurie/pulpulitiko/api/pkg/htmltext"
+)
+
+// ArticleAccessConfig controls what non-members see of members-only articles
+type ArticleAccessConfig struct {
+	// Blocks of content, such as paragraphs, kept in a preview
+	PreviewParagraphs int
+
+	// Members-only articles a non-member may read in full each calendar
+	// month, in the site timezone
+	FreeMonthly int
+}
+
+var DefaultArticleAccessConfig = ArticleAccessConfig{
+	PreviewParagraphs: 3,
+	FreeMonthly:       3,
+}
+
+// freeArticlesTTL keeps a month's meter a little past the end of the month
+const freeArticlesTTL = 32 * 24 * time.Hour
+
+// MemberLookup reports whether a user is a member. *repository.UserRepository
+// satisfies it.
+type MemberLookup interface {
+	IsMember(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// ArticleReader identifies who is reading an article: a signed-in user, or
+// an anonymous visitor by the ID their browser keeps
+type ArticleReader struct {
+	UserID    *uuid.UUID
+	VisitorID string
+	Role      string // Role slug of a signed-in reader
+}
+
+// SetAccessConfig sets the preview length and monthly free articles, and how
+// members are recognised. Without a lookup nobody is a member.
+func (s *ArticleService) SetAccessConfig(cfg ArticleAccessConfig, members MemberLookup) {
+	s.access = cfg
+	s.members = members
+}
+
+// ApplyAccess cuts an article down to what the reader may see. A premium
+// article is locked, its content replaced by the premium summary, for anyone
+// without the premium role; free articles don't apply.
+//
+// A members-only article is cut to a preview for a reader who may not read it
+// in full. Members always may; anyone else may while they have free articles
+// left this month, and reopening an article they already read is free. When
+// the meter cannot be read the reader gets the preview.
+func (s *ArticleService) ApplyAccess(ctx context.Context, article *models.Article, reader ArticleReader) {
+	if article.IsPremium && reader.Role != models.RolePremium {
+		article.ContentLocked = true
+		article.Content = ""
+		if article.PremiumSummary != nil {
+			article.Content = *article.PremiumSummary
+		}
+		return
+	}
+
+	if article.AccessLevel != models.ArticleAccessMembers {
+		return
+	}
+
+	if reader.UserID != nil && s.members != nil {
+		if isMember, err := s.members.IsMember(ctx, *reader.UserID); err == nil && isMember {
+			article.Access = models.ArticleAccessFull
+			return
+		}
+	}
+
+	key := meterReader(reader)
+	if key == "" || s.cache == nil {
+		s.restrict(article)
+		return
+	}
+
+	period := time.Now().In(s.schedule.Location).Format("2006-01")
+	meter := s.cache.Meter(cache.MeterKey(period, key), s.access.FreeMonthly, freeArticlesTTL)
+	allowed, remaining, err := meter.Use(ctx, article.ID.String())
+	if err != nil {
+		s.restrict(article)
+		return
+	}
+
+	article.FreeArticlesRemaining = &remaining
+	if allowed {
+		article.Access = models.ArticleAccessFull
+		return
+	}
+	s.restrict(article)
+}
+
+func (s *ArticleService) restrict(article *models.Article) {
+	article.Access = models.ArticleAccessRestricted
+	article.Content = htmltext.FirstBlocks(article.Content, s.access.PreviewParagraphs)
+}
+
+// meterReader names the meter a reader's free articles are counted on,
+// preferring their account over their browser. It is empty when the reader
+// cannot be told apart from anyone else.
+func meterReader(reader ArticleReader) string {
+	switch {
+	case reader.UserID != nil:
+		return "user:" + reader.UserID.String()
+	case reader.VisitorID != "":
+		return "visitor:" + reader.VisitorID
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMembers map[uuid.UUID]bool
+
+func (f fakeMembers) IsMember(_ context.Context, userID uuid.UUID) (bool, error) {
+	return f[userID], nil
+}
+
+func TestApplyAccess(t *testing.T) {
+	member, other := uuid.New(), uuid.New()
+	s := &ArticleService{schedule: DefaultArticleScheduleConfig}
+	s.SetAccessConfig(ArticleAccessConfig{PreviewParagraphs: 1, FreeMonthly: 3}, fakeMembers{member: true})
+
+	content := "<p>One</p><p>Two</p>"
+	newArticle := func(level string) *models.Article {
+		return &models.Article{ID: uuid.New(), AccessLevel: level, Content: content}
+	}
+
+	public := newArticle(models.ArticleAccessPublic)
+	s.ApplyAccess(context.Background(), public, ArticleReader{})
+	assert.Equal(t, content, public.Content)
+	assert.Empty(t, public.Access)
+
+	forMember := newArticle(models.ArticleAccessMembers)
+	s.ApplyAccess(context.Background(), forMember, ArticleReader{UserID: &member})
+	assert.Equal(t, models.ArticleAccessFull, forMember.Access)
+	assert.Equal(t, content, forMember.Content)
+	assert.Nil(t, forMember.FreeArticlesRemaining)
+
+	// Without a cache to meter on, non-members only get the preview
+	forOther := newArticle(models.ArticleAccessMembers)
+	s.ApplyAccess(context.Background(), forOther, ArticleReader{UserID: &other})
+	assert.Equal(t, models.ArticleAccessRestricted, forOther.Access)
+	assert.Equal(t, "<p>One</p>", forOther.Content)
+
+	anonymous := newArticle(models.ArticleAccessMembers)
+	s.ApplyAccess(context.Background(), anonymous, ArticleReader{})
+	assert.Equal(t, models.ArticleAccessRestricted, anonymous.Access)
+}
+
+func TestApplyAccessPremium(t *testing.T) {
+	userID := uuid.New()
+	s := &ArticleService{schedule: DefaultArticleScheduleConfig}
+	s.SetAccessConfig(ArticleAccessConfig{PreviewParagraphs: 1, FreeMonthly: 3}, fakeMembers{userID: true})
+
+	teaser := "<p>Subscribe to read on</p>"
+	newArticle := func() *models.Article {
+		return &models.Article{ID: uuid.New(), AccessLevel: models.ArticleAccessPublic, IsPremium: true, PremiumSummary: &teaser, Content: "<p>Full</p>"}
+	}
+
+	// Membership alone doesn't unlock a premium article
+	locked := newArticle()
+	s.ApplyAccess(context.Background(), locked, ArticleReader{UserID: &userID, Role: "user"})
+	assert.True(t, locked.ContentLocked)
+	assert.Equal(t, teaser, locked.Content)
+
+	unlocked := newArticle()
+	s.ApplyAccess(context.Background(), unlocked, ArticleReader{UserID: &userID, Role: models.RolePremium})
+	assert.False(t, unlocked.ContentLocked)
+	assert.Equal(t, "<p>Full</p>", unlocked.Content)
+
+	noSummary := newArticle()
+	noSummary.PremiumSummary = nil
+	s.ApplyAccess(context.Background(), noSummary, ArticleReader{})
+	assert.True(t, noSummary.ContentLocked)
+	assert.Empty(t, noSummary.Content)
+}
+
+func TestMeterReader(t *testing.T) {
+	userID := uuid.New()
+	assert.Equal(t, "user:"+userID.String(), meterReader(ArticleReader{UserID: &userID, VisitorID: "abc"}))
+	assert.Equal(t, "visitor:abc", meterReader(ArticleReader{VisitorID: "abc"}))
+	assert.Equal(t, "", meterReader(ArticleReader{}))
+}
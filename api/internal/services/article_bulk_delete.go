@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+// BulkDelete soft-deletes the articles. Articles that don't exist or are
+// already deleted are reported as failed; the rest are deleted together.
+func (s *ArticleService) BulkDelete(ctx context.Context, ids []uuid.UUID) (*models.BulkArticleResult, error) {
+	deleted, err := s.repo.BulkSoftDelete(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateBulkArticleCache(ctx, deleted)
+	return bulkArticleResult(ids, deleted, "article not found"), nil
+}
+
+// BulkRestore restores soft-deleted articles. Articles that don't exist or
+// aren't deleted are reported as failed.
+func (s *ArticleService) BulkRestore(ctx context.Context, ids []uuid.UUID) (*models.BulkArticleResult, error) {
+	restored, err := s.repo.BulkRestore(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateBulkArticleCache(ctx, restored)
+	return bulkArticleResult(ids, restored, "article not found or not deleted"), nil
+}
+
+// invalidateBulkArticleCache drops each changed article's own keys, then
+// every article list once rather than once per article
+func (s *ArticleService) invalidateBulkArticleCache(ctx context.Context, changed []models.ArticleTitle) {
+	if len(changed) == 0 {
+		return
+	}
+
+	keys := []string{cache.TrendingKey()}
+	for _, a := range changed {
+		keys = append(keys,
+			cache.ArticleKey(a.ID.String()),
+			cache.ArticleSlugKey(a.Slug),
+			cache.ArticleExportKey(a.Slug, ArticleExportText),
+			cache.ArticleExportKey(a.Slug, ArticleExportMarkdown),
+		)
+	}
+	_ = s.cache.Delete(ctx, keys...)
+	_ = s.cache.InvalidateTag(ctx, cache.TagArticleLists)
+}
+
+// bulkArticleResult reports each requested ID as succeeded if it is among
+// the changed articles, otherwise as failed with reason
+func bulkArticleResult(ids []uuid.UUID, changed []models.ArticleTitle, reason string) *models.BulkArticleResult {
+	done := make(map[uuid.UUID]bool, len(changed))
+	for _, a := range changed {
+		done[a.ID] = true
+	}
+
+	result := &models.BulkArticleResult{Results: make([]models.BulkArticleItemResult, len(ids))}
+	for i, id := range ids {
+		item := models.BulkArticleItemResult{ID: id, Success: done[id]}
+		if item.Success {
+			result.Succeeded++
+		} else {
+			item.Error = reason
+			result.Failed++
+		}
+		result.Results[i] = item
+	}
+	return result
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkArticleResult(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	changed := []models.ArticleTitle{{ID: c}, {ID: a}}
+
+	result := bulkArticleResult([]uuid.UUID{a, b, c}, changed, "article not found")
+
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, []models.BulkArticleItemResult{
+		{ID: a, Success: true},
+		{ID: b, Error: "article not found"},
+		{ID: c, Success: true},
+	}, result.Results)
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+// PreviewBulkTag reports which articles assigning (or removing) the tag would
+// touch, without changing anything
+func (s *ArticleService) PreviewBulkTag(ctx context.Context, tagID uuid.UUID, assign bool, req *models.BulkTagRequest) (*models.BulkTagPreview, error) {
+	targets, err := s.bulkTagTargets(ctx, tagID, assign, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BulkTagPreview{
+		Count:    len(targets),
+		Articles: targets[:min(len(targets), models.BulkTagPreviewSize)],
+	}, nil
+}
+
+// BulkTag assigns the tag to, or removes it from, the articles the request
+// picks. The cached detail of every touched article and the tag's article
+// lists are dropped.
+func (s *ArticleService) BulkTag(ctx context.Context, tagID uuid.UUID, assign bool, req *models.BulkTagRequest) (*models.BulkTagResult, error) {
+	targets, err := s.bulkTagTargets(ctx, tagID, assign, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return &models.BulkTagResult{}, nil
+	}
+
+	ids := make([]uuid.UUID, len(targets))
+	keys := make([]string, 0, 2*len(targets))
+	for i, t := range targets {
+		ids[i] = t.ID
+		keys = append(keys, cache.ArticleKey(t.ID.String()), cache.ArticleSlugKey(t.Slug))
+	}
+
+	affected, err := s.repo.SetArticlesTag(ctx, tagID, ids, assign)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Delete(ctx, keys...)
+	_ = s.cache.InvalidateTag(ctx, cache.TagArticlesTag(tagID.String()))
+
+	return &models.BulkTagResult{Affected: affected}, nil
+}
+
+// bulkTagTargets resolves the request to the articles whose tags would change.
+// Filters read like the admin article list, internal categories included.
+func (s *ArticleService) bulkTagTargets(ctx context.Context, tagID uuid.UUID, assign bool, req *models.BulkTagRequest) ([]models.ArticleTitle, error) {
+	if req.Filter != nil {
+		filter := req.Filter.Filter()
+		filter.IncludeInternal = true
+		return s.repo.BulkTagTargets(ctx, tagID, assign, nil, filter)
+	}
+
+	ids := make([]uuid.UUID, len(req.ArticleIDs))
+	for i, id := range req.ArticleIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid article ID: %w", err)
+		}
+		ids[i] = parsed
+	}
+	return s.repo.BulkTagTargets(ctx, tagID, assign, ids, nil)
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+// parseCommentsLockedAt parses a requested comment lock time. An empty value
+// means unlocked.
+func (s *ArticleService) parseCommentsLockedAt(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	t, err := models.ParseScheduleTime(*value, s.schedule.Location)
+	if err != nil {
+		return nil, errors.New("comments_locked_at must be RFC 3339 or a local YYYY-MM-DDTHH:MM time")
+	}
+	return &t, nil
+}
+
+// BulkUpdateCommentSettings applies comment settings to the articles of a
+// category, such as locking comments on every opinion piece older than 30 days
+func (s *ArticleService) BulkUpdateCommentSettings(ctx context.Context, req *models.BulkCommentSettingsRequest) (*models.BulkCommentSettingsResult, error) {
+	updates := make(map[string]interface{})
+	if req.CommentsEnabled != nil {
+		updates["comments_enabled"] = *req.CommentsEnabled
+	}
+	if req.CommentsPremoderated != nil {
+		updates["comments_premoderated"] = *req.CommentsPremoderated
+	}
+	if req.CommentsLockedAt != nil {
+		lockedAt, err := s.parseCommentsLockedAt(req.CommentsLockedAt)
+		if err != nil {
+			return nil, err
+		}
+		updates["comments_locked_at"] = lockedAt
+	}
+	if len(updates) == 0 {
+		return nil, errors.New("no comment settings to apply")
+	}
+
+	var publishedBefore *time.Time
+	if req.OlderThanDays != nil {
+		before := time.Now().AddDate(0, 0, -*req.OlderThanDays)
+		publishedBefore = &before
+	}
+
+	updated, err := s.repo.UpdateCategoryCommentSettings(ctx, req.CategoryID, publishedBefore, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lists don't carry comment settings, so only the articles' own keys go stale
+	for id, slug := range updated {
+		_ = s.cache.Delete(ctx, cache.ArticleKey(id.String()), cache.ArticleSlugKey(slug))
+	}
+
+	return &models.BulkCommentSettingsResult{Updated: len(updated)}, nil
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// SetAuditService records article exports in the audit log
+func (s *ArticleService) SetAuditService(audit *AuditService) {
+	s.audit = audit
+}
+
+// ExportArticles calls fn with every article matching the filter, for a CSV
+// export of the admin list. Exports over models.MaxArticleExportRows are
+// refused with *models.ArticleExportTooLargeError. The export is audited
+// before any row is read; without an audit log it does not happen.
+func (s *ArticleService) ExportArticles(ctx context.Context, filter *models.ArticleFilter, entry *models.AuditLog, fn func(*models.ArticleExportRow) error) error {
+	count, err := s.repo.CountForExport(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if count > models.MaxArticleExportRows {
+		return &models.ArticleExportTooLargeError{Count: count, Limit: models.MaxArticleExportRows}
+	}
+
+	if s.audit == nil {
+		return fmt.Errorf("audit log unavailable")
+	}
+	entry.Action = models.AuditActionArticleExport
+	if err := s.audit.Record(ctx, entry); err != nil {
+		return err
+	}
+
+	// Articles created since the count are cut off at the cap
+	return s.repo.EachForExport(ctx, filter, models.MaxArticleExportRows, fn)
+}
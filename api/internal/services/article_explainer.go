@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/sanitize"
+)
+
+// sanitizeArticleContent sanitizes article HTML but keeps explainer embed
+// tokens, which the sanitizer would strip with every other comment. The HTML
+// around each token is sanitized on its own, and the token rewritten in its
+// plain form.
+func sanitizeArticleContent(content string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range models.ExplainerTokenPattern.FindAllStringSubmatchIndex(content, -1) {
+		b.WriteString(sanitize.SanitizeArticleHTML(content[last:m[0]]))
+		b.WriteString(models.ExplainerToken(content[m[2]:m[3]]))
+		last = m[1]
+	}
+	b.WriteString(sanitize.SanitizeArticleHTML(content[last:]))
+	return b.String()
+}
+
+// syncArticleExplainers records which explainers the article's content
+// embeds, so the article is dropped from the cache when one changes
+func (s *ArticleService) syncArticleExplainers(ctx context.Context, articleID uuid.UUID, content string) error {
+	return s.repo.SetArticleExplainers(ctx, articleID, models.ExplainerSlugs(content))
+}
+
+// embedExplainers fills in the current content of the explainers the
+// article embeds
+func (s *ArticleService) embedExplainers(ctx context.Context, article *models.Article) error {
+	explainers, err := s.repo.GetEmbeddedExplainers(ctx, models.ExplainerSlugs(article.Content))
+	if err != nil {
+		return err
+	}
+	article.EmbeddedExplainers = explainers
+	return nil
+}
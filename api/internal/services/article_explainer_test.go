@@ -0,0 +1,15 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeArticleContent(t *testing.T) {
+	content := `<p>Intro</p><script>alert(1)</script><!-- explainer:pork-barrel --><p onclick="x()">More</p><!-- a note -->`
+
+	got := sanitizeArticleContent(content)
+	assert.Equal(t, `<p>Intro</p><!--explainer:pork-barrel--><p>More</p>`, got)
+	assert.Equal(t, "<p>Plain</p>", sanitizeArticleContent("<p>Plain</p>"))
+}
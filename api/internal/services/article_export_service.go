@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+	"github.com/humfurie/pulpulitiko/api/pkg/htmltext"
+)
+
+// Formats an article can be exported in besides JSON
+const (
+	ArticleExportText     = "text"
+	ArticleExportMarkdown = "markdown"
+)
+
+// GetPlainText renders the article as plain text for screen readers,
+// text-to-speech and Braille displays, with a header listing its byline,
+// publication date, category and tags
+func (s *ArticleService) GetPlainText(ctx context.Context, article *models.Article) string {
+	return s.export(ctx, article, ArticleExportText)
+}
+
+// GetMarkdown renders the article as Markdown under the same header
+func (s *ArticleService) GetMarkdown(ctx context.Context, article *models.Article) string {
+	return s.export(ctx, article, ArticleExportMarkdown)
+}
+
+func (s *ArticleService) export(ctx context.Context, article *models.Article, format string) string {
+	// Previews depend on the reader, so only full articles are cached
+	if article.Access == models.ArticleAccessRestricted || article.ContentLocked {
+		return renderArticleExport(article, format == ArticleExportMarkdown, s.schedule.Location)
+	}
+
+	cacheKey := cache.ArticleExportKey(article.Slug, format)
+
+	var result string
+	if err := s.cache.Get(ctx, cacheKey, &result); err == nil {
+		return result
+	}
+
+	result = renderArticleExport(article, format == ArticleExportMarkdown, s.schedule.Location)
+
+	_ = s.cache.Set(ctx, cacheKey, result, ArticleExportTTL)
+
+	return result
+}
+
+func renderArticleExport(article *models.Article, markdown bool, loc *time.Location) string {
+	var sb strings.Builder
+
+	if markdown {
+		sb.WriteString("# " + article.Title + "\n\n")
+		// Trailing double spaces keep each line as its own line in Markdown
+		sb.WriteString(strings.Join(articleExportHeader(article, loc), "  \n"))
+	} else {
+		sb.WriteString(article.Title + "\n")
+		sb.WriteString(strings.Join(articleExportHeader(article, loc), "\n"))
+	}
+
+	body := htmltext.ToPlainText(article.Content)
+	if markdown {
+		body = htmltext.ToMarkdown(article.Content)
+	}
+	if body != "" {
+		sb.WriteString("\n\n" + body)
+	}
+
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// articleExportHeader lists the article's metadata, skipping anything it
+// doesn't have. The publication date is the day in loc, the site timezone.
+func articleExportHeader(article *models.Article, loc *time.Location) []string {
+	var lines []string
+
+	if byline := articleByline(article); byline != "" {
+		lines = append(lines, "By "+byline)
+	}
+	if article.PublishedAt != nil {
+		lines = append(lines, "Published: "+article.PublishedAt.In(loc).Format("January 2, 2006"))
+	}
+	if article.Category != nil {
+		lines = append(lines, "Category: "+article.Category.Name)
+	}
+	if len(article.Tags) > 0 {
+		names := make([]string, len(article.Tags))
+		for i, tag := range article.Tags {
+			names[i] = tag.Name
+		}
+		lines = append(lines, "Tags: "+strings.Join(names, ", "))
+	}
+
+	return lines
+}
+
+// articleByline joins the author names, falling back to the primary author
+// for articles without a byline list
+func articleByline(article *models.Article) string {
+	var names []string
+	for _, author := range article.Authors {
+		names = append(names, author.Name)
+	}
+	if len(names) == 0 && article.Author != nil {
+		names = append(names, article.Author.Name)
+	}
+
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderArticleExport(t *testing.T) {
+	published := time.Date(2025, time.March, 4, 9, 0, 0, 0, time.UTC)
+	article := &models.Article{
+		Title:       "Senate passes budget",
+		Content:     "<h2>Vote</h2><p>The <strong>Senate</strong> voted.</p>",
+		PublishedAt: &published,
+		Authors: []models.ArticleAuthor{
+			{Name: "Ana Cruz", Position: 0},
+			{Name: "Ben Reyes", Position: 1},
+			{Name: "Carla Santos", Position: 2},
+		},
+		Category: &models.Category{Name: "Politics"},
+		Tags:     []models.Tag{{Name: "Budget"}, {Name: "Senate"}},
+	}
+
+	assert.Equal(t,
+		"Senate passes budget\n"+
+			"By Ana Cruz, Ben Reyes and Carla Santos\n"+
+			"Published: March 4, 2025\n"+
+			"Category: Politics\n"+
+			"Tags: Budget, Senate\n\n"+
+			"## Vote\n\nThe Senate voted.\n",
+		renderArticleExport(article, false, time.UTC))
+
+	assert.Equal(t,
+		"# Senate passes budget\n\n"+
+			"By Ana Cruz, Ben Reyes and Carla Santos  \n"+
+			"Published: March 4, 2025  \n"+
+			"Category: Politics  \n"+
+			"Tags: Budget, Senate\n\n"+
+			"## Vote\n\nThe **Senate** voted.\n",
+		renderArticleExport(article, true, time.UTC))
+}
+
+func TestArticleExportHeaderSkipsMissingFields(t *testing.T) {
+	article := &models.Article{
+		Title:  "Untitled draft",
+		Author: &models.Author{Name: "Ana Cruz"},
+	}
+
+	assert.Equal(t, []string{"By Ana Cruz"}, articleExportHeader(article, time.UTC))
+	assert.Equal(t, "Untitled draft\nBy Ana Cruz\n", renderArticleExport(article, false, time.UTC))
+}
+
+func TestArticleExportHeaderPublishedInSiteTimezone(t *testing.T) {
+	manila, err := time.LoadLocation("Asia/Manila")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Published at 7:30 AM on the 5th in Manila, still the 4th in UTC
+	published := time.Date(2025, time.March, 4, 23, 30, 0, 0, time.UTC)
+	article := &models.Article{Title: "Senate passes budget", PublishedAt: &published}
+
+	assert.Equal(t, []string{"Published: March 4, 2025"}, articleExportHeader(article, time.UTC))
+	assert.Equal(t, []string{"Published: March 5, 2025"}, articleExportHeader(article, manila))
+}
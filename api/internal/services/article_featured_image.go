@@ -0,0 +1,47 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ImageURLResolver gives the public URL of an uploaded file's object key
+type ImageURLResolver interface {
+	GetURL(key string) string
+}
+
+// objectKeyPattern matches upload object keys, such as
+// "2025/03/0b6f...e1.webp": path segments of safe characters ending in a
+// file extension
+var objectKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9_.-]*(/[A-Za-z0-9_-][A-Za-z0-9_.-]*)*\.[A-Za-z0-9]+$`)
+
+// SetImageStorage lets a featured or cover image be given as the object key
+// of an upload instead of its URL
+func (s *ArticleService) SetImageStorage(images ImageURLResolver) {
+	s.images = images
+}
+
+// resolveImage checks the image given for field is an http(s) URL or the
+// object key of an upload, and returns its URL. Empty clears the image.
+func (s *ArticleService) resolveImage(field, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", nil
+	}
+
+	resolved := ""
+	if u, err := url.Parse(ref); err == nil && u.IsAbs() {
+		if (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+			resolved = ref
+		}
+	} else if s.images != nil && objectKeyPattern.MatchString(ref) && !strings.Contains(ref, "..") {
+		resolved = s.images.GetURL(ref)
+	}
+
+	if resolved == "" || len(resolved) > maxMediaURLLength {
+		return "", fmt.Errorf("%s must be an http(s) URL or an uploaded file key", field)
+	}
+	return resolved, nil
+}
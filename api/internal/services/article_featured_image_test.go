@@ -0,0 +1,59 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bucketURLs struct{}
+
+func (bucketURLs) GetURL(key string) string {
+	return "https://media.pulpulitiko.com/politics-media/" + key
+}
+
+func TestResolveImage(t *testing.T) {
+	s := &ArticleService{}
+	s.SetImageStorage(bucketURLs{})
+
+	tests := []struct {
+		name string
+		ref  string
+		want string
+		err  bool
+	}{
+		{"https URL", "https://cdn.example.com/senate.jpg", "https://cdn.example.com/senate.jpg", false},
+		{"http URL", "http://localhost:9000/politics-media/2025/03/a.png", "http://localhost:9000/politics-media/2025/03/a.png", false},
+		{"object key", "2025/03/0b6f4c1e.webp", "https://media.pulpulitiko.com/politics-media/2025/03/0b6f4c1e.webp", false},
+		{"surrounding space", "  2025/03/a.jpg ", "https://media.pulpulitiko.com/politics-media/2025/03/a.jpg", false},
+		{"empty clears", "", "", false},
+		{"other scheme", "ftp://example.com/a.jpg", "", true},
+		{"javascript", "javascript:alert(1)", "", true},
+		{"no extension", "2025/03/image", "", true},
+		{"absolute path", "/2025/03/a.jpg", "", true},
+		{"parent directory", "2025/../secret.jpg", "", true},
+		{"protocol relative", "//cdn.example.com/a.jpg", "", true},
+		{"too long", "https://cdn.example.com/" + strings.Repeat("a", 500) + ".jpg", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.resolveImage("featured_image", tt.ref)
+			if tt.err {
+				assert.EqualError(t, err, "featured_image must be an http(s) URL or an uploaded file key")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// Without storage only URLs are accepted
+	_, err := (&ArticleService{}).resolveImage("featured_image", "2025/03/a.jpg")
+	assert.Error(t, err)
+
+	// The error names the field that was wrong
+	_, err = s.resolveImage("cover_image", "javascript:alert(1)")
+	assert.EqualError(t, err, "cover_image must be an http(s) URL or an uploaded file key")
+}
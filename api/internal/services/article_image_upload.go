@@ -0,0 +1,83 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/imaging"
+)
+
+// articleImagePrefix is the storage folder article images are kept under
+const articleImagePrefix = "articles"
+
+// UploadArticleImage converts an uploaded JPEG or PNG featured image into
+// centre-cropped renditions and stores them under articles/{uuid}. Each
+// rendition is WebP, or JPEG (PNG if transparent) when that is smaller.
+// The image is re-encoded from its pixels alone, so EXIF data such as camera
+// details and GPS position never reaches storage.
+func (s *UploadService) UploadArticleImage(ctx context.Context, file io.Reader, header *multipart.FileHeader) (*models.ArticleImages, error) {
+	data, err := readImageUpload(file, header)
+	if err != nil {
+		return nil, err
+	}
+
+	renditions, err := buildArticleImageRenditions(data)
+	if err != nil {
+		return nil, err
+	}
+
+	folder := fmt.Sprintf("%s/%s", articleImagePrefix, uuid.New().String())
+	urls := make([]string, 0, len(renditions))
+	for _, r := range renditions {
+		key := fmt.Sprintf("%s/%s%s", folder, r.name, r.ext)
+		result, err := s.storage.UploadWithKey(ctx, bytes.NewReader(r.data), key, r.contentType, int64(len(r.data)))
+		if err != nil {
+			// Don't leave a partial set behind
+			s.DeleteEntityImage(ctx, articleImagePrefix, urls...)
+			return nil, fmt.Errorf("failed to upload file: %w", err)
+		}
+		urls = append(urls, result.URL)
+	}
+
+	return &models.ArticleImages{Thumb: urls[0], Full: urls[1]}, nil
+}
+
+// buildArticleImageRenditions validates an uploaded featured image and
+// renders its thumb and full renditions, in that order
+func buildArticleImageRenditions(data []byte) ([]imageVariant, error) {
+	switch http.DetectContentType(data) {
+	case "image/jpeg", "image/png":
+	default:
+		return nil, fmt.Errorf("file type not allowed. Allowed types: JPEG, PNG")
+	}
+
+	img, _, err := imaging.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	renditions := []struct {
+		name          string
+		width, height int
+	}{
+		{"thumb", models.ArticleThumbWidth, models.ArticleThumbHeight},
+		{"full", models.ArticleFullWidth, models.ArticleFullHeight},
+	}
+
+	variants := make([]imageVariant, len(renditions))
+	for i, r := range renditions {
+		var buf bytes.Buffer
+		contentType, ext, err := imaging.EncodeSmallest(&buf, imaging.CropFill(img, r.width, r.height))
+		if err != nil {
+			return nil, err
+		}
+		variants[i] = imageVariant{name: r.name, data: buf.Bytes(), contentType: contentType, ext: ext}
+	}
+	return variants, nil
+}
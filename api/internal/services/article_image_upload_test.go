@@ -0,0 +1,53 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildArticleImageRenditions(t *testing.T) {
+	// A photo-like upload: smooth shading with sensor noise
+	rng := rand.New(rand.NewSource(1))
+	src := image.NewRGBA(image.Rect(0, 0, 1600, 900))
+	for y := 0; y < 900; y++ {
+		for x := 0; x < 1600; x++ {
+			shade := 100 + 60*math.Sin(float64(x)/37) + 40*math.Cos(float64(y)/23)
+			noise := func() uint8 { return uint8(min(max(shade+float64(rng.Intn(17)-8), 0), 255)) }
+			src.Set(x, y, color.RGBA{R: noise(), G: noise(), B: noise(), A: 255})
+		}
+	}
+	var upload bytes.Buffer
+	require.NoError(t, jpeg.Encode(&upload, src, &jpeg.Options{Quality: 85}))
+
+	renditions, err := buildArticleImageRenditions(upload.Bytes())
+	require.NoError(t, err)
+	require.Len(t, renditions, 2)
+
+	for i, want := range []image.Point{
+		{models.ArticleThumbWidth, models.ArticleThumbHeight},
+		{models.ArticleFullWidth, models.ArticleFullHeight},
+	} {
+		r := renditions[i]
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(r.data))
+		require.NoError(t, err)
+		assert.Equal(t, want, image.Pt(cfg.Width, cfg.Height))
+
+		// Cropping and scaling down must never make the file bigger than
+		// the upload it came from
+		assert.Equal(t, "image/jpeg", r.contentType, r.name)
+		assert.Equal(t, ".jpg", r.ext, r.name)
+		assert.Less(t, len(r.data), upload.Len(), r.name)
+	}
+
+	_, err = buildArticleImageRenditions([]byte("GIF89a"))
+	assert.EqualError(t, err, "file type not allowed. Allowed types: JPEG, PNG")
+}
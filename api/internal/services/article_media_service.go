@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/htmltext"
+)
+
+// featuredBackfillBatch is how many articles the backfill reads at a time
+const featuredBackfillBatch = 200
+
+// maxMediaURLLength is the longest URL article_media stores
+const maxMediaURLLength = 500
+
+// ListMedia returns an article's gallery, or nil if the article does not
+// exist
+func (s *ArticleService) ListMedia(ctx context.Context, articleID uuid.UUID) ([]models.ArticleMedia, error) {
+	article, err := s.repo.GetByID(ctx, articleID)
+	if err != nil || article == nil {
+		return nil, err
+	}
+	return article.Gallery, nil
+}
+
+func (s *ArticleService) CreateMedia(ctx context.Context, articleID uuid.UUID, req *models.CreateArticleMediaRequest) (*models.ArticleMedia, error) {
+	m, err := s.repo.CreateArticleMedia(ctx, articleID, req, false)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateArticleByID(ctx, articleID)
+	return m, nil
+}
+
+func (s *ArticleService) UpdateMedia(ctx context.Context, articleID, id uuid.UUID, req *models.UpdateArticleMediaRequest) (*models.ArticleMedia, error) {
+	if err := s.checkMediaOwner(ctx, articleID, id); err != nil {
+		return nil, err
+	}
+
+	m, err := s.repo.UpdateArticleMedia(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateArticleByID(ctx, articleID)
+	return m, nil
+}
+
+func (s *ArticleService) DeleteMedia(ctx context.Context, articleID, id uuid.UUID) error {
+	if err := s.checkMediaOwner(ctx, articleID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteArticleMedia(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateArticleByID(ctx, articleID)
+	return nil
+}
+
+// ReorderMedia puts an article's gallery in the given order and returns it
+func (s *ArticleService) ReorderMedia(ctx context.Context, articleID uuid.UUID, ids []uuid.UUID) ([]models.ArticleMedia, error) {
+	article, err := s.repo.GetByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if article == nil {
+		return nil, fmt.Errorf("article not found")
+	}
+
+	if err := s.repo.ReorderArticleMedia(ctx, articleID, ids); err != nil {
+		return nil, err
+	}
+	s.invalidateArticleCache(ctx, articleID, article, s.mentionedPoliticianIDs(ctx, articleID))
+	return s.repo.GetArticleMedia(ctx, articleID)
+}
+
+// BackfillFeaturedImages gives every article without featured media one:
+// its featured image if it has one, otherwise the first image of its
+// content, marked provisional for an editor to review
+func (s *ArticleService) BackfillFeaturedImages(ctx context.Context) (*models.FeaturedImageBackfill, error) {
+	result := &models.FeaturedImageBackfill{}
+
+	after := uuid.Nil
+	for {
+		articles, err := s.repo.ListArticlesWithoutFeaturedMedia(ctx, after, featuredBackfillBatch)
+		if err != nil {
+			return nil, err
+		}
+		if len(articles) == 0 {
+			break
+		}
+		after = articles[len(articles)-1].ID
+
+		for _, article := range articles {
+			result.ArticlesScanned++
+			req, provisional := backfillMediaRequest(&article)
+			if req == nil {
+				result.WithoutImage++
+				continue
+			}
+
+			if _, err := s.repo.CreateArticleMedia(ctx, article.ID, req, provisional); err != nil {
+				return nil, fmt.Errorf("article %s: %w", article.ID, err)
+			}
+			s.invalidateArticleByID(ctx, article.ID)
+			if provisional {
+				result.FromContent++
+			} else {
+				result.FromFeaturedImage++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// checkMediaOwner rejects a gallery item that belongs to another article as
+// not found
+func (s *ArticleService) checkMediaOwner(ctx context.Context, articleID, id uuid.UUID) error {
+	m, err := s.repo.GetArticleMediaItem(ctx, id)
+	if err != nil {
+		return err
+	}
+	if m == nil || m.ArticleID != articleID {
+		return fmt.Errorf("article media not found")
+	}
+	return nil
+}
+
+// backfillMediaRequest picks the featured media for an article that has
+// none: its featured image, or else the first image of its content, which
+// is provisional. It returns nil when there is no usable image.
+func backfillMediaRequest(article *models.Article) (*models.CreateArticleMediaRequest, bool) {
+	if article.FeaturedImage != nil && *article.FeaturedImage != "" {
+		return &models.CreateArticleMediaRequest{
+			URL:        *article.FeaturedImage,
+			Images:     article.Images,
+			IsFeatured: true,
+		}, false
+	}
+
+	url := htmltext.FirstImage(article.Content)
+	if url == "" || len(url) > maxMediaURLLength {
+		return nil, false
+	}
+	return &models.CreateArticleMediaRequest{URL: url, IsFeatured: true}, true
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillMediaRequest(t *testing.T) {
+	featured := "https://cdn.pulpulitiko.com/uploads/budget.jpg"
+	images := &models.ArticleImages{Thumb: "https://cdn.pulpulitiko.com/a/thumb.webp", Full: "https://cdn.pulpulitiko.com/a/full.webp"}
+	content := `<p>Intro</p><img src="https://example.com/inline.jpg">`
+
+	req, provisional := backfillMediaRequest(&models.Article{FeaturedImage: &featured, Images: images, Content: content})
+	require.NotNil(t, req)
+	assert.False(t, provisional, "an editor chose the featured image")
+	assert.Equal(t, featured, req.URL)
+	assert.Equal(t, images, req.Images)
+	assert.True(t, req.IsFeatured)
+
+	req, provisional = backfillMediaRequest(&models.Article{Content: content})
+	require.NotNil(t, req)
+	assert.True(t, provisional)
+	assert.Equal(t, "https://example.com/inline.jpg", req.URL)
+	assert.Nil(t, req.Images)
+
+	req, _ = backfillMediaRequest(&models.Article{Content: "<p>No images</p>"})
+	assert.Nil(t, req)
+
+	long := `<img src="https://example.com/` + strings.Repeat("a", maxMediaURLLength) + `.jpg">`
+	req, _ = backfillMediaRequest(&models.Article{Content: long})
+	assert.Nil(t, req, "URLs too long to store are skipped")
+}
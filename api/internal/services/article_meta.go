@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/htmltext"
+)
+
+// GetMeta returns the social sharing metadata of an article. It is the same
+// for every caller, so it's built from what an anonymous reader sees: a
+// preview of members-only articles, and only the premium summary of premium
+// ones. This doesn't count against any reader's free articles.
+func (s *ArticleService) GetMeta(ctx context.Context, article *models.Article) *models.ArticleMeta {
+	s.ApplyAccess(ctx, article, ArticleReader{})
+	return articleMeta(article, s.siteURL)
+}
+
+// articleMeta builds an article's sharing metadata. The description is the
+// summary, or one generated from the content. The image is the featured
+// image, preferring its full-size rendition, or else the first image in the
+// content.
+func articleMeta(article *models.Article, siteURL string) *models.ArticleMeta {
+	meta := &models.ArticleMeta{
+		Title:         article.Title,
+		CanonicalURL:  strings.TrimRight(siteURL, "/") + "/article/" + article.Slug,
+		PublishedTime: article.PublishedAt,
+		ModifiedTime:  article.UpdatedAt,
+		Authors:       []string{},
+		Tags:          []string{},
+		Image:         articleMetaImage(article),
+	}
+
+	if isBlankSummary(article.Summary) {
+		meta.Description = autoSummary(article.Title, article.Content)
+	} else {
+		meta.Description = strings.TrimSpace(*article.Summary)
+	}
+
+	for _, author := range article.Authors {
+		meta.Authors = append(meta.Authors, author.Name)
+	}
+	if len(meta.Authors) == 0 && article.Author != nil {
+		meta.Authors = append(meta.Authors, article.Author.Name)
+	}
+	if len(meta.Authors) > 0 {
+		meta.Author = meta.Authors[0]
+	}
+
+	if article.Category != nil {
+		meta.Section = article.Category.Name
+	}
+	for _, tag := range article.Tags {
+		meta.Tags = append(meta.Tags, tag.Name)
+	}
+
+	return meta
+}
+
+func articleMetaImage(article *models.Article) *models.ArticleMetaImage {
+	// A cover image is chosen for link previews, so it comes first. The
+	// featured media's caption describes a different image.
+	if article.CoverImage != nil && *article.CoverImage != "" {
+		return &models.ArticleMetaImage{URL: *article.CoverImage}
+	}
+
+	var image *models.ArticleMetaImage
+	switch {
+	case article.Images != nil:
+		image = &models.ArticleMetaImage{
+			URL:    article.Images.Full,
+			Width:  models.ArticleFullWidth,
+			Height: models.ArticleFullHeight,
+		}
+	case article.FeaturedImage != nil && *article.FeaturedImage != "":
+		image = &models.ArticleMetaImage{URL: *article.FeaturedImage}
+	default:
+		if url := htmltext.FirstImage(article.Content); url != "" {
+			return &models.ArticleMetaImage{URL: url}
+		}
+		return nil
+	}
+
+	if article.FeaturedMedia != nil && article.FeaturedMedia.Caption != nil {
+		image.Alt = *article.FeaturedMedia.Caption
+	}
+	return image
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArticleMeta(t *testing.T) {
+	published := time.Date(2025, 5, 12, 8, 0, 0, 0, time.UTC)
+	summary := "  Senate passes the budget "
+	caption := "Senators vote on the budget"
+	article := &models.Article{
+		Slug:        "budget-passes",
+		Title:       "Budget passes",
+		Summary:     &summary,
+		Content:     `<p>The vote was 20-3.</p><img src="https://example.com/inline.jpg">`,
+		PublishedAt: &published,
+		UpdatedAt:   published.Add(time.Hour),
+		Images:      &models.ArticleImages{Thumb: "https://cdn.pulpulitiko.com/a/thumb.webp", Full: "https://cdn.pulpulitiko.com/a/full.webp"},
+		Authors:     []models.ArticleAuthor{{Name: "Maria Santos"}, {Name: "Jose Cruz", Position: 1}},
+		Category:    &models.Category{Name: "Senate"},
+		Tags:        []models.Tag{{Name: "Budget"}},
+		FeaturedMedia: &models.ArticleMedia{
+			Caption: &caption,
+		},
+	}
+
+	meta := articleMeta(article, "https://pulpulitiko.com/")
+	assert.Equal(t, "Budget passes", meta.Title)
+	assert.Equal(t, "Senate passes the budget", meta.Description)
+	assert.Equal(t, "https://pulpulitiko.com/article/budget-passes", meta.CanonicalURL)
+	assert.Equal(t, &published, meta.PublishedTime)
+	assert.Equal(t, published.Add(time.Hour), meta.ModifiedTime)
+	assert.Equal(t, "Maria Santos", meta.Author)
+	assert.Equal(t, []string{"Maria Santos", "Jose Cruz"}, meta.Authors)
+	assert.Equal(t, "Senate", meta.Section)
+	assert.Equal(t, []string{"Budget"}, meta.Tags)
+	require.NotNil(t, meta.Image)
+	assert.Equal(t, models.ArticleMetaImage{URL: "https://cdn.pulpulitiko.com/a/full.webp", Width: 1200, Height: 675, Alt: caption}, *meta.Image)
+
+	cover := "https://cdn.pulpulitiko.com/covers/budget.png"
+	article.CoverImage = &cover
+	assert.Equal(t, &models.ArticleMetaImage{URL: cover}, articleMeta(article, "").Image, "a cover image wins over the featured image")
+}
+
+func TestArticleMetaFallbacks(t *testing.T) {
+	article := &models.Article{
+		Slug:    "draft",
+		Title:   "Draft",
+		Content: `<p>The vote was 20-3.</p><img src="https://example.com/inline.jpg">`,
+	}
+
+	meta := articleMeta(article, "https://pulpulitiko.com")
+	assert.Equal(t, "The vote was 20-3.", meta.Description, "a blank summary is generated from the content")
+	assert.Nil(t, meta.PublishedTime)
+	assert.Empty(t, meta.Author)
+	assert.NotNil(t, meta.Authors)
+	assert.NotNil(t, meta.Tags)
+	require.NotNil(t, meta.Image)
+	assert.Equal(t, models.ArticleMetaImage{URL: "https://example.com/inline.jpg"}, *meta.Image, "the first image in the content")
+
+	upload := "https://cdn.pulpulitiko.com/uploads/budget.jpg"
+	article.FeaturedImage = &upload
+	assert.Equal(t, upload, articleMeta(article, "").Image.URL, "an uploaded featured image without renditions")
+
+	article.FeaturedImage = nil
+	article.Content = "<p>No images</p>"
+	assert.Nil(t, articleMeta(article, "").Image)
+}
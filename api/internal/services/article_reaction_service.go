@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// GetReactions summarizes the reactions on an article. userID, when given,
+// picks out that user's reaction.
+func (s *ArticleService) GetReactions(ctx context.Context, articleID uuid.UUID, userID *uuid.UUID) (*models.ArticleReactions, error) {
+	counts, err := s.repo.GetReactionSummary(ctx, articleID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewArticleReactions(counts), nil
+}
+
+// React sets the user's reaction to an article, replacing any earlier one
+func (s *ArticleService) React(ctx context.Context, articleID, userID uuid.UUID, reaction string) (*models.ArticleReactions, error) {
+	if !models.IsValidReaction(reaction) {
+		return nil, fmt.Errorf("invalid reaction type")
+	}
+
+	if err := s.repo.SetReaction(ctx, articleID, userID, reaction); err != nil {
+		return nil, err
+	}
+
+	return s.GetReactions(ctx, articleID, &userID)
+}
+
+// Unreact removes the user's reaction to an article
+func (s *ArticleService) Unreact(ctx context.Context, articleID, userID uuid.UUID) (*models.ArticleReactions, error) {
+	if err := s.repo.RemoveReaction(ctx, articleID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.GetReactions(ctx, articleID, &userID)
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+// ArticleScheduleConfig controls how closely articles may be scheduled and
+// how fast the scheduled publisher puts them out
+type ArticleScheduleConfig struct {
+	// Site timezone. Publish times without an offset are read in it and the
+	// timeline is shown in it; everything is stored as UTC.
+	Location *time.Location
+
+	// An article crowds its window when more than ConflictLimit articles,
+	// itself included, publish within ConflictWindow of it
+	ConflictWindow time.Duration
+	ConflictLimit  int
+
+	// With EnforceCap set, the publisher puts out at most PublishCap articles
+	// per trailing hour and moves the rest back by Stagger each
+	PublishCap int
+	EnforceCap bool
+	Stagger    time.Duration
+}
+
+var DefaultArticleScheduleConfig = ArticleScheduleConfig{
+	Location:       time.UTC,
+	ConflictWindow: 15 * time.Minute,
+	ConflictLimit:  3,
+	PublishCap:     10,
+	Stagger:        5 * time.Minute,
+}
+
+// SetScheduleConfig sets the scheduling limits and site timezone
+func (s *ArticleService) SetScheduleConfig(cfg ArticleScheduleConfig) {
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	s.schedule = cfg
+}
+
+// ScheduleLocation returns the site timezone publish times are entered in
+func (s *ArticleService) ScheduleLocation() *time.Location {
+	return s.schedule.Location
+}
+
+// parsePublishedAt parses a requested publish time, if there is one
+func (s *ArticleService) parsePublishedAt(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	t, err := models.ParseScheduleTime(*value, s.schedule.Location)
+	if err != nil {
+		return nil, errors.New("published_at must be RFC 3339 or a local YYYY-MM-DDTHH:MM time")
+	}
+	return &t, nil
+}
+
+// resolveSchedule settles the status and publish time of an article moving
+// from prev (nil for a new article) to status. publishedAt is the requested
+// publish time, if any. The returned time is nil when it shouldn't change.
+func resolveSchedule(prev *models.Article, status models.ArticleStatus, publishedAt *time.Time, now time.Time) (models.ArticleStatus, *time.Time, error) {
+	var prevStatus models.ArticleStatus
+	var prevPublishedAt *time.Time
+	if prev != nil {
+		prevStatus, prevPublishedAt = prev.Status, prev.PublishedAt
+	}
+
+	if publishedAt == nil {
+		switch {
+		case status == models.ArticleStatusPublished && prevStatus != models.ArticleStatusPublished:
+			publishedAt = &now
+		case status == models.ArticleStatusScheduled && prevStatus == models.ArticleStatusScheduled:
+			// Stays queued at its current time
+			return status, nil, nil
+		case status == models.ArticleStatusScheduled:
+			publishedAt = prevPublishedAt
+		}
+	}
+
+	switch {
+	case status == models.ArticleStatusScheduled && publishedAt == nil:
+		return "", nil, errors.New("published_at is required to schedule an article")
+	case status == models.ArticleStatusScheduled && !publishedAt.After(now):
+		return "", nil, errors.New("scheduled publish time must be in the future")
+	case status == models.ArticleStatusPublished && publishedAt != nil && publishedAt.After(now):
+		// Publishing with a future time queues the article instead
+		status = models.ArticleStatusScheduled
+	}
+
+	return status, publishedAt, nil
+}
+
+// scheduleConflicts lists the other articles publishing close to article when
+// there are more than the configured limit, and nothing otherwise
+func (s *ArticleService) scheduleConflicts(ctx context.Context, article *models.Article) []models.ScheduledArticle {
+	if article.PublishedAt == nil ||
+		(article.Status != models.ArticleStatusScheduled && article.Status != models.ArticleStatusPublished) {
+		return nil
+	}
+
+	at := *article.PublishedAt
+	window := s.schedule.ConflictWindow
+	others, err := s.repo.ListScheduled(ctx, at.Add(-window), at.Add(window), article.ID)
+	if err != nil || len(others)+1 <= s.schedule.ConflictLimit {
+		return nil
+	}
+
+	localizeSchedule(others, s.schedule.Location)
+	return others
+}
+
+// GetSchedule returns the articles queued or published between from and to
+func (s *ArticleService) GetSchedule(ctx context.Context, from, to time.Time) (*models.ArticleSchedule, error) {
+	articles, err := s.repo.ListScheduled(ctx, from, to, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := s.schedule.Location
+	localizeSchedule(articles, loc)
+
+	return &models.ArticleSchedule{
+		From:     from.In(loc),
+		To:       to.In(loc),
+		Timezone: loc.String(),
+		Articles: articles,
+	}, nil
+}
+
+// PublishScheduled publishes the scheduled articles that are due. With the
+// hourly cap enforced, articles over it are moved back instead.
+func (s *ArticleService) PublishScheduled(ctx context.Context) (published, rescheduled int, err error) {
+	now := time.Now()
+
+	due, err := s.repo.ListDueScheduled(ctx, now)
+	if err != nil || len(due) == 0 {
+		return 0, 0, err
+	}
+
+	allowed := len(due)
+	if s.schedule.EnforceCap {
+		recent, err := s.repo.CountPublishedBetween(ctx, now.Add(-time.Hour), now)
+		if err != nil {
+			return 0, 0, err
+		}
+		allowed = s.schedule.PublishCap - recent
+	}
+
+	publish, adjustments := planScheduledPublishing(due, allowed, now, s.schedule.Stagger)
+
+	if err := s.repo.RescheduleArticles(ctx, adjustments); err != nil {
+		return 0, 0, err
+	}
+	for _, adj := range adjustments {
+		_ = s.cache.Delete(ctx, cache.ArticleKey(adj.ArticleID.String()))
+	}
+
+	ids, err := s.repo.PublishScheduled(ctx, publish)
+	if err != nil {
+		return 0, len(adjustments), err
+	}
+	for _, id := range ids {
+		article, _ := s.repo.GetByID(ctx, id)
+		s.invalidateArticleCache(ctx, id, article, s.mentionedPoliticianIDs(ctx, id))
+		if article != nil {
+			s.emitPublished(ctx, article)
+		}
+	}
+
+	return len(ids), len(adjustments), nil
+}
+
+// planScheduledPublishing splits the due articles into the first allowed to
+// publish now and the rest, which are staggered after now in order
+func planScheduledPublishing(due []models.ScheduledArticle, allowed int, now time.Time, stagger time.Duration) ([]uuid.UUID, []models.ArticleScheduleAdjustment) {
+	allowed = min(max(allowed, 0), len(due))
+
+	publish := make([]uuid.UUID, 0, allowed)
+	for _, article := range due[:allowed] {
+		publish = append(publish, article.ID)
+	}
+
+	var adjustments []models.ArticleScheduleAdjustment
+	for i, article := range due[allowed:] {
+		adjustments = append(adjustments, models.ArticleScheduleAdjustment{
+			ArticleID:         article.ID,
+			OriginalPublishAt: article.PublishedAt,
+			AdjustedPublishAt: now.Add(stagger * time.Duration(i+1)),
+			Reason:            models.ScheduleAdjustmentPublishCap,
+		})
+	}
+
+	return publish, adjustments
+}
+
+// localizeSchedule converts the timeline's times to the site timezone
+func localizeSchedule(articles []models.ScheduledArticle, loc *time.Location) {
+	for i := range articles {
+		articles[i].PublishedAt = articles[i].PublishedAt.In(loc)
+		if adj := articles[i].Adjustment; adj != nil {
+			adj.OriginalPublishAt = adj.OriginalPublishAt.In(loc)
+			adj.AdjustedPublishAt = adj.AdjustedPublishAt.In(loc)
+			adj.CreatedAt = adj.CreatedAt.In(loc)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSchedule(t *testing.T) {
+	now := time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time { t := now.Add(d); return &t }
+
+	tests := []struct {
+		name       string
+		prev       *models.Article
+		status     models.ArticleStatus
+		requested  *time.Time
+		wantStatus models.ArticleStatus
+		wantTime   *time.Time
+		wantErr    string
+	}{
+		{name: "draft keeps no time", status: models.ArticleStatusDraft, wantStatus: models.ArticleStatusDraft},
+		{name: "publishing now", status: models.ArticleStatusPublished, wantStatus: models.ArticleStatusPublished, wantTime: &now},
+		{name: "backdated publish", status: models.ArticleStatusPublished, requested: at(-time.Hour),
+			wantStatus: models.ArticleStatusPublished, wantTime: at(-time.Hour)},
+		{name: "future publish is scheduled", status: models.ArticleStatusPublished, requested: at(time.Hour),
+			wantStatus: models.ArticleStatusScheduled, wantTime: at(time.Hour)},
+		{name: "scheduling", status: models.ArticleStatusScheduled, requested: at(time.Hour),
+			wantStatus: models.ArticleStatusScheduled, wantTime: at(time.Hour)},
+		{name: "scheduling without time", status: models.ArticleStatusScheduled,
+			wantErr: "published_at is required to schedule an article"},
+		{name: "scheduling in the past", status: models.ArticleStatusScheduled, requested: at(-time.Minute),
+			wantErr: "scheduled publish time must be in the future"},
+		{name: "already published keeps its time",
+			prev:   &models.Article{Status: models.ArticleStatusPublished, PublishedAt: at(-24 * time.Hour)},
+			status: models.ArticleStatusPublished, wantStatus: models.ArticleStatusPublished},
+		{name: "already scheduled keeps its time",
+			prev:   &models.Article{Status: models.ArticleStatusScheduled, PublishedAt: at(-time.Second)},
+			status: models.ArticleStatusScheduled, wantStatus: models.ArticleStatusScheduled},
+		{name: "scheduled article published early",
+			prev:   &models.Article{Status: models.ArticleStatusScheduled, PublishedAt: at(time.Hour)},
+			status: models.ArticleStatusPublished, wantStatus: models.ArticleStatusPublished, wantTime: &now},
+		{name: "draft scheduled at its saved time",
+			prev:   &models.Article{Status: models.ArticleStatusDraft, PublishedAt: at(time.Hour)},
+			status: models.ArticleStatusScheduled, wantStatus: models.ArticleStatusScheduled, wantTime: at(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, publishedAt, err := resolveSchedule(tt.prev, tt.status, tt.requested, now)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantTime, publishedAt)
+		})
+	}
+}
+
+func TestPlanScheduledPublishing(t *testing.T) {
+	now := time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC)
+	due := make([]models.ScheduledArticle, 4)
+	for i := range due {
+		due[i] = models.ScheduledArticle{ID: uuid.New(), PublishedAt: now.Add(-time.Duration(4-i) * time.Minute)}
+	}
+
+	publish, adjustments := planScheduledPublishing(due, 2, now, 5*time.Minute)
+	assert.Equal(t, []uuid.UUID{due[0].ID, due[1].ID}, publish)
+	require.Len(t, adjustments, 2)
+	for i, adj := range adjustments {
+		assert.Equal(t, due[i+2].ID, adj.ArticleID)
+		assert.Equal(t, due[i+2].PublishedAt, adj.OriginalPublishAt)
+		assert.Equal(t, now.Add(time.Duration(i+1)*5*time.Minute), adj.AdjustedPublishAt)
+		assert.Equal(t, models.ScheduleAdjustmentPublishCap, adj.Reason)
+	}
+
+	// Over the cap already: everything is moved back
+	publish, adjustments = planScheduledPublishing(due, -3, now, 5*time.Minute)
+	assert.Empty(t, publish)
+	assert.Len(t, adjustments, 4)
+
+	// Room for all of them
+	publish, adjustments = planScheduledPublishing(due, 10, now, 5*time.Minute)
+	assert.Len(t, publish, 4)
+	assert.Empty(t, adjustments)
+}
@@ -5,24 +5,47 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/repository"
 	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+	"github.com/humfurie/pulpulitiko/api/pkg/htmltext"
+	"github.com/humfurie/pulpulitiko/api/pkg/metrics"
+	"github.com/humfurie/pulpulitiko/api/pkg/sanitize"
+	"github.com/humfurie/pulpulitiko/api/pkg/seo"
+	"github.com/humfurie/pulpulitiko/api/pkg/textutil"
 )
 
 const (
 	ArticleCacheTTL     = 15 * time.Minute
 	ArticleListCacheTTL = 5 * time.Minute
 	TrendingCacheTTL    = 10 * time.Minute
+	ArticleExportTTL    = 1 * time.Hour
+	KeywordDensityTTL   = 30 * time.Minute
 )
 
+// TrendingReactionWeight is how many views a reader reaction counts as in
+// the trending score
+const TrendingReactionWeight = 5
+
 type ArticleService struct {
 	repo           *repository.ArticleRepository
 	politicianRepo *repository.PoliticianRepository
 	cache          *cache.RedisCache
+	siteURL        string
+	siteHost       string
+	seo            *seo.Analyzer
+	schedule       ArticleScheduleConfig
+	access         ArticleAccessConfig
+	members        MemberLookup
+	webhooks       *WebhookService
+	audit          *AuditService
+	searches       *SearchCacheInvalidator
+	images         ImageURLResolver
 }
 
 func NewArticleService(repo *repository.ArticleRepository, politicianRepo *repository.PoliticianRepository, cache *cache.RedisCache) *ArticleService {
@@ -30,23 +53,137 @@ func NewArticleService(repo *repository.ArticleRepository, politicianRepo *repos
 		repo:           repo,
 		politicianRepo: politicianRepo,
 		cache:          cache,
+		seo:            seo.NewAnalyzer(""),
+		schedule:       DefaultArticleScheduleConfig,
+		access:         DefaultArticleAccessConfig,
+		searches:       NewSearchCacheInvalidator(cache),
+	}
+}
+
+// SetSiteURL sets the site's public URL, so views referred from its own pages
+// are counted as internal traffic, links to them as internal links, and
+// articles get canonical URLs
+func (s *ArticleService) SetSiteURL(siteURL string) {
+	s.siteURL = siteURL
+	if u, err := url.Parse(siteURL); err == nil {
+		s.siteHost = u.Hostname()
+	}
+	s.seo = seo.NewAnalyzer(siteURL)
+}
+
+// GetSEOReport scores the article's current draft or published version for
+// search engines, or returns nil if there is no such article. It is only
+// worked out on request, so saving never waits on it.
+func (s *ArticleService) GetSEOReport(ctx context.Context, id uuid.UUID) (*seo.SEOReport, error) {
+	article, err := s.repo.GetByID(ctx, id)
+	if err != nil || article == nil {
+		return nil, err
+	}
+
+	report := s.seo.Analyze(article)
+	return &report, nil
+}
+
+// GetKeywordDensity reports the most frequent words of the article's current
+// draft or published version and how often its focus keyword appears, or
+// returns nil if there is no such article
+func (s *ArticleService) GetKeywordDensity(ctx context.Context, id uuid.UUID) (*textutil.KeywordDensity, error) {
+	cacheKey := cache.ArticleKeywordDensityKey(id.String())
+
+	var report textutil.KeywordDensity
+	if err := s.cache.Get(ctx, cacheKey, &report); err == nil {
+		return &report, nil
+	}
+
+	article, err := s.repo.GetByID(ctx, id)
+	if err != nil || article == nil {
+		return nil, err
+	}
+
+	focusKeyword := ""
+	if article.FocusKeyword != nil {
+		focusKeyword = *article.FocusKeyword
 	}
+	report = textutil.AnalyzeKeywordDensity(htmltext.ToPlainText(article.Content), focusKeyword)
+
+	_ = s.cache.Set(ctx, cacheKey, report, KeywordDensityTTL)
+
+	return &report, nil
+}
+
+// SetWebhookService enables webhook events for published articles
+func (s *ArticleService) SetWebhookService(webhooks *WebhookService) {
+	s.webhooks = webhooks
 }
 
 func (s *ArticleService) Create(ctx context.Context, req *models.CreateArticleRequest) (*models.Article, error) {
+	if req.FeaturedImage != nil {
+		image, err := s.resolveImage("featured_image", *req.FeaturedImage)
+		if err != nil {
+			return nil, err
+		}
+		if image == "" {
+			req.FeaturedImage = nil
+		} else {
+			req.FeaturedImage = &image
+		}
+	}
+	if req.CoverImage != nil {
+		image, err := s.resolveImage("cover_image", *req.CoverImage)
+		if err != nil {
+			return nil, err
+		}
+		if image == "" {
+			req.CoverImage = nil
+		} else {
+			req.CoverImage = &image
+		}
+	}
+
 	article := &models.Article{
 		Slug:          req.Slug,
 		Title:         req.Title,
 		Summary:       req.Summary,
-		Content:       req.Content,
+		Content:       sanitizeArticleContent(req.Content),
 		FeaturedImage: req.FeaturedImage,
+		CoverImage:    req.CoverImage,
+		Images:        req.Images,
 		Status:        models.ArticleStatusDraft,
+		AccessLevel:   models.ArticleAccessPublic,
+
+		CommentsEnabled: true,
+	}
+	if isBlankSummary(article.Summary) {
+		summary := autoSummary(article.Title, article.Content)
+		article.Summary = &summary
+	}
+	if req.AccessLevel != "" {
+		article.AccessLevel = req.AccessLevel
+	}
+	article.IsPremium = req.IsPremium
+	if req.PremiumSummary != nil && *req.PremiumSummary != "" {
+		summary := sanitize.SanitizeArticleHTML(*req.PremiumSummary)
+		article.PremiumSummary = &summary
+	}
+	if req.FocusKeyword != nil {
+		if keyword := strings.TrimSpace(*req.FocusKeyword); keyword != "" {
+			article.FocusKeyword = &keyword
+		}
 	}
 
 	if req.Status != "" {
 		article.Status = models.ArticleStatus(req.Status)
 	}
 
+	publishedAt, err := s.parsePublishedAt(req.PublishedAt)
+	if err != nil {
+		return nil, err
+	}
+	article.Status, article.PublishedAt, err = resolveSchedule(nil, article.Status, publishedAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	if req.AuthorID != nil {
 		id, err := uuid.Parse(*req.AuthorID)
 		if err != nil {
@@ -71,10 +208,35 @@ func (s *ArticleService) Create(ctx context.Context, req *models.CreateArticleRe
 		article.PrimaryPoliticianID = &id
 	}
 
+	coAuthorIDs, err := parseCoAuthorIDs(article.AuthorID, req.CoAuthorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CommentsEnabled != nil {
+		article.CommentsEnabled = *req.CommentsEnabled
+	}
+	if req.CommentsPremoderated != nil {
+		article.CommentsPremoderated = *req.CommentsPremoderated
+	}
+	if article.CommentsLockedAt, err = s.parseCommentsLockedAt(req.CommentsLockedAt); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.Create(ctx, article); err != nil {
 		return nil, err
 	}
 
+	if err := s.syncArticleExplainers(ctx, article.ID, article.Content); err != nil {
+		return nil, err
+	}
+
+	if len(coAuthorIDs) > 0 {
+		if err := s.repo.SetArticleCoAuthors(ctx, article.ID, coAuthorIDs); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set tags if provided
 	if len(req.TagIDs) > 0 {
 		tagUUIDs := make([]uuid.UUID, len(req.TagIDs))
@@ -105,13 +267,42 @@ func (s *ArticleService) Create(ctx context.Context, req *models.CreateArticleRe
 		}
 	}
 
-	// Invalidate list cache
-	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixArticleList+"*")
+	for i := range req.Sources {
+		if _, err := s.repo.CreateArticleSource(ctx, article.ID, &req.Sources[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.repo.GetByID(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
 
-	return s.repo.GetByID(ctx, article.ID)
+	// Invalidate only the list families the new article can appear in
+	_ = s.cache.InvalidateTag(ctx, articleFamilyTags(created, req.PoliticianIDs)...)
+	_ = s.cache.Delete(ctx, cache.TrendingKey())
+
+	created.ScheduleConflicts = s.scheduleConflicts(ctx, created)
+	created.SourceWarning = sourceWarning(created)
+
+	if created.Status == models.ArticleStatusPublished {
+		s.emitPublished(ctx, created)
+	}
+
+	return created, nil
 }
 
 func (s *ArticleService) GetByID(ctx context.Context, id uuid.UUID) (*models.Article, error) {
+	article, err := s.getByID(ctx, id)
+	if err != nil || article == nil {
+		return article, err
+	}
+	s.addPendingViews(ctx, article)
+	article.CommentState = article.EffectiveCommentState(time.Now())
+	return article, nil
+}
+
+func (s *ArticleService) getByID(ctx context.Context, id uuid.UUID) (*models.Article, error) {
 	cacheKey := cache.ArticleKey(id.String())
 
 	var article models.Article
@@ -126,13 +317,40 @@ func (s *ArticleService) GetByID(ctx context.Context, id uuid.UUID) (*models.Art
 	if result == nil {
 		return nil, nil
 	}
+	if err := s.embedExplainers(ctx, result); err != nil {
+		return nil, err
+	}
 
 	_ = s.cache.Set(ctx, cacheKey, result, ArticleCacheTTL)
 
 	return result, nil
 }
 
+// ListRSS returns the latest published articles for the RSS feed, as an
+// anonymous reader may see them: premium articles carry their premium summary
+// and members-only articles their preview
+func (s *ArticleService) ListRSS(ctx context.Context, limit int) ([]models.Article, error) {
+	articles, err := s.repo.ListRSS(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range articles {
+		s.ApplyAccess(ctx, &articles[i], ArticleReader{})
+	}
+	return articles, nil
+}
+
 func (s *ArticleService) GetBySlug(ctx context.Context, slug string) (*models.Article, error) {
+	article, err := s.getBySlug(ctx, slug)
+	if err != nil || article == nil {
+		return article, err
+	}
+	s.addPendingViews(ctx, article)
+	article.CommentState = article.EffectiveCommentState(time.Now())
+	return article, nil
+}
+
+func (s *ArticleService) getBySlug(ctx context.Context, slug string) (*models.Article, error) {
 	cacheKey := cache.ArticleSlugKey(slug)
 
 	var article models.Article
@@ -147,19 +365,23 @@ func (s *ArticleService) GetBySlug(ctx context.Context, slug string) (*models.Ar
 	if result == nil {
 		return nil, nil
 	}
+	if err := s.embedExplainers(ctx, result); err != nil {
+		return nil, err
+	}
 
 	_ = s.cache.Set(ctx, cacheKey, result, ArticleCacheTTL)
 
 	return result, nil
 }
 
+// ResolveSlugRedirect returns the current slug for a article that used to be
+// at oldSlug, or "" if it never was
+func (s *ArticleService) ResolveSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	return s.repo.GetSlugRedirect(ctx, oldSlug)
+}
+
 func (s *ArticleService) List(ctx context.Context, filter *models.ArticleFilter, page, perPage int) (*models.PaginatedArticles, error) {
-	if page < 1 {
-		page = 1
-	}
-	if perPage < 1 || perPage > 100 {
-		perPage = 20
-	}
+	page, perPage = articlePage(page, perPage)
 
 	filterHash := hashFilter(filter)
 	cacheKey := cache.ArticleListKey(page, perPage, filterHash)
@@ -174,12 +396,22 @@ func (s *ArticleService) List(ctx context.Context, filter *models.ArticleFilter,
 		return nil, err
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, articles, ArticleListCacheTTL)
+	_ = s.cache.Set(ctx, cacheKey, articles, ArticleListCacheTTL, articleListTags(filter)...)
 
 	return articles, nil
 }
 
 func (s *ArticleService) Update(ctx context.Context, id uuid.UUID, req *models.UpdateArticleRequest) (*models.Article, error) {
+	// Snapshot the article so lists it is leaving get invalidated too
+	before, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return nil, nil
+	}
+	beforeMentions := s.mentionedPoliticianIDs(ctx, id)
+
 	updates := make(map[string]interface{})
 
 	if req.Slug != nil {
@@ -188,27 +420,75 @@ func (s *ArticleService) Update(ctx context.Context, id uuid.UUID, req *models.U
 	if req.Title != nil {
 		updates["title"] = *req.Title
 	}
-	if req.Summary != nil {
-		updates["summary"] = *req.Summary
+	title, content := before.Title, before.Content
+	if req.Title != nil {
+		title = *req.Title
 	}
 	if req.Content != nil {
-		updates["content"] = *req.Content
+		content = sanitizeArticleContent(*req.Content)
+		updates["content"] = content
+	}
+	if summary, changed := updatedSummary(before, req.Summary, title, content); changed {
+		updates["summary"] = summary
 	}
 	if req.FeaturedImage != nil {
-		updates["featured_image"] = *req.FeaturedImage
+		image, err := s.resolveImage("featured_image", *req.FeaturedImage)
+		if err != nil {
+			return nil, err
+		}
+		updates["featured_image"] = image
+	}
+	if req.CoverImage != nil {
+		image, err := s.resolveImage("cover_image", *req.CoverImage)
+		if err != nil {
+			return nil, err
+		}
+		if image == "" {
+			updates["cover_image"] = nil
+		} else {
+			updates["cover_image"] = image
+		}
 	}
+	if req.Images != nil {
+		updates["featured_image_thumb"] = req.Images.Thumb
+		updates["featured_image_full"] = req.Images.Full
+	}
+	primaryAuthorID := before.AuthorID
 	if req.AuthorID != nil {
 		authorID, err := uuid.Parse(*req.AuthorID)
 		if err != nil {
 			return nil, fmt.Errorf("invalid author ID: %w", err)
 		}
 		updates["author_id"] = authorID
+		primaryAuthorID = &authorID
+	}
+
+	// Check the co-authors against the primary author the article will end up with
+	var coAuthorIDs []uuid.UUID
+	if req.CoAuthorIDs != nil {
+		coAuthorIDs, err = parseCoAuthorIDs(primaryAuthorID, req.CoAuthorIDs)
+		if err != nil {
+			return nil, err
+		}
+	} else if req.AuthorID != nil {
+		existing, err := s.repo.GetArticleCoAuthorIDs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, coAuthorID := range existing {
+			if coAuthorID == *primaryAuthorID {
+				return nil, fmt.Errorf("primary author cannot also be a co-author")
+			}
+		}
 	}
 	if req.CategoryID != nil {
 		categoryID, err := uuid.Parse(*req.CategoryID)
 		if err != nil {
 			return nil, fmt.Errorf("invalid category ID: %w", err)
 		}
+		if err := s.checkInternalCategoryMove(ctx, before, categoryID, req.Confirm); err != nil {
+			return nil, err
+		}
 		updates["category_id"] = categoryID
 	}
 	if req.PrimaryPoliticianID != nil {
@@ -218,16 +498,69 @@ func (s *ArticleService) Update(ctx context.Context, id uuid.UUID, req *models.U
 		}
 		updates["primary_politician_id"] = politicianID
 	}
-	if req.Status != nil {
-		updates["status"] = *req.Status
-		if *req.Status == string(models.ArticleStatusPublished) {
-			updates["published_at"] = time.Now()
+	if req.CommentsEnabled != nil {
+		updates["comments_enabled"] = *req.CommentsEnabled
+	}
+	if req.CommentsPremoderated != nil {
+		updates["comments_premoderated"] = *req.CommentsPremoderated
+	}
+	if req.AccessLevel != nil {
+		updates["access_level"] = *req.AccessLevel
+	}
+	if req.IsPremium != nil {
+		updates["is_premium"] = *req.IsPremium
+	}
+	if req.PremiumSummary != nil {
+		if *req.PremiumSummary == "" {
+			updates["premium_summary"] = nil
+		} else {
+			updates["premium_summary"] = sanitize.SanitizeArticleHTML(*req.PremiumSummary)
+		}
+	}
+	if req.FocusKeyword != nil {
+		if keyword := strings.TrimSpace(*req.FocusKeyword); keyword == "" {
+			updates["focus_keyword"] = nil
+		} else {
+			updates["focus_keyword"] = keyword
+		}
+	}
+	if req.CommentsLockedAt != nil {
+		lockedAt, err := s.parseCommentsLockedAt(req.CommentsLockedAt)
+		if err != nil {
+			return nil, err
+		}
+		updates["comments_locked_at"] = lockedAt
+	}
+	publishTimeChanged := false
+	if req.Status != nil || req.PublishedAt != nil {
+		status := before.Status
+		if req.Status != nil {
+			status = models.ArticleStatus(*req.Status)
+		}
+		publishedAt, err := s.parsePublishedAt(req.PublishedAt)
+		if err != nil {
+			return nil, err
+		}
+		status, publishedAt, err = resolveSchedule(before, status, publishedAt, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		updates["status"] = status
+		if publishedAt != nil {
+			updates["published_at"] = *publishedAt
+			publishTimeChanged = true
 		}
 	}
 
 	if err := s.repo.Update(ctx, id, updates); err != nil {
 		return nil, err
 	}
+	if content, ok := updates["content"].(string); ok {
+		if err := s.syncArticleExplainers(ctx, id, content); err != nil {
+			return nil, err
+		}
+	}
 
 	// Update tags if provided
 	if req.TagIDs != nil {
@@ -244,6 +577,13 @@ func (s *ArticleService) Update(ctx context.Context, id uuid.UUID, req *models.U
 		}
 	}
 
+	// Update co-authors if provided
+	if req.CoAuthorIDs != nil {
+		if err := s.repo.SetArticleCoAuthors(ctx, id, coAuthorIDs); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update mentioned politicians if provided
 	if req.PoliticianIDs != nil {
 		politicianUUIDs := make([]uuid.UUID, len(req.PoliticianIDs))
@@ -259,10 +599,28 @@ func (s *ArticleService) Update(ctx context.Context, id uuid.UUID, req *models.U
 		}
 	}
 
+	after, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	// Invalidate caches
-	s.invalidateArticleCache(ctx, id)
+	s.invalidateArticleCache(ctx, id, before, beforeMentions)
+	s.invalidateArticleCache(ctx, id, after, req.PoliticianIDs)
+	if isInternalArticle(after) && !isInternalArticle(before) {
+		// Other articles' related lists may still point at it
+		_ = s.cache.DeletePattern(ctx, cache.KeyPrefixRelated+"*")
+	}
+
+	if publishTimeChanged {
+		after.ScheduleConflicts = s.scheduleConflicts(ctx, after)
+	}
+
+	if after != nil && after.Status == models.ArticleStatusPublished && before.Status != models.ArticleStatusPublished {
+		s.emitPublished(ctx, after)
+	}
 
-	return s.repo.GetByID(ctx, id)
+	return after, nil
 }
 
 func (s *ArticleService) Delete(ctx context.Context, id uuid.UUID) error {
@@ -274,13 +632,14 @@ func (s *ArticleService) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("article not found")
 	}
 
+	mentions := s.mentionedPoliticianIDs(ctx, id)
+
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return err
 	}
 
 	// Invalidate caches
-	s.invalidateArticleCache(ctx, id)
-	_ = s.cache.Delete(ctx, cache.ArticleSlugKey(article.Slug))
+	s.invalidateArticleCache(ctx, id, article, mentions)
 
 	return nil
 }
@@ -290,8 +649,13 @@ func (s *ArticleService) Restore(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
+	article, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	// Invalidate caches
-	s.invalidateArticleCache(ctx, id)
+	s.invalidateArticleCache(ctx, id, article, s.mentionedPoliticianIDs(ctx, id))
 
 	return nil
 }
@@ -311,9 +675,7 @@ func (s *ArticleService) GetTrending(ctx context.Context, limit int) ([]models.A
 		return articles, nil
 	}
 
-	// For now, trending is based on recent published articles
-	// In a real app, this would be based on view counts, shares, etc.
-	ids, err := s.repo.GetTrendingIDs(ctx, 20)
+	ids, err := s.repo.GetTrendingIDs(ctx, 20, TrendingReactionWeight)
 	if err != nil {
 		return nil, err
 	}
@@ -331,19 +693,58 @@ func (s *ArticleService) GetTrending(ctx context.Context, limit int) ([]models.A
 	return articles, nil
 }
 
+// Search finds published articles matching query. Results are cached for
+// SearchCacheTTL, tagged by the words of the query so that an edit only drops
+// the searches related to the article (see SearchCacheInvalidator).
 func (s *ArticleService) Search(ctx context.Context, query string, page, perPage int) (*models.PaginatedArticles, error) {
+	page, perPage = articlePage(page, perPage)
+	status := models.ArticleStatusPublished
 	filter := &models.ArticleFilter{
 		Search: &query,
-		Status: func() *models.ArticleStatus {
-			status := models.ArticleStatusPublished
-			return &status
-		}(),
+		Status: &status,
 	}
-	return s.List(ctx, filter, page, perPage)
+
+	cacheKey := cache.SearchKey(page, perPage, hashFilter(filter))
+
+	var result models.PaginatedArticles
+	if err := s.cache.Get(ctx, cacheKey, &result); err == nil {
+		return &result, nil
+	}
+
+	articles, err := s.repo.List(ctx, filter, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, articles, SearchCacheTTL, searchCacheTags(query)...)
+
+	return articles, nil
 }
 
-func (s *ArticleService) IncrementViewCount(ctx context.Context, slug string) error {
-	return s.repo.IncrementViewCountBySlug(ctx, slug)
+// IncrementViewCount counts a view of the article, attributed to the traffic
+// source named by the request's Referer header. Views accumulate in Redis
+// until FlushViewCounts writes them to the database.
+func (s *ArticleService) IncrementViewCount(ctx context.Context, slug, referer string) error {
+	source := models.ClassifyReferrer(referer, s.siteHost)
+
+	article, err := s.getBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if article == nil || article.Status != models.ArticleStatusPublished {
+		return nil
+	}
+
+	now := time.Now()
+	if err := s.cache.Counter(cache.CounterArticleViews).Add(ctx, article.ID.String(), 1); err != nil {
+		// Without Redis, count the view directly
+		if err := s.repo.IncrementViewCountBySlug(ctx, slug, source); err != nil {
+			return err
+		}
+		return s.repo.AddHourlyViews(ctx, map[time.Time]int64{now.UTC().Truncate(time.Hour): 1})
+	}
+	_ = s.cache.Counter(cache.CounterHourlyViews).Add(ctx, now.UTC().Format(hourlyViewsLayout), 1)
+	return s.cache.Counter(cache.CounterArticleReferrers).Add(ctx, referrerCounterField(article.ID, now, source), 1)
 }
 
 func (s *ArticleService) GetRelatedArticles(ctx context.Context, articleID uuid.UUID, categoryID *uuid.UUID, tagIDs []uuid.UUID, limit int) ([]models.ArticleListItem, error) {
@@ -351,7 +752,7 @@ func (s *ArticleService) GetRelatedArticles(ctx context.Context, articleID uuid.
 		limit = 4
 	}
 
-	cacheKey := fmt.Sprintf("related:%s", articleID.String())
+	cacheKey := cache.RelatedArticlesKey(articleID.String())
 
 	var articles []models.ArticleListItem
 	if err := s.cache.Get(ctx, cacheKey, &articles); err == nil {
@@ -374,11 +775,180 @@ func (s *ArticleService) GetRelatedArticles(ctx context.Context, articleID uuid.
 	return articles, nil
 }
 
-func (s *ArticleService) invalidateArticleCache(ctx context.Context, id uuid.UUID) {
-	_ = s.cache.Delete(ctx, cache.ArticleKey(id.String()))
-	_ = s.cache.Delete(ctx, cache.TrendingKey())
-	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixArticleList+"*")
-	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixArticleSlug+"*")
+// checkInternalCategoryMove refuses to move a published, public article into
+// an internal category unless the caller confirmed it, since doing so takes
+// the article off the public site
+func (s *ArticleService) checkInternalCategoryMove(ctx context.Context, before *models.Article, categoryID uuid.UUID, confirm bool) error {
+	if confirm || before.Status != models.ArticleStatusPublished {
+		return nil
+	}
+	if isInternalArticle(before) {
+		return nil
+	}
+
+	internal, err := s.repo.IsInternalCategory(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	if internal {
+		return fmt.Errorf("moving a published article into an internal category hides it from the public; resend with confirm=true")
+	}
+	return nil
+}
+
+func isInternalArticle(article *models.Article) bool {
+	return article != nil && article.Category != nil && article.Category.IsInternal
+}
+
+// emitPublished counts the article going live and tells webhooks. Articles
+// in internal categories never go live publicly, so nothing is sent for them.
+func (s *ArticleService) emitPublished(ctx context.Context, article *models.Article) {
+	metrics.RecordArticlePublished()
+
+	if s.webhooks == nil {
+		return
+	}
+	if isInternalArticle(article) {
+		return
+	}
+	_ = s.webhooks.Emit(ctx, models.WebhookEventArticlePublished, models.ArticlePublishedEvent{
+		ArticleID:   article.ID,
+		Slug:        article.Slug,
+		Title:       article.Title,
+		PublishedAt: article.PublishedAt,
+	})
+}
+
+// invalidateArticleCache drops the article's own keys and every list family
+// the article (in the given state) belongs to.
+func (s *ArticleService) invalidateArticleCache(ctx context.Context, id uuid.UUID, article *models.Article, mentionedPoliticianIDs []string) {
+	_ = s.cache.Delete(ctx, cache.ArticleKey(id.String()), cache.ArticleKeywordDensityKey(id.String()), cache.TrendingKey())
+	if article == nil {
+		// Without the article's state we can't tell which families it was in
+		_ = s.cache.InvalidateTag(ctx, cache.TagArticleLists)
+		return
+	}
+
+	_ = s.cache.Delete(ctx,
+		cache.ArticleSlugKey(article.Slug),
+		cache.ArticleExportKey(article.Slug, ArticleExportText),
+		cache.ArticleExportKey(article.Slug, ArticleExportMarkdown),
+	)
+	_ = s.cache.InvalidateTag(ctx, articleFamilyTags(article, mentionedPoliticianIDs)...)
+	_ = s.searches.InvalidateRelated(ctx, article)
+}
+
+func (s *ArticleService) mentionedPoliticianIDs(ctx context.Context, articleID uuid.UUID) []string {
+	politicians, err := s.politicianRepo.GetArticleMentionedPoliticians(ctx, articleID)
+	if err != nil {
+		return nil
+	}
+
+	ids := make([]string, len(politicians))
+	for i, p := range politicians {
+		ids[i] = p.ID.String()
+	}
+	return ids
+}
+
+// articleListTags returns the cache tags for a list page built from filter.
+// Lists narrowed by an entity are tagged with that entity's family; anything
+// else (status-only, search) lands in the catch-all family.
+func articleListTags(filter *models.ArticleFilter) []string {
+	tags := []string{cache.TagArticleLists}
+	if filter == nil {
+		return append(tags, cache.TagArticleListsAll)
+	}
+
+	scoped := false
+	if filter.CategoryID != nil {
+		tags = append(tags, cache.CategoryArticlesTag(filter.CategoryID.String()))
+		scoped = true
+	}
+	if filter.TagID != nil {
+		tags = append(tags, cache.TagArticlesTag(filter.TagID.String()))
+		scoped = true
+	}
+	if filter.AuthorID != nil {
+		tags = append(tags, cache.AuthorArticlesTag(filter.AuthorID.String()))
+		scoped = true
+	}
+	if filter.PoliticianID != nil {
+		tags = append(tags, cache.PoliticianArticlesTag(filter.PoliticianID.String()))
+		scoped = true
+	}
+	if !scoped {
+		tags = append(tags, cache.TagArticleListsAll)
+	}
+
+	return tags
+}
+
+// articleFamilyTags returns every list family tag the article can appear in.
+func articleFamilyTags(article *models.Article, mentionedPoliticianIDs []string) []string {
+	tags := []string{cache.TagArticleListsAll}
+	if article == nil {
+		return tags
+	}
+
+	if article.CategoryID != nil {
+		tags = append(tags, cache.CategoryArticlesTag(article.CategoryID.String()))
+	}
+	if article.AuthorID != nil {
+		tags = append(tags, cache.AuthorArticlesTag(article.AuthorID.String()))
+	}
+	for _, author := range article.Authors {
+		tags = append(tags, cache.AuthorArticlesTag(author.ID.String()))
+	}
+	if article.PrimaryPoliticianID != nil {
+		tags = append(tags, cache.PoliticianArticlesTag(article.PrimaryPoliticianID.String()))
+	}
+	for _, tag := range article.Tags {
+		tags = append(tags, cache.TagArticlesTag(tag.ID.String()))
+	}
+	for _, politicianID := range mentionedPoliticianIDs {
+		tags = append(tags, cache.PoliticianArticlesTag(politicianID))
+	}
+
+	return tags
+}
+
+// parseCoAuthorIDs parses and validates the co-author list of an article:
+// no duplicates, at most MaxArticleCoAuthors, and never the primary author.
+func parseCoAuthorIDs(primaryAuthorID *uuid.UUID, ids []string) ([]uuid.UUID, error) {
+	if len(ids) > models.MaxArticleCoAuthors {
+		return nil, fmt.Errorf("an article can have at most %d co-authors", models.MaxArticleCoAuthors)
+	}
+
+	parsed := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, raw := range ids {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid co-author ID: %w", err)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate co-author: %s", id)
+		}
+		if primaryAuthorID != nil && id == *primaryAuthorID {
+			return nil, fmt.Errorf("primary author cannot also be a co-author")
+		}
+		seen[id] = true
+		parsed = append(parsed, id)
+	}
+
+	return parsed, nil
+}
+
+// articlePage defaults an out-of-range page or page size
+func articlePage(page, perPage int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	return page, perPage
 }
 
 func hashFilter(filter *models.ArticleFilter) string {
@@ -386,15 +956,30 @@ func hashFilter(filter *models.ArticleFilter) string {
 		return "nil"
 	}
 
-	data := fmt.Sprintf("%v:%v:%v:%v:%v:%v",
-		filter.Status,
-		filter.CategoryID,
-		filter.TagID,
-		filter.AuthorID,
-		filter.PoliticianID,
-		filter.Search,
+	data := fmt.Sprintf("%s:%s:%s:%s:%s:%s:%s:%s:%s:%t:%t:%s",
+		derefString(filter.Status),
+		derefString(filter.CategoryID),
+		derefString(filter.TagID),
+		derefString(filter.AuthorID),
+		derefString(filter.PoliticianID),
+		derefString(filter.Search),
+		derefString(filter.OlderThanDays),
+		derefString(filter.PublishedAfter),
+		derefString(filter.PublishedBefore),
+		filter.IncludeDeleted,
+		filter.IncludeInternal,
+		filter.Sort,
 	)
 
 	hash := md5.Sum([]byte(data))
 	return hex.EncodeToString(hash[:])
 }
+
+// derefString formats a pointer's value for cache keys, so two filters with
+// equal values hash the same regardless of pointer identity.
+func derefString[T any](v *T) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", *v)
+}
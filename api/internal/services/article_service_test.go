@@ -0,0 +1,124 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleCacheTags(t *testing.T) {
+	categoryID := uuid.New()
+	otherCategoryID := uuid.New()
+	tagID := uuid.New()
+
+	article := &models.Article{
+		ID:         uuid.New(),
+		CategoryID: &categoryID,
+		Tags:       []models.Tag{{ID: tagID}},
+	}
+	invalidated := articleFamilyTags(article, nil)
+
+	t.Run("publishing invalidates unfiltered and search lists", func(t *testing.T) {
+		status := models.ArticleStatusPublished
+		search := "budget"
+		assert.Contains(t, invalidated, cache.TagArticleListsAll)
+		for _, filter := range []*models.ArticleFilter{nil, {Status: &status}, {Status: &status, Search: &search}} {
+			assert.Contains(t, articleListTags(filter), cache.TagArticleListsAll)
+		}
+	})
+
+	t.Run("publishing invalidates the article's category and tag lists", func(t *testing.T) {
+		categoryList := articleListTags(&models.ArticleFilter{CategoryID: &categoryID})
+		tagList := articleListTags(&models.ArticleFilter{TagID: &tagID})
+
+		assert.Contains(t, invalidated, cache.CategoryArticlesTag(categoryID.String()))
+		assert.Contains(t, categoryList, cache.CategoryArticlesTag(categoryID.String()))
+		assert.Contains(t, invalidated, cache.TagArticlesTag(tagID.String()))
+		assert.Contains(t, tagList, cache.TagArticlesTag(tagID.String()))
+	})
+
+	t.Run("other category lists survive", func(t *testing.T) {
+		otherList := articleListTags(&models.ArticleFilter{CategoryID: &otherCategoryID})
+		for _, tag := range otherList {
+			if tag == cache.TagArticleLists {
+				continue
+			}
+			assert.NotContains(t, invalidated, tag)
+		}
+	})
+}
+
+func TestHashFilterUsesValues(t *testing.T) {
+	a := uuid.New()
+	b := a
+	assert.Equal(t,
+		hashFilter(&models.ArticleFilter{CategoryID: &a}),
+		hashFilter(&models.ArticleFilter{CategoryID: &b}),
+	)
+}
+
+// Admin lists include internal categories, so they must never share a cache
+// entry with the public list
+func TestHashFilterSeparatesInternal(t *testing.T) {
+	status := models.ArticleStatusPublished
+	assert.NotEqual(t,
+		hashFilter(&models.ArticleFilter{Status: &status}),
+		hashFilter(&models.ArticleFilter{Status: &status, IncludeInternal: true}),
+	)
+}
+
+func TestParseCoAuthorIDs(t *testing.T) {
+	primary := uuid.New()
+	a, b := uuid.New(), uuid.New()
+
+	t.Run("keeps order", func(t *testing.T) {
+		ids, err := parseCoAuthorIDs(&primary, []string{b.String(), a.String()})
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{b, a}, ids)
+	})
+
+	t.Run("rejects duplicates", func(t *testing.T) {
+		_, err := parseCoAuthorIDs(&primary, []string{a.String(), a.String()})
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects the primary author", func(t *testing.T) {
+		_, err := parseCoAuthorIDs(&primary, []string{a.String(), primary.String()})
+		assert.EqualError(t, err, "primary author cannot also be a co-author")
+	})
+
+	t.Run("rejects more than the maximum", func(t *testing.T) {
+		ids := make([]string, models.MaxArticleCoAuthors+1)
+		for i := range ids {
+			ids[i] = uuid.NewString()
+		}
+		_, err := parseCoAuthorIDs(nil, ids)
+		assert.Error(t, err)
+	})
+}
+
+func TestArticleFamilyTagsIncludeCoAuthors(t *testing.T) {
+	primary, coAuthor := uuid.New(), uuid.New()
+	article := &models.Article{
+		ID:       uuid.New(),
+		AuthorID: &primary,
+		Authors:  []models.ArticleAuthor{{ID: primary}, {ID: coAuthor, Position: 1}},
+	}
+
+	tags := articleFamilyTags(article, nil)
+	assert.Contains(t, tags, cache.AuthorArticlesTag(primary.String()))
+	assert.Contains(t, tags, cache.AuthorArticlesTag(coAuthor.String()))
+}
+
+func TestSourceWarning(t *testing.T) {
+	investigations := &models.Category{Name: "Investigations", Slug: "investigations"}
+	news := &models.Category{Name: "News", Slug: "news"}
+
+	assert.NotEmpty(t, sourceWarning(&models.Article{Category: investigations}))
+	assert.Empty(t, sourceWarning(&models.Article{Category: investigations, Sources: []models.ArticleSource{{Title: "Report"}}}))
+	assert.Empty(t, sourceWarning(&models.Article{Category: news}))
+	assert.Empty(t, sourceWarning(&models.Article{}))
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// ListSources returns an article's sources, or nil if the article does not
+// exist
+func (s *ArticleService) ListSources(ctx context.Context, articleID uuid.UUID) ([]models.ArticleSource, error) {
+	article, err := s.repo.GetByID(ctx, articleID)
+	if err != nil || article == nil {
+		return nil, err
+	}
+	return article.Sources, nil
+}
+
+func (s *ArticleService) CreateSource(ctx context.Context, articleID uuid.UUID, req *models.CreateArticleSourceRequest) (*models.ArticleSource, error) {
+	source, err := s.repo.CreateArticleSource(ctx, articleID, req)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateArticleByID(ctx, articleID)
+	return source, nil
+}
+
+func (s *ArticleService) UpdateSource(ctx context.Context, articleID, id uuid.UUID, req *models.UpdateArticleSourceRequest) (*models.ArticleSource, error) {
+	if err := s.checkSourceOwner(ctx, articleID, id); err != nil {
+		return nil, err
+	}
+
+	source, err := s.repo.UpdateArticleSource(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateArticleByID(ctx, articleID)
+	return source, nil
+}
+
+func (s *ArticleService) DeleteSource(ctx context.Context, articleID, id uuid.UUID) error {
+	if err := s.checkSourceOwner(ctx, articleID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteArticleSource(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateArticleByID(ctx, articleID)
+	return nil
+}
+
+// checkSourceOwner rejects a source that belongs to another article as not
+// found
+func (s *ArticleService) checkSourceOwner(ctx context.Context, articleID, id uuid.UUID) error {
+	source, err := s.repo.GetArticleSource(ctx, id)
+	if err != nil {
+		return err
+	}
+	if source == nil || source.ArticleID != articleID {
+		return fmt.Errorf("article source not found")
+	}
+	return nil
+}
+
+// invalidateArticleByID drops the cached article page and the lists that
+// show it, after a change to its sources or gallery
+func (s *ArticleService) invalidateArticleByID(ctx context.Context, articleID uuid.UUID) {
+	article, _ := s.repo.GetByID(ctx, articleID)
+	s.invalidateArticleCache(ctx, articleID, article, s.mentionedPoliticianIDs(ctx, articleID))
+}
+
+// sourceWarning flags an article in a category that expects sources, such as
+// investigations, when it has none
+func sourceWarning(article *models.Article) string {
+	if article.Category == nil || len(article.Sources) > 0 {
+		return ""
+	}
+	if !slices.Contains(models.SourcedCategorySlugs, article.Category.Slug) {
+		return ""
+	}
+	return fmt.Sprintf("articles in %s should cite at least one source", article.Category.Name)
+}
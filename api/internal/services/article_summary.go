@@ -0,0 +1,46 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/htmltext"
+)
+
+// AutoSummaryLength is the most characters a generated summary has, about
+// the length of a search result snippet
+const AutoSummaryLength = 160
+
+// autoSummary generates a summary from the start of an article's text, or
+// from its title when the content is all markup
+func autoSummary(title, content string) string {
+	if summary := htmltext.Summary(content, AutoSummaryLength); summary != "" {
+		return summary
+	}
+	return htmltext.Truncate(strings.Join(strings.Fields(title), " "), AutoSummaryLength)
+}
+
+func isBlankSummary(summary *string) bool {
+	return summary == nil || strings.TrimSpace(*summary) == ""
+}
+
+// updatedSummary works out the summary an edit leaves the article with, and
+// whether it changed. A blank summary is generated from the content. One
+// that was generated follows edits to the title and content; one that was
+// written is only replaced by another.
+func updatedSummary(before *models.Article, summary *string, title, content string) (string, bool) {
+	if summary != nil {
+		if isBlankSummary(summary) {
+			return autoSummary(title, content), true
+		}
+		return *summary, true
+	}
+
+	if title == before.Title && content == before.Content {
+		return "", false
+	}
+	if isBlankSummary(before.Summary) || *before.Summary == autoSummary(before.Title, before.Content) {
+		return autoSummary(title, content), true
+	}
+	return "", false
+}
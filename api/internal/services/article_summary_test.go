@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoSummary(t *testing.T) {
+	assert.Equal(t, "The Senate passed the budget.", autoSummary("Budget", "<p>The Senate passed <em>the</em> budget.</p>"))
+	assert.Equal(t, "Photo essay", autoSummary("  Photo   essay ", `<figure><img src="x.png"></figure>`), "falls back to the title")
+}
+
+func TestUpdatedSummary(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	generated := &models.Article{Title: "Budget", Content: "<p>Old text.</p>", Summary: strPtr("Old text.")}
+	written := &models.Article{Title: "Budget", Content: "<p>Old text.</p>", Summary: strPtr("Editor's summary")}
+
+	summary, changed := updatedSummary(generated, nil, "Budget", "<p>New text.</p>")
+	assert.True(t, changed)
+	assert.Equal(t, "New text.", summary, "a generated summary follows the content")
+
+	_, changed = updatedSummary(written, nil, "Budget", "<p>New text.</p>")
+	assert.False(t, changed, "a written summary is kept")
+
+	_, changed = updatedSummary(generated, nil, "Budget", "<p>Old text.</p>")
+	assert.False(t, changed, "nothing to regenerate from")
+
+	summary, changed = updatedSummary(written, strPtr(" "), "Budget", "<p>Old text.</p>")
+	assert.True(t, changed)
+	assert.Equal(t, "Old text.", summary, "clearing the summary regenerates it")
+
+	summary, changed = updatedSummary(generated, strPtr("Written now"), "Budget", "<p>New text.</p>")
+	assert.True(t, changed)
+	assert.Equal(t, "Written now", summary)
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/repository"
+)
+
+type AuditService struct {
+	repo *repository.AuditLogRepository
+}
+
+func NewAuditService(repo *repository.AuditLogRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// Record adds an entry to the audit trail
+func (s *AuditService) Record(ctx context.Context, entry *models.AuditLog) error {
+	return s.repo.Create(ctx, entry)
+}
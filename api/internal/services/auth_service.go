@@ -21,9 +21,13 @@ type AuthService struct {
 	roleRepo     *repository.RoleRepository
 	authorRepo   *repository.AuthorRepository
 	emailService *email.EmailService
+	auditService *AuditService
 	jwtSecret    []byte
 }
 
+// ImpersonationTokenTTL is how long an impersonation session lasts
+const ImpersonationTokenTTL = 30 * time.Minute
+
 func NewAuthService(userRepo *repository.UserRepository, roleRepo *repository.RoleRepository, authorRepo *repository.AuthorRepository, emailService *email.EmailService, jwtSecret string) *AuthService {
 	return &AuthService{
 		userRepo:     userRepo,
@@ -39,9 +43,17 @@ type JWTClaims struct {
 	Email  string `json:"email"`
 	RoleID string `json:"role_id"`
 	Role   string `json:"role"` // Role slug for backwards compatibility
+
+	// ID of the admin acting as this user, set on impersonation tokens
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// SetAuditService sets where impersonation is recorded
+func (s *AuthService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
 func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.LoginResponse, error) {
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
@@ -229,18 +241,25 @@ func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 }
 
 func (s *AuthService) generateToken(user *models.User) (string, error) {
+	return s.signToken(user, 24*time.Hour, "")
+}
+
+// signToken issues a token for user valid for ttl. impersonatedBy is the
+// acting admin's ID for impersonation tokens, and empty otherwise.
+func (s *AuthService) signToken(user *models.User, ttl time.Duration, impersonatedBy string) (string, error) {
 	roleID := ""
 	if user.RoleID != nil {
 		roleID = user.RoleID.String()
 	}
 
 	claims := &JWTClaims{
-		UserID: user.ID.String(),
-		Email:  user.Email,
-		RoleID: roleID,
-		Role:   user.RoleSlug,
+		UserID:         user.ID.String(),
+		Email:          user.Email,
+		RoleID:         roleID,
+		Role:           user.RoleSlug,
+		ImpersonatedBy: impersonatedBy,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "pulpulitiko",
@@ -252,6 +271,58 @@ func (s *AuthService) generateToken(user *models.User) (string, error) {
 	return token.SignedString(s.jwtSecret)
 }
 
+// Impersonate issues adminID a short-lived token to act as userID, for
+// reproducing a user's issue. Admins cannot be impersonated. The start of the
+// session is recorded in the audit log.
+func (s *AuthService) Impersonate(ctx context.Context, adminID, userID uuid.UUID, ipAddress string) (*models.ImpersonationResponse, error) {
+	if adminID == userID {
+		return nil, fmt.Errorf("cannot impersonate yourself")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	if user.RoleSlug == "admin" {
+		return nil, fmt.Errorf("cannot impersonate an admin")
+	}
+
+	// An impersonation that can't be audited doesn't happen
+	if s.auditService == nil {
+		return nil, fmt.Errorf("audit log unavailable")
+	}
+	if err := s.auditService.Record(ctx, &models.AuditLog{
+		ActorID:            adminID,
+		ImpersonatedUserID: &user.ID,
+		Action:             models.AuditActionImpersonationStart,
+		IPAddress:          &ipAddress,
+	}); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(ImpersonationTokenTTL)
+	token, err := s.signToken(user, ImpersonationTokenTTL, adminID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	var permissions []string
+	if user.RoleID != nil {
+		permissions, _ = s.roleRepo.GetPermissionSlugsByRoleID(ctx, *user.RoleID)
+	}
+
+	return &models.ImpersonationResponse{
+		Token:          token,
+		ExpiresAt:      expiresAt,
+		User:           *user,
+		Permissions:    permissions,
+		ImpersonatedBy: adminID,
+	}, nil
+}
+
 func (s *AuthService) HashPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -302,7 +373,7 @@ func (s *AuthService) ForgotPassword(ctx context.Context, req *models.ForgotPass
 	}
 
 	// Send email
-	if err := s.emailService.SendPasswordReset(user.Email, token); err != nil {
+	if err := s.emailService.SendPasswordReset(user.Email, token, resetToken.ExpiresAt); err != nil {
 		return fmt.Errorf("failed to send reset email: %w", err)
 	}
 
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/pdftext"
+)
+
+// MaxBillDocumentSize caps uploaded bill PDFs. Gazette copies run larger than
+// the general upload limit.
+const MaxBillDocumentSize = 25 * 1024 * 1024
+
+// ListBillDocuments returns the documents attached to a bill
+func (s *BillService) ListBillDocuments(ctx context.Context, billID uuid.UUID) ([]models.BillDocument, error) {
+	return s.repo.GetBillDocuments(ctx, billID)
+}
+
+// GetBillDocument returns a document only if it belongs to the given bill
+func (s *BillService) GetBillDocument(ctx context.Context, billID, id uuid.UUID) (*models.BillDocument, error) {
+	doc, err := s.repo.GetBillDocument(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil || doc.BillID != billID {
+		return nil, nil
+	}
+	return doc, nil
+}
+
+// AddBillDocument stores an uploaded PDF and adds any text extracted from it to
+// the bill's full text. A PDF without readable text (such as a scan) is still
+// stored, flagged as extraction_failed.
+func (s *BillService) AddBillDocument(ctx context.Context, billID uuid.UUID, documentType string, file io.Reader, fileName string, uploadedBy *uuid.UUID) (*models.BillDocument, error) {
+	if s.uploads == nil {
+		return nil, fmt.Errorf("document uploads are not configured")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxBillDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(data) > MaxBillDocumentSize {
+		return nil, fmt.Errorf("file size exceeds maximum allowed size of 25MB")
+	}
+	if !pdftext.IsPDF(data) {
+		return nil, fmt.Errorf("file must be a PDF")
+	}
+
+	// Extraction failures don't reject the upload; the document is flagged instead
+	text, pages, err := pdftext.Extract(data)
+	if err != nil {
+		text = ""
+	}
+
+	fileURL, err := s.uploads.UploadBillDocument(ctx, billID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &models.BillDocument{
+		BillID:           billID,
+		DocumentType:     documentType,
+		FileURL:          fileURL,
+		FileName:         cleanDocumentName(fileName),
+		FileSize:         int64(len(data)),
+		PageCount:        pages,
+		ExtractionFailed: text == "",
+		UploadedBy:       uploadedBy,
+	}
+	if err := s.repo.CreateBillDocument(ctx, doc, text); err != nil {
+		_ = s.uploads.DeleteFile(ctx, fileURL)
+		return nil, err
+	}
+
+	s.invalidateBillCache(ctx, billID)
+
+	return doc, nil
+}
+
+// DeleteBillDocument removes a document and its stored file
+func (s *BillService) DeleteBillDocument(ctx context.Context, doc *models.BillDocument) error {
+	if err := s.repo.DeleteBillDocument(ctx, doc.ID); err != nil {
+		return err
+	}
+
+	if s.uploads != nil {
+		// An orphaned file is harmless, so a failed delete is ignored
+		_ = s.uploads.DeleteFile(ctx, doc.FileURL)
+	}
+
+	s.invalidateBillCache(ctx, doc.BillID)
+
+	return nil
+}
+
+// UploadBillDocument stores a bill PDF under the bill's folder and returns its URL
+func (s *UploadService) UploadBillDocument(ctx context.Context, billID uuid.UUID, data []byte) (string, error) {
+	key := fmt.Sprintf("bills/%s/documents/%s.pdf", billID, uuid.New())
+
+	result, err := s.storage.UploadWithKey(ctx, bytes.NewReader(data), key, "application/pdf", int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	return result.URL, nil
+}
+
+// cleanDocumentName keeps the base of an uploaded file name, bounded to the
+// column size, for display as the download name
+func cleanDocumentName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "document.pdf"
+	}
+	for utf8.RuneCountInString(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
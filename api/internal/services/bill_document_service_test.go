@@ -0,0 +1,37 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBillDocumentRejectsBeforeUpload(t *testing.T) {
+	// Storage is never reached, so an unconfigured upload service is enough
+	s := &BillService{uploads: &UploadService{}}
+	ctx := context.Background()
+
+	t.Run("content is checked, not the file name", func(t *testing.T) {
+		png := []byte("\x89PNG\r\n\x1a\nnot really a pdf")
+		_, err := s.AddBillDocument(ctx, uuid.New(), "filed_copy", bytes.NewReader(png), "bill.pdf", nil)
+		assert.EqualError(t, err, "file must be a PDF")
+	})
+
+	t.Run("oversized files are rejected", func(t *testing.T) {
+		big := bytes.NewReader(append([]byte("%PDF-1.4\n"), make([]byte, MaxBillDocumentSize)...))
+		_, err := s.AddBillDocument(ctx, uuid.New(), "filed_copy", big, "bill.pdf", nil)
+		assert.EqualError(t, err, "file size exceeds maximum allowed size of 25MB")
+	})
+}
+
+func TestCleanDocumentName(t *testing.T) {
+	assert.Equal(t, "HB-1234.pdf", cleanDocumentName("HB-1234.pdf"))
+	assert.Equal(t, "report.pdf", cleanDocumentName(`C:\Users\staff\report.pdf`))
+	assert.Equal(t, "report.pdf", cleanDocumentName("../../report.pdf"))
+	assert.Equal(t, "document.pdf", cleanDocumentName("  "))
+	assert.Len(t, []rune(cleanDocumentName(strings.Repeat("ñ", 300))), 255)
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,11 +22,19 @@ const (
 	sessionsCacheTTL      = 24 * time.Hour
 	committeesCacheTTL    = 24 * time.Hour
 	topicsCacheTTL        = 24 * time.Hour
+	autocompleteCacheTTL  = 15 * time.Minute
 )
 
+// maxAutocompleteQuery is the longest bill autocomplete query looked up;
+// longer ones are cut
+const maxAutocompleteQuery = 100
+
 type BillService struct {
-	repo  *repository.BillRepository
-	cache *cache.RedisCache
+	repo       *repository.BillRepository
+	cache      *cache.RedisCache
+	uploads    *UploadService
+	webhooks   *WebhookService
+	staleAfter time.Duration
 }
 
 func NewBillService(repo *repository.BillRepository, cache *cache.RedisCache) *BillService {
@@ -35,6 +44,22 @@ func NewBillService(repo *repository.BillRepository, cache *cache.RedisCache) *B
 	}
 }
 
+// SetUploadService enables bill document uploads
+func (s *BillService) SetUploadService(uploads *UploadService) {
+	s.uploads = uploads
+}
+
+// SetWebhookService enables webhook events for bill status changes
+func (s *BillService) SetWebhookService(webhooks *WebhookService) {
+	s.webhooks = webhooks
+}
+
+// SetStaleAfter sets how long an active bill may go without updates before
+// it is flagged as stale. Zero turns the flag off.
+func (s *BillService) SetStaleAfter(d time.Duration) {
+	s.staleAfter = d
+}
+
 // Legislative Sessions
 
 func (s *BillService) GetCurrentSession(ctx context.Context) (*models.LegislativeSession, error) {
@@ -138,6 +163,7 @@ func (s *BillService) GetBillByID(ctx context.Context, id uuid.UUID) (*models.Bi
 
 	var bill models.Bill
 	if err := s.cache.Get(ctx, cacheKey, &bill); err == nil {
+		s.markStale(&bill)
 		return &bill, nil
 	}
 
@@ -148,6 +174,7 @@ func (s *BillService) GetBillByID(ctx context.Context, id uuid.UUID) (*models.Bi
 
 	if billPtr != nil {
 		_ = s.cache.Set(ctx, cacheKey, billPtr, billCacheTTL)
+		s.markStale(billPtr)
 	}
 
 	return billPtr, nil
@@ -158,6 +185,7 @@ func (s *BillService) GetBillBySlug(ctx context.Context, slug string) (*models.B
 
 	var bill models.Bill
 	if err := s.cache.Get(ctx, cacheKey, &bill); err == nil {
+		s.markStale(&bill)
 		return &bill, nil
 	}
 
@@ -168,6 +196,7 @@ func (s *BillService) GetBillBySlug(ctx context.Context, slug string) (*models.B
 
 	if billPtr != nil {
 		_ = s.cache.Set(ctx, cacheKey, billPtr, billCacheTTL)
+		s.markStale(billPtr)
 	}
 
 	return billPtr, nil
@@ -175,16 +204,76 @@ func (s *BillService) GetBillBySlug(ctx context.Context, slug string) (*models.B
 
 func (s *BillService) ListBills(ctx context.Context, filter *models.BillFilter, page, perPage int) (*models.PaginatedBills, error) {
 	// Don't cache filtered results to ensure freshness
-	return s.repo.List(ctx, filter, page, perPage)
+	result, err := s.repo.List(ctx, filter, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range result.Bills {
+		b := &result.Bills[i]
+		b.IsStale = models.IsBillStale(b.Status, b.DataAsOf, now, s.staleAfter)
+	}
+
+	return result, nil
+}
+
+// AutocompleteBills suggests bills by number prefix or title for the search
+// widget. Results are cached per query until a bill changes, or for
+// autocompleteCacheTTL at most.
+func (s *BillService) AutocompleteBills(ctx context.Context, query string) ([]models.BillSuggestion, error) {
+	query = autocompleteQuery(query)
+	if query == "" {
+		return []models.BillSuggestion{}, nil
+	}
+
+	cacheKey := billsCachePrefix + "autocomplete:" + query
+	var suggestions []models.BillSuggestion
+	if err := s.cache.Get(ctx, cacheKey, &suggestions); err == nil {
+		return suggestions, nil
+	}
+
+	suggestions, err := s.repo.Autocomplete(ctx, query, models.BillAutocompleteLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, suggestions, autocompleteCacheTTL)
+	return suggestions, nil
+}
+
+// autocompleteQuery lowercases a query, collapses its whitespace and cuts it
+// to maxAutocompleteQuery characters, so equivalent queries share a cache
+// entry
+func autocompleteQuery(query string) string {
+	query = strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	if runes := []rune(query); len(runes) > maxAutocompleteQuery {
+		query = strings.TrimSpace(string(runes[:maxAutocompleteQuery]))
+	}
+	return query
 }
 
 func (s *BillService) UpdateBill(ctx context.Context, id uuid.UUID, req *models.UpdateBillRequest) (*models.Bill, error) {
+	var before *models.Bill
+	if req.Status != nil {
+		var err error
+		if before, err = s.repo.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
 	bill, err := s.repo.Update(ctx, id, req)
 	if err != nil {
 		return nil, err
 	}
 
+	if before != nil && bill != nil {
+		s.emitStatusChanged(ctx, bill, before.Status)
+	}
+
 	if bill != nil {
+		s.markStale(bill)
+
 		// Invalidate caches
 		_ = s.cache.Delete(ctx, billCachePrefix+"id:"+id.String())
 		_ = s.cache.Delete(ctx, billCachePrefix+"slug:"+bill.Slug)
@@ -220,7 +309,12 @@ func (s *BillService) GetBillStatusHistory(ctx context.Context, billID uuid.UUID
 }
 
 func (s *BillService) AddBillStatus(ctx context.Context, billID uuid.UUID, req *models.AddBillStatusRequest) error {
-	err := s.repo.AddBillStatus(ctx, billID, req)
+	before, err := s.repo.GetByID(ctx, billID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.AddBillStatus(ctx, billID, req)
 	if err != nil {
 		return err
 	}
@@ -228,9 +322,29 @@ func (s *BillService) AddBillStatus(ctx context.Context, billID uuid.UUID, req *
 	// Invalidate bill cache
 	s.invalidateBillCache(ctx, billID)
 
+	if before != nil {
+		after := *before
+		after.Status = req.Status
+		s.emitStatusChanged(ctx, &after, before.Status)
+	}
+
 	return nil
 }
 
+// emitStatusChanged tells webhooks the bill moved on from previousStatus
+func (s *BillService) emitStatusChanged(ctx context.Context, bill *models.Bill, previousStatus string) {
+	if s.webhooks == nil || bill.Status == previousStatus {
+		return
+	}
+	_ = s.webhooks.Emit(ctx, models.WebhookEventBillStatusChanged, models.BillStatusChangedEvent{
+		BillID:         bill.ID,
+		Slug:           bill.Slug,
+		Title:          bill.Title,
+		PreviousStatus: previousStatus,
+		Status:         bill.Status,
+	})
+}
+
 // Bill Authors
 
 func (s *BillService) GetBillAuthors(ctx context.Context, billID uuid.UUID) ([]models.BillAuthor, error) {
@@ -261,6 +375,101 @@ func (s *BillService) ListAllTopics(ctx context.Context) ([]models.BillTopic, er
 	return topics, nil
 }
 
+func (s *BillService) GetTopicTree(ctx context.Context) ([]models.BillTopicNode, error) {
+	cacheKey := topicsCachePrefix + "tree"
+
+	var tree []models.BillTopicNode
+	if err := s.cache.Get(ctx, cacheKey, &tree); err == nil {
+		return tree, nil
+	}
+
+	tree, err := s.repo.GetTopicTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, tree, topicsCacheTTL)
+
+	return tree, nil
+}
+
+func (s *BillService) CreateTopic(ctx context.Context, req *models.CreateBillTopicRequest) (*models.BillTopic, error) {
+	if req.ParentID != nil {
+		parent, err := s.repo.GetTopicByID(ctx, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("parent topic not found")
+		}
+	}
+
+	topic, err := s.repo.CreateTopic(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateTopicCache(ctx)
+	return topic, nil
+}
+
+func (s *BillService) UpdateTopic(ctx context.Context, id uuid.UUID, req *models.UpdateBillTopicRequest) (*models.BillTopic, error) {
+	if req.ParentID != nil && !req.RemoveParent {
+		if *req.ParentID == id {
+			return nil, fmt.Errorf("a topic cannot be its own parent")
+		}
+		parent, err := s.repo.GetTopicByID(ctx, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("parent topic not found")
+		}
+		// Re-parenting under one of the topic's own descendants would form a cycle
+		cycle, err := s.repo.IsTopicInSubtree(ctx, id, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if cycle {
+			return nil, fmt.Errorf("parent topic cannot be a descendant of this topic")
+		}
+	}
+
+	topic, err := s.repo.UpdateTopic(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if topic != nil {
+		s.invalidateTopicCache(ctx)
+	}
+	return topic, nil
+}
+
+func (s *BillService) DeleteTopic(ctx context.Context, id uuid.UUID) error {
+	hasChildren, err := s.repo.HasChildTopics(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		return fmt.Errorf("topic has sub-topics")
+	}
+
+	if err := s.repo.DeleteTopic(ctx, id); err != nil {
+		return err
+	}
+
+	s.invalidateTopicCache(ctx)
+	return nil
+}
+
+// invalidateTopicCache drops cached topic lists along with cached bill
+// details, which embed their topics.
+func (s *BillService) invalidateTopicCache(ctx context.Context) {
+	_ = s.cache.DeletePattern(ctx, topicsCachePrefix+"*")
+	_ = s.cache.DeletePattern(ctx, billCachePrefix+"*")
+}
+
 // Bill Committees
 
 func (s *BillService) GetBillCommittees(ctx context.Context, billID uuid.UUID) ([]models.BillCommittee, error) {
@@ -317,6 +526,12 @@ func (s *BillService) GetPoliticianVotingRecord(ctx context.Context, politicianI
 
 // Helper methods
 
+// markStale flags the bill if its data is out of date. Staleness depends on
+// the time of the request, so it is worked out after the cache.
+func (s *BillService) markStale(bill *models.Bill) {
+	bill.IsStale = models.IsBillStale(bill.Status, bill.DataAsOf, time.Now(), s.staleAfter)
+}
+
 func (s *BillService) invalidateBillCache(ctx context.Context, billID uuid.UUID) {
 	bill, _ := s.repo.GetByID(ctx, billID)
 	_ = s.cache.Delete(ctx, billCachePrefix+"id:"+billID.String())
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutocompleteQuery(t *testing.T) {
+	assert.Equal(t, "hb 123", autocompleteQuery("  HB   123 "))
+	assert.Equal(t, "", autocompleteQuery(" \t "))
+	assert.Equal(t, strings.Repeat("a", maxAutocompleteQuery), autocompleteQuery(strings.Repeat("A", maxAutocompleteQuery+20)))
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// VotingRecordLookup reads a politician's bill voting record
+type VotingRecordLookup interface {
+	GetPoliticianVotingRecord(ctx context.Context, politicianID uuid.UUID) (*models.PoliticianVotingRecord, error)
+}
+
+// SetVotingRecords enables voting records in candidate comparisons
+func (s *ElectionService) SetVotingRecords(records VotingRecordLookup) {
+	s.votingRecords = records
+}
+
+// CompareCandidates sets the given candidates for a position side by side,
+// in the order of ids. Each candidate's voting record is included when their
+// politician has bill votes on record, which is usually only incumbents.
+func (s *ElectionService) CompareCandidates(ctx context.Context, positionID uuid.UUID, ids []uuid.UUID) (*models.CandidateComparison, error) {
+	position, err := s.repo.GetElectionPositionBrief(ctx, positionID)
+	if err != nil {
+		return nil, err
+	}
+	if position == nil {
+		return nil, fmt.Errorf("position not found")
+	}
+
+	found, err := s.repo.GetComparedCandidates(ctx, positionID, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]models.ComparedCandidate, len(found))
+	for _, c := range found {
+		byID[c.ID] = c
+	}
+
+	comparison := &models.CandidateComparison{Position: *position, Candidates: make([]models.ComparedCandidate, 0, len(ids))}
+	for _, id := range ids {
+		c, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("candidate %s is not running for this position", id)
+		}
+
+		if s.votingRecords != nil {
+			record, err := s.votingRecords.GetPoliticianVotingRecord(ctx, c.PoliticianID)
+			if err != nil {
+				return nil, err
+			}
+			if record != nil && record.TotalVotes > 0 {
+				c.VotingRecord = record
+			}
+		}
+		comparison.Candidates = append(comparison.Candidates, c)
+	}
+
+	return comparison, nil
+}
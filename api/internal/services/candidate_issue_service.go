@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// syncCandidateIssues records the issues a candidate's platform addresses
+func (s *ElectionService) syncCandidateIssues(ctx context.Context, candidateID uuid.UUID, platform *string) error {
+	var issues []string
+	if platform != nil {
+		issues = s.analyzer.ExtractKeyIssues(*platform)
+	}
+	return s.repo.SetCandidateIssues(ctx, candidateID, issues)
+}
+
+// IsKnownIssue checks if candidates can be filtered by the issue
+func (s *ElectionService) IsKnownIssue(issue string) bool {
+	return s.analyzer.IsKnownIssue(issue)
+}
+
+// CheckPositionInElection returns an error if the position is not contested
+// in the election
+func (s *ElectionService) CheckPositionInElection(ctx context.Context, electionID, positionID uuid.UUID) error {
+	positionElectionID, err := s.repo.GetElectionPositionElectionID(ctx, positionID)
+	if err != nil {
+		return err
+	}
+	if positionElectionID == nil || *positionElectionID != electionID {
+		return fmt.Errorf("position is not contested in this election")
+	}
+	return nil
+}
+
+// GetIssuesMatrix compares which issues each candidate for one of the
+// election's positions addresses in their platform
+func (s *ElectionService) GetIssuesMatrix(ctx context.Context, electionID, positionID uuid.UUID) (models.IssuesMatrix, error) {
+	if err := s.CheckPositionInElection(ctx, electionID, positionID); err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.repo.GetCandidateIssuesForPosition(ctx, positionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewIssuesMatrix(s.analyzer.IssueSlugs(), candidates), nil
+}
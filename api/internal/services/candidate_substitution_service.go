@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// SubstituteCandidate replaces a candidate with a new one who inherits their
+// position and ballot number. The substitute must be from the same party
+// unless the request gives an override reason.
+func (s *ElectionService) SubstituteCandidate(ctx context.Context, id uuid.UUID, req *models.SubstituteCandidateRequest) (*models.Candidate, error) {
+	original, err := s.repo.GetCandidateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, fmt.Errorf("candidate not found")
+	}
+
+	partyID, err := req.SubstituteParty(original)
+	if err != nil {
+		return nil, err
+	}
+
+	substituteID, err := s.repo.SubstituteCandidate(ctx, id, req, partyID)
+	if err != nil {
+		return nil, err
+	}
+	_ = s.cache.DeletePattern(ctx, candidatesCachePrefix+"*")
+
+	substitute, err := s.repo.GetCandidateByID(ctx, substituteID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.syncCandidateIssues(ctx, substitute.ID, substitute.Platform); err != nil {
+		return nil, err
+	}
+
+	return substitute, nil
+}
+
+// maxSubstitutions bounds how far a chain of substitutions is followed
+const maxSubstitutions = 10
+
+// creditedCandidate follows substitutions from a candidate to whoever now
+// stands in their place
+func creditedCandidate(id uuid.UUID, substitutions map[uuid.UUID]uuid.UUID) uuid.UUID {
+	for i := 0; i < maxSubstitutions; i++ {
+		next, ok := substitutions[id]
+		if !ok {
+			break
+		}
+		id = next
+	}
+	return id
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreditedCandidate(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	substitutions := map[uuid.UUID]uuid.UUID{a: b, b: c}
+
+	assert.Equal(t, c, creditedCandidate(a, substitutions), "follows a chain of substitutions")
+	assert.Equal(t, c, creditedCandidate(b, substitutions))
+	assert.Equal(t, d, creditedCandidate(d, substitutions))
+	assert.Equal(t, a, creditedCandidate(a, nil))
+
+	loop := map[uuid.UUID]uuid.UUID{a: b, b: a}
+	assert.Contains(t, []uuid.UUID{a, b}, creditedCandidate(a, loop), "stops on a loop")
+}
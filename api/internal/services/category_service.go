@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,6 +30,7 @@ func (s *CategoryService) Create(ctx context.Context, req *models.CreateCategory
 		Name:        req.Name,
 		Slug:        req.Slug,
 		Description: req.Description,
+		IsInternal:  req.IsInternal,
 	}
 
 	if err := s.repo.Create(ctx, category); err != nil {
@@ -65,6 +67,13 @@ func (s *CategoryService) GetBySlug(ctx context.Context, slug string) (*models.C
 	return s.repo.GetBySlug(ctx, slug)
 }
 
+// ResolveSlugRedirect returns the current slug for a category that used to be
+// at oldSlug, or "" if it never was
+func (s *CategoryService) ResolveSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	return s.repo.GetSlugRedirect(ctx, oldSlug)
+}
+
+// List returns the public categories
 func (s *CategoryService) List(ctx context.Context) ([]models.Category, error) {
 	cacheKey := cache.CategoriesKey()
 
@@ -88,6 +97,11 @@ func (s *CategoryService) AdminList(ctx context.Context, filter *models.Category
 }
 
 func (s *CategoryService) Update(ctx context.Context, id uuid.UUID, req *models.UpdateCategoryRequest) (*models.Category, error) {
+	before, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.Update(ctx, id, req); err != nil {
 		return nil, err
 	}
@@ -95,6 +109,10 @@ func (s *CategoryService) Update(ctx context.Context, id uuid.UUID, req *models.
 	_ = s.cache.Delete(ctx, cache.CategoryKey(id.String()))
 	_ = s.cache.Delete(ctx, cache.CategoriesKey())
 
+	if before != nil && req.IsInternal != nil && *req.IsInternal != before.IsInternal {
+		s.invalidatePublicArticles(ctx)
+	}
+
 	return s.repo.GetByID(ctx, id)
 }
 
@@ -118,3 +136,54 @@ func (s *CategoryService) Restore(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+// MigrateArticles moves articles from one category into another; see
+// CategoryRepository.MigrateArticles
+func (s *CategoryService) MigrateArticles(ctx context.Context, sourceID, targetID uuid.UUID, tagIDs []uuid.UUID, deleteSource, confirm bool) (*models.MigrateArticlesResult, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("target category must differ from the source")
+	}
+
+	result, err := s.repo.MigrateArticles(ctx, sourceID, targetID, tagIDs, deleteSource, confirm)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.SourceDeleted {
+		_ = s.cache.Delete(ctx, cache.CategoryKey(sourceID.String()))
+		_ = s.cache.Delete(ctx, cache.CategoriesKey())
+	}
+	if result.Migrated > 0 {
+		s.invalidatePublicArticles(ctx)
+	}
+
+	return result, nil
+}
+
+func (s *CategoryService) Follow(ctx context.Context, categoryID, userID uuid.UUID) (*models.FollowStatus, error) {
+	if err := s.repo.Follow(ctx, categoryID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetFollowStatus(ctx, categoryID, &userID)
+}
+
+func (s *CategoryService) Unfollow(ctx context.Context, categoryID, userID uuid.UUID) (*models.FollowStatus, error) {
+	if err := s.repo.Unfollow(ctx, categoryID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetFollowStatus(ctx, categoryID, &userID)
+}
+
+// GetFollowStatus returns follower counts for everyone; userID is nil for anonymous viewers
+func (s *CategoryService) GetFollowStatus(ctx context.Context, categoryID uuid.UUID, userID *uuid.UUID) (*models.FollowStatus, error) {
+	return s.repo.GetFollowStatus(ctx, categoryID, userID)
+}
+
+// invalidatePublicArticles drops every cached public article view, for when
+// a category's articles are hidden from or shown to readers
+func (s *CategoryService) invalidatePublicArticles(ctx context.Context) {
+	_ = s.cache.InvalidateTag(ctx, cache.TagArticleLists)
+	_ = s.cache.Delete(ctx, cache.TrendingKey())
+	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixArticle+"*")
+	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixRelated+"*")
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/repository"
+	"github.com/humfurie/pulpulitiko/api/pkg/sanitize"
+	"github.com/humfurie/pulpulitiko/api/pkg/sentiment"
 )
 
 // Profanity word list (common profanity to flag for review)
@@ -30,22 +33,65 @@ func containsProfanity(content string) bool {
 	return false
 }
 
+// CommentEventPublisher pushes live comment events to readers of an article
+type CommentEventPublisher interface {
+	PublishCommentEvent(articleSlug string, event models.CommentEvent)
+}
+
 type CommentService struct {
 	repo                *repository.CommentRepository
 	articleRepo         *repository.ArticleRepository
 	notificationService *NotificationService
+	blocks              BlockChecker
+	publisher           CommentEventPublisher
+	sentiment           *sentiment.SentimentAnalyzer
+	toxicity            ToxicityScorer
+	toxicityThreshold   float64
 }
 
-func NewCommentService(repo *repository.CommentRepository, articleRepo *repository.ArticleRepository, notificationService *NotificationService) *CommentService {
+func NewCommentService(repo *repository.CommentRepository, articleRepo *repository.ArticleRepository, notificationService *NotificationService, blocks BlockChecker) *CommentService {
 	return &CommentService{
 		repo:                repo,
 		articleRepo:         articleRepo,
 		notificationService: notificationService,
+		blocks:              blocks,
+	}
+}
+
+// SetEventPublisher sets where live comment events are published
+func (s *CommentService) SetEventPublisher(publisher CommentEventPublisher) {
+	s.publisher = publisher
+}
+
+// LoadSentimentWords enables sentiment scoring of new and edited comments,
+// using the word list in the database. Changes to the list are picked up the
+// next time it is loaded.
+func (s *CommentService) LoadSentimentWords(ctx context.Context) error {
+	words, err := s.repo.ListSentimentWords(ctx)
+	if err != nil {
+		return err
+	}
+
+	list := make([]sentiment.Word, len(words))
+	for i, w := range words {
+		list[i] = sentiment.Word{Word: w.Word, Weight: w.Weight}
 	}
+	s.sentiment = sentiment.NewSentimentAnalyzer(list)
+	return nil
 }
 
-// CreateComment creates a new comment on an article
-func (s *CommentService) CreateComment(ctx context.Context, articleSlug string, userID uuid.UUID, req *models.CreateCommentRequest) (*models.Comment, error) {
+// scoreSentiment scores comment content, or returns nil if scoring is off
+func (s *CommentService) scoreSentiment(content string) *float64 {
+	if s.sentiment == nil {
+		return nil
+	}
+	score := s.sentiment.Score(content)
+	return &score
+}
+
+// CreateComment creates a new comment on an article, subject to the article's
+// comment settings
+func (s *CommentService) CreateComment(ctx context.Context, articleSlug string, userID uuid.UUID, isAdmin bool, req *models.CreateCommentRequest) (*models.Comment, error) {
 	// Get article by slug
 	article, err := s.articleRepo.GetBySlug(ctx, articleSlug)
 	if err != nil {
@@ -73,16 +119,25 @@ func (s *CommentService) CreateComment(ctx context.Context, articleSlug string,
 		if parentComment.ArticleID != article.ID {
 			return nil, fmt.Errorf("parent comment belongs to different article")
 		}
+		if err := checkReplyBlock(ctx, s.blocks, userID, parentComment.UserID); err != nil {
+			return nil, err
+		}
 		// Single-level threading is enforced at DB level
 	}
 
-	// Determine initial status based on profanity check
-	status := models.CommentStatusActive
+	status, err := articleCommentStatus(article, parentComment != nil, isAdmin, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	req.Content = sanitize.SanitizeCommentText(req.Content)
+
+	// Flag profanity for review
 	if containsProfanity(req.Content) {
 		status = models.CommentStatusUnderReview
 	}
 
-	comment, err := s.repo.Create(ctx, article.ID, userID, req, status)
+	comment, err := s.repo.Create(ctx, article.ID, userID, req, status, s.scoreSentiment(req.Content))
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +177,35 @@ func (s *CommentService) CreateComment(ctx context.Context, articleSlug string,
 	}
 
 	// Fetch full comment with user info
-	return s.repo.GetByID(ctx, comment.ID)
+	created, err := s.repo.GetByID(ctx, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishCommentState(article.Slug, created, models.CommentEventCreated)
+	s.scoreToxicityAsync(comment.ID, req.Content)
+
+	return created, nil
+}
+
+// articleCommentStatus checks a new comment against the article's comment
+// settings and returns the status it starts with. Admins can start threads on
+// locked articles and skip premoderation, but not comment where comments are
+// disabled. Premoderated comments wait under review, like flagged ones.
+func articleCommentStatus(article *models.Article, isReply, isAdmin bool, now time.Time) (models.CommentStatus, error) {
+	switch article.EffectiveCommentState(now) {
+	case models.CommentStateDisabled:
+		return "", fmt.Errorf("comments are disabled on this article")
+	case models.CommentStateLocked:
+		if !isReply && !isAdmin {
+			return "", fmt.Errorf("comments are locked on this article")
+		}
+	}
+
+	if article.CommentsPremoderated && !isAdmin {
+		return models.CommentStatusUnderReview, nil
+	}
+	return models.CommentStatusActive, nil
 }
 
 // GetComment retrieves a single comment
@@ -130,9 +213,9 @@ func (s *CommentService) GetComment(ctx context.Context, id uuid.UUID) (*models.
 	return s.repo.GetByID(ctx, id)
 }
 
-// ListArticleComments lists all comments for an article
+// ListArticleComments lists a page of an article's root comments in the given order
 // includeHidden is for admins only to see moderated comments
-func (s *CommentService) ListArticleComments(ctx context.Context, articleSlug string, currentUserID *uuid.UUID, includeHidden bool) ([]models.Comment, error) {
+func (s *CommentService) ListArticleComments(ctx context.Context, articleSlug string, currentUserID *uuid.UUID, includeHidden bool, sort models.CommentSort, page, perPage int) (*models.PaginatedComments, error) {
 	// Get article by slug
 	article, err := s.articleRepo.GetBySlug(ctx, articleSlug)
 	if err != nil {
@@ -142,7 +225,7 @@ func (s *CommentService) ListArticleComments(ctx context.Context, articleSlug st
 		return nil, fmt.Errorf("article not found")
 	}
 
-	return s.repo.ListByArticle(ctx, article.ID, currentUserID, includeHidden)
+	return s.repo.ListByArticle(ctx, article.ID, currentUserID, includeHidden, sort, page, perPage)
 }
 
 // ListReplies lists all replies to a comment
@@ -165,11 +248,20 @@ func (s *CommentService) UpdateComment(ctx context.Context, id uuid.UUID, userID
 		return nil, fmt.Errorf("not authorized to edit this comment")
 	}
 
-	if err := s.repo.Update(ctx, id, req.Content); err != nil {
+	content := sanitize.SanitizeCommentText(req.Content)
+	if err := s.repo.Update(ctx, id, content, s.scoreSentiment(content)); err != nil {
 		return nil, err
 	}
 
-	return s.repo.GetByID(ctx, id)
+	updated, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishCommentState(s.articleSlug(ctx, comment.ArticleID), updated, models.CommentEventUpdated)
+	s.scoreToxicityAsync(id, content)
+
+	return updated, nil
 }
 
 // DeleteComment soft deletes a comment
@@ -188,7 +280,16 @@ func (s *CommentService) DeleteComment(ctx context.Context, id uuid.UUID, userID
 		return fmt.Errorf("not authorized to delete this comment")
 	}
 
-	return s.repo.Delete(ctx, id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.publishCommentEvent(s.articleSlug(ctx, comment.ArticleID), models.CommentEvent{
+		Type:      models.CommentEventRemoved,
+		CommentID: id,
+	})
+
+	return nil
 }
 
 // AddReaction adds a reaction to a comment
@@ -249,10 +350,99 @@ func (s *CommentService) ModerateComment(ctx context.Context, commentID uuid.UUI
 	}
 
 	// Return updated comment
-	return s.repo.GetByID(ctx, commentID)
+	moderated, err := s.repo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishCommentState(s.articleSlug(ctx, comment.ArticleID), moderated, models.CommentEventUpdated)
+
+	return moderated, nil
 }
 
 // ListAllComments lists all comments for admin moderation panel
 func (s *CommentService) ListAllComments(ctx context.Context, filter *models.CommentFilter, currentUserID *uuid.UUID) ([]models.Comment, error) {
 	return s.repo.ListAllComments(ctx, filter, currentUserID)
 }
+
+// GetSentimentSummary returns the average sentiment of an article's comments
+func (s *CommentService) GetSentimentSummary(ctx context.Context, articleID uuid.UUID) (*models.CommentSentiment, error) {
+	return s.repo.GetSentimentSummary(ctx, articleID)
+}
+
+// ListUserMentions lists comments that @mention the user, newest first
+func (s *CommentService) ListUserMentions(ctx context.Context, userID uuid.UUID, page, perPage int, unreadOnly bool) (*models.PaginatedMentions, error) {
+	return s.repo.ListUserMentions(ctx, userID, page, perPage, unreadOnly)
+}
+
+// GetUnreadMentionCount counts the user's unread mentions
+func (s *CommentService) GetUnreadMentionCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.repo.CountUnreadMentions(ctx, userID)
+}
+
+// MarkMentionRead marks one of the user's mentions as read
+func (s *CommentService) MarkMentionRead(ctx context.Context, id, userID uuid.UUID) error {
+	if err := s.repo.MarkMentionRead(ctx, id, userID); err != nil {
+		return err
+	}
+	s.invalidateUnread(ctx, userID)
+	return nil
+}
+
+// MarkAllMentionsRead marks all of the user's mentions as read
+func (s *CommentService) MarkAllMentionsRead(ctx context.Context, userID uuid.UUID) error {
+	if err := s.repo.MarkAllMentionsRead(ctx, userID); err != nil {
+		return err
+	}
+	s.invalidateUnread(ctx, userID)
+	return nil
+}
+
+// invalidateUnread drops the user's cached inbox unread counts
+func (s *CommentService) invalidateUnread(ctx context.Context, userID uuid.UUID) {
+	if s.notificationService != nil {
+		s.notificationService.InvalidateUnreadCount(ctx, userID)
+	}
+}
+
+// publishCommentState publishes eventType for a publicly visible comment, or a
+// removal when the comment is missing or hidden by moderation
+func (s *CommentService) publishCommentState(articleSlug string, comment *models.Comment, eventType models.CommentEventType) {
+	if comment == nil {
+		return
+	}
+
+	if comment.Status != models.CommentStatusActive {
+		// Comments held for review on creation were never shown to readers
+		if eventType == models.CommentEventCreated {
+			return
+		}
+		s.publishCommentEvent(articleSlug, models.CommentEvent{
+			Type:      models.CommentEventRemoved,
+			CommentID: comment.ID,
+		})
+		return
+	}
+
+	s.publishCommentEvent(articleSlug, models.CommentEvent{
+		Type:      eventType,
+		CommentID: comment.ID,
+		Comment:   comment,
+	})
+}
+
+func (s *CommentService) publishCommentEvent(articleSlug string, event models.CommentEvent) {
+	if s.publisher == nil || articleSlug == "" {
+		return
+	}
+	s.publisher.PublishCommentEvent(articleSlug, event)
+}
+
+// articleSlug looks up the slug of the article a comment belongs to
+func (s *CommentService) articleSlug(ctx context.Context, articleID uuid.UUID) string {
+	article, err := s.articleRepo.GetByID(ctx, articleID)
+	if err != nil || article == nil {
+		return ""
+	}
+	return article.Slug
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleCommentStatus(t *testing.T) {
+	now := time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC)
+	lockedAt := now.Add(-time.Hour)
+
+	open := &models.Article{CommentsEnabled: true}
+	disabled := &models.Article{}
+	locked := &models.Article{CommentsEnabled: true, CommentsLockedAt: &lockedAt}
+	premoderated := &models.Article{CommentsEnabled: true, CommentsPremoderated: true}
+	lockedPremoderated := &models.Article{CommentsEnabled: true, CommentsPremoderated: true, CommentsLockedAt: &lockedAt}
+
+	tests := []struct {
+		name    string
+		article *models.Article
+		isReply bool
+		isAdmin bool
+		want    models.CommentStatus
+		wantErr string
+	}{
+		{"open", open, false, false, models.CommentStatusActive, ""},
+		{"disabled", disabled, false, false, "", "comments are disabled on this article"},
+		{"disabled reply", disabled, true, false, "", "comments are disabled on this article"},
+		{"disabled admin", disabled, false, true, "", "comments are disabled on this article"},
+		{"locked new thread", locked, false, false, "", "comments are locked on this article"},
+		{"locked reply", locked, true, false, models.CommentStatusActive, ""},
+		{"locked admin thread", locked, false, true, models.CommentStatusActive, ""},
+		{"premoderated", premoderated, false, false, models.CommentStatusUnderReview, ""},
+		{"premoderated admin", premoderated, false, true, models.CommentStatusActive, ""},
+		{"locked premoderated reply", lockedPremoderated, true, false, models.CommentStatusUnderReview, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := articleCommentStatus(tt.article, tt.isReply, tt.isAdmin, now)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
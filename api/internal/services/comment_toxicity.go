@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// ToxicityScorer rates how likely comment content is to be abusive, from 0
+// to 1. Name is recorded with each score.
+type ToxicityScorer interface {
+	Name() string
+	Score(ctx context.Context, content string) (float64, error)
+}
+
+const (
+	// How long one scoring attempt may take
+	toxicityScoreTimeout = 30 * time.Second
+	// Failed attempts are retried after toxicityRetryBase, doubling each time,
+	// up to toxicityMaxAttempts. Comments older than toxicityRetryWindow are
+	// left unscored.
+	toxicityRetryBase   = time.Minute
+	toxicityMaxAttempts = 5
+	toxicityRetryWindow = 7 * 24 * time.Hour
+	// Scoring started when a comment is posted gets this long before the
+	// retry job picks the comment up
+	toxicityRetryGrace = 2 * time.Minute
+	toxicityBatchSize  = 50
+)
+
+// SetToxicityScorer enables toxicity scoring of new and edited comments.
+// Comments scoring flagThreshold or more are held under review; a threshold
+// above 1 never holds any.
+func (s *CommentService) SetToxicityScorer(scorer ToxicityScorer, flagThreshold float64) {
+	s.toxicity = scorer
+	s.toxicityThreshold = flagThreshold
+}
+
+// scoreToxicityAsync scores a comment in the background so posting doesn't
+// wait on the scorer. Failures are left to ScoreUnscoredToxicity to retry.
+func (s *CommentService) scoreToxicityAsync(id uuid.UUID, content string) {
+	if s.toxicity == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), toxicityScoreTimeout)
+		defer cancel()
+		_ = s.scoreToxicity(ctx, id, content)
+	}()
+}
+
+// ScoreUnscoredToxicity retries comments whose scoring failed or never ran.
+// It returns how many were scored and how many failed again.
+func (s *CommentService) ScoreUnscoredToxicity(ctx context.Context) (scored, failed int, err error) {
+	if s.toxicity == nil {
+		return 0, 0, nil
+	}
+
+	pending, err := s.repo.ListUnscoredToxicity(ctx, toxicityMaxAttempts, toxicityRetryWindow, toxicityRetryGrace, toxicityBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, c := range pending {
+		if ctx.Err() != nil {
+			return scored, failed, ctx.Err()
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, toxicityScoreTimeout)
+		err := s.scoreToxicity(attemptCtx, c.ID, c.Content)
+		cancel()
+		if err != nil {
+			failed++
+		} else {
+			scored++
+		}
+	}
+	return scored, failed, nil
+}
+
+// scoreToxicity scores one comment and holds it for review if it crosses the
+// threshold. A failure only schedules a retry: the comment stays as posted.
+func (s *CommentService) scoreToxicity(ctx context.Context, id uuid.UUID, content string) error {
+	score, err := s.toxicity.Score(ctx, content)
+	if err != nil {
+		_ = s.repo.RecordToxicityFailure(context.WithoutCancel(ctx), id, toxicityRetryBase)
+		return err
+	}
+
+	held, err := s.repo.SetToxicityScore(ctx, id, content, score, s.toxicity.Name(), score >= s.toxicityThreshold)
+	if err != nil {
+		return err
+	}
+
+	if held {
+		// Take it down from readers' live views until a moderator decides
+		comment, err := s.repo.GetByID(ctx, id)
+		if err == nil && comment != nil {
+			s.publishCommentState(s.articleSlug(ctx, comment.ArticleID), comment, models.CommentEventUpdated)
+		}
+	}
+	return nil
+}
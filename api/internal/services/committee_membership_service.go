@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+// GetCommitteeByID returns an active committee, or nil if it does not exist
+func (s *BillService) GetCommitteeByID(ctx context.Context, id uuid.UUID) (*models.Committee, error) {
+	return s.repo.GetCommitteeByID(ctx, id)
+}
+
+// ListCommitteeMemberships returns every seat on a committee, current seats first
+func (s *BillService) ListCommitteeMemberships(ctx context.Context, committeeID uuid.UUID) ([]models.CommitteeMembership, error) {
+	return s.repo.ListCommitteeMemberships(ctx, committeeID)
+}
+
+// GetCommitteeMembership returns a membership only if it belongs to the given committee
+func (s *BillService) GetCommitteeMembership(ctx context.Context, committeeID, membershipID uuid.UUID) (*models.CommitteeMembership, error) {
+	membership, err := s.repo.GetCommitteeMembershipByID(ctx, membershipID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil || membership.CommitteeID != committeeID {
+		return nil, nil
+	}
+	return membership, nil
+}
+
+func (s *BillService) CreateCommitteeMembership(ctx context.Context, committee *models.Committee, req *models.CreateCommitteeMembershipRequest) (*models.CommitteeMembership, error) {
+	if err := validateMembershipDates(req.StartDate, req.EndDate); err != nil {
+		return nil, err
+	}
+	if err := s.checkRoleVacant(ctx, committee.ID, req.Role, req.StartDate, req.EndDate, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.CreateCommitteeMembership(ctx, committee.ID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := s.repo.GetCommitteeMembershipByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCommitteeMembershipCache(ctx, committee.Slug, membership.PoliticianSlug)
+
+	return membership, nil
+}
+
+func (s *BillService) UpdateCommitteeMembership(ctx context.Context, committee *models.Committee, membership *models.CommitteeMembership, req *models.UpdateCommitteeMembershipRequest) (*models.CommitteeMembership, error) {
+	// Validate against the merged result so partial updates can't produce an invalid seat
+	role := membership.Role
+	if req.Role != nil {
+		role = *req.Role
+	}
+	startDate := formatOptionalDate(membership.StartDate)
+	if req.StartDate != nil {
+		startDate = req.StartDate
+	}
+	endDate := formatOptionalDate(membership.EndDate)
+	if req.EndDate != nil {
+		endDate = req.EndDate
+	}
+	if req.RemoveEndDate {
+		endDate = nil
+	}
+	if err := validateMembershipDates(startDate, endDate); err != nil {
+		return nil, err
+	}
+	if err := s.checkRoleVacant(ctx, committee.ID, role, startDate, endDate, membership.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateCommitteeMembership(ctx, membership.ID, req); err != nil {
+		return nil, err
+	}
+
+	s.invalidateCommitteeMembershipCache(ctx, committee.Slug, membership.PoliticianSlug)
+
+	return s.repo.GetCommitteeMembershipByID(ctx, membership.ID)
+}
+
+func (s *BillService) DeleteCommitteeMembership(ctx context.Context, committee *models.Committee, membership *models.CommitteeMembership) error {
+	if err := s.repo.DeleteCommitteeMembership(ctx, membership.ID); err != nil {
+		return err
+	}
+
+	s.invalidateCommitteeMembershipCache(ctx, committee.Slug, membership.PoliticianSlug)
+
+	return nil
+}
+
+// ListCommitteeBills lists the bills referred to the committee
+func (s *BillService) ListCommitteeBills(ctx context.Context, committeeID uuid.UUID, page, perPage int) (*models.PaginatedBills, error) {
+	return s.repo.List(ctx, &models.BillFilter{CommitteeID: &committeeID}, page, perPage)
+}
+
+// checkRoleVacant rejects a second chair or vice chair whose term overlaps an
+// existing one. Any number of regular members may serve at once.
+func (s *BillService) checkRoleVacant(ctx context.Context, committeeID uuid.UUID, role string, startDate, endDate *string, excludeID uuid.UUID) error {
+	if role == models.CommitteeRoleMember {
+		return nil
+	}
+
+	taken, err := s.repo.HasOverlappingRoleHolder(ctx, committeeID, role, startDate, endDate, excludeID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("committee already has a %s for this period", committeeRoleLabel(role))
+	}
+	return nil
+}
+
+func validateMembershipDates(startDate, endDate *string) error {
+	// YYYY-MM-DD strings compare chronologically
+	if startDate != nil && endDate != nil && *endDate < *startDate {
+		return fmt.Errorf("end_date cannot be before start_date")
+	}
+	return nil
+}
+
+// formatOptionalDate renders a stored date in request (YYYY-MM-DD) form
+func formatOptionalDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02")
+	return &formatted
+}
+
+func committeeRoleLabel(role string) string {
+	if role == models.CommitteeRoleViceChair {
+		return "vice chair"
+	}
+	return role
+}
+
+// invalidateCommitteeMembershipCache clears the committee page and the
+// member's profile, which both list the seat
+func (s *BillService) invalidateCommitteeMembershipCache(ctx context.Context, committeeSlug, politicianSlug string) {
+	_ = s.cache.Delete(ctx, committeesCachePrefix+"slug:"+committeeSlug)
+	_ = s.cache.Delete(ctx, cache.PoliticianSlugKey(politicianSlug))
+}
@@ -127,7 +127,7 @@ func (s *ElectionEventService) ArchiveCurrentHolders(ctx context.Context, electi
 	}
 
 	// Archive all current holders
-	if err := s.historyRepo.BulkArchiveForElection(ctx, electionID, positionIDs, election.ElectionDate.Format("2006-01-02")); err != nil {
+	if err := s.historyRepo.BulkArchiveForElection(ctx, electionID, positionIDs, election.ElectionDate.String()); err != nil {
 		return 0, fmt.Errorf("failed to bulk archive positions: %w", err)
 	}
 
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// maxLocationResultRows bounds one location results import
+const maxLocationResultRows = 100000
+
+// parseLocationResultsCSV reads a location results CSV. The header names the
+// columns, in any order: candidate_id, location (city or municipality slug
+// or PSGC code), votes and, optionally, barangay. Rows that can't be read are
+// reported and skipped; an unreadable file is an error.
+func parseLocationResultsCSV(r io.Reader) ([]models.LocationResultRow, []models.ValidationError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, fmt.Errorf("file is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"candidate_id", "location", "votes"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+	barangayCol, hasBarangay := columns["barangay"]
+
+	var rows []models.LocationResultRow
+	var errs []models.ValidationError
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				errs = append(errs, models.ValidationError{Row: line, Error: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		if len(rows)+len(errs) >= maxLocationResultRows {
+			return nil, nil, fmt.Errorf("file has more than %d rows", maxLocationResultRows)
+		}
+
+		field := func(col int) string {
+			if col < len(record) {
+				return strings.TrimSpace(record[col])
+			}
+			return ""
+		}
+		fail := func(name, msg string) {
+			value := field(columns[name])
+			errs = append(errs, models.ValidationError{Row: line, Field: name, Error: msg, Value: &value})
+		}
+
+		row := models.LocationResultRow{Row: line, Location: field(columns["location"])}
+		if hasBarangay {
+			row.Barangay = field(barangayCol)
+		}
+
+		candidateID, err := uuid.Parse(field(columns["candidate_id"]))
+		if err != nil {
+			fail("candidate_id", "must be a candidate ID")
+			continue
+		}
+		row.CandidateID = candidateID
+
+		if row.Location == "" {
+			fail("location", "is required")
+			continue
+		}
+
+		votes, err := strconv.Atoi(strings.ReplaceAll(field(columns["votes"]), ",", ""))
+		if err != nil || votes < 0 {
+			fail("votes", "must be a whole number of at least 0")
+			continue
+		}
+		row.Votes = votes
+
+		rows = append(rows, row)
+	}
+
+	return rows, errs, nil
+}
+
+// ImportLocationResults stores votes by city, municipality or barangay from
+// a CSV. Every row must be valid or nothing is stored. Province and region
+// totals are rolled up afterwards by the rollup job. With creditSubstitutes,
+// votes cast for a substituted candidate, whose name is still on the ballot,
+// count for their substitute.
+func (s *ElectionService) ImportLocationResults(ctx context.Context, electionID uuid.UUID, file io.Reader, creditSubstitutes bool) (*models.LocationResultsImport, error) {
+	election, err := s.repo.GetElectionByID(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if election == nil {
+		return nil, fmt.Errorf("election not found")
+	}
+
+	rows, errs, err := parseLocationResultsCSV(file)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.LocationResultsImport{TotalRows: len(rows) + len(errs), Errors: errs}
+
+	results, resolveErrs, err := s.resolveLocationResults(ctx, electionID, rows, creditSubstitutes)
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = append(result.Errors, resolveErrs...)
+	if len(result.Errors) > 0 || len(results) == 0 {
+		return result, nil
+	}
+
+	if err := s.repo.ImportLocationResults(ctx, electionID, results); err != nil {
+		return nil, err
+	}
+	result.RowsImported = len(results)
+
+	return result, nil
+}
+
+// resolveLocationResults checks each row's candidate belongs to the election
+// and looks its places up. Rows credited to a substitute are added to the
+// substitute's own votes for the same place.
+func (s *ElectionService) resolveLocationResults(ctx context.Context, electionID uuid.UUID, rows []models.LocationResultRow, creditSubstitutes bool) ([]models.CandidateLocationResult, []models.ValidationError, error) {
+	candidateIDs, err := s.repo.ListElectionCandidateIDs(ctx, electionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	candidates := make(map[uuid.UUID]bool, len(candidateIDs))
+	for _, id := range candidateIDs {
+		candidates[id] = true
+	}
+
+	var substitutions map[uuid.UUID]uuid.UUID
+	if creditSubstitutes {
+		if substitutions, err = s.repo.ListElectionSubstitutions(ctx, electionID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var cityKeys, barangayKeys []string
+	for _, row := range rows {
+		cityKeys = append(cityKeys, row.Location)
+		if row.Barangay != "" {
+			barangayKeys = append(barangayKeys, row.Barangay)
+		}
+	}
+	cities, err := s.repo.ResolveCities(ctx, cityKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	barangays, err := s.repo.ResolveBarangays(ctx, barangayKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	type key struct {
+		candidate, city uuid.UUID
+		barangay        uuid.UUID // uuid.Nil for the whole city
+	}
+	seen := make(map[key]int, len(rows))
+	credited := make(map[key]int, len(rows)) // Index into results
+
+	var results []models.CandidateLocationResult
+	var errs []models.ValidationError
+	for _, row := range rows {
+		fail := func(field, value, msg string) {
+			errs = append(errs, models.ValidationError{Row: row.Row, Field: field, Error: msg, Value: &value})
+		}
+
+		if !candidates[row.CandidateID] {
+			fail("candidate_id", row.CandidateID.String(), "is not a candidate in this election")
+			continue
+		}
+		cityID, ok := cities[row.Location]
+		if !ok {
+			fail("location", row.Location, "is not a known city or municipality")
+			continue
+		}
+
+		res := models.CandidateLocationResult{CandidateID: row.CandidateID, CityMunicipalityID: cityID, Votes: row.Votes}
+		k := key{candidate: row.CandidateID, city: cityID}
+		if row.Barangay != "" {
+			b, ok := barangays[row.Barangay]
+			if !ok {
+				fail("barangay", row.Barangay, "is not a known barangay")
+				continue
+			}
+			if b.CityMunicipalityID != cityID {
+				fail("barangay", row.Barangay, "is not in "+row.Location)
+				continue
+			}
+			res.BarangayID = &b.ID
+			k.barangay = b.ID
+		}
+
+		if first, dup := seen[k]; dup {
+			fail("candidate_id", row.CandidateID.String(), fmt.Sprintf("repeats row %d for the same place", first))
+			continue
+		}
+		seen[k] = row.Row
+
+		res.CandidateID = creditedCandidate(row.CandidateID, substitutions)
+		k.candidate = res.CandidateID
+		if i, ok := credited[k]; ok {
+			results[i].Votes += res.Votes
+			continue
+		}
+		credited[k] = len(results)
+		results = append(results, res)
+	}
+
+	return results, errs, nil
+}
+
+// RecomputeStaleRollups rebuilds the province and region totals of every
+// election with results imported since its last rollup. It returns how many
+// elections were rolled up.
+func (s *ElectionService) RecomputeStaleRollups(ctx context.Context) (int, error) {
+	ids, err := s.repo.ListStaleRollups(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	var errs []error
+	for _, id := range ids {
+		if err := s.repo.RecomputeRollups(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("election %s: %w", id, err))
+			continue
+		}
+		done++
+	}
+	return done, errors.Join(errs...)
+}
+
+// GetResultsMap returns the leader in each province and region for one
+// position, or for the first position on the ballot with location results
+// when positionID is nil
+func (s *ElectionService) GetResultsMap(ctx context.Context, slug string, positionID *uuid.UUID) (*models.ElectionResultsMap, error) {
+	election, err := s.GetElectionBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if election == nil {
+		return nil, fmt.Errorf("election not found")
+	}
+
+	freshness, err := s.repo.GetRollupFreshness(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := s.repo.GetRollupPositions(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ElectionResultsMap{
+		ElectionID:      election.ID,
+		Name:            election.Name,
+		Slug:            election.Slug,
+		ElectionDate:    election.ElectionDate,
+		RollupFreshness: freshness,
+		Positions:       positions,
+		Provinces:       []models.LocationLeader{},
+		Regions:         []models.LocationLeader{},
+	}
+
+	for i, p := range positions {
+		if positionID == nil && i == 0 || positionID != nil && p.ID == *positionID {
+			result.Position = &positions[i]
+			break
+		}
+	}
+	if result.Position == nil {
+		if positionID != nil {
+			return nil, fmt.Errorf("position has no location results")
+		}
+		return result, nil
+	}
+
+	if result.Provinces, err = s.repo.GetLocationLeaders(ctx, result.Position.ID, models.ResultLevelProvince); err != nil {
+		return nil, err
+	}
+	if result.Regions, err = s.repo.GetLocationLeaders(ctx, result.Position.ID, models.ResultLevelRegion); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetCityResults returns every position's results in one city or
+// municipality
+func (s *ElectionService) GetCityResults(ctx context.Context, slug, citySlug string) (*models.ElectionCityResults, error) {
+	election, err := s.GetElectionBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if election == nil {
+		return nil, fmt.Errorf("election not found")
+	}
+
+	city, err := s.repo.GetResultsCity(ctx, citySlug)
+	if err != nil {
+		return nil, err
+	}
+	if city == nil {
+		return nil, fmt.Errorf("city not found")
+	}
+
+	freshness, err := s.repo.GetRollupFreshness(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := s.repo.GetCityResults(ctx, election.ID, city.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ElectionCityResults{
+		ElectionID:      election.ID,
+		Name:            election.Name,
+		Slug:            election.Slug,
+		ElectionDate:    election.ElectionDate,
+		RollupFreshness: freshness,
+		City:            *city,
+		Positions:       positions,
+	}, nil
+}
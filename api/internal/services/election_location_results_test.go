@@ -0,0 +1,41 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocationResultsCSV(t *testing.T) {
+	csv := "\ufeffvotes,Location,candidate_id,barangay\n" +
+		"\"1,250\",quezon-city,5f0c6a4e-8a3b-4a2b-9a6e-1c2d3e4f5a6b,\n" +
+		"300,137404000,5f0c6a4e-8a3b-4a2b-9a6e-1c2d3e4f5a6b,bagong-pag-asa\n" +
+		"12,quezon-city,not-an-id,\n" +
+		"-4,quezon-city,5f0c6a4e-8a3b-4a2b-9a6e-1c2d3e4f5a6b,\n" +
+		"5,,5f0c6a4e-8a3b-4a2b-9a6e-1c2d3e4f5a6b,\n"
+
+	rows, errs, err := parseLocationResultsCSV(strings.NewReader(csv))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 1250, rows[0].Votes)
+	assert.Equal(t, "quezon-city", rows[0].Location)
+	assert.Empty(t, rows[0].Barangay)
+	assert.Equal(t, 3, rows[1].Row)
+	assert.Equal(t, "bagong-pag-asa", rows[1].Barangay)
+
+	require.Len(t, errs, 3)
+	assert.Equal(t, 4, errs[0].Row)
+	assert.Equal(t, "candidate_id", errs[0].Field)
+	assert.Equal(t, "votes", errs[1].Field)
+	assert.Equal(t, "location", errs[2].Field)
+}
+
+func TestParseLocationResultsCSVHeader(t *testing.T) {
+	_, _, err := parseLocationResultsCSV(strings.NewReader(""))
+	assert.EqualError(t, err, "file is empty")
+
+	_, _, err = parseLocationResultsCSV(strings.NewReader("candidate_id,location\n"))
+	assert.EqualError(t, err, "missing votes column")
+}
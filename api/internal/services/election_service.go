@@ -21,8 +21,17 @@ const (
 )
 
 type ElectionService struct {
-	repo  *repository.ElectionRepository
-	cache *cache.RedisCache
+	repo       *repository.ElectionRepository
+	cache      *cache.RedisCache
+	webhooks   *WebhookService
+	analyzer   PlatformAnalyzer
+	staleAfter time.Duration
+	locations  LocationLookup
+	// The site timezone, which decides what day it is for upcoming
+	// elections and the calendar
+	siteLocation *time.Location
+
+	votingRecords VotingRecordLookup
 }
 
 func NewElectionService(repo *repository.ElectionRepository, cache *cache.RedisCache) *ElectionService {
@@ -32,6 +41,33 @@ func NewElectionService(repo *repository.ElectionRepository, cache *cache.RedisC
 	}
 }
 
+// SetWebhookService enables webhook events for election results
+func (s *ElectionService) SetWebhookService(webhooks *WebhookService) {
+	s.webhooks = webhooks
+}
+
+// SetStaleAfter sets how long an upcoming or ongoing election may go without
+// updates before it is flagged as stale. Zero turns the flag off.
+func (s *ElectionService) SetStaleAfter(d time.Duration) {
+	s.staleAfter = d
+}
+
+// SetLocationLookup enables checking that an election position's locations
+// nest
+func (s *ElectionService) SetLocationLookup(locations LocationLookup) {
+	s.locations = locations
+}
+
+// SetSiteLocation sets the timezone election days are counted in
+func (s *ElectionService) SetSiteLocation(loc *time.Location) {
+	s.siteLocation = loc
+}
+
+// Today returns the current day in the site timezone
+func (s *ElectionService) Today() models.Date {
+	return models.DateOf(time.Now(), s.siteLocation)
+}
+
 // Elections
 
 func (s *ElectionService) CreateElection(ctx context.Context, req *models.CreateElectionRequest) (*models.Election, error) {
@@ -50,6 +86,7 @@ func (s *ElectionService) GetElectionByID(ctx context.Context, id uuid.UUID) (*m
 
 	var election models.Election
 	if err := s.cache.Get(ctx, cacheKey, &election); err == nil {
+		s.markStale(&election)
 		return &election, nil
 	}
 
@@ -60,6 +97,7 @@ func (s *ElectionService) GetElectionByID(ctx context.Context, id uuid.UUID) (*m
 
 	if electionPtr != nil {
 		_ = s.cache.Set(ctx, cacheKey, electionPtr, electionCacheTTL)
+		s.markStale(electionPtr)
 	}
 
 	return electionPtr, nil
@@ -70,6 +108,7 @@ func (s *ElectionService) GetElectionBySlug(ctx context.Context, slug string) (*
 
 	var election models.Election
 	if err := s.cache.Get(ctx, cacheKey, &election); err == nil {
+		s.markStale(&election)
 		return &election, nil
 	}
 
@@ -80,6 +119,7 @@ func (s *ElectionService) GetElectionBySlug(ctx context.Context, slug string) (*
 
 	if electionPtr != nil {
 		_ = s.cache.Set(ctx, cacheKey, electionPtr, electionCacheTTL)
+		s.markStale(electionPtr)
 	}
 
 	return electionPtr, nil
@@ -97,7 +137,7 @@ func (s *ElectionService) GetUpcomingElections(ctx context.Context, limit int) (
 		return elections, nil
 	}
 
-	elections, err := s.repo.GetUpcomingElections(ctx, limit)
+	elections, err := s.repo.GetUpcomingElections(ctx, s.Today(), limit)
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +191,10 @@ func (s *ElectionService) UpdateElection(ctx context.Context, id uuid.UUID, req
 
 	if election != nil {
 		s.invalidateElectionCache(ctx, id, election.Slug)
+		s.markStale(election)
+		if req.VoterTurnoutPercentage != nil || req.TotalVotesCast != nil {
+			s.emitResultsUpdated(ctx, models.ElectionResultsUpdatedEvent{ElectionID: id})
+		}
 	}
 
 	return election, nil
@@ -174,12 +218,29 @@ func (s *ElectionService) DeleteElection(ctx context.Context, id uuid.UUID) erro
 // Election Positions
 
 func (s *ElectionService) CreateElectionPosition(ctx context.Context, req *models.CreateElectionPositionRequest) (*models.ElectionPosition, error) {
+	if s.locations != nil {
+		err := validateLocationHierarchy(ctx, s.locations, locationRefs{
+			regionID:   req.RegionID,
+			provinceID: req.ProvinceID,
+			cityID:     req.CityMunicipalityID,
+			barangayID: req.BarangayID,
+			districtID: req.DistrictID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	position, err := s.repo.CreateElectionPosition(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
 	_ = s.cache.Delete(ctx, electionCachePrefix+"id:"+req.ElectionID.String())
+	if election, _ := s.repo.GetElectionByID(ctx, req.ElectionID); election != nil {
+		// The slug view carries the ballot groups the new position joins
+		_ = s.cache.Delete(ctx, electionCachePrefix+"slug:"+election.Slug)
+	}
 
 	return position, nil
 }
@@ -188,6 +249,113 @@ func (s *ElectionService) GetElectionPositions(ctx context.Context, electionID u
 	return s.repo.GetElectionPositions(ctx, electionID)
 }
 
+// ReorderElectionPositions applies a new ballot order and returns the
+// positions in that order
+func (s *ElectionService) ReorderElectionPositions(ctx context.Context, electionID uuid.UUID, req *models.ReorderElectionPositionsRequest) ([]models.ElectionPositionListItem, error) {
+	election, err := s.repo.GetElectionByID(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if election == nil {
+		return nil, fmt.Errorf("election not found")
+	}
+
+	if err := s.repo.ReorderElectionPositions(ctx, electionID, req.PositionIDs); err != nil {
+		return nil, err
+	}
+
+	s.invalidateElectionCache(ctx, election.ID, election.Slug)
+
+	return s.repo.GetElectionPositions(ctx, electionID)
+}
+
+// SetBallotOrders moves some positions within their ballot groups and
+// returns all the positions in their new order
+func (s *ElectionService) SetBallotOrders(ctx context.Context, electionID uuid.UUID, req *models.SetBallotOrdersRequest) ([]models.ElectionPositionListItem, error) {
+	election, err := s.repo.GetElectionByID(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if election == nil {
+		return nil, fmt.Errorf("election not found")
+	}
+
+	if err := s.repo.SetBallotOrders(ctx, electionID, req.Orders); err != nil {
+		return nil, err
+	}
+
+	s.invalidateElectionCache(ctx, election.ID, election.Slug)
+
+	return s.repo.GetElectionPositions(ctx, electionID)
+}
+
+// GetResultsSummary returns the winners of each position in an election.
+// It is cached with the candidates, whose tallies it is built from.
+func (s *ElectionService) GetResultsSummary(ctx context.Context, slug string) (*models.ElectionResultsSummary, error) {
+	election, err := s.GetElectionBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if election == nil {
+		return nil, fmt.Errorf("election not found")
+	}
+
+	cacheKey := candidatesCachePrefix + "results_summary:" + election.ID.String()
+
+	var positions []models.PositionResultSummary
+	if err := s.cache.Get(ctx, cacheKey, &positions); err != nil {
+		positions, err = s.repo.GetResultsSummary(ctx, election.ID)
+		if err != nil {
+			return nil, err
+		}
+		_ = s.cache.Set(ctx, cacheKey, positions, electionCacheTTL)
+	}
+
+	return &models.ElectionResultsSummary{
+		ElectionID:   election.ID,
+		Name:         election.Name,
+		Slug:         election.Slug,
+		Status:       election.Status,
+		ElectionDate: election.ElectionDate,
+		DataAsOf:     election.DataAsOf,
+		Positions:    positions,
+	}, nil
+}
+
+// GetCoalitionResults totals candidates and winners by coalition. It is
+// cached with the candidates, and cleared when party relations change.
+func (s *ElectionService) GetCoalitionResults(ctx context.Context, slug string) (*models.ElectionCoalitionResults, error) {
+	election, err := s.GetElectionBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if election == nil {
+		return nil, fmt.Errorf("election not found")
+	}
+
+	cacheKey := candidatesCachePrefix + "by_coalition:" + election.ID.String()
+
+	var coalitions []models.CoalitionResult
+	if err := s.cache.Get(ctx, cacheKey, &coalitions); err != nil {
+		tallies, err := s.repo.GetPartyTallies(ctx, election.ID)
+		if err != nil {
+			return nil, err
+		}
+		coalitions = models.GroupByCoalition(tallies)
+		_ = s.cache.Set(ctx, cacheKey, coalitions, electionCacheTTL)
+	}
+
+	return &models.ElectionCoalitionResults{
+		ElectionID:   election.ID,
+		Name:         election.Name,
+		Slug:         election.Slug,
+		Status:       election.Status,
+		ElectionDate: election.ElectionDate,
+		DataAsOf:     election.DataAsOf,
+		Coalitions:   coalitions,
+	}, nil
+}
+
 // Candidates
 
 func (s *ElectionService) CreateCandidate(ctx context.Context, req *models.CreateCandidateRequest) (*models.Candidate, error) {
@@ -196,6 +364,10 @@ func (s *ElectionService) CreateCandidate(ctx context.Context, req *models.Creat
 		return nil, err
 	}
 
+	if err := s.syncCandidateIssues(ctx, candidate.ID, candidate.Platform); err != nil {
+		return nil, err
+	}
+
 	_ = s.cache.DeletePattern(ctx, candidatesCachePrefix+"*")
 
 	return candidate, nil
@@ -205,15 +377,20 @@ func (s *ElectionService) GetCandidateByID(ctx context.Context, id uuid.UUID) (*
 	return s.repo.GetCandidateByID(ctx, id)
 }
 
-func (s *ElectionService) GetCandidatesForPosition(ctx context.Context, positionID uuid.UUID) ([]models.CandidateListItem, error) {
+// GetCandidatesForPosition lists a position's candidates, only those whose
+// platform addresses issue when it is given
+func (s *ElectionService) GetCandidatesForPosition(ctx context.Context, positionID uuid.UUID, issue *string) ([]models.CandidateListItem, error) {
 	cacheKey := candidatesCachePrefix + "position:" + positionID.String()
+	if issue != nil {
+		cacheKey += ":issue:" + *issue
+	}
 
 	var candidates []models.CandidateListItem
 	if err := s.cache.Get(ctx, cacheKey, &candidates); err == nil {
 		return candidates, nil
 	}
 
-	candidates, err := s.repo.GetCandidatesForPosition(ctx, positionID)
+	candidates, err := s.repo.GetCandidatesForPosition(ctx, positionID, issue)
 	if err != nil {
 		return nil, err
 	}
@@ -233,11 +410,35 @@ func (s *ElectionService) UpdateCandidate(ctx context.Context, id uuid.UUID, req
 		return nil, err
 	}
 
+	if candidate != nil && req.Platform != nil {
+		if err := s.syncCandidateIssues(ctx, candidate.ID, candidate.Platform); err != nil {
+			return nil, err
+		}
+	}
+
 	_ = s.cache.DeletePattern(ctx, candidatesCachePrefix+"*")
 
+	if candidate != nil && (req.IsWinner != nil || req.VotesReceived != nil || req.VotePercentage != nil) {
+		if electionID, _ := s.repo.GetElectionPositionElectionID(ctx, candidate.ElectionPositionID); electionID != nil {
+			// The tally moved the election's data_as_of on
+			if election, _ := s.repo.GetElectionByID(ctx, *electionID); election != nil {
+				s.invalidateElectionCache(ctx, election.ID, election.Slug)
+			}
+			s.emitResultsUpdated(ctx, models.ElectionResultsUpdatedEvent{ElectionID: *electionID, CandidateID: &candidate.ID})
+		}
+	}
+
 	return candidate, nil
 }
 
+// emitResultsUpdated tells webhooks an election's results changed
+func (s *ElectionService) emitResultsUpdated(ctx context.Context, event models.ElectionResultsUpdatedEvent) {
+	if s.webhooks == nil {
+		return
+	}
+	_ = s.webhooks.Emit(ctx, models.WebhookEventElectionResultsUpdated, event)
+}
+
 // Voter Education
 
 func (s *ElectionService) CreateVoterEducation(ctx context.Context, req *models.CreateVoterEducationRequest) (*models.VoterEducation, error) {
@@ -256,6 +457,7 @@ func (s *ElectionService) GetVoterEducationBySlug(ctx context.Context, slug stri
 
 	var ve models.VoterEducation
 	if err := s.cache.Get(ctx, cacheKey, &ve); err == nil {
+		s.addPendingVoterEducationViews(ctx, &ve)
 		return &ve, nil
 	}
 
@@ -266,6 +468,7 @@ func (s *ElectionService) GetVoterEducationBySlug(ctx context.Context, slug stri
 
 	if vePtr != nil {
 		_ = s.cache.Set(ctx, cacheKey, vePtr, electionCacheTTL)
+		s.addPendingVoterEducationViews(ctx, vePtr)
 	}
 
 	return vePtr, nil
@@ -275,12 +478,14 @@ func (s *ElectionService) ListVoterEducation(ctx context.Context, electionID *uu
 	return s.repo.ListVoterEducation(ctx, electionID, category, page, perPage)
 }
 
-func (s *ElectionService) IncrementVoterEducationViewCount(ctx context.Context, id uuid.UUID) error {
-	return s.repo.IncrementVoterEducationViewCount(ctx, id)
-}
-
 // Helper methods
 
+// markStale flags the election if its data is out of date. Staleness depends
+// on the time of the request, so it is worked out after the cache.
+func (s *ElectionService) markStale(election *models.Election) {
+	election.IsStale = models.IsElectionStale(election.Status, election.DataAsOf, time.Now(), s.staleAfter)
+}
+
 func (s *ElectionService) invalidateElectionCache(ctx context.Context, id uuid.UUID, slug string) {
 	_ = s.cache.Delete(ctx, electionCachePrefix+"id:"+id.String())
 	_ = s.cache.Delete(ctx, electionCachePrefix+"slug:"+slug)
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+const surveysCachePrefix = "surveys:"
+
+// Election Surveys
+
+func (s *ElectionService) CreateSurvey(ctx context.Context, electionID uuid.UUID, req *models.CreateElectionSurveyRequest) (*models.ElectionSurvey, error) {
+	election, err := s.repo.GetElectionByID(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if election == nil {
+		return nil, fmt.Errorf("election not found")
+	}
+
+	survey := &models.ElectionSurvey{
+		ElectionID:    electionID,
+		PositionID:    req.PositionID,
+		SurveyFirm:    req.SurveyFirm,
+		SampleSize:    req.SampleSize,
+		MarginOfError: req.MarginOfError,
+		SourceURL:     req.SourceURL,
+	}
+	if survey.ConductedStart, err = parseSurveyDate("conducted_start", req.ConductedStart); err != nil {
+		return nil, err
+	}
+	if survey.ConductedEnd, err = parseSurveyDate("conducted_end", req.ConductedEnd); err != nil {
+		return nil, err
+	}
+	if req.PublishedAt != nil {
+		publishedAt, err := parseSurveyDate("published_at", *req.PublishedAt)
+		if err != nil {
+			return nil, err
+		}
+		survey.PublishedAt = &publishedAt
+	}
+
+	if err := s.validateSurvey(ctx, survey, req.Results); err != nil {
+		return nil, err
+	}
+	models.AssignSurveyRanks(req.Results)
+
+	if err := s.repo.CreateSurvey(ctx, survey, req.Results); err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Delete(ctx, surveysCachePrefix+electionID.String())
+
+	return s.repo.GetSurvey(ctx, survey.ID)
+}
+
+// UpdateSurvey changes a survey of the election. It returns nil if the
+// election has no such survey.
+func (s *ElectionService) UpdateSurvey(ctx context.Context, electionID, id uuid.UUID, req *models.UpdateElectionSurveyRequest) (*models.ElectionSurvey, error) {
+	survey, err := s.repo.GetSurvey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if survey == nil || survey.ElectionID != electionID {
+		return nil, nil
+	}
+
+	if req.PositionID != nil {
+		survey.PositionID = *req.PositionID
+	}
+	if req.SurveyFirm != nil {
+		survey.SurveyFirm = *req.SurveyFirm
+	}
+	if req.ConductedStart != nil {
+		if survey.ConductedStart, err = parseSurveyDate("conducted_start", *req.ConductedStart); err != nil {
+			return nil, err
+		}
+	}
+	if req.ConductedEnd != nil {
+		if survey.ConductedEnd, err = parseSurveyDate("conducted_end", *req.ConductedEnd); err != nil {
+			return nil, err
+		}
+	}
+	if req.SampleSize != nil {
+		survey.SampleSize = req.SampleSize
+	}
+	if req.MarginOfError != nil {
+		survey.MarginOfError = req.MarginOfError
+	}
+	if req.PublishedAt != nil {
+		publishedAt, err := parseSurveyDate("published_at", *req.PublishedAt)
+		if err != nil {
+			return nil, err
+		}
+		survey.PublishedAt = &publishedAt
+	}
+	if req.SourceURL != nil {
+		survey.SourceURL = req.SourceURL
+	}
+
+	// Kept results still have to belong to the (possibly new) position
+	results := req.Results
+	if results == nil {
+		for _, res := range survey.Results {
+			results = append(results, models.SurveyResultInput{CandidateID: res.CandidateID, Percentage: res.Percentage, Rank: res.Rank})
+		}
+	}
+	if err := s.validateSurvey(ctx, survey, results); err != nil {
+		return nil, err
+	}
+	if req.Results != nil {
+		models.AssignSurveyRanks(req.Results)
+	}
+
+	if err := s.repo.UpdateSurvey(ctx, survey, req.Results); err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Delete(ctx, surveysCachePrefix+electionID.String())
+
+	return s.repo.GetSurvey(ctx, id)
+}
+
+func (s *ElectionService) DeleteSurvey(ctx context.Context, electionID, id uuid.UUID) error {
+	if err := s.repo.DeleteSurvey(ctx, electionID, id); err != nil {
+		return err
+	}
+
+	_ = s.cache.Delete(ctx, surveysCachePrefix+electionID.String())
+
+	return nil
+}
+
+// ListSurveys returns all of an election's surveys, newest fieldwork first
+func (s *ElectionService) ListSurveys(ctx context.Context, electionID uuid.UUID) ([]models.ElectionSurvey, error) {
+	cacheKey := surveysCachePrefix + electionID.String()
+
+	var surveys []models.ElectionSurvey
+	if err := s.cache.Get(ctx, cacheKey, &surveys); err == nil {
+		return surveys, nil
+	}
+
+	surveys, err := s.repo.ListSurveys(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, surveys, electionCacheTTL)
+
+	return surveys, nil
+}
+
+// GetSurveyTrend returns each candidate's results across the surveys of one
+// of the election's positions
+func (s *ElectionService) GetSurveyTrend(ctx context.Context, electionID, positionID uuid.UUID) (*models.SurveyTrend, error) {
+	positionElectionID, err := s.repo.GetElectionPositionElectionID(ctx, positionID)
+	if err != nil {
+		return nil, err
+	}
+	if positionElectionID == nil || *positionElectionID != electionID {
+		return nil, fmt.Errorf("position is not contested in this election")
+	}
+
+	surveys, err := s.ListSurveys(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var positionSurveys []models.ElectionSurvey
+	for _, survey := range surveys {
+		if survey.PositionID == positionID {
+			positionSurveys = append(positionSurveys, survey)
+		}
+	}
+
+	return models.BuildSurveyTrend(electionID, positionID, positionSurveys), nil
+}
+
+// validateSurvey checks the survey's dates and that its position and every
+// result's candidate belong to its election
+func (s *ElectionService) validateSurvey(ctx context.Context, survey *models.ElectionSurvey, results []models.SurveyResultInput) error {
+	if survey.ConductedEnd.Before(survey.ConductedStart) {
+		return fmt.Errorf("conducted_end cannot be before conducted_start")
+	}
+
+	positionElectionID, err := s.repo.GetElectionPositionElectionID(ctx, survey.PositionID)
+	if err != nil {
+		return err
+	}
+	if positionElectionID == nil || *positionElectionID != survey.ElectionID {
+		return fmt.Errorf("position is not contested in this election")
+	}
+
+	candidateIDs := make([]uuid.UUID, 0, len(results))
+	seen := make(map[uuid.UUID]bool, len(results))
+	for _, res := range results {
+		if seen[res.CandidateID] {
+			return fmt.Errorf("results list a candidate more than once")
+		}
+		seen[res.CandidateID] = true
+		candidateIDs = append(candidateIDs, res.CandidateID)
+	}
+
+	count, err := s.repo.CountPositionCandidates(ctx, survey.PositionID, candidateIDs)
+	if err != nil {
+		return err
+	}
+	if count != len(candidateIDs) {
+		return fmt.Errorf("results include a candidate not running for this position")
+	}
+
+	return nil
+}
+
+func parseSurveyDate(name, value string) (time.Time, error) {
+	t, err := time.Parse(models.SurveyDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date in YYYY-MM-DD format", name)
+	}
+	return t, nil
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/repository"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+const (
+	embedCachePrefix = "embed:"
+	embedCacheTTL    = 5 * time.Minute
+
+	// An embedded results box shows the first few positions on the ballot
+	// and the leading candidates in each; the full results link to the site
+	embedMaxPositions  = 5
+	embedMaxCandidates = 10
+)
+
+// EmbedService serves the read-only poll and election result boxes partner
+// sites embed, and counts their views apart from views on the site itself
+type EmbedService struct {
+	repo            *repository.EmbedRepository
+	pollService     *PollService
+	electionService *ElectionService
+	cache           *cache.RedisCache
+	siteHost        string
+}
+
+func NewEmbedService(repo *repository.EmbedRepository, pollService *PollService, electionService *ElectionService, cache *cache.RedisCache) *EmbedService {
+	return &EmbedService{
+		repo:            repo,
+		pollService:     pollService,
+		electionService: electionService,
+		cache:           cache,
+	}
+}
+
+// SetSiteURL sets the site's own URL, so views from embeds on the site
+// itself are told apart from partner sites
+func (s *EmbedService) SetSiteURL(siteURL string) {
+	if u, err := url.Parse(siteURL); err == nil {
+		s.siteHost = u.Hostname()
+	}
+}
+
+// GetPollEmbed returns the embeddable view of an active or closed poll, or
+// nil if there is none
+func (s *EmbedService) GetPollEmbed(ctx context.Context, slug string) (*models.PollEmbed, error) {
+	cacheKey := embedCachePrefix + "poll:" + slug
+
+	var embed models.PollEmbed
+	if err := s.cache.Get(ctx, cacheKey, &embed); err == nil {
+		return &embed, nil
+	}
+
+	poll, err := s.pollService.GetPollBySlug(ctx, slug, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if poll == nil || (poll.Status != models.PollStatusActive && poll.Status != models.PollStatusClosed) {
+		return nil, nil
+	}
+
+	embed = models.PollEmbed{
+		ID:          poll.ID,
+		Title:       poll.Title,
+		Slug:        poll.Slug,
+		Status:      poll.Status,
+		VotingOpen:  poll.VotingOpen,
+		ShowResults: !poll.VotingOpen || poll.ShowResultsBeforeVote,
+		Options:     make([]models.PollEmbedOption, len(poll.Options)),
+	}
+	if embed.ShowResults {
+		embed.TotalVotes = poll.TotalVotes
+	}
+	for i, option := range poll.Options {
+		embed.Options[i] = models.PollEmbedOption{Text: option.Text}
+		if embed.ShowResults {
+			embed.Options[i].VoteCount = option.VoteCount
+			embed.Options[i].Percentage = option.Percentage
+		}
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, &embed, embedCacheTTL)
+
+	return &embed, nil
+}
+
+// GetElectionResultsEmbed returns the embeddable results of an election,
+// narrowed to one of its positions when positionID is given, or nil if there
+// is no such election or position
+func (s *EmbedService) GetElectionResultsEmbed(ctx context.Context, slug string, positionID *uuid.UUID) (*models.ElectionResultsEmbed, error) {
+	cacheKey := embedCachePrefix + "election:" + slug
+	if positionID != nil {
+		cacheKey += ":" + positionID.String()
+	}
+
+	var embed models.ElectionResultsEmbed
+	if err := s.cache.Get(ctx, cacheKey, &embed); err == nil {
+		return &embed, nil
+	}
+
+	election, err := s.electionService.GetElectionBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if election == nil {
+		return nil, nil
+	}
+
+	positions, err := s.electionService.GetElectionPositions(ctx, election.ID)
+	if err != nil {
+		return nil, err
+	}
+	if positionID != nil {
+		positions = slices.DeleteFunc(positions, func(p models.ElectionPositionListItem) bool {
+			return p.ID != *positionID
+		})
+		if len(positions) == 0 {
+			return nil, nil
+		}
+	}
+	if len(positions) > embedMaxPositions {
+		positions = positions[:embedMaxPositions]
+	}
+
+	embed = models.ElectionResultsEmbed{
+		ID:           election.ID,
+		Name:         election.Name,
+		Slug:         election.Slug,
+		Status:       election.Status,
+		ElectionDate: election.ElectionDate,
+		DataAsOf:     election.DataAsOf,
+		Positions:    make([]models.ElectionResultsEmbedPosition, 0, len(positions)),
+	}
+	for _, position := range positions {
+		candidates, err := s.electionService.GetCandidatesForPosition(ctx, position.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		item := models.ElectionResultsEmbedPosition{
+			ID:         position.ID,
+			Location:   position.Location,
+			Candidates: embedCandidates(candidates),
+		}
+		if position.Position != nil {
+			item.Name = position.Position.Name
+		}
+		embed.Positions = append(embed.Positions, item)
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, &embed, embedCacheTTL)
+
+	return &embed, nil
+}
+
+// embedCandidates orders candidates by votes received, those without a tally
+// last in ballot order, and keeps the leaders
+func embedCandidates(candidates []models.CandidateListItem) []models.ElectionResultsEmbedCandidate {
+	sorted := slices.Clone(candidates)
+	slices.SortStableFunc(sorted, func(a, b models.CandidateListItem) int {
+		switch {
+		case a.VotesReceived == nil && b.VotesReceived == nil:
+			return cmp.Compare(derefInt(a.BallotNumber), derefInt(b.BallotNumber))
+		case a.VotesReceived == nil:
+			return 1
+		case b.VotesReceived == nil:
+			return -1
+		}
+		return cmp.Compare(*b.VotesReceived, *a.VotesReceived)
+	})
+	if len(sorted) > embedMaxCandidates {
+		sorted = sorted[:embedMaxCandidates]
+	}
+
+	result := make([]models.ElectionResultsEmbedCandidate, len(sorted))
+	for i, c := range sorted {
+		result[i] = models.ElectionResultsEmbedCandidate{
+			VotesReceived:  c.VotesReceived,
+			VotePercentage: c.VotePercentage,
+			IsWinner:       c.IsWinner,
+		}
+		switch {
+		case c.BallotName != nil && *c.BallotName != "":
+			result[i].Name = *c.BallotName
+		case c.Politician != nil:
+			result[i].Name = c.Politician.Name
+		}
+		if c.Party != nil {
+			party := c.Party.Name
+			if c.Party.Abbreviation != nil && *c.Party.Abbreviation != "" {
+				party = *c.Party.Abbreviation
+			}
+			result[i].Party = &party
+		}
+	}
+	return result
+}
+
+func derefInt(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
+
+// RecordView counts a view of an embedded item, attributed to the domain of
+// the page embedding it. Views accumulate in Redis until FlushViewCounts
+// writes them to the database.
+func (s *EmbedService) RecordView(ctx context.Context, contentType string, contentID uuid.UUID, referer string) error {
+	views := models.EmbedViews{
+		Type:      contentType,
+		ContentID: contentID,
+		Date:      time.Now().Format("2006-01-02"),
+		Domain:    models.ClassifyReferrer(referer, s.siteHost).Domain,
+		Views:     1,
+	}
+
+	if err := s.cache.Counter(cache.CounterEmbedViews).Add(ctx, embedCounterField(views), 1); err != nil {
+		// Without Redis, count the view directly
+		return s.repo.AddViewCounts(ctx, []models.EmbedViews{views})
+	}
+	return nil
+}
+
+// FlushViewCounts writes the accumulated embed views to the database
+func (s *EmbedService) FlushViewCounts(ctx context.Context) error {
+	return s.cache.Counter(cache.CounterEmbedViews).Flush(ctx, func(ctx context.Context, deltas map[string]int64) error {
+		views := make([]models.EmbedViews, 0, len(deltas))
+		for field, delta := range deltas {
+			if v, ok := parseEmbedCounterField(field); ok && delta > 0 {
+				v.Views = delta
+				views = append(views, v)
+			}
+		}
+		return s.repo.AddViewCounts(ctx, views)
+	})
+}
+
+// GetReach lists the most viewed embedded items over the last days
+func (s *EmbedService) GetReach(ctx context.Context, days, limit int) ([]models.EmbedReach, error) {
+	since := time.Now().AddDate(0, 0, -(days - 1))
+	return s.repo.GetReach(ctx, since, limit)
+}
+
+// embedCounterField identifies views of one embedded item from one domain on
+// one day, in the same "|"-separated form as the article referrer counters
+func embedCounterField(v models.EmbedViews) string {
+	return strings.Join([]string{v.Type, v.ContentID.String(), v.Date, v.Domain}, "|")
+}
+
+func parseEmbedCounterField(field string) (models.EmbedViews, bool) {
+	parts := strings.SplitN(field, "|", 4)
+	if len(parts) != 4 || !slices.Contains(models.EmbedTypes, parts[0]) {
+		return models.EmbedViews{}, false
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return models.EmbedViews{}, false
+	}
+	if _, err := time.Parse("2006-01-02", parts[2]); err != nil {
+		return models.EmbedViews{}, false
+	}
+
+	return models.EmbedViews{Type: parts[0], ContentID: id, Date: parts[2], Domain: parts[3]}, true
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedCounterField(t *testing.T) {
+	views := models.EmbedViews{Type: models.EmbedTypePoll, ContentID: uuid.New(), Date: "2026-05-12", Domain: "news.example"}
+
+	parsed, ok := parseEmbedCounterField(embedCounterField(views))
+	assert.True(t, ok)
+	assert.Equal(t, views, parsed)
+
+	noReferrer := views
+	noReferrer.Domain = ""
+	parsed, ok = parseEmbedCounterField(embedCounterField(noReferrer))
+	assert.True(t, ok)
+	assert.Equal(t, "", parsed.Domain)
+
+	for _, field := range []string{
+		"",
+		"article|" + views.ContentID.String() + "|2026-05-12|news.example",
+		"poll|not-a-uuid|2026-05-12|news.example",
+		"poll|" + views.ContentID.String() + "|yesterday|news.example",
+		"poll|" + views.ContentID.String() + "|2026-05-12",
+	} {
+		_, ok := parseEmbedCounterField(field)
+		assert.False(t, ok, field)
+	}
+}
+
+func TestEmbedCandidates(t *testing.T) {
+	votes := func(n int) *int { return &n }
+	ballot := func(n int) *int { return &n }
+	abbreviation := "PFP"
+
+	candidates := []models.CandidateListItem{
+		{BallotNumber: ballot(3), Politician: &models.PoliticianListItem{Name: "No Tally B"}},
+		{BallotNumber: ballot(1), VotesReceived: votes(1200), Politician: &models.PoliticianListItem{Name: "Runner Up"}},
+		{BallotNumber: ballot(2), Politician: &models.PoliticianListItem{Name: "No Tally A"}},
+		{
+			BallotNumber:  ballot(4),
+			BallotName:    &[]string{"LEADER, Juan"}[0],
+			VotesReceived: votes(5400),
+			IsWinner:      true,
+			Politician:    &models.PoliticianListItem{Name: "Juan Leader"},
+			Party:         &models.PartyBrief{Name: "Partido Federal ng Pilipinas", Abbreviation: &abbreviation},
+		},
+	}
+
+	got := embedCandidates(candidates)
+
+	names := make([]string, len(got))
+	for i, c := range got {
+		names[i] = c.Name
+	}
+	assert.Equal(t, []string{"LEADER, Juan", "Runner Up", "No Tally A", "No Tally B"}, names)
+	assert.True(t, got[0].IsWinner)
+	assert.Equal(t, "PFP", *got[0].Party)
+	assert.Nil(t, got[1].Party)
+}
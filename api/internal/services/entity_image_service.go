@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+	"github.com/humfurie/pulpulitiko/api/pkg/imaging"
+)
+
+// politicianImagePrefix is the storage folder holding a politician's photos
+func politicianImagePrefix(id uuid.UUID) string {
+	return "politicians/" + id.String()
+}
+
+// partyImagePrefix is the storage folder holding a party's logos
+func partyImagePrefix(id uuid.UUID) string {
+	return "parties/" + id.String()
+}
+
+// SetPhoto uploads a new photo for the politician and replaces the previous one
+func (s *PoliticianService) SetPhoto(ctx context.Context, id uuid.UUID, file io.Reader, header *multipart.FileHeader, focal *models.FocalPoint, changedBy *uuid.UUID) (*models.EntityImage, error) {
+	if s.uploads == nil {
+		return nil, fmt.Errorf("image uploads are not configured")
+	}
+
+	politician, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if politician == nil {
+		return nil, fmt.Errorf("politician not found")
+	}
+
+	img, err := s.uploads.UploadEntityImage(ctx, politicianImagePrefix(id), file, header, focal)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.replacePhoto(ctx, politician, img, changedBy); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// RemovePhoto replaces the politician's photo with a generated initials placeholder
+func (s *PoliticianService) RemovePhoto(ctx context.Context, id uuid.UUID, changedBy *uuid.UUID) (*models.EntityImage, error) {
+	if s.uploads == nil {
+		return nil, fmt.Errorf("image uploads are not configured")
+	}
+
+	politician, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if politician == nil {
+		return nil, fmt.Errorf("politician not found")
+	}
+
+	img, err := s.uploads.UploadInitialsPlaceholder(ctx, politicianImagePrefix(id), imaging.Initials(politician.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.replacePhoto(ctx, politician, img, changedBy); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// replacePhoto points the politician at the new image set, then deletes the
+// previous set. If the row can't be updated the new set is deleted instead.
+func (s *PoliticianService) replacePhoto(ctx context.Context, politician *models.Politician, img *models.EntityImage, changedBy *uuid.UUID) error {
+	if err := s.repo.SetPhoto(ctx, politician.ID, img, changedBy); err != nil {
+		s.uploads.DeleteEntityImage(ctx, politicianImagePrefix(politician.ID), img.Original, img.Thumb, img.List)
+		return err
+	}
+
+	s.uploads.DeleteEntityImage(ctx, politicianImagePrefix(politician.ID), derefString(politician.Photo), derefString(politician.PhotoThumb), derefString(politician.PhotoList))
+
+	s.invalidatePoliticianCache(ctx, politician.ID)
+	_ = s.cache.Delete(ctx, cache.PoliticianSlugKey(politician.Slug))
+
+	return nil
+}
+
+// SetLogo uploads a new logo for the party and replaces the previous one
+func (s *PoliticalPartyService) SetLogo(ctx context.Context, id uuid.UUID, file io.Reader, header *multipart.FileHeader, focal *models.FocalPoint) (*models.EntityImage, error) {
+	if s.uploads == nil {
+		return nil, fmt.Errorf("image uploads are not configured")
+	}
+
+	party, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if party == nil {
+		return nil, fmt.Errorf("party not found")
+	}
+
+	img, err := s.uploads.UploadEntityImage(ctx, partyImagePrefix(id), file, header, focal)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.replaceLogo(ctx, party, img); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// RemoveLogo replaces the party's logo with a generated placeholder
+func (s *PoliticalPartyService) RemoveLogo(ctx context.Context, id uuid.UUID) (*models.EntityImage, error) {
+	if s.uploads == nil {
+		return nil, fmt.Errorf("image uploads are not configured")
+	}
+
+	party, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if party == nil {
+		return nil, fmt.Errorf("party not found")
+	}
+
+	img, err := s.uploads.UploadInitialsPlaceholder(ctx, partyImagePrefix(id), partyPlaceholderLabel(party))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.replaceLogo(ctx, party, img); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// partyPlaceholderLabel is the party's abbreviation when it is short enough to
+// fit the placeholder, or the initials of its name otherwise
+func partyPlaceholderLabel(party *models.PoliticalParty) string {
+	if party.Abbreviation != nil {
+		abbr := strings.TrimSpace(*party.Abbreviation)
+		if n := utf8.RuneCountInString(abbr); n > 0 && n <= 4 {
+			return strings.ToUpper(abbr)
+		}
+	}
+	return imaging.Initials(party.Name)
+}
+
+// replaceLogo points the party at the new image set, then deletes the
+// previous set. If the row can't be updated the new set is deleted instead.
+func (s *PoliticalPartyService) replaceLogo(ctx context.Context, party *models.PoliticalParty, img *models.EntityImage) error {
+	if err := s.repo.SetLogo(ctx, party.ID, img); err != nil {
+		s.uploads.DeleteEntityImage(ctx, partyImagePrefix(party.ID), img.Original, img.Thumb, img.List)
+		return err
+	}
+
+	s.uploads.DeleteEntityImage(ctx, partyImagePrefix(party.ID), derefString(party.Logo), derefString(party.LogoThumb), derefString(party.LogoList))
+
+	_ = s.cache.DeletePattern(ctx, "party:*")
+	_ = s.cache.DeletePattern(ctx, "parties:*")
+
+	return nil
+}
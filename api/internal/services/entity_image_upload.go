@@ -0,0 +1,168 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/imaging"
+	"github.com/humfurie/pulpulitiko/api/pkg/storage"
+)
+
+// Sizes of the variants stored alongside each politician photo and party logo
+const (
+	entityThumbSize = 256
+	entityListWidth = 640
+)
+
+// entityImageTypes are the formats accepted for photos and logos, keyed by
+// the type sniffed from the file contents
+var entityImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type imageVariant struct {
+	name        string
+	data        []byte
+	contentType string
+	ext         string
+}
+
+// buildImageVariants validates an uploaded image and renders the set stored
+// for it: the original bytes, a square thumbnail cropped around the focal
+// point, and a list-size copy
+func buildImageVariants(data []byte, focal *models.FocalPoint) ([]imageVariant, error) {
+	contentType := http.DetectContentType(data)
+	ext, ok := entityImageTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("file type not allowed. Allowed types: JPEG, PNG, GIF, WebP")
+	}
+
+	img, _, err := imaging.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	focalX, focalY := 50.0, 50.0
+	if focal != nil {
+		focalX, focalY = focal.X, focal.Y
+	}
+
+	thumb, err := encodeVariant("thumb", imaging.CropSquare(img, entityThumbSize, focalX, focalY))
+	if err != nil {
+		return nil, err
+	}
+	list, err := encodeVariant("list", imaging.FitWidth(img, entityListWidth))
+	if err != nil {
+		return nil, err
+	}
+
+	return []imageVariant{
+		{name: "original", data: data, contentType: contentType, ext: ext},
+		*thumb,
+		*list,
+	}, nil
+}
+
+func encodeVariant(name string, img image.Image) (*imageVariant, error) {
+	var buf bytes.Buffer
+	contentType, ext, err := imaging.Encode(&buf, img)
+	if err != nil {
+		return nil, err
+	}
+	return &imageVariant{name: name, data: buf.Bytes(), contentType: contentType, ext: ext}, nil
+}
+
+// readImageUpload reads an uploaded image whole, enforcing the size limit
+// whatever the form header claims
+func readImageUpload(file io.Reader, header *multipart.FileHeader) ([]byte, error) {
+	if header.Size > storage.GetMaxFileSize() {
+		return nil, fmt.Errorf("file size exceeds maximum allowed size of 10MB")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, storage.GetMaxFileSize()+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if int64(len(data)) > storage.GetMaxFileSize() {
+		return nil, fmt.Errorf("file size exceeds maximum allowed size of 10MB")
+	}
+	return data, nil
+}
+
+// UploadEntityImage validates a politician photo or party logo and stores it
+// with its variants under prefix. The set shares a fresh folder so a later
+// replacement never overwrites URLs that may still be cached.
+func (s *UploadService) UploadEntityImage(ctx context.Context, prefix string, file io.Reader, header *multipart.FileHeader, focal *models.FocalPoint) (*models.EntityImage, error) {
+	data, err := readImageUpload(file, header)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := buildImageVariants(data, focal)
+	if err != nil {
+		return nil, err
+	}
+
+	folder := fmt.Sprintf("%s/%s", prefix, uuid.New().String())
+	urls := make(map[string]string, len(variants))
+	for _, v := range variants {
+		key := fmt.Sprintf("%s/%s%s", folder, v.name, v.ext)
+		result, err := s.storage.UploadWithKey(ctx, bytes.NewReader(v.data), key, v.contentType, int64(len(v.data)))
+		if err != nil {
+			// Don't leave a partial set behind
+			for _, uploaded := range urls {
+				s.DeleteEntityImage(ctx, prefix, uploaded)
+			}
+			return nil, fmt.Errorf("failed to upload file: %w", err)
+		}
+		urls[v.name] = result.URL
+	}
+
+	return &models.EntityImage{
+		Original:   urls["original"],
+		Thumb:      urls["thumb"],
+		List:       urls["list"],
+		FocalPoint: focal,
+	}, nil
+}
+
+// UploadInitialsPlaceholder stores a generated image showing label (initials
+// or an abbreviation) under prefix, used in place of a removed photo or logo
+func (s *UploadService) UploadInitialsPlaceholder(ctx context.Context, prefix, label string) (*models.EntityImage, error) {
+	svg := imaging.InitialsSVG(label)
+	key := fmt.Sprintf("%s/%s/initials.svg", prefix, uuid.New().String())
+
+	result, err := s.storage.UploadWithKey(ctx, bytes.NewReader(svg), key, "image/svg+xml", int64(len(svg)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload placeholder: %w", err)
+	}
+
+	return &models.EntityImage{Original: result.URL, Thumb: result.URL, List: result.URL}, nil
+}
+
+// DeleteEntityImage removes the stored objects behind the given URLs. Only
+// keys under prefix are deleted, so a URL shared with other content (e.g. one
+// set through the generic upload endpoint) is left alone. Failures are ignored
+// since an orphaned object is harmless.
+func (s *UploadService) DeleteEntityImage(ctx context.Context, prefix string, urls ...string) {
+	seen := make(map[string]bool, len(urls))
+	for _, u := range urls {
+		key := s.storage.KeyFromURL(u)
+		if !strings.HasPrefix(key, prefix+"/") || seen[key] {
+			continue
+		}
+		seen[key] = true
+		_ = s.storage.Delete(ctx, key)
+	}
+}
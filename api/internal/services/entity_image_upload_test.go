@@ -0,0 +1,60 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildImageVariants(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 1200, 800))
+	for y := 0; y < 800; y++ {
+		for x := 0; x < 1200; x++ {
+			src.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 80, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+
+	t.Run("renders original, thumb and list", func(t *testing.T) {
+		variants, err := buildImageVariants(buf.Bytes(), &models.FocalPoint{X: 25, Y: 40})
+		require.NoError(t, err)
+		require.Len(t, variants, 3)
+
+		original, thumb, list := variants[0], variants[1], variants[2]
+		assert.Equal(t, "image/png", original.contentType)
+		assert.Equal(t, buf.Bytes(), original.data)
+
+		// Opaque PNGs are re-encoded as JPEG for the smaller variants
+		assert.Equal(t, ".jpg", thumb.ext)
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(thumb.data))
+		require.NoError(t, err)
+		assert.Equal(t, entityThumbSize, cfg.Width)
+		assert.Equal(t, entityThumbSize, cfg.Height)
+
+		cfg, _, err = image.DecodeConfig(bytes.NewReader(list.data))
+		require.NoError(t, err)
+		assert.Equal(t, entityListWidth, cfg.Width)
+		assert.Equal(t, 800*entityListWidth/1200, cfg.Height)
+	})
+
+	t.Run("rejects files that are not images", func(t *testing.T) {
+		_, err := buildImageVariants([]byte("%PDF-1.4"), nil)
+		assert.EqualError(t, err, "file type not allowed. Allowed types: JPEG, PNG, GIF, WebP")
+	})
+}
+
+func TestPartyPlaceholderLabel(t *testing.T) {
+	abbr := func(s string) *string { return &s }
+
+	assert.Equal(t, "LP", partyPlaceholderLabel(&models.PoliticalParty{Name: "Liberal Party", Abbreviation: abbr("lp")}))
+	assert.Equal(t, "NP", partyPlaceholderLabel(&models.PoliticalParty{Name: "Nacionalista Party", Abbreviation: abbr("  ")}))
+	assert.Equal(t, "PL", partyPlaceholderLabel(&models.PoliticalParty{Name: "Partido ng Lakas", Abbreviation: abbr("PARTIDO")}))
+	assert.Equal(t, "AP", partyPlaceholderLabel(&models.PoliticalParty{Name: "Aksyon Party"}))
+}
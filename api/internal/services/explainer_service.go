@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/repository"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+	"github.com/humfurie/pulpulitiko/api/pkg/sanitize"
+)
+
+type ExplainerService struct {
+	repo  *repository.ExplainerRepository
+	cache *cache.RedisCache
+}
+
+func NewExplainerService(repo *repository.ExplainerRepository, cache *cache.RedisCache) *ExplainerService {
+	return &ExplainerService{repo: repo, cache: cache}
+}
+
+func (s *ExplainerService) Create(ctx context.Context, req *models.CreateExplainerRequest) (*models.Explainer, error) {
+	if err := s.checkSlug(ctx, req.Slug, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	explainer := &models.Explainer{
+		Title: req.Title,
+		Slug:  req.Slug,
+		Body:  sanitize.SanitizeArticleHTML(req.Body),
+	}
+	if err := s.repo.Create(ctx, explainer); err != nil {
+		return nil, err
+	}
+
+	// Articles may already carry the token
+	s.invalidateEmbeddingArticles(ctx, explainer.Slug)
+
+	return explainer, nil
+}
+
+func (s *ExplainerService) GetByID(ctx context.Context, id uuid.UUID) (*models.Explainer, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ExplainerService) GetBySlug(ctx context.Context, slug string) (*models.Explainer, error) {
+	return s.repo.GetBySlug(ctx, slug)
+}
+
+// List returns every explainer, with how many articles embed each
+func (s *ExplainerService) List(ctx context.Context, search string) ([]models.Explainer, error) {
+	return s.repo.List(ctx, search)
+}
+
+// Update edits an explainer and drops the cached articles embedding it. A
+// new slug leaves tokens with the old one unresolved until they are edited.
+func (s *ExplainerService) Update(ctx context.Context, id uuid.UUID, req *models.UpdateExplainerRequest) (*models.Explainer, error) {
+	before, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return nil, fmt.Errorf("explainer not found")
+	}
+
+	if req.Slug != nil && *req.Slug != before.Slug {
+		if err := s.checkSlug(ctx, *req.Slug, id); err != nil {
+			return nil, err
+		}
+	}
+	if req.Body != nil {
+		body := sanitize.SanitizeArticleHTML(*req.Body)
+		req.Body = &body
+	}
+
+	if err := s.repo.Update(ctx, id, req); err != nil {
+		return nil, err
+	}
+
+	after, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateEmbeddingArticles(ctx, before.Slug, after.Slug)
+
+	return after, nil
+}
+
+func (s *ExplainerService) Delete(ctx context.Context, id uuid.UUID) error {
+	explainer, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if explainer == nil {
+		return fmt.Errorf("explainer not found")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateEmbeddingArticles(ctx, explainer.Slug)
+
+	return nil
+}
+
+// checkSlug rejects a slug that can't be written in a token or that another
+// explainer has
+func (s *ExplainerService) checkSlug(ctx context.Context, slug string, id uuid.UUID) error {
+	if !models.ValidExplainerSlug(slug) {
+		return fmt.Errorf("slug must be lowercase letters and numbers separated by hyphens")
+	}
+
+	existing, err := s.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != id {
+		return fmt.Errorf("explainer slug already exists")
+	}
+	return nil
+}
+
+// invalidateEmbeddingArticles drops the cached copies of every article
+// embedding one of the slugs, which carry the explainer's old content
+func (s *ExplainerService) invalidateEmbeddingArticles(ctx context.Context, slugs ...string) {
+	articles, err := s.repo.ListEmbeddingArticles(ctx, slugs)
+	if err != nil || len(articles) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, 2*len(articles))
+	for _, a := range articles {
+		keys = append(keys, cache.ArticleKey(a.ID.String()), cache.ArticleSlugKey(a.Slug))
+	}
+	_ = s.cache.Delete(ctx, keys...)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// ListFloorDeliberations returns a bill's deliberations without transcript text
+func (s *BillService) ListFloorDeliberations(ctx context.Context, billID uuid.UUID) ([]models.FloorDeliberation, error) {
+	return s.repo.ListFloorDeliberations(ctx, billID)
+}
+
+// GetFloorDeliberation returns a deliberation with its transcript, only if it
+// belongs to the given bill
+func (s *BillService) GetFloorDeliberation(ctx context.Context, billID, id uuid.UUID) (*models.FloorDeliberation, error) {
+	deliberation, err := s.repo.GetFloorDeliberation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if deliberation == nil || deliberation.BillID != billID {
+		return nil, nil
+	}
+	return deliberation, nil
+}
+
+func (s *BillService) CreateFloorDeliberation(ctx context.Context, billID uuid.UUID, req *models.CreateFloorDeliberationRequest) (*models.FloorDeliberation, error) {
+	if !hasTranscript(req.TranscriptText, req.TranscriptURL) {
+		return nil, fmt.Errorf("transcript_text or transcript_url is required")
+	}
+
+	id, err := s.repo.CreateFloorDeliberation(ctx, billID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bill lists report whether a bill has transcripts
+	_ = s.cache.DeletePattern(ctx, billsCachePrefix+"*")
+
+	return s.repo.GetFloorDeliberation(ctx, id)
+}
+
+func (s *BillService) UpdateFloorDeliberation(ctx context.Context, deliberation *models.FloorDeliberation, req *models.UpdateFloorDeliberationRequest) (*models.FloorDeliberation, error) {
+	// Check the merged result so a partial update can't clear both transcript fields
+	text := deliberation.TranscriptText
+	if req.TranscriptText != nil {
+		text = req.TranscriptText
+	}
+	url := deliberation.TranscriptURL
+	if req.TranscriptURL != nil {
+		url = req.TranscriptURL
+	}
+	if !hasTranscript(text, url) {
+		return nil, fmt.Errorf("transcript_text or transcript_url is required")
+	}
+
+	if err := s.repo.UpdateFloorDeliberation(ctx, deliberation.ID, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetFloorDeliberation(ctx, deliberation.ID)
+}
+
+func (s *BillService) DeleteFloorDeliberation(ctx context.Context, deliberation *models.FloorDeliberation) error {
+	if err := s.repo.DeleteFloorDeliberation(ctx, deliberation.ID); err != nil {
+		return err
+	}
+
+	_ = s.cache.DeletePattern(ctx, billsCachePrefix+"*")
+
+	return nil
+}
+
+// SearchFloorDeliberations searches within a bill's transcripts
+func (s *BillService) SearchFloorDeliberations(ctx context.Context, billID uuid.UUID, q string) ([]models.DeliberationSearchResult, error) {
+	return s.repo.SearchFloorDeliberations(ctx, billID, q)
+}
+
+func hasTranscript(text, url *string) bool {
+	return (text != nil && strings.TrimSpace(*text) != "") || (url != nil && *url != "")
+}
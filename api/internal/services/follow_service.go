@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/repository"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+// feedCacheTTL also bounds how long a follow made through the tag or category
+// endpoints takes to show up in the feed
+const feedCacheTTL = 2 * time.Minute
+
+type FollowService struct {
+	repo        *repository.FollowRepository
+	articleRepo *repository.ArticleRepository
+	cache       *cache.RedisCache
+}
+
+func NewFollowService(repo *repository.FollowRepository, articleRepo *repository.ArticleRepository, cache *cache.RedisCache) *FollowService {
+	return &FollowService{repo: repo, articleRepo: articleRepo, cache: cache}
+}
+
+func (s *FollowService) Follow(ctx context.Context, userID uuid.UUID, req *models.FollowRequest) (*models.FollowStatus, error) {
+	exists, err := s.repo.EntityExists(ctx, req.EntityType, req.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s not found", req.EntityType)
+	}
+
+	if err := s.repo.Follow(ctx, userID, req.EntityType, req.EntityID); err != nil {
+		return nil, err
+	}
+	s.invalidateFeed(ctx, userID)
+
+	return s.repo.GetFollowStatus(ctx, req.EntityType, req.EntityID, userID)
+}
+
+// Unfollow removes a follow. Unfollowing something not followed, or since
+// deleted, is a no-op.
+func (s *FollowService) Unfollow(ctx context.Context, userID uuid.UUID, req *models.FollowRequest) (*models.FollowStatus, error) {
+	if err := s.repo.Unfollow(ctx, userID, req.EntityType, req.EntityID); err != nil {
+		return nil, err
+	}
+	s.invalidateFeed(ctx, userID)
+
+	return s.repo.GetFollowStatus(ctx, req.EntityType, req.EntityID, userID)
+}
+
+// GetFeed lists published articles matching anything the user follows,
+// newest first
+func (s *FollowService) GetFeed(ctx context.Context, userID uuid.UUID, page, perPage int) (*models.PaginatedArticles, error) {
+	key := cache.FeedKey(userID.String(), page, perPage)
+
+	var feed models.PaginatedArticles
+	if s.cache != nil {
+		if err := s.cache.Get(ctx, key, &feed); err == nil {
+			return &feed, nil
+		}
+	}
+
+	result, err := s.articleRepo.GetFeed(ctx, userID, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cache != nil {
+		_ = s.cache.Set(ctx, key, result, feedCacheTTL)
+	}
+
+	return result, nil
+}
+
+func (s *FollowService) invalidateFeed(ctx context.Context, userID uuid.UUID) {
+	if s.cache != nil {
+		_ = s.cache.DeletePattern(ctx, cache.KeyPrefixFeed+userID.String()+":*")
+	}
+}
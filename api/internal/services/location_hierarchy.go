@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// LocationLookup reads the locations a record can be attached to;
+// *repository.LocationRepository satisfies it
+type LocationLookup interface {
+	GetRegionByID(ctx context.Context, id uuid.UUID) (*models.Region, error)
+	GetProvinceByID(ctx context.Context, id uuid.UUID) (*models.Province, error)
+	GetCityMunicipalityByID(ctx context.Context, id uuid.UUID) (*models.CityMunicipality, error)
+	GetBarangayByID(ctx context.Context, id uuid.UUID) (*models.Barangay, error)
+	GetDistrictByID(ctx context.Context, id uuid.UUID) (*models.CongressionalDistrict, error)
+}
+
+// locationRefs are the locations a jurisdiction or election position is
+// attached to. Any of them may be unset.
+type locationRefs struct {
+	regionID, provinceID, cityID, barangayID, districtID *uuid.UUID
+}
+
+// validateLocationHierarchy checks that every given location exists and sits
+// under each given location above it. The chain is walked up from the most
+// specific location, so a barangay is checked against a given province even
+// when no city is given.
+func validateLocationHierarchy(ctx context.Context, locations LocationLookup, refs locationRefs) error {
+	cityID, cityFrom := refs.cityID, "city/municipality"
+	if refs.barangayID != nil {
+		barangay, err := locations.GetBarangayByID(ctx, *refs.barangayID)
+		if err != nil {
+			return err
+		}
+		if barangay == nil {
+			return locationNotFound("barangay")
+		}
+		if cityID != nil && *cityID != barangay.CityMunicipalityID {
+			return locationOutside("barangay", "city/municipality")
+		}
+		cityID, cityFrom = &barangay.CityMunicipalityID, "barangay"
+	}
+
+	provinceID, provinceFrom := refs.provinceID, "province"
+	if cityID != nil {
+		city, err := locations.GetCityMunicipalityByID(ctx, *cityID)
+		if err != nil {
+			return err
+		}
+		if city == nil {
+			return locationNotFound("city/municipality")
+		}
+		if provinceID != nil && *provinceID != city.ProvinceID {
+			return locationOutside(cityFrom, "province")
+		}
+		provinceID, provinceFrom = &city.ProvinceID, cityFrom
+	}
+
+	if provinceID != nil {
+		province, err := locations.GetProvinceByID(ctx, *provinceID)
+		if err != nil {
+			return err
+		}
+		if province == nil {
+			return locationNotFound("province")
+		}
+		if refs.regionID != nil && *refs.regionID != province.RegionID {
+			return locationOutside(provinceFrom, "region")
+		}
+	} else if refs.regionID != nil {
+		region, err := locations.GetRegionByID(ctx, *refs.regionID)
+		if err != nil {
+			return err
+		}
+		if region == nil {
+			return locationNotFound("region")
+		}
+	}
+
+	if refs.districtID != nil {
+		district, err := locations.GetDistrictByID(ctx, *refs.districtID)
+		if err != nil {
+			return err
+		}
+		if district == nil {
+			return locationNotFound("district")
+		}
+		// Lone districts of highly urbanized cities belong to the city instead
+		// of a province
+		if district.ProvinceID != nil && provinceID != nil && *district.ProvinceID != *provinceID {
+			return locationOutside(provinceFrom, "district")
+		}
+		if district.CityMunicipalityID != nil && cityID != nil && *district.CityMunicipalityID != *cityID {
+			return locationOutside(cityFrom, "district")
+		}
+	}
+
+	return nil
+}
+
+func locationNotFound(level string) error {
+	return &models.LocationHierarchyError{Message: level + " not found"}
+}
+
+func locationOutside(child, parent string) error {
+	return &models.LocationHierarchyError{Message: fmt.Sprintf("%s does not belong to the given %s", child, parent)}
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLocations is a small region > province > city > barangay tree with a
+// provincial district and a city's lone district
+type fakeLocations struct {
+	regions   map[uuid.UUID]*models.Region
+	provinces map[uuid.UUID]*models.Province
+	cities    map[uuid.UUID]*models.CityMunicipality
+	barangays map[uuid.UUID]*models.Barangay
+	districts map[uuid.UUID]*models.CongressionalDistrict
+}
+
+func (f *fakeLocations) GetRegionByID(_ context.Context, id uuid.UUID) (*models.Region, error) {
+	return f.regions[id], nil
+}
+
+func (f *fakeLocations) GetProvinceByID(_ context.Context, id uuid.UUID) (*models.Province, error) {
+	return f.provinces[id], nil
+}
+
+func (f *fakeLocations) GetCityMunicipalityByID(_ context.Context, id uuid.UUID) (*models.CityMunicipality, error) {
+	return f.cities[id], nil
+}
+
+func (f *fakeLocations) GetBarangayByID(_ context.Context, id uuid.UUID) (*models.Barangay, error) {
+	return f.barangays[id], nil
+}
+
+func (f *fakeLocations) GetDistrictByID(_ context.Context, id uuid.UUID) (*models.CongressionalDistrict, error) {
+	return f.districts[id], nil
+}
+
+func TestValidateLocationHierarchy(t *testing.T) {
+	ctx := context.Background()
+
+	region, otherRegion := uuid.New(), uuid.New()
+	province, otherProvince := uuid.New(), uuid.New()
+	city, otherCity, huc := uuid.New(), uuid.New(), uuid.New()
+	barangay := uuid.New()
+	district, lone := uuid.New(), uuid.New()
+
+	locations := &fakeLocations{
+		regions: map[uuid.UUID]*models.Region{
+			region:      {ID: region},
+			otherRegion: {ID: otherRegion},
+		},
+		provinces: map[uuid.UUID]*models.Province{
+			province:      {ID: province, RegionID: region},
+			otherProvince: {ID: otherProvince, RegionID: otherRegion},
+		},
+		cities: map[uuid.UUID]*models.CityMunicipality{
+			city:      {ID: city, ProvinceID: province},
+			otherCity: {ID: otherCity, ProvinceID: otherProvince},
+			huc:       {ID: huc, ProvinceID: province},
+		},
+		barangays: map[uuid.UUID]*models.Barangay{
+			barangay: {ID: barangay, CityMunicipalityID: city},
+		},
+		districts: map[uuid.UUID]*models.CongressionalDistrict{
+			district: {ID: district, ProvinceID: &province},
+			lone:     {ID: lone, CityMunicipalityID: &huc},
+		},
+	}
+
+	consistent := map[string]locationRefs{
+		"national":                    {},
+		"region only":                 {regionID: &region},
+		"full chain":                  {regionID: &region, provinceID: &province, cityID: &city, barangayID: &barangay},
+		"barangay under region":       {regionID: &region, barangayID: &barangay},
+		"city under province":         {provinceID: &province, cityID: &city},
+		"provincial district":         {provinceID: &province, districtID: &district},
+		"district of city's province": {cityID: &city, districtID: &district},
+		"lone district of its city":   {cityID: &huc, districtID: &lone},
+	}
+	for name, refs := range consistent {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, validateLocationHierarchy(ctx, locations, refs))
+		})
+	}
+
+	missing := uuid.New()
+	inconsistent := map[string]struct {
+		refs    locationRefs
+		message string
+	}{
+		"barangay in another city":      {locationRefs{cityID: &otherCity, barangayID: &barangay}, "barangay does not belong to the given city/municipality"},
+		"barangay in another province":  {locationRefs{provinceID: &otherProvince, barangayID: &barangay}, "barangay does not belong to the given province"},
+		"city in another province":      {locationRefs{provinceID: &otherProvince, cityID: &city}, "city/municipality does not belong to the given province"},
+		"city in another region":        {locationRefs{regionID: &otherRegion, cityID: &city}, "city/municipality does not belong to the given region"},
+		"province in another region":    {locationRefs{regionID: &otherRegion, provinceID: &province}, "province does not belong to the given region"},
+		"district of another province":  {locationRefs{provinceID: &otherProvince, districtID: &district}, "province does not belong to the given district"},
+		"lone district of another city": {locationRefs{cityID: &city, districtID: &lone}, "city/municipality does not belong to the given district"},
+		"unknown region":                {locationRefs{regionID: &missing}, "region not found"},
+		"unknown barangay":              {locationRefs{barangayID: &missing}, "barangay not found"},
+		"unknown district":              {locationRefs{districtID: &missing}, "district not found"},
+	}
+	for name, tc := range inconsistent {
+		t.Run(name, func(t *testing.T) {
+			err := validateLocationHierarchy(ctx, locations, tc.refs)
+			var hierarchyErr *models.LocationHierarchyError
+			require.ErrorAs(t, err, &hierarchyErr)
+			assert.Equal(t, tc.message, hierarchyErr.Message)
+		})
+	}
+}
+
+func TestCreateWithInconsistentLocations(t *testing.T) {
+	ctx := context.Background()
+	province, city := uuid.New(), uuid.New()
+	locations := &fakeLocations{
+		provinces: map[uuid.UUID]*models.Province{province: {ID: province}},
+		cities:    map[uuid.UUID]*models.CityMunicipality{city: {ID: city, ProvinceID: uuid.New()}},
+	}
+
+	// The check runs before the repository is reached, so none is needed
+	parties := NewPoliticalPartyService(nil, nil)
+	parties.SetLocationLookup(locations)
+	_, err := parties.CreateJurisdiction(ctx, &models.CreatePoliticianJurisdictionRequest{ProvinceID: &province, CityID: &city})
+	var hierarchyErr *models.LocationHierarchyError
+	assert.ErrorAs(t, err, &hierarchyErr)
+
+	elections := NewElectionService(nil, nil)
+	elections.SetLocationLookup(locations)
+	_, err = elections.CreateElectionPosition(ctx, &models.CreateElectionPositionRequest{ProvinceID: &province, CityMunicipalityID: &city})
+	assert.ErrorAs(t, err, &hierarchyErr)
+}
@@ -2,17 +2,25 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/repository"
 	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+	"github.com/humfurie/pulpulitiko/api/pkg/geoip"
 )
 
+// barangaySearchCacheTTL is short since searches are many and varied
+const barangaySearchCacheTTL = 10 * time.Minute
+
 type LocationService struct {
 	repo  *repository.LocationRepository
 	cache *cache.RedisCache
+	geoip *geoip.Reader
 }
 
 func NewLocationService(repo *repository.LocationRepository, cache *cache.RedisCache) *LocationService {
@@ -22,6 +30,11 @@ func NewLocationService(repo *repository.LocationRepository, cache *cache.RedisC
 	}
 }
 
+// SetGeoIP enables detecting a visitor's location from their IP address
+func (s *LocationService) SetGeoIP(reader *geoip.Reader) {
+	s.geoip = reader
+}
+
 // =====================================================
 // REGIONS
 // =====================================================
@@ -56,7 +69,7 @@ func (s *LocationService) GetRegionByID(ctx context.Context, id uuid.UUID) (*mod
 		return nil, nil
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, time.Hour, regionCacheTags...)
 	return result, nil
 }
 
@@ -75,7 +88,7 @@ func (s *LocationService) GetRegionBySlug(ctx context.Context, slug string) (*mo
 		return nil, nil
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, time.Hour, regionCacheTags...)
 	return result, nil
 }
 
@@ -92,7 +105,7 @@ func (s *LocationService) ListRegions(ctx context.Context) ([]models.RegionListI
 	}
 
 	// Cache for 24 hours (regions rarely change)
-	_ = s.cache.Set(ctx, cacheKey, result, 24*time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, 24*time.Hour, regionCacheTags...)
 	return result, nil
 }
 
@@ -102,7 +115,6 @@ func (s *LocationService) UpdateRegion(ctx context.Context, id uuid.UUID, req *m
 	}
 
 	s.invalidateRegionsCache(ctx)
-	_ = s.cache.Delete(ctx, cache.RegionKey(id.String()))
 
 	return s.repo.GetRegionByID(ctx, id)
 }
@@ -113,7 +125,6 @@ func (s *LocationService) DeleteRegion(ctx context.Context, id uuid.UUID) error
 	}
 
 	s.invalidateRegionsCache(ctx)
-	_ = s.cache.Delete(ctx, cache.RegionKey(id.String()))
 	return nil
 }
 
@@ -138,7 +149,7 @@ func (s *LocationService) CreateProvince(ctx context.Context, req *models.Create
 		return nil, err
 	}
 
-	s.invalidateProvincesCache(ctx, regionID)
+	s.invalidateProvincesCache(ctx)
 	return province, nil
 }
 
@@ -157,7 +168,7 @@ func (s *LocationService) GetProvinceByID(ctx context.Context, id uuid.UUID) (*m
 		return nil, nil
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, time.Hour, provinceCacheTags...)
 	return result, nil
 }
 
@@ -176,7 +187,7 @@ func (s *LocationService) GetProvinceBySlug(ctx context.Context, slug string) (*
 		return nil, nil
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, time.Hour, provinceCacheTags...)
 	return result, nil
 }
 
@@ -192,7 +203,7 @@ func (s *LocationService) ListProvincesByRegion(ctx context.Context, regionID uu
 		return nil, err
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, 24*time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, 24*time.Hour, provinceCacheTags...)
 	return result, nil
 }
 
@@ -208,37 +219,26 @@ func (s *LocationService) ListAllProvinces(ctx context.Context) ([]models.Provin
 		return nil, err
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, 24*time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, 24*time.Hour, provinceCacheTags...)
 	return result, nil
 }
 
 func (s *LocationService) UpdateProvince(ctx context.Context, id uuid.UUID, req *models.UpdateProvinceRequest) (*models.Province, error) {
-	// Get current province to know which region's cache to invalidate
-	current, _ := s.repo.GetProvinceByID(ctx, id)
-
 	if err := s.repo.UpdateProvince(ctx, id, req); err != nil {
 		return nil, err
 	}
 
-	if current != nil {
-		s.invalidateProvincesCache(ctx, current.RegionID)
-	}
-	_ = s.cache.Delete(ctx, cache.ProvinceKey(id.String()))
+	s.invalidateProvincesCache(ctx)
 
 	return s.repo.GetProvinceByID(ctx, id)
 }
 
 func (s *LocationService) DeleteProvince(ctx context.Context, id uuid.UUID) error {
-	current, _ := s.repo.GetProvinceByID(ctx, id)
-
 	if err := s.repo.DeleteProvince(ctx, id); err != nil {
 		return err
 	}
 
-	if current != nil {
-		s.invalidateProvincesCache(ctx, current.RegionID)
-	}
-	_ = s.cache.Delete(ctx, cache.ProvinceKey(id.String()))
+	s.invalidateProvincesCache(ctx)
 	return nil
 }
 
@@ -262,13 +262,15 @@ func (s *LocationService) CreateCityMunicipality(ctx context.Context, req *model
 		IsHUC:      req.IsHUC,
 		IsICC:      req.IsICC,
 		Population: req.Population,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
 	}
 
 	if err := s.repo.CreateCityMunicipality(ctx, city); err != nil {
 		return nil, err
 	}
 
-	s.invalidateCitiesCache(ctx, provinceID)
+	s.invalidateCitiesCache(ctx)
 	return city, nil
 }
 
@@ -287,7 +289,7 @@ func (s *LocationService) GetCityMunicipalityByID(ctx context.Context, id uuid.U
 		return nil, nil
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, time.Hour, cityCacheTags...)
 	return result, nil
 }
 
@@ -306,7 +308,7 @@ func (s *LocationService) GetCityMunicipalityBySlug(ctx context.Context, slug st
 		return nil, nil
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, time.Hour, cityCacheTags...)
 	return result, nil
 }
 
@@ -322,36 +324,26 @@ func (s *LocationService) ListCitiesByProvince(ctx context.Context, provinceID u
 		return nil, err
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, 24*time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, 24*time.Hour, cityCacheTags...)
 	return result, nil
 }
 
 func (s *LocationService) UpdateCityMunicipality(ctx context.Context, id uuid.UUID, req *models.UpdateCityMunicipalityRequest) (*models.CityMunicipality, error) {
-	current, _ := s.repo.GetCityMunicipalityByID(ctx, id)
-
 	if err := s.repo.UpdateCityMunicipality(ctx, id, req); err != nil {
 		return nil, err
 	}
 
-	if current != nil {
-		s.invalidateCitiesCache(ctx, current.ProvinceID)
-	}
-	_ = s.cache.Delete(ctx, cache.CityKey(id.String()))
+	s.invalidateCitiesCache(ctx)
 
 	return s.repo.GetCityMunicipalityByID(ctx, id)
 }
 
 func (s *LocationService) DeleteCityMunicipality(ctx context.Context, id uuid.UUID) error {
-	current, _ := s.repo.GetCityMunicipalityByID(ctx, id)
-
 	if err := s.repo.DeleteCityMunicipality(ctx, id); err != nil {
 		return err
 	}
 
-	if current != nil {
-		s.invalidateCitiesCache(ctx, current.ProvinceID)
-	}
-	_ = s.cache.Delete(ctx, cache.CityKey(id.String()))
+	s.invalidateCitiesCache(ctx)
 	return nil
 }
 
@@ -377,7 +369,7 @@ func (s *LocationService) CreateBarangay(ctx context.Context, req *models.Create
 		return nil, err
 	}
 
-	s.invalidateBarangaysCache(ctx, cityID)
+	s.invalidateBarangaysCache(ctx)
 	return barangay, nil
 }
 
@@ -396,7 +388,7 @@ func (s *LocationService) GetBarangayByID(ctx context.Context, id uuid.UUID) (*m
 		return nil, nil
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, time.Hour, barangayCacheTags...)
 	return result, nil
 }
 
@@ -415,7 +407,7 @@ func (s *LocationService) GetBarangayBySlug(ctx context.Context, slug string) (*
 		return nil, nil
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, time.Hour, barangayCacheTags...)
 	return result, nil
 }
 
@@ -425,31 +417,21 @@ func (s *LocationService) ListBarangaysByCity(ctx context.Context, cityID uuid.U
 }
 
 func (s *LocationService) UpdateBarangay(ctx context.Context, id uuid.UUID, req *models.UpdateBarangayRequest) (*models.Barangay, error) {
-	current, _ := s.repo.GetBarangayByID(ctx, id)
-
 	if err := s.repo.UpdateBarangay(ctx, id, req); err != nil {
 		return nil, err
 	}
 
-	if current != nil {
-		s.invalidateBarangaysCache(ctx, current.CityMunicipalityID)
-	}
-	_ = s.cache.Delete(ctx, cache.BarangayKey(id.String()))
+	s.invalidateBarangaysCache(ctx)
 
 	return s.repo.GetBarangayByID(ctx, id)
 }
 
 func (s *LocationService) DeleteBarangay(ctx context.Context, id uuid.UUID) error {
-	current, _ := s.repo.GetBarangayByID(ctx, id)
-
 	if err := s.repo.DeleteBarangay(ctx, id); err != nil {
 		return err
 	}
 
-	if current != nil {
-		s.invalidateBarangaysCache(ctx, current.CityMunicipalityID)
-	}
-	_ = s.cache.Delete(ctx, cache.BarangayKey(id.String()))
+	s.invalidateBarangaysCache(ctx)
 	return nil
 }
 
@@ -487,6 +469,14 @@ func (s *LocationService) CreateDistrict(ctx context.Context, req *models.Create
 	return district, nil
 }
 
+func (s *LocationService) UpdateDistrict(ctx context.Context, id uuid.UUID, req *models.UpdateDistrictRequest) (*models.CongressionalDistrict, error) {
+	if err := s.repo.UpdateDistrict(ctx, id, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDistrictByID(ctx, id)
+}
+
 func (s *LocationService) GetDistrictByID(ctx context.Context, id uuid.UUID) (*models.CongressionalDistrict, error) {
 	return s.repo.GetDistrictByID(ctx, id)
 }
@@ -503,6 +493,32 @@ func (s *LocationService) ListDistrictsByProvince(ctx context.Context, provinceI
 // SEARCH & HIERARCHY
 // =====================================================
 
+// locationTreeCacheTTL is long since the tree only changes when the PSGC data
+// is edited, which drops it from the cache anyway
+const locationTreeCacheTTL = 7 * 24 * time.Hour
+
+// GetLocationTree returns regions nested down to depth. Trees down to cities
+// are cached; the barangay level is too large and is always read fresh.
+func (s *LocationService) GetLocationTree(ctx context.Context, depth int) ([]models.LocationTreeRegion, error) {
+	if depth >= models.LocationTreeBarangays {
+		return s.repo.GetLocationTree(ctx, depth)
+	}
+
+	cacheKey := cache.LocationTreeKey(depth)
+	var tree []models.LocationTreeRegion
+	if err := s.cache.Get(ctx, cacheKey, &tree); err == nil {
+		return tree, nil
+	}
+
+	result, err := s.repo.GetLocationTree(ctx, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, result, locationTreeCacheTTL, treeCacheTags...)
+	return result, nil
+}
+
 func (s *LocationService) SearchLocations(ctx context.Context, query string, limit int) ([]models.LocationSearchResult, error) {
 	if limit <= 0 {
 		limit = 20
@@ -510,6 +526,39 @@ func (s *LocationService) SearchLocations(ctx context.Context, query string, lim
 	return s.repo.SearchLocations(ctx, query, limit)
 }
 
+// SearchBarangays searches barangays by name within the filter. Results are
+// cached briefly since the location picker searches as the user types.
+func (s *LocationService) SearchBarangays(ctx context.Context, filter *models.BarangaySearchFilter, page, perPage int) (*models.PaginatedBarangays, error) {
+	cacheKey := cache.BarangaySearchKey(page, perPage, barangaySearchCacheFilter(filter))
+
+	var cached models.PaginatedBarangays
+	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	result, err := s.repo.SearchBarangays(ctx, filter, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, result, barangaySearchCacheTTL, barangayCacheTags...)
+	return result, nil
+}
+
+func barangaySearchCacheFilter(filter *models.BarangaySearchFilter) string {
+	key := strings.ToLower(filter.Query)
+	if filter.CityID != nil {
+		key += ":city=" + filter.CityID.String()
+	}
+	if filter.MinPopulation != nil {
+		key += fmt.Sprintf(":min=%d", *filter.MinPopulation)
+	}
+	if filter.MaxPopulation != nil {
+		key += fmt.Sprintf(":max=%d", *filter.MaxPopulation)
+	}
+	return key
+}
+
 func (s *LocationService) GetLocationHierarchy(ctx context.Context, barangayID uuid.UUID) (*models.LocationHierarchy, error) {
 	cacheKey := cache.LocationHierarchyKey(barangayID.String())
 	var hierarchy models.LocationHierarchy
@@ -525,31 +574,75 @@ func (s *LocationService) GetLocationHierarchy(ctx context.Context, barangayID u
 		return nil, nil
 	}
 
-	_ = s.cache.Set(ctx, cacheKey, result, 24*time.Hour)
+	_ = s.cache.Set(ctx, cacheKey, result, 24*time.Hour, hierarchyCacheTags...)
 	return result, nil
 }
 
+// A detected location is only returned when GeoIP places the address within
+// detectMaxAccuracyKm and a city's town centre lies within detectMaxDistanceKm
+// of that point. Anything vaguer would preselect the wrong city.
+const (
+	detectMaxAccuracyKm = 50
+	detectMaxDistanceKm = 30
+)
+
+// DetectLocation maps an IP address to the nearest city/municipality, as a
+// default for location pickers. It fails open: without a GeoIP database, or
+// when the address can't be placed confidently in the Philippines, it
+// returns nil. The address is neither stored nor cached, and the result is
+// only a suggestion; it must not be used to attribute votes or anything else
+// to a place.
+func (s *LocationService) DetectLocation(ctx context.Context, ip netip.Addr) (*models.DetectedLocation, error) {
+	if s.geoip == nil {
+		return nil, nil
+	}
+
+	point, err := s.geoip.Lookup(ip)
+	if err != nil || point == nil {
+		return nil, nil
+	}
+	if point.CountryCode != "PH" || point.AccuracyRadius == 0 || point.AccuracyRadius > detectMaxAccuracyKm {
+		return nil, nil
+	}
+
+	location, err := s.repo.FindNearestCity(ctx, point.Latitude, point.Longitude)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil || location.DistanceKm > detectMaxDistanceKm {
+		return nil, nil
+	}
+	return location, nil
+}
+
 // =====================================================
 // CACHE INVALIDATION
 // =====================================================
 
+// Location entries embed their parent's name and their children's counts, so
+// each level is tagged with its neighbours and is dropped when they change.
+// The tree stops at cities, so barangay edits leave it alone.
+var (
+	regionCacheTags    = []string{cache.TagLocationRegions, cache.TagLocationProvinces}
+	provinceCacheTags  = []string{cache.TagLocationRegions, cache.TagLocationProvinces, cache.TagLocationCities}
+	cityCacheTags      = []string{cache.TagLocationProvinces, cache.TagLocationCities, cache.TagLocationBarangays}
+	barangayCacheTags  = []string{cache.TagLocationCities, cache.TagLocationBarangays}
+	hierarchyCacheTags = []string{cache.TagLocationRegions, cache.TagLocationProvinces, cache.TagLocationCities, cache.TagLocationBarangays}
+	treeCacheTags      = []string{cache.TagLocationRegions, cache.TagLocationProvinces, cache.TagLocationCities}
+)
+
 func (s *LocationService) invalidateRegionsCache(ctx context.Context) {
-	_ = s.cache.Delete(ctx, cache.RegionsKey())
-	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixRegion+"*")
+	_ = s.cache.InvalidateTag(ctx, cache.TagLocationRegions)
 }
 
-func (s *LocationService) invalidateProvincesCache(ctx context.Context, regionID uuid.UUID) {
-	_ = s.cache.Delete(ctx, cache.ProvincesKey(regionID.String()))
-	_ = s.cache.Delete(ctx, cache.AllProvincesKey())
-	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixProvince+"*")
+func (s *LocationService) invalidateProvincesCache(ctx context.Context) {
+	_ = s.cache.InvalidateTag(ctx, cache.TagLocationProvinces)
 }
 
-func (s *LocationService) invalidateCitiesCache(ctx context.Context, provinceID uuid.UUID) {
-	_ = s.cache.Delete(ctx, cache.CitiesKey(provinceID.String()))
-	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixCity+"*")
+func (s *LocationService) invalidateCitiesCache(ctx context.Context) {
+	_ = s.cache.InvalidateTag(ctx, cache.TagLocationCities)
 }
 
-func (s *LocationService) invalidateBarangaysCache(ctx context.Context, cityID uuid.UUID) {
-	_ = s.cache.Delete(ctx, cache.BarangaysKey(cityID.String()))
-	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixBarangay+"*")
+func (s *LocationService) invalidateBarangaysCache(ctx context.Context) {
+	_ = s.cache.InvalidateTag(ctx, cache.TagLocationBarangays)
 }
@@ -9,23 +9,89 @@ import (
 	"github.com/humfurie/pulpulitiko/api/internal/repository"
 )
 
+// AssignmentNotifier is told when a support conversation is assigned to an admin
+type AssignmentNotifier interface {
+	CreateConversationAssignedNotification(ctx context.Context, conversation *models.Conversation, assigneeID, actorID uuid.UUID) error
+}
+
 type MessageService struct {
-	repo *repository.MessageRepository
+	repo         *repository.MessageRepository
+	userRepo     *repository.UserRepository
+	blocks       BlockChecker
+	unread       UnreadCountInvalidator
+	assignments  AssignmentNotifier
+	requireClaim bool
+}
+
+func NewMessageService(repo *repository.MessageRepository, userRepo *repository.UserRepository, blocks BlockChecker) *MessageService {
+	return &MessageService{
+		repo:     repo,
+		userRepo: userRepo,
+		blocks:   blocks,
+	}
+}
+
+// SetUnreadInvalidator sets what is told when a user's unread messages change
+func (s *MessageService) SetUnreadInvalidator(unread UnreadCountInvalidator) {
+	s.unread = unread
+}
+
+// SetAssignmentNotifier sets what is told when a conversation is assigned
+func (s *MessageService) SetAssignmentNotifier(assignments AssignmentNotifier) {
+	s.assignments = assignments
+}
+
+// SetRequireClaim sets whether admins may only reply to support conversations
+// assigned to them
+func (s *MessageService) SetRequireClaim(requireClaim bool) {
+	s.requireClaim = requireClaim
 }
 
-func NewMessageService(repo *repository.MessageRepository) *MessageService {
-	return &MessageService{repo: repo}
+// invalidateUnread drops the cached unread counts of everyone in the
+// conversation except the actor
+func (s *MessageService) invalidateUnread(ctx context.Context, conversation *models.Conversation, actorID uuid.UUID) {
+	if s.unread == nil || conversation == nil {
+		return
+	}
+	if conversation.UserID != actorID {
+		s.unread.InvalidateUnreadCount(ctx, conversation.UserID)
+	}
+	if conversation.RecipientID != nil && *conversation.RecipientID != actorID {
+		s.unread.InvalidateUnreadCount(ctx, *conversation.RecipientID)
+	}
 }
 
-// CreateConversation creates a new conversation with an initial message
-func (s *MessageService) CreateConversation(ctx context.Context, userID uuid.UUID, req *models.CreateConversationRequest) (*models.Conversation, *models.Message, error) {
+// CreateConversation creates a new conversation with an initial message.
+// Without a recipient the conversation goes to the admin team.
+func (s *MessageService) CreateConversation(ctx context.Context, userID uuid.UUID, isAdmin bool, req *models.CreateConversationRequest) (*models.Conversation, *models.Message, error) {
+	if req.RecipientID != nil {
+		if *req.RecipientID == userID {
+			return nil, nil, fmt.Errorf("you cannot message yourself")
+		}
+
+		recipient, err := s.userRepo.GetByID(ctx, *req.RecipientID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get recipient: %w", err)
+		}
+		if recipient == nil {
+			return nil, nil, fmt.Errorf("recipient not found")
+		}
+
+		// Admins bypass blocks so moderation conversations can always be opened
+		if !isAdmin {
+			if err := checkMessagingBlock(ctx, s.blocks, userID, recipient.ID, "you cannot message this user"); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
 	// Always create a new conversation (allows multiple conversations per user)
 	var subject *string
 	if req.Subject != "" {
 		subject = &req.Subject
 	}
 
-	conversation, err := s.repo.CreateConversation(ctx, userID, subject)
+	conversation, err := s.repo.CreateConversation(ctx, userID, req.RecipientID, subject)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create conversation: %w", err)
 	}
@@ -48,6 +114,8 @@ func (s *MessageService) CreateConversation(ctx context.Context, userID uuid.UUI
 		return nil, nil, fmt.Errorf("failed to get message: %w", err)
 	}
 
+	s.invalidateUnread(ctx, conversation, userID)
+
 	return conversation, message, nil
 }
 
@@ -61,7 +129,7 @@ func (s *MessageService) GetUserConversation(ctx context.Context, userID uuid.UU
 	return s.repo.GetConversationByUserID(ctx, userID)
 }
 
-// ListConversations lists all conversations (admin only)
+// ListConversations lists support conversations (admin only)
 func (s *MessageService) ListConversations(ctx context.Context, filter *models.ConversationFilter, page, perPage int) (*models.PaginatedConversations, error) {
 	return s.repo.ListConversations(ctx, filter, page, perPage)
 }
@@ -77,7 +145,7 @@ func (s *MessageService) UpdateConversationStatus(ctx context.Context, id uuid.U
 }
 
 // SendMessage sends a message in a conversation
-func (s *MessageService) SendMessage(ctx context.Context, conversationID, senderID uuid.UUID, req *models.CreateMessageRequest) (*models.Message, error) {
+func (s *MessageService) SendMessage(ctx context.Context, conversationID, senderID uuid.UUID, isAdmin bool, req *models.CreateMessageRequest) (*models.Message, error) {
 	// Verify conversation exists
 	conversation, err := s.repo.GetConversationByID(ctx, conversationID)
 	if err != nil {
@@ -87,6 +155,19 @@ func (s *MessageService) SendMessage(ctx context.Context, conversationID, sender
 		return nil, fmt.Errorf("conversation not found")
 	}
 
+	// A block makes a direct conversation read-only; admins bypass it for moderation
+	if other := conversation.OtherParticipant(senderID); other != nil && !isAdmin {
+		if err := checkMessagingBlock(ctx, s.blocks, senderID, *other, "this conversation is read-only"); err != nil {
+			return nil, err
+		}
+	}
+
+	if isAdmin {
+		if err := s.checkCanReply(conversation, senderID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create the message
 	message, err := s.repo.CreateMessage(ctx, conversationID, senderID, req.Content)
 	if err != nil {
@@ -99,9 +180,124 @@ func (s *MessageService) SendMessage(ctx context.Context, conversationID, sender
 		return nil, fmt.Errorf("failed to get message: %w", err)
 	}
 
+	s.invalidateUnread(ctx, conversation, senderID)
+
 	return message, nil
 }
 
+// checkCanReply rejects an admin's reply to a support conversation they don't
+// hold when claiming is required. The member who opened it can always reply.
+func (s *MessageService) checkCanReply(conversation *models.Conversation, adminID uuid.UUID) error {
+	if !s.requireClaim || conversation.RecipientID != nil || conversation.UserID == adminID {
+		return nil
+	}
+	if conversation.AssignedTo == nil {
+		return fmt.Errorf("claim this conversation before replying")
+	}
+	if *conversation.AssignedTo != adminID {
+		return fmt.Errorf("conversation is assigned to another admin")
+	}
+	return nil
+}
+
+// AssignConversation assigns a support conversation to an admin, notifying
+// them unless they assigned it to themselves. A nil AdminID unassigns it.
+func (s *MessageService) AssignConversation(ctx context.Context, conversationID, actorID uuid.UUID, req *models.AssignConversationRequest) (*models.Conversation, error) {
+	conversation, err := s.getSupportConversation(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AdminID != nil {
+		assignee, err := s.userRepo.GetByID(ctx, *req.AdminID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assignee: %w", err)
+		}
+		if assignee == nil || assignee.RoleSlug != "admin" {
+			return nil, fmt.Errorf("assignee must be an admin")
+		}
+	}
+
+	if err := s.repo.AssignConversation(ctx, conversationID, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	reassigned := req.AdminID != nil && (conversation.AssignedTo == nil || *conversation.AssignedTo != *req.AdminID)
+	if reassigned && *req.AdminID != actorID && s.assignments != nil {
+		// The assignment stands even if the notification can't be created
+		_ = s.assignments.CreateConversationAssignedNotification(ctx, conversation, *req.AdminID, actorID)
+	}
+
+	return s.repo.GetConversationByID(ctx, conversationID)
+}
+
+// ClaimConversation assigns an unassigned support conversation to the admin
+func (s *MessageService) ClaimConversation(ctx context.Context, conversationID, adminID uuid.UUID) (*models.Conversation, error) {
+	if _, err := s.getSupportConversation(ctx, conversationID); err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.repo.ClaimConversation(ctx, conversationID, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, fmt.Errorf("conversation is assigned to another admin")
+	}
+
+	return s.repo.GetConversationByID(ctx, conversationID)
+}
+
+// ArchiveConversation moves a conversation out of the inbox
+func (s *MessageService) ArchiveConversation(ctx context.Context, conversationID uuid.UUID) error {
+	conversation, err := s.repo.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	if conversation == nil {
+		return fmt.Errorf("conversation not found")
+	}
+	if conversation.Status == models.ConversationStatusArchived {
+		return fmt.Errorf("conversation is already archived")
+	}
+	return s.repo.UpdateConversationStatus(ctx, conversationID, models.ConversationStatusArchived)
+}
+
+// UnarchiveConversation returns an archived conversation to the inbox as open
+func (s *MessageService) UnarchiveConversation(ctx context.Context, conversationID uuid.UUID) error {
+	conversation, err := s.repo.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	if conversation == nil {
+		return fmt.Errorf("conversation not found")
+	}
+	if conversation.Status != models.ConversationStatusArchived {
+		return fmt.Errorf("conversation is not archived")
+	}
+	return s.repo.UpdateConversationStatus(ctx, conversationID, models.ConversationStatusOpen)
+}
+
+// GetQueueCounts sizes the support inbox queues for the admin's sidebar
+func (s *MessageService) GetQueueCounts(ctx context.Context, adminID uuid.UUID) (*models.ConversationQueueCounts, error) {
+	return s.repo.GetQueueCounts(ctx, adminID)
+}
+
+// getSupportConversation loads a conversation that can be assigned
+func (s *MessageService) getSupportConversation(ctx context.Context, id uuid.UUID) (*models.Conversation, error) {
+	conversation, err := s.repo.GetConversationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if conversation == nil {
+		return nil, fmt.Errorf("conversation not found")
+	}
+	if conversation.RecipientID != nil {
+		return nil, fmt.Errorf("only support conversations can be assigned")
+	}
+	return conversation, nil
+}
+
 // GetMessages retrieves messages in a conversation with pagination
 func (s *MessageService) GetMessages(ctx context.Context, conversationID uuid.UUID, page, perPage int) (*models.PaginatedMessages, error) {
 	return s.repo.ListMessages(ctx, conversationID, page, perPage)
@@ -109,7 +305,13 @@ func (s *MessageService) GetMessages(ctx context.Context, conversationID uuid.UU
 
 // MarkAsRead marks all messages in a conversation as read
 func (s *MessageService) MarkAsRead(ctx context.Context, conversationID, readerID uuid.UUID) error {
-	return s.repo.MarkMessagesAsRead(ctx, conversationID, readerID)
+	if err := s.repo.MarkMessagesAsRead(ctx, conversationID, readerID); err != nil {
+		return err
+	}
+	if s.unread != nil {
+		s.unread.InvalidateUnreadCount(ctx, readerID)
+	}
+	return nil
 }
 
 // GetUnreadCounts gets unread message counts for a user
@@ -132,6 +334,9 @@ func (s *MessageService) CanAccessConversation(ctx context.Context, conversation
 		return true, nil
 	}
 
-	// Users can only access their own conversations
-	return conversation.UserID == userID, nil
+	// Users can only access conversations they started or were sent
+	if conversation.UserID == userID {
+		return true, nil
+	}
+	return conversation.RecipientID != nil && *conversation.RecipientID == userID, nil
 }
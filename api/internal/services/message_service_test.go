@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCanReply(t *testing.T) {
+	member := uuid.New()
+	admin := uuid.New()
+	otherAdmin := uuid.New()
+	recipient := uuid.New()
+
+	unassigned := &models.Conversation{UserID: member}
+	mine := &models.Conversation{UserID: member, AssignedTo: &admin}
+	theirs := &models.Conversation{UserID: member, AssignedTo: &otherAdmin}
+	direct := &models.Conversation{UserID: member, RecipientID: &recipient}
+	ownSupport := &models.Conversation{UserID: admin, AssignedTo: &otherAdmin}
+
+	s := &MessageService{requireClaim: true}
+	assert.EqualError(t, s.checkCanReply(unassigned, admin), "claim this conversation before replying")
+	assert.NoError(t, s.checkCanReply(mine, admin))
+	assert.EqualError(t, s.checkCanReply(theirs, admin), "conversation is assigned to another admin")
+	assert.NoError(t, s.checkCanReply(direct, admin), "direct conversations aren't assigned")
+	assert.NoError(t, s.checkCanReply(ownSupport, admin), "an admin can reply in a conversation they opened")
+
+	s.requireClaim = false
+	assert.NoError(t, s.checkCanReply(unassigned, admin))
+	assert.NoError(t, s.checkCanReply(theirs, admin))
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+// inboxUnreadTTL bounds how stale a cached count can get when an event that
+// changes it (e.g. a comment being moderated) does not invalidate it
+const inboxUnreadTTL = 10 * time.Minute
+
+// UnreadCountInvalidator drops a user's cached inbox unread counts after an
+// event that changes them
+type UnreadCountInvalidator interface {
+	InvalidateUnreadCount(ctx context.Context, userID uuid.UUID)
+}
+
+// InvalidateUnreadCount drops the user's cached inbox unread counts
+func (s *NotificationService) InvalidateUnreadCount(ctx context.Context, userID uuid.UUID) {
+	if s.cache == nil {
+		return
+	}
+	_ = s.cache.Delete(ctx, cache.InboxUnreadKey(userID.String()))
+}
+
+// GetInboxUnreadCounts returns the user's unread mentions, replies and
+// messages, served from cache when possible
+func (s *NotificationService) GetInboxUnreadCounts(ctx context.Context, userID uuid.UUID) (*models.InboxUnreadCounts, error) {
+	cacheKey := cache.InboxUnreadKey(userID.String())
+
+	if s.cache != nil {
+		var cached models.InboxUnreadCounts
+		if err := s.cache.Get(ctx, cacheKey, &cached); err == nil {
+			return &cached, nil
+		}
+	}
+
+	mentions, err := s.commentRepo.CountUnreadMentions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	replies, err := s.repo.CountUnreadReplies(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := s.messageRepo.GetUnreadCounts(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := &models.InboxUnreadCounts{
+		Mentions: mentions,
+		Replies:  replies,
+		Messages: messages.Total,
+		Total:    mentions + replies + messages.Total,
+	}
+
+	if s.cache != nil {
+		_ = s.cache.Set(ctx, cacheKey, counts, inboxUnreadTTL)
+	}
+
+	return counts, nil
+}
+
+// ListInbox lists the user's mentions, replies and incoming messages
+func (s *NotificationService) ListInbox(ctx context.Context, userID uuid.UUID, page, perPage int, unreadOnly bool) (*models.PaginatedInbox, error) {
+	result, err := s.repo.ListInbox(ctx, userID, page, perPage, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.GetInboxUnreadCounts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result.UnreadCount = counts.Total
+
+	return result, nil
+}
+
+// MarkInboxItemRead marks a single inbox item as read; id is interpreted
+// according to itemType
+func (s *NotificationService) MarkInboxItemRead(ctx context.Context, userID uuid.UUID, itemType models.InboxItemType, id uuid.UUID) error {
+	var err error
+	switch itemType {
+	case models.InboxItemMention:
+		err = s.commentRepo.MarkMentionRead(ctx, id, userID)
+	case models.InboxItemReply:
+		err = s.repo.MarkAsRead(ctx, id, userID)
+	case models.InboxItemMessage:
+		err = s.messageRepo.MarkMessageRead(ctx, id, userID)
+	default:
+		return fmt.Errorf("invalid notification type")
+	}
+	if err != nil {
+		return err
+	}
+
+	s.InvalidateUnreadCount(ctx, userID)
+	return nil
+}
+
+// MarkInboxRead marks every mention, reply and incoming message as read
+func (s *NotificationService) MarkInboxRead(ctx context.Context, userID uuid.UUID) error {
+	if err := s.commentRepo.MarkAllMentionsRead(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.repo.MarkAllRepliesRead(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.messageRepo.MarkAllMessagesRead(ctx, userID); err != nil {
+		return err
+	}
+
+	s.InvalidateUnreadCount(ctx, userID)
+	return nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingInvalidator records which users had their unread counts dropped
+type recordingInvalidator []uuid.UUID
+
+func (r *recordingInvalidator) InvalidateUnreadCount(_ context.Context, userID uuid.UUID) {
+	*r = append(*r, userID)
+}
+
+func TestMarkInboxItemReadRejectsUnknownType(t *testing.T) {
+	// The type is checked before any repository access, so no repos are needed
+	service := NewNotificationService(nil, nil, nil, nil, nil, nil)
+	err := service.MarkInboxItemRead(context.Background(), uuid.New(), models.InboxItemType("poll"), uuid.New())
+	assert.EqualError(t, err, "invalid notification type")
+}
+
+func TestMessageInvalidatesOtherParticipants(t *testing.T) {
+	ctx := context.Background()
+	owner, recipient, admin := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("direct message invalidates the recipient only", func(t *testing.T) {
+		var seen recordingInvalidator
+		service := &MessageService{unread: &seen}
+		service.invalidateUnread(ctx, &models.Conversation{UserID: owner, RecipientID: &recipient}, owner)
+		assert.Equal(t, recordingInvalidator{recipient}, seen)
+	})
+
+	t.Run("admin reply to a support conversation invalidates the owner", func(t *testing.T) {
+		var seen recordingInvalidator
+		service := &MessageService{unread: &seen}
+		service.invalidateUnread(ctx, &models.Conversation{UserID: owner}, admin)
+		assert.Equal(t, recordingInvalidator{owner}, seen)
+	})
+
+	t.Run("no invalidator is a no-op", func(t *testing.T) {
+		service := &MessageService{}
+		service.invalidateUnread(ctx, &models.Conversation{UserID: owner}, admin)
+	})
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/fcm"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// Devices whose app hasn't registered its token for this long are
+	// skipped, as FCM advises treating such tokens as stale
+	deviceTokenStaleAfter = 270 * 24 * time.Hour
+	// pushTimeout bounds sending one notification to all of a user's devices
+	pushTimeout = 30 * time.Second
+	// pushWorkers notifications are pushed at once; up to pushQueueSize more
+	// wait their turn, and any beyond that are not pushed
+	pushWorkers   = 4
+	pushQueueSize = 1000
+)
+
+// pushQueue hands notifications to a fixed set of workers
+type pushQueue struct {
+	mu      sync.RWMutex
+	pending chan *models.Notification
+	closed  bool
+	workers sync.WaitGroup
+}
+
+// PushSender delivers a push notification to one device. It returns
+// fcm.ErrUnregistered when the device token is no longer valid.
+type PushSender interface {
+	Send(ctx context.Context, token, title, body string, data map[string]string) error
+}
+
+// DeviceTokenStore looks up and forgets users' push tokens
+type DeviceTokenStore interface {
+	ListDeviceTokens(ctx context.Context, userID uuid.UUID, seenSince time.Time) ([]models.DeviceToken, error)
+	DeleteDeviceToken(ctx context.Context, token string) error
+}
+
+// SetPushSender enables push notifications to the devices in tokens and
+// starts the workers that send them. Failures are logged to logger. Call
+// StopPush on shutdown to send what is still queued.
+func (s *NotificationService) SetPushSender(sender PushSender, tokens DeviceTokenStore, logger zerolog.Logger) {
+	s.push = sender
+	s.deviceTokens = tokens
+	s.logger = logger
+	s.pushQueue = &pushQueue{pending: make(chan *models.Notification, pushQueueSize)}
+
+	for i := 0; i < pushWorkers; i++ {
+		s.pushQueue.workers.Add(1)
+		go s.pushWorker()
+	}
+}
+
+// StopPush stops taking notifications to push and waits until the queued ones
+// are sent or ctx is done
+func (s *NotificationService) StopPush(ctx context.Context) error {
+	q := s.pushQueue
+	if q == nil {
+		return nil
+	}
+
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.pending)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("push notifications still queued at shutdown: %w", ctx.Err())
+	}
+}
+
+func (s *NotificationService) pushWorker() {
+	defer s.pushQueue.workers.Done()
+	for n := range s.pushQueue.pending {
+		ctx, cancel := context.WithTimeout(context.Background(), pushTimeout)
+		if err := s.SendPush(ctx, n); err != nil {
+			s.logger.Error().Err(err).
+				Str("notification_id", n.ID.String()).
+				Str("user_id", n.UserID.String()).
+				Msg("Failed to push notification")
+		}
+		cancel()
+	}
+}
+
+// RegisterDeviceToken saves the push token of the app the user is signed in
+// to
+func (s *NotificationService) RegisterDeviceToken(ctx context.Context, userID uuid.UUID, req *models.RegisterDeviceTokenRequest) (*models.DeviceToken, error) {
+	return s.repo.SaveDeviceToken(ctx, userID, req)
+}
+
+// Create stores an in-app notification and, once it is stored, drops the
+// user's cached unread counts and queues it to be pushed to their devices
+func (s *NotificationService) Create(ctx context.Context, req *models.CreateNotificationRequest) (*models.Notification, error) {
+	n, err := s.repo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.InvalidateUnreadCount(ctx, n.UserID)
+	s.queuePush(n)
+	return n, nil
+}
+
+// queuePush hands a notification to the push workers without waiting. When
+// the queue is full the notification stays in-app only.
+func (s *NotificationService) queuePush(n *models.Notification) {
+	q := s.pushQueue
+	if q == nil {
+		return
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return
+	}
+	select {
+	case q.pending <- n:
+	default:
+		s.logger.Warn().Str("notification_id", n.ID.String()).Msg("Push queue full, notification not pushed")
+	}
+}
+
+// SendPush sends a notification to each of its user's active devices,
+// forgetting tokens FCM no longer recognizes. A device that can't be reached
+// doesn't stop the others; their errors are returned together.
+func (s *NotificationService) SendPush(ctx context.Context, n *models.Notification) error {
+	if s.push == nil {
+		return nil
+	}
+
+	tokens, err := s.deviceTokens.ListDeviceTokens(ctx, n.UserID, time.Now().Add(-deviceTokenStaleAfter))
+	if err != nil {
+		return err
+	}
+
+	body := ""
+	if n.Message != nil {
+		body = *n.Message
+	}
+	data := pushData(n)
+
+	var errs []error
+	for _, t := range tokens {
+		err := s.push.Send(ctx, t.Token, n.Title, body, data)
+		if errors.Is(err, fcm.ErrUnregistered) {
+			if err := s.deviceTokens.DeleteDeviceToken(ctx, t.Token); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("device %s: %w", t.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// pushData is what the app needs to open a notification: its ID, type and
+// whatever it refers to. FCM data values must be strings.
+func pushData(n *models.Notification) map[string]string {
+	data := map[string]string{
+		"notification_id": n.ID.String(),
+		"type":            string(n.Type),
+	}
+	for key, id := range map[string]*uuid.UUID{
+		"actor_id":        n.ActorID,
+		"article_id":      n.ArticleID,
+		"politician_id":   n.PoliticianID,
+		"comment_id":      n.CommentID,
+		"poll_id":         n.PollID,
+		"conversation_id": n.ConversationID,
+	} {
+		if id != nil {
+			data[key] = id.String()
+		}
+	}
+	return data
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/fcm"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDeviceTokens struct {
+	mu        sync.Mutex
+	tokens    []models.DeviceToken
+	seenSince time.Time
+	deleted   []string
+}
+
+func (f *fakeDeviceTokens) ListDeviceTokens(_ context.Context, userID uuid.UUID, seenSince time.Time) ([]models.DeviceToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seenSince = seenSince
+	var result []models.DeviceToken
+	for _, t := range f.tokens {
+		if t.UserID == userID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeDeviceTokens) DeleteDeviceToken(_ context.Context, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, token)
+	return nil
+}
+
+// fakePushSender records what it sends and fails for the tokens in errs
+type fakePushSender struct {
+	mu   sync.Mutex
+	sent []string
+	errs map[string]error
+	data map[string]string
+}
+
+func (f *fakePushSender) Send(_ context.Context, token, title, body string, data map[string]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.errs[token]; err != nil {
+		return err
+	}
+	f.sent = append(f.sent, token+": "+title+" / "+body)
+	f.data = data
+	return nil
+}
+
+func TestSendPush(t *testing.T) {
+	userID, other := uuid.New(), uuid.New()
+	store := &fakeDeviceTokens{tokens: []models.DeviceToken{
+		{ID: uuid.New(), UserID: userID, Token: "phone", Platform: models.DevicePlatformAndroid},
+		{ID: uuid.New(), UserID: userID, Token: "old-tablet", Platform: models.DevicePlatformIOS},
+		{ID: uuid.New(), UserID: userID, Token: "browser", Platform: models.DevicePlatformWeb},
+		{ID: uuid.New(), UserID: other, Token: "someone-else", Platform: models.DevicePlatformWeb},
+	}}
+	sender := &fakePushSender{errs: map[string]error{
+		"old-tablet": fcm.ErrUnregistered,
+		"browser":    errors.New("FCM returned status 503"),
+	}}
+
+	service := NewNotificationService(nil, nil, nil, nil, nil, nil)
+	service.SetPushSender(sender, store, zerolog.Nop())
+
+	message := "Voting has ended."
+	pollID := uuid.New()
+	n := &models.Notification{ID: uuid.New(), UserID: userID, Type: models.NotificationTypePollClosed, Title: "Your poll has closed", Message: &message, PollID: &pollID}
+
+	err := service.SendPush(context.Background(), n)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "FCM returned status 503")
+
+	assert.Equal(t, []string{"phone: Your poll has closed / Voting has ended."}, sender.sent)
+	assert.Equal(t, []string{"old-tablet"}, store.deleted)
+	assert.WithinDuration(t, time.Now().Add(-deviceTokenStaleAfter), store.seenSince, time.Minute)
+	assert.Equal(t, map[string]string{
+		"notification_id": n.ID.String(),
+		"type":            "poll_closed",
+		"poll_id":         pollID.String(),
+	}, sender.data)
+}
+
+func TestSendPushDisabled(t *testing.T) {
+	service := NewNotificationService(nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, service.SendPush(context.Background(), &models.Notification{ID: uuid.New()}))
+}
+
+func TestQueuePushDrainsOnStop(t *testing.T) {
+	store := &fakeDeviceTokens{}
+	var notifications []*models.Notification
+	for i := 0; i < 3*pushWorkers; i++ {
+		n := &models.Notification{ID: uuid.New(), UserID: uuid.New(), Title: "Reply"}
+		store.tokens = append(store.tokens, models.DeviceToken{ID: uuid.New(), UserID: n.UserID, Token: n.ID.String()})
+		notifications = append(notifications, n)
+	}
+	sender := &fakePushSender{}
+
+	service := NewNotificationService(nil, nil, nil, nil, nil, nil)
+	service.SetPushSender(sender, store, zerolog.Nop())
+	for _, n := range notifications {
+		service.queuePush(n)
+	}
+	require.NoError(t, service.StopPush(context.Background()))
+
+	assert.Len(t, sender.sent, len(notifications))
+
+	// Nothing is queued once stopped
+	service.queuePush(notifications[0])
+	assert.Len(t, sender.sent, len(notifications))
+}
+
+func TestQueuePushLogsFailures(t *testing.T) {
+	userID := uuid.New()
+	store := &fakeDeviceTokens{tokens: []models.DeviceToken{{ID: uuid.New(), UserID: userID, Token: "browser"}}}
+	sender := &fakePushSender{errs: map[string]error{"browser": errors.New("FCM returned status 503")}}
+	var logs bytes.Buffer
+
+	service := NewNotificationService(nil, nil, nil, nil, nil, nil)
+	service.SetPushSender(sender, store, zerolog.New(&logs))
+	n := &models.Notification{ID: uuid.New(), UserID: userID, Title: "Reply"}
+	service.queuePush(n)
+	require.NoError(t, service.StopPush(context.Background()))
+
+	assert.Contains(t, logs.String(), "FCM returned status 503")
+	assert.Contains(t, logs.String(), n.ID.String())
+}
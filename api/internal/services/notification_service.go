@@ -7,17 +7,33 @@ import (
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/repository"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+	"github.com/rs/zerolog"
 )
 
 type NotificationService struct {
-	repo     *repository.NotificationRepository
-	userRepo *repository.UserRepository
+	repo        *repository.NotificationRepository
+	userRepo    *repository.UserRepository
+	commentRepo *repository.CommentRepository
+	messageRepo *repository.MessageRepository
+	blocks      BlockChecker
+	cache       *cache.RedisCache
+
+	push         PushSender
+	deviceTokens DeviceTokenStore
+	pushQueue    *pushQueue
+	logger       zerolog.Logger
 }
 
-func NewNotificationService(repo *repository.NotificationRepository, userRepo *repository.UserRepository) *NotificationService {
+func NewNotificationService(repo *repository.NotificationRepository, userRepo *repository.UserRepository, commentRepo *repository.CommentRepository, messageRepo *repository.MessageRepository, blocks BlockChecker, cache *cache.RedisCache) *NotificationService {
 	return &NotificationService{
-		repo:     repo,
-		userRepo: userRepo,
+		repo:        repo,
+		userRepo:    userRepo,
+		commentRepo: commentRepo,
+		messageRepo: messageRepo,
+		blocks:      blocks,
+		cache:       cache,
+		logger:      zerolog.Nop(),
 	}
 }
 
@@ -28,6 +44,17 @@ func (s *NotificationService) CreateMentionNotification(ctx context.Context, men
 		return nil
 	}
 
+	// Don't notify users who have blocked the actor
+	if s.blocks != nil {
+		blocked, err := s.blocks.IsBlocked(ctx, mentionedUserID, actorID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return nil
+		}
+	}
+
 	// Get actor name for the title
 	actor, err := s.userRepo.GetByID(ctx, actorID)
 	if err != nil || actor == nil {
@@ -56,7 +83,7 @@ func (s *NotificationService) CreateMentionNotification(ctx context.Context, men
 		CommentID:    commentID,
 	}
 
-	_, err = s.repo.Create(ctx, req)
+	_, err = s.Create(ctx, req)
 	return err
 }
 
@@ -95,10 +122,74 @@ func (s *NotificationService) CreateReplyNotification(ctx context.Context, paren
 		CommentID:    commentID,
 	}
 
-	_, err = s.repo.Create(ctx, req)
+	_, err = s.Create(ctx, req)
+	return err
+}
+
+// CreatePollClosedNotification notifies a poll's creator that voting has ended,
+// summarizing the final results
+func (s *NotificationService) CreatePollClosedNotification(ctx context.Context, poll *models.Poll, results *models.PollResults) error {
+	message := "Voting has ended."
+	if results != nil {
+		message = fmt.Sprintf("Voting has ended with %d total votes.", results.TotalVotes)
+		if leader := leadingOption(results.Options); leader != nil && results.TotalVotes > 0 {
+			message = fmt.Sprintf("Voting has ended with %d total votes. Top answer: \"%s\" (%.1f%%).",
+				results.TotalVotes, leader.Text, leader.Percentage)
+		}
+	}
+
+	req := &models.CreateNotificationRequest{
+		UserID:  poll.UserID,
+		Type:    models.NotificationTypePollClosed,
+		Title:   fmt.Sprintf("Your poll \"%s\" has closed", poll.Title),
+		Message: &message,
+		PollID:  &poll.ID,
+	}
+
+	_, err := s.Create(ctx, req)
+	return err
+}
+
+// CreateConversationAssignedNotification tells an admin that a support
+// conversation was assigned to them
+func (s *NotificationService) CreateConversationAssignedNotification(ctx context.Context, conversation *models.Conversation, assigneeID, actorID uuid.UUID) error {
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil || actor == nil {
+		return fmt.Errorf("failed to get actor: %w", err)
+	}
+
+	message := "assigned you a support conversation"
+	if conversation.User != nil {
+		message = fmt.Sprintf("assigned you a support conversation with %s", conversation.User.Name)
+	}
+	if conversation.Subject != nil && *conversation.Subject != "" {
+		message += fmt.Sprintf(": \"%s\"", *conversation.Subject)
+	}
+
+	req := &models.CreateNotificationRequest{
+		UserID:         assigneeID,
+		Type:           models.NotificationTypeConversationAssigned,
+		Title:          fmt.Sprintf("%s assigned you a conversation", actor.Name),
+		Message:        &message,
+		ActorID:        &actorID,
+		ConversationID: &conversation.ID,
+	}
+
+	_, err = s.Create(ctx, req)
 	return err
 }
 
+// leadingOption returns the option with the most votes, or nil if there are none
+func leadingOption(options []models.PollOption) *models.PollOption {
+	var leader *models.PollOption
+	for i := range options {
+		if leader == nil || options[i].VoteCount > leader.VoteCount {
+			leader = &options[i]
+		}
+	}
+	return leader
+}
+
 // ListNotifications lists paginated notifications for a user
 func (s *NotificationService) ListNotifications(ctx context.Context, userID uuid.UUID, page, perPage int, unreadOnly bool) (*models.PaginatedNotifications, error) {
 	return s.repo.ListByUser(ctx, userID, page, perPage, unreadOnly)
@@ -106,12 +197,20 @@ func (s *NotificationService) ListNotifications(ctx context.Context, userID uuid
 
 // MarkAsRead marks a single notification as read
 func (s *NotificationService) MarkAsRead(ctx context.Context, id, userID uuid.UUID) error {
-	return s.repo.MarkAsRead(ctx, id, userID)
+	if err := s.repo.MarkAsRead(ctx, id, userID); err != nil {
+		return err
+	}
+	s.InvalidateUnreadCount(ctx, userID)
+	return nil
 }
 
 // MarkAllAsRead marks all notifications for a user as read
 func (s *NotificationService) MarkAllAsRead(ctx context.Context, userID uuid.UUID) error {
-	return s.repo.MarkAllAsRead(ctx, userID)
+	if err := s.repo.MarkAllAsRead(ctx, userID); err != nil {
+		return err
+	}
+	s.InvalidateUnreadCount(ctx, userID)
+	return nil
 }
 
 // GetUnreadCount returns the count of unread notifications
@@ -121,5 +220,9 @@ func (s *NotificationService) GetUnreadCount(ctx context.Context, userID uuid.UU
 
 // DeleteNotification deletes a notification
 func (s *NotificationService) DeleteNotification(ctx context.Context, id, userID uuid.UUID) error {
-	return s.repo.Delete(ctx, id, userID)
+	if err := s.repo.Delete(ctx, id, userID); err != nil {
+		return err
+	}
+	s.InvalidateUnreadCount(ctx, userID)
+	return nil
 }
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// Cached briefly: whether an election period is under way changes by the day
+const partyCoalitionTTL = 1 * time.Hour
+
+// ListRelations returns every relation the party is on either side of
+func (s *PoliticalPartyService) ListRelations(ctx context.Context, partyID uuid.UUID) ([]models.PartyRelation, error) {
+	return s.repo.ListRelations(ctx, partyID)
+}
+
+// GetRelation returns a relation only if the given party is its subject
+func (s *PoliticalPartyService) GetRelation(ctx context.Context, partyID, relationID uuid.UUID) (*models.PartyRelation, error) {
+	relation, err := s.repo.GetRelationByID(ctx, relationID)
+	if err != nil {
+		return nil, err
+	}
+	if relation == nil || relation.PartyID != partyID {
+		return nil, nil
+	}
+	return relation, nil
+}
+
+func (s *PoliticalPartyService) CreateRelation(ctx context.Context, partyID uuid.UUID, req *models.CreatePartyRelationRequest) (*models.PartyRelation, error) {
+	if err := validateMembershipDates(req.StartDate, req.EndDate); err != nil {
+		return nil, err
+	}
+	if err := s.checkRelationCycle(ctx, req.RelationType, partyID, req.RelatedPartyID); err != nil {
+		return nil, err
+	}
+	if err := s.checkMergeVacant(ctx, partyID, req.RelationType, req.StartDate, req.EndDate, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.CreateRelation(ctx, partyID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateRelationCache(ctx)
+
+	return s.repo.GetRelationByID(ctx, id)
+}
+
+func (s *PoliticalPartyService) UpdateRelation(ctx context.Context, relation *models.PartyRelation, req *models.UpdatePartyRelationRequest) (*models.PartyRelation, error) {
+	// Validate against the merged result so partial updates can't produce an invalid relation
+	startDate := formatOptionalDate(relation.StartDate)
+	if req.StartDate != nil {
+		startDate = req.StartDate
+	}
+	endDate := formatOptionalDate(relation.EndDate)
+	if req.EndDate != nil {
+		endDate = req.EndDate
+	}
+	if req.RemoveEndDate {
+		endDate = nil
+	}
+	if err := validateMembershipDates(startDate, endDate); err != nil {
+		return nil, err
+	}
+	if err := s.checkMergeVacant(ctx, relation.PartyID, relation.RelationType, startDate, endDate, relation.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateRelation(ctx, relation.ID, req); err != nil {
+		return nil, err
+	}
+
+	s.invalidateRelationCache(ctx)
+
+	return s.repo.GetRelationByID(ctx, relation.ID)
+}
+
+func (s *PoliticalPartyService) DeleteRelation(ctx context.Context, relation *models.PartyRelation) error {
+	if err := s.repo.DeleteRelation(ctx, relation.ID); err != nil {
+		return err
+	}
+
+	s.invalidateRelationCache(ctx)
+
+	return nil
+}
+
+// GetElectionPeriodCoalition returns the coalition to show beside the party
+// while an election is under way, or nil outside one
+func (s *PoliticalPartyService) GetElectionPeriodCoalition(ctx context.Context, partyID uuid.UUID) (*models.PartyBrief, error) {
+	cacheKey := "party:coalition:" + partyID.String()
+
+	var coalition *models.PartyBrief
+	if err := s.cache.Get(ctx, cacheKey, &coalition); err == nil {
+		return coalition, nil
+	}
+
+	coalition, err := s.repo.GetElectionPeriodCoalition(ctx, partyID)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, coalition, partyCoalitionTTL)
+	return coalition, nil
+}
+
+// loadRelations fills in a party's current coalitions, its members if it is a
+// coalition, and its history of mergers and renamings
+func (s *PoliticalPartyService) loadRelations(ctx context.Context, party *models.PoliticalParty) error {
+	var err error
+	if party.Coalitions, err = s.repo.GetCurrentCoalitions(ctx, party.ID); err != nil {
+		return err
+	}
+	if party.CoalitionMembers, err = s.repo.GetCurrentCoalitionMembers(ctx, party.ID); err != nil {
+		return err
+	}
+	if party.Lineage, err = s.repo.GetLineage(ctx, party.ID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkRelationCycle rejects a relation that would make a party its own
+// predecessor, or a coalition a member of itself. Mergers and renamings are
+// checked together since both describe where a party came from.
+func (s *PoliticalPartyService) checkRelationCycle(ctx context.Context, relationType string, partyID, relatedPartyID uuid.UUID) error {
+	family := []string{models.PartyRelationMergedInto, models.PartyRelationRenamedFrom}
+	if relationType == models.PartyRelationCoalitionMemberOf {
+		family = []string{models.PartyRelationCoalitionMemberOf}
+	}
+
+	graph, err := s.repo.GetRelationGraph(ctx, family)
+	if err != nil {
+		return err
+	}
+
+	from, to := models.PartyLineageEdge(relationType, partyID, relatedPartyID)
+	if graph.CreatesCycle(from, to) {
+		if relationType == models.PartyRelationCoalitionMemberOf {
+			return fmt.Errorf("a coalition cannot be a member of itself")
+		}
+		return fmt.Errorf("party cannot be its own predecessor")
+	}
+	return nil
+}
+
+// checkMergeVacant rejects a merger whose period overlaps another merger of
+// the same party: a party can only merge into one other at a time
+func (s *PoliticalPartyService) checkMergeVacant(ctx context.Context, partyID uuid.UUID, relationType string, startDate, endDate *string, excludeID uuid.UUID) error {
+	if relationType != models.PartyRelationMergedInto {
+		return nil
+	}
+
+	taken, err := s.repo.HasOverlappingRelation(ctx, partyID, relationType, startDate, endDate, excludeID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("party already merged into another party for this period")
+	}
+	return nil
+}
+
+// invalidateRelationCache clears party pages, which show coalitions and
+// lineage, and the election results grouped by coalition
+func (s *PoliticalPartyService) invalidateRelationCache(ctx context.Context) {
+	_ = s.cache.DeletePattern(ctx, "party:*")
+	_ = s.cache.DeletePattern(ctx, "parties:*")
+	_ = s.cache.DeletePattern(ctx, candidatesCachePrefix+"by_coalition:*")
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/humfurie/pulpulitiko/api/pkg/htmltext"
+)
+
+// issueKeywords maps each issue to the words and phrases that show a
+// platform addresses it, in the order issues are listed. Filipino terms are
+// included since many platforms are written in Tagalog or Taglish.
+var issueKeywords = []struct {
+	slug     string
+	keywords []string
+}{
+	{"healthcare", []string{"health", "healthcare", "hospital", "hospitals", "medical", "medicine", "philhealth", "universal health care", "kalusugan", "doctors", "nurses"}},
+	{"education", []string{"education", "school", "schools", "teacher", "teachers", "scholarship", "scholarships", "classroom", "classrooms", "edukasyon", "paaralan", "students"}},
+	{"corruption", []string{"corruption", "corrupt", "graft", "transparency", "accountability", "anti-corruption", "katiwalian", "kurapsyon", "good governance"}},
+	{"environment", []string{"environment", "environmental", "climate", "climate change", "pollution", "reforestation", "renewable", "flood control", "kalikasan", "mining"}},
+	{"economy", []string{"economy", "economic", "jobs", "employment", "livelihood", "investment", "investments", "inflation", "trabaho", "hanapbuhay", "small business", "msme", "msmes"}},
+	{"agriculture", []string{"agriculture", "farmer", "farmers", "fisherfolk", "irrigation", "rice", "food security", "magsasaka", "mangingisda"}},
+	{"infrastructure", []string{"infrastructure", "roads", "bridges", "public transport", "transportation", "traffic", "housing", "pabahay"}},
+	{"peace-and-order", []string{"peace and order", "crime", "police", "illegal drugs", "drugs", "public safety", "insurgency", "kapayapaan"}},
+	{"social-welfare", []string{"poverty", "social welfare", "4ps", "senior citizens", "pwd", "pwds", "ayuda", "social services", "kahirapan"}},
+	{"labor", []string{"labor", "workers", "wages", "minimum wage", "contractualization", "endo", "ofw", "ofws", "manggagawa"}},
+}
+
+// PlatformAnalyzer finds the issues a candidate's platform addresses
+type PlatformAnalyzer struct{}
+
+// ExtractKeyIssues returns the slugs of the issues the platform mentions, in
+// issue list order. The platform may be HTML; matching is on whole words and
+// ignores case and punctuation.
+func (PlatformAnalyzer) ExtractKeyIssues(platform string) []string {
+	text := " " + strings.Join(tokenizePlatform(htmltext.ToPlainText(platform)), " ") + " "
+
+	issues := []string{}
+	for _, issue := range issueKeywords {
+		for _, keyword := range issue.keywords {
+			if strings.Contains(text, " "+keyword+" ") {
+				issues = append(issues, issue.slug)
+				break
+			}
+		}
+	}
+	return issues
+}
+
+// IssueSlugs lists every issue a platform can be matched against
+func (PlatformAnalyzer) IssueSlugs() []string {
+	slugs := make([]string, len(issueKeywords))
+	for i, issue := range issueKeywords {
+		slugs[i] = issue.slug
+	}
+	return slugs
+}
+
+// IsKnownIssue checks if slug is one of the issues platforms are matched against
+func (PlatformAnalyzer) IsKnownIssue(slug string) bool {
+	for _, issue := range issueKeywords {
+		if issue.slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenizePlatform lowercases text and splits it into words. Hyphens and
+// digits are kept so terms like "anti-corruption" and "4ps" survive.
+func tokenizePlatform(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+}
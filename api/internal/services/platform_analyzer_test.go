@@ -0,0 +1,61 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlatformAnalyzer_ExtractKeyIssues(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		want     []string
+	}{
+		{
+			name:     "empty platform",
+			platform: "",
+			want:     []string{},
+		},
+		{
+			name:     "issues are returned in list order",
+			platform: "Fight corruption and build more schools and hospitals.",
+			want:     []string{"healthcare", "education", "corruption"},
+		},
+		{
+			name:     "html is stripped and case ignored",
+			platform: "<h2>Priorities</h2><ul><li><strong>Climate Change</strong> adaptation</li><li>More TEACHERS</li></ul>",
+			want:     []string{"education", "environment"},
+		},
+		{
+			name:     "multi-word keywords",
+			platform: "Raise the minimum wage; ensure food security.",
+			want:     []string{"agriculture", "labor"},
+		},
+		{
+			name:     "whole words only",
+			platform: "A healthy schooling of the mind.",
+			want:     []string{},
+		},
+		{
+			name:     "hyphenated and filipino terms",
+			platform: "Anti-corruption at kalusugan para sa lahat.",
+			want:     []string{"healthcare", "corruption"},
+		},
+	}
+
+	var analyzer PlatformAnalyzer
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, analyzer.ExtractKeyIssues(tt.platform))
+		})
+	}
+}
+
+func TestPlatformAnalyzer_IsKnownIssue(t *testing.T) {
+	var analyzer PlatformAnalyzer
+	for _, slug := range analyzer.IssueSlugs() {
+		assert.True(t, analyzer.IsKnownIssue(slug), slug)
+	}
+	assert.False(t, analyzer.IsKnownIssue("weather"))
+}
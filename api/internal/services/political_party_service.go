@@ -11,14 +11,26 @@ import (
 )
 
 type PoliticalPartyService struct {
-	repo  *repository.PoliticalPartyRepository
-	cache *cache.RedisCache
+	repo      *repository.PoliticalPartyRepository
+	cache     *cache.RedisCache
+	uploads   *UploadService
+	locations LocationLookup
 }
 
 func NewPoliticalPartyService(repo *repository.PoliticalPartyRepository, cache *cache.RedisCache) *PoliticalPartyService {
 	return &PoliticalPartyService{repo: repo, cache: cache}
 }
 
+// SetUploadService enables logo uploads
+func (s *PoliticalPartyService) SetUploadService(uploads *UploadService) {
+	s.uploads = uploads
+}
+
+// SetLocationLookup enables checking that a jurisdiction's locations nest
+func (s *PoliticalPartyService) SetLocationLookup(locations LocationLookup) {
+	s.locations = locations
+}
+
 // Cache TTL
 const partyTTL = 24 * time.Hour
 
@@ -72,6 +84,9 @@ func (s *PoliticalPartyService) GetBySlug(ctx context.Context, slug string) (*mo
 	if result == nil {
 		return nil, nil
 	}
+	if err := s.loadRelations(ctx, result); err != nil {
+		return nil, err
+	}
 
 	_ = s.cache.Set(ctx, cacheKey, result, partyTTL)
 	return result, nil
@@ -186,6 +201,17 @@ func (s *PoliticalPartyService) DeletePosition(ctx context.Context, id uuid.UUID
 // Politician Jurisdiction methods
 
 func (s *PoliticalPartyService) CreateJurisdiction(ctx context.Context, req *models.CreatePoliticianJurisdictionRequest) (*models.PoliticianJurisdiction, error) {
+	if s.locations != nil {
+		err := validateLocationHierarchy(ctx, s.locations, locationRefs{
+			regionID:   req.RegionID,
+			provinceID: req.ProvinceID,
+			cityID:     req.CityID,
+			barangayID: req.BarangayID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 	return s.repo.CreateJurisdiction(ctx, req)
 }
 
@@ -205,3 +231,7 @@ func (s *PoliticalPartyService) DeleteAllJurisdictionsForPolitician(ctx context.
 func (s *PoliticalPartyService) FindRepresentativesByBarangay(ctx context.Context, barangayID uuid.UUID) ([]models.PoliticianListItem, error) {
 	return s.repo.FindRepresentativesByBarangay(ctx, barangayID)
 }
+
+func (s *PoliticalPartyService) FindRepresentativesByCity(ctx context.Context, cityID uuid.UUID) ([]models.PoliticianListItem, error) {
+	return s.repo.FindRepresentativesByCity(ctx, cityID)
+}
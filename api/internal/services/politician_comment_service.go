@@ -8,19 +8,22 @@ import (
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/repository"
+	"github.com/humfurie/pulpulitiko/api/pkg/sanitize"
 )
 
 type PoliticianCommentService struct {
 	repo                *repository.PoliticianCommentRepository
 	politicianRepo      *repository.PoliticianRepository
 	notificationService *NotificationService
+	blocks              BlockChecker
 }
 
-func NewPoliticianCommentService(repo *repository.PoliticianCommentRepository, politicianRepo *repository.PoliticianRepository, notificationService *NotificationService) *PoliticianCommentService {
+func NewPoliticianCommentService(repo *repository.PoliticianCommentRepository, politicianRepo *repository.PoliticianRepository, notificationService *NotificationService, blocks BlockChecker) *PoliticianCommentService {
 	return &PoliticianCommentService{
 		repo:                repo,
 		politicianRepo:      politicianRepo,
 		notificationService: notificationService,
+		blocks:              blocks,
 	}
 }
 
@@ -53,8 +56,13 @@ func (s *PoliticianCommentService) CreateComment(ctx context.Context, politician
 		if parentComment.PoliticianID != politician.ID {
 			return nil, fmt.Errorf("parent comment belongs to different politician")
 		}
+		if err := checkReplyBlock(ctx, s.blocks, userID, parentComment.UserID); err != nil {
+			return nil, err
+		}
 	}
 
+	req.Content = sanitize.SanitizeCommentText(req.Content)
+
 	// Determine initial status based on profanity check
 	status := models.CommentStatusActive
 	if containsPoliticianCommentProfanity(req.Content) {
@@ -142,7 +150,7 @@ func (s *PoliticianCommentService) UpdateComment(ctx context.Context, id uuid.UU
 		return nil, fmt.Errorf("not authorized to edit this comment")
 	}
 
-	if err := s.repo.Update(ctx, id, req.Content); err != nil {
+	if err := s.repo.Update(ctx, id, sanitize.SanitizeCommentText(req.Content)); err != nil {
 		return nil, err
 	}
 
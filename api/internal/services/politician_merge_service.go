@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+// PoliticianMergeStore is the persistence MergeService needs;
+// *repository.PoliticianRepository satisfies it
+type PoliticianMergeStore interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Politician, error)
+	MergePolitician(ctx context.Context, sourceID, targetID uuid.UUID) (*models.PoliticianMergeResult, error)
+	GetMergedTargetSlug(ctx context.Context, slug string) (string, error)
+}
+
+// MergeService combines duplicate politician records
+type MergeService struct {
+	store PoliticianMergeStore
+	cache *cache.RedisCache
+}
+
+func NewMergeService(store PoliticianMergeStore, cache *cache.RedisCache) *MergeService {
+	return &MergeService{
+		store: store,
+		cache: cache,
+	}
+}
+
+// MergePolitician merges the politician sourceID into targetID. The source's
+// records are copied rather than moved, and the source is soft-deleted so the
+// merge can be inspected or reverted by hand.
+func (s *MergeService) MergePolitician(ctx context.Context, sourceID, targetID uuid.UUID) (*models.PoliticianMergeResult, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("cannot merge a politician into itself")
+	}
+
+	source, err := s.store.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("politician not found")
+	}
+
+	target, err := s.store.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, fmt.Errorf("merge target not found")
+	}
+
+	result, err := s.store.MergePolitician(ctx, sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateMergeCache(ctx, source, result.TargetSlug)
+	return result, nil
+}
+
+// ResolveMergedSlug returns the slug a merged politician's old slug should
+// redirect to, or "" if the slug was never merged
+func (s *MergeService) ResolveMergedSlug(ctx context.Context, slug string) (string, error) {
+	cacheKey := cache.PoliticianMergedSlugKey(slug)
+	var target string
+	if s.cache != nil {
+		if err := s.cache.Get(ctx, cacheKey, &target); err == nil {
+			return target, nil
+		}
+	}
+
+	target, err := s.store.GetMergedTargetSlug(ctx, slug)
+	if err != nil {
+		return "", err
+	}
+
+	if s.cache != nil {
+		_ = s.cache.Set(ctx, cacheKey, target, time.Hour)
+	}
+	return target, nil
+}
+
+// invalidateMergeCache drops every cached politician entry, since both
+// profiles changed and earlier merges into the source now redirect elsewhere,
+// then records the source's redirect
+func (s *MergeService) invalidateMergeCache(ctx context.Context, source *models.Politician, targetSlug string) {
+	if s.cache == nil {
+		return
+	}
+	_ = s.cache.Delete(ctx, cache.PoliticiansKey())
+	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixPolitician+"*")
+	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixPoliticianList+"*")
+	_ = s.cache.Set(ctx, cache.PoliticianMergedSlugKey(source.Slug), targetSlug, time.Hour)
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMergeStore keeps active politicians in memory and records merges
+type fakeMergeStore struct {
+	active map[uuid.UUID]*models.Politician
+	merged map[string]string // old slug -> target slug
+	calls  int
+}
+
+func newFakeMergeStore(politicians ...*models.Politician) *fakeMergeStore {
+	store := &fakeMergeStore{active: map[uuid.UUID]*models.Politician{}, merged: map[string]string{}}
+	for _, p := range politicians {
+		store.active[p.ID] = p
+	}
+	return store
+}
+
+func (f *fakeMergeStore) GetByID(_ context.Context, id uuid.UUID) (*models.Politician, error) {
+	return f.active[id], nil
+}
+
+func (f *fakeMergeStore) MergePolitician(_ context.Context, sourceID, targetID uuid.UUID) (*models.PoliticianMergeResult, error) {
+	f.calls++
+	source, target := f.active[sourceID], f.active[targetID]
+	delete(f.active, sourceID)
+	f.merged[source.Slug] = target.Slug
+	return &models.PoliticianMergeResult{SourceID: sourceID, TargetID: targetID, TargetSlug: target.Slug}, nil
+}
+
+func (f *fakeMergeStore) GetMergedTargetSlug(_ context.Context, slug string) (string, error) {
+	return f.merged[slug], nil
+}
+
+func TestMergeService_MergePolitician(t *testing.T) {
+	ctx := context.Background()
+	newPolitician := func(slug string) *models.Politician {
+		return &models.Politician{ID: uuid.New(), Slug: slug}
+	}
+
+	t.Run("merges into the target", func(t *testing.T) {
+		dup, original := newPolitician("juan-cruz"), newPolitician("juan-dela-cruz")
+		store := newFakeMergeStore(dup, original)
+		svc := NewMergeService(store, nil)
+
+		result, err := svc.MergePolitician(ctx, dup.ID, original.ID)
+		require.NoError(t, err)
+		assert.Equal(t, dup.ID, result.SourceID)
+		assert.Equal(t, "juan-dela-cruz", result.TargetSlug)
+
+		target, err := svc.ResolveMergedSlug(ctx, "juan-cruz")
+		require.NoError(t, err)
+		assert.Equal(t, "juan-dela-cruz", target)
+	})
+
+	t.Run("rejects merging into itself", func(t *testing.T) {
+		p := newPolitician("maria-santos")
+		store := newFakeMergeStore(p)
+
+		_, err := NewMergeService(store, nil).MergePolitician(ctx, p.ID, p.ID)
+		assert.EqualError(t, err, "cannot merge a politician into itself")
+		assert.Zero(t, store.calls)
+	})
+
+	t.Run("source must exist", func(t *testing.T) {
+		target := newPolitician("maria-santos")
+		store := newFakeMergeStore(target)
+
+		_, err := NewMergeService(store, nil).MergePolitician(ctx, uuid.New(), target.ID)
+		assert.EqualError(t, err, "politician not found")
+		assert.Zero(t, store.calls)
+	})
+
+	t.Run("target must be active", func(t *testing.T) {
+		dup, original := newPolitician("a"), newPolitician("b")
+		store := newFakeMergeStore(dup, original)
+		svc := NewMergeService(store, nil)
+
+		_, err := svc.MergePolitician(ctx, original.ID, dup.ID)
+		require.NoError(t, err)
+
+		// original is now merged away, so nothing can be merged into it
+		_, err = svc.MergePolitician(ctx, dup.ID, original.ID)
+		assert.EqualError(t, err, "merge target not found")
+		assert.Equal(t, 1, store.calls)
+	})
+}
+
+func TestMergeService_ResolveMergedSlugUnmerged(t *testing.T) {
+	svc := NewMergeService(newFakeMergeStore(), nil)
+
+	target, err := svc.ResolveMergedSlug(context.Background(), "never-merged")
+	require.NoError(t, err)
+	assert.Empty(t, target)
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,8 +13,9 @@ import (
 )
 
 type PoliticianService struct {
-	repo  *repository.PoliticianRepository
-	cache *cache.RedisCache
+	repo    *repository.PoliticianRepository
+	cache   *cache.RedisCache
+	uploads *UploadService
 }
 
 func NewPoliticianService(repo *repository.PoliticianRepository, cache *cache.RedisCache) *PoliticianService {
@@ -22,6 +25,11 @@ func NewPoliticianService(repo *repository.PoliticianRepository, cache *cache.Re
 	}
 }
 
+// SetUploadService enables photo uploads
+func (s *PoliticianService) SetUploadService(uploads *UploadService) {
+	s.uploads = uploads
+}
+
 func (s *PoliticianService) Create(ctx context.Context, req *models.CreatePoliticianRequest) (*models.Politician, error) {
 	politician := &models.Politician{
 		Name:     req.Name,
@@ -30,6 +38,10 @@ func (s *PoliticianService) Create(ctx context.Context, req *models.CreatePoliti
 		Position: req.Position,
 		Party:    req.Party,
 		ShortBio: req.ShortBio,
+		Nickname: req.Nickname,
+	}
+	if politician.Nickname == nil {
+		politician.Nickname = models.ExtractNickname(req.Name)
 	}
 
 	if err := s.repo.Create(ctx, politician); err != nil {
@@ -82,6 +94,24 @@ func (s *PoliticianService) GetBySlug(ctx context.Context, slug string) (*models
 		return nil, nil
 	}
 
+	timeline, err := s.repo.GetCareerTimeline(ctx, result.ID)
+	if err != nil {
+		return nil, err
+	}
+	result.CareerTimeline = timeline
+
+	memberships, err := s.repo.GetPoliticianCommitteeMemberships(ctx, result.ID)
+	if err != nil {
+		return nil, err
+	}
+	result.CommitteeMemberships = memberships
+
+	socialLinks, err := s.repo.ListSocialLinks(ctx, result.ID)
+	if err != nil {
+		return nil, err
+	}
+	result.SocialAccounts = socialLinks
+
 	// Cache for 1 hour
 	_ = s.cache.Set(ctx, cacheKey, result, time.Hour)
 
@@ -112,15 +142,47 @@ func (s *PoliticianService) ListAll(ctx context.Context) ([]models.Politician, e
 	return result, nil
 }
 
-func (s *PoliticianService) Search(ctx context.Context, query string, limit int) ([]models.Politician, error) {
+// politicianSearchTTL is how long autocomplete results are cached
+const politicianSearchTTL = 60 * time.Second
+
+// Search finds politicians for autocomplete, caching results by the
+// lowercased, trimmed query
+func (s *PoliticianService) Search(ctx context.Context, query string, hasSocial *models.SocialPlatform, limit int) ([]models.Politician, error) {
 	if limit <= 0 {
 		limit = 10
 	}
-	return s.repo.Search(ctx, query, limit)
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	social := ""
+	if hasSocial != nil {
+		social = string(*hasSocial)
+	}
+	cacheKey := cache.PoliticianSearchKey(query, social, limit)
+
+	var politicians []models.Politician
+	if err := s.cache.Get(ctx, cacheKey, &politicians); err == nil {
+		return politicians, nil
+	}
+
+	politicians, err := s.repo.Search(ctx, query, hasSocial, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, politicians, politicianSearchTTL)
+
+	return politicians, nil
 }
 
-func (s *PoliticianService) Update(ctx context.Context, id uuid.UUID, req *models.UpdatePoliticianRequest) (*models.Politician, error) {
-	if err := s.repo.Update(ctx, id, req); err != nil {
+// Update edits a politician; changedBy is recorded in the politician's history
+func (s *PoliticianService) Update(ctx context.Context, id uuid.UUID, req *models.UpdatePoliticianRequest, changedBy *uuid.UUID) (*models.Politician, error) {
+	// A renamed politician picks up a nickname quoted in the new name unless
+	// one is given
+	if req.Nickname == nil && req.Name != nil {
+		req.Nickname = models.ExtractNickname(*req.Name)
+	}
+
+	if err := s.repo.Update(ctx, id, req, changedBy); err != nil {
 		return nil, err
 	}
 
@@ -158,6 +220,11 @@ func (s *PoliticianService) Restore(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// GetHistory returns the most recent changes to a politician's bio, newest first
+func (s *PoliticianService) GetHistory(ctx context.Context, politicianID uuid.UUID) ([]models.PoliticianHistoryEntry, error) {
+	return s.repo.GetHistory(ctx, politicianID, models.PoliticianHistoryLimit)
+}
+
 // GetArticleMentionedPoliticians returns the mentioned politicians for an article
 func (s *PoliticianService) GetArticleMentionedPoliticians(ctx context.Context, articleID uuid.UUID) ([]models.Politician, error) {
 	return s.repo.GetArticleMentionedPoliticians(ctx, articleID)
@@ -168,10 +235,115 @@ func (s *PoliticianService) SetArticleMentionedPoliticians(ctx context.Context,
 	return s.repo.SetArticleMentionedPoliticians(ctx, articleID, politicianIDs)
 }
 
+// ListTenures returns the tenures of a politician, most recent first
+func (s *PoliticianService) ListTenures(ctx context.Context, politicianID uuid.UUID) ([]models.PoliticianTenure, error) {
+	return s.repo.ListTenures(ctx, politicianID)
+}
+
+// GetTenure returns a tenure only if it belongs to the given politician
+func (s *PoliticianService) GetTenure(ctx context.Context, politicianID, tenureID uuid.UUID) (*models.PoliticianTenure, error) {
+	tenure, err := s.repo.GetTenureByID(ctx, tenureID)
+	if err != nil {
+		return nil, err
+	}
+	if tenure == nil || tenure.PoliticianID != politicianID {
+		return nil, nil
+	}
+	return tenure, nil
+}
+
+func (s *PoliticianService) CreateTenure(ctx context.Context, politicianID uuid.UUID, req *models.CreatePoliticianTenureRequest) (*models.PoliticianTenure, error) {
+	if err := validateTenureDates(req.StartedAt, req.EndedAt, req.IsCurrent); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.CreateTenure(ctx, politicianID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateProfileCache(ctx, politicianID)
+
+	return s.repo.GetTenureByID(ctx, id)
+}
+
+func (s *PoliticianService) UpdateTenure(ctx context.Context, tenure *models.PoliticianTenure, req *models.UpdatePoliticianTenureRequest) (*models.PoliticianTenure, error) {
+	// Validate against the merged result so partial updates can't produce an invalid range
+	startedAt := tenure.StartedAt.Format("2006-01-02")
+	if req.StartedAt != nil {
+		startedAt = *req.StartedAt
+	}
+	var endedAt *string
+	if tenure.EndedAt != nil {
+		formatted := tenure.EndedAt.Format("2006-01-02")
+		endedAt = &formatted
+	}
+	if req.EndedAt != nil {
+		endedAt = req.EndedAt
+	}
+	isCurrent := tenure.IsCurrent
+	if req.IsCurrent != nil {
+		isCurrent = *req.IsCurrent
+		if isCurrent && req.EndedAt == nil {
+			endedAt = nil
+		}
+	}
+	if err := validateTenureDates(startedAt, endedAt, isCurrent); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateTenure(ctx, tenure.ID, req); err != nil {
+		return nil, err
+	}
+
+	s.invalidateProfileCache(ctx, tenure.PoliticianID)
+
+	return s.repo.GetTenureByID(ctx, tenure.ID)
+}
+
+func (s *PoliticianService) DeleteTenure(ctx context.Context, tenure *models.PoliticianTenure) error {
+	if err := s.repo.DeleteTenure(ctx, tenure.ID); err != nil {
+		return err
+	}
+
+	s.invalidateProfileCache(ctx, tenure.PoliticianID)
+
+	return nil
+}
+
+// GetCurrentOfficeholders returns all politicians currently holding the given position
+func (s *PoliticianService) GetCurrentOfficeholders(ctx context.Context, positionID uuid.UUID) ([]models.Politician, error) {
+	return s.repo.GetCurrentOfficeholders(ctx, positionID)
+}
+
+func validateTenureDates(startedAt string, endedAt *string, isCurrent bool) error {
+	if endedAt == nil {
+		return nil
+	}
+	if isCurrent {
+		return fmt.Errorf("a current tenure cannot have an end date")
+	}
+	// YYYY-MM-DD strings compare chronologically
+	if *endedAt < startedAt {
+		return fmt.Errorf("ended_at cannot be before started_at")
+	}
+	return nil
+}
+
+// invalidateProfileCache clears the cached profile, including its career
+// timeline and social links
+func (s *PoliticianService) invalidateProfileCache(ctx context.Context, politicianID uuid.UUID) {
+	s.invalidatePoliticianCache(ctx, politicianID)
+	if politician, _ := s.repo.GetByID(ctx, politicianID); politician != nil {
+		_ = s.cache.Delete(ctx, cache.PoliticianSlugKey(politician.Slug))
+	}
+}
+
 func (s *PoliticianService) invalidatePoliticianCache(ctx context.Context, id uuid.UUID) {
 	_ = s.cache.Delete(ctx, cache.PoliticianKey(id.String()))
 	_ = s.cache.Delete(ctx, cache.PoliticiansKey())
 	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixPoliticianList+"*")
+	_ = s.cache.DeletePattern(ctx, cache.KeyPrefixPoliticianSearch+"*")
 }
 
 func (s *PoliticianService) invalidateCache(ctx context.Context) {
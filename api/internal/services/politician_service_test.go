@@ -0,0 +1,18 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTenureDates(t *testing.T) {
+	date := func(s string) *string { return &s }
+
+	assert.NoError(t, validateTenureDates("2019-06-30", nil, true))
+	assert.NoError(t, validateTenureDates("2019-06-30", date("2022-06-30"), false))
+	assert.NoError(t, validateTenureDates("2019-06-30", date("2019-06-30"), false))
+
+	assert.EqualError(t, validateTenureDates("2019-06-30", date("2019-06-29"), false), "ended_at cannot be before started_at")
+	assert.EqualError(t, validateTenureDates("2019-06-30", date("2022-06-30"), true), "a current tenure cannot have an end date")
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+)
+
+// Social Links
+
+func (s *PoliticianService) ListSocialLinks(ctx context.Context, politicianID uuid.UUID) ([]models.PoliticianSocialLink, error) {
+	return s.repo.ListSocialLinks(ctx, politicianID)
+}
+
+// GetSocialLink returns a social link of the politician, or nil if it has none with that ID
+func (s *PoliticianService) GetSocialLink(ctx context.Context, politicianID, id uuid.UUID) (*models.PoliticianSocialLink, error) {
+	return s.repo.GetSocialLink(ctx, politicianID, id)
+}
+
+func (s *PoliticianService) CreateSocialLink(ctx context.Context, politicianID uuid.UUID, req *models.CreatePoliticianSocialLinkRequest) (*models.PoliticianSocialLink, error) {
+	link := &models.PoliticianSocialLink{
+		PoliticianID: politicianID,
+		Platform:     req.Platform,
+		URL:          req.URL,
+		IsVerified:   req.IsVerified,
+	}
+	if err := s.validateSocialLink(ctx, link); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateSocialLink(ctx, link); err != nil {
+		return nil, err
+	}
+
+	s.invalidateProfileCache(ctx, politicianID)
+
+	return link, nil
+}
+
+func (s *PoliticianService) UpdateSocialLink(ctx context.Context, link *models.PoliticianSocialLink, req *models.UpdatePoliticianSocialLinkRequest) (*models.PoliticianSocialLink, error) {
+	updated := *link
+	if req.Platform != nil {
+		updated.Platform = *req.Platform
+	}
+	if req.URL != nil {
+		updated.URL = *req.URL
+	}
+	if req.IsVerified != nil {
+		updated.IsVerified = *req.IsVerified
+	}
+	if err := s.validateSocialLink(ctx, &updated); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateSocialLink(ctx, &updated); err != nil {
+		return nil, err
+	}
+
+	s.invalidateProfileCache(ctx, link.PoliticianID)
+
+	return &updated, nil
+}
+
+func (s *PoliticianService) DeleteSocialLink(ctx context.Context, link *models.PoliticianSocialLink) error {
+	if err := s.repo.DeleteSocialLink(ctx, link.PoliticianID, link.ID); err != nil {
+		return err
+	}
+
+	s.invalidateProfileCache(ctx, link.PoliticianID)
+
+	return nil
+}
+
+// validateSocialLink checks the link's URL suits its platform and that the
+// politician doesn't link it already
+func (s *PoliticianService) validateSocialLink(ctx context.Context, link *models.PoliticianSocialLink) error {
+	if err := models.ValidateSocialLinkURL(link.Platform, link.URL); err != nil {
+		return err
+	}
+
+	exists, err := s.repo.SocialLinkExists(ctx, link.PoliticianID, link.URL, link.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("politician already has this social link")
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
@@ -22,17 +23,26 @@ const (
 )
 
 type PollService struct {
-	repo  *repository.PollRepository
-	cache *cache.RedisCache
+	repo                *repository.PollRepository
+	cache               *cache.RedisCache
+	notificationService *NotificationService
+	voterSecret         []byte
 }
 
-func NewPollService(repo *repository.PollRepository, cache *cache.RedisCache) *PollService {
+func NewPollService(repo *repository.PollRepository, cache *cache.RedisCache, notificationService *NotificationService) *PollService {
 	return &PollService{
-		repo:  repo,
-		cache: cache,
+		repo:                repo,
+		cache:               cache,
+		notificationService: notificationService,
 	}
 }
 
+// SetVoterSecret sets the key for the hashed voter ids of signed-in votes on
+// anonymous polls
+func (s *PollService) SetVoterSecret(secret string) {
+	s.voterSecret = []byte(secret)
+}
+
 // Polls
 
 func (s *PollService) CreatePoll(ctx context.Context, userID uuid.UUID, req *models.CreatePollRequest) (*models.Poll, error) {
@@ -57,7 +67,8 @@ func (s *PollService) GetPollByID(ctx context.Context, id uuid.UUID, userID *uui
 
 	// Check if user has voted
 	if userID != nil || ipHash != nil {
-		hasVoted, optionID := s.repo.HasUserVoted(ctx, id, userID, ipHash)
+		voterID, voterHash := s.voterKeys(poll, userID, ipHash)
+		hasVoted, optionID := s.repo.HasUserVoted(ctx, id, voterID, voterHash)
 		if hasVoted {
 			poll.UserVote = optionID
 		}
@@ -70,6 +81,8 @@ func (s *PollService) GetPollByID(ctx context.Context, id uuid.UUID, userID *uui
 		}
 	}
 
+	poll.VotingOpen = poll.IsVotingOpen(time.Now().UTC())
+
 	return poll, nil
 }
 
@@ -84,7 +97,8 @@ func (s *PollService) GetPollBySlug(ctx context.Context, slug string, userID *uu
 
 	// Check if user has voted
 	if userID != nil || ipHash != nil {
-		hasVoted, optionID := s.repo.HasUserVoted(ctx, poll.ID, userID, ipHash)
+		voterID, voterHash := s.voterKeys(poll, userID, ipHash)
+		hasVoted, optionID := s.repo.HasUserVoted(ctx, poll.ID, voterID, voterHash)
 		if hasVoted {
 			poll.UserVote = optionID
 		}
@@ -97,6 +111,8 @@ func (s *PollService) GetPollBySlug(ctx context.Context, slug string, userID *uu
 		}
 	}
 
+	poll.VotingOpen = poll.IsVotingOpen(time.Now().UTC())
+
 	return poll, nil
 }
 
@@ -182,9 +198,9 @@ func (s *PollService) ApprovePoll(ctx context.Context, id uuid.UUID, approverID
 	return nil
 }
 
+// ClosePoll closes an open poll. Closing a poll that isn't open does nothing.
 func (s *PollService) ClosePoll(ctx context.Context, id uuid.UUID) error {
-	err := s.repo.ClosePoll(ctx, id)
-	if err != nil {
+	if _, err := s.repo.ClosePoll(ctx, id); err != nil {
 		return err
 	}
 
@@ -206,9 +222,57 @@ func (s *PollService) IncrementViewCount(ctx context.Context, id uuid.UUID) erro
 	return s.repo.IncrementViewCount(ctx, id)
 }
 
+// Scheduling
+
+// ActivateScheduledPolls activates scheduled polls whose start time has
+// arrived and returns how many it activated
+func (s *PollService) ActivateScheduledPolls(ctx context.Context) (int, error) {
+	ids, err := s.repo.ActivateScheduledPolls(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		s.invalidatePollCache(ctx, id)
+	}
+	return len(ids), nil
+}
+
+// ListExpiredPolls returns the open polls whose end time has passed
+func (s *PollService) ListExpiredPolls(ctx context.Context) ([]models.Poll, error) {
+	return s.repo.ListExpiredPolls(ctx)
+}
+
+// CloseExpiredPoll closes a poll whose end time has passed and notifies its
+// creator with the final results. Only the caller that actually closes the
+// poll notifies, so overlapping runs don't send the notification twice. It
+// reports whether the poll was closed by this call.
+func (s *PollService) CloseExpiredPoll(ctx context.Context, poll *models.Poll) (bool, error) {
+	closed, err := s.repo.ClosePoll(ctx, poll.ID)
+	if err != nil {
+		return false, err
+	}
+	if !closed {
+		return false, nil
+	}
+	s.invalidatePollCache(ctx, poll.ID)
+
+	if s.notificationService == nil {
+		return true, nil
+	}
+
+	// Notify even if the results lookup fails; the message degrades gracefully
+	results, _ := s.repo.GetPollResults(ctx, poll.ID)
+	if err := s.notificationService.CreatePollClosedNotification(ctx, poll, results); err != nil {
+		return true, fmt.Errorf("failed to notify poll creator: %w", err)
+	}
+	return true, nil
+}
+
 // Voting
 
-func (s *PollService) CastVote(ctx context.Context, pollID, optionID uuid.UUID, userID *uuid.UUID, ip string) (*models.VoteResponse, error) {
+// CastVote records a vote. regionID is the region the voter reports being
+// from; for polls scoped to a region it defaults to that region.
+func (s *PollService) CastVote(ctx context.Context, pollID, optionID uuid.UUID, userID *uuid.UUID, ip string, regionID *uuid.UUID) (*models.VoteResponse, error) {
 	// Get poll to check settings
 	poll, err := s.repo.GetPollByID(ctx, pollID)
 	if err != nil {
@@ -230,30 +294,34 @@ func (s *PollService) CastVote(ctx context.Context, pollID, optionID uuid.UUID,
 	}
 
 	// Check time constraints
-	now := time.Now()
+	now := time.Now().UTC()
 	if poll.StartsAt != nil && now.Before(*poll.StartsAt) {
 		return &models.VoteResponse{
 			Success: false,
 			Message: "This poll has not started yet",
 		}, nil
 	}
-	if poll.EndsAt != nil && now.After(*poll.EndsAt) {
+	if poll.EndsAt != nil && !now.Before(*poll.EndsAt) {
 		return &models.VoteResponse{
 			Success: false,
 			Message: "This poll has ended",
 		}, nil
 	}
 
-	// Hash IP for anonymous voting
+	// Anonymous polls keep only a hash of the voter
 	var ipHash *string
 	if poll.IsAnonymous && userID == nil {
-		hash := sha256.Sum256([]byte(ip + pollID.String()))
-		hashStr := hex.EncodeToString(hash[:])
-		ipHash = &hashStr
+		hash := HashIP(ip, pollID)
+		ipHash = &hash
+	}
+	userID, ipHash = s.voterKeys(poll, userID, ipHash)
+
+	if regionID == nil {
+		regionID = poll.RegionID
 	}
 
 	// Cast vote
-	err = s.repo.CastVote(ctx, pollID, optionID, userID, ipHash)
+	err = s.repo.CastVote(ctx, pollID, optionID, userID, ipHash, regionID)
 	if err != nil {
 		return &models.VoteResponse{
 			Success: false,
@@ -304,6 +372,33 @@ func (s *PollService) GetPollResults(ctx context.Context, pollID uuid.UUID) (*mo
 	return resultsPtr, nil
 }
 
+// GetVotingTimeline counts a poll's votes per hour or day, so sudden spikes
+// stand out
+func (s *PollService) GetVotingTimeline(ctx context.Context, slug, bucketSize string) ([]models.PollVoteBucket, error) {
+	poll, err := s.repo.GetPollBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if poll == nil {
+		return nil, fmt.Errorf("poll not found")
+	}
+
+	return s.repo.GetVotingTimeline(ctx, poll.ID, bucketSize)
+}
+
+// GetResultsByRegion breaks a poll's results down by the voters' regions
+func (s *PollService) GetResultsByRegion(ctx context.Context, slug string) ([]models.PollRegionResults, error) {
+	poll, err := s.repo.GetPollBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if poll == nil {
+		return nil, fmt.Errorf("poll not found")
+	}
+
+	return s.repo.GetResultsByRegion(ctx, poll.ID)
+}
+
 // Comments
 
 func (s *PollService) CreatePollComment(ctx context.Context, pollID, userID uuid.UUID, req *models.CreatePollCommentRequest) (*models.PollComment, error) {
@@ -326,6 +421,41 @@ func (s *PollService) invalidatePollCache(ctx context.Context, id uuid.UUID) {
 	_ = s.cache.DeletePattern(ctx, pollsCachePrefix+"*")
 }
 
+// voterKeys returns what identifies a voter on the poll. On anonymous polls a
+// signed-in voter is stored as a hash of their user id in place of the id, so
+// a vote can't be traced back to an account while a second vote from the same
+// account is still caught.
+//
+// The hash is keyed with the voter secret: without it, anyone with the
+// database could hash every user id and match the votes. Whoever holds the
+// secret can still do so, so this hides voters from database readers, not
+// from the operators. As before, a vote cast signed out is not matched to one
+// cast signed in.
+func (s *PollService) voterKeys(poll *models.Poll, userID *uuid.UUID, ipHash *string) (*uuid.UUID, *string) {
+	if !poll.IsAnonymous || userID == nil {
+		return userID, ipHash
+	}
+	hash := HashVoter(s.voterSecret, *userID, poll.ID)
+	return nil, &hash
+}
+
+// HashLegacyVoters hashes the signed-in votes on anonymous polls that were
+// stored by user id before voterKeys hashed them, so those voters are found
+// by hash like everyone else. It is safe to run on every start.
+func (s *PollService) HashLegacyVoters(ctx context.Context) (int, error) {
+	return s.repo.HashLegacyVoters(ctx, func(userID, pollID uuid.UUID) string {
+		return HashVoter(s.voterSecret, userID, pollID)
+	})
+}
+
+// HashVoter keys a hash of a user and poll id with secret. It is the same
+// length as HashIP so both fit the ip_hash column.
+func HashVoter(secret []byte, userID, pollID uuid.UUID) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("user:" + userID.String() + ":" + pollID.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // HashIP creates a hash of IP + poll ID for anonymous vote tracking
 func HashIP(ip string, pollID uuid.UUID) string {
 	hash := sha256.Sum256([]byte(ip + pollID.String()))
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoterKeys(t *testing.T) {
+	s := &PollService{}
+	s.SetVoterSecret("secret")
+	userID := uuid.New()
+	ipHash := HashIP("203.0.113.7", uuid.New())
+
+	public := &models.Poll{ID: uuid.New()}
+	gotUser, gotHash := s.voterKeys(public, &userID, nil)
+	assert.Equal(t, &userID, gotUser)
+	assert.Nil(t, gotHash)
+
+	anonymous := &models.Poll{ID: uuid.New(), IsAnonymous: true}
+	gotUser, gotHash = s.voterKeys(anonymous, &userID, nil)
+	assert.Nil(t, gotUser, "the user id must not be stored")
+	require.NotNil(t, gotHash)
+	assert.Len(t, *gotHash, 64)
+	assert.NotContains(t, *gotHash, userID.String())
+
+	// The same voter hashes the same way, so a second vote is caught
+	_, again := s.voterKeys(anonymous, &userID, nil)
+	assert.Equal(t, *gotHash, *again)
+
+	// but differently on another poll, so votes can't be linked across polls
+	_, elsewhere := s.voterKeys(&models.Poll{ID: uuid.New(), IsAnonymous: true}, &userID, nil)
+	assert.NotEqual(t, *gotHash, *elsewhere)
+
+	// A signed-out voter keeps their IP hash
+	gotUser, gotHash = s.voterKeys(anonymous, nil, &ipHash)
+	assert.Nil(t, gotUser)
+	assert.Equal(t, &ipHash, gotHash)
+}
+
+func TestHashVoterKeyed(t *testing.T) {
+	userID, pollID := uuid.New(), uuid.New()
+	assert.NotEqual(t, HashVoter([]byte("a"), userID, pollID), HashVoter([]byte("b"), userID, pollID))
+}
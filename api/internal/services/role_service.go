@@ -118,6 +118,29 @@ func (s *RoleService) HasPermission(ctx context.Context, roleID uuid.UUID, permi
 	return false, nil
 }
 
+// SetRolePermissions replaces a role's permissions with the given set and
+// returns the result. Permissions are looked up per request, so the change
+// applies to the role's users on their next request.
+func (s *RoleService) SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) ([]models.Permission, error) {
+	ids := make([]uuid.UUID, 0, len(permissionIDs))
+	seen := make(map[uuid.UUID]bool, len(permissionIDs))
+	for _, id := range permissionIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	permissions, err := s.roleRepo.SetPermissions(ctx, roleID, ids)
+	if err != nil {
+		return nil, err
+	}
+	if permissions == nil {
+		permissions = []models.Permission{}
+	}
+	return permissions, nil
+}
+
 // ListPermissions returns all permissions
 func (s *RoleService) ListPermissions(ctx context.Context) ([]models.Permission, error) {
 	return s.permissionRepo.List(ctx)
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/repository"
+	"github.com/humfurie/pulpulitiko/api/pkg/email"
+)
+
+// savedSearchAlertHours keeps a daily alert from re-sending when the job
+// runs again soon after, e.g. on restart
+const savedSearchAlertHours = 20
+
+type SavedSearchService struct {
+	repo           *repository.SavedSearchRepository
+	articleService *ArticleService
+	categoryRepo   *repository.CategoryRepository
+	emailService   *email.EmailService
+}
+
+func NewSavedSearchService(repo *repository.SavedSearchRepository, articleService *ArticleService, categoryRepo *repository.CategoryRepository, emailService *email.EmailService) *SavedSearchService {
+	return &SavedSearchService{
+		repo:           repo,
+		articleService: articleService,
+		categoryRepo:   categoryRepo,
+		emailService:   emailService,
+	}
+}
+
+func (s *SavedSearchService) Create(ctx context.Context, userID uuid.UUID, req *models.CreateSavedSearchRequest) (*models.SavedSearch, error) {
+	if err := req.Params.ValidatePublishedRange(); err != nil {
+		return nil, err
+	}
+	if req.Params.CategoryID != nil {
+		category, err := s.categoryRepo.GetByID(ctx, *req.Params.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if category == nil {
+			return nil, fmt.Errorf("category not found")
+		}
+	}
+
+	return s.repo.Create(ctx, userID, req)
+}
+
+func (s *SavedSearchService) List(ctx context.Context, userID uuid.UUID) ([]models.SavedSearch, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+func (s *SavedSearchService) Get(ctx context.Context, id, userID uuid.UUID) (*models.SavedSearch, error) {
+	return s.repo.GetByID(ctx, id, userID)
+}
+
+func (s *SavedSearchService) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	return s.repo.Delete(ctx, id, userID)
+}
+
+// Execute runs a saved search through the same ArticleService.List path as
+// the live admin article list. Broken searches are reported instead of run.
+func (s *SavedSearchService) Execute(ctx context.Context, id, userID uuid.UUID, page, perPage int) (*models.PaginatedArticles, error) {
+	search, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if search == nil {
+		return nil, fmt.Errorf("saved search not found")
+	}
+
+	if err := s.checkRunnable(ctx, search); err != nil {
+		return nil, err
+	}
+
+	return s.articleService.List(ctx, search.Params.Filter(), page, perPage)
+}
+
+// RunAlerts evaluates every due alerting search and emails owners whose
+// search has more results than its threshold. It returns the emails sent.
+func (s *SavedSearchService) RunAlerts(ctx context.Context) (int, error) {
+	if !s.emailService.IsConfigured() {
+		return 0, nil
+	}
+
+	alerts, err := s.repo.ListDueAlerts(ctx, savedSearchAlertHours)
+	if err != nil {
+		return 0, err
+	}
+
+	// A failed email should not hold up the other editors' alerts
+	sent := 0
+	var firstErr error
+	for _, alert := range alerts {
+		if err := s.checkRunnable(ctx, &alert.SavedSearch); err != nil {
+			continue
+		}
+
+		result, err := s.articleService.List(ctx, alert.Params.Filter(), 1, 1)
+		if err != nil {
+			return sent, err
+		}
+		if result.Total <= alert.AlertThreshold {
+			continue
+		}
+
+		if err := s.emailService.SendSavedSearchAlert(alert.OwnerEmail, alert.Name, alert.ID.String(), result.Total, alert.AlertThreshold); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		sent++
+		if err := s.repo.MarkAlerted(ctx, alert.ID); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return sent, firstErr
+}
+
+// checkRunnable returns an error for searches that are broken, marking them
+// broken first if a referenced category has gone missing since they were saved
+func (s *SavedSearchService) checkRunnable(ctx context.Context, search *models.SavedSearch) error {
+	if !search.IsBroken && search.Params.CategoryID != nil {
+		category, err := s.categoryRepo.GetByID(ctx, *search.Params.CategoryID)
+		if err != nil {
+			return err
+		}
+		if category == nil {
+			if err := s.repo.MarkBroken(ctx, search.ID, "category deleted"); err != nil {
+				return err
+			}
+			search.IsBroken = true
+		}
+	}
+
+	if search.IsBroken {
+		return fmt.Errorf("saved search is broken")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+// SearchCacheTTL is how long a page of search results is cached. An edit only
+// drops the searches related to the article, so this also bounds how long any
+// other search can miss the change.
+const SearchCacheTTL = 5 * time.Minute
+
+// maxSearchCacheTerms bounds how many words of a query a cached search is
+// tagged with
+const maxSearchCacheTerms = 10
+
+// SearchCacheInvalidator drops the cached searches an article edit is likely
+// to have changed. Each cached search is registered under a tag set per word
+// of its query, so finding them needs no key scan.
+type SearchCacheInvalidator struct {
+	cache *cache.RedisCache
+}
+
+func NewSearchCacheInvalidator(cache *cache.RedisCache) *SearchCacheInvalidator {
+	return &SearchCacheInvalidator{cache: cache}
+}
+
+// InvalidateRelated drops the cached searches for any word of the article's
+// tags or category, by name or slug. Without the article every cached search
+// is dropped.
+func (i *SearchCacheInvalidator) InvalidateRelated(ctx context.Context, article *models.Article) error {
+	if article == nil {
+		return i.cache.InvalidateTag(ctx, cache.TagSearches)
+	}
+	return i.cache.InvalidateTag(ctx, relatedSearchTags(article)...)
+}
+
+// searchCacheTags returns the tags a cached search for query is stored under.
+// Searches also belong to the article lists, so dropping every list drops
+// them too.
+func searchCacheTags(query string) []string {
+	tags := []string{cache.TagArticleLists, cache.TagSearches}
+	terms := searchTerms(query)
+	for _, term := range terms[:min(len(terms), maxSearchCacheTerms)] {
+		tags = append(tags, cache.SearchTermTag(term))
+	}
+	return tags
+}
+
+// relatedSearchTags returns the tags of the searches related to the article
+func relatedSearchTags(article *models.Article) []string {
+	var text []string
+	for _, tag := range article.Tags {
+		text = append(text, tag.Name, tag.Slug)
+	}
+	if article.Category != nil {
+		text = append(text, article.Category.Name, article.Category.Slug)
+	}
+
+	var tags []string
+	for _, term := range searchTerms(strings.Join(text, " ")) {
+		tags = append(tags, cache.SearchTermTag(term))
+	}
+	return tags
+}
+
+// searchTerms splits text into its distinct lowercase words of two or more
+// letters or digits, in order
+func searchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	var terms []string
+	for _, w := range words {
+		if len([]rune(w)) < 2 || seen[w] {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, w)
+	}
+	return terms
+}
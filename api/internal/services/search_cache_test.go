@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"senate", "budget", "2025"}, searchTerms("Senate budget, 2025 SENATE"))
+	assert.Equal(t, []string{"anti", "dynasty", "bill"}, searchTerms("anti-dynasty-bill"))
+	assert.Equal(t, []string{"cañao"}, searchTerms("a Cañao"))
+	assert.Empty(t, searchTerms(" - "))
+}
+
+func TestSearchCacheTags(t *testing.T) {
+	tags := searchCacheTags("Budget hearing")
+	assert.Equal(t, []string{
+		cache.TagArticleLists, cache.TagSearches,
+		cache.SearchTermTag("budget"), cache.SearchTermTag("hearing"),
+	}, tags)
+
+	long := searchCacheTags("a1 b2 c3 d4 e5 f6 g7 h8 i9 j10 k11 l12")
+	assert.Len(t, long, 2+maxSearchCacheTerms)
+}
+
+func TestRelatedSearchTags(t *testing.T) {
+	article := &models.Article{
+		Tags:     []models.Tag{{Name: "Budget", Slug: "budget"}, {Name: "Charter Change", Slug: "cha-cha"}},
+		Category: &models.Category{Name: "Senate", Slug: "senate"},
+	}
+	related := relatedSearchTags(article)
+
+	intersects := func(query string) bool {
+		for _, tag := range searchCacheTags(query) {
+			for _, r := range related {
+				if tag == r {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	// A search sharing a word with the article's tags or category is dropped
+	assert.True(t, intersects("budget deficit"))
+	assert.True(t, intersects("charter"))
+	assert.True(t, intersects("cha cha"))
+	assert.True(t, intersects("SENATE"))
+	// Others are left to expire
+	assert.False(t, intersects("typhoon relief"))
+
+	assert.Empty(t, relatedSearchTags(&models.Article{}))
+	assert.NotContains(t, related, cache.TagSearches)
+	assert.NotContains(t, related, cache.TagArticleLists)
+}
@@ -2,18 +2,21 @@ package services
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/internal/repository"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
 )
 
 type TagService struct {
-	repo *repository.TagRepository
+	repo  *repository.TagRepository
+	cache *cache.RedisCache
 }
 
-func NewTagService(repo *repository.TagRepository) *TagService {
-	return &TagService{repo: repo}
+func NewTagService(repo *repository.TagRepository, cache *cache.RedisCache) *TagService {
+	return &TagService{repo: repo, cache: cache}
 }
 
 func (s *TagService) Create(ctx context.Context, req *models.CreateTagRequest) (*models.Tag, error) {
@@ -33,8 +36,15 @@ func (s *TagService) GetByID(ctx context.Context, id uuid.UUID) (*models.Tag, er
 	return s.repo.GetByID(ctx, id)
 }
 
+// GetBySlug returns the tag with the slug, or the tag it was merged into
 func (s *TagService) GetBySlug(ctx context.Context, slug string) (*models.Tag, error) {
-	return s.repo.GetBySlug(ctx, slug)
+	return s.repo.GetBySlugOrAlias(ctx, slug)
+}
+
+// ResolveSlugRedirect returns the current slug for a tag that used to be
+// at oldSlug, or "" if it never was
+func (s *TagService) ResolveSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	return s.repo.GetSlugRedirect(ctx, oldSlug)
 }
 
 func (s *TagService) List(ctx context.Context) ([]models.Tag, error) {
@@ -56,6 +66,56 @@ func (s *TagService) Delete(ctx context.Context, id uuid.UUID) error {
 	return s.repo.Delete(ctx, id)
 }
 
+// Merge folds the source tag into the target, which takes over its articles,
+// followers and slug
+func (s *TagService) Merge(ctx context.Context, sourceID, targetID uuid.UUID) (*models.TagMergeResult, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("cannot merge a tag into itself")
+	}
+
+	articles, err := s.repo.Merge(ctx, sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cache != nil {
+		keys := make([]string, 0, 2*len(articles))
+		for _, a := range articles {
+			keys = append(keys, cache.ArticleKey(a.ID.String()), cache.ArticleSlugKey(a.Slug))
+		}
+		if len(keys) > 0 {
+			_ = s.cache.Delete(ctx, keys...)
+		}
+		_ = s.cache.InvalidateTag(ctx, cache.TagArticlesTag(sourceID.String()))
+		_ = s.cache.InvalidateTag(ctx, cache.TagArticlesTag(targetID.String()))
+	}
+
+	tag, err := s.repo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TagMergeResult{Tag: tag, ArticlesMoved: len(articles)}, nil
+}
+
 func (s *TagService) Restore(ctx context.Context, id uuid.UUID) error {
 	return s.repo.Restore(ctx, id)
 }
+
+func (s *TagService) Follow(ctx context.Context, tagID, userID uuid.UUID) (*models.FollowStatus, error) {
+	if err := s.repo.Follow(ctx, tagID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetFollowStatus(ctx, tagID, &userID)
+}
+
+func (s *TagService) Unfollow(ctx context.Context, tagID, userID uuid.UUID) (*models.FollowStatus, error) {
+	if err := s.repo.Unfollow(ctx, tagID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetFollowStatus(ctx, tagID, &userID)
+}
+
+// GetFollowStatus returns follower counts for everyone; userID is nil for anonymous viewers
+func (s *TagService) GetFollowStatus(ctx context.Context, tagID uuid.UUID, userID *uuid.UUID) (*models.FollowStatus, error) {
+	return s.repo.GetFollowStatus(ctx, tagID, userID)
+}
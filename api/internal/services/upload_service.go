@@ -6,17 +6,30 @@ import (
 	"io"
 	"mime/multipart"
 
+	"github.com/humfurie/pulpulitiko/api/internal/models"
 	"github.com/humfurie/pulpulitiko/api/pkg/storage"
 )
 
 type UploadService struct {
-	storage *storage.MinioStorage
+	storage       *storage.MinioStorage
+	featuredMedia FeaturedMediaLookup
+}
+
+// FeaturedMediaLookup finds the articles whose featured image is an upload
+type FeaturedMediaLookup interface {
+	ListArticlesFeaturingMedia(ctx context.Context, url string) ([]models.ArticleTitle, error)
 }
 
 func NewUploadService(storage *storage.MinioStorage) *UploadService {
 	return &UploadService{storage: storage}
 }
 
+// SetFeaturedMediaLookup stops DeleteUpload from removing an article's
+// featured image
+func (s *UploadService) SetFeaturedMediaLookup(lookup FeaturedMediaLookup) {
+	s.featuredMedia = lookup
+}
+
 func (s *UploadService) UploadFile(ctx context.Context, file multipart.File, header *multipart.FileHeader) (*storage.UploadResult, error) {
 	if header.Size > storage.GetMaxFileSize() {
 		return nil, fmt.Errorf("file size exceeds maximum allowed size of 10MB")
@@ -59,3 +72,25 @@ func (s *UploadService) DeleteFile(ctx context.Context, fileURL string) error {
 	}
 	return s.storage.Delete(ctx, key)
 }
+
+// DeleteUpload removes an uploaded file. An upload that is the featured
+// image of an article is kept and a MediaInUseError names the articles.
+func (s *UploadService) DeleteUpload(ctx context.Context, fileURL string) error {
+	if s.featuredMedia != nil {
+		articles, err := s.featuredMedia.ListArticlesFeaturingMedia(ctx, fileURL)
+		if err != nil {
+			return err
+		}
+		if len(articles) > 0 {
+			return &models.MediaInUseError{Articles: articles}
+		}
+	}
+
+	if err := s.DeleteFile(ctx, fileURL); err != nil {
+		if err.Error() == "invalid file URL" {
+			return err
+		}
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
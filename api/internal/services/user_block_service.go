@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/repository"
+)
+
+// BlockChecker reports whether one user has blocked another
+type BlockChecker interface {
+	IsBlocked(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error)
+}
+
+type UserBlockService struct {
+	repo     *repository.UserBlockRepository
+	userRepo *repository.UserRepository
+}
+
+func NewUserBlockService(repo *repository.UserBlockRepository, userRepo *repository.UserRepository) *UserBlockService {
+	return &UserBlockService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// BlockUser blocks the user with the given profile slug
+func (s *UserBlockService) BlockUser(ctx context.Context, blockerID uuid.UUID, slug string) error {
+	target, err := s.userRepo.GetUserBySlug(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if target == nil {
+		return fmt.Errorf("user not found")
+	}
+	if target.ID == blockerID {
+		return fmt.Errorf("you cannot block yourself")
+	}
+
+	return s.repo.Block(ctx, blockerID, target.ID)
+}
+
+// UnblockUser removes a block on the user with the given profile slug
+func (s *UserBlockService) UnblockUser(ctx context.Context, blockerID uuid.UUID, slug string) error {
+	target, err := s.userRepo.GetUserBySlug(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if target == nil {
+		return fmt.Errorf("user not found")
+	}
+
+	return s.repo.Unblock(ctx, blockerID, target.ID)
+}
+
+// ListBlocked returns the users the given user has blocked
+func (s *UserBlockService) ListBlocked(ctx context.Context, blockerID uuid.UUID) ([]models.BlockedUser, error) {
+	return s.repo.ListBlocked(ctx, blockerID)
+}
+
+// checkMessagingBlock returns an error when a block in either direction stops
+// senderID from messaging recipientID. blockedMessage is what the blocked
+// side sees, so it never names the blocker.
+func checkMessagingBlock(ctx context.Context, blocks BlockChecker, senderID, recipientID uuid.UUID, blockedMessage string) error {
+	blocked, err := blocks.IsBlocked(ctx, recipientID, senderID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return fmt.Errorf("%s", blockedMessage)
+	}
+
+	blocking, err := blocks.IsBlocked(ctx, senderID, recipientID)
+	if err != nil {
+		return err
+	}
+	if blocking {
+		return fmt.Errorf("unblock this user to send them messages")
+	}
+
+	return nil
+}
+
+// checkReplyBlock returns an error when the author of the parent comment has blocked the replier
+func checkReplyBlock(ctx context.Context, blocks BlockChecker, replierID, parentAuthorID uuid.UUID) error {
+	blocked, err := blocks.IsBlocked(ctx, parentAuthorID, replierID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return fmt.Errorf("you cannot reply to this comment")
+	}
+	return nil
+}
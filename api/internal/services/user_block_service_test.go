@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBlocks map[[2]uuid.UUID]bool
+
+func (f fakeBlocks) IsBlocked(_ context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	return f[[2]uuid.UUID{blockerID, blockedID}], nil
+}
+
+func (f fakeBlocks) block(blockerID, blockedID uuid.UUID) {
+	f[[2]uuid.UUID{blockerID, blockedID}] = true
+}
+
+func (f fakeBlocks) unblock(blockerID, blockedID uuid.UUID) {
+	delete(f, [2]uuid.UUID{blockerID, blockedID})
+}
+
+func TestCheckMessagingBlock(t *testing.T) {
+	ctx := context.Background()
+	alice, bob := uuid.New(), uuid.New()
+	blocks := fakeBlocks{}
+
+	require.NoError(t, checkMessagingBlock(ctx, blocks, bob, alice, "this conversation is read-only"))
+
+	blocks.block(alice, bob)
+
+	t.Run("blocked user cannot message the blocker", func(t *testing.T) {
+		err := checkMessagingBlock(ctx, blocks, bob, alice, "this conversation is read-only")
+		assert.EqualError(t, err, "this conversation is read-only")
+	})
+
+	t.Run("blocker is told to unblock first", func(t *testing.T) {
+		err := checkMessagingBlock(ctx, blocks, alice, bob, "this conversation is read-only")
+		assert.EqualError(t, err, "unblock this user to send them messages")
+	})
+
+	t.Run("unrelated users are unaffected", func(t *testing.T) {
+		assert.NoError(t, checkMessagingBlock(ctx, blocks, bob, uuid.New(), "this conversation is read-only"))
+	})
+
+	t.Run("unblocking restores messaging", func(t *testing.T) {
+		blocks.unblock(alice, bob)
+		assert.NoError(t, checkMessagingBlock(ctx, blocks, bob, alice, "this conversation is read-only"))
+		assert.NoError(t, checkMessagingBlock(ctx, blocks, alice, bob, "this conversation is read-only"))
+	})
+}
+
+func TestCheckReplyBlock(t *testing.T) {
+	ctx := context.Background()
+	alice, bob := uuid.New(), uuid.New()
+	blocks := fakeBlocks{}
+	blocks.block(alice, bob)
+
+	t.Run("blocked user cannot reply to the blocker", func(t *testing.T) {
+		assert.EqualError(t, checkReplyBlock(ctx, blocks, bob, alice), "you cannot reply to this comment")
+	})
+
+	t.Run("blocker can still reply to the blocked user", func(t *testing.T) {
+		assert.NoError(t, checkReplyBlock(ctx, blocks, alice, bob))
+	})
+
+	t.Run("unblocking restores replies", func(t *testing.T) {
+		blocks.unblock(alice, bob)
+		assert.NoError(t, checkReplyBlock(ctx, blocks, bob, alice))
+	})
+}
+
+func TestMentionNotificationSkipsBlockers(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	blocks := fakeBlocks{}
+	blocks.block(alice, bob)
+
+	// The block check runs before any repository access, so no repos are needed
+	service := NewNotificationService(nil, nil, nil, nil, blocks, nil)
+	assert.NoError(t, service.CreateMentionNotification(context.Background(), alice, bob, "article", nil, nil, nil, "Budget hearing"))
+}
+
+func TestConversationOtherParticipant(t *testing.T) {
+	owner, recipient := uuid.New(), uuid.New()
+
+	support := &models.Conversation{UserID: owner}
+	assert.Nil(t, support.OtherParticipant(owner))
+
+	direct := &models.Conversation{UserID: owner, RecipientID: &recipient}
+	assert.Equal(t, recipient, *direct.OtherParticipant(owner))
+	assert.Equal(t, owner, *direct.OtherParticipant(recipient))
+}
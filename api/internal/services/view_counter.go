@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/pkg/cache"
+)
+
+// View counts are accumulated in Redis counters and written to Postgres in
+// batches by the view count flush job. Reads of a single item add the views
+// that haven't been flushed yet, and each flush drops the cached copies of
+// the items it updated so the stored count is picked up.
+
+// addPendingViews adds the article's unflushed views to its stored count
+func (s *ArticleService) addPendingViews(ctx context.Context, article *models.Article) {
+	if pending, err := s.cache.Counter(cache.CounterArticleViews).Pending(ctx, article.ID.String()); err == nil {
+		article.ViewCount += int(pending)
+	}
+}
+
+// FlushViewCounts writes the accumulated article views and referrer tallies
+// to the database
+func (s *ArticleService) FlushViewCounts(ctx context.Context) error {
+	viewsErr := s.cache.Counter(cache.CounterArticleViews).Flush(ctx, func(ctx context.Context, deltas map[string]int64) error {
+		updated, err := s.repo.AddViewCounts(ctx, parseCounterIDs(deltas), nil)
+		if err != nil {
+			return err
+		}
+
+		for id, slug := range updated {
+			_ = s.cache.Delete(ctx, cache.ArticleKey(id.String()), cache.ArticleSlugKey(slug))
+		}
+		return nil
+	})
+
+	referrersErr := s.cache.Counter(cache.CounterArticleReferrers).Flush(ctx, func(ctx context.Context, deltas map[string]int64) error {
+		referrers := make([]models.ReferrerViews, 0, len(deltas))
+		for field, delta := range deltas {
+			if ref, ok := parseReferrerCounterField(field); ok && delta > 0 {
+				ref.Views = delta
+				referrers = append(referrers, ref)
+			}
+		}
+		if len(referrers) == 0 {
+			return nil
+		}
+
+		_, err := s.repo.AddViewCounts(ctx, nil, referrers)
+		return err
+	})
+
+	hourlyErr := s.cache.Counter(cache.CounterHourlyViews).Flush(ctx, func(ctx context.Context, deltas map[string]int64) error {
+		views := make(map[time.Time]int64, len(deltas))
+		for field, delta := range deltas {
+			if hour, err := time.Parse(hourlyViewsLayout, field); err == nil && delta > 0 {
+				views[hour] += delta
+			}
+		}
+		if len(views) == 0 {
+			return nil
+		}
+		return s.repo.AddHourlyViews(ctx, views)
+	})
+
+	return errors.Join(viewsErr, referrersErr, hourlyErr)
+}
+
+// hourlyViewsLayout formats the UTC hour a view was counted in as its
+// hourly views counter field
+const hourlyViewsLayout = "2006-01-02T15"
+
+// IncrementVoterEducationViewCount counts a view of the item. Views accumulate
+// in Redis until FlushViewCounts writes them to the database.
+func (s *ElectionService) IncrementVoterEducationViewCount(ctx context.Context, id uuid.UUID) error {
+	if err := s.cache.Counter(cache.CounterVoterEducationViews).Add(ctx, id.String(), 1); err != nil {
+		// Without Redis, count the view directly
+		return s.repo.IncrementVoterEducationViewCount(ctx, id)
+	}
+	return nil
+}
+
+// FlushViewCounts writes the accumulated voter education views to the database
+func (s *ElectionService) FlushViewCounts(ctx context.Context) error {
+	return s.cache.Counter(cache.CounterVoterEducationViews).Flush(ctx, func(ctx context.Context, deltas map[string]int64) error {
+		updated, err := s.repo.AddVoterEducationViewCounts(ctx, parseCounterIDs(deltas))
+		if err != nil {
+			return err
+		}
+
+		for _, slug := range updated {
+			_ = s.cache.Delete(ctx, voterEducationCachePrefix+"slug:"+slug)
+		}
+		return nil
+	})
+}
+
+// addPendingVoterEducationViews adds the item's unflushed views to its stored count
+func (s *ElectionService) addPendingVoterEducationViews(ctx context.Context, ve *models.VoterEducation) {
+	if pending, err := s.cache.Counter(cache.CounterVoterEducationViews).Pending(ctx, ve.ID.String()); err == nil {
+		ve.ViewCount += int(pending)
+	}
+}
+
+// parseCounterIDs converts counter fields holding IDs, dropping any that
+// don't parse or have nothing to add
+func parseCounterIDs(deltas map[string]int64) map[uuid.UUID]int64 {
+	views := make(map[uuid.UUID]int64, len(deltas))
+	for field, delta := range deltas {
+		id, err := uuid.Parse(field)
+		if err != nil || delta <= 0 {
+			continue
+		}
+		views[id] += delta
+	}
+	return views
+}
+
+// referrerCounterField identifies one article's views from one referrer on
+// one day. Hostnames can't contain "|", so it is safe as a separator.
+func referrerCounterField(articleID uuid.UUID, at time.Time, source models.ReferrerSource) string {
+	return strings.Join([]string{articleID.String(), at.Format("2006-01-02"), source.Type, source.Domain}, "|")
+}
+
+func parseReferrerCounterField(field string) (models.ReferrerViews, bool) {
+	parts := strings.SplitN(field, "|", 4)
+	if len(parts) != 4 {
+		return models.ReferrerViews{}, false
+	}
+
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return models.ReferrerViews{}, false
+	}
+	if _, err := time.Parse("2006-01-02", parts[1]); err != nil {
+		return models.ReferrerViews{}, false
+	}
+	if !slices.Contains(models.ReferrerTypes, parts[2]) {
+		return models.ReferrerViews{}, false
+	}
+
+	return models.ReferrerViews{
+		ArticleID: id,
+		Date:      parts[1],
+		Source:    models.ReferrerSource{Type: parts[2], Domain: parts[3]},
+	}, true
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferrerCounterField(t *testing.T) {
+	id := uuid.New()
+	at := time.Date(2025, time.May, 12, 23, 59, 0, 0, time.UTC)
+	source := models.ReferrerSource{Type: models.ReferrerSearch, Domain: "google.com"}
+
+	ref, ok := parseReferrerCounterField(referrerCounterField(id, at, source))
+	assert.True(t, ok)
+	assert.Equal(t, models.ReferrerViews{ArticleID: id, Date: "2025-05-12", Source: source}, ref)
+
+	direct, ok := parseReferrerCounterField(referrerCounterField(id, at, models.ReferrerSource{Type: models.ReferrerDirect}))
+	assert.True(t, ok)
+	assert.Equal(t, "", direct.Source.Domain)
+
+	for _, field := range []string{
+		"",
+		"not-a-uuid|2025-05-12|search|google.com",
+		id.String() + "|yesterday|search|google.com",
+		id.String() + "|2025-05-12|carrier-pigeon|",
+		id.String() + "|2025-05-12|search",
+	} {
+		_, ok := parseReferrerCounterField(field)
+		assert.False(t, ok, field)
+	}
+}
+
+func TestParseCounterIDs(t *testing.T) {
+	id := uuid.New()
+	views := parseCounterIDs(map[string]int64{
+		id.String():      4,
+		"garbage":        2,
+		uuid.NewString(): 0,
+	})
+
+	assert.Equal(t, map[uuid.UUID]int64{id: 4}, views)
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/humfurie/pulpulitiko/api/internal/repository"
+	"github.com/humfurie/pulpulitiko/api/pkg/webhook"
+)
+
+const (
+	// How many deliveries one dispatch run sends
+	webhookDispatchBatch = 50
+	// How long a claimed delivery is held before another run may retry it
+	webhookDeliveryLease = 2 * time.Minute
+)
+
+type WebhookService struct {
+	repo   *repository.WebhookRepository
+	client *webhook.Client
+}
+
+func NewWebhookService(repo *repository.WebhookRepository, client *webhook.Client) *WebhookService {
+	return &WebhookService{
+		repo:   repo,
+		client: client,
+	}
+}
+
+func (s *WebhookService) Create(ctx context.Context, req *models.CreateWebhookRequest) (*models.Webhook, error) {
+	if err := validateWebhookEvents(req.EventTypes); err != nil {
+		return nil, err
+	}
+
+	hook := &models.Webhook{
+		URL:         req.URL,
+		Secret:      req.Secret,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+		IsActive:    true,
+	}
+	if req.IsActive != nil {
+		hook.IsActive = *req.IsActive
+	}
+	if hook.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		hook.Secret = secret
+	}
+
+	if err := s.repo.Create(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	// The secret is shown this once
+	return hook, nil
+}
+
+func (s *WebhookService) GetByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error) {
+	hook, err := s.repo.GetByID(ctx, id)
+	if err != nil || hook == nil {
+		return hook, err
+	}
+	hook.Secret = ""
+	return hook, nil
+}
+
+func (s *WebhookService) List(ctx context.Context) ([]models.Webhook, error) {
+	hooks, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range hooks {
+		hooks[i].Secret = ""
+	}
+	return hooks, nil
+}
+
+// Update changes a webhook. It returns nil if there is no such webhook.
+func (s *WebhookService) Update(ctx context.Context, id uuid.UUID, req *models.UpdateWebhookRequest) (*models.Webhook, error) {
+	hook, err := s.repo.GetByID(ctx, id)
+	if err != nil || hook == nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		hook.URL = *req.URL
+	}
+	if req.Secret != nil {
+		hook.Secret = *req.Secret
+	}
+	if req.EventTypes != nil {
+		if err := validateWebhookEvents(req.EventTypes); err != nil {
+			return nil, err
+		}
+		hook.EventTypes = req.EventTypes
+	}
+	if req.Description != nil {
+		hook.Description = req.Description
+	}
+	if req.IsActive != nil {
+		hook.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Update(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	hook.Secret = ""
+	return hook, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, webhookID uuid.UUID, status *string, limit int) ([]models.WebhookDelivery, error) {
+	return s.repo.ListDeliveries(ctx, webhookID, status, limit)
+}
+
+// RetryDelivery requeues a dead-lettered delivery
+func (s *WebhookService) RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) error {
+	return s.repo.RequeueDelivery(ctx, webhookID, id)
+}
+
+// Emit queues an event for the webhooks subscribed to it. Delivery happens
+// later, off the request, so a slow or failing endpoint never holds up the
+// change that raised the event.
+func (s *WebhookService) Emit(ctx context.Context, eventType string, data interface{}) error {
+	event := &models.WebhookEvent{
+		ID:        uuid.New(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	_, err = s.repo.EnqueueEvent(ctx, event, payload)
+	return err
+}
+
+// DispatchDue sends the deliveries that are due. Failed deliveries are
+// retried with backoff and dead-lettered after MaxWebhookAttempts.
+func (s *WebhookService) DispatchDue(ctx context.Context) (delivered, failed int, err error) {
+	deliveries, err := s.repo.ClaimDueDeliveries(ctx, webhookDispatchBatch, webhookDeliveryLease)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i := range deliveries {
+		d := &deliveries[i]
+		statusCode, sendErr := s.client.Send(ctx, d.URL, d.Secret, d.EventID.String(), d.EventType, d.Payload)
+		if sendErr == nil {
+			if err := s.repo.MarkDelivered(ctx, d.ID, statusCode); err != nil {
+				return delivered, failed, err
+			}
+			delivered++
+			continue
+		}
+
+		failed++
+		var code *int
+		if statusCode != 0 {
+			code = &statusCode
+		}
+		status, nextAttemptAt := nextWebhookAttempt(d.Attempts+1, time.Now())
+		if err := s.repo.RecordFailure(ctx, d.ID, status, code, sendErr.Error(), nextAttemptAt); err != nil {
+			return delivered, failed, err
+		}
+	}
+
+	return delivered, failed, nil
+}
+
+// nextWebhookAttempt decides what happens to a delivery after its
+// attempts-th failure
+func nextWebhookAttempt(attempts int, now time.Time) (string, time.Time) {
+	if attempts >= models.MaxWebhookAttempts {
+		return models.WebhookDeliveryDead, now
+	}
+	return models.WebhookDeliveryPending, now.Add(models.WebhookRetryDelay(attempts))
+}
+
+func validateWebhookEvents(eventTypes []string) error {
+	for _, t := range eventTypes {
+		if !models.IsValidWebhookEvent(t) {
+			return fmt.Errorf("unknown event type: %s", t)
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextWebhookAttempt(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	status, at := nextWebhookAttempt(1, now)
+	assert.Equal(t, models.WebhookDeliveryPending, status)
+	assert.Equal(t, now.Add(time.Minute), at)
+
+	status, at = nextWebhookAttempt(3, now)
+	assert.Equal(t, models.WebhookDeliveryPending, status)
+	assert.Equal(t, now.Add(4*time.Minute), at)
+
+	status, _ = nextWebhookAttempt(models.MaxWebhookAttempts, now)
+	assert.Equal(t, models.WebhookDeliveryDead, status)
+}
+
+func TestValidateWebhookEvents(t *testing.T) {
+	assert.NoError(t, validateWebhookEvents([]string{models.WebhookEventArticlePublished, models.WebhookEventElectionResultsUpdated}))
+	assert.EqualError(t, validateWebhookEvents([]string{"article.published", "poll.closed"}), "unknown event type: poll.closed")
+}
@@ -0,0 +1,4 @@
+-- Rollback: 000015_topic_follows
+
+DROP TABLE IF EXISTS category_follows;
+DROP TABLE IF EXISTS tag_follows;
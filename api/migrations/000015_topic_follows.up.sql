@@ -0,0 +1,28 @@
+-- Migration: 000015_topic_follows
+-- Lets users follow tags and categories
+
+-- =====================================================
+-- TAG FOLLOWS
+-- =====================================================
+
+CREATE TABLE tag_follows (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, tag_id)
+);
+
+CREATE INDEX idx_tag_follows_tag ON tag_follows(tag_id);
+
+-- =====================================================
+-- CATEGORY FOLLOWS
+-- =====================================================
+
+CREATE TABLE category_follows (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, category_id)
+);
+
+CREATE INDEX idx_category_follows_category ON category_follows(category_id);
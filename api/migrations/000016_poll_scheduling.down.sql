@@ -0,0 +1,12 @@
+-- Rollback: 000016_poll_scheduling
+-- Note: enum values cannot be dropped, so 'scheduled' and 'poll_closed' remain defined.
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS poll_id;
+
+UPDATE polls SET status = 'active' WHERE status = 'scheduled';
+
+DROP INDEX IF EXISTS idx_polls_ends_at;
+
+ALTER TABLE polls
+    ALTER COLUMN starts_at TYPE TIMESTAMP USING starts_at AT TIME ZONE 'UTC',
+    ALTER COLUMN ends_at TYPE TIMESTAMP USING ends_at AT TIME ZONE 'UTC';
@@ -0,0 +1,25 @@
+-- Migration: 000016_poll_scheduling
+-- Scheduled/auto-closing polls and poll result notifications
+
+-- =====================================================
+-- POLL STATUS
+-- =====================================================
+
+-- Approved polls whose starts_at is still in the future
+ALTER TYPE poll_status ADD VALUE IF NOT EXISTS 'scheduled';
+
+-- Store poll windows as absolute instants so comparisons against NOW()
+-- don't depend on the session time zone. Existing values were written as UTC.
+ALTER TABLE polls
+    ALTER COLUMN starts_at TYPE TIMESTAMPTZ USING starts_at AT TIME ZONE 'UTC',
+    ALTER COLUMN ends_at TYPE TIMESTAMPTZ USING ends_at AT TIME ZONE 'UTC';
+
+CREATE INDEX idx_polls_ends_at ON polls(ends_at) WHERE ends_at IS NOT NULL AND deleted_at IS NULL;
+
+-- =====================================================
+-- POLL NOTIFICATIONS
+-- =====================================================
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'poll_closed';
+
+ALTER TABLE notifications ADD COLUMN poll_id UUID REFERENCES polls(id) ON DELETE CASCADE;
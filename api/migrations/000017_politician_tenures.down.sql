@@ -0,0 +1,3 @@
+-- Rollback: 000017_politician_tenures
+
+DROP TABLE IF EXISTS politician_tenures;
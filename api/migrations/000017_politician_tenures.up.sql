@@ -0,0 +1,24 @@
+-- Migration: 000017_politician_tenures
+-- Offices a politician has held over time, for career timelines
+
+CREATE TABLE politician_tenures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    politician_id UUID NOT NULL REFERENCES politicians(id) ON DELETE CASCADE,
+    position_id UUID NOT NULL REFERENCES government_positions(id) ON DELETE RESTRICT,
+    started_at DATE NOT NULL,
+    ended_at DATE,
+    election_id UUID REFERENCES elections(id) ON DELETE SET NULL,
+    appointment_reason TEXT,
+    is_current BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    CONSTRAINT check_tenure_dates CHECK (ended_at IS NULL OR ended_at >= started_at),
+    CONSTRAINT check_current_tenure_open CHECK (NOT is_current OR ended_at IS NULL)
+);
+
+CREATE INDEX idx_politician_tenures_politician ON politician_tenures(politician_id, started_at DESC);
+CREATE INDEX idx_politician_tenures_current_position ON politician_tenures(position_id) WHERE is_current = TRUE;
+
+CREATE TRIGGER update_politician_tenures_updated_at BEFORE UPDATE ON politician_tenures
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- Rollback: 000018_user_blocks
+
+DROP INDEX IF EXISTS idx_conversations_recipient;
+ALTER TABLE conversations DROP COLUMN IF EXISTS recipient_id;
+
+DROP TABLE IF EXISTS user_blocks;
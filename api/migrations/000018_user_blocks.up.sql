@@ -0,0 +1,25 @@
+-- Migration: 000018_user_blocks
+-- Lets users block each other and address conversations to another user
+
+-- =====================================================
+-- USER BLOCKS
+-- =====================================================
+
+CREATE TABLE user_blocks (
+    blocker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT user_blocks_not_self CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_user_blocks_blocked ON user_blocks(blocked_id);
+
+-- =====================================================
+-- DIRECT CONVERSATIONS
+-- =====================================================
+
+-- NULL recipient means a support conversation with the admin team
+ALTER TABLE conversations ADD COLUMN recipient_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_conversations_recipient ON conversations(recipient_id) WHERE recipient_id IS NOT NULL;
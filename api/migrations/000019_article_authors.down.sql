@@ -0,0 +1,3 @@
+-- Rollback: 000019_article_authors
+
+DROP TABLE IF EXISTS article_authors;
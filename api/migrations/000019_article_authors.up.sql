@@ -0,0 +1,13 @@
+-- Migration: 000019_article_authors
+-- Co-authors for articles; articles.author_id stays the primary byline
+
+CREATE TABLE article_authors (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES authors(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL CHECK (position >= 1),
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (article_id, author_id),
+    UNIQUE (article_id, position)
+);
+
+CREATE INDEX idx_article_authors_author ON article_authors(author_id);
@@ -0,0 +1,7 @@
+-- Rollback: 000020_bill_topic_hierarchy
+
+DROP INDEX IF EXISTS idx_bill_topics_parent;
+
+ALTER TABLE bill_topics
+    DROP CONSTRAINT IF EXISTS bill_topics_not_own_parent,
+    DROP COLUMN IF EXISTS parent_id;
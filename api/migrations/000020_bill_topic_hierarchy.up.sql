@@ -0,0 +1,8 @@
+-- Migration: 000020_bill_topic_hierarchy
+-- Self-referential parent for nesting bill topics
+
+ALTER TABLE bill_topics
+    ADD COLUMN parent_id UUID REFERENCES bill_topics(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT bill_topics_not_own_parent CHECK (parent_id <> id);
+
+CREATE INDEX idx_bill_topics_parent ON bill_topics(parent_id);
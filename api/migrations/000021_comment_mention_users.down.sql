@@ -0,0 +1,12 @@
+-- Rollback: 000021_comment_mention_users
+
+DROP INDEX IF EXISTS idx_comment_mentions_user;
+
+DELETE FROM comment_mentions WHERE mentioned_author_id IS NULL;
+
+ALTER TABLE comment_mentions
+    DROP CONSTRAINT IF EXISTS comment_mentions_comment_user_key,
+    DROP CONSTRAINT IF EXISTS comment_mentions_target,
+    DROP COLUMN IF EXISTS read_at,
+    DROP COLUMN IF EXISTS mentioned_user_id,
+    ALTER COLUMN mentioned_author_id SET NOT NULL;
@@ -0,0 +1,12 @@
+-- Migration: 000021_comment_mention_users
+-- Lets comment @mentions resolve to regular users as well as authors, with read state for the mentions inbox
+
+ALTER TABLE comment_mentions
+    ALTER COLUMN mentioned_author_id DROP NOT NULL,
+    ADD COLUMN mentioned_user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    ADD COLUMN read_at TIMESTAMP DEFAULT NULL,
+    ADD CONSTRAINT comment_mentions_target CHECK (mentioned_author_id IS NOT NULL OR mentioned_user_id IS NOT NULL),
+    ADD CONSTRAINT comment_mentions_comment_user_key UNIQUE (comment_id, mentioned_user_id);
+
+CREATE INDEX idx_comment_mentions_user ON comment_mentions(mentioned_user_id, created_at DESC)
+    WHERE mentioned_user_id IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Rollback: 000022_politician_merges
+
+DROP INDEX IF EXISTS idx_politicians_merged_into;
+
+ALTER TABLE politicians
+    DROP CONSTRAINT IF EXISTS politicians_not_merged_into_self,
+    DROP COLUMN IF EXISTS merged_into_id;
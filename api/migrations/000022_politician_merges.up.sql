@@ -0,0 +1,8 @@
+-- Migration: 000022_politician_merges
+-- Duplicate politicians are soft-deleted and point at the record they were merged into
+
+ALTER TABLE politicians
+    ADD COLUMN merged_into_id UUID REFERENCES politicians(id) ON DELETE SET NULL,
+    ADD CONSTRAINT politicians_not_merged_into_self CHECK (merged_into_id <> id);
+
+CREATE INDEX idx_politicians_merged_into ON politicians(merged_into_id) WHERE merged_into_id IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Rollback: 000023_saved_searches
+
+DROP TRIGGER IF EXISTS categories_mark_saved_searches_broken ON categories;
+DROP FUNCTION IF EXISTS mark_saved_searches_broken_for_category();
+DROP TABLE IF EXISTS saved_searches;
@@ -0,0 +1,41 @@
+-- Migration: 000023_saved_searches
+-- Saved admin article searches with optional daily count alerts
+
+CREATE TABLE saved_searches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}', -- AdminList filter parameters
+    alert_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    alert_threshold INTEGER NOT NULL DEFAULT 0 CHECK (alert_threshold >= 0),
+    is_broken BOOLEAN NOT NULL DEFAULT FALSE, -- a referenced record was deleted
+    broken_reason VARCHAR(200),
+    last_alerted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_saved_searches_user ON saved_searches(user_id, created_at DESC);
+CREATE INDEX idx_saved_searches_alerts ON saved_searches(last_alerted_at)
+    WHERE alert_enabled = TRUE AND is_broken = FALSE;
+
+CREATE TRIGGER update_saved_searches_updated_at BEFORE UPDATE ON saved_searches
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Deleting (or soft-deleting) a category breaks the searches filtering on it,
+-- so they are flagged instead of failing when run
+CREATE OR REPLACE FUNCTION mark_saved_searches_broken_for_category()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' OR (NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL) THEN
+        UPDATE saved_searches
+        SET is_broken = TRUE, broken_reason = 'category deleted'
+        WHERE params->>'category_id' = OLD.id::text AND is_broken = FALSE;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER categories_mark_saved_searches_broken
+    AFTER UPDATE OF deleted_at OR DELETE ON categories
+    FOR EACH ROW EXECUTE FUNCTION mark_saved_searches_broken_for_category();
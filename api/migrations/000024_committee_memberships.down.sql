@@ -0,0 +1,21 @@
+-- Rollback: 000024_committee_memberships
+
+ALTER TABLE committees
+    ADD COLUMN IF NOT EXISTS chairperson_id UUID REFERENCES politicians(id),
+    ADD COLUMN IF NOT EXISTS vice_chairperson_id UUID REFERENCES politicians(id);
+
+-- Restore the current chair and vice chair of each committee
+UPDATE committees c SET
+    chairperson_id = (
+        SELECT m.politician_id FROM committee_memberships m
+        WHERE m.committee_id = c.id AND m.role = 'chair' AND (m.end_date IS NULL OR m.end_date >= CURRENT_DATE)
+        ORDER BY m.start_date DESC NULLS LAST LIMIT 1
+    ),
+    vice_chairperson_id = (
+        SELECT m.politician_id FROM committee_memberships m
+        WHERE m.committee_id = c.id AND m.role = 'vice_chair' AND (m.end_date IS NULL OR m.end_date >= CURRENT_DATE)
+        ORDER BY m.start_date DESC NULLS LAST LIMIT 1
+    );
+
+DROP TABLE IF EXISTS committee_memberships;
+DROP TYPE IF EXISTS committee_role;
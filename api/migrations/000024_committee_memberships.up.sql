@@ -0,0 +1,38 @@
+-- Migration: 000024_committee_memberships
+-- Tracks every politician seated on a committee, replacing the single
+-- chairperson/vice-chairperson columns on committees
+
+CREATE TYPE committee_role AS ENUM (
+    'chair',
+    'vice_chair',
+    'member'
+);
+
+CREATE TABLE committee_memberships (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    committee_id UUID NOT NULL REFERENCES committees(id) ON DELETE CASCADE,
+    politician_id UUID NOT NULL REFERENCES politicians(id) ON DELETE CASCADE,
+    role committee_role NOT NULL DEFAULT 'member',
+    start_date DATE,
+    end_date DATE, -- NULL while the seat is still held
+    session_id UUID REFERENCES legislative_sessions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT committee_memberships_dates CHECK (end_date IS NULL OR start_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX idx_committee_memberships_committee ON committee_memberships(committee_id, role);
+CREATE INDEX idx_committee_memberships_politician ON committee_memberships(politician_id);
+
+CREATE TRIGGER update_committee_memberships_updated_at BEFORE UPDATE ON committee_memberships
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Carry the existing chair assignments over before dropping the columns
+INSERT INTO committee_memberships (committee_id, politician_id, role)
+SELECT id, chairperson_id, 'chair' FROM committees WHERE chairperson_id IS NOT NULL
+UNION ALL
+SELECT id, vice_chairperson_id, 'vice_chair' FROM committees WHERE vice_chairperson_id IS NOT NULL;
+
+ALTER TABLE committees
+    DROP COLUMN chairperson_id,
+    DROP COLUMN vice_chairperson_id;
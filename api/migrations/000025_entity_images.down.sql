@@ -0,0 +1,15 @@
+-- Rollback: 000025_entity_images
+
+ALTER TABLE political_parties
+    DROP CONSTRAINT IF EXISTS political_parties_logo_focal_range,
+    DROP COLUMN IF EXISTS logo_focal_y,
+    DROP COLUMN IF EXISTS logo_focal_x,
+    DROP COLUMN IF EXISTS logo_list,
+    DROP COLUMN IF EXISTS logo_thumb;
+
+ALTER TABLE politicians
+    DROP CONSTRAINT IF EXISTS politicians_photo_focal_range,
+    DROP COLUMN IF EXISTS photo_focal_y,
+    DROP COLUMN IF EXISTS photo_focal_x,
+    DROP COLUMN IF EXISTS photo_list,
+    DROP COLUMN IF EXISTS photo_thumb;
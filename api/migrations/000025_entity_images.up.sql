@@ -0,0 +1,23 @@
+-- Migration: 000025_entity_images
+-- Resized variants and crop focal points for politician photos and party logos.
+-- photo/logo keep the original upload; list queries prefer the smaller variant.
+
+ALTER TABLE politicians
+    ADD COLUMN photo_thumb VARCHAR(500),
+    ADD COLUMN photo_list VARCHAR(500),
+    ADD COLUMN photo_focal_x NUMERIC(5,2),
+    ADD COLUMN photo_focal_y NUMERIC(5,2),
+    ADD CONSTRAINT politicians_photo_focal_range CHECK (
+        (photo_focal_x IS NULL OR photo_focal_x BETWEEN 0 AND 100) AND
+        (photo_focal_y IS NULL OR photo_focal_y BETWEEN 0 AND 100)
+    );
+
+ALTER TABLE political_parties
+    ADD COLUMN logo_thumb VARCHAR(500),
+    ADD COLUMN logo_list VARCHAR(500),
+    ADD COLUMN logo_focal_x NUMERIC(5,2),
+    ADD COLUMN logo_focal_y NUMERIC(5,2),
+    ADD CONSTRAINT political_parties_logo_focal_range CHECK (
+        (logo_focal_x IS NULL OR logo_focal_x BETWEEN 0 AND 100) AND
+        (logo_focal_y IS NULL OR logo_focal_y BETWEEN 0 AND 100)
+    );
@@ -0,0 +1,3 @@
+-- Rollback: 000026_article_published_index
+
+DROP INDEX IF EXISTS idx_articles_published_live;
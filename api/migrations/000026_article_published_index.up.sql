@@ -0,0 +1,8 @@
+-- Migration: 000026_article_published_index
+-- Public article lists filter on status and publish date (including the
+-- published_after/published_before range); a partial index over live
+-- published articles keeps those scans small.
+
+CREATE INDEX IF NOT EXISTS idx_articles_published_live
+    ON articles (published_at DESC)
+    WHERE status = 'published' AND deleted_at IS NULL;
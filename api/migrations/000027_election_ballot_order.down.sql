@@ -0,0 +1,9 @@
+-- Rollback: 000027_election_ballot_order
+
+DROP INDEX IF EXISTS idx_election_positions_ballot;
+
+ALTER TABLE election_positions
+    DROP COLUMN IF EXISTS ballot_order,
+    DROP COLUMN IF EXISTS ballot_group;
+
+DROP TYPE IF EXISTS ballot_group;
@@ -0,0 +1,45 @@
+-- Migration: 000027_election_ballot_order
+-- Groups election positions into sample ballot sections and lets admins set
+-- the order of positions within each section
+
+CREATE TYPE ballot_group AS ENUM (
+    'national',
+    'regional',
+    'provincial',
+    'city',
+    'barangay'
+);
+
+ALTER TABLE election_positions
+    ADD COLUMN ballot_group ballot_group NOT NULL DEFAULT 'national',
+    ADD COLUMN ballot_order INTEGER NOT NULL DEFAULT 0;
+
+-- Municipal offices share the city section of the ballot
+UPDATE election_positions ep
+SET ballot_group = CASE gp.level
+        WHEN 'municipal' THEN 'city'
+        ELSE gp.level::text
+    END::ballot_group
+FROM government_positions gp
+WHERE ep.position_id = gp.id;
+
+-- Seed the order from the previous display order
+UPDATE election_positions ep
+SET ballot_order = ranked.n
+FROM (
+    SELECT ep2.id,
+           ROW_NUMBER() OVER (
+               PARTITION BY ep2.election_id, ep2.ballot_group
+               ORDER BY gp.display_order, COALESCE(r.name, pr.name, cm.name, b.name, cd.name, '')
+           ) AS n
+    FROM election_positions ep2
+    JOIN government_positions gp ON ep2.position_id = gp.id
+    LEFT JOIN regions r ON ep2.region_id = r.id
+    LEFT JOIN provinces pr ON ep2.province_id = pr.id
+    LEFT JOIN cities_municipalities cm ON ep2.city_municipality_id = cm.id
+    LEFT JOIN barangays b ON ep2.barangay_id = b.id
+    LEFT JOIN congressional_districts cd ON ep2.district_id = cd.id
+) ranked
+WHERE ep.id = ranked.id;
+
+CREATE INDEX idx_election_positions_ballot ON election_positions(election_id, ballot_group, ballot_order);
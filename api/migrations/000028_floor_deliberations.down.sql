@@ -0,0 +1,4 @@
+-- Rollback: 000028_floor_deliberations
+
+DROP TRIGGER IF EXISTS update_floor_deliberations_updated_at ON floor_deliberations;
+DROP TABLE IF EXISTS floor_deliberations;
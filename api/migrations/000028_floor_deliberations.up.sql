@@ -0,0 +1,26 @@
+-- Migration: 000028_floor_deliberations
+-- Stores floor deliberation transcripts for bills. Transcripts can run to
+-- hundreds of kilobytes, so they live apart from the bills table and are only
+-- loaded when asked for.
+
+CREATE TABLE floor_deliberations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    bill_id UUID NOT NULL REFERENCES bills(id) ON DELETE CASCADE,
+    session_date DATE NOT NULL,
+    chamber legislative_chamber NOT NULL,
+    transcript_text TEXT,
+    transcript_url VARCHAR(500),
+    is_third_reading BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT floor_deliberations_transcript CHECK (transcript_text IS NOT NULL OR transcript_url IS NOT NULL)
+);
+
+CREATE INDEX idx_floor_deliberations_bill ON floor_deliberations(bill_id, session_date);
+
+-- Full-text search within transcripts; queries must use the same expression
+CREATE INDEX idx_floor_deliberations_transcript_search ON floor_deliberations
+    USING GIN (to_tsvector('english', COALESCE(transcript_text, '')));
+
+CREATE TRIGGER update_floor_deliberations_updated_at BEFORE UPDATE ON floor_deliberations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
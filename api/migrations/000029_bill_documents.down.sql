@@ -0,0 +1,5 @@
+-- Rollback: 000029_bill_documents
+
+DROP INDEX IF EXISTS idx_bills_full_text_search;
+DROP TABLE IF EXISTS bill_documents;
+DROP TYPE IF EXISTS bill_document_type;
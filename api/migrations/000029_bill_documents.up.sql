@@ -0,0 +1,29 @@
+-- Migration: 000029_bill_documents
+-- PDF copies attached to bills (filed copies, committee reports, enrolled
+-- copies). Text extracted from them is added to bills.full_text, which bill
+-- search now matches through a full-text index.
+
+CREATE TYPE bill_document_type AS ENUM (
+    'filed_copy',
+    'committee_report',
+    'enrolled_copy'
+);
+
+CREATE TABLE bill_documents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    bill_id UUID NOT NULL REFERENCES bills(id) ON DELETE CASCADE,
+    document_type bill_document_type NOT NULL,
+    file_url VARCHAR(500) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    file_size BIGINT NOT NULL,
+    page_count INTEGER NOT NULL DEFAULT 0,
+    extraction_failed BOOLEAN NOT NULL DEFAULT FALSE, -- no text layer, e.g. a scanned copy
+    uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_bill_documents_bill ON bill_documents(bill_id, created_at);
+
+-- Bill search matches full text; queries must use the same expression
+CREATE INDEX idx_bills_full_text_search ON bills
+    USING GIN (to_tsvector('english', COALESCE(full_text, '')));
@@ -0,0 +1,4 @@
+-- Rollback: 000030_article_referrers
+
+DROP TABLE IF EXISTS article_referrers;
+DROP TYPE IF EXISTS referrer_type;
@@ -0,0 +1,23 @@
+-- Migration: 000030_article_referrers
+-- Daily article view counts by traffic source, recorded from the Referer
+-- header when a view is counted
+
+CREATE TYPE referrer_type AS ENUM (
+    'direct',
+    'search',
+    'social',
+    'internal',
+    'other'
+);
+
+CREATE TABLE article_referrers (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    view_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    referrer_type referrer_type NOT NULL,
+    referrer_domain VARCHAR(255) NOT NULL DEFAULT '', -- empty for direct traffic
+    view_count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (article_id, view_date, referrer_type, referrer_domain)
+);
+
+-- Site-wide summaries scan by date rather than by article
+CREATE INDEX idx_article_referrers_date ON article_referrers(view_date);
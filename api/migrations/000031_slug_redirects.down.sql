@@ -0,0 +1,7 @@
+-- Rollback: 000031_slug_redirects
+
+DROP TRIGGER IF EXISTS tags_slug_redirect ON tags;
+DROP TRIGGER IF EXISTS categories_slug_redirect ON categories;
+DROP TRIGGER IF EXISTS articles_slug_redirect ON articles;
+DROP FUNCTION IF EXISTS record_slug_redirect();
+DROP TABLE IF EXISTS slug_redirects;
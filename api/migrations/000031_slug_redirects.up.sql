@@ -0,0 +1,49 @@
+-- Migration: 000031_slug_redirects
+-- Remembers the previous slugs of articles, categories and tags so old URLs
+-- can redirect to the current one. A trigger keeps the table in step with
+-- every slug change.
+
+CREATE TABLE slug_redirects (
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('article', 'category', 'tag')),
+    old_slug VARCHAR(255) NOT NULL,
+    entity_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (entity_type, old_slug)
+);
+
+CREATE INDEX idx_slug_redirects_entity ON slug_redirects(entity_type, entity_id);
+
+-- Records OLD.slug as a redirect to the row. A slug that becomes live again,
+-- whether on the same row or another one, stops redirecting, so a redirect
+-- never points away from a live slug. The entity type is the trigger argument.
+CREATE OR REPLACE FUNCTION record_slug_redirect()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM slug_redirects
+    WHERE entity_type = TG_ARGV[0] AND old_slug = NEW.slug;
+
+    IF TG_OP = 'UPDATE' AND OLD.slug IS DISTINCT FROM NEW.slug THEN
+        INSERT INTO slug_redirects (entity_type, old_slug, entity_id)
+        VALUES (TG_ARGV[0], OLD.slug, NEW.id)
+        ON CONFLICT (entity_type, old_slug)
+        DO UPDATE SET entity_id = EXCLUDED.entity_id, created_at = NOW();
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER articles_slug_redirect
+    AFTER INSERT OR UPDATE OF slug ON articles
+    FOR EACH ROW
+    EXECUTE FUNCTION record_slug_redirect('article');
+
+CREATE TRIGGER categories_slug_redirect
+    AFTER INSERT OR UPDATE OF slug ON categories
+    FOR EACH ROW
+    EXECUTE FUNCTION record_slug_redirect('category');
+
+CREATE TRIGGER tags_slug_redirect
+    AFTER INSERT OR UPDATE OF slug ON tags
+    FOR EACH ROW
+    EXECUTE FUNCTION record_slug_redirect('tag');
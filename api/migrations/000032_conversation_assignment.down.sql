@@ -0,0 +1,10 @@
+-- Rollback: 000032_conversation_assignment
+-- Note: enum values cannot be dropped, so 'conversation_assigned' remains defined.
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS conversation_id;
+
+DROP INDEX IF EXISTS idx_conversations_assigned_to;
+
+ALTER TABLE conversations
+    DROP COLUMN IF EXISTS assigned_at,
+    DROP COLUMN IF EXISTS assigned_to;
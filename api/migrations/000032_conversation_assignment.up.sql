@@ -0,0 +1,21 @@
+-- Migration: 000032_conversation_assignment
+-- Assign support conversations to individual admins and notify them
+
+-- =====================================================
+-- ASSIGNMENT
+-- =====================================================
+
+ALTER TABLE conversations
+    ADD COLUMN assigned_to UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN assigned_at TIMESTAMPTZ;
+
+-- Per-admin queues only cover support conversations
+CREATE INDEX idx_conversations_assigned_to ON conversations(assigned_to, status) WHERE recipient_id IS NULL;
+
+-- =====================================================
+-- ASSIGNMENT NOTIFICATIONS
+-- =====================================================
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'conversation_assigned';
+
+ALTER TABLE notifications ADD COLUMN conversation_id UUID REFERENCES conversations(id) ON DELETE CASCADE;
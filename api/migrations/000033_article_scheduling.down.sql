@@ -0,0 +1,13 @@
+-- Rollback: 000033_article_scheduling
+
+DROP TABLE IF EXISTS article_schedule_adjustments;
+
+DROP INDEX IF EXISTS idx_articles_schedule;
+
+-- Queued articles go back to drafts, keeping their intended publish time
+UPDATE articles SET status = 'draft' WHERE status = 'scheduled';
+
+COMMENT ON COLUMN articles.status IS NULL;
+
+ALTER TABLE articles
+    ALTER COLUMN published_at TYPE TIMESTAMP USING published_at AT TIME ZONE 'UTC';
@@ -0,0 +1,34 @@
+-- Migration: 000033_article_scheduling
+-- Scheduled articles, publish-rate adjustments and the editorial timeline
+
+-- =====================================================
+-- ARTICLE SCHEDULING
+-- =====================================================
+
+-- Articles can now be 'scheduled': published automatically once published_at
+-- arrives. Store publish times as absolute instants so comparisons against
+-- NOW() don't depend on the session time zone. Existing values were written as UTC.
+ALTER TABLE articles
+    ALTER COLUMN published_at TYPE TIMESTAMPTZ USING published_at AT TIME ZONE 'UTC';
+
+COMMENT ON COLUMN articles.status IS 'draft, scheduled, published, archived';
+
+-- The scheduled publisher and the timeline only look at queued and published articles
+CREATE INDEX idx_articles_schedule ON articles(published_at)
+    WHERE status IN ('scheduled', 'published') AND deleted_at IS NULL;
+
+-- =====================================================
+-- SCHEDULE ADJUSTMENTS
+-- =====================================================
+
+-- Publish times moved by the scheduled publisher, e.g. to stay under the hourly cap
+CREATE TABLE article_schedule_adjustments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    original_publish_at TIMESTAMPTZ NOT NULL,
+    adjusted_publish_at TIMESTAMPTZ NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_article_schedule_adjustments_article ON article_schedule_adjustments(article_id, created_at DESC);
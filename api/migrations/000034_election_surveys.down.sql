@@ -0,0 +1,4 @@
+-- Rollback: 000034_election_surveys
+
+DROP TABLE IF EXISTS survey_data;
+DROP TABLE IF EXISTS election_surveys;
@@ -0,0 +1,41 @@
+-- Migration: 000034_election_surveys
+-- Pre-election surveys from polling firms, with per-candidate results for trend charts
+
+-- =====================================================
+-- SURVEYS
+-- =====================================================
+
+-- One published survey of a contested position. position_id is the election
+-- position (election_positions), so results can only name its candidates.
+CREATE TABLE election_surveys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    election_id UUID NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    position_id UUID NOT NULL REFERENCES election_positions(id) ON DELETE CASCADE,
+    survey_firm VARCHAR(200) NOT NULL,
+    conducted_start DATE NOT NULL,
+    conducted_end DATE NOT NULL,
+    sample_size INTEGER CHECK (sample_size > 0),
+    margin_of_error DECIMAL(4,2) CHECK (margin_of_error > 0),
+    published_at DATE,
+    source_url VARCHAR(500),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (conducted_end >= conducted_start)
+);
+
+CREATE INDEX idx_election_surveys_election ON election_surveys(election_id, conducted_end DESC);
+CREATE INDEX idx_election_surveys_position ON election_surveys(position_id, conducted_end);
+
+-- =====================================================
+-- SURVEY RESULTS
+-- =====================================================
+
+CREATE TABLE survey_data (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    survey_id UUID NOT NULL REFERENCES election_surveys(id) ON DELETE CASCADE,
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    percentage DECIMAL(5,2) NOT NULL CHECK (percentage >= 0 AND percentage <= 100),
+    rank INTEGER CHECK (rank > 0),
+    UNIQUE(survey_id, candidate_id)
+);
+
+CREATE INDEX idx_survey_data_candidate ON survey_data(candidate_id);
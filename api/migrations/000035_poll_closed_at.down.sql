@@ -0,0 +1,3 @@
+-- Rollback: 000035_poll_closed_at
+
+ALTER TABLE polls DROP COLUMN IF EXISTS closed_at;
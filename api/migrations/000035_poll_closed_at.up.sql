@@ -0,0 +1,7 @@
+-- Migration: 000035_poll_closed_at
+-- Record when a poll closed
+
+ALTER TABLE polls ADD COLUMN closed_at TIMESTAMPTZ;
+
+-- Best guess for polls closed before the column existed
+UPDATE polls SET closed_at = LEAST(COALESCE(ends_at, updated_at), updated_at) WHERE status = 'closed';
@@ -0,0 +1,6 @@
+-- Rollback: 000036_article_comment_settings
+
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS comments_premoderated,
+    DROP COLUMN IF EXISTS comments_locked_at,
+    DROP COLUMN IF EXISTS comments_enabled;
@@ -0,0 +1,7 @@
+-- Migration: 000036_article_comment_settings
+-- Per-article comment settings: disable, lock after a date, premoderate
+
+ALTER TABLE articles
+    ADD COLUMN comments_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN comments_locked_at TIMESTAMPTZ, -- No new root comments from readers from this time on
+    ADD COLUMN comments_premoderated BOOLEAN NOT NULL DEFAULT FALSE; -- Reader comments wait for approval
@@ -0,0 +1,3 @@
+-- Rollback: 000037_audit_logs
+
+DROP TABLE IF EXISTS audit_logs;
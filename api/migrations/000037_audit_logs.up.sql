@@ -0,0 +1,19 @@
+-- Migration: 000037_audit_logs
+-- Audit trail of admin impersonation, attributed to the real admin
+
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- Who actually acted
+    impersonated_user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- Whose session they acted in, if not their own
+    action VARCHAR(100) NOT NULL,
+    method VARCHAR(10),
+    route VARCHAR(255),
+    path TEXT,
+    status_code INTEGER,
+    ip_address VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_actor ON audit_logs(actor_id, created_at DESC);
+CREATE INDEX idx_audit_logs_impersonated_user ON audit_logs(impersonated_user_id, created_at DESC)
+    WHERE impersonated_user_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Rollback: 000038_politician_social_links
+
+DROP TABLE IF EXISTS politician_social_links;
+DROP TYPE IF EXISTS social_platform;
@@ -0,0 +1,26 @@
+-- Migration: 000038_politician_social_links
+-- Social media accounts and websites linked from politician profiles
+
+CREATE TYPE social_platform AS ENUM (
+    'facebook',
+    'twitter',
+    'instagram',
+    'youtube',
+    'tiktok',
+    'website'
+);
+
+CREATE TABLE politician_social_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    politician_id UUID NOT NULL REFERENCES politicians(id) ON DELETE CASCADE,
+    platform social_platform NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    is_verified BOOLEAN NOT NULL DEFAULT FALSE, -- Confirmed by an admin as the politician's own account
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_politician_social_links_url UNIQUE (politician_id, url)
+);
+
+CREATE INDEX idx_politician_social_links_politician ON politician_social_links(politician_id);
+-- Search filters politicians by linked platform
+CREATE INDEX idx_politician_social_links_platform ON politician_social_links(platform, politician_id);
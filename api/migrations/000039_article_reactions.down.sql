@@ -0,0 +1,3 @@
+-- Rollback: 000039_article_reactions
+
+DROP TABLE IF EXISTS article_reactions;
@@ -0,0 +1,13 @@
+-- Migration: 000039_article_reactions
+-- Reader reactions on articles, using the same reaction set as comments
+
+CREATE TABLE article_reactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reaction VARCHAR(50) NOT NULL, -- 'heart', 'thumbsup', 'thumbsdown', 'laugh', 'fire', 'eyes'
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (article_id, user_id) -- One reaction per user per article
+);
+
+CREATE INDEX idx_article_reactions_user ON article_reactions(user_id);
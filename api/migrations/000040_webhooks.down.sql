@@ -0,0 +1,4 @@
+-- Rollback: 000040_webhooks
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,3 @@
+-- Rollback: 000041_candidate_issues
+
+DROP TABLE IF EXISTS candidate_issues;
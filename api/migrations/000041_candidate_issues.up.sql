@@ -0,0 +1,10 @@
+-- Migration: 000041_candidate_issues
+-- Issues a candidate's platform addresses, extracted by keyword when the platform is saved
+
+CREATE TABLE candidate_issues (
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    issue_slug VARCHAR(50) NOT NULL, -- 'healthcare', 'education', 'corruption', ...
+    PRIMARY KEY (candidate_id, issue_slug)
+);
+
+CREATE INDEX idx_candidate_issues_issue ON candidate_issues(issue_slug);
@@ -0,0 +1,3 @@
+-- Rollback: 000042_barangay_search_index
+
+DROP INDEX IF EXISTS idx_barangays_name_search;
@@ -0,0 +1,5 @@
+-- Migration: 000042_barangay_search_index
+-- Full-text index for searching barangays by partial name
+
+CREATE INDEX IF NOT EXISTS idx_barangays_name_search
+ON barangays USING gin(to_tsvector('simple', name)) WHERE deleted_at IS NULL;
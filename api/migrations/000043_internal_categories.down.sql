@@ -0,0 +1,4 @@
+-- Rollback: 000043_internal_categories
+
+DROP INDEX IF EXISTS idx_categories_is_internal;
+ALTER TABLE categories DROP COLUMN IF EXISTS is_internal;
@@ -0,0 +1,6 @@
+-- Migration: 000043_internal_categories
+-- Internal categories (e.g. editorial notes) whose articles never appear publicly
+
+ALTER TABLE categories ADD COLUMN is_internal BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_categories_is_internal ON categories(id) WHERE is_internal;
@@ -0,0 +1,6 @@
+-- Rollback: 000044_data_freshness
+
+DROP INDEX IF EXISTS idx_elections_data_as_of;
+DROP INDEX IF EXISTS idx_bills_data_as_of;
+ALTER TABLE elections DROP COLUMN IF EXISTS data_as_of;
+ALTER TABLE bills DROP COLUMN IF EXISTS data_as_of;
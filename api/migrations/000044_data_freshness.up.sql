@@ -0,0 +1,18 @@
+-- Migration: 000044_data_freshness
+-- When each bill's and election's manually encoded data was last brought up to date
+
+ALTER TABLE bills ADD COLUMN data_as_of TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE elections ADD COLUMN data_as_of TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- Existing rows were last touched when they were last updated. The
+-- updated_at triggers are paused so the backfill doesn't reset updated_at.
+ALTER TABLE bills DISABLE TRIGGER update_bills_updated_at;
+UPDATE bills SET data_as_of = COALESCE(updated_at, created_at, NOW());
+ALTER TABLE bills ENABLE TRIGGER update_bills_updated_at;
+
+ALTER TABLE elections DISABLE TRIGGER update_elections_updated_at;
+UPDATE elections SET data_as_of = COALESCE(updated_at, created_at, NOW());
+ALTER TABLE elections ENABLE TRIGGER update_elections_updated_at;
+
+CREATE INDEX idx_bills_data_as_of ON bills(data_as_of) WHERE deleted_at IS NULL;
+CREATE INDEX idx_elections_data_as_of ON elections(data_as_of) WHERE deleted_at IS NULL;
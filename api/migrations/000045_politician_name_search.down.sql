@@ -0,0 +1,13 @@
+-- Rollback: 000045_politician_name_search
+
+DROP INDEX IF EXISTS idx_politicians_search_nickname_trgm;
+DROP INDEX IF EXISTS idx_politicians_search_name_trgm;
+
+ALTER TABLE politicians
+    DROP COLUMN IF EXISTS search_nickname,
+    DROP COLUMN IF EXISTS search_name,
+    DROP COLUMN IF EXISTS nickname;
+
+DROP FUNCTION IF EXISTS normalize_person_name(TEXT);
+
+-- pg_trgm is left installed; other database objects may come to rely on it
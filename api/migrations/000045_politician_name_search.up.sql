@@ -0,0 +1,38 @@
+-- Migration: 000045_politician_name_search
+-- Name search that copes with Filipino naming: quoted nicknames, suffixes and maternal surnames
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Lowercases a name, turns punctuation into spaces and drops generational
+-- suffixes, so "Romualdez-Marcos, Jr." and "romualdez marcos" compare equal.
+-- Queries are normalized with the same function.
+CREATE OR REPLACE FUNCTION normalize_person_name(name TEXT) RETURNS TEXT
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT btrim(regexp_replace(
+        regexp_replace(
+            regexp_replace(lower(name), '[^[:alnum:][:space:]]+', ' ', 'g'),
+            '\m(jr|sr|ii|iii|iv)\M', ' ', 'g'
+        ),
+        '\s+', ' ', 'g'
+    ))
+$$;
+
+ALTER TABLE politicians ADD COLUMN nickname VARCHAR(100);
+
+-- A nickname written into the name in quotes becomes the nickname
+UPDATE politicians
+SET nickname = btrim(substring(name FROM '["“]([^"”]+)["”]'))
+WHERE nickname IS NULL AND name ~ '["“][^"”]+["”]';
+
+-- The name without its quoted nickname, and the nickname, both normalized
+ALTER TABLE politicians
+    ADD COLUMN search_name TEXT GENERATED ALWAYS AS (
+        normalize_person_name(regexp_replace(name, '["“][^"”]*["”]', ' ', 'g'))
+    ) STORED,
+    ADD COLUMN search_nickname TEXT GENERATED ALWAYS AS (normalize_person_name(nickname)) STORED;
+
+CREATE INDEX idx_politicians_search_name_trgm
+ON politicians USING gin (search_name gin_trgm_ops) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_politicians_search_nickname_trgm
+ON politicians USING gin (search_nickname gin_trgm_ops) WHERE deleted_at IS NULL AND nickname IS NOT NULL;
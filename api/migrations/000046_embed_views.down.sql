@@ -0,0 +1,3 @@
+-- Rollback: 000046_embed_views
+
+DROP TABLE IF EXISTS embed_views;
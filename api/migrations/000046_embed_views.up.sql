@@ -0,0 +1,15 @@
+-- Migration: 000046_embed_views
+-- Daily views of embedded polls and election results by the domain of the
+-- page embedding them, counted apart from views on the site itself
+
+CREATE TABLE embed_views (
+    content_type VARCHAR(20) NOT NULL CHECK (content_type IN ('poll', 'election')),
+    content_id UUID NOT NULL, -- polls.id or elections.id, by content_type
+    view_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    referrer_domain VARCHAR(255) NOT NULL DEFAULT '', -- empty when the embedding page sent no referrer
+    view_count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (content_type, content_id, view_date, referrer_domain)
+);
+
+-- Reach reports scan by date rather than by item
+CREATE INDEX idx_embed_views_date ON embed_views(view_date);
@@ -0,0 +1,7 @@
+-- Rollback: 000047_poll_vote_analytics
+
+DROP INDEX IF EXISTS idx_poll_votes_poll_region;
+DROP INDEX IF EXISTS idx_poll_votes_poll_voted_at;
+
+ALTER TABLE poll_votes DROP COLUMN IF EXISTS region_id;
+ALTER TABLE poll_votes DROP COLUMN IF EXISTS voted_at;
@@ -0,0 +1,18 @@
+-- Migration: 000047_poll_vote_analytics
+-- When each poll vote was cast, as a timestamptz for bucketing, and the
+-- voter's region when known, for timeline and regional breakdowns
+
+ALTER TABLE poll_votes ADD COLUMN voted_at TIMESTAMPTZ;
+
+-- created_at is a plain TIMESTAMP written in UTC
+UPDATE poll_votes SET voted_at = COALESCE(created_at AT TIME ZONE 'UTC', NOW());
+
+ALTER TABLE poll_votes
+    ALTER COLUMN voted_at SET DEFAULT NOW(),
+    ALTER COLUMN voted_at SET NOT NULL;
+
+-- Reported by the voter, or the poll's own region for region-scoped polls
+ALTER TABLE poll_votes ADD COLUMN region_id UUID REFERENCES regions(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_poll_votes_poll_voted_at ON poll_votes(poll_id, voted_at);
+CREATE INDEX idx_poll_votes_poll_region ON poll_votes(poll_id, region_id);
@@ -0,0 +1,7 @@
+-- Rollback: 000048_location_trigram_search
+-- pg_trgm is left installed; politician name search uses it too
+
+DROP INDEX IF EXISTS idx_barangays_name_trgm;
+DROP INDEX IF EXISTS idx_cities_name_trgm;
+DROP INDEX IF EXISTS idx_provinces_name_trgm;
+DROP INDEX IF EXISTS idx_regions_name_trgm;
@@ -0,0 +1,10 @@
+-- Migration: 000048_location_trigram_search
+-- Trigram indexes so location search tolerates typos ("Cebuu") and ranks
+-- results by similarity
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_regions_name_trgm ON regions USING GIN (lower(name) gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX idx_provinces_name_trgm ON provinces USING GIN (lower(name) gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX idx_cities_name_trgm ON cities_municipalities USING GIN (lower(name) gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX idx_barangays_name_trgm ON barangays USING GIN (lower(name) gin_trgm_ops) WHERE deleted_at IS NULL;
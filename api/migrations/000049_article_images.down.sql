@@ -0,0 +1,5 @@
+-- Rollback: 000049_article_images
+
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS featured_image_full,
+    DROP COLUMN IF EXISTS featured_image_thumb;
@@ -0,0 +1,7 @@
+-- Migration: 000049_article_images
+-- WebP renditions of an article's featured image, made by the article image
+-- upload. featured_image keeps whatever URL the editor set.
+
+ALTER TABLE articles
+    ADD COLUMN featured_image_thumb VARCHAR(500),
+    ADD COLUMN featured_image_full VARCHAR(500);
@@ -0,0 +1,4 @@
+-- Rollback: 000050_article_sources
+
+DROP TABLE IF EXISTS article_sources;
+DROP TYPE IF EXISTS article_source_type;
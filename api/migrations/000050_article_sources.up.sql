@@ -0,0 +1,24 @@
+-- Migration: 000050_article_sources
+-- Sources an article is attributed to. accessed_at is a plain date: the day a
+-- source was read matters, the time of day does not.
+
+CREATE TYPE article_source_type AS ENUM (
+    'press_release',
+    'official_statement',
+    'interview',
+    'document',
+    'other'
+);
+
+CREATE TABLE article_sources (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    type article_source_type NOT NULL DEFAULT 'other',
+    title VARCHAR(500) NOT NULL,
+    url VARCHAR(1000),
+    accessed_at DATE,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_article_sources_article ON article_sources(article_id, created_at);
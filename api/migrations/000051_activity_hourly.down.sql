@@ -0,0 +1,4 @@
+-- Rollback: 000051_activity_hourly
+
+DROP INDEX IF EXISTS idx_poll_votes_voted_at;
+DROP TABLE IF EXISTS activity_hourly;
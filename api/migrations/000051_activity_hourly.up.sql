@@ -0,0 +1,14 @@
+-- Migration: 000051_activity_hourly
+-- Site-wide activity per UTC hour, for the metrics activity heatmap. Comment
+-- and poll vote counts are recounted from their tables by the activity rollup
+-- job; article views are added by the view count flush.
+
+CREATE TABLE activity_hourly (
+    hour TIMESTAMPTZ PRIMARY KEY,
+    comments INTEGER NOT NULL DEFAULT 0,
+    poll_votes INTEGER NOT NULL DEFAULT 0,
+    article_views INTEGER NOT NULL DEFAULT 0
+);
+
+-- The rollup recounts recent votes across all polls
+CREATE INDEX idx_poll_votes_voted_at ON poll_votes(voted_at);
@@ -0,0 +1,4 @@
+-- Rollback: 000052_article_access
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_member;
+ALTER TABLE articles DROP COLUMN IF EXISTS access_level;
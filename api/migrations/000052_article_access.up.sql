@@ -0,0 +1,9 @@
+-- Migration: 000052_article_access
+-- Members-only articles. Readers who are not members see a preview of them,
+-- after a few free reads a month.
+
+ALTER TABLE articles ADD COLUMN access_level VARCHAR(20) NOT NULL DEFAULT 'public'
+    CHECK (access_level IN ('public', 'members'));
+
+-- Set by admins
+ALTER TABLE users ADD COLUMN is_member BOOLEAN NOT NULL DEFAULT FALSE;
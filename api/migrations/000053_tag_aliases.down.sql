@@ -0,0 +1,3 @@
+-- Rollback: 000053_tag_aliases
+
+DROP TABLE IF EXISTS tag_aliases;
@@ -0,0 +1,11 @@
+-- Migration: 000053_tag_aliases
+-- Slugs of tags merged into another tag. Public tag lookups fall back to
+-- these, so links to a merged tag keep working.
+
+CREATE TABLE tag_aliases (
+    old_slug VARCHAR(255) PRIMARY KEY,
+    canonical_tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_tag_aliases_canonical ON tag_aliases(canonical_tag_id);
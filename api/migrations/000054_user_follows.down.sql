@@ -0,0 +1,34 @@
+-- Rollback: 000054_user_follows
+-- Tag and category follows go back to their own tables; politician and
+-- author follows are lost.
+
+CREATE TABLE tag_follows (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, tag_id)
+);
+
+CREATE INDEX idx_tag_follows_tag ON tag_follows(tag_id);
+
+CREATE TABLE category_follows (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, category_id)
+);
+
+CREATE INDEX idx_category_follows_category ON category_follows(category_id);
+
+INSERT INTO tag_follows (user_id, tag_id, created_at)
+SELECT f.user_id, f.entity_id, f.created_at
+FROM user_follows f JOIN tags t ON t.id = f.entity_id
+WHERE f.entity_type = 'tag';
+
+INSERT INTO category_follows (user_id, category_id, created_at)
+SELECT f.user_id, f.entity_id, f.created_at
+FROM user_follows f JOIN categories c ON c.id = f.entity_id
+WHERE f.entity_type = 'category';
+
+DROP TABLE IF EXISTS user_follows;
+DROP TYPE IF EXISTS follow_entity_type;
@@ -0,0 +1,24 @@
+-- Migration: 000054_user_follows
+-- One table for everything a user follows, feeding their personalized feed.
+-- Replaces tag_follows and category_follows, whose rows are carried over.
+
+CREATE TYPE follow_entity_type AS ENUM ('category', 'tag', 'politician', 'author');
+
+CREATE TABLE user_follows (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    entity_type follow_entity_type NOT NULL,
+    entity_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, entity_type, entity_id)
+);
+
+CREATE INDEX idx_user_follows_entity ON user_follows(entity_type, entity_id);
+
+INSERT INTO user_follows (user_id, entity_type, entity_id, created_at)
+SELECT user_id, 'tag', tag_id, COALESCE(created_at, NOW()) FROM tag_follows;
+
+INSERT INTO user_follows (user_id, entity_type, entity_id, created_at)
+SELECT user_id, 'category', category_id, COALESCE(created_at, NOW()) FROM category_follows;
+
+DROP TABLE tag_follows;
+DROP TABLE category_follows;
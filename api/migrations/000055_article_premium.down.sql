@@ -0,0 +1,8 @@
+-- Rollback: 000055_article_premium
+
+UPDATE users SET role_id = (SELECT id FROM roles WHERE slug = 'user')
+WHERE role_id = (SELECT id FROM roles WHERE slug = 'premium');
+DELETE FROM roles WHERE slug = 'premium';
+
+ALTER TABLE articles DROP COLUMN IF EXISTS premium_summary;
+ALTER TABLE articles DROP COLUMN IF EXISTS is_premium;
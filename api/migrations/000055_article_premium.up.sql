@@ -0,0 +1,10 @@
+-- Migration: 000055_article_premium
+-- Premium articles are read in full only by users with the premium role;
+-- everyone else gets the premium summary. No payments are taken yet.
+
+ALTER TABLE articles ADD COLUMN is_premium BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE articles ADD COLUMN premium_summary TEXT;
+
+INSERT INTO roles (name, slug, description, is_system) VALUES
+    ('Premium', 'premium', 'Reads premium articles in full', TRUE)
+ON CONFLICT (slug) DO NOTHING;
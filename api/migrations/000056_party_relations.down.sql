@@ -0,0 +1,4 @@
+-- Rollback: 000056_party_relations
+
+DROP TABLE IF EXISTS party_relations;
+DROP TYPE IF EXISTS party_relation_type;
@@ -0,0 +1,26 @@
+-- Migration: 000056_party_relations
+-- Coalitions, mergers and renamings between political parties. A coalition
+-- is itself a row in political_parties.
+--
+--   coalition_member_of: party_id was in the related_party_id coalition
+--   merged_into:         party_id merged into related_party_id
+--   renamed_from:        party_id is the new name of related_party_id
+
+CREATE TYPE party_relation_type AS ENUM ('coalition_member_of', 'merged_into', 'renamed_from');
+
+CREATE TABLE party_relations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    party_id UUID NOT NULL REFERENCES political_parties(id) ON DELETE CASCADE,
+    related_party_id UUID NOT NULL REFERENCES political_parties(id) ON DELETE CASCADE,
+    relation_type party_relation_type NOT NULL,
+    start_date DATE,
+    end_date DATE,
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (party_id <> related_party_id),
+    CHECK (end_date IS NULL OR start_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX idx_party_relations_party ON party_relations(party_id, relation_type);
+CREATE INDEX idx_party_relations_related ON party_relations(related_party_id, relation_type);
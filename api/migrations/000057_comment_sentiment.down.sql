@@ -0,0 +1,5 @@
+-- Rollback: 000057_comment_sentiment
+
+DROP INDEX IF EXISTS idx_comments_sentiment;
+ALTER TABLE comments DROP COLUMN IF EXISTS sentiment_score;
+DROP TABLE IF EXISTS sentiment_words;
@@ -0,0 +1,78 @@
+-- Migration: 000057_comment_sentiment
+-- Keyword sentiment scores for article comments, from -1 (very negative) to
+-- +1 (very positive). The word list is read when the API starts.
+
+CREATE TABLE sentiment_words (
+    word VARCHAR(50) PRIMARY KEY,
+    weight REAL NOT NULL CHECK (weight BETWEEN -1 AND 1),
+    language VARCHAR(3) NOT NULL DEFAULT 'en', -- en, fil
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE comments ADD COLUMN sentiment_score REAL;
+
+CREATE INDEX idx_comments_sentiment ON comments(sentiment_score) WHERE deleted_at IS NULL;
+
+INSERT INTO sentiment_words (word, weight, language) VALUES
+    ('good', 0.5, 'en'),
+    ('great', 0.7, 'en'),
+    ('excellent', 0.8, 'en'),
+    ('love', 0.7, 'en'),
+    ('best', 0.7, 'en'),
+    ('honest', 0.6, 'en'),
+    ('agree', 0.4, 'en'),
+    ('support', 0.4, 'en'),
+    ('thanks', 0.4, 'en'),
+    ('hope', 0.3, 'en'),
+    ('fair', 0.3, 'en'),
+    ('helpful', 0.5, 'en'),
+    ('proud', 0.6, 'en'),
+    ('bad', -0.5, 'en'),
+    ('worse', -0.6, 'en'),
+    ('worst', -0.8, 'en'),
+    ('terrible', -0.8, 'en'),
+    ('hate', -0.8, 'en'),
+    ('corrupt', -0.8, 'en'),
+    ('liar', -0.8, 'en'),
+    ('lies', -0.7, 'en'),
+    ('useless', -0.7, 'en'),
+    ('incompetent', -0.7, 'en'),
+    ('stupid', -0.8, 'en'),
+    ('disgusting', -0.8, 'en'),
+    ('shame', -0.6, 'en'),
+    ('disappointed', -0.5, 'en'),
+    ('fake', -0.6, 'en'),
+    ('thief', -0.8, 'en'),
+    ('maganda', 0.6, 'fil'),
+    ('ganda', 0.6, 'fil'),
+    ('mabuti', 0.5, 'fil'),
+    ('mahusay', 0.7, 'fil'),
+    ('magaling', 0.7, 'fil'),
+    ('galing', 0.7, 'fil'),
+    ('tapat', 0.6, 'fil'),
+    ('salamat', 0.4, 'fil'),
+    ('mabait', 0.5, 'fil'),
+    ('masaya', 0.6, 'fil'),
+    ('saludo', 0.7, 'fil'),
+    ('ayos', 0.4, 'fil'),
+    ('tama', 0.3, 'fil'),
+    ('bilib', 0.6, 'fil'),
+    ('suportado', 0.5, 'fil'),
+    ('pangit', -0.6, 'fil'),
+    ('masama', -0.6, 'fil'),
+    ('kurakot', -0.9, 'fil'),
+    ('magnanakaw', -0.9, 'fil'),
+    ('sinungaling', -0.8, 'fil'),
+    ('bobo', -0.8, 'fil'),
+    ('tanga', -0.8, 'fil'),
+    ('walanghiya', -0.9, 'fil'),
+    ('nakakahiya', -0.6, 'fil'),
+    ('nakakainis', -0.5, 'fil'),
+    ('nakakagalit', -0.7, 'fil'),
+    ('palpak', -0.6, 'fil'),
+    ('peke', -0.6, 'fil'),
+    ('trapo', -0.6, 'fil'),
+    ('buwaya', -0.7, 'fil'),
+    ('dismayado', -0.5, 'fil'),
+    ('galit', -0.5, 'fil')
+ON CONFLICT (word) DO NOTHING;
@@ -0,0 +1,6 @@
+-- Rollback: 000058_city_coordinates
+
+ALTER TABLE cities_municipalities
+    DROP CONSTRAINT IF EXISTS check_city_coordinates,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude;
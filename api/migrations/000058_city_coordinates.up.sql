@@ -0,0 +1,9 @@
+-- Migration: 000058_city_coordinates
+-- Coordinates of each city/municipality's town centre, imported with the PSGC
+-- data. A visitor's approximate GeoIP location is mapped to the nearest city
+-- to preselect location pickers.
+
+ALTER TABLE cities_municipalities
+    ADD COLUMN latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    ADD CONSTRAINT check_city_coordinates CHECK ((latitude IS NULL) = (longitude IS NULL));
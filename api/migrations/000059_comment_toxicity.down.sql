@@ -0,0 +1,10 @@
+-- Rollback: 000059_comment_toxicity
+
+DROP INDEX IF EXISTS idx_comments_toxicity_unscored;
+DROP INDEX IF EXISTS idx_comments_toxicity;
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS toxicity_next_attempt_at,
+    DROP COLUMN IF EXISTS toxicity_attempts,
+    DROP COLUMN IF EXISTS toxicity_scored_at,
+    DROP COLUMN IF EXISTS toxicity_provider,
+    DROP COLUMN IF EXISTS toxicity_score;
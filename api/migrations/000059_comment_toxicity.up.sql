@@ -0,0 +1,16 @@
+-- Migration: 000059_comment_toxicity
+-- Toxicity scores for article comments, from 0 (benign) to 1 (almost
+-- certainly abusive), and the scorer that produced them. Comments are scored
+-- after they are posted; a NULL score means not scored yet. Failed attempts
+-- are retried with backoff until toxicity_attempts runs out.
+
+ALTER TABLE comments
+    ADD COLUMN toxicity_score REAL CHECK (toxicity_score BETWEEN 0 AND 1),
+    ADD COLUMN toxicity_provider VARCHAR(50),
+    ADD COLUMN toxicity_scored_at TIMESTAMP,
+    ADD COLUMN toxicity_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN toxicity_next_attempt_at TIMESTAMP;
+
+CREATE INDEX idx_comments_toxicity ON comments(toxicity_score DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_comments_toxicity_unscored ON comments(created_at)
+    WHERE toxicity_scored_at IS NULL AND deleted_at IS NULL;
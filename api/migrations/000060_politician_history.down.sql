@@ -0,0 +1,3 @@
+-- Rollback: 000060_politician_history
+
+DROP TABLE IF EXISTS politician_history;
@@ -0,0 +1,16 @@
+-- Migration: 000060_politician_history
+-- One row per field changed by an edit to a politician's bio, photo or
+-- position, so a profile can be shown as it was on an earlier date. Dates are
+-- stored as YYYY-MM-DD text.
+
+CREATE TABLE politician_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    politician_id UUID NOT NULL REFERENCES politicians(id) ON DELETE CASCADE,
+    field_name VARCHAR(50) NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_politician_history_politician ON politician_history(politician_id, changed_at DESC);
@@ -0,0 +1,5 @@
+-- Rollback: 000061_location_results
+
+DROP TABLE IF EXISTS election_result_rollup_status;
+DROP TABLE IF EXISTS election_result_rollups;
+DROP TABLE IF EXISTS candidate_location_results;
@@ -0,0 +1,43 @@
+-- Migration: 000061_location_results
+-- Votes per candidate by city or municipality, optionally down to the
+-- barangay, imported from CSV. A city row without a barangay is the city's
+-- total; cities with only barangay rows are totalled from them.
+--
+-- Province and region rollups are precomputed into election_result_rollups
+-- by a background job after each import.
+
+CREATE TABLE candidate_location_results (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    city_municipality_id UUID NOT NULL REFERENCES cities_municipalities(id) ON DELETE CASCADE,
+    barangay_id UUID REFERENCES barangays(id) ON DELETE CASCADE,
+    votes INTEGER NOT NULL CHECK (votes >= 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE NULLS NOT DISTINCT (candidate_id, city_municipality_id, barangay_id)
+);
+
+CREATE INDEX idx_candidate_location_results_city ON candidate_location_results(city_municipality_id);
+
+CREATE TABLE election_result_rollups (
+    election_position_id UUID NOT NULL REFERENCES election_positions(id) ON DELETE CASCADE,
+    level VARCHAR(10) NOT NULL CHECK (level IN ('city', 'province', 'region')),
+    location_id UUID NOT NULL,
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    votes INTEGER NOT NULL,
+    total_votes INTEGER NOT NULL, -- All candidates for the position in the location
+    rank INTEGER NOT NULL,
+    PRIMARY KEY (election_position_id, level, location_id, candidate_id)
+);
+
+CREATE INDEX idx_election_result_rollups_rank ON election_result_rollups(election_position_id, level, rank);
+CREATE INDEX idx_election_result_rollups_location ON election_result_rollups(level, location_id);
+
+-- An import bumps imported_version; the rollup job catches computed_version
+-- up to it. Both lock this row first, so they never interleave.
+CREATE TABLE election_result_rollup_status (
+    election_id UUID PRIMARY KEY REFERENCES elections(id) ON DELETE CASCADE,
+    imported_version BIGINT NOT NULL DEFAULT 0,
+    computed_version BIGINT NOT NULL DEFAULT 0,
+    imported_at TIMESTAMP,
+    computed_at TIMESTAMP
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_candidates_position_ballot_number;
@@ -0,0 +1,19 @@
+-- Migration: 000062_candidate_ballot_number_unique
+-- A ballot number belongs to one candidate per position. Candidates without
+-- a number yet (before the draw) don't conflict. Where a position already
+-- repeats a number, the earliest candidate keeps it and the rest are cleared
+-- to be set again.
+
+UPDATE candidates c
+SET ballot_number = NULL
+WHERE c.ballot_number IS NOT NULL
+  AND EXISTS (
+    SELECT 1 FROM candidates earlier
+    WHERE earlier.election_position_id = c.election_position_id
+      AND earlier.ballot_number = c.ballot_number
+      AND (earlier.created_at, earlier.id) < (c.created_at, c.id)
+  );
+
+CREATE UNIQUE INDEX idx_candidates_position_ballot_number
+    ON candidates(election_position_id, ballot_number)
+    WHERE ballot_number IS NOT NULL;
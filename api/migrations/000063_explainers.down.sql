@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS article_explainers;
+DROP TABLE IF EXISTS explainers;
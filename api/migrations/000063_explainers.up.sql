@@ -0,0 +1,31 @@
+-- Migration: 000063_explainers
+-- Reusable background boxes embedded in article content with an
+-- <!--explainer:slug--> token. article_explainers records which articles
+-- embed which slugs, so cached articles can be dropped when an explainer
+-- changes. It is keyed by slug, as tokens are, so it also covers tokens
+-- written before their explainer exists.
+
+CREATE TABLE explainers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR(300) NOT NULL,
+    slug VARCHAR(200) UNIQUE NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_explainers_updated_at BEFORE UPDATE ON explainers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE article_explainers (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    explainer_slug VARCHAR(200) NOT NULL,
+    PRIMARY KEY (article_id, explainer_slug)
+);
+
+CREATE INDEX idx_article_explainers_slug ON article_explainers(explainer_slug);
+
+INSERT INTO article_explainers (article_id, explainer_slug)
+SELECT DISTINCT a.id, m[1]
+FROM articles a,
+     regexp_matches(a.content, '<!--\s*explainer:([a-z0-9]+(?:-[a-z0-9]+)*)\s*-->', 'g') AS m;
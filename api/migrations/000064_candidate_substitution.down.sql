@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_candidates_position_ballot_number;
+
+-- Substitutes share their ballot number with the candidate they replaced
+UPDATE candidates SET ballot_number = NULL WHERE status = 'substituted';
+
+CREATE UNIQUE INDEX idx_candidates_position_ballot_number
+    ON candidates(election_position_id, ballot_number)
+    WHERE ballot_number IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_candidates_substituted_by;
+
+ALTER TABLE candidates
+    DROP COLUMN IF EXISTS substitution_reason,
+    DROP COLUMN IF EXISTS substituted_by;
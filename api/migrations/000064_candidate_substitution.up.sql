@@ -0,0 +1,16 @@
+-- Migration: 000064_candidate_substitution
+-- A substituted candidate links to the candidate who replaced them. The
+-- substitute takes over the ballot number, so the substituted candidate no
+-- longer holds it. substitution_reason records why an admin allowed a
+-- substitute from another party.
+
+ALTER TABLE candidates
+    ADD COLUMN substituted_by UUID REFERENCES candidates(id) ON DELETE SET NULL,
+    ADD COLUMN substitution_reason TEXT;
+
+CREATE INDEX idx_candidates_substituted_by ON candidates(substituted_by) WHERE substituted_by IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_candidates_position_ballot_number;
+CREATE UNIQUE INDEX idx_candidates_position_ballot_number
+    ON candidates(election_position_id, ballot_number)
+    WHERE ballot_number IS NOT NULL AND status <> 'substituted';
@@ -0,0 +1,30 @@
+-- Migration: 000065_article_media
+-- An article's image gallery, with the captions and photographer credits
+-- that must be shown alongside each image. At most one item is featured;
+-- its URLs are copied to the article's featured_image columns, so lists and
+-- feeds keep reading them from articles. Provisional items were picked by
+-- the backfill from the article content and await an editor's review.
+
+CREATE TABLE article_media (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    url VARCHAR(500) NOT NULL,
+    thumb_url VARCHAR(500),
+    full_url VARCHAR(500),
+    caption TEXT,
+    credit VARCHAR(300),
+    position INT NOT NULL DEFAULT 0,
+    is_featured BOOLEAN NOT NULL DEFAULT FALSE,
+    is_provisional BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_article_media_updated_at BEFORE UPDATE ON article_media
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE INDEX idx_article_media_article ON article_media(article_id, position);
+CREATE UNIQUE INDEX idx_article_media_featured ON article_media(article_id) WHERE is_featured;
+CREATE INDEX idx_article_media_url ON article_media(url);
+CREATE INDEX idx_article_media_thumb_url ON article_media(thumb_url) WHERE thumb_url IS NOT NULL;
+CREATE INDEX idx_article_media_full_url ON article_media(full_url) WHERE full_url IS NOT NULL;
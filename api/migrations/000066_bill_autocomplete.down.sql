@@ -0,0 +1,5 @@
+-- Rollback: 000066_bill_autocomplete
+-- pg_trgm is left installed; politician and location search use it too
+
+DROP INDEX IF EXISTS idx_bills_title_trgm;
+DROP INDEX IF EXISTS idx_bills_number_prefix;
@@ -0,0 +1,9 @@
+-- Migration: 000066_bill_autocomplete
+-- Indexes for bill autocomplete. Bill numbers are matched by prefix with
+-- spaces removed, so "HB123" finds "HB 1234"; text_pattern_ops lets a btree
+-- serve LIKE 'prefix%'. Titles are matched anywhere, which needs trigrams.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_bills_number_prefix ON bills (lower(replace(bill_number, ' ', '')) text_pattern_ops) WHERE deleted_at IS NULL;
+CREATE INDEX idx_bills_title_trgm ON bills USING GIN (title gin_trgm_ops) WHERE deleted_at IS NULL;
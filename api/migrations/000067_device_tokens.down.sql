@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS device_tokens;
+DROP TYPE IF EXISTS device_platform;
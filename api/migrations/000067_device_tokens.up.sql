@@ -0,0 +1,18 @@
+-- Migration: 000067_device_tokens
+-- Firebase Cloud Messaging registration tokens for push notifications. A
+-- token identifies one app install; it moves to whoever signed in on the
+-- device last. last_seen_at is bumped each time the app registers it, so
+-- tokens of apps that stopped checking in can be skipped.
+
+CREATE TYPE device_platform AS ENUM ('ios', 'android', 'web');
+
+CREATE TABLE device_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    platform device_platform NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_device_tokens_user ON device_tokens(user_id, last_seen_at DESC);
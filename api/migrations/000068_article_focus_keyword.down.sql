@@ -0,0 +1,3 @@
+-- Rollback: 000068_article_focus_keyword
+
+ALTER TABLE articles DROP COLUMN IF EXISTS focus_keyword;
@@ -0,0 +1,5 @@
+-- Migration: 000068_article_focus_keyword
+-- The word or phrase an article is meant to rank for. Editors check how often
+-- it appears against the recommended keyword density.
+
+ALTER TABLE articles ADD COLUMN focus_keyword VARCHAR(100);
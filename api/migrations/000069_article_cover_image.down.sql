@@ -0,0 +1,3 @@
+-- Rollback: 000069_article_cover_image
+
+ALTER TABLE articles DROP COLUMN IF EXISTS cover_image;
@@ -0,0 +1,5 @@
+-- Migration: 000069_article_cover_image
+-- The image shown on article cards, in link previews and as the RSS
+-- enclosure. Articles without one fall back to their featured image.
+
+ALTER TABLE articles ADD COLUMN cover_image VARCHAR(500);
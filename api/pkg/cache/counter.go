@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// counterFlushLockTTL bounds how long a crashed flush can block the next one
+const counterFlushLockTTL = 2 * time.Minute
+
+// Counter accumulates increments in a Redis hash, one field per counted
+// item, so hot paths don't write to Postgres on every request. Flush hands
+// the accumulated deltas to the database in one batch.
+type Counter struct {
+	client *redis.Client
+	key    string
+}
+
+// Counter returns the counter stored under key
+func (c *RedisCache) Counter(key string) *Counter {
+	return &Counter{client: c.client, key: key}
+}
+
+// flushingKey holds the deltas taken by a flush until they are written, so
+// increments arriving during the flush go to a fresh hash
+func (c *Counter) flushingKey() string {
+	return c.key + ":flushing"
+}
+
+// Add increments field by delta
+func (c *Counter) Add(ctx context.Context, field string, delta int64) error {
+	return c.client.HIncrBy(ctx, c.key, field, delta).Err()
+}
+
+// Pending returns the increments of field not yet written to the database,
+// including any taken by a flush that hasn't finished
+func (c *Counter) Pending(ctx context.Context, field string) (int64, error) {
+	pipe := c.client.Pipeline()
+	current := pipe.HGet(ctx, c.key, field)
+	flushing := pipe.HGet(ctx, c.flushingKey(), field)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, cmd := range []*redis.StringCmd{current, flushing} {
+		n, err := cmd.Int64()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// Flush takes the accumulated deltas and passes them to write. The deltas are
+// only discarded once write succeeds; after a failure they are retried by the
+// next flush. Only one instance flushes a counter at a time.
+func (c *Counter) Flush(ctx context.Context, write func(ctx context.Context, deltas map[string]int64) error) error {
+	lockKey := c.key + ":lock"
+	locked, err := c.client.SetNX(ctx, lockKey, 1, counterFlushLockTTL).Result()
+	if err != nil {
+		return fmt.Errorf("failed to lock counter: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+	defer c.client.Del(context.WithoutCancel(ctx), lockKey)
+
+	raw, err := takeCounterScript.Run(ctx, c.client, []string{c.key, c.flushingKey()}).StringSlice()
+	if err != nil {
+		return fmt.Errorf("failed to read counter: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+
+	deltas := make(map[string]int64, len(raw)/2)
+	for i := 0; i+1 < len(raw); i += 2 {
+		n, err := strconv.ParseInt(raw[i+1], 10, 64)
+		if err != nil {
+			continue
+		}
+		deltas[raw[i]] += n
+	}
+
+	if err := write(ctx, deltas); err != nil {
+		return err
+	}
+
+	return c.client.Del(context.WithoutCancel(ctx), c.flushingKey()).Err()
+}
+
+// takeCounterScript moves KEYS[1] to KEYS[2] and returns its fields. If
+// KEYS[2] is left over from a failed flush it is returned as is, and new
+// increments stay in KEYS[1] for the next flush.
+var takeCounterScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[2]) == 0 then
+	if redis.call('EXISTS', KEYS[1]) == 0 then
+		return {}
+	end
+	redis.call('RENAME', KEYS[1], KEYS[2])
+end
+return redis.call('HGETALL', KEYS[2])
+`)
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounter_Flush(t *testing.T) {
+	c := setupTestCache(t)
+	if c == nil {
+		return
+	}
+	defer teardownTestCache(t, c)
+
+	ctx := context.Background()
+	counter := c.Counter("test:counter")
+
+	require.NoError(t, counter.Add(ctx, "a", 1))
+	require.NoError(t, counter.Add(ctx, "a", 2))
+	require.NoError(t, counter.Add(ctx, "b", 1))
+
+	pending, err := counter.Pending(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), pending)
+
+	t.Run("failed write keeps the deltas", func(t *testing.T) {
+		err := counter.Flush(ctx, func(ctx context.Context, deltas map[string]int64) error {
+			// Increments during a flush go to the next batch
+			require.NoError(t, counter.Add(ctx, "a", 10))
+			return errors.New("database down")
+		})
+		require.Error(t, err)
+
+		pending, err := counter.Pending(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, int64(13), pending)
+	})
+
+	t.Run("retried deltas are written before new ones", func(t *testing.T) {
+		var written []map[string]int64
+		write := func(ctx context.Context, deltas map[string]int64) error {
+			written = append(written, deltas)
+			return nil
+		}
+
+		require.NoError(t, counter.Flush(ctx, write))
+		require.NoError(t, counter.Flush(ctx, write))
+		require.NoError(t, counter.Flush(ctx, write))
+
+		assert.Equal(t, []map[string]int64{{"a": 3, "b": 1}, {"a": 10}}, written)
+
+		pending, err := counter.Pending(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), pending)
+	})
+}
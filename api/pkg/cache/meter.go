@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyPrefixMeter namespaces meters by period and reader
+const KeyPrefixMeter = "meter:"
+
+// MeterKey returns the meter of one reader in one period, e.g. a month
+func MeterKey(period, reader string) string {
+	return KeyPrefixMeter + period + ":" + reader
+}
+
+// Meter allows a reader a limited number of distinct items, such as free
+// articles a month. Items are kept in a Redis set, so reopening an item
+// already counted is always allowed and costs nothing.
+type Meter struct {
+	client *redis.Client
+	key    string
+	limit  int
+	ttl    time.Duration
+}
+
+// Meter returns the meter stored under key. The set expires ttl after its
+// last new item, which should outlast the period.
+func (c *RedisCache) Meter(key string, limit int, ttl time.Duration) *Meter {
+	return &Meter{client: c.client, key: key, limit: limit, ttl: ttl}
+}
+
+// Use counts item against the meter. It reports whether the item is within
+// the limit and how many more new items the reader may open.
+func (m *Meter) Use(ctx context.Context, item string) (bool, int, error) {
+	res, err := useMeterScript.Run(ctx, m.client, []string{m.key}, item, m.limit, int64(m.ttl/time.Second)).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to use meter: %w", err)
+	}
+	return res[0] == 1, int(res[1]), nil
+}
+
+// useMeterScript adds ARGV[1] to the set KEYS[1] unless it already holds
+// ARGV[2] items, returning {allowed, remaining}
+var useMeterScript = redis.NewScript(`
+local limit = tonumber(ARGV[2])
+local used = redis.call('SCARD', KEYS[1])
+if redis.call('SISMEMBER', KEYS[1], ARGV[1]) == 1 then
+	return {1, math.max(limit - used, 0)}
+end
+if used >= limit then
+	return {0, 0}
+end
+redis.call('SADD', KEYS[1], ARGV[1])
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+return {1, limit - used - 1}
+`)
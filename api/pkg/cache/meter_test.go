@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeter_Use(t *testing.T) {
+	c := setupTestCache(t)
+	if c == nil {
+		return
+	}
+	defer teardownTestCache(t, c)
+
+	ctx := context.Background()
+	meter := c.Meter("test:meter", 2, time.Hour)
+
+	allowed, remaining, err := meter.Use(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 1, remaining)
+
+	// Reopening an item is free
+	allowed, remaining, err = meter.Use(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 1, remaining)
+
+	allowed, remaining, err = meter.Use(ctx, "b")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 0, remaining)
+
+	allowed, _, err = meter.Use(ctx, "c")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	allowed, _, err = meter.Use(ctx, "b")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
@@ -35,18 +35,46 @@ func (c *RedisCache) Close() error {
 	return c.client.Close()
 }
 
-func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+// Set stores value under key. Optional tags register the key as a member of
+// each tag's set so the whole family can later be dropped with InvalidateTag.
+func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration, tags ...string) error {
 	data, err := json.Marshal(value)
 	if err != nil {
 		return fmt.Errorf("failed to marshal value: %w", err)
 	}
 
-	return c.client.Set(ctx, key, data, ttl).Err()
+	if len(tags) == 0 {
+		return c.client.Set(ctx, key, data, ttl).Err()
+	}
+
+	keys := make([]string, 0, len(tags)+1)
+	keys = append(keys, key)
+	for _, tag := range tags {
+		keys = append(keys, TagKey(tag))
+	}
+
+	return setWithTagsScript.Run(ctx, c.client, keys, data, ttl.Milliseconds()).Err()
+}
+
+// InvalidateTag deletes every key registered under the given tags, along with
+// the tag sets themselves, in a single atomic script call.
+func (c *RedisCache) InvalidateTag(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = TagKey(tag)
+	}
+
+	return invalidateTagsScript.Run(ctx, c.client, keys).Err()
 }
 
 func (c *RedisCache) Get(ctx context.Context, key string, dest interface{}) error {
 	data, err := c.client.Get(ctx, key).Bytes()
 	if err != nil {
+		recordLookup(ctx, false)
 		if err == redis.Nil {
 			return ErrCacheMiss
 		}
@@ -54,9 +82,11 @@ func (c *RedisCache) Get(ctx context.Context, key string, dest interface{}) erro
 	}
 
 	if err := json.Unmarshal(data, dest); err != nil {
+		recordLookup(ctx, false)
 		return fmt.Errorf("failed to unmarshal value: %w", err)
 	}
 
+	recordLookup(ctx, true)
 	return nil
 }
 
@@ -95,19 +125,64 @@ func (c *RedisCache) SetNX(ctx context.Context, key string, value interface{}, t
 	return c.client.SetNX(ctx, key, data, ttl).Result()
 }
 
+// setWithTagsScript writes KEYS[1] and adds it to every tag set in KEYS[2..].
+// Tag sets never expire before the longest-lived member they track.
+var setWithTagsScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+for i = 2, #KEYS do
+	local existed = redis.call('EXISTS', KEYS[i])
+	redis.call('SADD', KEYS[i], KEYS[1])
+	if ttl <= 0 then
+		redis.call('PERSIST', KEYS[i])
+	else
+		local current = redis.call('PTTL', KEYS[i])
+		if existed == 0 or (current >= 0 and current < ttl) then
+			redis.call('PEXPIRE', KEYS[i], ttl)
+		end
+	end
+end
+return 1
+`)
+
+// invalidateTagsScript deletes all members of each tag set in KEYS, then the
+// sets. Members are deleted in chunks to stay within Lua's unpack limit.
+var invalidateTagsScript = redis.NewScript(`
+local deleted = 0
+for i = 1, #KEYS do
+	local members = redis.call('SMEMBERS', KEYS[i])
+	for j = 1, #members, 500 do
+		local last = math.min(j + 499, #members)
+		deleted = deleted + redis.call('DEL', unpack(members, j, last))
+	end
+	redis.call('DEL', KEYS[i])
+end
+return deleted
+`)
+
 // Cache key generators
 const (
-	KeyPrefixArticle        = "article:"
-	KeyPrefixArticleSlug    = "article:slug:"
-	KeyPrefixArticleList    = "articles:list:"
-	KeyPrefixTrending       = "articles:trending"
-	KeyPrefixCategory       = "category:"
-	KeyPrefixCategories     = "categories:all"
-	KeyPrefixPolitician     = "politician:"
-	KeyPrefixPoliticianSlug = "politician:slug:"
-	KeyPrefixPoliticians    = "politicians:all"
-	KeyPrefixPoliticianList = "politicians:list:"
-	KeyPrefixRateLimit      = "ratelimit:"
+	KeyPrefixArticle          = "article:"
+	KeyPrefixArticleSlug      = "article:slug:"
+	KeyPrefixArticleList      = "articles:list:"
+	KeyPrefixRelated          = "related:"
+	KeyPrefixTrending         = "articles:trending"
+	KeyPrefixCategory         = "category:"
+	KeyPrefixCategories       = "categories:all"
+	KeyPrefixPolitician       = "politician:"
+	KeyPrefixPoliticianSlug   = "politician:slug:"
+	KeyPrefixPoliticians      = "politicians:all"
+	KeyPrefixPoliticianList   = "politicians:list:"
+	KeyPrefixPoliticianSearch = "politician:search:"
+	KeyPrefixRateLimit        = "ratelimit:"
+	KeyPrefixFeed             = "feed:"
+	KeyPrefixSearch           = "search:"
+	KeyPrefixInboxUnread      = "inbox:unread:"
+	KeyPrefixCacheTag         = "cachetag:"
 
 	// Location cache keys
 	KeyPrefixRegion            = "region:"
@@ -123,10 +198,57 @@ const (
 	KeyPrefixBarangay          = "barangay:"
 	KeyPrefixBarangaySlug      = "barangay:slug:"
 	KeyPrefixBarangays         = "barangays:"
+	KeyPrefixBarangaySearch    = "barangays:search:"
 	KeyPrefixDistrict          = "district:"
 	KeyPrefixLocationHierarchy = "location:hierarchy:"
+	KeyPrefixLocationTree      = "location:tree:"
 )
 
+// Counters accumulating views between flushes (see Counter)
+const (
+	CounterArticleViews        = "counters:article_views"
+	CounterArticleReferrers    = "counters:article_referrers"
+	CounterVoterEducationViews = "counters:voter_education_views"
+	CounterEmbedViews          = "counters:embed_views"
+	CounterHourlyViews         = "counters:hourly_views" // Site-wide article views by UTC hour
+)
+
+// Cache tags group keys into families that are invalidated together
+const (
+	TagArticleLists      = "articles:lists"
+	TagArticleListsAll   = "articles:lists:all"
+	TagSearches          = "search:all"
+	TagLocationRegions   = "locations:regions"
+	TagLocationProvinces = "locations:provinces"
+	TagLocationCities    = "locations:cities"
+	TagLocationBarangays = "locations:barangays"
+)
+
+func TagKey(tag string) string {
+	return KeyPrefixCacheTag + tag
+}
+
+func CategoryArticlesTag(categoryID string) string {
+	return "articles:lists:category:" + categoryID
+}
+
+func TagArticlesTag(tagID string) string {
+	return "articles:lists:tag:" + tagID
+}
+
+func AuthorArticlesTag(authorID string) string {
+	return "articles:lists:author:" + authorID
+}
+
+func PoliticianArticlesTag(politicianID string) string {
+	return "articles:lists:politician:" + politicianID
+}
+
+// SearchTermTag groups the cached searches whose query has the given word
+func SearchTermTag(term string) string {
+	return "search:term:" + term
+}
+
 func ArticleKey(id string) string {
 	return KeyPrefixArticle + id
 }
@@ -135,10 +257,35 @@ func ArticleSlugKey(slug string) string {
 	return KeyPrefixArticleSlug + slug
 }
 
+// ArticleExportKey caches an article rendered in an alternate format
+// ("text" or "markdown")
+func ArticleExportKey(slug, format string) string {
+	return KeyPrefixArticleSlug + slug + ":" + format
+}
+
+// ArticleKeywordDensityKey caches the keyword density report of an article
+func ArticleKeywordDensityKey(id string) string {
+	return KeyPrefixArticle + id + ":keyword-density"
+}
+
 func ArticleListKey(page, perPage int, filter string) string {
 	return fmt.Sprintf("%s%d:%d:%s", KeyPrefixArticleList, page, perPage, filter)
 }
 
+// SearchKey caches a page of article search results
+func SearchKey(page, perPage int, filter string) string {
+	return fmt.Sprintf("%s%d:%d:%s", KeyPrefixSearch, page, perPage, filter)
+}
+
+// FeedKey caches a page of a user's personalized feed
+func FeedKey(userID string, page, perPage int) string {
+	return fmt.Sprintf("%s%s:%d:%d", KeyPrefixFeed, userID, page, perPage)
+}
+
+func RelatedArticlesKey(articleID string) string {
+	return KeyPrefixRelated + articleID
+}
+
 func TrendingKey() string {
 	return KeyPrefixTrending
 }
@@ -163,6 +310,11 @@ func PoliticianSlugKey(slug string) string {
 	return KeyPrefixPoliticianSlug + slug
 }
 
+// PoliticianMergedSlugKey caches where a merged politician's old slug redirects
+func PoliticianMergedSlugKey(slug string) string {
+	return KeyPrefixPolitician + "merged:" + slug
+}
+
 func PoliticiansKey() string {
 	return KeyPrefixPoliticians
 }
@@ -171,6 +323,12 @@ func PoliticianListKey(page, perPage int, filter string) string {
 	return fmt.Sprintf("%s%d:%d:%s", KeyPrefixPoliticianList, page, perPage, filter)
 }
 
+// PoliticianSearchKey caches politician autocomplete results. Any change to a
+// politician, their social links or tenures drops every cached search.
+func PoliticianSearchKey(query, hasSocial string, limit int) string {
+	return fmt.Sprintf("%s%d:%s:%s", KeyPrefixPoliticianSearch, limit, hasSocial, query)
+}
+
 // Location cache key functions
 func RegionKey(id string) string {
 	return KeyPrefixRegion + id
@@ -224,6 +382,10 @@ func BarangaysKey(cityID string) string {
 	return KeyPrefixBarangays + cityID
 }
 
+func BarangaySearchKey(page, perPage int, filter string) string {
+	return fmt.Sprintf("%s%d:%d:%s", KeyPrefixBarangaySearch, page, perPage, filter)
+}
+
 func DistrictKey(id string) string {
 	return KeyPrefixDistrict + id
 }
@@ -231,3 +393,12 @@ func DistrictKey(id string) string {
 func LocationHierarchyKey(barangayID string) string {
 	return KeyPrefixLocationHierarchy + barangayID
 }
+
+func LocationTreeKey(depth int) string {
+	return fmt.Sprintf("%s%d", KeyPrefixLocationTree, depth)
+}
+
+// InboxUnreadKey caches a user's unified notifications unread counts
+func InboxUnreadKey(userID string) string {
+	return KeyPrefixInboxUnread + userID
+}
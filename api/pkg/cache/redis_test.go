@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestCache(t *testing.T) *RedisCache {
+	// Use REDIS_URL when set, otherwise a local Redis on its default port
+	redisURL := os.Getenv("REDIS_URL")
+	if redisURL == "" {
+		redisURL = "redis://localhost:6379/15"
+	}
+
+	c, err := NewRedisCache(redisURL)
+	if err != nil {
+		t.Skip("Skipping cache tests: cannot connect to redis")
+		return nil
+	}
+
+	ctx := context.Background()
+	_ = c.DeletePattern(ctx, "test:*")
+	_ = c.DeletePattern(ctx, KeyPrefixCacheTag+"test:*")
+
+	return c
+}
+
+func teardownTestCache(t *testing.T, c *RedisCache) {
+	if c != nil {
+		ctx := context.Background()
+		_ = c.DeletePattern(ctx, "test:*")
+		_ = c.DeletePattern(ctx, KeyPrefixCacheTag+"test:*")
+		_ = c.Close()
+	}
+}
+
+func TestRedisCache_InvalidateTag(t *testing.T) {
+	c := setupTestCache(t)
+	if c == nil {
+		return
+	}
+	defer teardownTestCache(t, c)
+
+	ctx := context.Background()
+
+	t.Run("no stale list page remains after publish", func(t *testing.T) {
+		pages := []string{"test:articles:1", "test:articles:2", "test:articles:3"}
+		for _, key := range pages {
+			require.NoError(t, c.Set(ctx, key, "page", time.Minute, "test:lists", "test:lists:all"))
+		}
+
+		// Publishing invalidates the family every page belongs to
+		require.NoError(t, c.InvalidateTag(ctx, "test:lists:all"))
+
+		for _, key := range pages {
+			exists, err := c.Exists(ctx, key)
+			require.NoError(t, err)
+			assert.False(t, exists, "page %s should have been invalidated", key)
+		}
+
+		exists, err := c.Exists(ctx, TagKey("test:lists:all"))
+		require.NoError(t, err)
+		assert.False(t, exists, "tag set should be removed with its members")
+	})
+
+	t.Run("unrelated tags survive", func(t *testing.T) {
+		require.NoError(t, c.Set(ctx, "test:category:a", "a", time.Minute, "test:category:a"))
+		require.NoError(t, c.Set(ctx, "test:category:b", "b", time.Minute, "test:category:b"))
+
+		require.NoError(t, c.InvalidateTag(ctx, "test:category:a"))
+
+		exists, err := c.Exists(ctx, "test:category:a")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		var value string
+		require.NoError(t, c.Get(ctx, "test:category:b", &value))
+		assert.Equal(t, "b", value)
+	})
+
+	t.Run("tag set lives as long as its longest member", func(t *testing.T) {
+		require.NoError(t, c.Set(ctx, "test:ttl:long", 1, time.Hour, "test:ttl"))
+		require.NoError(t, c.Set(ctx, "test:ttl:short", 1, time.Second, "test:ttl"))
+
+		ttl, err := c.client.PTTL(ctx, TagKey("test:ttl")).Result()
+		require.NoError(t, err)
+		assert.Greater(t, ttl, 30*time.Minute)
+	})
+
+	t.Run("untagged set behaves as before", func(t *testing.T) {
+		require.NoError(t, c.Set(ctx, "test:plain", "v", time.Minute))
+
+		var value string
+		require.NoError(t, c.Get(ctx, "test:plain", &value))
+		assert.Equal(t, "v", value)
+	})
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/humfurie/pulpulitiko/api/pkg/metrics"
+)
+
+type statusContextKey struct{}
+
+// Status records whether the cache lookups made while serving a request hit,
+// so the request log can tell cached responses from ones that went to the
+// database
+type Status struct {
+	hits, misses atomic.Int32
+}
+
+// WithStatus returns a context under which Get records its hits and misses
+func WithStatus(ctx context.Context) (context.Context, *Status) {
+	status := &Status{}
+	return context.WithValue(ctx, statusContextKey{}, status), status
+}
+
+// recordLookup counts a lookup in the cache metrics, and against the
+// request's status when ctx carries one
+func recordLookup(ctx context.Context, hit bool) {
+	if hit {
+		metrics.RecordCacheHit()
+	} else {
+		metrics.RecordCacheMiss()
+	}
+
+	status, ok := ctx.Value(statusContextKey{}).(*Status)
+	if !ok {
+		return
+	}
+	if hit {
+		status.hits.Add(1)
+	} else {
+		status.misses.Add(1)
+	}
+}
+
+// String is "hit" or "miss" when every lookup went the same way, "partial"
+// when some hit and some missed, and empty when the cache was not consulted
+func (s *Status) String() string {
+	hits, misses := s.hits.Load(), s.misses.Load()
+	switch {
+	case hits > 0 && misses > 0:
+		return "partial"
+	case hits > 0:
+		return "hit"
+	case misses > 0:
+		return "miss"
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus(t *testing.T) {
+	ctx, status := WithStatus(context.Background())
+	assert.Equal(t, "", status.String())
+
+	recordLookup(ctx, true)
+	assert.Equal(t, "hit", status.String())
+
+	recordLookup(ctx, false)
+	assert.Equal(t, "partial", status.String())
+
+	ctx, status = WithStatus(context.Background())
+	recordLookup(ctx, false)
+	assert.Equal(t, "miss", status.String())
+
+	// Lookups outside a request are not recorded
+	recordLookup(context.Background(), true)
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"html"
 	"net/http"
+	"time"
 )
 
 type EmailService struct {
@@ -12,6 +14,7 @@ type EmailService struct {
 	fromEmail string
 	fromName  string
 	siteURL   string
+	location  *time.Location
 }
 
 type SendEmailRequest struct {
@@ -37,9 +40,21 @@ func NewEmailService(apiKey, fromEmail, fromName, siteURL string) *EmailService
 		fromEmail: fromEmail,
 		fromName:  fromName,
 		siteURL:   siteURL,
+		location:  time.UTC,
 	}
 }
 
+// SetLocation sets the timezone times in emails are shown in
+func (s *EmailService) SetLocation(loc *time.Location) {
+	s.location = loc
+}
+
+// formatTime shows a time in the email timezone with its UTC offset, since
+// zone abbreviations such as PST are ambiguous
+func (s *EmailService) formatTime(t time.Time) string {
+	return t.In(s.location).Format("January 2, 2006 3:04 PM (UTC-07:00)")
+}
+
 func (s *EmailService) Send(to, subject, html string) error {
 	if s.apiKey == "" {
 		return fmt.Errorf("email service not configured: missing API key")
@@ -83,7 +98,7 @@ func (s *EmailService) Send(to, subject, html string) error {
 	return nil
 }
 
-func (s *EmailService) SendPasswordReset(to, resetToken string) error {
+func (s *EmailService) SendPasswordReset(to, resetToken string, expiresAt time.Time) error {
 	resetURL := fmt.Sprintf("%s/reset-password?token=%s", s.siteURL, resetToken)
 
 	html := fmt.Sprintf(`
@@ -103,7 +118,7 @@ func (s *EmailService) SendPasswordReset(to, resetToken string) error {
         <div style="text-align: center; margin: 30px 0;">
             <a href="%s" style="background: #667eea; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; display: inline-block; font-weight: 600;">Reset Password</a>
         </div>
-        <p style="color: #666; font-size: 14px;">This link will expire in 1 hour.</p>
+        <p style="color: #666; font-size: 14px;">This link will expire on %s.</p>
         <p style="color: #666; font-size: 14px;">If you didn't request a password reset, you can safely ignore this email.</p>
         <hr style="border: none; border-top: 1px solid #e5e7eb; margin: 30px 0;">
         <p style="color: #999; font-size: 12px; text-align: center;">
@@ -113,11 +128,43 @@ func (s *EmailService) SendPasswordReset(to, resetToken string) error {
     </div>
 </body>
 </html>
-`, resetURL, resetURL, resetURL)
+`, resetURL, s.formatTime(expiresAt), resetURL, resetURL)
 
 	return s.Send(to, "Reset your password", html)
 }
 
+// SendSavedSearchAlert tells an editor that one of their saved searches has
+// more results than its alert threshold
+func (s *EmailService) SendSavedSearchAlert(to, searchName, searchID string, count, threshold int) error {
+	searchURL := fmt.Sprintf("%s/admin/searches/%s", s.siteURL, searchID)
+	name := html.EscapeString(searchName)
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+</head>
+<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%); padding: 30px; text-align: center; border-radius: 10px 10px 0 0;">
+        <h1 style="color: white; margin: 0; font-size: 24px;">Saved Search Alert</h1>
+    </div>
+    <div style="background: #f9fafb; padding: 30px; border-radius: 0 0 10px 10px;">
+        <p>Hi,</p>
+        <p>As of %s, your saved search <strong>%s</strong> has <strong>%d</strong> results, above your alert threshold of %d.</p>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="%s" style="background: #667eea; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; display: inline-block; font-weight: 600;">View Results</a>
+        </div>
+        <p style="color: #666; font-size: 14px;">You can turn off alerts for this search from the admin panel.</p>
+    </div>
+</body>
+</html>
+`, s.formatTime(time.Now()), name, count, threshold, searchURL)
+
+	return s.Send(to, fmt.Sprintf("Saved search \"%s\" has %d results", searchName, count), body)
+}
+
 // IsConfigured returns true if the email service has an API key configured
 func (s *EmailService) IsConfigured() bool {
 	return s.apiKey != ""
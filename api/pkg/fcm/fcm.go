@@ -0,0 +1,147 @@
+// Package fcm sends push notifications through the Firebase Cloud Messaging
+// HTTP v1 API.
+//
+// Requests are authorized as a Google service account with
+// golang.org/x/oauth2/google, which signs the account's JWT, trades it for an
+// access token and refreshes it before it expires.
+package fcm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	messagingScope = "https://www.googleapis.com/auth/firebase.messaging"
+	sendURLFormat  = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+
+	// Longest error body read from FCM
+	maxErrorBody = 64 << 10
+)
+
+// ErrUnregistered means the device token is no longer valid, usually because
+// the app was uninstalled, and should be forgotten
+var ErrUnregistered = errors.New("device token is no longer registered")
+
+type Client struct {
+	sendURL string
+	http    *http.Client
+}
+
+// NewClient creates a client from a service account key file's JSON. An
+// empty projectID uses the service account's project.
+func NewClient(projectID string, credentialsJSON []byte, timeout time.Duration) (*Client, error) {
+	config, err := google.JWTConfigFromJSON(credentialsJSON, messagingScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse FCM credentials: %w", err)
+	}
+	if config.Email == "" {
+		return nil, fmt.Errorf("FCM credentials have no client_email")
+	}
+	if len(config.PrivateKey) == 0 {
+		return nil, fmt.Errorf("FCM credentials have no private_key")
+	}
+
+	if projectID == "" {
+		var key struct {
+			ProjectID string `json:"project_id"`
+		}
+		_ = json.Unmarshal(credentialsJSON, &key)
+		projectID = key.ProjectID
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("FCM project ID is not set")
+	}
+
+	// Access tokens are fetched with the same timeout as messages
+	tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: timeout})
+	return &Client{
+		sendURL: fmt.Sprintf(sendURLFormat, url.PathEscape(projectID)),
+		http: &http.Client{
+			Transport: &oauth2.Transport{Source: config.TokenSource(tokenCtx), Base: http.DefaultTransport},
+			Timeout:   timeout,
+		},
+	}, nil
+}
+
+type message struct {
+	Token        string            `json:"token"`
+	Notification notification      `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type notification struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+// Send pushes a notification to one device. It returns ErrUnregistered when
+// FCM no longer knows the token.
+func (c *Client) Send(ctx context.Context, token, title, body string, data map[string]string) error {
+	payload, err := json.Marshal(map[string]message{"message": {
+		Token:        token,
+		Notification: notification{Title: title, Body: body},
+		Data:         data,
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to encode FCM message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.sendURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create FCM request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send FCM message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		return nil
+	}
+	return sendError(resp)
+}
+
+// errorResponse is a Google API error. FCM says why a message failed in a
+// google.firebase.fcm.v1.FcmError detail.
+type errorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			Type      string `json:"@type"`
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func sendError(resp *http.Response) error {
+	var body errorResponse
+	_ = json.NewDecoder(io.LimitReader(resp.Body, maxErrorBody)).Decode(&body)
+
+	for _, d := range body.Error.Details {
+		if strings.HasSuffix(d.Type, "google.firebase.fcm.v1.FcmError") && d.ErrorCode == "UNREGISTERED" {
+			return ErrUnregistered
+		}
+	}
+	if body.Error.Message != "" {
+		return fmt.Errorf("FCM returned status %d: %s", resp.StatusCode, body.Error.Message)
+	}
+	return fmt.Errorf("FCM returned status %d", resp.StatusCode)
+}
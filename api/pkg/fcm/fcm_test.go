@@ -0,0 +1,143 @@
+package fcm
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFCM serves the token endpoint and the send endpoint of one project
+type fakeFCM struct {
+	key         *rsa.PrivateKey
+	tokenCalls  int
+	sent        []map[string]message
+	auth        []string
+	sendStatus  int
+	sendErrBody string
+}
+
+func (f *fakeFCM) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		f.tokenCalls++
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(r.PostForm.Get("assertion"), claims, func(*jwt.Token) (interface{}, error) {
+			return &f.key.PublicKey, nil
+		}, jwt.WithValidMethods([]string{"RS256"}))
+		require.NoError(t, err)
+		assert.Equal(t, "push@pulpulitiko.iam.gserviceaccount.com", claims["iss"])
+		assert.Equal(t, messagingScope, claims["scope"])
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "access-1", "expires_in": 3600})
+	})
+	mux.HandleFunc("/v1/projects/pulpulitiko/messages:send", func(w http.ResponseWriter, r *http.Request) {
+		f.auth = append(f.auth, r.Header.Get("Authorization"))
+		var body map[string]message
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		f.sent = append(f.sent, body)
+
+		if f.sendStatus != 0 {
+			w.WriteHeader(f.sendStatus)
+			_, _ = w.Write([]byte(f.sendErrBody))
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"projects/pulpulitiko/messages/1"}`))
+	})
+	return mux
+}
+
+func newTestClient(t *testing.T) (*Client, *fakeFCM) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	fake := &fakeFCM{key: key}
+
+	server := httptest.NewServer(fake.handler(t))
+	t.Cleanup(server.Close)
+
+	creds, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "pulpulitiko",
+		"private_key_id": "key-1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"client_email":   "push@pulpulitiko.iam.gserviceaccount.com",
+		"token_uri":      server.URL + "/token",
+	})
+	require.NoError(t, err)
+
+	client, err := NewClient("", creds, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "https://fcm.googleapis.com/v1/projects/pulpulitiko/messages:send", client.sendURL)
+	client.sendURL = server.URL + "/v1/projects/pulpulitiko/messages:send"
+	return client, fake
+}
+
+func TestClientSend(t *testing.T) {
+	client, fake := newTestClient(t)
+	ctx := context.Background()
+
+	data := map[string]string{"notification_id": "n-1", "type": "poll_closed"}
+	require.NoError(t, client.Send(ctx, "device-1", "Your poll has closed", "Voting has ended.", data))
+	require.NoError(t, client.Send(ctx, "device-2", "Ana replied to you", "", nil))
+
+	// The access token is fetched once and reused
+	assert.Equal(t, 1, fake.tokenCalls)
+	assert.Equal(t, []string{"Bearer access-1", "Bearer access-1"}, fake.auth)
+
+	require.Len(t, fake.sent, 2)
+	first := fake.sent[0]["message"]
+	assert.Equal(t, "device-1", first.Token)
+	assert.Equal(t, notification{Title: "Your poll has closed", Body: "Voting has ended."}, first.Notification)
+	assert.Equal(t, data, first.Data)
+	assert.Equal(t, "device-2", fake.sent[1]["message"].Token)
+}
+
+func TestClientSendUnregistered(t *testing.T) {
+	client, fake := newTestClient(t)
+	fake.sendStatus = http.StatusNotFound
+	fake.sendErrBody = `{"error":{"code":404,"message":"Requested entity was not found.","status":"NOT_FOUND",
+		"details":[{"@type":"type.googleapis.com/google.firebase.fcm.v1.FcmError","errorCode":"UNREGISTERED"}]}}`
+
+	err := client.Send(context.Background(), "gone", "Title", "Body", nil)
+	assert.True(t, errors.Is(err, ErrUnregistered))
+}
+
+func TestClientSendFailure(t *testing.T) {
+	client, fake := newTestClient(t)
+	fake.sendStatus = http.StatusBadRequest
+	fake.sendErrBody = `{"error":{"code":400,"message":"Invalid data payload","status":"INVALID_ARGUMENT",
+		"details":[{"@type":"type.googleapis.com/google.firebase.fcm.v1.FcmError","errorCode":"INVALID_ARGUMENT"}]}}`
+
+	err := client.Send(context.Background(), "device-1", "Title", "Body", nil)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrUnregistered))
+	assert.Contains(t, err.Error(), "Invalid data payload")
+}
+
+func TestNewClientRejectsBadCredentials(t *testing.T) {
+	for name, creds := range map[string]string{
+		"not JSON":        `nope`,
+		"user account":    `{"type":"authorized_user","client_email":"a@b.c"}`,
+		"no private key":  `{"type":"service_account","client_email":"a@b.c","project_id":"p"}`,
+		"no client email": `{"type":"service_account","project_id":"p"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClient("", []byte(creds), time.Second)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,371 @@
+// Package geoip looks IP addresses up in a local MaxMind DB (.mmdb) file such
+// as GeoLite2-City. It reads only what location detection needs, the country
+// and coordinates, and never makes network calls.
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"os"
+)
+
+// metadataMarker precedes the metadata map at the end of the file
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+var errInvalid = errors.New("invalid geoip database")
+
+// Location is what the database knows about an address
+type Location struct {
+	CountryCode    string // ISO 3166-1 alpha-2, empty if unknown
+	Latitude       float64
+	Longitude      float64
+	AccuracyRadius int // km; the address is likely within this distance of the point
+}
+
+// Reader looks addresses up in a database held in memory. It is safe for
+// concurrent use.
+type Reader struct {
+	tree       []byte
+	data       []byte
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	ipv4Start  uint
+}
+
+// Open reads the database file at path
+func Open(path string) (*Reader, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read geoip database: %w", err)
+	}
+	return New(buf)
+}
+
+// New parses a database already in memory
+func New(buf []byte) (*Reader, error) {
+	i := bytes.LastIndex(buf, metadataMarker)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: metadata not found", errInvalid)
+	}
+	meta, _, err := decoder{buf: buf[i+len(metadataMarker):]}.decode(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read geoip metadata: %w", err)
+	}
+	fields, ok := meta.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", errInvalid)
+	}
+
+	r := &Reader{
+		nodeCount:  uint(asUint(fields["node_count"])),
+		recordSize: uint(asUint(fields["record_size"])),
+		ipVersion:  uint(asUint(fields["ip_version"])),
+	}
+	if r.recordSize != 24 && r.recordSize != 28 && r.recordSize != 32 {
+		return nil, fmt.Errorf("%w: unsupported record size %d", errInvalid, r.recordSize)
+	}
+	if r.ipVersion != 4 && r.ipVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported ip version %d", errInvalid, r.ipVersion)
+	}
+
+	// The search tree is followed by 16 zero bytes, then the data section
+	treeSize := r.nodeCount * r.recordSize / 4
+	if treeSize+16 > uint(i) {
+		return nil, fmt.Errorf("%w: search tree larger than file", errInvalid)
+	}
+	r.tree = buf[:treeSize]
+	r.data = buf[treeSize+16 : i]
+
+	// IPv4 addresses sit under ::/96 in an IPv6 database
+	if r.ipVersion == 6 {
+		for range 96 {
+			if r.ipv4Start >= r.nodeCount {
+				break
+			}
+			r.ipv4Start = r.record(r.ipv4Start, 0)
+		}
+	}
+
+	return r, nil
+}
+
+// Lookup returns the location of addr, or nil when the database has no
+// coordinates for it
+func (r *Reader) Lookup(addr netip.Addr) (*Location, error) {
+	addr = addr.Unmap()
+
+	var ip []byte
+	node := uint(0)
+	switch {
+	case addr.Is4():
+		b := addr.As4()
+		ip = b[:]
+		node = r.ipv4Start
+	case addr.Is6() && r.ipVersion == 6:
+		b := addr.As16()
+		ip = b[:]
+	default:
+		return nil, nil
+	}
+
+	for i := 0; i < len(ip)*8 && node < r.nodeCount; i++ {
+		bit := ip[i/8] >> (7 - i%8) & 1
+		node = r.record(node, bit)
+	}
+	if node == r.nodeCount {
+		return nil, nil
+	}
+	if node < r.nodeCount {
+		return nil, fmt.Errorf("%w: search tree deeper than the address", errInvalid)
+	}
+
+	record, _, err := decoder{buf: r.data}.decode(node-r.nodeCount-16, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read geoip record: %w", err)
+	}
+	return toLocation(record), nil
+}
+
+// record returns the left (bit 0) or right (bit 1) record of a node
+func (r *Reader) record(node uint, bit byte) uint {
+	b := r.tree[node*r.recordSize/4:]
+	switch r.recordSize {
+	case 24:
+		if bit == 0 {
+			return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3])<<16 | uint(b[4])<<8 | uint(b[5])
+	case 28:
+		// The middle byte holds the high nibble of each record
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		if bit == 0 {
+			return uint(binary.BigEndian.Uint32(b[0:4]))
+		}
+		return uint(binary.BigEndian.Uint32(b[4:8]))
+	}
+}
+
+func toLocation(record any) *Location {
+	fields, _ := record.(map[string]any)
+	coords, _ := fields["location"].(map[string]any)
+	lat, latOK := coords["latitude"].(float64)
+	lng, lngOK := coords["longitude"].(float64)
+	if !latOK || !lngOK {
+		return nil
+	}
+
+	loc := &Location{
+		Latitude:       lat,
+		Longitude:      lng,
+		AccuracyRadius: int(asUint(coords["accuracy_radius"])),
+	}
+	if country, ok := fields["country"].(map[string]any); ok {
+		loc.CountryCode, _ = country["iso_code"].(string)
+	}
+	return loc
+}
+
+func asUint(v any) uint64 {
+	n, _ := v.(uint64)
+	return n
+}
+
+// Data section types
+const (
+	typeExtended = 0
+	typePointer  = 1
+	typeString   = 2
+	typeDouble   = 3
+	typeBytes    = 4
+	typeUint16   = 5
+	typeUint32   = 6
+	typeMap      = 7
+	typeInt32    = 8
+	typeUint64   = 9
+	typeUint128  = 10
+	typeArray    = 11
+	typeBool     = 14
+	typeFloat    = 15
+)
+
+// maxDepth bounds nesting so a corrupt file cannot recurse forever
+const maxDepth = 32
+
+// decoder reads values from the data section. Maps decode to map[string]any,
+// arrays to []any, unsigned integers to uint64 and floats to float64.
+type decoder struct {
+	buf []byte
+}
+
+// decode returns the value at offset and the offset just past it
+func (d decoder) decode(offset uint, depth int) (any, uint, error) {
+	if depth > maxDepth {
+		return nil, 0, fmt.Errorf("%w: data nested too deeply", errInvalid)
+	}
+	if offset >= uint(len(d.buf)) {
+		return nil, 0, fmt.Errorf("%w: data offset out of range", errInvalid)
+	}
+	ctrl := d.buf[offset]
+	offset++
+	typ := int(ctrl >> 5)
+
+	if typ == typePointer {
+		target, next, err := d.pointer(ctrl, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		value, _, err := d.decode(target, depth+1)
+		return value, next, err
+	}
+	if typ == typeExtended {
+		if offset >= uint(len(d.buf)) {
+			return nil, 0, fmt.Errorf("%w: data offset out of range", errInvalid)
+		}
+		typ = 7 + int(d.buf[offset])
+		offset++
+	}
+
+	size, offset, err := d.size(ctrl, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch typ {
+	case typeMap:
+		m := make(map[string]any, size)
+		for range size {
+			var key, value any
+			if key, offset, err = d.decode(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			name, ok := key.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("%w: map key is not a string", errInvalid)
+			}
+			if value, offset, err = d.decode(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			m[name] = value
+		}
+		return m, offset, nil
+	case typeArray:
+		a := make([]any, 0, size)
+		for range size {
+			var value any
+			if value, offset, err = d.decode(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			a = append(a, value)
+		}
+		return a, offset, nil
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	b, err := d.bytes(offset, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	offset += size
+
+	switch typ {
+	case typeString:
+		return string(b), offset, nil
+	case typeBytes, typeUint128:
+		return bytes.Clone(b), offset, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%w: double of size %d", errInvalid, size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), offset, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%w: float of size %d", errInvalid, size)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), offset, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("%w: integer of size %d", errInvalid, size)
+		}
+		return readUint(b), offset, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("%w: integer of size %d", errInvalid, size)
+		}
+		return int64(int32(readUint(b))), offset, nil
+	default:
+		return nil, 0, fmt.Errorf("%w: unsupported data type %d", errInvalid, typ)
+	}
+}
+
+// size reads the payload size from the control byte and the bytes after it
+func (d decoder) size(ctrl byte, offset uint) (uint, uint, error) {
+	size := uint(ctrl & 0x1F)
+	if size < 29 {
+		return size, offset, nil
+	}
+
+	n := size - 28
+	b, err := d.bytes(offset, n)
+	if err != nil {
+		return 0, 0, err
+	}
+	extra := uint(readUint(b))
+	switch size {
+	case 29:
+		size = 29 + extra
+	case 30:
+		size = 285 + extra
+	default:
+		size = 65821 + extra
+	}
+	return size, offset + n, nil
+}
+
+// pointer reads a pointer into the data section
+func (d decoder) pointer(ctrl byte, offset uint) (uint, uint, error) {
+	ss := uint(ctrl>>3) & 0x3
+	b, err := d.bytes(offset, ss+1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	target := uint(0)
+	if ss != 3 {
+		target = uint(ctrl & 0x7)
+	}
+	for _, c := range b {
+		target = target<<8 | uint(c)
+	}
+	switch ss {
+	case 1:
+		target += 2048
+	case 2:
+		target += 526336
+	}
+	return target, offset + ss + 1, nil
+}
+
+func (d decoder) bytes(offset, n uint) ([]byte, error) {
+	if offset+n > uint(len(d.buf)) {
+		return nil, fmt.Errorf("%w: data runs past the end of the file", errInvalid)
+	}
+	return d.buf[offset : offset+n], nil
+}
+
+func readUint(b []byte) uint64 {
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n
+}
@@ -0,0 +1,147 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Encoders for the data section types the fixtures use
+
+func encString(s string) []byte {
+	return append([]byte{typeString<<5 | byte(len(s))}, s...)
+}
+
+func encDouble(f float64) []byte {
+	return binary.BigEndian.AppendUint64([]byte{typeDouble<<5 | 8}, math.Float64bits(f))
+}
+
+func encUint16(n uint16) []byte {
+	return binary.BigEndian.AppendUint16([]byte{typeUint16<<5 | 2}, n)
+}
+
+func encUint32(n uint32) []byte {
+	return binary.BigEndian.AppendUint32([]byte{typeUint32<<5 | 4}, n)
+}
+
+func encPointer(offset uint16) []byte {
+	return []byte{typePointer<<5 | byte(offset>>8), byte(offset)}
+}
+
+// encMap encodes alternating keys and already-encoded values
+func encMap(pairs ...any) []byte {
+	b := []byte{typeMap<<5 | byte(len(pairs)/2)}
+	for i := 0; i < len(pairs); i += 2 {
+		b = append(b, encString(pairs[i].(string))...)
+		b = append(b, pairs[i+1].([]byte)...)
+	}
+	return b
+}
+
+// buildDB writes a database whose only record covers every address whose
+// first bit is 0 (0.0.0.0/1 in an IPv4 database; in an IPv6 one, the same
+// range of IPv4 addresses under ::/96). The country code is stored once and
+// pointed to from the record.
+func buildDB(ipVersion uint16) []byte {
+	data := encString("PH")
+	recordOffset := len(data)
+	data = append(data, encMap(
+		"country", encMap("iso_code", encPointer(0)),
+		"location", encMap(
+			"latitude", encDouble(14.6507),
+			"longitude", encDouble(121.0494),
+			"accuracy_radius", encUint16(20),
+		),
+	)...)
+
+	// IPv6 walks 96 zero bits before the IPv4 part of the address
+	depth := 0
+	if ipVersion == 6 {
+		depth = 96
+	}
+	nodeCount := uint32(depth + 1)
+	put := func(tree []byte, n uint32) []byte {
+		return append(tree, byte(n>>16), byte(n>>8), byte(n))
+	}
+
+	var tree []byte
+	for i := 0; i < depth; i++ {
+		tree = put(tree, uint32(i+1))
+		tree = put(tree, nodeCount)
+	}
+	tree = put(tree, nodeCount+16+uint32(recordOffset))
+	tree = put(tree, nodeCount)
+
+	db := append(tree, make([]byte, 16)...)
+	db = append(db, data...)
+	db = append(db, metadataMarker...)
+	db = append(db, encMap(
+		"node_count", encUint32(nodeCount),
+		"record_size", encUint16(24),
+		"ip_version", encUint16(ipVersion),
+	)...)
+	return db
+}
+
+func TestLookup(t *testing.T) {
+	for _, version := range []uint16{4, 6} {
+		r, err := New(buildDB(version))
+		require.NoError(t, err)
+
+		loc, err := r.Lookup(netip.MustParseAddr("49.145.1.1"))
+		require.NoError(t, err)
+		require.NotNil(t, loc, "IPv%d database", version)
+		assert.Equal(t, "PH", loc.CountryCode)
+		assert.InDelta(t, 14.6507, loc.Latitude, 1e-9)
+		assert.InDelta(t, 121.0494, loc.Longitude, 1e-9)
+		assert.Equal(t, 20, loc.AccuracyRadius)
+
+		// IPv4-mapped IPv6 addresses are looked up as IPv4
+		loc, err = r.Lookup(netip.MustParseAddr("::ffff:49.145.1.1"))
+		require.NoError(t, err)
+		assert.NotNil(t, loc)
+
+		loc, err = r.Lookup(netip.MustParseAddr("200.1.1.1"))
+		require.NoError(t, err)
+		assert.Nil(t, loc, "address outside the database")
+
+		loc, err = r.Lookup(netip.MustParseAddr("2001:db8::1"))
+		require.NoError(t, err)
+		assert.Nil(t, loc, "IPv6 address outside the database")
+	}
+}
+
+func TestNewRejectsInvalidDatabase(t *testing.T) {
+	_, err := New([]byte("not a database"))
+	assert.Error(t, err)
+
+	// Metadata claiming a tree larger than the file
+	db := append([]byte{}, metadataMarker...)
+	db = append(db, encMap(
+		"node_count", encUint32(1000),
+		"record_size", encUint16(24),
+		"ip_version", encUint16(4),
+	)...)
+	_, err = New(db)
+	assert.Error(t, err)
+}
+
+func TestOpen(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "missing.mmdb"))
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	require.NoError(t, os.WriteFile(path, buildDB(4), 0o600))
+	r, err := Open(path)
+	require.NoError(t, err)
+
+	loc, err := r.Lookup(netip.MustParseAddr("1.2.3.4"))
+	require.NoError(t, err)
+	assert.NotNil(t, loc)
+}
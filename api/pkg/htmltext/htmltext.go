@@ -0,0 +1,505 @@
+package htmltext
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ToPlainText renders article HTML as plain text for screen readers and
+// text-to-speech: headings are prefixed with "## ", blockquote lines with
+// "> ", list items with "- " or their number, and blocks are separated by a
+// blank line. Images are reduced to their alt text.
+func ToPlainText(src string) string {
+	return convert(src, false)
+}
+
+// ToMarkdown renders article HTML as basic Markdown. Only the elements the
+// article sanitizer allows are converted; anything else contributes its text.
+func ToMarkdown(src string) string {
+	return convert(src, true)
+}
+
+func convert(src string, markdown bool) string {
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return ""
+	}
+
+	root := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	for _, n := range nodes {
+		root.AppendChild(n)
+	}
+
+	c := converter{markdown: markdown}
+	return strings.Join(c.blocks(root), "\n\n")
+}
+
+type converter struct {
+	markdown bool
+}
+
+// blocks renders the children of n as a list of blocks. Runs of inline
+// content between block elements become their own paragraph.
+func (c converter) blocks(n *html.Node) []string {
+	var out []string
+	var inline strings.Builder
+
+	flush := func() {
+		if text := cleanInline(inline.String()); text != "" {
+			out = append(out, text)
+		}
+		inline.Reset()
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || !isBlock(child.DataAtom) {
+			c.inline(child, &inline)
+			continue
+		}
+
+		flush()
+		out = append(out, c.block(child)...)
+	}
+	flush()
+
+	return out
+}
+
+func (c converter) block(n *html.Node) []string {
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		text := c.inlineText(n)
+		if text == "" {
+			return nil
+		}
+		prefix := "## "
+		if c.markdown {
+			level, _ := strconv.Atoi(n.Data[1:])
+			prefix = strings.Repeat("#", level) + " "
+		}
+		return []string{prefix + strings.ReplaceAll(text, "\n", " ")}
+
+	case atom.P, atom.Figcaption:
+		if text := c.inlineText(n); text != "" {
+			return []string{text}
+		}
+		return nil
+
+	case atom.Blockquote:
+		inner := strings.Join(c.blocks(n), "\n\n")
+		if inner == "" {
+			return nil
+		}
+		return []string{prefixLines(inner, "> ", "> ")}
+
+	case atom.Ul, atom.Ol:
+		if list := c.list(n); list != "" {
+			return []string{list}
+		}
+		return nil
+
+	case atom.Table:
+		if table := c.table(n); table != "" {
+			return []string{table}
+		}
+		return nil
+
+	case atom.Hr:
+		if c.markdown {
+			return []string{"---"}
+		}
+		return nil
+
+	case atom.Script, atom.Style, atom.Head:
+		return nil
+
+	default:
+		// Containers such as figure or div
+		return c.blocks(n)
+	}
+}
+
+// list renders each item on its own line, indenting any continuation lines
+// (including nested lists) under the marker
+func (c converter) list(n *html.Node) string {
+	var items []string
+	number := 1
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.DataAtom != atom.Li {
+			continue
+		}
+
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+
+		content := strings.Join(c.blocks(li), "\n")
+		items = append(items, prefixLines(content, marker, strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// table renders one line per row. Markdown tables treat the first row as
+// the header, since that is where article tables put it.
+func (c converter) table(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.DataAtom != atom.Tr {
+				walk(child)
+				continue
+			}
+
+			var cells []string
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+					text := strings.ReplaceAll(c.inlineText(cell), "\n", " ")
+					if c.markdown {
+						text = strings.ReplaceAll(text, "|", `\|`)
+					}
+					cells = append(cells, text)
+				}
+			}
+			if len(cells) > 0 {
+				rows = append(rows, cells)
+			}
+		}
+	}
+	walk(n)
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for i, cells := range rows {
+		if !c.markdown {
+			lines = append(lines, strings.Join(cells, " | "))
+			continue
+		}
+
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", len(cells)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c converter) inlineText(n *html.Node) string {
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.inline(child, &sb)
+	}
+	return cleanInline(sb.String())
+}
+
+func (c converter) inline(n *html.Node, sb *strings.Builder) {
+	switch n.Type {
+	case html.TextNode:
+		text := collapseSpace(n.Data)
+		if c.markdown {
+			text = escapeMarkdown(text)
+		}
+		sb.WriteString(text)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		sb.WriteString("\n")
+
+	case atom.Img:
+		alt := strings.TrimSpace(collapseSpace(attr(n, "alt")))
+		if !c.markdown {
+			if alt != "" {
+				sb.WriteString("[Image: " + alt + "]")
+			}
+			return
+		}
+		if src := attr(n, "src"); src != "" {
+			sb.WriteString("![" + escapeMarkdown(alt) + "](" + src + ")")
+		}
+
+	case atom.Strong, atom.B:
+		c.wrapInline(n, sb, "**")
+
+	case atom.Em, atom.I:
+		c.wrapInline(n, sb, "*")
+
+	case atom.A:
+		href := attr(n, "href")
+		if !c.markdown || href == "" {
+			c.children(n, sb)
+			return
+		}
+		text := strings.TrimSpace(c.inlineText(n))
+		if text == "" {
+			text = escapeMarkdown(href)
+		}
+		sb.WriteString("[" + text + "](" + href + ")")
+
+	case atom.Script, atom.Style:
+
+	default:
+		c.children(n, sb)
+	}
+}
+
+func (c converter) children(n *html.Node, sb *strings.Builder) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.inline(child, sb)
+	}
+}
+
+// wrapInline surrounds an element's text with a Markdown marker. Surrounding
+// spaces are kept outside the marker, since "** bold**" is not emphasis.
+func (c converter) wrapInline(n *html.Node, sb *strings.Builder, marker string) {
+	var inner strings.Builder
+	c.children(n, &inner)
+	text := inner.String()
+
+	if !c.markdown {
+		sb.WriteString(text)
+		return
+	}
+
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		sb.WriteString(text)
+		return
+	}
+	if strings.HasPrefix(text, " ") {
+		sb.WriteString(" ")
+	}
+	sb.WriteString(marker + trimmed + marker)
+	if strings.HasSuffix(text, " ") {
+		sb.WriteString(" ")
+	}
+}
+
+func isBlock(a atom.Atom) bool {
+	switch a {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6,
+		atom.P, atom.Blockquote, atom.Ul, atom.Ol, atom.Li,
+		atom.Figure, atom.Figcaption, atom.Table, atom.Hr,
+		atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Pre,
+		atom.Script, atom.Style, atom.Head:
+		return true
+	}
+	return false
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// collapseSpace replaces each run of whitespace with a single space, as a
+// browser would when rendering the text
+func collapseSpace(s string) string {
+	var sb strings.Builder
+	space := false
+	for _, r := range s {
+		switch r {
+		case ' ', '\t', '\n', '\r', '\f':
+			if !space {
+				sb.WriteByte(' ')
+			}
+			space = true
+		default:
+			sb.WriteRune(r)
+			space = false
+		}
+	}
+	return sb.String()
+}
+
+// cleanInline trims each line of a rendered paragraph and drops the spaces
+// left where text nodes met
+func cleanInline(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func prefixLines(s, first, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		if line == "" {
+			prefix = strings.TrimRight(prefix, " ")
+		}
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+)
+
+// escapeMarkdown escapes characters that would otherwise start emphasis,
+// code, or a link
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// FirstBlocks returns the HTML of the first n top-level blocks of src, such
+// as paragraphs, headings or figures, for a preview of an article. Runs of
+// bare text count as a block.
+func FirstBlocks(src string, n int) string {
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil || n <= 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	count := 0
+	for _, node := range nodes {
+		if node.Type == html.TextNode && strings.TrimSpace(node.Data) == "" {
+			continue
+		}
+		if node.Type != html.ElementNode && node.Type != html.TextNode {
+			continue
+		}
+		if count == n {
+			break
+		}
+		_ = html.Render(&out, node)
+		count++
+	}
+	return out.String()
+}
+
+// Summary returns the text of article HTML on one line, cut at a word
+// boundary to at most max characters, for a summary or meta description.
+// Images, scripts and styles contribute nothing, so markup without text
+// gives "".
+func Summary(src string, max int) string {
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, n := range nodes {
+		writeText(n, &sb)
+	}
+	return Truncate(strings.Join(strings.Fields(sb.String()), " "), max)
+}
+
+// writeText writes the text under n, with a space wherever a block or line
+// break would separate words
+func writeText(n *html.Node, sb *strings.Builder) {
+	switch n.Type {
+	case html.TextNode:
+		sb.WriteString(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Head:
+		return
+	case atom.Br, atom.Td, atom.Th:
+		sb.WriteString(" ")
+	}
+
+	block := isBlock(n.DataAtom)
+	if block {
+		sb.WriteString(" ")
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeText(child, sb)
+	}
+	if block {
+		sb.WriteString(" ")
+	}
+}
+
+// Truncate shortens text to at most max characters, ending at a word
+// boundary with an ellipsis. A single word longer than max is cut.
+func Truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	if max <= 1 {
+		return string(runes[:max])
+	}
+
+	cut := string(runes[:max-1]) // Leaves room for the ellipsis
+	if runes[max-1] != ' ' {
+		if i := strings.LastIndexByte(cut, ' '); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRight(cut, " ,;:-–—") + "…"
+}
+
+// FirstImage returns the src of the first image in article HTML with an
+// http or https URL, or "" if there is none
+func FirstImage(src string) string {
+	tokens := html.NewTokenizer(strings.NewReader(src))
+	for {
+		switch tokens.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := tokens.Token()
+			if tok.DataAtom != atom.Img {
+				continue
+			}
+			for _, a := range tok.Attr {
+				if a.Key != "src" {
+					continue
+				}
+				url := strings.TrimSpace(a.Val)
+				if strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://") {
+					return url
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package htmltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToPlainText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "paragraphs are separated by a blank line",
+			in:   "<p>First   paragraph\n spans lines.</p><p>Second &amp; last.</p>",
+			want: "First paragraph spans lines.\n\nSecond & last.",
+		},
+		{
+			name: "headings get a marker regardless of level",
+			in:   "<h2>Budget</h2><p>Text</p><h4>Details</h4>",
+			want: "## Budget\n\nText\n\n## Details",
+		},
+		{
+			name: "blockquote lines are prefixed",
+			in:   "<blockquote><p>One</p><p>Two</p></blockquote>",
+			want: "> One\n>\n> Two",
+		},
+		{
+			name: "formatting and links keep only their text",
+			in:   `<p>A <strong>bold</strong> and <em>quiet</em> <a href="https://example.com">link</a>.</p>`,
+			want: "A bold and quiet link.",
+		},
+		{
+			name: "lists",
+			in:   "<ul><li>One</li><li>Two</li></ul><ol><li>First</li><li>Second</li></ol>",
+			want: "- One\n- Two\n\n1. First\n2. Second",
+		},
+		{
+			name: "images become their alt text",
+			in:   `<figure><img src="/a.jpg" alt="The Senate floor"><figcaption>Session</figcaption></figure><p><img src="/b.jpg"></p>`,
+			want: "[Image: The Senate floor]\n\nSession",
+		},
+		{
+			name: "tables render a line per row",
+			in:   "<table><thead><tr><th>Name</th><th>Votes</th></tr></thead><tbody><tr><td>Yes</td><td>12</td></tr></tbody></table>",
+			want: "Name | Votes\nYes | 12",
+		},
+		{
+			name: "bare text outside blocks is kept",
+			in:   "Loose text<p>Para</p>",
+			want: "Loose text\n\nPara",
+		},
+		{
+			name: "empty input",
+			in:   "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ToPlainText(tt.in))
+		})
+	}
+}
+
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "headings keep their level",
+			in:   "<h2>Budget</h2><h3>Details</h3>",
+			want: "## Budget\n\n### Details",
+		},
+		{
+			name: "inline formatting and links",
+			in:   `<p>A<strong> bold </strong>word, <em>quiet</em> and <a href="https://example.com/a">a link</a>.</p>`,
+			want: "A **bold** word, *quiet* and [a link](https://example.com/a).",
+		},
+		{
+			name: "special characters are escaped",
+			in:   "<p>2*3 = snake_case [sic]</p>",
+			want: `2\*3 = snake\_case \[sic\]`,
+		},
+		{
+			name: "images",
+			in:   `<figure><img src="https://cdn.example.com/a.jpg" alt="Floor"></figure>`,
+			want: "![Floor](https://cdn.example.com/a.jpg)",
+		},
+		{
+			name: "nested lists are indented",
+			in:   "<ol><li>One<ul><li>Sub</li></ul></li><li>Two</li></ol>",
+			want: "1. One\n   - Sub\n2. Two",
+		},
+		{
+			name: "tables use the first row as header",
+			in:   "<table><tr><th>Name</th><th>Party</th></tr><tr><td>A | B</td><td>X</td></tr></table>",
+			want: "| Name | Party |\n| --- | --- |\n| A \\| B | X |",
+		},
+		{
+			name: "blockquote",
+			in:   "<blockquote><p>Quoted <em>text</em></p></blockquote>",
+			want: "> Quoted *text*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ToMarkdown(tt.in))
+		})
+	}
+}
+
+func TestFirstBlocks(t *testing.T) {
+	src := "<h2>Budget</h2>\n<p>One &amp; two</p>\n<!-- note -->\n<p>Three</p><p>Four</p>"
+
+	assert.Equal(t, "<h2>Budget</h2><p>One &amp; two</p>", FirstBlocks(src, 2))
+	assert.Equal(t, "<h2>Budget</h2><p>One &amp; two</p><p>Three</p><p>Four</p>", FirstBlocks(src, 10))
+	assert.Equal(t, "", FirstBlocks(src, 0))
+	assert.Equal(t, "", FirstBlocks("", 3))
+}
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{
+			name: "blocks are joined with a space",
+			in:   "<h2>Budget</h2><p>First   line<br>second &amp; last.</p><ul><li>One</li><li>Two</li></ul>",
+			max:  160,
+			want: "Budget First line second & last. One Two",
+		},
+		{
+			name: "inline markup doesn't split words",
+			in:   "<p>Sen<strong>ate</strong> bill</p>",
+			max:  160,
+			want: "Senate bill",
+		},
+		{
+			name: "scripts, styles, images and comments are dropped",
+			in:   `<p>Text</p><script>alert(1)</script><style>p{}</style><img src="x.png" alt="Photo"><!--explainer:pork-barrel-->`,
+			max:  160,
+			want: "Text",
+		},
+		{
+			name: "markup without text is empty",
+			in:   `<p> </p><figure><img src="x.png"></figure><hr>`,
+			max:  160,
+			want: "",
+		},
+		{
+			name: "long text is cut at a word boundary",
+			in:   "<p>The Senate passed the budget, after a long debate.</p>",
+			max:  30,
+			want: "The Senate passed the budget…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Summary(tt.in, tt.max))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "Short enough", Truncate("Short enough", 12))
+	assert.Equal(t, "Short…", Truncate("Short enough", 11))
+	assert.Equal(t, "Short…", Truncate("Short enough", 7))
+	assert.Equal(t, "Pambansang…", Truncate("Pambansang Kamao", 12))
+	assert.Equal(t, "Supercalif…", Truncate("Supercalifragilistic", 11))
+	assert.Equal(t, "Ñaña ñaña…", Truncate("Ñaña ñaña ñaña", 11), "counts characters, not bytes")
+}
+
+func TestFirstImage(t *testing.T) {
+	assert.Equal(t, "https://cdn.example.com/a.jpg",
+		FirstImage(`<p>Intro</p><figure><img alt="A" src=" https://cdn.example.com/a.jpg "></figure><img src="https://cdn.example.com/b.jpg">`))
+	assert.Equal(t, "https://cdn.example.com/b.jpg",
+		FirstImage(`<img src="data:image/png;base64,AAAA"><img src="/relative.png"><img src="https://cdn.example.com/b.jpg"/>`),
+		"skips images without an absolute web URL")
+	assert.Equal(t, "", FirstImage(`<p>No images here</p>`))
+}
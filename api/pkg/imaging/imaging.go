@@ -0,0 +1,229 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	// Register decoders for the formats accepted as uploads
+	_ "golang.org/x/image/webp"
+	_ "image/gif"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	// MinDimension and MaxDimension bound each side of an uploaded image
+	MinDimension = 100
+	MaxDimension = 8000
+
+	jpegQuality = 85
+)
+
+// Decode checks an image's format and dimensions from its header before
+// decoding the pixels, so oversized images are rejected cheaply
+func Decode(data []byte) (image.Image, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("unsupported or corrupt image")
+	}
+	if cfg.Width < MinDimension || cfg.Height < MinDimension {
+		return nil, "", fmt.Errorf("image must be at least %dx%d pixels", MinDimension, MinDimension)
+	}
+	if cfg.Width > MaxDimension || cfg.Height > MaxDimension {
+		return nil, "", fmt.Errorf("image must be at most %dx%d pixels", MaxDimension, MaxDimension)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("unsupported or corrupt image")
+	}
+	return img, format, nil
+}
+
+// CropSquare cuts the largest square that keeps the focal point (percentages
+// of width and height) as close to its centre as the edges allow, then scales
+// it to size x size
+func CropSquare(src image.Image, size int, focalX, focalY float64) image.Image {
+	b := src.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+
+	x0 := b.Min.X + clampOffset(int(float64(b.Dx())*focalX/100)-side/2, b.Dx()-side)
+	y0 := b.Min.Y + clampOffset(int(float64(b.Dy())*focalY/100)-side/2, b.Dy()-side)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, image.Rect(x0, y0, x0+side, y0+side), draw.Src, nil)
+	return dst
+}
+
+// CropFill cuts the largest centred region with the aspect ratio of width x
+// height, then scales it to exactly that size
+func CropFill(src image.Image, width, height int) image.Image {
+	b := src.Bounds()
+	cropW, cropH := b.Dx(), b.Dy()
+	if cropW*height > cropH*width {
+		cropW = cropH * width / height
+	} else {
+		cropH = cropW * height / width
+	}
+	cropW, cropH = max(cropW, 1), max(cropH, 1)
+
+	x0 := b.Min.X + (b.Dx()-cropW)/2
+	y0 := b.Min.Y + (b.Dy()-cropH)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, image.Rect(x0, y0, x0+cropW, y0+cropH), draw.Src, nil)
+	return dst
+}
+
+// FitWidth scales src down to maxWidth, keeping its aspect ratio. Images that
+// are already narrow enough are returned unchanged.
+func FitWidth(src image.Image, maxWidth int) image.Image {
+	b := src.Bounds()
+	if b.Dx() <= maxWidth {
+		return src
+	}
+
+	height := b.Dy() * maxWidth / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, maxWidth, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+// Encode writes img as JPEG when it is fully opaque and as PNG otherwise, so
+// transparent logos keep their transparency. It returns the content type and
+// file extension used.
+func Encode(w io.Writer, img image.Image) (string, string, error) {
+	if IsOpaque(img) {
+		if err := jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return "", "", fmt.Errorf("failed to encode jpeg: %w", err)
+		}
+		return "image/jpeg", ".jpg", nil
+	}
+
+	if err := png.Encode(w, img); err != nil {
+		return "", "", fmt.Errorf("failed to encode png: %w", err)
+	}
+	return "image/png", ".png", nil
+}
+
+// EncodeSmallest writes img as lossless WebP or as Encode would, whichever
+// comes out smaller. The WebP encoder is lossless without backward
+// references, so in practice photos and most graphics stay JPEG or PNG. It returns the content type and
+// file extension used.
+func EncodeSmallest(w io.Writer, img image.Image) (string, string, error) {
+	var webp, other bytes.Buffer
+	if err := EncodeWebP(&webp, img); err != nil {
+		return "", "", err
+	}
+	contentType, ext, err := Encode(&other, img)
+	if err != nil {
+		return "", "", err
+	}
+
+	smallest := &other
+	if webp.Len() < other.Len() {
+		smallest, contentType, ext = &webp, "image/webp", ".webp"
+	}
+	if _, err := smallest.WriteTo(w); err != nil {
+		return "", "", fmt.Errorf("failed to write image: %w", err)
+	}
+	return contentType, ext, nil
+}
+
+// IsOpaque reports whether every pixel of img is fully opaque
+func IsOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0xffff {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Initials returns up to two uppercase initials for a name: the first letters
+// of its first and last words
+func Initials(name string) string {
+	var words []string
+	for _, w := range strings.Fields(name) {
+		if r := firstLetter(w); r != 0 {
+			words = append(words, string(unicode.ToUpper(r)))
+		}
+	}
+
+	switch len(words) {
+	case 0:
+		return "?"
+	case 1:
+		return words[0]
+	default:
+		return words[0] + words[len(words)-1]
+	}
+}
+
+// placeholderColors are the backgrounds initials placeholders pick from
+var placeholderColors = []string{
+	"#1e3a8a", "#065f46", "#7c2d12", "#581c87", "#9f1239", "#134e4a", "#3f3f46", "#854d0e",
+}
+
+// InitialsSVG renders a square placeholder showing a short label such as a
+// person's initials or a party abbreviation. The background colour is derived
+// from the label so it stays stable.
+func InitialsSVG(label string) []byte {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(label))
+	color := placeholderColors[h.Sum32()%uint32(len(placeholderColors))]
+
+	fontSize := 200
+	if utf8.RuneCountInString(label) > 2 {
+		fontSize = 140
+	}
+
+	return []byte(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="512" height="512" viewBox="0 0 512 512">`+
+			`<rect width="512" height="512" fill="%s"/>`+
+			`<text x="50%%" y="50%%" dy=".35em" fill="#ffffff" font-family="Helvetica, Arial, sans-serif" font-size="%d" font-weight="600" text-anchor="middle">%s</text>`+
+			`</svg>`,
+		color, fontSize, html.EscapeString(label),
+	))
+}
+
+func clampOffset(offset, max int) int {
+	if offset < 0 {
+		return 0
+	}
+	if offset > max {
+		return max
+	}
+	return offset
+}
+
+func firstLetter(word string) rune {
+	for _, r := range word {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+	}
+	return 0
+}
@@ -0,0 +1,183 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+// halves returns a w x h image whose left half is red and right half is blue
+func halves(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("accepts an image within bounds", func(t *testing.T) {
+		img, format, err := Decode(encodePNG(t, halves(300, 200)))
+		require.NoError(t, err)
+		assert.Equal(t, "png", format)
+		assert.Equal(t, 300, img.Bounds().Dx())
+	})
+
+	t.Run("rejects images that are too small", func(t *testing.T) {
+		_, _, err := Decode(encodePNG(t, halves(MinDimension-1, 200)))
+		assert.EqualError(t, err, "image must be at least 100x100 pixels")
+	})
+
+	t.Run("rejects non-images", func(t *testing.T) {
+		_, _, err := Decode([]byte("%PDF-1.4 not an image"))
+		assert.EqualError(t, err, "unsupported or corrupt image")
+	})
+}
+
+func TestCropSquare(t *testing.T) {
+	src := halves(400, 200)
+
+	t.Run("focal point picks the side of a wide image", func(t *testing.T) {
+		left := CropSquare(src, 64, 0, 50)
+		right := CropSquare(src, 64, 100, 50)
+
+		assert.Equal(t, image.Rect(0, 0, 64, 64), left.Bounds())
+		r, _, b, _ := left.At(32, 32).RGBA()
+		assert.Greater(t, r, b)
+		r, _, b, _ = right.At(32, 32).RGBA()
+		assert.Greater(t, b, r)
+	})
+
+	t.Run("centre crop straddles the middle", func(t *testing.T) {
+		center := CropSquare(src, 64, 50, 50)
+		r, _, b, _ := center.At(4, 32).RGBA()
+		assert.Greater(t, r, b)
+		r, _, b, _ = center.At(60, 32).RGBA()
+		assert.Greater(t, b, r)
+	})
+}
+
+func TestCropFill(t *testing.T) {
+	wide := CropFill(halves(400, 200), 120, 40)
+	assert.Equal(t, image.Rect(0, 0, 120, 40), wide.Bounds())
+
+	// A square from the middle of a wide image keeps both halves
+	square := CropFill(halves(400, 200), 64, 64)
+	r, _, b, _ := square.At(4, 32).RGBA()
+	assert.Greater(t, r, b)
+	r, _, b, _ = square.At(60, 32).RGBA()
+	assert.Greater(t, b, r)
+}
+
+func TestFitWidth(t *testing.T) {
+	assert.Equal(t, image.Rect(0, 0, 320, 80), FitWidth(halves(1280, 320), 320).Bounds())
+
+	small := halves(200, 100)
+	assert.Same(t, small, FitWidth(small, 320))
+}
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	contentType, ext, err := Encode(&buf, halves(10, 10))
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", contentType)
+	assert.Equal(t, ".jpg", ext)
+
+	transparent := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	buf.Reset()
+	contentType, ext, err = Encode(&buf, transparent)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+	assert.Equal(t, ".png", ext)
+}
+
+// photoLike returns an opaque image with smooth shading and sensor-like
+// noise, which lossless coding handles far worse than JPEG
+func photoLike(w, h int) *image.RGBA {
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			shade := 100 + 60*math.Sin(float64(x)/37) + 40*math.Cos(float64(y)/23)
+			noise := func() uint8 { return uint8(min(max(shade+float64(rng.Intn(17)-8), 0), 255)) }
+			img.Set(x, y, color.RGBA{R: noise(), G: noise(), B: noise(), A: 255})
+		}
+	}
+	return img
+}
+
+func TestEncodeSmallest(t *testing.T) {
+	encodedSize := func(encode func(*bytes.Buffer) error) int {
+		var buf bytes.Buffer
+		require.NoError(t, encode(&buf))
+		return buf.Len()
+	}
+
+	t.Run("keeps the JPEG of a photo", func(t *testing.T) {
+		src := photoLike(1200, 675)
+		webpSize := encodedSize(func(b *bytes.Buffer) error { return EncodeWebP(b, src) })
+		jpegSize := encodedSize(func(b *bytes.Buffer) error { _, _, err := Encode(b, src); return err })
+		require.Greater(t, webpSize, jpegSize)
+
+		var buf bytes.Buffer
+		contentType, ext, err := EncodeSmallest(&buf, src)
+		require.NoError(t, err)
+		assert.Equal(t, "image/jpeg", contentType)
+		assert.Equal(t, ".jpg", ext)
+		assert.Equal(t, jpegSize, buf.Len())
+		// Well under the three bytes a pixel takes uncompressed
+		assert.Less(t, buf.Len(), 1200*675)
+	})
+
+	t.Run("picks WebP when it is smaller", func(t *testing.T) {
+		// Plain black predicts perfectly, so every residual codes in no bits
+		src := image.NewRGBA(image.Rect(0, 0, 400, 250))
+		for i := 3; i < len(src.Pix); i += 4 {
+			src.Pix[i] = 0xff
+		}
+		jpegSize := encodedSize(func(b *bytes.Buffer) error { _, _, err := Encode(b, src); return err })
+
+		var buf bytes.Buffer
+		contentType, ext, err := EncodeSmallest(&buf, src)
+		require.NoError(t, err)
+		assert.Equal(t, "image/webp", contentType)
+		assert.Equal(t, ".webp", ext)
+		assert.Less(t, buf.Len(), jpegSize)
+		_, err = webp.Decode(bytes.NewReader(buf.Bytes()))
+		assert.NoError(t, err)
+	})
+}
+
+func TestInitials(t *testing.T) {
+	assert.Equal(t, "JD", Initials("Juan dela Cruz Dimaculangan"))
+	assert.Equal(t, "MS", Initials("maria santos"))
+	assert.Equal(t, "Ñ", Initials("  Ñino "))
+	assert.Equal(t, "JR", Initials("\"Jun\" Reyes"))
+	assert.Equal(t, "?", Initials(""))
+}
+
+func TestInitialsSVG(t *testing.T) {
+	svg := string(InitialsSVG("A&B"))
+	assert.Contains(t, svg, "A&amp;B")
+	assert.Equal(t, svg, string(InitialsSVG("A&B")))
+}
@@ -0,0 +1,488 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+	"sort"
+
+	"golang.org/x/image/draw"
+)
+
+// WebP output is lossless (VP8L): the standard library and x/image only ship
+// WebP decoders, and lossless keeps the encoder small. The subtract-green and
+// predictor transforms do most of the work; there are no backward references,
+// so photos come out several times larger than as JPEG. Use EncodeSmallest
+// for uploads.
+// See https://developers.google.com/speed/webp/docs/webp_lossless_bitstream_specification
+
+const (
+	vp8lSignature = 0x2f
+
+	transformPredictor     = 0
+	transformSubtractGreen = 2
+
+	// predictorBits sets the predictor tile size, 1<<predictorBits pixels square
+	predictorBits = 4
+
+	maxCodeLength           = 15
+	maxCodeLengthCodeLength = 7
+
+	// Alphabet sizes of the green (plus backward reference lengths), red,
+	// blue, alpha and distance codes
+	greenAlphabetSize    = 256 + 24
+	literalAlphabetSize  = 256
+	distanceAlphabetSize = 40
+)
+
+// predictorModes are the predictors tried for each tile: L, T, Average2(L, T),
+// Select(L, T, TL) and ClampAddSubtractFull(L, T, TL). The rest either rarely
+// win on photos or read the top-right pixel, whose edge rules are fiddly.
+var predictorModes = []uint8{1, 2, 7, 11, 12}
+
+// codeLengthCodeOrder is the order code length code lengths are written in
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// EncodeWebP writes img as a lossless WebP. Only pixels are written, so no
+// metadata carries over from the source file.
+func EncodeWebP(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width < 1 || height < 1 || width > 1<<14 || height > 1<<14 {
+		return fmt.Errorf("failed to encode webp: image must be between 1x1 and 16384x16384 pixels")
+	}
+
+	nrgba := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(nrgba, nrgba.Bounds(), img, b.Min, draw.Src)
+	pix := nrgba.Pix
+
+	var bw bitWriter
+	bw.write(vp8lSignature, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if IsOpaque(nrgba) {
+		bw.write(0, 1)
+	} else {
+		bw.write(1, 1)
+	}
+	bw.write(0, 3) // Version
+
+	// The decoder undoes transforms in reverse, so they are applied in the
+	// order they are written
+	bw.write(1, 1)
+	bw.write(transformSubtractGreen, 2)
+	for p := 0; p < len(pix); p += 4 {
+		pix[p+0] -= pix[p+1]
+		pix[p+2] -= pix[p+1]
+	}
+
+	bw.write(1, 1)
+	bw.write(transformPredictor, 2)
+	bw.write(predictorBits-2, 3)
+	tiles, residuals := predict(pix, width, height)
+	writeImage(&bw, tiles, false)
+
+	bw.write(0, 1) // No more transforms
+	writeImage(&bw, residuals, true)
+
+	data := bw.bytes()
+	padding := len(data) % 2
+
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(12+len(data)+padding))
+	copy(header[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(header[16:], uint32(len(data)))
+
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to encode webp: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to encode webp: %w", err)
+	}
+	if padding == 1 {
+		if _, err := w.Write([]byte{0}); err != nil {
+			return fmt.Errorf("failed to encode webp: %w", err)
+		}
+	}
+	return nil
+}
+
+// predict picks a predictor for each tile of pix (RGBA bytes), choosing the
+// one with the smallest residuals, and returns the tile image along with the
+// residual of every pixel
+func predict(pix []byte, width, height int) (tiles, residuals []byte) {
+	tileSize := 1 << predictorBits
+	tilesWide := (width + tileSize - 1) / tileSize
+	tilesHigh := (height + tileSize - 1) / tileSize
+	tiles = make([]byte, 4*tilesWide*tilesHigh)
+	residuals = make([]byte, len(pix))
+
+	mode := func(x, y int) uint8 {
+		return tiles[4*((y>>predictorBits)*tilesWide+(x>>predictorBits))+1]
+	}
+
+	for ty := 0; ty < tilesHigh; ty++ {
+		for tx := 0; tx < tilesWide; tx++ {
+			best, bestCost := predictorModes[0], -1
+			for _, m := range predictorModes {
+				cost := 0
+				for y := ty * tileSize; y < min((ty+1)*tileSize, height); y++ {
+					for x := tx * tileSize; x < min((tx+1)*tileSize, width); x++ {
+						var pred [4]byte
+						predictPixel(pix, width, x, y, m, &pred)
+						p := 4 * (y*width + x)
+						for c := 0; c < 4; c++ {
+							cost += residualCost(pix[p+c] - pred[c])
+						}
+					}
+				}
+				if bestCost < 0 || cost < bestCost {
+					best, bestCost = m, cost
+				}
+			}
+			t := 4 * (ty*tilesWide + tx)
+			tiles[t+1] = best // The mode is read from green
+			tiles[t+3] = 0xff
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var pred [4]byte
+			predictPixel(pix, width, x, y, mode(x, y), &pred)
+			p := 4 * (y*width + x)
+			for c := 0; c < 4; c++ {
+				residuals[p+c] = pix[p+c] - pred[c]
+			}
+		}
+	}
+	return tiles, residuals
+}
+
+// predictPixel predicts the pixel at x, y from its already decoded
+// neighbours. The first pixel, first row and first column use fixed
+// predictors whatever the tile's mode.
+func predictPixel(pix []byte, width, x, y int, mode uint8, pred *[4]byte) {
+	p := 4 * (y*width + x)
+	switch {
+	case x == 0 && y == 0:
+		*pred = [4]byte{0, 0, 0, 0xff}
+		return
+	case y == 0:
+		copy(pred[:], pix[p-4:p])
+		return
+	case x == 0:
+		copy(pred[:], pix[p-4*width:p-4*width+4])
+		return
+	}
+
+	l, t, tl := pix[p-4:p], pix[p-4*width:p-4*width+4], pix[p-4*width-4:p-4*width]
+	switch mode {
+	case 1:
+		copy(pred[:], l)
+	case 2:
+		copy(pred[:], t)
+	case 7:
+		for c := 0; c < 4; c++ {
+			pred[c] = uint8((uint16(l[c]) + uint16(t[c])) / 2)
+		}
+	case 11:
+		pl, pt := 0, 0
+		for c := 0; c < 4; c++ {
+			pl += abs(int(tl[c]) - int(t[c]))
+			pt += abs(int(tl[c]) - int(l[c]))
+		}
+		if pl < pt {
+			copy(pred[:], l)
+		} else {
+			copy(pred[:], t)
+		}
+	case 12:
+		for c := 0; c < 4; c++ {
+			v := int(l[c]) + int(t[c]) - int(tl[c])
+			pred[c] = uint8(min(max(v, 0), 255))
+		}
+	}
+}
+
+// residualCost estimates how expensive a residual is to code: small positive
+// or negative differences are cheap
+func residualCost(r byte) int {
+	return min(int(r), 256-int(r))
+}
+
+// writeImage entropy codes pix (RGBA bytes) with one set of prefix codes.
+// Every pixel is written as a literal; there are no backward references or
+// colour cache.
+func writeImage(bw *bitWriter, pix []byte, topLevel bool) {
+	bw.write(0, 1) // No colour cache
+	if topLevel {
+		bw.write(0, 1) // No meta prefix codes
+	}
+
+	green := make([]int, greenAlphabetSize)
+	red := make([]int, literalAlphabetSize)
+	blue := make([]int, literalAlphabetSize)
+	alpha := make([]int, literalAlphabetSize)
+	for p := 0; p < len(pix); p += 4 {
+		red[pix[p+0]]++
+		green[pix[p+1]]++
+		blue[pix[p+2]]++
+		alpha[pix[p+3]]++
+	}
+
+	greenCode := writePrefixCode(bw, green)
+	redCode := writePrefixCode(bw, red)
+	blueCode := writePrefixCode(bw, blue)
+	alphaCode := writePrefixCode(bw, alpha)
+	writePrefixCode(bw, make([]int, distanceAlphabetSize))
+
+	for p := 0; p < len(pix); p += 4 {
+		greenCode.write(bw, int(pix[p+1]))
+		redCode.write(bw, int(pix[p+0]))
+		blueCode.write(bw, int(pix[p+2]))
+		alphaCode.write(bw, int(pix[p+3]))
+	}
+}
+
+// prefixCode holds the canonical code of each symbol, already bit-reversed
+// since the decoder reads codes from their most significant bit
+type prefixCode struct {
+	codes   []uint32
+	lengths []uint8
+}
+
+func (c *prefixCode) write(bw *bitWriter, symbol int) {
+	bw.write(c.codes[symbol], uint(c.lengths[symbol]))
+}
+
+// writePrefixCode writes a prefix code fitted to the symbol counts and
+// returns it. Codes with at most one symbol take no bits per symbol.
+func writePrefixCode(bw *bitWriter, counts []int) *prefixCode {
+	var used []int
+	for s, n := range counts {
+		if n > 0 {
+			used = append(used, s)
+		}
+	}
+
+	if len(used) <= 1 {
+		symbol := 0
+		if len(used) == 1 {
+			symbol = used[0]
+		}
+		bw.write(1, 1) // Simple code
+		bw.write(0, 1) // One symbol
+		if symbol < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(symbol), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(symbol), 8)
+		}
+		return &prefixCode{codes: make([]uint32, len(counts)), lengths: make([]uint8, len(counts))}
+	}
+
+	lengths := codeLengths(counts, maxCodeLength)
+
+	// The code lengths are themselves run-length and prefix coded: 0-15 are
+	// literal lengths, 17 and 18 are runs of zeros with 3 and 7 extra bits
+	type token struct {
+		symbol, extra int
+	}
+	var tokens []token
+	for i := 0; i < len(lengths); {
+		run := 1
+		for i+run < len(lengths) && lengths[i+run] == lengths[i] {
+			run++
+		}
+		switch {
+		case lengths[i] == 0 && run >= 11:
+			run = min(run, 138)
+			tokens = append(tokens, token{18, run - 11})
+		case lengths[i] == 0 && run >= 3:
+			run = min(run, 10)
+			tokens = append(tokens, token{17, run - 3})
+		default:
+			run = 1
+			tokens = append(tokens, token{int(lengths[i]), 0})
+		}
+		i += run
+	}
+
+	tokenCounts := make([]int, len(codeLengthCodeOrder))
+	for _, t := range tokens {
+		tokenCounts[t.symbol]++
+	}
+	tokenLengths := codeLengths(tokenCounts, maxCodeLengthCodeLength)
+	lengthCode := newPrefixCode(tokenLengths)
+
+	numCodes := len(codeLengthCodeOrder)
+	for numCodes > 4 && tokenLengths[codeLengthCodeOrder[numCodes-1]] == 0 {
+		numCodes--
+	}
+
+	bw.write(0, 1) // Normal code
+	bw.write(uint32(numCodes-4), 4)
+	for _, s := range codeLengthCodeOrder[:numCodes] {
+		bw.write(uint32(tokenLengths[s]), 3)
+	}
+	bw.write(0, 1) // Lengths for the whole alphabet follow
+
+	for _, t := range tokens {
+		lengthCode.write(bw, t.symbol)
+		switch t.symbol {
+		case 17:
+			bw.write(uint32(t.extra), 3)
+		case 18:
+			bw.write(uint32(t.extra), 7)
+		}
+	}
+
+	return newPrefixCode(lengths)
+}
+
+// newPrefixCode assigns canonical codes from code lengths. A lone symbol is
+// given no bits, as the decoder reads nothing for it.
+func newPrefixCode(lengths []uint8) *prefixCode {
+	c := &prefixCode{codes: make([]uint32, len(lengths)), lengths: make([]uint8, len(lengths))}
+
+	var perLength [maxCodeLength + 1]uint32
+	used := 0
+	for _, l := range lengths {
+		if l > 0 {
+			perLength[l]++
+			used++
+		}
+	}
+	if used <= 1 {
+		return c
+	}
+
+	var next [maxCodeLength + 1]uint32
+	code := uint32(0)
+	for l := 1; l <= maxCodeLength; l++ {
+		code = (code + perLength[l-1]) << 1
+		next[l] = code
+	}
+
+	for s, l := range lengths {
+		if l == 0 {
+			continue
+		}
+		c.codes[s] = reverseBits(next[l], l)
+		c.lengths[s] = l
+		next[l]++
+	}
+	return c
+}
+
+func reverseBits(code uint32, length uint8) uint32 {
+	var r uint32
+	for i := uint8(0); i < length; i++ {
+		r = r<<1 | code&1
+		code >>= 1
+	}
+	return r
+}
+
+// codeLengths builds Huffman code lengths no longer than maxLength for the
+// symbol counts. When the optimal tree is too deep, rare symbols are counted
+// as more common until it fits.
+func codeLengths(counts []int, maxLength int) []uint8 {
+	lengths := make([]uint8, len(counts))
+
+	var symbols []int
+	for s, n := range counts {
+		if n > 0 {
+			symbols = append(symbols, s)
+		}
+	}
+	switch len(symbols) {
+	case 0:
+		return lengths
+	case 1:
+		lengths[symbols[0]] = 1
+		return lengths
+	}
+
+	for floor := 1; ; floor *= 2 {
+		weight := func(s int) int { return max(counts[s], floor) }
+		sort.SliceStable(symbols, func(i, j int) bool { return weight(symbols[i]) < weight(symbols[j]) })
+
+		// Leaves come first in ascending weight, then internal nodes in the
+		// order they are made, which is also ascending; the two queues are
+		// merged by always taking the lighter front
+		n := len(symbols)
+		weights := make([]int, n, 2*n-1)
+		parents := make([]int, 2*n-1)
+		for i, s := range symbols {
+			weights[i] = weight(s)
+		}
+		leaf, internal := 0, n
+		lightest := func() int {
+			if leaf < n && (internal >= len(weights) || weights[leaf] <= weights[internal]) {
+				leaf++
+				return leaf - 1
+			}
+			internal++
+			return internal - 1
+		}
+		for len(weights) < 2*n-1 {
+			a, b := lightest(), lightest()
+			weights = append(weights, weights[a]+weights[b])
+			parents[a], parents[b] = len(weights)-1, len(weights)-1
+		}
+
+		depths := make([]int, 2*n-1)
+		deepest := 0
+		for i := 2*n - 3; i >= 0; i-- {
+			depths[i] = depths[parents[i]] + 1
+			if i < n {
+				deepest = max(deepest, depths[i])
+			}
+		}
+		if deepest > maxLength {
+			continue
+		}
+
+		for i, s := range symbols {
+			lengths[s] = uint8(depths[i])
+		}
+		return lengths
+	}
+}
+
+// bitWriter packs values least significant bit first, as VP8L reads them
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nBits uint
+}
+
+func (w *bitWriter) write(v uint32, n uint) {
+	w.acc |= uint64(v) << w.nBits
+	w.nBits += n
+	for w.nBits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nBits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.nBits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.nBits = 0, 0
+	}
+	return w.buf
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,77 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+func TestEncodeWebP(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	gradient := image.NewNRGBA(image.Rect(0, 0, 203, 117))
+	noise := image.NewNRGBA(image.Rect(0, 0, 64, 48))
+	translucent := image.NewNRGBA(image.Rect(0, 0, 33, 17))
+	for y := 0; y < 117; y++ {
+		for x := 0; x < 203; x++ {
+			gradient.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y * 2), B: uint8(x + y), A: 255})
+			if x < 64 && y < 48 {
+				noise.Set(x, y, color.NRGBA{R: uint8(rng.Intn(256)), G: uint8(rng.Intn(256)), B: uint8(rng.Intn(256)), A: 255})
+			}
+			if x < 33 && y < 17 {
+				translucent.Set(x, y, color.NRGBA{R: 200, G: uint8(x * 7), B: 10, A: uint8(y * 15)})
+			}
+		}
+	}
+	single := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	single.Set(0, 0, color.NRGBA{R: 1, G: 2, B: 3, A: 255})
+
+	for name, src := range map[string]*image.NRGBA{
+		"gradient":    gradient,
+		"noise":       noise,
+		"translucent": translucent,
+		"single":      single,
+		"offset":      gradient.SubImage(image.Rect(10, 20, 110, 70)).(*image.NRGBA),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, EncodeWebP(&buf, src))
+			assert.Equal(t, "RIFF", buf.String()[:4])
+
+			decoded, err := webp.Decode(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			require.Equal(t, src.Bounds().Size(), decoded.Bounds().Size())
+
+			// Lossless: every pixel comes back as it went in
+			b := src.Bounds()
+			for y := 0; y < b.Dy(); y++ {
+				for x := 0; x < b.Dx(); x++ {
+					want := src.NRGBAAt(b.Min.X+x, b.Min.Y+y)
+					if got := decoded.(*image.NRGBA).NRGBAAt(x, y); got != want {
+						t.Fatalf("pixel %d,%d = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeWebPCompressesSmoothImages(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 400, 250))
+	for y := 0; y < 250; y++ {
+		for x := 0; x < 400; x++ {
+			src.Set(x, y, color.RGBA{R: uint8(x / 2), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeWebP(&buf, src))
+	// Well under the four bytes a pixel takes uncompressed
+	assert.Less(t, buf.Len(), 400*250)
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerServesRecordedMetrics(t *testing.T) {
+	RecordHTTPRequest(http.MethodGet, "/api/articles", http.StatusOK, 30*time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain"))
+	body := rec.Body.String()
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/api/articles",status="200"}`)
+	assert.Contains(t, body, `http_request_duration_seconds_bucket{route="/api/articles",status="200",le="0.05"}`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestRecordHelpers(t *testing.T) {
+	before := testutil.ToFloat64(cacheLookups.WithLabelValues("hit"))
+	RecordCacheHit()
+	assert.Equal(t, before+1, testutil.ToFloat64(cacheLookups.WithLabelValues("hit")))
+
+	RecordDBQuery(20*time.Millisecond, errors.New("connection refused"))
+	assert.Positive(t, testutil.CollectAndCount(dbQueryDuration, "db_query_duration_seconds"))
+
+	before = testutil.ToFloat64(articlesPublished)
+	RecordArticlePublished()
+	assert.Equal(t, before+1, testutil.ToFloat64(articlesPublished))
+}
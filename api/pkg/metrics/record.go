@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the request and query
+// duration histograms
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var factory = promauto.With(Registry)
+
+var (
+	httpRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+	httpRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request duration by route and status.",
+		Buckets: durationBuckets,
+	}, []string{"route", "status"})
+	cacheLookups = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_lookups_total",
+		Help: "Redis cache lookups by result, hit or miss.",
+	}, []string{"result"})
+	dbQueryDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query duration by outcome, ok or error.",
+		Buckets: durationBuckets,
+	}, []string{"outcome"})
+	articlesPublished = factory.NewCounter(prometheus.CounterOpts{
+		Name: "articles_published_total",
+		Help: "Articles published, directly or by the scheduler.",
+	})
+)
+
+// RecordHTTPRequest counts a served request under its route pattern
+func RecordHTTPRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpRequestDuration.WithLabelValues(route, code).Observe(duration.Seconds())
+}
+
+// RecordCacheHit counts a cache lookup that found its key
+func RecordCacheHit() {
+	cacheLookups.WithLabelValues("hit").Inc()
+}
+
+// RecordCacheMiss counts a cache lookup that did not, including failed ones
+func RecordCacheMiss() {
+	cacheLookups.WithLabelValues("miss").Inc()
+}
+
+// RecordDBQuery times a database query
+func RecordDBQuery(duration time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	dbQueryDuration.WithLabelValues(outcome).Observe(duration.Seconds())
+}
+
+// RecordArticlePublished counts an article going live
+func RecordArticlePublished() {
+	articlesPublished.Inc()
+}
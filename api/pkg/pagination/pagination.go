@@ -0,0 +1,47 @@
+package pagination
+
+// PaginationMeta describes the page a list response covers
+type PaginationMeta struct {
+	Page       int  `json:"page"`
+	PerPage    int  `json:"per_page"`
+	Total      int  `json:"total"`
+	TotalPages int  `json:"total_pages"`
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+}
+
+// PaginatedResponse is the standard envelope for paginated list endpoints
+type PaginatedResponse[T any] struct {
+	Items []T            `json:"items"`
+	Meta  PaginationMeta `json:"meta"`
+}
+
+// NewPaginationMeta computes total pages and navigation flags for a page
+func NewPaginationMeta(page, perPage, total int) PaginationMeta {
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = (total + perPage - 1) / perPage
+	}
+
+	return PaginationMeta{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
+
+// NewPaginatedResponse wraps a page of items. A nil slice is encoded as an
+// empty array rather than null.
+func NewPaginatedResponse[T any](items []T, page, perPage, total int) *PaginatedResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return &PaginatedResponse[T]{
+		Items: items,
+		Meta:  NewPaginationMeta(page, perPage, total),
+	}
+}
@@ -0,0 +1,42 @@
+package pagination
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPaginationMeta(t *testing.T) {
+	tests := []struct {
+		name                 string
+		page, perPage, total int
+		want                 PaginationMeta
+	}{
+		{"empty", 1, 20, 0, PaginationMeta{Page: 1, PerPage: 20, Total: 0, TotalPages: 0}},
+		{"single page", 1, 20, 5, PaginationMeta{Page: 1, PerPage: 20, Total: 5, TotalPages: 1}},
+		{"first of many", 1, 10, 25, PaginationMeta{Page: 1, PerPage: 10, Total: 25, TotalPages: 3, HasNext: true}},
+		{"middle", 2, 10, 25, PaginationMeta{Page: 2, PerPage: 10, Total: 25, TotalPages: 3, HasNext: true, HasPrev: true}},
+		{"last", 3, 10, 25, PaginationMeta{Page: 3, PerPage: 10, Total: 25, TotalPages: 3, HasPrev: true}},
+		{"exact multiple", 2, 10, 20, PaginationMeta{Page: 2, PerPage: 10, Total: 20, TotalPages: 2, HasPrev: true}},
+		{"zero per page", 1, 0, 5, PaginationMeta{Page: 1, PerPage: 0, Total: 5, TotalPages: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewPaginationMeta(tt.page, tt.perPage, tt.total))
+		})
+	}
+}
+
+func TestNewPaginatedResponseEncodesEmptyItems(t *testing.T) {
+	var items []string
+	data, err := json.Marshal(NewPaginatedResponse(items, 1, 20, 0))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"items": [],
+		"meta": {"page": 1, "per_page": 20, "total": 0, "total_pages": 0, "has_next": false, "has_prev": false}
+	}`, string(data))
+}
@@ -0,0 +1,72 @@
+package pdftext
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// magic is the header every PDF file starts with
+var magic = []byte("%PDF-")
+
+// IsPDF reports whether data starts with the PDF header. The check uses the
+// file contents, not its name or declared type.
+func IsPDF(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+// Extract returns the text of every page, one page per paragraph, along with
+// the page count. Scanned PDFs have no text layer and return an empty string.
+// Malformed files return an error rather than panicking the caller.
+func Extract(data []byte) (text string, pages int, err error) {
+	if !IsPDF(data) {
+		return "", 0, fmt.Errorf("not a PDF file")
+	}
+
+	// The parser panics on some malformed input
+	defer func() {
+		if r := recover(); r != nil {
+			text, pages, err = "", 0, fmt.Errorf("failed to read PDF: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read PDF: %w", err)
+	}
+
+	pages = reader.NumPage()
+	fonts := make(map[string]*pdf.Font)
+	paragraphs := make([]string, 0, pages)
+	for i := 1; i <= pages; i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		// Share parsed fonts across pages, as the reader's own GetPlainText does
+		for _, name := range page.Fonts() {
+			if _, ok := fonts[name]; !ok {
+				f := page.Font(name)
+				fonts[name] = &f
+			}
+		}
+
+		content, err := page.GetPlainText(fonts)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to read page %d: %w", i, err)
+		}
+		if content = normalizeSpace(content); content != "" {
+			paragraphs = append(paragraphs, content)
+		}
+	}
+
+	return strings.Join(paragraphs, "\n\n"), pages, nil
+}
+
+// normalizeSpace collapses runs of whitespace and drops NUL bytes, which
+// Postgres text columns reject
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(s, "\x00", "")), " ")
+}
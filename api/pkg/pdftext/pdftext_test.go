@@ -0,0 +1,82 @@
+package pdftext
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildPDF writes a minimal PDF with one page per entry of pages. Each page
+// shows its string in Helvetica; an empty string gives a page with no text,
+// like a scanned image.
+func buildPDF(pages ...string) []byte {
+	var objects []string
+	kids := ""
+	for i, text := range pages {
+		pageNum := 4 + i*2
+		kids += fmt.Sprintf("%d 0 R ", pageNum)
+		stream := ""
+		if text != "" {
+			stream = fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+		}
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pageNum+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream),
+		)
+	}
+	objects = append([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids, len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}, objects...)
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+func TestIsPDF(t *testing.T) {
+	assert.True(t, IsPDF(buildPDF("x")))
+	assert.False(t, IsPDF([]byte("\x89PNG\r\n\x1a\n")))
+	assert.False(t, IsPDF(nil))
+}
+
+func TestExtract(t *testing.T) {
+	t.Run("reads text from every page", func(t *testing.T) {
+		text, pages, err := Extract(buildPDF("An Act providing free tuition", "Section 2.   Coverage"))
+		require.NoError(t, err)
+		assert.Equal(t, 2, pages)
+		assert.Equal(t, "An Act providing free tuition\n\nSection 2. Coverage", text)
+	})
+
+	t.Run("pages without a text layer yield nothing", func(t *testing.T) {
+		text, pages, err := Extract(buildPDF(""))
+		require.NoError(t, err)
+		assert.Equal(t, 1, pages)
+		assert.Empty(t, text)
+	})
+
+	t.Run("rejects other files", func(t *testing.T) {
+		_, _, err := Extract([]byte("plain text"))
+		assert.EqualError(t, err, "not a PDF file")
+	})
+
+	t.Run("malformed PDFs return an error", func(t *testing.T) {
+		_, _, err := Extract([]byte("%PDF-1.4\ngarbage"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,89 @@
+package sanitize
+
+import (
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Policies are safe for concurrent use, so they are built once at startup
+var articlePolicy = newArticlePolicy()
+
+// SanitizeArticleHTML strips everything from article HTML except a small set
+// of formatting, list, table, and image tags. Scripts, iframes, inline event
+// handlers, styles, and non-http(s)/mailto URLs (e.g. javascript:) are removed.
+func SanitizeArticleHTML(input string) string {
+	return articlePolicy.Sanitize(input)
+}
+
+// SanitizeCommentText strips HTML tags from a user comment. Comments are
+// plain text with markdown-like formatting, escaped when they are rendered, so
+// the text itself is kept as typed: "a > b" and "&" are not turned into
+// entities. Script and style contents are dropped with their tags. Running it
+// again on its own output changes nothing.
+func SanitizeCommentText(input string) string {
+	// Stripping can join text into a new tag, as in "<<b>b>", so strip until
+	// nothing changes. Each pass that changes the text shortens it.
+	for {
+		out := stripTags(input)
+		if out == input {
+			return out
+		}
+		input = out
+	}
+}
+
+// stripTags keeps the raw, unescaped text between tags
+func stripTags(input string) string {
+	var sb strings.Builder
+	z := html.NewTokenizer(strings.NewReader(input))
+	skip := atom.Atom(0)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return sb.String()
+		case html.TextToken:
+			if skip == 0 {
+				sb.Write(z.Raw())
+			}
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			if a := atom.Lookup(name); a == atom.Script || a == atom.Style {
+				skip = a
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			if atom.Lookup(name) == skip {
+				skip = 0
+			}
+		}
+	}
+}
+
+func newArticlePolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+
+	p.AllowElements(
+		"h2", "h3", "h4", "h5", "h6",
+		"p", "ul", "ol", "li",
+		"strong", "em", "blockquote",
+		"figure", "figcaption",
+		"table", "thead", "tbody", "tr", "td", "th",
+	)
+
+	allowLinks(p)
+
+	p.AllowAttrs("src", "alt", "title", "width", "height").OnElements("img")
+	p.AllowAttrs("colspan", "rowspan").Matching(bluemonday.Integer).OnElements("td", "th")
+
+	return p
+}
+
+func allowLinks(p *bluemonday.Policy) {
+	p.AllowStandardURLs()
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.AllowAttrs("href", "title").OnElements("a")
+	p.RequireNoFollowOnFullyQualifiedLinks(true)
+}
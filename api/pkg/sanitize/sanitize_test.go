@@ -0,0 +1,68 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeArticleHTML(t *testing.T) {
+	t.Run("keeps allowed formatting", func(t *testing.T) {
+		input := `<h2>Budget</h2><p><strong>Senate</strong> passes <em>GAA</em></p><figure><img src="https://example.com/a.jpg" alt="a"><figcaption>Photo</figcaption></figure>`
+		assert.Equal(t, input, SanitizeArticleHTML(input))
+	})
+
+	t.Run("strips scripts and iframes", func(t *testing.T) {
+		out := SanitizeArticleHTML(`<p>Hi</p><script>alert(1)</script><iframe src="https://evil.example"></iframe>`)
+		assert.Equal(t, `<p>Hi</p>`, out)
+	})
+
+	t.Run("strips inline event handlers", func(t *testing.T) {
+		out := SanitizeArticleHTML(`<img src="https://example.com/a.jpg" onerror="alert(1)">`)
+		assert.NotContains(t, out, "onerror")
+		assert.Contains(t, out, `src="https://example.com/a.jpg"`)
+	})
+
+	t.Run("strips javascript hrefs", func(t *testing.T) {
+		out := SanitizeArticleHTML(`<a href="javascript:alert(1)">click</a>`)
+		assert.NotContains(t, out, "javascript")
+		assert.Contains(t, out, "click")
+	})
+}
+
+func TestSanitizeCommentText(t *testing.T) {
+	t.Run("strips tags and keeps their text", func(t *testing.T) {
+		out := SanitizeCommentText(`<p>Look</p><img src="https://example.com/a.jpg"> at <a href="https://example.com">this</a>`)
+		assert.Equal(t, `Look at this`, out)
+	})
+
+	t.Run("drops scripts with their contents", func(t *testing.T) {
+		out := SanitizeCommentText(`Hi<script>alert("x")</script><style>p{}</style> there`)
+		assert.Equal(t, `Hi there`, out)
+	})
+
+	t.Run("keeps formatting text as typed", func(t *testing.T) {
+		for _, input := range []string{
+			"> quoting the senator\n@juan agreed",
+			"Tom & Jerry's \"cartoon\" budget",
+			"2 < 3 and 5 > 4",
+			"**bold**, _italic_ and ~~struck~~",
+			"already &amp; escaped &lt;b&gt;",
+		} {
+			assert.Equal(t, input, SanitizeCommentText(input))
+		}
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		for _, input := range []string{
+			`<<b>b>script>alert(1)<</b>/script>`,
+			`> <em>quote</em> & "more"`,
+			`x<y`,
+			`<a href="#">link</a> &gt; text`,
+		} {
+			once := SanitizeCommentText(input)
+			assert.Equal(t, once, SanitizeCommentText(once), input)
+			assert.NotContains(t, once, "<b>")
+		}
+	})
+}
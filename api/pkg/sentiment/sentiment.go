@@ -0,0 +1,157 @@
+// Package sentiment scores comments from -1.0 (very negative) to +1.0 (very
+// positive) by looking their words up in a weighted word list. It knows
+// enough Filipino to read Taglish comments: negators such as "hindi" and
+// "wala", intensifiers such as "sobrang" and the "napaka-" prefix, and the
+// "-ng" linker ("magandang balita").
+package sentiment
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Word is one entry in the word list. Weight runs from -1.0 to +1.0.
+type Word struct {
+	Word   string
+	Weight float64
+}
+
+// A negator flips the next sentiment word within negationWindow words
+var negators = map[string]bool{
+	"not": true, "no": true, "never": true, "dont": true, "doesnt": true, "didnt": true,
+	"isnt": true, "arent": true, "wasnt": true, "cant": true, "cannot": true, "wont": true,
+	"hindi": true, "di": true, "wala": true, "walang": true, "huwag": true, "wag": true,
+}
+
+// An intensifier strengthens the next sentiment word
+var intensifiers = map[string]bool{
+	"very": true, "so": true, "really": true, "extremely": true, "super": true,
+	"sobra": true, "sobrang": true, "talaga": true, "masyado": true, "masyadong": true,
+}
+
+// Prefixes that intensify the word they are attached to: "napakaganda" is
+// "very beautiful"
+var intensifierPrefixes = []string{"napaka", "pinaka"}
+
+const (
+	negationWindow    = 3
+	intensifierFactor = 1.5
+)
+
+// SentimentAnalyzer scores text against a word list
+type SentimentAnalyzer struct {
+	words map[string]float64
+}
+
+// NewSentimentAnalyzer builds an analyzer from a word list. Words are matched
+// case-insensitively; weights are clamped to [-1, 1].
+func NewSentimentAnalyzer(words []Word) *SentimentAnalyzer {
+	a := &SentimentAnalyzer{words: make(map[string]float64, len(words))}
+	for _, w := range words {
+		if key := normalize(w.Word); key != "" {
+			a.words[key] = clamp(w.Weight)
+		}
+	}
+	return a
+}
+
+// Score returns the average weight of the sentiment words in the content,
+// from -1.0 to +1.0. Content with no sentiment words scores 0.
+func (a *SentimentAnalyzer) Score(content string) float64 {
+	var total float64
+	matched := 0
+	negateFor, intensify := 0, false
+
+	for _, token := range tokenize(content) {
+		if negators[token] {
+			negateFor = negationWindow
+			continue
+		}
+		if intensifiers[token] {
+			intensify = true
+			continue
+		}
+
+		weight, prefixed, ok := a.lookup(token)
+		if !ok {
+			if negateFor > 0 {
+				negateFor--
+			}
+			continue
+		}
+
+		if intensify || prefixed {
+			weight *= intensifierFactor
+		}
+		if negateFor > 0 {
+			weight = -weight
+		}
+		total += clamp(weight)
+		matched++
+		negateFor, intensify = 0, false
+	}
+
+	if matched == 0 {
+		return 0
+	}
+	return clamp(total / float64(matched))
+}
+
+// lookup finds a token's weight, trying it without an intensifying prefix and
+// without the "-ng" linker. prefixed reports whether a prefix was stripped.
+func (a *SentimentAnalyzer) lookup(token string) (weight float64, prefixed, ok bool) {
+	if weight, ok := a.lookupLinked(token); ok {
+		return weight, false, true
+	}
+	for _, prefix := range intensifierPrefixes {
+		rest, found := strings.CutPrefix(token, prefix)
+		if !found || rest == "" {
+			continue
+		}
+		// The prefix takes the root, whose adjective is "ma-" + root:
+		// "napakaganda" is a stronger "maganda"
+		for _, word := range []string{rest, "ma" + rest} {
+			if weight, ok := a.lookupLinked(word); ok {
+				return weight, true, true
+			}
+		}
+	}
+	return 0, false, false
+}
+
+func (a *SentimentAnalyzer) lookupLinked(token string) (float64, bool) {
+	if weight, ok := a.words[token]; ok {
+		return weight, true
+	}
+	// "magandang" is "maganda" joined to the next word
+	if base, found := strings.CutSuffix(token, "ng"); found && len(base) > 1 {
+		weight, ok := a.words[base]
+		return weight, ok
+	}
+	return 0, false
+}
+
+// tokenize lowercases the content and splits it into words. Apostrophes are
+// dropped so "don't" matches "dont". Hyphens split words, except after an
+// intensifying prefix: "napaka-ganda" is read as "napakaganda".
+func tokenize(content string) []string {
+	content = strings.NewReplacer("'", "", "’", "").Replace(strings.ToLower(content))
+	for _, prefix := range intensifierPrefixes {
+		content = strings.ReplaceAll(content, prefix+"-", prefix)
+	}
+	return strings.FieldsFunc(content, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func normalize(word string) string {
+	tokens := tokenize(word)
+	if len(tokens) != 1 {
+		return ""
+	}
+	return tokens[0]
+}
+
+func clamp(f float64) float64 {
+	return max(-1, min(1, f))
+}
@@ -0,0 +1,64 @@
+package sentiment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testAnalyzer() *SentimentAnalyzer {
+	return NewSentimentAnalyzer([]Word{
+		{Word: "good", Weight: 0.6},
+		{Word: "terrible", Weight: -0.9},
+		{Word: "Maganda", Weight: 0.6},
+		{Word: "pangit", Weight: -0.6},
+		{Word: "corrupt", Weight: -0.8},
+		{Word: "salamat", Weight: 0.4},
+		{Word: "overrated", Weight: -3}, // Clamped
+	})
+}
+
+func TestScore(t *testing.T) {
+	a := testAnalyzer()
+
+	for content, want := range map[string]float64{
+		"":                               0,
+		"The hearing is on Monday.":      0,
+		"Good job!":                      0.6,
+		"This bill is terrible":          -0.9,
+		"Maganda ang plano, salamat po.": 0.5,
+		"good but corrupt":               -0.1,
+		"Overrated.":                     -1,
+	} {
+		assert.InDelta(t, want, a.Score(content), 1e-9, content)
+	}
+}
+
+func TestScoreFilipino(t *testing.T) {
+	a := testAnalyzer()
+
+	// The "-ng" linker
+	assert.InDelta(t, 0.6, a.Score("Magandang balita ito"), 1e-9)
+
+	// Negation, in English and Filipino, within a few words
+	assert.InDelta(t, -0.6, a.Score("not good"), 1e-9)
+	assert.InDelta(t, -0.6, a.Score("Hindi maganda ang nangyari"), 1e-9)
+	assert.InDelta(t, 0.6, a.Score("Hindi naman siya pangit"), 1e-9)
+	assert.InDelta(t, -0.6, a.Score("Don't think it's good"), 1e-9)
+	assert.InDelta(t, 0.6, a.Score("hindi ko alam kung bakit pero good"), 1e-9, "too far to negate")
+
+	// Intensifiers, as words or as a prefix
+	assert.InDelta(t, 0.9, a.Score("Napakaganda!"), 1e-9)
+	assert.InDelta(t, 0.9, a.Score("napaka-ganda"), 1e-9)
+	assert.InDelta(t, -0.9, a.Score("sobrang pangit"), 1e-9)
+	assert.InDelta(t, -1, a.Score("very terrible"), 1e-9, "clamped")
+}
+
+func TestScoreBounds(t *testing.T) {
+	a := testAnalyzer()
+	for _, content := range []string{"terrible terrible terrible", "very very good good", "walang corrupt"} {
+		score := a.Score(content)
+		assert.GreaterOrEqual(t, score, -1.0, content)
+		assert.LessOrEqual(t, score, 1.0, content)
+	}
+}
@@ -0,0 +1,257 @@
+// Package seo scores articles against basic search engine optimisation
+// heuristics. The score is advisory: it is worked out when an editor asks for
+// it and never blocks saving or publishing.
+package seo
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Check names, in the order they appear in a report
+const (
+	CheckTitleLength           = "title_length"
+	CheckMetaDescriptionLength = "meta_description_length"
+	CheckWordCount             = "word_count"
+	CheckHasH2Heading          = "has_h2_heading"
+	CheckSlugMatchesTitle      = "slug_matches_title"
+	CheckHasInternalLink       = "has_internal_link"
+	CheckImageAltText          = "image_alt_text"
+)
+
+const (
+	minTitleLength           = 50
+	maxTitleLength           = 70
+	minMetaDescriptionLength = 120
+	maxMetaDescriptionLength = 160
+	minWordCount             = 300
+)
+
+// checkWeights is how many of the 100 points each check is worth
+var checkWeights = map[string]int{
+	CheckTitleLength:           15,
+	CheckMetaDescriptionLength: 15,
+	CheckWordCount:             20,
+	CheckHasH2Heading:          10,
+	CheckSlugMatchesTitle:      10,
+	CheckHasInternalLink:       15,
+	CheckImageAltText:          15,
+}
+
+// SEOReport is an article's score out of 100 and the checks behind it
+type SEOReport struct {
+	Score  int     `json:"score"`
+	Checks []Check `json:"checks"`
+}
+
+// Check is one heuristic. Value is the measured length or count, for checks
+// that measure one.
+type Check struct {
+	Name        string `json:"name"`
+	Passed      bool   `json:"passed"`
+	Value       *int   `json:"value,omitempty"`
+	Recommended string `json:"recommended,omitempty"`
+}
+
+// Analyzer scores articles. Links to the site's own host count as internal.
+type Analyzer struct {
+	siteHost string
+}
+
+func NewAnalyzer(siteURL string) *Analyzer {
+	a := &Analyzer{}
+	if u, err := url.Parse(siteURL); err == nil {
+		a.siteHost = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	}
+	return a
+}
+
+// Analyze runs every check on the article
+func (a *Analyzer) Analyze(article *models.Article) SEOReport {
+	body := parseContent(article.Content)
+
+	titleLength := utf8.RuneCountInString(strings.TrimSpace(article.Title))
+	descriptionLength := 0
+	if article.Summary != nil {
+		descriptionLength = utf8.RuneCountInString(strings.TrimSpace(*article.Summary))
+	}
+	words := len(strings.Fields(body.text.String()))
+
+	checks := []Check{
+		{
+			Name:        CheckTitleLength,
+			Passed:      titleLength >= minTitleLength && titleLength <= maxTitleLength,
+			Value:       &titleLength,
+			Recommended: "50-70",
+		},
+		{
+			Name:        CheckMetaDescriptionLength,
+			Passed:      descriptionLength >= minMetaDescriptionLength && descriptionLength <= maxMetaDescriptionLength,
+			Value:       &descriptionLength,
+			Recommended: "120-160",
+		},
+		{
+			Name:        CheckWordCount,
+			Passed:      words > minWordCount,
+			Value:       &words,
+			Recommended: "more than 300",
+		},
+		{
+			Name:   CheckHasH2Heading,
+			Passed: body.h2Count > 0,
+			Value:  &body.h2Count,
+		},
+		{
+			Name:        CheckSlugMatchesTitle,
+			Passed:      slugMatchesTitle(article.Slug, article.Title),
+			Recommended: "slug made of words from the title",
+		},
+		{
+			Name:   CheckHasInternalLink,
+			Passed: a.countInternalLinks(body.links) > 0,
+		},
+		{
+			Name:        CheckImageAltText,
+			Passed:      body.imagesWithoutAlt == 0,
+			Value:       &body.imagesWithoutAlt,
+			Recommended: "alt text on every image",
+		},
+	}
+
+	report := SEOReport{Checks: checks}
+	for _, c := range checks {
+		if c.Passed {
+			report.Score += checkWeights[c.Name]
+		}
+	}
+	return report
+}
+
+// countInternalLinks counts links to other pages of the site: relative paths
+// and absolute URLs on the site's host. In-page anchors do not count.
+func (a *Analyzer) countInternalLinks(hrefs []string) int {
+	count := 0
+	for _, href := range hrefs {
+		u, err := url.Parse(strings.TrimSpace(href))
+		if err != nil {
+			continue
+		}
+		switch {
+		case u.Scheme == "" && u.Host == "":
+			if u.Path != "" {
+				count++
+			}
+		case u.Scheme == "http" || u.Scheme == "https":
+			if a.siteHost != "" && strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.") == a.siteHost {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// slugMatchesTitle reports whether every word of the slug comes from the
+// title. A trailing number, added to tell apart articles with the same
+// title, is ignored.
+func slugMatchesTitle(slug, title string) bool {
+	parts := strings.Split(strings.ToLower(slug), "-")
+	if n := len(parts); n > 1 && isNumber(parts[n-1]) {
+		parts = parts[:n-1]
+	}
+
+	titleWords := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		titleWords[w] = true
+	}
+
+	matched := 0
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		if !titleWords[p] {
+			return false
+		}
+		matched++
+	}
+	return matched > 0
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// content is what the checks need from an article's HTML
+type content struct {
+	text             strings.Builder
+	h2Count          int
+	links            []string
+	imagesWithoutAlt int
+}
+
+func parseContent(src string) *content {
+	c := &content{}
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return c
+	}
+	for _, n := range nodes {
+		c.walk(n)
+	}
+	return c
+}
+
+func (c *content) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		c.text.WriteString(n.Data)
+		c.text.WriteByte(' ')
+	case html.ElementNode:
+		switch n.DataAtom {
+		case atom.H2:
+			c.h2Count++
+		case atom.A:
+			if href, ok := attr(n, "href"); ok {
+				c.links = append(c.links, href)
+			}
+		case atom.Img:
+			if alt, _ := attr(n, "alt"); strings.TrimSpace(alt) == "" {
+				c.imagesWithoutAlt++
+			}
+		case atom.Script, atom.Style:
+			return
+		}
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.walk(child)
+	}
+}
+
+func attr(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
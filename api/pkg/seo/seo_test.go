@@ -0,0 +1,95 @@
+package seo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/humfurie/pulpulitiko/api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checksByName(report SEOReport) map[string]Check {
+	checks := make(map[string]Check, len(report.Checks))
+	for _, c := range report.Checks {
+		checks[c.Name] = c
+	}
+	return checks
+}
+
+func TestAnalyzeWellOptimisedArticle(t *testing.T) {
+	title := "Senate passes the 2026 national budget after marathon session"
+	summary := strings.Repeat("The Senate approved the spending plan late on Tuesday night. ", 2)
+	article := &models.Article{
+		Title:   title,
+		Slug:    "senate-passes-2026-national-budget-2",
+		Summary: &summary,
+		Content: `<p>` + strings.Repeat("word ", 310) + `</p>
+			<h2>What changed</h2>
+			<p>See <a href="/politicians/juan-dela-cruz">his profile</a> and
+			<a href="https://example.org/report">the report</a>.</p>
+			<img src="/chart.png" alt="Budget by department">`,
+	}
+
+	report := NewAnalyzer("https://pulpulitiko.com").Analyze(article)
+
+	for _, c := range report.Checks {
+		assert.True(t, c.Passed, c.Name)
+	}
+	assert.Equal(t, 100, report.Score)
+	assert.Len(t, report.Checks, len(checkWeights))
+	assert.Equal(t, len([]rune(title)), *checksByName(report)[CheckTitleLength].Value)
+}
+
+func TestAnalyzeThinArticle(t *testing.T) {
+	article := &models.Article{
+		Title:   "Budget",
+		Slug:    "untitled-draft",
+		Content: `<p>Short.</p><a href="#comments">Jump</a><img src="/a.png"><img src="/b.png" alt=" ">`,
+	}
+
+	report := NewAnalyzer("https://pulpulitiko.com").Analyze(article)
+	checks := checksByName(report)
+
+	assert.Equal(t, 0, report.Score)
+	assert.Equal(t, 6, *checks[CheckTitleLength].Value)
+	assert.Equal(t, "50-70", checks[CheckTitleLength].Recommended)
+	assert.Equal(t, 0, *checks[CheckMetaDescriptionLength].Value)
+	assert.Equal(t, 2, *checks[CheckWordCount].Value)
+	assert.False(t, checks[CheckHasH2Heading].Passed)
+	assert.False(t, checks[CheckSlugMatchesTitle].Passed)
+	assert.False(t, checks[CheckHasInternalLink].Passed)
+	assert.Equal(t, 2, *checks[CheckImageAltText].Value)
+}
+
+func TestInternalLinks(t *testing.T) {
+	a := NewAnalyzer("https://www.pulpulitiko.com")
+
+	assert.Equal(t, 3, a.countInternalLinks([]string{
+		"/articles/budget",
+		"https://pulpulitiko.com/politicians/x",
+		"http://www.pulpulitiko.com/",
+		"https://rappler.com/story",
+		"#section",
+		"mailto:editor@pulpulitiko.com",
+	}))
+
+	// Without a site URL only relative links count
+	assert.Equal(t, 1, NewAnalyzer("").countInternalLinks([]string{"/a", "https://pulpulitiko.com/b"}))
+}
+
+func TestSlugMatchesTitle(t *testing.T) {
+	assert.True(t, slugMatchesTitle("senate-passes-budget", "Senate passes budget!"))
+	assert.True(t, slugMatchesTitle("senate-passes-budget-3", "Senate passes budget"))
+	assert.True(t, slugMatchesTitle("2026-budget", "The 2026 budget"))
+	assert.False(t, slugMatchesTitle("senate-budget-news", "Senate passes budget"))
+	assert.False(t, slugMatchesTitle("", "Senate passes budget"))
+}
+
+func TestScoreSumsWeights(t *testing.T) {
+	total := 0
+	for _, w := range checkWeights {
+		total += w
+	}
+	require.Equal(t, 100, total)
+}
@@ -86,6 +86,12 @@ func (s *MinioStorage) Upload(ctx context.Context, reader io.Reader, fileName st
 	ext := filepath.Ext(fileName)
 	key := fmt.Sprintf("%s/%s%s", time.Now().Format("2006/01"), uuid.New().String(), ext)
 
+	return s.UploadWithKey(ctx, reader, key, contentType, size)
+}
+
+// UploadWithKey stores an object under a caller-chosen key, for uploads that
+// group related objects under a shared prefix
+func (s *MinioStorage) UploadWithKey(ctx context.Context, reader io.Reader, key string, contentType string, size int64) (*UploadResult, error) {
 	opts := minio.PutObjectOptions{
 		ContentType: contentType,
 	}
@@ -95,14 +101,9 @@ func (s *MinioStorage) Upload(ctx context.Context, reader io.Reader, fileName st
 		return nil, fmt.Errorf("failed to upload file: %w", err)
 	}
 
-	protocol := "http"
-	if s.useSSL {
-		protocol = "https"
-	}
-
 	return &UploadResult{
 		Key:      key,
-		URL:      fmt.Sprintf("%s://%s/%s/%s", protocol, s.publicEndpoint, s.bucketName, key),
+		URL:      s.GetURL(key),
 		Size:     info.Size,
 		MimeType: contentType,
 	}, nil
@@ -0,0 +1,152 @@
+// Package textutil measures plain text, such as how often an article repeats
+// its words.
+package textutil
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// TopKeywordsLimit is how many of the most frequent words are reported
+	TopKeywordsLimit = 20
+
+	// Recommended share of the text taken up by the focus keyword, in percent
+	MinFocusKeywordDensity = 1.0
+	MaxFocusKeywordDensity = 3.0
+)
+
+// KeywordDensity is how often the words of a text are used. Densities are
+// percentages of the total word count, rounded to two decimals.
+type KeywordDensity struct {
+	TotalWords int                `json:"total_words"`
+	Keywords   []KeywordFrequency `json:"keywords"`
+
+	// Set only when a focus keyword is given
+	FocusKeyword        string   `json:"focus_keyword,omitempty"`
+	FocusKeywordCount   *int     `json:"focus_keyword_count,omitempty"`
+	FocusKeywordDensity *float64 `json:"focus_keyword_density,omitempty"`
+	RecommendedDensity  string   `json:"recommended_density,omitempty"`
+	Warning             string   `json:"warning,omitempty"` // Set when the focus keyword density is out of range
+}
+
+// KeywordFrequency is one word and how often it is used
+type KeywordFrequency struct {
+	Word    string  `json:"word"`
+	Count   int     `json:"count"`
+	Density float64 `json:"density"`
+}
+
+// AnalyzeKeywordDensity counts the words of content, ignoring case, and
+// returns the most frequent ones that carry meaning: stop words, numbers and
+// single letters are left out of the list but still count towards the total.
+//
+// A focus keyword may be a phrase; each occurrence of the whole phrase counts
+// once. Its density is checked against the recommended 1-3%.
+func AnalyzeKeywordDensity(content, focusKeyword string) KeywordDensity {
+	words := splitWords(content)
+	report := KeywordDensity{TotalWords: len(words), Keywords: []KeywordFrequency{}}
+
+	counts := make(map[string]int)
+	for _, w := range words {
+		if isMeaningful(w) {
+			counts[w]++
+		}
+	}
+	for w, n := range counts {
+		report.Keywords = append(report.Keywords, KeywordFrequency{Word: w, Count: n, Density: density(n, len(words))})
+	}
+	sort.Slice(report.Keywords, func(i, j int) bool {
+		a, b := report.Keywords[i], report.Keywords[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Word < b.Word
+	})
+	if len(report.Keywords) > TopKeywordsLimit {
+		report.Keywords = report.Keywords[:TopKeywordsLimit]
+	}
+
+	phrase := splitWords(focusKeyword)
+	if len(phrase) == 0 {
+		return report
+	}
+
+	count := countPhrase(words, phrase)
+	d := density(count, len(words))
+	report.FocusKeyword = strings.Join(phrase, " ")
+	report.FocusKeywordCount = &count
+	report.FocusKeywordDensity = &d
+	report.RecommendedDensity = fmt.Sprintf("%g-%g%%", MinFocusKeywordDensity, MaxFocusKeywordDensity)
+	switch {
+	case d < MinFocusKeywordDensity:
+		report.Warning = fmt.Sprintf("focus keyword density %.2f%% is below the recommended %s", d, report.RecommendedDensity)
+	case d > MaxFocusKeywordDensity:
+		report.Warning = fmt.Sprintf("focus keyword density %.2f%% is above the recommended %s", d, report.RecommendedDensity)
+	}
+	return report
+}
+
+// splitWords lowercases text and splits it into words. Apostrophes inside a
+// word, as in "don't", are kept, but a possessive or contracted "'s" is
+// dropped so "Senate's" counts as "senate".
+func splitWords(text string) []string {
+	text = strings.ReplaceAll(strings.ToLower(text), "’", "'")
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\'' && r != '-'
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if w := strings.TrimSuffix(strings.Trim(f, "'-"), "'s"); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+func isMeaningful(word string) bool {
+	if utf8.RuneCountInString(word) < 2 || stopWords[word] {
+		return false
+	}
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// countPhrase counts the non-overlapping occurrences of phrase in words
+func countPhrase(words, phrase []string) int {
+	count := 0
+	for i := 0; i+len(phrase) <= len(words); {
+		if equalWords(words[i:i+len(phrase)], phrase) {
+			count++
+			i += len(phrase)
+			continue
+		}
+		i++
+	}
+	return count
+}
+
+func equalWords(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func density(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(count)/float64(total)*10000) / 100
+}
@@ -0,0 +1,105 @@
+package textutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeKeywordDensity(t *testing.T) {
+	content := "The Senate passed the budget. Ang budget ay para sa 2025, and the Senate’s vote was 20-3."
+
+	report := AnalyzeKeywordDensity(content, "")
+
+	assert.Equal(t, 17, report.TotalWords)
+	assert.Equal(t, []KeywordFrequency{
+		{Word: "budget", Count: 2, Density: 11.76},
+		{Word: "senate", Count: 2, Density: 11.76},
+		{Word: "passed", Count: 1, Density: 5.88},
+		{Word: "vote", Count: 1, Density: 5.88},
+	}, report.Keywords)
+	assert.Empty(t, report.FocusKeyword)
+	assert.Nil(t, report.FocusKeywordDensity)
+	assert.Empty(t, report.Warning)
+}
+
+func TestAnalyzeKeywordDensityTopWords(t *testing.T) {
+	var words []string
+	for i := 0; i < 30; i++ {
+		for n := 0; n <= i; n++ {
+			words = append(words, fmt.Sprintf("q%c%c", 'a'+i/26, 'a'+i%26))
+		}
+	}
+
+	report := AnalyzeKeywordDensity(strings.Join(words, " "), "")
+
+	require.Len(t, report.Keywords, TopKeywordsLimit)
+	assert.Equal(t, "qbd", report.Keywords[0].Word)
+	assert.Equal(t, 30, report.Keywords[0].Count)
+	assert.Equal(t, 11, report.Keywords[TopKeywordsLimit-1].Count)
+}
+
+func TestAnalyzeKeywordDensityFocusKeyword(t *testing.T) {
+	filler := strings.Repeat("lawmakers debated the measure today ", 20)
+
+	tests := []struct {
+		name    string
+		content string
+		keyword string
+		count   int
+		density float64
+		warning string
+	}{
+		{
+			name:    "in range",
+			content: filler + "Rice Tariff. rice tariff, rice tariff",
+			keyword: "Rice  tariff",
+			count:   3,
+			density: 2.83,
+		},
+		{
+			name:    "too sparse",
+			content: filler + "rice tariff",
+			keyword: "rice tariff",
+			count:   1,
+			density: 0.98,
+			warning: "focus keyword density 0.98% is below the recommended 1-3%",
+		},
+		{
+			name:    "stuffed",
+			content: filler + strings.Repeat("tariff ", 10),
+			keyword: "tariff",
+			count:   10,
+			density: 9.09,
+			warning: "focus keyword density 9.09% is above the recommended 1-3%",
+		},
+		{
+			name:    "empty content",
+			content: "",
+			keyword: "tariff",
+			density: 0,
+			warning: "focus keyword density 0.00% is below the recommended 1-3%",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := AnalyzeKeywordDensity(tt.content, tt.keyword)
+
+			require.NotNil(t, report.FocusKeywordCount)
+			require.NotNil(t, report.FocusKeywordDensity)
+			assert.Equal(t, tt.count, *report.FocusKeywordCount)
+			assert.Equal(t, tt.density, *report.FocusKeywordDensity)
+			assert.Equal(t, "1-3%", report.RecommendedDensity)
+			assert.Equal(t, tt.warning, report.Warning)
+		})
+	}
+}
+
+func TestAnalyzeKeywordDensityNormalizesFocusKeyword(t *testing.T) {
+	report := AnalyzeKeywordDensity("rice tariff", "  Rice   TARIFF ")
+	assert.Equal(t, "rice tariff", report.FocusKeyword)
+}
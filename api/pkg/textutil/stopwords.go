@@ -0,0 +1,40 @@
+package textutil
+
+// stopWords are English and Filipino words too common to say what a text is
+// about. Articles often mix the two languages.
+var stopWords = toSet(
+	// English
+	"a", "about", "above", "after", "again", "against", "all", "also", "am", "an", "and", "any", "are",
+	"aren't", "as", "at", "be", "because", "been", "before", "being", "below", "between", "both", "but",
+	"by", "can", "can't", "cannot", "could", "couldn't", "did", "didn't", "do", "does", "doesn't",
+	"doing", "don't", "down", "during", "each", "few", "for", "from", "further", "had", "hadn't", "has",
+	"hasn't", "have", "haven't", "having", "he", "he'd", "he'll", "her", "here",
+	"hers", "herself", "him", "himself", "his", "how", "i", "i'd", "i'll", "i'm", "i've",
+	"if", "in", "into", "is", "isn't", "it", "its", "itself", "just", "let", "like", "may",
+	"me", "might", "more", "most", "much", "must", "mustn't", "my", "myself", "no", "nor", "not", "now",
+	"of", "off", "on", "once", "only", "or", "other", "ought", "our", "ours", "ourselves", "out", "over",
+	"own", "said", "same", "says", "shall", "shan't", "she", "she'd", "she'll", "should",
+	"shouldn't", "so", "some", "such", "than", "that", "the", "their", "theirs", "them",
+	"themselves", "then", "there", "these", "they", "they'd", "they'll", "they're",
+	"they've", "this", "those", "through", "to", "too", "under", "until", "up", "upon", "us", "very",
+	"was", "wasn't", "we", "we'd", "we'll", "we're", "we've", "were", "weren't", "what",
+	"when", "where", "which", "while", "who", "whom", "why",
+	"will", "with", "won't", "would", "wouldn't", "yet", "you", "you'd", "you'll", "you're", "you've",
+	"your", "yours", "yourself", "yourselves",
+
+	// Filipino
+	"ako", "akin", "aking", "amin", "aming", "ang", "ano", "anong", "at", "ay", "ba", "bakit", "dahil",
+	"daw", "din", "dito", "doon", "eh", "ha", "hindi", "ibang", "iba", "ikaw", "ito", "iyan", "iyon",
+	"iyong", "ka", "kami", "kanila", "kanilang", "kanya", "kanyang", "kapag", "kasi", "kay", "kayo",
+	"kina", "ko", "kung", "lamang", "lang", "mga", "mo", "muna", "na", "nag", "naman", "namin", "nang",
+	"nasa", "natin", "ng", "ni", "nila", "nina", "nito", "niya", "niyang", "noon", "o", "pa", "pag",
+	"para", "pero", "po", "rin", "sa", "sila", "siya", "subalit", "tayo", "upang", "yung",
+)
+
+func toSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
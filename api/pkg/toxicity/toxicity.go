@@ -0,0 +1,156 @@
+// Package toxicity scores how likely a comment is to be abusive, from 0
+// (benign) to 1 (almost certainly abusive). WordlistScorer runs locally;
+// WebhookScorer asks an external model.
+package toxicity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+	"unicode"
+
+	"github.com/humfurie/pulpulitiko/api/pkg/webhook"
+)
+
+// Provider names recorded with each score
+const (
+	ProviderWordlist = "wordlist"
+	ProviderWebhook  = "webhook"
+)
+
+type term struct {
+	pattern *regexp.Regexp
+	weight  float64
+}
+
+func terms(weight float64, patterns ...string) []term {
+	t := make([]term, len(patterns))
+	for i, p := range patterns {
+		t[i] = term{pattern: regexp.MustCompile(`(?i)\b(?:` + p + `)\b`), weight: weight}
+	}
+	return t
+}
+
+// defaultTerms covers common English and Filipino insults, profanity and
+// threats. Weights are the chance a single use makes a comment abusive.
+var defaultTerms = slices.Concat(
+	terms(0.3, `shit`, `crap`, `damn`, `leche`, `bwisit`, `buwisit`),
+	terms(0.5, `idiots?`, `stupid`, `morons?`, `dumb`, `losers?`, `scum`, `trash`, `bastards?`,
+		`bobo`, `tanga`, `gago`, `ulol`, `inutil`, `tarantado`, `hayop ka`, `punyeta`),
+	terms(0.6, `fuck\w*`, `bitch\w*`, `assholes?`, `dickhead`, `pakyu`,
+		`(?:putang|tang)\s*ina\w*`, `puta`),
+	terms(0.7, `whores?`, `sluts?`, `mamatay ka`),
+	terms(0.9, `(?:i|we)(?:'ll|’ll| will)\s+(?:kill|hurt|find)\s+(?:you|u)`, `papatayin\s+(?:kita|ka)`, `kys`),
+	terms(0.95, `kill\s+yourself`),
+)
+
+const (
+	// Each term counts at most this many times
+	maxTermHits = 3
+	// Shouting: mostly capital letters over at least shoutMinLetters letters
+	shoutMinLetters = 20
+	shoutRatio      = 0.8
+	shoutWeight     = 0.15
+)
+
+// WordlistScorer matches comments against a built-in list of insults,
+// profanity and threats. Each match raises the score, as if each were an
+// independent chance of the comment being abusive.
+type WordlistScorer struct {
+	terms []term
+}
+
+func NewWordlistScorer() *WordlistScorer {
+	return &WordlistScorer{terms: defaultTerms}
+}
+
+func (s *WordlistScorer) Name() string {
+	return ProviderWordlist
+}
+
+func (s *WordlistScorer) Score(_ context.Context, content string) (float64, error) {
+	benign := 1.0
+	for _, t := range s.terms {
+		hits := len(t.pattern.FindAllStringIndex(content, maxTermHits))
+		benign *= math.Pow(1-t.weight, float64(hits))
+	}
+	if isShouting(content) {
+		benign *= 1 - shoutWeight
+	}
+	return 1 - benign, nil
+}
+
+func isShouting(content string) bool {
+	letters, upper := 0, 0
+	for _, r := range content {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= shoutMinLetters && float64(upper) >= shoutRatio*float64(letters)
+}
+
+// WebhookScorer POSTs {"content": "..."} to an external model and reads
+// {"score": 0.87} back. Requests are signed like outgoing webhooks, with the
+// X-Pulpulitiko-Signature header, when a secret is set.
+type WebhookScorer struct {
+	url    string
+	secret string
+	http   *http.Client
+}
+
+func NewWebhookScorer(url, secret string, timeout time.Duration) *WebhookScorer {
+	return &WebhookScorer{url: url, secret: secret, http: &http.Client{Timeout: timeout}}
+}
+
+func (s *WebhookScorer) Name() string {
+	return ProviderWebhook
+}
+
+func (s *WebhookScorer) Score(ctx context.Context, content string) (float64, error) {
+	body, err := json.Marshal(map[string]string{"content": content})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode toxicity request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create toxicity request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.secret != "" {
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(s.secret, body))
+	}
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach toxicity scorer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		return 0, fmt.Errorf("toxicity scorer returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Score *float64 `json:"score"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode toxicity score: %w", err)
+	}
+	if result.Score == nil || *result.Score < 0 || *result.Score > 1 {
+		return 0, fmt.Errorf("toxicity scorer returned no score from 0 to 1")
+	}
+	return *result.Score, nil
+}
@@ -0,0 +1,86 @@
+package toxicity
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/humfurie/pulpulitiko/api/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordlistScorer(t *testing.T) {
+	s := NewWordlistScorer()
+	score := func(content string) float64 {
+		v, err := s.Score(context.Background(), content)
+		require.NoError(t, err)
+		return v
+	}
+
+	assert.Zero(t, score("The budget hearing runs until Friday."))
+	// Words containing a listed term don't match it
+	assert.Zero(t, score("The class will assess the tangible results."))
+
+	insult := score("You are an idiot")
+	assert.InDelta(t, 0.5, insult, 1e-9)
+	assert.Greater(t, score("You are an idiot and a moron"), insult, "more insults score higher")
+	assert.Greater(t, score("Tang ina mo, bobo"), insult)
+	assert.GreaterOrEqual(t, score("I'll kill you"), 0.9)
+	assert.GreaterOrEqual(t, score("Papatayin kita"), 0.9)
+
+	// Shouting alone is a weak signal
+	shout := score("THIS IS THE WORST BUDGET EVER PASSED")
+	assert.Greater(t, shout, 0.0)
+	assert.Less(t, shout, 0.3)
+}
+
+func TestWebhookScorer(t *testing.T) {
+	var status int
+	var reply string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !webhook.Verify("secret", body, r.Header.Get(webhook.SignatureHeader)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			Content string `json:"content"`
+		}
+		_ = json.Unmarshal(body, &req)
+		if req.Content != "hello" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, reply)
+	}))
+	defer server.Close()
+
+	s := NewWebhookScorer(server.URL, "secret", time.Second)
+	assert.Equal(t, ProviderWebhook, s.Name())
+
+	status, reply = http.StatusOK, `{"score": 0.87}`
+	score, err := s.Score(context.Background(), "hello")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.87, score, 1e-9)
+
+	status, reply = http.StatusOK, `{"score": 1.5}`
+	_, err = s.Score(context.Background(), "hello")
+	assert.Error(t, err, "score out of range")
+
+	status, reply = http.StatusOK, `{}`
+	_, err = s.Score(context.Background(), "hello")
+	assert.Error(t, err, "missing score")
+
+	status, reply = http.StatusServiceUnavailable, ""
+	_, err = s.Score(context.Background(), "hello")
+	assert.Error(t, err)
+
+	_, err = NewWebhookScorer(server.URL, "wrong", time.Second).Score(context.Background(), "hello")
+	assert.Error(t, err, "bad signature")
+}
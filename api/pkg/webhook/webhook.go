@@ -0,0 +1,74 @@
+// Package webhook signs and sends webhook payloads to integrators.
+//
+// Each request carries the event type and ID in headers, and an HMAC-SHA256
+// signature of the raw body made with the webhook's secret, so receivers can
+// check the payload came from us and drop events they have already handled.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Pulpulitiko-Signature"
+	EventHeader     = "X-Pulpulitiko-Event"
+	EventIDHeader   = "X-Pulpulitiko-Event-ID"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature header value for body: "sha256=" followed by
+// the hex HMAC-SHA256 of body keyed with secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is a valid signature of body for secret
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+type Client struct {
+	http *http.Client
+}
+
+func NewClient(timeout time.Duration) *Client {
+	return &Client{http: &http.Client{Timeout: timeout}}
+}
+
+// Send POSTs a signed JSON payload to url. It returns the response status
+// code, if there was a response, and an error unless the status was 2xx.
+func (c *Client) Send(ctx context.Context, url, secret, eventID, eventType string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Pulpulitiko-Webhooks/1.0")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(EventIDHeader, eventID)
+	req.Header.Set(SignatureHeader, Sign(secret, body))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drain a little so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":"1","type":"article.published"}`)
+
+	signature := Sign("s3cret", body)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.True(t, Verify("s3cret", body, signature))
+	assert.False(t, Verify("other", body, signature))
+	assert.False(t, Verify("s3cret", []byte(`{"id":"2"}`), signature))
+}
+
+func TestClientSend(t *testing.T) {
+	body := []byte(`{"id":"evt-1"}`)
+
+	var got *http.Request
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	status, err := NewClient(time.Second).Send(context.Background(), server.URL, "s3cret", "evt-1", "bill.status_changed", body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	assert.Equal(t, "bill.status_changed", got.Header.Get(EventHeader))
+	assert.Equal(t, "evt-1", got.Header.Get(EventIDHeader))
+	assert.True(t, Verify("s3cret", gotBody, got.Header.Get(SignatureHeader)))
+}
+
+func TestClientSendFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	status, err := NewClient(time.Second).Send(context.Background(), server.URL, "s3cret", "evt-1", "article.published", []byte(`{}`))
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadGateway, status)
+}
@@ -1,51 +1,81 @@
 import type {
+  ActivePoliticianFilter,
   ApiResponse,
   Article,
+  ArticleImages,
   ArticleListItem,
+  ArticleMedia,
+  ArticleMeta,
+  ArticleSort,
   Author,
   AuthorWithArticles,
+  BallotPositionOrder,
   Barangay,
   Bill,
   BillFilter,
+  BillSuggestion,
   BillTopic,
   BillVote,
   Candidate,
+  CandidateComparison,
   CandidateListItem,
   Category,
   CategoryWithArticles,
   CityMunicipalityListItem,
   CityWithBarangays,
   Comment,
+  AdminCommentFilter,
+  ModerateCommentRequest,
   CommentAuthor,
   CommentCountResponse,
+  CommentSort,
   Committee,
   CommitteeListItem,
   CongressionalDistrict,
+  CreateArticleMediaRequest,
   CreateCommentRequest,
   CreatePollCommentRequest,
+  CreateElectionSurveyRequest,
+  CreateExplainerRequest,
   CreatePollRequest,
+  DeliberationSearchResult,
+  DeviceToken,
   DistrictListItem,
   Election,
   ElectionCalendarItem,
+  ElectionCityResults,
+  ElectionCoalitionResults,
   ElectionFilter,
   ElectionListItem,
   ElectionPositionListItem,
+  ElectionResultsMap,
+  ElectionResultsSummary,
+  ElectionSurvey,
+  Explainer,
+  FeaturedImageBackfill,
+  FloorDeliberation,
   GovernmentPosition,
   GovernmentPositionListItem,
   LegislativeChamber,
   LegislativeSession,
   LegislativeSessionListItem,
   LocationHierarchy,
+  LocationResultsImport,
+  DetectedLocation,
+  LocationTreeRegion,
   LocationSearchResult,
   PaginatedArticles,
   PaginatedBarangays,
+  BarangaySearchParams,
   PaginatedBills,
   PaginatedCandidates,
   PaginatedElections,
   PaginatedNotifications,
   PaginatedPoliticalParties,
+  PaginatedComments,
   PaginatedPoliticianComments,
   PaginatedPoliticianVotes,
+  PaginatedPoliticians,
   PaginatedPollComments,
   PaginatedPolls,
   PaginatedVoterEducation,
@@ -63,13 +93,22 @@ import type {
   PollFilter,
   PollListItem,
   PollResults,
+  PollTimelineBucket,
+  PollVoteBucket,
+  PollRegionResults,
   PollStatus,
   ProvinceListItem,
   ProvinceWithCities,
   RegionListItem,
   RegionWithProvinces,
+  RegisterDeviceTokenRequest,
   Tag,
+  SubstituteCandidateRequest,
+  SurveyTrend,
   TagWithArticles,
+  UpdateArticleMediaRequest,
+  UpdateElectionSurveyRequest,
+  UpdateExplainerRequest,
   UpdatePollRequest,
   UploadResult,
   UserProfile,
@@ -98,6 +137,8 @@ export function useApi() {
   async function fetchApi<T>(endpoint: string, options?: FetchOptions): Promise<T> {
     const headers: Record<string, string> = {
       'Content-Type': 'application/json',
+      // List responses are still consumed in their per-endpoint shapes
+      'X-Legacy-Pagination': 'true',
       ...options?.headers
     }
 
@@ -114,14 +155,52 @@ export function useApi() {
     return response.data
   }
 
+  // Posts a file as multipart form data to an admin upload endpoint
+  async function postUpload<T>(path: string, file: File, authHeaders: HeadersInit): Promise<T> {
+    const formData = new FormData()
+    formData.append('file', file)
+
+    // Extract only the Authorization header - don't set Content-Type for FormData
+    const headers: Record<string, string> = {}
+    if (authHeaders && typeof authHeaders === 'object') {
+      const authRecord = authHeaders as Record<string, string>
+      if (authRecord.Authorization) {
+        headers.Authorization = authRecord.Authorization
+      }
+    }
+
+    const response = await $fetch<ApiResponse<T>>(`${baseUrl}${path}`, {
+      method: 'POST',
+      headers,
+      body: formData
+    })
+
+    if (!response.success) {
+      throw new Error((response as unknown as { error: string }).error || 'Upload failed')
+    }
+
+    return response.data
+  }
+
   return {
     // Articles
-    async getArticles(page = 1, perPage = 10): Promise<PaginatedArticles> {
-      return fetchApi<PaginatedArticles>(`/articles?page=${page}&per_page=${perPage}`)
+    async getArticles(page = 1, perPage = 10, sort?: ArticleSort): Promise<PaginatedArticles> {
+      const params = new URLSearchParams({
+        page: String(page),
+        per_page: String(perPage)
+      })
+      if (sort) params.set('sort', sort)
+      return fetchApi<PaginatedArticles>(`/articles?${params}`)
     },
 
-    async getArticleBySlug(slug: string): Promise<Article> {
-      return fetchApi<Article>(`/articles/${slug}`)
+    // Pass X-Visitor-ID so anonymous readers are metered on members-only articles
+    async getArticleBySlug(slug: string, headers?: Record<string, string>): Promise<Article> {
+      return fetchApi<Article>(`/articles/${slug}`, { headers })
+    },
+
+    // Sharing metadata for SSR meta tags; staff headers allow unpublished articles
+    async getArticleMeta(slug: string, authHeaders?: Record<string, string>): Promise<ArticleMeta> {
+      return fetchApi<ArticleMeta>(`/articles/${slug}/meta`, { headers: authHeaders })
     },
 
     async trackArticleView(slug: string): Promise<void> {
@@ -166,6 +245,39 @@ export function useApi() {
       return fetchApi<TagWithArticles>(`/tags/${slug}?page=${page}&per_page=${perPage}`)
     },
 
+    // Explainers
+    async getExplainer(slug: string): Promise<Explainer> {
+      return fetchApi<Explainer>(`/explainers/${slug}`)
+    },
+
+    async adminGetExplainers(authHeaders: Record<string, string>, search?: string): Promise<Explainer[]> {
+      const params = search ? `?search=${encodeURIComponent(search)}` : ''
+      return fetchApi<Explainer[]>(`/admin/explainers${params}`, { headers: authHeaders })
+    },
+
+    async adminCreateExplainer(data: CreateExplainerRequest, authHeaders: Record<string, string>): Promise<Explainer> {
+      return fetchApi<Explainer>('/admin/explainers', {
+        method: 'POST',
+        headers: authHeaders,
+        body: data
+      })
+    },
+
+    async adminUpdateExplainer(id: string, data: UpdateExplainerRequest, authHeaders: Record<string, string>): Promise<Explainer> {
+      return fetchApi<Explainer>(`/admin/explainers/${id}`, {
+        method: 'PUT',
+        headers: authHeaders,
+        body: data
+      })
+    },
+
+    async adminDeleteExplainer(id: string, authHeaders: Record<string, string>): Promise<void> {
+      await fetchApi<{ message: string }>(`/admin/explainers/${id}`, {
+        method: 'DELETE',
+        headers: authHeaders
+      })
+    },
+
     // Authors
     async getAuthors(): Promise<Author[]> {
       return fetchApi<Author[]>('/authors')
@@ -180,6 +292,18 @@ export function useApi() {
       return fetchApi<Politician[]>('/politicians')
     },
 
+    async getActivePoliticians(filter: ActivePoliticianFilter = {}, page = 1, perPage = 20): Promise<PaginatedPoliticians> {
+      const params = new URLSearchParams({
+        active: 'true',
+        page: String(page),
+        per_page: String(perPage)
+      })
+      for (const [key, value] of Object.entries(filter)) {
+        if (value) params.set(key, value)
+      }
+      return fetchApi<PaginatedPoliticians>(`/politicians?${params}`)
+    },
+
     async searchPoliticians(query: string, limit = 10): Promise<Politician[]> {
       return fetchApi<Politician[]>(`/politicians/search?q=${encodeURIComponent(query)}&limit=${limit}`)
     },
@@ -188,31 +312,66 @@ export function useApi() {
       return fetchApi<PoliticianWithArticles>(`/politicians/${slug}?page=${page}&per_page=${perPage}`)
     },
 
-    // Upload
-    async uploadFile(file: File, authHeaders: HeadersInit): Promise<UploadResult> {
-      const formData = new FormData()
-      formData.append('file', file)
-
-      // Extract only the Authorization header - don't set Content-Type for FormData
-      const headers: Record<string, string> = {}
-      if (authHeaders && typeof authHeaders === 'object') {
-        const authRecord = authHeaders as Record<string, string>
-        if (authRecord.Authorization) {
-          headers.Authorization = authRecord.Authorization
-        }
-      }
+    // Article gallery
+    async adminGetArticleMedia(articleId: string, authHeaders: Record<string, string>): Promise<ArticleMedia[]> {
+      return fetchApi<ArticleMedia[]>(`/admin/articles/${articleId}/media`, { headers: authHeaders })
+    },
 
-      const response = await $fetch<ApiResponse<UploadResult>>(`${baseUrl}/admin/upload`, {
+    async adminCreateArticleMedia(articleId: string, data: CreateArticleMediaRequest, authHeaders: Record<string, string>): Promise<ArticleMedia> {
+      return fetchApi<ArticleMedia>(`/admin/articles/${articleId}/media`, {
         method: 'POST',
-        headers,
-        body: formData
+        headers: authHeaders,
+        body: data
       })
+    },
 
-      if (!response.success) {
-        throw new Error((response as unknown as { error: string }).error || 'Upload failed')
-      }
+    async adminUpdateArticleMedia(articleId: string, mediaId: string, data: UpdateArticleMediaRequest, authHeaders: Record<string, string>): Promise<ArticleMedia> {
+      return fetchApi<ArticleMedia>(`/admin/articles/${articleId}/media/${mediaId}`, {
+        method: 'PUT',
+        headers: authHeaders,
+        body: data
+      })
+    },
+
+    // mediaIds must list every item of the gallery
+    async adminReorderArticleMedia(articleId: string, mediaIds: string[], authHeaders: Record<string, string>): Promise<ArticleMedia[]> {
+      return fetchApi<ArticleMedia[]>(`/admin/articles/${articleId}/media/order`, {
+        method: 'PUT',
+        headers: authHeaders,
+        body: { media_ids: mediaIds }
+      })
+    },
 
-      return response.data
+    async adminDeleteArticleMedia(articleId: string, mediaId: string, authHeaders: Record<string, string>): Promise<void> {
+      await fetchApi<{ message: string }>(`/admin/articles/${articleId}/media/${mediaId}`, {
+        method: 'DELETE',
+        headers: authHeaders
+      })
+    },
+
+    async adminBackfillFeaturedImages(authHeaders: Record<string, string>): Promise<FeaturedImageBackfill> {
+      return fetchApi<FeaturedImageBackfill>('/admin/articles/backfill-featured-media', {
+        method: 'POST',
+        headers: authHeaders
+      })
+    },
+
+    // Upload
+    async uploadFile(file: File, authHeaders: HeadersInit): Promise<UploadResult> {
+      return postUpload<UploadResult>('/admin/upload', file, authHeaders)
+    },
+
+    // Fails with 409 MEDIA_IN_USE while the file is an article's featured image
+    async deleteUpload(url: string, authHeaders: Record<string, string>): Promise<void> {
+      await fetchApi<{ message: string }>(`/admin/upload?url=${encodeURIComponent(url)}`, {
+        method: 'DELETE',
+        headers: authHeaders
+      })
+    },
+
+    // Converts a JPEG or PNG featured image into WebP thumbnail and full-size renditions
+    async uploadArticleImage(file: File, authHeaders: HeadersInit): Promise<ArticleImages> {
+      return postUpload<ArticleImages>('/admin/upload/article-image', file, authHeaders)
     },
 
     // Comments
@@ -220,15 +379,15 @@ export function useApi() {
       slug: string,
       authHeaders?: Record<string, string>,
       page = 1,
-      pageSize = 10,
-      sort: 'recent' | 'liked' | 'oldest' = 'recent'
-    ): Promise<Comment[]> {
+      perPage = 10,
+      sort: CommentSort = 'newest'
+    ): Promise<PaginatedComments> {
       const params = new URLSearchParams({
         page: String(page),
-        page_size: String(pageSize),
+        per_page: String(perPage),
         sort
       })
-      return fetchApi<Comment[]>(`/articles/${slug}/comments?${params}`, { headers: authHeaders })
+      return fetchApi<PaginatedComments>(`/articles/${slug}/comments?${params}`, { headers: authHeaders })
     },
 
     async getCommentCount(slug: string): Promise<CommentCountResponse> {
@@ -281,6 +440,24 @@ export function useApi() {
       })
     },
 
+    // Comment moderation (admin); at most the 100 newest or most toxic
+    async adminGetComments(authHeaders: Record<string, string>, filter?: AdminCommentFilter): Promise<Comment[]> {
+      const params = new URLSearchParams()
+      if (filter?.status) params.set('status', filter.status)
+      if (filter?.min_toxicity !== undefined) params.set('min_toxicity', String(filter.min_toxicity))
+      if (filter?.unscored) params.set('unscored', 'true')
+      if (filter?.sort) params.set('sort', filter.sort)
+      return fetchApi<Comment[]>(`/admin/comments?${params}`, { headers: authHeaders })
+    },
+
+    async adminModerateComment(id: string, data: ModerateCommentRequest, authHeaders: Record<string, string>): Promise<Comment> {
+      return fetchApi<Comment>(`/admin/comments/${id}/moderate`, {
+        method: 'PUT',
+        headers: authHeaders,
+        body: data
+      })
+    },
+
     // Users (for mentions)
     async getMentionableUsers(): Promise<CommentAuthor[]> {
       return fetchApi<CommentAuthor[]>('/users/mentionable')
@@ -420,6 +597,15 @@ export function useApi() {
       })
     },
 
+    // Registers this app's Firebase Cloud Messaging token for push notifications
+    async registerDeviceToken(data: RegisterDeviceTokenRequest, authHeaders: Record<string, string>): Promise<DeviceToken> {
+      return fetchApi<DeviceToken>('/auth/device-token', {
+        method: 'POST',
+        headers: authHeaders,
+        body: data
+      })
+    },
+
     // =====================================================
     // LOCATIONS (Philippine Geographic Hierarchy)
     // =====================================================
@@ -464,6 +650,18 @@ export function useApi() {
       return fetchApi<PaginatedBarangays>(`/locations/barangays/by-city/${cityId}?page=${page}&per_page=${perPage}`)
     },
 
+    async searchBarangays(search: BarangaySearchParams, page = 1, perPage = 20): Promise<PaginatedBarangays> {
+      const params = new URLSearchParams({
+        page: String(page),
+        per_page: String(perPage)
+      })
+      if (search.q) params.set('q', search.q)
+      if (search.city_id) params.set('city_id', search.city_id)
+      if (search.min_population !== undefined) params.set('min_population', String(search.min_population))
+      if (search.max_population !== undefined) params.set('max_population', String(search.max_population))
+      return fetchApi<PaginatedBarangays>(`/locations/barangays/search?${params}`)
+    },
+
     // Congressional Districts
     async getDistrictBySlug(slug: string): Promise<CongressionalDistrict> {
       return fetchApi<CongressionalDistrict>(`/locations/districts/${slug}`)
@@ -482,6 +680,17 @@ export function useApi() {
       return fetchApi<LocationHierarchy>(`/locations/hierarchy/${barangayId}`)
     },
 
+    // null when the location can't be detected confidently
+    async detectLocation(): Promise<DetectedLocation | null> {
+      return fetchApi<DetectedLocation | null>('/locations/detect')
+    },
+
+    // depth: 1 regions, 2 provinces, 3 cities, 4 barangays (very large)
+    async getLocationTree(depth = 3): Promise<LocationTreeRegion[]> {
+      const includeBarangays = depth >= 4 ? '&include_barangays=true' : ''
+      return fetchApi<LocationTreeRegion[]>(`/locations/tree?depth=${depth}${includeBarangays}`)
+    },
+
     // =====================================================
     // POLITICAL PARTIES
     // =====================================================
@@ -528,6 +737,11 @@ export function useApi() {
       return fetchApi<PoliticianListItem[]>(`/my-representatives?barangay_id=${barangayId}`)
     },
 
+    // Without barangay officials, for when only the city is known
+    async findRepresentativesByCity(cityId: string): Promise<PoliticianListItem[]> {
+      return fetchApi<PoliticianListItem[]>(`/my-representatives?city_municipality_id=${cityId}`)
+    },
+
     // =====================================================
     // LEGISLATION / BILLS TRACKER
     // =====================================================
@@ -571,6 +785,11 @@ export function useApi() {
       return fetchApi<PaginatedBills>(`/legislation/bills?${params}`)
     },
 
+    // Up to 8 bills whose number starts with the query or whose title contains it
+    async autocompleteBills(query: string): Promise<BillSuggestion[]> {
+      return fetchApi<BillSuggestion[]>(`/legislation/bills/autocomplete?q=${encodeURIComponent(query)}`)
+    },
+
     async getBillBySlug(slug: string): Promise<Bill> {
       return fetchApi<Bill>(`/legislation/bills/${slug}`)
     },
@@ -583,6 +802,18 @@ export function useApi() {
       return fetchApi<BillVote[]>(`/legislation/bills/${billId}/votes`)
     },
 
+    async getBillDeliberations(slug: string): Promise<FloorDeliberation[]> {
+      return fetchApi<FloorDeliberation[]>(`/legislation/bills/${slug}/deliberations`)
+    },
+
+    async getBillDeliberation(slug: string, deliberationId: string): Promise<FloorDeliberation> {
+      return fetchApi<FloorDeliberation>(`/legislation/bills/${slug}/deliberations/${deliberationId}`)
+    },
+
+    async searchBillDeliberations(slug: string, q: string): Promise<DeliberationSearchResult[]> {
+      return fetchApi<DeliberationSearchResult[]>(`/legislation/bills/${slug}/deliberations/search?q=${encodeURIComponent(q)}`)
+    },
+
     async getPoliticianVotesForBillVote(voteId: string): Promise<PoliticianVote[]> {
       return fetchApi<PoliticianVote[]>(`/legislation/votes/${voteId}/politicians`)
     },
@@ -639,6 +870,98 @@ export function useApi() {
       return fetchApi<ElectionPositionListItem[]>(`/elections/${electionId}/positions`)
     },
 
+    // Positions not listed keep their order
+    async adminSetBallotOrders(electionId: string, orders: BallotPositionOrder[], authHeaders: Record<string, string>): Promise<ElectionPositionListItem[]> {
+      return fetchApi<ElectionPositionListItem[]>(`/admin/elections/${electionId}/positions/reorder`, {
+        method: 'PATCH',
+        headers: authHeaders,
+        body: { orders }
+      })
+    },
+
+    async getElectionResultsSummary(electionSlug: string): Promise<ElectionResultsSummary> {
+      return fetchApi<ElectionResultsSummary>(`/elections/${electionSlug}/results`)
+    },
+
+    async getElectionCoalitionResults(electionSlug: string): Promise<ElectionCoalitionResults> {
+      return fetchApi<ElectionCoalitionResults>(`/elections/${electionSlug}/results/by-coalition`)
+    },
+
+    // Defaults to the first position on the ballot with location results
+    async getElectionResultsMap(electionSlug: string, positionId?: string): Promise<ElectionResultsMap> {
+      const params = positionId ? `?position_id=${positionId}` : ''
+      return fetchApi<ElectionResultsMap>(`/elections/${electionSlug}/results/map${params}`)
+    },
+
+    async getElectionCityResults(electionSlug: string, citySlug: string): Promise<ElectionCityResults> {
+      return fetchApi<ElectionCityResults>(`/elections/${electionSlug}/results/locations/${citySlug}`)
+    },
+
+    // CSV columns: candidate_id, location (city slug or PSGC code), votes and optionally barangay
+    // With creditSubstitutes, votes for substituted candidates go to their substitutes
+    async adminImportLocationResults(
+      electionId: string,
+      file: File,
+      authHeaders: HeadersInit,
+      creditSubstitutes = false
+    ): Promise<LocationResultsImport> {
+      const params = creditSubstitutes ? '?credit_substitutes=true' : ''
+      return postUpload<LocationResultsImport>(`/admin/elections/${electionId}/results/locations/import${params}`, file, authHeaders)
+    },
+
+    async adminSubstituteCandidate(
+      candidateId: string,
+      data: SubstituteCandidateRequest,
+      authHeaders: Record<string, string>
+    ): Promise<Candidate> {
+      return fetchApi<Candidate>(`/admin/candidates/${candidateId}/substitute`, {
+        method: 'POST',
+        headers: authHeaders,
+        body: data
+      })
+    },
+
+    // Election surveys
+    async getElectionSurveys(electionSlug: string): Promise<ElectionSurvey[]> {
+      return fetchApi<ElectionSurvey[]>(`/elections/${electionSlug}/surveys`)
+    },
+
+    async getSurveyTrend(electionSlug: string, positionId: string): Promise<SurveyTrend> {
+      return fetchApi<SurveyTrend>(`/elections/${electionSlug}/positions/${positionId}/survey-trend`)
+    },
+
+    async adminCreateElectionSurvey(
+      electionId: string,
+      data: CreateElectionSurveyRequest,
+      authHeaders: Record<string, string>
+    ): Promise<ElectionSurvey> {
+      return fetchApi<ElectionSurvey>(`/admin/elections/${electionId}/surveys`, {
+        method: 'POST',
+        headers: authHeaders,
+        body: data
+      })
+    },
+
+    async adminUpdateElectionSurvey(
+      electionId: string,
+      surveyId: string,
+      data: UpdateElectionSurveyRequest,
+      authHeaders: Record<string, string>
+    ): Promise<ElectionSurvey> {
+      return fetchApi<ElectionSurvey>(`/admin/elections/${electionId}/surveys/${surveyId}`, {
+        method: 'PUT',
+        headers: authHeaders,
+        body: data
+      })
+    },
+
+    async adminDeleteElectionSurvey(electionId: string, surveyId: string, authHeaders: Record<string, string>): Promise<void> {
+      await fetchApi<{ message: string }>(`/admin/elections/${electionId}/surveys/${surveyId}`, {
+        method: 'DELETE',
+        headers: authHeaders
+      })
+    },
+
     // Candidates
     async getCandidates(
       filter?: { election_id?: string; position_id?: string; party_id?: string; status?: string; is_winner?: boolean },
@@ -665,6 +988,13 @@ export function useApi() {
       return fetchApi<CandidateListItem[]>(`/candidates/position/${positionId}`)
     },
 
+    // At most 5 candidates
+    async compareCandidates(positionId: string, candidateIds: string[]): Promise<CandidateComparison> {
+      return fetchApi<CandidateComparison>(
+        `/election-positions/${positionId}/compare?candidates=${candidateIds.join(',')}`
+      )
+    },
+
     // Voter Education
     async getVoterEducation(electionId?: string, category?: string, page = 1, perPage = 20): Promise<PaginatedVoterEducation> {
       const params = new URLSearchParams({
@@ -697,6 +1027,8 @@ export function useApi() {
       if (filter?.category) params.set('category', filter.category)
       if (filter?.politician_id) params.set('politician_id', filter.politician_id)
       if (filter?.election_id) params.set('election_id', filter.election_id)
+      if (filter?.bill_id) params.set('bill_id', filter.bill_id)
+      if (filter?.attachment) params.set('attachment', filter.attachment)
       if (filter?.search) params.set('search', filter.search)
       return fetchApi<PaginatedPolls>(`/polls?${params}`)
     },
@@ -717,11 +1049,19 @@ export function useApi() {
       return fetchApi<PollResults>(`/polls/${pollId}/results`)
     },
 
-    async castVote(pollId: string, optionId: string, authHeaders?: Record<string, string>): Promise<VoteResponse> {
+    async getPollResultsByRegion(slug: string): Promise<PollRegionResults[]> {
+      return fetchApi<PollRegionResults[]>(`/polls/${slug}/results/by-region`)
+    },
+
+    async getPollVotingTimeline(slug: string, bucket: PollTimelineBucket, authHeaders: Record<string, string>): Promise<PollVoteBucket[]> {
+      return fetchApi<PollVoteBucket[]>(`/polls/${slug}/results/timeline?bucket=${bucket}`, { headers: authHeaders })
+    },
+
+    async castVote(pollId: string, optionId: string, authHeaders?: Record<string, string>, regionId?: string): Promise<VoteResponse> {
       return fetchApi<VoteResponse>(`/polls/${pollId}/vote`, {
         method: 'POST',
         headers: authHeaders,
-        body: { option_id: optionId }
+        body: { option_id: optionId, region_id: regionId }
       })
     },
 
@@ -793,7 +1133,7 @@ export function useApi() {
     // Admin poll endpoints
     async adminGetPolls(
       authHeaders: Record<string, string>,
-      filter?: { category?: PollCategory; status?: PollStatus; search?: string; is_featured?: boolean },
+      filter?: { category?: PollCategory; status?: PollStatus; search?: string; is_featured?: boolean; include_deleted?: boolean },
       page = 1,
       perPage = 20
     ): Promise<PaginatedPolls> {
@@ -805,6 +1145,7 @@ export function useApi() {
       if (filter?.status) params.set('status', filter.status)
       if (filter?.search) params.set('search', filter.search)
       if (filter?.is_featured !== undefined) params.set('is_featured', String(filter.is_featured))
+      if (filter?.include_deleted) params.set('include_deleted', 'true')
       return fetchApi<PaginatedPolls>(`/admin/polls?${params}`, { headers: authHeaders })
     },
 
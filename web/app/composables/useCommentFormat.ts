@@ -0,0 +1,29 @@
+// Comments are stored as plain text with markdown-like formatting. They are
+// escaped here, when rendered, so the formatting below is the only HTML.
+
+export function escapeHtml(text: string): string {
+  return text
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;')
+    .replace(/'/g, '&#39;')
+}
+
+export function formatCommentContent(content: string): string {
+  return escapeHtml(content)
+      // Bold: **text** or __text__
+      .replace(/\*\*(.*?)\*\*/g, '<strong>$1</strong>')
+      .replace(/__(.*?)__/g, '<strong>$1</strong>')
+      // Italic: *text* or _text_
+      .replace(/\*([^*]+)\*/g, '<em>$1</em>')
+      .replace(/_([^_]+)_/g, '<em>$1</em>')
+      // Strikethrough: ~~text~~
+      .replace(/~~(.*?)~~/g, '<del>$1</del>')
+      // Blockquote: > text (escaped by now)
+      .replace(/^&gt;\s?(.*)$/gm, '<blockquote class="border-l-4 border-gray-300 dark:border-gray-600 pl-4 my-2 text-gray-600 dark:text-gray-400 italic">$1</blockquote>')
+      // @mentions - make them clickable links to user profiles
+      .replace(/@([a-zA-Z0-9_-]+)/g, '<a href="/user/$1" class="text-primary font-medium hover:underline">@$1</a>')
+      // Line breaks
+      .replace(/\n/g, '<br>')
+}
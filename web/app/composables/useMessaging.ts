@@ -1,6 +1,8 @@
 import type {
   ApiResponse,
+  AssignConversationRequest,
   Conversation,
+  ConversationQueueCounts,
   CreateConversationRequest,
   CreateMessageRequest,
   Message,
@@ -47,6 +49,7 @@ export function useMessaging() {
       method: options?.method || 'GET',
       headers: {
         'Content-Type': 'application/json',
+        'X-Legacy-Pagination': 'true',
         ...headers
       },
       body: options?.body
@@ -83,7 +86,14 @@ export function useMessaging() {
   }
 
   // Fetch admin conversations list (paginated)
-  async function fetchAdminConversations(page = 1, perPage = 20, status?: string) {
+  // Archived conversations are only returned when status is 'archived'.
+  // assignee is 'me', 'unassigned' or an admin ID.
+  async function fetchAdminConversations(
+    page = 1,
+    perPage = 20,
+    status?: string,
+    filters: { assignee?: string, unread?: boolean } = {}
+  ) {
     loading.value = true
     error.value = null
     try {
@@ -91,6 +101,12 @@ export function useMessaging() {
       if (status) {
         url += `&status=${status}`
       }
+      if (filters.assignee) {
+        url += `&assignee=${encodeURIComponent(filters.assignee)}`
+      }
+      if (filters.unread) {
+        url += '&unread=true'
+      }
       return await fetchApi<PaginatedConversations>(url)
     } catch (err) {
       error.value = err instanceof Error ? err.message : 'Failed to fetch conversations'
@@ -242,6 +258,77 @@ export function useMessaging() {
     }
   }
 
+  // Replace a conversation in local state with the server's copy
+  function replaceConversation(updated: Conversation) {
+    const index = conversations.value.findIndex(c => c.id === updated.id)
+    if (index !== -1) {
+      conversations.value[index] = { ...conversations.value[index], ...updated }
+    }
+    if (currentConversation.value?.id === updated.id) {
+      currentConversation.value = { ...currentConversation.value, ...updated }
+    }
+  }
+
+  // Assign a support conversation to an admin, or unassign it with null (admin only)
+  async function assignConversation(conversationId: string, data: AssignConversationRequest) {
+    error.value = null
+    try {
+      const updated = await fetchApi<Conversation>(
+        `/admin/messages/conversations/${conversationId}/assign`,
+        { method: 'PATCH', body: data }
+      )
+      replaceConversation(updated)
+      return updated
+    } catch (err) {
+      error.value = err instanceof Error ? err.message : 'Failed to assign conversation'
+      throw err
+    }
+  }
+
+  // Claim an unassigned support conversation for the current admin
+  async function claimConversation(conversationId: string) {
+    error.value = null
+    try {
+      const updated = await fetchApi<Conversation>(
+        `/admin/messages/conversations/${conversationId}/claim`,
+        { method: 'POST' }
+      )
+      replaceConversation(updated)
+      return updated
+    } catch (err) {
+      error.value = err instanceof Error ? err.message : 'Failed to claim conversation'
+      throw err
+    }
+  }
+
+  // Archive or unarchive a conversation (admin only). Unarchiving reopens it.
+  async function setConversationArchived(conversationId: string, archived: boolean) {
+    error.value = null
+    try {
+      await fetchApi<{ success: boolean }>(
+        `/admin/messages/conversations/${conversationId}/archive`,
+        { method: archived ? 'POST' : 'DELETE' }
+      )
+
+      const status = archived ? 'archived' : 'open'
+      const conversation = conversations.value.find(c => c.id === conversationId)
+      if (conversation) {
+        conversation.status = status
+      }
+      if (currentConversation.value?.id === conversationId) {
+        currentConversation.value.status = status
+      }
+    } catch (err) {
+      error.value = err instanceof Error ? err.message : 'Failed to update conversation'
+      throw err
+    }
+  }
+
+  // Queue sizes for the admin inbox sidebar
+  async function fetchQueueCounts() {
+    return await fetchApi<ConversationQueueCounts>('/admin/messages/queues')
+  }
+
   // Handle typing indicator
   function startTyping(conversationId: string) {
     ws.sendTyping(conversationId)
@@ -319,6 +406,10 @@ export function useMessaging() {
     sendMessage,
     markAsRead,
     updateConversationStatus,
+    assignConversation,
+    claimConversation,
+    setConversationArchived,
+    fetchQueueCounts,
     startTyping
   }
 }
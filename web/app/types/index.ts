@@ -1,5 +1,6 @@
 // Article types
-export type ArticleStatus = 'draft' | 'published' | 'archived'
+export type ArticleStatus = 'draft' | 'scheduled' | 'published' | 'archived'
+export type ArticleSort = 'newest' | 'oldest' | 'most_viewed' | 'most_commented'
 
 // Permission types
 export interface Permission {
@@ -55,6 +56,10 @@ export interface UpdateRoleRequest {
   permission_ids?: string[]
 }
 
+export interface SetRolePermissionsRequest {
+  permission_ids: string[]
+}
+
 export interface SocialLinks {
   twitter?: string
   facebook?: string
@@ -77,6 +82,7 @@ export interface Author {
   social_links?: SocialLinks
   role_id?: string
   role: string
+  is_member: boolean
   created_at: string
   updated_at: string
   deleted_at?: string
@@ -87,8 +93,10 @@ export interface Category {
   name: string
   slug: string
   description?: string
+  is_internal: boolean // Hidden from the public site
   created_at: string
   updated_at: string
+  deleted_at?: string // Admin lists with include_deleted only
 }
 
 export interface Tag {
@@ -97,6 +105,59 @@ export interface Tag {
   slug: string
   created_at: string
   updated_at: string
+  deleted_at?: string // Admin lists with include_deleted only
+}
+
+// Body of POST /admin/tags/{id}/assign and /unassign: either article IDs or a
+// filter with the admin article list's semantics
+export interface BulkTagRequest {
+  article_ids?: string[]
+  filter?: {
+    status?: ArticleStatus
+    category_id?: string
+    tag_id?: string
+    author_id?: string
+    politician_id?: string
+    search?: string
+    older_than_days?: number
+    published_after?: string // YYYY-MM-DD
+    published_before?: string // YYYY-MM-DD
+  }
+}
+
+// Returned with ?preview=true instead of changing anything
+export interface BulkTagPreview {
+  count: number
+  articles: { id: string; slug: string; title: string }[] // The first 20
+}
+
+export interface BulkTagResult {
+  affected: number
+}
+
+// Body of POST /admin/tags/{id}/merge. The merged tag's slug keeps resolving
+// to the target, with an X-Canonical-Slug header naming the target's slug.
+export interface MergeTagRequest {
+  into_tag_id: string
+}
+
+export interface TagMergeResult {
+  tag: Tag
+  articles_moved: number
+}
+
+// Focal point of a photo or logo, as percentages from the top-left corner
+export interface FocalPoint {
+  x: number
+  y: number
+}
+
+// Response of the admin photo/logo upload and remove endpoints
+export interface EntityImage {
+  original: string
+  thumb: string
+  list: string
+  focal_point?: FocalPoint
 }
 
 // Politician types
@@ -104,7 +165,11 @@ export interface Politician {
   id: string
   name: string
   slug: string
+  nickname?: string
   photo?: string
+  photo_thumb?: string
+  photo_list?: string
+  photo_focal_point?: FocalPoint
   position?: string
   party?: string
   short_bio?: string
@@ -118,7 +183,33 @@ export interface Politician {
   deleted_at?: string
   article_count?: number
   party_info?: PartyBrief
+  coalition?: PartyBrief
   position_info?: GovernmentPositionInfo
+  committee_memberships?: PoliticianCommitteeMembership[]
+  social_accounts?: PoliticianSocialLink[]
+}
+
+export type SocialPlatform = 'facebook' | 'twitter' | 'instagram' | 'youtube' | 'tiktok' | 'website'
+
+export interface PoliticianSocialLink {
+  id: string
+  politician_id: string
+  platform: SocialPlatform
+  url: string
+  is_verified: boolean
+  created_at: string
+}
+
+export interface CreatePoliticianSocialLinkRequest {
+  platform: SocialPlatform
+  url: string
+  is_verified?: boolean
+}
+
+export interface UpdatePoliticianSocialLinkRequest {
+  platform?: SocialPlatform
+  url?: string
+  is_verified?: boolean
 }
 
 export interface PoliticianListItem {
@@ -130,13 +221,24 @@ export interface PoliticianListItem {
   party?: string
   level?: string
   branch?: string
+  location?: string // Most specific place the politician represents
+  term_start?: string
+  term_end?: string
   article_count: number
   party_info?: PartyBrief
 }
 
+export interface ActivePoliticianFilter {
+  position_id?: string
+  level?: string
+  party_id?: string
+  party?: string
+}
+
 export interface CreatePoliticianRequest {
   name: string
   slug: string
+  nickname?: string
   photo?: string
   position?: string
   party?: string
@@ -151,6 +253,7 @@ export interface CreatePoliticianRequest {
 export interface UpdatePoliticianRequest {
   name?: string
   slug?: string
+  nickname?: string // An empty string clears it
   photo?: string
   position?: string
   party?: string
@@ -162,6 +265,19 @@ export interface UpdatePoliticianRequest {
   district_id?: string
 }
 
+// One field changed by an edit to a politician (admin only). Dates are
+// YYYY-MM-DD; a missing value means the field was empty.
+export interface PoliticianHistoryEntry {
+  id: string
+  politician_id: string
+  field_name: 'name' | 'slug' | 'nickname' | 'photo' | 'position' | 'party' | 'short_bio' | 'term_start' | 'term_end'
+  old_value?: string
+  new_value?: string
+  changed_by?: string
+  changed_by_name?: string
+  changed_at: string
+}
+
 export interface PaginatedPoliticians {
   politicians: PoliticianListItem[]
   total: number
@@ -175,6 +291,45 @@ export interface PoliticianWithArticles {
   articles: PaginatedArticles
 }
 
+// Advisory SEO analysis of an article (GET /admin/articles/{id}/seo-score)
+export interface SEOCheck {
+  name: string
+  passed: boolean
+  value?: number
+  recommended?: string
+}
+
+export interface SEOReport {
+  score: number
+  checks: SEOCheck[]
+}
+
+// Most frequent words of an article (GET /admin/articles/{id}/keyword-density).
+// Densities are percentages of total_words.
+export interface KeywordFrequency {
+  word: string
+  count: number
+  density: number
+}
+
+export interface KeywordDensityReport {
+  total_words: number
+  keywords: KeywordFrequency[] // Top 20, stop words left out
+  // Set when the article has a focus keyword
+  focus_keyword?: string
+  focus_keyword_count?: number
+  focus_keyword_density?: number
+  recommended_density?: string // "1-3%"
+  warning?: string // Set when focus_keyword_density is out of range
+}
+
+// Centre-cropped renditions made by the article image upload: WebP, or JPEG
+// or PNG when that was smaller
+export interface ArticleImages {
+  thumb: string // 400x250
+  full: string // 1200x675
+}
+
 export interface Article {
   id: string
   slug: string
@@ -182,19 +337,191 @@ export interface Article {
   summary?: string
   content: string
   featured_image?: string
+  cover_image?: string // Shown on cards and in link previews; falls back to the featured image
+  images?: ArticleImages // Cropped renditions of the featured image
   author_id?: string
   category_id?: string
   primary_politician_id?: string
   status: ArticleStatus
+  access_level: ArticleAccessLevel
+  is_premium: boolean
+  premium_summary?: string // Shown instead of the content to readers without the premium role
+  focus_keyword?: string // What the article is meant to rank for in search
   view_count: number
   published_at?: string
   created_at: string
   updated_at: string
+  comments_enabled: boolean
+  comments_locked_at?: string
+  comments_premoderated: boolean
+  // Whether readers can comment right now
+  comment_state?: CommentState
+  reactions?: ArticleReactions
   author?: Author
   category?: Category
   tags?: Tag[]
   primary_politician?: Politician
   mentioned_politicians?: Politician[]
+  sources?: ArticleSource[] // Primary sources first
+  // Image gallery in display order, and the featured item out of it
+  gallery?: ArticleMedia[]
+  featured_media?: ArticleMedia
+  // Explainers embedded in the content by <!--explainer:slug--> tokens, in order
+  embedded_explainers?: EmbeddedExplainer[]
+  // Other articles publishing in the same window, returned by create and update
+  schedule_conflicts?: ScheduledArticle[]
+  // Returned by create when an investigation or fact-check cites no source
+  source_warning?: string
+  // Average sentiment of the article's comments, on the admin article page
+  avg_comment_sentiment?: number
+  // Set on members-only articles; restricted content is a preview
+  access?: ArticleAccess
+  free_articles_remaining?: number
+  // Set on premium articles for readers without the premium role; content is the premium summary
+  content_locked?: boolean
+}
+
+export type ArticleAccessLevel = 'public' | 'members'
+
+export type ArticleAccess = 'full' | 'restricted'
+
+export type ArticleSourceType = 'press_release' | 'official_statement' | 'interview' | 'document' | 'other'
+
+export interface ArticleSource {
+  id: string
+  article_id: string
+  type: ArticleSourceType
+  title: string
+  url?: string
+  accessed_at?: string // YYYY-MM-DD
+  is_primary: boolean
+  created_at: string
+}
+
+// Open Graph and other sharing metadata for an article
+export interface ArticleMeta {
+  title: string
+  description: string
+  canonical_url: string
+  published_time?: string
+  modified_time: string
+  author?: string // Primary author
+  authors: string[]
+  section?: string
+  tags: string[]
+  image?: ArticleMetaImage
+}
+
+// Width and height are only known for pipeline renditions
+export interface ArticleMetaImage {
+  url: string
+  width?: number
+  height?: number
+  alt?: string
+}
+
+// An image in an article's gallery, with the caption and credit that must be
+// shown alongside it. Provisional items were picked by the featured image
+// backfill and await review.
+export interface ArticleMedia {
+  id: string
+  article_id: string
+  url: string
+  images?: ArticleImages
+  caption?: string
+  credit?: string
+  position: number
+  is_featured: boolean
+  is_provisional: boolean
+  created_at: string
+  updated_at: string
+}
+
+export interface CreateArticleMediaRequest {
+  url: string
+  images?: ArticleImages
+  caption?: string
+  credit?: string
+  is_featured?: boolean
+}
+
+// A blank caption or credit clears it
+export interface UpdateArticleMediaRequest {
+  caption?: string
+  credit?: string
+  is_featured?: boolean
+}
+
+export interface FeaturedImageBackfill {
+  articles_scanned: number
+  from_featured_image: number
+  from_content: number
+  without_image: number
+}
+
+// A reusable block of explanatory content that articles embed by slug
+export interface Explainer {
+  id: string
+  title: string
+  slug: string
+  body: string
+  created_at: string
+  updated_at: string
+  article_count?: number // Admin list only
+}
+
+export interface EmbeddedExplainer {
+  slug: string
+  title: string
+  body: string
+  updated_at: string
+}
+
+export interface CreateExplainerRequest {
+  title: string
+  slug: string
+  body: string
+}
+
+export interface UpdateExplainerRequest {
+  title?: string
+  slug?: string
+  body?: string
+}
+
+export type CommentState = 'open' | 'premoderated' | 'locked' | 'disabled'
+
+export interface BulkCommentSettingsRequest {
+  category_id: string
+  older_than_days?: number
+  comments_enabled?: boolean
+  comments_locked_at?: string
+  comments_premoderated?: boolean
+}
+
+export interface ScheduledArticle {
+  id: string
+  slug: string
+  title: string
+  status: ArticleStatus
+  published_at: string
+  adjustment?: ArticleScheduleAdjustment
+}
+
+export interface ArticleScheduleAdjustment {
+  id: string
+  article_id: string
+  original_publish_at: string
+  adjusted_publish_at: string
+  reason: 'publish_cap'
+  created_at: string
+}
+
+export interface ArticleSchedule {
+  from: string
+  to: string
+  timezone: string
+  articles: ScheduledArticle[]
 }
 
 export interface ArticleListItem {
@@ -203,10 +530,14 @@ export interface ArticleListItem {
   title: string
   summary?: string
   featured_image?: string
+  cover_image?: string
   status: ArticleStatus
+  access_level: ArticleAccessLevel
+  is_premium: boolean
   view_count: number
   published_at?: string
   created_at: string
+  deleted_at?: string // Admin lists with include_deleted only
   author_name?: string
   author_slug?: string
   author_avatar?: string
@@ -214,6 +545,7 @@ export interface ArticleListItem {
   category_slug?: string
   primary_politician_name?: string
   primary_politician_slug?: string
+  citation_count: number
 }
 
 export interface PaginatedArticles {
@@ -224,6 +556,17 @@ export interface PaginatedArticles {
   total_pages: number
 }
 
+// POST /admin/articles/bulk-delete and bulk-restore, at most 100 IDs
+export interface BulkArticleRequest {
+  article_ids: string[]
+}
+
+export interface BulkArticleResult {
+  succeeded: number
+  failed: number
+  results: { id: string, success: boolean, error?: string }[]
+}
+
 export interface CategoryWithArticles {
   category: Category
   articles: PaginatedArticles
@@ -276,18 +619,31 @@ export interface LoginResponse {
   permissions: string[]
 }
 
+// A short-lived session as another user, started by an admin
+export interface ImpersonationResponse extends LoginResponse {
+  expires_at: string
+  impersonated_by: string
+}
+
 export interface CreateArticleRequest {
   slug: string
   title: string
   summary?: string
   content: string
   featured_image?: string
+  cover_image?: string // A URL or the object key of an upload
+  images?: ArticleImages
   author_id?: string
   category_id?: string
   primary_politician_id?: string
   status?: ArticleStatus
+  published_at?: string // RFC 3339, or local time in the site timezone
   tag_ids?: string[]
   politician_ids?: string[]
+  access_level?: ArticleAccessLevel
+  is_premium?: boolean
+  premium_summary?: string
+  focus_keyword?: string
 }
 
 export interface UpdateArticleRequest {
@@ -296,12 +652,20 @@ export interface UpdateArticleRequest {
   summary?: string
   content?: string
   featured_image?: string
+  cover_image?: string // Empty clears it
+  images?: ArticleImages
   author_id?: string
   category_id?: string
   primary_politician_id?: string
   status?: ArticleStatus
+  published_at?: string // RFC 3339, or local time in the site timezone
   tag_ids?: string[]
   politician_ids?: string[]
+  access_level?: ArticleAccessLevel
+  is_premium?: boolean
+  premium_summary?: string // Empty clears it
+  focus_keyword?: string // Empty clears it
+  confirm?: boolean // Required to move a published article into an internal category
 }
 
 export interface PaginatedCategories {
@@ -316,12 +680,28 @@ export interface CreateCategoryRequest {
   name: string
   slug: string
   description?: string
+  is_internal?: boolean
 }
 
 export interface UpdateCategoryRequest {
   name?: string
   slug?: string
   description?: string
+  is_internal?: boolean
+}
+
+export interface MigrateArticlesRequest {
+  target_category_id: string
+  filter?: {
+    tag_ids?: string[]
+  }
+  delete_source?: boolean
+}
+
+export interface MigrateArticlesResult {
+  migrated: number
+  remaining: number
+  source_deleted: boolean
 }
 
 export interface PaginatedTags {
@@ -401,6 +781,15 @@ export interface ReactionSummary {
   has_reacted: boolean
 }
 
+export interface ArticleReactions {
+  total: number
+  counts: ReactionSummary[]
+  user_reaction?: string
+}
+
+// Order of an article's comments; top puts the most reacted-to first
+export type CommentSort = 'newest' | 'oldest' | 'top'
+
 export interface Comment {
   id: string
   article_id: string
@@ -415,6 +804,10 @@ export interface Comment {
   moderated_by?: string
   moderated_at?: string
   moderation_reason?: string
+  sentiment_score?: number // -1 (negative) to 1 (positive), admin views only
+  // Likelihood of abuse from 0 to 1, admin views only; missing when unscored
+  toxicity_score?: number
+  toxicity_provider?: string // 'wordlist' or 'webhook'
   // Relations
   author?: CommentAuthor
   reactions?: ReactionSummary[]
@@ -424,6 +817,12 @@ export interface Comment {
   article_title?: string
 }
 
+export interface CommentSentiment {
+  article_id: string
+  average_score?: number
+  scored_comments: number
+}
+
 export interface CreateCommentRequest {
   content: string
   parent_id?: string
@@ -437,6 +836,14 @@ export interface AddReactionRequest {
   reaction: string
 }
 
+// Filters for the admin comment moderation queue
+export interface AdminCommentFilter {
+  status?: CommentStatus
+  min_toxicity?: number
+  unscored?: boolean
+  sort?: 'newest' | 'toxicity'
+}
+
 export interface ModerateCommentRequest {
   status: CommentStatus
   reason?: string
@@ -479,6 +886,7 @@ export interface TopArticle {
   slug: string
   title: string
   view_count: number
+  reaction_count: number
   category_name?: string
 }
 
@@ -492,6 +900,22 @@ export interface DashboardMetrics {
   tag_metrics: TagMetric[]
 }
 
+// Rows are days from Sunday (0), columns hours 0-23, in the site timezone
+export interface HeatmapMatrix {
+  raw: number[][]
+  normalized: number[][] // 0-1 against the busiest cell
+  total: number
+}
+
+export interface ActivityHeatmap {
+  days: number
+  since: string
+  timezone: string
+  comments: HeatmapMatrix
+  poll_votes: HeatmapMatrix
+  article_views: HeatmapMatrix
+}
+
 // Messaging types
 export type ConversationStatus = 'open' | 'closed' | 'archived'
 
@@ -506,6 +930,15 @@ export interface Conversation {
   unread_count?: number
   created_at: string
   updated_at: string
+  assigned_to?: string
+  assigned_at?: string
+  assignee?: ConversationAssignee
+}
+
+export interface ConversationAssignee {
+  id: string
+  name: string
+  avatar?: string
 }
 
 export interface Message {
@@ -532,6 +965,29 @@ export interface UpdateConversationRequest {
   status: ConversationStatus
 }
 
+export interface AssignConversationRequest {
+  admin_id: string | null
+}
+
+export interface QueueCount {
+  total: number
+  unread: number
+}
+
+export interface AdminQueueCount extends QueueCount {
+  admin_id: string
+  name: string
+  avatar?: string
+}
+
+export interface ConversationQueueCounts {
+  mine: QueueCount
+  unassigned: QueueCount
+  all: QueueCount
+  archived: number
+  admins: AdminQueueCount[]
+}
+
 export interface PaginatedConversations {
   conversations: Conversation[]
   total: number
@@ -653,6 +1109,14 @@ export interface PoliticianComment {
   politician_name?: string
 }
 
+export interface PaginatedComments {
+  comments: Comment[]
+  total: number
+  page: number
+  per_page: number
+  total_pages: number
+}
+
 export interface PaginatedPoliticianComments {
   comments: PoliticianComment[]
   total: number
@@ -668,6 +1132,7 @@ export type NotificationType =
   | 'reply_article_comment'
   | 'reply_politician_comment'
   | 'comment_reaction'
+  | 'conversation_assigned'
 
 export interface NotificationActor {
   id: string
@@ -691,6 +1156,7 @@ export interface Notification {
   article_id?: string
   politician_id?: string
   comment_id?: string
+  conversation_id?: string
   is_read: boolean
   read_at?: string
   created_at: string
@@ -708,6 +1174,45 @@ export interface PaginatedNotifications {
   total_pages: number
 }
 
+export type DevicePlatform = 'ios' | 'android' | 'web'
+
+// A Firebase Cloud Messaging token push notifications are sent to
+export interface DeviceToken {
+  id: string
+  user_id: string
+  token: string
+  platform: DevicePlatform
+  created_at: string
+  last_seen_at: string
+}
+
+export interface RegisterDeviceTokenRequest {
+  token: string
+  platform: DevicePlatform
+}
+
+export type InboxItemType = 'mention' | 'reply' | 'message'
+
+export interface InboxItem {
+  type: InboxItemType
+  id: string
+  is_read: boolean
+  preview: string
+  comment_id?: string
+  conversation_id?: string
+  created_at: string
+  actor?: NotificationActor
+  article?: NotificationRef
+  politician?: NotificationRef
+}
+
+export interface InboxUnreadCounts {
+  mentions: number
+  replies: number
+  messages: number
+  total: number
+}
+
 // =====================================================
 // LOCATION TYPES (Philippine Geographic Hierarchy)
 // =====================================================
@@ -767,6 +1272,8 @@ export interface CityMunicipality {
   is_huc: boolean // Highly Urbanized City
   is_icc: boolean // Independent Component City
   population?: number
+  latitude?: number // Town centre
+  longitude?: number
   created_at: string
   updated_at: string
   deleted_at?: string
@@ -808,7 +1315,16 @@ export interface BarangayListItem {
   code: string
   name: string
   slug: string
+  population?: number
   city_municipality_name?: string
+  province_name?: string // Set by barangay search
+}
+
+export interface BarangaySearchParams {
+  q?: string
+  city_id?: string
+  min_population?: number
+  max_population?: number
 }
 
 export interface CongressionalDistrict {
@@ -844,6 +1360,49 @@ export interface LocationHierarchy {
   district?: DistrictListItem
 }
 
+// The visitor's likely city from their IP address, to preselect pickers
+export interface DetectedLocation {
+  region: RegionListItem
+  province: ProvinceListItem
+  city_municipality: CityMunicipalityListItem
+  distance_km: number
+}
+
+// Nested location tree for pickers; levels below the requested depth are absent
+export interface LocationTreeBarangay {
+  id: string
+  code: string
+  name: string
+  slug: string
+}
+
+export interface LocationTreeCity {
+  id: string
+  code: string
+  name: string
+  slug: string
+  is_city: boolean
+  is_capital: boolean
+  is_huc: boolean
+  barangays?: LocationTreeBarangay[]
+}
+
+export interface LocationTreeProvince {
+  id: string
+  code: string
+  name: string
+  slug: string
+  cities?: LocationTreeCity[]
+}
+
+export interface LocationTreeRegion {
+  id: string
+  code: string
+  name: string
+  slug: string
+  provinces?: LocationTreeProvince[]
+}
+
 // Location search result
 export interface LocationSearchResult {
   type: 'region' | 'province' | 'city' | 'barangay'
@@ -853,6 +1412,7 @@ export interface LocationSearchResult {
   slug: string
   parent_name?: string
   full_path: string
+  score: number
 }
 
 // Paginated responses
@@ -883,6 +1443,7 @@ export interface UpdateRegionRequest {
   code?: string
   name?: string
   slug?: string
+  expected_updated_at?: string
 }
 
 export interface CreateProvinceRequest {
@@ -897,6 +1458,7 @@ export interface UpdateProvinceRequest {
   code?: string
   name?: string
   slug?: string
+  expected_updated_at?: string
 }
 
 export interface CreateCityMunicipalityRequest {
@@ -921,6 +1483,7 @@ export interface UpdateCityMunicipalityRequest {
   is_huc?: boolean
   is_icc?: boolean
   population?: number
+  expected_updated_at?: string
 }
 
 export interface CreateBarangayRequest {
@@ -947,6 +1510,21 @@ export interface CreateDistrictRequest {
   slug: string
 }
 
+export interface UpdateDistrictRequest {
+  province_id?: string
+  city_municipality_id?: string
+  district_number?: number
+  name?: string
+  slug?: string
+  expected_updated_at?: string
+}
+
+// Body of a 409 when expected_updated_at no longer matches
+export interface ConcurrentUpdateConflict {
+  error: 'concurrent_update'
+  current_updated_at: string
+}
+
 // Response types for combined data
 export interface RegionWithProvinces {
   region: Region
@@ -977,6 +1555,9 @@ export interface PoliticalParty {
   slug: string
   abbreviation?: string
   logo?: string
+  logo_thumb?: string
+  logo_list?: string
+  logo_focal_point?: FocalPoint
   color?: string
   description?: string
   founded_year?: number
@@ -987,6 +1568,31 @@ export interface PoliticalParty {
   updated_at: string
   deleted_at?: string
   member_count?: number
+  coalitions?: PartyBrief[]
+  coalition_members?: PartyBrief[]
+  lineage?: PartyLineageStep[]
+}
+
+export type PartyRelationType = 'coalition_member_of' | 'merged_into' | 'renamed_from'
+
+export interface PartyRelation {
+  id: string
+  party_id: string
+  party: PartyBrief
+  related_party: PartyBrief
+  relation_type: PartyRelationType
+  start_date?: string
+  end_date?: string
+  notes?: string
+  created_at: string
+  updated_at: string
+}
+
+export interface PartyLineageStep {
+  relation_type: Exclude<PartyRelationType, 'coalition_member_of'>
+  from: PartyBrief
+  to: PartyBrief
+  date?: string
 }
 
 export interface PoliticalPartyListItem {
@@ -1170,6 +1776,27 @@ export interface Committee {
   deleted_at?: string
   chairperson?: PoliticianListItem
   vice_chairperson?: PoliticianListItem
+  members: CommitteeMember[]
+}
+
+export type CommitteeRole = 'chair' | 'vice_chair' | 'member'
+
+export interface CommitteeMember {
+  politician_id: string
+  politician_name: string
+  slug: string
+  photo?: string
+  role: CommitteeRole
+}
+
+export interface PoliticianCommitteeMembership {
+  committee_name: string
+  committee_slug: string
+  chamber: LegislativeChamber
+  role: CommitteeRole
+  start_date?: string
+  end_date?: string
+  is_current: boolean
 }
 
 export interface CommitteeListItem {
@@ -1198,6 +1825,8 @@ export interface Bill {
   last_action_date?: string
   date_signed?: string
   republic_act_number?: string
+  data_as_of: string // When status and votes were last brought up to date
+  is_stale: boolean // Show a stale-data banner
   created_at: string
   updated_at: string
   deleted_at?: string
@@ -1209,6 +1838,7 @@ export interface Bill {
   status_history?: BillStatusHistoryItem[]
   topics?: BillTopic[]
   votes?: BillVote[]
+  documents?: BillDocument[]
 }
 
 export interface BillListItem {
@@ -1223,6 +1853,36 @@ export interface BillListItem {
   last_action_date?: string
   author_count: number
   topic_names?: string[]
+  has_transcripts: boolean
+  data_as_of: string
+  is_stale: boolean
+}
+
+// Bill autocomplete result
+export interface BillSuggestion {
+  id: string
+  bill_number: string
+  title: string
+  slug: string
+  short_title?: string
+  status: BillStatus
+  chamber: LegislativeChamber
+}
+
+export type BillDocumentType = 'filed_copy' | 'committee_report' | 'enrolled_copy'
+
+// Bill Document (PDF copy; extraction_failed means no text could be read, e.g. a scan)
+export interface BillDocument {
+  id: string
+  bill_id: string
+  document_type: BillDocumentType
+  file_url: string
+  file_name: string
+  file_size: number
+  page_count: number
+  extraction_failed: boolean
+  uploaded_by?: string
+  created_at: string
 }
 
 // Bill Author
@@ -1273,6 +1933,30 @@ export interface BillVote {
   created_at: string
 }
 
+// Floor deliberation (transcript_text is only present when fetched individually)
+export interface FloorDeliberation {
+  id: string
+  bill_id: string
+  session_date: string
+  chamber: LegislativeChamber
+  transcript_text?: string
+  transcript_url?: string
+  has_transcript_text: boolean
+  is_third_reading: boolean
+  created_at: string
+  updated_at: string
+}
+
+// Transcript search match; snippet is escaped HTML with matches in <mark>
+export interface DeliberationSearchResult {
+  id: string
+  session_date: string
+  chamber: LegislativeChamber
+  is_third_reading: boolean
+  snippet: string
+  rank: number
+}
+
 // Individual Politician Vote
 export interface PoliticianVote {
   id: string
@@ -1290,9 +1974,15 @@ export interface BillTopic {
   slug: string
   description?: string
   created_at: string
+  parent_id?: string
+  level: number
   bill_count?: number
 }
 
+export interface BillTopicNode extends BillTopic {
+  children: BillTopicNode[]
+}
+
 // Politician Voting Record Summary
 export interface PoliticianVotingRecord {
   politician_id: string
@@ -1360,6 +2050,7 @@ export interface UpdateBillRequest {
   date_signed?: string // YYYY-MM-DD
   republic_act_number?: string
   topic_ids?: string[]
+  data_as_of?: string // RFC 3339; set after a bulk import session
 }
 
 export interface AddBillStatusRequest {
@@ -1404,6 +2095,7 @@ export type ElectionType = 'national' | 'local' | 'barangay' | 'special' | 'pleb
 
 // Election Status constants
 export type ElectionStatus = 'upcoming' | 'ongoing' | 'completed' | 'cancelled'
+export type BallotGroup = 'national' | 'regional' | 'provincial' | 'city' | 'barangay'
 
 // Candidate Status constants
 export type CandidateStatus = 'filed' | 'qualified' | 'disqualified' | 'withdrawn' | 'substituted'
@@ -1425,14 +2117,24 @@ export interface Election {
   voter_turnout_percentage?: number
   total_registered_voters?: number
   total_votes_cast?: number
+  data_as_of: string // When turnout and candidate tallies were last brought up to date
+  is_stale: boolean // Show a stale-data banner
   created_at: string
   updated_at: string
   deleted_at?: string
   // Joined fields
   positions?: ElectionPositionListItem[]
+  ballot_groups?: BallotSection[]
   candidates?: CandidateListItem[]
 }
 
+// A sample ballot section and its positions in ballot order
+export interface BallotSection {
+  group: BallotGroup
+  label: string
+  positions: ElectionPositionListItem[]
+}
+
 export interface ElectionListItem {
   id: string
   name: string
@@ -1458,6 +2160,8 @@ export interface ElectionPosition {
   district_id?: string
   seats_available: number
   description?: string
+  ballot_group: BallotGroup
+  ballot_order: number
   created_at: string
   // Joined fields
   position?: GovernmentPositionInfo
@@ -1465,10 +2169,18 @@ export interface ElectionPosition {
   candidates?: CandidateListItem[]
 }
 
+// Puts one election position at a place within its ballot group
+export interface BallotPositionOrder {
+  position_id: string
+  order: number
+}
+
 export interface ElectionPositionListItem {
   id: string
   position_id: string
   seats_available: number
+  ballot_group: BallotGroup
+  ballot_order: number
   position?: GovernmentPositionInfo
   location?: string
   candidate_count: number
@@ -1490,6 +2202,8 @@ export interface Candidate {
   is_winner: boolean
   votes_received?: number
   vote_percentage?: number
+  substituted_by?: string // The candidate who replaced this one
+  substitution_reason?: string // Why a substitute from another party was allowed
   created_at: string
   updated_at: string
   // Joined fields
@@ -1497,6 +2211,19 @@ export interface Candidate {
   party?: PartyBrief
 }
 
+// The substitute takes over the position and ballot number, and runs for the
+// same party unless override_reason is given
+export interface SubstituteCandidateRequest {
+  politician_id: string
+  party_id?: string
+  ballot_name?: string
+  campaign_slogan?: string
+  platform?: string
+  filing_date?: string // YYYY-MM-DD
+  is_incumbent: boolean
+  override_reason?: string
+}
+
 export interface CandidateListItem {
   id: string
   politician_id: string
@@ -1507,10 +2234,34 @@ export interface CandidateListItem {
   is_winner: boolean
   votes_received?: number
   vote_percentage?: number
+  withdrawn: boolean // Withdrawn or substituted, but still named on the ballot
+  substituted_by?: string
   politician?: PoliticianListItem
   party?: PartyBrief
 }
 
+// Candidates for one position side by side, in the order asked for
+export interface CandidateComparison {
+  position: ElectionPositionBrief
+  candidates: ComparedCandidate[]
+}
+
+// Every field is always present; voting_record is null without recorded bill votes
+export interface ComparedCandidate {
+  id: string
+  politician_id: string
+  ballot_number: number | null
+  ballot_name: string | null
+  status: CandidateStatus
+  is_incumbent: boolean
+  campaign_slogan: string | null
+  platform: string | null
+  issues: string[]
+  politician: PoliticianListItem
+  party: PartyBrief | null
+  voting_record: PoliticianVotingRecord | null
+}
+
 // Election Result
 export interface ElectionResult {
   id: string
@@ -1525,6 +2276,133 @@ export interface ElectionResult {
   created_at: string
 }
 
+// Election results summary: the winners of each position
+export type PositionResultStatus = 'pending' | 'decided' | 'tied'
+
+export interface ResultCandidate {
+  candidate_id: string
+  politician_id: string
+  name: string
+  slug: string
+  photo?: string
+  party?: PartyBrief
+  votes: number
+  percentage?: number
+}
+
+export interface PositionResultSummary {
+  election_position_id: string
+  position_name: string
+  location?: string
+  seats_available: number
+  status: PositionResultStatus
+  winners: ResultCandidate[]
+  runner_up?: ResultCandidate
+  margin_votes?: number
+  margin_percentage?: number
+}
+
+export interface ElectionResultsSummary {
+  election_id: string
+  name: string
+  slug: string
+  status: ElectionStatus
+  election_date: string
+  data_as_of: string
+  positions: PositionResultSummary[]
+}
+
+export interface PartyTally {
+  party: PartyBrief
+  candidates: number
+  winners: number
+}
+
+export interface CoalitionResult {
+  coalition: PartyBrief | null
+  candidates: number
+  winners: number
+  parties: PartyTally[]
+}
+
+export interface ElectionCoalitionResults {
+  election_id: string
+  name: string
+  slug: string
+  status: ElectionStatus
+  election_date: string
+  data_as_of: string
+  coalitions: CoalitionResult[]
+}
+
+// Election results by location, rolled up to provinces and regions.
+// pending is set while newly imported results await the next rollup.
+export interface ElectionPositionBrief {
+  id: string
+  name: string
+}
+
+export interface LocationLeader {
+  location_id: string
+  name: string
+  slug: string
+  total_votes: number
+  leader?: ResultCandidate
+  runner_up?: ResultCandidate
+  margin_votes?: number
+  margin_percentage?: number
+}
+
+export interface ElectionResultsMap {
+  election_id: string
+  name: string
+  slug: string
+  election_date: string
+  data_as_of?: string
+  pending: boolean
+  position?: ElectionPositionBrief
+  positions: ElectionPositionBrief[]
+  provinces: LocationLeader[]
+  regions: LocationLeader[]
+}
+
+export interface CityPositionResult {
+  election_position_id: string
+  position_name: string
+  total_votes: number
+  candidates: ResultCandidate[]
+}
+
+export interface ElectionCityResults {
+  election_id: string
+  name: string
+  slug: string
+  election_date: string
+  data_as_of?: string
+  pending: boolean
+  city: {
+    id: string
+    name: string
+    slug: string
+    province_name: string
+  }
+  positions: CityPositionResult[]
+}
+
+export interface LocationResultsImportError {
+  row: number
+  field: string
+  error: string
+  value?: string
+}
+
+// Nothing is stored when errors is set
+export interface LocationResultsImport {
+  total_rows: number
+  rows_imported: number
+  errors?: LocationResultsImportError[]
+}
+
 // Precinct Result
 export interface PrecinctResult {
   id: string
@@ -1577,6 +2455,99 @@ export interface ElectionCalendarItem {
   status: ElectionStatus
 }
 
+// Pre-election surveys by polling firms (SWS, Pulse Asia, ...)
+export interface SurveyResult {
+  id: string
+  survey_id: string
+  candidate_id: string
+  percentage: number
+  rank?: number
+  // Percentage minus/plus the survey's margin of error, within 0-100
+  low?: number
+  high?: number
+  candidate_name: string
+  politician_slug: string
+  party_name?: string
+  party_color?: string
+}
+
+export interface ElectionSurvey {
+  id: string
+  election_id: string
+  position_id: string
+  survey_firm: string
+  conducted_start: string
+  conducted_end: string
+  sample_size?: number
+  margin_of_error?: number
+  published_at?: string
+  source_url?: string
+  created_at: string
+  position_name?: string
+  results: SurveyResult[]
+}
+
+export interface SurveyResultInput {
+  candidate_id: string
+  percentage: number
+  rank?: number
+}
+
+export interface CreateElectionSurveyRequest {
+  position_id: string
+  survey_firm: string
+  conducted_start: string // YYYY-MM-DD
+  conducted_end: string // YYYY-MM-DD
+  sample_size?: number
+  margin_of_error?: number
+  published_at?: string // YYYY-MM-DD
+  source_url?: string
+  results: SurveyResultInput[]
+}
+
+// Results, when given, replace all of the survey's results
+export type UpdateElectionSurveyRequest = Partial<CreateElectionSurveyRequest>
+
+export interface SurveyTrendPoint {
+  survey_id: string
+  survey_firm: string
+  date: string // Last day of fieldwork
+  percentage: number
+  rank?: number
+  margin_of_error?: number
+  low?: number
+  high?: number
+}
+
+export interface CandidateSurveySeries {
+  candidate_id: string
+  candidate_name: string
+  politician_slug: string
+  party_name?: string
+  party_color?: string
+  points: SurveyTrendPoint[]
+}
+
+export interface SurveyTrend {
+  election_id: string
+  position_id: string
+  surveys: number
+  series: CandidateSurveySeries[]
+}
+
+// Issues a candidate's platform addresses
+export type CandidateIssue =
+  | 'healthcare' | 'education' | 'corruption' | 'environment' | 'economy'
+  | 'agriculture' | 'infrastructure' | 'peace-and-order' | 'social-welfare' | 'labor'
+
+export interface IssueMatrixEntry {
+  candidate_id: string
+  candidate_name: string
+  has_issue: boolean
+}
+
+export type IssuesMatrix = Record<CandidateIssue, IssueMatrixEntry[]>
+
 // Paginated types
 export interface PaginatedElections {
   elections: ElectionListItem[]
@@ -1631,6 +2602,7 @@ export interface UpdateElectionRequest {
   voter_turnout_percentage?: number
   total_registered_voters?: number
   total_votes_cast?: number
+  data_as_of?: string // RFC 3339; set after a bulk import session
 }
 
 export interface CreateElectionPositionRequest {
@@ -1816,6 +2788,7 @@ export interface Poll {
   approved_by?: string
   approved_at?: string
   rejection_reason?: string
+  closed_at?: string
   total_votes: number
   view_count: number
   comment_count: number
@@ -1845,6 +2818,7 @@ export interface PollListItem {
   created_at: string
   author?: PollAuthor
   option_count: number
+  deleted_at?: string // Admin lists with include_deleted only
 }
 
 // Poll Results
@@ -1854,6 +2828,27 @@ export interface PollResults {
   options: PollOption[]
 }
 
+export type PollTimelineBucket = 'hour' | 'day'
+
+export interface PollVoteBucket {
+  bucket: string
+  votes: number
+}
+
+export interface PollRegionOptions {
+  option_id: string
+  text: string
+  vote_count: number
+  percentage: number
+}
+
+export interface PollRegionResults {
+  region_id: string | null
+  region_name: string | null
+  total_votes: number
+  options: PollRegionOptions[]
+}
+
 // Vote Response
 export interface VoteResponse {
   success: boolean
@@ -1919,6 +2914,7 @@ export interface ApprovePollRequest {
 
 export interface CastVoteRequest {
   option_id: string
+  region_id?: string
 }
 
 export interface CreatePollCommentRequest {
@@ -1927,12 +2923,102 @@ export interface CreatePollCommentRequest {
 }
 
 // Filter type
+export type PollAttachmentType = 'politician' | 'election' | 'bill' | 'location' | 'none'
+
 export interface PollFilter {
   category?: PollCategory
   status?: PollStatus
   user_id?: string
   politician_id?: string
   election_id?: string
+  bill_id?: string
+  attachment?: PollAttachmentType
   is_featured?: boolean
   search?: string
 }
+
+// Webhook types
+export type WebhookEventType = 'article.published' | 'bill.status_changed' | 'election.results_updated'
+
+export type WebhookDeliveryStatus = 'pending' | 'delivered' | 'dead'
+
+export interface Webhook {
+  id: string
+  url: string
+  secret?: string // Only returned when the webhook is created
+  event_types: WebhookEventType[]
+  description?: string
+  is_active: boolean
+  created_at: string
+  updated_at: string
+}
+
+export interface WebhookDelivery {
+  id: string
+  webhook_id: string
+  event_id: string
+  event_type: WebhookEventType
+  status: WebhookDeliveryStatus
+  attempts: number
+  next_attempt_at: string
+  last_status_code?: number
+  last_error?: string
+  delivered_at?: string
+  created_at: string
+}
+
+export interface CreateWebhookRequest {
+  url: string
+  secret?: string // Generated when empty
+  event_types: WebhookEventType[]
+  description?: string
+  is_active?: boolean
+}
+
+export interface UpdateWebhookRequest {
+  url?: string
+  secret?: string
+  event_types?: WebhookEventType[]
+  description?: string
+  is_active?: boolean
+}
+
+export type FreshnessModule = 'bills' | 'elections'
+
+export interface ModuleFreshness {
+  module: FreshnessModule
+  active_count: number
+  stale_count: number
+  oldest_data_as_of?: string
+  oldest_id?: string
+  oldest_slug?: string
+  oldest_title?: string
+}
+
+export type EmbedType = 'poll' | 'election'
+
+export interface OEmbedResponse {
+  type: 'rich'
+  version: '1.0'
+  title: string
+  provider_name: string
+  provider_url: string
+  cache_age: number
+  html: string
+  width: number
+  height: number
+}
+
+export interface EmbedDomainViews {
+  domain: string
+  views: number
+}
+
+export interface EmbedReach {
+  type: EmbedType
+  content_id: string
+  slug: string
+  title: string
+  views: number
+  top_domains: EmbedDomainViews[]
+}